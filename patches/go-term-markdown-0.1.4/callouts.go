@@ -0,0 +1,109 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/gomarkdown/markdown/ast"
+)
+
+// CalloutStyle describes how a GitHub style callout (> [!NOTE]) is rendered.
+type CalloutStyle struct {
+	// Title is the default title, used when none follows the marker.
+	Title string
+	// Icon is written before the title.
+	Icon string
+	// Color formats the title and the gutter bar of the callout.
+	Color func(a ...interface{}) string
+}
+
+// calloutRegexp matches the marker opening a callout, like "[!WARNING]"
+var calloutRegexp = regexp.MustCompile(`^\[!([A-Za-z]+)\][ \t]*`)
+
+// DefaultCallouts returns the callouts supported by GitHub, keyed by their
+// upper case marker.
+func DefaultCallouts() map[string]CalloutStyle {
+	return map[string]CalloutStyle{
+		"NOTE":      {Title: "Note", Icon: "ℹ", Color: color.New(color.FgBlue).SprintFunc()},
+		"TIP":       {Title: "Tip", Icon: "✱", Color: color.New(color.FgGreen).SprintFunc()},
+		"IMPORTANT": {Title: "Important", Icon: "❖", Color: color.New(color.FgMagenta).SprintFunc()},
+		"WARNING":   {Title: "Warning", Icon: "⚠", Color: color.New(color.FgYellow).SprintFunc()},
+		"CAUTION":   {Title: "Caution", Icon: "✖", Color: color.New(color.FgRed).SprintFunc()},
+	}
+}
+
+// WithCallouts replaces the supported callouts, keyed by their upper case
+// marker. An empty map disables the callouts; the marker is then rendered as
+// regular text.
+func WithCallouts(callouts map[string]CalloutStyle) Options {
+	return func(r *renderer) {
+		r.callouts = callouts
+	}
+}
+
+// WithBlockquoteGutter sets the bar drawn on the left of blockquotes and its
+// colors, indexed by nesting level starting at 1.
+func WithBlockquoteGutter(bar string, colors []func(a ...interface{}) string) Options {
+	return func(r *renderer) {
+		if bar != "" {
+			r.blockQuoteBar = bar
+		}
+		if len(colors) > 0 {
+			shades := make([]shadeFmt, len(colors))
+			for i, c := range colors {
+				shades[i] = c
+			}
+			r.blockQuoteShade = shade(shades)
+		}
+	}
+}
+
+// startCallout checks if a paragraph directly inside a blockquote opens a
+// callout. If so, the marker is removed from the text, the title is rendered
+// and the gutter of the blockquote takes the color of the callout.
+// It returns true if nothing is left to render in the paragraph.
+func (r *renderer) startCallout(w io.Writer, node *ast.Paragraph) (empty bool) {
+	if _, ok := node.Parent.(*ast.BlockQuote); !ok || len(node.Children) == 0 {
+		return false
+	}
+	first, ok := node.Children[0].(*ast.Text)
+	if !ok {
+		return false
+	}
+	match := calloutRegexp.FindSubmatchIndex(first.Literal)
+	if match == nil {
+		return false
+	}
+	style, ok := r.callouts[strings.ToUpper(string(first.Literal[match[2]:match[3]]))]
+	if !ok {
+		// unknown marker: render as text, with the regular gutter as the
+		// parser merges consecutive blockquotes
+		r.popPad()
+		r.addPad(r.blockQuoteShade(r.blockQuoteLevel)(r.blockQuoteBar + " "))
+		return false
+	}
+
+	// a title can follow the marker on the same line
+	rest := first.Literal[match[1]:]
+	title := rest
+	if nl := bytes.IndexByte(rest, '\n'); nl >= 0 {
+		title, rest = rest[:nl], rest[nl+1:]
+	} else {
+		rest = nil
+	}
+	first.Literal = rest
+	header := style.Title
+	if t := strings.TrimSpace(string(title)); t != "" {
+		header = t
+	}
+
+	r.popPad()
+	r.addPad(style.Color(r.blockQuoteBar + " "))
+	_, _ = fmt.Fprintf(w, "%s%s\n", r.pad(), style.Color(boldOn+strings.TrimSpace(style.Icon+" "+header)))
+
+	return len(rest) == 0 && len(node.Children) == 1
+}
@@ -0,0 +1,163 @@
+package markdown
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+
+	md "github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/parser"
+)
+
+// CodeBlock is a code block of a document.
+type CodeBlock struct {
+	// Language is the language tag of the block or, when it has none, the
+	// one guessed by DetectLanguage. It can be empty.
+	Language string
+	// Detected is true if Language was guessed from the code.
+	Detected bool
+	// Filename is the file the block is meant for, when the fence names it
+	// (```go title=main.go or ```go:main.go) or the paragraph right before
+	// introduces it (like "In file main.go:"). It is a relative path as
+	// written in the document, or empty.
+	Filename string
+	// Caption is the plain text of the paragraph right before the block,
+	// which often says what to do with it ("Change line 12 of main.go to:").
+	Caption string
+	Code    string
+}
+
+// WithCodeBlockHook calls hook for every code block rendered, in document
+// order, with the output line (starting at 0) and the number of lines of the
+// block; typically for an interactive viewer to act on the block under the
+// cursor.
+func WithCodeBlockHook(hook func(block CodeBlock, line int, height int)) Options {
+	return func(r *renderer) {
+		r.codeBlockHook = hook
+	}
+}
+
+type renderedCodeBlock struct {
+	block        CodeBlock
+	line, height int
+}
+
+// newCodeBlock describes a block, guessing its language when untagged
+func newCodeBlock(node *ast.CodeBlock) CodeBlock {
+	b := CodeBlock{
+		Language: languageTag(node.Info),
+		Filename: fenceFilename(node.Info),
+		Caption:  captionText(node),
+		Code:     string(node.Literal),
+	}
+	if b.Filename == "" {
+		b.Filename = captionFilename(b.Caption)
+	}
+	if b.Language == "" {
+		b.Language = DetectLanguage(b.Code)
+		b.Detected = b.Language != ""
+	}
+	return b
+}
+
+// outputLine is the current line of the main output, or -1 for a temporary
+// buffer
+func (r *renderer) outputLine(w io.Writer) int {
+	out, ok := w.(*bytes.Buffer)
+	if !ok || out != r.out {
+		return -1
+	}
+	return bytes.Count(out.Bytes(), []byte("\n"))
+}
+
+// recordCodeBlock keeps track of a code block written on the main output
+func (r *renderer) recordCodeBlock(w io.Writer, node *ast.CodeBlock, start int) {
+	if r.codeBlockHook == nil || start < 0 {
+		return
+	}
+	// the block is followed by an empty line
+	height := r.outputLine(w) - start - 1
+	r.codeBlocks = append(r.codeBlocks, renderedCodeBlock{newCodeBlock(node), start, height})
+}
+
+// CodeBlocks lists the code blocks of a document, in order, including the
+// ones inside <details> blocks.
+func CodeBlocks(source string) []CodeBlock {
+	var blocks []CodeBlock
+	for _, seg := range splitDetails(source) {
+		if seg.kind != segmentMarkdown {
+			continue
+		}
+		p := parser.NewWithExtensions(Extensions())
+		doc := md.Parse([]byte(seg.text), p)
+		ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+			if block, ok := node.(*ast.CodeBlock); ok && entering {
+				blocks = append(blocks, newCodeBlock(block))
+			}
+			return ast.GoToNext
+		})
+	}
+	return blocks
+}
+
+// languageTag is the first word of the info string of a fence, without the
+// filename of the ```go:main.go form
+func languageTag(info []byte) string {
+	fields := strings.Fields(string(info))
+	if len(fields) == 0 {
+		return ""
+	}
+	lang, _, _ := strings.Cut(fields[0], ":")
+	return lang
+}
+
+var (
+	fenceAttrRegexp = regexp.MustCompile(`\b(?:title|file|filename|path)=(?:"([^"]+)"|'([^']+)'|(\S+))`)
+	captionRegexp   = regexp.MustCompile("(?i)^(?:(?:in|into|to|create|update|edit|save(?: it)?(?: as| to)?|add (?:this )?to)\\s+)?(?:(?:the|a|new)\\s+)?(?:file\\s*:?\\s*)?[`*\"']*([\\w.][\\w./-]*\\.[\\w]+|[\\w./-]*(?:Makefile|Dockerfile))[`*\"']*\\s*:$")
+)
+
+// fenceFilename reads the filename from the info string of a fence
+func fenceFilename(info []byte) string {
+	fields := strings.Fields(string(info))
+	if len(fields) == 0 {
+		return ""
+	}
+	if _, name, ok := strings.Cut(fields[0], ":"); ok && name != "" {
+		return name
+	}
+	if m := fenceAttrRegexp.FindStringSubmatch(string(info)); m != nil {
+		return m[1] + m[2] + m[3]
+	}
+	return ""
+}
+
+// captionText is the text of the paragraph right before the block
+func captionText(node *ast.CodeBlock) string {
+	prev := ast.GetPrevNode(node)
+	p, ok := prev.(*ast.Paragraph)
+	if !ok {
+		return ""
+	}
+	var caption strings.Builder
+	ast.WalkFunc(p, func(n ast.Node, entering bool) ast.WalkStatus {
+		if leaf := n.AsLeaf(); leaf != nil && entering {
+			caption.Write(leaf.Literal)
+		}
+		return ast.GoToNext
+	})
+	return strings.TrimSpace(caption.String())
+}
+
+// captionFilename reads the filename from a short paragraph introducing the
+// block, like "In file main.go:" or "`cmd/app/main.go`:"
+func captionFilename(caption string) string {
+	if strings.Contains(caption, "\n") {
+		return ""
+	}
+	if m := captionRegexp.FindStringSubmatch(caption); m != nil {
+		return m[1]
+	}
+	return ""
+}
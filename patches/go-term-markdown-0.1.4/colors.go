@@ -0,0 +1,33 @@
+package markdown
+
+import "github.com/fatih/color"
+
+var (
+	// we need a bunch of escape code for manual formatting
+	boldOn = "\x1b[1m"
+	// boldOff       = "\x1b[21m" --> use resetAll + snapshot with bold off instead
+	italicOn      = "\x1b[3m"
+	italicOff     = "\x1b[23m"
+	crossedOutOn  = "\x1b[9m"
+	crossedOutOff = "\x1b[29m"
+	greenOn       = "\x1b[32m"
+
+	resetAll = "\x1b[0m"
+	colorOff = "\x1b[39m"
+
+	Green        = sprintFunc(color.FgGreen)
+	HiGreen      = sprintFunc(color.FgHiGreen)
+	GreenBold    = sprintFunc(color.FgGreen, color.Bold)
+	Blue         = sprintFunc(color.FgBlue)
+	BlueBgItalic = sprintFunc(color.BgBlue, color.Italic)
+	Red          = sprintFunc(color.FgRed)
+)
+
+// sprintFunc is like color.New(attrs...).SprintFunc(), but creates the color
+// on each call: color.New checks NO_COLOR when it is called, so a package
+// level color would keep the environment seen at init time.
+func sprintFunc(attrs ...color.Attribute) func(a ...interface{}) string {
+	return func(a ...interface{}) string {
+		return color.New(attrs...).Sprint(a...)
+	}
+}
@@ -0,0 +1,230 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/go-term-text"
+	md "github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/parser"
+)
+
+// DetailsState lets the caller collapse <details> blocks and locate them in
+// the output, typically for an interactive viewer.
+type DetailsState struct {
+	// Collapsed reports if the block with the given index (in document
+	// order, starting at 0) is rendered as a single summary line. open is
+	// true if the block has the "open" attribute. When nil, every block is
+	// expanded.
+	Collapsed func(index int, open bool) bool
+	// Summary, when not nil, receives the output line (starting at 0) of the
+	// summary of each rendered block.
+	Summary func(index int, line int)
+}
+
+// WithDetails controls the rendering of <details> blocks.
+func WithDetails(state DetailsState) Options {
+	return func(r *renderer) {
+		r.details = state
+	}
+}
+
+var (
+	detailsOpenRegexp  = regexp.MustCompile(`(?is)^[ \t]*<details(\s[^>]*)?>\s*(?:<summary[^>]*>(.*?)</summary>)?`)
+	detailsCloseRegexp = regexp.MustCompile(`(?i)</details\s*>`)
+	detailsAttrOpen    = regexp.MustCompile(`(?i)\bopen\b`)
+	htmlTagRegexp      = regexp.MustCompile(`<[^>]*>`)
+)
+
+type segmentKind int
+
+const (
+	segmentMarkdown segmentKind = iota
+	segmentDetailsOpen
+	segmentDetailsClose
+)
+
+// segment is a part of the source: either regular markdown, or the boundary
+// of a <details> block.
+type segment struct {
+	kind    segmentKind
+	text    string // markdown, or the summary of a block
+	openTag bool   // the block has the "open" attribute
+}
+
+// splitDetails cuts the source at the <details> boundaries, outside of fenced
+// code blocks. The parser would otherwise see raw HTML spans mixed within
+// paragraphs. An opening tag must start a line, a closing tag can be anywhere
+// inside an open block.
+func splitDetails(source string) []segment {
+	if !strings.Contains(strings.ToLower(source), "<details") {
+		return []segment{{kind: segmentMarkdown, text: source}}
+	}
+
+	var segments []segment
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, segment{kind: segmentMarkdown, text: current.String()})
+			current.Reset()
+		}
+	}
+
+	depth := 0
+	fence := ""
+	rest := source
+	for rest != "" {
+		line := rest
+		if nl := strings.IndexByte(rest, '\n'); nl >= 0 {
+			line = rest[:nl+1]
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+		default:
+			// the opening tag and the summary can span several lines
+			if loc := detailsOpenRegexp.FindStringSubmatchIndex(rest); loc != nil {
+				flush()
+				attrs, summary := "", ""
+				if loc[2] >= 0 {
+					attrs = rest[loc[2]:loc[3]]
+				}
+				if loc[4] >= 0 {
+					summary = rest[loc[4]:loc[5]]
+				}
+				segments = append(segments, segment{
+					kind:    segmentDetailsOpen,
+					text:    cleanSummary(summary),
+					openTag: detailsAttrOpen.MatchString(attrs),
+				})
+				depth++
+				rest = rest[loc[1]:]
+				continue
+			}
+			if depth > 0 {
+				if loc := detailsCloseRegexp.FindStringIndex(line); loc != nil {
+					current.WriteString(line[:loc[0]])
+					flush()
+					segments = append(segments, segment{kind: segmentDetailsClose})
+					depth--
+					rest = rest[loc[1]:]
+					continue
+				}
+			}
+		}
+		current.WriteString(line)
+		rest = rest[len(line):]
+	}
+	flush()
+	return segments
+}
+
+// cleanSummary turns the HTML of a summary into a single line of text
+func cleanSummary(summary string) string {
+	summary = htmlTagRegexp.ReplaceAllString(summary, "")
+	summary = html.UnescapeString(summary)
+	return strings.Join(strings.Fields(summary), " ")
+}
+
+// renderSource renders a full document, with its <details> blocks
+func (r *renderer) renderSource(out *bytes.Buffer, source string) {
+	index := 0
+	// nesting of the open blocks, true when collapsed
+	var stack []bool
+	hidden := 0
+
+	for _, seg := range splitDetails(source) {
+		switch seg.kind {
+		case segmentMarkdown:
+			if hidden > 0 {
+				continue
+			}
+			p := parser.NewWithExtensions(Extensions())
+			doc := md.Parse([]byte(seg.text), p)
+			ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+				return r.RenderNode(out, node, entering)
+			})
+
+		case segmentDetailsOpen:
+			collapsed := r.details.Collapsed != nil && r.details.Collapsed(index, seg.openTag)
+			if hidden == 0 {
+				r.renderDetailsOpen(out, index, seg.text, collapsed)
+			}
+			if collapsed || hidden > 0 {
+				hidden++
+			}
+			stack = append(stack, collapsed || hidden > 0)
+			index++
+
+		case segmentDetailsClose:
+			if len(stack) == 0 {
+				continue
+			}
+			skipped := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if skipped {
+				hidden--
+				continue
+			}
+			r.renderDetailsClose(out)
+		}
+	}
+	// unclosed blocks
+	for i := len(stack) - 1; i >= 0; i-- {
+		if !stack[i] {
+			r.renderDetailsClose(out)
+		}
+	}
+}
+
+func (r *renderer) renderDetailsOpen(out *bytes.Buffer, index int, summary string, collapsed bool) {
+	ensureBlankLine(out)
+	if summary == "" {
+		summary = "Details"
+	}
+	marker := "▼"
+	if collapsed {
+		marker = "▶"
+	}
+	if r.details.Summary != nil {
+		r.summaries = append(r.summaries, [2]int{index, bytes.Count(out.Bytes(), []byte("\n"))})
+	}
+	content := GreenBold(marker + " " + summary)
+	wrapped, _ := text.WrapWithPad(content, r.lineWidth, r.pad())
+	_, _ = fmt.Fprintln(out, wrapped)
+	if collapsed {
+		_, _ = fmt.Fprintln(out)
+		return
+	}
+	r.addPad(Green("│ "))
+}
+
+func (r *renderer) renderDetailsClose(out *bytes.Buffer) {
+	r.popPad()
+	// the blank line ending the last block would break the gutter
+	trimmed := bytes.TrimRight(out.Bytes(), "\n")
+	out.Truncate(len(trimmed))
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintf(out, "%s%s\n\n", r.pad(), Green("└"+strings.Repeat("─", r.lineWidth-r.leftPad-1)))
+}
+
+// ensureBlankLine ends the output with an empty line, if it isn't empty
+func ensureBlankLine(out *bytes.Buffer) {
+	data := out.Bytes()
+	switch {
+	case len(data) == 0, bytes.HasSuffix(data, []byte("\n\n")):
+	case bytes.HasSuffix(data, []byte("\n")):
+		out.WriteString("\n")
+	default:
+		out.WriteString("\n\n")
+	}
+}
@@ -0,0 +1,174 @@
+package markdown
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+type languageHint struct {
+	weight  int
+	pattern *regexp.Regexp
+}
+
+type languageRule struct {
+	name  string // name of the chroma lexer
+	hints []languageHint
+}
+
+func hint(weight int, pattern string) languageHint {
+	return languageHint{weight, regexp.MustCompile(pattern)}
+}
+
+var shebangRegexp = regexp.MustCompile(`^#!\s*\S*?(?:/env\s+)?\b(python|bash|sh|zsh|node|ruby|perl|php)\d*(?:\.\d+)?\b`)
+
+var shebangLanguages = map[string]string{
+	"python": "python", "bash": "bash", "sh": "bash", "zsh": "bash",
+	"node": "javascript", "ruby": "ruby", "perl": "perl", "php": "php",
+}
+
+var javascriptHints = []languageHint{
+	hint(2, `^\s*(const|let|var) \w+ = `),
+	hint(2, `=> \{?`),
+	hint(2, `\bconsole\.log\(`),
+	hint(2, `\brequire\(['"]`),
+	hint(2, `^import .* from ['"]`),
+	hint(1, `^\s*function \w*\(`),
+	hint(1, `\b(document|window)\.\w+`),
+}
+
+// languageRules score the lines of a block; the rule with the highest total
+// wins, if it reaches minLanguageScore.
+var languageRules = []languageRule{
+	{"go", []languageHint{
+		hint(5, `^package \w+$`),
+		hint(3, `^func (\(\w+ \*?\w+\) )?\w+\(`),
+		hint(2, `\w+ := `),
+		hint(2, `\b(fmt|errors|strings|os)\.[A-Z]\w*\(`),
+		hint(2, `^import \($|^import "`),
+		hint(1, `\bif err != nil\b`),
+	}},
+	{"python", []languageHint{
+		hint(3, `^\s*def \w+\(.*\)( -> .+)?:$`),
+		hint(3, `^(from [\w.]+ )?import [\w.]+( as \w+)?$`),
+		hint(2, `^\s*class \w+(\(.*\))?:$`),
+		hint(2, `^\s*(elif .*|else|try|except\b.*|finally):$`),
+		hint(1, `\bprint\(`),
+		hint(1, `\bself\.\w+`),
+		hint(1, `^if __name__ == `),
+	}},
+	{"javascript", javascriptHints},
+	// TypeScript is scored as JavaScript plus its own hints, and wins only
+	// when one of them matches
+	{"typescript", append([]languageHint{
+		hint(3, `^(export )?(interface|type) \w+`),
+		hint(2, `\b(const|let) \w+: \w+`),
+		hint(2, `\(\w+: (string|number|boolean)\b`),
+	}, javascriptHints...)},
+	{"rust", []languageHint{
+		hint(3, `^\s*(pub )?fn \w+(<.*>)?\(`),
+		hint(3, `\blet mut \w+`),
+		hint(2, `\b(println|format|vec)!\(`),
+		hint(2, `^use (std|crate)::`),
+		hint(2, `^\s*impl\b`),
+	}},
+	{"java", []languageHint{
+		hint(3, `^\s*public (static )?(class|interface|void) `),
+		hint(3, `System\.out\.print`),
+		hint(1, `^import java\.`),
+	}},
+	{"c", []languageHint{
+		hint(3, `^#include <\w+\.h>`),
+		hint(2, `^int main\(`),
+		hint(1, `\bprintf\(`),
+	}},
+	{"cpp", []languageHint{
+		hint(3, `^#include <\w+>$`),
+		hint(3, `\bstd::\w+`),
+		hint(1, `\bcout\s*<<`),
+	}},
+	{"php", []languageHint{hint(5, `^<\?php`)}},
+	{"bash", []languageHint{
+		hint(2, `^\$ \S`),
+		hint(2, `^\s*(sudo|apt(-get)?|brew|yum|dnf|pacman|npm|pnpm|yarn|pip3?|go|cargo|git|docker|kubectl|make|curl|wget|cd|ls|mkdir|rm|cp|mv|chmod|export|echo|source) `),
+		hint(2, `^\s*(if \[|fi$|then$|do$|done$|esac$)`),
+		hint(1, `\$\{?\w+\}?`),
+		hint(1, ` (&&|\|\|) `),
+		hint(1, `\s-{1,2}[a-z][\w-]*`),
+	}},
+	{"sql", []languageHint{
+		hint(3, `(?i)^\s*select\b.+\bfrom\b`),
+		hint(3, `(?i)^\s*(insert into|update \w+ set|delete from|create (table|index|view)|alter table|drop table)\b`),
+		hint(1, `(?i)\b(where|join|group by|order by)\b`),
+	}},
+	{"docker", []languageHint{
+		hint(3, `^FROM \S+`),
+		hint(2, `^(RUN|COPY|ADD|CMD|ENTRYPOINT|WORKDIR|ENV|EXPOSE|ARG) `),
+	}},
+	{"html", []languageHint{
+		hint(5, `(?i)^<!doctype html>`),
+		hint(3, `(?i)^\s*<(html|head|body|div|span|p|a|ul|li|script|style)\b[^>]*>`),
+		hint(1, `</\w+>`),
+	}},
+	{"xml", []languageHint{
+		hint(5, `^<\?xml `),
+		hint(1, `^\s*<[\w:]+( [\w:]+="[^"]*")*\s*/?>`),
+	}},
+	{"css", []languageHint{
+		hint(2, `^[.#]?[\w-]+(\s*[,>]?\s*[.#]?[\w-]+)*\s*\{$`),
+		hint(2, `^\s*[a-z-]+:\s*[^;]+;$`),
+	}},
+	{"diff", []languageHint{
+		hint(3, `^(\+\+\+|---) \S`),
+		hint(3, `^@@ -\d+(,\d+)? \+\d+(,\d+)? @@`),
+	}},
+	{"toml", []languageHint{
+		hint(2, `^\[[\w.-]+\]$`),
+		hint(1, `^[\w-]+ = ("|\d|\[|true|false)`),
+	}},
+	{"yaml", []languageHint{
+		hint(1, `^\s*[\w-]+:( [^{};]+)?$`),
+		hint(1, `^\s*- [\w"'-]+`),
+		hint(2, `^---$`),
+	}},
+}
+
+// minLanguageScore avoids guessing on a couple of ambiguous lines
+const minLanguageScore = 3
+
+// DetectLanguage guesses the language of a code block without language tag,
+// from its shebang, JSON syntax, or keywords and idioms of the common
+// languages. It returns the name of a chroma lexer, or "" when unsure.
+func DetectLanguage(code string) string {
+	trimmed := strings.TrimSpace(code)
+	if trimmed == "" {
+		return ""
+	}
+	if m := shebangRegexp.FindStringSubmatch(trimmed); m != nil {
+		return shebangLanguages[m[1]]
+	}
+	if (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid([]byte(trimmed)) {
+		return "json"
+	}
+
+	lines := strings.Split(trimmed, "\n")
+	best, bestScore := "", 0
+	for _, rule := range languageRules {
+		score := 0
+		for _, line := range lines {
+			line = strings.TrimRight(line, " \t\r")
+			for _, h := range rule.hints {
+				if h.pattern.MatchString(line) {
+					score += h.weight
+				}
+			}
+		}
+		if score > bestScore {
+			best, bestScore = rule.name, score
+		}
+	}
+	if bestScore < minLanguageScore {
+		return ""
+	}
+	return best
+}
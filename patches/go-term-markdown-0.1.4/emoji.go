@@ -0,0 +1,96 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/kyokomi/emoji/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// shortcodeRegexp matches a single :shortcode:, like :rocket: or :+1:
+var shortcodeRegexp = regexp.MustCompile(`:[a-zA-Z0-9_+\-]+:`)
+
+var flagRegexp = regexp.MustCompile(`^:flag-([a-z]{2}):$`)
+
+const variationSelector16 = '\uFE0F'
+
+// WithEmoji enables or disables the :shortcode: to Unicode emoji conversion.
+// Disabling is useful on terminals whose font lacks emoji glyphs.
+// Default is enabled.
+func WithEmoji(enabled bool) Options {
+	return func(r *renderer) {
+		r.noEmoji = !enabled
+	}
+}
+
+// emojize replaces the known shortcodes in a text with their emoji.
+//
+// Unlike emoji.Sprint, no padding space is appended after the emoji, as the
+// measured width is made to match the displayed one (see fitEmojiWidth).
+func emojize(content string) string {
+	if !strings.Contains(content, ":") {
+		return content
+	}
+	codes := emoji.CodeMap()
+	return shortcodeRegexp.ReplaceAllStringFunc(content, func(code string) string {
+		if e, ok := codes[code]; ok {
+			return fitEmojiWidth(e)
+		}
+		if match := flagRegexp.FindStringSubmatch(code); match != nil {
+			return string('\U0001F1E6'+rune(match[1][0]-'a')) + string('\U0001F1E6'+rune(match[1][1]-'a'))
+		}
+		return code
+	})
+}
+
+// fitEmojiWidth makes the width the line wrapper measures agree with what the
+// terminal displays. A narrow symbol followed by VS16 (like ⚠️ or ❤️) is drawn
+// two columns wide by most terminals but counted as one column, which overflows
+// the line. Dropping the selector falls back to the one column text presentation.
+func fitEmojiWidth(e string) string {
+	runes := []rune(e)
+	if len(runes) == 2 && runes[1] == variationSelector16 && runewidth.RuneWidth(runes[0]) == 1 {
+		return string(runes[0])
+	}
+	return e
+}
+
+// protectLeadingShortcodes handles the shortcodes starting a line, outside of
+// fenced code blocks. The parser takes a paragraph followed by a line starting
+// with ':' as a definition list, so ":warning: careful" would turn the previous
+// paragraph into a definition term. Those shortcodes are converted before
+// parsing, or escaped when the emoji conversion is disabled.
+func protectLeadingShortcodes(source string, enabled bool) string {
+	if !strings.Contains(source, "\n:") && !strings.HasPrefix(source, ":") {
+		return source
+	}
+	lines := strings.SplitAfter(source, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		loc := shortcodeRegexp.FindStringIndex(line)
+		if loc == nil || loc[0] != 0 {
+			continue
+		}
+		code := line[:loc[1]]
+		if converted := emojize(code); converted != code {
+			if enabled {
+				lines[i] = converted + line[loc[1]:]
+			} else {
+				lines[i] = `\` + line
+			}
+		}
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,15 @@
+module github.com/MichaelMure/go-term-markdown
+
+go 1.12
+
+require (
+	github.com/MichaelMure/go-term-text v0.3.1
+	github.com/alecthomas/chroma v0.7.1
+	github.com/eliukblau/pixterm/pkg/ansimage v0.0.0-20191210081756-9fb6cf8c2f75
+	github.com/fatih/color v1.9.0
+	github.com/gomarkdown/markdown v0.0.0-20191123064959-2c17d62f5098
+	github.com/kyokomi/emoji/v2 v2.2.8
+	github.com/mattn/go-runewidth v0.0.12
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/net v0.0.0-20190813141303-74dc4d7220e7
+)
@@ -0,0 +1,98 @@
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MichaelMure/go-term-text"
+)
+
+// HeadingStyle describes how one level of heading is rendered.
+type HeadingStyle struct {
+	// Color formats the heading line (color, bold, underline ...).
+	// When nil, the heading shades are used.
+	Color func(a ...interface{}) string
+	// Prefix is written before the title, like "§" or "##".
+	Prefix string
+	// Numbering writes the section number (like "1.2") before the title.
+	Numbering bool
+	// Rule, when not empty, is repeated on the full width under the heading.
+	Rule string
+}
+
+var defaultHeadingStyles = []HeadingStyle{
+	{Numbering: true, Rule: "─"},
+	{Numbering: true},
+}
+
+// WithHeadingStyles sets the style of each heading level. The styles are
+// indexed by level, starting at 1; deeper levels use the last style.
+func WithHeadingStyles(styles []HeadingStyle) Options {
+	return func(r *renderer) {
+		if len(styles) > 0 {
+			r.headingStyles = styles
+		}
+	}
+}
+
+// WithNumberingStart continues the section numbering of a larger document,
+// when rendering an extract of it. start is the number of the first heading,
+// like []int{2, 1} for "2.1".
+func WithNumberingStart(start []int) Options {
+	return func(r *renderer) {
+		if len(start) == 0 || len(start) > 6 {
+			return
+		}
+		copy(r.headingNumbering.levels[:], start)
+		// the first heading increments its own level
+		r.headingNumbering.levels[len(start)-1]--
+	}
+}
+
+func (r *renderer) headingStyle(level int) HeadingStyle {
+	if level > len(r.headingStyles) {
+		level = len(r.headingStyles)
+	}
+	return r.headingStyles[level-1]
+}
+
+func (r *renderer) renderHeading(w io.Writer, level int) {
+	content := r.inlineAccumulator.String()
+	r.inlineAccumulator.Reset()
+
+	style := r.headingStyle(level)
+	r.headingNumbering.Observe(level)
+
+	// render the full line with the prefix and the numbering
+	parts := make([]string, 0, 3)
+	if style.Prefix != "" {
+		parts = append(parts, style.Prefix)
+	}
+	if style.Numbering {
+		parts = append(parts, r.headingNumbering.Render())
+	}
+	r.recordHeading(w, level, content)
+	content = strings.Join(append(parts, content), " ")
+	if style.Color != nil {
+		content = style.Color(content)
+	} else {
+		content = r.headingShade(level)(content)
+	}
+
+	// wrap if needed
+	wrapped, _ := text.WrapWithPad(content, r.lineWidth, r.pad())
+	_, _ = fmt.Fprintln(w, wrapped)
+
+	// render the underline, if any
+	if style.Rule != "" {
+		count := r.lineWidth - r.leftPad
+		if n := text.Len(style.Rule); n > 1 {
+			count /= n
+		}
+		rule := strings.Repeat(style.Rule, count)
+		_, _ = fmt.Fprintf(w, "%s%s\n", r.pad(), rule)
+	}
+
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,86 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/formatters"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/fatih/color"
+)
+
+// NoHighlight as the style of a language prints its code blocks without
+// syntax highlighting.
+const NoHighlight = "none"
+
+// Highlight selects the chroma styles used to color code blocks.
+type Highlight struct {
+	// Style is the name of the default style; empty keeps pygments.
+	Style string
+	// Languages maps a language to the name of its style, overriding Style.
+	// Keys are lower case and match the language tag of the fence, or the
+	// name or an alias of the chroma lexer used for the block.
+	Languages map[string]string
+	// Colors is the number of colors of the terminal (16, 256 or 1<<24),
+	// used to pick the formatter; below 256 the 8 color formatter is used.
+	Colors int
+}
+
+// WithHighlight sets the syntax highlighting styles of code blocks.
+func WithHighlight(h Highlight) Options {
+	return func(r *renderer) {
+		r.highlight = h
+	}
+}
+
+// HighlightStyles returns the sorted names of the available chroma styles.
+func HighlightStyles() []string {
+	return styles.Names()
+}
+
+// HasHighlightStyle tells if name is an available chroma style.
+func HasHighlightStyle(name string) bool {
+	_, ok := styles.Registry[strings.ToLower(name)]
+	return ok
+}
+
+// highlightStyle returns the style for a block with the given fence tag and
+// lexer, or nil when highlighting is disabled for its language.
+func (r *renderer) highlightStyle(tag string, lexer chroma.Lexer) *chroma.Style {
+	name := r.highlight.Style
+	if len(r.highlight.Languages) > 0 {
+		candidates := []string{strings.ToLower(tag)}
+		if config := lexer.Config(); config != nil {
+			candidates = append(candidates, strings.ToLower(config.Name))
+			for _, alias := range config.Aliases {
+				candidates = append(candidates, strings.ToLower(alias))
+			}
+		}
+		for _, c := range candidates {
+			if s, ok := r.highlight.Languages[c]; ok && c != "" {
+				name = s
+				break
+			}
+		}
+	}
+	switch {
+	case strings.EqualFold(name, NoHighlight):
+		return nil
+	case name == "":
+		return styles.Pygments
+	}
+	return styles.Get(strings.ToLower(name))
+}
+
+// highlightFormatter returns the formatter matching the colors of the terminal.
+func (r *renderer) highlightFormatter() chroma.Formatter {
+	switch {
+	case color.NoColor:
+		return formatters.Fallback
+	case r.highlight.Colors >= 1<<24:
+		return formatters.TTY16m
+	case r.highlight.Colors >= 256:
+		return formatters.TTY256
+	}
+	return formatters.TTY8
+}
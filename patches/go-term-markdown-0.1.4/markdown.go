@@ -0,0 +1,59 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/gomarkdown/markdown/parser"
+)
+
+// Extensions returns the bitmask of extensions supported by this renderer.
+// The output of this function can be used to instantiate a new markdown
+// parser using the `NewWithExtensions` function.
+func Extensions() parser.Extensions {
+	extensions := parser.NoIntraEmphasis        // Ignore emphasis markers inside words
+	extensions |= parser.Tables                 // Parse tables
+	extensions |= parser.FencedCode             // Parse fenced code blocks
+	extensions |= parser.Autolink               // Detect embedded URLs that are not explicitly marked
+	extensions |= parser.Strikethrough          // Strikethrough text using ~~test~~
+	extensions |= parser.SpaceHeadings          // Be strict about prefix heading rules
+	extensions |= parser.HeadingIDs             // specify heading IDs  with {#id}
+	extensions |= parser.BackslashLineBreak     // Translate trailing backslashes into line breaks
+	extensions |= parser.DefinitionLists        // Parse definition lists
+	extensions |= parser.LaxHTMLBlocks          // more in HTMLBlock, less in HTMLSpan
+	extensions |= parser.NoEmptyLineBeforeBlock // no need for new line before a list
+
+	return extensions
+}
+
+func Render(source string, lineWidth int, leftPad int, opts ...Options) []byte {
+	renderer := NewRenderer(lineWidth, leftPad, opts...)
+	source = protectLeadingShortcodes(source, !renderer.noEmoji)
+
+	var doc bytes.Buffer
+	renderer.out = &doc
+	renderer.renderSource(&doc, source)
+
+	var out bytes.Buffer
+	offset := 0
+	if renderer.toc {
+		offset = renderer.renderTOC(&out, renderer.headings)
+	}
+	if renderer.headingHook != nil {
+		for _, h := range renderer.headings {
+			h.Line += offset
+			renderer.headingHook(h)
+		}
+	}
+	if renderer.codeBlockHook != nil {
+		for _, b := range renderer.codeBlocks {
+			renderer.codeBlockHook(b.block, b.line+offset, b.height)
+		}
+	}
+	if renderer.details.Summary != nil {
+		for _, s := range renderer.summaries {
+			renderer.details.Summary(s[0], s[1]+offset)
+		}
+	}
+	out.Write(doc.Bytes())
+	return out.Bytes()
+}
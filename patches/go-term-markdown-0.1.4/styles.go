@@ -0,0 +1,33 @@
+package markdown
+
+// Styles are the formatting functions of inline elements and tables.
+// A nil field keeps the default look.
+type Styles struct {
+	Code        func(a ...interface{}) string // inline code
+	Link        func(a ...interface{}) string // destination of links
+	TableBorder func(a ...interface{}) string // borders of tables
+	TableHeader func(a ...interface{}) string // content of the header cells
+}
+
+// WithStyles sets the formatting of inline elements and tables.
+func WithStyles(styles Styles) Options {
+	return func(r *renderer) {
+		if styles.Code != nil {
+			r.codeStyle = styles.Code
+		}
+		if styles.Link != nil {
+			r.linkStyle = styles.Link
+		}
+		r.tableBorder = styles.TableBorder
+		r.tableHeader = styles.TableHeader
+	}
+}
+
+// WithTextFilter rewrites every piece of text of the document before it is
+// wrapped, for example to turn markers inserted in the source into colors.
+// Code, code blocks and link destinations are left untouched.
+func WithTextFilter(filter func(text string) string) Options {
+	return func(r *renderer) {
+		r.textFilter = filter
+	}
+}
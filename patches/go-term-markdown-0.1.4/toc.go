@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/go-term-text"
+)
+
+// Heading is a rendered heading, as reported to the heading hook.
+type Heading struct {
+	Level  int
+	Number string // section number, like "1.2", even if not displayed
+	Title  string // plain text of the heading
+	Line   int    // output line, starting at 0
+}
+
+// WithHeadingHook calls hook for every rendered heading, in document order.
+func WithHeadingHook(hook func(h Heading)) Options {
+	return func(r *renderer) {
+		r.headingHook = hook
+	}
+}
+
+// WithTOC prepends a table of contents listing the headings up to the given
+// level, with their section number.
+func WithTOC(title string, depth int) Options {
+	return func(r *renderer) {
+		r.toc = true
+		r.tocTitle = title
+		r.tocDepth = depth
+	}
+}
+
+var ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// recordHeading keeps track of a heading written on the main output
+func (r *renderer) recordHeading(w io.Writer, level int, content string) {
+	if r.headingHook == nil && !r.toc {
+		return
+	}
+	out, ok := w.(*bytes.Buffer)
+	if !ok || out != r.out {
+		// heading inside an HTML block, rendered in a temporary buffer
+		return
+	}
+	r.headings = append(r.headings, Heading{
+		Level:  level,
+		Number: r.headingNumbering.Render(),
+		Title:  strings.TrimSpace(ansiRegexp.ReplaceAllString(content, "")),
+		Line:   bytes.Count(out.Bytes(), []byte("\n")),
+	})
+}
+
+// renderTOC writes the table of contents and returns the number of lines written
+func (r *renderer) renderTOC(w *bytes.Buffer, headings []Heading) int {
+	before := bytes.Count(w.Bytes(), []byte("\n"))
+	var entries []Heading
+	for _, h := range headings {
+		if r.tocDepth <= 0 || h.Level <= r.tocDepth {
+			entries = append(entries, h)
+		}
+	}
+	if len(entries) == 0 {
+		return 0
+	}
+
+	// indent relatively to the shallowest level
+	top := entries[0].Level
+	for _, h := range entries {
+		if h.Level < top {
+			top = h.Level
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "%s%s\n", r.pad(), r.headingShade(1)(r.tocTitle))
+	for _, h := range entries {
+		indent := r.pad() + strings.Repeat("  ", h.Level-top)
+		line := Green(h.Number) + " " + h.Title
+		wrapped, _ := text.WrapWithPadIndent(line, r.lineWidth, indent, indent+strings.Repeat(" ", text.Len(h.Number)+1))
+		_, _ = fmt.Fprintln(w, wrapped)
+	}
+	_, _ = fmt.Fprintf(w, "%s%s\n\n", r.pad(), strings.Repeat("─", r.lineWidth-r.leftPad))
+	return bytes.Count(w.Bytes(), []byte("\n")) - before
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"wcp_agent/internal/provider"
+)
+
+type ctxKey struct{}
+
+// TestContinueTruncated 连续两次截断：对话里始终只有一条完整回答的 assistant 消息和一条续写请求，
+// 续写请求沿用调用方传入的 ctx
+func TestContinueTruncated(t *testing.T) {
+	parts := []string{"one ", "two ", "three"}
+	var seen [][]provider.Message
+	client := provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+		if ctx.Value(ctxKey{}) == nil {
+			t.Error("continue request did not use the chat context")
+		}
+		seen = append(seen, slices.Clone(messages))
+		part := parts[len(seen)]
+		if len(seen) < len(parts)-1 {
+			return part, provider.ErrTruncated
+		}
+		return part, nil
+	})
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	user := provider.Message{Role: "user", Content: "count"}
+	answer, messages, truncated, err := continueTruncated(ctx, client, []provider.Message{user}, -1, parts[0], provider.ErrTruncated, nil, func() bool { return true })
+	if err != nil || truncated {
+		t.Fatalf("err = %v, truncated = %v", err, truncated)
+	}
+	if answer != "one two three" {
+		t.Errorf("answer = %q", answer)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("%d continue requests, want 2", len(seen))
+	}
+	for i, want := range []string{"one ", "one two "} {
+		got := seen[i]
+		if len(got) != 3 || got[0].Content != user.Content || got[1].Role != "assistant" || got[1].Content != want || got[2].Content != continuePrompt {
+			t.Errorf("round %d sent %+v", i+1, got)
+		}
+	}
+	if len(messages) != 3 || messages[1].Content != "one two " {
+		t.Errorf("messages = %+v", messages)
+	}
+}
+
+// TestContinueTruncatedResume 续写中断的回答时沿用已有的 assistant 消息，不再重复发送前缀
+func TestContinueTruncatedResume(t *testing.T) {
+	var seen []provider.Message
+	client := provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+		seen = slices.Clone(messages)
+		return "end", nil
+	})
+	messages := []provider.Message{
+		{Role: "user", Content: "count"},
+		{Role: "assistant", Content: "prefix "},
+		{Role: "user", Content: continuePrompt},
+	}
+	answer, _, _, err := continueTruncated(context.Background(), client, messages, 1, "prefix more ", provider.ErrTruncated, nil, func() bool { return true })
+	if err != nil || answer != "prefix more end" {
+		t.Fatalf("answer = %q, err = %v", answer, err)
+	}
+	if len(seen) != 3 || seen[1].Content != "prefix more " {
+		t.Errorf("sent %+v", seen)
+	}
+	if messages[1].Content != "prefix " {
+		t.Errorf("caller's messages were modified: %+v", messages)
+	}
+}
+
+// TestContinueTruncatedDeclined 用户拒绝续写时保留已收到的部分，截断不算错误
+func TestContinueTruncatedDeclined(t *testing.T) {
+	client := provider.ClientFunc(func(context.Context, []provider.Message, func(string)) (string, error) {
+		t.Error("unexpected continue request")
+		return "", errors.New("unexpected")
+	})
+	answer, _, truncated, err := continueTruncated(context.Background(), client, nil, -1, "part", provider.ErrTruncated, nil, func() bool { return false })
+	if answer != "part" || !truncated || err != nil {
+		t.Errorf("answer = %q, truncated = %v, err = %v", answer, truncated, err)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"wcp_agent/internal/audit"
+	"wcp_agent/internal/i18n"
+)
+
+// runAudit agent audit show [-n N] [--json]
+func runAudit(args []string) error {
+	if len(args) == 0 || args[0] != "show" {
+		return errors.New(i18n.T(i18n.MsgAuditUsage))
+	}
+	fs := flag.NewFlagSet("audit show", flag.ContinueOnError)
+	limit := fs.Int("n", 20, "number of most recent entries to show (0 for all)")
+	asJSON := fs.Bool("json", false, "print raw JSON lines")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	entries, err := audit.Tail(*limit)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println(i18n.T(i18n.MsgAuditEmpty, audit.Path()))
+		return nil
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, e := range entries {
+		status := "-"
+		if e.ExitCode != nil {
+			status = fmt.Sprint(*e.ExitCode)
+		}
+		fmt.Printf("%s  %-9s %4s  [%s] %s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"), e.Decision, status, e.Source, e.Command)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/keypool"
+)
+
+// runAuth agent auth login|logout|status|keys
+func runAuth(args []string) error {
+	if len(args) == 0 {
+		return errors.New(i18n.T(i18n.MsgAuthUsage))
+	}
+	switch args[0] {
+	case "login":
+		return authLogin(args[1:])
+	case "logout":
+		return authLogout(args[1:])
+	case "status":
+		return authStatus()
+	case "keys":
+		return authKeys(args[1:])
+	default:
+		return errors.New(i18n.T(i18n.MsgAuthUsage))
+	}
+}
+
+// authLogin 读取 API Key 并写入系统钥匙串；--add 加为又一个 Key（多个 Key 轮换），--purge 同时清除配置文件里的明文 Key
+func authLogin(args []string) error {
+	fs := flag.NewFlagSet("auth login", flag.ContinueOnError)
+	purge := fs.Bool("purge", false, "clear the plaintext api_key in agent_config.json")
+	add := fs.Bool("add", false, "keep the stored keys and add this one for rotation (see key_rotation)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New(i18n.T(i18n.MsgAuthUsage))
+	}
+	name := fs.Arg(0)
+
+	// 会写回配置，不叠加项目的 .j.toml
+	cfg, err := loadAgentFile()
+	if err != nil {
+		return err
+	}
+	idx := cfg.FindProvider(name)
+	if idx < 0 {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgAuthUnknown, name))
+	} else {
+		name = cfg.Providers[idx].Name
+	}
+
+	key, err := readKey(name)
+	if err != nil {
+		return err
+	}
+	if *add {
+		slot, err := auth.Add(name, key)
+		if err != nil {
+			return err
+		}
+		fmt.Println(i18n.T(i18n.MsgAuthAdded, slot, name))
+	} else {
+		if err := auth.Store(name, key); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T(i18n.MsgAuthStored, name))
+	}
+
+	if idx < 0 || cfg.Providers[idx].APIKey == "" {
+		return nil
+	}
+	if !*purge {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgAuthPlaintext, name))
+		return nil
+	}
+	cfg.Providers[idx].APIKey = ""
+	if err := config.SaveAgent(cfg); err != nil {
+		return err
+	}
+	fmt.Println(i18n.T(i18n.MsgAuthPurged, name))
+	return nil
+}
+
+// readKey 终端下隐藏回显读取，否则从管道读取一行
+func readKey(name string) (string, error) {
+	var key string
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, i18n.T(i18n.MsgAuthPrompt, name))
+		raw, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		key = string(raw)
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", err
+		}
+		key = line
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", errors.New(i18n.T(i18n.MsgAuthEmptyKey))
+	}
+	return key, nil
+}
+
+// authLogout 从系统钥匙串删除该 provider 的全部 API Key
+func authLogout(args []string) error {
+	if len(args) != 1 {
+		return errors.New(i18n.T(i18n.MsgAuthUsage))
+	}
+	if err := auth.Delete(args[0]); err != nil {
+		return err
+	}
+	fmt.Println(i18n.T(i18n.MsgAuthRemoved, args[0]))
+	return nil
+}
+
+// authStatus 列出每个 provider 的 API Key 来源
+func authStatus() error {
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Providers) == 0 {
+		fmt.Println(i18n.T(i18n.MsgAuthNoProviders))
+		return nil
+	}
+	if p, _ := config.Profile(); p != config.DefaultProfile {
+		fmt.Println(i18n.T(i18n.MsgAuthProfile, p, auth.ServiceName()))
+	}
+	for _, p := range cfg.Providers {
+		keys, src := auth.Keys(p)
+		label := sourceLabel(p, src)
+		if len(keys) > 1 {
+			label += i18n.T(i18n.MsgAuthKeyCount, len(keys), rotationLabel(p))
+		}
+		fmt.Println(i18n.T(i18n.MsgAuthStatusRow, p.Name, label))
+	}
+	return nil
+}
+
+// authKeys agent auth keys [--json] [provider]：多个 API Key 的 provider 各 Key 的用量与冷却状态
+func authKeys(args []string) error {
+	fs := flag.NewFlagSet("auth keys", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the usage as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errors.New(i18n.T(i18n.MsgAuthUsage))
+	}
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	providers := cfg.Providers
+	if fs.NArg() == 1 {
+		p, err := selectProvider(cfg, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		providers = []config.Provider{p}
+	}
+	type keyUsage struct {
+		Provider string          `json:"provider"`
+		Rotation string          `json:"rotation"`
+		Keys     []keypool.Usage `json:"keys"`
+	}
+	var out []keyUsage
+	for _, p := range providers {
+		keys, _ := auth.Keys(p)
+		if len(keys) < 2 && fs.NArg() == 0 {
+			continue
+		}
+		usage, err := keypool.Stats(p.Name, keys)
+		if err != nil {
+			return err
+		}
+		out = append(out, keyUsage{p.Name, rotationLabel(p), usage})
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	if len(out) == 0 {
+		fmt.Println(i18n.T(i18n.MsgAuthKeysNone))
+		return nil
+	}
+	now := time.Now()
+	for n, ku := range out {
+		if n > 0 {
+			fmt.Println()
+		}
+		fmt.Println(i18n.T(i18n.MsgAuthKeysTitle, ku.Provider, len(ku.Keys), ku.Rotation))
+		rows := [][]string{strings.Split(i18n.T(i18n.MsgAuthKeysHeader), "|")}
+		for i, u := range ku.Keys {
+			last, state := "-", i18n.T(i18n.MsgAuthKeyReady)
+			if !u.LastUsed.IsZero() {
+				last = u.LastUsed.Local().Format("01-02 15:04")
+			}
+			if u.Cooling(now) {
+				state = i18n.T(i18n.MsgAuthKeyCooling, u.CooldownUntil.Local().Format("15:04:05"))
+			}
+			rows = append(rows, []string{strconv.Itoa(i + 1), u.Label, strconv.Itoa(u.Requests),
+				strconv.Itoa(u.InputTokens), strconv.Itoa(u.OutputTokens), strconv.Itoa(u.RateLimited),
+				strconv.Itoa(u.Rejected), last, state})
+		}
+		printTable(rows)
+	}
+	return nil
+}
+
+// rotationLabel provider 生效的 Key 轮换方式
+func rotationLabel(p config.Provider) string {
+	if p.KeyRotation == config.KeyRotationFailover {
+		return config.KeyRotationFailover
+	}
+	return config.KeyRotationRoundRobin
+}
+
+// sourceLabel Key 来源的展示文案
+func sourceLabel(p config.Provider, src auth.Source) string {
+	switch src {
+	case auth.SourceKeyring:
+		return i18n.T(i18n.MsgKeySrcKeyring)
+	case auth.SourceEnv:
+		return i18n.T(i18n.MsgKeySrcEnv, auth.EnvVar(p.Name))
+	case auth.SourceConfig:
+		return i18n.T(i18n.MsgKeySrcConfig)
+	default:
+		return i18n.T(i18n.MsgKeySrcNone)
+	}
+}
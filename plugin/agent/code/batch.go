@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/spinner"
+)
+
+// defaultBatchConcurrency --batch 默认的并发请求数
+const defaultBatchConcurrency = 4
+
+// batchItem 批量输入中的一条：纯文本文件每行一条 prompt，JSONL 每行一个对象
+type batchItem struct {
+	ID      string `json:"id,omitempty"`
+	Prompt  string `json:"prompt"`
+	Context string `json:"context,omitempty"` // 附在 prompt 之前的上下文（如待处理的文档）
+	System  string `json:"system,omitempty"`  // 覆盖全局 system prompt
+	index   int
+}
+
+// batchResult 输出文件中的一行
+type batchResult struct {
+	Index      int    `json:"index"`
+	ID         string `json:"id,omitempty"`
+	Prompt     string `json:"prompt"`
+	Status     string `json:"status"` // ok | error | truncated | cancelled，与问答历史一致
+	Answer     string `json:"answer"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// readBatch 读取批量输入："-" 表示 stdin；.jsonl / .ndjson 或首个非空行以 { 开头时按 JSONL 解析
+func readBatch(path string) ([]batchItem, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	jsonl := ext == ".jsonl" || ext == ".ndjson"
+
+	var items []batchItem
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if len(items) == 0 && strings.HasPrefix(line, "{") {
+			jsonl = true
+		}
+		item := batchItem{Prompt: line}
+		if jsonl {
+			item = batchItem{}
+			if err := json.Unmarshal([]byte(line), &item); err != nil {
+				return nil, errors.New(i18n.T(i18n.MsgBatchBadLine, path, lineNo, err))
+			}
+			if strings.TrimSpace(item.Prompt) == "" {
+				return nil, errors.New(i18n.T(i18n.MsgBatchNoPrompt, path, lineNo))
+			}
+		}
+		item.index = len(items)
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errors.New(i18n.T(i18n.MsgBatchEmpty, path))
+	}
+	return items, nil
+}
+
+// batchOutputPath 未指定 --out 时输出到输入文件旁的 <name>.results.jsonl，stdin 输入时写到 stdout
+func batchOutputPath(input, out string) string {
+	if out != "" {
+		return out
+	}
+	if input == "-" {
+		return "-"
+	}
+	ext := filepath.Ext(input)
+	return strings.TrimSuffix(input, ext) + ".results.jsonl"
+}
+
+// runBatch 以至多 concurrency 个并发请求跑完所有 prompt，按完成顺序逐行写入结果（中途中断也保留已完成部分）。
+// opts 为除 Stream 外已填好的客户端参数；任何一条失败时以退出码 1 结束
+func runBatch(ctx context.Context, cfg config.AgentConfig, p config.Provider, opts provider.Options, input, out string, concurrency int) error {
+	items, err := readBatch(input)
+	if err != nil {
+		return err
+	}
+	out = batchOutputPath(input, out)
+	var w io.Writer = os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	opts.Stream = false
+	client, err := newClient(p, opts)
+	if err != nil {
+		return err
+	}
+	defaultSystem := config.LoadSystemPrompt(cfg)
+
+	var (
+		mu             sync.Mutex
+		enc            = json.NewEncoder(w)
+		done, failed   atomic.Int32
+		wg             sync.WaitGroup
+		queue          = make(chan batchItem)
+		total          = len(items)
+		spin           = spinner.Start(i18n.T(i18n.MsgBatchProgress, 0, total, 0))
+		writeErr       error
+		concurrencyCap = min(max(concurrency, 1), total)
+	)
+	for range concurrencyCap {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				r := runBatchItem(quietly(ctx), client, defaultSystem, item)
+				if r.Status != history.StatusOK {
+					failed.Add(1)
+				}
+				// 结果文件是导出给其他程序的，与终端输出一样按 output_redact 规则脱敏
+				r.Answer = masked(r.Answer)
+				mu.Lock()
+				if err := enc.Encode(r); err != nil && writeErr == nil {
+					writeErr = err
+				}
+				mu.Unlock()
+				spin.Set(i18n.T(i18n.MsgBatchProgress, done.Add(1), total, failed.Load()))
+			}
+		}()
+	}
+feed:
+	for _, item := range items {
+		select {
+		case queue <- item:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+	spin.Stop()
+
+	if writeErr != nil {
+		return writeErr
+	}
+	dest := out
+	if dest == "-" {
+		dest = "stdout"
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgBatchDone, done.Load(), total, failed.Load(), dest))
+	if ctx.Err() != nil {
+		return exitCode(130)
+	}
+	if failed.Load() > 0 {
+		return exitCode(1)
+	}
+	return nil
+}
+
+// runBatchItem 执行单条 prompt，返回带状态的结果
+func runBatchItem(ctx context.Context, client provider.Client, system string, item batchItem) batchResult {
+	r := batchResult{Index: item.index, ID: item.ID, Prompt: item.Prompt, Status: history.StatusOK}
+	if item.System != "" {
+		system = item.System
+	}
+	var messages []provider.Message
+	if system != "" {
+		messages = append(messages, provider.Message{Role: "system", Content: system})
+	}
+	content := item.Prompt
+	if item.Context != "" {
+		content = item.Context + "\n\n" + item.Prompt
+	}
+	messages = append(messages, provider.Message{Role: "user", Content: content})
+
+	start := time.Now()
+	var err error
+	r.Answer, err = client.Chat(ctx, messages, nil)
+	r.DurationMs = time.Since(start).Milliseconds()
+	switch {
+	case ctx.Err() != nil:
+		r.Status = history.StatusCancelled
+	case errors.Is(err, provider.ErrTruncated):
+		r.Status = history.StatusTruncated
+	case err != nil:
+		r.Status = history.StatusError
+		r.Error = err.Error()
+	}
+	return r
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/budget"
+	"wcp_agent/internal/i18n"
+)
+
+// runBudget agent budget [YYYY-MM]：按 provider 列出某月（默认本月）的估算费用与预算
+func runBudget(args []string) error {
+	if len(args) > 1 {
+		return errors.New(i18n.T(i18n.MsgBudgetUsage))
+	}
+	month := budget.Month(time.Now())
+	if len(args) == 1 {
+		if _, err := time.Parse("2006-01", args[0]); err != nil {
+			return errors.New(i18n.T(i18n.MsgBudgetBadMonth, args[0]))
+		}
+		month = args[0]
+	}
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	ledger, err := budget.Load()
+	if err != nil {
+		return err
+	}
+
+	// 列出配置了 pricing 或本月有费用的 provider
+	limits := map[string]float64{}
+	for _, p := range cfg.Providers {
+		if p.Pricing != nil {
+			limits[p.Name] = p.MonthlyBudget
+		}
+	}
+	for name := range ledger[month] {
+		if _, ok := limits[name]; !ok {
+			limits[name] = 0
+		}
+	}
+	names := make([]string, 0, len(limits))
+	for name := range limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println(i18n.T(i18n.MsgBudgetHeader, month, budget.Path()))
+	fmt.Println()
+	cols := strings.Split(i18n.T(i18n.MsgBudgetColumns), "|")
+	fmt.Printf("%s %s %s %s\n", padRight(cols[0], 20), padLeft(cols[1], 12), padLeft(cols[2], 12), padLeft(cols[3], 8))
+	configured := false
+	for _, name := range names {
+		printBudgetRow(name, ledger[month][name], limits[name])
+		configured = configured || limits[name] > 0
+	}
+	total := budget.TotalLimit()
+	printBudgetRow(i18n.T(i18n.MsgBudgetTotalRow), ledger.Total(month), total)
+	if !configured && total <= 0 {
+		fmt.Println()
+		fmt.Println(i18n.T(i18n.MsgBudgetNoLimits))
+	}
+	return nil
+}
+
+// printBudgetRow 输出一行：名称、已用、预算与占比（未设置预算时后两列留空）
+func printBudgetRow(name string, spent, limit float64) {
+	budgetCol, used := "", ""
+	if limit > 0 {
+		budgetCol = fmt.Sprintf("%.2f", limit)
+		used = fmt.Sprintf("%.0f%%", spent/limit*100)
+	}
+	fmt.Printf("%s %12.4f %12s %8s\n", padRight(name, 20), spent, budgetCol, used)
+}
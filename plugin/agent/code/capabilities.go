@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/trace"
+)
+
+// 插件握手：调用方以唯一的参数 --capabilities 启动插件，插件在 stdout 输出一行 JSON
+// {"name": ..., "protocol": 1, "features": [...]} 后以 0 退出。不认识该参数的旧版插件会报错退出，
+// 调用方据此按协议 0（不支持任何特性）处理，例如改为缓冲输出。
+// transport 声明插件使用的传输方式：stdio 为一次调用一个进程的 stdin / stdout，
+// grpc 表示插件还可以由 hashicorp/go-plugin 启动，在本地 socket 上通过 gRPC 连续处理多个请求。
+const (
+	// CapabilitiesFlag 握手参数
+	CapabilitiesFlag = "--capabilities"
+	// ProtocolVersion 握手协议版本
+	ProtocolVersion = 1
+	// HelpMarkdownFlag 声明了 help-markdown 特性的插件以 --help-markdown [主题] 启动时在 stdout 输出内嵌的帮助文档（Markdown），
+	// 由调用方（如 j help）交给 md_render 渲染；主题为空时为插件总览，agent 的主题为子命令名
+	HelpMarkdownFlag = "--help-markdown"
+	// TransportStdio agent 只使用 stdin / stdout
+	TransportStdio = "stdio"
+
+	capabilitiesTimeout = 2 * time.Second
+)
+
+// 握手中声明的特性
+const (
+	FeatureStreaming    = "streaming"     // ask 边生成边输出（stream_mode 开启时）
+	FeatureJSONOutput   = "json-output"   // ask --schema 输出符合 schema 的 JSON
+	FeatureCancellation = "cancellation"  // Ctrl-C 时输出已收到的部分、记入历史并以 130 退出
+	FeatureHelp         = "help-markdown" // --help-markdown [主题] 输出内嵌的帮助文档
+)
+
+// capabilities 握手应答
+type capabilities struct {
+	Name      string   `json:"name"`
+	Protocol  int      `json:"protocol"`
+	Features  []string `json:"features"`
+	Transport string   `json:"transport,omitempty"`
+}
+
+// printCapabilities 输出握手应答
+func printCapabilities() error {
+	return json.NewEncoder(os.Stdout).Encode(capabilities{
+		Name:      "agent",
+		Protocol:  ProtocolVersion,
+		Features:  []string{FeatureStreaming, FeatureJSONOutput, FeatureCancellation, FeatureHelp},
+		Transport: TransportStdio,
+	})
+}
+
+// probeCapabilities 与其他插件（如 md_render）握手，失败（旧版插件、超时、输出无法解析）时返回零值；
+// 守护进程在运行时直接取它注册表中的结果
+func probeCapabilities(ctx context.Context, bin string) capabilities {
+	ctx, span := trace.Start(ctx, "plugin capabilities", trace.KindClient, trace.String("j.plugin", filepath.Base(bin)))
+	defer span.End()
+	if p, ok := daemon.LookupPlugin(bin); ok {
+		span.SetAttrs(trace.Bool("j.from_daemon", true))
+		return capabilities{Name: p.Name, Protocol: p.Protocol, Features: p.Features, Transport: p.Transport}
+	}
+	ctx, cancel := context.WithTimeout(ctx, capabilitiesTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, CapabilitiesFlag)
+	trace.Inject(ctx, cmd)
+	out, err := cmd.Output()
+	var c capabilities
+	if err != nil || json.Unmarshal(out, &c) != nil {
+		span.RecordError(err)
+		return capabilities{}
+	}
+	span.SetAttrs(trace.String("j.transport", c.Transport))
+	return c
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/a11y"
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/project"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/spinner"
+)
+
+// sendFlags 影响本次命令全部请求的 --no-redact、--offline 与 --override-budget
+var sendFlags struct {
+	noRedact       bool
+	offline        bool
+	overrideBudget bool
+}
+
+// registerSendFlags 在 FlagSet 上注册 --no-redact、--offline 与 --override-budget
+func registerSendFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&sendFlags.noRedact, "no-redact", false, "send the prompt as is, without replacing likely secrets with "+intercept.Redacted)
+	fs.BoolVar(&sendFlags.offline, "offline", false, "use only cached answers and local providers (e.g. Ollama); fail at once instead of waiting for timeouts")
+	fs.BoolVar(&sendFlags.overrideBudget, "override-budget", false, "send even if this month's estimated spend has reached the budget (setting.agent_budget / monthly_budget)")
+}
+
+// newClient 创建 provider 客户端并套上拦截器链（规范化、上下文上限、日志、脱敏、缓存、离线、预算、记账、重试、Key 轮换、限流），
+// 未指定上下文上限时沿用 --max-context，并带上 --no-redact、--offline、--override-budget 与项目 .j.toml 中的脱敏模式；
+// 所有对话请求都应通过它创建客户端；守护进程在运行时改为经它发送，拦截器链在守护进程中执行。
+// 带工具（MCP）的请求需要在本进程内执行工具，始终直接发送。provider 配置了 fallback 时失败后依次改用其中的 provider
+func newClient(p config.Provider, opts provider.Options) (provider.Client, error) {
+	if opts.MaxContextTokens == 0 {
+		opts.MaxContextTokens = inputLimits.contextTokens
+	}
+	opts.NoRedact = opts.NoRedact || sendFlags.noRedact
+	opts.Offline = opts.Offline || sendFlags.offline
+	opts.OverrideBudget = opts.OverrideBudget || sendFlags.overrideBudget
+	if pc, _ := project.Current(); pc != nil && opts.RedactPatterns == nil {
+		// 项目的脱敏模式随请求发送，拦截器链在守护进程中执行时同样生效
+		opts.RedactPatterns = pc.Redact
+	}
+	client, err := directClient(p, opts)
+	if err != nil || len(p.Fallback) == 0 {
+		return client, err
+	}
+	return withFallback(client, p, opts), nil
+}
+
+// directClient 单个 provider 的客户端，不含 fallback；provider 有多个 API Key 且 opts.Key 是其中第一个时
+// 带上全部 Key，由拦截器按 key_rotation 轮换
+func directClient(p config.Provider, opts provider.Options) (provider.Client, error) {
+	if opts.Keys == nil && opts.Key != "" {
+		if keys, _ := auth.Keys(p); len(keys) > 1 && keys[0] == opts.Key {
+			opts.Keys = keys
+		}
+	}
+	if opts.Tools == nil && daemon.Available() {
+		return daemon.NewClient(p, opts), nil
+	}
+	client, err := provider.New(p, opts)
+	if err != nil {
+		return nil, err
+	}
+	return intercept.Wrap(client, p, opts), nil
+}
+
+// withSpinner 让拦截器事件显示在 spinner 上：等待限流与重试时更新文案，等到额度后恢复为 status；
+// 其余事件在 stderr 单独提示一行。stderr 不是终端或开启了无障碍模式时 spinner 不刷新，事件按默认方式逐行提示
+func withSpinner(ctx context.Context, spin *spinner.Spinner, status string) context.Context {
+	if !term.IsTerminal(int(os.Stderr.Fd())) || a11y.Enabled() {
+		return ctx
+	}
+	return intercept.WithObserver(ctx, func(e intercept.Event) {
+		switch e.Kind {
+		case intercept.EventRateLimited:
+			spin.Set(i18n.T(i18n.MsgAskRateLimited, e.Wait.Seconds()))
+		case intercept.EventRateLimitDone:
+			spin.Set(status)
+		case intercept.EventRetry:
+			spin.Set(i18n.T(i18n.MsgInterceptRetrying, e.Wait.Seconds(), e.Attempt))
+		default:
+			fmt.Fprint(os.Stderr, "\r\033[K")
+			intercept.Report(e)
+		}
+	})
+}
+
+// quietly 忽略拦截器事件，用于并发请求（batch、compare）等逐条提示会打乱输出的场景
+func quietly(ctx context.Context) context.Context {
+	return intercept.WithObserver(ctx, func(intercept.Event) {})
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/a11y"
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/spinner"
+	"wcp_agent/internal/tokenizer"
+)
+
+// 对比模式的排版方式
+const (
+	layoutAuto  = "auto"  // 终端足够宽时并排，否则依次输出
+	layoutSide  = "side"  // 按列并排
+	layoutStack = "stack" // 依次输出，每个回答带标题
+)
+
+// minColumnWidth 并排显示时每列的最小宽度，不足时 auto 退化为依次输出
+const minColumnWidth = 30
+
+// compareResult 单个 provider 的对比结果
+type compareResult struct {
+	provider config.Provider
+	answer   string
+	err      error
+	elapsed  time.Duration
+	tokens   int  // 回答的估算 token 数
+	cut      bool // 回答被 token 上限截断
+}
+
+// resolveCompare 把 --compare 的逗号列表解析为 provider
+func resolveCompare(cfg config.AgentConfig, list string) ([]config.Provider, error) {
+	var out []config.Provider
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		p, err := lookupProvider(cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	if len(out) < 2 {
+		return nil, errors.New(i18n.T(i18n.MsgCompareTooFew))
+	}
+	return out, nil
+}
+
+// lookupProvider 先按 provider 名称匹配，再按模型名匹配（便于直接写 gpt-4o 这类模型名）
+func lookupProvider(cfg config.AgentConfig, name string) (config.Provider, error) {
+	if idx := cfg.FindProvider(name); idx >= 0 {
+		return cfg.Providers[idx], nil
+	}
+	for _, p := range cfg.Providers {
+		if strings.EqualFold(p.Model, name) {
+			return p, nil
+		}
+	}
+	return config.Provider{}, errors.New(i18n.T(i18n.MsgAuthUnknown, name))
+}
+
+// runCompare 并发向多个 provider 发送同一组消息（samplings 与 providers 一一对应），全部完成后按 layout 输出回答与耗时、token 统计
+func runCompare(ctx context.Context, cfg config.AgentConfig, providers []config.Provider, samplings []config.Sampling, limits provider.Options, messages []provider.Message, prompt string, attachments []string, layout string) error {
+	results := make([]compareResult, len(providers))
+	spin := spinner.Start(i18n.T(i18n.MsgCompareWaiting, 0, len(providers)))
+	var (
+		wg   sync.WaitGroup
+		done atomic.Int32
+	)
+	for i, p := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = askOne(ctx, cfg, p, samplings[i], limits, messages)
+			spin.Set(i18n.T(i18n.MsgCompareWaiting, done.Add(1), len(providers)))
+		}()
+	}
+	wg.Wait()
+	spin.Stop()
+
+	failed := 0
+	for _, r := range results {
+		exchange := history.Exchange{
+			Provider:    r.provider.Name,
+			Model:       r.provider.Model,
+			Prompt:      prompt,
+			Attachments: attachments,
+			Answer:      r.answer,
+			Status:      history.StatusOK,
+			DurationMs:  r.elapsed.Milliseconds(),
+		}
+		switch {
+		case ctx.Err() != nil:
+			exchange.Status = history.StatusCancelled
+		case r.err != nil:
+			exchange.Status = history.StatusError
+			exchange.Error = r.err.Error()
+			failed++
+		case r.cut:
+			exchange.Status = history.StatusTruncated
+		}
+		if herr := history.Append(exchange); herr != nil {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, herr))
+		}
+	}
+	if ctx.Err() != nil {
+		return interrupted(ctx, ctx.Err())
+	}
+
+	termWidth := 0
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		termWidth, _, _ = term.GetSize(int(os.Stdout.Fd()))
+	}
+	switch layout {
+	case layoutAuto:
+		// 无障碍模式下不并排：读屏软件按行朗读时各列会交错在一起
+		if termWidth > 0 && !a11y.Enabled() && columnWidth(termWidth, len(results)) >= minColumnWidth {
+			printSideBySide(results, termWidth)
+		} else {
+			printStacked(results)
+		}
+	case layoutSide:
+		if termWidth <= 0 {
+			termWidth = 160
+		}
+		printSideBySide(results, termWidth)
+	case layoutStack:
+		printStacked(results)
+	default:
+		return errors.New(i18n.T(i18n.MsgCompareBadLayout, layout))
+	}
+	if failed == len(results) {
+		return exitCode(1)
+	}
+	return nil
+}
+
+// askOne 以非流式方式向单个 provider 提问（并发输出无法交错显示增量）；
+// limits 携带各 provider 共用的 max_tokens / stop 设置
+func askOne(ctx context.Context, cfg config.AgentConfig, p config.Provider, sampling config.Sampling, limits provider.Options, messages []provider.Message) compareResult {
+	r := compareResult{provider: p}
+	opts := limits
+	opts.Key, _ = auth.Resolve(p)
+	opts.Timeouts = cfg.EffectiveTimeouts(p)
+	opts.Sampling = sampling
+	client, err := newClient(p, opts)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	start := time.Now()
+	r.answer, r.err = client.Chat(quietly(ctx), messages, func(string) {})
+	if errors.Is(r.err, provider.ErrTruncated) {
+		r.err, r.cut = nil, true
+	}
+	r.elapsed = time.Since(start)
+	r.tokens = tokenizer.For(p).Count(r.answer)
+	return r
+}
+
+// header 回答标题：provider (model) · 耗时 · 估算 token 数
+func (r compareResult) header() string {
+	name := r.provider.Name
+	if r.provider.Model != "" && !strings.EqualFold(r.provider.Model, name) {
+		name += " (" + r.provider.Model + ")"
+	}
+	h := i18n.T(i18n.MsgCompareHeader, name, r.elapsed.Seconds(), r.tokens)
+	if r.cut {
+		h += " · " + i18n.T(i18n.MsgCompareTruncated)
+	}
+	return h
+}
+
+// body 回答正文，失败时为错误信息
+func (r compareResult) body() string {
+	if r.err != nil {
+		return i18n.T(i18n.MsgError, r.err)
+	}
+	return strings.TrimRight(masked(r.answer), "\n")
+}
+
+// printStacked 依次输出每个回答，用 Markdown 标题分隔，便于管道给 md_render
+func printStacked(results []compareResult) {
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("## %s\n\n%s\n", r.header(), r.body())
+	}
+}
+
+// columnWidth 扣除列间分隔符后每列可用的宽度
+func columnWidth(termWidth, n int) int {
+	return (termWidth - 3*(n-1)) / n
+}
+
+// printSideBySide 按列并排输出，每列按显示宽度折行（中日韩字符计 2 列）
+func printSideBySide(results []compareResult, termWidth int) {
+	colWidth := max(columnWidth(termWidth, len(results)), 10)
+	columns := make([][]string, len(results))
+	rows := 0
+	for i, r := range results {
+		lines := wrap(r.header(), colWidth)
+		lines = append(lines, strings.Repeat("─", colWidth))
+		for _, line := range strings.Split(r.body(), "\n") {
+			lines = append(lines, wrap(line, colWidth)...)
+		}
+		columns[i] = lines
+		rows = max(rows, len(lines))
+	}
+	for row := 0; row < rows; row++ {
+		var b strings.Builder
+		for i, col := range columns {
+			cell := ""
+			if row < len(col) {
+				cell = col[row]
+			}
+			if i > 0 {
+				b.WriteString(" │ ")
+			}
+			b.WriteString(cell)
+			if i < len(columns)-1 {
+				b.WriteString(strings.Repeat(" ", colWidth-displayWidth(cell)))
+			}
+		}
+		fmt.Println(strings.TrimRight(b.String(), " "))
+	}
+}
+
+// wrap 按显示宽度把一行切成多段，优先在空格处断开；空行保留为一个空段
+func wrap(line string, limit int) []string {
+	var (
+		out []string
+		cur []rune
+		w   int
+	)
+	for _, r := range strings.ReplaceAll(line, "\t", "    ") {
+		rw := runeWidth(r)
+		if w+rw > limit && len(cur) > 0 {
+			cut := len(cur)
+			for j := len(cur) - 1; j > 0; j-- {
+				if cur[j] == ' ' {
+					cut = j
+					break
+				}
+			}
+			out = append(out, strings.TrimRight(string(cur[:cut]), " "))
+			cur = []rune(strings.TrimLeft(string(cur[cut:]), " "))
+			w = displayWidth(string(cur))
+		}
+		cur = append(cur, r)
+		w += rw
+	}
+	return append(out, string(cur))
+}
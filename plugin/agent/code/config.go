@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/configcheck"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/project"
+)
+
+// runConfig agent config check [file]：校验 agent 配置，有错误时以 1 退出；
+// agent config project：显示当前项目的 .j.toml 及其覆盖的设置
+func runConfig(args []string) error {
+	if len(args) == 1 && args[0] == "project" {
+		return configProject()
+	}
+	if len(args) == 0 || args[0] != "check" || len(args) > 2 {
+		return errors.New(i18n.T(i18n.MsgConfigUsage))
+	}
+	path := config.AgentConfigPath()
+	if len(args) == 2 {
+		path = args[1]
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	issues := configcheck.Check(data)
+	if len(issues) == 0 {
+		fmt.Println(i18n.T(i18n.MsgConfigOK, path))
+		return nil
+	}
+	for _, is := range issues {
+		fmt.Println(is.Format(path))
+	}
+	if configcheck.Errors(issues) > 0 {
+		return exitCode(1)
+	}
+	return nil
+}
+
+// loadAgent 加载 agent 配置（见 loadAgentFile），再叠加当前项目 .j.toml 中的默认 provider、模型与采样预设；
+// 叠加的结果只在本次运行中生效，需要写回配置的命令使用 loadAgentFile
+func loadAgent() (config.AgentConfig, error) {
+	cfg, err := loadAgentFile()
+	if err != nil {
+		return cfg, err
+	}
+	pc, err := project.Current()
+	if err != nil || pc == nil {
+		return cfg, err
+	}
+	return applyProject(cfg, pc), nil
+}
+
+// applyProject 用 .j.toml 覆盖默认 provider、它的模型与同名的采样预设
+func applyProject(cfg config.AgentConfig, pc *project.Config) config.AgentConfig {
+	if pc.Provider != "" {
+		if idx := cfg.FindProvider(pc.Provider); idx >= 0 {
+			cfg.ActiveIndex = idx
+		} else {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgProjectUnknownProvider, pc.Path, pc.Provider))
+		}
+	}
+	if pc.Model != "" && cfg.ActiveIndex >= 0 && cfg.ActiveIndex < len(cfg.Providers) {
+		cfg.Providers = append([]config.Provider(nil), cfg.Providers...)
+		cfg.Providers[cfg.ActiveIndex].Model = pc.Model
+	}
+	if len(pc.Presets) > 0 {
+		presets := make(map[string]config.Sampling, len(cfg.SamplingPresets)+len(pc.Presets))
+		for name, s := range cfg.SamplingPresets {
+			presets[name] = s
+		}
+		for name, s := range pc.Presets {
+			for existing := range presets {
+				if strings.EqualFold(existing, name) {
+					delete(presets, existing)
+				}
+			}
+			presets[name] = s
+		}
+		cfg.SamplingPresets = presets
+	}
+	return cfg
+}
+
+// loadAgentFile 加载 agent 配置前先校验：警告（未知项、废弃项等）输出到 stderr 后照常加载，
+// 有错误时输出全部问题并返回错误，而不是只给出 encoding/json 的一句报错
+func loadAgentFile() (config.AgentConfig, error) {
+	path := config.AgentConfigPath()
+	if data, err := os.ReadFile(path); err == nil {
+		issues := configcheck.Check(data)
+		for _, is := range issues {
+			fmt.Fprintln(os.Stderr, is.Format(path))
+		}
+		if n := configcheck.Errors(issues); n > 0 {
+			return config.AgentConfig{}, errors.New(i18n.T(i18n.MsgConfigInvalid, path, n))
+		}
+	}
+	return config.LoadAgent()
+}
+
+// configProject 显示当前目录所属项目的 .j.toml 及其中生效的设置
+func configProject() error {
+	pc, err := project.Current()
+	if err != nil {
+		return err
+	}
+	if pc == nil {
+		fmt.Println(i18n.T(i18n.MsgProjectNone, project.FileName))
+		return nil
+	}
+	fmt.Println(i18n.T(i18n.MsgProjectHeader, pc.Path))
+	show := func(key, value string) {
+		if value != "" {
+			fmt.Printf("  %-16s %s\n", key, value)
+		}
+	}
+	show("provider", pc.Provider)
+	show("model", pc.Model)
+	show("preset", pc.Preset)
+	names := make([]string, 0, len(pc.Presets))
+	for name := range pc.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	show("presets", strings.Join(names, ", "))
+	show("context.mode", pc.Context.Mode)
+	if pc.Context.Budget > 0 {
+		show("context.budget", strconv.Itoa(pc.Context.Budget))
+	}
+	show("context.exclude", strings.Join(pc.Context.Exclude, ", "))
+	show("redact.patterns", strings.Join(pc.Redact, "  "))
+	return nil
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/cron"
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/flow"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/templates"
+)
+
+// runCron agent cron add | list | run | log | pause | resume | remove：
+// 管理由守护进程按时执行的任务，run 在前台立即执行一次（不需要守护进程）
+func runCron(args []string) error {
+	if len(args) == 0 {
+		return errors.New(i18n.T(i18n.MsgCronUsage))
+	}
+	switch args[0] {
+	case "add":
+		return cronAdd(args[1:])
+	case "list", "ls":
+		return cronList(args[1:])
+	case "run":
+		return cronRun(args[1:])
+	case "log":
+		return cronLog(args[1:])
+	case "pause", "resume":
+		if len(args) != 2 {
+			return errors.New(i18n.T(i18n.MsgCronUsage))
+		}
+		return cronPause(args[1], args[0] == "pause")
+	case "remove", "rm":
+		if len(args) != 2 {
+			return errors.New(i18n.T(i18n.MsgCronUsage))
+		}
+		return cronRemove(args[1])
+	default:
+		return errors.New(i18n.T(i18n.MsgCronUsage))
+	}
+}
+
+// cronAdd 校验并保存一个任务：时间表、模板、流水线与去向在添加时检查，模板与 @文件 变量在每次执行时读取
+func cronAdd(args []string) error {
+	fs := flag.NewFlagSet("cron add", flag.ContinueOnError)
+	name := fs.String("name", "", "job name (letters, digits, _ . -)")
+	schedule := fs.String("schedule", "", `cron expression ("0 9 * * 1-5"), @daily, @hourly... or "@every 30m"`)
+	providerName := fs.String("provider", "", "provider name or model (defaults to the active one at run time)")
+	templateName := fs.String("template", "", "build the prompt from this template (see agent template list)")
+	flowPath := fs.String("flow", "", "run this pipeline file instead of a prompt")
+	var varList stringList
+	fs.Var(&varList, "var", "template or pipeline variable key=value; @file values are read at every run (repeatable)")
+	input := fs.String("input", "", `shell command whose output is piped in, e.g. "git log --since=yesterday"`)
+	dir := fs.String("dir", "", "working directory for --input and the job (default: the current directory)")
+	var deliver stringList
+	fs.Var(&deliver, "deliver", "where to send the result: file:<path> ({date} is replaced), notify or webhook:<url> (repeatable)")
+	timeout := fs.Duration("timeout", cron.DefaultTimeout, "stop a run that takes longer than this")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" || *schedule == "" {
+		return errors.New(i18n.T(i18n.MsgCronAddUsage))
+	}
+	if !cron.ValidName(*name) {
+		return errors.New(i18n.T(i18n.MsgCronBadName, *name))
+	}
+	sched, err := cron.Parse(*schedule)
+	if err != nil {
+		return err
+	}
+	if sched.Next(time.Now()).IsZero() {
+		return errors.New(i18n.T(i18n.MsgCronNeverMatches, *schedule))
+	}
+	job := cron.Job{
+		Name:        *name,
+		Schedule:    strings.TrimSpace(*schedule),
+		Prompt:      strings.Join(fs.Args(), " "),
+		Template:    *templateName,
+		Vars:        varList,
+		Provider:    *providerName,
+		Input:       *input,
+		Deliver:     deliver,
+		TimeoutSecs: int(timeout.Seconds()),
+		Created:     time.Now(),
+	}
+	switch {
+	case *flowPath != "":
+		if job.Prompt != "" || job.Template != "" || job.Provider != "" {
+			return errors.New(i18n.T(i18n.MsgCronFlowOnly))
+		}
+		if _, err := flow.Load(*flowPath); err != nil {
+			return err
+		}
+		if job.Flow, err = filepath.Abs(*flowPath); err != nil {
+			return err
+		}
+	case job.Template != "":
+		path, err := templates.Resolve(job.Template)
+		if err != nil {
+			return err
+		}
+		if path != filepath.Join(templates.Dir(), filepath.Base(path)) {
+			// 模板目录之外的文件按绝对路径保存，执行时与工作目录无关
+			if job.Template, err = filepath.Abs(path); err != nil {
+				return err
+			}
+		}
+	case job.Prompt == "":
+		return errors.New(i18n.T(i18n.MsgCronNoTask))
+	}
+	for _, kv := range varList {
+		if k, _, ok := strings.Cut(kv, "="); !ok || k == "" {
+			return errors.New(i18n.T(i18n.MsgVarBad, kv))
+		}
+	}
+	for _, spec := range deliver {
+		if _, err := cron.ParseTarget(spec); err != nil {
+			return err
+		}
+	}
+	if job.Dir = *dir; job.Dir == "" {
+		job.Dir = "."
+	}
+	if job.Dir, err = filepath.Abs(job.Dir); err != nil {
+		return err
+	}
+
+	err = cron.Update(func(jobs []cron.Job) ([]cron.Job, error) {
+		if cron.Find(jobs, job.Name) >= 0 {
+			return nil, errors.New(i18n.T(i18n.MsgCronExists, job.Name, job.Name))
+		}
+		return append(jobs, job), nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.T(i18n.MsgCronAdded, job.Name, formatNext(sched.Next(time.Now()))))
+	if _, err := daemon.FetchStatus(); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgCronNoDaemon))
+	}
+	return nil
+}
+
+// cronList 列出任务：时间表、下次执行、上次执行的结果与去向
+func cronList(args []string) error {
+	fs := flag.NewFlagSet("cron list", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the jobs as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	jobs, err := cron.Load()
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		if jobs == nil {
+			jobs = []cron.Job{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(jobs)
+	}
+	if len(jobs) == 0 {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgCronNone))
+		return nil
+	}
+	last, err := cron.LastRuns()
+	if err != nil {
+		return err
+	}
+	// 守护进程在运行时以它的调度状态为准（可看到执行中的任务）
+	states := map[string]cron.JobStatus{}
+	if st, err := daemon.FetchStatus(); err == nil {
+		for _, j := range st.Jobs {
+			states[j.Name] = j
+		}
+	}
+	rows := [][]string{strings.Split(i18n.T(i18n.MsgCronHeader), "|")}
+	for _, j := range jobs {
+		st, ok := states[j.Name]
+		if !ok {
+			st = cron.JobStatus{Paused: j.Paused}
+			if sched, err := cron.Parse(j.Schedule); err == nil {
+				st.Next = sched.Next(time.Now())
+			}
+		}
+		lastRun := i18n.T(i18n.MsgCronNever)
+		if r, ok := last[j.Name]; ok {
+			lastRun = r.Started.Local().Format("01-02 15:04") + " " + r.Status
+		}
+		deliver := strings.Join(j.Deliver, ", ")
+		if deliver == "" {
+			deliver = "-"
+		}
+		rows = append(rows, []string{j.Name, j.Schedule, cronNext(st.Next, st.Paused, st.Running), lastRun, deliver})
+	}
+	printTable(rows)
+	return nil
+}
+
+// printTable 按显示宽度对齐各列，最后一列不补空格
+func printTable(rows [][]string) {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if i < len(row)-1 {
+				cell = padRight(cell, widths[i])
+			}
+			cells[i] = cell
+		}
+		fmt.Println(strings.Join(cells, "  "))
+	}
+}
+
+// cronNext 下次执行的显示：暂停、执行中或本地时间
+func cronNext(next time.Time, paused, running bool) string {
+	switch {
+	case running:
+		return i18n.T(i18n.MsgCronRunning)
+	case paused:
+		return i18n.T(i18n.MsgCronStatePaused)
+	}
+	return formatNext(next)
+}
+
+func formatNext(next time.Time) string {
+	if next.IsZero() {
+		return i18n.T(i18n.MsgCronNever)
+	}
+	return next.Local().Format("2006-01-02 15:04")
+}
+
+// cronRun 在前台立即执行一次任务：输出写到 stdout，结果同样送到各个去向并记入执行记录；失败时以 1 退出
+func cronRun(args []string) error {
+	if len(args) != 1 {
+		return errors.New(i18n.T(i18n.MsgCronUsage))
+	}
+	job, err := findJob(args[0])
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	r := cron.Perform(ctx, job, cron.TriggerManual)
+	if r.Output != "" {
+		fmt.Println(r.Output)
+	}
+	if r.Error != "" {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, r.Error))
+	}
+	for _, d := range r.Delivered {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgCronDelivered, d))
+	}
+	for _, f := range r.Failed {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgCronDeliverFail, f))
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgCronRunDone, r.Job, r.Status, float64(r.DurationMs)/1000))
+	if r.Status != cron.StatusOK {
+		return exitCode(1)
+	}
+	return nil
+}
+
+// cronLog 最近的执行记录，每行一次：时间、任务、状态、耗时与输出（或错误）的第一行
+func cronLog(args []string) error {
+	fs := flag.NewFlagSet("cron log", flag.ContinueOnError)
+	n := fs.Int("n", 10, "number of runs to show")
+	asJSON := fs.Bool("json", false, "print the runs as JSON lines (with the full output)")
+	var name string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if name == "" && fs.NArg() > 0 {
+		name = fs.Arg(0)
+	}
+	runs, err := cron.Runs(name)
+	if err != nil {
+		return err
+	}
+	if *n > 0 && len(runs) > *n {
+		runs = runs[len(runs)-*n:]
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, r := range runs {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if len(runs) == 0 {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgCronNoRuns))
+		return nil
+	}
+	for _, r := range runs {
+		text := r.Output
+		if r.Status != cron.StatusOK {
+			text = r.Error
+		}
+		text, _, _ = strings.Cut(strings.TrimSpace(text), "\n")
+		fmt.Printf("%s  %-16s %-7s %6.1fs  %s\n", r.Started.Local().Format("2006-01-02 15:04"), r.Job, r.Status,
+			float64(r.DurationMs)/1000, truncateRunes(text, 80))
+	}
+	return nil
+}
+
+// truncateRunes 至多保留 n 个字符
+func truncateRunes(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n-1]) + "…"
+	}
+	return s
+}
+
+func findJob(name string) (cron.Job, error) {
+	jobs, err := cron.Load()
+	if err != nil {
+		return cron.Job{}, err
+	}
+	i := cron.Find(jobs, name)
+	if i < 0 {
+		return cron.Job{}, errors.New(i18n.T(i18n.MsgCronNotFound, name))
+	}
+	return jobs[i], nil
+}
+
+// cronPause 暂停或恢复任务；暂停期间到点的执行直接跳过
+func cronPause(name string, paused bool) error {
+	var job cron.Job
+	err := cron.Update(func(jobs []cron.Job) ([]cron.Job, error) {
+		i := cron.Find(jobs, name)
+		if i < 0 {
+			return nil, errors.New(i18n.T(i18n.MsgCronNotFound, name))
+		}
+		jobs[i].Paused = paused
+		job = jobs[i]
+		return jobs, nil
+	})
+	if err != nil {
+		return err
+	}
+	if paused {
+		fmt.Println(i18n.T(i18n.MsgCronPaused, name))
+		return nil
+	}
+	next := time.Time{}
+	if sched, err := cron.Parse(job.Schedule); err == nil {
+		next = sched.Next(time.Now())
+	}
+	fmt.Println(i18n.T(i18n.MsgCronResumed, name, formatNext(next)))
+	return nil
+}
+
+func cronRemove(name string) error {
+	err := cron.Update(func(jobs []cron.Job) ([]cron.Job, error) {
+		i := cron.Find(jobs, name)
+		if i < 0 {
+			return nil, errors.New(i18n.T(i18n.MsgCronNotFound, name))
+		}
+		return append(jobs[:i], jobs[i+1:]...), nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.T(i18n.MsgCronRemoved, name))
+	return nil
+}
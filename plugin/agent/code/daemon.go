@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/i18n"
+)
+
+// runDaemon agent daemon [run] | status [--json] | stop | watch：
+// 不带参数时在前台运行守护进程（可放进 launchd / systemd --user / 登录脚本）
+func runDaemon(args []string) error {
+	if len(args) == 0 {
+		return daemonRun()
+	}
+	switch args[0] {
+	case "run":
+		return daemonRun()
+	case "status":
+		return daemonStatus(args[1:])
+	case "stop":
+		return daemonStop()
+	case "watch":
+		return daemonWatch()
+	default:
+		return errors.New(i18n.T(i18n.MsgDaemonUsage))
+	}
+}
+
+func daemonRun() error {
+	ln, err := daemon.Listen()
+	if errors.Is(err, daemon.ErrRunning) {
+		return errors.New(i18n.T(i18n.MsgDaemonRunning, daemon.SocketPath()))
+	}
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgDaemonStarted, daemon.SocketPath(), os.Getpid()))
+	if err := daemon.NewServer(os.Stderr).Serve(ctx, ln); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgDaemonStopped))
+	return nil
+}
+
+// notRunning 守护进程未运行时提示并以 1 退出
+func notRunning(err error) error {
+	if errors.Is(err, daemon.ErrNotRunning) {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgDaemonNotRunning))
+		return exitCode(1)
+	}
+	return err
+}
+
+func daemonStatus(args []string) error {
+	fs := flag.NewFlagSet("daemon status", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the status as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	st, err := daemon.FetchStatus()
+	if err != nil {
+		return notRunning(err)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(st)
+	}
+	fmt.Println(i18n.T(i18n.MsgDaemonStatus, st.PID, time.Since(st.Started).Round(time.Second), st.Requests, st.Socket))
+	warm := "-"
+	if len(st.Warm) > 0 {
+		warm = strings.Join(st.Warm, ", ")
+	}
+	fmt.Println(i18n.T(i18n.MsgDaemonWarm, warm))
+	if len(st.Sessions) > 0 {
+		fmt.Println(i18n.T(i18n.MsgDaemonSessions))
+		for _, s := range st.Sessions {
+			fmt.Println(i18n.T(i18n.MsgDaemonSession, s.Name, s.Messages, s.Updated.Local().Format("15:04:05")))
+		}
+	}
+	if len(st.Plugins) > 0 {
+		fmt.Println(i18n.T(i18n.MsgDaemonPlugins))
+		for _, p := range st.Plugins {
+			features := strings.Join(p.Features, ",")
+			if features == "" {
+				features = "-"
+			}
+			transport := p.Transport
+			if transport == "" {
+				transport = "stdio"
+			}
+			if len(p.Filters) > 0 {
+				names := make([]string, len(p.Filters))
+				for i, f := range p.Filters {
+					names[i] = f.Name
+				}
+				features += " (" + strings.Join(names, ",") + ")"
+			}
+			fmt.Printf("  %-12s %d  %-6s %s\n", p.Name, p.Protocol, transport, features)
+		}
+	}
+	if len(st.Jobs) > 0 {
+		fmt.Println(i18n.T(i18n.MsgDaemonJobs))
+		for _, j := range st.Jobs {
+			fmt.Println(i18n.T(i18n.MsgDaemonJob, j.Name, j.Schedule, cronNext(j.Next, j.Paused, j.Running)))
+		}
+	}
+	return nil
+}
+
+func daemonStop() error {
+	if err := daemon.Stop(); err != nil {
+		return notRunning(err)
+	}
+	fmt.Println(i18n.T(i18n.MsgDaemonStopped))
+	return nil
+}
+
+// daemonWatch 逐行输出守护进程推送的事件，直到 Ctrl-C 或守护进程退出
+func daemonWatch() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgDaemonWatching))
+	err := daemon.Watch(ctx, func(n daemon.Notice) {
+		var text string
+		switch n.Kind {
+		case daemon.NoticeConfig:
+			text = i18n.T(i18n.MsgDaemonNoticeConfig, n.Count)
+		case daemon.NoticePlugins:
+			text = i18n.T(i18n.MsgDaemonNoticePlugins, n.Count)
+		case daemon.NoticeRequest:
+			text = i18n.T(i18n.MsgDaemonNoticeRequest, n.Provider, n.Model, n.Status, n.DurationMs)
+			if n.Session != "" {
+				text += "  [" + n.Session + "]"
+			}
+		case daemon.NoticeCron:
+			text = i18n.T(i18n.MsgDaemonNoticeCron, n.Job, n.Status, n.DurationMs)
+		default:
+			text = n.Kind
+		}
+		fmt.Printf("%s  %s\n", n.Time.Local().Format("15:04:05"), text)
+	})
+	return notRunning(err)
+}
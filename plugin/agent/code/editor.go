@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/editor"
+	"wcp_agent/internal/i18n"
+)
+
+// runEditor agent editor [nvim]：编辑器集成。不带参数时从 stdin 读取一个编辑器请求
+// （与守护进程 socket 上的请求相同），把回复帧以 JSON Lines 写到 stdout，守护进程未运行时在本进程内完成；
+// nvim 输出 Neovim 的参考 Lua 模块
+func runEditor(args []string) error {
+	if len(args) == 1 && args[0] == "nvim" {
+		fmt.Print(editor.NeovimPlugin)
+		return nil
+	}
+	if len(args) > 0 {
+		return errors.New(i18n.T(i18n.MsgEditorUsage))
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	var line bytes.Buffer
+	if err := json.Compact(&line, data); err != nil {
+		return errors.New(i18n.T(i18n.MsgEditorBadRequest, err))
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err = daemon.Editor(ctx, line.Bytes(), os.Stdout)
+	if errors.Is(err, context.Canceled) {
+		return exitCode(130)
+	}
+	return err
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/ratelimit"
+	"wcp_agent/internal/spinner"
+	"wcp_agent/internal/tokenizer"
+)
+
+const (
+	// defaultEmbeddingModel provider 未配置 embedding_model 时使用的模型
+	defaultEmbeddingModel = "text-embedding-3-small"
+	// defaultEmbedBatch 每次请求携带的文本条数
+	defaultEmbedBatch = 64
+)
+
+// embedItem 一段待向量化的文本及其输出
+type embedItem struct {
+	Index     int       `json:"index"`
+	Source    string    `json:"source,omitempty"` // 来源文件，读取 stdin 时为空
+	Text      string    `json:"text"`
+	Embedding []float64 `json:"embedding"`
+}
+
+// runEmbed agent embed [--provider name] [--model m] [--lines] [--format json|jsonl|tsv] [--batch N] [file...]
+func runEmbed(args []string) error {
+	fs := flag.NewFlagSet("embed", flag.ContinueOnError)
+	providerName := fs.String("provider", "", "provider name (defaults to the active one)")
+	model := fs.String("model", "", "embedding model (defaults to the provider's embedding_model)")
+	lines := fs.Bool("lines", false, "embed each non-empty line separately instead of the whole input")
+	format := fs.String("format", "json", "output format: json, jsonl or tsv")
+	batch := fs.Int("batch", defaultEmbedBatch, "number of texts per request")
+	registerMaxInput(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch *format {
+	case "json", "jsonl", "tsv":
+	default:
+		return errors.New(i18n.T(i18n.MsgEmbedBadFormat, *format))
+	}
+
+	items, err := readEmbedInput(fs.Args(), *lines)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return errors.New(i18n.T(i18n.MsgEmbedNoInput))
+	}
+
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	p, err := selectProvider(cfg, *providerName)
+	if err != nil {
+		return err
+	}
+	if *model == "" {
+		*model = p.EmbeddingModel
+	}
+	if *model == "" {
+		*model = defaultEmbeddingModel
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := embedItems(ctx, cfg, p, *model, items, max(*batch, 1)); err != nil {
+		if ctx.Err() != nil {
+			return exitCode(130)
+		}
+		return err
+	}
+	return writeEmbeddings(os.Stdout, items, *format)
+}
+
+// embedItems 按批请求向量并写回 items，批次之间遵守 provider 的客户端限流
+func embedItems(ctx context.Context, cfg config.AgentConfig, p config.Provider, model string, items []embedItem, batch int) error {
+	key, _ := auth.Resolve(p)
+	opts := provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(p)}
+	limiter := ratelimit.New(p.Name, p.RateLimit)
+	counter := tokenizer.For(config.Provider{Model: model})
+	spin := spinner.Start(i18n.T(i18n.MsgEmbedProgress, 0, len(items)))
+	defer spin.Stop()
+	for start := 0; start < len(items); start += batch {
+		end := min(start+batch, len(items))
+		inputs := make([]string, 0, end-start)
+		tokens := 0
+		for _, it := range items[start:end] {
+			inputs = append(inputs, it.Text)
+			tokens += counter.Count(it.Text)
+		}
+		if err := limiter.Wait(ctx, tokens, func(wait time.Duration) {
+			spin.Set(i18n.T(i18n.MsgAskRateLimited, wait.Seconds()))
+		}); err != nil {
+			return err
+		}
+		vectors, err := provider.Embed(ctx, p, opts, model, inputs)
+		if err != nil {
+			return err
+		}
+		for i, v := range vectors {
+			items[start+i].Embedding = v
+		}
+		spin.Set(i18n.T(i18n.MsgEmbedProgress, end, len(items)))
+	}
+	return nil
+}
+
+// readEmbedInput 读取文件（未给出时读取 stdin）；lines 为 true 时每个非空行单独成一条
+func readEmbedInput(paths []string, lines bool) ([]embedItem, error) {
+	type source struct {
+		name string
+		data string
+	}
+	var sources []source
+	if len(paths) == 0 {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, errors.New(i18n.T(i18n.MsgEmbedNoInput))
+		}
+		data, err := readLimited(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source{"", data})
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source{path, string(data)})
+	}
+
+	var items []embedItem
+	add := func(src, text string) {
+		if text = strings.TrimSpace(text); text != "" {
+			items = append(items, embedItem{Index: len(items), Source: src, Text: text})
+		}
+	}
+	for _, s := range sources {
+		if !lines {
+			add(s.name, s.data)
+			continue
+		}
+		scanner := bufio.NewScanner(strings.NewReader(s.data))
+		scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+		for scanner.Scan() {
+			add(s.name, scanner.Text())
+		}
+	}
+	return items, nil
+}
+
+// writeEmbeddings 输出结果：json 为数组，jsonl 每行一条，tsv 为 "文本\t分量1\t分量2..."
+func writeEmbeddings(w io.Writer, items []embedItem, format string) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	switch format {
+	case "json":
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	case "jsonl":
+		enc := json.NewEncoder(bw)
+		for _, it := range items {
+			if err := enc.Encode(it); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		escape := strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+		for _, it := range items {
+			bw.WriteString(escape.Replace(it.Text))
+			for _, x := range it.Embedding {
+				bw.WriteByte('\t')
+				bw.WriteString(strconv.FormatFloat(x, 'g', -1, 64))
+			}
+			bw.WriteByte('\n')
+		}
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/provider"
+)
+
+// withFallback 在 client 之后依次接上 p.Fallback 中的 provider（各用自己的 API Key 与超时，其余参数相同）；
+// 无法创建的备选 provider 在 stderr 提示后跳过
+func withFallback(client provider.Client, p config.Provider, opts provider.Options) provider.Client {
+	cfg, err := config.LoadAgent()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+		return client
+	}
+	clients := []provider.Client{client}
+	names := []string{providerLabel(p.Name, p.Model)}
+	for _, fp := range fallbackProviders(cfg, p) {
+		fo := opts
+		fo.Key, _ = auth.Resolve(fp)
+		fo.Keys = nil
+		fo.Timeouts = cfg.EffectiveTimeouts(fp)
+		c, err := directClient(fp, fo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFallbackSkipped, fp.Name, err))
+			continue
+		}
+		clients = append(clients, c)
+		names = append(names, providerLabel(fp.Name, fp.Model))
+	}
+	return intercept.Fallback(clients, names)
+}
+
+// fallbackProviders p.Fallback 依次对应的 provider：每项为 provider 名称、某个 provider 配置的模型名，
+// 都不是时视为同一 provider 的另一个模型（与 history regen --model 相同）；重复项与 p 自身跳过，
+// 备选 provider 自己的 fallback 不再展开
+func fallbackProviders(cfg config.AgentConfig, p config.Provider) []config.Provider {
+	seen := map[string]bool{p.Name + "\x00" + p.Model: true}
+	var out []config.Provider
+	for _, name := range p.Fallback {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		fp, err := lookupProvider(cfg, name)
+		if err != nil {
+			fp = p
+			fp.Model = name
+		}
+		if key := fp.Name + "\x00" + fp.Model; !seen[key] {
+			seen[key] = true
+			out = append(out, fp)
+		}
+	}
+	return out
+}
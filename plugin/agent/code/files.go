@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"wcp_agent/internal/document"
+	"wcp_agent/internal/i18n"
+)
+
+// maxContextFileBytes 超过该大小的文件不附上内容；PDF / DOCX 按取出的文字计算，超出部分截断
+const maxContextFileBytes = 256 << 10
+
+// filesPrompt 问题之后附上各文件的内容（agent ask -f 与 agent watch -f）：PDF 与 DOCX 取出文字并按页加上页码标记，
+// 过大或二进制的文件只列出路径
+func filesPrompt(prompt string, paths []string) string {
+	var b strings.Builder
+	b.WriteString(prompt + "\n")
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		switch {
+		case err != nil:
+			fmt.Fprintf(&b, "\n%s: (unreadable: %v)\n", path, err)
+		case document.Kind(path, data) != "":
+			b.WriteString(documentBlock(path))
+		case len(data) > maxContextFileBytes:
+			fmt.Fprintf(&b, "\n%s: (%d bytes, too large to include)\n", path, len(data))
+		case bytes.IndexByte(data, 0) >= 0:
+			fmt.Fprintf(&b, "\n%s: (binary file, not included)\n", path)
+		default:
+			b.WriteString("\n" + path + ":\n\n```\n" + string(data))
+			if len(data) > 0 && data[len(data)-1] != '\n' {
+				b.WriteString("\n")
+			}
+			b.WriteString("```\n")
+		}
+	}
+	return b.String()
+}
+
+// documentBlock PDF / DOCX 取出的文字；取不出时（加密、扫描件、格式损坏）在 stderr 提示并只列出路径
+func documentBlock(path string) string {
+	doc, err := document.Extract(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgDocumentSkipped, err))
+		return fmt.Sprintf("\n%s: (text could not be extracted, not included)\n", path)
+	}
+	text := doc.Text()
+	note := ""
+	if len(text) > maxContextFileBytes {
+		cut := maxContextFileBytes
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		text = text[:cut]
+		note = fmt.Sprintf("\n[... truncated at %d KiB of extracted text]", maxContextFileBytes>>10)
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgDocumentTruncated, path, maxContextFileBytes>>10))
+	}
+	return fmt.Sprintf("\n%s (%s, %d pages):\n\n```\n%s%s\n```\n", path, strings.ToUpper(doc.Kind), len(doc.Pages), text, note)
+}
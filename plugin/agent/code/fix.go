@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/patch"
+	"wcp_agent/internal/provider"
+)
+
+const (
+	// fixContextLines 每处错误前后附上的源码行数
+	fixContextLines = 6
+	// maxFixErrors 提示词中最多附上源码的错误个数（后面的错误往往由前面的引起）
+	maxFixErrors = 20
+	// maxFixOutputBytes 提示词中编译输出的长度上限
+	maxFixOutputBytes = 16 << 10
+)
+
+// compileErrorRegexp 编译器输出中的位置：file.go:12:5: msg、vet: file.go:12: msg、--> src/main.rs:3:5
+var compileErrorRegexp = regexp.MustCompile(`^\s*(?:vet: |--> )?(\S+?\.\w+):(\d+)(?::(\d+))?(?::\s*(.*))?$`)
+
+// compileError 编译输出中指向源码的一条错误
+type compileError struct {
+	path string
+	line int
+}
+
+// runFix go build 2>&1 | agent fix [--check cmd] [补充要求]：把编译错误与所指的源码行交给模型，
+// 渲染解释与最小修复的 diff，列出各文件的应用方式（模糊匹配、三方合并、冲突）并确认后写入
+// （原文件备份为 .orig），再按需重新运行检查命令；留有冲突标记时以 1 退出
+func runFix(args []string) error {
+	fs := flag.NewFlagSet("fix", flag.ContinueOnError)
+	providerName := fs.String("provider", "", "provider name (defaults to the active one)")
+	check := fs.String("check", "", "command to rerun after applying the fix, e.g. \"go build ./...\"")
+	var sampling samplingFlags
+	sampling.register(fs)
+	registerLimitFlags(fs)
+	registerSendFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New(i18n.T(i18n.MsgFixNoInput))
+	}
+	data, err := readLimited(os.Stdin)
+	if err != nil {
+		return err
+	}
+	output := strings.TrimSpace(data)
+	if output == "" {
+		return errors.New(i18n.T(i18n.MsgFixNoInput))
+	}
+	errs := parseCompileErrors(output)
+	if len(errs) == 0 {
+		return errors.New(i18n.T(i18n.MsgFixNoLocations))
+	}
+
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	p, err := selectProvider(cfg, *providerName)
+	if err != nil {
+		return err
+	}
+	params, err := sampling.resolve(cfg, p)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	key, _ := auth.Resolve(p)
+	opts := provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(p), Sampling: params}
+	prompt := buildFixPrompt(output, errs, strings.Join(fs.Args(), " "))
+	answer, err := chatOnce(ctx, cfg, p, opts, prompt, i18n.T(i18n.MsgFixThinking, len(errs)))
+	if err != nil {
+		return err
+	}
+	if err := renderMarkdown(answer); err != nil {
+		return err
+	}
+
+	diff, ok := diffCodeBlock(answer)
+	if !ok {
+		return errors.New(i18n.T(i18n.MsgFixNoDiff))
+	}
+	files, err := patch.Parse(diff)
+	if err != nil {
+		return err
+	}
+	changes, err := patch.Prepare(files)
+	if err != nil {
+		return errors.New(i18n.T(i18n.MsgFixNotApplied, err))
+	}
+	for _, c := range changes {
+		plan := i18n.T(i18n.MsgFixPlan, c.Path, c.Hunks)
+		if c.Fuzzy > 0 {
+			plan += i18n.T(i18n.MsgFixPlanFuzzy, c.Fuzzy)
+		}
+		if c.Merged {
+			plan += i18n.T(i18n.MsgFixPlanMerged)
+		}
+		if c.Conflicts > 0 {
+			plan += i18n.T(i18n.MsgFixPlanConflicts, c.Conflicts)
+		}
+		fmt.Fprintln(os.Stderr, plan)
+	}
+	if !confirm(i18n.T(i18n.MsgFixConfirm)) {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFixSkipped))
+		return nil
+	}
+	var conflicted []string
+	for i := range changes {
+		c := &changes[i]
+		if err := c.Write(); err != nil {
+			return err
+		}
+		if c.Backup != "" {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFixApplied, c.Path, c.Backup))
+		} else {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFixCreated, c.Path))
+		}
+		if c.Conflicts > 0 {
+			conflicted = append(conflicted, c.Path)
+		}
+	}
+	if len(conflicted) > 0 {
+		// 留有冲突标记时重新检查必然失败，先由用户解决冲突
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFixConflicts, strings.Join(conflicted, ", ")))
+		return exitCode(1)
+	}
+	if *check == "" {
+		return nil
+	}
+	return runCheck(ctx, *check)
+}
+
+// parseCompileErrors 找出输出中指向现有文件的错误位置，同一位置只保留一次，最多 maxFixErrors 个
+func parseCompileErrors(output string) []compileError {
+	var errs []compileError
+	seen := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		m := compileErrorRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		path := strings.TrimPrefix(m[1], "./")
+		number, _ := strconv.Atoi(m[2])
+		key := path + ":" + m[2]
+		if seen[key] || number < 1 {
+			continue
+		}
+		seen[key] = true
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		errs = append(errs, compileError{path: path, line: number})
+		if len(errs) == maxFixErrors {
+			break
+		}
+	}
+	return errs
+}
+
+// buildFixPrompt 组装修复提示词：编译输出、各文件出错位置前后的源码（带行号，出错行以 > 标出）与补充要求
+func buildFixPrompt(output string, errs []compileError, extra string) string {
+	var b strings.Builder
+	b.WriteString("The build failed with the output below. Explain each error in one or two sentences, " +
+		"then fix it with the smallest possible change.\n\nRequirements:\n" +
+		"- Reply with the explanation followed by exactly one ```diff code block containing a unified diff " +
+		"(--- a/path and +++ b/path headers with the paths exactly as shown, @@ hunk headers, three lines of context).\n" +
+		"- Only change what is needed to make the build pass; do not refactor, reformat or rename unrelated code.\n" +
+		"- Copy the context and removed lines exactly from the source shown, without the line numbers.\n")
+	if extra = strings.TrimSpace(extra); extra != "" {
+		b.WriteString("- " + extra + "\n")
+	}
+	if len(output) > maxFixOutputBytes {
+		output = output[:maxFixOutputBytes]
+		output = output[:strings.LastIndexByte(output, '\n')+1] + "..."
+	}
+	b.WriteString("\nBuild output:\n\n```\n" + output + "\n```\n")
+
+	// 按文件合并相邻的出错位置，文件按首次出现的顺序排列
+	var order []string
+	marked := map[string]map[int]bool{}
+	for _, e := range errs {
+		if marked[e.path] == nil {
+			order = append(order, e.path)
+			marked[e.path] = map[int]bool{}
+		}
+		marked[e.path][e.line] = true
+	}
+	for _, path := range order {
+		if excerpt := sourceExcerpt(path, marked[path]); excerpt != "" {
+			b.WriteString("\n" + path + ":\n\n```\n" + excerpt + "```\n")
+		}
+	}
+	return b.String()
+}
+
+// sourceExcerpt 文件中出错行前后 fixContextLines 行的源码，相邻的片段合并，片段之间以 ... 分隔
+func sourceExcerpt(path string, marked map[int]bool) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	numbers := make([]int, 0, len(marked))
+	for n := range marked {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+	width := len(strconv.Itoa(len(lines)))
+	var b strings.Builder
+	next := 0 // 下一行未输出的行（从 0 开始）
+	for _, n := range numbers {
+		from := max(n-1-fixContextLines, next)
+		to := min(n+fixContextLines, len(lines))
+		if from >= to {
+			continue
+		}
+		if next > 0 && from > next {
+			b.WriteString("...\n")
+		}
+		for i := from; i < to; i++ {
+			mark := " "
+			if marked[i+1] {
+				mark = ">"
+			}
+			fmt.Fprintf(&b, "%s%*d| %s\n", mark, width, i+1, lines[i])
+		}
+		next = to
+	}
+	return b.String()
+}
+
+// diffCodeBlock 取回答中第一个 diff / patch 代码块，没有标注语言时取含 +++ 文件头的代码块
+func diffCodeBlock(answer string) (string, bool) {
+	lines := strings.Split(answer, "\n")
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, "```") {
+			continue
+		}
+		lang := strings.ToLower(strings.TrimSpace(strings.TrimLeft(trimmed, "`")))
+		var code []string
+		for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+			code = append(code, lines[i])
+		}
+		block := strings.Join(code, "\n") + "\n"
+		if lang == "diff" || lang == "patch" || (lang == "" && strings.Contains(block, "\n+++ ")) {
+			return block, true
+		}
+	}
+	return "", false
+}
+
+// runCheck 应用修复后重新运行检查命令，输出转到 stderr；仍然失败时以 1 退出
+func runCheck(ctx context.Context, command string) error {
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFixChecking, command))
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFixCheckPassed, command))
+		return nil
+	case ctx.Err() != nil:
+		return interrupted(ctx, err)
+	case !errors.As(err, &exitErr):
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFixCheckFailed, command))
+	return exitCode(1)
+}
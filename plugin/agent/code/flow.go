@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/flow"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/spinner"
+)
+
+// flowStepResult 单个步骤的执行结果（--json 输出）
+type flowStepResult struct {
+	ID         string `json:"id"`
+	Provider   string `json:"provider"`
+	Model      string `json:"model"`
+	Output     string `json:"output"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// runFlow agent flow run [--input file] [--var k=v]... [--verbose] [--json] pipeline.yaml
+func runFlow(args []string) error {
+	if len(args) == 0 || args[0] != "run" {
+		return errors.New(i18n.T(i18n.MsgFlowUsage))
+	}
+	fs := flag.NewFlagSet("flow run", flag.ContinueOnError)
+	inputPath := fs.String("input", "", "file whose content becomes {{input}} (- for stdin; default: piped stdin, then the pipeline's input)")
+	var varList stringList
+	fs.Var(&varList, "var", "set {{vars.key}} as key=value; @file, @- (stdin) or @clip read the value (repeatable, overrides the pipeline's vars)")
+	verbose := fs.Bool("verbose", false, "print every step's output to stderr as it finishes")
+	asJSON := fs.Bool("json", false, "print all step outputs as JSON instead of the final output")
+	registerSendFlags(fs)
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New(i18n.T(i18n.MsgFlowUsage))
+	}
+	pipeline, err := flow.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	vars := map[string]string{}
+	for k, v := range pipeline.Vars {
+		vars[k] = v
+	}
+	given, err := parseVars(varList)
+	if err != nil {
+		return err
+	}
+	for k, v := range given {
+		vars[k] = v
+	}
+	input, err := readFlowInput(*inputPath, pipeline.Input)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	outputs := map[string]string{}
+	var results []flowStepResult
+	for i, step := range pipeline.Steps {
+		r, err := runFlowStep(ctx, cfg, pipeline, step, input, vars, outputs, i, len(pipeline.Steps))
+		if err != nil {
+			if ctx.Err() != nil {
+				fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgAskCancelled))
+				return exitCode(130)
+			}
+			return errors.New(i18n.T(i18n.MsgFlowFailed, step.ID, err))
+		}
+		outputs[step.ID] = r.Output
+		results = append(results, r)
+		if *verbose {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgFlowStep, r.ID, r.Provider, float64(r.DurationMs)/1000))
+			fmt.Fprintln(os.Stderr, strings.TrimRight(masked(r.Output), "\n"))
+		}
+	}
+
+	// 只在输出时脱敏，传给后续步骤的仍是原文
+	final := masked(outputs[pipeline.OutputStep()])
+	if *asJSON {
+		for i := range results {
+			results[i].Output = masked(results[i].Output)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Name   string           `json:"name,omitempty"`
+			Steps  []flowStepResult `json:"steps"`
+			Output string           `json:"output"`
+		}{pipeline.Name, results, final})
+	}
+	fmt.Println(strings.TrimRight(final, "\n"))
+	return nil
+}
+
+// runFlowStep 渲染并执行一个步骤：provider 取 步骤 → 流水线 → 当前 provider，
+// 采样参数在 provider 默认值上叠加步骤配置
+func runFlowStep(ctx context.Context, cfg config.AgentConfig, pipeline *flow.Pipeline, step flow.Step, input string, vars, outputs map[string]string, index, total int) (flowStepResult, error) {
+	var (
+		p   config.Provider
+		err error
+	)
+	switch name := firstNonEmpty(step.Provider, pipeline.Provider); name {
+	case "":
+		p, err = selectProvider(cfg, "")
+	default:
+		p, err = lookupProvider(cfg, name)
+	}
+	if err != nil {
+		return flowStepResult{}, err
+	}
+	var sampling config.Sampling
+	if p.Sampling != nil {
+		sampling = *p.Sampling
+	}
+	sampling = sampling.Override(config.Sampling{Temperature: step.Temperature, TopP: step.TopP})
+	if err := provider.ValidateSampling(p, sampling); err != nil {
+		return flowStepResult{}, err
+	}
+
+	prompt, err := flow.Render(step.Prompt, input, vars, outputs)
+	if err != nil {
+		return flowStepResult{}, err
+	}
+	system := firstNonEmpty(step.System, pipeline.System)
+	if system == "" {
+		system = config.LoadSystemPrompt(cfg)
+	} else if system, err = flow.Render(system, input, vars, outputs); err != nil {
+		return flowStepResult{}, err
+	}
+	var messages []provider.Message
+	if system != "" {
+		messages = append(messages, provider.Message{Role: "system", Content: system})
+	}
+	messages = append(messages, provider.Message{Role: "user", Content: prompt})
+
+	key, _ := auth.Resolve(p)
+	client, err := newClient(p, provider.Options{
+		Key:       key,
+		Timeouts:  cfg.EffectiveTimeouts(p),
+		Sampling:  sampling,
+		MaxTokens: step.MaxTokens,
+	})
+	if err != nil {
+		return flowStepResult{}, err
+	}
+
+	status := i18n.T(i18n.MsgFlowRunning, index+1, total, step.ID, p.Name)
+	spin := spinner.Start(status)
+	defer spin.Stop()
+	start := time.Now()
+	answer, err := client.Chat(withSpinner(ctx, spin, status), messages, nil)
+	if errors.Is(err, provider.ErrTruncated) {
+		// 截断的输出仍交给后续步骤，只提示一次
+		spin.Stop()
+		warnTruncated()
+		err = nil
+	}
+	if err != nil {
+		return flowStepResult{}, err
+	}
+	return flowStepResult{
+		ID:         step.ID,
+		Provider:   p.Name,
+		Model:      p.Model,
+		Output:     answer,
+		DurationMs: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// readFlowInput 确定 {{input}}：--input 文件（- 为 stdin）→ 管道输入 → 流水线中的 input 默认值
+func readFlowInput(path, fallback string) (string, error) {
+	var r io.Reader
+	switch {
+	case path == "-":
+		r = os.Stdin
+	case path != "":
+		data, err := os.ReadFile(path)
+		return strings.TrimSpace(string(data)), err
+	case !term.IsTerminal(int(os.Stdin.Fd())):
+		r = os.Stdin
+	default:
+		return fallback, nil
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if text := strings.TrimSpace(string(data)); text != "" {
+		return text, nil
+	}
+	return fallback, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
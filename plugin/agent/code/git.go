@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/i18n"
+)
+
+const (
+	// hookMarker 写在 agent 安装的钩子中，卸载与覆盖时据此识别
+	hookMarker = "# installed by agent git install-hooks"
+	// hookBackupSuffix 安装时已有的其他钩子改名保存的后缀，卸载时恢复
+	hookBackupSuffix = ".j-backup"
+	// maxGitDiffBytes 提示词中 diff 的长度上限
+	maxGitDiffBytes = 24 << 10
+	// gitHookTimeout 钩子中等待模型的时限，超时就放弃，不让提交或推送久等
+	gitHookTimeout = 60 * time.Second
+	// zeroSHA pre-push 输入中表示"不存在"的提交
+	zeroSHA = "0000000000000000000000000000000000000000"
+)
+
+// hookScript 钩子内容：agent 不存在或 J_GIT_HOOKS=off 时什么也不做；总以 0 退出，不因生成失败而阻止提交或推送
+const hookScript = `#!/bin/sh
+%s (remove with: agent git uninstall-hooks)
+[ "${J_GIT_HOOKS:-}" = off ] && exit 0
+[ -x %s ] || exit 0
+%s git %s "$@"
+exit 0
+`
+
+// runGit agent git install-hooks | uninstall-hooks | commit-msg | pre-push：
+// 后两个由安装的钩子调用
+func runGit(args []string) error {
+	if len(args) == 0 {
+		return errors.New(i18n.T(i18n.MsgGitUsage))
+	}
+	switch args[0] {
+	case "install-hooks":
+		return gitInstallHooks(args[1:])
+	case "uninstall-hooks":
+		return gitUninstallHooks(args[1:])
+	case "commit-msg":
+		return gitCommitMsg(args[1:])
+	case "pre-push":
+		return gitPrePush(args[1:])
+	}
+	return errors.New(i18n.T(i18n.MsgGitUsage))
+}
+
+// gitInstallHooks agent git install-hooks [--pre-push] [--force]：安装 prepare-commit-msg（生成提交信息），
+// --pre-push 另装 pre-push（推送前总结待推送的提交）；已有其他钩子时需 --force，原钩子改名为 *.j-backup
+func gitInstallHooks(args []string) error {
+	fs := flag.NewFlagSet("git install-hooks", flag.ContinueOnError)
+	prePush := fs.Bool("pre-push", false, "also install a pre-push hook that reviews the outgoing commits")
+	force := fs.Bool("force", false, "replace existing hooks (they are kept as <hook>.j-backup and restored on uninstall)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dir, err := hooksDir()
+	if err != nil {
+		return err
+	}
+	agent, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	// 钩子名与对应的 agent git 子命令
+	hooks := [][2]string{{"prepare-commit-msg", "commit-msg"}}
+	if *prePush {
+		hooks = append(hooks, [2]string{"pre-push", "pre-push"})
+	}
+	// 先检查全部钩子，不留下装了一半的状态
+	foreign := map[string]bool{}
+	for _, hook := range hooks {
+		path := filepath.Join(dir, hook[0])
+		if data, err := os.ReadFile(path); err == nil && !strings.Contains(string(data), hookMarker) {
+			if !*force {
+				return errors.New(i18n.T(i18n.MsgGitHookExists, path))
+			}
+			foreign[path] = true
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		path, sub := filepath.Join(dir, hook[0]), hook[1]
+		if foreign[path] {
+			if err := os.Rename(path, path+hookBackupSuffix); err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgGitHookBackedUp, path+hookBackupSuffix))
+		}
+		bin := quoteArgs([]string{agent})[0]
+		script := fmt.Sprintf(hookScript, hookMarker, bin, bin, sub)
+		if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T(i18n.MsgGitHookInstalled, path))
+	}
+	return nil
+}
+
+// gitUninstallHooks agent git uninstall-hooks：删除 agent 安装的钩子并恢复备份，其他钩子不动
+func gitUninstallHooks(args []string) error {
+	if len(args) > 0 {
+		return errors.New(i18n.T(i18n.MsgGitUsage))
+	}
+	dir, err := hooksDir()
+	if err != nil {
+		return err
+	}
+	removed := 0
+	for _, name := range []string{"prepare-commit-msg", "pre-push"} {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil || !strings.Contains(string(data), hookMarker) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		fmt.Println(i18n.T(i18n.MsgGitHookRemoved, path))
+		if _, err := os.Stat(path + hookBackupSuffix); err == nil {
+			if err := os.Rename(path+hookBackupSuffix, path); err != nil {
+				return err
+			}
+			fmt.Println(i18n.T(i18n.MsgGitHookRestored, path))
+		}
+	}
+	if removed == 0 {
+		fmt.Println(i18n.T(i18n.MsgGitNoHooks))
+	}
+	return nil
+}
+
+// hooksDir 当前仓库的钩子目录（遵循 core.hooksPath）
+func hooksDir() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", errors.New(i18n.T(i18n.MsgGitNotRepo))
+	}
+	return filepath.Abs(strings.TrimSpace(string(out)))
+}
+
+// gitCommitMsg agent git commit-msg <文件> [来源] [提交]：prepare-commit-msg 钩子调用。
+// 只在普通的 git commit（没有 -m、模板、合并、squash 或 --amend）且信息文件中没有内容时，
+// 按暂存区的改动生成提交信息写在注释之前，编辑器打开后可以修改；任何失败都只提示，不阻止提交
+func gitCommitMsg(args []string) error {
+	if len(args) == 0 {
+		return errors.New(i18n.T(i18n.MsgGitUsage))
+	}
+	file := args[0]
+	if len(args) > 1 && args[1] != "" {
+		return nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	comment := commentChar()
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, comment) {
+			return nil
+		}
+	}
+	diff, err := gitOutput("diff", "--cached", "--no-color", "--no-ext-diff")
+	if err != nil || strings.TrimSpace(diff) == "" {
+		return nil
+	}
+	stat, _ := gitOutput("diff", "--cached", "--stat", "--no-color")
+	recent, _ := gitOutput("log", "-n", "10", "--no-merges", "--format=%s")
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitHookTimeout)
+	defer cancel()
+	answer, err := shellChat(ctx, "", commitMsgPrompt(stat, clipDiff(diff), recent), i18n.T(i18n.MsgGitGenerating))
+	if err != nil {
+		hookSkipped(err)
+		return nil
+	}
+	message := strings.TrimSpace(extractCommand(answer))
+	if message == "" {
+		return nil
+	}
+	return os.WriteFile(file, []byte(message+"\n"+string(data)), 0o644)
+}
+
+// commitMsgPrompt 请模型按仓库的既有风格写提交信息
+func commitMsgPrompt(stat, diff, recent string) string {
+	var b strings.Builder
+	b.WriteString("Write a git commit message for the staged changes below.\n" +
+		"Reply with the message only (no Markdown, no code fence): a subject line of at most 72 characters " +
+		"in the imperative mood, then, only if the change needs it, a blank line and a short body wrapped at 72 columns " +
+		"explaining what and why.\n")
+	if recent = strings.TrimSpace(recent); recent != "" {
+		b.WriteString("Match the style and language of the repository's recent subjects:\n\n" + recent + "\n")
+	}
+	b.WriteString("\nChanged files:\n\n" + stat + "\nDiff:\n\n```diff\n" + diff + "```\n")
+	return b.String()
+}
+
+// gitPrePush agent git pre-push <远程> <地址>：pre-push 钩子调用，stdin 为待推送的引用
+// （"本地引用 本地提交 远程引用 远程提交"），输出待推送提交的总结与需要留意的风险；不阻止推送
+func gitPrePush(args []string) error {
+	remote := "origin"
+	if len(args) > 0 {
+		remote = args[0]
+	}
+	var ranges [][]string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 || fields[1] == zeroSHA {
+			continue // 删除远程分支
+		}
+		if fields[3] == zeroSHA {
+			ranges = append(ranges, []string{fields[1], "--not", "--remotes=" + remote})
+		} else {
+			ranges = append(ranges, []string{fields[3] + ".." + fields[1]})
+		}
+	}
+	var log, diff strings.Builder
+	for _, r := range ranges {
+		out, err := gitOutput(append([]string{"log", "--no-color", "--format=%h %s"}, r...)...)
+		if err != nil {
+			continue
+		}
+		log.WriteString(out)
+		if d, err := gitOutput(append([]string{"log", "--no-color", "--no-ext-diff", "-p", "--format=commit %h%n%n%B"}, r...)...); err == nil {
+			diff.WriteString(d)
+		}
+	}
+	if strings.TrimSpace(log.String()) == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gitHookTimeout)
+	defer cancel()
+	answer, err := shellChat(ctx, "", prePushPrompt(log.String(), clipDiff(diff.String())), i18n.T(i18n.MsgGitReviewing))
+	if err != nil {
+		hookSkipped(err)
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgGitReviewTitle, remote))
+	return renderMarkdown(answer)
+}
+
+// prePushPrompt 请模型总结待推送的提交并指出风险
+func prePushPrompt(log, diff string) string {
+	return fmt.Sprintf(`These commits are about to be pushed. Review them briefly for the author, in the language %q:
+- one line summarising what the push contains;
+- then a short list of anything worth a second look before pushing: likely bugs, leftover debug code,
+  secrets or credentials, unintended files, missing tests. Say so in one line if nothing stands out.
+
+Commits:
+
+%s
+Changes:
+
+`+"```diff\n%s```\n", string(i18n.Current()), log, diff)
+}
+
+// hookSkipped 生成失败时提示一行（Ctrl-C 的中断提示已经输出过）
+func hookSkipped(err error) {
+	var code exitCode
+	if !errors.As(err, &code) {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgGitSkipped, err))
+	}
+}
+
+// gitOutput 运行 git 并返回 stdout
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	return string(out), err
+}
+
+// commentChar 提交信息中的注释字符（core.commentChar，默认 #）
+func commentChar() string {
+	c, _ := gitOutput("config", "core.commentChar")
+	if c = strings.TrimSpace(c); c == "" || c == "auto" {
+		return "#"
+	}
+	return c
+}
+
+// clipDiff 截断过长的 diff，保留到最后一个完整的行
+func clipDiff(diff string) string {
+	if len(diff) <= maxGitDiffBytes {
+		return diff
+	}
+	diff = diff[:maxGitDiffBytes]
+	return diff[:strings.LastIndexByte(diff, '\n')+1] + "...\n"
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"wcp_agent/internal/audit"
+	"wcp_agent/internal/guard"
+	"wcp_agent/internal/i18n"
+)
+
+// guardConfirmWord 执行高风险命令前需要输入的确认词
+const guardConfirmWord = "yes"
+
+// agent guard check 的退出码：没有风险为 0
+const (
+	guardExitWarn = exitCode(1) // 只有提示
+	guardExitHigh = exitCode(2) // 有高风险
+)
+
+// guardExitConfirmed agent guard confirm 中高风险命令已输入确认词，调用方不必再次确认
+const guardExitConfirmed = exitCode(2)
+
+// runGuard agent guard check | confirm | rules：生成的命令在执行前经它做静态风险分析，
+// 规则可在 guard.json 中扩展（见 internal/guard）
+func runGuard(args []string) error {
+	if len(args) == 0 {
+		return errors.New(i18n.T(i18n.MsgGuardUsage))
+	}
+	switch args[0] {
+	case "check":
+		return guardCheck(args[1:])
+	case "confirm":
+		return guardConfirm(args[1:])
+	case "rules":
+		return guardRules(args[1:])
+	}
+	return errors.New(i18n.T(i18n.MsgGuardUsage))
+}
+
+// guardCheck agent guard check [--brief|--json] [--cwd 目录] -- <命令>：列出风险，
+// 没有风险时以 0 退出，只有提示时以 1、有高风险时以 2 退出；--brief 没有风险时不输出，供 shell 小部件显示
+func guardCheck(args []string) error {
+	fs := flag.NewFlagSet("guard check", flag.ContinueOnError)
+	brief := fs.Bool("brief", false, "one line per risk and no output when there is none")
+	asJSON := fs.Bool("json", false, "print the findings as a JSON array")
+	cwd := fs.String("cwd", "", "directory the command would run in (default: the current one)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	command, err := readPrompt(fs.Args())
+	if err != nil {
+		return err
+	}
+	findings, err := analyzeCommand(command, *cwd)
+	if err != nil {
+		return err
+	}
+	switch {
+	case *asJSON:
+		if findings == nil {
+			findings = []guard.Finding{}
+		}
+		if err := json.NewEncoder(os.Stdout).Encode(findings); err != nil {
+			return err
+		}
+	case len(findings) == 0 && !*brief:
+		fmt.Println(i18n.T(i18n.MsgGuardNone))
+	default:
+		printFindings(os.Stdout, findings)
+	}
+	switch guard.Highest(findings) {
+	case guard.LevelHigh:
+		return guardExitHigh
+	case guard.LevelWarn:
+		return guardExitWarn
+	}
+	return nil
+}
+
+// guardConfirm agent guard confirm [--source 来源] -- <命令>：供执行生成命令的钩子在 eval 之前调用。
+// 提示输出到 stderr；没有高风险时以 0 退出（调用方照常确认），高风险时要求在终端中输入确认词：
+// 输入正确以 2 退出（已确认，直接执行），否则以 1 退出并记入审计日志
+func guardConfirm(args []string) error {
+	fs := flag.NewFlagSet("guard confirm", flag.ContinueOnError)
+	source := fs.String("source", "shell", "caller recorded in the audit log when the command is refused")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	command, err := readPrompt(fs.Args())
+	if err != nil {
+		return err
+	}
+	findings, err := analyzeCommand(command, "")
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	printFindings(os.Stderr, findings)
+	if guard.Highest(findings) != guard.LevelHigh {
+		return nil
+	}
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgGuardNoTerminal))
+		audit.Record("guard/"+*source, command, audit.DecisionBlocked, nil)
+		return exitCode(1)
+	}
+	defer tty.Close()
+	fmt.Fprint(os.Stderr, i18n.T(i18n.MsgGuardTypeConfirm, guardConfirmWord))
+	line, _ := bufio.NewReader(tty).ReadString('\n')
+	if strings.TrimSpace(line) != guardConfirmWord {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgGuardDeclined))
+		audit.Record("guard/"+*source, command, audit.DecisionRejected, nil)
+		return exitCode(1)
+	}
+	return guardExitConfirmed
+}
+
+// guardRules agent guard rules：列出内置规则与 guard.json 中的用户规则
+func guardRules(args []string) error {
+	if len(args) > 0 {
+		return errors.New(i18n.T(i18n.MsgGuardUsage))
+	}
+	rs, err := guard.Load()
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.T(i18n.MsgGuardBuiltinTitle))
+	for _, r := range guard.Builtin {
+		state := r.Level
+		if rs.Disable[r.Name] {
+			state = i18n.T(i18n.MsgGuardDisabled)
+		}
+		fmt.Printf("  %-15s %-10s %s\n", r.Name, state, i18n.T(r.Summary))
+	}
+	fmt.Println()
+	if len(rs.Rules) == 0 && len(rs.Allow) == 0 {
+		fmt.Println(i18n.T(i18n.MsgGuardNoUserRules, guard.Path()))
+		return nil
+	}
+	fmt.Println(i18n.T(i18n.MsgGuardUserTitle, guard.Path()))
+	for _, r := range rs.Rules {
+		state := r.Level
+		if rs.Disable[r.Name] {
+			state = i18n.T(i18n.MsgGuardDisabled)
+		}
+		fmt.Printf("  %-15s %-10s %s  %s\n", r.Name, state, r.Pattern, r.Reason)
+	}
+	for _, re := range rs.Allow {
+		fmt.Printf("  %-15s %-10s %s\n", "allow", "-", re)
+	}
+	return nil
+}
+
+// analyzeCommand 以 cwd（为空时为当前目录）为工作目录分析命令
+func analyzeCommand(command, cwd string) ([]guard.Finding, error) {
+	rs, err := guard.Load()
+	if err != nil {
+		return nil, err
+	}
+	if cwd == "" {
+		if cwd, err = os.Getwd(); err != nil {
+			return nil, err
+		}
+	}
+	return rs.Analyze(command, cwd), nil
+}
+
+// printFindings 每处风险一行，高风险在前
+func printFindings(w io.Writer, findings []guard.Finding) {
+	for _, level := range []string{guard.LevelHigh, guard.LevelWarn} {
+		label := i18n.T(i18n.MsgGuardWarn)
+		if level == guard.LevelHigh {
+			label = i18n.T(i18n.MsgGuardHigh)
+		}
+		for _, f := range findings {
+			if f.Level == level {
+				fmt.Fprintf(w, "⚠ %s: %s [%s]\n", label, f.Reason, f.Rule)
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"wcp_agent/internal/i18n"
+)
+
+// helpDocs 内嵌的帮助文档：help/agent.md 为总览，help/<命令>.md 为各命令的说明与示例
+//
+//go:embed help/*.md
+var helpDocs embed.FS
+
+var (
+	// flagsMarker 文档中由选项表替换的位置，可带子命令（如 <!-- flags list -->）
+	flagsMarker = regexp.MustCompile(`<!-- flags( [a-z-]+)? -->`)
+	// flagDefault flag 包在选项说明末尾给出的默认值
+	flagDefault = regexp.MustCompile(`\s*\(default (.*)\)$`)
+)
+
+// 在 init 中注册：help 要读取命令表，写进 commands 的字面量会形成初始化循环
+func init() {
+	commands["help"] = command{runHelp, i18n.MsgHelpSummary}
+}
+
+// runHelp agent help [命令]：经 md_render 渲染内嵌的帮助文档（说明、示例与选项表），不给命令时为总览
+func runHelp(args []string) error {
+	if len(args) > 1 {
+		return errors.New(i18n.T(i18n.MsgHelpUsage))
+	}
+	topic := ""
+	if len(args) == 1 {
+		topic = args[0]
+	}
+	doc, err := helpMarkdown(topic)
+	if err != nil {
+		return err
+	}
+	return renderMarkdown(doc)
+}
+
+// helpMarkdown 命令的帮助文档（Markdown）：help/<命令>.md 中的 <!-- flags [子命令] --> 换成由该（子）命令的选项生成的表格，
+// 没有文档的命令由一句话介绍与选项表生成；topic 为空时为总览，其中的 <!-- commands --> 换成命令列表
+func helpMarkdown(topic string) (string, error) {
+	if topic == "" {
+		data, err := helpDocs.ReadFile("help/agent.md")
+		if err != nil {
+			return "", err
+		}
+		return strings.Replace(string(data), "<!-- commands -->", commandTable(), 1), nil
+	}
+	cmd, ok := commands[topic]
+	if !ok {
+		return "", errors.New(i18n.T(i18n.MsgHelpUnknown, topic))
+	}
+	data, err := helpDocs.ReadFile("help/" + topic + ".md")
+	if err != nil {
+		doc := fmt.Sprintf("# agent %s\n\n%s\n", topic, i18n.T(cmd.summary))
+		if table := flagTable(topic, ""); table != "" {
+			doc += "\n## " + i18n.T(i18n.MsgHelpOptions) + "\n\n" + table
+		}
+		return doc, nil
+	}
+	return flagsMarker.ReplaceAllStringFunc(string(data), func(m string) string {
+		sub := strings.TrimSpace(flagsMarker.FindStringSubmatch(m)[1])
+		return strings.TrimSuffix(flagTable(topic, sub), "\n")
+	}), nil
+}
+
+// commandTable 全部命令与一句话介绍的表格
+func commandTable() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString(i18n.T(i18n.MsgHelpCommandsHeader) + "\n|---|---|\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "| `%s` | %s |\n", name, cell(i18n.T(commands[name].summary)))
+	}
+	return b.String()
+}
+
+// flagDoc 一个选项的说明
+type flagDoc struct {
+	name, kind, usage, def string
+}
+
+// flagTable 命令（sub 非空时为其子命令）的选项表，没有选项或命令不接受 -h 时为空
+func flagTable(name, sub string) string {
+	flags := commandFlags(name, sub)
+	if len(flags) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T(i18n.MsgHelpFlagsHeader) + "\n|---|---|---|\n")
+	for _, f := range flags {
+		opt := "`--" + f.name
+		if len(f.name) == 1 {
+			opt = "`-" + f.name
+		}
+		if f.kind != "" {
+			opt += " " + f.kind
+		}
+		opt += "`"
+		def := ""
+		if f.def != "" {
+			def = "`" + strings.ReplaceAll(f.def, "`", "'") + "`"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", opt, cell(f.usage), def)
+	}
+	return b.String()
+}
+
+// commandFlags 以 -h 在本进程中调用命令，解析 flag 包写到 stderr 的选项说明（PrintDefaults 的格式）；
+// 各命令都先解析参数再做其他事情，-h 只会让它们输出说明后返回 flag.ErrHelp。
+// 需要先给出子命令的命令在 sub 为空时直接报用法错误，结果为空
+func commandFlags(name, sub string) []flagDoc {
+	args := []string{"-h"}
+	if sub != "" {
+		args = []string{sub, "-h"}
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil
+	}
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	stderr := os.Stderr
+	os.Stderr = w
+	err = commands[name].run(args)
+	os.Stderr = stderr
+	w.Close()
+	text := <-out
+	r.Close()
+	if !errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return parseDefaults(text)
+}
+
+// parseDefaults 解析 PrintDefaults 的输出：每个选项一行 "  -name type"（短的布尔选项说明紧跟在 tab 之后），
+// 说明在其后以 "    \t" 开头的行中，多行说明合成一行
+func parseDefaults(text string) []flagDoc {
+	var flags []flagDoc
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "  -"):
+			head, usage, _ := strings.Cut(strings.TrimPrefix(line, "  -"), "\t")
+			name, kind, _ := strings.Cut(strings.TrimSpace(head), " ")
+			flags = append(flags, flagDoc{name: name, kind: kind, usage: strings.TrimSpace(usage)})
+		case strings.HasPrefix(line, "    \t") && len(flags) > 0:
+			f := &flags[len(flags)-1]
+			f.usage = strings.TrimSpace(f.usage + " " + strings.TrimSpace(line))
+		}
+	}
+	for i := range flags {
+		if m := flagDefault.FindStringSubmatchIndex(flags[i].usage); m != nil {
+			flags[i].def = flags[i].usage[m[2]:m[3]]
+			flags[i].usage = flags[i].usage[:m[0]]
+		}
+	}
+	return flags
+}
+
+// cell 表格单元格中的文字：竖线转义
+func cell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+)
+
+// runHistory agent history list [-n N] | show <id> | pick [--copy | --ask [追问]] | regen <id> [--model M] |
+// fork <id> [追问] | tree [id] [-n N]
+func runHistory(args []string) error {
+	if len(args) == 0 {
+		return errors.New(i18n.T(i18n.MsgHistoryUsage))
+	}
+	switch args[0] {
+	case "list":
+		return historyList(args[1:])
+	case "show":
+		return historyShow(args[1:])
+	case "pick":
+		return historyPick(args[1:])
+	case "regen":
+		return historyRegen(args[1:])
+	case "fork":
+		return historyFork(args[1:])
+	case "tree":
+		return historyTree(args[1:])
+	default:
+		return errors.New(i18n.T(i18n.MsgHistoryUsage))
+	}
+}
+
+func historyList(args []string) error {
+	fs := flag.NewFlagSet("history list", flag.ContinueOnError)
+	limit := fs.Int("n", 20, "number of most recent exchanges to show (0 for all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	list, err := history.Load()
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Println(i18n.T(i18n.MsgHistoryEmpty))
+		return nil
+	}
+	titles := history.Titles(list)
+	if *limit > 0 && len(list) > *limit {
+		list = list[len(list)-*limit:]
+	}
+	for _, e := range list {
+		mark := ""
+		if e.Status != history.StatusOK {
+			mark = " [" + e.Status + "]"
+		}
+		fmt.Printf("%s  %s  %-12s %s%s\n", e.ID, e.Time.Local().Format("2006-01-02 15:04"), e.Provider, oneLine(masked(exchangeLabel(e, titles, 0)), 72), mark)
+	}
+	return nil
+}
+
+func historyShow(args []string) error {
+	if len(args) != 1 {
+		return errors.New(i18n.T(i18n.MsgHistoryUsage))
+	}
+	e, ok, err := history.Find(args[0])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New(i18n.T(i18n.MsgHistoryNotFound, args[0]))
+	}
+	fmt.Println(i18n.T(i18n.MsgHistoryHeader, e.ID, e.Time.Local().Format("2006-01-02 15:04:05"), e.Provider, e.Model, e.Status))
+	if e.Title != "" {
+		fmt.Println(i18n.T(i18n.MsgHistoryTitle, e.Title))
+	}
+	if e.Parent != "" {
+		fmt.Println(i18n.T(i18n.MsgHistoryParent, e.Parent))
+	}
+	fmt.Println()
+	fmt.Println("> " + strings.ReplaceAll(masked(e.Prompt), "\n", "\n> "))
+	fmt.Println()
+	fmt.Println(masked(e.Answer))
+	if e.Error != "" {
+		fmt.Println()
+		fmt.Println(i18n.T(i18n.MsgError, e.Error))
+	}
+	return nil
+}
+
+// historyFork agent history fork <id> [ask 的选项] [追问]：以某一轮及其之前的对话为上文另起一个分支，
+// 原来那一轮之后的对话不受影响；没有给出追问时与 agent ask 一样从终端或 stdin 读取
+func historyFork(args []string) error {
+	if len(args) == 0 {
+		return errors.New(i18n.T(i18n.MsgHistoryForkUsage))
+	}
+	list, err := history.Load()
+	if err != nil {
+		return err
+	}
+	e, ok := history.Lookup(list, args[0])
+	if !ok {
+		return errors.New(i18n.T(i18n.MsgHistoryNotFound, args[0]))
+	}
+	thread, _, err := history.Thread(e.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgHistoryForkFrom, e.ID, len(thread)-1, len(children(list)[e.ID])))
+	known := map[string]bool{}
+	for _, x := range list {
+		known[x.ID] = true
+	}
+	if err := followUp([]string{"--continue", e.ID}, args[1:]); err != nil {
+		return err
+	}
+	after, err := history.Load()
+	if err != nil {
+		return err
+	}
+	for i := len(after) - 1; i >= 0; i-- {
+		if x := after[i]; x.Parent == e.ID && !known[x.ID] {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgHistoryForked, x.ID))
+			break
+		}
+	}
+	return nil
+}
+
+// historyTree agent history tree [id] [-n N]：按 --continue / fork 的接续关系把问答画成树；
+// 给出 id 时画出它所在的那段对话并标出它，否则画出最近 N 段有多轮的对话
+func historyTree(args []string) error {
+	fs := flag.NewFlagSet("history tree", flag.ContinueOnError)
+	limit := fs.Int("n", 10, "number of most recent conversations to show (0 for all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errors.New(i18n.T(i18n.MsgHistoryUsage))
+	}
+	list, err := history.Load()
+	if err != nil {
+		return err
+	}
+	kids := children(list)
+	byID := make(map[string]history.Exchange, len(list))
+	for _, e := range list {
+		byID[e.ID] = e
+	}
+	if fs.NArg() == 1 {
+		target, ok := history.Lookup(list, fs.Arg(0))
+		if !ok {
+			return errors.New(i18n.T(i18n.MsgHistoryNotFound, fs.Arg(0)))
+		}
+		printTree(kids, rootOf(byID, target), target.ID)
+		return nil
+	}
+	// 从最新的一轮往前找，每段对话只算一次，最近活跃的对话排在最后
+	var roots []history.Exchange
+	seen := map[string]bool{}
+	for i := len(list) - 1; i >= 0 && (*limit <= 0 || len(roots) < *limit); i-- {
+		root := rootOf(byID, list[i])
+		if seen[root.ID] {
+			continue
+		}
+		seen[root.ID] = true
+		if len(kids[root.ID]) > 0 {
+			roots = append(roots, root)
+		}
+	}
+	if len(roots) == 0 {
+		fmt.Println(i18n.T(i18n.MsgHistoryTreeNone))
+		return nil
+	}
+	for i := len(roots) - 1; i >= 0; i-- {
+		printTree(kids, roots[i], "")
+		if i > 0 {
+			fmt.Println()
+		}
+	}
+	return nil
+}
+
+// children 每一轮之后接出的各轮，按时间先后排列
+func children(list []history.Exchange) map[string][]history.Exchange {
+	kids := map[string][]history.Exchange{}
+	for _, e := range list {
+		if e.Parent != "" {
+			kids[e.Parent] = append(kids[e.Parent], e)
+		}
+	}
+	return kids
+}
+
+// rootOf e 所在对话的第一轮；上一轮已不在历史中（被裁剪）时，最早还在的那一轮即为根
+func rootOf(byID map[string]history.Exchange, e history.Exchange) history.Exchange {
+	seen := map[string]bool{e.ID: true}
+	for {
+		parent, ok := byID[e.Parent]
+		if e.Parent == "" || !ok || seen[parent.ID] {
+			return e
+		}
+		seen[parent.ID] = true
+		e = parent
+	}
+}
+
+// printTree 画出以 root 为根的对话树：先是对话标题、轮数与分支数，再每轮一行；mark 非空时在该轮后加上 ←
+func printTree(kids map[string][]history.Exchange, root history.Exchange, mark string) {
+	var lines []string
+	var turns, leaves int
+	seen := map[string]bool{}
+	var walk func(e history.Exchange, prefix, branch, indent string)
+	walk = func(e history.Exchange, prefix, branch, indent string) {
+		seen[e.ID] = true
+		turns++
+		line := fmt.Sprintf("%s%s%s  %s  %-12s %s", prefix, branch, e.ID, e.Time.Local().Format("2006-01-02 15:04"), e.Provider, oneLine(e.Prompt, 50))
+		if e.Status != history.StatusOK {
+			line += " [" + e.Status + "]"
+		}
+		if e.RegeneratedFrom != "" {
+			line += " " + i18n.T(i18n.MsgHistoryTreeRegen, e.RegeneratedFrom)
+		}
+		if e.ID == mark {
+			line += "  ←"
+		}
+		lines = append(lines, line)
+		var next []history.Exchange
+		for _, c := range kids[e.ID] {
+			if !seen[c.ID] {
+				next = append(next, c)
+			}
+		}
+		if len(next) == 0 {
+			leaves++
+		}
+		for i, c := range next {
+			if i == len(next)-1 {
+				walk(c, prefix+indent, "└─ ", "   ")
+			} else {
+				walk(c, prefix+indent, "├─ ", "│  ")
+			}
+		}
+	}
+	walk(root, "", "", "")
+	title := root.Title
+	if title == "" {
+		title = oneLine(root.Prompt, 40)
+	}
+	fmt.Println(i18n.T(i18n.MsgHistoryTreeHeader, root.ID, title, turns, leaves))
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+}
+
+// oneLine 将多行文本压成一行并按字符截断，用于列表展示
+func oneLine(s string, limit int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) > limit {
+		return string(r[:limit-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,38 @@
+// Package a11y 无障碍模式：为读屏软件去掉加载动画、框线、就地重绘与只靠颜色区分的信息，改为逐行输出的纯文本。
+// 默认关闭：J_ACCESSIBLE 或 config.yaml 的 setting.accessible 开启；j 主程序与 md_render 读取同一开关。
+package a11y
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"wcp_agent/internal/config"
+)
+
+const (
+	// EnableEnv 开关环境变量（优先级高于配置文件）
+	EnableEnv = "J_ACCESSIBLE"
+	// SettingEnabled config.yaml 中 setting 段的开关项
+	SettingEnabled = "accessible"
+)
+
+var (
+	enabledOnce sync.Once
+	enabled     bool
+)
+
+// Enabled 是否开启了无障碍模式：J_ACCESSIBLE > config.yaml 的 setting.accessible（只解析一次），默认关闭
+func Enabled() bool {
+	enabledOnce.Do(func() {
+		v := os.Getenv(EnableEnv)
+		if v == "" {
+			v = config.Setting(SettingEnabled)
+		}
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "1", "true", "on", "yes":
+			enabled = true
+		}
+	})
+	return enabled
+}
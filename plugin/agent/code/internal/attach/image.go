@@ -0,0 +1,129 @@
+// Package attach 处理 ask 的附件：图片编码为 data URL 交给视觉模型，
+// 超过尺寸或体积上限时自动缩放重新编码。
+package attach
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	_ "image/gif" // 注册 GIF 解码器（取第一帧）
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // 注册 WebP 解码器
+
+	"wcp_agent/internal/i18n"
+)
+
+const (
+	// MaxImageSide 长边上限（像素），多数视觉模型会在服务端缩放到 2048 以内
+	MaxImageSide = 2048
+	// MaxImageBytes 单张图片编码后的体积上限（base64 之前），留出请求体余量
+	MaxImageBytes = 4 << 20
+	// minImageSide 为满足体积上限逐步缩小时的最小长边
+	minImageSide = 512
+)
+
+// Image 已编码的图片
+type Image struct {
+	Path    string // 原始文件路径，用于历史记录
+	MIME    string // image/png | image/jpeg ...
+	Data    []byte
+	Resized bool // 是否经过缩放 / 重新编码
+}
+
+// DataURL base64 data URL，OpenAI 兼容接口的 image_url 字段格式
+func (img Image) DataURL() string {
+	return "data:" + img.MIME + ";base64," + base64.StdEncoding.EncodeToString(img.Data)
+}
+
+// LoadImage 读取图片；尺寸与体积都在上限内的 PNG / JPEG / GIF / WebP 原样发送，
+// 否则缩放到长边不超过 MaxImageSide 并编码为 JPEG（带透明通道的 PNG 保持 PNG），
+// 仍超出体积上限时继续按 3/4 缩小
+func LoadImage(path string) (Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Image{}, err
+	}
+	mime := http.DetectContentType(data)
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, fmt.Errorf("%s", i18n.T(i18n.MsgAttachNotImage, filepath.Base(path), err))
+	}
+	if format == "webp" {
+		mime = "image/webp"
+	}
+	if len(data) <= MaxImageBytes && max(cfg.Width, cfg.Height) <= MaxImageSide {
+		return Image{Path: path, MIME: mime, Data: data}, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, fmt.Errorf("%s", i18n.T(i18n.MsgAttachNotImage, filepath.Base(path), err))
+	}
+	side := min(max(cfg.Width, cfg.Height), MaxImageSide)
+	for {
+		out, outMIME, err := encode(resize(src, side), format == "png" && hasAlpha(src))
+		if err != nil {
+			return Image{}, err
+		}
+		if len(out) <= MaxImageBytes || side <= minImageSide {
+			if len(out) > MaxImageBytes {
+				return Image{}, fmt.Errorf("%s", i18n.T(i18n.MsgAttachTooLarge, filepath.Base(path), MaxImageBytes>>20))
+			}
+			return Image{Path: path, MIME: outMIME, Data: out, Resized: true}, nil
+		}
+		side = side * 3 / 4
+	}
+}
+
+// resize 等比缩放到长边为 side（已小于 side 时不放大）
+func resize(src image.Image, side int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if max(w, h) <= side {
+		return src
+	}
+	if w >= h {
+		h, w = max(1, h*side/w), side
+	} else {
+		w, h = max(1, w*side/h), side
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+	return dst
+}
+
+// encode 透明图片编码为 PNG，其余编码为 JPEG（quality 85）
+func encode(img image.Image, keepAlpha bool) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if keepAlpha {
+		err := (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, img)
+		return buf.Bytes(), "image/png", err
+	}
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	return buf.Bytes(), "image/jpeg", err
+}
+
+// hasAlpha 图片是否含有非不透明像素（抽样检查，避免逐像素遍历大图）
+func hasAlpha(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return !o.Opaque()
+	}
+	b := img.Bounds()
+	step := max(1, max(b.Dx(), b.Dy())/256)
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0xffff {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+// Package audit 维护只追加的命令审计日志（JSON Lines），记录每条生成或执行的
+// shell 命令及其确认结果、退出码。格式与 Rust 端 src/command/chat/audit.rs 共享。
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+// 确认结果
+const (
+	DecisionConfirmed = "confirmed" // 用户确认后执行
+	DecisionRejected  = "rejected"  // 用户拒绝
+	DecisionBlocked   = "blocked"   // 被安全策略拦截
+	DecisionGenerated = "generated" // 仅生成、未执行
+)
+
+// Entry 单条审计记录
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Source   string    `json:"source"`
+	Command  string    `json:"command"`
+	Decision string    `json:"decision"`
+	ExitCode *int      `json:"exit_code,omitempty"`
+}
+
+// Path 审计日志路径: ~/.jdata/agent/data/audit.jsonl
+func Path() string {
+	return filepath.Join(config.AgentDataDir(), "audit.jsonl")
+}
+
+// Record 追加一条记录；exitCode 为 nil 表示命令未执行
+func Record(source, command, decision string, exitCode *int) error {
+	line, err := json.Marshal(Entry{
+		Time:     time.Now(),
+		Source:   source,
+		Command:  command,
+		Decision: decision,
+		ExitCode: exitCode,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(Path()), 0o755); err != nil {
+		return err
+	}
+	return filelock.AppendLine(Path(), line)
+}
+
+// Tail 读取最后 n 条记录（n <= 0 表示全部），损坏的行会被跳过
+func Tail(n int) ([]Entry, error) {
+	f, err := os.Open(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if n > 0 && len(entries) > n {
+			entries = entries[1:]
+		}
+	}
+	return entries, scanner.Err()
+}
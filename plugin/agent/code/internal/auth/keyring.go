@@ -0,0 +1,150 @@
+// Package auth 负责 provider API Key 的存取：
+// 优先系统钥匙串（macOS Keychain / Secret Service / Windows Credential Manager），
+// 其次环境变量，最后才回退到 agent_config.json 中的明文 Key。
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/zalando/go-keyring"
+
+	"wcp_agent/internal/config"
+)
+
+// Service 钥匙串中登记的服务名，账户名为小写的 provider 名称；
+// 非默认 profile 的 Key 登记在 "j-cli:<profile>" 下，不同 profile 的同名 provider 互不影响
+const Service = "j-cli"
+
+// ServiceName 当前 profile 在钥匙串中使用的服务名
+func ServiceName() string {
+	if p, _ := config.Profile(); p != config.DefaultProfile {
+		return Service + ":" + p
+	}
+	return Service
+}
+
+// Source API Key 的来源
+type Source int
+
+const (
+	SourceNone    Source = iota // 未找到
+	SourceKeyring               // 系统钥匙串
+	SourceEnv                   // 环境变量
+	SourceConfig                // 配置文件明文
+)
+
+// MaxKeys 每个 provider 在钥匙串中最多保存的 Key 数
+const MaxKeys = 16
+
+// account 钥匙串账户名
+func account(provider string) string {
+	return strings.ToLower(strings.TrimSpace(provider))
+}
+
+// slotAccount 第 slot 个 Key（从 1 开始）的账户名：第一个即 account，其余为 "<provider>#<slot>"
+func slotAccount(provider string, slot int) string {
+	if slot <= 1 {
+		return account(provider)
+	}
+	return account(provider) + "#" + strconv.Itoa(slot)
+}
+
+// EnvVar provider 对应的环境变量名，如 "DeepSeek-V3" → DEEPSEEK_V3_API_KEY
+func EnvVar(provider string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(provider) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToUpper(r))
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String() + "_API_KEY"
+}
+
+// EnvVarKeys 以逗号分隔的多个 Key 所用的环境变量名，如 "DeepSeek-V3" → DEEPSEEK_V3_API_KEYS
+func EnvVarKeys(provider string) string {
+	return EnvVar(provider) + "S"
+}
+
+// Store 保存 API Key 到系统钥匙串（已存在则覆盖）
+func Store(provider, key string) error {
+	return keyring.Set(ServiceName(), account(provider), key)
+}
+
+// Add 把 key 加为该 provider 的又一个 Key，保存到第一个空位，返回所在的位置（从 1 开始）；
+// 已保存过同一个 Key 时直接返回它的位置。各位置总是连续的（只会整体删除），读取时遇到空位即停
+func Add(provider, key string) (int, error) {
+	for slot := 1; slot <= MaxKeys; slot++ {
+		old, err := keyring.Get(ServiceName(), slotAccount(provider, slot))
+		if err != nil {
+			return slot, keyring.Set(ServiceName(), slotAccount(provider, slot), key)
+		}
+		if old == key {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("%s already has %d keys in the keyring", provider, MaxKeys)
+}
+
+// Delete 从系统钥匙串删除该 provider 的全部 API Key，本就不存在时视为成功
+func Delete(provider string) error {
+	for slot := 1; slot <= MaxKeys; slot++ {
+		err := keyring.Delete(ServiceName(), slotAccount(provider, slot))
+		if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keys 该 provider 的全部 API Key，取自 Resolve 所用的同一来源：钥匙串中的各个位置、
+// 环境变量 <PROVIDER>_API_KEY 与 <PROVIDER>_API_KEYS（逗号分隔），或配置文件的 api_key 与 api_keys；重复的只保留一个
+func Keys(p config.Provider) ([]string, Source) {
+	var keys []string
+	add := func(key string) {
+		if key = strings.TrimSpace(key); key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	for slot := 1; slot <= MaxKeys; slot++ {
+		key, err := keyring.Get(ServiceName(), slotAccount(p.Name, slot))
+		if err != nil {
+			break
+		}
+		add(key)
+	}
+	if len(keys) > 0 {
+		return keys, SourceKeyring
+	}
+	add(os.Getenv(EnvVar(p.Name)))
+	for _, key := range strings.Split(os.Getenv(EnvVarKeys(p.Name)), ",") {
+		add(key)
+	}
+	if len(keys) > 0 {
+		return keys, SourceEnv
+	}
+	add(p.APIKey)
+	for _, key := range p.APIKeys {
+		add(key)
+	}
+	if len(keys) > 0 {
+		return keys, SourceConfig
+	}
+	return nil, SourceNone
+}
+
+// Resolve 按 钥匙串 → 环境变量 → 配置文件 的顺序解析 API Key；有多个 Key 时返回第一个（见 Keys）
+func Resolve(p config.Provider) (string, Source) {
+	keys, src := Keys(p)
+	if len(keys) == 0 {
+		return "", SourceNone
+	}
+	return keys[0], src
+}
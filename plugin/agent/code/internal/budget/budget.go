@@ -0,0 +1,173 @@
+// Package budget 按月累计各 provider 的估算费用（token 数按本地编码统计，没有编码文件时按字符估算；单价取自 provider 的 pricing），
+// 并据此判断是否接近或超出每月预算：config.yaml 中 setting.agent_budget 为当前 profile 的每月总预算，
+// provider 的 monthly_budget 为单个 provider 的每月预算，币种与 pricing 一致。
+// 月度账本存放在 agent/data/spend.json，按本地时间的月份分别记录；与 stats 的用量统计不同，无需开启即会记录。
+package budget
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+const (
+	// SettingTotal config.yaml 中 setting 段当前 profile 的每月总预算，为空或不大于 0 表示不限制
+	SettingTotal = "agent_budget"
+	// SettingWarn config.yaml 中 setting 段的提醒比例（0~1），已用达到预算的这个比例时提醒
+	SettingWarn = "agent_budget_warn"
+	// DefaultWarn 默认的提醒比例
+	DefaultWarn = 0.8
+	// FileName 月度账本文件名（位于 agent 数据目录）
+	FileName = "spend.json"
+)
+
+// Ledger 月度账本：月份（2006-01）→ provider → 估算费用
+type Ledger map[string]map[string]float64
+
+// Path 月度账本路径
+func Path() string {
+	return filepath.Join(config.AgentDataDir(), FileName)
+}
+
+// Month t 所在的月份，账本的键
+func Month(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// Load 读取月度账本，不存在时返回空账本
+func Load() (Ledger, error) {
+	path := Path()
+	if _, err := os.Stat(filepath.Dir(path)); err != nil {
+		return Ledger{}, nil
+	}
+	unlock, err := filelock.RLock(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return read(path)
+}
+
+func read(path string) (Ledger, error) {
+	l := Ledger{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Add 把 provider 的一次估算费用记入当月；cost 不大于 0 时什么也不做。
+// 读改写在独占锁内完成，多个进程同时记录不会互相覆盖
+func Add(provider string, cost float64) error {
+	if cost <= 0 {
+		return nil
+	}
+	path := Path()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	unlock, err := filelock.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	l, err := read(path)
+	if err != nil {
+		// 账本损坏时从头记起，不让记账失败挡住请求
+		l = Ledger{}
+	}
+	month := Month(time.Now())
+	if l[month] == nil {
+		l[month] = map[string]float64{}
+	}
+	l[month][provider] += cost
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Total 某月全部 provider 的估算费用
+func (l Ledger) Total(month string) float64 {
+	total := 0.0
+	for _, cost := range l[month] {
+		total += cost
+	}
+	return total
+}
+
+// Limit 一项预算与本月的已用额
+type Limit struct {
+	// Provider 为空表示当前 profile 的总预算
+	Provider string
+	Limit    float64
+	Spent    float64
+}
+
+// Exceeded 已用额是否达到预算
+func (l Limit) Exceeded() bool {
+	return l.Spent >= l.Limit
+}
+
+// Near 已用额是否达到预算的 ratio
+func (l Limit) Near(ratio float64) bool {
+	return l.Spent >= l.Limit*ratio
+}
+
+// TotalLimit setting.agent_budget 中的每月总预算，未设置或无效时为 0
+func TotalLimit() float64 {
+	return parseAmount(config.Setting(SettingTotal))
+}
+
+// WarnRatio setting.agent_budget_warn 中的提醒比例，未设置或不在 (0, 1] 内时为 DefaultWarn
+func WarnRatio() float64 {
+	v := strings.TrimSpace(config.Setting(SettingWarn))
+	if r, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64); err == nil {
+		if strings.HasSuffix(v, "%") {
+			r /= 100
+		}
+		if r > 0 && r <= 1 {
+			return r
+		}
+	}
+	return DefaultWarn
+}
+
+// Limits 对 provider p 生效的预算：先是当前 profile 的总预算，再是 p 的 monthly_budget；都未配置时为空
+func Limits(p config.Provider, l Ledger, month string) []Limit {
+	var limits []Limit
+	if total := TotalLimit(); total > 0 {
+		limits = append(limits, Limit{Limit: total, Spent: l.Total(month)})
+	}
+	if p.MonthlyBudget > 0 {
+		limits = append(limits, Limit{Provider: p.Name, Limit: p.MonthlyBudget, Spent: l[month][p.Name]})
+	}
+	return limits
+}
+
+func parseAmount(v string) float64 {
+	n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
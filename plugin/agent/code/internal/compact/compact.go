@@ -0,0 +1,148 @@
+// Package compact 在多轮会话的历史接近上下文预算时，用（通常更便宜的）模型把较早的消息压缩成一段摘要并替换它们，
+// 长时间的会话因此可以一直继续，不必手动清理或等到请求因超出上下文被拒。
+package compact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/session"
+	"wcp_agent/internal/tokenizer"
+)
+
+// instruction 生成摘要的系统提示词
+const instruction = `Summarize the conversation below so that the summary can replace it as the context for continuing the conversation.
+Keep facts, decisions, constraints, names, numbers, code identifiers, file paths and open questions; drop greetings and repetition.
+If the conversation starts with an earlier summary, merge it in. Write concise bullet points in the language of the conversation, with no preamble.`
+
+// Prefix 摘要消息的开头，让模型知道其后是较早对话的摘要
+const Prefix = "Summary of the earlier conversation:\n\n"
+
+// Opener 按 provider 创建生成摘要用的客户端
+type Opener func(p config.Provider) (provider.Client, error)
+
+// Budget p 的上下文预算：maxContext（即 Options.MaxContextTokens，见 intercept.MaxContextTokens）生效的上限
+// 与模型上下文窗口中较小的一个，都不限制时为 0
+func Budget(p config.Provider, maxContext int) int {
+	limit, window := intercept.MaxContextTokens(maxContext), tokenizer.ContextWindow(p)
+	switch {
+	case limit <= 0:
+		return max(window, 0)
+	case window <= 0:
+		return limit
+	}
+	return min(limit, window)
+}
+
+// Plan history 连同本轮消息共 total 个 token、达到 budget 的 s.Threshold 时，
+// 返回应压缩的较早消息条数：保留最近 s.Keep 条，并在一轮提问处断开；无需或不值得压缩时返回 0
+func Plan(history []provider.Message, total, budget int, s config.Summarize) int {
+	if budget <= 0 || float64(total) < float64(budget)*s.Threshold {
+		return 0
+	}
+	n := len(history) - s.Keep
+	for n > 0 && history[n].Role != "user" {
+		n--
+	}
+	if n < 2 {
+		return 0
+	}
+	return n
+}
+
+// Summarize 让 client 把 messages 压缩成一段摘要；被 token 上限截断的摘要照常使用
+func Summarize(ctx context.Context, client provider.Client, messages []provider.Message) (string, error) {
+	var b strings.Builder
+	for _, m := range messages {
+		fmt.Fprintf(&b, "[%s]\n%s\n\n", m.Role, strings.TrimSpace(m.Content))
+	}
+	summary, err := client.Chat(ctx, []provider.Message{
+		{Role: "system", Content: instruction},
+		{Role: "user", Content: b.String()},
+	}, nil)
+	if err != nil && !errors.Is(err, provider.ErrTruncated) {
+		return "", err
+	}
+	if summary = strings.TrimSpace(summary); summary == "" {
+		return "", errors.New("empty summary")
+	}
+	return summary, nil
+}
+
+// Message 替换较早消息的摘要消息
+func Message(summary string) provider.Message {
+	return provider.Message{Role: "system", Content: Prefix + summary}
+}
+
+// Provider 生成摘要使用的 provider：s.Provider 指定的，未指定时为会话当前的 p；s.Model 非空时替换其模型
+func Provider(cfg config.AgentConfig, s config.Summarize, p config.Provider) (config.Provider, error) {
+	if s.Provider != "" {
+		i := cfg.FindProvider(s.Provider)
+		if i < 0 {
+			return config.Provider{}, errors.New(i18n.T(i18n.MsgCompactProvider, s.Provider))
+		}
+		p = cfg.Providers[i]
+	}
+	if s.Model != "" {
+		p.Model = s.Model
+	}
+	return p, nil
+}
+
+// Session store 中会话 name 的历史连同 pending 达到 p 的上下文预算（maxContext 见 Budget）的阈值时，
+// 用 open 创建的客户端摘要较早的消息并替换它们，结果以 EventSummarized 报告。
+// 摘要失败只提醒一行，会话保持原样，本轮照常发送
+func Session(ctx context.Context, store *session.Store, name string, pending []provider.Message, cfg config.AgentConfig, p config.Provider, maxContext int, open Opener) {
+	s := cfg.EffectiveSummarize()
+	if !*s.Enabled {
+		return
+	}
+	history, ok := store.Messages(name)
+	if !ok {
+		return
+	}
+	tokens, total := tokenizer.For(p), 0
+	for _, m := range append(append([]provider.Message{}, history...), pending...) {
+		total += tokens.Count(m.Content)
+	}
+	budget := Budget(p, maxContext)
+	n := Plan(history, total, budget, s)
+	if n == 0 {
+		return
+	}
+	fail := func(err error) {
+		intercept.Notify(ctx, intercept.Event{Kind: intercept.EventSummarized, Err: errors.New(i18n.T(i18n.MsgCompactFailed, err))})
+	}
+	sp, err := Provider(cfg, s, p)
+	if err != nil {
+		fail(err)
+		return
+	}
+	client, err := open(sp)
+	if err != nil {
+		fail(err)
+		return
+	}
+	summary, err := Summarize(ctx, client, history[:n])
+	if err != nil {
+		if ctx.Err() == nil {
+			fail(err)
+		}
+		return
+	}
+	if !store.Replace(name, history[:n], Message(summary)) {
+		return
+	}
+	label := sp.Name
+	if sp.Model != "" {
+		label += " (" + sp.Model + ")"
+	}
+	intercept.Notify(ctx, intercept.Event{Kind: intercept.EventSummarized, Count: n,
+		Err: errors.New(i18n.T(i18n.MsgCompactDone, total, budget, n, label))})
+}
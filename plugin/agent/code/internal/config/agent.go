@@ -0,0 +1,522 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultMaxHistoryMessages = 20
+	defaultMaxToolRounds      = 10
+)
+
+// Provider 单个模型提供方配置
+type Provider struct {
+	// Name 显示名称（如 "GPT-4o", "DeepSeek-V3"）
+	Name string `json:"name"`
+	// APIBase API Base URL（如 "https://api.openai.com/v1"）
+	APIBase string `json:"api_base"`
+	// APIKey 明文 API Key，推荐改用系统钥匙串（agent auth login）
+	APIKey string `json:"api_key"`
+	// APIKeys api_key 之外的更多明文 Key（团队共用额度时），与 api_key 一起按 key_rotation 轮换
+	APIKeys []string `json:"api_keys,omitempty"`
+	// KeyRotation 多个 Key 的轮换方式：round-robin（默认，每次请求换下一个）或 failover（一直用第一个可用的）；
+	// 两种方式下收到 401 / 403 / 429 时都会换下一个 Key 重发
+	KeyRotation string `json:"key_rotation,omitempty"`
+	// Model 模型名称（如 "gpt-4o", "deepseek-chat"）
+	Model string `json:"model"`
+	// Proxy 该 provider 专用代理（http/https/socks5，"direct" 表示直连），为空时读取环境变量
+	Proxy string `json:"proxy,omitempty"`
+	// RateLimit 客户端限流（每分钟请求数 / token 数），为空表示不限制
+	RateLimit *RateLimit `json:"rate_limit,omitempty"`
+	// Timeouts 覆盖全局超时配置，只需填写要改的项
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+	// Vision 是否支持图片输入；为空表示未知（照常发送，由服务端判断）
+	Vision *bool `json:"vision,omitempty"`
+	// Sampling 该 provider 的默认采样参数，可被 --preset 与命令行参数覆盖
+	Sampling *Sampling `json:"sampling,omitempty"`
+	// MaxTemperature temperature 上限；为 0 时按 provider 推断（Anthropic 为 1，其余为 2）
+	MaxTemperature float64 `json:"max_temperature,omitempty"`
+	// StructuredOutput 是否支持原生结构化输出（response_format: json_schema）；
+	// 为空时仅对 api.openai.com 与 Azure OpenAI 启用，其余 provider 通过提示词约束并在本地校验
+	StructuredOutput *bool `json:"structured_output,omitempty"`
+	// EmbeddingModel agent embed 使用的向量模型，默认 text-embedding-3-small
+	EmbeddingModel string `json:"embedding_model,omitempty"`
+	// Pricing 每百万 token 的价格，用于本地用量统计中的费用估算，为空时只统计 token 数
+	Pricing *Pricing `json:"pricing,omitempty"`
+	// MonthlyBudget 每月预算（币种与 pricing 一致），本月估算费用达到后需加 --override-budget 才会发送；
+	// 为 0 表示不限制，未配置 pricing 时不生效
+	MonthlyBudget float64 `json:"monthly_budget,omitempty"`
+	// ContextWindow 模型的上下文窗口（token），发送前接近或超出时提醒；为 0 时按常见模型推断
+	ContextWindow int `json:"context_window,omitempty"`
+	// Tokenizer 本地计数 token 所用的编码（cl100k_base、o200k_base，estimate 表示按字符估算）；为空时按模型推断
+	Tokenizer string `json:"tokenizer,omitempty"`
+	// Type 接口类型：openai（默认，OpenAI 兼容）、azure（Azure OpenAI）、bedrock（AWS Bedrock）、vertex（Google Vertex AI）、
+	// openrouter（OpenRouter，api_base 默认为 DefaultOpenRouterBase）
+	Type string `json:"type,omitempty"`
+	// Deployment Azure OpenAI 的部署名，为空时使用 model
+	Deployment string `json:"deployment,omitempty"`
+	// APIVersion Azure OpenAI 的 api-version，为空时使用 DefaultAzureAPIVersion
+	APIVersion string `json:"api_version,omitempty"`
+	// Region Bedrock 的 AWS 区域（为空时读取 AWS_REGION / AWS_DEFAULT_REGION）或 Vertex 的 location（默认 us-central1）
+	Region string `json:"region,omitempty"`
+	// Project Vertex 的 GCP 项目，为空时读取 GOOGLE_CLOUD_PROJECT，再取应用默认凭据中的项目
+	Project string `json:"project,omitempty"`
+	// AWSProfile Bedrock 从 ~/.aws/credentials 读取凭据时的 profile，为空时读取 AWS_PROFILE，再为 default
+	AWSProfile string `json:"aws_profile,omitempty"`
+	// OpenRouter OpenRouter 的模型路由偏好，type 为 openrouter 时随请求发送
+	OpenRouter *OpenRouterRouting `json:"openrouter,omitempty"`
+	// Fallback 请求失败（限流、过载、超时、网络错误等，重试之后仍失败）时依次改用的 provider，
+	// 每项为 provider 名称或某个 provider 配置的模型名
+	Fallback []string `json:"fallback,omitempty"`
+}
+
+// OpenRouterRouting OpenRouter 的路由偏好（见 https://openrouter.ai/docs/features/provider-routing）
+type OpenRouterRouting struct {
+	// Models 主模型不可用时由 OpenRouter 依次改用的模型（请求中的 models）
+	Models []string `json:"models,omitempty"`
+	// Order 优先使用的上游服务商（如 ["anthropic", "amazon-bedrock"]）
+	Order []string `json:"order,omitempty"`
+	// Only 只使用这些上游服务商
+	Only []string `json:"only,omitempty"`
+	// Ignore 不使用这些上游服务商
+	Ignore []string `json:"ignore,omitempty"`
+	// AllowFallbacks 为 false 时上游服务商不可用也不改用 order 以外的服务商
+	AllowFallbacks *bool `json:"allow_fallbacks,omitempty"`
+	// Sort 上游服务商的排序方式：price、throughput 或 latency，为空时由 OpenRouter 按价格与可用性均衡
+	Sort string `json:"sort,omitempty"`
+	// DataCollection 为 deny 时只使用不保留请求数据的上游服务商
+	DataCollection string `json:"data_collection,omitempty"`
+}
+
+// provider 接口类型
+const (
+	ProviderOpenAI     = "openai"
+	ProviderAzure      = "azure"
+	ProviderBedrock    = "bedrock"
+	ProviderVertex     = "vertex"
+	ProviderOpenRouter = "openrouter"
+
+	// DefaultAzureAPIVersion Azure OpenAI 默认的 api-version
+	DefaultAzureAPIVersion = "2024-10-21"
+	// DefaultVertexRegion Vertex AI 默认的 location
+	DefaultVertexRegion = "us-central1"
+	// DefaultOpenRouterBase OpenRouter 的默认 api_base
+	DefaultOpenRouterBase = "https://openrouter.ai/api/v1"
+)
+
+// API Key 的轮换方式
+const (
+	KeyRotationRoundRobin = "round-robin"
+	KeyRotationFailover   = "failover"
+)
+
+// BedrockRegion Bedrock 使用的 AWS 区域：region，其次环境变量 AWS_REGION、AWS_DEFAULT_REGION，都没有时为空
+func (p Provider) BedrockRegion() string {
+	for _, r := range []string{p.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")} {
+		if r != "" {
+			return r
+		}
+	}
+	return ""
+}
+
+// VertexRegion Vertex AI 使用的 location，默认 DefaultVertexRegion
+func (p Provider) VertexRegion() string {
+	if p.Region != "" {
+		return p.Region
+	}
+	return DefaultVertexRegion
+}
+
+// Endpoint 请求发往的地址：api_base，OpenRouter 未配置时为默认地址，Bedrock 与 Vertex 未配置时按区域推出默认地址
+func (p Provider) Endpoint() string {
+	if p.APIBase != "" {
+		return strings.TrimRight(p.APIBase, "/")
+	}
+	switch p.Type {
+	case ProviderOpenRouter:
+		return DefaultOpenRouterBase
+	case ProviderBedrock:
+		if r := p.BedrockRegion(); r != "" {
+			return "https://bedrock-runtime." + r + ".amazonaws.com"
+		}
+	case ProviderVertex:
+		r := p.VertexRegion()
+		if r == "global" {
+			return "https://aiplatform.googleapis.com"
+		}
+		return "https://" + r + "-aiplatform.googleapis.com"
+	}
+	return ""
+}
+
+// Pricing provider 的计价（每百万 token，币种由用户自定）
+type Pricing struct {
+	InputPerMTok  float64 `json:"input_per_mtok"`
+	OutputPerMTok float64 `json:"output_per_mtok"`
+}
+
+// Cost 按 token 数估算费用
+func (p *Pricing) Cost(input, output int) float64 {
+	if p == nil {
+		return 0
+	}
+	return (float64(input)*p.InputPerMTok + float64(output)*p.OutputPerMTok) / 1e6
+}
+
+// SupportsStructuredOutput 判断是否向该 provider 发送 response_format
+func (p Provider) SupportsStructuredOutput() bool {
+	if p.StructuredOutput != nil {
+		return *p.StructuredOutput
+	}
+	return strings.Contains(p.APIBase, "api.openai.com") || p.Type == ProviderAzure
+}
+
+// Sampling 采样参数，nil 表示不发送该字段（由服务端决定默认值）
+type Sampling struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	Seed        *int64   `json:"seed,omitempty"`
+	// MCP 提供工具的 MCP 服务器（mcp_servers 中的名称）；写在预设中即按预设启用，空列表表示不启用
+	MCP []string `json:"mcp,omitempty"`
+	// Postprocess 输出前依次处理回答的后处理器（插件声明为 postprocess 的过滤器）；空列表表示不启用
+	Postprocess []string `json:"postprocess,omitempty"`
+}
+
+// Override 用 o 中已设置的字段覆盖 s，返回合并结果
+func (s Sampling) Override(o Sampling) Sampling {
+	if o.Temperature != nil {
+		s.Temperature = o.Temperature
+	}
+	if o.TopP != nil {
+		s.TopP = o.TopP
+	}
+	if o.Seed != nil {
+		s.Seed = o.Seed
+	}
+	if o.MCP != nil {
+		s.MCP = o.MCP
+	}
+	if o.Postprocess != nil {
+		s.Postprocess = o.Postprocess
+	}
+	return s
+}
+
+// builtinPresets 内置采样预设，可在配置的 sampling_presets 中同名覆盖
+var builtinPresets = map[string]Sampling{
+	"precise":  {Temperature: ptr(0.0), TopP: ptr(1.0)},
+	"balanced": {Temperature: ptr(0.7), TopP: ptr(1.0)},
+	"creative": {Temperature: ptr(1.0), TopP: ptr(0.95)},
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// Preset 按名称查找采样预设：配置中的 sampling_presets 优先于内置预设
+func (c AgentConfig) Preset(name string) (Sampling, bool) {
+	for k, v := range c.SamplingPresets {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	s, ok := builtinPresets[strings.ToLower(name)]
+	return s, ok
+}
+
+// PresetNames 所有可用预设名称（内置 + 配置），已排序
+func (c AgentConfig) PresetNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, m := range []map[string]Sampling{builtinPresets, c.SamplingPresets} {
+		for k := range m {
+			if !seen[strings.ToLower(k)] {
+				seen[strings.ToLower(k)] = true
+				names = append(names, k)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MCPServer 一个 MCP（Model Context Protocol）服务器：给出 command 时以子进程启动并经 stdin / stdout 通信，
+// 给出 url 时使用 Streamable HTTP 传输。env 与 headers 的取值可以引用环境变量（如 "Bearer $GITHUB_TOKEN"）
+type MCPServer struct {
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Tools 只向模型提供这些工具，为空时提供服务器的全部工具
+	Tools []string `json:"tools,omitempty"`
+}
+
+// Timeouts 请求超时配置（秒），0 表示沿用上一级配置，负数表示不限制
+type Timeouts struct {
+	// ConnectSecs 建立 TCP / TLS 连接的超时
+	ConnectSecs int `json:"connect_secs,omitempty"`
+	// FirstTokenSecs 发出请求到收到第一个回复片段的超时（仅流式模式）
+	FirstTokenSecs int `json:"first_token_secs,omitempty"`
+	// TotalSecs 整个回复的总超时
+	TotalSecs int `json:"total_secs,omitempty"`
+}
+
+// 默认超时（秒）
+const (
+	DefaultConnectSecs    = 10
+	DefaultFirstTokenSecs = 60
+	DefaultTotalSecs      = 300
+)
+
+// EffectiveTimeouts 合并 默认值 → 全局 timeouts → provider timeouts，得到最终超时
+func (c AgentConfig) EffectiveTimeouts(p Provider) Timeouts {
+	t := Timeouts{
+		ConnectSecs:    DefaultConnectSecs,
+		FirstTokenSecs: DefaultFirstTokenSecs,
+		TotalSecs:      DefaultTotalSecs,
+	}
+	for _, o := range []*Timeouts{c.Timeouts, p.Timeouts} {
+		if o == nil {
+			continue
+		}
+		if o.ConnectSecs != 0 {
+			t.ConnectSecs = o.ConnectSecs
+		}
+		if o.FirstTokenSecs != 0 {
+			t.FirstTokenSecs = o.FirstTokenSecs
+		}
+		if o.TotalSecs != 0 {
+			t.TotalSecs = o.TotalSecs
+		}
+	}
+	return t
+}
+
+// RateLimit provider 的每分钟额度配置，0 表示该维度不限制
+type RateLimit struct {
+	RequestsPerMinute int `json:"requests_per_minute,omitempty"`
+	TokensPerMinute   int `json:"tokens_per_minute,omitempty"`
+}
+
+// AgentConfig agent 配置，字段与 Rust 端 AgentConfig 一一对应
+type AgentConfig struct {
+	Providers          []Provider `json:"providers"`
+	ActiveIndex        int        `json:"active_index"`
+	SystemPrompt       *string    `json:"system_prompt,omitempty"`
+	StreamMode         bool       `json:"stream_mode"`
+	MaxHistoryMessages int        `json:"max_history_messages"`
+	Theme              string     `json:"theme,omitempty"`
+	ToolsEnabled       bool       `json:"tools_enabled"`
+	MaxToolRounds      int        `json:"max_tool_rounds"`
+	Style              *string    `json:"style,omitempty"`
+	// Timeouts 全局超时配置（agent 插件专用，Rust 端原样保留）
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+	// STT 语音转文字配置（agent 插件专用，Rust 端原样保留）
+	STT *STT `json:"stt,omitempty"`
+	// TTS 朗读回答的配置（agent 插件专用，Rust 端原样保留）
+	TTS *TTS `json:"tts,omitempty"`
+	// SamplingPresets 自定义采样预设（ask --preset 使用，agent 插件专用）
+	SamplingPresets map[string]Sampling `json:"sampling_presets,omitempty"`
+	// MCPServers 按名称配置的 MCP 服务器，由预设的 mcp 字段启用（agent 插件专用）
+	MCPServers map[string]MCPServer `json:"mcp_servers,omitempty"`
+	// Summarize 会话历史接近上下文预算时自动摘要较早消息的配置（agent 插件专用）
+	Summarize *Summarize `json:"summarize,omitempty"`
+	// Titles 为对话生成标题的配置（agent 插件专用），未配置时按第一轮的问题在本地生成
+	Titles *Titles `json:"titles,omitempty"`
+	// OutputRedact 显示与导出回答前按顺序套用的脱敏规则（agent 插件专用），问答历史中仍保存原文
+	OutputRedact []RedactRule `json:"output_redact,omitempty"`
+}
+
+// STT 后端
+const (
+	STTOpenAI     = "openai"      // OpenAI 兼容的 /audio/transcriptions 接口（Whisper API）
+	STTWhisperCpp = "whisper_cpp" // 本地 whisper.cpp 命令行
+)
+
+// STT ask --audio / --mic 使用的语音转文字配置，未配置的字段取默认值
+type STT struct {
+	Backend   string `json:"backend,omitempty"`    // openai（默认）| whisper_cpp
+	Provider  string `json:"provider,omitempty"`   // openai 后端使用的 provider 名，默认当前 provider
+	Model     string `json:"model,omitempty"`      // openai 后端的模型名，默认 whisper-1
+	Language  string `json:"language,omitempty"`   // ISO-639-1 语言代码，留空自动识别
+	Binary    string `json:"binary,omitempty"`     // whisper.cpp 可执行文件，默认 whisper-cli
+	ModelPath string `json:"model_path,omitempty"` // whisper.cpp 模型，默认复用 j voice 的 ggml-small.bin
+}
+
+// EffectiveSTT 返回补齐默认值后的 STT 配置
+func (c AgentConfig) EffectiveSTT() STT {
+	var s STT
+	if c.STT != nil {
+		s = *c.STT
+	}
+	if s.Backend == "" {
+		s.Backend = STTOpenAI
+	}
+	if s.Model == "" {
+		s.Model = "whisper-1"
+	}
+	if s.Binary == "" {
+		s.Binary = "whisper-cli"
+	}
+	if s.ModelPath == "" {
+		// 与 j voice 下载的模型共用，位于数据根目录（各 profile 共用）
+		s.ModelPath = filepath.Join(RootDir(), "voice", "model", "ggml-small.bin")
+	}
+	return s
+}
+
+// TTS 后端
+const (
+	TTSSystem = "system" // 系统自带朗读：macOS say / Linux espeak-ng / Windows SAPI
+	TTSOpenAI = "openai" // OpenAI 兼容的 /audio/speech 接口
+)
+
+// TTS ask --speak 使用的语音合成配置，未配置的字段取默认值
+type TTS struct {
+	Backend  string  `json:"backend,omitempty"`  // system（默认）| openai
+	Provider string  `json:"provider,omitempty"` // openai 后端使用的 provider 名，默认当前 provider
+	Model    string  `json:"model,omitempty"`    // openai 后端的模型名，默认 tts-1
+	Voice    string  `json:"voice,omitempty"`    // 音色：openai 默认 alloy；system 后端传给 say -v / espeak-ng -v
+	Speed    float64 `json:"speed,omitempty"`    // 语速倍率，0 表示默认
+	Player   string  `json:"player,omitempty"`   // openai 后端播放音频的命令，默认自动探测 afplay / mpv / ffplay
+}
+
+// EffectiveTTS 返回补齐默认值后的 TTS 配置
+func (c AgentConfig) EffectiveTTS() TTS {
+	var t TTS
+	if c.TTS != nil {
+		t = *c.TTS
+	}
+	if t.Backend == "" {
+		t.Backend = TTSSystem
+	}
+	if t.Model == "" {
+		t.Model = "tts-1"
+	}
+	if t.Voice == "" && t.Backend == TTSOpenAI {
+		t.Voice = "alloy"
+	}
+	return t
+}
+
+// Summarize 多轮会话的历史连同本轮消息达到上下文预算的 threshold 时，把最近 keep 条以外的消息交给
+// （通常更便宜的）模型压缩成一段摘要替换它们；未配置的字段取默认值
+type Summarize struct {
+	Enabled   *bool   `json:"enabled,omitempty"`   // 默认开启
+	Provider  string  `json:"provider,omitempty"`  // 生成摘要的 provider 名，默认会话当前使用的 provider
+	Model     string  `json:"model,omitempty"`     // 生成摘要的模型名，默认该 provider 配置的模型
+	Threshold float64 `json:"threshold,omitempty"` // 占上下文预算的比例，默认 0.75
+	Keep      int     `json:"keep,omitempty"`      // 原样保留的最近消息条数，默认 6
+}
+
+// Titles 配置了 provider 时，新对话的第一轮问答结束后请（通常更便宜的）模型概括出标题
+type Titles struct {
+	Provider string `json:"provider,omitempty"` // 生成标题的 provider 名，为空表示在本地按问题生成
+	Model    string `json:"model,omitempty"`    // 生成标题的模型名，默认该 provider 配置的模型
+}
+
+// RedactRule 输出脱敏规则：匹配 Pattern（Go 正则表达式）的内容替换为 Mask，Mask 中可用 $1、${name} 引用分组，
+// 为空时替换为 [REDACTED]
+type RedactRule struct {
+	Pattern string `json:"pattern"`
+	Mask    string `json:"mask,omitempty"`
+}
+
+// EffectiveSummarize 返回补齐默认值后的会话摘要配置
+func (c AgentConfig) EffectiveSummarize() Summarize {
+	var s Summarize
+	if c.Summarize != nil {
+		s = *c.Summarize
+	}
+	if s.Enabled == nil {
+		on := true
+		s.Enabled = &on
+	}
+	if s.Threshold <= 0 || s.Threshold > 1 {
+		s.Threshold = 0.75
+	}
+	if s.Keep <= 0 {
+		s.Keep = 6
+	}
+	return s
+}
+
+// defaultAgentConfig 与 Rust 端 serde default 保持一致的默认值
+func defaultAgentConfig() AgentConfig {
+	return AgentConfig{
+		StreamMode:         true,
+		MaxHistoryMessages: defaultMaxHistoryMessages,
+		MaxToolRounds:      defaultMaxToolRounds,
+	}
+}
+
+// LoadAgent 加载 agent 配置，文件不存在时返回默认配置
+func LoadAgent() (AgentConfig, error) {
+	cfg := defaultAgentConfig()
+	data, err := os.ReadFile(AgentConfigPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("read %s: %w", AgentConfigFile, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse %s: %w", AgentConfigFile, err)
+	}
+	return cfg, nil
+}
+
+// SaveAgent 保存 agent 配置（格式化输出，与 Rust 端 to_string_pretty 一致）
+func SaveAgent(cfg AgentConfig) error {
+	path := AgentConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// FindProvider 按名称（忽略大小写）查找 provider，返回其下标，找不到返回 -1
+func (c AgentConfig) FindProvider(name string) int {
+	for i, p := range c.Providers {
+		if strings.EqualFold(p.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Active 获取当前选中的 provider
+func (c AgentConfig) Active() (Provider, bool) {
+	if c.ActiveIndex < 0 || c.ActiveIndex >= len(c.Providers) {
+		return Provider{}, false
+	}
+	return c.Providers[c.ActiveIndex], true
+}
+
+// SystemPromptPath 系统提示词文件路径（Rust 端将提示词独立存放在此文件）
+func SystemPromptPath() string {
+	return filepath.Join(AgentDataDir(), "system_prompt.md")
+}
+
+// LoadSystemPrompt 读取系统提示词，文件不存在或为空时回退到配置中的字段
+func LoadSystemPrompt(cfg AgentConfig) string {
+	if data, err := os.ReadFile(SystemPromptPath()); err == nil {
+		if prompt := strings.TrimSpace(string(data)); prompt != "" {
+			return prompt
+		}
+	}
+	if cfg.SystemPrompt != nil {
+		return strings.TrimSpace(*cfg.SystemPrompt)
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+// Package config 负责定位 j 的数据目录并读写 agent 相关配置，
+// 目录与文件格式与 Rust 主程序（src/command/chat/model.rs）保持一致。
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"wcp_jdata"
+)
+
+const (
+	// DataPathEnv 数据目录环境变量，与 j 主程序保持一致
+	DataPathEnv = jdata.DataPathEnv
+	// MainConfigFile j 主程序的配置文件名
+	MainConfigFile = jdata.ConfigFile
+	// AgentConfigFile agent 配置文件名
+	AgentConfigFile = "agent_config.json"
+	// ProfileEnv 选择 profile 的环境变量，与 j 主程序保持一致（j --profile 同样通过它传给插件）
+	ProfileEnv = jdata.ProfileEnv
+	// DefaultProfile 默认 profile，数据直接存放在数据根目录
+	DefaultProfile = jdata.DefaultProfile
+	// BinDir 插件二进制目录（位于数据根目录下，各 profile 共用），与 md_render 的释放位置一致
+	BinDir = jdata.BinDir
+	// MdRenderBinary md_render 渲染引擎的可执行文件名
+	MdRenderBinary = jdata.MdRenderBinary
+)
+
+// ProfileError 当前 profile 不可用：名称不合法或 profile 不存在，与 j 主程序启动时的检查一致
+type ProfileError = jdata.ProfileError
+
+// RootDir 获取数据根目录: 优先 J_DATA_PATH，否则 ~/.jdata/（插件二进制等各 profile 共用的内容放在这里）
+func RootDir() string {
+	return jdata.RootDir()
+}
+
+// PluginPath 查找插件可执行文件：优先 ~/.jdata/bin/<name>，其次 PATH
+func PluginPath(name string) (string, error) {
+	return jdata.PluginPath(name)
+}
+
+// MdRenderPath 查找 md_render 渲染引擎：优先 ~/.jdata/bin/md_render（j 主程序释放的位置），其次 PATH
+func MdRenderPath() (string, error) {
+	return jdata.MdRenderPath()
+}
+
+// Profile 当前 profile 的名称（见 jdata.Profile），不可用时返回 *ProfileError
+// （main 在启动时报告该错误后退出）
+func Profile() (string, error) {
+	return jdata.Profile()
+}
+
+// DataDir 获取当前 profile 的数据目录：默认 profile 为数据根目录本身，其他 profile 为 profiles/<name>/；
+// profile 不可用时由 main 在启动时报错，这里不再重复检查
+func DataDir() string {
+	name, _ := Profile()
+	return jdata.ProfileDir(name)
+}
+
+// AgentDataDir 获取 agent 数据目录: ~/.jdata/agent/data/
+func AgentDataDir() string {
+	return filepath.Join(DataDir(), "agent", "data")
+}
+
+// AgentConfigPath 获取 agent 配置文件路径
+func AgentConfigPath() string {
+	return filepath.Join(AgentDataDir(), AgentConfigFile)
+}
+
+// Setting 读取 config.yaml 中 setting 段的某个配置项，读取失败时返回空串
+func Setting(key string) string {
+	return jdata.Settings()[key]
+}
+
+// SetSetting 把 config.yaml 中 setting 段的配置项 key 设为 value 并写回，comment 非空时作为该项上方的注释
+// （每行以 # 开头）；经 yaml.Node 修改，文件中其余内容与注释保持不变，文件不存在时新建
+func SetSetting(key, value, comment string) error {
+	path := filepath.Join(DataDir(), MainConfigFile)
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("parse %s: %w", MainConfigFile, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("read %s: %w", MainConfigFile, err)
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: the top level is not a mapping", MainConfigFile)
+	}
+	setting := mappingItem(root, "setting")
+	if setting.Kind != yaml.MappingNode {
+		*setting = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	item := mappingItem(setting, key)
+	*item = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if comment != "" {
+		setting.Content[indexOfKey(setting, key)].HeadComment = comment
+	}
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2) // 与 j 主程序（serde_yaml）的缩进一致
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// mappingItem 映射节点中 key 对应的值节点，没有时追加一个空值
+func mappingItem(m *yaml.Node, key string) *yaml.Node {
+	if i := indexOfKey(m, key); i >= 0 {
+		return m.Content[i+1]
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"})
+	return m.Content[len(m.Content)-1]
+}
+
+// indexOfKey 映射节点中 key 的键节点下标，没有时为 -1
+func indexOfKey(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,455 @@
+// Package configcheck 按 config.AgentConfig 的结构校验 agent_config.json：
+// 报告未知的配置项（拼写接近已知项时给出建议，如 "modle" → "model"）、类型不符、
+// 无效的枚举取值、重复的键以及已废弃的写法，每条问题都带有行号、列号和修改建议。
+// 结构取自 Go 端的字段定义（json tag），与 Rust 端 AgentConfig 一一对应，因此不需要另外维护一份 schema。
+package configcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// Severity 问题的严重程度
+type Severity int
+
+const (
+	// Warning 不影响加载，但配置很可能没有按预期生效（未知项、废弃项、重复键）
+	Warning Severity = iota
+	// Error 配置无法加载或取值无效
+	Error
+)
+
+// Issue 一处问题
+type Issue struct {
+	Severity Severity
+	Line     int    // 从 1 开始
+	Col      int    // 从 1 开始，按字符计
+	Path     string // 如 providers[0].model，语法错误时为空
+	Message  string
+	Fix      string // 修改建议，可能为空
+}
+
+// Format 输出形如 "file:3:7: 警告: providers[0].modle: 未知的配置项 "modle"（建议: 改为 "model"）"
+func (is Issue) Format(file string) string {
+	label := i18n.T(i18n.MsgConfigWarning)
+	if is.Severity == Error {
+		label = i18n.T(i18n.MsgConfigError)
+	}
+	msg := is.Message
+	if is.Path != "" {
+		msg = is.Path + ": " + msg
+	}
+	if is.Fix != "" {
+		msg += i18n.T(i18n.MsgConfigFix, is.Fix)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", file, is.Line, is.Col, label, msg)
+}
+
+// Errors Error 级别的问题数
+func Errors(issues []Issue) int {
+	n := 0
+	for _, is := range issues {
+		if is.Severity == Error {
+			n++
+		}
+	}
+	return n
+}
+
+// enums 取值受限的字符串配置项（路径中数组下标写作 []）
+var enums = map[string][]string{
+	"theme":                                  {"dark", "light", "midnight", "nord", "monokai"},
+	"stt.backend":                            {config.STTOpenAI, config.STTWhisperCpp},
+	"tts.backend":                            {config.TTSSystem, config.TTSOpenAI},
+	"providers[].type":                       {config.ProviderOpenAI, config.ProviderAzure, config.ProviderBedrock, config.ProviderVertex, config.ProviderOpenRouter},
+	"providers[].openrouter.sort":            {"price", "throughput", "latency"},
+	"providers[].openrouter.data_collection": {"allow", "deny"},
+	"providers[].key_rotation":               {config.KeyRotationRoundRobin, config.KeyRotationFailover},
+}
+
+// deprecated 已废弃或写错位置的配置项及其替代写法
+var deprecated = map[string]string{
+	"providers[].temperature": "sampling.temperature",
+	"providers[].top_p":       "sampling.top_p",
+	"providers[].seed":        "sampling.seed",
+	"providers[].rpm":         "rate_limit.requests_per_minute",
+	"providers[].tpm":         "rate_limit.tokens_per_minute",
+}
+
+// Check 校验 agent_config.json 的内容；JSON 语法错误时只返回这一处错误
+func Check(data []byte) []Issue {
+	c := &checker{data: data}
+	root, err := parse(data)
+	if err != nil {
+		return []Issue{c.syntaxIssue(err)}
+	}
+	c.walk(root, reflect.TypeOf(config.AgentConfig{}), "", "")
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		a, b := c.issues[i], c.issues[j]
+		return a.Line < b.Line || (a.Line == b.Line && a.Col < b.Col)
+	})
+	return c.issues
+}
+
+type checker struct {
+	data   []byte
+	issues []Issue
+}
+
+func (c *checker) add(sev Severity, off int, path, msg, fix string) {
+	line, col := position(c.data, off)
+	c.issues = append(c.issues, Issue{Severity: sev, Line: line, Col: col, Path: path, Message: msg, Fix: fix})
+}
+
+// syntaxIssue JSON 语法错误的位置
+func (c *checker) syntaxIssue(err error) Issue {
+	off := len(c.data)
+	var se *json.SyntaxError
+	var te *trailingError
+	switch {
+	case errors.As(err, &se):
+		// json.SyntaxError 的 Offset 指向出错字符之后
+		off = max(int(se.Offset)-1, 0)
+	case errors.As(err, &te):
+		off = te.off
+	}
+	line, col := position(c.data, off)
+	return Issue{Severity: Error, Line: line, Col: col, Message: i18n.T(i18n.MsgConfigSyntax, err)}
+}
+
+// walk 按 Go 类型 t 校验 n；path 为显示用的路径，pattern 为查表用的路径（下标写作 []）
+func (c *checker) walk(n *node, t reflect.Type, path, pattern string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if n.kind == kindNull {
+		// null 对所有字段都等同于未填写
+		return
+	}
+	want := typeName(t)
+	if want != "" && want != n.typeName() {
+		c.add(Error, n.off, path, i18n.T(i18n.MsgConfigType, want, n.typeName()), typeFix(want, n))
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := jsonFields(t)
+		c.checkDuplicates(n, path)
+		for _, f := range n.fields {
+			sub, subPattern := join(path, f.key), join(pattern, f.key)
+			if ft, ok := fields[f.key]; ok {
+				c.walk(f.val, ft, sub, subPattern)
+				continue
+			}
+			if repl, ok := deprecated[subPattern]; ok {
+				c.add(Warning, f.off, sub, i18n.T(i18n.MsgConfigDeprecated, f.key), i18n.T(i18n.MsgConfigFixMove, repl))
+				continue
+			}
+			fix := i18n.T(i18n.MsgConfigFixRemove)
+			if s := suggest(f.key, keys(fields)); s != "" {
+				fix = i18n.T(i18n.MsgConfigFixRename, s)
+			}
+			c.add(Warning, f.off, sub, i18n.T(i18n.MsgConfigUnknown, f.key), fix)
+		}
+	case reflect.Map:
+		c.checkDuplicates(n, path)
+		for _, f := range n.fields {
+			c.walk(f.val, t.Elem(), join(path, f.key), join(pattern, "*"))
+		}
+	case reflect.Slice:
+		for i, item := range n.items {
+			c.walk(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), pattern+"[]")
+		}
+	case reflect.String:
+		if values, ok := enums[pattern]; ok && !contains(values, n.str) {
+			fix := i18n.T(i18n.MsgConfigFixOneOf, strings.Join(values, " / "))
+			if s := suggest(n.str, values); s != "" {
+				fix = i18n.T(i18n.MsgConfigFixValue, s)
+			}
+			c.add(Error, n.off, path, i18n.T(i18n.MsgConfigEnum, n.str), fix)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if strings.ContainsAny(n.str, ".eE") {
+			c.add(Error, n.off, path, i18n.T(i18n.MsgConfigType, "integer", n.str), i18n.T(i18n.MsgConfigFixInteger))
+		}
+	}
+}
+
+// checkDuplicates 同一对象中重复的键：加载时以最后一个为准，前面的会被悄悄忽略
+func (c *checker) checkDuplicates(n *node, path string) {
+	seen := map[string]bool{}
+	for _, f := range n.fields {
+		if seen[f.key] {
+			c.add(Warning, f.off, join(path, f.key), i18n.T(i18n.MsgConfigDuplicate, f.key), i18n.T(i18n.MsgConfigFixDuplicate))
+		}
+		seen[f.key] = true
+	}
+}
+
+// typeFix 类型不符时的修改建议：多了或少了引号时直接给出改后的写法
+func typeFix(want string, n *node) string {
+	switch {
+	case want == "boolean" && n.kind == kindString && (n.str == "true" || n.str == "false"):
+		return i18n.T(i18n.MsgConfigFixUnquote, n.str)
+	case want == "number" && n.kind == kindString && isNumber(n.str):
+		return i18n.T(i18n.MsgConfigFixUnquote, n.str)
+	case want == "string" && (n.kind == kindNumber || n.kind == kindBool):
+		return i18n.T(i18n.MsgConfigFixQuote, `"`+n.str+`"`)
+	}
+	return i18n.T(i18n.MsgConfigFixType, want)
+}
+
+func isNumber(s string) bool {
+	var v any
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if dec.Decode(&v) != nil {
+		return false
+	}
+	_, ok := v.(json.Number)
+	return ok
+}
+
+// typeName Go 类型对应的 JSON 类型名，无法判断时返回空串
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
+
+// jsonFields 结构体的 JSON 字段名到字段类型
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func keys(m map[string]reflect.Type) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest 在 candidates 中找与 s 最接近的一个（编辑距离不超过 2 且小于 s 长度的一半），没有时返回空串
+func suggest(s string, candidates []string) string {
+	best, bestDist := "", 3
+	lower := strings.ToLower(s)
+	for _, c := range candidates {
+		d := distance(lower, c)
+		if d < bestDist && d*2 < utf8.RuneCountInString(s) {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// distance 编辑距离（相邻字符交换算一次，"modle" 与 "model" 的距离为 1）
+func distance(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	d := make([][]int, len(x)+1)
+	for i := range d {
+		d[i] = make([]int, len(y)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(x)][len(y)]
+}
+
+// position 字节偏移对应的行号与列号
+func position(data []byte, off int) (int, int) {
+	off = min(off, len(data))
+	before := data[:off]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:]) + 1
+	return line, col
+}
+
+// JSON 值的类型
+const (
+	kindObject = iota
+	kindArray
+	kindString
+	kindNumber
+	kindBool
+	kindNull
+)
+
+// node 带位置信息的 JSON 值；标量的原文保存在 str 中
+type node struct {
+	kind   int
+	off    int
+	fields []field // 对象的键值，保留原始顺序与重复的键
+	items  []*node
+	str    string
+}
+
+type field struct {
+	key string
+	off int
+	val *node
+}
+
+func (n *node) typeName() string {
+	return [...]string{"object", "array", "string", "number", "boolean", "null"}[n.kind]
+}
+
+// parse 解析 JSON 并记录每个键与值的起始位置
+func parse(data []byte) (*node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := &parser{data: data, dec: dec}
+	root, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	off := p.start()
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			return nil, &trailingError{off: off}
+		}
+		return nil, err
+	}
+	return root, nil
+}
+
+// trailingError 顶层值之后还有多余的内容
+type trailingError struct {
+	off int
+}
+
+func (e *trailingError) Error() string {
+	return i18n.T(i18n.MsgConfigTrailing)
+}
+
+type parser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// start 下一个 token 的起始位置：跳过空白与分隔符
+func (p *parser) start() int {
+	off := int(p.dec.InputOffset())
+	for off < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+func (p *parser) value() (*node, error) {
+	off := p.start()
+	tok, err := p.dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	n := &node{off: off}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			n.kind = kindObject
+			for p.dec.More() {
+				keyOff := p.start()
+				key, err := p.dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				val, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.fields = append(n.fields, field{key: key.(string), off: keyOff, val: val})
+			}
+		} else {
+			n.kind = kindArray
+			for p.dec.More() {
+				item, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, item)
+			}
+		}
+		if _, err := p.dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		n.kind, n.str = kindString, t
+	case json.Number:
+		n.kind, n.str = kindNumber, t.String()
+	case bool:
+		n.kind, n.str = kindBool, fmt.Sprint(t)
+	case nil:
+		n.kind = kindNull
+	}
+	return n, nil
+}
@@ -0,0 +1,230 @@
+// Package cron 定时任务：按 cron 表达式定期执行保存的问题、模板或流水线，把结果写入文件、发送桌面通知或 webhook。
+// 任务保存在 agent/data/cron.json，由守护进程（agent daemon）按时执行；守护进程未运行期间错过的执行不会补跑。
+// 每次执行以子进程运行 agent ask / agent flow run，与命令行中的调用完全一致（拦截器链、预算、模板变量等），
+// 执行记录追加到 agent/data/cron_runs.jsonl。
+package cron
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+const (
+	// FileName 任务文件名（位于 agent 数据目录）
+	FileName = "cron.json"
+	// RunsFileName 执行记录文件名（位于 agent 数据目录）
+	RunsFileName = "cron_runs.jsonl"
+	// DefaultTimeout 单次执行的默认超时
+	DefaultTimeout = 10 * time.Minute
+)
+
+// Job 一个定时任务：Prompt / Template / Flow 三者至少给出一个（Template 与 Prompt 可同时给出，Prompt 为其 Input 或补充要求）
+type Job struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+	Prompt   string `json:"prompt,omitempty"`
+	Template string `json:"template,omitempty"`
+	// Flow 流水线文件的绝对路径
+	Flow string `json:"flow,omitempty"`
+	// Vars 模板或流水线的变量，原样传给 --var（@文件 等来源在每次执行时读取）
+	Vars     []string `json:"vars,omitempty"`
+	Provider string   `json:"provider,omitempty"`
+	// Input 执行前运行的 shell 命令，输出作为管道输入（如 git log --since=yesterday）
+	Input string `json:"input,omitempty"`
+	// Dir 执行 Input 与 agent 的工作目录
+	Dir string `json:"dir,omitempty"`
+	// Deliver 结果的去向，见 ParseTarget；为空时只记入执行记录
+	Deliver []string `json:"deliver,omitempty"`
+	// TimeoutSecs 单次执行的超时，0 为 DefaultTimeout
+	TimeoutSecs int       `json:"timeout_secs,omitempty"`
+	Paused      bool      `json:"paused,omitempty"`
+	Created     time.Time `json:"created"`
+}
+
+// Timeout 单次执行的超时
+func (j Job) Timeout() time.Duration {
+	if j.TimeoutSecs > 0 {
+		return time.Duration(j.TimeoutSecs) * time.Second
+	}
+	return DefaultTimeout
+}
+
+// Run 一次执行的记录
+type Run struct {
+	Job        string    `json:"job"`
+	Started    time.Time `json:"started"`
+	DurationMs int64     `json:"duration_ms"`
+	// Status ok / error / timeout
+	Status string `json:"status"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Trigger schedule（按时执行）或 manual（agent cron run）
+	Trigger string `json:"trigger"`
+	// Delivered 已送达的去向，Failed 送达失败的去向及原因
+	Delivered []string `json:"delivered,omitempty"`
+	Failed    []string `json:"failed,omitempty"`
+}
+
+// 执行状态
+const (
+	StatusOK      = "ok"
+	StatusError   = "error"
+	StatusTimeout = "timeout"
+)
+
+// 触发方式
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// ValidName 任务名只能包含字母、数字、_ . -（以字母或数字开头，至多 64 个字符）
+func ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// Path 任务文件路径
+func Path() string {
+	return filepath.Join(config.AgentDataDir(), FileName)
+}
+
+// RunsPath 执行记录路径
+func RunsPath() string {
+	return filepath.Join(config.AgentDataDir(), RunsFileName)
+}
+
+// Load 读取全部任务（按名称排序），文件不存在时为空
+func Load() ([]Job, error) {
+	path := Path()
+	if _, err := os.Stat(filepath.Dir(path)); err != nil {
+		return nil, nil
+	}
+	unlock, err := filelock.RLock(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return read(path)
+}
+
+func read(path string) ([]Job, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var jobs []Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].Name < jobs[k].Name })
+	return jobs, nil
+}
+
+// Update 在独占锁内读取、修改并写回全部任务
+func Update(fn func(jobs []Job) ([]Job, error)) error {
+	path := Path()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	unlock, err := filelock.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	jobs, err := read(path)
+	if err != nil {
+		return err
+	}
+	if jobs, err = fn(jobs); err != nil {
+		return err
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].Name < jobs[k].Name })
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Find 按名称查找任务
+func Find(jobs []Job, name string) int {
+	for i, j := range jobs {
+		if j.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// AppendRun 追加一条执行记录
+func AppendRun(r Run) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(RunsPath()), 0o755); err != nil {
+		return err
+	}
+	return filelock.AppendLine(RunsPath(), line)
+}
+
+// Runs 读取执行记录（按时间先后），job 不为空时只保留该任务的记录；损坏的行会被跳过
+func Runs(job string) ([]Run, error) {
+	if unlock, err := filelock.RLock(RunsPath()); err == nil {
+		defer unlock()
+	}
+	f, err := os.Open(RunsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var runs []Run
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		var r Run
+		if json.Unmarshal(scanner.Bytes(), &r) != nil {
+			continue
+		}
+		if job == "" || r.Job == job {
+			runs = append(runs, r)
+		}
+	}
+	return runs, scanner.Err()
+}
+
+// LastRuns 每个任务最近的一次执行
+func LastRuns() (map[string]Run, error) {
+	runs, err := Runs("")
+	if err != nil {
+		return nil, err
+	}
+	last := map[string]Run{}
+	for _, r := range runs {
+		last[r.Job] = r
+	}
+	return last, nil
+}
@@ -0,0 +1,173 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// 结果去向
+const (
+	TargetFile    = "file"    // file:路径，追加到文件（路径中的 {date} 替换为执行当天的日期）
+	TargetNotify  = "notify"  // 桌面通知（osascript / notify-send）
+	TargetWebhook = "webhook" // webhook:URL，POST JSON（带 text 字段，可直接发到 Slack 等的 incoming webhook）
+)
+
+const (
+	// webhookTimeout 发送 webhook 的超时
+	webhookTimeout = 15 * time.Second
+	// notifyRunes 通知正文的最大字符数
+	notifyRunes = 200
+)
+
+// Target 结果的一个去向
+type Target struct {
+	Kind  string
+	Value string
+}
+
+// ParseTarget 解析去向：file:路径、notify 或 webhook:URL
+func ParseTarget(spec string) (Target, error) {
+	kind, value, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	switch kind {
+	case TargetNotify:
+		if value == "" {
+			return Target{Kind: kind}, nil
+		}
+	case TargetFile:
+		if value != "" {
+			return Target{Kind: kind, Value: value}, nil
+		}
+	case TargetWebhook:
+		if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+			return Target{Kind: kind, Value: value}, nil
+		}
+	}
+	return Target{}, fmt.Errorf("invalid target %q (file:<path>, notify or webhook:<url>)", spec)
+}
+
+// Deliver 把一次执行的结果送到去向；执行失败时同样送达，内容为错误信息
+func (t Target) Deliver(ctx context.Context, j Job, r Run) error {
+	switch t.Kind {
+	case TargetFile:
+		return deliverFile(t.Value, r)
+	case TargetNotify:
+		return deliverNotify(ctx, r)
+	case TargetWebhook:
+		return deliverWebhook(ctx, t.Value, j, r)
+	}
+	return fmt.Errorf("unknown target %q", t.Kind)
+}
+
+// body 送达的正文：成功时为输出，失败时为错误
+func body(r Run) string {
+	if r.Status != StatusOK {
+		return fmt.Sprintf("[%s] %s", r.Status, r.Error)
+	}
+	return r.Output
+}
+
+// FilePath 文件去向的实际路径：展开 ~/ 并把 {date} 替换为 t 当天的日期
+func FilePath(path string, t time.Time) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	return strings.ReplaceAll(path, "{date}", t.Format("2006-01-02"))
+}
+
+// deliverFile 以一个二级标题开头追加到文件
+func deliverFile(path string, r Run) error {
+	path = FilePath(path, r.Started)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "## %s · %s\n\n%s\n\n", r.Job, r.Started.Format("2006-01-02 15:04"), strings.TrimSpace(body(r)))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// deliverNotify 发送桌面通知，正文截取开头的 notifyRunes 个字符
+func deliverNotify(ctx context.Context, r Run) error {
+	title := "j cron: " + r.Job
+	text := strings.Join(strings.Fields(body(r)), " ")
+	if runes := []rune(text); len(runes) > notifyRunes {
+		text = string(runes[:notifyRunes]) + "…"
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		script := fmt.Sprintf("display notification %s with title %s", appleString(text), appleString(title))
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	} else {
+		path, err := exec.LookPath("notify-send")
+		if err != nil {
+			return errors.New("notify-send not found")
+		}
+		cmd = exec.CommandContext(ctx, path, "--app-name=j", title, text)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleString AppleScript 字符串字面量
+func appleString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// webhookPayload webhook 的请求体
+type webhookPayload struct {
+	Job        string    `json:"job"`
+	Schedule   string    `json:"schedule"`
+	Status     string    `json:"status"`
+	Started    time.Time `json:"started"`
+	DurationMs int64     `json:"duration_ms"`
+	Output     string    `json:"output,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	// Text 供 Slack / Mattermost 等 incoming webhook 直接显示的文本
+	Text string `json:"text"`
+}
+
+// deliverWebhook POST JSON，非 2xx 视为失败
+func deliverWebhook(ctx context.Context, url string, j Job, r Run) error {
+	data, err := json.Marshal(webhookPayload{
+		Job: r.Job, Schedule: j.Schedule, Status: r.Status, Started: r.Started, DurationMs: r.DurationMs,
+		Output: r.Output, Error: r.Error, Text: fmt.Sprintf("*%s*\n%s", r.Job, body(r)),
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("POST %s: %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/templates"
+)
+
+// maxErrorBytes 执行记录中保留的 stderr 末尾长度
+const maxErrorBytes = 4 << 10
+
+// command 执行任务时传给 agent 的参数与标准输入；input 为 Input 命令的输出：
+// 流水线以它为 {{input}}，模板以它为 {{.Input}}（Prompt 为补充要求），普通问题把它附在 Prompt 之后
+func (j Job) command(input []byte) ([]string, []byte) {
+	var args []string
+	if j.Flow != "" {
+		args = []string{"flow", "run"}
+	} else {
+		args = []string{"ask"}
+		if j.Provider != "" {
+			args = append(args, "--provider", j.Provider)
+		}
+		if j.Template != "" {
+			args = append(args, "--template", j.Template)
+		}
+	}
+	for _, v := range j.Vars {
+		args = append(args, "--var", v)
+	}
+	switch {
+	case j.Flow != "":
+		return append(args, j.Flow), input
+	case j.Input == "":
+	case j.Template != "":
+		if !slices.ContainsFunc(j.Vars, func(v string) bool { return strings.HasPrefix(v, templates.InputVar+"=") }) {
+			args = append(args, "--var", templates.InputVar+"=@-")
+		}
+	default:
+		return args, []byte(j.Prompt + "\n\n" + string(input))
+	}
+	if j.Prompt != "" {
+		args = append(args, "--", j.Prompt)
+	}
+	return args, input
+}
+
+// Perform 执行任务、把结果送到各个去向并记入执行记录
+func Perform(ctx context.Context, j Job, trigger string) Run {
+	r := Execute(ctx, j)
+	r.Trigger = trigger
+	for _, spec := range j.Deliver {
+		t, err := ParseTarget(spec)
+		if err == nil {
+			err = t.Deliver(ctx, j, r)
+		}
+		if err != nil {
+			r.Failed = append(r.Failed, fmt.Sprintf("%s: %v", spec, err))
+		} else {
+			r.Delivered = append(r.Delivered, spec)
+		}
+	}
+	if err := AppendRun(r); err != nil {
+		r.Failed = append(r.Failed, fmt.Sprintf("%s: %v", RunsPath(), err))
+	}
+	return r
+}
+
+// Execute 以子进程运行 agent（先运行 Input 命令取得管道输入），超时或 ctx 结束时终止子进程
+func Execute(ctx context.Context, j Job) Run {
+	r := Run{Job: j.Name, Started: time.Now()}
+	ctx, cancel := context.WithTimeout(ctx, j.Timeout())
+	defer cancel()
+	output, err := execute(ctx, j)
+	r.DurationMs = time.Since(r.Started).Milliseconds()
+	r.Output = output
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		r.Status, r.Error = StatusTimeout, fmt.Sprintf("timed out after %s", j.Timeout())
+	case err != nil:
+		r.Status, r.Error = StatusError, err.Error()
+	default:
+		r.Status = StatusOK
+	}
+	return r
+}
+
+func execute(ctx context.Context, j Job) (string, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	profile, err := config.Profile()
+	if err != nil {
+		return "", err
+	}
+	env := append(os.Environ(), config.ProfileEnv+"="+profile)
+	var stdin []byte
+	if j.Input != "" {
+		cmd := exec.CommandContext(ctx, "sh", "-c", j.Input)
+		cmd.Dir, cmd.Env = j.Dir, env
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if stdin, err = cmd.Output(); err != nil {
+			return "", fmt.Errorf("input %q: %w%s", j.Input, err, tail(stderr.Bytes()))
+		}
+	}
+	args, stdin := j.command(stdin)
+	cmd := exec.CommandContext(ctx, self, args...)
+	cmd.Dir, cmd.Env = j.Dir, env
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err = cmd.Run()
+	output := strings.TrimSpace(stdout.String())
+	if err != nil {
+		return output, fmt.Errorf("%w%s", err, tail(stderr.Bytes()))
+	}
+	return output, nil
+}
+
+// tail stderr 的末尾部分，附在错误之后
+func tail(stderr []byte) string {
+	text := strings.TrimSpace(string(stderr))
+	if text == "" {
+		return ""
+	}
+	if len(text) > maxErrorBytes {
+		text = "..." + text[len(text)-maxErrorBytes:]
+	}
+	return ": " + text
+}
@@ -0,0 +1,197 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinEvery @every 的最小间隔
+const MinEvery = time.Minute
+
+// Schedule 解析后的执行时间表：五段式 cron 表达式（分 时 日 月 周），或 @every 固定间隔
+type Schedule struct {
+	minute, hour, dom, month, dow uint64 // 各段允许的取值（按位）
+	domAny, dowAny                bool   // 日 / 周 为 *（两者都有限制时满足其一即可，与 cron 一致）
+	every                         time.Duration
+}
+
+// shortcuts 常用的简写
+var shortcuts = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	dowNames   = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// field 一段的取值范围，names 为可用的英文缩写（从 min 开始编号）
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var fields = []field{
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day of month", 1, 31, nil},
+	{"month", 1, 12, monthNames},
+	{"day of week", 0, 7, dowNames}, // 0 与 7 都是周日
+}
+
+// Parse 解析时间表：五段式 cron 表达式（支持 *、列表 a,b、范围 a-b、步长 */n 与 a-b/n、月份与星期的英文缩写），
+// @hourly / @daily / @weekly / @monthly / @yearly，或 @every 30m 这样的固定间隔（至少 1 分钟）
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", spec, err)
+		}
+		if d < MinEvery {
+			return nil, fmt.Errorf("%q: interval must be at least %s", spec, MinEvery)
+		}
+		return &Schedule{every: d}, nil
+	}
+	expr := spec
+	if s, ok := shortcuts[strings.ToLower(spec)]; ok {
+		expr = s
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%q: expected 5 fields (minute hour day-of-month month day-of-week) or @daily, @every 1h...", spec)
+	}
+	s := &Schedule{}
+	sets := []*uint64{&s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, part := range parts {
+		bits, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s: %w", spec, fields[i].name, err)
+		}
+		*sets[i] = bits
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny, s.dowAny = strings.HasPrefix(parts[2], "*"), strings.HasPrefix(parts[4], "*")
+	return s, nil
+}
+
+// parseField 解析一段，返回允许取值的位集合
+func parseField(part string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(part, ",") {
+		rng, stepText, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepText)
+			}
+			step = n
+		}
+		lo, hi := f.min, f.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = f.value(a); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(b); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			v, err := f.value(rng)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if !hasStep {
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// value 一个取值：数字或英文缩写
+func (f field) value(text string) (int, error) {
+	lower := strings.ToLower(text)
+	for i, name := range f.names {
+		if lower == name {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", text)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%d out of range %d-%d", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next after 之后（不含）的下一次执行时间（与 after 同一时区，精确到分钟）；
+// 表达式永远不会满足（如 2 月 30 日）时为零值
+func (s *Schedule) Next(after time.Time) time.Time {
+	if s.every > 0 {
+		return after.Truncate(time.Second).Add(s.every)
+	}
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	// 逐级跳过不满足的月、日、时、分；五年内找不到即视为永远不满足
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches 日与周都有限制时满足其一即可，只有一个有限制时只看它
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
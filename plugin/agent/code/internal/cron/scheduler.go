@@ -0,0 +1,159 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// checkInterval 调度器检查到期任务与任务文件变化的间隔
+const checkInterval = time.Second
+
+// JobStatus 守护进程中一个任务的调度状态
+type JobStatus struct {
+	Name     string    `json:"name"`
+	Schedule string    `json:"schedule"`
+	Next     time.Time `json:"next,omitempty"`
+	Paused   bool      `json:"paused,omitempty"`
+	Running  bool      `json:"running,omitempty"`
+}
+
+// Scheduler 在守护进程中按时执行任务：任务文件变化时重新加载，同一任务上一次执行未结束时跳过这一次
+type Scheduler struct {
+	log  io.Writer
+	done func(Run)
+
+	mu      sync.Mutex
+	mod     time.Time
+	jobs    []Job
+	next    map[string]time.Time // 任务名 → 下一次执行时间
+	specs   map[string]string    // 任务名 → 计算 next 时的时间表
+	running map[string]bool
+}
+
+// NewScheduler 创建调度器，每次执行结束后调用 done；加载或执行中的错误写到 log
+func NewScheduler(log io.Writer, done func(Run)) *Scheduler {
+	return &Scheduler{
+		log:     log,
+		done:    done,
+		next:    map[string]time.Time{},
+		specs:   map[string]string{},
+		running: map[string]bool{},
+	}
+}
+
+// Run 调度任务直到 ctx 结束，返回前等待正在执行的任务结束（ctx 结束时子进程会被终止）
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		s.reload()
+		for _, j := range s.due(time.Now()) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r := Perform(ctx, j, TriggerSchedule)
+				s.mu.Lock()
+				delete(s.running, j.Name)
+				s.mu.Unlock()
+				if s.done != nil {
+					s.done(r)
+				}
+			}()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reload 任务文件有变化时重新加载，时间表没有变化的任务保留原来的下一次执行时间
+func (s *Scheduler) reload() {
+	info, err := os.Stat(Path())
+	var mod time.Time
+	if err == nil {
+		mod = info.ModTime()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if mod.Equal(s.mod) && s.jobs != nil {
+		return
+	}
+	jobs, err := Load()
+	if err != nil {
+		fmt.Fprintf(s.log, "cron: %v\n", err)
+		return
+	}
+	s.mod, s.jobs = mod, append([]Job{}, jobs...)
+	now := time.Now()
+	names := map[string]bool{}
+	for _, j := range jobs {
+		names[j.Name] = true
+		if s.specs[j.Name] == j.Schedule {
+			continue
+		}
+		sched, err := Parse(j.Schedule)
+		if err != nil {
+			fmt.Fprintf(s.log, "cron: %s: %v\n", j.Name, err)
+			delete(s.next, j.Name)
+			s.specs[j.Name] = j.Schedule
+			continue
+		}
+		s.next[j.Name], s.specs[j.Name] = sched.Next(now), j.Schedule
+	}
+	for name := range s.specs {
+		if !names[name] {
+			delete(s.specs, name)
+			delete(s.next, name)
+		}
+	}
+}
+
+// due 到期的任务：标记为执行中并计算下一次执行时间；暂停的任务只推进时间，不执行
+func (s *Scheduler) due(now time.Time) []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []Job
+	for _, j := range s.jobs {
+		next, ok := s.next[j.Name]
+		if !ok || next.IsZero() || now.Before(next) {
+			continue
+		}
+		sched, err := Parse(j.Schedule)
+		if err != nil {
+			continue
+		}
+		s.next[j.Name] = sched.Next(now)
+		if j.Paused {
+			continue
+		}
+		if s.running[j.Name] {
+			fmt.Fprintf(s.log, "cron: %s is still running, skipped\n", j.Name)
+			continue
+		}
+		s.running[j.Name] = true
+		due = append(due, j)
+	}
+	return due
+}
+
+// Status 各任务的调度状态（按名称排序）
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		list = append(list, JobStatus{Name: j.Name, Schedule: j.Schedule, Next: s.next[j.Name],
+			Paused: j.Paused, Running: s.running[j.Name]})
+	}
+	sort.Slice(list, func(i, k int) bool { return list[i].Name < list[k].Name })
+	return list
+}
@@ -0,0 +1,239 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/metrics"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/trace"
+)
+
+// Available 守护进程是否在运行且允许使用
+func Available() bool {
+	if !Enabled() {
+		return false
+	}
+	conn, err := dial()
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+type sessionKey struct{}
+
+// WithSession 让经守护进程发送的请求使用名为 name 的会话：守护进程在请求前补上会话中的历史消息，
+// 并在完成后记入本轮问答
+func WithSession(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, name)
+}
+
+// remote 经守护进程发送请求的客户端，拦截器链在守护进程中执行
+type remote struct {
+	p    config.Provider
+	opts provider.Options
+}
+
+// NewClient 创建经守护进程发送请求的客户端；调用方应先用 Available 确认守护进程在运行
+func NewClient(p config.Provider, opts provider.Options) provider.Client {
+	return &remote{p, opts}
+}
+
+func (r *remote) Chat(ctx context.Context, messages []provider.Message, onDelta func(string)) (answer string, err error) {
+	ctx, span := trace.Start(ctx, "daemon "+opChat, trace.KindClient, trace.String("j.provider", r.p.Name))
+	defer func() {
+		provider.RecordError(span, err)
+		span.End()
+	}()
+	conn, err := dial()
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	// 取消时关闭连接，守护进程读到 EOF 后取消它那边的请求
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	session, _ := ctx.Value(sessionKey{}).(string)
+	req := request{Op: opChat, Provider: &r.p, Options: &r.opts, Messages: toWire(messages), Session: session,
+		Traceparent: trace.Traceparent(ctx)}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return "", err
+	}
+	dec := json.NewDecoder(conn)
+	for {
+		var f frame
+		if err := dec.Decode(&f); err != nil {
+			if ctx.Err() != nil {
+				return answer, ctx.Err()
+			}
+			return answer, err
+		}
+		switch {
+		case f.Done:
+			switch {
+			case f.Truncated:
+				return f.Answer, provider.ErrTruncated
+			case f.Unavailable:
+				return f.Answer, &intercept.UnavailableError{Err: errors.New(f.Error)}
+			case f.Error != "":
+				return f.Answer, errors.New(f.Error)
+			}
+			return f.Answer, nil
+		case f.Event != nil:
+			e := intercept.Event{Kind: f.Event.Kind, Wait: time.Duration(f.Event.WaitMs) * time.Millisecond,
+				Attempt: f.Event.Attempt, Count: f.Event.Count}
+			if f.Event.Error != "" {
+				e.Err = errors.New(f.Event.Error)
+			}
+			intercept.Notify(ctx, e)
+		default:
+			answer += f.Delta
+			if onDelta != nil {
+				onDelta(f.Delta)
+			}
+		}
+	}
+}
+
+// ErrNotRunning 连接不上守护进程
+var ErrNotRunning = errors.New("daemon not running")
+
+// call 发送一个请求并读取第一帧
+func call(req request) (f frame, err error) {
+	ctx, span := trace.Start(context.Background(), "daemon "+req.Op, trace.KindClient)
+	defer func() {
+		if errors.Is(err, ErrNotRunning) {
+			span.SetAttrs(trace.Bool("j.daemon_running", false))
+		} else {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+	req.Traceparent = trace.Traceparent(ctx)
+	conn, err := dial()
+	if err != nil {
+		return frame{}, ErrNotRunning
+	}
+	defer conn.Close()
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return frame{}, err
+	}
+	if err := json.NewDecoder(conn).Decode(&f); err != nil {
+		return frame{}, err
+	}
+	if f.Error != "" {
+		return f, errors.New(f.Error)
+	}
+	return f, nil
+}
+
+// FetchStatus 读取守护进程的运行状态
+func FetchStatus() (Status, error) {
+	f, err := call(request{Op: opStatus})
+	if err != nil {
+		return Status{}, err
+	}
+	if f.Status == nil {
+		return Status{}, errors.New("empty status")
+	}
+	return *f.Status, nil
+}
+
+// FetchMetrics 读取守护进程启动以来的请求统计
+func FetchMetrics() (metrics.Snapshot, error) {
+	f, err := call(request{Op: opMetrics})
+	if err != nil {
+		return metrics.Snapshot{}, err
+	}
+	if f.Metrics == nil {
+		return metrics.Snapshot{}, errors.New("empty metrics")
+	}
+	return *f.Metrics, nil
+}
+
+// Stop 让守护进程退出
+func Stop() error {
+	_, err := call(request{Op: opStop})
+	return err
+}
+
+// SessionMessages 读取守护进程中会话 name 的消息
+func SessionMessages(name string) ([]provider.Message, bool, error) {
+	f, err := call(request{Op: opSession, Session: name})
+	if err != nil {
+		return nil, false, err
+	}
+	return fromWire(f.Messages), f.Found, nil
+}
+
+// ForgetSession 删除守护进程中的会话 name，不存在时返回 false
+func ForgetSession(name string) (bool, error) {
+	f, err := call(request{Op: opForget, Session: name})
+	if err != nil {
+		return false, err
+	}
+	return f.Found, nil
+}
+
+// Watch 订阅守护进程推送的事件，直到 ctx 结束或守护进程退出（两者都返回 nil）
+func Watch(ctx context.Context, fn func(Notice)) error {
+	conn, err := dial()
+	if err != nil {
+		return ErrNotRunning
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	if err := json.NewEncoder(conn).Encode(request{Op: opWatch}); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(conn)
+	for {
+		var f frame
+		if err := dec.Decode(&f); err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if f.Notice != nil {
+			fn(*f.Notice)
+		}
+	}
+}
+
+// LookupPlugin 从守护进程的插件注册表中查找 path 对应的插件，守护进程未运行时返回 false
+func LookupPlugin(path string) (Plugin, bool) {
+	if !Enabled() {
+		return Plugin{}, false
+	}
+	st, err := FetchStatus()
+	if err != nil {
+		return Plugin{}, false
+	}
+	for _, p := range st.Plugins {
+		if p.Path == path {
+			return p, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// Plugins 插件目录下各插件的握手结果：守护进程运行时取它的注册表，否则当场逐个握手
+func Plugins() []Plugin {
+	if Enabled() {
+		if st, err := FetchStatus(); err == nil {
+			return st.Plugins
+		}
+	}
+	return scanPlugins(filepath.Join(config.RootDir(), config.BinDir))
+}
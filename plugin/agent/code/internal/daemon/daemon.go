@@ -0,0 +1,187 @@
+// Package daemon 实现 agent 守护进程：常驻进程保持与各 provider 的 HTTP 连接（省去每次调用的
+// 进程启动与 TLS 握手）、缓存插件握手结果（插件注册表）、在内存中保存多轮会话并按时执行定时任务（见 cron 包）；
+// CLI 通过数据目录下的 unix socket 与它通信，守护进程未运行时照常在本进程内直接请求。
+//
+// 协议为 JSON Lines：每个连接发送一个请求，守护进程回复若干帧，对话请求依次回复增量、
+// 拦截器事件与最终结果；编辑器请求（见 editor 包）同样回复增量与最终结果；
+// watch 请求保持连接，持续推送守护进程中发生的事件。
+package daemon
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/cron"
+	"wcp_agent/internal/editor"
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/metrics"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/session"
+)
+
+const (
+	// SocketFile 守护进程 socket 的文件名（位于 agent 数据目录下）
+	SocketFile = "daemon.sock"
+	// SettingKey config.yaml setting 段的开关，设为 off 时 CLI 不使用守护进程
+	SettingKey = "agent_daemon"
+	// dialTimeout 连接守护进程的超时，超时视为守护进程未运行
+	dialTimeout = 200 * time.Millisecond
+	// pollInterval 守护进程检查配置与插件变化的间隔
+	pollInterval = 2 * time.Second
+)
+
+// 请求类型
+const (
+	opChat   = "chat"
+	opStatus = "status"
+	opWatch  = "watch"
+	opStop   = "stop"
+	// opSession 读取会话中的消息，opForget 删除会话（Session 为会话名）
+	opSession = "session"
+	opForget  = "forget"
+	// opEditor 编辑器集成请求（Editor 为请求内容，见 editor 包）
+	opEditor = "editor"
+	// opMetrics 读取守护进程启动以来的请求统计
+	opMetrics = "metrics"
+)
+
+// 推送事件类型
+const (
+	NoticeConfig  = "config"  // agent_config.json 已重新加载，Count 为 provider 数
+	NoticePlugins = "plugins" // 插件注册表有变化，Count 为插件数
+	NoticeRequest = "request" // 完成一次对话请求
+	NoticeCron    = "cron"    // 完成一次定时任务，Job 为任务名
+)
+
+// SocketPath 守护进程 socket 的路径
+func SocketPath() string {
+	return filepath.Join(config.AgentDataDir(), SocketFile)
+}
+
+// Enabled 是否允许 CLI 使用守护进程（默认允许）
+func Enabled() bool {
+	switch strings.ToLower(strings.TrimSpace(config.Setting(SettingKey))) {
+	case "off", "false", "0", "no":
+		return false
+	}
+	return true
+}
+
+// dial 连接守护进程
+func dial() (net.Conn, error) {
+	return net.DialTimeout("unix", SocketPath(), dialTimeout)
+}
+
+// request 客户端发给守护进程的请求
+type request struct {
+	Op       string            `json:"op"`
+	Provider *config.Provider  `json:"provider,omitempty"`
+	Options  *provider.Options `json:"options,omitempty"`
+	Messages []message         `json:"messages,omitempty"`
+	Session  string            `json:"session,omitempty"`
+	Editor   *editor.Request   `json:"editor,omitempty"`
+	// Traceparent 客户端当前 span 的 W3C traceparent，守护进程中的 span 接在它之后
+	Traceparent string `json:"traceparent,omitempty"`
+}
+
+// message 传输用的对话消息：provider.Message 的 JSON 形式是发给模型的格式，不带图片原文
+type message struct {
+	Role    string   `json:"role"`
+	Content string   `json:"content"`
+	Images  []string `json:"images,omitempty"`
+}
+
+func toWire(messages []provider.Message) []message {
+	out := make([]message, len(messages))
+	for i, m := range messages {
+		out[i] = message{m.Role, m.Content, m.Images}
+	}
+	return out
+}
+
+func fromWire(messages []message) []provider.Message {
+	out := make([]provider.Message, len(messages))
+	for i, m := range messages {
+		out[i] = provider.Message{Role: m.Role, Content: m.Content, Images: m.Images}
+	}
+	return out
+}
+
+// frame 守护进程回复的一帧，各字段按请求类型择一出现
+type frame struct {
+	Delta     string  `json:"delta,omitempty"`
+	Event     *event  `json:"event,omitempty"`
+	Done      bool    `json:"done,omitempty"`
+	Answer    string  `json:"answer,omitempty"`
+	Error     string  `json:"error,omitempty"`
+	Truncated bool    `json:"truncated,omitempty"`
+	Status    *Status `json:"status,omitempty"`
+	Notice    *Notice `json:"notice,omitempty"`
+	// Messages opSession 回复的会话消息，Found 表示会话（opSession / opForget）存在
+	Messages []message `json:"messages,omitempty"`
+	Found    bool      `json:"found,omitempty"`
+	// Edit opEditor 中 edit 动作的结果
+	Edit *editor.Result `json:"edit,omitempty"`
+	// Metrics opMetrics 回复的请求统计
+	Metrics *metrics.Snapshot `json:"metrics,omitempty"`
+	// Unavailable 错误满足 intercept.CanFallback，调用方可改用 fallback 中的下一个 provider
+	Unavailable bool `json:"unavailable,omitempty"`
+}
+
+// event 拦截器事件（错误只传文本）
+type event struct {
+	Kind    intercept.EventKind `json:"kind"`
+	WaitMs  int64               `json:"wait_ms,omitempty"`
+	Attempt int                 `json:"attempt,omitempty"`
+	Count   int                 `json:"count,omitempty"`
+	Error   string              `json:"error,omitempty"`
+}
+
+// Status 守护进程的运行状态
+type Status struct {
+	PID      int       `json:"pid"`
+	Started  time.Time `json:"started"`
+	Socket   string    `json:"socket"`
+	Requests int       `json:"requests"`
+	// Warm 已建立连接的 provider
+	Warm     []string       `json:"warm"`
+	Sessions []session.Info `json:"sessions"`
+	Plugins  []Plugin       `json:"plugins"`
+	// Jobs 定时任务的调度状态
+	Jobs []cron.JobStatus `json:"jobs,omitempty"`
+}
+
+// Plugin 插件注册表中的一项：数据目录 bin 下的可执行文件及其握手结果（不支持握手时协议为 0）
+type Plugin struct {
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Protocol  int      `json:"protocol"`
+	Features  []string `json:"features,omitempty"`
+	Transport string   `json:"transport,omitempty"`
+	// Filters 声明了 filter 特性的插件提供的过滤器（见 internal/filter）
+	Filters []PluginFilter `json:"filters,omitempty"`
+}
+
+// PluginFilter 插件在握手应答中声明的一个过滤器
+type PluginFilter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Kind 为 postprocess 时过滤器保持 Markdown，可由预设的 postprocess 在每次回答后自动执行
+	Kind string `json:"kind,omitempty"`
+}
+
+// Notice 守护进程推送的事件
+type Notice struct {
+	Time       time.Time `json:"time"`
+	Kind       string    `json:"kind"`
+	Provider   string    `json:"provider,omitempty"`
+	Model      string    `json:"model,omitempty"`
+	Session    string    `json:"session,omitempty"`
+	Job        string    `json:"job,omitempty"`
+	Status     string    `json:"status,omitempty"` // request：ok / truncated / cancelled / error；cron：ok / error / timeout
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	Count      int       `json:"count,omitempty"`
+}
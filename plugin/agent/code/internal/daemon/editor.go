@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/editor"
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+// editor 处理编辑器请求：以配置中的 provider（或请求指定的）发送 editor.Prompt，
+// 问答记入问答历史；edit 动作在最终帧中附上替换文本与 diff
+func (s *Server) editor(ctx context.Context, req request, w *frameWriter) {
+	if req.Editor == nil {
+		w.write(frame{Done: true, Error: "missing editor request"})
+		return
+	}
+	er := *req.Editor
+	if err := er.Validate(); err != nil {
+		w.write(frame{Done: true, Error: err.Error()})
+		return
+	}
+	if er.Lang == "" {
+		er.Lang = string(i18n.Current())
+	}
+	cfg, err := config.LoadAgent()
+	if err != nil {
+		w.write(frame{Done: true, Error: err.Error()})
+		return
+	}
+	p, err := editorProvider(cfg, er.Provider)
+	if err != nil {
+		w.write(frame{Done: true, Error: err.Error()})
+		return
+	}
+	key, _ := auth.Resolve(p)
+	opts := provider.Options{Key: key, Stream: er.Stream, Timeouts: cfg.EffectiveTimeouts(p)}
+	prompt := editor.Prompt(er)
+	var messages []provider.Message
+	if system := config.LoadSystemPrompt(cfg); system != "" {
+		messages = append(messages, provider.Message{Role: "system", Content: system})
+	}
+	messages = append(messages, provider.Message{Role: "user", Content: prompt})
+
+	start := time.Now()
+	answer, err := s.converse(ctx, request{Op: opChat, Provider: &p, Options: &opts, Messages: toWire(messages), Session: er.Session}, w)
+	truncated := errors.Is(err, provider.ErrTruncated)
+	exchange := history.Exchange{
+		ID:         history.NewID(),
+		Provider:   p.Name,
+		Model:      p.Model,
+		Prompt:     prompt,
+		Answer:     answer,
+		Status:     history.StatusOK,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	switch {
+	case ctx.Err() != nil:
+		exchange.Status = history.StatusCancelled
+	case truncated:
+		exchange.Status = history.StatusTruncated
+	case err != nil:
+		exchange.Status = history.StatusError
+		exchange.Error = err.Error()
+	}
+	if herr := history.Append(exchange); herr != nil {
+		fmt.Fprintln(s.log, i18n.T(i18n.MsgError, herr))
+	}
+
+	f := frame{Done: true, Answer: answer, Truncated: truncated}
+	if err != nil && !truncated {
+		f.Error = err.Error()
+	}
+	if er.Action == editor.ActionEdit && f.Error == "" {
+		result := editor.Parse(er, answer)
+		f.Edit = &result
+	}
+	w.write(f)
+}
+
+// editorProvider 按名称选择 provider，名称为空时使用活动的 provider
+func editorProvider(cfg config.AgentConfig, name string) (config.Provider, error) {
+	if name != "" {
+		idx := cfg.FindProvider(name)
+		if idx < 0 {
+			return config.Provider{}, errors.New(i18n.T(i18n.MsgAuthUnknown, name))
+		}
+		return cfg.Providers[idx], nil
+	}
+	p, ok := cfg.Active()
+	if !ok {
+		return config.Provider{}, errors.New(i18n.T(i18n.MsgAuthNoProviders))
+	}
+	return p, nil
+}
+
+// ErrNotEditor 经 Editor 转发的不是编辑器请求
+var ErrNotEditor = errors.New(`expected a request with "op": "editor"`)
+
+// Editor 转发一个编辑器请求（socket 协议中的一行 JSON）并把回复帧原样写到 out：
+// 守护进程在运行时交给它，否则在本进程内以同样的处理逻辑完成（此时会话不会保留）。
+// 不支持 unix socket 的编辑器可以经 agent editor 的 stdin / stdout 使用同一协议
+func Editor(ctx context.Context, line []byte, out io.Writer) error {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return err
+	}
+	if req.Op != opEditor {
+		return ErrNotEditor
+	}
+	var conn net.Conn
+	if c, err := dial(); err == nil && Enabled() {
+		conn = c
+	} else {
+		if err == nil {
+			c.Close()
+		}
+		client, server := net.Pipe()
+		go func() {
+			defer server.Close()
+			NewServer(io.Discard).handle(ctx, server)
+		}()
+		conn = client
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return err
+	}
+	r := bufio.NewReader(conn)
+	for {
+		frameLine, err := r.ReadBytes('\n')
+		if len(frameLine) > 0 {
+			if _, werr := out.Write(frameLine); werr != nil {
+				return werr
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		var f frame
+		if json.Unmarshal(frameLine, &f) == nil && f.Done {
+			return nil
+		}
+	}
+}
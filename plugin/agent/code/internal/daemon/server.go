@@ -0,0 +1,475 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/compact"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/cron"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/metrics"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/session"
+	"wcp_agent/internal/trace"
+)
+
+const (
+	// capabilitiesTimeout 插件握手的超时
+	capabilitiesTimeout = 2 * time.Second
+	// warmTimeout 预先建立 provider 连接的超时
+	warmTimeout = 10 * time.Second
+)
+
+// ErrRunning 已有守护进程在运行
+var ErrRunning = errors.New("daemon already running")
+
+// Listen 在 SocketPath 上监听；已有守护进程响应时返回 ErrRunning，残留的 socket 文件会被删除。
+// socket 权限为 0600，只有当前用户可以连接
+func Listen() (net.Listener, error) {
+	path := SocketPath()
+	if conn, err := dial(); err == nil {
+		conn.Close()
+		return nil, ErrRunning
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// Server 守护进程
+type Server struct {
+	started time.Time
+	log     io.Writer
+
+	mu        sync.Mutex
+	https     map[string]*http.Client // 按 provider 连接参数复用的 HTTP 客户端
+	warm      map[string]bool
+	sessions  *session.Store
+	cron      *cron.Scheduler
+	plugins   []Plugin
+	requests  int
+	watchers  map[chan Notice]struct{}
+	configMod time.Time
+	binMod    time.Time
+
+	stop context.CancelFunc
+}
+
+// NewServer 创建守护进程，运行中的错误写到 log
+func NewServer(log io.Writer) *Server {
+	s := &Server{
+		started:  time.Now(),
+		log:      log,
+		https:    map[string]*http.Client{},
+		warm:     map[string]bool{},
+		sessions: session.NewStore(),
+		watchers: map[chan Notice]struct{}{},
+	}
+	s.cron = cron.NewScheduler(log, func(r cron.Run) {
+		s.publish(Notice{Kind: NoticeCron, Job: r.Job, Status: r.Status, DurationMs: r.DurationMs})
+	})
+	return s
+}
+
+// Serve 处理连接直到 ctx 结束或收到 stop 请求，返回前关闭监听并删除 socket 文件
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	ctx, s.stop = context.WithCancel(ctx)
+	defer s.stop()
+	defer os.Remove(SocketPath())
+	context.AfterFunc(ctx, func() { ln.Close() })
+
+	s.refresh()
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.refresh()
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.cron.Run(ctx)
+	}()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			s.handle(ctx, conn)
+		}()
+	}
+}
+
+// refresh 配置文件或插件目录有变化时重新加载，并推送事件
+func (s *Server) refresh() {
+	if mod := modTime(config.AgentConfigPath()); !mod.Equal(s.configMod) {
+		s.configMod = mod
+		if cfg, err := config.LoadAgent(); err != nil {
+			fmt.Fprintln(s.log, i18n.T(i18n.MsgError, err))
+		} else {
+			s.warmUp(cfg)
+			s.publish(Notice{Kind: NoticeConfig, Count: len(cfg.Providers)})
+		}
+	}
+	dir := filepath.Join(config.RootDir(), config.BinDir)
+	if mod := modTime(dir); !mod.Equal(s.binMod) {
+		s.binMod = mod
+		plugins := scanPlugins(dir)
+		s.mu.Lock()
+		s.plugins = plugins
+		s.mu.Unlock()
+		s.publish(Notice{Kind: NoticePlugins, Count: len(plugins)})
+	}
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// warmUp 为每个联网的 provider 预先建立连接（完成 DNS 与 TLS 握手），之后的请求复用这条连接
+func (s *Server) warmUp(cfg config.AgentConfig) {
+	s.mu.Lock()
+	s.warm = map[string]bool{}
+	s.mu.Unlock()
+	for _, p := range cfg.Providers {
+		if strings.HasPrefix(p.APIBase, provider.MockScheme) {
+			continue
+		}
+		client, err := s.httpClient(p, cfg.EffectiveTimeouts(p))
+		if err != nil {
+			continue
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), warmTimeout)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodHead, p.Endpoint(), nil)
+			if err != nil {
+				return
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return
+			}
+			resp.Body.Close()
+			s.mu.Lock()
+			s.warm[p.Name] = true
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// httpClient 按 provider 的地址、代理与连接超时复用 HTTP 客户端
+func (s *Server) httpClient(p config.Provider, timeouts config.Timeouts) (*http.Client, error) {
+	key := fmt.Sprintf("%s\x00%s\x00%s\x00%d", p.Name, p.Endpoint(), p.Proxy, timeouts.ConnectSecs)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.https[key]; ok {
+		return c, nil
+	}
+	c, err := provider.HTTPClient(p, timeouts)
+	if err != nil {
+		return nil, err
+	}
+	s.https[key] = c
+	return c, nil
+}
+
+// scanPlugins 与插件目录下的每个可执行文件握手
+func scanPlugins(dir string) []Plugin {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	plugins := make([]Plugin, 0, len(entries))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := probe(filepath.Join(dir, e.Name()))
+			mu.Lock()
+			plugins = append(plugins, p)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// probe 以 --capabilities 与插件握手，失败时按协议 0 记录
+func probe(path string) Plugin {
+	p := Plugin{Name: filepath.Base(path), Path: path}
+	ctx, cancel := context.WithTimeout(context.Background(), capabilitiesTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--capabilities").Output()
+	if err != nil {
+		return p
+	}
+	var c Plugin
+	if json.Unmarshal(out, &c) != nil {
+		return p
+	}
+	p.Protocol, p.Features, p.Transport, p.Filters = c.Protocol, c.Features, c.Transport, c.Filters
+	return p
+}
+
+// publish 把事件推送给所有 watch 连接；来不及接收的连接丢弃该事件
+func (s *Server) publish(n Notice) {
+	n.Time = time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.watchers {
+		select {
+		case ch <- n:
+		default:
+		}
+	}
+}
+
+// handle 处理一个连接上的请求
+func (s *Server) handle(ctx context.Context, conn net.Conn) {
+	dec := json.NewDecoder(conn)
+	var req request
+	if err := dec.Decode(&req); err != nil {
+		return
+	}
+	// 请求之后客户端不再发送数据：读到 EOF 说明客户端已断开（如 Ctrl-C），取消正在进行的请求
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		_, _ = io.Copy(io.Discard, dec.Buffered())
+		_, _ = io.Copy(io.Discard, conn)
+		cancel()
+	}()
+
+	ctx, span := trace.StartRemote(ctx, req.Traceparent, "daemon "+req.Op, trace.KindServer)
+	defer span.End()
+	if req.Session != "" {
+		span.SetAttrs(trace.String("j.session", req.Session))
+	}
+
+	w := &frameWriter{enc: json.NewEncoder(conn)}
+	switch req.Op {
+	case opChat:
+		s.chat(ctx, req, w)
+	case opEditor:
+		s.editor(ctx, req, w)
+	case opStatus:
+		st := s.status()
+		w.write(frame{Done: true, Status: &st})
+	case opMetrics:
+		snap := metrics.Default.Snapshot()
+		w.write(frame{Done: true, Metrics: &snap})
+	case opWatch:
+		s.watch(ctx, w)
+	case opSession:
+		messages, ok := s.sessions.Messages(req.Session)
+		w.write(frame{Done: true, Messages: toWire(messages), Found: ok})
+	case opForget:
+		w.write(frame{Done: true, Found: s.sessions.Delete(req.Session)})
+	case opStop:
+		w.write(frame{Done: true})
+		s.stop()
+	default:
+		w.write(frame{Done: true, Error: fmt.Sprintf("unknown op %q", req.Op)})
+	}
+}
+
+// frameWriter 串行写帧：增量回调与拦截器事件可能来自不同的 goroutine
+type frameWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (w *frameWriter) write(f frame) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_ = w.enc.Encode(f)
+}
+
+// chat 经完整的拦截器链发送对话，带会话名时在前面补上会话中的历史消息并在完成后记入会话
+func (s *Server) chat(ctx context.Context, req request, w *frameWriter) {
+	if req.Provider == nil || req.Options == nil {
+		w.write(frame{Done: true, Error: "missing provider"})
+		return
+	}
+	answer, err := s.converse(ctx, req, w)
+	truncated := errors.Is(err, provider.ErrTruncated)
+	f := frame{Done: true, Answer: answer, Truncated: truncated}
+	if err != nil && !truncated {
+		f.Error, f.Unavailable = err.Error(), intercept.CanFallback(err)
+	}
+	w.write(f)
+}
+
+// converse 发送对话并推送增量与拦截器事件，最终结果由调用方写出
+func (s *Server) converse(ctx context.Context, req request, w *frameWriter) (string, error) {
+	p, opts := *req.Provider, *req.Options
+	if !strings.HasPrefix(p.APIBase, provider.MockScheme) {
+		client, err := s.httpClient(p, opts.Timeouts)
+		if err != nil {
+			return "", err
+		}
+		opts.HTTP = client
+	}
+	client, err := provider.New(p, opts)
+	if err != nil {
+		return "", err
+	}
+	client = intercept.Wrap(client, p, opts)
+
+	ctx = intercept.WithObserver(ctx, func(e intercept.Event) {
+		ev := &event{Kind: e.Kind, WaitMs: e.Wait.Milliseconds(), Attempt: e.Attempt, Count: e.Count}
+		if e.Err != nil {
+			ev.Error = e.Err.Error()
+		}
+		w.write(frame{Event: ev})
+	})
+	messages := fromWire(req.Messages)
+	var turn []provider.Message
+	if req.Session != "" {
+		// 会话接近上下文预算时先把较早的消息压缩成摘要
+		if cfg, err := config.LoadAgent(); err == nil {
+			compact.Session(ctx, s.sessions, req.Session, messages, cfg, p, opts.MaxContextTokens, s.summarizer(cfg))
+		}
+		messages, turn = s.sessions.Compose(req.Session, messages)
+	}
+	// 非流式请求的完整回答同样作为一次增量转发：与直接请求一致，ask 等调用方靠增量回调输出回答
+	onDelta := func(delta string) { w.write(frame{Delta: delta}) }
+	start := time.Now()
+	answer, err := client.Chat(ctx, messages, onDelta)
+
+	truncated := errors.Is(err, provider.ErrTruncated)
+	status := "ok"
+	switch {
+	case ctx.Err() != nil:
+		status = "cancelled"
+	case truncated:
+		status = "truncated"
+	case err != nil:
+		status = "error"
+	}
+	if req.Session != "" && (err == nil || truncated) {
+		s.sessions.Remember(req.Session, turn, answer)
+	}
+	s.mu.Lock()
+	s.requests++
+	s.mu.Unlock()
+	s.publish(Notice{Kind: NoticeRequest, Provider: p.Name, Model: p.Model, Session: req.Session,
+		Status: status, DurationMs: time.Since(start).Milliseconds()})
+	return answer, err
+}
+
+// summarizer 生成会话摘要的客户端：与对话请求一样复用连接并经过完整的拦截器链（缓存、预算与用量记账照常）
+func (s *Server) summarizer(cfg config.AgentConfig) compact.Opener {
+	return func(p config.Provider) (provider.Client, error) {
+		key, _ := auth.Resolve(p)
+		opts := provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(p)}
+		if !strings.HasPrefix(p.APIBase, provider.MockScheme) {
+			client, err := s.httpClient(p, opts.Timeouts)
+			if err != nil {
+				return nil, err
+			}
+			opts.HTTP = client
+		}
+		client, err := provider.New(p, opts)
+		if err != nil {
+			return nil, err
+		}
+		return intercept.Wrap(client, p, opts), nil
+	}
+}
+
+func (s *Server) status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := Status{
+		PID:      os.Getpid(),
+		Started:  s.started,
+		Socket:   SocketPath(),
+		Requests: s.requests,
+		Warm:     []string{},
+		Sessions: s.sessions.List(),
+		Plugins:  s.plugins,
+		Jobs:     s.cron.Status(),
+	}
+	for name := range s.warm {
+		st.Warm = append(st.Warm, name)
+	}
+	sort.Strings(st.Warm)
+	return st
+}
+
+// watch 持续推送事件直到客户端断开
+func (s *Server) watch(ctx context.Context, w *frameWriter) {
+	ch := make(chan Notice, 16)
+	s.mu.Lock()
+	s.watchers[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, ch)
+		s.mu.Unlock()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-ch:
+			w.write(frame{Notice: &n})
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Package document 从 PDF 与 DOCX 文件中取出文字，按页加上页码标记，供 -f 把规范、论文、合同等文档作为提问的上下文。
+// 不依赖外部程序：PDF 解析对象（含对象流）与页面树，解码内容流中的文字（ToUnicode、WinAnsi 等编码与 /Differences）；
+// DOCX 读取 word/document.xml，页以文档中保存的分页符为准。扫描件等没有文字层的页面取不到内容。
+package document
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	KindPDF  = "pdf"
+	KindDOCX = "docx"
+
+	// MaxFileBytes 解析的文件大小上限
+	MaxFileBytes = 64 << 20
+)
+
+// ErrNoText 文档中没有可取出的文字（如扫描件）
+var ErrNoText = errors.New("no extractable text (scanned document?)")
+
+// Document 取出的文字，每页一项
+type Document struct {
+	Kind  string
+	Pages []string
+}
+
+// Kind 按扩展名与文件头判断文档类型，不是 PDF / DOCX 时返回空串
+func Kind(path string, head []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pdf":
+		return KindPDF
+	case ".docx":
+		return KindDOCX
+	}
+	if bytes.HasPrefix(head, []byte("%PDF-")) {
+		return KindPDF
+	}
+	return ""
+}
+
+// Extract 读取 path 并取出各页的文字
+func Extract(path string) (Document, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Document{}, err
+	}
+	if info.Size() > MaxFileBytes {
+		return Document{}, fmt.Errorf("%s: %d bytes, larger than %d MiB", path, info.Size(), MaxFileBytes>>20)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Document{}, err
+	}
+	doc := Document{Kind: Kind(path, data)}
+	switch doc.Kind {
+	case KindPDF:
+		doc.Pages, err = pdfPages(data)
+	case KindDOCX:
+		doc.Pages, err = docxPages(data)
+	default:
+		return Document{}, fmt.Errorf("%s: not a PDF or DOCX file", path)
+	}
+	if err != nil {
+		return Document{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if strings.TrimSpace(strings.Join(doc.Pages, "")) == "" {
+		return Document{}, fmt.Errorf("%s: %w", path, ErrNoText)
+	}
+	return doc, nil
+}
+
+// Text 全部页面的文字，每页之前是 "--- page N ---" 标记，没有文字的页面只留标记
+func (d Document) Text() string {
+	var b strings.Builder
+	for i, p := range d.Pages {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "--- page %d ---\n", i+1)
+		b.WriteString(strings.TrimSpace(p))
+	}
+	return b.String()
+}
+
+// tidy 去掉行尾空白，合并连续的空行与行内多余的空格
+func tidy(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r", ""), "\n")
+	out := make([]string, 0, len(lines))
+	blank := false
+	for _, l := range lines {
+		l = strings.TrimRight(strings.Join(strings.FieldsFunc(l, func(r rune) bool { return r == ' ' || r == '\u00a0' }), " "), " \t")
+		if l == "" {
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		out = append(out, l)
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
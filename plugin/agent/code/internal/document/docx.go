@@ -0,0 +1,177 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// docxBody 正文所在的部件
+const docxBody = "word/document.xml"
+
+// docxPages 取出 DOCX 正文的文字：段落各占一行，标题样式加上 Markdown 的 #，列表项以 "- " 开头，表格单元格以 " | " 分隔；
+// DOCX 本身没有固定的页，按文档中的手动分页符与 Word 保存时记下的分页位置分页
+func docxPages(data []byte) ([]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	var body *zip.File
+	for _, f := range zr.File {
+		if f.Name == docxBody {
+			body = f
+			break
+		}
+	}
+	if body == nil {
+		return nil, errors.New("not a Word document (" + docxBody + " missing)")
+	}
+	rc, err := body.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return parseDocx(io.LimitReader(rc, MaxFileBytes))
+}
+
+func parseDocx(r io.Reader) ([]string, error) {
+	var (
+		pages []string
+		page  strings.Builder // 当前页已完成的段落
+		para  strings.Builder // 当前段落
+		// 当前段落的前缀（标题、列表）与是否处在 <w:t> 中
+		prefix string
+		inText bool
+		// 删除的修订、域代码与制表位定义不属于正文
+		skipDepth int
+		cells     []string
+		inCell    bool
+	)
+	newPage := func() {
+		// 连续的分页（手动分页符之后紧跟 Word 记下的分页位置）只算一次
+		if strings.TrimSpace(page.String()) == "" && strings.TrimSpace(para.String()) == "" {
+			return
+		}
+		if para.Len() > 0 {
+			page.WriteString(prefix + para.String())
+			para.Reset()
+			prefix = ""
+		}
+		pages = append(pages, tidy(page.String()))
+		page.Reset()
+	}
+	endPara := func() {
+		text := strings.TrimSpace(para.String())
+		para.Reset()
+		if inCell {
+			cells = append(cells, text)
+			prefix = ""
+			return
+		}
+		if text != "" {
+			page.WriteString(prefix + text + "\n")
+		}
+		prefix = ""
+	}
+
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if skipDepth > 0 {
+				skipDepth++
+				continue
+			}
+			switch t.Name.Local {
+			case "del", "instrText", "delText", "tabs":
+				skipDepth = 1
+			case "t":
+				inText = true
+			case "tab":
+				para.WriteString("\t")
+			case "br", "cr":
+				if docxAttr(t, "type") == "page" {
+					newPage()
+				} else {
+					para.WriteString("\n")
+				}
+			case "lastRenderedPageBreak":
+				newPage()
+			case "pStyle":
+				prefix = headingPrefix(docxAttr(t, "val"), prefix)
+			case "numPr":
+				if prefix == "" {
+					prefix = "- "
+				}
+			case "tc":
+				inCell, cells = true, cells[:0:0]
+			}
+		case xml.EndElement:
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				endPara()
+			case "tc":
+				inCell = false
+				// 单元格中的多个段落合为一格
+				if len(cells) > 0 {
+					para.WriteString(strings.Join(cells, " ") + "\x00")
+				}
+			case "tr":
+				row := strings.Split(strings.TrimSuffix(para.String(), "\x00"), "\x00")
+				para.Reset()
+				page.WriteString("| " + strings.Join(row, " | ") + " |\n")
+			case "tbl":
+				page.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText && skipDepth == 0 {
+				para.Write(t)
+			}
+		}
+	}
+	if para.Len() > 0 {
+		endPara()
+	}
+	if rest := tidy(page.String()); rest != "" || len(pages) == 0 {
+		pages = append(pages, rest)
+	}
+	return pages, nil
+}
+
+// headingPrefix 标题样式（Title、Heading1…Heading6，含本地化样式中常见的 "heading 1" 写法）对应的 Markdown 前缀
+func headingPrefix(style, current string) string {
+	s := strings.ToLower(strings.ReplaceAll(style, " ", ""))
+	if s == "title" {
+		return "# "
+	}
+	if level, ok := strings.CutPrefix(s, "heading"); ok && len(level) == 1 && level[0] >= '1' && level[0] <= '6' {
+		return strings.Repeat("#", int(level[0]-'0')) + " "
+	}
+	return current
+}
+
+// docxAttr 元素的属性值（忽略命名空间，如 w:val）
+func docxAttr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,663 @@
+package document
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// PDF 对象的 Go 表示：nil、bool、float64、[]byte（字符串）、pdfName、[]any（数组）、pdfDict、pdfRef、*pdfStream，
+// 内容流中的运算符为 pdfKeyword
+
+type (
+	pdfName    string
+	pdfKeyword string
+	pdfDict    map[pdfName]any
+	pdfRef     struct{ num, gen int }
+	pdfStream  struct {
+		dict pdfDict
+		raw  []byte
+	}
+)
+
+// pdfFile 解析出的全部间接对象
+type pdfFile struct {
+	objs map[int]any
+}
+
+// objHeader 间接对象的开头 "N G obj"
+var objHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// parsePDF 顺序扫描文件中的全部间接对象（不依赖交叉引用表，损坏或增量更新过的文件也能读取），再展开对象流；
+// 后出现的同号对象覆盖先出现的（增量更新）
+func parsePDF(data []byte) (*pdfFile, error) {
+	if i := bytes.Index(data, []byte("%PDF-")); i < 0 || i > 1024 {
+		return nil, errors.New("not a PDF file")
+	}
+	f := &pdfFile{objs: map[int]any{}}
+	for pos := 0; pos < len(data); {
+		loc := objHeader.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		l := &pdfLexer{data: data, pos: pos + loc[1], refs: true}
+		if v, err := l.object(); err == nil {
+			f.objs[num] = v
+		}
+		pos = max(l.pos, pos+loc[1])
+	}
+	if len(f.objs) == 0 {
+		return nil, errors.New("no objects found (damaged or encrypted PDF?)")
+	}
+	f.expandObjectStreams()
+	return f, nil
+}
+
+// expandObjectStreams 取出对象流（/Type /ObjStm）中压缩存放的对象，文件中直接写出的同号对象优先
+func (f *pdfFile) expandObjectStreams() {
+	nums := make([]int, 0, len(f.objs))
+	for n := range f.objs {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	for _, n := range nums {
+		s, ok := f.objs[n].(*pdfStream)
+		if !ok || s.dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		data, err := f.decode(s)
+		if err != nil {
+			continue
+		}
+		count, first := f.int(s.dict["N"]), f.int(s.dict["First"])
+		header := &pdfLexer{data: data}
+		for i := 0; i < count; i++ {
+			num, err1 := header.object()
+			off, err2 := header.object()
+			if err1 != nil || err2 != nil {
+				break
+			}
+			n, _ := num.(float64)
+			o, _ := off.(float64)
+			if _, exists := f.objs[int(n)]; exists || first+int(o) >= len(data) {
+				continue
+			}
+			l := &pdfLexer{data: data, pos: first + int(o), refs: true}
+			if v, err := l.object(); err == nil {
+				f.objs[int(n)] = v
+			}
+		}
+	}
+}
+
+// resolve 取出引用指向的对象（最多追 32 层，防止循环引用）
+func (f *pdfFile) resolve(v any) any {
+	for i := 0; i < 32; i++ {
+		r, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		v = f.objs[r.num]
+	}
+	return nil
+}
+
+func (f *pdfFile) dict(v any) pdfDict {
+	switch d := f.resolve(v).(type) {
+	case pdfDict:
+		return d
+	case *pdfStream:
+		return d.dict
+	}
+	return nil
+}
+
+func (f *pdfFile) array(v any) []any {
+	a, _ := f.resolve(v).([]any)
+	return a
+}
+
+func (f *pdfFile) int(v any) int {
+	n, _ := f.resolve(v).(float64)
+	return int(n)
+}
+
+func (f *pdfFile) name(v any) pdfName {
+	n, _ := f.resolve(v).(pdfName)
+	return n
+}
+
+// decode 按 /Filter 解码流数据；支持内容流与对象流中常见的 Flate、ASCIIHex 与 ASCII85，LZW 等少见的编码报错
+func (f *pdfFile) decode(s *pdfStream) ([]byte, error) {
+	var filters []any
+	switch v := f.resolve(s.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []any{v}
+	case []any:
+		filters = v
+	}
+	params := f.array(s.dict["DecodeParms"])
+	if d := f.dict(s.dict["DecodeParms"]); d != nil {
+		params = []any{d}
+	}
+	data := s.raw
+	for i, filter := range filters {
+		var err error
+		switch f.name(filter) {
+		case "FlateDecode", "Fl":
+			data, err = inflate(data)
+			if err == nil && i < len(params) {
+				data, err = f.unpredict(data, f.dict(params[i]))
+			}
+		case "ASCIIHexDecode", "AHx":
+			data, err = asciiHex(data)
+		case "ASCII85Decode", "A85":
+			data, err = ascii85(data)
+		default:
+			err = fmt.Errorf("unsupported filter %s", f.name(filter))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// inflate zlib 解压；流被截断或校验和不对时返回已解出的部分
+func inflate(data []byte) ([]byte, error) {
+	var r io.ReadCloser
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		// 少数生成器写出不带 zlib 头的裸 deflate 数据
+		r = flate.NewReader(bytes.NewReader(data))
+	}
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil
+}
+
+// unpredict 还原 PNG 预测器（/Predictor ≥ 10，对象流与交叉引用流常用）
+func (f *pdfFile) unpredict(data []byte, params pdfDict) ([]byte, error) {
+	if params == nil || f.int(params["Predictor"]) < 10 {
+		return data, nil
+	}
+	colors, bpc, columns := max(f.int(params["Colors"]), 1), 8, max(f.int(params["Columns"]), 1)
+	if v := f.int(params["BitsPerComponent"]); v > 0 {
+		bpc = v
+	}
+	bpp := max(colors*bpc/8, 1)
+	row := (colors*bpc*columns + 7) / 8
+	var out []byte
+	prev := make([]byte, row)
+	for len(data) >= row+1 {
+		kind, cur := data[0], append([]byte(nil), data[1:row+1]...)
+		data = data[row+1:]
+		for i := range cur {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = cur[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch kind {
+			case 1:
+				cur[i] += left
+			case 2:
+				cur[i] += up
+			case 3:
+				cur[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				cur[i] += paeth(left, up, upLeft)
+			}
+		}
+		out = append(out, cur...)
+		prev = cur
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func asciiHex(data []byte) ([]byte, error) {
+	var out []byte
+	var hi int = -1
+	for _, c := range data {
+		if c == '>' {
+			break
+		}
+		v := hexValue(c)
+		if v < 0 {
+			continue
+		}
+		if hi < 0 {
+			hi = v
+		} else {
+			out = append(out, byte(hi<<4|v))
+			hi = -1
+		}
+	}
+	if hi >= 0 {
+		out = append(out, byte(hi<<4))
+	}
+	return out, nil
+}
+
+func ascii85(data []byte) ([]byte, error) {
+	var out []byte
+	var group [5]byte
+	n := 0
+	for _, c := range data {
+		switch {
+		case c == '~':
+			goto done
+		case c == 'z' && n == 0:
+			out = append(out, 0, 0, 0, 0)
+			continue
+		case c < '!' || c > 'u':
+			continue
+		}
+		group[n] = c - '!'
+		if n++; n == 5 {
+			v := uint32(0)
+			for _, g := range group {
+				v = v*85 + uint32(g)
+			}
+			out = append(out, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+			n = 0
+		}
+	}
+done:
+	if n > 1 {
+		for i := n; i < 5; i++ {
+			group[i] = 84
+		}
+		v := uint32(0)
+		for _, g := range group {
+			v = v*85 + uint32(g)
+		}
+		out = append(out, []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}[:n-1]...)
+	}
+	return out, nil
+}
+
+// pdfPage 一页的字典与（可能从父节点继承的）资源
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// pages 按页面树的顺序列出全部页面；找不到目录时按对象号排列所有 /Type /Page 对象
+func (f *pdfFile) pages() []pdfPage {
+	var catalog pdfDict
+	nums := make([]int, 0, len(f.objs))
+	for n := range f.objs {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	for _, n := range nums {
+		if d := f.dict(f.objs[n]); d != nil && d["Type"] == pdfName("Catalog") && d["Pages"] != nil {
+			catalog = d
+		}
+	}
+	var pages []pdfPage
+	if catalog != nil {
+		f.walkPages(catalog["Pages"], nil, map[int]bool{}, &pages, 0)
+	}
+	if len(pages) == 0 {
+		for _, n := range nums {
+			if d := f.dict(f.objs[n]); d != nil && d["Type"] == pdfName("Page") {
+				pages = append(pages, pdfPage{dict: d, resources: f.dict(d["Resources"])})
+			}
+		}
+	}
+	return pages
+}
+
+// walkPages 深度优先遍历页面树；seen 记下走过的对象号，循环引用的节点只走一次
+func (f *pdfFile) walkPages(ref any, resources pdfDict, seen map[int]bool, out *[]pdfPage, depth int) {
+	if r, ok := ref.(pdfRef); ok {
+		if seen[r.num] {
+			return
+		}
+		seen[r.num] = true
+	}
+	node := f.dict(ref)
+	if node == nil || depth > 64 {
+		return
+	}
+	if r := f.dict(node["Resources"]); r != nil {
+		resources = r
+	}
+	if node["Type"] == pdfName("Pages") || node["Kids"] != nil {
+		for _, kid := range f.array(node["Kids"]) {
+			f.walkPages(kid, resources, seen, out, depth+1)
+		}
+		return
+	}
+	*out = append(*out, pdfPage{dict: node, resources: resources})
+}
+
+// contents 页面内容流解码后拼接在一起
+func (f *pdfFile) contents(page pdfPage) []byte {
+	var streams []any
+	switch c := f.resolve(page.dict["Contents"]).(type) {
+	case *pdfStream:
+		streams = []any{c}
+	case []any:
+		streams = c
+	}
+	var out []byte
+	for _, s := range streams {
+		if st, ok := f.resolve(s).(*pdfStream); ok {
+			if data, err := f.decode(st); err == nil {
+				out = append(append(out, data...), '\n')
+			}
+		}
+	}
+	return out
+}
+
+// pdfLexer PDF 语法的词法与对象解析；refs 为 false 时不识别 "N G R" 引用（内容流中没有引用）
+type pdfLexer struct {
+	data []byte
+	pos  int
+	refs bool
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelim(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// errPDFEnd 数据已读完
+var errPDFEnd = errors.New("unexpected end of data")
+
+// object 解析下一个对象（内容流中也返回运算符）
+func (l *pdfLexer) object() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, errPDFEnd
+	}
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		return l.name(), nil
+	case c == '(':
+		return l.literal(), nil
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		return l.dictOrStream()
+	case c == '<':
+		return l.hexString(), nil
+	case c == '[':
+		l.pos++
+		var arr []any
+		for {
+			l.skipSpace()
+			if l.pos >= len(l.data) {
+				return nil, errPDFEnd
+			}
+			if l.data[l.pos] == ']' {
+				l.pos++
+				return arr, nil
+			}
+			v, err := l.object()
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+	case c == ']' || c == '>' || c == ')' || c == '{' || c == '}':
+		l.pos++
+		return pdfKeyword(c), nil
+	case c == '+' || c == '-' || c == '.' || c >= '0' && c <= '9':
+		return l.number(), nil
+	}
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	switch word := string(l.data[start:l.pos]); word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	default:
+		return pdfKeyword(word), nil
+	}
+}
+
+func (l *pdfLexer) name() pdfName {
+	l.pos++
+	var b []byte
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelim(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if hi, lo := hexValue(l.data[l.pos+1]), hexValue(l.data[l.pos+2]); hi >= 0 && lo >= 0 {
+				b = append(b, byte(hi<<4|lo))
+				l.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		l.pos++
+	}
+	return pdfName(b)
+}
+
+func (l *pdfLexer) literal() []byte {
+	l.pos++
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func (l *pdfLexer) hexString() []byte {
+	l.pos++
+	end := bytes.IndexByte(l.data[l.pos:], '>')
+	if end < 0 {
+		end = len(l.data) - l.pos
+	}
+	out, _ := asciiHex(l.data[l.pos : l.pos+end])
+	l.pos += end + 1
+	return out
+}
+
+// number 数字，之后若是 "G R" 则为间接引用
+func (l *pdfLexer) number() any {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.data) && (l.data[l.pos] >= '0' && l.data[l.pos] <= '9' || l.data[l.pos] == '.') {
+		l.pos++
+	}
+	v, err := strconv.ParseFloat(string(l.data[start:l.pos]), 64)
+	if err != nil {
+		return 0.0
+	}
+	if l.refs && v >= 0 && v == float64(int(v)) {
+		save := l.pos
+		l.skipSpace()
+		gs := l.pos
+		for l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '9' {
+			l.pos++
+		}
+		if l.pos > gs {
+			gen, _ := strconv.Atoi(string(l.data[gs:l.pos]))
+			l.skipSpace()
+			if l.pos < len(l.data) && l.data[l.pos] == 'R' && (l.pos+1 == len(l.data) || isPDFSpace(l.data[l.pos+1]) || isPDFDelim(l.data[l.pos+1])) {
+				l.pos++
+				return pdfRef{int(v), gen}
+			}
+		}
+		l.pos = save
+	}
+	return v
+}
+
+// dictOrStream 字典，其后紧跟 stream 关键字时为流：优先按 /Length 取数据，长度不可用（间接引用、写错）时找 endstream
+func (l *pdfLexer) dictOrStream() (any, error) {
+	l.pos += 2
+	d := pdfDict{}
+	for {
+		l.skipSpace()
+		if l.pos+1 >= len(l.data) {
+			return nil, errPDFEnd
+		}
+		if l.data[l.pos] == '>' && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			break
+		}
+		k, err := l.object()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(pdfName)
+		if !ok {
+			continue
+		}
+		v, err := l.object()
+		if err != nil {
+			return nil, err
+		}
+		d[key] = v
+	}
+	save := l.pos
+	l.skipSpace()
+	if !bytes.HasPrefix(l.data[l.pos:], []byte("stream")) {
+		l.pos = save
+		return d, nil
+	}
+	l.pos += len("stream")
+	if l.pos < len(l.data) && l.data[l.pos] == '\r' {
+		l.pos++
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+		l.pos++
+	}
+	start := l.pos
+	if n, ok := d["Length"].(float64); ok && n >= 0 && start+int(n) <= len(l.data) {
+		rest := bytes.TrimLeft(l.data[start+int(n):min(start+int(n)+32, len(l.data))], " \r\n\t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			l.pos = start + int(n)
+			return &pdfStream{dict: d, raw: l.data[start:l.pos]}, nil
+		}
+	}
+	end := bytes.Index(l.data[start:], []byte("endstream"))
+	if end < 0 {
+		end = len(l.data) - start
+	}
+	raw := bytes.TrimRight(l.data[start:start+end], "\r\n")
+	l.pos = start + end
+	return &pdfStream{dict: d, raw: raw}, nil
+}
+
+func hexValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10
+	case c >= 'A' && c <= 'F':
+		return int(c-'A') + 10
+	}
+	return -1
+}
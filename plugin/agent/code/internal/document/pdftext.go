@@ -0,0 +1,496 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// encrypted 交叉引用表或交叉引用流中的 /Encrypt
+var encrypted = regexp.MustCompile(`/Encrypt\s*(\d+\s+\d+\s+R|<<)`)
+
+// pdfPages 取出 PDF 各页的文字
+func pdfPages(data []byte) ([]string, error) {
+	if encrypted.Match(data) {
+		return nil, errors.New("encrypted PDF is not supported")
+	}
+	f, err := parsePDF(data)
+	if err != nil {
+		return nil, err
+	}
+	pages := f.pages()
+	if len(pages) == 0 {
+		return nil, errors.New("no pages found")
+	}
+	fonts := map[int]*pdfFont{}
+	out := make([]string, len(pages))
+	for i, p := range pages {
+		var w textWriter
+		f.text(f.contents(p), p.resources, fonts, &w, 0)
+		out[i] = tidy(w.b.String())
+	}
+	return out, nil
+}
+
+// textWriter 按内容流中的定位运算推测空格与换行
+type textWriter struct {
+	b strings.Builder
+}
+
+func (w *textWriter) write(s string) {
+	w.b.WriteString(s)
+}
+
+func (w *textWriter) space() {
+	if s := w.b.String(); s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+		w.b.WriteByte(' ')
+	}
+}
+
+func (w *textWriter) newline() {
+	if s := w.b.String(); s != "" && !strings.HasSuffix(s, "\n") {
+		w.b.WriteByte('\n')
+	}
+}
+
+// maxFormDepth 表单 XObject 嵌套的最大深度
+const maxFormDepth = 8
+
+// kernSpace TJ 中大于这么多（千分之一字号）的间距视为单词之间的空格
+const kernSpace = 200
+
+// text 解释内容流中的文字运算符，把文字写入 w；表单 XObject（页眉页脚、模板中的文字）递归展开
+func (f *pdfFile) text(content []byte, resources pdfDict, fonts map[int]*pdfFont, w *textWriter, depth int) {
+	fontRes := f.dict(resources["Font"])
+	var (
+		stack []any
+		cur   *pdfFont
+		// 当前行的纵坐标与 Tm 的纵向缩放，用于判断 Tm 是否换了行
+		lineY = 0.0
+		scale = 1.0
+	)
+	l := &pdfLexer{data: content}
+	for {
+		v, err := l.object()
+		if err != nil {
+			return
+		}
+		op, ok := v.(pdfKeyword)
+		if !ok {
+			stack = append(stack, v)
+			continue
+		}
+		switch op {
+		case "Tf":
+			if len(stack) >= 2 {
+				if name, ok := stack[len(stack)-2].(pdfName); ok && fontRes != nil {
+					cur = f.font(fontRes[name], fonts)
+				}
+			}
+		case "Td", "TD":
+			if len(stack) >= 2 {
+				tx, _ := stack[len(stack)-2].(float64)
+				ty, _ := stack[len(stack)-1].(float64)
+				if ty != 0 {
+					w.newline()
+				} else if tx > 0 {
+					w.space()
+				}
+				lineY += ty * scale
+			}
+		case "Tm":
+			if len(stack) >= 6 {
+				y, _ := stack[len(stack)-1].(float64)
+				if math.Abs(y-lineY) > 1 {
+					w.newline()
+				} else {
+					w.space()
+				}
+				lineY = y
+				if d, _ := stack[len(stack)-3].(float64); d != 0 {
+					scale = d
+				}
+			}
+		case "T*":
+			w.newline()
+		case "Tj", "'", "\"":
+			if op != "Tj" {
+				w.newline()
+			}
+			if len(stack) > 0 {
+				if s, ok := stack[len(stack)-1].([]byte); ok {
+					w.write(cur.decode(s))
+				}
+			}
+		case "TJ":
+			if len(stack) > 0 {
+				arr, _ := stack[len(stack)-1].([]any)
+				for _, item := range arr {
+					switch x := item.(type) {
+					case []byte:
+						w.write(cur.decode(x))
+					case float64:
+						if x < -kernSpace {
+							w.space()
+						}
+					}
+				}
+			}
+		case "ET":
+			w.space()
+		case "Do":
+			if len(stack) > 0 && depth < maxFormDepth {
+				name, _ := stack[len(stack)-1].(pdfName)
+				if s, ok := f.resolve(f.dict(resources["XObject"])[name]).(*pdfStream); ok && f.name(s.dict["Subtype"]) == "Form" {
+					if data, err := f.decode(s); err == nil {
+						res := f.dict(s.dict["Resources"])
+						if res == nil {
+							res = resources
+						}
+						w.newline()
+						f.text(data, res, fonts, w, depth+1)
+						w.newline()
+					}
+				}
+			}
+		case "ID":
+			l.skipInlineImage()
+		}
+		stack = stack[:0]
+	}
+}
+
+// skipInlineImage 跳过内联图片（ID 与 EI 之间的二进制数据）
+func (l *pdfLexer) skipInlineImage() {
+	for l.pos < len(l.data) {
+		i := bytes.Index(l.data[l.pos:], []byte("EI"))
+		if i < 0 {
+			l.pos = len(l.data)
+			return
+		}
+		at := l.pos + i
+		l.pos = at + 2
+		if at > 0 && isPDFSpace(l.data[at-1]) && (l.pos == len(l.data) || isPDFSpace(l.data[l.pos])) {
+			return
+		}
+	}
+}
+
+// pdfFont 字体的编码：ToUnicode 映射优先，其次是单字节字体的编码表（含 /Differences）
+type pdfFont struct {
+	toUnicode *cmap
+	composite bool // Type0 字体，字符编码为多字节
+	simple    [256]string
+}
+
+// font 字体对象对应的解码方式，按对象号缓存
+func (f *pdfFile) font(v any, cache map[int]*pdfFont) *pdfFont {
+	if r, ok := v.(pdfRef); ok {
+		if ft, ok := cache[r.num]; ok {
+			return ft
+		}
+		ft := f.loadFont(f.dict(v))
+		cache[r.num] = ft
+		return ft
+	}
+	return f.loadFont(f.dict(v))
+}
+
+func (f *pdfFile) loadFont(d pdfDict) *pdfFont {
+	if d == nil {
+		return nil
+	}
+	ft := &pdfFont{composite: f.name(d["Subtype"]) == "Type0"}
+	if s, ok := f.resolve(d["ToUnicode"]).(*pdfStream); ok {
+		if data, err := f.decode(s); err == nil {
+			ft.toUnicode = parseCMap(data)
+		}
+	}
+	if !ft.composite {
+		ft.simple = f.simpleEncoding(d["Encoding"])
+	}
+	return ft
+}
+
+// simpleEncoding 单字节字体的编码表：MacRoman 或 WinAnsi（未指定时按 WinAnsi），再套用 /Differences
+func (f *pdfFile) simpleEncoding(v any) [256]string {
+	base := pdfName("WinAnsiEncoding")
+	var diffs []any
+	switch e := f.resolve(v).(type) {
+	case pdfName:
+		base = e
+	case pdfDict:
+		if b := f.name(e["BaseEncoding"]); b != "" {
+			base = b
+		}
+		diffs = f.array(e["Differences"])
+	}
+	cm := charmap.Windows1252
+	if base == "MacRomanEncoding" {
+		cm = charmap.Macintosh
+	}
+	var table [256]string
+	for i := 0x20; i < 256; i++ {
+		if r := cm.DecodeByte(byte(i)); r >= 0x20 && (r < 0x7f || r > 0x9f) && r != '\ufffd' {
+			table[i] = string(r)
+		}
+	}
+	code := 0
+	for _, d := range diffs {
+		switch x := f.resolve(d).(type) {
+		case float64:
+			code = int(x)
+		case pdfName:
+			if code >= 0 && code < 256 {
+				if s, ok := glyphText(string(x)); ok {
+					table[code] = s
+				}
+			}
+			code++
+		}
+	}
+	return table
+}
+
+// decode 字符串按字体的编码转换为文字；没有字体信息时按 Latin-1 取可打印字符
+func (ft *pdfFont) decode(s []byte) string {
+	var b strings.Builder
+	if ft == nil {
+		for _, c := range s {
+			if c >= 0x20 && c != 0x7f {
+				b.WriteRune(rune(c))
+			}
+		}
+		return b.String()
+	}
+	for i := 0; i < len(s); {
+		n := ft.codeLen(s[i:])
+		code := string(s[i : i+n])
+		i += n
+		if ft.toUnicode != nil {
+			if u, ok := ft.toUnicode.chars[code]; ok {
+				b.WriteString(u)
+				continue
+			}
+		}
+		if !ft.composite && n == 1 {
+			b.WriteString(ft.simple[code[0]])
+		}
+	}
+	return b.String()
+}
+
+// codeLen 下一个字符编码的字节数：按 CMap 的 codespace 判断，没有时 Type0 字体为 2 字节，其余为 1 字节
+func (ft *pdfFont) codeLen(s []byte) int {
+	if ft.toUnicode != nil {
+		for _, r := range ft.toUnicode.spaces {
+			if n := len(r.lo); n <= len(s) && bytes.Compare(s[:n], r.lo) >= 0 && bytes.Compare(s[:n], r.hi) <= 0 {
+				return n
+			}
+		}
+	}
+	if ft.composite && len(s) >= 2 {
+		return 2
+	}
+	return 1
+}
+
+// cmap ToUnicode CMap：codespace 与字符编码到文字的映射
+type cmap struct {
+	spaces []codeRange
+	chars  map[string]string
+}
+
+type codeRange struct{ lo, hi []byte }
+
+// maxCMapEntries bfrange 展开后的条目上限，防止畸形的 CMap 占用过多内存
+const maxCMapEntries = 1 << 18
+
+// parseCMap 解析 begincodespacerange / beginbfchar / beginbfrange 段
+func parseCMap(data []byte) *cmap {
+	m := &cmap{chars: map[string]string{}}
+	l := &pdfLexer{data: data}
+	var operands []any
+	section := ""
+	for {
+		v, err := l.object()
+		if err != nil {
+			break
+		}
+		kw, ok := v.(pdfKeyword)
+		if !ok {
+			if section != "" {
+				operands = append(operands, v)
+			}
+			continue
+		}
+		switch kw {
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			section, operands = string(kw), operands[:0]
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				lo, _ := operands[i].([]byte)
+				hi, _ := operands[i+1].([]byte)
+				if len(lo) > 0 && len(lo) == len(hi) {
+					m.spaces = append(m.spaces, codeRange{lo, hi})
+				}
+			}
+			section = ""
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, _ := operands[i].([]byte)
+				dst, _ := operands[i+1].([]byte)
+				if len(src) > 0 {
+					m.chars[string(src)] = utf16BE(dst)
+				}
+			}
+			section = ""
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				m.addRange(operands[i], operands[i+1], operands[i+2])
+			}
+			section = ""
+		}
+	}
+	return m
+}
+
+// addRange 展开一条 bfrange：目标为字符串时逐个递增最后一个字符，为数组时依次对应
+func (m *cmap) addRange(loV, hiV, dst any) {
+	lo, _ := loV.([]byte)
+	hi, _ := hiV.([]byte)
+	if len(lo) == 0 || len(lo) != len(hi) || len(lo) > 4 {
+		return
+	}
+	start, end := beInt(lo), beInt(hi)
+	if end < start || len(m.chars)+int(end-start) > maxCMapEntries {
+		return
+	}
+	for code := start; code <= end; code++ {
+		key := make([]byte, len(lo))
+		for i, c := len(lo)-1, code; i >= 0; i, c = i-1, c>>8 {
+			key[i] = byte(c)
+		}
+		switch d := dst.(type) {
+		case []byte:
+			units := utf16.Decode(beUnits(d))
+			if len(units) == 0 {
+				continue
+			}
+			units[len(units)-1] += rune(code - start)
+			m.chars[string(key)] = string(units)
+		case []any:
+			if int(code-start) >= len(d) {
+				break
+			}
+			if s, ok := d[code-start].([]byte); ok {
+				m.chars[string(key)] = utf16BE(s)
+			}
+		}
+	}
+}
+
+func beInt(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+func beUnits(b []byte) []uint16 {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return units
+}
+
+// utf16BE ToUnicode 中的目标字符串（UTF-16BE），单字节时按 Latin-1
+func utf16BE(b []byte) string {
+	if len(b) == 1 {
+		return string(rune(b[0]))
+	}
+	return string(utf16.Decode(beUnits(b)))
+}
+
+// glyphNames /Differences 中常见的字形名（字母与数字之外）
+var glyphNames = map[string]string{
+	"space": " ", "exclam": "!", "quotedbl": "\"", "numbersign": "#", "dollar": "$", "percent": "%",
+	"ampersand": "&", "quotesingle": "'", "quoteright": "’", "quoteleft": "‘", "parenleft": "(",
+	"parenright": ")", "asterisk": "*", "plus": "+", "comma": ",", "hyphen": "-", "period": ".",
+	"slash": "/", "colon": ":", "semicolon": ";", "less": "<", "equal": "=", "greater": ">",
+	"question": "?", "at": "@", "bracketleft": "[", "backslash": "\\", "bracketright": "]",
+	"asciicircum": "^", "underscore": "_", "grave": "`", "braceleft": "{", "bar": "|",
+	"braceright": "}", "asciitilde": "~", "bullet": "•", "endash": "–", "emdash": "—",
+	"quotedblleft": "“", "quotedblright": "”", "quotesinglbase": "‚", "quotedblbase": "„",
+	"ellipsis": "…", "fi": "fi", "fl": "fl", "ff": "ff", "ffi": "ffi", "ffl": "ffl", "dagger": "†",
+	"daggerdbl": "‡", "trademark": "™", "copyright": "©", "registered": "®", "degree": "°",
+	"section": "§", "paragraph": "¶", "minus": "−", "multiply": "×", "divide": "÷",
+	"nbspace": " ", "nonbreakingspace": " ", "periodcentered": "·", "dotlessi": "ı", "florin": "ƒ",
+	"Euro": "€", "sterling": "£", "yen": "¥", "cent": "¢", "germandbls": "ß", "ae": "æ", "AE": "Æ",
+	"oe": "œ", "OE": "Œ", "oslash": "ø", "Oslash": "Ø", "guillemotleft": "«", "guillemotright": "»",
+	"guilsinglleft": "‹", "guilsinglright": "›", "exclamdown": "¡", "questiondown": "¿",
+	"plusminus": "±", "mu": "µ", "zero": "0", "one": "1", "two": "2", "three": "3", "four": "4",
+	"five": "5", "six": "6", "seven": "7", "eight": "8", "nine": "9",
+}
+
+// accents 带重音字母的字形名后缀（如 eacute）对应的组合符号
+var accents = map[string]rune{
+	"acute": '\u0301', "grave": '\u0300', "circumflex": '\u0302', "dieresis": '\u0308', "tilde": '\u0303',
+	"ring": '\u030a', "cedilla": '\u0327', "caron": '\u030c', "macron": '\u0304', "breve": '\u0306',
+	"ogonek": '\u0328', "dotaccent": '\u0307', "hungarumlaut": '\u030b',
+}
+
+// glyphText 字形名对应的文字：常见名称、单个字母、uniXXXX / uXXXX、带重音的字母与 f_f 式的连字
+func glyphText(name string) (string, bool) {
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		name = name[:i]
+	}
+	if s, ok := glyphNames[name]; ok {
+		return s, true
+	}
+	if len(name) == 1 {
+		return name, true
+	}
+	if hex, ok := strings.CutPrefix(name, "uni"); ok && len(hex)%4 == 0 {
+		var units []uint16
+		for i := 0; i < len(hex); i += 4 {
+			v, err := strconv.ParseUint(hex[i:i+4], 16, 16)
+			if err != nil {
+				return "", false
+			}
+			units = append(units, uint16(v))
+		}
+		return string(utf16.Decode(units)), true
+	}
+	if hex, ok := strings.CutPrefix(name, "u"); ok && len(hex) >= 4 && len(hex) <= 6 {
+		if v, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return string(rune(v)), true
+		}
+	}
+	if strings.Contains(name, "_") {
+		var b strings.Builder
+		for _, part := range strings.Split(name, "_") {
+			s, ok := glyphText(part)
+			if !ok {
+				return "", false
+			}
+			b.WriteString(s)
+		}
+		return b.String(), true
+	}
+	if len(name) > 1 {
+		if mark, ok := accents[name[1:]]; ok {
+			return norm.NFC.String(name[:1] + string(mark)), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,192 @@
+// Package editor 编辑器集成协议：编辑器把选中的文本与文件类型交给 agent，取回回答（ask）
+// 或改写后的文本与对应的 unified diff（edit），这样 Vim / Neovim / VS Code 都能以 j 为 AI 后端，不必各自再接一遍 provider。
+//
+// 请求经守护进程的 socket 发送（JSON Lines，一个连接一个请求）：
+//
+//	{"op": "editor", "editor": {"action": "edit", "text": "...", "filetype": "go", "file": "main.go", "line": 12, "instruction": "..."}}
+//
+// 回复与对话请求相同：stream 时先有若干 {"delta": "..."}，最后一帧为
+// {"done": true, "answer": "...", "edit": {...}, "error": "..."}。守护进程未运行时，
+// agent editor 从 stdin 读取同样的请求，在本进程内完成后以同样的帧写到 stdout。
+package editor
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NeovimPlugin Neovim 的参考 Lua 模块（agent editor nvim 输出）
+//
+//go:embed nvim.lua
+var NeovimPlugin string
+
+// 请求的动作
+const (
+	ActionAsk  = "ask"  // 针对选中的文本提问，回答为 Markdown
+	ActionEdit = "edit" // 按要求改写选中的文本
+)
+
+// contextLines diff 中改动前后保留的上下文行数
+const contextLines = 3
+
+// ErrEmpty 缺少选中的文本
+var ErrEmpty = errors.New("empty selection")
+
+// Request 编辑器的请求；除 action 与 text 外均可省略
+type Request struct {
+	Action      string `json:"action"`
+	Text        string `json:"text"`
+	Filetype    string `json:"filetype,omitempty"`
+	File        string `json:"file,omitempty"`
+	Line        int    `json:"line,omitempty"` // 选中部分在文件中的首行（从 1 开始），用于 diff 的行号
+	Instruction string `json:"instruction,omitempty"`
+	Provider    string `json:"provider,omitempty"` // provider 名称，为空时使用活动的 provider
+	Session     string `json:"session,omitempty"`  // 守护进程中的会话名，与 agent ask --session 共享
+	Stream      bool   `json:"stream,omitempty"`
+	Lang        string `json:"lang,omitempty"` // 回答的语言，为空时由调用方填入界面语言
+}
+
+// Result edit 的结果：Replacement 为替换选中部分的完整文本，Patch 为相应的 unified diff，
+// Explanation 为代码块之外的说明；模型没有给出代码块时 Replacement 与 Patch 为空
+type Result struct {
+	Replacement string `json:"replacement,omitempty"`
+	Patch       string `json:"patch,omitempty"`
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// Validate 检查动作并补上默认值
+func (r *Request) Validate() error {
+	switch r.Action {
+	case "":
+		r.Action = ActionAsk
+	case ActionAsk, ActionEdit:
+	default:
+		return fmt.Errorf("unknown action %q (expected %s or %s)", r.Action, ActionAsk, ActionEdit)
+	}
+	if strings.TrimSpace(r.Text) == "" {
+		return ErrEmpty
+	}
+	if r.Line < 1 {
+		r.Line = 1
+	}
+	return nil
+}
+
+// Prompt 组装发给模型的提示词
+func Prompt(r Request) string {
+	var b strings.Builder
+	where := "the user's editor"
+	if r.File != "" {
+		where = fmt.Sprintf("%s (lines %d-%d)", r.File, r.Line, r.Line+strings.Count(strings.TrimSuffix(r.Text, "\n"), "\n"))
+	}
+	filetype := r.Filetype
+	if filetype == "" {
+		filetype = "text"
+	}
+	switch r.Action {
+	case ActionEdit:
+		instruction := strings.TrimSpace(r.Instruction)
+		if instruction == "" {
+			instruction = "Improve this code: fix bugs and make it clearer without changing its behaviour."
+		}
+		fmt.Fprintf(&b, "Rewrite the following %s selected in %s.\n\nInstruction: %s\n\n", filetype, where, instruction)
+		fmt.Fprintf(&b, "Reply with exactly one ```%s code block containing the complete replacement for the selection "+
+			"(only the selection, not the whole file; keep its indentation), followed by at most two sentences on what changed, "+
+			"in the language %q.\n\n", filetype, r.Lang)
+	default:
+		question := strings.TrimSpace(r.Instruction)
+		if question == "" {
+			question = "Explain what this code does and point out any problems."
+		}
+		fmt.Fprintf(&b, "%s\n\nThe %s below is selected in %s. Answer in Markdown in the language %q.\n\n", question, filetype, where, r.Lang)
+	}
+	b.WriteString("```" + filetype + "\n" + strings.TrimSuffix(r.Text, "\n") + "\n```\n")
+	return b.String()
+}
+
+// Parse 从 edit 的回答中取出第一个代码块作为替换文本，其余部分作为说明，并生成 diff
+func Parse(r Request, answer string) Result {
+	lines := strings.Split(answer, "\n")
+	start, end := -1, -1
+	for i, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+		if start < 0 {
+			start = i
+		} else {
+			end = i
+			break
+		}
+	}
+	if start < 0 || end < 0 {
+		return Result{Explanation: strings.TrimSpace(answer)}
+	}
+	replacement := strings.Join(lines[start+1:end], "\n")
+	if strings.HasSuffix(r.Text, "\n") {
+		replacement += "\n"
+	}
+	rest := append(append([]string{}, lines[:start]...), lines[end+1:]...)
+	return Result{
+		Replacement: replacement,
+		Patch:       Diff(r.File, r.Line, r.Text, replacement),
+		Explanation: strings.TrimSpace(strings.Join(rest, "\n")),
+	}
+}
+
+// Diff 选中部分改写前后的 unified diff：去掉首尾相同的行，前后各保留 contextLines 行上下文；
+// 行号以 line（选中部分的首行）为起点，没有改动时为空
+func Diff(file string, line int, before, after string) string {
+	old, cur := splitLines(before), splitLines(after)
+	prefix := 0
+	for prefix < len(old) && prefix < len(cur) && old[prefix] == cur[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(cur)-prefix && old[len(old)-1-suffix] == cur[len(cur)-1-suffix] {
+		suffix++
+	}
+	if prefix == len(old) && prefix == len(cur) {
+		return ""
+	}
+	from := max(prefix-contextLines, 0)
+	oldTo := min(len(old)-suffix+contextLines, len(old))
+	curTo := min(len(cur)-suffix+contextLines, len(cur))
+	if file == "" {
+		file = "selection"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", file, file)
+	fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(line+from, oldTo-from), hunkRange(line+from, curTo-from))
+	for _, l := range old[from:prefix] {
+		b.WriteString(" " + l + "\n")
+	}
+	for _, l := range old[prefix : len(old)-suffix] {
+		b.WriteString("-" + l + "\n")
+	}
+	for _, l := range cur[prefix : len(cur)-suffix] {
+		b.WriteString("+" + l + "\n")
+	}
+	for _, l := range old[len(old)-suffix : oldTo] {
+		b.WriteString(" " + l + "\n")
+	}
+	return b.String()
+}
+
+// hunkRange @@ 头中的 "起始行,行数"；行数为 0 时起始行为其前一行（与 diff -u 一致）
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
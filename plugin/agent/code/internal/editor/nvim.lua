@@ -0,0 +1,228 @@
+-- j.lua：以 j 的 agent 为 AI 后端的 Neovim 参考模块（agent editor nvim > ~/.config/nvim/lua/j.lua）
+--
+--   require("j").setup({ provider = nil, session = nil, keymaps = true })
+--
+--   :'<,'>JAsk [问题]    针对选中的行提问，回答流式显示在浮动窗口中（q 关闭）
+--   :'<,'>JEdit [要求]   按要求改写选中的行（u 撤销），说明显示在消息区
+--
+-- 守护进程（agent daemon）在运行时直接连接它的 socket，否则经 agent editor 在子进程中完成。
+local M = {}
+
+local uv = vim.uv or vim.loop
+
+M.opts = { agent = nil, provider = nil, session = nil, keymaps = true }
+
+local function data_dir()
+  local dir = os.getenv("J_DATA_PATH")
+  if dir and dir ~= "" then
+    return dir
+  end
+  return (os.getenv("HOME") or "") .. "/.jdata"
+end
+
+local function agent_bin()
+  if M.opts.agent then
+    return M.opts.agent
+  end
+  local bin = data_dir() .. "/bin/agent"
+  if vim.fn.executable(bin) == 1 then
+    return bin
+  end
+  return "agent"
+end
+
+-- line_reader 把收到的字节拆成 JSON Lines，每一帧交给 on_frame
+local function line_reader(on_frame)
+  local buf = ""
+  return function(chunk)
+    buf = buf .. chunk
+    while true do
+      local i = buf:find("\n", 1, true)
+      if not i then
+        return
+      end
+      local line = buf:sub(1, i - 1)
+      buf = buf:sub(i + 1)
+      if line ~= "" then
+        local ok, frame = pcall(vim.json.decode, line)
+        if ok and type(frame) == "table" then
+          on_frame(frame)
+        end
+      end
+    end
+  end
+end
+
+-- request 发送一个编辑器请求：on_delta(文本) 在收到增量时调用，on_done(最终帧) 在结束时调用一次
+function M.request(req, on_delta, on_done)
+  local payload = vim.json.encode({ op = "editor", editor = req }) .. "\n"
+  local finished = false
+  local function on_frame(frame)
+    if finished then
+      return
+    end
+    if frame.done then
+      finished = true
+      on_done(frame)
+    elseif frame.delta and on_delta then
+      on_delta(frame.delta)
+    end
+  end
+
+  local function via_agent()
+    local feed = line_reader(on_frame)
+    local job = vim.fn.jobstart({ agent_bin(), "editor" }, {
+      on_stdout = function(_, data)
+        feed(table.concat(data, "\n"))
+      end,
+      on_exit = function(_, code)
+        on_frame({ done = true, error = "agent editor exited with status " .. code })
+      end,
+    })
+    if job <= 0 then
+      on_frame({ done = true, error = "cannot run " .. agent_bin() })
+      return
+    end
+    vim.fn.chansend(job, payload)
+    vim.fn.chanclose(job, "stdin")
+  end
+
+  local pipe = uv.new_pipe(false)
+  pipe:connect(data_dir() .. "/agent/data/daemon.sock", function(err)
+    if err then
+      pipe:close()
+      vim.schedule(via_agent)
+      return
+    end
+    local feed = line_reader(on_frame)
+    pipe:write(payload)
+    pipe:read_start(function(rerr, chunk)
+      if rerr or not chunk then
+        pipe:close()
+        vim.schedule(function()
+          on_frame({ done = true, error = rerr or "the daemon closed the connection" })
+        end)
+        return
+      end
+      vim.schedule(function()
+        feed(chunk)
+      end)
+    end)
+  end)
+end
+
+-- selection 命令范围内的行及请求的公共字段
+local function selection(cmd)
+  local buf = vim.api.nvim_get_current_buf()
+  local lines = vim.api.nvim_buf_get_lines(buf, cmd.line1 - 1, cmd.line2, false)
+  local instruction = nil
+  if cmd.args ~= "" then
+    instruction = cmd.args
+  end
+  return buf, {
+    text = table.concat(lines, "\n") .. "\n",
+    filetype = vim.bo[buf].filetype,
+    file = vim.fn.expand("%:."),
+    line = cmd.line1,
+    instruction = instruction,
+    provider = M.opts.provider,
+    session = M.opts.session,
+  }
+end
+
+-- answer_window 居中的浮动窗口，返回设置全文与追加文本的函数
+local function answer_window()
+  local buf = vim.api.nvim_create_buf(false, true)
+  vim.bo[buf].filetype = "markdown"
+  local width = math.floor(vim.o.columns * 0.7)
+  local height = math.floor(vim.o.lines * 0.6)
+  local win = vim.api.nvim_open_win(buf, true, {
+    relative = "editor",
+    width = width,
+    height = height,
+    row = math.floor((vim.o.lines - height) / 2),
+    col = math.floor((vim.o.columns - width) / 2),
+    style = "minimal",
+    border = "rounded",
+  })
+  vim.wo[win].wrap = true
+  vim.keymap.set("n", "q", function()
+    if vim.api.nvim_win_is_valid(win) then
+      vim.api.nvim_win_close(win, true)
+    end
+  end, { buffer = buf, nowait = true })
+  local text = ""
+  local function set(s)
+    text = s
+    if vim.api.nvim_buf_is_valid(buf) then
+      vim.api.nvim_buf_set_lines(buf, 0, -1, false, vim.split(text, "\n", { plain = true }))
+    end
+  end
+  return set, function(delta)
+    set(text .. delta)
+  end
+end
+
+-- ask :'<,'>JAsk [问题]
+function M.ask(cmd)
+  local _, req = selection(cmd)
+  req.action = "ask"
+  req.stream = true
+  local set, append = answer_window()
+  set("…")
+  local started = false
+  M.request(req, function(delta)
+    if not started then
+      started = true
+      set("")
+    end
+    append(delta)
+  end, function(frame)
+    if frame.error and frame.error ~= "" then
+      set("error: " .. frame.error)
+      return
+    end
+    set(frame.answer or "")
+  end)
+end
+
+-- edit :'<,'>JEdit [要求]：缓冲区在等待期间被修改时不再替换
+function M.edit(cmd)
+  local buf, req = selection(cmd)
+  req.action = "edit"
+  local first, last = cmd.line1, cmd.line2
+  local tick = vim.api.nvim_buf_get_changedtick(buf)
+  vim.notify("j: …")
+  M.request(req, nil, function(frame)
+    if frame.error and frame.error ~= "" then
+      vim.notify("j: " .. frame.error, vim.log.levels.ERROR)
+      return
+    end
+    local edit = frame.edit or {}
+    if not edit.replacement or edit.replacement == "" then
+      vim.notify("j: " .. (edit.explanation or frame.answer or ""), vim.log.levels.WARN)
+      return
+    end
+    if not vim.api.nvim_buf_is_valid(buf) or vim.api.nvim_buf_get_changedtick(buf) ~= tick then
+      vim.notify("j: the buffer changed while waiting, the rewrite is not applied", vim.log.levels.WARN)
+      return
+    end
+    local replacement = edit.replacement:gsub("\n$", "")
+    vim.api.nvim_buf_set_lines(buf, first - 1, last, false, vim.split(replacement, "\n", { plain = true }))
+    if edit.explanation and edit.explanation ~= "" then
+      vim.notify("j: " .. edit.explanation)
+    end
+  end)
+end
+
+function M.setup(opts)
+  M.opts = vim.tbl_extend("force", M.opts, opts or {})
+  vim.api.nvim_create_user_command("JAsk", M.ask, { range = true, nargs = "*" })
+  vim.api.nvim_create_user_command("JEdit", M.edit, { range = true, nargs = "*" })
+  if M.opts.keymaps then
+    vim.keymap.set("x", "<leader>ja", ":JAsk<CR>", { desc = "j: ask about the selection" })
+    vim.keymap.set("x", "<leader>je", ":JEdit ", { desc = "j: rewrite the selection" })
+  end
+end
+
+return M
@@ -0,0 +1,89 @@
+// Package filelock 用 flock(2) 建议锁串行化多个进程对同一数据文件的读写
+// （如脚本里的 agent ask 与交互中的 j chat 同时写问答历史）。
+// 锁加在旁边的 <文件>.lock 上而不是数据文件本身：整体重写的文件以改名替换，加在旧文件上的锁会失效。
+// 进程退出时内核自动释放锁，不会留下需要清理的死锁；Rust 主程序（src/util/file_lock.rs）使用同一约定。
+package filelock
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Timeout 等待锁的最长时间，超过时返回 ErrTimeout
+const Timeout = 5 * time.Second
+
+// ErrTimeout 等待锁超时（另一个进程长时间持有锁）
+var ErrTimeout = errors.New("timed out waiting for file lock")
+
+// Lock 以独占方式锁住 path（写入时使用），返回解锁函数
+func Lock(path string) (func(), error) {
+	return lock(path, syscall.LOCK_EX, Timeout)
+}
+
+// TryLock 不等待地以独占方式锁住 path；锁被其他进程持有时 ok 为 false
+func TryLock(path string) (unlock func(), ok bool, err error) {
+	unlock, err = lock(path, syscall.LOCK_EX, 0)
+	if errors.Is(err, ErrTimeout) {
+		return nil, false, nil
+	}
+	return unlock, err == nil, err
+}
+
+// RLock 以共享方式锁住 path（读取时使用），多个读者可同时持有，与 Lock 互斥
+func RLock(path string) (func(), error) {
+	return lock(path, syscall.LOCK_SH, Timeout)
+}
+
+func lock(path string, how int, wait time.Duration) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(wait)
+	for {
+		err := syscall.Flock(int(f.Fd()), how|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) && !errors.Is(err, syscall.EINTR) {
+			f.Close()
+			return nil, err
+		}
+		if !time.Now().Before(deadline) {
+			f.Close()
+			return nil, ErrTimeout
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// AppendLine 在独占锁内向 path 追加一行（line 不含换行符）。
+// 文件末尾缺少换行（上次写入中途被杀）时先补上，半行只损坏它自己，不会连带吞掉这一行
+func AppendLine(path string, line []byte) error {
+	unlock, err := Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	buf := make([]byte, 0, len(line)+2)
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			buf = append(buf, '\n')
+		}
+	}
+	buf = append(append(buf, line...), '\n')
+	_, err = f.Write(buf)
+	return err
+}
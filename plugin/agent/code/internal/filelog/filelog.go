@@ -0,0 +1,230 @@
+// Package filelog 写入结构化的文件日志（JSON Lines）：命令分发、provider 调用（脱敏后）与错误各记一行，
+// 偶发的问题可以拿日志里的真实记录来报告。默认关闭：J_LOG_FILE 或 config.yaml 的 setting.log_file 开启后才记录。
+// 日志位于 ~/.jdata/logs/j.jsonl，与 Rust 主程序（src/util/filelog.rs）共用同一文件与格式，
+// 超过大小上限或跨天时改名为 j-<时间>.jsonl 轮换，过期与超出个数的旧文件随之删除。
+package filelog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+const (
+	// EnableEnv 开关环境变量（优先级高于配置文件）
+	EnableEnv = "J_LOG_FILE"
+	// SettingEnabled config.yaml 中 setting 段的开关项
+	SettingEnabled = "log_file"
+	// SettingMaxSize 单个日志文件的大小上限（MB），默认 DefaultMaxSize
+	SettingMaxSize = "log_file_max_size"
+	// SettingMaxAge 轮换出的旧文件保留的天数，默认 DefaultMaxAge
+	SettingMaxAge = "log_file_max_age"
+	// SettingMaxBackups 最多保留的旧文件个数，默认 DefaultMaxBackups
+	SettingMaxBackups = "log_file_max_backups"
+
+	DefaultMaxSize    = 10
+	DefaultMaxAge     = 14
+	DefaultMaxBackups = 5
+
+	// Dir 日志目录（位于当前 profile 的数据目录下）
+	Dir = "logs"
+	// FileName 当前日志文件名，轮换出的旧文件为 j-<时间>.jsonl
+	FileName = "j.jsonl"
+)
+
+// 事件类型
+const (
+	EventDispatch  = "dispatch"  // 一条命令执行完毕
+	EventProvider  = "provider"  // 一次 provider 调用（含拦截器中的重试）
+	EventIntercept = "intercept" // 调用过程中的限流等待、重试、换 Key 与改用备选 provider
+	EventError     = "error"     // 报告给用户的错误
+)
+
+// Entry 日志中的一行；各事件只填相关的字段
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Level      string    `json:"level"`
+	Source     string    `json:"source"`
+	PID        int       `json:"pid"`
+	Event      string    `json:"event"`
+	Command    string    `json:"command,omitempty"`
+	Args       int       `json:"args,omitempty"`
+	Provider   string    `json:"provider,omitempty"`
+	Model      string    `json:"model,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Detail     string    `json:"detail,omitempty"`
+	Attempt    int       `json:"attempt,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	ExitCode   *int      `json:"exit_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// source 本进程在日志中的来源名
+const source = "agent"
+
+var (
+	enabledOnce sync.Once
+	enabled     bool
+)
+
+// Enabled 是否开启了文件日志：J_LOG_FILE > config.yaml 的 setting.log_file（只解析一次），默认关闭
+func Enabled() bool {
+	enabledOnce.Do(func() {
+		v := os.Getenv(EnableEnv)
+		if v == "" {
+			v = config.Setting(SettingEnabled)
+		}
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "1", "true", "on", "yes":
+			enabled = true
+		}
+	})
+	return enabled
+}
+
+// Path 当前日志文件路径
+func Path() string {
+	return filepath.Join(config.DataDir(), Dir, FileName)
+}
+
+// Write 补上时间、级别、来源与进程号后追加一行；未开启时什么也不做，写入失败时静默忽略（日志不应影响命令本身）
+func Write(e Entry) {
+	if !Enabled() {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.Level == "" {
+		e.Level = "info"
+		if e.Error != "" {
+			e.Level = "error"
+		}
+	}
+	e.Source, e.PID = source, os.Getpid()
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	_ = appendLine(Path(), line, e.Time)
+}
+
+// Dispatch 记录一条执行完毕的命令：只记命令名与参数个数，不记参数内容；errText 为（已脱敏的）错误信息
+func Dispatch(command string, args int, d time.Duration, code int, errText string) {
+	Write(Entry{Event: EventDispatch, Command: command, Args: args, DurationMs: d.Milliseconds(), ExitCode: &code, Error: errText})
+}
+
+// appendLine 在独占锁内按需轮换，再追加一行
+func appendLine(path string, line []byte, now time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	unlock, err := filelock.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if due(path, now) {
+		rotate(path, now)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// due 当前文件是否需要轮换：达到大小上限，或第一行记录于更早的一天
+func due(path string, now time.Time) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() == 0 {
+		return false
+	}
+	if info.Size() >= int64(setting(SettingMaxSize, DefaultMaxSize))<<20 {
+		return true
+	}
+	first, ok := firstTime(path)
+	if !ok {
+		return false
+	}
+	y1, m1, d1 := first.Local().Date()
+	y2, m2, d2 := now.Local().Date()
+	return y1 != y2 || m1 != m2 || d1 != d2
+}
+
+// firstTime 文件第一行的 time 字段
+func firstTime(path string) (time.Time, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadSlice('\n')
+	if err != nil && len(line) == 0 {
+		return time.Time{}, false
+	}
+	var head struct {
+		Time time.Time `json:"time"`
+	}
+	if json.Unmarshal(line, &head) != nil || head.Time.IsZero() {
+		return time.Time{}, false
+	}
+	return head.Time, true
+}
+
+// rotate 把当前文件改名为 j-<时间>.jsonl，再删除过期与超出个数的旧文件
+func rotate(path string, now time.Time) {
+	dir := filepath.Dir(path)
+	name := "j-" + now.Format("20060102-150405")
+	target := filepath.Join(dir, name+".jsonl")
+	if _, err := os.Stat(target); err == nil {
+		target = filepath.Join(dir, fmt.Sprintf("%s-%d.jsonl", name, os.Getpid()))
+	}
+	if os.Rename(path, target) != nil {
+		return
+	}
+	prune(dir, now)
+}
+
+// prune 删除修改时间超过保留天数的旧文件，并只保留最新的若干个
+func prune(dir string, now time.Time) {
+	backups := Backups(dir)
+	maxAge := time.Duration(setting(SettingMaxAge, DefaultMaxAge)) * 24 * time.Hour
+	keep := setting(SettingMaxBackups, DefaultMaxBackups)
+	for i, path := range backups {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if len(backups)-i > keep || now.Sub(info.ModTime()) > maxAge {
+			os.Remove(path)
+		}
+	}
+}
+
+// Backups 目录中轮换出的旧日志文件，从旧到新排列
+func Backups(dir string) []string {
+	matches, _ := filepath.Glob(filepath.Join(dir, "j-*.jsonl"))
+	sort.Strings(matches)
+	return matches
+}
+
+// setting 读取正整数配置项，未设置或无效时取 def
+func setting(key string, def int) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(config.Setting(key))); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
@@ -0,0 +1,176 @@
+// Package filter 把插件串成过滤器流水线：插件在握手应答中声明 filter 特性及其提供的过滤器
+// （"filters": [{"name": ..., "description": ...}]），调用方以 --filter <名称> 启动插件，
+// 在 stdin 写入一个 JSON 对象 {"text": ..., "meta": {...}}，插件在 stdout 输出同样格式的结果后以 0 退出；
+// 失败时输出 {"error": ...}（或以非 0 退出，stderr 照常显示给用户）。
+// 多个过滤器依次执行，前一个的结果交给下一个：结果中的 meta 覆盖同名的键（值为空串时删除该键），
+// 未出现的键原样传下去，例如 extract-code 写入 language，format 据此选择格式化命令。
+// 声明为 postprocess 的过滤器输入输出都是 Markdown，可以在渲染前自动处理每个回答（由预设启用）
+package filter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/trace"
+)
+
+const (
+	// Flag 以过滤器方式启动插件的参数，其后为过滤器名
+	Flag = "--filter"
+	// Feature 握手中声明提供过滤器的特性
+	Feature = "filter"
+	// KindPostprocess 后处理器：输出仍是 Markdown 的过滤器
+	KindPostprocess = "postprocess"
+)
+
+// Payload 过滤器之间传递的数据
+type Payload struct {
+	Text string            `json:"text"`
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// response 插件的输出
+type response struct {
+	Payload
+	Error string `json:"error,omitempty"`
+}
+
+// Stage 流水线中的一个过滤器
+type Stage struct {
+	Name   string // 过滤器名
+	Plugin string // 提供它的插件
+	Path   string // 插件可执行文件
+	Kind   string // 过滤器类型，后处理器为 KindPostprocess
+}
+
+// String 形如 md_render:format
+func (s Stage) String() string { return s.Plugin + ":" + s.Name }
+
+// Available 各插件提供的全部过滤器，形如 插件:过滤器，按名称排序
+func Available(plugins []daemon.Plugin) []string {
+	var list []string
+	for _, p := range plugins {
+		if !slices.Contains(p.Features, Feature) {
+			continue
+		}
+		for _, f := range p.Filters {
+			list = append(list, p.Name+":"+f.Name)
+		}
+	}
+	slices.Sort(list)
+	return list
+}
+
+// Resolve 按名称找出各过滤器所在的插件；名称可以写成 插件:过滤器，多个插件提供同名过滤器时必须这样写
+func Resolve(specs []string, plugins []daemon.Plugin) ([]Stage, error) {
+	stages := make([]Stage, 0, len(specs))
+	for _, spec := range specs {
+		pluginName, name, qualified := strings.Cut(spec, ":")
+		if !qualified {
+			pluginName, name = "", spec
+		}
+		var found []Stage
+		for _, p := range plugins {
+			if !slices.Contains(p.Features, Feature) || (qualified && p.Name != pluginName) {
+				continue
+			}
+			if i := slices.IndexFunc(p.Filters, func(f daemon.PluginFilter) bool { return f.Name == name }); i >= 0 {
+				found = append(found, Stage{Name: name, Plugin: p.Name, Path: p.Path, Kind: p.Filters[i].Kind})
+			}
+		}
+		switch len(found) {
+		case 0:
+			available := strings.Join(Available(plugins), ", ")
+			if available == "" {
+				available = "-"
+			}
+			return nil, errors.New(i18n.T(i18n.MsgFilterUnknown, spec, available))
+		case 1:
+			stages = append(stages, found[0])
+		default:
+			names := make([]string, len(found))
+			for i, s := range found {
+				names[i] = s.String()
+			}
+			return nil, errors.New(i18n.T(i18n.MsgFilterAmbiguous, spec, strings.Join(names, ", ")))
+		}
+	}
+	return stages, nil
+}
+
+// Postprocessors 按名称找出后处理器，名称规则同 Resolve；找到的过滤器不是后处理器时报错
+func Postprocessors(specs []string, plugins []daemon.Plugin) ([]Stage, error) {
+	stages, err := Resolve(specs, plugins)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range stages {
+		if s.Kind != KindPostprocess {
+			return nil, errors.New(i18n.T(i18n.MsgFilterNotPostprocess, s.String()))
+		}
+	}
+	return stages, nil
+}
+
+// Run 依次执行各过滤器，返回最后一个的结果
+func Run(ctx context.Context, stages []Stage, in Payload) (Payload, error) {
+	for _, s := range stages {
+		out, err := s.run(ctx, in)
+		if err != nil {
+			return in, errors.New(i18n.T(i18n.MsgFilterFailed, s.String(), err))
+		}
+		in = out
+	}
+	return in, nil
+}
+
+func (s Stage) run(ctx context.Context, in Payload) (Payload, error) {
+	ctx, span := trace.Start(ctx, "filter "+s.Name, trace.KindClient, trace.String("j.plugin", s.Plugin))
+	defer span.End()
+	data, err := json.Marshal(in)
+	if err != nil {
+		return in, err
+	}
+	cmd := exec.CommandContext(ctx, s.Path, Flag, s.Name)
+	trace.Inject(ctx, cmd)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	var res response
+	if jerr := json.Unmarshal(out, &res); jerr != nil {
+		if err == nil {
+			err = fmt.Errorf("invalid output: %w", jerr)
+		}
+		span.RecordError(err)
+		return in, err
+	}
+	if res.Error != "" {
+		err = errors.New(res.Error)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return in, err
+	}
+	meta := maps.Clone(in.Meta)
+	if meta == nil {
+		meta = map[string]string{}
+	}
+	for k, v := range res.Meta {
+		if v == "" {
+			delete(meta, k)
+		} else {
+			meta[k] = v
+		}
+	}
+	return Payload{Text: res.Text, Meta: meta}, nil
+}
@@ -0,0 +1,156 @@
+// Package flow 解析声明式的提示词流水线（YAML）：每一步是一次模型调用，
+// prompt 中可用 {{input}}、{{vars.x}} 与 {{<步骤 id>}} 引用输入、变量和之前步骤的输出。
+package flow
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"wcp_agent/internal/i18n"
+)
+
+// Pipeline 流水线定义
+//
+//	name: polish
+//	provider: DeepSeek-V3          # 所有步骤的默认 provider（名称或模型名），缺省为当前 provider
+//	vars: {tone: friendly}
+//	steps:
+//	  - id: summarize
+//	    prompt: "总结以下内容:\n{{input}}"
+//	  - id: critique
+//	    provider: GPT-4o
+//	    prompt: "指出这份总结的不足:\n{{summarize}}"
+//	  - id: rewrite
+//	    temperature: 0.7
+//	    prompt: "根据意见 {{critique}} 用 {{vars.tone}} 的语气重写:\n{{summarize}}"
+//	output: rewrite                 # 输出哪一步的结果，缺省为最后一步
+type Pipeline struct {
+	Name     string            `yaml:"name"`
+	Provider string            `yaml:"provider"`
+	System   string            `yaml:"system"`
+	Input    string            `yaml:"input"` // 未从 stdin / --input 提供输入时的默认值
+	Vars     map[string]string `yaml:"vars"`
+	Steps    []Step            `yaml:"steps"`
+	Output   string            `yaml:"output"`
+}
+
+// Step 单个步骤；未填写的 provider / system 沿用流水线级配置
+type Step struct {
+	ID          string   `yaml:"id"`
+	Provider    string   `yaml:"provider"`
+	System      string   `yaml:"system"`
+	Prompt      string   `yaml:"prompt"`
+	Temperature *float64 `yaml:"temperature"`
+	TopP        *float64 `yaml:"top_p"`
+	MaxTokens   int      `yaml:"max_tokens"`
+}
+
+// refPattern 模板引用：{{ name }}、{{ vars.name }}、{{ steps.name }}
+var refPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][\w.-]*)\s*\}\}`)
+
+// idPattern 步骤 id 只允许字母、数字、下划线与连字符，避免与 vars. / steps. 前缀冲突
+var idPattern = regexp.MustCompile(`^[A-Za-z_][\w-]*$`)
+
+// Load 读取并校验流水线文件
+func Load(path string) (*Pipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Pipeline
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// validate 检查步骤 id 唯一、每一步只引用输入、变量和它之前的步骤
+func (p *Pipeline) validate() error {
+	if len(p.Steps) == 0 {
+		return fmt.Errorf("%s", i18n.T(i18n.MsgFlowNoSteps))
+	}
+	seen := map[string]bool{}
+	for i := range p.Steps {
+		s := &p.Steps[i]
+		if s.ID == "" {
+			s.ID = fmt.Sprintf("step%d", i+1)
+		}
+		if !idPattern.MatchString(s.ID) || s.ID == "input" || s.ID == "vars" || s.ID == "steps" {
+			return fmt.Errorf("%s", i18n.T(i18n.MsgFlowBadID, s.ID))
+		}
+		if seen[s.ID] {
+			return fmt.Errorf("%s", i18n.T(i18n.MsgFlowDupID, s.ID))
+		}
+		if strings.TrimSpace(s.Prompt) == "" {
+			return fmt.Errorf("%s", i18n.T(i18n.MsgFlowNoPrompt, s.ID))
+		}
+		for _, text := range []string{s.Prompt, s.System} {
+			for _, m := range refPattern.FindAllStringSubmatch(text, -1) {
+				if err := p.checkRef(m[1], seen, s.ID); err != nil {
+					return err
+				}
+			}
+		}
+		seen[s.ID] = true
+	}
+	if p.Output != "" && !seen[p.Output] {
+		return fmt.Errorf("%s", i18n.T(i18n.MsgFlowBadOutput, p.Output))
+	}
+	return nil
+}
+
+func (p *Pipeline) checkRef(ref string, earlier map[string]bool, step string) error {
+	switch {
+	case ref == "input":
+		return nil
+	case strings.HasPrefix(ref, "vars."):
+		// 变量可由 --var 在运行时提供，这里不做存在性检查
+		return nil
+	}
+	id := strings.TrimPrefix(ref, "steps.")
+	if earlier[id] {
+		return nil
+	}
+	if id == step {
+		return fmt.Errorf("%s", i18n.T(i18n.MsgFlowSelfRef, step))
+	}
+	return fmt.Errorf("%s", i18n.T(i18n.MsgFlowBadRef, step, ref))
+}
+
+// OutputStep 最终输出的步骤 id
+func (p *Pipeline) OutputStep() string {
+	if p.Output != "" {
+		return p.Output
+	}
+	return p.Steps[len(p.Steps)-1].ID
+}
+
+// Render 用输入、变量与已完成步骤的输出替换模板引用；未定义的变量报错
+func Render(text, input string, vars, outputs map[string]string) (string, error) {
+	var missing string
+	out := refPattern.ReplaceAllStringFunc(text, func(m string) string {
+		ref := refPattern.FindStringSubmatch(m)[1]
+		switch {
+		case ref == "input":
+			return input
+		case strings.HasPrefix(ref, "vars."):
+			if v, ok := vars[strings.TrimPrefix(ref, "vars.")]; ok {
+				return v
+			}
+			missing = ref
+			return m
+		}
+		return outputs[strings.TrimPrefix(ref, "steps.")]
+	})
+	if missing != "" {
+		return "", fmt.Errorf("%s", i18n.T(i18n.MsgFlowNoVar, missing))
+	}
+	return out, nil
+}
@@ -0,0 +1,547 @@
+// Package guard 在执行生成的 shell 命令之前做静态的风险分析：递归删除根目录或家目录、
+// curl | sh、chmod 777、覆写磁盘设备、写到工作目录之外等。分析只看命令文本，不执行任何东西；
+// 规则可在 ~/.jdata/agent/data/guard.json 中扩展、关闭或放行（allow 逐条匹配命令行中的简单命令，应以 ^ 与 $ 锚定整条）：
+//
+//	{
+//	  "rules":   [{"name": "kubectl-delete", "pattern": "\\bkubectl\\s+delete\\b", "level": "high", "reason": "deletes cluster resources"}],
+//	  "disable": ["privileged"],
+//	  "allow":   ["^sudo apt(-get)? install [a-z0-9.+-]+$"]
+//	}
+package guard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// 风险等级
+const (
+	LevelWarn = "warn" // 提示后照常确认
+	LevelHigh = "high" // 需要输入确认词才执行
+)
+
+// 内置规则名，可在 guard.json 的 disable 中关闭
+const (
+	RuleRmRecursive   = "rm-recursive"
+	RulePipeToShell   = "pipe-to-shell"
+	RuleWorldWritable = "world-writable"
+	RuleDisk          = "disk-overwrite"
+	RuleForkBomb      = "fork-bomb"
+	RulePrivileged    = "privileged"
+	RuleOutsideCwd    = "outside-cwd"
+)
+
+// Builtin 内置规则及其说明（i18n key），供 agent guard rules 列出
+var Builtin = []struct {
+	Name, Level, Summary string
+}{
+	{RuleRmRecursive, LevelHigh, i18n.MsgGuardRuleRmRecursive},
+	{RulePipeToShell, LevelHigh, i18n.MsgGuardRulePipeToShell},
+	{RuleWorldWritable, LevelWarn + "/" + LevelHigh, i18n.MsgGuardRuleWorldWritable},
+	{RuleDisk, LevelHigh, i18n.MsgGuardRuleDisk},
+	{RuleForkBomb, LevelHigh, i18n.MsgGuardRuleForkBomb},
+	{RulePrivileged, LevelWarn, i18n.MsgGuardRulePrivileged},
+	{RuleOutsideCwd, LevelWarn + "/" + LevelHigh, i18n.MsgGuardRuleOutsideCwd},
+}
+
+// Rule 用户规则：pattern 为匹配整条命令的正则表达式
+type Rule struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+	Level   string `json:"level,omitempty"` // 默认 high
+	Reason  string `json:"reason,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// Finding 一处风险
+type Finding struct {
+	Rule   string `json:"rule"`
+	Level  string `json:"level"`
+	Reason string `json:"reason"`
+}
+
+// Ruleset 内置规则加上 guard.json 中的用户规则
+type Ruleset struct {
+	Rules   []Rule
+	Disable map[string]bool
+	Allow   []*regexp.Regexp
+}
+
+// file guard.json 的格式
+type file struct {
+	Rules   []Rule   `json:"rules"`
+	Disable []string `json:"disable"`
+	Allow   []string `json:"allow"`
+}
+
+// Path 用户规则文件路径: ~/.jdata/agent/data/guard.json
+func Path() string {
+	return filepath.Join(config.AgentDataDir(), "guard.json")
+}
+
+// Load 读取用户规则；文件不存在时只有内置规则，格式或正则有误时返回错误（调用方应拒绝执行，而不是悄悄放过）
+func Load() (Ruleset, error) {
+	rs := Ruleset{Disable: map[string]bool{}}
+	data, err := os.ReadFile(Path())
+	if errors.Is(err, os.ErrNotExist) {
+		return rs, nil
+	}
+	if err != nil {
+		return rs, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return rs, fmt.Errorf("%s: %w", Path(), err)
+	}
+	for _, r := range f.Rules {
+		if r.Name == "" || r.Pattern == "" {
+			return rs, fmt.Errorf("%s: every rule needs a name and a pattern", Path())
+		}
+		switch r.Level {
+		case "":
+			r.Level = LevelHigh
+		case LevelWarn, LevelHigh:
+		default:
+			return rs, fmt.Errorf("%s: rule %s: level must be %s or %s", Path(), r.Name, LevelWarn, LevelHigh)
+		}
+		if r.re, err = regexp.Compile(r.Pattern); err != nil {
+			return rs, fmt.Errorf("%s: rule %s: %w", Path(), r.Name, err)
+		}
+		rs.Rules = append(rs.Rules, r)
+	}
+	for _, name := range f.Disable {
+		rs.Disable[name] = true
+	}
+	for _, pattern := range f.Allow {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return rs, fmt.Errorf("%s: allow %q: %w", Path(), pattern, err)
+		}
+		rs.Allow = append(rs.Allow, re)
+	}
+	return rs, nil
+}
+
+// Highest 最高的风险等级，没有风险时为空
+func Highest(findings []Finding) string {
+	level := ""
+	for _, f := range findings {
+		if f.Level == LevelHigh {
+			return LevelHigh
+		}
+		level = LevelWarn
+	}
+	return level
+}
+
+// forkBomb :(){ :|:& };: 及其换了函数名的写法
+var forkBomb = regexp.MustCompile(`(\S+)\s*\(\)\s*\{\s*(\S+)\s*\|\s*(\S+)\s*&\s*\}\s*;\s*(\S+)`)
+
+// Analyze 分析以 cwd 为工作目录执行的命令。allow 逐条匹配拆分出的简单命令（如 sudo apt install foo; rm -rf /
+// 中的两条），只跳过匹配的那几条；用户规则与 fork 炸弹检查作用于去掉这几条之后的命令行
+func (rs Ruleset) Analyze(command, cwd string) []Finding {
+	commands := parse(command)
+	allowed := make([]bool, len(commands))
+	rest := []rune(command)
+	all := len(commands) > 0
+	for i, c := range commands {
+		if allowed[i] = rs.allowed(c.text); allowed[i] {
+			for j := c.start; j < c.end; j++ {
+				rest[j] = ' '
+			}
+		} else {
+			all = false
+		}
+	}
+	if all {
+		return nil
+	}
+	command = string(rest)
+
+	a := analyzer{cwd: filepath.Clean(cwd), home: home(), seen: map[string]bool{}}
+	if m := forkBomb.FindStringSubmatch(command); m != nil && m[1] == m[2] && m[2] == m[3] && m[3] == m[4] {
+		a.add(RuleForkBomb, LevelHigh, i18n.T(i18n.MsgGuardForkBomb))
+	}
+	for i, c := range commands {
+		if allowed[i] {
+			continue
+		}
+		a.check(c)
+		if c.piped {
+			a.checkPipe(c, commands[i+1:])
+		}
+	}
+	var findings []Finding
+	for _, f := range a.findings {
+		if !rs.Disable[f.Rule] {
+			findings = append(findings, f)
+		}
+	}
+	for _, r := range rs.Rules {
+		if r.re.MatchString(command) {
+			reason := r.Reason
+			if reason == "" {
+				reason = i18n.T(i18n.MsgGuardUserRule, r.Name)
+			}
+			findings = append(findings, Finding{Rule: r.Name, Level: r.Level, Reason: reason})
+		}
+	}
+	return findings
+}
+
+// allowed 简单命令的原文是否匹配 allow 中的某条
+func (rs Ruleset) allowed(text string) bool {
+	for _, re := range rs.Allow {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+type analyzer struct {
+	cwd, home string
+	findings  []Finding
+	seen      map[string]bool
+	depth     int // 当前分析的嵌套层数（sh -c 的脚本、命令替换）
+}
+
+// maxDepth 嵌套脚本最多分析的层数
+const maxDepth = 4
+
+// scan 分析嵌套在命令中的脚本：sh -c 的参数、$( ) 与反引号中的命令、watch 的命令串
+func (a *analyzer) scan(script string) {
+	if a.depth >= maxDepth {
+		return
+	}
+	a.depth++
+	defer func() { a.depth-- }()
+	commands := parse(script)
+	for i, c := range commands {
+		a.check(c)
+		if c.piped {
+			a.checkPipe(c, commands[i+1:])
+		}
+	}
+}
+
+func (a *analyzer) add(rule, level, reason string) {
+	if key := rule + "\x00" + reason; !a.seen[key] {
+		a.seen[key] = true
+		a.findings = append(a.findings, Finding{Rule: rule, Level: level, Reason: reason})
+	}
+}
+
+// shells 读取并执行 stdin 或参数中脚本的解释器
+var shells = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "dash": true, "ksh": true, "fish": true,
+	"python": true, "python3": true, "perl": true, "ruby": true, "node": true,
+}
+
+// shellScripts 以 -c 执行参数中 shell 脚本的解释器
+var shellScripts = map[string]bool{"sh": true, "bash": true, "zsh": true, "dash": true, "ksh": true}
+
+// downloaders 下载远程内容的命令
+var downloaders = map[string]bool{"curl": true, "wget": true, "fetch": true}
+
+// check 单条命令的检查
+func (a *analyzer) check(c simpleCommand) {
+	inv := c.program()
+	name, args := inv.name, inv.args
+	if inv.elevated {
+		a.add(RulePrivileged, LevelWarn, i18n.T(i18n.MsgGuardPrivileged))
+	}
+	for _, target := range c.redirects {
+		a.checkWrite(target)
+	}
+	for _, w := range c.words {
+		for _, sub := range substitutions(w) {
+			a.scan(sub)
+		}
+	}
+	if inv.script != "" {
+		a.scan(inv.script)
+		return
+	}
+	options, operands := splitOptions(args)
+	switch {
+	case name == "rm":
+		recursive := hasShortFlag(options, "rR", "--recursive")
+		if recursive && inv.xargs {
+			a.add(RuleRmRecursive, LevelWarn, i18n.T(i18n.MsgGuardRmXargs))
+		}
+		for _, op := range operands {
+			if recursive {
+				a.checkRecursiveDelete(op)
+			} else {
+				a.checkWrite(op)
+			}
+		}
+	case name == "rmdir", name == "touch", name == "mkdir", name == "truncate", name == "tee", name == "unlink":
+		for _, op := range operands {
+			a.checkWrite(op)
+		}
+	case name == "cp", name == "mv", name == "install", name == "ln", name == "rsync":
+		if len(operands) > 1 {
+			a.checkWrite(operands[len(operands)-1])
+		}
+		if name == "mv" {
+			for _, op := range operands[:max(len(operands)-1, 0)] {
+				a.checkWrite(op)
+			}
+		}
+	case name == "chmod", name == "chown", name == "chgrp":
+		if len(operands) < 2 {
+			break
+		}
+		recursive := hasShortFlag(options, "R", "--recursive")
+		if name == "chmod" && worldWritable(operands[0]) {
+			level := LevelWarn
+			if recursive {
+				level = LevelHigh
+			}
+			a.add(RuleWorldWritable, level, i18n.T(i18n.MsgGuardWorldWritable, strings.Join(operands[1:], " ")))
+		}
+		for _, op := range operands[1:] {
+			if recursive {
+				if p, ok := a.resolve(op); ok && !within(p, a.cwd) && a.critical(p) {
+					a.add(RuleOutsideCwd, LevelHigh, i18n.T(i18n.MsgGuardRecursiveChange, name, op))
+					continue
+				}
+			}
+			a.checkWrite(op)
+		}
+	case strings.HasPrefix(name, "mkfs"), name == "wipefs", name == "fdisk", name == "sfdisk", name == "parted":
+		a.add(RuleDisk, LevelHigh, i18n.T(i18n.MsgGuardFormat, name))
+	case name == "dd":
+		for _, arg := range args {
+			if of, ok := strings.CutPrefix(arg, "of="); ok {
+				a.checkWrite(of)
+			}
+		}
+	case name == "shred":
+		for _, op := range operands {
+			a.checkWrite(op)
+		}
+	case name == "find":
+		a.checkFind(args)
+	case shells[name] || name == "eval" || name == "source" || name == ".":
+		if name == "eval" {
+			a.scan(strings.Join(args, " "))
+		}
+		if shellScripts[name] {
+			for i, arg := range args[:max(len(args)-1, 0)] {
+				if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && strings.Contains(arg, "c") {
+					a.scan(args[i+1])
+					break
+				}
+			}
+		}
+		// bash <(curl ...)、sh -c "$(curl ...)"
+		for _, arg := range args {
+			if downloaders[baseName(arg)] || strings.HasPrefix(arg, "$(curl") || strings.HasPrefix(arg, "$(wget") ||
+				strings.HasPrefix(arg, "`curl") || strings.HasPrefix(arg, "`wget") {
+				a.add(RulePipeToShell, LevelHigh, i18n.T(i18n.MsgGuardPipeToShell, name))
+				break
+			}
+		}
+	}
+}
+
+// checkFind find 带 -delete 或 -exec rm 时按递归删除检查起点目录（工作目录之中的起点不算风险）
+func (a *analyzer) checkFind(args []string) {
+	var starts []string
+	deletes := false
+	for i, arg := range args {
+		switch {
+		case arg == "-delete":
+			deletes = true
+		case (arg == "-exec" || arg == "-execdir" || arg == "-ok" || arg == "-okdir") && i+1 < len(args):
+			deletes = deletes || baseName(args[i+1]) == "rm"
+		case len(starts) == i && !strings.HasPrefix(arg, "-") && arg != "(" && arg != "!":
+			starts = append(starts, arg)
+		}
+	}
+	if !deletes {
+		return
+	}
+	for _, start := range starts {
+		if p, ok := a.resolve(start); ok && within(p, a.cwd) {
+			continue
+		}
+		a.checkRecursiveDelete(start)
+	}
+}
+
+// checkPipe 下载的内容经管道直接交给解释器执行（curl ... | sh，中间可以有 tee 之类）
+func (a *analyzer) checkPipe(c simpleCommand, rest []simpleCommand) {
+	if !downloaders[c.program().name] {
+		return
+	}
+	for _, next := range rest {
+		name := next.program().name
+		if shells[name] {
+			a.add(RulePipeToShell, LevelHigh, i18n.T(i18n.MsgGuardPipeToShell, name))
+			return
+		}
+		if !next.piped {
+			return
+		}
+	}
+}
+
+// checkRecursiveDelete rm -r 的目标：根目录、系统目录、家目录、工作目录本身或其上级为高风险，工作目录之外的其他位置也是
+func (a *analyzer) checkRecursiveDelete(op string) {
+	dir := strings.TrimSuffix(strings.TrimSuffix(op, "*"), "/")
+	if dir == "" && strings.HasPrefix(op, "/") {
+		dir = "/"
+	}
+	if dir == "" || dir == "." {
+		// rm -rf * 或 rm -rf .：删除整个工作目录的内容
+		a.add(RuleRmRecursive, LevelHigh, i18n.T(i18n.MsgGuardRmCwd, op))
+		return
+	}
+	p, ok := a.resolve(dir)
+	if !ok {
+		if strings.ContainsAny(op, "$`") {
+			a.add(RuleRmRecursive, LevelWarn, i18n.T(i18n.MsgGuardRmExpansion, op))
+		}
+		return
+	}
+	switch {
+	case within(a.cwd, p) || p == a.home || a.critical(p):
+		a.add(RuleRmRecursive, LevelHigh, i18n.T(i18n.MsgGuardRmRecursive, op))
+	case !within(p, a.cwd) && !a.scratch(p):
+		a.add(RuleRmRecursive, LevelHigh, i18n.T(i18n.MsgGuardRmOutside, op))
+	}
+}
+
+// checkWrite 写入或删除的目标在工作目录之外：系统目录与磁盘设备为高风险，其他位置提示
+func (a *analyzer) checkWrite(op string) {
+	p, ok := a.resolve(op)
+	if !ok || within(p, a.cwd) || a.scratch(p) {
+		return
+	}
+	switch {
+	case disk(p):
+		a.add(RuleDisk, LevelHigh, i18n.T(i18n.MsgGuardDiskWrite, op))
+	case a.critical(p):
+		a.add(RuleOutsideCwd, LevelHigh, i18n.T(i18n.MsgGuardSystemWrite, op))
+	default:
+		a.add(RuleOutsideCwd, LevelWarn, i18n.T(i18n.MsgGuardOutsideCwd, op))
+	}
+}
+
+// resolve 展开 ~ 与 $HOME 并转为绝对路径；含有其他展开或命令替换时无法判断
+func (a *analyzer) resolve(op string) (string, bool) {
+	switch {
+	case op == "~" || op == "$HOME" || op == "${HOME}":
+		op = a.home
+	case strings.HasPrefix(op, "~/"):
+		op = filepath.Join(a.home, op[2:])
+	case strings.HasPrefix(op, "$HOME/"):
+		op = filepath.Join(a.home, op[len("$HOME/"):])
+	case strings.HasPrefix(op, "${HOME}/"):
+		op = filepath.Join(a.home, op[len("${HOME}/"):])
+	}
+	if op == "" || strings.ContainsAny(op, "$`") || strings.HasPrefix(op, "~") {
+		return "", false
+	}
+	if !filepath.IsAbs(op) {
+		op = filepath.Join(a.cwd, op)
+	}
+	return filepath.Clean(op), true
+}
+
+// systemDirs 操作系统自身的目录，写入或递归修改它们需要输入确认
+var systemDirs = []string{
+	"/bin", "/boot", "/dev", "/etc", "/lib", "/lib32", "/lib64", "/opt", "/proc", "/root",
+	"/sbin", "/sys", "/usr", "/var", "/System", "/Library", "/Applications", "/private",
+}
+
+// critical 根目录、系统目录及其中的内容，或家目录的上级（家目录本身与其中的内容不算，即使它在 /root 之下）
+func (a *analyzer) critical(p string) bool {
+	if a.home != "" && within(a.home, p) {
+		return p != a.home
+	}
+	if a.home != "" && within(p, a.home) {
+		return false
+	}
+	if p == "/" {
+		return true
+	}
+	for _, dir := range systemDirs {
+		if within(p, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// scratch 临时目录与 /dev/null 等伪设备，写入它们不算风险
+func (a *analyzer) scratch(p string) bool {
+	switch p {
+	case "/dev/null", "/dev/stdout", "/dev/stderr", "/dev/stdin", "/dev/tty", "/dev/zero":
+		return true
+	}
+	if strings.HasPrefix(p, "/dev/fd/") {
+		return true
+	}
+	for _, dir := range []string{os.TempDir(), "/tmp", "/var/tmp", "/private/tmp"} {
+		if dir != "" && within(p, filepath.Clean(dir)) && p != filepath.Clean(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// disk 块设备文件
+func disk(p string) bool {
+	for _, prefix := range []string{"/dev/sd", "/dev/hd", "/dev/vd", "/dev/xvd", "/dev/nvme", "/dev/mmcblk", "/dev/disk", "/dev/rdisk", "/dev/mapper/"} {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// worldWritable chmod 的模式是否让所有人可写（777、666、o+w、a+rwx 等）
+func worldWritable(mode string) bool {
+	if len(mode) >= 3 && isDigits(mode) {
+		other := mode[len(mode)-1]
+		return other == '2' || other == '3' || other == '6' || other == '7'
+	}
+	for _, clause := range strings.Split(mode, ",") {
+		who, perm, found := strings.Cut(clause, "+")
+		if !found {
+			who, perm, found = strings.Cut(clause, "=")
+		}
+		if found && (who == "" || strings.ContainsAny(who, "ao")) && strings.Contains(perm, "w") {
+			return true
+		}
+	}
+	return false
+}
+
+// within p 是否为 dir 本身或其中的路径
+func within(p, dir string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+func home() string {
+	h, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Clean(h)
+}
@@ -0,0 +1,88 @@
+package guard
+
+import "testing"
+
+// TestAnalyze 复合命令（if / for / while、!、timeout、xargs、sh -c、命令替换、find -delete）中的危险操作
+// 与原有规则命中的命令都能识别，普通命令不报风险
+func TestAnalyze(t *testing.T) {
+	t.Setenv("HOME", "/home/u")
+	cases := []struct {
+		command string
+		rule    string // 应包含的规则，空为没有任何发现
+		level   string // 最高风险等级
+	}{
+		// 复合命令与前缀
+		{"if true; then rm -rf /; fi", RuleRmRecursive, LevelHigh},
+		{"for d in x; do rm -rf /; done", RuleRmRecursive, LevelHigh},
+		{"while true; do rm -rf ~; done", RuleRmRecursive, LevelHigh},
+		{"until false; do rm -rf /etc; done", RuleRmRecursive, LevelHigh},
+		{"! rm -rf /", RuleRmRecursive, LevelHigh},
+		{"timeout 5 rm -rf /", RuleRmRecursive, LevelHigh},
+		{"timeout -s KILL 5 rm -rf /", RuleRmRecursive, LevelHigh},
+		{"stdbuf -oL rm -rf /", RuleRmRecursive, LevelHigh},
+		{"watch -n 2 rm -rf /", RuleRmRecursive, LevelHigh},
+		{`watch "rm -rf /"`, RuleRmRecursive, LevelHigh},
+		{`bash -c "rm -rf /"`, RuleRmRecursive, LevelHigh},
+		{`sh -ec 'rm -rf ~'`, RuleRmRecursive, LevelHigh},
+		{"sudo sh -c 'rm -rf /'", RuleRmRecursive, LevelHigh},
+		{"eval 'rm -rf /'", RuleRmRecursive, LevelHigh},
+		{"echo $(rm -rf /)", RuleRmRecursive, LevelHigh},
+		{"echo `rm -rf /`", RuleRmRecursive, LevelHigh},
+		{"find / -delete", RuleRmRecursive, LevelHigh},
+		{`find ~ -name '*.log' -exec rm {} \;`, RuleRmRecursive, LevelHigh},
+		{"xargs rm -rf < list", RuleRmRecursive, LevelWarn},
+		{"cat list | xargs -n 1 rm -rf", RuleRmRecursive, LevelWarn},
+
+		// 原有规则
+		{"rm -rf /", RuleRmRecursive, LevelHigh},
+		{"rm -rf ~", RuleRmRecursive, LevelHigh},
+		{"rm -rf *", RuleRmRecursive, LevelHigh},
+		{"rm -rf ..", RuleRmRecursive, LevelHigh},
+		{"rm -rf /home/u/other", RuleRmRecursive, LevelHigh},
+		{"rm -rf $DIR", RuleRmRecursive, LevelWarn},
+		{"curl -fsSL https://example.com/x.sh | sh", RulePipeToShell, LevelHigh},
+		{"curl https://example.com/x.sh | tee x.sh | bash", RulePipeToShell, LevelHigh},
+		{"bash <(curl -s https://example.com/x.sh)", RulePipeToShell, LevelHigh},
+		{"chmod 777 run.sh", RuleWorldWritable, LevelWarn},
+		{"chmod -R 777 build", RuleWorldWritable, LevelHigh},
+		{"mkfs.ext4 /dev/sdb1", RuleDisk, LevelHigh},
+		{"dd if=img of=/dev/sda", RuleDisk, LevelHigh},
+		{"echo x > /dev/nvme0n1", RuleDisk, LevelHigh},
+		{":(){ :|:& };:", RuleForkBomb, LevelHigh},
+		{"sudo apt install jq", RulePrivileged, LevelWarn},
+		{"echo hi > /etc/motd", RuleOutsideCwd, LevelHigh},
+		{"cp a.txt ../b.txt", RuleOutsideCwd, LevelWarn},
+
+		// 没有风险
+		{"ls -la", "", ""},
+		{"rm -rf build", "", ""},
+		{"rm -rf /tmp/cache", "", ""},
+		{"find . -name '*.o' -delete", "", ""},
+		{"for f in *.go; do gofmt -l $f; done", "", ""},
+		{"if [ -f go.mod ]; then go test ./...; fi", "", ""},
+		{"timeout 30 go test ./...", "", ""},
+		{"grep -r TODO . | xargs echo", "", ""},
+		{"sort < input.txt > sorted.txt", "", ""},
+		{`bash -c "go build ./..."`, "", ""},
+		{"echo $(date)", "", ""},
+		{"go build 2>&1 > /dev/null", "", ""},
+	}
+	rs := Ruleset{Disable: map[string]bool{}}
+	for _, c := range cases {
+		findings := rs.Analyze(c.command, "/home/u/proj")
+		if got := Highest(findings); got != c.level {
+			t.Errorf("%s: level %q, want %q (%+v)", c.command, got, c.level, findings)
+			continue
+		}
+		if c.rule == "" {
+			continue
+		}
+		found := false
+		for _, f := range findings {
+			found = found || f.Rule == c.rule
+		}
+		if !found {
+			t.Errorf("%s: no %s finding in %+v", c.command, c.rule, findings)
+		}
+	}
+}
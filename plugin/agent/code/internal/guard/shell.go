@@ -0,0 +1,320 @@
+package guard
+
+import "strings"
+
+// simpleCommand 命令行中的一条简单命令：words 为去掉引号后的各个词（含命令名），
+// redirects 为输出重定向的目标，piped 表示它的输出经管道交给下一条命令；
+// text 为它在命令行中的原文（去掉首尾空白），start、end 为原文所在的 rune 区间
+type simpleCommand struct {
+	words      []string
+	redirects  []string
+	piped      bool
+	text       string
+	start, end int
+}
+
+// parse 把命令行粗略拆成简单命令：认得引号、反斜杠转义、$( ) 与反引号中的命令替换（整体作为一个词），
+// 以 ; & && || | 换行与括号分隔，并取出 > >> &> 2> >| 等输出重定向的目标；不追求完整的 shell 语法，只供风险分析使用
+func parse(line string) []simpleCommand {
+	var (
+		commands []simpleCommand
+		cur      simpleCommand
+		word     strings.Builder
+		inWord   bool
+		redirect bool // 下一个词是重定向目标
+		input    bool // 下一个词是输入重定向的来源或 here-doc 的结束标记，丢弃
+		start    int  // 当前命令原文的起点
+		at       int  // 当前字符的位置，分隔符之前为当前命令原文的终点
+	)
+	runes := []rune(line)
+	endWord := func() {
+		if !inWord {
+			return
+		}
+		w := word.String()
+		word.Reset()
+		inWord = false
+		if input {
+			input = false
+			return
+		}
+		if redirect {
+			redirect = false
+			if !strings.HasPrefix(w, "&") { // 2>&1 之类复制描述符
+				cur.redirects = append(cur.redirects, w)
+			}
+			return
+		}
+		cur.words = append(cur.words, w)
+	}
+	endCommand := func(piped bool, next int) {
+		endWord()
+		redirect, input = false, false
+		if len(cur.words) > 0 || len(cur.redirects) > 0 {
+			cur.piped = piped
+			cur.start, cur.end = start, at
+			cur.text = strings.TrimSpace(string(runes[start:at]))
+			commands = append(commands, cur)
+		}
+		cur = simpleCommand{}
+		start = min(next, len(runes))
+	}
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		at = i
+		switch {
+		case c == '\\' && i+1 < len(runes):
+			i++
+			if runes[i] != '\n' {
+				word.WriteRune(runes[i])
+				inWord = true
+			}
+		case c == '\'':
+			inWord = true
+			for i++; i < len(runes) && runes[i] != '\''; i++ {
+				word.WriteRune(runes[i])
+			}
+		case c == '"':
+			inWord = true
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]) {
+					i++
+				}
+				word.WriteRune(runes[i])
+			}
+		case c == '$' && i+1 < len(runes) && runes[i+1] == '(':
+			// 命令替换原样保留在词中，括号配对到结束
+			depth := 0
+			for ; i < len(runes); i++ {
+				word.WriteRune(runes[i])
+				if runes[i] == '(' {
+					depth++
+				} else if runes[i] == ')' {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			inWord = true
+		case c == '`':
+			word.WriteRune(c)
+			for i++; i < len(runes) && runes[i] != '`'; i++ {
+				word.WriteRune(runes[i])
+			}
+			word.WriteRune('`')
+			inWord = true
+		case c == '#' && !inWord:
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			endCommand(false, i+1)
+		case c == ' ' || c == '\t':
+			endWord()
+		case c == '\n' || c == ';' || c == '(' || c == ')' || c == '{' && !inWord || c == '}' && !inWord:
+			endCommand(false, i+1)
+		case c == '|':
+			if i+1 < len(runes) && runes[i+1] == '|' {
+				i++
+				endCommand(false, i+1)
+			} else {
+				if i+1 < len(runes) && runes[i+1] == '&' { // |& 同时传递 stderr
+					i++
+				}
+				endCommand(true, i+1)
+			}
+		case c == '&':
+			if i+1 < len(runes) && runes[i+1] == '>' { // &> 与 &>>
+				endWord()
+				i++
+				if i+1 < len(runes) && runes[i+1] == '>' {
+					i++
+				}
+				redirect = true
+				continue
+			}
+			if i+1 < len(runes) && runes[i+1] == '&' {
+				i++
+			}
+			endCommand(false, i+1)
+		case c == '>':
+			// 数字开头的词（如 2>）是描述符编号而不是参数
+			if inWord && isDigits(word.String()) {
+				word.Reset()
+				inWord = false
+			} else {
+				endWord()
+			}
+			if i+1 < len(runes) && (runes[i+1] == '>' || runes[i+1] == '|') {
+				i++
+			}
+			redirect = true
+		case c == '<':
+			endWord()
+			// 输入重定向与 here-doc 只读不写，来源丢弃；<( ) 进程替换中的命令照常拆分
+			for i+1 < len(runes) && runes[i+1] == '<' {
+				i++
+			}
+			if i+1 < len(runes) && runes[i+1] == '(' {
+				i++
+			} else {
+				input = true
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	at = len(runes)
+	endCommand(false, len(runes))
+	return commands
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// wrappers 只是改变运行方式、真正的命令在其后的前缀命令，值为它们带参数的短选项（sudo -u root、nice -n 10）
+var wrappers = map[string]string{
+	"sudo": "ugCDpr", "doas": "uC", "env": "uCS", "nohup": "", "nice": "n",
+	"time": "fo", "command": "", "exec": "a", "builtin": "",
+	"timeout": "sk", "xargs": "nILPdsEa", "watch": "nd", "stdbuf": "ioe",
+}
+
+// reserved 命令位置上的 shell 保留字：if true; then rm -rf /; fi 中真正执行的是 then 之后的 rm
+var reserved = map[string]bool{
+	"if": true, "then": true, "elif": true, "else": true, "do": true,
+	"while": true, "until": true, "!": true,
+}
+
+// invocation 简单命令真正运行的程序
+type invocation struct {
+	name     string
+	args     []string
+	elevated bool   // 经 sudo 或 doas 以 root 运行
+	xargs    bool   // 经 xargs 运行，操作数还包括 xargs 从输入读取的内容
+	script   string // 整条命令在一个词里（watch "rm -rf /"），按一段脚本分析
+}
+
+// program 去掉变量赋值、if / then / do / ! 等保留字与 sudo、env、timeout、xargs 等前缀后的命令名及其参数；
+// for 与 select 的循环头不是命令，返回空的命令名
+func (c simpleCommand) program() invocation {
+	var inv invocation
+	words := c.words
+	for len(words) > 0 {
+		w := words[0]
+		switch {
+		case w == "for" || w == "select":
+			return inv
+		case reserved[w]:
+			words = words[1:]
+		case strings.Contains(w, "=") && !strings.HasPrefix(w, "=") && !strings.HasPrefix(w, "-"):
+			words = words[1:]
+		default:
+			withArg, ok := wrappers[w]
+			if !ok && strings.ContainsAny(w, " \t\n") {
+				inv.script = strings.Join(words, " ")
+				return inv
+			}
+			if !ok {
+				inv.name, inv.args = baseName(w), words[1:]
+				return inv
+			}
+			switch w {
+			case "sudo", "doas":
+				inv.elevated = true
+			case "xargs":
+				inv.xargs = true
+			}
+			words = words[1:]
+			for len(words) > 0 && strings.HasPrefix(words[0], "-") {
+				opt := words[0]
+				words = words[1:]
+				if len(opt) == 2 && strings.ContainsRune(withArg, rune(opt[1])) && len(words) > 0 {
+					words = words[1:]
+				}
+			}
+			if w == "timeout" && len(words) > 0 { // timeout 5 rm ...：时长之后才是命令
+				words = words[1:]
+			}
+		}
+	}
+	return inv
+}
+
+// substitutions 词中 $( ) 与反引号命令替换里的命令
+func substitutions(word string) []string {
+	var found []string
+	for i := 0; i < len(word); i++ {
+		switch {
+		case strings.HasPrefix(word[i:], "$("):
+			depth := 0
+			for j := i + 1; j < len(word); j++ {
+				if word[j] == '(' {
+					depth++
+				} else if word[j] == ')' {
+					if depth--; depth == 0 {
+						found = append(found, word[i+2:j])
+						i = j
+						break
+					}
+				}
+			}
+		case word[i] == '`':
+			if j := strings.IndexByte(word[i+1:], '`'); j >= 0 {
+				found = append(found, word[i+1:i+1+j])
+				i += j + 1
+			}
+		}
+	}
+	return found
+}
+
+// baseName /bin/rm 与 rm 同样看待
+func baseName(name string) string {
+	if i := strings.LastIndexByte(name, '/'); i >= 0 && i < len(name)-1 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// splitOptions 把参数分成选项与操作数（"--" 之后都是操作数）
+func splitOptions(args []string) (options, operands []string) {
+	for i, a := range args {
+		if a == "--" {
+			return options, append(operands, args[i+1:]...)
+		}
+		if strings.HasPrefix(a, "-") && a != "-" {
+			options = append(options, a)
+		} else {
+			operands = append(operands, a)
+		}
+	}
+	return options, operands
+}
+
+// hasShortFlag 选项中是否含有某个短选项（-rf 中的 r）或给出的长选项
+func hasShortFlag(options []string, short string, long ...string) bool {
+	for _, o := range options {
+		if strings.HasPrefix(o, "--") {
+			for _, l := range long {
+				if o == l {
+					return true
+				}
+			}
+			continue
+		}
+		if strings.ContainsAny(o[1:], short) {
+			return true
+		}
+	}
+	return false
+}
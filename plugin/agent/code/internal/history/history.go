@@ -0,0 +1,166 @@
+// Package history 记录 agent ask 的每一轮问答（JSON Lines，只追加），
+// 被中断或出错的回答同样保留，并以 status 字段标记。
+package history
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+// 问答状态
+const (
+	StatusOK          = "ok"          // 正常完成
+	StatusCancelled   = "cancelled"   // 用户中断（Ctrl-C），answer 为已收到的部分
+	StatusError       = "error"       // 请求失败，answer 为失败前已收到的部分
+	StatusTruncated   = "truncated"   // 回答因 max_tokens 被截断且未续写完整
+	StatusInterrupted = "interrupted" // 进程在回答完成前退出（崩溃、被杀、终端关闭），answer 为已落盘的部分
+)
+
+// Exchange 一轮问答
+type Exchange struct {
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Provider string    `json:"provider"`
+	Model    string    `json:"model"`
+	Prompt   string    `json:"prompt"`
+	// Attachments 随问题发送的附件路径（图片等）
+	Attachments []string `json:"attachments,omitempty"`
+	Answer      string   `json:"answer"`
+	Status      string   `json:"status"`
+	Error       string   `json:"error,omitempty"`
+	DurationMs  int64    `json:"duration_ms"`
+	// ResumedFrom 由 agent ask --resume 续写时，被续写的那一轮的 ID
+	ResumedFrom string `json:"resumed_from,omitempty"`
+	// RegeneratedFrom 由 agent history regen 重新提问时，原来那一轮的 ID
+	RegeneratedFrom string `json:"regenerated_from,omitempty"`
+	// Parent 同一对话中的上一轮（agent ask --continue、agent history fork 接着的那一轮），为空表示对话的第一轮；
+	// 各轮由此连成树，从同一轮接出的几轮即对话的分支
+	Parent string `json:"parent,omitempty"`
+	// Title 对话的标题，只记在对话的第一轮上：由模型概括（见 titles 配置），或在追加时按问题生成
+	Title string `json:"title,omitempty"`
+}
+
+// Path 历史文件路径: ~/.jdata/agent/data/ask_history.jsonl
+func Path() string {
+	return filepath.Join(config.AgentDataDir(), "ask_history.jsonl")
+}
+
+// NewID 生成 8 位十六进制的问答 ID
+func NewID() string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Append 追加一轮问答，ID / 时间为空时自动补全，对话第一轮没有标题时按问题生成
+func Append(e Exchange) error {
+	if e.ID == "" {
+		e.ID = NewID()
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.Parent == "" && e.Title == "" {
+		e.Title = Title(e.Prompt)
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(Path()), 0o755); err != nil {
+		return err
+	}
+	return filelock.AppendLine(Path(), line)
+}
+
+// Load 读取全部问答（按时间先后），损坏的行会被跳过；读取时持有共享锁，不会读到另一个进程写了一半的行
+func Load() ([]Exchange, error) {
+	if unlock, err := filelock.RLock(Path()); err == nil {
+		defer unlock()
+	}
+	f, err := os.Open(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var list []Exchange
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		var e Exchange
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		list = append(list, e)
+	}
+	return list, scanner.Err()
+}
+
+// Find 按 ID（或唯一前缀）查找问答
+func Find(id string) (Exchange, bool, error) {
+	list, err := Load()
+	if err != nil {
+		return Exchange{}, false, err
+	}
+	e, ok := Lookup(list, id)
+	return e, ok, nil
+}
+
+// Lookup 在 list 中按 ID（或至少 4 个字符的唯一前缀）查找问答
+func Lookup(list []Exchange, id string) (Exchange, bool) {
+	var found []Exchange
+	for _, e := range list {
+		if e.ID == id {
+			return e, true
+		}
+		if len(id) >= 4 && len(e.ID) > len(id) && e.ID[:len(id)] == id {
+			found = append(found, e)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
+	}
+	return Exchange{}, false
+}
+
+// Thread 从对话的第一轮到 id 这一轮（含）的各轮问答，按先后排列；id 不存在时 ok 为 false。
+// 上溯途中遇到已不在历史中的一轮时到此为止
+func Thread(id string) (thread []Exchange, ok bool, err error) {
+	list, err := Load()
+	if err != nil {
+		return nil, false, err
+	}
+	e, ok := Lookup(list, id)
+	if !ok {
+		return nil, false, nil
+	}
+	byID := make(map[string]Exchange, len(list))
+	for _, x := range list {
+		byID[x.ID] = x
+	}
+	seen := map[string]bool{}
+	for {
+		thread = append(thread, e)
+		seen[e.ID] = true
+		parent, found := byID[e.Parent]
+		if e.Parent == "" || !found || seen[parent.ID] {
+			break
+		}
+		e = parent
+	}
+	slices.Reverse(thread)
+	return thread, true, nil
+}
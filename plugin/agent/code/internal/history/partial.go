@@ -0,0 +1,150 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+// partialExt 进度文件的扩展名
+const partialExt = ".part"
+
+// PartialDir 进度文件目录: ~/.jdata/agent/data/partial/
+//
+// 回答流式输出期间，已收到的内容随时追加到 partial/<id>.part（首行是问答的 JSON，之后是原样的回答文本），
+// 正常结束时删除；进程崩溃或被杀时文件留下，下次运行由 Recover 补记为 interrupted 状态的问答
+func PartialDir() string {
+	return filepath.Join(config.AgentDataDir(), "partial")
+}
+
+// Checkpoint 一轮进行中的问答的进度文件；写入失败时静默停止记录，不影响回答本身
+type Checkpoint struct {
+	path   string
+	f      *os.File
+	unlock func()
+}
+
+// StartCheckpoint 为 e 创建进度文件（ID 为空时补全），进程持有它的锁直到 Done
+func StartCheckpoint(e *Exchange) *Checkpoint {
+	if e.ID == "" {
+		e.ID = NewID()
+	}
+	c := &Checkpoint{path: filepath.Join(PartialDir(), e.ID+partialExt)}
+	header := *e
+	if header.Time.IsZero() {
+		header.Time = time.Now()
+	}
+	line, err := json.Marshal(header)
+	if err != nil || os.MkdirAll(PartialDir(), 0o700) != nil {
+		return c
+	}
+	unlock, ok, _ := filelock.TryLock(c.path)
+	if !ok {
+		return c
+	}
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		unlock()
+		return c
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		unlock()
+		return c
+	}
+	c.f, c.unlock = f, unlock
+	return c
+}
+
+// Write 追加一段回答
+func (c *Checkpoint) Write(delta string) {
+	if c.f == nil || delta == "" {
+		return
+	}
+	if _, err := c.f.WriteString(delta); err != nil {
+		c.Done()
+	}
+}
+
+// Done 问答已记入历史（或无需保留），删除进度文件
+func (c *Checkpoint) Done() {
+	if c.f == nil {
+		return
+	}
+	c.f.Close()
+	c.f = nil
+	os.Remove(c.path)
+	os.Remove(c.path + ".lock")
+	c.unlock()
+}
+
+// Recover 把上次运行留下的进度文件（写入它的进程已退出）补记为 interrupted 状态的问答，
+// 返回补记的问答（按时间先后）；仍在回答中的进程持有锁，它的进度文件不受影响
+func Recover() ([]Exchange, error) {
+	entries, err := os.ReadDir(PartialDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var recovered []Exchange
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), partialExt) {
+			continue
+		}
+		path := filepath.Join(PartialDir(), entry.Name())
+		e, ok, err := recoverPartial(path)
+		if err != nil {
+			return recovered, err
+		}
+		if ok {
+			recovered = append(recovered, e)
+		}
+	}
+	sort.Slice(recovered, func(i, j int) bool { return recovered[i].Time.Before(recovered[j].Time) })
+	return recovered, nil
+}
+
+func recoverPartial(path string) (Exchange, bool, error) {
+	unlock, ok, err := filelock.TryLock(path)
+	if err != nil || !ok {
+		return Exchange{}, false, err
+	}
+	defer unlock()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// 刚刚正常结束，或已被另一个进程补记
+		return Exchange{}, false, nil
+	}
+	if err != nil {
+		return Exchange{}, false, err
+	}
+	header, answer, _ := bytes.Cut(data, []byte("\n"))
+	var e Exchange
+	if json.Unmarshal(header, &e) != nil || e.ID == "" {
+		// 首行都没写完，没有可以保留的内容
+		os.Remove(path)
+		os.Remove(path + ".lock")
+		return Exchange{}, false, nil
+	}
+	e.Answer = string(answer)
+	e.Status = StatusInterrupted
+	if info, err := os.Stat(path); err == nil {
+		e.DurationMs = info.ModTime().Sub(e.Time).Milliseconds()
+	}
+	if err := Append(e); err != nil {
+		return Exchange{}, false, err
+	}
+	os.Remove(path)
+	os.Remove(path + ".lock")
+	return e, true, nil
+}
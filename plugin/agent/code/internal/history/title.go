@@ -0,0 +1,102 @@
+package history
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxTitleRunes 标题的最大字符数
+const maxTitleRunes = 48
+
+// fillers 问题开头不影响标题含义的客套话（忽略大小写比较）
+var fillers = []string{
+	"please ", "can you ", "could you ", "would you ", "help me ", "i want you to ", "i'd like you to ", "hey, ", "hi, ",
+	"请问", "请你", "请帮我", "帮我", "麻烦你",
+}
+
+// Title 按问题在本地生成标题：取第一行有内容的文字（跳过代码块），去掉 Markdown 标记与开头的客套话，
+// 在第一个句末标点处断开，过长时在词边界截断并加上省略号
+func Title(prompt string) string {
+	line := firstLine(prompt)
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, f := range fillers {
+			if len(line) > len(f) && strings.EqualFold(line[:len(f)], f) {
+				line, trimmed = strings.TrimSpace(line[len(f):]), true
+				break
+			}
+		}
+	}
+	if i := strings.IndexAny(line, "。？！?!"); i > 0 {
+		line = line[:i]
+	} else if i := strings.Index(line, ". "); i > 0 {
+		line = line[:i]
+	}
+	line = strings.TrimRightFunc(line, func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSpace(r) })
+	if utf8.RuneCountInString(line) > maxTitleRunes {
+		r := []rune(line)[:maxTitleRunes-1]
+		if i := lastSpace(r); i > maxTitleRunes/2 {
+			r = r[:i]
+		}
+		line = strings.TrimRightFunc(string(r), func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSpace(r) }) + "…"
+	}
+	if r, size := utf8.DecodeRuneInString(line); unicode.IsLower(r) {
+		line = string(unicode.ToUpper(r)) + line[size:]
+	}
+	return line
+}
+
+// firstLine 第一行有内容的文字（代码块中的行不算），多余的空白压成一个空格
+func firstLine(text string) string {
+	fenced := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			continue
+		}
+		line = strings.TrimLeft(line, "#>*-+` \t")
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func lastSpace(r []rune) int {
+	for i := len(r) - 1; i >= 0; i-- {
+		if r[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// Titles 每一轮所在对话的标题：沿 parent 找到对话的第一轮，取它的标题；
+// 第一轮已不在历史中（被裁剪）时取最早还在的那一轮的标题，都没有时不在结果中
+func Titles(list []Exchange) map[string]string {
+	byID := make(map[string]Exchange, len(list))
+	for _, e := range list {
+		byID[e.ID] = e
+	}
+	titles := make(map[string]string, len(list))
+	for _, e := range list {
+		root, seen := e, map[string]bool{e.ID: true}
+		for {
+			parent, ok := byID[root.Parent]
+			if root.Parent == "" || !ok || seen[parent.ID] {
+				break
+			}
+			seen[parent.ID] = true
+			root = parent
+		}
+		if root.Title != "" {
+			titles[e.ID] = root.Title
+		}
+	}
+	return titles
+}
@@ -0,0 +1,81 @@
+// Package i18n 提供 agent 插件的中英文文案，语言选择规则与 md_render 插件一致。
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"wcp_agent/internal/config"
+)
+
+// Locale 界面语言标识
+type Locale string
+
+const (
+	LocaleEN   Locale = "en"    // 英文
+	LocaleZhCN Locale = "zh-CN" // 简体中文
+
+	// DefaultLocale 未能识别任何语言设置时使用的默认语言
+	DefaultLocale = LocaleZhCN
+
+	// LangEnv 显式指定界面语言的环境变量（优先级高于配置文件和 LANG）
+	LangEnv = "J_LANG"
+	// SettingLang config.yaml 中 setting 段的界面语言配置项
+	SettingLang = "lang"
+)
+
+// current 进程内生效的语言，启动时由 detect 决定
+var current = detect()
+
+// detect 按优先级确定界面语言：
+// J_LANG > config.yaml 的 setting.lang > LC_ALL > LC_MESSAGES > LANG
+func detect() Locale {
+	if v := os.Getenv(LangEnv); v != "" {
+		return parse(v)
+	}
+	if v := config.Setting(SettingLang); v != "" {
+		return parse(v)
+	}
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); v != "" && v != "C" && v != "POSIX" {
+			return parse(v)
+		}
+	}
+	return DefaultLocale
+}
+
+// parse 将 "zh_CN.UTF-8"、"en-US" 这类写法归一化为已支持的语言
+func parse(raw string) Locale {
+	tag := strings.ToLower(raw)
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if strings.HasPrefix(tag, "zh") {
+		return LocaleZhCN
+	}
+	if strings.HasPrefix(tag, "en") {
+		return LocaleEN
+	}
+	return DefaultLocale
+}
+
+// Current 返回当前生效的语言
+func Current() Locale {
+	return current
+}
+
+// T 获取当前语言下的文案，缺失时回退到默认语言，再缺失则原样返回 key
+func T(key string, args ...any) string {
+	format, ok := bundles[current][key]
+	if !ok {
+		format, ok = bundles[DefaultLocale][key]
+	}
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
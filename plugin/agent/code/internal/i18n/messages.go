@@ -0,0 +1,44 @@
+package i18n
+
+// entry 一条文案的各语言版本，格式化参数遵循 fmt 语法
+type entry struct {
+	EN   string
+	ZhCN string
+}
+
+// bundles 各语言的消息模板，由各 messages_*.go 在 init 中注册
+var bundles = map[Locale]map[string]string{
+	LocaleEN:   {},
+	LocaleZhCN: {},
+}
+
+// register 注册一组文案
+func register(entries map[string]entry) {
+	for key, e := range entries {
+		bundles[LocaleEN][key] = e.EN
+		bundles[LocaleZhCN][key] = e.ZhCN
+	}
+}
+
+// 通用文案
+const (
+	MsgUsage          = "usage"
+	MsgUsageCommand   = "usage_command"
+	MsgUnknownCommand = "unknown_command"
+	MsgError          = "error"
+
+	MsgProfileInvalid = "profile_invalid"
+	MsgProfileMissing = "profile_missing"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgUsage:          {"usage: agent <command> [args]\n\ncommands:", "用法: agent <命令> [参数]\n\n命令:"},
+		MsgUsageCommand:   {"  %-*s %s", "  %-*s %s"},
+		MsgUnknownCommand: {"unknown command: %s", "未知命令: %s"},
+		MsgError:          {"error: %v", "错误: %v"},
+
+		MsgProfileInvalid: {"invalid profile name %q (from %s): only letters, digits, - and _ are allowed", "profile 名称 %q 不合法（来自 %s），只能包含字母、数字、- 与 _"},
+		MsgProfileMissing: {"profile %s does not exist (from %s), run j profile create %s first", "profile %s 不存在（来自 %s），先执行 j profile create %s"},
+	})
+}
@@ -0,0 +1,95 @@
+package i18n
+
+// ask 子命令文案
+const (
+	MsgAskSummary     = "ask_summary"
+	MsgAskNoPrompt    = "ask_no_prompt"
+	MsgAskThinking    = "ask_thinking"
+	MsgAskRateLimited = "ask_rate_limited"
+	MsgAskCancelled   = "ask_cancelled"
+
+	MsgAskUnknownPreset = "ask_unknown_preset"
+	MsgAskTruncated     = "ask_truncated"
+	MsgAskContinue      = "ask_continue"
+	MsgAskTooManyStops  = "ask_too_many_stops"
+	MsgAskContinueLimit = "ask_continue_limit"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgAskSummary:     {"send a prompt to the active provider", "向当前 provider 提问"},
+		MsgAskNoPrompt:    {"no prompt given: pass it as arguments or pipe it via stdin", "未提供问题：请通过参数传入或经管道输入"},
+		MsgAskThinking:    {"thinking...", "思考中..."},
+		MsgAskRateLimited: {"rate limited, waiting %.1fs...", "触发限流，等待 %.1fs..."},
+		MsgAskCancelled:   {"cancelled; the partial answer was saved to history", "已中断，已收到的部分回答已记入历史"},
+
+		MsgAskUnknownPreset: {"unknown sampling preset %q (available: %s)", "未知的采样预设 %q（可选: %s）"},
+		MsgAskTruncated:     {"⚠ the answer was cut off by the token limit", "⚠ 回答因 token 上限被截断"},
+		MsgAskContinue:      {"continue fetching the rest? [y/N] ", "继续获取剩余内容？[y/N] "},
+		MsgAskTooManyStops:  {"at most %d --stop sequences are supported", "--stop 最多支持 %d 个停止序列"},
+		MsgAskContinueLimit: {"stopped after %d continuations; the answer is still incomplete", "已续写 %d 次，回答仍不完整，停止续写"},
+	})
+}
+
+// ask --compare 对比模式文案
+const (
+	MsgCompareTooFew    = "compare_too_few"
+	MsgCompareWaiting   = "compare_waiting"
+	MsgCompareHeader    = "compare_header"
+	MsgCompareBadLayout = "compare_bad_layout"
+	MsgCompareTruncated = "compare_truncated"
+)
+
+// ask --schema 结构化输出文案
+const (
+	MsgSchemaNoCompare  = "schema_no_compare"
+	MsgSchemaValidating = "schema_validating"
+	MsgSchemaRepairing  = "schema_repairing"
+	MsgSchemaInvalid    = "schema_invalid"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgCompareTooFew:    {"--compare needs at least two providers, e.g. --compare gpt-4o,claude-sonnet", "--compare 至少需要两个 provider，如 --compare gpt-4o,claude-sonnet"},
+		MsgCompareWaiting:   {"waiting for answers (%d/%d)...", "等待回答（%d/%d）..."},
+		MsgCompareHeader:    {"%s · %.2fs · ~%d tokens", "%s · %.2fs · 约 %d tokens"},
+		MsgCompareBadLayout: {"unknown layout %q (expected auto, side or stack)", "未知的排版方式 %q（可选 auto、side、stack）"},
+		MsgCompareTruncated: {"truncated", "已截断"},
+
+		MsgSchemaNoCompare:  {"--schema cannot be combined with --compare", "--schema 不能与 --compare 同时使用"},
+		MsgSchemaValidating: {"validating JSON...", "校验 JSON..."},
+		MsgSchemaRepairing:  {"answer violates the schema in %d place(s), asking the model to fix it (attempt %d)", "回答有 %d 处不符合 schema，请模型修正（第 %d 次）"},
+		MsgSchemaInvalid:    {"the answer still does not match the schema after %d repair attempts", "修正 %d 次后回答仍不符合 schema"},
+	})
+}
+
+// ask --tests 生成测试文案
+const (
+	MsgTestsConflict    = "tests_conflict"
+	MsgTestsNotGo       = "tests_not_go"
+	MsgTestsGenerating  = "tests_generating"
+	MsgTestsNoCode      = "tests_no_code"
+	MsgTestsConfirm     = "tests_confirm"
+	MsgTestsNotWritten  = "tests_not_written"
+	MsgTestsWritten     = "tests_written"
+	MsgTestsRunning     = "tests_running"
+	MsgTestsPassed      = "tests_passed"
+	MsgTestsBuildFailed = "tests_build_failed"
+	MsgTestsFailed      = "tests_failed"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgTestsConflict:    {"--tests cannot be combined with --batch, --compare, --schema, --image or audio input", "--tests 不能与 --batch、--compare、--schema、--image 或音频输入同时使用"},
+		MsgTestsNotGo:       {"%s is not a Go source file (expected a .go file that is not a _test.go)", "%s 不是 Go 源文件（需要非 _test.go 的 .go 文件）"},
+		MsgTestsGenerating:  {"generating tests for %s...", "正在为 %s 生成测试..."},
+		MsgTestsNoCode:      {"the answer contains no code block", "回答中没有代码块"},
+		MsgTestsConfirm:     {"write %s? [y/N] ", "写入 %s？[y/N] "},
+		MsgTestsNotWritten:  {"not written", "未写入"},
+		MsgTestsWritten:     {"wrote %s", "已写入 %s"},
+		MsgTestsRunning:     {"running go test in %s...", "在 %s 中运行 go test..."},
+		MsgTestsPassed:      {"✓ %s compiles and the tests pass", "✓ %s 编译通过，测试全部通过"},
+		MsgTestsBuildFailed: {"✗ %s does not compile", "✗ %s 无法编译"},
+		MsgTestsFailed:      {"✗ %s compiles but some tests fail", "✗ %s 编译通过，但有测试失败"},
+	})
+}
@@ -0,0 +1,18 @@
+package i18n
+
+// 附件相关文案
+const (
+	MsgAttachNotImage = "attach_not_image"
+	MsgAttachTooLarge = "attach_too_large"
+	MsgAttachResized  = "attach_resized"
+	MsgAttachNoVision = "attach_no_vision"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgAttachNotImage: {"%s is not a supported image (png, jpeg, gif, webp): %v", "%s 不是支持的图片格式（png、jpeg、gif、webp）: %v"},
+		MsgAttachTooLarge: {"%s is still larger than %d MiB after downscaling", "%s 缩小后仍超过 %d MiB"},
+		MsgAttachResized:  {"%s downscaled to %d KiB (%s)", "%s 已缩小至 %d KiB（%s）"},
+		MsgAttachNoVision: {"provider %s is configured with \"vision\": false and cannot take images", "provider %s 配置了 \"vision\": false，不支持图片输入"},
+	})
+}
@@ -0,0 +1,16 @@
+package i18n
+
+// audit 子命令文案
+const (
+	MsgAuditSummary = "audit_summary"
+	MsgAuditUsage   = "audit_usage"
+	MsgAuditEmpty   = "audit_empty"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgAuditSummary: {"review generated and executed shell commands", "查看生成与执行过的 shell 命令审计日志"},
+		MsgAuditUsage:   {"usage: agent audit show [-n N] [--json]", "用法: agent audit show [-n N] [--json]"},
+		MsgAuditEmpty:   {"audit log is empty (%s)", "审计日志为空（%s）"},
+	})
+}
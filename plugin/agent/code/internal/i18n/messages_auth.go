@@ -0,0 +1,62 @@
+package i18n
+
+// auth 子命令文案
+const (
+	MsgAuthSummary     = "auth_summary"
+	MsgAuthUsage       = "auth_usage"
+	MsgAuthPrompt      = "auth_prompt"
+	MsgAuthEmptyKey    = "auth_empty_key"
+	MsgAuthStored      = "auth_stored"
+	MsgAuthRemoved     = "auth_removed"
+	MsgAuthUnknown     = "auth_unknown_provider"
+	MsgAuthPlaintext   = "auth_plaintext_left"
+	MsgAuthPurged      = "auth_plaintext_purged"
+	MsgAuthStatusRow   = "auth_status_row"
+	MsgAuthNoProviders = "auth_no_providers"
+	MsgAuthProfile     = "auth_profile"
+	MsgKeySrcKeyring   = "key_source_keyring"
+	MsgKeySrcEnv       = "key_source_env"
+	MsgKeySrcConfig    = "key_source_config"
+	MsgKeySrcNone      = "key_source_none"
+	MsgAuthAdded       = "auth_added"
+	MsgAuthKeyCount    = "auth_key_count"
+	MsgAuthKeysNone    = "auth_keys_none"
+	MsgAuthKeysTitle   = "auth_keys_title"
+	MsgAuthKeysHeader  = "auth_keys_header"
+	MsgAuthKeyReady    = "auth_key_ready"
+	MsgAuthKeyCooling  = "auth_key_cooling"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgAuthSummary: {"manage provider API keys in the OS keyring", "管理系统钥匙串中的 provider API Key"},
+		MsgAuthUsage: {
+			"usage: agent auth login [--add] [--purge] <provider> | logout <provider> | status | keys [--json] [provider]",
+			"用法: agent auth login [--add] [--purge] <provider> | logout <provider> | status | keys [--json] [provider]",
+		},
+		MsgAuthPrompt:   {"API key for %s: ", "请输入 %s 的 API Key: "},
+		MsgAuthEmptyKey: {"API key is empty", "API Key 不能为空"},
+		MsgAuthStored:   {"API key for %s saved to the OS keyring", "%s 的 API Key 已保存到系统钥匙串"},
+		MsgAuthRemoved:  {"API keys for %s removed from the OS keyring", "%s 的 API Key 已从系统钥匙串删除"},
+		MsgAuthUnknown:  {"provider %s is not configured in agent_config.json", "agent_config.json 中没有名为 %s 的 provider"},
+		MsgAuthPlaintext: {
+			"agent_config.json still holds a plaintext key for %s; rerun with --purge to clear it",
+			"agent_config.json 中仍保存着 %s 的明文 Key，可加 --purge 重新执行以清除",
+		},
+		MsgAuthPurged:      {"plaintext key for %s cleared from agent_config.json", "已清除 agent_config.json 中 %s 的明文 Key"},
+		MsgAuthStatusRow:   {"%-20s %s", "%-20s %s"},
+		MsgAuthNoProviders: {"no providers configured", "尚未配置任何 provider"},
+		MsgAuthProfile:     {"profile %s (keyring service %s)", "profile %s（钥匙串服务名 %s）"},
+		MsgKeySrcKeyring:   {"keyring", "钥匙串"},
+		MsgKeySrcEnv:       {"env (%s)", "环境变量 (%s)"},
+		MsgKeySrcConfig:    {"config (plaintext)", "配置文件（明文）"},
+		MsgKeySrcNone:      {"missing", "缺失"},
+		MsgAuthAdded:       {"API key #%d for %s saved to the OS keyring", "%[2]s 的第 %[1]d 个 API Key 已保存到系统钥匙串"},
+		MsgAuthKeyCount:    {", %d keys, %s", "，%d 个 Key，%s"},
+		MsgAuthKeysNone:    {"no provider has more than one API key (add one with agent auth login --add <provider>)", "没有配置了多个 API Key 的 provider（用 agent auth login --add <provider> 添加）"},
+		MsgAuthKeysTitle:   {"%s · %d key(s) · %s", "%s · %d 个 Key · %s"},
+		MsgAuthKeysHeader:  {"#|KEY|REQUESTS|INPUT|OUTPUT|429|401/403|LAST USED|STATE", "#|KEY|请求数|输入 token|输出 token|429|401/403|上次使用|状态"},
+		MsgAuthKeyReady:    {"ready", "可用"},
+		MsgAuthKeyCooling:  {"cooling until %s", "冷却至 %s"},
+	})
+}
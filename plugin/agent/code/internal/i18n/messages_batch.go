@@ -0,0 +1,22 @@
+package i18n
+
+// ask --batch 批量模式文案
+const (
+	MsgBatchConflict = "batch_conflict"
+	MsgBatchBadLine  = "batch_bad_line"
+	MsgBatchNoPrompt = "batch_no_prompt"
+	MsgBatchEmpty    = "batch_empty"
+	MsgBatchProgress = "batch_progress"
+	MsgBatchDone     = "batch_done"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgBatchConflict: {"--batch reads prompts from the file and cannot be combined with prompt arguments, --audio/--mic, --compare or --schema", "--batch 从文件读取问题，不能与问题参数、--audio/--mic、--compare 或 --schema 同时使用"},
+		MsgBatchBadLine:  {"%s line %d: invalid JSON: %v", "%s 第 %d 行: JSON 格式错误: %v"},
+		MsgBatchNoPrompt: {"%s line %d: missing \"prompt\"", "%s 第 %d 行: 缺少 \"prompt\""},
+		MsgBatchEmpty:    {"%s contains no prompts", "%s 中没有任何问题"},
+		MsgBatchProgress: {"batch %d/%d (%d failed)...", "批量处理 %d/%d（失败 %d）..."},
+		MsgBatchDone:     {"finished %d/%d prompts, %d failed → %s", "已完成 %d/%d 条，失败 %d 条 → %s"},
+	})
+}
@@ -0,0 +1,46 @@
+package i18n
+
+// 每月预算（setting.agent_budget、provider 的 monthly_budget、--override-budget）与 agent budget 子命令文案
+const (
+	MsgBudgetSummary          = "budget_summary"
+	MsgBudgetUsage            = "budget_usage"
+	MsgBudgetTotal            = "budget_total"
+	MsgBudgetTotalRow         = "budget_total_row"
+	MsgBudgetTotalExceeded    = "budget_total_exceeded"
+	MsgBudgetProviderExceeded = "budget_provider_exceeded"
+	MsgBudgetNear             = "budget_near"
+	MsgBudgetOverridden       = "budget_overridden"
+	MsgBudgetHeader           = "budget_header"
+	MsgBudgetColumns          = "budget_columns"
+	MsgBudgetNoLimits         = "budget_no_limits"
+	MsgBudgetBadMonth         = "budget_bad_month"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgBudgetSummary:  {"show this month's estimated spend against the budgets", "查看本月估算费用与预算"},
+		MsgBudgetUsage:    {"usage: agent budget [YYYY-MM]", "用法: agent budget [YYYY-MM]"},
+		MsgBudgetTotal:    {"all providers", "全部 provider"},
+		MsgBudgetTotalRow: {"total", "合计"},
+		MsgBudgetTotalExceeded: {
+			"monthly budget reached: estimated spend for %s is %.4f of %.2f (setting.agent_budget); rerun with --override-budget to send anyway",
+			"已达到每月预算：%s 的估算费用为 %.4f，预算 %.2f（setting.agent_budget）；确需发送请加 --override-budget 重新执行",
+		},
+		MsgBudgetProviderExceeded: {
+			"monthly budget of provider %s reached: estimated spend for %s is %.4f of %.2f (monthly_budget); rerun with --override-budget to send anyway, or use --provider with another one",
+			"provider %s 已达到每月预算：%s 的估算费用为 %.4f，预算 %.2f（monthly_budget）；确需发送请加 --override-budget 重新执行，或用 --provider 换一个",
+		},
+		MsgBudgetNear: {
+			"budget warning: %s has used %.4f of its monthly budget %.2f (%.0f%%)",
+			"预算提醒：%s 本月已用 %.4f，预算 %.2f（%.0f%%）",
+		},
+		MsgBudgetOverridden: {
+			"over budget: %s has used %.4f of its monthly budget %.2f (%.0f%%); sending because of --override-budget",
+			"已超出预算：%s 本月已用 %.4f，预算 %.2f（%.0f%%）；因 --override-budget 照常发送",
+		},
+		MsgBudgetHeader:   {"estimated spend for %s (%s)", "%s 的估算费用（%s）"},
+		MsgBudgetColumns:  {"provider|spent|budget|used", "provider|已用|预算|占比"},
+		MsgBudgetNoLimits: {"no budget configured (set setting.agent_budget in config.yaml or monthly_budget on a provider)", "尚未配置预算（在 config.yaml 中设置 setting.agent_budget，或给 provider 设置 monthly_budget）"},
+		MsgBudgetBadMonth: {"invalid month %q, expected YYYY-MM", "无效的月份 %q，应为 YYYY-MM"},
+	})
+}
@@ -0,0 +1,19 @@
+package i18n
+
+// 会话自动摘要文案
+const (
+	MsgCompactDone     = "compact_done"
+	MsgCompactFailed   = "compact_failed"
+	MsgCompactProvider = "compact_provider"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgCompactDone: {
+			"session history reached the summarize threshold (~%d of %d tokens): %d earlier message(s) summarized with %s",
+			"会话历史达到摘要阈值（约 %d / %d token）：已用 %[4]s 把较早的 %[3]d 条消息压缩成摘要",
+		},
+		MsgCompactFailed:   {"could not summarize earlier session messages, sending them as they are: %v", "摘要会话中较早的消息失败，照原样发送: %v"},
+		MsgCompactProvider: {"summarize.provider %s not found", "找不到 summarize.provider 指定的 %s"},
+	})
+}
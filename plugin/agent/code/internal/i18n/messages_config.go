@@ -0,0 +1,64 @@
+package i18n
+
+// config 子命令与配置校验文案
+const (
+	MsgConfigSummary = "config_summary"
+	MsgConfigUsage   = "config_usage"
+	MsgConfigOK      = "config_ok"
+	MsgConfigInvalid = "config_invalid"
+
+	MsgConfigWarning = "config_warning"
+	MsgConfigError   = "config_error"
+	MsgConfigFix     = "config_fix"
+
+	MsgConfigSyntax     = "config_syntax"
+	MsgConfigTrailing   = "config_trailing"
+	MsgConfigUnknown    = "config_unknown"
+	MsgConfigDeprecated = "config_deprecated"
+	MsgConfigType       = "config_type"
+	MsgConfigEnum       = "config_enum"
+	MsgConfigDuplicate  = "config_duplicate"
+
+	MsgConfigFixRename    = "config_fix_rename"
+	MsgConfigFixRemove    = "config_fix_remove"
+	MsgConfigFixMove      = "config_fix_move"
+	MsgConfigFixUnquote   = "config_fix_unquote"
+	MsgConfigFixQuote     = "config_fix_quote"
+	MsgConfigFixType      = "config_fix_type"
+	MsgConfigFixInteger   = "config_fix_integer"
+	MsgConfigFixOneOf     = "config_fix_one_of"
+	MsgConfigFixValue     = "config_fix_value"
+	MsgConfigFixDuplicate = "config_fix_duplicate"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgConfigSummary: {"check agent_config.json for typos, wrong types and deprecated options", "检查 agent_config.json 中的拼写、类型与废弃项"},
+		MsgConfigUsage:   {"usage: agent config check [file] | project", "用法: agent config check [文件] | project"},
+		MsgConfigOK:      {"%s: no problems found", "%s：未发现问题"},
+		MsgConfigInvalid: {"%s has %d error(s); fix them and try again", "%s 中有 %d 处错误，请修正后重试"},
+
+		MsgConfigWarning: {"warning", "警告"},
+		MsgConfigError:   {"error", "错误"},
+		MsgConfigFix:     {" (fix: %s)", "（建议：%s）"},
+
+		MsgConfigSyntax:     {"invalid JSON: %v", "JSON 格式错误: %v"},
+		MsgConfigTrailing:   {"unexpected content after the top-level object", "顶层对象之后有多余的内容"},
+		MsgConfigUnknown:    {"unknown option %q", "未知的配置项 %q"},
+		MsgConfigDeprecated: {"%q is deprecated", "%q 已废弃"},
+		MsgConfigType:       {"expected %s, got %s", "应为 %s，实际为 %s"},
+		MsgConfigEnum:       {"invalid value %q", "无效的取值 %q"},
+		MsgConfigDuplicate:  {"duplicate option %q; only the last one takes effect", "重复的配置项 %q，只有最后一个生效"},
+
+		MsgConfigFixRename:    {"rename it to %q", "改为 %q"},
+		MsgConfigFixRemove:    {"remove it or check the spelling", "删除该项或检查拼写"},
+		MsgConfigFixMove:      {"move it to %s", "改用 %s"},
+		MsgConfigFixUnquote:   {"remove the quotes: %s", "去掉引号：%s"},
+		MsgConfigFixQuote:     {"add quotes: %s", "加上引号：%s"},
+		MsgConfigFixType:      {"change it to a %s", "改为 %s"},
+		MsgConfigFixInteger:   {"use a whole number", "改为整数"},
+		MsgConfigFixOneOf:     {"use one of %s", "可选 %s"},
+		MsgConfigFixValue:     {"change it to %q", "改为 %q"},
+		MsgConfigFixDuplicate: {"keep only one of them", "只保留其中一个"},
+	})
+}
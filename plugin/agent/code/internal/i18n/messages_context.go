@@ -0,0 +1,20 @@
+package i18n
+
+// 项目上下文收集文案
+const (
+	MsgContextBadMode  = "context_bad_mode"
+	MsgContextIncluded = "context_included"
+
+	MsgHistoryContextIncluded = "history_context_included"
+	MsgHistoryContextFailed   = "history_context_failed"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgContextBadMode:  {"unknown --context %q (expected auto, none or full)", "未知的 --context %q（可选 auto、none 或 full）"},
+		MsgContextIncluded: {"project context: %s, %d file(s), ~%d tokens", "项目上下文：%s，%d 个文件，约 %d token"},
+
+		MsgHistoryContextIncluded: {"shell history: last %d command(s) from %s, %d secret(s) redacted", "shell 历史：%[2]s 中最近 %[1]d 条命令，已脱敏 %[3]d 处"},
+		MsgHistoryContextFailed:   {"could not read shell history: %v", "读取 shell 历史失败: %v"},
+	})
+}
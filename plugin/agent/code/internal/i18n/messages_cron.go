@@ -0,0 +1,56 @@
+package i18n
+
+// 定时任务（agent cron）文案
+const (
+	MsgCronSummary      = "cron_summary"
+	MsgCronUsage        = "cron_usage"
+	MsgCronAddUsage     = "cron_add_usage"
+	MsgCronBadName      = "cron_bad_name"
+	MsgCronExists       = "cron_exists"
+	MsgCronNeverMatches = "cron_never_matches"
+	MsgCronNotFound     = "cron_not_found"
+	MsgCronNoTask       = "cron_no_task"
+	MsgCronFlowOnly     = "cron_flow_only"
+	MsgCronAdded        = "cron_added"
+	MsgCronNoDaemon     = "cron_no_daemon"
+	MsgCronRemoved      = "cron_removed"
+	MsgCronPaused       = "cron_paused"
+	MsgCronResumed      = "cron_resumed"
+	MsgCronNone         = "cron_none"
+	MsgCronHeader       = "cron_header"
+	MsgCronNever        = "cron_never"
+	MsgCronStatePaused  = "cron_state_paused"
+	MsgCronRunning      = "cron_running"
+	MsgCronRunDone      = "cron_run_done"
+	MsgCronDelivered    = "cron_delivered"
+	MsgCronDeliverFail  = "cron_deliver_fail"
+	MsgCronNoRuns       = "cron_no_runs"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgCronSummary:      {"run saved prompts, templates or pipelines on a schedule (executed by agent daemon)", "按时执行保存的问题、模板或流水线（由 agent daemon 执行）"},
+		MsgCronUsage:        {"usage: agent cron add ... | list [--json] | run <name> | log [name] [-n N] [--json] | pause <name> | resume <name> | remove <name>", "用法: agent cron add ... | list [--json] | run <名称> | log [名称] [-n N] [--json] | pause <名称> | resume <名称> | remove <名称>"},
+		MsgCronAddUsage:     {"usage: agent cron add --name N --schedule \"0 9 * * *\" [--template T | --flow file] [--var k=v]... [--input cmd] [--deliver file:path | notify | webhook:url]... [prompt...]", "用法: agent cron add --name 名称 --schedule \"0 9 * * *\" [--template 模板 | --flow 文件] [--var k=v]... [--input 命令] [--deliver file:路径 | notify | webhook:url]... [问题...]"},
+		MsgCronBadName:      {"invalid job name %q (letters, digits, _ . -, up to 64 characters)", "任务名 %q 不合法（仅限字母、数字、_ . -，至多 64 个字符）"},
+		MsgCronNeverMatches: {"schedule %q never matches a date", "时间表 %q 永远不会到期"},
+		MsgCronExists:       {"job %q already exists (agent cron remove %s first)", "任务 %q 已存在（先执行 agent cron remove %s）"},
+		MsgCronNotFound:     {"no job named %q (see agent cron list)", "没有名为 %q 的任务（见 agent cron list）"},
+		MsgCronNoTask:       {"give a prompt, --template or --flow for the job to run", "请给出任务要执行的问题、--template 或 --flow"},
+		MsgCronFlowOnly:     {"--flow runs a pipeline and cannot be combined with a prompt, --template or --provider", "--flow 执行流水线，不能与问题、--template 或 --provider 同时使用"},
+		MsgCronAdded:        {"added job %s, next run %s", "已添加任务 %s，下次执行 %s"},
+		MsgCronNoDaemon:     {"note: jobs run only while the daemon is running (start it with agent daemon)", "注意：定时任务只在守护进程运行时执行（用 agent daemon 启动）"},
+		MsgCronRemoved:      {"removed job %s", "已删除任务 %s"},
+		MsgCronPaused:       {"paused job %s", "已暂停任务 %s"},
+		MsgCronResumed:      {"resumed job %s, next run %s", "已恢复任务 %s，下次执行 %s"},
+		MsgCronNone:         {"no cron jobs yet (add one with agent cron add)", "还没有定时任务（用 agent cron add 添加）"},
+		MsgCronHeader:       {"NAME|SCHEDULE|NEXT|LAST|DELIVER", "名称|时间表|下次执行|上次执行|去向"},
+		MsgCronNever:        {"never", "从不"},
+		MsgCronStatePaused:  {"paused", "已暂停"},
+		MsgCronRunning:      {"running", "执行中"},
+		MsgCronRunDone:      {"job %s: %s in %.1fs", "任务 %s: %s，耗时 %.1fs"},
+		MsgCronDelivered:    {"delivered to %s", "已送达 %s"},
+		MsgCronDeliverFail:  {"delivery failed: %s", "送达失败: %s"},
+		MsgCronNoRuns:       {"no runs yet", "还没有执行记录"},
+	})
+}
@@ -0,0 +1,52 @@
+package i18n
+
+// daemon 子命令与 ask --session 文案
+const (
+	MsgDaemonSummary       = "daemon_summary"
+	MsgDaemonUsage         = "daemon_usage"
+	MsgDaemonStarted       = "daemon_started"
+	MsgDaemonRunning       = "daemon_running"
+	MsgDaemonNotRunning    = "daemon_not_running"
+	MsgDaemonStopped       = "daemon_stopped"
+	MsgDaemonStatus        = "daemon_status"
+	MsgDaemonWarm          = "daemon_warm"
+	MsgDaemonSessions      = "daemon_sessions"
+	MsgDaemonSession       = "daemon_session"
+	MsgDaemonPlugins       = "daemon_plugins"
+	MsgDaemonJobs          = "daemon_jobs"
+	MsgDaemonJob           = "daemon_job"
+	MsgDaemonWatching      = "daemon_watching"
+	MsgDaemonNoticeConfig  = "daemon_notice_config"
+	MsgDaemonNoticePlugins = "daemon_notice_plugins"
+	MsgDaemonNoticeRequest = "daemon_notice_request"
+	MsgDaemonNoticeCron    = "daemon_notice_cron"
+
+	MsgAskSessionNoDaemon = "ask_session_no_daemon"
+	MsgAskSessionConflict = "ask_session_conflict"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgDaemonSummary:       {"run a background daemon that keeps providers warm, sessions in memory and runs cron jobs", "常驻守护进程：保持 provider 连接、插件注册表与内存中的会话，并按时执行定时任务"},
+		MsgDaemonUsage:         {"usage: agent daemon [run] | status [--json] | stop | watch", "用法: agent daemon [run] | status [--json] | stop | watch"},
+		MsgDaemonStarted:       {"daemon listening on %s (pid %d), Ctrl-C to stop", "守护进程已在 %s 上监听（pid %d），Ctrl-C 退出"},
+		MsgDaemonRunning:       {"a daemon is already running on %s", "已有守护进程在 %s 上运行"},
+		MsgDaemonNotRunning:    {"the daemon is not running (start it with agent daemon)", "守护进程未运行（用 agent daemon 启动）"},
+		MsgDaemonStopped:       {"daemon stopped", "守护进程已退出"},
+		MsgDaemonStatus:        {"pid %d · up %s · %d request(s) · %s", "pid %d · 已运行 %s · %d 次请求 · %s"},
+		MsgDaemonWarm:          {"warm providers: %s", "已建立连接的 provider：%s"},
+		MsgDaemonSessions:      {"sessions:", "会话："},
+		MsgDaemonSession:       {"  %-16s %d message(s) · last used %s", "  %-16s %d 条消息 · 最近使用 %s"},
+		MsgDaemonPlugins:       {"plugins (name, protocol, transport, features):", "插件（名称、协议、传输、特性）："},
+		MsgDaemonJobs:          {"cron jobs:", "定时任务："},
+		MsgDaemonJob:           {"  %-16s %-16s next %s", "  %-16s %-16s 下次 %s"},
+		MsgDaemonWatching:      {"watching daemon events, Ctrl-C to stop", "正在接收守护进程事件，Ctrl-C 结束"},
+		MsgDaemonNoticeConfig:  {"configuration reloaded (%d providers)", "已重新加载配置（%d 个 provider）"},
+		MsgDaemonNoticePlugins: {"plugin registry updated (%d plugins)", "插件注册表已更新（%d 个插件）"},
+		MsgDaemonNoticeRequest: {"%s (%s) %s in %dms", "%s（%s）%s，耗时 %dms"},
+		MsgDaemonNoticeCron:    {"cron job %s %s in %dms", "定时任务 %s %s，耗时 %dms"},
+
+		MsgAskSessionNoDaemon: {"--session needs a running daemon (start it with agent daemon)", "--session 需要守护进程（先运行 agent daemon）"},
+		MsgAskSessionConflict: {"--session cannot be combined with --batch, --compare or --tests", "--session 不能与 --batch、--compare 或 --tests 同时使用"},
+	})
+}
@@ -0,0 +1,16 @@
+package i18n
+
+// -f 附上文件（含 PDF / DOCX）的文案
+const (
+	MsgDocumentSkipped   = "document_skipped"
+	MsgDocumentTruncated = "document_truncated"
+	MsgFilesConflict     = "files_conflict"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgDocumentSkipped:   {"skipped: %v", "已跳过：%v"},
+		MsgDocumentTruncated: {"%s: extracted text truncated to %d KiB", "%s：取出的文字已截断到 %d KiB"},
+		MsgFilesConflict:     {"-f cannot be combined with --batch, --tests or --resume", "-f 不能与 --batch、--tests 或 --resume 同时使用"},
+	})
+}
@@ -0,0 +1,16 @@
+package i18n
+
+// 编辑器集成（agent editor）文案
+const (
+	MsgEditorSummary    = "editor_summary"
+	MsgEditorUsage      = "editor_usage"
+	MsgEditorBadRequest = "editor_bad_request"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgEditorSummary:    {"editor integration: answer or rewrite a selection sent as JSON on stdin (agent editor nvim prints the Neovim module)", "编辑器集成：处理 stdin 上的 JSON 请求，解答或改写选中的文本（agent editor nvim 输出 Neovim 模块）"},
+		MsgEditorUsage:      {"usage: agent editor [nvim] (the request is read from stdin)", "用法: agent editor [nvim]（请求从 stdin 读取）"},
+		MsgEditorBadRequest: {"the request is not valid JSON: %v", "请求不是有效的 JSON: %v"},
+	})
+}
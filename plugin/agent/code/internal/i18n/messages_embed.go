@@ -0,0 +1,18 @@
+package i18n
+
+// embed 子命令文案
+const (
+	MsgEmbedSummary   = "embed_summary"
+	MsgEmbedNoInput   = "embed_no_input"
+	MsgEmbedBadFormat = "embed_bad_format"
+	MsgEmbedProgress  = "embed_progress"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgEmbedSummary:   {"turn text from files or stdin into embedding vectors", "把文件或管道输入的文本转换为向量"},
+		MsgEmbedNoInput:   {"no input: pass files or pipe text via stdin", "没有输入：请传入文件或经管道输入文本"},
+		MsgEmbedBadFormat: {"unknown format %q (expected json, jsonl or tsv)", "未知的输出格式 %q（可选 json、jsonl、tsv）"},
+		MsgEmbedProgress:  {"embedding %d/%d...", "向量化 %d/%d..."},
+	})
+}
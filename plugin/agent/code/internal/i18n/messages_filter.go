@@ -0,0 +1,20 @@
+package i18n
+
+// 插件过滤器（agent ask --through）与后处理器文案
+const (
+	MsgFilterUnknown        = "filter_unknown"
+	MsgFilterAmbiguous      = "filter_ambiguous"
+	MsgFilterFailed         = "filter_failed"
+	MsgFilterConflict       = "filter_conflict"
+	MsgFilterNotPostprocess = "filter_not_postprocess"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgFilterUnknown:        {"no plugin provides filter %q (available: %s)", "没有插件提供过滤器 %q（可用: %s）"},
+		MsgFilterAmbiguous:      {"filter %q is provided by several plugins, name one of: %s", "多个插件提供过滤器 %q，请写明其中一个: %s"},
+		MsgFilterFailed:         {"filter %s: %v", "过滤器 %s: %v"},
+		MsgFilterConflict:       {"--through cannot be combined with --tests, --batch, --compare or --resume", "--through 不能与 --tests、--batch、--compare 或 --resume 同时使用"},
+		MsgFilterNotPostprocess: {"filter %s is not a postprocessor (its output may not be Markdown); use it with --through instead", "过滤器 %s 不是后处理器（输出可能不是 Markdown），请改用 --through"},
+	})
+}
@@ -0,0 +1,46 @@
+package i18n
+
+// fix 子命令文案
+const (
+	MsgFixSummary       = "fix_summary"
+	MsgFixNoInput       = "fix_no_input"
+	MsgFixNoLocations   = "fix_no_locations"
+	MsgFixThinking      = "fix_thinking"
+	MsgFixNoDiff        = "fix_no_diff"
+	MsgFixNotApplied    = "fix_not_applied"
+	MsgFixConfirm       = "fix_confirm"
+	MsgFixSkipped       = "fix_skipped"
+	MsgFixPlan          = "fix_plan"
+	MsgFixPlanFuzzy     = "fix_plan_fuzzy"
+	MsgFixPlanMerged    = "fix_plan_merged"
+	MsgFixPlanConflicts = "fix_plan_conflicts"
+	MsgFixApplied       = "fix_applied"
+	MsgFixCreated       = "fix_created"
+	MsgFixConflicts     = "fix_conflicts"
+	MsgFixChecking      = "fix_checking"
+	MsgFixCheckPassed   = "fix_check_passed"
+	MsgFixCheckFailed   = "fix_check_failed"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgFixSummary:       {"explain compiler errors piped on stdin and apply a minimal fix", "解释管道输入的编译错误并应用最小修复"},
+		MsgFixNoInput:       {"pipe the build output into agent fix, e.g. go build ./... 2>&1 | agent fix", "请把编译输出通过管道交给 agent fix，如 go build ./... 2>&1 | agent fix"},
+		MsgFixNoLocations:   {"the input contains no file:line references to existing files", "输入中没有指向现有文件的 文件:行号 位置"},
+		MsgFixThinking:      {"analyzing %d error(s)...", "正在分析 %d 处错误..."},
+		MsgFixNoDiff:        {"the answer contains no diff", "回答中没有 diff"},
+		MsgFixNotApplied:    {"the suggested fix does not apply, nothing was changed: %v", "建议的修复无法应用，未修改任何文件：%v"},
+		MsgFixPlan:          {"%s: %d hunk(s)", "%s：%d 处改动"},
+		MsgFixPlanFuzzy:     {", %d matched with fuzz", "，%d 处经模糊匹配"},
+		MsgFixPlanMerged:    {", the diff targets an older version: three-way merged with the copy in git", "，diff 基于旧版本：已与 git 中的版本三方合并"},
+		MsgFixPlanConflicts: {", %d conflict(s) left as <<<<<<< markers", "，%d 处冲突以 <<<<<<< 标记留在文件中"},
+		MsgFixConfirm:       {"apply the fix? [y/N] ", "应用修复？[y/N] "},
+		MsgFixSkipped:       {"not applied", "未应用"},
+		MsgFixApplied:       {"patched %s (original saved as %s)", "已修改 %s（原文件备份为 %s）"},
+		MsgFixCreated:       {"created %s", "已新建 %s"},
+		MsgFixConflicts:     {"resolve the conflict markers in %s before building again", "请先解决 %s 中的冲突标记再重新编译"},
+		MsgFixChecking:      {"running: %s", "正在运行：%s"},
+		MsgFixCheckPassed:   {"✓ %s succeeded", "✓ %s 成功"},
+		MsgFixCheckFailed:   {"✗ %s still fails", "✗ %s 仍然失败"},
+	})
+}
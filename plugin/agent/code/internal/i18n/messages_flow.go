@@ -0,0 +1,36 @@
+package i18n
+
+// flow 子命令文案
+const (
+	MsgFlowSummary   = "flow_summary"
+	MsgFlowUsage     = "flow_usage"
+	MsgFlowNoSteps   = "flow_no_steps"
+	MsgFlowBadID     = "flow_bad_id"
+	MsgFlowDupID     = "flow_dup_id"
+	MsgFlowNoPrompt  = "flow_no_prompt"
+	MsgFlowBadRef    = "flow_bad_ref"
+	MsgFlowSelfRef   = "flow_self_ref"
+	MsgFlowBadOutput = "flow_bad_output"
+	MsgFlowNoVar     = "flow_no_var"
+	MsgFlowRunning   = "flow_running"
+	MsgFlowStep      = "flow_step"
+	MsgFlowFailed    = "flow_failed"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgFlowSummary:   {"run a declarative prompt pipeline (YAML)", "执行声明式提示词流水线（YAML）"},
+		MsgFlowUsage:     {"usage: agent flow run [--input file] [--var k=v]... [--verbose] [--json] pipeline.yaml", "用法: agent flow run [--input file] [--var k=v]... [--verbose] [--json] pipeline.yaml"},
+		MsgFlowNoSteps:   {"pipeline has no steps", "流水线没有任何步骤"},
+		MsgFlowBadID:     {"invalid step id %q (letters, digits, _ and -; input/vars/steps are reserved)", "步骤 id %q 不合法（仅限字母、数字、_ 和 -，input/vars/steps 为保留字）"},
+		MsgFlowDupID:     {"duplicate step id %q", "步骤 id %q 重复"},
+		MsgFlowNoPrompt:  {"step %s has no prompt", "步骤 %s 缺少 prompt"},
+		MsgFlowBadRef:    {"step %s references {{%s}}, which is not an earlier step, input or vars.*", "步骤 %s 引用了 {{%s}}，它不是之前的步骤、input 或 vars.*"},
+		MsgFlowSelfRef:   {"step %s references its own output", "步骤 %s 引用了自身的输出"},
+		MsgFlowBadOutput: {"output refers to unknown step %q", "output 指向不存在的步骤 %q"},
+		MsgFlowNoVar:     {"{{%s}} is not defined: add it under vars or pass --var", "{{%s}} 未定义：请在 vars 中添加或通过 --var 传入"},
+		MsgFlowRunning:   {"[%d/%d] %s (%s)...", "[%d/%d] %s（%s）..."},
+		MsgFlowStep:      {"── %s · %s · %.2fs ──", "── %s · %s · %.2fs ──"},
+		MsgFlowFailed:    {"step %s failed: %v", "步骤 %s 执行失败: %v"},
+	})
+}
@@ -0,0 +1,36 @@
+package i18n
+
+// git 钩子（agent git）文案
+const (
+	MsgGitSummary       = "git_summary"
+	MsgGitUsage         = "git_usage"
+	MsgGitNotRepo       = "git_not_repo"
+	MsgGitHookExists    = "git_hook_exists"
+	MsgGitHookBackedUp  = "git_hook_backed_up"
+	MsgGitHookInstalled = "git_hook_installed"
+	MsgGitHookRemoved   = "git_hook_removed"
+	MsgGitHookRestored  = "git_hook_restored"
+	MsgGitNoHooks       = "git_no_hooks"
+	MsgGitGenerating    = "git_generating"
+	MsgGitReviewing     = "git_reviewing"
+	MsgGitReviewTitle   = "git_review_title"
+	MsgGitSkipped       = "git_skipped"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgGitSummary:       {"install git hooks that write commit messages and review outgoing commits", "安装生成提交信息、推送前总结提交的 git 钩子"},
+		MsgGitUsage:         {"usage: agent git install-hooks [--pre-push] [--force] | uninstall-hooks", "用法: agent git install-hooks [--pre-push] [--force] | uninstall-hooks"},
+		MsgGitNotRepo:       {"not inside a git repository", "不在 git 仓库中"},
+		MsgGitHookExists:    {"%s already exists and was not installed by agent (use --force to replace it; it is kept as a backup)", "%s 已存在且不是 agent 安装的（--force 替换，原钩子会备份）"},
+		MsgGitHookBackedUp:  {"kept the existing hook as %s", "原钩子已保存为 %s"},
+		MsgGitHookInstalled: {"installed %s", "已安装 %s"},
+		MsgGitHookRemoved:   {"removed %s", "已删除 %s"},
+		MsgGitHookRestored:  {"restored the previous %s", "已恢复原来的 %s"},
+		MsgGitNoHooks:       {"no hooks installed by agent in this repository", "本仓库中没有 agent 安装的钩子"},
+		MsgGitGenerating:    {"writing the commit message", "正在生成提交信息"},
+		MsgGitReviewing:     {"reviewing the outgoing commits", "正在总结待推送的提交"},
+		MsgGitReviewTitle:   {"── commits to push to %s ──", "── 即将推送到 %s 的提交 ──"},
+		MsgGitSkipped:       {"agent: skipped (%v)", "agent: 已跳过（%v）"},
+	})
+}
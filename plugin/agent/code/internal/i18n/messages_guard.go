@@ -0,0 +1,82 @@
+package i18n
+
+// 危险命令检查（agent guard 与 internal/guard）文案
+const (
+	MsgGuardSummary      = "guard_summary"
+	MsgGuardUsage        = "guard_usage"
+	MsgGuardNone         = "guard_none"
+	MsgGuardHigh         = "guard_high"
+	MsgGuardWarn         = "guard_warn"
+	MsgGuardTypeConfirm  = "guard_type_confirm"
+	MsgGuardDeclined     = "guard_declined"
+	MsgGuardNoTerminal   = "guard_no_terminal"
+	MsgGuardBuiltinTitle = "guard_builtin_title"
+	MsgGuardUserTitle    = "guard_user_title"
+	MsgGuardNoUserRules  = "guard_no_user_rules"
+	MsgGuardDisabled     = "guard_disabled"
+
+	MsgGuardRuleRmRecursive   = "guard_rule_rm_recursive"
+	MsgGuardRulePipeToShell   = "guard_rule_pipe_to_shell"
+	MsgGuardRuleWorldWritable = "guard_rule_world_writable"
+	MsgGuardRuleDisk          = "guard_rule_disk"
+	MsgGuardRuleForkBomb      = "guard_rule_fork_bomb"
+	MsgGuardRulePrivileged    = "guard_rule_privileged"
+	MsgGuardRuleOutsideCwd    = "guard_rule_outside_cwd"
+
+	MsgGuardRmRecursive     = "guard_rm_recursive"
+	MsgGuardRmCwd           = "guard_rm_cwd"
+	MsgGuardRmOutside       = "guard_rm_outside"
+	MsgGuardRmExpansion     = "guard_rm_expansion"
+	MsgGuardRmXargs         = "guard_rm_xargs"
+	MsgGuardPipeToShell     = "guard_pipe_to_shell"
+	MsgGuardWorldWritable   = "guard_world_writable"
+	MsgGuardRecursiveChange = "guard_recursive_change"
+	MsgGuardFormat          = "guard_format"
+	MsgGuardDiskWrite       = "guard_disk_write"
+	MsgGuardSystemWrite     = "guard_system_write"
+	MsgGuardOutsideCwd      = "guard_outside_cwd"
+	MsgGuardForkBomb        = "guard_fork_bomb"
+	MsgGuardPrivileged      = "guard_privileged"
+	MsgGuardUserRule        = "guard_user_rule"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgGuardSummary:      {"check a shell command for risky operations before running it", "执行前检查 shell 命令中的危险操作"},
+		MsgGuardUsage:        {"usage: agent guard check [--brief|--json] [--cwd dir] -- <command> | confirm [--source name] -- <command> | rules", "用法: agent guard check [--brief|--json] [--cwd 目录] -- <命令> | confirm [--source 来源] -- <命令> | rules"},
+		MsgGuardNone:         {"no risks found", "未发现风险"},
+		MsgGuardHigh:         {"high risk", "高风险"},
+		MsgGuardWarn:         {"warning", "注意"},
+		MsgGuardTypeConfirm:  {"This command is high risk. Type %q to run it anyway: ", "这是高风险命令，确认执行请输入 %q: "},
+		MsgGuardDeclined:     {"not run", "已取消执行"},
+		MsgGuardNoTerminal:   {"high-risk command not run: no terminal to confirm it", "没有可用于确认的终端，高风险命令不执行"},
+		MsgGuardBuiltinTitle: {"built-in rules:", "内置规则:"},
+		MsgGuardUserTitle:    {"user rules (%s):", "用户规则（%s）:"},
+		MsgGuardNoUserRules:  {"no user rules (%s)", "没有用户规则（%s）"},
+		MsgGuardDisabled:     {"disabled", "已关闭"},
+
+		MsgGuardRuleRmRecursive:   {"rm -r of /, system directories, the home directory, the working directory itself or anything outside it", "rm -r 删除根目录、系统目录、家目录、工作目录本身或工作目录之外的内容"},
+		MsgGuardRulePipeToShell:   {"downloaded content run directly by a shell or interpreter (curl | sh, bash <(curl ...))", "下载的内容直接交给 shell 或解释器执行（curl | sh、bash <(curl ...)）"},
+		MsgGuardRuleWorldWritable: {"chmod that makes files writable by everyone (777, o+w); high risk with -R", "chmod 使文件对所有人可写（777、o+w），带 -R 时为高风险"},
+		MsgGuardRuleDisk:          {"formatting or writing block devices (mkfs, dd of=/dev/sda, > /dev/nvme0n1)", "格式化或写入块设备（mkfs、dd of=/dev/sda、> /dev/nvme0n1）"},
+		MsgGuardRuleForkBomb:      {"fork bombs", "fork 炸弹"},
+		MsgGuardRulePrivileged:    {"commands run with sudo or doas", "经 sudo 或 doas 以 root 运行的命令"},
+		MsgGuardRuleOutsideCwd:    {"writes, moves or deletes outside the working directory; high risk for system directories", "在工作目录之外写入、移动或删除；系统目录为高风险"},
+
+		MsgGuardRmRecursive:     {"recursively deletes %s", "递归删除 %s"},
+		MsgGuardRmCwd:           {"recursively deletes everything in the working directory (%s)", "递归删除工作目录中的全部内容（%s）"},
+		MsgGuardRmOutside:       {"recursively deletes %s outside the working directory", "递归删除工作目录之外的 %s"},
+		MsgGuardRmExpansion:     {"recursively deletes %s, whose value is only known when it runs", "递归删除 %s，其值在运行时才能确定"},
+		MsgGuardRmXargs:         {"recursively deletes the paths xargs reads from its input, which are only known when it runs", "递归删除 xargs 从输入读取的路径，要删除什么在运行时才能确定"},
+		MsgGuardPipeToShell:     {"runs downloaded content with %s without inspecting it", "未经查看就用 %s 执行下载的内容"},
+		MsgGuardWorldWritable:   {"makes %s writable by everyone", "使 %s 对所有人可写"},
+		MsgGuardRecursiveChange: {"%s -R on %s", "对 %[2]s 递归执行 %[1]s"},
+		MsgGuardFormat:          {"%s erases or repartitions a disk", "%s 会抹掉磁盘或重新分区"},
+		MsgGuardDiskWrite:       {"writes directly to the block device %s", "直接写入块设备 %s"},
+		MsgGuardSystemWrite:     {"modifies the system path %s", "修改系统路径 %s"},
+		MsgGuardOutsideCwd:      {"modifies %s outside the working directory", "修改工作目录之外的 %s"},
+		MsgGuardForkBomb:        {"fork bomb: exhausts the system's processes", "fork 炸弹：耗尽系统进程"},
+		MsgGuardPrivileged:      {"runs with root privileges", "以 root 权限运行"},
+		MsgGuardUserRule:        {"matches the rule %s", "匹配规则 %s"},
+	})
+}
@@ -0,0 +1,22 @@
+package i18n
+
+// help 子命令文案
+const (
+	MsgHelpSummary        = "help_summary"
+	MsgHelpUsage          = "help_usage"
+	MsgHelpUnknown        = "help_unknown"
+	MsgHelpOptions        = "help_options"
+	MsgHelpFlagsHeader    = "help_flags_header"
+	MsgHelpCommandsHeader = "help_commands_header"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgHelpSummary:        {"show a command's documentation with examples and options", "查看命令的帮助文档（说明、示例与选项）"},
+		MsgHelpUsage:          {"usage: agent help [command]", "用法: agent help [命令]"},
+		MsgHelpUnknown:        {"no help for %q: not an agent command (see agent help)", "没有 %q 的帮助：不是 agent 的命令（见 agent help）"},
+		MsgHelpOptions:        {"Options", "选项"},
+		MsgHelpFlagsHeader:    {"| Option | Description | Default |", "| 选项 | 说明 | 默认值 |"},
+		MsgHelpCommandsHeader: {"| Command | Description |", "| 命令 | 说明 |"},
+	})
+}
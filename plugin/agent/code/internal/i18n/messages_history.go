@@ -0,0 +1,53 @@
+package i18n
+
+// history 子命令文案
+const (
+	MsgHistorySummary    = "history_summary"
+	MsgHistoryUsage      = "history_usage"
+	MsgHistoryEmpty      = "history_empty"
+	MsgHistoryNotFound   = "history_not_found"
+	MsgHistoryHeader     = "history_header"
+	MsgHistoryParent     = "history_parent"
+	MsgHistoryForkUsage  = "history_fork_usage"
+	MsgHistoryForkFrom   = "history_fork_from"
+	MsgHistoryForked     = "history_forked"
+	MsgHistoryTreeNone   = "history_tree_none"
+	MsgHistoryTreeHeader = "history_tree_header"
+	MsgHistoryTreeRegen  = "history_tree_regen"
+	MsgHistoryTitle      = "history_title"
+	MsgTitlesNoProvider  = "titles_no_provider"
+	MsgRegenUsage        = "regen_usage"
+	MsgRegenThinking     = "regen_thinking"
+	MsgRegenOld          = "regen_old"
+	MsgRegenNew          = "regen_new"
+	MsgRegenStats        = "regen_stats"
+	MsgRegenSame         = "regen_same"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgHistorySummary:   {"browse past ask exchanges", "浏览 ask 问答历史"},
+		MsgHistoryUsage:     {"usage: agent history list [-n N] | show <id> | pick [--copy | --ask [follow-up]] | regen <id> [--model M] [--provider P] | fork <id> [follow-up] | tree [id] [-n N]", "用法: agent history list [-n N] | show <id> | pick [--copy | --ask [追问]] | regen <id> [--model M] [--provider P] | fork <id> [追问] | tree [id] [-n N]"},
+		MsgHistoryEmpty:     {"no history yet", "暂无问答历史"},
+		MsgHistoryNotFound:  {"no exchange with id %s", "找不到 ID 为 %s 的问答"},
+		MsgRegenUsage:       {"usage: agent history regen <id> [--model M] [--provider P] [--preset name]", "用法: agent history regen <id> [--model M] [--provider P] [--preset 名称]"},
+		MsgRegenThinking:    {"asking %s again...", "正在重新询问 %s..."},
+		MsgRegenOld:         {"--- [%s] %s  %s", "--- [%s] %s  %s"},
+		MsgRegenNew:         {"+++ [%s] %s  %.1fs", "+++ [%s] %s  %.1fs"},
+		MsgRegenStats:       {"%d word(s) removed, %d added", "删除 %d 个词，新增 %d 个词"},
+		MsgRegenSame:        {"the answers are identical", "新旧回答完全相同"},
+		MsgHistoryHeader:    {"[%s] %s  %s (%s)  status: %s", "[%s] %s  %s（%s）  状态: %s"},
+		MsgHistoryParent:    {"continues %s", "接在 %s 之后"},
+		MsgHistoryForkUsage: {"usage: agent history fork <id> [ask options] [follow-up]", "用法: agent history fork <id> [ask 的选项] [追问]"},
+		MsgHistoryForkFrom: {
+			"branching from %s (%d earlier exchange(s) as context, %d existing branch(es)); the original conversation is unchanged",
+			"从 %s 分出新的分支（上文另有 %d 轮，已有 %d 个分支），原来的对话不受影响",
+		},
+		MsgHistoryForked:     {"new branch %[1]s; continue it with: agent ask --continue %[1]s", "新分支 %[1]s，继续这一分支: agent ask --continue %[1]s"},
+		MsgHistoryTreeNone:   {"no multi-turn conversations yet (continue one with agent ask --continue <id> or agent history fork <id>)", "暂无多轮对话（用 agent ask --continue <id> 或 agent history fork <id> 接着问）"},
+		MsgHistoryTreeHeader: {"conversation %s \"%s\": %d exchange(s), %d branch(es)", "对话 %s「%s」：%d 轮，%d 个分支"},
+		MsgHistoryTreeRegen:  {"(regenerated from %s)", "（由 %s 重新生成）"},
+		MsgHistoryTitle:      {"title: %s", "标题: %s"},
+		MsgTitlesNoProvider:  {"titles.provider %s not found, titling the conversation from the question", "找不到 titles.provider 指定的 %s，按问题生成对话标题"},
+	})
+}
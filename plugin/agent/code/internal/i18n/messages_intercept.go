@@ -0,0 +1,26 @@
+package i18n
+
+// 请求拦截器（重试、改用备选 provider 或 API Key、缓存、脱敏、工具调用）文案
+const (
+	MsgInterceptRetry      = "intercept_retry"
+	MsgInterceptRetrying   = "intercept_retrying"
+	MsgInterceptCacheHit   = "intercept_cache_hit"
+	MsgInterceptRedacted   = "intercept_redacted"
+	MsgInterceptToolCall   = "intercept_tool_call"
+	MsgInterceptBadPattern = "intercept_bad_pattern"
+	MsgInterceptFallback   = "intercept_fallback"
+	MsgInterceptKeyRotated = "intercept_key_rotated"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgInterceptRetry:      {"request failed (%v), retrying in %.0fs (attempt %d)", "请求失败（%v），%.0fs 后第 %d 次重试"},
+		MsgInterceptRetrying:   {"request failed, retrying in %.0fs (attempt %d)...", "请求失败，%.0fs 后第 %d 次重试..."},
+		MsgInterceptCacheHit:   {"answer served from the local cache", "回答取自本地缓存"},
+		MsgInterceptRedacted:   {"redacted %d likely secret(s) before sending (--no-redact sends them as is)", "发送前已隐去 %d 处疑似密钥（--no-redact 可原样发送）"},
+		MsgInterceptToolCall:   {"→ calling tool %s", "→ 调用工具 %s"},
+		MsgInterceptBadPattern: {"%s line %d: invalid pattern, skipped: %v", "%s 第 %d 行不是有效的正则表达式，已跳过：%v"},
+		MsgInterceptFallback:   {"request failed (%v), falling back to %s", "请求失败（%v），改用 %s"},
+		MsgInterceptKeyRotated: {"request failed (%v), retrying with API key #%d", "请求失败（%v），改用第 %d 个 API Key 重发"},
+	})
+}
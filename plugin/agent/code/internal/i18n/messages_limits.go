@@ -0,0 +1,20 @@
+package i18n
+
+// 输入大小与上下文 token 上限（--max-input、--max-context）文案
+const (
+	MsgLimitInputTruncated    = "limit_input_truncated"
+	MsgLimitBadSize           = "limit_bad_size"
+	MsgLimitContextTooLong    = "limit_context_too_long"
+	MsgLimitContextNearWindow = "limit_context_near_window"
+	MsgLimitContextOverWindow = "limit_context_over_window"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgLimitInputTruncated:    {"input truncated at %d bytes (use --max-input to raise)", "输入已在 %d 字节处截断（可用 --max-input 调高上限）"},
+		MsgLimitBadSize:           {"invalid size %q (examples: 65536, 512k, 4m, -1 for no limit)", "无效的大小 %q（示例：65536、512k、4m，-1 表示不限制）"},
+		MsgLimitContextTooLong:    {"context is about %d tokens, over the limit of %d: not sent (use --max-context to raise, or setting.agent_max_context_tokens in config.yaml)", "上下文约 %d token，超过上限 %d，未发送（可用 --max-context 或 config.yaml 中的 setting.agent_max_context_tokens 调高上限）"},
+		MsgLimitContextNearWindow: {"context is %d tokens, close to the %[3]d-token context window of %[2]s; the answer may be cut short", "上下文 %d token，接近 %s 的上下文窗口 %d token，回答可能被截短"},
+		MsgLimitContextOverWindow: {"context is %d tokens, over the %[3]d-token context window of %[2]s; the request will likely be rejected (shorten the input or pick a model with a larger window)", "上下文 %d token，超出 %s 的上下文窗口 %d token，请求多半会被拒绝（可缩短输入，或换用窗口更大的模型）"},
+	})
+}
@@ -0,0 +1,12 @@
+package i18n
+
+// output_redact 输出脱敏的文案
+const (
+	MsgMaskBadRule = "mask_bad_rule"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgMaskBadRule: {"ignoring invalid redaction rule %v", "忽略无法编译的脱敏规则 %v"},
+	})
+}
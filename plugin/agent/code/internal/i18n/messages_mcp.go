@@ -0,0 +1,38 @@
+package i18n
+
+// mcp 子命令与 MCP 工具文案
+const (
+	MsgMCPSummary      = "mcp_summary"
+	MsgMCPUsage        = "mcp_usage"
+	MsgMCPNone         = "mcp_none"
+	MsgMCPUnknown      = "mcp_unknown"
+	MsgMCPNoTransport  = "mcp_no_transport"
+	MsgMCPNoTool       = "mcp_no_tool"
+	MsgMCPConnecting   = "mcp_connecting"
+	MsgMCPConnected    = "mcp_connected"
+	MsgMCPServer       = "mcp_server"
+	MsgMCPSession      = "mcp_session"
+	MsgMCPServing      = "mcp_serving"
+	MsgMCPServeMissing = "mcp_serve_missing"
+	MsgMCPServeUnknown = "mcp_serve_unknown"
+	MsgMCPServeAction  = "mcp_serve_action"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgMCPSummary:      {"list the MCP servers and their tools, or serve j plugins as MCP tools", "列出 MCP 服务器及其提供的工具，或把 j 的插件作为 MCP 工具提供"},
+		MsgMCPUsage:        {"usage: agent mcp [list] [server...] | agent mcp serve [--tools render,snippets,notes,shell_explain]", "用法: agent mcp [list] [服务器...] | agent mcp serve [--tools render,snippets,notes,shell_explain]"},
+		MsgMCPNone:         {"no MCP servers configured (add them under mcp_servers in agent_config.json)", "没有配置 MCP 服务器（在 agent_config.json 的 mcp_servers 中添加）"},
+		MsgMCPUnknown:      {"unknown MCP server %s (configured: %s)", "未知的 MCP 服务器 %s（已配置: %s）"},
+		MsgMCPNoTransport:  {"MCP server %s needs a command or a url", "MCP 服务器 %s 需要配置 command 或 url"},
+		MsgMCPNoTool:       {"no tool named %s", "没有名为 %s 的工具"},
+		MsgMCPConnecting:   {"connecting to MCP servers...", "正在连接 MCP 服务器..."},
+		MsgMCPConnected:    {"tools from %s: %d", "已接入 %s 的工具：%d 个"},
+		MsgMCPServer:       {"%s (%d tools)", "%s（%d 个工具）"},
+		MsgMCPSession:      {"--session cannot be combined with MCP tools: requests with tools are sent directly, not through the daemon", "--session 不能与 MCP 工具同时使用：带工具的请求直接发送，不经守护进程"},
+		MsgMCPServing:      {"serving MCP tools over stdio: %s", "正在通过 stdio 提供 MCP 工具：%s"},
+		MsgMCPServeMissing: {"skipping tool %s: its plugin is not installed", "跳过工具 %s：对应的插件未安装"},
+		MsgMCPServeUnknown: {"unknown tool %s (available: %s)", "未知的工具 %s（可用: %s）"},
+		MsgMCPServeAction:  {"unknown action %q: use list, search, view or add", "未知的操作 %q：可用 list、search、view 或 add"},
+	})
+}
@@ -0,0 +1,26 @@
+package i18n
+
+// 数据格式迁移（agent migrate 与 internal/migrate）文案
+const (
+	MsgMigrateSummary  = "migrate_summary"
+	MsgMigrateUsage    = "migrate_usage"
+	MsgMigrateUpgraded = "migrate_upgraded"
+	MsgMigrateFailed   = "migrate_failed"
+	MsgMigrateVersion  = "migrate_version"
+	MsgMigrateBackups  = "migrate_backups"
+	MsgMigrateDone     = "migrate_done"
+	MsgMigratePending  = "migrate_pending"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgMigrateSummary:  {"show the data format version and upgrade old config / history layouts", "查看数据格式版本，升级旧版的配置与历史格式"},
+		MsgMigrateUsage:    {"usage: agent migrate [status | run]", "用法: agent migrate [status | run]"},
+		MsgMigrateUpgraded: {"upgraded agent data from format v%d to v%d (%s); backup: %s", "已将 agent 数据从格式 v%d 升级到 v%d（%s），备份: %s"},
+		MsgMigrateFailed:   {"could not upgrade agent data (%v); run agent migrate run to retry", "升级 agent 数据失败（%v），可运行 agent migrate run 重试"},
+		MsgMigrateVersion:  {"format version: v%d (this program: v%d)", "格式版本: v%d（当前程序: v%d）"},
+		MsgMigrateBackups:  {"backups: %s", "备份: %s"},
+		MsgMigrateDone:     {"done", "已完成"},
+		MsgMigratePending:  {"pending", "待执行"},
+	})
+}
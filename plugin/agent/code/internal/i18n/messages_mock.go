@@ -0,0 +1,19 @@
+package i18n
+
+// mock 子命令文案
+const (
+	MsgMockSummary   = "mock_summary"
+	MsgMockUsage     = "mock_usage"
+	MsgMockListening = "mock_listening"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgMockSummary: {"serve scripted responses for offline development", "启动回放预设回复的 mock 服务（离线开发 / 测试）"},
+		MsgMockUsage:   {"usage: agent mock serve [--listen addr] [--script file.json]", "用法: agent mock serve [--listen 地址] [--script file.json]"},
+		MsgMockListening: {
+			"mock provider listening on http://%s/v1 (point api_base here)",
+			"mock provider 已启动: http://%s/v1（将 api_base 指向该地址即可）",
+		},
+	})
+}
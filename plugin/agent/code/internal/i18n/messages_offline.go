@@ -0,0 +1,14 @@
+package i18n
+
+// 离线模式（--offline、setting.agent_offline）文案
+const (
+	MsgOfflineRemote   = "offline_remote"
+	MsgOfflineDetected = "offline_detected"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgOfflineRemote:   {"offline: no cached answer for this request, and provider %s is not local (use --provider with a local one such as Ollama)", "离线模式：这个请求没有缓存的回答，provider %s 也不在本地（可用 --provider 换成 Ollama 等本地 provider）"},
+		MsgOfflineDetected: {"no network connection: no cached answer for this request, and provider %s is not local (use a local provider such as Ollama, or set setting.agent_offline: off to skip the check)", "没有网络连接：这个请求没有缓存的回答，provider %s 也不在本地（可换成 Ollama 等本地 provider，或在 config.yaml 中设置 setting.agent_offline: off 跳过检测）"},
+	})
+}
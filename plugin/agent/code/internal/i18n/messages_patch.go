@@ -0,0 +1,18 @@
+package i18n
+
+// 应用 unified diff 的文案
+const (
+	MsgPatchBadHunk    = "patch_bad_hunk"
+	MsgPatchEmpty      = "patch_empty"
+	MsgPatchDelete     = "patch_delete"
+	MsgPatchHunkFailed = "patch_hunk_failed"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgPatchBadHunk:    {"%s: malformed hunk header %q", "%s：hunk 头格式错误 %q"},
+		MsgPatchEmpty:      {"the diff contains no changes", "diff 中没有改动"},
+		MsgPatchDelete:     {"%s: deleting files is not supported", "%s：不支持删除文件"},
+		MsgPatchHunkFailed: {"hunk #%d does not apply to %s (expected near line %d)", "第 %d 个 hunk 无法应用到 %s（预期在第 %d 行附近）"},
+	})
+}
@@ -0,0 +1,38 @@
+package i18n
+
+// 模糊选择（history pick、session pick）文案
+const (
+	MsgPickHistory     = "pick_history"
+	MsgPickSession     = "pick_session"
+	MsgPickNoTerminal  = "pick_no_terminal"
+	MsgPickFollowUp    = "pick_follow_up"
+	MsgPickCopied      = "pick_copied"
+	MsgPickNoClipboard = "pick_no_clipboard"
+	MsgPickListed      = "pick_listed"
+	MsgPickMore        = "pick_more"
+	MsgPickNumber      = "pick_number"
+	MsgSessionSummary  = "session_summary"
+	MsgSessionUsage    = "session_usage"
+	MsgSessionEmpty    = "session_empty"
+	MsgSessionMessages = "session_messages"
+	MsgSessionNoAnswer = "session_no_answer"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgPickHistory:     {"history", "问答历史"},
+		MsgPickSession:     {"session", "会话"},
+		MsgPickNoTerminal:  {"picking needs a terminal (or fzf)", "选择需要在终端中进行（或安装 fzf）"},
+		MsgPickFollowUp:    {"follow-up:", "追问:"},
+		MsgPickCopied:      {"copied to the clipboard", "已复制到剪贴板"},
+		MsgPickNoClipboard: {"no clipboard tool found (pbcopy, wl-copy, xclip, xsel or clip.exe)", "未找到剪贴板工具（pbcopy、wl-copy、xclip、xsel 或 clip.exe）"},
+		MsgPickListed:      {"%s, %d matches:", "%s，共 %d 项："},
+		MsgPickMore:        {"and %d more; type some text to narrow the list", "另有 %d 项，输入文字可缩小范围"},
+		MsgPickNumber:      {"number to pick, text to filter, empty line to cancel: ", "输入编号选中，输入文字筛选，空行取消: "},
+		MsgSessionSummary:  {"list or pick the daemon's conversation sessions", "列出或选择守护进程中的多轮会话"},
+		MsgSessionUsage:    {"usage: agent session list | pick [--copy | --ask [follow-up]]", "用法: agent session list | pick [--copy | --ask [追问]]"},
+		MsgSessionEmpty:    {"the daemon has no sessions yet (start one with agent ask --session name)", "守护进程中还没有会话（用 agent ask --session 名称 开始）"},
+		MsgSessionMessages: {"%d messages", "%d 条消息"},
+		MsgSessionNoAnswer: {"session %s has no answer yet", "会话 %s 中还没有回答"},
+	})
+}
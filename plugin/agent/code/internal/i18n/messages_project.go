@@ -0,0 +1,30 @@
+package i18n
+
+// 项目配置（.j.toml）文案
+const (
+	MsgProjectParse           = "project_parse"
+	MsgProjectWarning         = "project_warning"
+	MsgProjectUnknownKey      = "project_unknown_key"
+	MsgProjectBadType         = "project_bad_type"
+	MsgProjectBadMode         = "project_bad_mode"
+	MsgProjectBadGlob         = "project_bad_glob"
+	MsgProjectBadPattern      = "project_bad_pattern"
+	MsgProjectUnknownProvider = "project_unknown_provider"
+	MsgProjectNone            = "project_none"
+	MsgProjectHeader          = "project_header"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgProjectParse:           {"parse %s: %v", "解析 %s 失败：%v"},
+		MsgProjectWarning:         {"%s: warning: %s (ignored)", "%s: 警告: %s（已忽略）"},
+		MsgProjectUnknownKey:      {"unknown setting", "未知的配置项"},
+		MsgProjectBadType:         {"expected %s", "应为 %s"},
+		MsgProjectBadMode:         {"invalid mode %q (none, auto or full)", "无效的模式 %q（可选 none、auto、full）"},
+		MsgProjectBadGlob:         {"invalid pattern %q", "无效的匹配模式 %q"},
+		MsgProjectBadPattern:      {"invalid regular expression %q: %v", "无效的正则表达式 %q：%v"},
+		MsgProjectUnknownProvider: {"%s: provider %q is not in your agent config, using the active one", "%s: 个人配置中没有 provider %q，使用当前 provider"},
+		MsgProjectNone:            {"no %s found in this directory or its parents", "当前目录及其上级目录中没有 %s"},
+		MsgProjectHeader:          {"project config: %s", "项目配置：%s"},
+	})
+}
@@ -0,0 +1,14 @@
+package i18n
+
+// 终端中输入问题（行编辑器与 --editor）文案
+const (
+	MsgPromptHint  = "prompt_hint"
+	MsgPromptEmpty = "prompt_empty"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgPromptHint:  {"type the prompt: an empty line or Ctrl-D sends it, Ctrl-C cancels, ↑/↓ recall earlier prompts", "输入问题：空行或 Ctrl-D 发送，Ctrl-C 取消，↑/↓ 翻出以前的问题"},
+		MsgPromptEmpty: {"the prompt is empty, nothing was sent", "问题为空，未发送"},
+	})
+}
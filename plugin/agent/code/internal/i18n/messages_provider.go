@@ -0,0 +1,67 @@
+package i18n
+
+// provider 请求相关文案
+const (
+	MsgTimeoutConnect    = "timeout_connect"
+	MsgTimeoutFirstToken = "timeout_first_token"
+	MsgTimeoutTotal      = "timeout_total"
+
+	MsgSamplingTemperature = "sampling_temperature"
+	MsgSamplingTopP        = "sampling_top_p"
+	MsgSamplingSeed        = "sampling_seed"
+
+	MsgProviderUnknownType  = "provider_unknown_type"
+	MsgProviderNoBase       = "provider_no_base"
+	MsgProviderNoTools      = "provider_no_tools"
+	MsgProviderUnsupported  = "provider_unsupported"
+	MsgBedrockNoRegion      = "bedrock_no_region"
+	MsgBedrockNoCredentials = "bedrock_no_credentials"
+	MsgVertexNoProject      = "vertex_no_project"
+	MsgVertexToken          = "vertex_token"
+	MsgFallbackSkipped      = "fallback_skipped"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgTimeoutConnect: {
+			"connect timeout: no connection within %v (timeouts.connect_secs)",
+			"连接超时：%v 内未能建立连接（timeouts.connect_secs）",
+		},
+		MsgTimeoutFirstToken: {
+			"first-token timeout: model sent nothing within %v (timeouts.first_token_secs)",
+			"首字超时：%v 内未收到任何回复（timeouts.first_token_secs）",
+		},
+		MsgTimeoutTotal: {
+			"response timeout: answer not finished within %v (timeouts.total_secs)",
+			"回复超时：%v 内未完成回复（timeouts.total_secs）",
+		},
+		MsgSamplingTemperature: {
+			"temperature %[1]g is out of range: %[3]s accepts 0 to %[2]g (set max_temperature to override)",
+			"temperature %[1]g 超出范围：%[3]s 仅支持 0 到 %[2]g（可配置 max_temperature 覆盖）",
+		},
+		MsgSamplingTopP: {"top_p %g is out of range: expected (0, 1]", "top_p %g 超出范围：应在 (0, 1] 之间"},
+		MsgSamplingSeed: {"seed %d must not be negative", "seed %d 不能为负数"},
+
+		MsgProviderUnknownType: {"provider %s: unknown type %q (openai, azure, bedrock, vertex or openrouter)", "provider %s: 未知的类型 %q（可选 openai、azure、bedrock、vertex、openrouter）"},
+		MsgProviderNoBase:      {"provider %s: type %s needs api_base", "provider %s: 类型 %s 需要配置 api_base"},
+		MsgProviderNoTools: {
+			"provider %s: MCP tools are only available with OpenAI-compatible providers (type %s)",
+			"provider %s: MCP 工具只能用于 OpenAI 兼容的 provider（当前类型 %s）",
+		},
+		MsgProviderUnsupported: {"provider %s: %s is not available for type %s", "provider %s: 类型 %[3]s 不支持 %[2]s"},
+		MsgBedrockNoRegion: {
+			"provider %s: no AWS region (set region, AWS_REGION or api_base)",
+			"provider %s: 未指定 AWS 区域（配置 region、AWS_REGION 或 api_base）",
+		},
+		MsgBedrockNoCredentials: {
+			"provider %s: no AWS credentials (set AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY, add profile %s to ~/.aws/credentials, or store a Bedrock API key)",
+			"provider %s: 未找到 AWS 凭据（设置 AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY，在 ~/.aws/credentials 中添加 profile %s，或保存 Bedrock API Key）",
+		},
+		MsgVertexNoProject: {
+			"provider %s: no GCP project (set project or GOOGLE_CLOUD_PROJECT)",
+			"provider %s: 未指定 GCP 项目（配置 project 或 GOOGLE_CLOUD_PROJECT）",
+		},
+		MsgVertexToken:     {"Vertex AI credentials: %v", "Vertex AI 凭据: %v"},
+		MsgFallbackSkipped: {"fallback provider %s skipped: %v", "已跳过备选 provider %s: %v"},
+	})
+}
@@ -0,0 +1,20 @@
+package i18n
+
+// 中断回答的恢复与续写（agent ask --resume）文案
+const (
+	MsgResumeRecovered = "resume_recovered"
+	MsgResumeNothing   = "resume_nothing"
+	MsgResumeComplete  = "resume_complete"
+	MsgResumeConflict  = "resume_conflict"
+	MsgResumeFrom      = "resume_from"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgResumeRecovered: {"an answer was interrupted before it finished (%s, %d characters saved): continue it with agent ask --resume", "有一轮回答在完成前中断（%s，已保存 %d 字），可用 agent ask --resume 继续"},
+		MsgResumeNothing:   {"no unfinished answer to resume", "没有可以续写的未完成回答"},
+		MsgResumeComplete:  {"exchange %s finished normally, nothing to resume", "问答 %s 已正常完成，无需续写"},
+		MsgResumeConflict:  {"--resume takes no prompt and cannot be combined with --batch, --compare, --tests, --schema, --session, --continue, --audio, --mic, --editor, --template or --image", "--resume 不接受问题，也不能与 --batch、--compare、--tests、--schema、--session、--continue、--audio、--mic、--editor、--template 或 --image 同时使用"},
+		MsgResumeFrom:      {"resuming %s from where it stopped (%d characters so far)", "从 %s 中断处续写（已有 %d 字）"},
+	})
+}
@@ -0,0 +1,32 @@
+package i18n
+
+// serve 子命令（HTTP API）文案
+const (
+	MsgServeSummary      = "serve_summary"
+	MsgServeListening    = "serve_listening"
+	MsgServeToken        = "serve_token"
+	MsgServeExposed      = "serve_exposed"
+	MsgServeStopped      = "serve_stopped"
+	MsgServeUnauthorized = "serve_unauthorized"
+	MsgServeBadRequest   = "serve_bad_request"
+	MsgServeRenderArg    = "serve_render_arg"
+	MsgServeNoSession    = "serve_no_session"
+	MsgServeWebUI        = "serve_web_ui"
+	MsgServeMetrics      = "serve_metrics"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgServeSummary:      {"serve ask, render, history and sessions over a local HTTP API", "以本地 HTTP API 提供 ask、render、history 与会话"},
+		MsgServeListening:    {"serving the API on http://%s, Ctrl-C to stop", "HTTP API 已在 http://%s 上提供服务，Ctrl-C 退出"},
+		MsgServeToken:        {"clients must send Authorization: Bearer <token> (token from %s)", "客户端须带 Authorization: Bearer <令牌>（令牌来自 %s）"},
+		MsgServeExposed:      {"warning: listening beyond localhost over plain HTTP; use it on trusted networks only", "警告：以明文 HTTP 监听本机以外的地址，请只在可信网络中使用"},
+		MsgServeStopped:      {"API server stopped", "HTTP API 已停止"},
+		MsgServeUnauthorized: {"missing or invalid bearer token", "缺少令牌或令牌不正确"},
+		MsgServeBadRequest:   {"invalid request: %v", "请求无效: %v"},
+		MsgServeRenderArg:    {"%s is not available through the API", "API 不支持 %s"},
+		MsgServeNoSession:    {"no session named %s", "没有名为 %s 的会话"},
+		MsgServeWebUI:        {"web UI: %s", "网页界面: %s"},
+		MsgServeMetrics:      {"Prometheus metrics: %s (bearer token required)", "Prometheus 指标: %s（同样需要令牌）"},
+	})
+}
@@ -0,0 +1,76 @@
+package i18n
+
+// setup 配置向导文案
+const (
+	MsgSetupSummary        = "setup_summary"
+	MsgSetupUsage          = "setup_usage"
+	MsgSetupNoTerminal     = "setup_no_terminal"
+	MsgSetupFirstRun       = "setup_first_run"
+	MsgSetupSkipped        = "setup_skipped"
+	MsgSetupCancelled      = "setup_cancelled"
+	MsgSetupTerminal       = "setup_terminal"
+	MsgSetupUnicode        = "setup_unicode"
+	MsgSetupNoUnicode      = "setup_no_unicode"
+	MsgSetupColorHint      = "setup_color_hint"
+	MsgSetupStepProvider   = "setup_step_provider"
+	MsgSetupProviderPrompt = "setup_provider_prompt"
+	MsgSetupCustom         = "setup_custom"
+	MsgSetupAskName        = "setup_ask_name"
+	MsgSetupAskBase        = "setup_ask_base"
+	MsgSetupAskModel       = "setup_ask_model"
+	MsgSetupRequired       = "setup_required"
+	MsgSetupKeepKey        = "setup_keep_key"
+	MsgSetupLocal          = "setup_local"
+	MsgSetupKeyringFailed  = "setup_keyring_failed"
+	MsgSetupStepTheme      = "setup_step_theme"
+	MsgSetupThemePrompt    = "setup_theme_prompt"
+	MsgSetupThemeCurrent   = "setup_theme_current"
+	MsgSetupUseTheme       = "setup_use_theme"
+	MsgSetupNoRenderer     = "setup_no_renderer"
+	MsgSetupStepSave       = "setup_step_save"
+	MsgSetupSavedAgent     = "setup_saved_agent"
+	MsgSetupSavedTheme     = "setup_saved_theme"
+	MsgSetupThemeComment   = "setup_theme_comment"
+	MsgSetupDone           = "setup_done"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgSetupSummary:    {"set up a provider, its API key and the theme step by step", "配置向导：逐步选择 provider、保存 API Key 并挑选主题"},
+		MsgSetupUsage:      {"usage: agent setup", "用法: agent setup"},
+		MsgSetupNoTerminal: {"agent setup needs an interactive terminal (without one, edit agent_config.json with agent config and store keys with agent auth login)", "agent setup 需要交互式终端（没有终端时请用 agent config 修改 agent_config.json，用 agent auth login 保存 Key）"},
+		MsgSetupFirstRun:   {"no agent configuration yet (%s): starting the setup wizard, cancel any step to skip it", "还没有 agent 配置（%s），启动配置向导；在任一步取消即可跳过"},
+		MsgSetupSkipped:    {"setup skipped: %v (run agent setup at any time)", "已跳过配置向导：%v（随时可以运行 agent setup）"},
+		MsgSetupCancelled:  {"cancelled", "已取消"},
+		MsgSetupTerminal:   {"Terminal: %d×%d, %s colors, %s", "终端：%d×%d，%s 色，%s"},
+		MsgSetupUnicode:    {"UTF-8", "UTF-8"},
+		MsgSetupNoUnicode:  {"no UTF-8 locale (borders and emoji may look wrong)", "未使用 UTF-8 locale（边框与 emoji 可能显示不正常）"},
+		MsgSetupColorHint: {
+			"only %s colors detected: themes are shown with the closest colors (set %s=truecolor if your terminal supports more)",
+			"只检测到 %s 色：主题中的颜色会换成最接近的颜色（终端实际支持真彩色时可设置 %s=truecolor）",
+		},
+		MsgSetupStepProvider:   {"Step 1/3: provider", "第 1/3 步：provider"},
+		MsgSetupProviderPrompt: {"provider", "provider"},
+		MsgSetupCustom:         {"Other OpenAI-compatible service", "其他 OpenAI 兼容服务"},
+		MsgSetupAskName:        {"Name", "名称"},
+		MsgSetupAskBase:        {"API base URL", "API Base URL"},
+		MsgSetupAskModel:       {"Model", "模型"},
+		MsgSetupRequired:       {"a value is required", "不能为空"},
+		MsgSetupKeepKey:        {"%s already has an API key in the keyring, keep it? [Y/n] ", "%s 已在系统钥匙串中保存了 API Key，继续使用吗？[Y/n] "},
+		MsgSetupLocal:          {"%s runs locally: no API key needed", "%s 在本机运行，不需要 API Key"},
+		MsgSetupKeyringFailed:  {"cannot store the key in the system keyring: %v (set %s instead)", "无法写入系统钥匙串：%v（可改为设置环境变量 %s）"},
+		MsgSetupStepTheme:      {"Step 2/3: theme", "第 2/3 步：主题"},
+		MsgSetupThemePrompt:    {"theme", "主题"},
+		MsgSetupThemeCurrent:   {" (current)", "（当前）"},
+		MsgSetupUseTheme:       {"use the %s theme? [Y/n] ", "使用 %s 主题吗？[Y/n] "},
+		MsgSetupNoRenderer:     {"md_render not found: skipping the theme (set setting.md_theme in config.yaml later)", "没有找到 md_render，跳过主题（之后可在 config.yaml 中设置 setting.md_theme）"},
+		MsgSetupStepSave:       {"Step 3/3: save", "第 3/3 步：保存"},
+		MsgSetupSavedAgent:     {"provider %s (%s) saved as the default in %s", "provider %s（%s）已设为默认并保存到 %s"},
+		MsgSetupSavedTheme:     {"theme %s saved in %s", "主题 %s 已保存到 %s"},
+		MsgSetupThemeComment: {
+			"# Markdown theme for md_render and agent output (chosen in agent setup): %s",
+			"# md_render 与 agent 输出的 Markdown 主题（由 agent setup 选择），可选：%s",
+		},
+		MsgSetupDone: {"all set, try: agent ask \"hello\"", "配置完成，试试：agent ask \"你好\""},
+	})
+}
@@ -0,0 +1,30 @@
+package i18n
+
+// do / explain / widget / not-found 子命令文案
+const (
+	MsgDoSummary         = "do_summary"
+	MsgExplainSummary    = "explain_summary"
+	MsgWidgetSummary     = "widget_summary"
+	MsgWidgetUsage       = "widget_usage"
+	MsgWidgetUnsupported = "widget_unsupported"
+	MsgShellAsking       = "shell_asking"
+	MsgShellExplaining   = "shell_explaining"
+	MsgNotFoundSummary   = "not_found_summary"
+	MsgNotFoundAsking    = "not_found_asking"
+	MsgNotFoundConfirm   = "not_found_confirm"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgDoSummary:         {"turn a description into a single shell command printed on stdout", "把描述转成一条 shell 命令并输出到 stdout"},
+		MsgExplainSummary:    {"explain a shell command part by part, with its risks", "逐段解释 shell 命令及其风险"},
+		MsgWidgetSummary:     {"print the zsh/bash Ctrl-G widget for inline suggestions", "输出 zsh/bash 的 Ctrl-G 行内建议小部件"},
+		MsgWidgetUsage:       {"usage: agent widget [zsh|bash] [--key binding] [--not-found [--no-ai]]", "用法: agent widget [zsh|bash] [--key 快捷键] [--not-found [--no-ai]]"},
+		MsgWidgetUnsupported: {"no widget for shell %s (supported: %s)", "不支持 %s 的小部件（支持: %s）"},
+		MsgShellAsking:       {"generating a command...", "正在生成命令..."},
+		MsgShellExplaining:   {"explaining...", "正在解释..."},
+		MsgNotFoundSummary:   {"suggest the intended command or its package (used by the command-not-found hook)", "给出本意的命令或提供它的软件包（供 command-not-found 钩子调用）"},
+		MsgNotFoundAsking:    {"%s: command not found, asking for a suggestion...", "%s: 找不到命令，正在查找建议..."},
+		MsgNotFoundConfirm:   {"did you mean: %s  [Enter run / other key skip] ", "是否要运行: %s  [回车执行 / 其他键跳过] "},
+	})
+}
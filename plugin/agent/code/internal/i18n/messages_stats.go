@@ -0,0 +1,44 @@
+package i18n
+
+// stats 子命令文案
+const (
+	MsgStatsSummary  = "stats_summary"
+	MsgStatsUsage    = "stats_usage"
+	MsgStatsCleared  = "stats_cleared"
+	MsgStatsDisabled = "stats_disabled"
+	MsgStatsEmpty    = "stats_empty"
+	MsgStatsSince    = "stats_since"
+	MsgStatsHeader   = "stats_header" // 列名以 | 分隔
+	MsgStatsRender   = "stats_render"
+	MsgStatsUsageHdr = "stats_usage_header" // 列名以 | 分隔
+	// 守护进程的运行时统计
+	MsgStatsMetricsSince = "stats_metrics_since"
+	MsgStatsMetricsEmpty = "stats_metrics_empty"
+	MsgStatsMetricsHdr   = "stats_metrics_header" // 列名以 | 分隔
+)
+
+func init() {
+	register(map[string]entry{
+		MsgStatsSummary: {"show opt-in local usage statistics", "查看本地使用统计（需手动开启）"},
+		MsgStatsUsage:   {"usage: agent stats [reset | metrics [--json]]", "用法: agent stats [reset | metrics [--json]]"},
+		MsgStatsCleared: {"usage statistics cleared", "使用统计已清空"},
+		MsgStatsDisabled: {
+			"statistics are off (set %s=1 or setting.%s: true in config.yaml to opt in; data never leaves this machine)",
+			"统计未开启（设置 %s=1 或在 config.yaml 中配置 setting.%s: true 开启；数据只保存在本机）",
+		},
+		MsgStatsEmpty:  {"no statistics recorded yet", "暂无统计数据"},
+		MsgStatsSince:  {"since %s (%s)", "统计起始于 %s（%s）"},
+		MsgStatsHeader: {"command|count|avg ms|max ms", "命令|次数|平均耗时 ms|最大耗时 ms"},
+		MsgStatsRender: {"renders: %d, avg %d bytes, max %d bytes", "渲染: %d 次，平均 %d 字节，最大 %d 字节"},
+		MsgStatsUsageHdr: {
+			"provider|requests|input tokens|output tokens|cost",
+			"provider|请求数|输入 token|输出 token|费用",
+		},
+		MsgStatsMetricsSince: {"runtime metrics of the daemon (since %s):", "守护进程的运行时统计（启动于 %s）："},
+		MsgStatsMetricsEmpty: {"no requests through the daemon yet", "守护进程尚未处理过模型请求"},
+		MsgStatsMetricsHdr: {
+			"provider|requests|errors|error %|p50 ms|p90 ms|p99 ms|input tokens|output tokens",
+			"provider|请求数|错误数|错误率|p50 ms|p90 ms|p99 ms|输入 token|输出 token",
+		},
+	})
+}
@@ -0,0 +1,30 @@
+package i18n
+
+// 语音转文字相关文案
+const (
+	MsgSTTRecording      = "stt_recording"
+	MsgSTTTranscribing   = "stt_transcribing"
+	MsgSTTTranscript     = "stt_transcript"
+	MsgSTTEmpty          = "stt_empty"
+	MsgSTTAudioAndMic    = "stt_audio_and_mic"
+	MsgSTTUnknownBackend = "stt_unknown_backend"
+	MsgSTTNoModel        = "stt_no_model"
+	MsgSTTNoTool         = "stt_no_tool"
+	MsgSTTNoRecorder     = "stt_no_recorder"
+	MsgSTTToolFailed     = "stt_tool_failed"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgSTTRecording:      {"🔴 recording... press Enter to stop", "🔴 录音中... 按回车结束"},
+		MsgSTTTranscribing:   {"transcribing...", "转写中..."},
+		MsgSTTTranscript:     {"🎙 %s", "🎙 %s"},
+		MsgSTTEmpty:          {"no speech recognized in the audio", "音频中未识别到语音"},
+		MsgSTTAudioAndMic:    {"--audio and --mic cannot be used together", "--audio 与 --mic 不能同时使用"},
+		MsgSTTUnknownBackend: {"unknown stt backend %q (expected openai or whisper_cpp)", "未知的 stt backend %q（可选 openai、whisper_cpp）"},
+		MsgSTTNoModel:        {"whisper.cpp model not found: %s (download it with j voice download, or set stt.model_path)", "未找到 whisper.cpp 模型: %s（可通过 j voice download 下载，或配置 stt.model_path）"},
+		MsgSTTNoTool:         {"%s not found in PATH: %s", "PATH 中未找到 %s: %s"},
+		MsgSTTNoRecorder:     {"no recorder found: install sox (rec), arecord or ffmpeg", "未找到录音工具：请安装 sox（rec）、arecord 或 ffmpeg"},
+		MsgSTTToolFailed:     {"%s failed: %v\n%s", "%s 执行失败: %v\n%s"},
+	})
+}
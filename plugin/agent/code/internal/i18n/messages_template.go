@@ -0,0 +1,38 @@
+package i18n
+
+// 提示词模板（agent template、ask --template）与 --var 文案
+const (
+	MsgTemplateSummary     = "template_summary"
+	MsgTemplateUsage       = "template_usage"
+	MsgTemplateNotFound    = "template_not_found"
+	MsgTemplateBadSyntax   = "template_bad_syntax"
+	MsgTemplateMissingVars = "template_missing_vars"
+	MsgTemplateExecFailed  = "template_exec_failed"
+	MsgTemplateNone        = "template_none"
+	MsgTemplateInvalid     = "template_invalid"
+	MsgTemplateNoVars      = "template_no_vars"
+	MsgTemplateVars        = "template_vars"
+	MsgTemplateConflict    = "template_conflict"
+	MsgVarBad              = "var_bad"
+	MsgVarStdinTwice       = "var_stdin_twice"
+	MsgVarNoClipboard      = "var_no_clipboard"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgTemplateSummary:     {"list and show prompt templates ({{.Var}} placeholders filled with ask --template name --var k=v)", "列出与查看提示词模板（{{.Var}} 占位符由 ask --template 名称 --var k=v 填入）"},
+		MsgTemplateUsage:       {"usage: agent template list | show <name>", "用法: agent template list | show <名称>"},
+		MsgTemplateNotFound:    {"template %q not found (neither a file nor in %s)", "找不到模板 %q（既不是文件，也不在 %s 中）"},
+		MsgTemplateBadSyntax:   {"template %s: %v", "模板 %s: %v"},
+		MsgTemplateMissingVars: {"template %s needs --var for: %s", "模板 %s 缺少变量，请用 --var 传入: %s"},
+		MsgTemplateExecFailed:  {"template %s: %v", "模板 %s 渲染失败: %v"},
+		MsgTemplateNone:        {"no templates yet: put <name>.md files in %s", "还没有模板：在 %s 中放入 <名称>.md 文件"},
+		MsgTemplateInvalid:     {"(cannot be parsed)", "（无法解析）"},
+		MsgTemplateNoVars:      {"(no variables)", "（没有变量）"},
+		MsgTemplateVars:        {"variables: %s", "变量: %s"},
+		MsgTemplateConflict:    {"--template builds the prompt itself and cannot be combined with --batch, --tests, --audio, --mic or --editor", "--template 自行生成问题，不能与 --batch、--tests、--audio、--mic 或 --editor 同时使用"},
+		MsgVarBad:              {"--var expects key=value, got %q", "--var 格式应为 key=value，实际为 %q"},
+		MsgVarStdinTwice:       {"--var %s=@-: stdin is already used by another variable", "--var %s=@-: 标准输入已被其他变量使用"},
+		MsgVarNoClipboard:      {"no clipboard tool found (pbpaste, wl-paste, xclip, xsel or powershell)", "未找到剪贴板工具（pbpaste、wl-paste、xclip、xsel 或 powershell）"},
+	})
+}
@@ -0,0 +1,22 @@
+package i18n
+
+// tmux 弹窗（agent tmux-popup）文案
+const (
+	MsgTmuxSummary   = "tmux_summary"
+	MsgTmuxNotInside = "tmux_not_inside"
+	MsgTmuxNoDaemon  = "tmux_no_daemon"
+	MsgTmuxChatHint  = "tmux_chat_hint"
+	MsgTmuxPrompt    = "tmux_prompt"
+	MsgTmuxClose     = "tmux_close"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgTmuxSummary:   {"open a quick ask or chat in a tmux popup, sharing the daemon's sessions", "在 tmux 弹窗中快速提问或对话，与守护进程共享会话"},
+		MsgTmuxNotInside: {"not running inside tmux (TMUX is not set)", "不在 tmux 中运行（未设置 TMUX）"},
+		MsgTmuxNoDaemon:  {"the daemon is not running, so this conversation is not kept in a session (start it with agent daemon)", "守护进程未运行，本次对话不会保存到会话中（用 agent daemon 启动）"},
+		MsgTmuxChatHint:  {"an empty line or Ctrl-D closes the popup", "输入空行或 Ctrl-D 关闭弹窗"},
+		MsgTmuxPrompt:    {"ask> ", "提问> "},
+		MsgTmuxClose:     {"press any key to close", "按任意键关闭"},
+	})
+}
@@ -0,0 +1,24 @@
+package i18n
+
+// 本地 token 计数（agent tokens）文案
+const (
+	MsgTokensSummary     = "tokens_summary"
+	MsgTokensCounted     = "tokens_counted"
+	MsgTokensEstimated   = "tokens_estimated"
+	MsgTokensWindow      = "tokens_window"
+	MsgTokensUnknown     = "tokens_unknown"
+	MsgTokensDownloading = "tokens_downloading"
+	MsgTokensDownloaded  = "tokens_downloaded"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgTokensSummary:     {"count the tokens of a prompt locally (download the tokenizer files with agent tokens download)", "在本地统计提示词的 token 数（用 agent tokens download 下载编码文件）"},
+		MsgTokensCounted:     {"tokens for %s, counted with %s", "%s 的 token 数，按 %s 编码统计"},
+		MsgTokensEstimated:   {"tokens for %s, estimated from characters (run agent tokens download to count with a local tokenizer)", "%s 的 token 数，按字符估算（执行 agent tokens download 后改用本地编码统计）"},
+		MsgTokensWindow:      {"%.1f%% of the %d-token context window", "占上下文窗口 %[2]d token 的 %.1[1]f%%"},
+		MsgTokensUnknown:     {"unknown encoding %q (supported: cl100k_base, o200k_base)", "未知的编码 %q（支持 cl100k_base、o200k_base）"},
+		MsgTokensDownloading: {"downloading %s from %s...", "正在下载 %s: %s..."},
+		MsgTokensDownloaded:  {"saved %s (%.1f MiB)", "已保存 %s（%.1f MiB）"},
+	})
+}
@@ -0,0 +1,24 @@
+package i18n
+
+// trace 子命令文案
+const (
+	MsgTraceSummary  = "trace_summary"
+	MsgTraceUsage    = "trace_usage"
+	MsgTraceEmpty    = "trace_empty"
+	MsgTraceNotFound = "trace_not_found"
+	MsgTraceHeader   = "trace_header"
+	MsgTraceFailed   = "trace_failed"
+	MsgTraceExport   = "trace_export"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgTraceSummary:  {"show traces recorded with J_TRACE (latency of dispatch, plugins, providers, rendering)", "查看 J_TRACE 记录的链路（命令分发、插件、provider 与渲染的耗时）"},
+		MsgTraceUsage:    {"usage: agent trace [show [id]] | list [-n N]  [--file path]", "用法: agent trace [show [id]] | list [-n N]  [--file 路径]"},
+		MsgTraceEmpty:    {"no traces in %s (enable tracing with J_TRACE=1 or setting.trace in config.yaml)", "%s 中没有链路（用 J_TRACE=1 或 config.yaml 的 setting.trace 开启追踪）"},
+		MsgTraceNotFound: {"no trace with id %s", "找不到 ID 为 %s 的链路"},
+		MsgTraceHeader:   {"trace %s  %s  %s  %d spans", "链路 %s  %s  %s  %d 个 span"},
+		MsgTraceFailed:   {"%d failed", "%d 个失败"},
+		MsgTraceExport:   {"could not export traces: %v", "导出链路失败: %v"},
+	})
+}
@@ -0,0 +1,20 @@
+package i18n
+
+// 朗读相关文案
+const (
+	MsgTTSSpeaking       = "tts_speaking"
+	MsgTTSFailed         = "tts_failed"
+	MsgTTSUnknownBackend = "tts_unknown_backend"
+	MsgTTSNoSystem       = "tts_no_system"
+	MsgTTSNoPlayer       = "tts_no_player"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgTTSSpeaking:       {"🔊 speaking... (Ctrl-C to stop)", "🔊 朗读中...（Ctrl-C 停止）"},
+		MsgTTSFailed:         {"could not speak the answer: %v", "朗读失败: %v"},
+		MsgTTSUnknownBackend: {"unknown tts backend %q (expected system or openai)", "未知的 tts backend %q（可选 system、openai）"},
+		MsgTTSNoSystem:       {"no system TTS found: install espeak-ng, or set \"tts\": {\"backend\": \"openai\"}", "未找到系统朗读工具：请安装 espeak-ng，或配置 \"tts\": {\"backend\": \"openai\"}"},
+		MsgTTSNoPlayer:       {"no audio player found: install mpv, ffplay or mpg123, or set tts.player", "未找到音频播放器：请安装 mpv、ffplay 或 mpg123，或配置 tts.player"},
+	})
+}
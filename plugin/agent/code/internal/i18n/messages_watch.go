@@ -0,0 +1,24 @@
+package i18n
+
+// 监视文件重新提问（agent watch）文案
+const (
+	MsgWatchSummary = "watch_summary"
+	MsgWatchUsage   = "watch_usage"
+	MsgWatchNoFiles = "watch_no_files"
+	MsgWatchFirst   = "watch_first"
+	MsgWatchChanged = "watch_changed"
+	MsgWatchSame    = "watch_same"
+	MsgWatchWaiting = "watch_waiting"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgWatchSummary: {"re-ask a prompt about files whenever they change and diff the answers", "文件变化时带着文件重新提问，并对比前后回答"},
+		MsgWatchUsage:   {"usage: agent watch -f <file|dir|glob>... [--provider P] [--debounce 500ms] [--clear] <prompt>", "用法: agent watch -f <文件|目录|glob>... [--provider P] [--debounce 500ms] [--clear] <问题>"},
+		MsgWatchNoFiles: {"no files match %s", "没有匹配 %s 的文件"},
+		MsgWatchFirst:   {"[%s] asking %s about %d file(s)", "[%s] 向 %s 提问（%d 个文件）"},
+		MsgWatchChanged: {"[%s] changed: %s", "[%s] 已变化: %s"},
+		MsgWatchSame:    {"(answer unchanged)", "（回答没有变化）"},
+		MsgWatchWaiting: {"watching %d file(s) for changes, Ctrl-C to stop", "正在监视 %d 个文件，Ctrl-C 结束"},
+	})
+}
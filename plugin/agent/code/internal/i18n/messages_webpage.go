@@ -0,0 +1,18 @@
+package i18n
+
+// --url 网页上下文文案
+const (
+	MsgURLFetching  = "url_fetching"
+	MsgURLIncluded  = "url_included"
+	MsgURLTruncated = "url_truncated"
+	MsgURLConflict  = "url_conflict"
+)
+
+func init() {
+	register(map[string]entry{
+		MsgURLFetching:  {"fetching %s", "正在抓取 %s"},
+		MsgURLIncluded:  {"web page: %s, ~%d tokens", "网页：%s，约 %d token"},
+		MsgURLTruncated: {"web page: %s, truncated to ~%d tokens (raise --url-budget to include more)", "网页：%s，已截断到约 %d token（调大 --url-budget 可附上更多）"},
+		MsgURLConflict:  {"--url cannot be combined with --batch, --tests or --resume", "--url 不能与 --batch、--tests 或 --resume 同时使用"},
+	})
+}
@@ -0,0 +1,70 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wcp_agent/internal/budget"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+// BudgetError 本月的估算费用已达到预算；请求未发送
+type BudgetError struct {
+	Limit budget.Limit
+	Month string
+}
+
+func (e *BudgetError) Error() string {
+	l := e.Limit
+	if l.Provider == "" {
+		return i18n.T(i18n.MsgBudgetTotalExceeded, e.Month, l.Spent, l.Limit)
+	}
+	return i18n.T(i18n.MsgBudgetProviderExceeded, l.Provider, e.Month, l.Spent, l.Limit)
+}
+
+// Budget 发送前检查本月的估算费用：达到预算时返回 BudgetError（override 为 true 时照常发送并提醒），
+// 达到提醒比例时报告 EventBudgetWarning；请求完成后把这次的估算费用记入月度账本。
+// 未配置 pricing 的 provider（如本地模型）不产生费用，返回 nil
+func Budget(p config.Provider, override bool) provider.Middleware {
+	if p.Pricing == nil {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			month := budget.Month(time.Now())
+			ledger, err := budget.Load()
+			if err != nil {
+				ledger = budget.Ledger{}
+			}
+			ratio := budget.WarnRatio()
+			for _, l := range budget.Limits(p, ledger, month) {
+				switch {
+				case l.Exceeded() && !override:
+					return "", &BudgetError{Limit: l, Month: month}
+				case l.Exceeded():
+					Notify(ctx, Event{Kind: EventBudgetWarning, Err: errors.New(budgetNote(i18n.MsgBudgetOverridden, l))})
+				case l.Near(ratio):
+					Notify(ctx, Event{Kind: EventBudgetWarning, Err: errors.New(budgetNote(i18n.MsgBudgetNear, l))})
+				}
+			}
+			answer, err := next.Chat(ctx, messages, onDelta)
+			input, output := promptTokens(ctx, messages), countTokens(ctx, answer)
+			if output > 0 || err == nil {
+				budget.Add(p.Name, p.Pricing.Cost(input, output))
+			}
+			return answer, err
+		})
+	}
+}
+
+// budgetNote 提醒文案：预算的名称、已用额、预算与百分比
+func budgetNote(msg string, l budget.Limit) string {
+	scope := l.Provider
+	if scope == "" {
+		scope = i18n.T(i18n.MsgBudgetTotal)
+	}
+	return i18n.T(msg, scope, l.Spent, l.Limit, l.Spent/l.Limit*100)
+}
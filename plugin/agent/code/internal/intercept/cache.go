@@ -0,0 +1,135 @@
+package intercept
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/provider"
+)
+
+const (
+	// SettingCache config.yaml 中 setting 段的回答缓存：on 表示缓存 DefaultCacheTTL，
+	// 也可以写有效期（如 1h、30m），默认关闭
+	SettingCache = "agent_cache"
+	// DefaultCacheTTL 开启缓存时默认的有效期
+	DefaultCacheTTL = 24 * time.Hour
+)
+
+// cacheEntry 缓存文件内容
+type cacheEntry struct {
+	Time   time.Time `json:"time"`
+	Answer string    `json:"answer"`
+}
+
+// CacheDir 回答缓存目录: ~/.jdata/agent/data/cache/
+func CacheDir() string {
+	return filepath.Join(config.AgentDataDir(), "cache")
+}
+
+// cacheTTL 解析缓存有效期，0 表示关闭
+func cacheTTL() time.Duration {
+	v := strings.TrimSpace(config.Setting(SettingCache))
+	if d, err := time.ParseDuration(v); err == nil && d > 0 {
+		return d
+	}
+	if enabled(v, false) {
+		return DefaultCacheTTL
+	}
+	return 0
+}
+
+// cacheKeyPrefix 影响回答的请求参数，与消息一起决定缓存键
+func cacheKeyPrefix(p config.Provider, opts provider.Options) []byte {
+	data, _ := json.Marshal(struct {
+		Name      string               `json:"name"`
+		APIBase   string               `json:"api_base"`
+		Model     string               `json:"model"`
+		Sampling  config.Sampling      `json:"sampling"`
+		MaxTokens int                  `json:"max_tokens"`
+		Stop      []string             `json:"stop"`
+		Schema    *provider.JSONSchema `json:"schema"`
+	}{p.Name, p.APIBase, p.Model, opts.Sampling, opts.MaxTokens, opts.Stop, opts.Schema})
+	return data
+}
+
+// Cache 相同请求在有效期内直接返回上次的回答，只缓存完整成功的回答；
+// offline 返回 true（离线且 provider 不在本地）时不论是否开启缓存、不论缓存多久以前写入，命中即返回。
+// refresh 为 true 时跳过读取，只写入新的回答。关闭缓存且 offline 为 nil 时返回 nil
+func Cache(prefix []byte, offline func() bool, refresh bool) provider.Middleware {
+	ttl := cacheTTL()
+	if ttl == 0 && offline == nil {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			lookup := ttl
+			if offline != nil && offline() {
+				lookup = time.Duration(math.MaxInt64)
+			}
+			if lookup == 0 {
+				return next.Chat(ctx, messages, onDelta)
+			}
+			path := cachePath(prefix, messages)
+			if answer, ok := loadCache(path, lookup); ok && !refresh {
+				markCached(ctx)
+				Notify(ctx, Event{Kind: EventCacheHit})
+				if onDelta != nil {
+					onDelta(answer)
+				}
+				return answer, nil
+			}
+			answer, err := next.Chat(ctx, messages, onDelta)
+			if err == nil && ttl > 0 {
+				saveCache(path, answer)
+			}
+			return answer, err
+		})
+	}
+}
+
+// cachePath 缓存文件路径，文件名为请求参数与消息（含图片）的 SHA-256
+func cachePath(prefix []byte, messages []provider.Message) string {
+	h := sha256.New()
+	h.Write(prefix)
+	enc := json.NewEncoder(h)
+	for _, m := range messages {
+		_ = enc.Encode(struct {
+			Role    string   `json:"role"`
+			Content string   `json:"content"`
+			Images  []string `json:"images,omitempty"`
+		}{m.Role, m.Content, m.Images})
+	}
+	return filepath.Join(CacheDir(), hex.EncodeToString(h.Sum(nil))+".json")
+}
+
+func loadCache(path string, ttl time.Duration) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil || time.Since(entry.Time) > ttl {
+		return "", false
+	}
+	return entry.Answer, true
+}
+
+// saveCache 写入缓存，失败时静默忽略
+func saveCache(path, answer string) {
+	data, err := json.Marshal(cacheEntry{Time: time.Now(), Answer: answer})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,27 @@
+package intercept
+
+import (
+	"context"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/stats"
+)
+
+// Cost 把每次实际发出的请求的 token 用量（有本地编码时按编码计数，否则按字符估算）与按 pricing 计算的费用记入本地统计；
+// 未开启统计时返回 nil
+func Cost(p config.Provider) provider.Middleware {
+	if !stats.Enabled() {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			answer, err := next.Chat(ctx, messages, onDelta)
+			input, output := promptTokens(ctx, messages), countTokens(ctx, answer)
+			if output > 0 || err == nil {
+				stats.RecordUsage(p.Name, input, output, p.Pricing.Cost(input, output))
+			}
+			return answer, err
+		})
+	}
+}
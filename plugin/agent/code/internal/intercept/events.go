@@ -0,0 +1,92 @@
+package intercept
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"wcp_agent/internal/i18n"
+)
+
+// EventKind 拦截器向调用方报告的事件
+type EventKind int
+
+const (
+	// EventRateLimited 需要等待限流额度，Wait 为预计等待时长
+	EventRateLimited EventKind = iota
+	// EventRateLimitDone 等到了限流额度，请求即将发出
+	EventRateLimitDone
+	// EventRetry 请求失败后将在 Wait 之后第 Attempt 次重试，Err 为失败原因
+	EventRetry
+	// EventCacheHit 回答取自本地缓存
+	EventCacheHit
+	// EventRedacted 发送前从消息中去掉了 Count 处疑似密钥
+	EventRedacted
+	// EventToolCall 模型调用了工具 Tool
+	EventToolCall
+	// EventBudgetWarning 本月的估算费用接近（或已超出但指定了 --override-budget）预算，Err 为提醒文案
+	EventBudgetWarning
+	// EventContextWindow 消息的 token 数（Count）接近或超出模型的上下文窗口，Err 为提醒文案
+	EventContextWindow
+	// EventFallback 请求失败（原因为 Err），改用 fallback 中的下一个 provider（Provider）
+	EventFallback
+	// EventKeyRotated 请求失败（原因为 Err），改用 provider 的第 Count 个 API Key 重发
+	EventKeyRotated
+	// EventSummarized 会话中较早的 Count 条消息被压缩成摘要（Count 为 0 表示摘要失败），Err 为提示文案
+	EventSummarized
+)
+
+// Event 拦截器事件
+type Event struct {
+	Kind    EventKind
+	Wait    time.Duration
+	Attempt int
+	Count   int
+	Err     error
+	Tool    string
+	// Provider EventFallback 改用的 provider
+	Provider string
+}
+
+// Observer 接收拦截器事件（如更新 spinner 的提示）
+type Observer func(Event)
+
+type observerKey struct{}
+
+// WithObserver 让本次请求的拦截器事件交给 fn 处理；未设置时由 Report 输出到 stderr
+func WithObserver(ctx context.Context, fn Observer) context.Context {
+	return context.WithValue(ctx, observerKey{}, fn)
+}
+
+// Notify 把事件交给 ctx 中的 Observer，没有时按默认方式报告，开启追踪时同时记到当前 span 上，开启文件日志时记入日志；
+// 守护进程的客户端用它转交守护进程中发生的事件
+func Notify(ctx context.Context, e Event) {
+	traceEvent(ctx, e)
+	fileLogEvent(ctx, e)
+	if fn, ok := ctx.Value(observerKey{}).(Observer); ok && fn != nil {
+		fn(e)
+		return
+	}
+	Report(e)
+}
+
+// Report 默认的事件处理：重试、改用备选 provider 或另一个 API Key、缓存命中、脱敏、工具调用、会话摘要、预算与上下文窗口提醒在 stderr 提示一行，限流等待不提示
+func Report(e Event) {
+	switch e.Kind {
+	case EventRetry:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgInterceptRetry, e.Err, e.Wait.Seconds(), e.Attempt))
+	case EventCacheHit:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgInterceptCacheHit))
+	case EventRedacted:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgInterceptRedacted, e.Count))
+	case EventToolCall:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgInterceptToolCall, e.Tool))
+	case EventFallback:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgInterceptFallback, e.Err, e.Provider))
+	case EventKeyRotated:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgInterceptKeyRotated, e.Err, e.Count))
+	case EventBudgetWarning, EventContextWindow, EventSummarized:
+		fmt.Fprintln(os.Stderr, e.Err)
+	}
+}
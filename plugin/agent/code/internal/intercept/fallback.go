@@ -0,0 +1,70 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"wcp_agent/internal/provider"
+)
+
+// UnavailableError 标记 CanFallback 成立的错误；守护进程只把错误文本传回，由它保留判断结果
+type UnavailableError struct {
+	Err error
+}
+
+func (e *UnavailableError) Error() string { return e.Err.Error() }
+
+func (e *UnavailableError) Unwrap() error { return e.Err }
+
+// CanFallback 失败后是否值得改用下一个 provider：限流、过载与其他 5xx、超时、网络错误、
+// 密钥无效或模型不存在（401 / 403 / 404）、离线时的远程 provider，以及超出该模型上下文窗口的请求；
+// 取消、被截断与超出预算不改用
+func CanFallback(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, provider.ErrTruncated) {
+		return false
+	}
+	var (
+		ue *UnavailableError
+		se *provider.StatusError
+		te *provider.TimeoutError
+		oe *OfflineError
+		ce *ContextTooLongError
+		ne net.Error
+	)
+	if errors.As(err, &se) {
+		switch se.Code {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusRequestTimeout,
+			http.StatusTooManyRequests:
+			return true
+		}
+		return se.Code >= 500
+	}
+	return errors.As(err, &ue) || errors.As(err, &te) || errors.As(err, &oe) || errors.As(err, &ce) || errors.As(err, &ne)
+}
+
+// Fallback 依次尝试 clients（names 为对应的 provider 名称，用于提示）：前一个以 CanFallback 的错误失败、
+// 且还没有输出任何增量时改用下一个并报告 EventFallback；全部失败时返回最后一个错误
+func Fallback(clients []provider.Client, names []string) provider.Client {
+	if len(clients) == 1 {
+		return clients[0]
+	}
+	return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+		emitted := false
+		deltas := onDelta
+		if onDelta != nil {
+			deltas = func(delta string) {
+				emitted = true
+				onDelta(delta)
+			}
+		}
+		for i := 0; ; i++ {
+			answer, err := clients[i].Chat(ctx, messages, deltas)
+			if i == len(clients)-1 || emitted || ctx.Err() != nil || !CanFallback(err) {
+				return answer, err
+			}
+			Notify(ctx, Event{Kind: EventFallback, Provider: names[i+1], Err: err})
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wcp_agent/internal/filelog"
+	"wcp_agent/internal/provider"
+)
+
+// FileLogging 把每次 provider 调用记入文件日志：provider、模型、状态、耗时与脱敏后的错误，不含消息内容；
+// 未开启文件日志时返回 nil
+func FileLogging(info Info) provider.Middleware {
+	if !filelog.Enabled() {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			start := time.Now()
+			answer, err := next.Chat(ctx, messages, onDelta)
+			e := filelog.Entry{
+				Event:      filelog.EventProvider,
+				Provider:   info.Provider,
+				Model:      info.Model,
+				Status:     LogStatusOK,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			switch {
+			case ctx.Err() != nil:
+				e.Status = LogStatusCancelled
+			case errors.Is(err, provider.ErrTruncated):
+				e.Status = LogStatusTruncated
+			case err != nil:
+				e.Status = LogStatusError
+				e.Error = RedactError(err)
+			}
+			filelog.Write(e)
+			return answer, err
+		})
+	}
+}
+
+// fileLogEvent 把限流等待、重试、换 Key 与改用备选 provider 记入文件日志，其余事件不记
+func fileLogEvent(ctx context.Context, e Event) {
+	switch e.Kind {
+	case EventRateLimited, EventRetry, EventKeyRotated, EventFallback:
+	default:
+		return
+	}
+	info, _ := RequestInfo(ctx)
+	entry := filelog.Entry{
+		Level:      "warn",
+		Event:      filelog.EventIntercept,
+		Provider:   info.Provider,
+		Model:      info.Model,
+		Status:     eventNames[e.Kind],
+		Detail:     e.Provider,
+		Attempt:    e.Attempt,
+		DurationMs: e.Wait.Milliseconds(),
+	}
+	if e.Kind == EventKeyRotated {
+		entry.Attempt = e.Count
+	}
+	if e.Err != nil {
+		entry.Error = RedactError(e.Err)
+	}
+	filelog.Write(entry)
+}
+
+// RedactError 错误信息中疑似密钥的内容替换为 Redacted 后的文字（请求地址、响应正文中都可能带着 Key）
+func RedactError(err error) string {
+	text, _ := Redact(err.Error())
+	return text
+}
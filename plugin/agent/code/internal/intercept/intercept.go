@@ -0,0 +1,134 @@
+// Package intercept 实现模型请求的拦截器链：追踪、文件日志、指标、规范化、上下文上限、日志、脱敏、缓存、离线、预算、
+// 用量记账、重试、多 Key 轮换与限流。每个拦截器都是一个 provider.Middleware，Wrap 按固定顺序组合内置拦截器与第三方通过 sdk 注册的拦截器：
+//
+//	追踪 → 文件日志 → 指标 → 规范化 → 上下文上限 → 日志 → 脱敏 → 第三方拦截器 → 缓存 → 离线 → 预算 → 记账 → 重试 → Key 轮换 → 限流 → provider
+//
+// 追踪、文件日志与指标在最外层，记下整次调用（包括缓存命中与被拦下的请求）的结果，写出的错误信息都先脱敏；
+// 规范化之后的拦截器（包括脱敏的匹配与缓存键）看到的都是统一格式的文本；
+// 超过上下文 token 上限的请求不再往下传递；
+// 脱敏在第三方拦截器与缓存之前，它们看到和保存的都是脱敏后的消息；缓存命中时不记账、不占用限流额度；
+// 离线时缓存未命中的请求在离线拦截器处直接失败，不会等到连接超时；本月估算费用达到预算的请求在预算拦截器处失败；
+// 重试在 Key 轮换与限流之外，每次重试都重新选择 Key、重新申请额度。各拦截器的开关在 config.yaml 的 setting 段配置。
+package intercept
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/ratelimit"
+	"wcp_agent/internal/tokenizer"
+)
+
+// Info 当前请求的 provider 信息，拦截器可通过 RequestInfo 从 ctx 中读取
+type Info struct {
+	Provider string
+	Model    string
+	Stream   bool
+}
+
+type infoKey struct{}
+
+// RequestInfo 读取 Wrap 放入 ctx 的请求信息
+func RequestInfo(ctx context.Context) (Info, bool) {
+	info, ok := ctx.Value(infoKey{}).(Info)
+	return info, ok
+}
+
+// registration 第三方注册的拦截器
+type registration struct {
+	name string
+	mw   provider.Middleware
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []registration
+)
+
+// Register 注册第三方拦截器，按注册顺序排在脱敏之后、缓存之前；
+// 通常在包的 init 中调用，名称重复或拦截器为 nil 时 panic（与 database/sql 注册驱动的约定一致）
+func Register(name string, mw provider.Middleware) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if mw == nil {
+		panic("intercept: Register middleware is nil")
+	}
+	for _, r := range registry {
+		if r.name == name {
+			panic(fmt.Sprintf("intercept: Register called twice for %q", name))
+		}
+	}
+	registry = append(registry, registration{name, mw})
+}
+
+// Wrap 为 provider 客户端套上完整的拦截器链
+func Wrap(client provider.Client, p config.Provider, opts provider.Options) provider.Client {
+	info := Info{Provider: p.Name, Model: p.Model, Stream: opts.Stream}
+	var redact provider.Middleware
+	if !opts.NoRedact {
+		redact = Redaction(opts.RedactPatterns...)
+	}
+	model := p.Model
+	if model == "" {
+		model = p.Name
+	}
+	limit := ContextLimit(MaxContextTokens(opts.MaxContextTokens), tokenizer.ContextWindow(p), model)
+	mws := []provider.Middleware{withInfo(info, tokenizer.For(p)), Tracing(info), FileLogging(info), Metrics(info), Normalization(), limit, Logging(info), redact}
+	registryMu.Lock()
+	for _, r := range registry {
+		mws = append(mws, r.mw)
+	}
+	registryMu.Unlock()
+	var cache provider.Middleware
+	if opts.Tools == nil {
+		// 调用工具的回答取决于工具当时的结果，不缓存
+		var offline func() bool
+		if !IsLocal(p) {
+			offline = func() bool {
+				off, _ := offlineState(opts.Offline)
+				return off
+			}
+		}
+		cache = Cache(cacheKeyPrefix(p, opts), offline, opts.NoCache)
+	}
+	mws = append(mws,
+		cache,
+		Offline(p, opts.Offline),
+		Budget(p, opts.OverrideBudget),
+		Cost(p),
+		Retry(),
+		KeyRotation(p, opts.Keys),
+		RateLimit(ratelimit.New(p.Name, p.RateLimit)),
+	)
+	return provider.Chain(client, mws...)
+}
+
+type tokensKey struct{}
+
+// withInfo 把请求信息与 provider 的 token 计数器放入 ctx
+func withInfo(info Info, tokens tokenizer.Counter) provider.Middleware {
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			ctx = context.WithValue(ctx, infoKey{}, info)
+			return next.Chat(context.WithValue(ctx, tokensKey{}, tokens), messages, onDelta)
+		})
+	}
+}
+
+// countTokens 文本的 token 数：按 withInfo 放入 ctx 的计数器（有本地编码时精确计数），没有时按字符估算
+func countTokens(ctx context.Context, text string) int {
+	tokens, _ := ctx.Value(tokensKey{}).(tokenizer.Counter)
+	return tokens.Count(text)
+}
+
+// promptTokens 一组消息的 token 数
+func promptTokens(ctx context.Context, messages []provider.Message) int {
+	n := 0
+	for _, m := range messages {
+		n += countTokens(ctx, m.Content)
+	}
+	return n
+}
@@ -0,0 +1,58 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/keypool"
+	"wcp_agent/internal/provider"
+)
+
+// KeyRotation provider 有多个 API Key 时按 key_rotation 为每次请求选择 Key（见 keypool），并按 Key 记下用量；
+// 收到 401 / 403 / 429 且还没有输出任何增量时立即换下一个 Key 重发并报告 EventKeyRotated，全部试过后返回最后的错误。
+// 位于重试之内：重试的每一次尝试都重新选择 Key；只有一个 Key 时返回 nil
+func KeyRotation(p config.Provider, keys []string) provider.Middleware {
+	pool := keypool.New(p.Name, keys, p.KeyRotation)
+	if pool == nil {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			emitted := false
+			deltas := onDelta
+			if onDelta != nil {
+				deltas = func(delta string) {
+					emitted = true
+					onDelta(delta)
+				}
+			}
+			tried := map[int]bool{}
+			for {
+				i := pool.Pick(tried)
+				tried[i] = true
+				answer, err := next.Chat(provider.WithAPIKey(ctx, pool.Key(i)), messages, deltas)
+				r := keypool.Result{Input: promptTokens(ctx, messages), Output: countTokens(ctx, answer)}
+				var se *provider.StatusError
+				if errors.As(err, &se) {
+					r.Status, r.RetryAfter = se.Code, se.RetryAfter
+				}
+				pool.Record(i, r)
+				if !rotatable(r.Status) || emitted || ctx.Err() != nil || len(tried) == pool.Len() {
+					return answer, err
+				}
+				Notify(ctx, Event{Kind: EventKeyRotated, Count: len(tried) + 1, Err: err})
+			}
+		})
+	}
+}
+
+// rotatable 换一个 Key 可能就会成功的状态码：Key 无效或没有权限，以及这个 Key 的额度用完
+func rotatable(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
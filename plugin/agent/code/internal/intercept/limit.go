@@ -0,0 +1,69 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+const (
+	// SettingMaxContextTokens config.yaml 中 setting 段的上下文 token 上限，0 或负数表示不限制
+	SettingMaxContextTokens = "agent_max_context_tokens"
+	// DefaultMaxContextTokens 默认的上下文 token 上限
+	DefaultMaxContextTokens = 200000
+	// ContextWindowWarn 消息占模型上下文窗口达到这个比例时提醒
+	ContextWindowWarn = 0.9
+)
+
+// ContextTooLongError 消息的 token 数超过上限，请求未发送
+type ContextTooLongError struct {
+	Tokens int
+	Limit  int
+}
+
+func (e *ContextTooLongError) Error() string {
+	return i18n.T(i18n.MsgLimitContextTooLong, e.Tokens, e.Limit)
+}
+
+// MaxContextTokens 生效的上下文 token 上限：override（--max-context）非 0 时优先，其次为 setting，
+// 都未设置时为 DefaultMaxContextTokens；返回值不大于 0 表示不限制
+func MaxContextTokens(override int) int {
+	if override != 0 {
+		return override
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(config.Setting(SettingMaxContextTokens))); err == nil {
+		return n
+	}
+	return DefaultMaxContextTokens
+}
+
+// ContextLimit 发送前统计全部消息的 token 数，超过 limit 时直接返回 ContextTooLongError，
+// 避免把过大的输入发给模型白白计费或等到服务端报错；达到模型 model 的上下文窗口 window 的 ContextWindowWarn 时
+// 报告 EventContextWindow（各模型的分词不尽相同，计数可能是近似值，仍照常发送）。
+// limit 与 window 都不大于 0 时返回 nil
+func ContextLimit(limit, window int, model string) provider.Middleware {
+	if limit <= 0 && window <= 0 {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			n := promptTokens(ctx, messages)
+			if limit > 0 && n > limit {
+				return "", &ContextTooLongError{Tokens: n, Limit: limit}
+			}
+			if window > 0 && float64(n) >= float64(window)*ContextWindowWarn {
+				msg := i18n.MsgLimitContextNearWindow
+				if n > window {
+					msg = i18n.MsgLimitContextOverWindow
+				}
+				Notify(ctx, Event{Kind: EventContextWindow, Count: n, Err: errors.New(i18n.T(msg, n, model, window))})
+			}
+			return next.Chat(ctx, messages, onDelta)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package intercept
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/provider"
+)
+
+// SettingLog config.yaml 中 setting 段的请求日志开关，为 on 时记录每次请求的元数据（不含消息内容）
+const SettingLog = "agent_log"
+
+// 请求日志中的状态
+const (
+	LogStatusOK        = "ok"
+	LogStatusCached    = "cached"
+	LogStatusTruncated = "truncated"
+	LogStatusCancelled = "cancelled"
+	LogStatusError     = "error"
+)
+
+// LogEntry 请求日志的一行
+type LogEntry struct {
+	Time         time.Time `json:"time"`
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	Stream       bool      `json:"stream,omitempty"`
+	Messages     int       `json:"messages"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	DurationMs   int64     `json:"duration_ms"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// LogPath 请求日志路径: ~/.jdata/agent/data/requests.jsonl
+func LogPath() string {
+	return filepath.Join(config.AgentDataDir(), "requests.jsonl")
+}
+
+type cachedKey struct{}
+
+// markCached 由缓存拦截器调用，告诉日志与统计拦截器本次回答取自缓存
+func markCached(ctx context.Context) {
+	if hit, ok := ctx.Value(cachedKey{}).(*bool); ok {
+		*hit = true
+	}
+}
+
+// withCached 返回带缓存标记的 ctx；外层拦截器已放入标记时沿用同一个，内外都能看到命中
+func withCached(ctx context.Context) (context.Context, *bool) {
+	if hit, ok := ctx.Value(cachedKey{}).(*bool); ok {
+		return ctx, hit
+	}
+	hit := new(bool)
+	return context.WithValue(ctx, cachedKey{}, hit), hit
+}
+
+// Logging 记录请求日志；未开启时返回 nil（Chain 会跳过）
+func Logging(info Info) provider.Middleware {
+	if !enabled(config.Setting(SettingLog), false) {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			ctx, cached := withCached(ctx)
+			start := time.Now()
+			answer, err := next.Chat(ctx, messages, onDelta)
+			entry := LogEntry{
+				Time:         start,
+				Provider:     info.Provider,
+				Model:        info.Model,
+				Stream:       info.Stream,
+				Messages:     len(messages),
+				InputTokens:  promptTokens(ctx, messages),
+				OutputTokens: countTokens(ctx, answer),
+				DurationMs:   time.Since(start).Milliseconds(),
+				Status:       LogStatusOK,
+			}
+			switch {
+			case ctx.Err() != nil:
+				entry.Status = LogStatusCancelled
+			case errors.Is(err, provider.ErrTruncated):
+				entry.Status = LogStatusTruncated
+			case err != nil:
+				entry.Status = LogStatusError
+				entry.Error = RedactError(err)
+			case *cached:
+				entry.Status = LogStatusCached
+			}
+			appendLog(entry)
+			return answer, err
+		})
+	}
+}
+
+// appendLog 追加一行日志，失败时静默忽略（日志不应影响请求本身）
+func appendLog(entry LogEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(LogPath()), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(LogPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
+
+// enabled 解析开关类配置项，空值时取默认值
+func enabled(v string, def bool) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "on", "yes":
+		return true
+	case "0", "false", "off", "no":
+		return false
+	}
+	return def
+}
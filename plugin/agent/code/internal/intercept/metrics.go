@@ -0,0 +1,35 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wcp_agent/internal/metrics"
+	"wcp_agent/internal/provider"
+)
+
+// Metrics 把每次请求的状态、耗时与估算的 token 数记入 metrics.Default（始终开启，只在内存中）；
+// 位于缓存之外，命中缓存的请求记为 cached
+func Metrics(info Info) provider.Middleware {
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			ctx, cached := withCached(ctx)
+			start := time.Now()
+			answer, err := next.Chat(ctx, messages, onDelta)
+			status := LogStatusOK
+			switch {
+			case ctx.Err() != nil:
+				status = LogStatusCancelled
+			case errors.Is(err, provider.ErrTruncated):
+				status = LogStatusTruncated
+			case err != nil:
+				status = LogStatusError
+			case *cached:
+				status = LogStatusCached
+			}
+			metrics.Default.Observe(info.Provider, status, time.Since(start), promptTokens(ctx, messages), countTokens(ctx, answer))
+			return answer, err
+		})
+	}
+}
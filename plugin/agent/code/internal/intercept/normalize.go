@@ -0,0 +1,57 @@
+package intercept
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/provider"
+)
+
+// SettingNormalize config.yaml 中 setting 段的输入规范化开关，默认开启，设为 off 时原样发送
+const SettingNormalize = "agent_normalize"
+
+// bom 字节顺序标记（U+FEFF），从 Windows 记事本或网页复制的内容中常见
+const bom = "\ufeff"
+
+// Normalization 发送前统一消息文本的格式：去掉 BOM、CRLF 与单独的 CR 换成 LF、按 Unicode NFC 合并组合字符，
+// 同样的内容因此得到同样的缓存键，也不会因 \r 或拆开的组合字符多占 token。
+// 制表符保留不变：代码与 Makefile 中它有含义，展开为空格反而更费 token。关闭时返回 nil
+func Normalization() provider.Middleware {
+	if !enabled(config.Setting(SettingNormalize), true) {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			var out []provider.Message
+			for i, m := range messages {
+				text := Normalize(m.Content)
+				if text == m.Content {
+					continue
+				}
+				if out == nil {
+					out = append([]provider.Message{}, messages...)
+				}
+				out[i].Content = text
+			}
+			if out == nil {
+				return next.Chat(ctx, messages, onDelta)
+			}
+			return next.Chat(ctx, out, onDelta)
+		})
+	}
+}
+
+// Normalize 去掉 BOM、把 CRLF 与单独的 CR 换成 LF，并转为 NFC
+func Normalize(text string) string {
+	if strings.Contains(text, bom) {
+		text = strings.ReplaceAll(text, bom, "")
+	}
+	if strings.Contains(text, "\r") {
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+		text = strings.ReplaceAll(text, "\r", "\n")
+	}
+	return norm.NFC.String(text)
+}
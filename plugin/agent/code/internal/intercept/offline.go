@@ -0,0 +1,107 @@
+package intercept
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+// SettingOffline config.yaml 中 setting 段的离线模式：on 始终离线，off 不检测，
+// 默认（auto）在本机没有通往外网的路由时视为离线
+const SettingOffline = "agent_offline"
+
+// OfflineError 离线时请求了非本地的 provider，且没有可用的缓存回答；请求未发送
+type OfflineError struct {
+	Provider string
+	// Detected 为 true 表示是自动检测到没有网络，而不是 --offline / setting 指定的
+	Detected bool
+}
+
+func (e *OfflineError) Error() string {
+	if e.Detected {
+		return i18n.T(i18n.MsgOfflineDetected, e.Provider)
+	}
+	return i18n.T(i18n.MsgOfflineRemote, e.Provider)
+}
+
+// probeAddrs 检测网络时尝试的地址；UDP 的 Dial 只查路由表，不发出任何数据包
+var probeAddrs = []string{"1.1.1.1:53", "[2606:4700:4700::1111]:53"}
+
+// probeTTL 检测结果的缓存时长，同一条命令中的多次请求只检测一次
+const probeTTL = 5 * time.Second
+
+var probe struct {
+	sync.Mutex
+	at     time.Time
+	online bool
+}
+
+// online 本机是否有通往外网的路由（飞行模式、断开 Wi-Fi 时没有）
+func online() bool {
+	probe.Lock()
+	defer probe.Unlock()
+	if time.Since(probe.at) < probeTTL {
+		return probe.online
+	}
+	probe.at, probe.online = time.Now(), false
+	for _, addr := range probeAddrs {
+		if c, err := net.Dial("udp", addr); err == nil {
+			c.Close()
+			probe.online = true
+			break
+		}
+	}
+	return probe.online
+}
+
+// offlineState 返回本次请求是否离线，以及离线是否由自动检测得出
+func offlineState(forced bool) (offline, detected bool) {
+	v := config.Setting(SettingOffline)
+	if forced || enabled(v, false) {
+		return true, false
+	}
+	if !enabled(v, true) || online() {
+		return false, false
+	}
+	return true, true
+}
+
+// IsLocal provider 是否不经外网即可访问：mock、回环地址、局域网地址与 .local / localhost 主机名（如 Ollama 的 http://localhost:11434/v1）
+func IsLocal(p config.Provider) bool {
+	if strings.HasPrefix(p.APIBase, provider.MockScheme) {
+		return true
+	}
+	u, err := url.Parse(p.Endpoint())
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast())
+}
+
+// Offline 离线时对非本地的 provider 直接返回 OfflineError，不再等到连接超时；位于缓存之后，
+// 缓存命中的请求照常返回。本地 provider 返回 nil
+func Offline(p config.Provider, forced bool) provider.Middleware {
+	if IsLocal(p) {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			if offline, detected := offlineState(forced); offline {
+				return "", &OfflineError{Provider: p.Name, Detected: detected}
+			}
+			return next.Chat(ctx, messages, onDelta)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package intercept
+
+import (
+	"context"
+	"time"
+
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/ratelimit"
+)
+
+// RateLimit 请求前按估算的 token 数等待限流额度，请求后按回答的长度补扣；
+// 未配置限额（limiter 为 nil）时返回 nil
+func RateLimit(limiter *ratelimit.Limiter) provider.Middleware {
+	if limiter == nil {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			waited := false
+			err := limiter.Wait(ctx, promptTokens(ctx, messages), func(wait time.Duration) {
+				waited = true
+				Notify(ctx, Event{Kind: EventRateLimited, Wait: wait})
+			})
+			if err != nil {
+				return "", err
+			}
+			if waited {
+				Notify(ctx, Event{Kind: EventRateLimitDone})
+			}
+			answer, err := next.Chat(ctx, messages, onDelta)
+			limiter.Charge(countTokens(ctx, answer))
+			return answer, err
+		})
+	}
+}
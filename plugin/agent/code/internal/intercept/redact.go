@@ -0,0 +1,153 @@
+package intercept
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+// SettingRedact config.yaml 中 setting 段的脱敏开关，默认开启，设为 off 时原样发送
+const SettingRedact = "agent_redact"
+
+// Redacted 替换疑似密钥所用的文字
+const Redacted = "[REDACTED]"
+
+// PatternsFile 自定义脱敏模式（位于 agent 数据目录）：每行一个 Go 正则表达式，空行与 # 开头的行忽略，
+// 与内置模式一样有分组时只替换第 1 个分组
+const PatternsFile = "redact_patterns.txt"
+
+// secretPatterns 疑似密钥的模式；有分组时只替换第 1 个分组（保留 password= 等前缀，便于模型理解上下文）
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	// JWT：header.payload.signature，前两段是以 {" 开头的 base64url JSON
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`),
+	regexp.MustCompile(`\bsk-(?:ant-|proj-)?[A-Za-z0-9_-]{20,}`),
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+	regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{60,}`),
+	regexp.MustCompile(`\b[sr]k_(?:live|test)_[A-Za-z0-9]{20,}`),
+	regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`),
+	regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`),
+	regexp.MustCompile(`(?i)\bBearer\s+([A-Za-z0-9._~+/-]{16,}=*)`),
+	// password=、api_key: 等赋值，引用其他变量（$FOO、${FOO}）的不算
+	regexp.MustCompile(`(?i)\b(?:password|passwd|secret|token|api[_-]?key|access[_-]?key)\b["']?\s*[:=]\s*["']?([^\s"',;$][^\s"',;]{5,})`),
+	// .env 风格的赋值：变量名中以下划线分隔出 KEY、SECRET、TOKEN、PASSWORD 等词（如 DB_PASSWORD=、export STRIPE_SECRET_KEY=），
+	// 引用其他变量（$FOO）的不算
+	regexp.MustCompile(`(?m)^[ \t]*(?:export[ \t]+)?(?:[A-Z0-9]+_)*(?:KEY|SECRET|TOKEN|PASSWORD|PASSWD|PASS|PWD|CREDENTIALS?|AUTH|DSN)(?:_[A-Z0-9]+)*[ \t]*=[ \t]*["']?([^\s"'#$][^\s"'#]{3,})`),
+}
+
+// Redaction 发送前把消息中疑似密钥的内容替换为 Redacted，extra 为额外的模式（无法编译的跳过）；关闭时返回 nil
+func Redaction(extra ...string) provider.Middleware {
+	if !enabled(config.Setting(SettingRedact), true) {
+		return nil
+	}
+	var patterns []*regexp.Regexp
+	for _, p := range extra {
+		if re, err := regexp.Compile(p); err == nil {
+			patterns = append(patterns, re)
+		}
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			count := 0
+			var out []provider.Message
+			for i, m := range messages {
+				text, n := redact(m.Content, patterns)
+				if n > 0 && out == nil {
+					out = append([]provider.Message{}, messages...)
+				}
+				if n > 0 {
+					out[i].Content = text
+					count += n
+				}
+			}
+			if count == 0 {
+				return next.Chat(ctx, messages, onDelta)
+			}
+			Notify(ctx, Event{Kind: EventRedacted, Count: count})
+			return next.Chat(ctx, out, onDelta)
+		})
+	}
+}
+
+// Redact 按内置模式与 PatternsFile 中的自定义模式替换文本中疑似密钥的内容，返回替换后的文本与替换处数；
+// 已被前面的模式替换过的内容不再计数
+func Redact(text string) (string, int) {
+	return redact(text, nil)
+}
+
+// redact 与 Redact 相同，另外按 extra 替换
+func redact(text string, extra []*regexp.Regexp) (string, int) {
+	count := 0
+	for _, re := range slices.Concat(secretPatterns, customPatterns(), extra) {
+		text = re.ReplaceAllStringFunc(text, func(match string) string {
+			if re.NumSubexp() == 0 {
+				if strings.Contains(match, Redacted) {
+					return match
+				}
+				count++
+				return Redacted
+			}
+			loc := re.FindStringSubmatchIndex(match)
+			if loc[2] < 0 || strings.Contains(match[loc[2]:loc[3]], Redacted) {
+				return match
+			}
+			count++
+			return match[:loc[2]] + Redacted + match[loc[3]:]
+		})
+	}
+	return text, count
+}
+
+// custom 已编译的自定义模式，文件修改后重新读取（守护进程无需重启）
+var custom struct {
+	sync.Mutex
+	modTime  time.Time
+	patterns []*regexp.Regexp
+}
+
+// customPatterns 读取 PatternsFile，无法编译的行在 stderr 提示后跳过
+func customPatterns() []*regexp.Regexp {
+	path := filepath.Join(config.AgentDataDir(), PatternsFile)
+	info, err := os.Stat(path)
+	custom.Lock()
+	defer custom.Unlock()
+	if err != nil {
+		custom.modTime, custom.patterns = time.Time{}, nil
+		return nil
+	}
+	if info.ModTime().Equal(custom.modTime) {
+		return custom.patterns
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return custom.patterns
+	}
+	custom.modTime, custom.patterns = info.ModTime(), nil
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		re, err := regexp.Compile(line)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgInterceptBadPattern, PatternsFile, n, err))
+			continue
+		}
+		custom.patterns = append(custom.patterns, re)
+	}
+	return custom.patterns
+}
@@ -0,0 +1,95 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/provider"
+)
+
+const (
+	// SettingRetries config.yaml 中 setting 段的重试次数，0 表示不重试
+	SettingRetries = "agent_retries"
+	// DefaultRetries 默认的重试次数
+	DefaultRetries = 2
+	// maxBackoff 两次重试之间最长的等待
+	maxBackoff = 30 * time.Second
+)
+
+// retries 解析重试次数
+func retries() int {
+	if n, err := strconv.Atoi(strings.TrimSpace(config.Setting(SettingRetries))); err == nil && n >= 0 {
+		return n
+	}
+	return DefaultRetries
+}
+
+// Retry 遇到限流、服务端过载与网络连接错误时按指数退避重试（服务端给出 Retry-After 时按其等待）；
+// 已经输出过增量的请求不重试，避免回答重复输出
+func Retry() provider.Middleware {
+	attempts := retries()
+	if attempts == 0 {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			emitted := false
+			deltas := onDelta
+			if onDelta != nil {
+				deltas = func(delta string) {
+					emitted = true
+					onDelta(delta)
+				}
+			}
+			for attempt := 1; ; attempt++ {
+				answer, err := next.Chat(ctx, messages, deltas)
+				if err == nil || emitted || attempt > attempts || ctx.Err() != nil || !transient(err) {
+					return answer, err
+				}
+				wait := backoff(err, attempt)
+				Notify(ctx, Event{Kind: EventRetry, Wait: wait, Attempt: attempt, Err: err})
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return answer, err
+				case <-timer.C:
+				}
+			}
+		})
+	}
+}
+
+// transient 是否为值得重试的错误：429、5xx 过载类状态码、连接超时与网络错误
+func transient(err error) bool {
+	var se *provider.StatusError
+	if errors.As(err, &se) {
+		switch se.Code {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout, 529:
+			return true
+		}
+		return false
+	}
+	var te *provider.TimeoutError
+	if errors.As(err, &te) {
+		return te.Stage == "connect"
+	}
+	var ne net.Error
+	return errors.As(err, &ne)
+}
+
+// backoff 第 attempt 次重试前的等待：Retry-After 优先，否则 1s、2s、4s……，最长 maxBackoff
+func backoff(err error, attempt int) time.Duration {
+	var se *provider.StatusError
+	if errors.As(err, &se) && se.RetryAfter > 0 {
+		return min(se.RetryAfter, maxBackoff)
+	}
+	return min(time.Second<<(attempt-1), maxBackoff)
+}
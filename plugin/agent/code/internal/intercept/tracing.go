@@ -0,0 +1,76 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/trace"
+)
+
+// eventNames 拦截器事件在追踪中的名称
+var eventNames = map[EventKind]string{
+	EventRateLimited:   "rate_limited",
+	EventRateLimitDone: "rate_limit_done",
+	EventRetry:         "retry",
+	EventCacheHit:      "cache_hit",
+	EventRedacted:      "redacted",
+	EventToolCall:      "tool_call",
+	EventFallback:      "fallback",
+	EventKeyRotated:    "key_rotated",
+	EventSummarized:    "summarized",
+}
+
+// Tracing 为整条拦截器链记一个 span：限流等待、重试与缓存命中作为其中的事件，每次 HTTP 请求是它的子 span；
+// 未开启追踪时返回 nil
+func Tracing(info Info) provider.Middleware {
+	if !trace.Enabled() {
+		return nil
+	}
+	return func(next provider.Client) provider.Client {
+		return provider.ClientFunc(func(ctx context.Context, messages []provider.Message, onDelta func(string)) (string, error) {
+			ctx, span := trace.Start(ctx, "chat "+info.Provider, trace.KindInternal,
+				trace.String("j.provider", info.Provider),
+				trace.String("gen_ai.request.model", info.Model),
+				trace.Bool("j.stream", info.Stream),
+				trace.Int("j.messages", len(messages)))
+			defer span.End()
+			answer, err := next.Chat(ctx, messages, onDelta)
+			provider.RecordError(span, redactedError(err))
+			return answer, err
+		})
+	}
+}
+
+// traceEvent 把拦截器事件记到当前 span 上
+func traceEvent(ctx context.Context, e Event) {
+	span := trace.FromContext(ctx)
+	if span == nil {
+		return
+	}
+	var attrs []trace.Attr
+	if e.Wait > 0 {
+		attrs = append(attrs, trace.Int("wait_ms", int(e.Wait.Milliseconds())))
+	}
+	if e.Attempt > 0 {
+		attrs = append(attrs, trace.Int("attempt", e.Attempt))
+	}
+	if e.Err != nil {
+		attrs = append(attrs, trace.String("error", RedactError(e.Err)))
+	}
+	if e.Tool != "" {
+		attrs = append(attrs, trace.String("tool", e.Tool))
+	}
+	if e.Provider != "" {
+		attrs = append(attrs, trace.String("provider", e.Provider))
+	}
+	span.AddEvent(eventNames[e.Kind], attrs...)
+}
+
+// redactedError 记入 span 的错误：文字按 RedactError 脱敏，截断与取消仍保持原样以便 RecordError 识别
+func redactedError(err error) error {
+	if err == nil || errors.Is(err, provider.ErrTruncated) || errors.Is(err, context.Canceled) {
+		return err
+	}
+	return errors.New(RedactError(err))
+}
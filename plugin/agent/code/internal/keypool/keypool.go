@@ -0,0 +1,269 @@
+// Package keypool 在一个 provider 配置了多个 API Key 时决定每次请求用哪一个：round-robin 依次轮换，
+// failover 一直用第一个可用的。收到 429 的 Key 按 Retry-After（没有时为 RateLimitCooldown）冷却，
+// 收到 401 / 403 的 Key 冷却 AuthCooldown，冷却中的 Key 只在别无选择时使用。
+// 轮换位置、冷却时间与每个 Key 的累计用量存放在 agent/data/keys.json，多个 agent 进程与守护进程共享；
+// 文件中只记录 Key 的指纹与打码后的样子，不保存 Key 本身。
+package keypool
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+const (
+	// FileName 轮换状态与用量文件名（位于 agent 数据目录）
+	FileName = "keys.json"
+	// RateLimitCooldown 收到 429 且没有 Retry-After 时 Key 的冷却时间
+	RateLimitCooldown = time.Minute
+	// AuthCooldown 收到 401 / 403（Key 无效、被吊销或没有权限）时 Key 的冷却时间
+	AuthCooldown = time.Hour
+)
+
+// Usage 单个 Key 的累计用量
+type Usage struct {
+	// Label 打码后的 Key（如 sk-…a1b2），用于展示
+	Label        string `json:"label"`
+	Requests     int    `json:"requests"`
+	InputTokens  int    `json:"input_tokens"`
+	OutputTokens int    `json:"output_tokens"`
+	// RateLimited 收到 429 的次数，Rejected 收到 401 / 403 的次数
+	RateLimited int       `json:"rate_limited,omitempty"`
+	Rejected    int       `json:"rejected,omitempty"`
+	LastUsed    time.Time `json:"last_used,omitempty"`
+	// CooldownUntil 在此之前不优先使用该 Key
+	CooldownUntil time.Time `json:"cooldown_until,omitempty"`
+}
+
+// Cooling 在 now 时是否仍在冷却
+func (u Usage) Cooling(now time.Time) bool {
+	return now.Before(u.CooldownUntil)
+}
+
+// state 单个 provider 的轮换状态：Next 为 round-robin 下一次优先使用的 Key，Keys 以指纹为键
+type state struct {
+	Next int               `json:"next"`
+	Keys map[string]*Usage `json:"keys"`
+}
+
+// ledger provider 名称 → 轮换状态
+type ledger map[string]*state
+
+// Result 一次请求的结果，Status 为 HTTP 状态码（请求成功或没有收到响应时为 0）
+type Result struct {
+	Input      int
+	Output     int
+	Status     int
+	RetryAfter time.Duration
+}
+
+// Pool 单个 provider 的一组 Key
+type Pool struct {
+	provider string
+	keys     []string
+	ids      []string
+	failover bool
+}
+
+// New 创建 Key 池；不足两个 Key 时返回 nil，无需轮换
+func New(provider string, keys []string, rotation string) *Pool {
+	if len(keys) < 2 {
+		return nil
+	}
+	p := &Pool{provider: provider, keys: keys, failover: rotation == config.KeyRotationFailover}
+	for _, key := range keys {
+		p.ids = append(p.ids, ID(key))
+	}
+	return p
+}
+
+// Len Key 的个数
+func (p *Pool) Len() int { return len(p.keys) }
+
+// Key 第 i 个 Key
+func (p *Pool) Key(i int) string { return p.keys[i] }
+
+// Pick 选出本次请求使用的 Key 的下标，跳过 tried 中已经试过的；按轮换方式排出顺序后取第一个不在冷却中的，
+// 都在冷却时取最早结束冷却的。round-robin 下同时把下一次的起点移到它之后
+func (p *Pool) Pick(tried map[int]bool) int {
+	pick := -1
+	_ = update(func(l ledger) {
+		st := l.provider(p.provider)
+		now := time.Now()
+		start := 0
+		if !p.failover {
+			start = st.Next % len(p.keys)
+		}
+		var soonest time.Time
+		for k := range p.keys {
+			i := (start + k) % len(p.keys)
+			if tried[i] {
+				continue
+			}
+			u := st.Keys[p.ids[i]]
+			if u == nil || !u.Cooling(now) {
+				pick = i
+				break
+			}
+			if soonest.IsZero() || u.CooldownUntil.Before(soonest) {
+				pick, soonest = i, u.CooldownUntil
+			}
+		}
+		if pick >= 0 && !p.failover {
+			st.Next = (pick + 1) % len(p.keys)
+		}
+	})
+	if pick < 0 {
+		// 状态文件读写失败时也要能发出请求
+		for i := range p.keys {
+			if !tried[i] {
+				return i
+			}
+		}
+		return 0
+	}
+	return pick
+}
+
+// Record 记下第 i 个 Key 的一次请求：累计用量，429 与 401 / 403 时开始冷却，成功时结束冷却
+func (p *Pool) Record(i int, r Result) {
+	_ = update(func(l ledger) {
+		st := l.provider(p.provider)
+		u := st.Keys[p.ids[i]]
+		if u == nil {
+			u = &Usage{}
+			st.Keys[p.ids[i]] = u
+		}
+		now := time.Now()
+		u.Label = Mask(p.keys[i])
+		u.Requests++
+		u.InputTokens += r.Input
+		u.OutputTokens += r.Output
+		u.LastUsed = now
+		switch r.Status {
+		case http.StatusTooManyRequests:
+			u.RateLimited++
+			wait := r.RetryAfter
+			if wait <= 0 {
+				wait = RateLimitCooldown
+			}
+			u.CooldownUntil = now.Add(wait)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			u.Rejected++
+			u.CooldownUntil = now.Add(AuthCooldown)
+		case 0:
+			u.CooldownUntil = time.Time{}
+		}
+	})
+}
+
+// Stats 某 provider 各 Key 的用量，与 keys 一一对应；从未使用过的 Key 只有 Label
+func Stats(provider string, keys []string) ([]Usage, error) {
+	l, err := load()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Usage, len(keys))
+	for i, key := range keys {
+		out[i] = Usage{Label: Mask(key)}
+		if st := l[provider]; st != nil && st.Keys[ID(key)] != nil {
+			out[i] = *st.Keys[ID(key)]
+		}
+	}
+	return out, nil
+}
+
+// ID Key 的指纹：SHA-256 的前 16 个十六进制字符
+func ID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Mask 打码后的 Key：保留前 3 个与后 4 个字符，过短时全部隐去
+func Mask(key string) string {
+	if len(key) < 12 {
+		return "****"
+	}
+	return key[:3] + "…" + key[len(key)-4:]
+}
+
+func (l ledger) provider(name string) *state {
+	st := l[name]
+	if st == nil {
+		st = &state{}
+		l[name] = st
+	}
+	if st.Keys == nil {
+		st.Keys = map[string]*Usage{}
+	}
+	return st
+}
+
+// Path 轮换状态文件路径
+func Path() string {
+	return filepath.Join(config.AgentDataDir(), FileName)
+}
+
+func load() (ledger, error) {
+	path := Path()
+	if _, err := os.Stat(filepath.Dir(path)); err != nil {
+		return ledger{}, nil
+	}
+	unlock, err := filelock.RLock(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return read(path)
+}
+
+func read(path string) (ledger, error) {
+	l := ledger{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// update 在独占锁内读改写状态文件，多个进程同时轮换不会互相覆盖
+func update(fn func(ledger)) error {
+	path := Path()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	unlock, err := filelock.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	l, err := read(path)
+	if err != nil {
+		// 文件损坏时从头记起，不让轮换挡住请求
+		l = ledger{}
+	}
+	fn(l)
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,103 @@
+// Package mask 回答在显示与导出时的脱敏：按 agent_config.json 的 output_redact 规则（正则 → 替换文字）
+// 把回答中出现的密钥、内部主机名等替换掉，再输出到终端、写入批量结果或交给其他程序。
+// 与发送前的脱敏（intercept.Redaction）互补：那里防止密钥发给模型，这里防止模型复述出来的内容被看到或带出去。
+package mask
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"wcp_agent/internal/config"
+)
+
+// DefaultMask 规则未给出 mask 时的替换文字
+const DefaultMask = "[REDACTED]"
+
+// maxPending 流式输出时一行超过这么长仍没有换行就先输出，避免长段落迟迟不显示
+const maxPending = 4 << 10
+
+type rule struct {
+	re   *regexp.Regexp
+	mask string
+}
+
+// Masker 编译好的规则；nil 与没有规则的 Masker 原样返回文本
+type Masker struct {
+	rules []rule
+}
+
+// New 编译规则，无法编译的规则跳过并在 errs 中返回
+func New(rules []config.RedactRule) (*Masker, []error) {
+	m := &Masker{}
+	var errs []error
+	for i, r := range rules {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("output_redact[%d]: %w", i, err))
+			continue
+		}
+		if r.Pattern == "" {
+			continue
+		}
+		mask := r.Mask
+		if mask == "" {
+			mask = DefaultMask
+		}
+		m.rules = append(m.rules, rule{re, mask})
+	}
+	return m, errs
+}
+
+// Enabled 是否有生效的规则
+func (m *Masker) Enabled() bool {
+	return m != nil && len(m.rules) > 0
+}
+
+// Apply 依次套用各规则
+func (m *Masker) Apply(text string) string {
+	if !m.Enabled() {
+		return text
+	}
+	for _, r := range m.rules {
+		text = r.re.ReplaceAllString(text, r.mask)
+	}
+	return text
+}
+
+// Stream 流式输出时的脱敏：规则按行匹配，收到的增量攒到换行再脱敏输出，跨行的内容（如私钥块）在流式输出中匹配不到
+func (m *Masker) Stream() *Stream {
+	return &Stream{m: m}
+}
+
+// Stream 见 Masker.Stream
+type Stream struct {
+	m       *Masker
+	pending strings.Builder
+}
+
+// Write 收下一段增量，返回可以输出的部分（已脱敏）
+func (s *Stream) Write(delta string) string {
+	if !s.m.Enabled() {
+		return delta
+	}
+	s.pending.WriteString(delta)
+	text := s.pending.String()
+	cut := strings.LastIndexByte(text, '\n') + 1
+	if cut == 0 {
+		if len(text) < maxPending {
+			return ""
+		}
+		cut = len(text)
+	}
+	s.pending.Reset()
+	s.pending.WriteString(text[cut:])
+	return s.m.Apply(text[:cut])
+}
+
+// Flush 回答结束时输出剩下的部分
+func (s *Stream) Flush() string {
+	text := s.pending.String()
+	s.pending.Reset()
+	return s.m.Apply(text)
+}
@@ -0,0 +1,164 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/trace"
+)
+
+// httpTransport Streamable HTTP：每条消息 POST 到同一地址，响应为 JSON 或 text/event-stream；
+// 服务器在握手时给出 Mcp-Session-Id 后，之后的请求都带上它
+type httpTransport struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+
+	mu      sync.Mutex
+	nextID  int64
+	session string
+}
+
+func newHTTP(cfg config.MCPServer) *httpTransport {
+	return &httpTransport{url: cfg.URL, headers: expand(cfg.Headers), client: &http.Client{}}
+}
+
+func (t *httpTransport) post(ctx context.Context, msg rpcMessage) (*http.Response, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	if tp := trace.Traceparent(ctx); tp != "" {
+		req.Header.Set("traceparent", tp)
+	}
+	t.mu.Lock()
+	if t.session != "" {
+		req.Header.Set("Mcp-Session-Id", t.session)
+		req.Header.Set("MCP-Protocol-Version", protocolVersion)
+	}
+	t.mu.Unlock()
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	if id := resp.Header.Get("Mcp-Session-Id"); id != "" {
+		t.mu.Lock()
+		t.session = id
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
+
+func (t *httpTransport) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	t.mu.Lock()
+	t.nextID++
+	id := requestID(t.nextID)
+	t.mu.Unlock()
+	resp, err := t.post(ctx, rpcMessage{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var msg rpcMessage
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		msg, err = readEvents(resp.Body, id)
+	} else {
+		err = json.NewDecoder(resp.Body).Decode(&msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if msg.Error != nil {
+		return nil, msg.Error
+	}
+	return msg.Result, nil
+}
+
+// readEvents 从事件流中找出 id 对应的响应，其间的通知与服务器请求忽略
+func readEvents(r io.Reader, id json.RawMessage) (rpcMessage, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if v, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimPrefix(v, " "))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+		var msg rpcMessage
+		err := json.Unmarshal([]byte(data.String()), &msg)
+		data.Reset()
+		if err == nil && msg.Method == "" && bytes.Equal(msg.ID, id) {
+			return msg, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return rpcMessage{}, err
+	}
+	// 最后一个事件后可能没有空行
+	var msg rpcMessage
+	if data.Len() > 0 && json.Unmarshal([]byte(data.String()), &msg) == nil && bytes.Equal(msg.ID, id) {
+		return msg, nil
+	}
+	return rpcMessage{}, errors.New("event stream closed before the response")
+}
+
+func (t *httpTransport) notify(ctx context.Context, method string, params any) error {
+	resp, err := t.post(ctx, rpcMessage{JSONRPC: "2.0", Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// close 有会话时通知服务器结束会话
+func (t *httpTransport) close() error {
+	t.mu.Lock()
+	session := t.session
+	t.mu.Unlock()
+	if session == "" {
+		return nil
+	}
+	req, err := http.NewRequest(http.MethodDelete, t.url, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Mcp-Session-Id", session)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
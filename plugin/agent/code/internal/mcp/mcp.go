@@ -0,0 +1,321 @@
+// Package mcp 实现 Model Context Protocol 客户端：连接配置中的 MCP 服务器（stdio 子进程或
+// Streamable HTTP），列出它们提供的工具，并在模型调用工具时转发 tools/call。
+// 只实现客户端所需的部分：初始化握手、工具列表与工具调用；服务器发来的请求中只响应 ping。
+// Serve 则提供同样这部分协议的服务器端，供 agent mcp serve 使用。
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/trace"
+)
+
+const (
+	// protocolVersion 握手时请求的协议版本
+	protocolVersion = "2025-06-18"
+	// connectTimeout 启动服务器并完成握手、取得工具列表的超时（npx 首次运行需要下载）
+	connectTimeout = 60 * time.Second
+	// nameSep 提供给模型的工具名为 "<服务器>__<工具>"，避免不同服务器的同名工具冲突
+	nameSep = "__"
+	// maxNameLen OpenAI 兼容接口允许的工具名长度上限
+	maxNameLen = 64
+)
+
+// transport 一种 JSON-RPC 传输
+type transport interface {
+	// call 发送请求并等待对应的响应结果
+	call(ctx context.Context, method string, params any) (json.RawMessage, error)
+	// notify 发送通知（不等待响应）
+	notify(ctx context.Context, method string, params any) error
+	close() error
+}
+
+// rpcMessage JSON-RPC 2.0 消息（请求、通知或响应）
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // 服务器发来的 id 可能是字符串
+	Method  string          `json:"method,omitempty"`
+	Params  any             `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// requestID 本端请求的 id
+func requestID(n int64) json.RawMessage {
+	return json.RawMessage(strconv.FormatInt(n, 10))
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Message, e.Code)
+}
+
+// Tool 服务器提供的一个工具
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"inputSchema,omitempty"`
+}
+
+// Server 与一个 MCP 服务器的连接
+type Server struct {
+	Name  string
+	Tools []Tool
+	t     transport
+}
+
+// Connect 启动（或连接）服务器，完成握手并取得工具列表；cfg.Tools 非空时只保留其中的工具
+func Connect(ctx context.Context, name string, cfg config.MCPServer) (_ *Server, err error) {
+	ctx, span := trace.Start(ctx, "mcp connect "+name, trace.KindClient, trace.String("j.mcp.server", name))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+	var t transport
+	switch {
+	case cfg.Command != "":
+		t, err = startStdio(ctx, cfg)
+	case cfg.URL != "":
+		t = newHTTP(cfg)
+	default:
+		return nil, errors.New(i18n.T(i18n.MsgMCPNoTransport, name))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mcp %s: %w", name, err)
+	}
+	s := &Server{Name: name, t: t}
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	if err := s.initialize(ctx); err != nil {
+		_ = t.close()
+		return nil, fmt.Errorf("mcp %s: %w", name, err)
+	}
+	if len(cfg.Tools) > 0 {
+		var kept []Tool
+		for _, tool := range s.Tools {
+			for _, want := range cfg.Tools {
+				if tool.Name == want {
+					kept = append(kept, tool)
+				}
+			}
+		}
+		s.Tools = kept
+	}
+	span.SetAttrs(trace.Int("j.mcp.tools", len(s.Tools)))
+	return s, nil
+}
+
+func (s *Server) initialize(ctx context.Context) error {
+	_, err := s.t.call(ctx, "initialize", map[string]any{
+		"protocolVersion": protocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]string{"name": "j-agent", "version": "1"},
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.t.notify(ctx, "notifications/initialized", nil); err != nil {
+		return err
+	}
+	cursor := ""
+	for {
+		var params any
+		if cursor != "" {
+			params = map[string]string{"cursor": cursor}
+		}
+		raw, err := s.t.call(ctx, "tools/list", params)
+		if err != nil {
+			return err
+		}
+		var page struct {
+			Tools      []Tool `json:"tools"`
+			NextCursor string `json:"nextCursor"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		s.Tools = append(s.Tools, page.Tools...)
+		if page.NextCursor == "" {
+			return nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// Call 调用工具并把结果中的内容拼成文本；服务器报告工具执行失败（isError）时以错误返回
+func (s *Server) Call(ctx context.Context, tool string, arguments json.RawMessage) (text string, err error) {
+	ctx, span := trace.Start(ctx, "mcp tools/call "+tool, trace.KindClient,
+		trace.String("j.mcp.server", s.Name), trace.String("j.mcp.tool", tool))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+	params := map[string]any{"name": tool, "arguments": arguments}
+	if tp := trace.Traceparent(ctx); tp != "" {
+		// 服务器支持时（如 agent mcp serve）把工具执行记在这次调用之下
+		params["_meta"] = map[string]string{"traceparent": tp}
+	}
+	raw, err := s.t.call(ctx, "tools/call", params)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Content []struct {
+			Type     string `json:"type"`
+			Text     string `json:"text"`
+			MIMEType string `json:"mimeType"`
+			Resource *struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"resource"`
+		} `json:"content"`
+		StructuredContent json.RawMessage `json:"structuredContent"`
+		IsError           bool            `json:"isError"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return "", err
+	}
+	var parts []string
+	for _, c := range result.Content {
+		switch {
+		case c.Type == "text":
+			parts = append(parts, c.Text)
+		case c.Resource != nil && c.Resource.Text != "":
+			parts = append(parts, c.Resource.Text)
+		case c.Resource != nil:
+			parts = append(parts, "["+c.Resource.URI+"]")
+		default:
+			parts = append(parts, fmt.Sprintf("[%s %s]", c.Type, c.MIMEType))
+		}
+	}
+	if len(parts) == 0 && len(result.StructuredContent) > 0 {
+		parts = append(parts, string(result.StructuredContent))
+	}
+	text = strings.Join(parts, "\n")
+	if result.IsError {
+		return "", errors.New(text)
+	}
+	return text, nil
+}
+
+// Close 断开连接（stdio 服务器随之退出）
+func (s *Server) Close() error {
+	return s.t.close()
+}
+
+// Toolset 一组已连接的服务器，以 provider.Tools 的形式提供给模型
+type Toolset struct {
+	servers []*Server
+	routes  map[string]route
+	specs   []provider.ToolSpec
+}
+
+// route 提供给模型的工具名对应的服务器与原始工具名
+type route struct {
+	server *Server
+	tool   string
+}
+
+// Open 连接 names 中的服务器；任一服务器未配置或连接失败时关闭已连接的服务器并返回错误
+func Open(ctx context.Context, cfg config.AgentConfig, names []string) (*Toolset, error) {
+	ts := &Toolset{routes: map[string]route{}}
+	for _, name := range names {
+		sc, ok := cfg.MCPServers[name]
+		if !ok {
+			ts.Close()
+			return nil, errors.New(i18n.T(i18n.MsgMCPUnknown, name, strings.Join(Names(cfg), ", ")))
+		}
+		s, err := Connect(ctx, name, sc)
+		if err != nil {
+			ts.Close()
+			return nil, err
+		}
+		ts.servers = append(ts.servers, s)
+		for _, tool := range s.Tools {
+			exposed := ToolName(name, tool.Name)
+			ts.routes[exposed] = route{s, tool.Name}
+			ts.specs = append(ts.specs, provider.ToolSpec{
+				Name:        exposed,
+				Description: strings.TrimSpace("[" + name + "] " + tool.Description),
+				Parameters:  tool.InputSchema,
+			})
+		}
+	}
+	return ts, nil
+}
+
+// Servers 已连接的服务器
+func (ts *Toolset) Servers() []*Server {
+	return ts.servers
+}
+
+// Tools 转换为对话请求使用的工具；onCall 在每次调用工具前收到提供给模型的工具名
+func (ts *Toolset) Tools(maxRounds int, onCall func(ctx context.Context, name string)) *provider.Tools {
+	return &provider.Tools{
+		Specs:     ts.specs,
+		MaxRounds: maxRounds,
+		Call: func(ctx context.Context, name string, arguments json.RawMessage) (string, error) {
+			r, ok := ts.routes[name]
+			if !ok {
+				return "", errors.New(i18n.T(i18n.MsgMCPNoTool, name))
+			}
+			if onCall != nil {
+				onCall(ctx, name)
+			}
+			return r.server.Call(ctx, r.tool, arguments)
+		},
+	}
+}
+
+// Close 断开所有服务器
+func (ts *Toolset) Close() {
+	for _, s := range ts.servers {
+		_ = s.Close()
+	}
+}
+
+// Names 已配置的服务器名称，已排序
+func Names(cfg config.AgentConfig) []string {
+	names := make([]string, 0, len(cfg.MCPServers))
+	for name := range cfg.MCPServers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var invalidName = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// ToolName 提供给模型的工具名：<服务器>__<工具>，不允许的字符替换为 _，超长时截断
+func ToolName(server, tool string) string {
+	name := invalidName.ReplaceAllString(server+nameSep+tool, "_")
+	if len(name) > maxNameLen {
+		name = name[:maxNameLen]
+	}
+	return name
+}
+
+// expand 展开取值中的环境变量
+func expand(values map[string]string) map[string]string {
+	out := make(map[string]string, len(values))
+	for k, v := range values {
+		out[k] = os.ExpandEnv(v)
+	}
+	return out
+}
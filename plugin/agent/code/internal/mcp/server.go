@@ -0,0 +1,183 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"wcp_agent/internal/trace"
+)
+
+// ServerTool 作为 MCP 服务器时提供的一个工具
+type ServerTool struct {
+	Tool
+	// Run 执行工具，返回交给调用方的文本；返回错误时以 isError 结果报告
+	Run func(ctx context.Context, arguments json.RawMessage) (string, error)
+}
+
+// Serve 作为 stdio MCP 服务器处理 r 上的请求、把响应写入 w，直到 r 关闭或 ctx 取消。
+// 工具调用并发执行，调用方发送 notifications/cancelled 时取消对应的调用
+func Serve(ctx context.Context, r io.Reader, w io.Writer, name, version string, tools []ServerTool) error {
+	s := &stdioServer{w: w, name: name, version: version, tools: map[string]ServerTool{}, running: map[string]context.CancelFunc{}}
+	for _, t := range tools {
+		s.list = append(s.list, t.Tool)
+		s.tools[t.Name] = t
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer s.wg.Wait()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		var msg struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			s.reply(nil, nil, &rpcError{Code: -32700, Message: "parse error"})
+			continue
+		}
+		if msg.Method == "" {
+			continue // 本端不发请求，忽略响应
+		}
+		s.handle(ctx, msg.ID, msg.Method, msg.Params)
+	}
+	return scanner.Err()
+}
+
+type stdioServer struct {
+	w       io.Writer
+	name    string
+	version string
+	list    []Tool
+	tools   map[string]ServerTool
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	running map[string]context.CancelFunc // 进行中的工具调用，键为请求 id
+	wg      sync.WaitGroup
+}
+
+func (s *stdioServer) reply(id json.RawMessage, result any, rerr *rpcError) {
+	msg := rpcMessage{JSONRPC: "2.0", ID: id, Error: rerr}
+	if rerr == nil {
+		data, err := json.Marshal(result)
+		if err != nil {
+			msg.Error = &rpcError{Code: -32603, Message: err.Error()}
+		}
+		msg.Result = data
+	}
+	if len(msg.ID) == 0 {
+		msg.ID = json.RawMessage("null")
+	}
+	data, _ := json.Marshal(msg)
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, _ = s.w.Write(append(data, '\n'))
+}
+
+func (s *stdioServer) handle(ctx context.Context, id json.RawMessage, method string, params json.RawMessage) {
+	notification := len(id) == 0
+	switch method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(params, &p)
+		version := protocolVersion
+		if p.ProtocolVersion != "" && p.ProtocolVersion < protocolVersion {
+			version = p.ProtocolVersion // 按调用方的旧版本回复，消息格式兼容
+		}
+		s.reply(id, map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		}, nil)
+	case "ping":
+		s.reply(id, map[string]any{}, nil)
+	case "tools/list":
+		s.reply(id, map[string]any{"tools": s.list}, nil)
+	case "tools/call":
+		s.call(ctx, id, params)
+	case "notifications/cancelled":
+		var p struct {
+			RequestID json.RawMessage `json:"requestId"`
+		}
+		if json.Unmarshal(params, &p) == nil {
+			s.mu.Lock()
+			if cancel, ok := s.running[string(p.RequestID)]; ok {
+				cancel()
+			}
+			s.mu.Unlock()
+		}
+	default:
+		if !notification {
+			s.reply(id, nil, &rpcError{Code: -32601, Message: "method not found: " + method})
+		}
+	}
+}
+
+// call 在单独的 goroutine 中执行工具，不阻塞 ping 与取消通知
+func (s *stdioServer) call(ctx context.Context, id json.RawMessage, params json.RawMessage) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+		Meta      struct {
+			Traceparent string `json:"traceparent"`
+		} `json:"_meta"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		s.reply(id, nil, &rpcError{Code: -32602, Message: err.Error()})
+		return
+	}
+	tool, ok := s.tools[p.Name]
+	if !ok {
+		s.reply(id, nil, &rpcError{Code: -32602, Message: "unknown tool: " + p.Name})
+		return
+	}
+	if len(p.Arguments) == 0 || string(p.Arguments) == "null" {
+		p.Arguments = json.RawMessage("{}")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	key := string(id)
+	s.mu.Lock()
+	s.running[key] = cancel
+	s.mu.Unlock()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, key)
+			s.mu.Unlock()
+			cancel()
+		}()
+		ctx, span := startCall(ctx, p.Meta.Traceparent, p.Name)
+		text, err := tool.Run(ctx, p.Arguments)
+		span.RecordError(err)
+		span.End()
+		if ctx.Err() != nil {
+			return // 已取消的请求不再回复
+		}
+		isError := err != nil
+		if isError {
+			text = err.Error()
+		}
+		s.reply(id, map[string]any{
+			"content": []map[string]string{{"type": "text", "text": text}},
+			"isError": isError,
+		}, nil)
+	}()
+}
+
+// startCall 为一次工具调用开始 span：调用方在 _meta 中带了 traceparent 时接在它之后，否则挂在本进程的命令 span 下
+func startCall(ctx context.Context, traceparent, tool string) (context.Context, *trace.Span) {
+	if traceparent != "" {
+		return trace.StartRemote(ctx, traceparent, "mcp serve "+tool, trace.KindServer, trace.String("j.mcp.tool", tool))
+	}
+	return trace.Start(ctx, "mcp serve "+tool, trace.KindServer, trace.String("j.mcp.tool", tool))
+}
@@ -0,0 +1,191 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/trace"
+)
+
+// stderrTail 保留服务器 stderr 的最后这么多字节，用于连接失败时的错误信息
+const stderrTail = 2 << 10
+
+// stdioTransport 以子进程启动服务器，每行一条 JSON-RPC 消息
+type stdioTransport struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr *tailBuffer
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan rpcMessage
+	done    chan struct{} // 服务器 stdout 关闭（进程退出）后关闭
+}
+
+// startStdio 启动服务器进程；ctx 只用于把当前链路经 TRACEPARENT 传给服务器，进程的生命周期由 close 控制
+func startStdio(ctx context.Context, cfg config.MCPServer) (*stdioTransport, error) {
+	cmd := exec.Command(cfg.Command, cfg.Args...)
+	cmd.Env = os.Environ()
+	for k, v := range expand(cfg.Env) {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	trace.Inject(ctx, cmd)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	t := &stdioTransport{
+		cmd:     cmd,
+		stdin:   stdin,
+		stderr:  &tailBuffer{},
+		pending: map[int64]chan rpcMessage{},
+		done:    make(chan struct{}),
+	}
+	cmd.Stderr = t.stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go t.read(stdout)
+	return t, nil
+}
+
+// read 分发服务器发来的消息：响应交给等待中的请求，请求中只回复 ping，通知忽略
+func (t *stdioTransport) read(r io.Reader) {
+	defer close(t.done)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		var msg rpcMessage
+		if json.Unmarshal(scanner.Bytes(), &msg) != nil {
+			continue
+		}
+		switch {
+		case msg.Method != "" && len(msg.ID) > 0:
+			reply := rpcMessage{JSONRPC: "2.0", ID: msg.ID}
+			if msg.Method == "ping" {
+				reply.Result = json.RawMessage("{}")
+			} else {
+				reply.Error = &rpcError{Code: -32601, Message: "method not found"}
+			}
+			_ = t.write(reply)
+		case len(msg.ID) > 0:
+			var id int64
+			if json.Unmarshal(msg.ID, &id) != nil {
+				continue
+			}
+			t.mu.Lock()
+			ch, ok := t.pending[id]
+			delete(t.pending, id)
+			t.mu.Unlock()
+			if ok {
+				ch <- msg
+			}
+		}
+	}
+}
+
+func (t *stdioTransport) write(msg rpcMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_, err = t.stdin.Write(append(data, '\n'))
+	return err
+}
+
+func (t *stdioTransport) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	t.mu.Lock()
+	t.nextID++
+	id := t.nextID
+	ch := make(chan rpcMessage, 1)
+	t.pending[id] = ch
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+	}()
+
+	if err := t.write(rpcMessage{JSONRPC: "2.0", ID: requestID(id), Method: method, Params: params}); err != nil {
+		return nil, t.exited(err)
+	}
+	select {
+	case msg := <-ch:
+		if msg.Error != nil {
+			return nil, msg.Error
+		}
+		return msg.Result, nil
+	case <-t.done:
+		return nil, t.exited(io.EOF)
+	case <-ctx.Done():
+		// 通知服务器放弃这个请求
+		_ = t.notify(context.Background(), "notifications/cancelled", map[string]any{"requestId": id})
+		return nil, ctx.Err()
+	}
+}
+
+func (t *stdioTransport) notify(_ context.Context, method string, params any) error {
+	return t.write(rpcMessage{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// exited 服务器已退出时附上它在 stderr 的最后输出
+func (t *stdioTransport) exited(err error) error {
+	if tail := strings.TrimSpace(t.stderr.String()); tail != "" {
+		return fmt.Errorf("server exited: %s", tail)
+	}
+	if errors.Is(err, io.EOF) {
+		return errors.New("server exited")
+	}
+	return err
+}
+
+// close 关闭 stdin 让服务器自行退出，超时未退出则结束进程
+func (t *stdioTransport) close() error {
+	_ = t.stdin.Close()
+	select {
+	case <-t.done:
+	case <-time.After(2 * time.Second):
+		_ = t.cmd.Process.Kill()
+	}
+	_ = t.cmd.Wait()
+	return nil
+}
+
+// tailBuffer 只保留最后 stderrTail 字节的输出
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > stderrTail {
+		b.buf = b.buf[len(b.buf)-stderrTail:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
@@ -0,0 +1,182 @@
+// Package metrics 在进程内统计各 provider 的模型请求：按状态的请求数、错误率、延迟分位数与估算的 token 数。
+// 拦截器链执行时把每次请求记入 Default；守护进程常驻，其中的统计覆盖启动以来经它发出的全部请求，
+// agent stats 经 socket 读取，agent serve --metrics 以 Prometheus 文本格式在 /metrics 上提供。只在内存中，进程退出即清空。
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatusError 计入错误率的状态；取消与截断不算错误
+const StatusError = "error"
+
+// Buckets 延迟直方图的上界（秒）：在 Prometheus 客户端默认值的基础上补上长回答常见的 30s、60s 与 120s
+var Buckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// window 计算分位数时保留的最近请求数
+const window = 1024
+
+// Provider 一个 provider 的统计
+type Provider struct {
+	Provider     string         `json:"provider"`
+	Requests     int            `json:"requests"`
+	Statuses     map[string]int `json:"statuses"`
+	Errors       int            `json:"errors"`
+	InputTokens  int            `json:"input_tokens"`
+	OutputTokens int            `json:"output_tokens"`
+	// LatencySum 全部请求的耗时之和（秒），Buckets 为各上界的累计请求数（与 metrics.Buckets 一一对应）
+	LatencySum float64 `json:"latency_sum_seconds"`
+	Buckets    []int   `json:"buckets"`
+	// P50Ms / P90Ms / P99Ms 最近 window 次请求的延迟分位数（毫秒）
+	P50Ms int64 `json:"p50_ms"`
+	P90Ms int64 `json:"p90_ms"`
+	P99Ms int64 `json:"p99_ms"`
+}
+
+// ErrorRate 错误请求占全部请求的比例
+func (p Provider) ErrorRate() float64 {
+	if p.Requests == 0 {
+		return 0
+	}
+	return float64(p.Errors) / float64(p.Requests)
+}
+
+// Snapshot 某一时刻的全部统计，Providers 按名称排列
+type Snapshot struct {
+	Since     time.Time  `json:"since"`
+	Providers []Provider `json:"providers"`
+}
+
+// Registry 进程内的统计，可并发使用
+type Registry struct {
+	mu    sync.Mutex
+	since time.Time
+	stats map[string]*series
+}
+
+// series 一个 provider 的累计值与最近的延迟
+type series struct {
+	Provider
+	recent []time.Duration
+	next   int
+}
+
+// New 创建空的统计
+func New() *Registry {
+	return &Registry{since: time.Now(), stats: map[string]*series{}}
+}
+
+// Default 拦截器链使用的统计
+var Default = New()
+
+// Observe 记录一次请求
+func (r *Registry) Observe(provider, status string, d time.Duration, inputTokens, outputTokens int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.stats[provider]
+	if !ok {
+		s = &series{Provider: Provider{Provider: provider, Statuses: map[string]int{}, Buckets: make([]int, len(Buckets))}}
+		r.stats[provider] = s
+	}
+	s.Requests++
+	s.Statuses[status]++
+	if status == StatusError {
+		s.Errors++
+	}
+	s.InputTokens += inputTokens
+	s.OutputTokens += outputTokens
+	s.LatencySum += d.Seconds()
+	for i, le := range Buckets {
+		if d.Seconds() <= le {
+			s.Buckets[i]++
+		}
+	}
+	if len(s.recent) < window {
+		s.recent = append(s.recent, d)
+	} else {
+		s.recent[s.next] = d
+		s.next = (s.next + 1) % window
+	}
+}
+
+// Snapshot 复制当前的统计并计算分位数
+func (r *Registry) Snapshot() Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snap := Snapshot{Since: r.since, Providers: make([]Provider, 0, len(r.stats))}
+	for _, s := range r.stats {
+		p := s.Provider
+		p.Statuses = make(map[string]int, len(s.Statuses))
+		for k, v := range s.Statuses {
+			p.Statuses[k] = v
+		}
+		p.Buckets = append([]int(nil), s.Buckets...)
+		sorted := append([]time.Duration(nil), s.recent...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		p.P50Ms = quantile(sorted, 0.5).Milliseconds()
+		p.P90Ms = quantile(sorted, 0.9).Milliseconds()
+		p.P99Ms = quantile(sorted, 0.99).Milliseconds()
+		snap.Providers = append(snap.Providers, p)
+	}
+	sort.Slice(snap.Providers, func(i, j int) bool { return snap.Providers[i].Provider < snap.Providers[j].Provider })
+	return snap
+}
+
+// quantile 已排序延迟的 q 分位数（最近秩法），没有数据时为 0
+func quantile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[min(max(i, 0), len(sorted)-1)]
+}
+
+// WritePrometheus 以 Prometheus 文本格式（0.0.4）写出统计
+func WritePrometheus(w io.Writer, snap Snapshot) error {
+	var b strings.Builder
+	metric := func(name, typ, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+	metric("j_agent_requests_total", "counter", "Model requests by provider and status.")
+	for _, p := range snap.Providers {
+		statuses := make([]string, 0, len(p.Statuses))
+		for s := range p.Statuses {
+			statuses = append(statuses, s)
+		}
+		sort.Strings(statuses)
+		for _, s := range statuses {
+			fmt.Fprintf(&b, "j_agent_requests_total{provider=%s,status=%s} %d\n", label(p.Provider), label(s), p.Statuses[s])
+		}
+	}
+	metric("j_agent_request_duration_seconds", "histogram", "Model request latency, including retries.")
+	for _, p := range snap.Providers {
+		for i, le := range Buckets {
+			fmt.Fprintf(&b, "j_agent_request_duration_seconds_bucket{provider=%s,le=\"%s\"} %d\n",
+				label(p.Provider), strconv.FormatFloat(le, 'g', -1, 64), p.Buckets[i])
+		}
+		fmt.Fprintf(&b, "j_agent_request_duration_seconds_bucket{provider=%s,le=\"+Inf\"} %d\n", label(p.Provider), p.Requests)
+		fmt.Fprintf(&b, "j_agent_request_duration_seconds_sum{provider=%s} %s\n", label(p.Provider), strconv.FormatFloat(p.LatencySum, 'g', -1, 64))
+		fmt.Fprintf(&b, "j_agent_request_duration_seconds_count{provider=%s} %d\n", label(p.Provider), p.Requests)
+	}
+	metric("j_agent_tokens_total", "counter", "Estimated tokens by provider and direction.")
+	for _, p := range snap.Providers {
+		fmt.Fprintf(&b, "j_agent_tokens_total{provider=%s,direction=\"input\"} %d\n", label(p.Provider), p.InputTokens)
+		fmt.Fprintf(&b, "j_agent_tokens_total{provider=%s,direction=\"output\"} %d\n", label(p.Provider), p.OutputTokens)
+	}
+	metric("j_agent_metrics_start_time_seconds", "gauge", "Unix time the metrics started counting.")
+	fmt.Fprintf(&b, "j_agent_metrics_start_time_seconds %d\n", snap.Since.Unix())
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// label 带引号的标签值，转义反斜杠、双引号与换行
+func label(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v) + `"`
+}
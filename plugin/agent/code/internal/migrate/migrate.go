@@ -0,0 +1,195 @@
+// Package migrate 在新版本首次运行时把旧版的 agent 配置与问答历史升级为当前格式。
+// 数据目录中的 format_version 记录已完成的格式版本；每个迁移步骤把格式从 Version-1 升到 Version，
+// 修改文件前先把它们备份到 agent/data/backup/<时间>-v<旧版本>/。某一步失败时停在上一个版本，
+// 备份保留，下次运行时从失败的那一步重试。新增的格式变更只需在 steps 末尾追加一步。
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+const (
+	// VersionFile 记录当前格式版本的文件（位于 agent 数据目录）
+	VersionFile = "format_version"
+	// BackupDir 迁移前备份的存放目录（位于 agent 数据目录）
+	BackupDir = "backup"
+)
+
+// Step 一个迁移步骤
+type Step struct {
+	// Version 完成后的格式版本，从 1 开始连续递增
+	Version int
+	// Name 简短的名称，用于提示与 agent migrate status
+	Name string
+	// Files 可能修改的文件（相对 agent 数据目录），存在的会在执行前备份
+	Files []string
+	// Apply 执行迁移，返回是否修改了文件；已经是新格式的数据应原样保留
+	Apply func(dir string) (bool, error)
+}
+
+// steps 全部迁移步骤，按版本排列
+var steps = []Step{
+	{1, "config-prompt-files", []string{config.AgentConfigFile, promptFile, styleFile}, migratePromptFiles},
+	{2, "config-provider-fields", []string{config.AgentConfigFile}, migrateProviderFields},
+	{3, "history-status", []string{historyFile}, migrateHistoryStatus},
+}
+
+// Latest 当前程序使用的格式版本
+func Latest() int {
+	return steps[len(steps)-1].Version
+}
+
+// Steps 全部迁移步骤
+func Steps() []Step {
+	return steps
+}
+
+// Result 一次迁移的结果
+type Result struct {
+	From, To int
+	// Applied 实际修改了文件的步骤名称
+	Applied []string
+	// Backup 备份目录，没有修改任何文件时为空
+	Backup string
+}
+
+// Current 读取已完成的格式版本，文件不存在时为 0（旧版本从未迁移过）
+func Current() (int, error) {
+	data, err := os.ReadFile(filepath.Join(config.AgentDataDir(), VersionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// Run 执行尚未完成的迁移；已是最新版本时只读取一次版本文件。
+// 另一个进程正在迁移时返回零值的 Result，本次运行照常使用现有数据
+func Run() (Result, error) {
+	dir := config.AgentDataDir()
+	from, err := Current()
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", VersionFile, err)
+	}
+	res := Result{From: from, To: from}
+	if from >= Latest() {
+		return res, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return res, err
+	}
+	// 锁住 format_version，防止多个进程（如守护进程与命令行）同时迁移；
+	// 另一个进程迁移太久时本次跳过，下次运行再试
+	unlock, err := filelock.Lock(filepath.Join(dir, VersionFile))
+	if errors.Is(err, filelock.ErrTimeout) {
+		return res, nil
+	} else if err != nil {
+		return res, err
+	}
+	defer unlock()
+	// 拿到锁后重新读取，另一个进程可能刚刚完成
+	if from, err = Current(); err != nil || from >= Latest() {
+		return Result{From: from, To: from}, err
+	}
+	res = Result{From: from, To: from}
+
+	backup := filepath.Join(dir, BackupDir, fmt.Sprintf("%s-v%d", time.Now().Format("20060102-150405"), from))
+	for _, s := range steps {
+		if s.Version <= from {
+			continue
+		}
+		backedUp, err := backupFiles(dir, backup, s.Files)
+		if err != nil {
+			return res, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		if backedUp {
+			res.Backup = backup
+		}
+		changed, err := s.Apply(dir)
+		if err != nil {
+			return res, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		if changed {
+			res.Applied = append(res.Applied, s.Name)
+		}
+		if err := writeVersion(dir, s.Version); err != nil {
+			return res, err
+		}
+		res.To = s.Version
+	}
+	if len(res.Applied) == 0 && res.Backup != "" {
+		// 没有改动时备份没有意义
+		os.RemoveAll(res.Backup)
+		res.Backup = ""
+	}
+	return res, nil
+}
+
+// backupFiles 把存在的文件复制到备份目录（已备份过的不再覆盖），返回是否备份了文件
+func backupFiles(dir, backup string, files []string) (bool, error) {
+	copied := false
+	for _, name := range files {
+		src := filepath.Join(dir, name)
+		dst := filepath.Join(backup, name)
+		if _, err := os.Stat(dst); err == nil {
+			copied = true
+			continue
+		}
+		if err := copyFile(src, dst); errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return copied, err
+		}
+		copied = true
+	}
+	return copied, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func writeVersion(dir string, version int) error {
+	return writeFileAtomic(filepath.Join(dir, VersionFile), []byte(strconv.Itoa(version)+"\n"), 0o644)
+}
+
+// writeFileAtomic 先写临时文件再改名，迁移中途退出不会留下写了一半的文件
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".migrating"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
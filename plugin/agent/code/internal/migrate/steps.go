@@ -0,0 +1,296 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+	"wcp_agent/internal/history"
+)
+
+const (
+	promptFile  = "system_prompt.md"
+	styleFile   = "style.md"
+	historyFile = "ask_history.jsonl"
+)
+
+// migratePromptFiles v1：旧版把 system_prompt 与 style 直接写在 agent_config.json 中，
+// 现在分别存放在 system_prompt.md 与 style.md；文件已有内容时以文件为准，只删掉配置中的字段
+func migratePromptFiles(dir string) (bool, error) {
+	cfg, ok, err := readConfig(dir)
+	if !ok || err != nil {
+		return false, err
+	}
+	changed := false
+	for key, file := range map[string]string{"system_prompt": promptFile, "style": styleFile} {
+		raw, ok := cfg.get(key)
+		if !ok {
+			continue
+		}
+		var text *string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return false, err
+		}
+		if text != nil && strings.TrimSpace(*text) != "" {
+			path := filepath.Join(dir, file)
+			existing, err := os.ReadFile(path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return false, err
+			}
+			if strings.TrimSpace(string(existing)) == "" {
+				if err := writeFileAtomic(path, []byte(strings.TrimSpace(*text)+"\n"), 0o644); err != nil {
+					return false, err
+				}
+			}
+		}
+		cfg.remove(key)
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, writeConfig(dir, cfg)
+}
+
+// providerFieldMoves v2：provider 中旧的平铺写法 → 新的位置（与 configcheck 中的废弃项一致）
+var providerFieldMoves = []struct{ old, group, key string }{
+	{"temperature", "sampling", "temperature"},
+	{"top_p", "sampling", "top_p"},
+	{"seed", "sampling", "seed"},
+	{"rpm", "rate_limit", "requests_per_minute"},
+	{"tpm", "rate_limit", "tokens_per_minute"},
+}
+
+// migrateProviderFields v2：把 providers[] 中的 temperature / top_p / seed 移到 sampling，rpm / tpm 移到 rate_limit；
+// 新位置已有取值时保留新位置的（旧字段此前不生效）
+func migrateProviderFields(dir string) (bool, error) {
+	cfg, ok, err := readConfig(dir)
+	if !ok || err != nil {
+		return false, err
+	}
+	raw, ok := cfg.get("providers")
+	if !ok {
+		return false, nil
+	}
+	var providers []json.RawMessage
+	if err := json.Unmarshal(raw, &providers); err != nil {
+		return false, err
+	}
+	changed := false
+	for i, praw := range providers {
+		p, err := parseObject(praw)
+		if err != nil {
+			return false, err
+		}
+		moved := false
+		groups := map[string]*object{}
+		for _, m := range providerFieldMoves {
+			v, ok := p.get(m.old)
+			if !ok {
+				continue
+			}
+			g := groups[m.group]
+			if g == nil {
+				g = &object{}
+				if graw, ok := p.get(m.group); ok && string(graw) != "null" {
+					if g, err = parseObject(graw); err != nil {
+						return false, err
+					}
+				}
+				groups[m.group] = g
+			}
+			if _, exists := g.get(m.key); !exists {
+				g.set(m.key, v)
+			}
+			p.remove(m.old)
+			moved = true
+		}
+		if !moved {
+			continue
+		}
+		for _, name := range []string{"sampling", "rate_limit"} {
+			if g := groups[name]; g != nil {
+				p.set(name, g.marshal())
+			}
+		}
+		providers[i] = p.marshal()
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	data, err := json.Marshal(providers)
+	if err != nil {
+		return false, err
+	}
+	cfg.set("providers", data)
+	return true, writeConfig(dir, cfg)
+}
+
+// migrateHistoryStatus v3：早期的问答历史没有 id 与 status，补上 id，status 按是否有 error 记为 error 或 ok；
+// 无法解析的行原样保留
+func migrateHistoryStatus(dir string) (bool, error) {
+	path := filepath.Join(dir, historyFile)
+	// 整体重写期间挡住其他进程的追加，否则改名会丢掉读取之后追加的记录
+	unlock, err := filelock.Lock(path)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	lines := bytes.Split(data, []byte("\n"))
+	changed := false
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		e, err := parseObject(line)
+		if err != nil {
+			continue
+		}
+		fixed := false
+		if id, ok := e.get("id"); !ok || string(id) == `""` {
+			id, _ := json.Marshal(history.NewID())
+			e.set("id", id)
+			fixed = true
+		}
+		if _, ok := e.get("status"); !ok {
+			status := history.StatusOK
+			if msg, ok := e.get("error"); ok && string(msg) != `""` && string(msg) != "null" {
+				status = history.StatusError
+			}
+			v, _ := json.Marshal(status)
+			e.set("status", v)
+			fixed = true
+		}
+		if fixed {
+			lines[i] = e.marshal()
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, writeFileAtomic(path, bytes.Join(lines, []byte("\n")), 0o600)
+}
+
+// readConfig 读取 agent_config.json，文件不存在时 ok 为 false
+func readConfig(dir string) (*object, bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, config.AgentConfigFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, err := parseObject(data)
+	if err != nil {
+		return nil, false, err
+	}
+	return cfg, true, nil
+}
+
+// writeConfig 按两个空格缩进写回 agent_config.json（与 SaveAgent 一致）
+func writeConfig(dir string, cfg *object) error {
+	var out bytes.Buffer
+	if err := json.Indent(&out, cfg.marshal(), "", "  "); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, config.AgentConfigFile), out.Bytes(), 0o600)
+}
+
+// object 保留键顺序与原始取值的 JSON 对象：迁移只改动涉及的键，
+// 其余内容（包括 Go 端不认识的 Rust 端字段）原样写回
+type object struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+func parseObject(data []byte) (*object, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, errors.New("not a JSON object")
+	}
+	o := &object{values: map[string]json.RawMessage{}}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		o.set(key, v)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (o *object) get(key string) (json.RawMessage, bool) {
+	v, ok := o.values[key]
+	return v, ok
+}
+
+func (o *object) set(key string, v json.RawMessage) {
+	if o.values == nil {
+		o.values = map[string]json.RawMessage{}
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = v
+}
+
+func (o *object) remove(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// marshal 按原有顺序输出紧凑的 JSON
+func (o *object) marshal() json.RawMessage {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		var v bytes.Buffer
+		if json.Compact(&v, o.values[k]) == nil {
+			b.Write(v.Bytes())
+		} else {
+			b.Write(o.values[k])
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes()
+}
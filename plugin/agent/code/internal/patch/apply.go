@@ -0,0 +1,323 @@
+package patch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"wcp_agent/internal/i18n"
+)
+
+const (
+	// MaxFuzz 模糊匹配时 hunk 首尾各自最多去掉的上下文行数
+	MaxFuzz = 2
+	// BackupSuffix 写入前备份原文件所加的后缀
+	BackupSuffix = ".orig"
+	// minConflictSimilarity 留下冲突标记所需的最低相似度：改动前的非空行在文件某处出现的比例
+	minConflictSimilarity = 0.5
+)
+
+// Result 一个文件应用改动的结果
+type Result struct {
+	Content   []byte
+	Fuzzy     int  // 经模糊匹配才定位到的 hunk 数
+	Merged    bool // 经三方合并应用
+	Conflicts int  // 留下冲突标记的处数
+}
+
+// Apply 把 hunk 依次应用到文件内容上，content 为 nil 表示新建文件。
+// 有 hunk 无法定位时，base 提供 diff 所基于的原始版本（可以为 nil）用于三方合并；
+// 仍然不行时在最相似的位置留下冲突标记，找不到相似位置才返回错误
+func Apply(f File, content []byte, base func() ([]byte, bool)) (Result, error) {
+	if f.NewPath == DevNull {
+		return Result{}, errors.New(i18n.T(i18n.MsgPatchDelete, f.OldPath))
+	}
+	lines, trailing := splitLines(content)
+	if out, fuzzy, _, err := applyHunks(lines, f.Hunks, false, f.Path()); err == nil {
+		return Result{Content: joinLines(out, trailing), Fuzzy: fuzzy}, nil
+	}
+	if base != nil {
+		if original, ok := base(); ok && !bytes.Equal(original, content) {
+			baseLines, _ := splitLines(original)
+			if theirs, fuzzy, _, err := applyHunks(baseLines, f.Hunks, false, f.Path()); err == nil {
+				if merged, conflicts, ok := merge3(baseLines, lines, theirs); ok {
+					return Result{Content: joinLines(merged, trailing), Fuzzy: fuzzy, Merged: true, Conflicts: conflicts}, nil
+				}
+			}
+		}
+	}
+	out, fuzzy, conflicts, err := applyHunks(lines, f.Hunks, true, f.Path())
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Content: joinLines(out, trailing), Fuzzy: fuzzy, Conflicts: conflicts}, nil
+}
+
+func splitLines(content []byte) ([]string, bool) {
+	text := string(content)
+	if text == "" {
+		return nil, true
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), strings.HasSuffix(text, "\n")
+}
+
+func joinLines(lines []string, trailing bool) []byte {
+	out := strings.Join(lines, "\n")
+	if trailing && len(lines) > 0 {
+		out += "\n"
+	}
+	return []byte(out)
+}
+
+// applyHunks 依次定位并应用 hunk，返回模糊匹配的 hunk 数；markConflicts 时定位不到的 hunk
+// 在最相似的位置留下冲突标记，否则返回错误
+func applyHunks(lines []string, hunks []Hunk, markConflicts bool, path string) ([]string, int, int, error) {
+	lines = append([]string{}, lines...)
+	offset, last := 0, 0 // 已应用的 hunk 造成的行号偏移；前一个 hunk 之后的第一行
+	fuzzy, conflicts := 0, 0
+	for n, h := range hunks {
+		want := h.OldStart - 1 + offset
+		var old, replaced []string
+		at, matched, exact, ok := locate(lines, h, want, last)
+		if ok {
+			old = matched.Old()
+			replaced = patchLines(lines[at:at+len(old)], matched)
+		} else if markConflicts {
+			old = h.Old()
+			if at, ok = similar(lines, old, want, last); ok {
+				replaced = conflictBlock(lines[at:at+len(old)], h.New())
+				conflicts++
+				exact = true
+			}
+		}
+		if !ok {
+			return nil, 0, 0, errors.New(i18n.T(i18n.MsgPatchHunkFailed, n+1, path, h.OldStart))
+		}
+		if !exact {
+			fuzzy++
+		}
+		lines = append(lines[:at], append(replaced, lines[at+len(old):]...)...)
+		offset += len(replaced) - len(old)
+		last = at + len(replaced)
+	}
+	return lines, fuzzy, conflicts, nil
+}
+
+// locate 查找 hunk 的位置：先要求上下文完全一致，再忽略空白差异，再去掉首尾 1 到 MaxFuzz 行上下文；
+// 返回位置、实际匹配的（可能去掉了首尾上下文的）hunk，以及是否为精确匹配
+func locate(lines []string, h Hunk, want, from int) (int, Hunk, bool, bool) {
+	for fuzz := 0; fuzz <= MaxFuzz; fuzz++ {
+		trimmed, lead := trimContext(h, fuzz)
+		old := trimmed.Old()
+		if len(old) == 0 {
+			// 纯新增的 hunk：插入到标明的位置（新建文件时为开头）
+			return min(max(want+1, from), len(lines)), trimmed, fuzz == 0, true
+		}
+		for _, loose := range []bool{false, true} {
+			if fuzz > 0 && !loose {
+				continue
+			}
+			if at, ok := search(lines, old, want+lead, from, loose); ok {
+				return at, trimmed, fuzz == 0 && !loose, true
+			}
+		}
+	}
+	return 0, Hunk{}, false, false
+}
+
+// trimContext 去掉 hunk 首尾各至多 fuzz 行上下文（保留至少一行改动前的内容），返回首部去掉的行数
+func trimContext(h Hunk, fuzz int) (Hunk, int) {
+	lead, trail := 0, 0
+	for lead < fuzz && lead < len(h.Lines) && h.Lines[lead][0] == ' ' {
+		lead++
+	}
+	for trail < fuzz && trail < len(h.Lines)-lead && h.Lines[len(h.Lines)-1-trail][0] == ' ' {
+		trail++
+	}
+	trimmed := Hunk{OldStart: h.OldStart, Lines: h.Lines[lead : len(h.Lines)-trail]}
+	if len(trimmed.Old()) == 0 && len(h.Old()) > 0 {
+		return h, 0
+	}
+	return trimmed, lead
+}
+
+// patchLines hunk 应用到匹配位置 current 上的结果：上下文行保留文件中的原样（忽略空白差异匹配时
+// 不把 diff 里的缩进带进来），新增行取自 diff
+func patchLines(current []string, h Hunk) []string {
+	var out []string
+	i := 0
+	for _, line := range h.Lines {
+		switch line[0] {
+		case ' ':
+			if i < len(current) {
+				out = append(out, current[i])
+			}
+			i++
+		case '-':
+			i++
+		case '+':
+			out = append(out, line[1:])
+		}
+	}
+	return out
+}
+
+// search 从 want 开始向前后交替查找与 old 一致的位置，不早于 from；loose 时忽略空白差异
+func search(lines, old []string, want, from int, loose bool) (int, bool) {
+	for shift := 0; shift <= len(lines); shift++ {
+		for _, at := range []int{want + shift, want - shift} {
+			if at >= from && at+len(old) <= len(lines) && equalLines(lines[at:at+len(old)], old, loose) {
+				return at, true
+			}
+			if shift == 0 {
+				break
+			}
+		}
+	}
+	return 0, false
+}
+
+func equalLines(a, b []string, loose bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] == b[i] {
+			continue
+		}
+		if !loose || strings.Join(strings.Fields(a[i]), " ") != strings.Join(strings.Fields(b[i]), " ") {
+			return false
+		}
+	}
+	return true
+}
+
+// similar 找与 old 最相似的位置（相同的非空行最多，并列时取离 want 最近的），相似度不足时返回 false
+func similar(lines, old []string, want, from int) (int, bool) {
+	if len(old) == 0 || len(old) > len(lines)-from {
+		return 0, false
+	}
+	need := map[string]int{}
+	total := 0
+	for _, line := range old {
+		if t := strings.TrimSpace(line); t != "" {
+			need[t]++
+			total++
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	best, bestScore := -1, 0
+	for at := from; at+len(old) <= len(lines); at++ {
+		seen := map[string]int{}
+		score := 0
+		for _, line := range lines[at : at+len(old)] {
+			if t := strings.TrimSpace(line); need[t] > seen[t] {
+				seen[t]++
+				score++
+			}
+		}
+		if score > bestScore || (score == bestScore && best >= 0 && abs(at-want) < abs(best-want)) {
+			best, bestScore = at, score
+		}
+	}
+	if best < 0 || float64(bestScore) < minConflictSimilarity*float64(total) {
+		return 0, false
+	}
+	return best, true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// conflictBlock 冲突标记：上半部分为文件当前的内容，下半部分为 diff 给出的内容
+func conflictBlock(current, patched []string) []string {
+	block := append([]string{"<<<<<<< current"}, current...)
+	block = append(block, "=======")
+	block = append(block, patched...)
+	return append(block, ">>>>>>> patch")
+}
+
+// gitBase diff 所基于的原始版本：index 行标明的 blob，没有或取不到时取 HEAD 中的版本
+func gitBase(f File) ([]byte, bool) {
+	dir, name := filepath.Split(f.Path())
+	if dir == "" {
+		dir = "."
+	}
+	if f.OldBlob != "" {
+		if out, err := exec.Command("git", "-C", dir, "cat-file", "-p", f.OldBlob).Output(); err == nil {
+			return out, true
+		}
+	}
+	out, err := exec.Command("git", "-C", dir, "show", "HEAD:./"+name).Output()
+	return out, err == nil
+}
+
+// Change 一个文件应用改动前后的内容
+type Change struct {
+	Path      string
+	Original  []byte // 新建文件时为 nil
+	Patched   []byte
+	Hunks     int
+	Fuzzy     int
+	Merged    bool
+	Conflicts int
+	Backup    string // 写入后备份文件的路径，新建文件时为空
+}
+
+// Prepare 读取 diff 涉及的文件并在内存中应用全部改动；任一文件失败时返回错误，不写入任何文件
+func Prepare(files []File) ([]Change, error) {
+	var changes []Change
+	for _, f := range files {
+		path := f.Path()
+		var original []byte
+		if f.OldPath != DevNull {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			original = data
+		}
+		res, err := Apply(f, original, func() ([]byte, bool) { return gitBase(f) })
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, Change{
+			Path:      path,
+			Original:  original,
+			Patched:   res.Content,
+			Hunks:     len(f.Hunks),
+			Fuzzy:     res.Fuzzy,
+			Merged:    res.Merged,
+			Conflicts: res.Conflicts,
+		})
+	}
+	return changes, nil
+}
+
+// Write 写入改动，保留原文件的权限；修改已有文件前先把原内容备份到 <文件>.orig
+func (c *Change) Write() error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(c.Path); err == nil {
+		mode = info.Mode().Perm()
+	} else if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return err
+	}
+	if c.Original != nil {
+		backup := c.Path + BackupSuffix
+		if err := os.WriteFile(backup, c.Original, mode); err != nil {
+			return err
+		}
+		c.Backup = backup
+	}
+	return os.WriteFile(c.Path, c.Patched, mode)
+}
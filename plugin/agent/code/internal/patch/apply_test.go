@@ -0,0 +1,178 @@
+package patch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestApply 行号偏移、忽略空白、去掉边缘上下文、多个 hunk、冲突标记与无法定位的 hunk
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name      string
+		content   string
+		diff      string
+		want      string // 期望的结果，wantErr 时不检查
+		fuzzy     int
+		conflicts int
+		wantErr   bool
+	}{
+		{
+			name:    "exact",
+			content: "a\nb\nc\n",
+			diff:    "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+			want:    "a\nB\nc\n",
+		},
+		{
+			name:    "drifted offset",
+			content: "x\ny\nz\na\nb\nc\n",
+			diff:    "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+			want:    "x\ny\nz\na\nB\nc\n",
+		},
+		{
+			name:    "wrong line counts in header",
+			content: "a\nb\nc\n",
+			diff:    "--- a/f\n+++ b/f\n@@ -1,7 +1,9 @@\n a\n-b\n+B\n+B2\n c\n",
+			want:    "a\nB\nB2\nc\n",
+		},
+		{
+			name:    "whitespace differences",
+			content: "func f() {\n\treturn 1\n}\n",
+			diff:    "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n func f() {\n-    return 1\n+    return 2\n }\n",
+			want:    "func f() {\n    return 2\n}\n",
+			fuzzy:   1,
+		},
+		{
+			name:    "stale edge context",
+			content: "a\nB\nc\nd\ne\n",
+			diff:    "--- a/f\n+++ b/f\n@@ -2,4 +2,4 @@\n b\n c\n-d\n+D\n e\n",
+			want:    "a\nB\nc\nD\ne\n",
+			fuzzy:   1,
+		},
+		{
+			name:    "second hunk shifted by the first",
+			content: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			diff: "--- a/f\n+++ b/f\n@@ -1,3 +1,5 @@\n 1\n+1a\n+1b\n 2\n 3\n" +
+				"@@ -7,3 +9,3 @@\n 7\n-8\n+eight\n 9\n",
+			want: "1\n1a\n1b\n2\n3\n4\n5\n6\n7\neight\n9\n",
+		},
+		{
+			name:    "new file",
+			content: "",
+			diff:    "--- /dev/null\n+++ b/f\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+			want:    "a\nb\n",
+		},
+		{
+			name:      "conflicting hunk",
+			content:   "x\ny\nchanged\nz\n",
+			diff:      "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n x\n y\n-old\n+new\n z\n",
+			want:      "<<<<<<< current\nx\ny\nchanged\nz\n=======\nx\ny\nnew\nz\n>>>>>>> patch\n",
+			conflicts: 1,
+		},
+		{
+			name:    "non-matching hunk",
+			content: "p\nq\nr\ns\nt\nu\n",
+			diff:    "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n x\n y\n-old\n+new\n z\n",
+			wantErr: true,
+		},
+		{
+			name:    "hunks out of order",
+			content: "a\nb\nc\nd\n",
+			diff:    "--- a/f\n+++ b/f\n@@ -4,1 +4,1 @@\n-d\n+D\n@@ -1,1 +1,1 @@\n-a\n+A\n",
+			wantErr: true,
+		},
+		{
+			name:    "delete file",
+			content: "a\n",
+			diff:    "--- a/f\n+++ /dev/null\n@@ -1 +0,0 @@\n-a\n",
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			files, err := Parse(c.diff)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			var content []byte
+			if files[0].OldPath != DevNull {
+				content = []byte(c.content)
+			}
+			res, err := Apply(files[0], content, nil)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got %q", res.Content)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if got := string(res.Content); got != c.want {
+				t.Errorf("content:\n%s\nwant:\n%s", got, c.want)
+			}
+			if res.Fuzzy != c.fuzzy || res.Conflicts != c.conflicts {
+				t.Errorf("fuzzy %d conflicts %d, want %d and %d", res.Fuzzy, res.Conflicts, c.fuzzy, c.conflicts)
+			}
+		})
+	}
+}
+
+// TestWriteBackup 修改已有文件时把原内容备份到 .orig 并保留权限，新建文件不备份
+func TestWriteBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "run.sh")
+	if err := os.WriteFile(path, []byte("old\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	change := Change{Path: path, Original: []byte("old\n"), Patched: []byte("new\n")}
+	if err := change.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if change.Backup != path+BackupSuffix {
+		t.Errorf("backup %q, want %q", change.Backup, path+BackupSuffix)
+	}
+	if data, _ := os.ReadFile(change.Backup); string(data) != "old\n" {
+		t.Errorf("backup content %q", data)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new\n" {
+		t.Errorf("patched content %q", data)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o755 {
+		t.Errorf("mode not preserved: %v %v", info.Mode(), err)
+	}
+
+	created := Change{Path: filepath.Join(dir, "sub", "new.txt"), Patched: []byte("x\n")}
+	if err := created.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if created.Backup != "" {
+		t.Errorf("new file got backup %q", created.Backup)
+	}
+	if _, err := os.Stat(created.Path + BackupSuffix); !os.IsNotExist(err) {
+		t.Errorf("unexpected backup for new file: %v", err)
+	}
+}
+
+// TestPrepareAtomic 任一文件的 hunk 定位不到时不返回任何改动
+func TestPrepareAtomic(t *testing.T) {
+	dir := t.TempDir()
+	good, bad := filepath.Join(dir, "good.txt"), filepath.Join(dir, "bad.txt")
+	os.WriteFile(good, []byte("a\nb\n"), 0o644)
+	os.WriteFile(bad, []byte("p\nq\nr\ns\n"), 0o644)
+	diff := strings.Join([]string{
+		"--- a/" + good, "+++ b/" + good, "@@ -1,2 +1,2 @@", " a", "-b", "+B",
+		"--- a/" + bad, "+++ b/" + bad, "@@ -1,3 +1,3 @@", " x", "-y", "+Y", " z", "",
+	}, "\n")
+	files, err := Parse(diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := Prepare(files); err == nil {
+		t.Fatalf("want error, got %d change(s)", len(changes))
+	}
+	if data, _ := os.ReadFile(good); string(data) != "a\nb\n" {
+		t.Errorf("good.txt modified: %q", data)
+	}
+}
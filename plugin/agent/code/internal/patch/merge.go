@@ -0,0 +1,101 @@
+package patch
+
+// maxMergeCells 三方合并中最长公共子序列表的格数上限（去掉公共首尾之后），超出时不做合并
+const maxMergeCells = 4_000_000
+
+// merge3 以 base 为共同祖先合并 ours（文件当前内容）与 theirs（在 base 上应用 diff 的结果）：
+// 只有一方改动的部分取改动的一方，双方改法相同时取其一，双方改法不同时留下冲突标记；
+// 文件过大无法比较时返回 false
+func merge3(base, ours, theirs []string) ([]string, int, bool) {
+	m1, ok := matches(base, ours)
+	if !ok {
+		return nil, 0, false
+	}
+	m2, ok := matches(base, theirs)
+	if !ok {
+		return nil, 0, false
+	}
+	var out []string
+	conflicts := 0
+	i, a, b := 0, 0, 0
+	for {
+		// 三方一致的行
+		for i < len(base) && m1[i] == a && m2[i] == b {
+			out = append(out, base[i])
+			i, a, b = i+1, a+1, b+1
+		}
+		// 下一个三方都保留的基准行之前为一个改动块
+		j := i
+		for j < len(base) && (m1[j] < 0 || m2[j] < 0) {
+			j++
+		}
+		oursEnd, theirsEnd := len(ours), len(theirs)
+		if j < len(base) {
+			oursEnd, theirsEnd = m1[j], m2[j]
+		}
+		baseChunk, oursChunk, theirsChunk := base[i:j], ours[a:oursEnd], theirs[b:theirsEnd]
+		switch {
+		case equalLines(oursChunk, baseChunk, false):
+			out = append(out, theirsChunk...)
+		case equalLines(theirsChunk, baseChunk, false), equalLines(oursChunk, theirsChunk, false):
+			out = append(out, oursChunk...)
+		default:
+			out = append(out, conflictBlock(oursChunk, theirsChunk)...)
+			conflicts++
+		}
+		if j == len(base) {
+			return out, conflicts, true
+		}
+		i, a, b = j, oursEnd, theirsEnd
+	}
+}
+
+// matches a 中每一行在 b 中对应的行（最长公共子序列），没有对应时为 -1
+func matches(a, b []string) ([]int, bool) {
+	m := make([]int, len(a))
+	for i := range m {
+		m[i] = -1
+	}
+	// 公共首尾直接对应，缩小比较范围
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		m[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		m[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+	x, y := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(x) == 0 || len(y) == 0 {
+		return m, true
+	}
+	if len(x)*len(y) > maxMergeCells {
+		return nil, false
+	}
+	// lcs[i][j] 为 x[i:] 与 y[j:] 的最长公共子序列长度
+	width := len(y) + 1
+	lcs := make([]int32, (len(x)+1)*width)
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(x) && j < len(y); {
+		switch {
+		case x[i] == y[j]:
+			m[prefix+i] = prefix + j
+			i, j = i+1, j+1
+		case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return m, true
+}
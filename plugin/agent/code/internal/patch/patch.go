@@ -0,0 +1,146 @@
+// Package patch 解析并应用模型回答中的 unified diff。
+// 模型写出的 diff 常常把 @@ 头里的行数算错，因此解析时以实际的行前缀为准，不信任行数。
+// 应用时 hunk 先在标明的行号附近查找与上下文完全一致的位置，找不到时依次放宽为忽略空白差异、
+// 去掉边缘的上下文行（模糊匹配）；仍然失败说明 diff 基于旧版本的文件，此时取 git 中的原始版本
+// （diff 的 index 行标明的 blob，没有时取 HEAD）应用 diff 后与当前文件做三方合并，
+// 合并不了的部分留下冲突标记，而不是整个放弃。
+package patch
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"wcp_agent/internal/i18n"
+)
+
+// DevNull 新建文件时 diff 中旧文件的路径
+const DevNull = "/dev/null"
+
+var (
+	hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+	indexLineRegexp  = regexp.MustCompile(`^index ([0-9a-f]{7,40})\.\.[0-9a-f]{7,40}`)
+)
+
+// File 一个文件的改动
+type File struct {
+	OldPath string // 去掉 a/ 前缀，新建文件时为 DevNull
+	NewPath string // 去掉 b/ 前缀，删除文件时为 DevNull
+	OldBlob string // index 行中旧文件的 git blob（可能是缩写），没有 index 行时为空
+	Hunks   []Hunk
+}
+
+// Hunk 一段改动，Lines 为带前缀（空格、-、+）的行
+type Hunk struct {
+	OldStart int // 旧文件中的起始行（从 1 开始）
+	Lines    []string
+}
+
+// Path 改动的目标文件
+func (f File) Path() string {
+	if f.NewPath == DevNull {
+		return f.OldPath
+	}
+	return f.NewPath
+}
+
+// Old 改动前的行（上下文与删除行）
+func (h Hunk) Old() []string {
+	return h.side('+')
+}
+
+// New 改动后的行（上下文与新增行）
+func (h Hunk) New() []string {
+	return h.side('-')
+}
+
+func (h Hunk) side(skip byte) []string {
+	var lines []string
+	for _, line := range h.Lines {
+		if line[0] != skip {
+			lines = append(lines, line[1:])
+		}
+	}
+	return lines
+}
+
+// Parse 解析 unified diff，忽略 diff --git、index 等头部与 diff 之外的文字
+func Parse(diff string) ([]File, error) {
+	var files []File
+	var current *File
+	var hunk *Hunk
+	blob := ""
+	flush := func() {
+		if hunk != nil && len(hunk.Lines) > 0 {
+			current.Hunks = append(current.Hunks, *hunk)
+		}
+		hunk = nil
+	}
+	lines := strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			if current != nil {
+				flush()
+			}
+			files = append(files, File{OldPath: diffPath(line[4:], "a/"), NewPath: diffPath(lines[i+1][4:], "b/"), OldBlob: blob})
+			current = &files[len(files)-1]
+			blob = ""
+			i++
+		case strings.HasPrefix(line, "index "):
+			// index <旧 blob>..<新 blob> [mode]，属于下一个文件头
+			if m := indexLineRegexp.FindStringSubmatch(line); m != nil {
+				blob = m[1]
+			}
+		case current == nil:
+			// 第一个文件头之前的说明文字
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			m := hunkHeaderRegexp.FindStringSubmatch(line)
+			if m == nil {
+				return nil, errors.New(i18n.T(i18n.MsgPatchBadHunk, current.Path(), line))
+			}
+			start, _ := strconv.Atoi(m[1])
+			hunk = &Hunk{OldStart: start}
+		case hunk == nil:
+			// 文件头与第一个 hunk 之间的行
+		case line == "":
+			// 模型常把空的上下文行写成空行；diff 末尾的空行不算
+			if i < len(lines)-1 {
+				hunk.Lines = append(hunk.Lines, " ")
+			}
+		case line[0] == ' ' || line[0] == '-' || line[0] == '+':
+			hunk.Lines = append(hunk.Lines, line)
+		case strings.HasPrefix(line, `\`):
+			// \ No newline at end of file
+		default:
+			flush()
+		}
+	}
+	if current != nil {
+		flush()
+	}
+	var result []File
+	for _, f := range files {
+		if len(f.Hunks) > 0 {
+			result = append(result, f)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New(i18n.T(i18n.MsgPatchEmpty))
+	}
+	return result, nil
+}
+
+// diffPath 去掉文件头中的时间戳与 a/、b/ 前缀
+func diffPath(header, prefix string) string {
+	path, _, _ := strings.Cut(header, "\t")
+	path = strings.TrimSpace(path)
+	if path == DevNull {
+		return path
+	}
+	return filepath.FromSlash(strings.TrimPrefix(path, prefix))
+}
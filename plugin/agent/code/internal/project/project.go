@@ -0,0 +1,330 @@
+// Package project 读取项目中的 .j.toml：团队把共享的 j 设置提交到仓库，覆盖个人配置中的默认 provider 与模型、
+// 采样预设、项目上下文的收集规则与脱敏模式。从当前目录向上查找最近的 .j.toml。
+// 优先级为 命令行参数 > .j.toml > 个人配置（agent_config.json 与 config.yaml 的 setting 段）。
+// .j.toml 只能引用个人配置中已有的 provider 与 MCP 服务器，不能写入 API Key 或要执行的命令。
+package project
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// FileName 项目配置文件名
+const FileName = ".j.toml"
+
+// Config .j.toml 的内容
+//
+//	provider = "work-gpt"   # 默认 provider（个人配置中的名称）
+//	model = "gpt-4o-mini"   # 覆盖默认 provider 的模型
+//	preset = "review"       # 默认的 --preset
+//
+//	[presets.review]        # 采样预设，与 sampling_presets 中的同名预设合并时以这里为准
+//	temperature = 0.2
+//	mcp = ["fs"]
+//
+//	[context]
+//	mode = "auto"           # --context 的默认值
+//	budget = 8000           # --context-budget 的默认值
+//	exclude = ["testdata/**", "*.pb.go"]
+//
+//	[redact]
+//	patterns = ['ACME-[0-9]{6}']
+type Config struct {
+	// Path .j.toml 的绝对路径
+	Path     string
+	Provider string
+	Model    string
+	Preset   string
+	Presets  map[string]config.Sampling
+	Context  Context
+	// Redact 额外的脱敏模式（正则表达式，有分组时只替换第 1 个分组），无法编译的已在加载时跳过
+	Redact []string
+}
+
+// Context [context] 段：项目上下文的收集规则
+type Context struct {
+	// Mode none、auto 或 full，空串表示按个人配置
+	Mode string
+	// Budget token 预算，0 表示按模式默认值
+	Budget int
+	// Exclude 不收集的文件（相对项目根目录的 glob，dir/** 匹配整个目录，不含 / 的模式同时匹配文件名）
+	Exclude []string
+}
+
+// Excluded 相对项目根目录的路径 rel（/ 分隔）是否被 Exclude 排除
+func (c Context) Excluded(rel string) bool {
+	for _, pattern := range c.Exclude {
+		if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if rel == dir || strings.HasPrefix(rel, dir+"/") {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var current struct {
+	once sync.Once
+	cfg  *Config
+	err  error
+}
+
+// Current 当前目录所属项目的 .j.toml（进程内只读取一次），没有时返回 nil；
+// 首次读取时在 stderr 提示被忽略的配置项
+func Current() (*Config, error) {
+	current.once.Do(func() {
+		dir, err := os.Getwd()
+		if err != nil {
+			return
+		}
+		path := Find(dir)
+		if path == "" {
+			return
+		}
+		var warnings []string
+		current.cfg, warnings, current.err = Load(path)
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgProjectWarning, path, w))
+		}
+	})
+	return current.cfg, current.err
+}
+
+// Find 由 dir 向上查找最近的 .j.toml，找不到时返回空串
+func Find(dir string) string {
+	for d := dir; ; d = filepath.Dir(d) {
+		p := filepath.Join(d, FileName)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+		if filepath.Dir(d) == d {
+			return ""
+		}
+	}
+}
+
+// Load 读取并校验 path；未知的键、取值无效的项与无法编译的脱敏模式作为警告返回并忽略，语法错误返回错误
+func Load(path string) (*Config, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := parseTOML(string(data))
+	if err != nil {
+		return nil, nil, errors.New(i18n.T(i18n.MsgProjectParse, path, err))
+	}
+	d := &decoder{}
+	cfg := &Config{Path: path}
+	for _, key := range sortedKeys(doc) {
+		v := doc[key]
+		switch key {
+		case "provider":
+			cfg.Provider = d.str(key, v)
+		case "model":
+			cfg.Model = d.str(key, v)
+		case "preset":
+			cfg.Preset = d.str(key, v)
+		case "presets":
+			cfg.Presets = d.presets(key, v)
+		case "context":
+			cfg.Context = d.context(key, v)
+		case "redact":
+			cfg.Redact = d.redact(key, v)
+		default:
+			d.unknown(key)
+		}
+	}
+	return cfg, d.warnings, nil
+}
+
+// decoder 把解析结果转换为 Config，遇到问题时记下警告并跳过该项
+type decoder struct {
+	warnings []string
+}
+
+func (d *decoder) warn(key string, msg string, args ...any) {
+	d.warnings = append(d.warnings, key+": "+i18n.T(msg, args...))
+}
+
+func (d *decoder) unknown(key string) {
+	d.warn(key, i18n.MsgProjectUnknownKey)
+}
+
+func (d *decoder) str(key string, v any) string {
+	s, ok := v.(string)
+	if !ok {
+		d.warn(key, i18n.MsgProjectBadType, "string")
+	}
+	return strings.TrimSpace(s)
+}
+
+func (d *decoder) strs(key string, v any) []string {
+	list, ok := v.([]any)
+	if !ok {
+		d.warn(key, i18n.MsgProjectBadType, "array of strings")
+		return nil
+	}
+	var out []string
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			d.warn(key, i18n.MsgProjectBadType, "array of strings")
+			return nil
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (d *decoder) number(key string, v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	d.warn(key, i18n.MsgProjectBadType, "number")
+	return 0, false
+}
+
+func (d *decoder) integer(key string, v any) (int64, bool) {
+	n, ok := v.(int64)
+	if !ok {
+		d.warn(key, i18n.MsgProjectBadType, "integer")
+	}
+	return n, ok
+}
+
+func (d *decoder) table(key string, v any) map[string]any {
+	t, ok := v.(map[string]any)
+	if !ok {
+		d.warn(key, i18n.MsgProjectBadType, "table")
+	}
+	return t
+}
+
+func (d *decoder) presets(key string, v any) map[string]config.Sampling {
+	presets := map[string]config.Sampling{}
+	t := d.table(key, v)
+	for _, name := range sortedKeys(t) {
+		pt := d.table(key+"."+name, t[name])
+		if pt == nil {
+			continue
+		}
+		var s config.Sampling
+		for _, k := range sortedKeys(pt) {
+			full := key + "." + name + "." + k
+			switch k {
+			case "temperature":
+				if n, ok := d.number(full, pt[k]); ok {
+					s.Temperature = &n
+				}
+			case "top_p":
+				if n, ok := d.number(full, pt[k]); ok {
+					s.TopP = &n
+				}
+			case "seed":
+				if n, ok := d.integer(full, pt[k]); ok {
+					s.Seed = &n
+				}
+			case "mcp":
+				if list := d.strs(full, pt[k]); list != nil {
+					s.MCP = list
+				} else if l, ok := pt[k].([]any); ok && len(l) == 0 {
+					s.MCP = []string{}
+				}
+			case "postprocess":
+				if list := d.strs(full, pt[k]); list != nil {
+					s.Postprocess = list
+				} else if l, ok := pt[k].([]any); ok && len(l) == 0 {
+					s.Postprocess = []string{}
+				}
+			default:
+				d.unknown(full)
+			}
+		}
+		presets[name] = s
+	}
+	return presets
+}
+
+func (d *decoder) context(key string, v any) Context {
+	var c Context
+	t := d.table(key, v)
+	for _, k := range sortedKeys(t) {
+		full := key + "." + k
+		switch k {
+		case "mode":
+			switch m := strings.ToLower(d.str(full, t[k])); m {
+			case "none", "auto", "full":
+				c.Mode = m
+			default:
+				d.warn(full, i18n.MsgProjectBadMode, m)
+			}
+		case "budget":
+			if n, ok := d.integer(full, t[k]); ok && n > 0 && n <= math.MaxInt32 {
+				c.Budget = int(n)
+			}
+		case "exclude":
+			for _, pattern := range d.strs(full, t[k]) {
+				if _, err := path.Match(pattern, ""); err != nil {
+					d.warn(full, i18n.MsgProjectBadGlob, pattern)
+					continue
+				}
+				c.Exclude = append(c.Exclude, pattern)
+			}
+		default:
+			d.unknown(full)
+		}
+	}
+	return c
+}
+
+func (d *decoder) redact(key string, v any) []string {
+	var patterns []string
+	t := d.table(key, v)
+	for _, k := range sortedKeys(t) {
+		full := key + "." + k
+		if k != "patterns" {
+			d.unknown(full)
+			continue
+		}
+		for _, pattern := range d.strs(full, t[k]) {
+			if _, err := regexp.Compile(pattern); err != nil {
+				d.warn(full, i18n.MsgProjectBadPattern, pattern, err)
+				continue
+			}
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+func sortedKeys(t map[string]any) []string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
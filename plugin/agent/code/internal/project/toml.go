@@ -0,0 +1,374 @@
+package project
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseTOML 解析 TOML 的常用子集：表头 [a.b]、（点分）键值对、基本与字面量字符串、整数、浮点数、布尔值、
+// 数组（可跨行）与内联表。多行字符串、日期时间与表数组 [[...]] 不支持，遇到时报错
+func parseTOML(data string) (map[string]any, error) {
+	p := &tomlParser{s: data, line: 1}
+	root := map[string]any{}
+	table := root
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return root, nil
+		}
+		if p.peek() == '[' {
+			p.pos++
+			if p.peek() == '[' {
+				return nil, p.errorf("arrays of tables ([[...]]) are not supported")
+			}
+			p.skipSpace()
+			path, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpace()
+			if p.peek() != ']' {
+				return nil, p.errorf("expected ] after table name")
+			}
+			p.pos++
+			if table, err = subTable(root, path, p); err != nil {
+				return nil, err
+			}
+		} else {
+			path, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpace()
+			if p.peek() != '=' {
+				return nil, p.errorf("expected = after key %q", strings.Join(path, "."))
+			}
+			p.pos++
+			p.skipSpace()
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			if err := setKey(table, path, v, p); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.endOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type tomlParser struct {
+	s    string
+	pos  int
+	line int
+}
+
+func (p *tomlParser) eof() bool { return p.pos >= len(p.s) }
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// skipSpace 跳过行内的空格与制表符
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank 跳过空白、换行与注释
+func (p *tomlParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '\n':
+			p.pos++
+			p.line++
+		case '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// endOfLine 一条键值对或表头之后只能有注释
+func (p *tomlParser) endOfLine() error {
+	p.skipSpace()
+	if p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' {
+			p.pos++
+		}
+	}
+	if p.peek() == '\r' {
+		p.pos++
+	}
+	if !p.eof() && p.peek() != '\n' {
+		return p.errorf("unexpected %q after value", p.peek())
+	}
+	return nil
+}
+
+// key 点分的键：裸键（字母、数字、_ 与 -）或带引号的键
+func (p *tomlParser) key() ([]string, error) {
+	var path []string
+	for {
+		var part string
+		switch c := p.peek(); {
+		case c == '"':
+			s, err := p.basicString()
+			if err != nil {
+				return nil, err
+			}
+			part = s
+		case c == '\'':
+			s, err := p.literalString()
+			if err != nil {
+				return nil, err
+			}
+			part = s
+		default:
+			start := p.pos
+			for !p.eof() && isBareKey(p.peek()) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("expected a key")
+			}
+			part = p.s[start:p.pos]
+		}
+		path = append(path, part)
+		p.skipSpace()
+		if p.peek() != '.' {
+			return path, nil
+		}
+		p.pos++
+		p.skipSpace()
+	}
+}
+
+func isBareKey(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *tomlParser) value() (any, error) {
+	switch c := p.peek(); c {
+	case '"':
+		if strings.HasPrefix(p.s[p.pos:], `"""`) {
+			return nil, p.errorf("multi-line strings are not supported")
+		}
+		return p.basicString()
+	case '\'':
+		if strings.HasPrefix(p.s[p.pos:], "'''") {
+			return nil, p.errorf("multi-line strings are not supported")
+		}
+		return p.literalString()
+	case '[':
+		return p.array()
+	case '{':
+		return p.inlineTable()
+	case 0, '\n', '#':
+		return nil, p.errorf("missing value")
+	}
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\r\n,]}#", rune(p.peek())) {
+		p.pos++
+	}
+	tok := p.s[start:p.pos]
+	switch tok {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	num := strings.ReplaceAll(tok, "_", "")
+	if n, err := strconv.ParseInt(num, 0, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(num, 64); err == nil && !strings.ContainsAny(num, "xXpP") {
+		return f, nil
+	}
+	return nil, p.errorf("invalid value %q (dates and times are not supported)", tok)
+}
+
+// basicString 双引号字符串，支持 \" \\ \b \t \n \f \r \e \uXXXX \UXXXXXXXX 转义
+func (p *tomlParser) basicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.s[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", p.errorf("unterminated string")
+			}
+			e := p.s[p.pos]
+			p.pos++
+			switch e {
+			case '"', '\\':
+				b.WriteByte(e)
+			case 'b':
+				b.WriteByte('\b')
+			case 't':
+				b.WriteByte('\t')
+			case 'n':
+				b.WriteByte('\n')
+			case 'f':
+				b.WriteByte('\f')
+			case 'r':
+				b.WriteByte('\r')
+			case 'e':
+				b.WriteByte('\x1b')
+			case 'u', 'U':
+				size := 4
+				if e == 'U' {
+					size = 8
+				}
+				if p.pos+size > len(p.s) {
+					return "", p.errorf("invalid unicode escape")
+				}
+				n, err := strconv.ParseUint(p.s[p.pos:p.pos+size], 16, 32)
+				if err != nil || !utf8.ValidRune(rune(n)) {
+					return "", p.errorf("invalid unicode escape")
+				}
+				p.pos += size
+				b.WriteRune(rune(n))
+			default:
+				return "", p.errorf("invalid escape \\%c (use single quotes for regular expressions)", e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+// literalString 单引号字符串，内容原样保留（适合写正则表达式）
+func (p *tomlParser) literalString() (string, error) {
+	p.pos++
+	end := strings.IndexAny(p.s[p.pos:], "'\n")
+	if end < 0 || p.s[p.pos+end] != '\'' {
+		return "", p.errorf("unterminated string")
+	}
+	s := p.s[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+func (p *tomlParser) array() ([]any, error) {
+	p.pos++
+	list := []any{}
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return list, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return list, nil
+		default:
+			return nil, p.errorf("expected , or ] in array")
+		}
+	}
+}
+
+func (p *tomlParser) inlineTable() (map[string]any, error) {
+	p.pos++
+	table := map[string]any{}
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		p.skipSpace()
+		path, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != '=' {
+			return nil, p.errorf("expected = after key %q", strings.Join(path, "."))
+		}
+		p.pos++
+		p.skipSpace()
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if err := setKey(table, path, v, p); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, p.errorf("expected , or } in inline table")
+		}
+	}
+}
+
+// subTable 取得（必要时创建）path 指向的表
+func subTable(root map[string]any, path []string, p *tomlParser) (map[string]any, error) {
+	t := root
+	for _, k := range path {
+		switch v := t[k].(type) {
+		case nil:
+			next := map[string]any{}
+			t[k] = next
+			t = next
+		case map[string]any:
+			t = v
+		default:
+			return nil, p.errorf("%q is not a table", strings.Join(path, "."))
+		}
+	}
+	return t, nil
+}
+
+// setKey 在 table 中按点分的 path 写入 v，键已存在时报错
+func setKey(table map[string]any, path []string, v any, p *tomlParser) error {
+	t, err := subTable(table, path[:len(path)-1], p)
+	if err != nil {
+		return err
+	}
+	k := path[len(path)-1]
+	if _, exists := t[k]; exists {
+		return p.errorf("duplicate key %q", strings.Join(path, "."))
+	}
+	t[k] = v
+	return nil
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Google 应用默认凭据（Application Default Credentials）：依次查找 GOOGLE_APPLICATION_CREDENTIALS 指向的文件、
+// gcloud auth application-default login 写下的文件，都没有时向 GCE / Cloud Run 的元数据服务器索取 token
+const (
+	adcEnv         = "GOOGLE_APPLICATION_CREDENTIALS"
+	googleTokenURL = "https://oauth2.googleapis.com/token"
+	cloudScope     = "https://www.googleapis.com/auth/cloud-platform"
+	metadataURL    = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	// tokenMargin 距过期不足该时长的 token 提前刷新
+	tokenMargin = time.Minute
+)
+
+// adcFile 凭据文件：authorized_user（gcloud 登录）或 service_account（服务账号密钥）
+type adcFile struct {
+	Type           string `json:"type"`
+	ClientID       string `json:"client_id"`
+	ClientSecret   string `json:"client_secret"`
+	RefreshToken   string `json:"refresh_token"`
+	ClientEmail    string `json:"client_email"`
+	PrivateKey     string `json:"private_key"`
+	TokenURI       string `json:"token_uri"`
+	ProjectID      string `json:"project_id"`
+	QuotaProjectID string `json:"quota_project_id"`
+
+	path string // 为空表示使用元数据服务器
+}
+
+// loadADC 找到并读取凭据文件；没有任何文件时返回零值（使用元数据服务器）
+func loadADC() (adcFile, error) {
+	path := os.Getenv(adcEnv)
+	if path == "" {
+		path = defaultADCPath()
+		if _, err := os.Stat(path); err != nil {
+			return adcFile{}, nil
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return adcFile{}, err
+	}
+	var f adcFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return adcFile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if f.Type != "authorized_user" && f.Type != "service_account" {
+		return adcFile{}, fmt.Errorf("%s: unsupported credentials type %q", path, f.Type)
+	}
+	f.path = path
+	return f, nil
+}
+
+// defaultADCPath gcloud 写下的凭据文件位置
+func defaultADCPath() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "gcloud", "application_default_credentials.json")
+	}
+	if dir := os.Getenv("CLOUDSDK_CONFIG"); dir != "" {
+		return filepath.Join(dir, "application_default_credentials.json")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
+}
+
+// tokenSource 缓存一份凭据换来的 access token，过期前复用
+type tokenSource struct {
+	creds adcFile
+
+	mu      sync.Mutex
+	value   string
+	expires time.Time
+}
+
+var (
+	tokenSourcesMu sync.Mutex
+	tokenSources   = map[string]*tokenSource{}
+)
+
+// adcTokens 凭据对应的 token 缓存，同一进程（如守护进程）内的请求共用
+func adcTokens(creds adcFile) *tokenSource {
+	tokenSourcesMu.Lock()
+	defer tokenSourcesMu.Unlock()
+	if ts, ok := tokenSources[creds.path]; ok {
+		return ts
+	}
+	ts := &tokenSource{creds: creds}
+	tokenSources[creds.path] = ts
+	return ts
+}
+
+func (ts *tokenSource) token(ctx context.Context, client *http.Client) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.value != "" && time.Until(ts.expires) > tokenMargin {
+		return ts.value, nil
+	}
+	var (
+		req *http.Request
+		err error
+	)
+	switch ts.creds.Type {
+	case "authorized_user":
+		req, err = tokenRequest(ctx, ts.creds.tokenURI(), url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {ts.creds.ClientID},
+			"client_secret": {ts.creds.ClientSecret},
+			"refresh_token": {ts.creds.RefreshToken},
+		})
+	case "service_account":
+		var assertion string
+		if assertion, err = ts.creds.assertion(time.Now()); err == nil {
+			req, err = tokenRequest(ctx, ts.creds.tokenURI(), url.Values{
+				"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+				"assertion":  {assertion},
+			})
+		}
+	default:
+		if req, err = http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil); err == nil {
+			req.Header.Set("Metadata-Flavor", "Google")
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ts.creds.Type == "" {
+			return "", errors.New("no credentials: run gcloud auth application-default login or set " + adcEnv)
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", statusError(resp)
+	}
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	if out.AccessToken == "" {
+		return "", errors.New("empty access token")
+	}
+	ts.value, ts.expires = out.AccessToken, time.Now().Add(time.Duration(out.ExpiresIn)*time.Second)
+	return ts.value, nil
+}
+
+func tokenRequest(ctx context.Context, endpoint string, form url.Values) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, nil
+}
+
+func (f adcFile) tokenURI() string {
+	if f.TokenURI != "" {
+		return f.TokenURI
+	}
+	return googleTokenURL
+}
+
+// assertion 服务账号以私钥签名（RS256）的 JWT，用于换取 access token
+func (f adcFile) assertion(now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(f.PrivateKey))
+	if block == nil {
+		return "", errors.New("service account: invalid private_key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return "", fmt.Errorf("service account: %w", err)
+		}
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return "", errors.New("service account: private_key is not an RSA key")
+	}
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]any{
+		"iss":   f.ClientEmail,
+		"scope": cloudScope,
+		"aud":   f.tokenURI(),
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := header + "." + enc.EncodeToString(claims)
+	sum := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// newAzure Azure OpenAI：请求与回复格式与 OpenAI 相同，地址按部署名拼出，以 api-key 头认证。
+// api_base 为资源地址（https://<资源名>.openai.azure.com）；以 /openai/v1 结尾时使用新版 v1 接口，
+// 不再需要 api-version，部署名放在 model 字段
+func newAzure(p config.Provider, opts Options) (*openAIClient, error) {
+	if p.APIBase == "" {
+		return nil, errors.New(i18n.T(i18n.MsgProviderNoBase, p.Name, p.Type))
+	}
+	c, err := newOpenAI(p, opts)
+	if err != nil {
+		return nil, err
+	}
+	deployment := p.Deployment
+	if deployment == "" {
+		deployment = p.Model
+	}
+	base := p.Endpoint()
+	if strings.HasSuffix(base, "/openai/v1") {
+		c.model = deployment
+	} else {
+		version := p.APIVersion
+		if version == "" {
+			version = config.DefaultAzureAPIVersion
+		}
+		c.url = base + "/openai/deployments/" + url.PathEscape(deployment) + "/chat/completions?api-version=" + url.QueryEscape(version)
+	}
+	key := opts.Key
+	c.authorize = func(ctx context.Context, req *http.Request) error {
+		if key := requestKey(ctx, key); key != "" {
+			req.Header.Set("api-key", key)
+		}
+		return nil
+	}
+	return c, nil
+}
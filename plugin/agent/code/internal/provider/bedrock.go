@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// bedrockBearerEnv AWS 为 Bedrock API Key 约定的环境变量
+const bedrockBearerEnv = "AWS_BEARER_TOKEN_BEDROCK"
+
+// bedrockClient AWS Bedrock 的 Converse / ConverseStream 接口：有 API Key（钥匙串、<PROVIDER>_API_KEY 或
+// AWS_BEARER_TOKEN_BEDROCK）时以 Bearer 认证，否则以 AWS 凭据做 SigV4 签名。model 为模型 ID 或推理配置文件 ARN
+type bedrockClient struct {
+	http     *http.Client
+	name     string
+	base     string
+	region   string
+	key      string
+	creds    awsCredentials
+	model    string
+	stream   bool
+	sampling config.Sampling
+	maxToks  int
+	stop     []string
+}
+
+func newBedrock(p config.Provider, opts Options) (*bedrockClient, error) {
+	region := p.BedrockRegion()
+	if region == "" {
+		return nil, errors.New(i18n.T(i18n.MsgBedrockNoRegion, p.Name))
+	}
+	c := &bedrockClient{
+		http:     opts.HTTP,
+		name:     p.Name,
+		base:     p.Endpoint(),
+		region:   region,
+		key:      opts.Key,
+		model:    p.Model,
+		stream:   opts.Stream,
+		sampling: opts.Sampling,
+		maxToks:  opts.MaxTokens,
+		stop:     opts.Stop,
+	}
+	if c.key == "" {
+		c.key = os.Getenv(bedrockBearerEnv)
+	}
+	if c.key == "" {
+		profile := awsProfile(p.AWSProfile)
+		creds, ok := loadAWSCredentials(profile)
+		if !ok {
+			return nil, errors.New(i18n.T(i18n.MsgBedrockNoCredentials, p.Name, profile))
+		}
+		c.creds = creds
+	}
+	if c.http == nil {
+		var err error
+		if c.http, err = HTTPClient(p, opts.Timeouts); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+type converseRequest struct {
+	Messages        []converseMessage `json:"messages"`
+	System          []converseContent `json:"system,omitempty"`
+	InferenceConfig *inferenceConfig  `json:"inferenceConfig,omitempty"`
+}
+
+type converseMessage struct {
+	Role    string            `json:"role"`
+	Content []converseContent `json:"content"`
+}
+
+type converseContent struct {
+	Text  string         `json:"text,omitempty"`
+	Image *converseImage `json:"image,omitempty"`
+}
+
+type converseImage struct {
+	Format string `json:"format"`
+	Source struct {
+		Bytes []byte `json:"bytes"`
+	} `json:"source"`
+}
+
+type inferenceConfig struct {
+	MaxTokens     int      `json:"maxTokens,omitempty"`
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          *float64 `json:"topP,omitempty"`
+	StopSequences []string `json:"stopSequences,omitempty"`
+}
+
+type converseResponse struct {
+	Output struct {
+		Message converseMessage `json:"message"`
+	} `json:"output"`
+	StopReason string `json:"stopReason"`
+}
+
+// Chat 发送对话；Bedrock 不支持 seed，工具调用只在 OpenAI 兼容的 provider 上提供
+func (c *bedrockClient) Chat(ctx context.Context, messages []Message, onDelta func(string)) (string, error) {
+	if roundTools(ctx) != nil {
+		return "", errors.New(i18n.T(i18n.MsgProviderNoTools, c.name, config.ProviderBedrock))
+	}
+	payload, err := converseBody(messages)
+	if err != nil {
+		return "", err
+	}
+	if c.maxToks > 0 || c.sampling.Temperature != nil || c.sampling.TopP != nil || len(c.stop) > 0 {
+		payload.InferenceConfig = &inferenceConfig{MaxTokens: c.maxToks, Temperature: c.sampling.Temperature, TopP: c.sampling.TopP, StopSequences: c.stop}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	op := "/converse"
+	if c.stream {
+		op = "/converse-stream"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/model/"+awsEscape(c.model)+op, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.key != "" {
+		req.Header.Set("Authorization", "Bearer "+requestKey(ctx, c.key))
+	} else {
+		signV4(req, body, c.creds, c.region, "bedrock", time.Now())
+	}
+	req, span := traceRequest(req, c.model)
+	defer span.End()
+
+	resp, err := c.http.Do(req)
+	traceResponse(span, resp, err)
+	if err != nil {
+		var te *TimeoutError
+		if errors.As(err, &te) {
+			return "", te
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", statusError(resp)
+	}
+	if span != nil {
+		onDelta = firstDelta(span, onDelta)
+	}
+	var text string
+	if c.stream {
+		text, err = readEventStream(resp.Body, onDelta)
+	} else {
+		var out converseResponse
+		if err = json.NewDecoder(resp.Body).Decode(&out); err == nil {
+			for _, part := range out.Output.Message.Content {
+				text += part.Text
+			}
+			if onDelta != nil && text != "" {
+				onDelta(text)
+			}
+			err = stopError(out.StopReason)
+		}
+	}
+	RecordError(span, err)
+	return text, err
+}
+
+// converseBody 把对话转换为 Converse 格式：system 消息放入 system，相邻的同角色消息合并为一条
+// （Converse 要求 user 与 assistant 交替出现），图片由 data URL 解码为原始字节
+func converseBody(messages []Message) (converseRequest, error) {
+	var req converseRequest
+	for _, m := range messages {
+		if m.Role == "system" {
+			req.System = append(req.System, converseContent{Text: m.Content})
+			continue
+		}
+		var content []converseContent
+		if m.Content != "" {
+			content = append(content, converseContent{Text: m.Content})
+		}
+		for _, url := range m.Images {
+			img, err := converseImageFrom(url)
+			if err != nil {
+				return req, err
+			}
+			content = append(content, converseContent{Image: img})
+		}
+		if len(content) == 0 {
+			continue
+		}
+		if n := len(req.Messages); n > 0 && req.Messages[n-1].Role == m.Role {
+			req.Messages[n-1].Content = append(req.Messages[n-1].Content, content...)
+			continue
+		}
+		req.Messages = append(req.Messages, converseMessage{Role: m.Role, Content: content})
+	}
+	return req, nil
+}
+
+// converseImageFrom 解码 data:image/<格式>;base64,... 形式的图片
+func converseImageFrom(url string) (*converseImage, error) {
+	meta, data, ok := strings.Cut(strings.TrimPrefix(url, "data:"), ",")
+	mime, isBase64 := strings.CutSuffix(meta, ";base64")
+	format, isImage := strings.CutPrefix(mime, "image/")
+	if !ok || !isBase64 || !isImage {
+		return nil, fmt.Errorf("unsupported image %.40q", url)
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	img := &converseImage{Format: format}
+	if img.Format == "jpg" {
+		img.Format = "jpeg"
+	}
+	img.Source.Bytes = raw
+	return img, nil
+}
+
+// stopError 把 stopReason 转换为错误：max_tokens 表示被截断
+func stopError(reason string) error {
+	if reason == "max_tokens" {
+		return ErrTruncated
+	}
+	return nil
+}
+
+// readEventStream 解析 ConverseStream 的 AWS event stream：每条消息为
+// 总长度、头部长度、前导 CRC（各 4 字节）、头部、JSON 负载与整条消息的 CRC（4 字节）
+func readEventStream(r io.Reader, onDelta func(string)) (string, error) {
+	var sb strings.Builder
+	var reason string
+	br := bufio.NewReader(r)
+	prelude := make([]byte, 12)
+	for {
+		if _, err := io.ReadFull(br, prelude); err != nil {
+			if errors.Is(err, io.EOF) {
+				return sb.String(), stopError(reason)
+			}
+			return sb.String(), err
+		}
+		total := binary.BigEndian.Uint32(prelude[0:4])
+		headersLen := binary.BigEndian.Uint32(prelude[4:8])
+		if crc32.ChecksumIEEE(prelude[:8]) != binary.BigEndian.Uint32(prelude[8:12]) || total < 16+headersLen || total > 16<<20 {
+			return sb.String(), errors.New("event stream: corrupt message prelude")
+		}
+		rest := make([]byte, total-12)
+		if _, err := io.ReadFull(br, rest); err != nil {
+			return sb.String(), err
+		}
+		crc := crc32.Update(crc32.ChecksumIEEE(prelude), crc32.IEEETable, rest[:len(rest)-4])
+		if crc != binary.BigEndian.Uint32(rest[len(rest)-4:]) {
+			return sb.String(), errors.New("event stream: message checksum mismatch")
+		}
+		headers := eventHeaders(rest[:headersLen])
+		payload := rest[headersLen : len(rest)-4]
+		if headers[":message-type"] == "exception" || headers[":message-type"] == "error" {
+			var e struct {
+				Message string `json:"message"`
+			}
+			_ = json.Unmarshal(payload, &e)
+			kind := headers[":exception-type"] + headers[":error-code"]
+			return sb.String(), fmt.Errorf("%s: %s", kind, e.Message)
+		}
+		switch headers[":event-type"] {
+		case "contentBlockDelta":
+			var e struct {
+				Delta struct {
+					Text string `json:"text"`
+				} `json:"delta"`
+			}
+			if err := json.Unmarshal(payload, &e); err != nil {
+				return sb.String(), err
+			}
+			if e.Delta.Text != "" {
+				sb.WriteString(e.Delta.Text)
+				if onDelta != nil {
+					onDelta(e.Delta.Text)
+				}
+			}
+		case "messageStop":
+			var e struct {
+				StopReason string `json:"stopReason"`
+			}
+			_ = json.Unmarshal(payload, &e)
+			reason = e.StopReason
+		}
+	}
+}
+
+// eventHeaders 解析 event stream 消息头，只保留字符串类型（7）的值；遇到无法解析的部分时停止
+func eventHeaders(b []byte) map[string]string {
+	headers := map[string]string{}
+	// 各类型值的固定长度，-1 表示以 2 字节长度开头的变长值
+	sizes := map[byte]int{0: 0, 1: 0, 2: 1, 3: 2, 4: 4, 5: 8, 6: -1, 7: -1, 8: 8, 9: 16}
+	for len(b) > 0 {
+		n := int(b[0])
+		if len(b) < 2+n {
+			break
+		}
+		name, kind := string(b[1:1+n]), b[1+n]
+		b = b[2+n:]
+		size, ok := sizes[kind]
+		if !ok {
+			break
+		}
+		if size < 0 {
+			if len(b) < 2 {
+				break
+			}
+			size = int(binary.BigEndian.Uint16(b))
+			b = b[2:]
+		}
+		if len(b) < size {
+			break
+		}
+		if kind == 7 {
+			headers[name] = string(b[:size])
+		}
+		b = b[size:]
+	}
+	return headers
+}
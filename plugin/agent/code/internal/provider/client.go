@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+)
+
+// openAIClient 绑定到单个 provider 的 OpenAI 兼容 HTTP 客户端；Azure OpenAI 与 Vertex AI 只是地址与认证不同
+type openAIClient struct {
+	http *http.Client
+	url  string // 对话接口的完整地址
+	// authorize 为请求加上认证信息，默认为 Authorization: Bearer <key>
+	authorize func(ctx context.Context, req *http.Request) error
+	model     string
+	stream    bool
+	sampling  config.Sampling
+	maxToks   int
+	stop      []string
+	schema    *JSONSchema
+	// models 与 routing OpenRouter 的模型回退列表与上游服务商偏好，其他 provider 为空
+	models  []string
+	routing *routingPrefs
+}
+
+func newOpenAI(p config.Provider, opts Options) (*openAIClient, error) {
+	client := opts.HTTP
+	if client == nil {
+		var err error
+		if client, err = HTTPClient(p, opts.Timeouts); err != nil {
+			return nil, err
+		}
+	}
+	return &openAIClient{
+		http:      client,
+		url:       p.Endpoint() + "/chat/completions",
+		authorize: bearer(opts.Key),
+		model:     p.Model,
+		stream:    opts.Stream,
+		sampling:  opts.Sampling,
+		maxToks:   opts.MaxTokens,
+		stop:      opts.Stop,
+		schema:    opts.Schema,
+	}, nil
+}
+
+// bearer 以 Authorization: Bearer <key> 认证（本次请求经 WithAPIKey 指定了 Key 时用它），key 为空时不加
+func bearer(key string) func(context.Context, *http.Request) error {
+	return func(ctx context.Context, req *http.Request) error {
+		if key := requestKey(ctx, key); key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		return nil
+	}
+}
+
+// HTTPClient 按 provider 的代理与连接超时配置创建 HTTP 客户端，
+// 供对话以外的 OpenAI 兼容接口（如语音转写）复用同一套网络设置
+func HTTPClient(p config.Provider, timeouts config.Timeouts) (*http.Client, error) {
+	proxy, err := proxyFunc(p.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	if connect := seconds(timeouts.ConnectSecs); connect > 0 {
+		dialer := &net.Dialer{Timeout: connect, KeepAlive: 30 * time.Second}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, network, addr)
+			if err != nil && isTimeout(err) {
+				return nil, &TimeoutError{Stage: "connect", Limit: connect}
+			}
+			return conn, err
+		}
+		transport.TLSHandshakeTimeout = connect
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// isTimeout 判断网络错误是否为超时
+func isTimeout(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+type chatRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	Stream      bool      `json:"stream"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	TopP        *float64  `json:"top_p,omitempty"`
+	Seed        *int64    `json:"seed,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+	Stop        []string  `json:"stop,omitempty"`
+	// ResponseFormat 原生结构化输出：{"type": "json_schema", "json_schema": {...}}
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+	Tools          []toolSpec      `json:"tools,omitempty"`
+	ToolChoice     string          `json:"tool_choice,omitempty"`
+	Models         []string        `json:"models,omitempty"`
+	Provider       *routingPrefs   `json:"provider,omitempty"`
+}
+
+type responseFormat struct {
+	Type       string         `json:"type"`
+	JSONSchema jsonSchemaSpec `json:"json_schema"`
+}
+
+type jsonSchemaSpec struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message Message `json:"message"`
+		Delta   struct {
+			Content   string          `json:"content"`
+			ToolCalls []toolCallDelta `json:"tool_calls"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+type errorResponse struct {
+	Error apiError `json:"error"`
+	// Message AWS 等接口把错误信息放在顶层
+	Message string `json:"message"`
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+// Chat 发送对话请求并返回完整回复；流式模式下每收到一段增量就回调 onDelta
+func (c *openAIClient) Chat(ctx context.Context, messages []Message, onDelta func(string)) (string, error) {
+	payload := chatRequest{
+		Model:       c.model,
+		Messages:    messages,
+		Stream:      c.stream,
+		Temperature: c.sampling.Temperature,
+		TopP:        c.sampling.TopP,
+		Seed:        c.sampling.Seed,
+		MaxTokens:   c.maxToks,
+		Stop:        c.stop,
+		Models:      c.models,
+		Provider:    c.routing,
+	}
+	if c.schema != nil {
+		payload.ResponseFormat = &responseFormat{
+			Type:       "json_schema",
+			JSONSchema: jsonSchemaSpec{Name: c.schema.Name, Schema: c.schema.Schema},
+		}
+	}
+	tools := roundTools(ctx)
+	if tools != nil {
+		payload.Tools = requestTools(tools.specs)
+		if tools.final {
+			payload.ToolChoice = "none"
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := c.authorize(ctx, req); err != nil {
+		return "", err
+	}
+	req, span := traceRequest(req, c.model)
+	defer span.End()
+
+	resp, err := c.http.Do(req)
+	traceResponse(span, resp, err)
+	if err != nil {
+		var te *TimeoutError
+		if errors.As(err, &te) {
+			return "", te
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", statusError(resp)
+	}
+	if span != nil {
+		onDelta = firstDelta(span, onDelta)
+	}
+	var text string
+	if !c.stream {
+		text, err = readWhole(resp.Body, onDelta, tools)
+	} else {
+		text, err = readStream(resp.Body, onDelta, tools)
+	}
+	RecordError(span, err)
+	return text, err
+}
+
+// StatusError 服务端返回了非 200 状态码
+type StatusError struct {
+	Code       int
+	Status     string // 如 "429 Too Many Requests"
+	Message    string
+	RetryAfter time.Duration // 响应头 Retry-After 给出的等待时间，没有时为 0
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Status, e.Message)
+}
+
+// statusError 从非 200 响应中提取错误信息
+func statusError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	e := &StatusError{Code: resp.StatusCode, Status: resp.Status, Message: strings.TrimSpace(string(data))}
+	var body errorResponse
+	if json.Unmarshal(data, &body) == nil {
+		if body.Error.Message != "" {
+			e.Message = body.Error.Message
+		} else if body.Message != "" {
+			e.Message = body.Message
+		}
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		e.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return e
+}
+
+// readWhole 解析非流式回复；tools 非空时记下模型要求的工具调用
+func readWhole(r io.Reader, onDelta func(string), tools *toolRound) (string, error) {
+	var out chatResponse
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		return "", err
+	}
+	if len(out.Choices) == 0 {
+		return "", nil
+	}
+	text := out.Choices[0].Message.Content
+	if tools != nil {
+		tools.calls = out.Choices[0].Message.ToolCalls
+	}
+	if onDelta != nil && text != "" {
+		onDelta(text)
+	}
+	return text, finishError(out.Choices[0].FinishReason)
+}
+
+// finishError 把 finish_reason 转换为错误：只有 "length" 表示被截断
+func finishError(reason string) error {
+	if reason == "length" {
+		return ErrTruncated
+	}
+	return nil
+}
+
+// readStream 解析 SSE：每行 "data: {json}"，以 "data: [DONE]" 结束；tools 非空时拼接工具调用片段
+func readStream(r io.Reader, onDelta func(string), tools *toolRound) (string, error) {
+	var (
+		sb     strings.Builder
+		reason string
+	)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		data, ok := strings.CutPrefix(line, "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+		var chunk chatResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return sb.String(), err
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		if fr := chunk.Choices[0].FinishReason; fr != "" {
+			reason = fr
+		}
+		if tools != nil && len(chunk.Choices[0].Delta.ToolCalls) > 0 {
+			tools.calls = mergeToolCalls(tools.calls, chunk.Choices[0].Delta.ToolCalls)
+		}
+		if chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		delta := chunk.Choices[0].Delta.Content
+		sb.WriteString(delta)
+		if onDelta != nil {
+			onDelta(delta)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return sb.String(), err
+	}
+	return sb.String(), finishError(reason)
+}
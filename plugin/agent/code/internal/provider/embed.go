@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"wcp_agent/internal/config"
+)
+
+// MockEmbeddingDim mock provider 生成的向量维度
+const MockEmbeddingDim = 64
+
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed 调用 OpenAI 兼容的 /embeddings 接口，按输入顺序返回每段文本的向量。
+// api_base 为 mock:// 时在本地生成确定性的词袋哈希向量（含相同词的文本余弦相似度更高），便于离线测试检索。
+func Embed(ctx context.Context, p config.Provider, opts Options, model string, inputs []string) ([][]float64, error) {
+	if strings.HasPrefix(p.APIBase, MockScheme) {
+		out := make([][]float64, len(inputs))
+		for i, text := range inputs {
+			out[i] = MockEmbedding(text)
+		}
+		return out, nil
+	}
+	if err := openAIOnly(p, "embeddings"); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(embeddingRequest{Model: model, Input: inputs})
+	if err != nil {
+		return nil, err
+	}
+	client, err := HTTPClient(p, opts.Timeouts)
+	if err != nil {
+		return nil, err
+	}
+	if total := seconds(opts.Timeouts.TotalSecs); total > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, total)
+		defer cancel()
+	}
+	url := strings.TrimRight(p.APIBase, "/") + "/embeddings"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if opts.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Key)
+	}
+	req, span := traceRequest(req, model)
+	defer span.End()
+	resp, err := client.Do(req)
+	traceResponse(span, resp, err)
+	if err != nil {
+		var te *TimeoutError
+		if errors.As(err, &te) {
+			return nil, te
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp)
+	}
+	var out embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	vectors := make([][]float64, len(inputs))
+	for _, d := range out.Data {
+		if d.Index < 0 || d.Index >= len(inputs) {
+			return nil, fmt.Errorf("embeddings: unexpected index %d in response", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("embeddings: no vector returned for input %d", i)
+		}
+	}
+	return vectors, nil
+}
+
+// MockEmbedding 把文本切成小写单词（中日韩逐字），哈希到固定维度后归一化
+func MockEmbedding(text string) []float64 {
+	v := make([]float64, MockEmbeddingDim)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		for _, tok := range SplitChunks(word) {
+			h := fnv.New32a()
+			h.Write([]byte(tok))
+			sum := h.Sum32()
+			sign := 1.0
+			if sum&1 == 1 {
+				sign = -1
+			}
+			v[(sum>>1)%MockEmbeddingDim] += sign
+		}
+	}
+	norm := 0.0
+	for _, x := range v {
+		norm += x * x
+	}
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range v {
+			v[i] /= norm
+		}
+	}
+	return v
+}
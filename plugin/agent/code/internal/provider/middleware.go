@@ -0,0 +1,25 @@
+package provider
+
+import "context"
+
+// Middleware 拦截器：包装下一层 Client，可以在请求前后改写消息、直接返回（如缓存命中）、
+// 重试或记录用量；与超时控制一样以装饰的方式组合
+type Middleware func(next Client) Client
+
+// ClientFunc 把函数当作 Client 使用，便于用闭包编写拦截器
+type ClientFunc func(ctx context.Context, messages []Message, onDelta func(string)) (string, error)
+
+// Chat 调用函数本身
+func (f ClientFunc) Chat(ctx context.Context, messages []Message, onDelta func(string)) (string, error) {
+	return f(ctx, messages, onDelta)
+}
+
+// Chain 依次套上拦截器：mws[0] 在最外层，最先收到请求、最后拿到回答；nil 拦截器跳过
+func Chain(client Client, mws ...Middleware) Client {
+	for i := len(mws) - 1; i >= 0; i-- {
+		if mws[i] != nil {
+			client = mws[i](client)
+		}
+	}
+	return client
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// MockScheme mock provider 的 api_base 前缀："mock://" 为回声模式，
+	// "mock://<脚本路径>" 从 JSON 脚本回放预设回复
+	MockScheme = "mock://"
+	// defaultMockDelay 流式模式下每个片段之间的默认间隔
+	defaultMockDelay = 20 * time.Millisecond
+)
+
+// MockScript mock 回放脚本
+//
+//	{
+//	  "delay_ms": 30,
+//	  "responses": [
+//	    {"match": "(?i)hello", "reply": "Hi!"},
+//	    {"reply": "第一轮回复"},
+//	    {"reply": "第二轮回复"},
+//	    {"match": "boom", "error": "simulated failure"},
+//	    {"match": "files", "tool": {"name": "fs__list", "arguments": {"path": "."}}, "reply": "目录中有：{{result}}"}
+//	  ]
+//	}
+//
+// 选择规则：先按顺序匹配带 match 正则的条目（作用于最后一条 user 消息）；
+// 都不匹配时，在不带 match 的条目中按对话轮次（第 N 条 user 消息）依次回放，循环使用；
+// 脚本没有可用条目时回声输出用户消息。带 tool 的条目在请求附带工具时先要求调用该工具，
+// 拿到结果后再回复 reply，其中的 {{result}} 替换为工具结果。
+type MockScript struct {
+	DelayMs   int            `json:"delay_ms"`
+	Responses []MockResponse `json:"responses"`
+}
+
+// MockResponse 单条预设回复
+type MockResponse struct {
+	Match string `json:"match,omitempty"`
+	Reply string `json:"reply,omitempty"`
+	Error string `json:"error,omitempty"`
+	// Status 非 0 时以该 HTTP 状态码返回错误（模拟限流、服务端故障等），error 为错误信息
+	Status int `json:"status,omitempty"`
+	// Tool 回复前要求调用的工具
+	Tool *MockToolCall `json:"tool,omitempty"`
+}
+
+// MockToolCall mock 回复要求的工具调用
+type MockToolCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// LoadMockScript 读取回放脚本，路径为空时返回空脚本（回声模式）
+func LoadMockScript(path string) (MockScript, error) {
+	var script MockScript
+	if path == "" {
+		return script, nil
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return script, fmt.Errorf("read mock script: %w", err)
+	}
+	if err := json.Unmarshal(data, &script); err != nil {
+		return script, fmt.Errorf("parse mock script %s: %w", path, err)
+	}
+	for _, r := range script.Responses {
+		if r.Match == "" {
+			continue
+		}
+		if _, err := regexp.Compile(r.Match); err != nil {
+			return script, fmt.Errorf("mock script %s: invalid match %q: %w", path, r.Match, err)
+		}
+	}
+	return script, nil
+}
+
+// Delay 流式片段间隔
+func (s MockScript) Delay() time.Duration {
+	if s.DelayMs > 0 {
+		return time.Duration(s.DelayMs) * time.Millisecond
+	}
+	return defaultMockDelay
+}
+
+// Respond 按选择规则决定对这组消息的回复
+func (s MockScript) Respond(messages []Message) (string, error) {
+	r, ok := s.pick(messages)
+	if !ok {
+		return lastUser(messages), nil
+	}
+	return r.result(messages)
+}
+
+// pick 按选择规则选出条目，脚本没有可用条目（回声模式）时返回 false
+func (s MockScript) pick(messages []Message) (MockResponse, bool) {
+	last, turn := lastUser(messages), 0
+	for _, m := range messages {
+		if m.Role == "user" {
+			turn++
+		}
+	}
+	var fallback []MockResponse
+	for _, r := range s.Responses {
+		if r.Match == "" {
+			fallback = append(fallback, r)
+			continue
+		}
+		if regexp.MustCompile(r.Match).MatchString(last) {
+			return r, true
+		}
+	}
+	if len(fallback) == 0 {
+		return MockResponse{}, false
+	}
+	return fallback[max(turn-1, 0)%len(fallback)], true
+}
+
+func lastUser(messages []Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+func (r MockResponse) result(messages []Message) (string, error) {
+	if r.Status != 0 {
+		return "", &StatusError{Code: r.Status, Status: fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)), Message: r.Error}
+	}
+	if r.Error != "" {
+		return "", errors.New(r.Error)
+	}
+	result := ""
+	if n := len(messages); n > 0 && messages[n-1].Role == "tool" {
+		result = messages[n-1].Content
+	}
+	return strings.ReplaceAll(r.Reply, "{{result}}", result), nil
+}
+
+// SplitChunks 把回复切成"单词 + 其后空白"的片段，用于模拟流式输出；中日韩字符逐字切分
+func SplitChunks(text string) []string {
+	var chunks []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+		}
+	}
+	inSpace := false
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			inSpace = true
+			cur.WriteRune(r)
+		case r >= 0x2E80:
+			flush()
+			inSpace = false
+			chunks = append(chunks, string(r))
+		default:
+			if inSpace {
+				flush()
+				inSpace = false
+			}
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return chunks
+}
+
+// mockClient 进程内 mock 客户端
+type mockClient struct {
+	script    MockScript
+	stream    bool
+	maxTokens int
+	stop      []string
+}
+
+func newMock(path string, opts Options) (*mockClient, error) {
+	script, err := LoadMockScript(path)
+	if err != nil {
+		return nil, err
+	}
+	return &mockClient{script: script, stream: opts.Stream, maxTokens: opts.MaxTokens, stop: opts.Stop}, nil
+}
+
+// Limit 模拟服务端的停止序列与 token 上限：在第一个停止序列处截止，
+// 再按片段数（每个片段视为一个 token）截断；返回截断后的回复及是否因上限被截断
+func Limit(reply string, maxTokens int, stop []string) (string, bool) {
+	for _, s := range stop {
+		if i := strings.Index(reply, s); s != "" && i >= 0 {
+			reply = reply[:i]
+		}
+	}
+	if maxTokens <= 0 {
+		return reply, false
+	}
+	chunks := SplitChunks(reply)
+	if len(chunks) <= maxTokens {
+		return reply, false
+	}
+	return strings.Join(chunks[:maxTokens], ""), true
+}
+
+func (c *mockClient) Chat(ctx context.Context, messages []Message, onDelta func(string)) (string, error) {
+	// 条目带 tool 且还没有工具结果时，本轮只要求调用工具
+	if tools := roundTools(ctx); tools != nil && !tools.final {
+		r, ok := c.script.pick(messages)
+		if ok && r.Tool != nil && messages[len(messages)-1].Role != "tool" {
+			args := string(r.Tool.Arguments)
+			if args == "" {
+				args = "{}"
+			}
+			tools.calls = []ToolCall{{ID: "call_mock", Type: "function", Function: FunctionCall{r.Tool.Name, args}}}
+			return "", nil
+		}
+	}
+	reply, err := c.script.Respond(messages)
+	if err != nil {
+		return "", err
+	}
+	reply, truncated := Limit(reply, c.maxTokens, c.stop)
+	if truncated {
+		err = ErrTruncated
+	}
+	if !c.stream {
+		if onDelta != nil {
+			onDelta(reply)
+		}
+		return reply, err
+	}
+	var sb strings.Builder
+	for _, chunk := range SplitChunks(reply) {
+		select {
+		case <-ctx.Done():
+			return sb.String(), ctx.Err()
+		case <-time.After(c.script.Delay()):
+		}
+		sb.WriteString(chunk)
+		if onDelta != nil {
+			onDelta(chunk)
+		}
+	}
+	return sb.String(), err
+}
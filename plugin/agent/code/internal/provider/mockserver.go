@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// MockHandler OpenAI 兼容的 mock 服务端，按脚本回放 /chat/completions 请求，
+// 并模拟 max_tokens（按片段计数，超出时 finish_reason 为 "length"）与 stop 停止序列。
+// 任意客户端（包括 Rust 端的 chat TUI）把 api_base 指向它即可离线开发与联调。
+func MockHandler(script MockScript) http.Handler {
+	mux := http.NewServeMux()
+	handle := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeMockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		reply, err := script.Respond(req.Messages)
+		if err != nil {
+			writeMockError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		reply, truncated := Limit(reply, req.MaxTokens, req.Stop)
+		finish := "stop"
+		if truncated {
+			finish = "length"
+		}
+		if !req.Stream {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"object":  "chat.completion",
+				"model":   req.Model,
+				"choices": []map[string]any{{"index": 0, "message": Message{Role: "assistant", Content: reply}, "finish_reason": finish}},
+			})
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		flusher, _ := w.(http.Flusher)
+		for _, chunk := range SplitChunks(reply) {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(script.Delay()):
+			}
+			data, _ := json.Marshal(map[string]any{
+				"object":  "chat.completion.chunk",
+				"model":   req.Model,
+				"choices": []map[string]any{{"index": 0, "delta": map[string]string{"content": chunk}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		data, _ := json.Marshal(map[string]any{
+			"object":  "chat.completion.chunk",
+			"model":   req.Model,
+			"choices": []map[string]any{{"index": 0, "delta": map[string]string{}, "finish_reason": finish}},
+		})
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	mux.HandleFunc("/chat/completions", handle)
+	mux.HandleFunc("/v1/chat/completions", handle)
+	mux.HandleFunc("/embeddings", handleEmbeddings)
+	mux.HandleFunc("/v1/embeddings", handleEmbeddings)
+	return mux
+}
+
+// handleEmbeddings 以 MockEmbedding 生成确定性向量，响应格式与 OpenAI /embeddings 一致
+func handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req embeddingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	data := make([]map[string]any, len(req.Input))
+	for i, text := range req.Input {
+		data[i] = map[string]any{"object": "embedding", "index": i, "embedding": MockEmbedding(text)}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"object": "list", "model": req.Model, "data": data})
+}
+
+func writeMockError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: apiError{Message: message}})
+}
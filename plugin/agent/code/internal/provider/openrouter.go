@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"wcp_agent/internal/config"
+)
+
+const (
+	// openRouterReferer 与 openRouterTitle 是 OpenRouter 用于识别调用方应用的请求头取值
+	openRouterReferer = "https://github.com/LingoJack/j"
+	openRouterTitle   = "j"
+)
+
+// routingPrefs 请求中的 provider 字段：OpenRouter 选择上游服务商的偏好
+type routingPrefs struct {
+	Order          []string `json:"order,omitempty"`
+	Only           []string `json:"only,omitempty"`
+	Ignore         []string `json:"ignore,omitempty"`
+	AllowFallbacks *bool    `json:"allow_fallbacks,omitempty"`
+	Sort           string   `json:"sort,omitempty"`
+	DataCollection string   `json:"data_collection,omitempty"`
+}
+
+// newOpenRouter OpenRouter：OpenAI 兼容接口，另外带上应用标识请求头与 openrouter 配置中的路由偏好
+// （models 为 OpenRouter 端的模型回退列表，其余字段决定上游服务商）
+func newOpenRouter(p config.Provider, opts Options) (*openAIClient, error) {
+	c, err := newOpenAI(p, opts)
+	if err != nil {
+		return nil, err
+	}
+	auth := c.authorize
+	c.authorize = func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("HTTP-Referer", openRouterReferer)
+		req.Header.Set("X-Title", openRouterTitle)
+		return auth(ctx, req)
+	}
+	if r := p.OpenRouter; r != nil {
+		c.models = r.Models
+		prefs := routingPrefs{Order: r.Order, Only: r.Only, Ignore: r.Ignore, AllowFallbacks: r.AllowFallbacks,
+			Sort: r.Sort, DataCollection: r.DataCollection}
+		if prefs.Order != nil || prefs.Only != nil || prefs.Ignore != nil || prefs.AllowFallbacks != nil ||
+			prefs.Sort != "" || prefs.DataCollection != "" {
+			c.routing = &prefs
+		}
+	}
+	return c, nil
+}
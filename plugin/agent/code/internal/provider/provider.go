@@ -0,0 +1,157 @@
+// Package provider 实现对话模型客户端：OpenAI 兼容的 Chat Completions（流式 SSE /
+// 非流式，Azure OpenAI、Vertex AI 与 OpenRouter 也走这一格式）、AWS Bedrock 的 Converse 接口，
+// 以及用于离线开发和确定性测试的 mock 实现。
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// Message 对话消息
+type Message struct {
+	Role    string `json:"role"` // "system" | "user" | "assistant" | "tool"
+	Content string `json:"content"`
+	// Images 随消息发送的图片（data URL），非空时按多模态格式序列化
+	Images []string `json:"-"`
+	// ToolCalls assistant 消息中模型要求的工具调用
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID tool 消息对应的工具调用
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// contentPart 多模态消息的一段内容
+type contentPart struct {
+	Type     string    `json:"type"` // "text" | "image_url"
+	Text     string    `json:"text,omitempty"`
+	ImageURL *imageURL `json:"image_url,omitempty"`
+}
+
+type imageURL struct {
+	URL string `json:"url"`
+}
+
+// MarshalJSON 带图片的消息按 OpenAI 视觉格式输出：content 为 text + image_url 数组
+func (m Message) MarshalJSON() ([]byte, error) {
+	if len(m.Images) == 0 {
+		type plain Message
+		return json.Marshal(plain(m))
+	}
+	parts := []contentPart{{Type: "text", Text: m.Content}}
+	for _, url := range m.Images {
+		parts = append(parts, contentPart{Type: "image_url", ImageURL: &imageURL{URL: url}})
+	}
+	return json.Marshal(struct {
+		Role    string        `json:"role"`
+		Content []contentPart `json:"content"`
+	}{m.Role, parts})
+}
+
+// JSONSchema 结构化输出使用的 schema
+type JSONSchema struct {
+	Name   string
+	Schema json.RawMessage
+}
+
+// openAIOnly 只有 OpenAI 兼容接口提供的功能（向量、语音）在其他类型的 provider 上报错
+func openAIOnly(p config.Provider, feature string) error {
+	if p.Type == "" || p.Type == config.ProviderOpenAI {
+		return nil
+	}
+	return errors.New(i18n.T(i18n.MsgProviderUnsupported, p.Name, feature, p.Type))
+}
+
+type apiKeyKey struct{}
+
+// WithAPIKey 让本次请求改用 key 认证，覆盖创建客户端时的 Options.Key（多个 Key 轮换时由拦截器按请求选择）
+func WithAPIKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, key)
+}
+
+// requestKey 本次请求使用的 API Key：WithAPIKey 指定的，否则为 key
+func requestKey(ctx context.Context, key string) string {
+	if k, ok := ctx.Value(apiKeyKey{}).(string); ok && k != "" {
+		return k
+	}
+	return key
+}
+
+// ErrTruncated 回答因达到 token 上限（finish_reason 为 "length"）被截断；
+// 与之一同返回的回答是有效的部分内容，调用方可追加 "继续" 请求取回余下部分
+var ErrTruncated = errors.New("response truncated by the token limit")
+
+// Client 对话模型客户端
+type Client interface {
+	// Chat 发送对话并返回完整回复；流式模式下每收到一段增量就回调 onDelta。
+	// 回复被 token 上限截断时返回已收到的内容和 ErrTruncated
+	Chat(ctx context.Context, messages []Message, onDelta func(string)) (string, error)
+}
+
+// Options 创建客户端所需的运行参数
+type Options struct {
+	Key       string          // API Key，由调用方通过 auth.Resolve 解析
+	Stream    bool            // 是否流式输出
+	Timeouts  config.Timeouts // 生效的超时配置（见 AgentConfig.EffectiveTimeouts）
+	Sampling  config.Sampling // 采样参数，发送前应先经 ValidateSampling 校验
+	MaxTokens int             // 回答的最大 token 数，0 表示不限制
+	Stop      []string        // 停止序列，生成到任一序列时结束
+	Schema    *JSONSchema     // 非空时请求原生结构化输出（response_format: json_schema）
+	// Keys 配置了多个 API Key 时的全部 Key（第一个即 Key），由拦截器按 key_rotation 为每次请求选择；只有一个时为空
+	Keys []string
+	// MaxContextTokens 发送的消息估算 token 数的上限，0 表示按 setting 中的 agent_max_context_tokens，负数表示不限制
+	MaxContextTokens int
+	// NoRedact 为 true 时本次请求跳过脱敏（--no-redact）
+	NoRedact bool
+	// RedactPatterns 在内置与自定义模式之外额外的脱敏模式（项目 .j.toml 中的 redact.patterns）
+	RedactPatterns []string
+	// Offline 为 true 时只使用缓存的回答与本地 provider（--offline）
+	Offline bool
+	// OverrideBudget 为 true 时本月估算费用超出预算也照常发送（--override-budget）
+	OverrideBudget bool
+	// NoCache 为 true 时不读取缓存的回答，总是重新请求（agent history regen）；完整的新回答仍会写入缓存
+	NoCache bool
+	// Tools 非空时模型可在回答过程中调用这些工具（见 tools.go）
+	Tools *Tools `json:"-"`
+	// HTTP 非空时复用该 HTTP 客户端（守护进程借此跨请求保持连接），否则按 provider 配置新建
+	HTTP *http.Client `json:"-"`
+}
+
+// New 根据 provider 配置创建客户端，按 type 选择接口。
+// api_base 以 mock:// 开头时返回 mock 客户端，不发起任何网络请求。
+func New(p config.Provider, opts Options) (Client, error) {
+	var (
+		client Client
+		err    error
+	)
+	switch {
+	case strings.HasPrefix(p.APIBase, MockScheme):
+		client, err = newMock(strings.TrimPrefix(p.APIBase, MockScheme), opts)
+	case p.Type == "" || p.Type == config.ProviderOpenAI:
+		client, err = newOpenAI(p, opts)
+	case p.Type == config.ProviderOpenRouter:
+		client, err = newOpenRouter(p, opts)
+	case p.Type == config.ProviderAzure:
+		client, err = newAzure(p, opts)
+	case p.Type == config.ProviderBedrock:
+		client, err = newBedrock(p, opts)
+	case p.Type == config.ProviderVertex:
+		client, err = newVertex(p, opts)
+	default:
+		err = errors.New(i18n.T(i18n.MsgProviderUnknownType, p.Name, p.Type))
+	}
+	if err != nil {
+		return nil, err
+	}
+	client = withTimeouts(client, opts.Timeouts, opts.Stream)
+	if opts.Tools != nil {
+		// 超时按每一轮请求计算，执行工具的时间不计入首 token 超时
+		client = withTools(client, opts.Tools)
+	}
+	return client, nil
+}
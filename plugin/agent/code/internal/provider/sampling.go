@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// MaxTemperature provider 允许的 temperature 上限：优先取配置的 max_temperature，
+// 否则 Anthropic（api_base 或模型名含 anthropic / claude）为 1，其余 OpenAI 兼容接口为 2
+func MaxTemperature(p config.Provider) float64 {
+	if p.MaxTemperature > 0 {
+		return p.MaxTemperature
+	}
+	id := strings.ToLower(p.APIBase + " " + p.Model)
+	if strings.Contains(id, "anthropic") || strings.Contains(id, "claude") {
+		return 1
+	}
+	return 2
+}
+
+// ValidateSampling 校验采样参数是否在 provider 支持的范围内
+func ValidateSampling(p config.Provider, s config.Sampling) error {
+	if t := s.Temperature; t != nil {
+		if limit := MaxTemperature(p); *t < 0 || *t > limit {
+			return errors.New(i18n.T(i18n.MsgSamplingTemperature, *t, limit, p.Name))
+		}
+	}
+	if t := s.TopP; t != nil && (*t <= 0 || *t > 1) {
+		return errors.New(i18n.T(i18n.MsgSamplingTopP, *t))
+	}
+	if t := s.Seed; t != nil && *t < 0 {
+		return errors.New(i18n.T(i18n.MsgSamplingSeed, *t))
+	}
+	return nil
+}
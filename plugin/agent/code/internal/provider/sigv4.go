@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// awsCredentials AWS 访问凭据
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// loadAWSCredentials 依次读取环境变量 AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY（及 AWS_SESSION_TOKEN）
+// 与共享凭据文件（AWS_SHARED_CREDENTIALS_FILE，默认 ~/.aws/credentials）中 profile 一节，都没有时返回 false
+func loadAWSCredentials(profile string) (awsCredentials, bool) {
+	if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" && secret != "" {
+		return awsCredentials{id, secret, os.Getenv("AWS_SESSION_TOKEN")}, true
+	}
+	path := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if path == "" {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, ".aws", "credentials")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return awsCredentials{}, false
+	}
+	defer f.Close()
+	var c awsCredentials
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || section != profile {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "aws_access_key_id":
+			c.AccessKeyID = strings.TrimSpace(value)
+		case "aws_secret_access_key":
+			c.SecretAccessKey = strings.TrimSpace(value)
+		case "aws_session_token":
+			c.SessionToken = strings.TrimSpace(value)
+		}
+	}
+	return c, c.AccessKeyID != "" && c.SecretAccessKey != ""
+}
+
+// awsProfile 读取共享凭据文件时的 profile：配置的 aws_profile，其次 AWS_PROFILE，再为 default
+func awsProfile(configured string) string {
+	for _, p := range []string{configured, os.Getenv("AWS_PROFILE")} {
+		if p != "" {
+			return p
+		}
+	}
+	return "default"
+}
+
+// signV4 按 AWS Signature Version 4 为请求签名（body 为请求体），
+// 签入 host、content-type、x-amz-date 与临时凭据的 x-amz-security-token
+func signV4(req *http.Request, body []byte, creds awsCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	// 除 S3 外的服务要求对已编码的路径再编码一次
+	segments := strings.Split(req.URL.EscapedPath(), "/")
+	for i, s := range segments {
+		segments[i] = awsEscape(s)
+	}
+	canonical := strings.Join([]string{
+		req.Method,
+		strings.Join(segments, "/"),
+		canonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonical))
+	key := []byte("AWS4" + creds.SecretAccessKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+hex.EncodeToString(hmacSHA256(key, toSign)))
+}
+
+// canonicalQuery 按键排序、逐项编码的查询串
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	var pairs []string
+	for key, values := range query {
+		for _, v := range values {
+			pairs = append(pairs, awsEscape(key)+"="+awsEscape(v))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// awsEscape SigV4 的 URI 编码：除 A-Z a-z 0-9 - _ . ~ 以外的字节都编码为 %XX
+func awsEscape(s string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hexDigits[c>>4])
+		b.WriteByte(hexDigits[c&15])
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"wcp_agent/internal/config"
+)
+
+// speechRequest /audio/speech 请求体
+type speechRequest struct {
+	Model          string  `json:"model"`
+	Input          string  `json:"input"`
+	Voice          string  `json:"voice"`
+	Speed          float64 `json:"speed,omitempty"`
+	ResponseFormat string  `json:"response_format"`
+}
+
+// Speech 调用 OpenAI 兼容的 /audio/speech 接口合成语音，返回 mp3 数据。
+// api_base 为 mock:// 时不发请求，返回空数据（调用方据此跳过播放）。
+func Speech(ctx context.Context, p config.Provider, opts Options, t config.TTS, text string) ([]byte, error) {
+	if strings.HasPrefix(p.APIBase, MockScheme) {
+		return nil, nil
+	}
+	if err := openAIOnly(p, "speech"); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(speechRequest{Model: t.Model, Input: text, Voice: t.Voice, Speed: t.Speed, ResponseFormat: "mp3"})
+	if err != nil {
+		return nil, err
+	}
+	client, err := HTTPClient(p, opts.Timeouts)
+	if err != nil {
+		return nil, err
+	}
+	if total := seconds(opts.Timeouts.TotalSecs); total > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, total)
+		defer cancel()
+	}
+	url := strings.TrimRight(p.APIBase, "/") + "/audio/speech"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if opts.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Key)
+	}
+	req, span := traceRequest(req, t.Model)
+	defer span.End()
+	resp, err := client.Do(req)
+	traceResponse(span, resp, err)
+	if err != nil {
+		var te *TimeoutError
+		if errors.As(err, &te) {
+			return nil, te
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp)
+	}
+	return io.ReadAll(resp.Body)
+}
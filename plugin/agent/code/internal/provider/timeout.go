@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// TimeoutError 请求超时，Stage 标明是哪一段超时
+type TimeoutError struct {
+	Stage string // "connect" | "first_token" | "total"
+	Limit time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	switch e.Stage {
+	case "connect":
+		return i18n.T(i18n.MsgTimeoutConnect, e.Limit)
+	case "first_token":
+		return i18n.T(i18n.MsgTimeoutFirstToken, e.Limit)
+	default:
+		return i18n.T(i18n.MsgTimeoutTotal, e.Limit)
+	}
+}
+
+// seconds 将配置中的秒数转换为时长，<= 0 表示不限制
+func seconds(n int) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	return time.Duration(n) * time.Second
+}
+
+// timeoutClient 为任意 Client 加上首 token 超时与总超时
+type timeoutClient struct {
+	next       Client
+	firstToken time.Duration
+	total      time.Duration
+	stream     bool
+}
+
+func withTimeouts(next Client, t config.Timeouts, stream bool) Client {
+	return &timeoutClient{
+		next:       next,
+		firstToken: seconds(t.FirstTokenSecs),
+		total:      seconds(t.TotalSecs),
+		stream:     stream,
+	}
+}
+
+func (c *timeoutClient) Chat(ctx context.Context, messages []Message, onDelta func(string)) (string, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	if c.total > 0 {
+		timer := time.AfterFunc(c.total, func() {
+			cancel(&TimeoutError{Stage: "total", Limit: c.total})
+		})
+		defer timer.Stop()
+	}
+	var firstTimer *time.Timer
+	if c.stream && c.firstToken > 0 {
+		firstTimer = time.AfterFunc(c.firstToken, func() {
+			cancel(&TimeoutError{Stage: "first_token", Limit: c.firstToken})
+		})
+		defer firstTimer.Stop()
+	}
+
+	answer, err := c.next.Chat(ctx, messages, func(delta string) {
+		if firstTimer != nil {
+			firstTimer.Stop()
+		}
+		if onDelta != nil {
+			onDelta(delta)
+		}
+	})
+	var te *TimeoutError
+	if cause := context.Cause(ctx); errors.As(cause, &te) {
+		return answer, te
+	}
+	return answer, err
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ToolSpec 提供给模型的一个工具（OpenAI function calling 格式）
+type ToolSpec struct {
+	Name        string
+	Description string
+	// Parameters 参数的 JSON Schema，为空时视为不带参数
+	Parameters json.RawMessage
+}
+
+// Tools 对话中可供模型调用的工具
+type Tools struct {
+	Specs []ToolSpec
+	// Call 执行一次工具调用，返回交给模型的结果；返回错误时把错误信息作为结果交给模型
+	Call func(ctx context.Context, name string, arguments json.RawMessage) (string, error)
+	// MaxRounds 最多进行的工具调用轮数，用完后要求模型不再调用工具、直接回答
+	MaxRounds int
+}
+
+// ToolCall 模型要求的一次工具调用
+type ToolCall struct {
+	ID       string       `json:"id"`
+	Type     string       `json:"type"` // 固定为 "function"
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall 工具名与 JSON 编码的参数
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+type toolsKey struct{}
+
+// toolRound 一轮请求附带的工具；final 为 true 时要求模型不再调用工具（tool_choice: none）。
+// 客户端把模型要求的工具调用记入 calls
+type toolRound struct {
+	specs []ToolSpec
+	final bool
+	calls []ToolCall
+}
+
+// roundTools 取出本轮请求附带的工具，没有时返回 nil
+func roundTools(ctx context.Context) *toolRound {
+	r, _ := ctx.Value(toolsKey{}).(*toolRound)
+	return r
+}
+
+// toolClient 工具调用循环：模型要求调用工具时执行工具、附上结果再次请求，直到模型给出回答。
+// 各轮的回答文本依次拼接，与流式输出的内容一致
+type toolClient struct {
+	next  Client
+	tools *Tools
+}
+
+func withTools(next Client, tools *Tools) Client {
+	return &toolClient{next: next, tools: tools}
+}
+
+func (c *toolClient) Chat(ctx context.Context, messages []Message, onDelta func(string)) (string, error) {
+	messages = append([]Message{}, messages...)
+	var sb strings.Builder
+	for round := 0; ; round++ {
+		r := &toolRound{specs: c.tools.Specs, final: round >= c.tools.MaxRounds}
+		text, err := c.next.Chat(context.WithValue(ctx, toolsKey{}, r), messages, onDelta)
+		sb.WriteString(text)
+		if err != nil || len(r.calls) == 0 || r.final {
+			return sb.String(), err
+		}
+		messages = append(messages, Message{Role: "assistant", Content: text, ToolCalls: r.calls})
+		for _, call := range r.calls {
+			args := json.RawMessage(call.Function.Arguments)
+			if strings.TrimSpace(call.Function.Arguments) == "" {
+				args = json.RawMessage("{}")
+			}
+			result, err := c.tools.Call(ctx, call.Function.Name, args)
+			if ctx.Err() != nil {
+				return sb.String(), ctx.Err()
+			}
+			if err != nil {
+				result = fmt.Sprintf("error: %v", err)
+			}
+			messages = append(messages, Message{Role: "tool", Content: result, ToolCallID: call.ID})
+		}
+	}
+}
+
+// toolSpec 请求中的工具定义
+type toolSpec struct {
+	Type     string       `json:"type"`
+	Function functionSpec `json:"function"`
+}
+
+type functionSpec struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+func requestTools(specs []ToolSpec) []toolSpec {
+	var out []toolSpec
+	for _, s := range specs {
+		params := s.Parameters
+		if len(params) == 0 {
+			params = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+		out = append(out, toolSpec{Type: "function", Function: functionSpec{s.Name, s.Description, params}})
+	}
+	return out
+}
+
+// toolCallDelta 流式回复中工具调用的片段：同一 index 的 id、名称与参数分多段到达
+type toolCallDelta struct {
+	Index    int    `json:"index"`
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// mergeToolCalls 把工具调用片段按 index 拼接到 calls 中
+func mergeToolCalls(calls []ToolCall, deltas []toolCallDelta) []ToolCall {
+	for _, d := range deltas {
+		for len(calls) <= d.Index {
+			calls = append(calls, ToolCall{Type: "function"})
+		}
+		c := &calls[d.Index]
+		if d.ID != "" {
+			c.ID = d.ID
+		}
+		c.Function.Name += d.Function.Name
+		c.Function.Arguments += d.Function.Arguments
+	}
+	return calls
+}
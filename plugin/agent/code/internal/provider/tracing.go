@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+
+	"wcp_agent/internal/trace"
+)
+
+// traceRequest 为一次 HTTP 请求开始一个 span，并以 traceparent 头把链路传给服务端（自建网关可以接上）；
+// 未开启追踪时原样返回 req 与 nil span
+func traceRequest(req *http.Request, model string) (*http.Request, *trace.Span) {
+	ctx, span := trace.Start(req.Context(), req.Method+" "+req.URL.Path, trace.KindClient,
+		trace.String("http.request.method", req.Method),
+		trace.String("server.address", req.URL.Host),
+		trace.String("url.path", req.URL.Path),
+		trace.String("gen_ai.request.model", model))
+	if span == nil {
+		return req, nil
+	}
+	if req.ContentLength > 0 {
+		span.SetAttrs(trace.Int("http.request.body.size", int(req.ContentLength)))
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("traceparent", trace.Traceparent(ctx))
+	return req, span
+}
+
+// traceResponse 记下响应状态码，请求失败或状态码不是 200 时把 span 标记为失败
+func traceResponse(span *trace.Span, resp *http.Response, err error) {
+	if err != nil {
+		span.RecordError(err)
+		return
+	}
+	span.SetAttrs(trace.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode != http.StatusOK {
+		span.RecordError(errors.New(resp.Status))
+	}
+}
+
+// firstDelta 在收到第一段回答时记一个事件（首 token 延迟），之后照常回调 onDelta
+func firstDelta(span *trace.Span, onDelta func(string)) func(string) {
+	seen := false
+	return func(delta string) {
+		if !seen {
+			seen = true
+			span.AddEvent("first_token")
+		}
+		if onDelta != nil {
+			onDelta(delta)
+		}
+	}
+}
+
+// RecordError 把 span 标记为失败；回答被截断不算失败，只记为属性
+func RecordError(span *trace.Span, err error) {
+	if errors.Is(err, ErrTruncated) {
+		span.SetAttrs(trace.Bool("j.truncated", true))
+		return
+	}
+	span.RecordError(err)
+}
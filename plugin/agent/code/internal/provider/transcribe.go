@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wcp_agent/internal/config"
+)
+
+// Transcribe 调用 OpenAI 兼容的 /audio/transcriptions 接口把音频文件转成文字。
+// api_base 为 mock:// 时不发请求，直接返回包含文件名的固定文本，便于离线测试。
+func Transcribe(ctx context.Context, p config.Provider, opts Options, model, language, path string) (string, error) {
+	if strings.HasPrefix(p.APIBase, MockScheme) {
+		return "[mock transcript of " + filepath.Base(path) + "]", nil
+	}
+	if err := openAIOnly(p, "transcription"); err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	_ = w.WriteField("model", model)
+	_ = w.WriteField("response_format", "json")
+	if language != "" {
+		_ = w.WriteField("language", language)
+	}
+	part, err := w.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	client, err := HTTPClient(p, opts.Timeouts)
+	if err != nil {
+		return "", err
+	}
+	if total := seconds(opts.Timeouts.TotalSecs); total > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, total)
+		defer cancel()
+	}
+	url := strings.TrimRight(p.APIBase, "/") + "/audio/transcriptions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if opts.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Key)
+	}
+	req, span := traceRequest(req, model)
+	defer span.End()
+	resp, err := client.Do(req)
+	traceResponse(span, resp, err)
+	if err != nil {
+		var te *TimeoutError
+		if errors.As(err, &te) {
+			return "", te
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", statusError(resp)
+	}
+	var out struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.Text), nil
+}
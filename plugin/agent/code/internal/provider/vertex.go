@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+// newVertex Google Vertex AI：使用它的 OpenAI 兼容接口（endpoints/openapi），以应用默认凭据（ADC）换取的
+// access token 认证。模型名不含 "/" 时补上 google/ 前缀（如 gemini-2.0-flash → google/gemini-2.0-flash）
+func newVertex(p config.Provider, opts Options) (*openAIClient, error) {
+	c, err := newOpenAI(p, opts)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := loadADC()
+	if err != nil {
+		return nil, err
+	}
+	project := p.Project
+	for _, v := range []string{os.Getenv("GOOGLE_CLOUD_PROJECT"), creds.QuotaProjectID, creds.ProjectID} {
+		if project == "" {
+			project = v
+		}
+	}
+	if project == "" {
+		return nil, errors.New(i18n.T(i18n.MsgVertexNoProject, p.Name))
+	}
+	c.url = p.Endpoint() + "/v1/projects/" + url.PathEscape(project) + "/locations/" + url.PathEscape(p.VertexRegion()) +
+		"/endpoints/openapi/chat/completions"
+	if !strings.Contains(c.model, "/") {
+		c.model = "google/" + c.model
+	}
+	tokens := adcTokens(creds)
+	httpClient := c.http
+	c.authorize = func(ctx context.Context, req *http.Request) error {
+		token, err := tokens.token(ctx, httpClient)
+		if err != nil {
+			return errors.New(i18n.T(i18n.MsgVertexToken, err))
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	return c, nil
+}
@@ -0,0 +1,176 @@
+// Package ratelimit 实现按 provider 配置的客户端令牌桶限流。
+// 桶状态持久化在 ~/.jdata/agent/data/ratelimit/ 下，因此脚本里连续调用的多个
+// agent 进程共享同一份额度，不会因为每次都是"满桶"而打爆服务端 429。
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelock"
+)
+
+// state 持久化的桶余量
+type state struct {
+	Requests float64   `json:"requests"`
+	Tokens   float64   `json:"tokens"`
+	Updated  time.Time `json:"updated"`
+}
+
+// Limiter 单个 provider 的限流器，可在多个 goroutine 间共享
+type Limiter struct {
+	mu     sync.Mutex // 串行化同一进程内的读-改-写；跨进程由状态文件旁的文件锁保证
+	limits config.RateLimit
+	path   string
+}
+
+// New 创建限流器；未配置限额时返回 nil，nil Limiter 的所有方法都是空操作
+func New(provider string, limits *config.RateLimit) *Limiter {
+	if limits == nil || (limits.RequestsPerMinute <= 0 && limits.TokensPerMinute <= 0) {
+		return nil
+	}
+	name := strings.ToLower(strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(provider))
+	return &Limiter{
+		limits: *limits,
+		path:   filepath.Join(config.AgentDataDir(), "ratelimit", name+".json"),
+	}
+}
+
+// Wait 阻塞直到桶内同时有 1 个请求额度和 tokens 个 token 额度，然后扣除。
+// 需要等待时先回调 onWait 告知预计等待时长（用于 spinner 展示）。
+func (l *Limiter) Wait(ctx context.Context, tokens int, onWait func(time.Duration)) error {
+	if l == nil {
+		return nil
+	}
+	need := float64(tokens)
+	if tpm := float64(l.limits.TokensPerMinute); tpm > 0 && need > tpm {
+		// 单次请求超过整桶容量时按满桶处理，否则永远等不到
+		need = tpm
+	}
+	for {
+		var wait time.Duration
+		l.update(func(st *state) bool {
+			wait = l.deficit(*st, need)
+			if wait > 0 {
+				return false
+			}
+			if l.limits.RequestsPerMinute > 0 {
+				st.Requests--
+			}
+			if l.limits.TokensPerMinute > 0 {
+				st.Tokens -= need
+			}
+			return true
+		})
+		if wait <= 0 {
+			return nil
+		}
+		if onWait != nil {
+			onWait(wait)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Charge 请求完成后按实际用量补扣（或退还）token 额度，delta 可为负
+func (l *Limiter) Charge(delta int) {
+	if l == nil || l.limits.TokensPerMinute <= 0 || delta == 0 {
+		return
+	}
+	l.update(func(st *state) bool {
+		st.Tokens -= float64(delta)
+		return true
+	})
+}
+
+// update 持有文件锁完成一次读-改-写：fn 拿到补充后的余量并就地修改，返回 true 时写回。
+// 拿不到锁时（如另一个进程长时间持有）仍按读到的状态继续，限流只是尽力而为
+func (l *Limiter) update(fn func(st *state) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err == nil {
+		if unlock, err := filelock.Lock(l.path); err == nil {
+			defer unlock()
+		}
+	}
+	st := l.refill(time.Now())
+	if fn(&st) {
+		l.save(st)
+	}
+}
+
+// deficit 计算额度不足时还需等待多久
+func (l *Limiter) deficit(st state, need float64) time.Duration {
+	var wait float64
+	if rpm := float64(l.limits.RequestsPerMinute); rpm > 0 && st.Requests < 1 {
+		wait = math.Max(wait, (1-st.Requests)*60/rpm)
+	}
+	if tpm := float64(l.limits.TokensPerMinute); tpm > 0 && st.Tokens < need {
+		wait = math.Max(wait, (need-st.Tokens)*60/tpm)
+	}
+	return time.Duration(wait * float64(time.Second))
+}
+
+// refill 读取持久化状态并按流逝时间补充额度
+func (l *Limiter) refill(now time.Time) state {
+	st := state{
+		Requests: float64(l.limits.RequestsPerMinute),
+		Tokens:   float64(l.limits.TokensPerMinute),
+		Updated:  now,
+	}
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return st
+	}
+	var saved state
+	if json.Unmarshal(data, &saved) != nil || saved.Updated.IsZero() {
+		return st
+	}
+	elapsed := now.Sub(saved.Updated).Minutes()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	st.Requests = math.Min(st.Requests, saved.Requests+elapsed*float64(l.limits.RequestsPerMinute))
+	st.Tokens = math.Min(st.Tokens, saved.Tokens+elapsed*float64(l.limits.TokensPerMinute))
+	return st
+}
+
+// save 写回桶状态：先写临时文件再改名，其他进程不会读到写了一半的文件；失败时忽略（限流只是尽力而为）
+func (l *Limiter) save(st state) {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, l.path)
+}
+
+// EstimateTokens 粗略估算文本 token 数：ASCII 约 4 字符 1 token，其余字符各算 1 token
+func EstimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrNoJSON 回答中找不到可解析的 JSON
+var ErrNoJSON = errors.New("no JSON value found in the answer")
+
+// Extract 从模型回答中取出 JSON 值及其原文（保留键顺序，便于原样格式化输出）：先尝试整段解析，再尝试 ``` 代码块内容，
+// 最后从第一个 { 或 [ 起截取到与之配对的括号为止（容忍前后的说明文字）
+func Extract(answer string) (any, string, error) {
+	answer = strings.TrimSpace(answer)
+	candidates := []string{answer}
+	if i := strings.Index(answer, "```"); i >= 0 {
+		rest := answer[i+3:]
+		if nl := strings.IndexByte(rest, '\n'); nl >= 0 {
+			rest = rest[nl+1:]
+		}
+		if j := strings.Index(rest, "```"); j >= 0 {
+			candidates = append(candidates, rest[:j])
+		}
+	}
+	if span := bracketSpan(answer); span != "" {
+		candidates = append(candidates, span)
+	}
+	var lastErr error = ErrNoJSON
+	for _, c := range candidates {
+		var v any
+		c = strings.TrimSpace(c)
+		if err := json.Unmarshal([]byte(c), &v); err == nil {
+			return v, c, nil
+		} else if c != answer {
+			lastErr = err
+		}
+	}
+	return nil, "", lastErr
+}
+
+// bracketSpan 截取第一个 { / [ 到其配对括号之间的文本，跳过字符串内的括号
+func bracketSpan(s string) string {
+	start := strings.IndexAny(s, "{[")
+	if start < 0 {
+		return ""
+	}
+	depth, inString, escaped := 0, false, false
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+			if depth == 0 {
+				return s[start : i+1]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,295 @@
+// Package schema 实现 ask --schema 所需的 JSON Schema 子集校验，以及从模型回答中提取 JSON。
+//
+// 支持的关键字：type、enum、const、properties、required、additionalProperties、
+// items、minItems、maxItems、minLength、maxLength、pattern、minimum、maximum、
+// exclusiveMinimum、exclusiveMaximum、anyOf、oneOf、allOf，以及指向 #/$defs、#/definitions 的 $ref。
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema 已加载的 JSON Schema
+type Schema struct {
+	Raw  json.RawMessage // 原始文本，原样发给支持结构化输出的 provider
+	root map[string]any
+	name string
+}
+
+// Load 读取并解析 schema 文件
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	name, _ := root["title"].(string)
+	return &Schema{Raw: json.RawMessage(data), root: root, name: name}, nil
+}
+
+// Name 结构化输出请求中的 schema 名称：取 title 中的字母数字，缺省为 "response"
+func (s *Schema) Name() string {
+	var b strings.Builder
+	for _, r := range s.name {
+		if r < utf8.RuneSelf && (r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "response"
+	}
+	return b.String()
+}
+
+// Validate 校验 v，返回所有不符合之处（形如 "$.items[0].name: expected string"），为空表示通过
+func (s *Schema) Validate(v any) []string {
+	var errs []string
+	s.check(s.root, v, "$", &errs)
+	return errs
+}
+
+func (s *Schema) check(node map[string]any, v any, path string, errs *[]string) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, path+": "+fmt.Sprintf(format, args...))
+	}
+	if ref, ok := node["$ref"].(string); ok {
+		target := s.resolve(ref)
+		if target == nil {
+			fail("unresolvable $ref %q", ref)
+			return
+		}
+		s.check(target, v, path, errs)
+		return
+	}
+
+	if t, ok := node["type"]; ok && !matchesType(t, v) {
+		fail("expected %s, got %s", typeNames(t), typeOf(v))
+		return
+	}
+	if enum, ok := node["enum"].([]any); ok && !contains(enum, v) {
+		fail("must be one of %s", compact(enum))
+	}
+	if c, ok := node["const"]; ok && !equal(c, v) {
+		fail("must equal %s", compact(c))
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		props, _ := node["properties"].(map[string]any)
+		if req, ok := node["required"].([]any); ok {
+			for _, r := range req {
+				if name, ok := r.(string); ok {
+					if _, present := val[name]; !present {
+						fail("missing required property %q", name)
+					}
+				}
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := path + "." + k
+			if sub, ok := props[k].(map[string]any); ok {
+				s.check(sub, val[k], child, errs)
+				continue
+			}
+			switch extra := node["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					fail("unexpected property %q", k)
+				}
+			case map[string]any:
+				s.check(extra, val[k], child, errs)
+			}
+		}
+	case []any:
+		if n, ok := number(node["minItems"]); ok && float64(len(val)) < n {
+			fail("must have at least %g items", n)
+		}
+		if n, ok := number(node["maxItems"]); ok && float64(len(val)) > n {
+			fail("must have at most %g items", n)
+		}
+		if items, ok := node["items"].(map[string]any); ok {
+			for i, item := range val {
+				s.check(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case string:
+		n := float64(utf8.RuneCountInString(val))
+		if m, ok := number(node["minLength"]); ok && n < m {
+			fail("must be at least %g characters", m)
+		}
+		if m, ok := number(node["maxLength"]); ok && n > m {
+			fail("must be at most %g characters", m)
+		}
+		if p, ok := node["pattern"].(string); ok {
+			if re, err := regexp.Compile(p); err == nil && !re.MatchString(val) {
+				fail("must match pattern %q", p)
+			}
+		}
+	case float64:
+		if m, ok := number(node["minimum"]); ok && val < m {
+			fail("must be >= %g", m)
+		}
+		if m, ok := number(node["maximum"]); ok && val > m {
+			fail("must be <= %g", m)
+		}
+		if m, ok := number(node["exclusiveMinimum"]); ok && val <= m {
+			fail("must be > %g", m)
+		}
+		if m, ok := number(node["exclusiveMaximum"]); ok && val >= m {
+			fail("must be < %g", m)
+		}
+	}
+
+	if all, ok := node["allOf"].([]any); ok {
+		for _, sub := range all {
+			if m, ok := sub.(map[string]any); ok {
+				s.check(m, v, path, errs)
+			}
+		}
+	}
+	if anyOf, ok := node["anyOf"].([]any); ok && s.countMatches(anyOf, v, path) == 0 {
+		fail("must match at least one schema in anyOf")
+	}
+	if oneOf, ok := node["oneOf"].([]any); ok {
+		if n := s.countMatches(oneOf, v, path); n != 1 {
+			fail("must match exactly one schema in oneOf (matched %d)", n)
+		}
+	}
+}
+
+// countMatches 统计 v 满足了几个子 schema
+func (s *Schema) countMatches(subs []any, v any, path string) int {
+	n := 0
+	for _, sub := range subs {
+		m, ok := sub.(map[string]any)
+		if !ok {
+			continue
+		}
+		var errs []string
+		s.check(m, v, path, &errs)
+		if len(errs) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// resolve 解析本文档内的 $ref（#/$defs/x、#/definitions/x 或任意 JSON Pointer）
+func (s *Schema) resolve(ref string) map[string]any {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil
+	}
+	var cur any = s.root
+	for _, part := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[part]
+	}
+	m, _ := cur.(map[string]any)
+	return m
+}
+
+// matchesType type 可以是单个类型名或类型名数组
+func matchesType(t any, v any) bool {
+	switch t := t.(type) {
+	case string:
+		return isType(t, v)
+	case []any:
+		for _, name := range t {
+			if s, ok := name.(string); ok && isType(s, v) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func isType(name string, v any) bool {
+	switch name {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	default:
+		return typeOf(v) == name
+	}
+}
+
+// typeOf JSON 值的类型名
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func typeNames(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, n := range list {
+			names = append(names, fmt.Sprint(n))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func number(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func contains(list []any, v any) bool {
+	for _, item := range list {
+		if equal(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal 以规范化 JSON 比较两个值（Go 的 map 序列化按键排序）
+func equal(a, b any) bool {
+	return compact(a) == compact(b)
+}
+
+func compact(v any) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,127 @@
+// Package session 在内存中保存多轮会话，供守护进程与 HTTP API 共用：
+// 每次请求前补上同名会话的历史消息，完成后记入本轮问答。会话不落盘，进程退出即清空。
+package session
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"wcp_agent/internal/provider"
+)
+
+// MaxMessages 每个会话保留的最近消息条数（不含 system 消息）
+const MaxMessages = 40
+
+// Info 会话概要
+type Info struct {
+	Name     string    `json:"name"`
+	Messages int       `json:"messages"`
+	Updated  time.Time `json:"updated"`
+}
+
+type session struct {
+	messages []provider.Message
+	updated  time.Time
+}
+
+// Store 会话表，可并发使用
+type Store struct {
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// NewStore 创建空的会话表
+func NewStore() *Store {
+	return &Store{sessions: map[string]*session{}}
+}
+
+// Compose 在本轮消息前补上会话 name 的历史：开头的 system 消息保持在最前，其后是历史，再是本轮其余的消息。
+// 返回实际发送的消息与本轮消息（不含开头的 system 消息，完成后交给 Remember）
+func (s *Store) Compose(name string, messages []provider.Message) (all, turn []provider.Message) {
+	var system []provider.Message
+	for _, m := range messages {
+		if m.Role == "system" && len(turn) == 0 {
+			system = append(system, m)
+		} else {
+			turn = append(turn, m)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[name]
+	if !ok {
+		return messages, turn
+	}
+	all = append(append([]provider.Message{}, system...), sess.messages...)
+	return append(all, turn...), turn
+}
+
+// Remember 把本轮消息与回答记入会话，只保留最近 MaxMessages 条
+func (s *Store) Remember(name string, turn []provider.Message, answer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[name]
+	if !ok {
+		sess = &session{}
+		s.sessions[name] = sess
+	}
+	for _, m := range append(turn, provider.Message{Role: "assistant", Content: answer}) {
+		m.Images = nil // 图片只随当轮发送，不在之后的请求中重复发送
+		sess.messages = append(sess.messages, m)
+	}
+	if over := len(sess.messages) - MaxMessages; over > 0 {
+		sess.messages = sess.messages[over:]
+	}
+	sess.updated = time.Now()
+}
+
+// Replace 把会话 name 开头的 old 这几条消息换成 with（如较早消息的摘要）；
+// 期间会话已有变化（被删除，或开头的消息已按 MaxMessages 丢弃）时不做改动并返回 false
+func (s *Store) Replace(name string, old []provider.Message, with ...provider.Message) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[name]
+	if !ok || len(sess.messages) < len(old) {
+		return false
+	}
+	for i, m := range old {
+		if sess.messages[i].Role != m.Role || sess.messages[i].Content != m.Content {
+			return false
+		}
+	}
+	sess.messages = append(append([]provider.Message{}, with...), sess.messages[len(old):]...)
+	return true
+}
+
+// List 按名称排序的会话概要
+func (s *Store) List() []Info {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]Info, 0, len(s.sessions))
+	for name, sess := range s.sessions {
+		list = append(list, Info{Name: name, Messages: len(sess.messages), Updated: sess.updated})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Messages 会话中保存的消息
+func (s *Store) Messages(name string) ([]provider.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[name]
+	if !ok {
+		return nil, false
+	}
+	return append([]provider.Message{}, sess.messages...), true
+}
+
+// Delete 删除会话，不存在时返回 false
+func (s *Store) Delete(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.sessions[name]
+	delete(s.sessions, name)
+	return ok
+}
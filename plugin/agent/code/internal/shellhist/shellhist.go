@@ -0,0 +1,203 @@
+// Package shellhist 读取 shell 的历史文件（zsh、bash、fish），取最近的若干条命令并脱敏，
+// 作为提问的背景，让"刚才那条为什么失败"这类问题不必手动粘贴命令。
+package shellhist
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"wcp_agent/internal/intercept"
+)
+
+// maxReadBytes 只读取历史文件末尾的这些字节，历史很长时也不必整个读入
+const maxReadBytes = 512 << 10
+
+// Entries 最近的命令（最早的在前）与所读取的历史文件
+type Entries struct {
+	Path     string
+	Commands []string
+	Redacted int // 脱敏替换的处数
+}
+
+// extraSecrets 历史中常见、intercept.Redact 未覆盖的密钥写法：URL 中的账号密码、命令行上的密码参数
+var extraSecrets = []*regexp.Regexp{
+	regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s/:@]+:([^\s/@]+)@`),
+	regexp.MustCompile(`(?i)--password(?:=|\s+)(\S+)`),
+	regexp.MustCompile(`(?i)\b[A-Z0-9_]*(?:TOKEN|SECRET|PASSWORD|PASSWD|API_KEY|ACCESS_KEY)[A-Z0-9_]*=(\S+)`),
+}
+
+// Recent 读取 shell（zsh / bash / fish，为空时取 $SHELL）最近的 n 条命令并脱敏；
+// skip 判断的命令（如本次调用自身）不计入。历史文件由 $HISTFILE 指定，否则取各 shell 的默认位置
+func Recent(shell string, n int, skip func(string) bool) (Entries, error) {
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+	}
+	path := historyFile(shell)
+	data, err := readTail(path)
+	if err != nil {
+		return Entries{Path: path}, err
+	}
+	var commands []string
+	switch shell {
+	case "fish":
+		commands = parseFish(data)
+	case "zsh":
+		commands = parseZsh(unmetafy(data))
+	default:
+		commands = parseBash(data)
+	}
+	out := Entries{Path: path}
+	for i := len(commands) - 1; i >= 0 && len(out.Commands) < n; i-- {
+		c := strings.TrimSpace(commands[i])
+		if c == "" || skip != nil && skip(c) {
+			continue
+		}
+		c, count := redact(c)
+		out.Redacted += count
+		out.Commands = append(out.Commands, c)
+	}
+	for i, j := 0, len(out.Commands)-1; i < j; i, j = i+1, j-1 {
+		out.Commands[i], out.Commands[j] = out.Commands[j], out.Commands[i]
+	}
+	return out, nil
+}
+
+// historyFile 历史文件路径：$HISTFILE（zsh、bash）或各 shell 的默认位置
+func historyFile(shell string) string {
+	home, _ := os.UserHomeDir()
+	switch shell {
+	case "fish":
+		dir := os.Getenv("XDG_DATA_HOME")
+		if dir == "" {
+			dir = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dir, "fish", "fish_history")
+	case "zsh":
+		if f := os.Getenv("HISTFILE"); f != "" {
+			return f
+		}
+		return filepath.Join(home, ".zsh_history")
+	}
+	if f := os.Getenv("HISTFILE"); f != "" {
+		return f
+	}
+	return filepath.Join(home, ".bash_history")
+}
+
+// readTail 读取文件末尾至多 maxReadBytes 字节，丢掉被截断的首行
+func readTail(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := max(info.Size()-maxReadBytes, 0)
+	data := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(data, offset); err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	return data, nil
+}
+
+// unmetafy 还原 zsh 历史文件中转义的字节（0x83 后的字节异或 32）
+func unmetafy(data []byte) []byte {
+	if bytes.IndexByte(data, 0x83) < 0 {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] == 0x83 && i+1 < len(data) {
+			i++
+			out = append(out, data[i]^32)
+			continue
+		}
+		out = append(out, data[i])
+	}
+	return out
+}
+
+// parseZsh 解析 zsh 历史：扩展格式为 ": 时间:耗时;命令"，多行命令以行尾的反斜杠续行
+func parseZsh(data []byte) []string {
+	var commands []string
+	var cur []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if cur == nil {
+			if strings.HasPrefix(line, ": ") {
+				if i := strings.IndexByte(line, ';'); i >= 0 {
+					line = line[i+1:]
+				}
+			}
+		}
+		if strings.HasSuffix(line, `\`) {
+			cur = append(cur, strings.TrimSuffix(line, `\`))
+			continue
+		}
+		cur = append(cur, line)
+		commands = append(commands, strings.Join(cur, "\n"))
+		cur = nil
+	}
+	return commands
+}
+
+// parseBash 解析 bash 历史：每行一条，HISTTIMEFORMAT 写入的 "#时间" 行跳过
+func parseBash(data []byte) []string {
+	var commands []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if isTimestamp(line) {
+			continue
+		}
+		commands = append(commands, line)
+	}
+	return commands
+}
+
+func isTimestamp(line string) bool {
+	if len(line) < 2 || line[0] != '#' {
+		return false
+	}
+	for _, c := range line[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFish 解析 fish 历史（类 YAML）："- cmd: 命令"，命令中的换行与反斜杠被转义为 \n、\\
+func parseFish(data []byte) []string {
+	var commands []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if c, ok := strings.CutPrefix(line, "- cmd: "); ok {
+			commands = append(commands, strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(c))
+		}
+	}
+	return commands
+}
+
+// redact 去掉命令中疑似密钥的内容：intercept.Redact 的规则加上 extraSecrets
+func redact(command string) (string, int) {
+	command, count := intercept.Redact(command)
+	for _, re := range extraSecrets {
+		command = re.ReplaceAllStringFunc(command, func(match string) string {
+			loc := re.FindStringSubmatchIndex(match)
+			if match[loc[2]:loc[3]] == intercept.Redacted {
+				return match
+			}
+			count++
+			return match[:loc[2]] + intercept.Redacted + match[loc[3]:]
+		})
+	}
+	return command, count
+}
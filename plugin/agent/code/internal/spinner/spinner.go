@@ -0,0 +1,74 @@
+// Package spinner 在等待模型首个 token 时于 stderr 显示加载动画，
+// 非终端环境下完全静默，避免污染管道输出；无障碍模式下不播放动画、不就地重绘，只把文案输出一行。
+package spinner
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/a11y"
+)
+
+var frames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// Spinner 加载动画，零值不可用，请通过 Start 创建
+type Spinner struct {
+	mu      sync.Mutex
+	message string
+	done    chan struct{}
+	stopped sync.Once
+	wg      sync.WaitGroup
+}
+
+// Start 开始显示带文案的加载动画
+func Start(message string) *Spinner {
+	s := &Spinner{message: message, done: make(chan struct{})}
+	if !term.IsTerminal(int(os.Stderr.Fd())) {
+		return s
+	}
+	if a11y.Enabled() {
+		// 读屏软件会反复朗读就地刷新的行，只说一次正在做什么；之后的进度更新不再输出
+		fmt.Fprintln(os.Stderr, message)
+		return s
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return s
+}
+
+// Set 更新动画旁的文案
+func (s *Spinner) Set(message string) {
+	s.mu.Lock()
+	s.message = message
+	s.mu.Unlock()
+}
+
+// Stop 停止动画并清除当前行，可重复调用
+func (s *Spinner) Stop() {
+	s.stopped.Do(func() {
+		close(s.done)
+		s.wg.Wait()
+	})
+}
+
+func (s *Spinner) loop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(80 * time.Millisecond)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		msg := s.message
+		s.mu.Unlock()
+		fmt.Fprintf(os.Stderr, "\r\033[K%c %s", frames[i%len(frames)], msg)
+		select {
+		case <-s.done:
+			fmt.Fprint(os.Stderr, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package stats 记录本地匿名使用统计（命令次数、耗时、渲染体积），
+// 仅在用户显式开启时写入 ~/.jdata/stats.json，绝不上传到任何地方。
+// 文件格式与 md_render 插件共享，两个插件各自累加自己的计数。
+package stats
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+)
+
+const (
+	// EnableEnv 设置为 1/true/on 时开启统计（优先级高于配置文件）
+	EnableEnv = "J_STATS"
+	// SettingStats config.yaml 中 setting 段的开关项
+	SettingStats = "stats"
+	// FileName 统计文件名，位于数据根目录
+	FileName = "stats.json"
+)
+
+// Counter 单个命令的调用次数与耗时
+type Counter struct {
+	Count   int64 `json:"count"`
+	TotalMs int64 `json:"total_ms"`
+	MaxMs   int64 `json:"max_ms"`
+}
+
+// AvgMs 平均耗时
+func (c Counter) AvgMs() int64 {
+	if c.Count == 0 {
+		return 0
+	}
+	return c.TotalMs / c.Count
+}
+
+// RenderCounter Markdown 渲染体积统计（由 md_render 插件写入）
+type RenderCounter struct {
+	Count      int64 `json:"count"`
+	TotalBytes int64 `json:"total_bytes"`
+	MaxBytes   int64 `json:"max_bytes"`
+}
+
+// Usage 单个 provider 的请求用量（token 数按本地编码统计，没有编码文件时按字符估算），Cost 按 provider 的 pricing 计算
+type Usage struct {
+	Requests     int64   `json:"requests"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	Cost         float64 `json:"cost,omitempty"`
+}
+
+// Stats 统计文件内容
+type Stats struct {
+	Since    time.Time           `json:"since"`
+	Commands map[string]*Counter `json:"commands"`
+	Render   RenderCounter       `json:"render"`
+	Usage    map[string]*Usage   `json:"usage,omitempty"`
+}
+
+// Enabled 是否开启了统计：J_STATS > config.yaml 的 setting.stats，默认关闭
+func Enabled() bool {
+	v := os.Getenv(EnableEnv)
+	if v == "" {
+		v = config.Setting(SettingStats)
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "on", "yes":
+		return true
+	}
+	return false
+}
+
+// Path 统计文件路径
+func Path() string {
+	return filepath.Join(config.DataDir(), FileName)
+}
+
+// Load 读取统计文件，不存在时返回空统计
+func Load() (Stats, error) {
+	st := Stats{Commands: map[string]*Counter{}}
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return st, err
+	}
+	if st.Commands == nil {
+		st.Commands = map[string]*Counter{}
+	}
+	return st, nil
+}
+
+// Reset 删除统计文件
+func Reset() error {
+	err := os.Remove(Path())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// RecordCommand 记录一次命令调用，未开启统计时什么也不做；写入失败静默忽略
+func RecordCommand(name string, elapsed time.Duration) {
+	if !Enabled() {
+		return
+	}
+	st, err := Load()
+	if err != nil {
+		return
+	}
+	if st.Since.IsZero() {
+		st.Since = time.Now()
+	}
+	c := st.Commands[name]
+	if c == nil {
+		c = &Counter{}
+		st.Commands[name] = c
+	}
+	ms := elapsed.Milliseconds()
+	c.Count++
+	c.TotalMs += ms
+	if ms > c.MaxMs {
+		c.MaxMs = ms
+	}
+	save(st)
+}
+
+// RecordUsage 累加一次模型请求的用量，未开启统计时什么也不做；写入失败静默忽略
+func RecordUsage(provider string, input, output int, cost float64) {
+	if !Enabled() {
+		return
+	}
+	st, err := Load()
+	if err != nil {
+		return
+	}
+	if st.Since.IsZero() {
+		st.Since = time.Now()
+	}
+	if st.Usage == nil {
+		st.Usage = map[string]*Usage{}
+	}
+	u := st.Usage[provider]
+	if u == nil {
+		u = &Usage{}
+		st.Usage[provider] = u
+	}
+	u.Requests++
+	u.InputTokens += int64(input)
+	u.OutputTokens += int64(output)
+	u.Cost += cost
+	save(st)
+}
+
+func save(st Stats) {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.MkdirAll(filepath.Dir(Path()), 0o755)
+	_ = os.WriteFile(Path(), data, 0o644)
+}
@@ -0,0 +1,98 @@
+package stt
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"wcp_agent/internal/i18n"
+)
+
+// recorder 录音命令模板，out 为输出的 WAV 文件路径
+type recorder struct {
+	name string
+	args func(out string) []string
+}
+
+// recorders 按优先级排列的录音工具，统一录成 16kHz 单声道 16bit WAV
+var recorders = []recorder{
+	{"rec", func(out string) []string { return []string{"-q", "-c", "1", "-r", "16000", "-b", "16", out} }},
+	{"arecord", func(out string) []string { return []string{"-q", "-f", "S16_LE", "-r", "16000", "-c", "1", out} }},
+	{"ffmpeg", func(out string) []string {
+		input := []string{"-f", "pulse", "-i", "default"}
+		if runtime.GOOS == "darwin" {
+			input = []string{"-f", "avfoundation", "-i", ":0"}
+		}
+		args := append([]string{"-y", "-loglevel", "error"}, input...)
+		return append(args, "-ar", "16000", "-ac", "1", "-c:a", "pcm_s16le", out)
+	}},
+}
+
+// Record 从麦克风录音直到用户按下回车（或 ctx 取消），返回临时 WAV 文件及清理函数
+func Record(ctx context.Context) (string, func(), error) {
+	var rec *recorder
+	for i := range recorders {
+		if _, err := exec.LookPath(recorders[i].name); err == nil {
+			rec = &recorders[i]
+			break
+		}
+	}
+	if rec == nil {
+		return "", nil, errors.New(i18n.T(i18n.MsgSTTNoRecorder))
+	}
+
+	f, err := os.CreateTemp("", "j-mic-*.wav")
+	if err != nil {
+		return "", nil, err
+	}
+	f.Close()
+	cleanup := func() { os.Remove(f.Name()) }
+
+	cmd := exec.Command(rec.name, rec.args(f.Name())...)
+	if err := cmd.Start(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	os.Stderr.WriteString(i18n.T(i18n.MsgSTTRecording) + "\n")
+
+	// stdin 可能被管道占用，优先从终端读取回车
+	in := os.Stdin
+	if tty, err := os.Open("/dev/tty"); err == nil {
+		defer tty.Close()
+		in = tty
+	}
+	enter := make(chan struct{})
+	go func() {
+		_, _ = bufio.NewReader(in).ReadString('\n')
+		close(enter)
+	}()
+	select {
+	case <-enter:
+	case <-ctx.Done():
+	}
+
+	// 发送 SIGINT 让录音工具补全 WAV 头后退出，超时再强制结束
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		_ = cmd.Process.Kill()
+	}
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		_ = cmd.Process.Kill()
+		<-done
+	}
+	if ctx.Err() != nil {
+		cleanup()
+		return "", nil, ctx.Err()
+	}
+	return f.Name(), cleanup, nil
+}
@@ -0,0 +1,135 @@
+// Package stt 把音频转成文字供 ask 作为 prompt：
+// 支持 OpenAI 兼容的 Whisper API 与本地 whisper.cpp 两种后端，并提供麦克风快速录音。
+package stt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+// Transcribe 按 stt 配置转写音频文件；openai 后端未指定 provider 时使用 fallback
+func Transcribe(ctx context.Context, cfg config.AgentConfig, fallback config.Provider, path string) (string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	s := cfg.EffectiveSTT()
+	var (
+		text string
+		err  error
+	)
+	switch s.Backend {
+	case config.STTOpenAI:
+		p := fallback
+		if s.Provider != "" {
+			idx := cfg.FindProvider(s.Provider)
+			if idx < 0 {
+				return "", errors.New(i18n.T(i18n.MsgAuthUnknown, s.Provider))
+			}
+			p = cfg.Providers[idx]
+		}
+		key, _ := auth.Resolve(p)
+		text, err = provider.Transcribe(ctx, p, provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(p)}, s.Model, s.Language, path)
+	case config.STTWhisperCpp:
+		text, err = whisperCpp(ctx, s, path)
+	default:
+		return "", errors.New(i18n.T(i18n.MsgSTTUnknownBackend, s.Backend))
+	}
+	if err != nil {
+		return "", err
+	}
+	if text = strings.TrimSpace(text); text == "" {
+		return "", errors.New(i18n.T(i18n.MsgSTTEmpty))
+	}
+	return text, nil
+}
+
+// whisperCpp 调用本地 whisper.cpp 转写；非 WAV 输入先用 ffmpeg 转成 16kHz 单声道 WAV
+func whisperCpp(ctx context.Context, s config.STT, path string) (string, error) {
+	if _, err := os.Stat(s.ModelPath); err != nil {
+		return "", errors.New(i18n.T(i18n.MsgSTTNoModel, s.ModelPath))
+	}
+	bin, err := lookTool(s.Binary)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".wav") {
+		wav, cleanup, err := toWav(ctx, path)
+		if err != nil {
+			return "", err
+		}
+		defer cleanup()
+		path = wav
+	}
+	lang := s.Language
+	if lang == "" {
+		lang = "auto"
+	}
+	// -nt 不输出时间戳，-np 只输出转写结果
+	out, err := run(ctx, bin, "-m", s.ModelPath, "-f", path, "-l", lang, "-nt", "-np")
+	if err != nil {
+		return "", err
+	}
+	return joinLines(out), nil
+}
+
+// toWav 用 ffmpeg 把任意音频转成 whisper.cpp 需要的 16kHz 单声道 16bit WAV
+func toWav(ctx context.Context, path string) (string, func(), error) {
+	ffmpeg, err := lookTool("ffmpeg")
+	if err != nil {
+		return "", nil, err
+	}
+	f, err := os.CreateTemp("", "j-stt-*.wav")
+	if err != nil {
+		return "", nil, err
+	}
+	f.Close()
+	cleanup := func() { os.Remove(f.Name()) }
+	if _, err := run(ctx, ffmpeg, "-y", "-loglevel", "error", "-i", path, "-ar", "16000", "-ac", "1", "-c:a", "pcm_s16le", f.Name()); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return f.Name(), cleanup, nil
+}
+
+// lookTool 在 PATH 中查找外部工具，找不到时给出带名称的提示
+func lookTool(name string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", errors.New(i18n.T(i18n.MsgSTTNoTool, filepath.Base(name), name))
+	}
+	return path, nil
+}
+
+// run 执行外部命令并返回 stdout，失败时附带 stderr 便于排查
+func run(ctx context.Context, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s", i18n.T(i18n.MsgSTTToolFailed, filepath.Base(name), err, strings.TrimSpace(stderr.String())))
+	}
+	return stdout.String(), nil
+}
+
+// joinLines whisper.cpp 按语音片段分行输出，合并为一段文字
+func joinLines(s string) string {
+	var parts []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			parts = append(parts, line)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,182 @@
+// Package templates 提示词模板：Go text/template 语法的文本文件，{{.name}} 引用变量（由 --var name=value 给出），
+// 放在 ~/.jdata/agent/templates/<名称>.md，按名称引用；也可以直接给出文件路径。
+// 模板用到的变量缺少任何一个时不会渲染出残缺的提示词，而是一次列出缺少的全部变量。
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+const (
+	// Ext 模板文件的扩展名，按名称引用时可以省略
+	Ext = ".md"
+	// InputVar 未用 --var 给出时取自命令行参数或管道输入的变量
+	InputVar = "Input"
+)
+
+// Template 解析后的模板
+type Template struct {
+	Name string
+	Path string
+	// Vars 模板引用的变量（按名称排序）
+	Vars []string
+	tmpl *template.Template
+}
+
+// Dir 模板目录: ~/.jdata/agent/templates/
+func Dir() string {
+	return filepath.Join(config.DataDir(), "agent", "templates")
+}
+
+// Resolve 模板文件路径：name 是已存在的文件时直接使用，否则在模板目录中查找 name 与 name.md
+func Resolve(name string) (string, error) {
+	if info, err := os.Stat(name); err == nil && !info.IsDir() {
+		return name, nil
+	}
+	if !strings.ContainsRune(name, filepath.Separator) && !strings.Contains(name, "/") {
+		for _, candidate := range []string{name, name + Ext} {
+			path := filepath.Join(Dir(), candidate)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+	}
+	return "", errors.New(i18n.T(i18n.MsgTemplateNotFound, name, Dir()))
+}
+
+// Load 按名称或路径读取并解析模板
+func Load(name string) (*Template, error) {
+	path, err := Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := Parse(strings.TrimSuffix(filepath.Base(path), Ext), string(data))
+	if err != nil {
+		return nil, err
+	}
+	t.Path = path
+	return t, nil
+}
+
+// Parse 解析模板文本
+func Parse(name, text string) (*Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.New(i18n.T(i18n.MsgTemplateBadSyntax, name, err))
+	}
+	seen := map[string]bool{}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			collect(t.Tree.Root, seen)
+		}
+	}
+	vars := make([]string, 0, len(seen))
+	for v := range seen {
+		vars = append(vars, v)
+	}
+	sort.Strings(vars)
+	return &Template{Name: name, Vars: vars, tmpl: tmpl}, nil
+}
+
+// Uses 模板是否引用了变量 name
+func (t *Template) Uses(name string) bool {
+	i := sort.SearchStrings(t.Vars, name)
+	return i < len(t.Vars) && t.Vars[i] == name
+}
+
+// Execute 用 vars 渲染模板；缺少变量时返回列出全部缺少变量的错误
+func (t *Template) Execute(vars map[string]string) (string, error) {
+	var missing []string
+	for _, v := range t.Vars {
+		if _, ok := vars[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return "", errors.New(i18n.T(i18n.MsgTemplateMissingVars, t.Name, strings.Join(missing, ", ")))
+	}
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, vars); err != nil {
+		return "", errors.New(i18n.T(i18n.MsgTemplateExecFailed, t.Name, err))
+	}
+	return b.String(), nil
+}
+
+// List 模板目录中的全部模板（按名称排序），无法解析的模板 Vars 为空
+func List() ([]Template, error) {
+	entries, err := os.ReadDir(Dir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []Template
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(Dir(), e.Name())
+		if t, err := Load(path); err == nil {
+			list = append(list, *t)
+		} else {
+			list = append(list, Template{Name: strings.TrimSuffix(e.Name(), Ext), Path: path})
+		}
+	}
+	return list, nil
+}
+
+// collect 收集以根数据（.）为对象引用的变量名；range / with 内部的 . 不再是根数据，只看它们的管道
+func collect(node parse.Node, seen map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			collect(c, seen)
+		}
+	case *parse.ActionNode:
+		collect(n.Pipe, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			collect(c, seen)
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			collect(a, seen)
+		}
+	case *parse.FieldNode:
+		seen[n.Ident[0]] = true
+	case *parse.ChainNode:
+		collect(n.Node, seen)
+	case *parse.IfNode:
+		collect(n.Pipe, seen)
+		collect(n.List, seen)
+		collect(n.ElseList, seen)
+	case *parse.RangeNode:
+		collect(n.Pipe, seen)
+		collect(n.ElseList, seen)
+	case *parse.WithNode:
+		collect(n.Pipe, seen)
+		collect(n.ElseList, seen)
+	case *parse.TemplateNode:
+		collect(n.Pipe, seen)
+	}
+}
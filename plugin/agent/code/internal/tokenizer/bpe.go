@@ -0,0 +1,160 @@
+package tokenizer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 各编码的预分词规则（与 tiktoken 相同）；RE2 不支持 tiktoken 中的 \s+(?!\S)，
+// 这里写作 \s+，由 split 把紧接非空白字符的空白段最后一个字符留给下一段
+var patterns = map[string]string{
+	CL100K: `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`,
+	O200K: `[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?` +
+		`|[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?` +
+		`|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+`,
+}
+
+// Encoding tiktoken 格式的字节级 BPE 编码
+type Encoding struct {
+	Name    string
+	ranks   map[string]int
+	pattern *regexp.Regexp
+}
+
+// parse 读取 .tiktoken 文件：每行为 base64 编码的字节序列与它的 rank
+func parse(name string, r io.Reader) (*Encoding, error) {
+	pattern, ok := patterns[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding %q", name)
+	}
+	ranks := make(map[string]int, 200_000)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		token, rank, ok := bytes.Cut(text, []byte(" "))
+		if !ok {
+			return nil, fmt.Errorf("%s.tiktoken line %d: missing rank", name, line)
+		}
+		b, err := base64.StdEncoding.DecodeString(string(token))
+		if err != nil {
+			return nil, fmt.Errorf("%s.tiktoken line %d: %w", name, line, err)
+		}
+		n, err := strconv.Atoi(string(rank))
+		if err != nil {
+			return nil, fmt.Errorf("%s.tiktoken line %d: %w", name, line, err)
+		}
+		ranks[string(b)] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(ranks) < 256 {
+		return nil, fmt.Errorf("%s.tiktoken: only %d tokens", name, len(ranks))
+	}
+	return &Encoding{Name: name, ranks: ranks, pattern: regexp.MustCompile(pattern)}, nil
+}
+
+// Count 文本编码后的 token 数（特殊标记如 <|endoftext|> 按普通文本计）
+func (e *Encoding) Count(text string) int {
+	n := 0
+	e.split(text, func(piece string) {
+		if _, ok := e.ranks[piece]; ok {
+			n++
+			return
+		}
+		n += e.merge(piece)
+	})
+	return n
+}
+
+// split 按预分词规则切分文本，依次回调每一段
+func (e *Encoding) split(text string, fn func(string)) {
+	for len(text) > 0 {
+		loc := e.pattern.FindStringIndex(text)
+		if loc == nil {
+			fn(text)
+			return
+		}
+		if loc[0] > 0 {
+			// 规则覆盖全部字符，不会出现；保险起见按一段处理
+			fn(text[:loc[0]])
+		}
+		end := loc[1]
+		piece := text[loc[0]:end]
+		// \s+(?!\S)：紧接非空白字符的多个空白，最后一个留给下一段（如 "  foo" 切为 " " 与 " foo"）
+		if end < len(text) && isSpaces(piece) && !isNewline(piece[len(piece)-1]) {
+			if r, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsSpace(r) {
+				if _, size := utf8.DecodeLastRuneInString(piece); size < len(piece) {
+					end -= size
+					piece = piece[:len(piece)-size]
+				}
+			}
+		}
+		fn(piece)
+		text = text[end:]
+	}
+}
+
+func isSpaces(s string) bool {
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNewline(b byte) bool {
+	return b == '\n' || b == '\r'
+}
+
+// merge 字节级 BPE：从单个字节开始，反复合并 rank 最小的相邻两段，返回最终的段数
+func (e *Encoding) merge(piece string) int {
+	// parts[i] 为第 i 段的起始位置，最后一个元素为 len(piece)
+	parts := make([]int, len(piece)+1)
+	for i := range parts {
+		parts[i] = i
+	}
+	rank := func(i int) int {
+		if i+2 >= len(parts) {
+			return math.MaxInt
+		}
+		if r, ok := e.ranks[piece[parts[i]:parts[i+2]]]; ok {
+			return r
+		}
+		return math.MaxInt
+	}
+	ranks := make([]int, len(parts)-1)
+	for i := range ranks {
+		ranks[i] = rank(i)
+	}
+	for len(parts) > 2 {
+		best, at := math.MaxInt, -1
+		for i, r := range ranks[:len(parts)-2] {
+			if r < best {
+				best, at = r, i
+			}
+		}
+		if at < 0 {
+			break
+		}
+		parts = append(parts[:at+1], parts[at+2:]...)
+		ranks = append(ranks[:at+1], ranks[at+2:]...)
+		ranks[at] = rank(at)
+		if at > 0 {
+			ranks[at-1] = rank(at - 1)
+		}
+	}
+	return len(parts) - 1
+}
@@ -0,0 +1,73 @@
+package tokenizer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// baseURL 编码文件的下载地址（与 tiktoken 相同）
+const baseURL = "https://openaipublic.blob.core.windows.net/encodings/"
+
+// checksums 编码文件的 SHA-256，下载后校验
+var checksums = map[string]string{
+	CL100K: "223921b76ee99bde995b7ff738513eef100fb51d18c93597a113bcffe865b2a7",
+	O200K:  "446a9538cb6c348e3516120d7c08b09f57c36495e2acfffe59a5bf8b0cfb1a2d",
+}
+
+// URL 编码文件的下载地址
+func URL(name string) string {
+	return baseURL + name + ".tiktoken"
+}
+
+// Known 是否为支持的编码
+func Known(name string) bool {
+	_, ok := checksums[name]
+	return ok
+}
+
+// Download 下载编码文件到 Path(name)，校验 SHA-256 后再替换原有的文件，返回文件大小
+func Download(ctx context.Context, client *http.Client, name string) (int64, error) {
+	sum, ok := checksums[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown encoding %q", name)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, URL(name), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("GET %s: %s", URL(name), resp.Status)
+	}
+	if err := os.MkdirAll(Dir(), 0o755); err != nil {
+		return 0, err
+	}
+	path := Path(name)
+	tmp, err := os.CreateTemp(filepath.Dir(path), name+"-*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), resp.Body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != sum {
+		return 0, fmt.Errorf("%s: checksum mismatch (got %s, want %s)", URL(name), got, sum)
+	}
+	return n, os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,174 @@
+// Package tokenizer 在本地统计文本的 token 数：使用与 tiktoken 相同的 BPE 编码（cl100k_base、o200k_base），
+// 编码文件由 agent tokens download 下载到 ~/.jdata/agent/tokenizers/，各 profile 共用。
+// OpenAI 的模型按官方对应的编码计数，其余模型默认借用 cl100k_base，结果是近似值但比按字符估算准确得多；
+// 编码文件不存在时退回按字符估算（见 ratelimit.EstimateTokens）。
+package tokenizer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/ratelimit"
+)
+
+// 支持的编码
+const (
+	CL100K = "cl100k_base" // GPT-4、GPT-3.5 与 text-embedding-3
+	O200K  = "o200k_base"  // GPT-4o、GPT-4.1、GPT-5 与 o 系列
+	// Estimate 写在 provider 的 tokenizer 中表示不使用本地编码，始终按字符估算
+	Estimate = "estimate"
+)
+
+// Encodings 全部支持的编码，agent tokens download 默认全部下载
+var Encodings = []string{CL100K, O200K}
+
+// modelEncodings 模型名前缀对应的编码，按顺序匹配（更具体的前缀在前）
+var modelEncodings = []struct{ prefix, encoding string }{
+	{"gpt-4o", O200K},
+	{"chatgpt-4o", O200K},
+	{"gpt-4.1", O200K},
+	{"gpt-4.5", O200K},
+	{"gpt-5", O200K},
+	{"gpt-oss", O200K},
+	{"o1", O200K},
+	{"o3", O200K},
+	{"o4", O200K},
+	{"gpt-4", CL100K},
+	{"gpt-3.5", CL100K},
+	{"text-embedding-", CL100K},
+}
+
+// contextWindows 常见模型的上下文窗口（token），按顺序匹配模型名前缀；其余模型在 provider 的 context_window 中配置
+var contextWindows = []struct {
+	prefix string
+	tokens int
+}{
+	{"gpt-4o", 128_000},
+	{"chatgpt-4o", 128_000},
+	{"gpt-4.1", 1_047_576},
+	{"gpt-4-turbo", 128_000},
+	{"gpt-4-32k", 32_768},
+	{"gpt-4", 8_192},
+	{"gpt-3.5-turbo", 16_385},
+	{"gpt-5", 400_000},
+	{"o1-mini", 128_000},
+	{"o1-preview", 128_000},
+	{"o1", 200_000},
+	{"o3", 200_000},
+	{"o4", 200_000},
+	{"claude", 200_000},
+}
+
+// Dir 编码文件目录: ~/.jdata/agent/tokenizers/
+func Dir() string {
+	return filepath.Join(config.RootDir(), "agent", "tokenizers")
+}
+
+// Path 编码文件路径
+func Path(name string) string {
+	return filepath.Join(Dir(), name+".tiktoken")
+}
+
+// model 去掉 "openai/" 这类前缀后的小写模型名
+func model(p config.Provider) string {
+	m := strings.ToLower(strings.TrimSpace(p.Model))
+	if i := strings.LastIndexByte(m, '/'); i >= 0 {
+		m = m[i+1:]
+	}
+	return m
+}
+
+// EncodingFor provider 使用的编码：provider 的 tokenizer 优先，其次按模型名推断，其余模型为 cl100k_base
+func EncodingFor(p config.Provider) string {
+	if p.Tokenizer != "" {
+		return p.Tokenizer
+	}
+	m := model(p)
+	for _, e := range modelEncodings {
+		if strings.HasPrefix(m, e.prefix) {
+			return e.encoding
+		}
+	}
+	return CL100K
+}
+
+// ContextWindow provider 模型的上下文窗口：provider 的 context_window 优先，其次查常见模型表，未知时为 0
+func ContextWindow(p config.Provider) int {
+	if p.ContextWindow > 0 {
+		return p.ContextWindow
+	}
+	m := model(p)
+	for _, w := range contextWindows {
+		if strings.HasPrefix(m, w.prefix) {
+			return w.tokens
+		}
+	}
+	return 0
+}
+
+// loaded 一个编码的加载结果，每个进程只读取一次
+type loaded struct {
+	once sync.Once
+	enc  *Encoding
+	err  error
+}
+
+var encodings sync.Map // 编码名 → *loaded
+
+// Load 读取编码文件，结果在进程内缓存
+func Load(name string) (*Encoding, error) {
+	v, _ := encodings.LoadOrStore(name, &loaded{})
+	l := v.(*loaded)
+	l.once.Do(func() {
+		f, err := os.Open(Path(name))
+		if err != nil {
+			l.err = err
+			return
+		}
+		defer f.Close()
+		l.enc, l.err = parse(name, f)
+	})
+	return l.enc, l.err
+}
+
+// Counter 统计 token 数：有本地编码时精确计数，否则按字符估算；零值即按字符估算
+type Counter struct {
+	enc *Encoding
+}
+
+// For provider p 的计数器；编码文件不存在或无法读取时退回按字符估算
+func For(p config.Provider) Counter {
+	name := EncodingFor(p)
+	if name == Estimate {
+		return Counter{}
+	}
+	enc, err := Load(name)
+	if err != nil {
+		return Counter{}
+	}
+	return Counter{enc: enc}
+}
+
+// Count 文本的 token 数
+func (c Counter) Count(text string) int {
+	if c.enc == nil {
+		return ratelimit.EstimateTokens(text)
+	}
+	return c.enc.Count(text)
+}
+
+// Local 是否使用本地编码计数（而非按字符估算）
+func (c Counter) Local() bool {
+	return c.enc != nil
+}
+
+// Encoding 使用的编码名，按字符估算时为空
+func (c Counter) Encoding() string {
+	if c.enc == nil {
+		return ""
+	}
+	return c.enc.Name
+}
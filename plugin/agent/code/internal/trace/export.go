@@ -0,0 +1,340 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+const (
+	// FileName 默认的本地追踪文件，位于数据根目录；每行一个 OTLP/JSON 的 ExportTraceServiceRequest
+	FileName = "traces.jsonl"
+	// DefaultEndpoint J_TRACE=otlp 且没有设置 OTEL_EXPORTER_OTLP_* 时使用的 collector 地址
+	DefaultEndpoint = "http://localhost:4318"
+	// serviceName 默认的 service.name，可用 OTEL_SERVICE_NAME 覆盖
+	serviceName = "j-agent"
+	// batchDelay 常驻进程（serve、daemon）中结束的 span 攒一会儿再导出
+	batchDelay = 2 * time.Second
+	// exportTimeout 向 collector 发送一批 span 的超时
+	exportTimeout = 5 * time.Second
+)
+
+// Target 导出目标：Endpoint 为 OTLP/HTTP 的 traces 地址，否则写入 File
+type Target struct {
+	Endpoint string
+	Headers  map[string]string
+	File     string
+}
+
+var (
+	targetOnce sync.Once
+	target     *Target
+
+	mu      sync.Mutex
+	pending []*Span
+	timer   *time.Timer
+)
+
+// Enabled 是否开启了追踪
+func Enabled() bool {
+	return CurrentTarget() != nil
+}
+
+// CurrentTarget 解析 J_TRACE > config.yaml 的 setting.trace（只解析一次），未开启时为 nil：
+//   - 1 / true / on / file：写入 ~/.jdata/traces.jsonl
+//   - otlp：发往 OTEL_EXPORTER_OTLP_TRACES_ENDPOINT / OTEL_EXPORTER_OTLP_ENDPOINT（默认 localhost:4318）
+//   - http(s):// 开头：发往该 collector
+//   - 其他取值：作为追踪文件路径
+func CurrentTarget() *Target {
+	targetOnce.Do(func() {
+		v := os.Getenv(EnableEnv)
+		if v == "" {
+			v = config.Setting(SettingTrace)
+		}
+		target = parseTarget(strings.TrimSpace(v))
+	})
+	return target
+}
+
+func parseTarget(v string) *Target {
+	switch strings.ToLower(v) {
+	case "", "0", "false", "off", "no":
+		return nil
+	case "1", "true", "on", "yes", "file":
+		return &Target{File: filepath.Join(config.DataDir(), FileName)}
+	case "otlp":
+		if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); endpoint != "" {
+			return &Target{Endpoint: endpoint, Headers: otlpHeaders()}
+		}
+		return &Target{Endpoint: tracesURL(firstNonEmpty(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"), DefaultEndpoint)), Headers: otlpHeaders()}
+	}
+	if strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") {
+		return &Target{Endpoint: tracesURL(v), Headers: otlpHeaders()}
+	}
+	if rest, ok := strings.CutPrefix(v, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			v = filepath.Join(home, rest)
+		}
+	}
+	return &Target{File: v}
+}
+
+// tracesURL collector 的基地址补上 /v1/traces（已带路径时原样使用）
+func tracesURL(base string) string {
+	u, err := url.Parse(base)
+	if err != nil || strings.Trim(u.Path, "/") != "" {
+		return base
+	}
+	return strings.TrimRight(base, "/") + "/v1/traces"
+}
+
+// otlpHeaders 解析 OTEL_EXPORTER_OTLP_HEADERS（k=v,k2=v2，取值可做 URL 编码）
+func otlpHeaders() map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"), ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			continue
+		}
+		if decoded, err := url.QueryUnescape(v); err == nil {
+			v = decoded
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers
+}
+
+// enqueue 收下结束的 span，batchDelay 后批量导出
+func enqueue(s *Span) {
+	mu.Lock()
+	defer mu.Unlock()
+	pending = append(pending, s)
+	if timer == nil {
+		timer = time.AfterFunc(batchDelay, func() { _ = Flush() })
+	}
+}
+
+// Flush 立即导出已结束的 span
+func Flush() error {
+	mu.Lock()
+	batch := pending
+	pending = nil
+	if timer != nil {
+		timer.Stop()
+		timer = nil
+	}
+	mu.Unlock()
+	t := CurrentTarget()
+	if len(batch) == 0 || t == nil {
+		return nil
+	}
+	data, err := json.Marshal(encode(batch))
+	if err != nil {
+		return err
+	}
+	if t.Endpoint != "" {
+		return post(t, data)
+	}
+	return appendFile(t.File, data)
+}
+
+// Shutdown 结束本进程的命令 span 并导出剩余的 span；导出失败时在 stderr 提示一行，不影响命令本身
+func Shutdown(err error) {
+	if process != nil {
+		process.RecordError(err)
+		process.End()
+	}
+	if ferr := Flush(); ferr != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTraceExport, ferr))
+	}
+}
+
+func post(t *Target, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// appendFile 以一次写入追加一行，多个进程同时写入时各行不会交错
+func appendFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// OTLP/JSON 编码（opentelemetry-proto 的 JSON 映射：id 为十六进制，64 位整数读写时数字与字符串均可）
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []wireSpan `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type wireSpan struct {
+	TraceID      string      `json:"traceId"`
+	SpanID       string      `json:"spanId"`
+	ParentSpanID string      `json:"parentSpanId,omitempty"`
+	Name         string      `json:"name"`
+	Kind         Kind        `json:"kind"`
+	Start        json.Number `json:"startTimeUnixNano"`
+	End          json.Number `json:"endTimeUnixNano"`
+	Attributes   []keyValue  `json:"attributes,omitempty"`
+	Events       []wireEvent `json:"events,omitempty"`
+	Status       wireStatus  `json:"status"`
+}
+
+type wireEvent struct {
+	Time       json.Number `json:"timeUnixNano"`
+	Name       string      `json:"name"`
+	Attributes []keyValue  `json:"attributes,omitempty"`
+}
+
+// wireStatus code：0 未设置，1 成功，2 失败
+type wireStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	String *string      `json:"stringValue,omitempty"`
+	Int    *json.Number `json:"intValue,omitempty"`
+	Double *float64     `json:"doubleValue,omitempty"`
+	Bool   *bool        `json:"boolValue,omitempty"`
+}
+
+func encode(spans []*Span) exportRequest {
+	name := firstNonEmpty(os.Getenv("OTEL_SERVICE_NAME"), serviceName)
+	host, _ := os.Hostname()
+	res := resource{Attributes: attributes([]Attr{
+		String("service.name", name),
+		String("host.name", host),
+		Int("process.pid", os.Getpid()),
+	})}
+	out := make([]wireSpan, len(spans))
+	for i, s := range spans {
+		s.mu.Lock()
+		w := wireSpan{
+			TraceID:    hex.EncodeToString(s.TraceID[:]),
+			SpanID:     hex.EncodeToString(s.SpanID[:]),
+			Name:       s.Name,
+			Kind:       s.Kind,
+			Start:      unixNano(s.StartTime),
+			End:        unixNano(s.EndTime),
+			Attributes: attributes(s.Attrs),
+			Status:     wireStatus{Code: 1},
+		}
+		if s.ParentID != [8]byte{} {
+			w.ParentSpanID = hex.EncodeToString(s.ParentID[:])
+		}
+		if s.Failed {
+			w.Status = wireStatus{Code: 2, Message: s.Message}
+		}
+		for _, e := range s.Events {
+			w.Events = append(w.Events, wireEvent{Time: unixNano(e.Time), Name: e.Name, Attributes: attributes(e.Attrs)})
+		}
+		s.mu.Unlock()
+		out[i] = w
+	}
+	return exportRequest{ResourceSpans: []resourceSpans{{
+		Resource:   res,
+		ScopeSpans: []scopeSpans{{Scope: scope{Name: "wcp_agent/internal/trace"}, Spans: out}},
+	}}}
+}
+
+func attributes(attrs []Attr) []keyValue {
+	out := make([]keyValue, 0, len(attrs))
+	for _, a := range attrs {
+		var v anyValue
+		switch x := a.Value.(type) {
+		case string:
+			v.String = &x
+		case int64:
+			n := json.Number(strconv.FormatInt(x, 10))
+			v.Int = &n
+		case float64:
+			v.Double = &x
+		case bool:
+			v.Bool = &x
+		default:
+			s := fmt.Sprint(x)
+			v.String = &s
+		}
+		out = append(out, keyValue{a.Key, v})
+	}
+	return out
+}
+
+func unixNano(t time.Time) json.Number {
+	return json.Number(strconv.FormatInt(t.UnixNano(), 10))
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Record 从追踪文件读回的 span
+type Record struct {
+	TraceID  string
+	SpanID   string
+	ParentID string
+	Name     string
+	Service  string
+	Start    time.Time
+	End      time.Time
+	Attrs    []Attr // 值均为字符串形式
+	Events   []Event
+	Failed   bool
+	Message  string
+}
+
+// Duration span 的耗时
+func (r Record) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Trace 一条链路：Spans 按开始时间排序
+type Trace struct {
+	ID    string
+	Spans []Record
+}
+
+// Start 链路中最早的开始时间
+func (t Trace) Start() time.Time {
+	return t.Spans[0].Start
+}
+
+// Duration 链路从最早开始到最晚结束的耗时
+func (t Trace) Duration() time.Duration {
+	end := t.Spans[0].End
+	for _, s := range t.Spans {
+		if s.End.After(end) {
+			end = s.End
+		}
+	}
+	return end.Sub(t.Start())
+}
+
+// Root 链路的根 span：父 span 不在本链路中的最早一个
+func (t Trace) Root() Record {
+	ids := make(map[string]bool, len(t.Spans))
+	for _, s := range t.Spans {
+		ids[s.SpanID] = true
+	}
+	for _, s := range t.Spans {
+		if !ids[s.ParentID] {
+			return s
+		}
+	}
+	return t.Spans[0]
+}
+
+// Load 读取追踪文件，按链路分组并按开始时间排序（最早的在前）；无法解析的行跳过
+func Load(path string) ([]Trace, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	byID := map[string]*Trace{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		var req exportRequest
+		if json.Unmarshal(scanner.Bytes(), &req) != nil {
+			continue
+		}
+		for _, rs := range req.ResourceSpans {
+			service := ""
+			for _, kv := range rs.Resource.Attributes {
+				if kv.Key == "service.name" {
+					service = kv.Value.text()
+				}
+			}
+			for _, ss := range rs.ScopeSpans {
+				for _, w := range ss.Spans {
+					t := byID[w.TraceID]
+					if t == nil {
+						t = &Trace{ID: w.TraceID}
+						byID[w.TraceID] = t
+					}
+					t.Spans = append(t.Spans, w.record(service))
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	traces := make([]Trace, 0, len(byID))
+	for _, t := range byID {
+		sort.SliceStable(t.Spans, func(i, j int) bool { return t.Spans[i].Start.Before(t.Spans[j].Start) })
+		traces = append(traces, *t)
+	}
+	sort.Slice(traces, func(i, j int) bool { return traces[i].Start().Before(traces[j].Start()) })
+	return traces, nil
+}
+
+func (w wireSpan) record(service string) Record {
+	r := Record{
+		TraceID:  w.TraceID,
+		SpanID:   w.SpanID,
+		ParentID: w.ParentSpanID,
+		Name:     w.Name,
+		Service:  service,
+		Start:    fromUnixNano(w.Start),
+		End:      fromUnixNano(w.End),
+		Attrs:    textAttrs(w.Attributes),
+		Failed:   w.Status.Code == 2,
+		Message:  w.Status.Message,
+	}
+	for _, e := range w.Events {
+		r.Events = append(r.Events, Event{Name: e.Name, Time: fromUnixNano(e.Time), Attrs: textAttrs(e.Attributes)})
+	}
+	return r
+}
+
+func textAttrs(kvs []keyValue) []Attr {
+	out := make([]Attr, len(kvs))
+	for i, kv := range kvs {
+		out[i] = Attr{kv.Key, kv.Value.text()}
+	}
+	return out
+}
+
+func (v anyValue) text() string {
+	switch {
+	case v.String != nil:
+		return *v.String
+	case v.Int != nil:
+		return v.Int.String()
+	case v.Double != nil:
+		return strconv.FormatFloat(*v.Double, 'g', -1, 64)
+	case v.Bool != nil:
+		return strconv.FormatBool(*v.Bool)
+	}
+	return ""
+}
+
+// fromUnixNano 解析十进制字符串（或 JSON 数字）形式的纳秒时间戳
+func fromUnixNano(v json.Number) time.Time {
+	n, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		if f, ferr := v.Float64(); ferr == nil {
+			n = int64(f)
+		}
+	}
+	return time.Unix(0, n)
+}
+
+// String 按 "key=value" 输出属性，用于列表展示
+func (a Attr) String() string {
+	return fmt.Sprintf("%s=%v", a.Key, a.Value)
+}
@@ -0,0 +1,256 @@
+// Package trace 记录 OpenTelemetry 格式的链路追踪：命令分发、插件协议往返（守护进程、MCP、md_render）、
+// provider HTTP 请求与渲染各记一个 span，导出到 OTLP/HTTP 端点或本地追踪文件，用来查明一次调用慢在哪里。
+// 默认关闭：J_TRACE 或 config.yaml 的 setting.trace 开启后才记录；关闭时 Start 返回 nil span，方法均可安全调用。
+// 只记录名称、耗时与少量属性（provider、模型、状态码、字节数），不记录提问与回答的内容。
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnableEnv 开启追踪并指定导出目标（优先级高于配置文件），取值见 Target
+	EnableEnv = "J_TRACE"
+	// SettingTrace config.yaml 中 setting 段的开关项
+	SettingTrace = "trace"
+	// ParentEnv 父进程传入的 W3C traceparent，子进程的 span 接在它下面
+	ParentEnv = "TRACEPARENT"
+)
+
+// Kind span 类型，取值与 OTLP 的 SpanKind 一致
+type Kind int
+
+const (
+	KindInternal Kind = 1
+	KindServer   Kind = 2
+	KindClient   Kind = 3
+)
+
+// Attr 一个 span 属性；值为 string、int64、float64 或 bool
+type Attr struct {
+	Key   string
+	Value any
+}
+
+func String(key, value string) Attr    { return Attr{key, value} }
+func Int(key string, value int) Attr   { return Attr{key, int64(value)} }
+func Bool(key string, value bool) Attr { return Attr{key, value} }
+
+// Event span 中的一个时间点（如首个 token、重试）
+type Event struct {
+	Name  string
+	Time  time.Time
+	Attrs []Attr
+}
+
+// Span 一段计时；nil 表示未开启追踪
+type Span struct {
+	TraceID   [16]byte
+	SpanID    [8]byte
+	ParentID  [8]byte // 全零表示根 span
+	Name      string
+	Kind      Kind
+	StartTime time.Time
+	EndTime   time.Time
+	Attrs     []Attr
+	Events    []Event
+	Failed    bool
+	Message   string // 失败原因
+
+	mu    sync.Mutex
+	ended bool
+}
+
+type spanKey struct{}
+
+var (
+	// process 本进程的命令 span，ctx 中没有 span 时作为父 span（子命令不必层层传递 ctx）
+	process *Span
+	// remote 由 TRACEPARENT 传入的父 span
+	remote     = parseParentEnv()
+	processOne sync.Once
+)
+
+func parseParentEnv() *Span {
+	id, parent, ok := ParseTraceparent(os.Getenv(ParentEnv))
+	if !ok {
+		return nil
+	}
+	return &Span{TraceID: id, SpanID: parent}
+}
+
+// StartProcess 开始本进程的命令 span，之后没有父 span 的 span 都挂在它下面；父进程通过 TRACEPARENT 传入时接在其后
+func StartProcess(name string, attrs ...Attr) *Span {
+	if !Enabled() {
+		return nil
+	}
+	var s *Span
+	processOne.Do(func() {
+		s = newSpan(remote, name, KindInternal, attrs)
+		process = s
+	})
+	return s
+}
+
+// Start 开始一个子 span：父 span 取自 ctx，没有时取本进程的命令 span
+func Start(ctx context.Context, name string, kind Kind, attrs ...Attr) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+	parent := FromContext(ctx)
+	if parent == nil {
+		parent = process
+	}
+	s := newSpan(parent, name, kind, attrs)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// StartRemote 以 traceparent（为空时新建一条链路）为父开始一个 span，用于常驻进程中每个请求单独成一条链路
+func StartRemote(ctx context.Context, traceparent, name string, kind Kind, attrs ...Attr) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+	var parent *Span
+	if id, spanID, ok := ParseTraceparent(traceparent); ok {
+		parent = &Span{TraceID: id, SpanID: spanID}
+	}
+	s := newSpan(parent, name, kind, attrs)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func newSpan(parent *Span, name string, kind Kind, attrs []Attr) *Span {
+	s := &Span{Name: name, Kind: kind, StartTime: time.Now(), Attrs: attrs}
+	_, _ = rand.Read(s.SpanID[:])
+	if parent != nil {
+		s.TraceID, s.ParentID = parent.TraceID, parent.SpanID
+	} else {
+		_, _ = rand.Read(s.TraceID[:])
+	}
+	return s
+}
+
+// FromContext ctx 中当前的 span
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// SetName 修改名称（如 HTTP 路由在分发后才知道）
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.Name = name
+	s.mu.Unlock()
+}
+
+// SetAttrs 追加属性
+func (s *Span) SetAttrs(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.Attrs = append(s.Attrs, attrs...)
+	s.mu.Unlock()
+}
+
+// AddEvent 记录一个时间点
+func (s *Span) AddEvent(name string, attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.Events = append(s.Events, Event{Name: name, Time: time.Now(), Attrs: attrs})
+	s.mu.Unlock()
+}
+
+// RecordError err 非空时把 span 标记为失败；取消不算失败，只记为属性
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if errors.Is(err, context.Canceled) {
+		s.Attrs = append(s.Attrs, Bool("cancelled", true))
+		return
+	}
+	s.Failed, s.Message = true, err.Error()
+}
+
+// End 结束计时并交给导出器；重复调用只生效一次
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended, s.EndTime = true, time.Now()
+	s.mu.Unlock()
+	enqueue(s)
+}
+
+// Duration span 的耗时
+func (s *Span) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// AddEvent 给 ctx 中当前的 span 记录一个时间点
+func AddEvent(ctx context.Context, name string, attrs ...Attr) {
+	FromContext(ctx).AddEvent(name, attrs...)
+}
+
+// Traceparent ctx 中当前 span（没有时为本进程的命令 span）的 W3C traceparent；未开启追踪时为空
+func Traceparent(ctx context.Context) string {
+	if !Enabled() {
+		return ""
+	}
+	s := FromContext(ctx)
+	if s == nil {
+		s = process
+	}
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.TraceID[:]), hex.EncodeToString(s.SpanID[:]))
+}
+
+// ParseTraceparent 解析 W3C traceparent（00-<trace-id>-<parent-id>-<flags>）
+func ParseTraceparent(v string) (traceID [16]byte, spanID [8]byte, ok bool) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return traceID, spanID, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(parts[1])); err != nil {
+		return traceID, spanID, false
+	}
+	if _, err := hex.Decode(spanID[:], []byte(parts[2])); err != nil {
+		return traceID, spanID, false
+	}
+	return traceID, spanID, traceID != [16]byte{} && spanID != [8]byte{}
+}
+
+// Inject 让子进程（插件、MCP 服务器）经 TRACEPARENT 接在 ctx 中当前的 span 之后
+func Inject(ctx context.Context, cmd *exec.Cmd) {
+	tp := Traceparent(ctx)
+	if tp == "" {
+		return
+	}
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, ParentEnv+"="+tp)
+}
@@ -0,0 +1,62 @@
+package tts
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	reImage    = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	reLink     = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	reInline   = regexp.MustCompile("`([^`]*)`")
+	reEmphasis = regexp.MustCompile(`(\*\*|__|\*|~~)`)
+	rePrefix   = regexp.MustCompile(`^\s*(#{1,6}\s+|>\s?|[-*+]\s+\[[ xX]\]\s+|[-*+]\s+|\d+[.)]\s+)`)
+	reRule     = regexp.MustCompile(`^\s*([-*_]\s*){3,}$`)
+)
+
+// Plain 把 Markdown 回答转成适合朗读的纯文本：
+// 去掉围栏代码块与表格，保留链接文字、行内代码内容，去掉标题、引用、列表等标记符号
+func Plain(markdown string) string {
+	var (
+		out   []string
+		fence string
+	)
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		if strings.HasPrefix(trimmed, "|") || reRule.MatchString(trimmed) {
+			continue
+		}
+		line = rePrefix.ReplaceAllString(line, "")
+		line = reImage.ReplaceAllString(line, "")
+		line = reLink.ReplaceAllString(line, "$1")
+		line = reInline.ReplaceAllString(line, "$1")
+		line = reEmphasis.ReplaceAllString(line, "")
+		out = append(out, strings.TrimSpace(line))
+	}
+	// 合并连续空行，段落之间保留一个空行作为停顿
+	var b strings.Builder
+	blank := true
+	for _, line := range out {
+		if line == "" {
+			if !blank {
+				b.WriteString("\n")
+			}
+			blank = true
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+		blank = false
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,136 @@
+// Package tts 朗读 ask 的回答：支持系统自带的语音合成（say / espeak-ng / SAPI）
+// 与 OpenAI 兼容的 /audio/speech 接口，后者合成 mp3 后交给本地播放器。
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+// Speak 朗读 Markdown 回答（代码块不读），阻塞到播放结束或 ctx 取消
+func Speak(ctx context.Context, cfg config.AgentConfig, fallback config.Provider, answer string) error {
+	text := Plain(answer)
+	if text == "" {
+		return nil
+	}
+	t := cfg.EffectiveTTS()
+	switch t.Backend {
+	case config.TTSSystem:
+		return speakSystem(ctx, t, text)
+	case config.TTSOpenAI:
+		p := fallback
+		if t.Provider != "" {
+			idx := cfg.FindProvider(t.Provider)
+			if idx < 0 {
+				return errors.New(i18n.T(i18n.MsgAuthUnknown, t.Provider))
+			}
+			p = cfg.Providers[idx]
+		}
+		key, _ := auth.Resolve(p)
+		audio, err := provider.Speech(ctx, p, provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(p)}, t, text)
+		if err != nil || len(audio) == 0 {
+			return err
+		}
+		return play(ctx, t.Player, audio)
+	default:
+		return errors.New(i18n.T(i18n.MsgTTSUnknownBackend, t.Backend))
+	}
+}
+
+// speakSystem 通过 stdin 把文字交给系统朗读命令，避免超长参数
+func speakSystem(ctx context.Context, t config.TTS, text string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		args := []string{"-f", "-"}
+		if t.Voice != "" {
+			args = append(args, "-v", t.Voice)
+		}
+		if t.Speed > 0 {
+			// say 默认约 175 词/分钟
+			args = append(args, "-r", strconv.Itoa(int(175*t.Speed)))
+		}
+		cmd = exec.CommandContext(ctx, "say", args...)
+	case "windows":
+		script := "Add-Type -AssemblyName System.Speech; $s = New-Object System.Speech.Synthesis.SpeechSynthesizer; "
+		if t.Voice != "" {
+			script += fmt.Sprintf("$s.SelectVoice('%s'); ", strings.ReplaceAll(t.Voice, "'", "''"))
+		}
+		script += "$s.Speak([Console]::In.ReadToEnd())"
+		cmd = exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", script)
+	default:
+		bin := ""
+		for _, name := range []string{"espeak-ng", "espeak"} {
+			if path, err := exec.LookPath(name); err == nil {
+				bin = path
+				break
+			}
+		}
+		if bin == "" {
+			return errors.New(i18n.T(i18n.MsgTTSNoSystem))
+		}
+		args := []string{"--stdin"}
+		if t.Voice != "" {
+			args = append(args, "-v", t.Voice)
+		}
+		if t.Speed > 0 {
+			args = append(args, "-s", strconv.Itoa(int(175*t.Speed)))
+		}
+		cmd = exec.CommandContext(ctx, bin, args...)
+	}
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// players 按优先级探测的 mp3 播放命令（均支持从文件播放并在结束后退出）
+var players = [][]string{
+	{"afplay"},
+	{"mpv", "--really-quiet", "--no-video"},
+	{"ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet"},
+	{"mpg123", "-q"},
+}
+
+// play 把合成的音频写入临时文件并用播放器播放；player 非空时按空格拆分为命令与参数
+func play(ctx context.Context, player string, audio []byte) error {
+	var argv []string
+	if player != "" {
+		argv = strings.Fields(player)
+	} else {
+		for _, p := range players {
+			if _, err := exec.LookPath(p[0]); err == nil {
+				argv = p
+				break
+			}
+		}
+	}
+	if len(argv) == 0 {
+		return errors.New(i18n.T(i18n.MsgTTSNoPlayer))
+	}
+	f, err := os.CreateTemp("", "j-speak-*.mp3")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(audio); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, argv[0], append(argv[1:], f.Name())...)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
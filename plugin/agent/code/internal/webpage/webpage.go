@@ -0,0 +1,501 @@
+// Package webpage 抓取网页作为提问的上下文：下载页面，取出正文（优先 <article> / <main>，去掉导航、页眉页脚、
+// 侧栏与脚本），转换为 Markdown，再按 token 预算截断，让"总结这篇文章""解释这页文档"不必手动复制粘贴。
+package webpage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+
+	"wcp_agent/internal/tokenizer"
+)
+
+const (
+	// MaxBytes 下载的页面体积上限，超出的部分不读取
+	MaxBytes = 5 << 20
+	// Timeout 单个页面的下载超时
+	Timeout = 20 * time.Second
+	// DefaultBudget 未指定预算时全部页面合计的 token 预算
+	DefaultBudget = 6000
+
+	userAgent = "Mozilla/5.0 (compatible; j-agent; +https://github.com/LingoJack/j)"
+)
+
+// Page 抓取并转换后的页面
+type Page struct {
+	URL      string // 重定向之后的地址
+	Title    string
+	Markdown string
+}
+
+// Fetch 下载 rawURL 并取出正文：HTML 转换为 Markdown，纯文本、Markdown 与 JSON 原样保留，其他类型报错
+func Fetch(ctx context.Context, rawURL string) (Page, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Page{}, fmt.Errorf("invalid URL %q (expected http:// or https://)", rawURL)
+	}
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Page{}, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/markdown,text/plain;q=0.9,*/*;q=0.5")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Page{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return Page{}, fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	page := Page{URL: resp.Request.URL.String()}
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	body, err := charset.NewReader(io.LimitReader(resp.Body, MaxBytes), contentType)
+	if err != nil {
+		return Page{}, err
+	}
+	switch {
+	case mediaType == "" || mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		page.Title, page.Markdown, err = Extract(body, resp.Request.URL)
+	case strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var data []byte
+		data, err = io.ReadAll(body)
+		page.Markdown = strings.TrimSpace(string(data))
+	default:
+		return Page{}, fmt.Errorf("%s: unsupported content type %s", u, mediaType)
+	}
+	if err != nil {
+		return Page{}, err
+	}
+	if page.Markdown == "" {
+		return Page{}, fmt.Errorf("%s: no readable content", u)
+	}
+	return page, nil
+}
+
+// Extract 解析 HTML，返回标题与正文的 Markdown；链接与图片地址相对 base 补全
+func Extract(r io.Reader, base *url.URL) (title, markdown string, err error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return "", "", err
+	}
+	if t := find(doc, func(n *html.Node) bool { return n.DataAtom == atom.Title }); t != nil {
+		title = collapse(textContent(t))
+	}
+	root := readableRoot(doc)
+	c := &converter{base: base, rootLen: len(collapse(textContent(root)))}
+	markdown = tidy(c.blocks(root))
+	if title == "" {
+		if h := find(doc, func(n *html.Node) bool { return n.DataAtom == atom.H1 }); h != nil {
+			title = collapse(textContent(h))
+		}
+	}
+	return title, markdown, nil
+}
+
+// Fit 按 token 预算截断：整段保留，放不下的段落及其后的内容去掉；第一段就放不下时按字符截断
+func Fit(text string, budget int, tokens tokenizer.Counter) (string, bool) {
+	if budget <= 0 || tokens.Count(text) <= budget {
+		return text, false
+	}
+	var b strings.Builder
+	for _, para := range strings.Split(text, "\n\n") {
+		next := para
+		if b.Len() > 0 {
+			next = b.String() + "\n\n" + para
+		}
+		if tokens.Count(next) > budget {
+			break
+		}
+		b.Reset()
+		b.WriteString(next)
+	}
+	if b.Len() > 0 {
+		return b.String(), true
+	}
+	cut := text
+	for tokens.Count(cut) > budget && cut != "" {
+		n := utf8.RuneCountInString(cut) * 9 / 10
+		cut = string([]rune(cut)[:n])
+	}
+	return cut, true
+}
+
+// skipped 不含正文的元素
+var skipped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true, atom.Svg: true,
+	atom.Canvas: true, atom.Iframe: true, atom.Object: true, atom.Form: true, atom.Button: true,
+	atom.Input: true, atom.Select: true, atom.Textarea: true, atom.Nav: true, atom.Header: true,
+	atom.Footer: true, atom.Aside: true, atom.Dialog: true, atom.Head: true,
+}
+
+// boilerplate class / id 中常见的非正文区块
+var boilerplate = regexp.MustCompile(`(?i)(^|[\s_-])(sidebar|comments?|cookies?|banner|advert|ads|promo|share|social|related|breadcrumbs?|newsletter|popup|modal|menu|navbar|footer|header|toc)([\s_-]|$)`)
+
+// boilerplateRoles 非正文区块的 ARIA role
+var boilerplateRoles = map[string]bool{"navigation": true, "banner": true, "contentinfo": true, "complementary": true, "search": true, "dialog": true}
+
+// readableRoot 正文所在的元素：文字最多的 <article>，其次是 <main> 或 role=main，否则为 <body>
+func readableRoot(doc *html.Node) *html.Node {
+	var best *html.Node
+	bestLen := 0
+	walk(doc, func(n *html.Node) {
+		if n.DataAtom == atom.Article {
+			if l := len(collapse(textContent(n))); l > bestLen {
+				best, bestLen = n, l
+			}
+		}
+	})
+	if best != nil && bestLen > 200 {
+		return best
+	}
+	if m := find(doc, func(n *html.Node) bool { return n.DataAtom == atom.Main || attr(n, "role") == "main" }); m != nil {
+		return m
+	}
+	if b := find(doc, func(n *html.Node) bool { return n.DataAtom == atom.Body }); b != nil {
+		return b
+	}
+	return doc
+}
+
+// hidden 元素是否不显示或属于页面中的非正文区块；class / id 像非正文区块的元素只在文字不到正文四分之一时去掉，
+// 以免 "with-sidebar" 这类包住整个正文的容器被误删
+func (c *converter) hidden(n *html.Node) bool {
+	if skipped[n.DataAtom] {
+		return true
+	}
+	for _, a := range n.Attr {
+		switch a.Key {
+		case "hidden":
+			return true
+		case "aria-hidden":
+			if a.Val == "true" {
+				return true
+			}
+		case "role":
+			if boilerplateRoles[a.Val] {
+				return true
+			}
+		case "style":
+			if strings.Contains(strings.ReplaceAll(a.Val, " ", ""), "display:none") {
+				return true
+			}
+		case "class", "id":
+			if boilerplate.MatchString(a.Val) && len(collapse(textContent(n))) < c.rootLen/4 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// converter 把 HTML 节点转换为 Markdown
+type converter struct {
+	base    *url.URL
+	rootLen int // 正文所在元素的文字长度
+}
+
+// blockWriter 收集块级内容：行内文字先累积，遇到块级元素时成为一段
+type blockWriter struct {
+	blocks []string
+	inline strings.Builder
+}
+
+func (w *blockWriter) flush() {
+	if t := collapse(w.inline.String()); t != "" {
+		w.blocks = append(w.blocks, t)
+	}
+	w.inline.Reset()
+}
+
+func (w *blockWriter) block(s string) {
+	w.flush()
+	if strings.TrimSpace(s) != "" {
+		w.blocks = append(w.blocks, s)
+	}
+}
+
+func (w *blockWriter) String() string {
+	w.flush()
+	return strings.Join(w.blocks, "\n\n")
+}
+
+// blocks 子节点转换为以空行分隔的 Markdown 段落
+func (c *converter) blocks(n *html.Node) string {
+	var w blockWriter
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		c.node(&w, ch)
+	}
+	return w.String()
+}
+
+// inline 子节点转换为一行 Markdown
+func (c *converter) inline(n *html.Node) string {
+	return collapse(strings.ReplaceAll(c.blocks(n), "\n", " "))
+}
+
+func (c *converter) node(w *blockWriter, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		// 源码中的换行只是排版，段内换行来自 <br>
+		w.inline.WriteString(strings.ReplaceAll(n.Data, "\n", " "))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	if c.hidden(n) {
+		return
+	}
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		if text := c.inline(n); text != "" {
+			w.block(strings.Repeat("#", level) + " " + text)
+		}
+	case atom.P, atom.Figcaption, atom.Summary, atom.Dt:
+		w.block(c.blocks(n))
+	case atom.Pre:
+		w.block(fence(n))
+	case atom.Blockquote:
+		w.block(prefixLines(c.blocks(n), "> ", "> "))
+	case atom.Ul, atom.Ol:
+		w.block(c.list(n))
+	case atom.Table:
+		w.block(c.table(n))
+	case atom.Hr:
+		w.block("---")
+	case atom.Br:
+		w.inline.WriteString("\n")
+	case atom.Img:
+		if alt := collapse(attr(n, "alt")); alt != "" {
+			fmt.Fprintf(&w.inline, "![%s](%s)", alt, c.resolve(attr(n, "src")))
+		}
+	case atom.A:
+		text := c.inline(n)
+		href := attr(n, "href")
+		switch {
+		case text == "":
+		case href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:"):
+			w.inline.WriteString(text)
+		default:
+			fmt.Fprintf(&w.inline, "[%s](%s)", text, c.resolve(href))
+		}
+	case atom.Strong, atom.B:
+		if text := c.inline(n); text != "" {
+			w.inline.WriteString("**" + text + "**")
+		}
+	case atom.Em, atom.I:
+		if text := c.inline(n); text != "" {
+			w.inline.WriteString("*" + text + "*")
+		}
+	case atom.Code, atom.Kbd, atom.Samp:
+		if text := collapse(textContent(n)); text != "" {
+			w.inline.WriteString("`" + text + "`")
+		}
+	case atom.Div, atom.Section, atom.Article, atom.Main, atom.Figure, atom.Details, atom.Dl, atom.Dd,
+		atom.Li, atom.Center, atom.Address, atom.Body, atom.Html:
+		w.flush()
+		for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+			c.node(w, ch)
+		}
+		w.flush()
+	default:
+		for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+			c.node(w, ch)
+		}
+	}
+}
+
+// list 列表项转换为 "- " 或 "1. " 开头的行，项内的后续行缩进对齐
+func (c *converter) list(n *html.Node) string {
+	var lines []string
+	i := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+		i = start
+	}
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.DataAtom != atom.Li || c.hidden(li) {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(i) + ". "
+			i++
+		}
+		item := strings.ReplaceAll(c.blocks(li), "\n\n", "\n")
+		if item == "" {
+			continue
+		}
+		lines = append(lines, prefixLines(item, marker, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// table 表格转换为 Markdown 表格，第一行作为表头
+func (c *converter) table(n *html.Node) string {
+	var rows [][]string
+	walk(n, func(tr *html.Node) {
+		if tr.DataAtom != atom.Tr {
+			return
+		}
+		var cells []string
+		for td := tr.FirstChild; td != nil; td = td.NextSibling {
+			if td.DataAtom == atom.Td || td.DataAtom == atom.Th {
+				cells = append(cells, strings.ReplaceAll(c.inline(td), "|", `\|`))
+			}
+		}
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+		}
+	})
+	if len(rows) == 0 {
+		return ""
+	}
+	cols := 0
+	for _, r := range rows {
+		cols = max(cols, len(r))
+	}
+	var b strings.Builder
+	for i, r := range rows {
+		r = append(r, make([]string, cols-len(r))...)
+		b.WriteString("| " + strings.Join(r, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// resolve 相对地址补全为绝对地址
+func (c *converter) resolve(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if c.base == nil {
+		return ref
+	}
+	u, err := c.base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+// fence <pre> 转换为代码块，语言取自 class 中的 language-xxx / lang-xxx
+func fence(n *html.Node) string {
+	code := strings.Trim(textContent(n), "\n")
+	if strings.TrimSpace(code) == "" {
+		return ""
+	}
+	lang := ""
+	for _, el := range []*html.Node{n, find(n, func(x *html.Node) bool { return x.DataAtom == atom.Code })} {
+		if el == nil {
+			continue
+		}
+		for _, class := range strings.Fields(attr(el, "class")) {
+			if l, ok := strings.CutPrefix(class, "language-"); ok {
+				lang = l
+			} else if l, ok := strings.CutPrefix(class, "lang-"); ok {
+				lang = l
+			}
+		}
+	}
+	ticks := "```"
+	for strings.Contains(code, ticks) {
+		ticks += "`"
+	}
+	return ticks + lang + "\n" + code + "\n" + ticks
+}
+
+// prefixLines 第一行加 first，其余行加 rest
+func prefixLines(text, first, rest string) string {
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		p := rest
+		if i == 0 {
+			p = first
+		}
+		if l == "" {
+			lines[i] = strings.TrimRight(p, " ")
+		} else {
+			lines[i] = p + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var spaces = regexp.MustCompile(`[ \t\r\f\v\x{00a0}]+`)
+
+// collapse 每行中连续的空白合并为一个空格，去掉首尾空白与空行
+func collapse(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, l := range lines {
+		if l = strings.TrimSpace(spaces.ReplaceAllString(l, " ")); l != "" {
+			out = append(out, l)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// tidy 合并多余的空行
+func tidy(s string) string {
+	for strings.Contains(s, "\n\n\n") {
+		s = strings.ReplaceAll(s, "\n\n\n", "\n\n")
+	}
+	return strings.TrimSpace(s)
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	walk(n, func(x *html.Node) {
+		if x.Type == html.TextNode {
+			b.WriteString(x.Data)
+		}
+	})
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// walk 先序遍历 n 及其全部后代
+func walk(n *html.Node, fn func(*html.Node)) {
+	fn(n)
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		walk(ch, fn)
+	}
+}
+
+// find 先序遍历中第一个满足 match 的节点
+func find(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if match(n) {
+		return n
+	}
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		if found := find(ch, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+"use strict";
+const dict = {
+  "en": {
+    tokenTitle: "Connect to agent serve", tokenHint: "Paste the bearer token printed by agent serve (by default saved in ~/.jdata/agent/data/serve_token).",
+    connect: "Connect", sessions: "Sessions", history: "History", newChat: "new", logout: "forget token", session: "Session",
+    forget: "Delete session", send: "Send", stop: "Stop", placeholder: "Ask something… (Enter to send, Shift+Enter for a new line)",
+    unauthorized: "The token was rejected.", noSessions: "no sessions yet", noHistory: "no history yet", confirmForget: "Delete session %s?",
+    cancelled: "cancelled", truncated: "truncated"
+  },
+  "zh-CN": {
+    tokenTitle: "连接 agent serve", tokenHint: "填入 agent serve 启动时提示的 Bearer 令牌（默认保存在 ~/.jdata/agent/data/serve_token）。",
+    connect: "连接", sessions: "会话", history: "历史", newChat: "新对话", logout: "清除令牌", session: "会话",
+    forget: "删除会话", send: "发送", stop: "停止", placeholder: "输入问题…（Enter 发送，Shift+Enter 换行）",
+    unauthorized: "令牌不正确。", noSessions: "还没有会话", noHistory: "还没有历史", confirmForget: "删除会话 %s？",
+    cancelled: "已取消", truncated: "已截断"
+  }
+};
+const L = dict[document.documentElement.lang] || dict["zh-CN"];
+const $ = (id) => document.getElementById(id);
+document.querySelectorAll("[data-t]").forEach((el) => { el.textContent = L[el.dataset.t]; });
+$("prompt").placeholder = L.placeholder;
+
+let token = localStorage.getItem("j.serve.token") || "";
+let busy = null;
+
+async function api(path, init = {}) {
+  init.headers = Object.assign({ "Authorization": "Bearer " + token }, init.headers || {});
+  const resp = await fetch(path, init);
+  if (resp.status === 401) { showAuth(L.unauthorized); throw new Error(L.unauthorized); }
+  return resp;
+}
+
+function showAuth(err) {
+  $("auth").classList.remove("hidden");
+  document.querySelector("aside").classList.add("hidden");
+  document.querySelector("main").classList.add("hidden");
+  $("autherr").textContent = err || "";
+}
+
+async function start() {
+  try {
+    const resp = await api("/v1/providers");
+    if (!resp.ok) throw new Error((await resp.json()).error);
+    const select = $("provider");
+    select.innerHTML = "";
+    for (const p of await resp.json()) {
+      const opt = new Option(p.name + " · " + p.model, p.name, p.active, p.active);
+      select.add(opt);
+    }
+  } catch (e) { showAuth(e.message); return; }
+  $("auth").classList.add("hidden");
+  document.querySelector("aside").classList.remove("hidden");
+  document.querySelector("main").classList.remove("hidden");
+  refresh();
+}
+
+async function refresh() {
+  const [sessions, history] = await Promise.all([
+    api("/v1/sessions").then((r) => r.json()).catch(() => []),
+    api("/v1/history?n=50").then((r) => r.json()).catch(() => [])
+  ]);
+  const current = $("session").value;
+  fill($("sessions"), sessions || [], L.noSessions, (s) => [s.name, s.messages], (s) => s.name === current, (s) => openSession(s.name));
+  fill($("history"), (history || []).reverse(), L.noHistory, (e) => [e.prompt, e.provider], () => false, (e) => openExchange(e));
+}
+
+function fill(list, items, empty, label, on, click) {
+  list.innerHTML = "";
+  if (!items.length) {
+    const li = document.createElement("li");
+    li.style.color = "var(--muted)";
+    li.textContent = empty;
+    list.append(li);
+    return;
+  }
+  for (const item of items) {
+    const li = document.createElement("li");
+    const [text, note] = label(item);
+    li.textContent = text;
+    const small = document.createElement("small");
+    small.textContent = note;
+    li.append(small);
+    li.title = text;
+    if (on(item)) li.classList.add("on");
+    li.onclick = () => click(item);
+    list.append(li);
+  }
+}
+
+async function openSession(name) {
+  $("session").value = name;
+  $("log").innerHTML = "";
+  const resp = await api("/v1/sessions/" + encodeURIComponent(name));
+  if (resp.ok) {
+    for (const m of (await resp.json()).messages) {
+      if (m.role !== "system") bubble(m.role, m.content);
+    }
+  }
+  refresh();
+}
+
+function openExchange(e) {
+  $("session").value = "";
+  $("log").innerHTML = "";
+  bubble("user", e.prompt);
+  const b = bubble(e.status === "error" ? "error" : "assistant", e.status === "error" ? e.error : e.answer);
+  meta(b, e);
+  refresh();
+}
+
+function bubble(role, text) {
+  const div = document.createElement("div");
+  div.className = "msg " + role;
+  if (role === "assistant") div.replaceChildren(markdown(text)); else div.textContent = text;
+  $("log").append(div);
+  div.scrollIntoView({ block: "end" });
+  return div;
+}
+
+function meta(div, r) {
+  const m = document.createElement("div");
+  m.className = "meta";
+  const status = r.status === "ok" ? "" : " · " + (L[r.status] || r.status);
+  m.textContent = r.provider + " · " + r.model + " · " + (r.duration_ms / 1000).toFixed(1) + "s" + status;
+  div.append(m);
+}
+
+// markdown 只处理常见写法（代码块、行内代码、标题、粗体、列表、链接），完整渲染请用 md_render；
+// 回答来自模型，按文本节点与 setAttribute 组装 DOM，不拼接 HTML
+function markdown(src) {
+  const el = (tag, ...children) => { const e = document.createElement(tag); e.append(...children); return e; };
+  const inline = (s) => {
+    const nodes = [];
+    const re = /`([^`]+)`|\*\*([^*]+)\*\*|\[([^\]]+)\]\((https?:\/\/[^)\s]+)\)/g;
+    let last = 0, m;
+    while ((m = re.exec(s)) !== null) {
+      if (m.index > last) nodes.push(s.slice(last, m.index));
+      if (m[1] !== undefined) nodes.push(el("code", m[1]));
+      else if (m[2] !== undefined) nodes.push(el("strong", ...inline(m[2])));
+      else {
+        const a = el("a", ...inline(m[3]));
+        a.setAttribute("href", m[4]);
+        a.setAttribute("target", "_blank");
+        a.setAttribute("rel", "noopener noreferrer");
+        nodes.push(a);
+      }
+      last = re.lastIndex;
+    }
+    if (last < s.length) nodes.push(s.slice(last));
+    return nodes;
+  };
+  const out = document.createDocumentFragment();
+  const parts = src.split(/^```.*$/m);
+  parts.forEach((part, i) => {
+    if (i % 2 === 1) { out.append(el("pre", el("code", part.replace(/^\n|\n$/g, "")))); return; }
+    for (const block of part.split(/\n{2,}/)) {
+      const lines = block.split("\n").filter((l) => l.trim() !== "");
+      if (!lines.length) continue;
+      const h = lines[0].match(/^(#{1,6})\s+(.*)/);
+      if (h && lines.length === 1) { out.append(el("h" + h[1].length, ...inline(h[2]))); continue; }
+      if (lines.every((l) => /^\s*([-*+]|\d+\.)\s/.test(l))) {
+        const tag = /^\s*\d/.test(lines[0]) ? "ol" : "ul";
+        out.append(el(tag, ...lines.map((l) => el("li", ...inline(l.replace(/^\s*([-*+]|\d+\.)\s+/, ""))))));
+        continue;
+      }
+      const p = el("p");
+      lines.forEach((l, k) => { if (k > 0) p.append(el("br")); p.append(...inline(l)); });
+      out.append(p);
+    }
+  });
+  return out;
+}
+
+async function ask(prompt) {
+  bubble("user", prompt);
+  const div = bubble("assistant", "");
+  let text = "";
+  busy = new AbortController();
+  $("send").textContent = L.stop;
+  try {
+    const resp = await api("/v1/ask", {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ prompt, provider: $("provider").value, session: $("session").value.trim(), stream: true }),
+      signal: busy.signal
+    });
+    if (!resp.ok) throw new Error((await resp.json()).error);
+    const reader = resp.body.getReader();
+    const decoder = new TextDecoder();
+    let buf = "";
+    for (;;) {
+      const { value, done } = await reader.read();
+      if (done) break;
+      buf += decoder.decode(value, { stream: true });
+      let at;
+      while ((at = buf.indexOf("\n\n")) >= 0) {
+        const line = buf.slice(0, at).replace(/^data: /, "");
+        buf = buf.slice(at + 2);
+        const ev = JSON.parse(line);
+        if (ev.done) {
+          if (ev.status === "error") { div.className = "msg error"; div.textContent = ev.error; }
+          else div.replaceChildren(markdown(ev.answer || text));
+          meta(div, ev);
+        } else if (ev.delta) {
+          text += ev.delta;
+          div.replaceChildren(markdown(text));
+          div.scrollIntoView({ block: "end" });
+        }
+      }
+    }
+  } catch (e) {
+    if (e.name !== "AbortError") { div.className = "msg error"; div.textContent = e.message; }
+  } finally {
+    busy = null;
+    $("send").textContent = L.send;
+    refresh();
+  }
+}
+
+$("ask").onsubmit = (ev) => {
+  ev.preventDefault();
+  if (busy) { busy.abort(); return; }
+  const prompt = $("prompt").value.trim();
+  if (!prompt) return;
+  $("prompt").value = "";
+  ask(prompt);
+};
+$("prompt").onkeydown = (ev) => {
+  if (ev.key === "Enter" && !ev.shiftKey && !ev.isComposing) { ev.preventDefault(); $("ask").requestSubmit(); }
+};
+$("save").onclick = () => {
+  token = $("token").value.trim();
+  localStorage.setItem("j.serve.token", token);
+  start();
+};
+$("token").onkeydown = (ev) => { if (ev.key === "Enter") $("save").click(); };
+$("logout").onclick = () => { localStorage.removeItem("j.serve.token"); token = ""; showAuth(); };
+$("new").onclick = () => {
+  $("session").value = "chat-" + new Date().toISOString().slice(5, 19).replace(/[-:T]/g, "");
+  $("log").innerHTML = "";
+  $("prompt").focus();
+};
+$("forget").onclick = async () => {
+  const name = $("session").value.trim();
+  if (!name || !confirm(L.confirmForget.replace("%s", name))) return;
+  await api("/v1/sessions/" + encodeURIComponent(name), { method: "DELETE" });
+  $("session").value = "";
+  $("log").innerHTML = "";
+  refresh();
+};
+
+if (token) start(); else showAuth();
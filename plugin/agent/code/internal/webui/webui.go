@@ -0,0 +1,43 @@
+// Package webui agent serve 内嵌的网页界面：一个 HTML 页面（样式内联）与它的脚本 app.js，无需构建，
+// 通过同一 HTTP API 浏览历史、管理会话并流式对话；页面本身不需要令牌，调用 API 时由用户填写。
+package webui
+
+import (
+	_ "embed"
+	"net/http"
+	"strings"
+
+	"wcp_agent/internal/i18n"
+)
+
+// ScriptPath 页面脚本的地址
+const ScriptPath = "/app.js"
+
+// contentSecurityPolicy 脚本只允许同源的 app.js，回答中即使混入 HTML 也不会执行
+const contentSecurityPolicy = "default-src 'self'; script-src 'self'; style-src 'unsafe-inline'; object-src 'none'; base-uri 'none'; frame-ancestors 'none'"
+
+//go:embed index.html
+var page string
+
+//go:embed app.js
+var script string
+
+// Handler 返回网页界面，页面语言跟随当前界面语言
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Content-Security-Policy", contentSecurityPolicy)
+		_, _ = w.Write([]byte(strings.ReplaceAll(page, "{{lang}}", string(i18n.Current()))))
+	})
+}
+
+// Script 返回页面脚本
+func Script() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		_, _ = w.Write([]byte(script))
+	})
+}
@@ -0,0 +1,25 @@
+# j 的 bash command-not-found 钩子：eval "$(agent widget bash --not-found)"
+# 找不到命令时由 agent not-found 给出建议（先在 PATH 中找拼写相近的命令，再请模型给出本意或安装命令），
+# 按回车或 y 执行建议的命令，其他键跳过；执行前经 agent guard confirm 检查风险
+command_not_found_handle() {
+  [[ $- == *i* && -t 2 ]] || { printf 'bash: %s: command not found\n' "$1" >&2; return 127; }
+  local suggestion key
+  suggestion=$({{agent}} not-found --shell bash{{flags}} -- "$@" </dev/null)
+  if [[ $? -ne 0 || -z $suggestion ]]; then
+    printf 'bash: %s: command not found\n' "$1" >&2
+    return 127
+  fi
+  # 有风险时先列出；高风险需输入确认词（退出码 2 表示已确认，不再询问）
+  {{agent}} guard confirm --source not-found -- "$suggestion" </dev/null
+  case $? in
+    0)
+      printf {{prompt}} "$suggestion" >&2
+      IFS= read -r -s -n 1 key </dev/tty
+      printf '\n' >&2
+      [[ -z $key || $key == [yY] ]] || return 127
+      ;;
+    2) ;;
+    *) return 127 ;;
+  esac
+  eval "$suggestion"
+}
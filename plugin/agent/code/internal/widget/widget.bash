@@ -0,0 +1,26 @@
+# j 的 bash 行内 AI 小部件：eval "$(agent widget bash)"
+# {{key}}：命令行首词不是可执行的命令（或以 # 开头）时把描述交给 agent do 并替换为生成的命令，
+# 否则交给 agent explain，在命令行上方输出简短的解释（命令行保持不变）
+_j_agent_widget() {
+  local line=$READLINE_LINE out rc
+  [[ -z ${line//[[:space:]]/} ]] && return 0
+  local first=${line%%[[:space:]]*}
+  if [[ $line == '#'* ]] || ! type -- "$first" >/dev/null 2>&1; then
+    printf '%s\n' {{asking}} >&2
+    out=$({{agent}} do --shell bash -- "${line#\#}" 2>&1 </dev/null)
+    rc=$?
+    if [[ $rc -eq 0 && -n $out ]]; then
+      READLINE_LINE=$out
+      READLINE_POINT=${#out}
+      # 生成的命令有风险时输出在命令行上方
+      {{agent}} guard check --brief -- "$out" </dev/null >&2
+    else
+      printf '%s\n' "$out" >&2
+    fi
+  else
+    printf '%s\n' {{explaining}} >&2
+    out=$({{agent}} explain --brief --shell bash -- "$line" 2>&1 </dev/null)
+    printf '%s\n' "$out" >&2
+  fi
+}
+bind -x '{{key}}: _j_agent_widget'
@@ -0,0 +1,91 @@
+// Package widget 生成 zsh / bash 的行内 AI 小部件脚本：按下快捷键把当前命令行交给 agent do（生成命令并替换）
+// 或 agent explain（在提示符旁显示解释），不必另开一次调用。脚本内嵌在二进制中，由 agent widget 输出后 eval 加载。
+package widget
+
+import (
+	_ "embed"
+	"errors"
+	"strings"
+)
+
+var (
+	//go:embed widget.zsh
+	zshScript string
+	//go:embed widget.bash
+	bashScript string
+)
+
+// Shells 支持的 shell
+var Shells = []string{"zsh", "bash"}
+
+// DefaultKey 各 shell 的默认快捷键（Ctrl-G），写法为该 shell 绑定按键的格式
+var DefaultKey = map[string]string{"zsh": "^G", "bash": `"\C-g"`}
+
+// ErrUnsupported 不支持的 shell
+var ErrUnsupported = errors.New("unsupported shell")
+
+// Texts 脚本中显示的提示文案
+type Texts struct {
+	Asking     string
+	Explaining string
+}
+
+// Script 生成 shell 的小部件脚本：agent 为 agent 可执行文件路径，key 为空时使用 DefaultKey
+func Script(shell, agent, key string, texts Texts) (string, error) {
+	var script string
+	switch shell {
+	case "zsh":
+		script = zshScript
+	case "bash":
+		script = bashScript
+	default:
+		return "", ErrUnsupported
+	}
+	if key == "" {
+		key = DefaultKey[shell]
+	}
+	if shell == "zsh" {
+		key = quote(key)
+	}
+	return strings.NewReplacer(
+		"{{agent}}", quote(agent),
+		"{{key}}", key,
+		"{{asking}}", quote(texts.Asking),
+		"{{explaining}}", quote(texts.Explaining),
+	).Replace(script), nil
+}
+
+// quote 以单引号包裹，供 shell 原样使用
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+var (
+	//go:embed notfound.zsh
+	zshNotFound string
+	//go:embed notfound.bash
+	bashNotFound string
+)
+
+// NotFoundScript 生成 shell 的 command-not-found 钩子：找不到命令时交给 agent not-found 给出建议，
+// prompt 为确认提示的 printf 格式（%s 为建议的命令），按回车或 y 执行，其他键跳过；localOnly 时不请模型给出建议
+func NotFoundScript(shell, agent, prompt string, localOnly bool) (string, error) {
+	var script string
+	switch shell {
+	case "zsh":
+		script = zshNotFound
+	case "bash":
+		script = bashNotFound
+	default:
+		return "", ErrUnsupported
+	}
+	flags := ""
+	if localOnly {
+		flags = " --no-ai"
+	}
+	return strings.NewReplacer(
+		"{{agent}}", quote(agent),
+		"{{prompt}}", quote(prompt),
+		"{{flags}}", flags,
+	).Replace(script), nil
+}
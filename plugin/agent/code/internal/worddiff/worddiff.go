@@ -0,0 +1,222 @@
+// Package worddiff 逐词比较两段文本：英文单词、数字与连续空白各为一个词，
+// 汉字等表意文字与标点逐字比较，结果为按顺序排列的相同、删除与新增片段。
+package worddiff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxCells 最长公共子序列表的格数上限（去掉公共首尾之后），超出时改为逐行比较
+const maxCells = 4_000_000
+
+// Kind 片段的类型
+type Kind int
+
+const (
+	Equal  Kind = iota // 两边相同
+	Delete             // 只在旧文本中
+	Insert             // 只在新文本中
+)
+
+// Op 一段连续的同类文本
+type Op struct {
+	Kind Kind
+	Text string
+}
+
+// Stats 删除与新增的词数（不计空白）
+type Stats struct {
+	Deleted  int
+	Inserted int
+}
+
+// Diff 逐词比较 a 与 b；文本过长时退化为逐行比较，仍然过长时整体视为替换
+func Diff(a, b string) []Op {
+	ops, ok := diff(words(a), words(b))
+	if !ok {
+		ops, ok = diff(lines(a), lines(b))
+	}
+	if !ok {
+		ops = []Op{{Delete, a}, {Insert, b}}
+	}
+	return compact(ops)
+}
+
+// Count 统计 ops 中删除与新增的词数
+func Count(ops []Op) Stats {
+	var s Stats
+	for _, op := range ops {
+		n := 0
+		for _, w := range words(op.Text) {
+			if strings.TrimSpace(w) != "" {
+				n++
+			}
+		}
+		switch op.Kind {
+		case Delete:
+			s.Deleted += n
+		case Insert:
+			s.Inserted += n
+		}
+	}
+	return s
+}
+
+// words 切分为词：字母与数字的连续序列、空白的连续序列，其余字符（标点、汉字等）各自成词
+func words(s string) []string {
+	var out []string
+	start, class := 0, 0
+	for i, r := range s {
+		c := classOf(r)
+		if i > start && (c != class || c == classSingle) {
+			out = append(out, s[start:i])
+			start = i
+		}
+		class = c
+	}
+	if start < len(s) {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+const (
+	classWord = iota + 1
+	classSpace
+	classSingle
+)
+
+func classOf(r rune) int {
+	switch {
+	case unicode.IsSpace(r):
+		return classSpace
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+		return classSingle
+	case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+		return classWord
+	}
+	return classSingle
+}
+
+// lines 按行切分，保留行尾的换行符
+func lines(s string) []string {
+	var out []string
+	for s != "" {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			out = append(out, s)
+			break
+		}
+		out = append(out, s[:i+1])
+		s = s[i+1:]
+	}
+	return out
+}
+
+// diff 按最长公共子序列比较两组词；比较表超出 maxCells 时返回 false
+func diff(a, b []string) ([]Op, bool) {
+	// 公共首尾直接保留，缩小比较范围
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	x, y := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(x)*len(y) > maxCells {
+		return nil, false
+	}
+	var ops []Op
+	for _, w := range a[:prefix] {
+		ops = append(ops, Op{Equal, w})
+	}
+	// lcs[i][j] 为 x[i:] 与 y[j:] 的最长公共子序列长度
+	width := len(y) + 1
+	lcs := make([]int32, (len(x)+1)*width)
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			ops = append(ops, Op{Equal, x[i]})
+			i, j = i+1, j+1
+		case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+			ops = append(ops, Op{Delete, x[i]})
+			i++
+		default:
+			ops = append(ops, Op{Insert, y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		ops = append(ops, Op{Delete, x[i]})
+	}
+	for ; j < len(y); j++ {
+		ops = append(ops, Op{Insert, y[j]})
+	}
+	for _, w := range a[len(a)-suffix:] {
+		ops = append(ops, Op{Equal, w})
+	}
+	return ops, true
+}
+
+// compact 合并相邻的同类片段；夹在改动之间的单个空白并入两侧的改动，
+// 使 "[-a b-]{+c d+}" 不会被拆成 "[-a-]{+c+} [-b-]{+d+}"，连续的删除排在新增之前
+func compact(ops []Op) []Op {
+	var out []Op
+	for i := 0; i < len(ops); i++ {
+		op := ops[i]
+		if op.Text == "" {
+			continue
+		}
+		if op.Kind == Equal && strings.TrimSpace(op.Text) == "" && i > 0 && i < len(ops)-1 &&
+			ops[i-1].Kind != Equal && ops[i+1].Kind != Equal {
+			out = append(out, Op{Delete, op.Text}, Op{Insert, op.Text})
+			continue
+		}
+		out = append(out, op)
+	}
+	// 一段改动内先删除后新增
+	var merged []Op
+	for i := 0; i < len(out); {
+		if out[i].Kind == Equal {
+			merged = appendOp(merged, out[i])
+			i++
+			continue
+		}
+		var del, ins strings.Builder
+		for ; i < len(out) && out[i].Kind != Equal; i++ {
+			if out[i].Kind == Delete {
+				del.WriteString(out[i].Text)
+			} else {
+				ins.WriteString(out[i].Text)
+			}
+		}
+		if del.Len() > 0 {
+			merged = append(merged, Op{Delete, del.String()})
+		}
+		if ins.Len() > 0 {
+			merged = append(merged, Op{Insert, ins.String()})
+		}
+	}
+	return merged
+}
+
+func appendOp(ops []Op, op Op) []Op {
+	if n := len(ops); n > 0 && ops[n-1].Kind == op.Kind {
+		ops[n-1].Text += op.Text
+		return ops
+	}
+	return append(ops, op)
+}
@@ -0,0 +1,554 @@
+// Package workspace 收集提问所在项目的上下文：模块路径、目录结构概要与相关代码片段。
+// auto 模式只附上问题中提到的文件与符号（按声明定位），full 模式在预算内尽量附上全部文件；
+// 上下文总长度按 token 估算控制在预算之内，不在项目中（找不到 .git 或 go.mod 等）时不收集。
+package workspace
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/project"
+	"wcp_agent/internal/tokenizer"
+)
+
+// Mode 上下文收集方式
+type Mode string
+
+const (
+	ModeNone Mode = "none"
+	ModeAuto Mode = "auto"
+	ModeFull Mode = "full"
+)
+
+const (
+	// SettingMode setting 段中 --context 的默认值
+	SettingMode = "agent_context"
+	// AutoBudget auto 模式默认的 token 预算
+	AutoBudget = 4000
+	// FullBudget full 模式默认的 token 预算
+	FullBudget = 24000
+
+	// maxScanFiles 最多扫描的文件数，超大仓库只看前面的部分
+	maxScanFiles = 3000
+	// maxFileBytes 超过该大小的文件不读取（多为生成文件或数据）
+	maxFileBytes = 256 << 10
+	// maxSymbols 问题中最多查找的标识符个数
+	maxSymbols = 30
+	// maxDeclsPerSymbol 同名声明最多附上的个数
+	maxDeclsPerSymbol = 3
+	// maxExcerptLines 非 Go 文件中一个声明片段的最大行数
+	maxExcerptLines = 40
+	// maxTreeEntries 目录概要的最大行数
+	maxTreeEntries = 60
+)
+
+// projectMarkers 项目根目录的标志，由近及远查找，.git 优先
+var projectMarkers = []string{".git", "go.mod", "Cargo.toml", "package.json", "pyproject.toml"}
+
+// skippedDirs 不是 git 仓库时遍历目录跳过的依赖与构建产物目录
+var skippedDirs = map[string]bool{"node_modules": true, "vendor": true, "target": true, "dist": true, "build": true, "__pycache__": true}
+
+// lockFiles full 模式不附上的依赖锁文件
+var lockFiles = map[string]bool{"go.sum": true, "Cargo.lock": true, "package-lock.json": true, "yarn.lock": true, "pnpm-lock.yaml": true, "poetry.lock": true}
+
+// languages 代码块的语言标注，同时决定哪些文件参与符号查找
+var languages = map[string]string{
+	".go": "go", ".rs": "rust", ".py": "python", ".js": "javascript", ".ts": "typescript", ".tsx": "tsx",
+	".java": "java", ".kt": "kotlin", ".swift": "swift", ".rb": "ruby", ".c": "c", ".h": "c",
+	".cc": "cpp", ".cpp": "cpp", ".hpp": "cpp", ".cs": "csharp", ".sh": "bash", ".lua": "lua",
+}
+
+var (
+	identifierRegexp = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]{2,}`)
+	// declRegexp 非 Go 源码中的声明行：fn / def / class / struct / function 等关键字之后的名字
+	declRegexp = regexp.MustCompile(`^\s*(?:(?:pub(?:\([^)]*\))?|export|default|async|static|public|private|protected|abstract|final|unsafe)\s+)*` +
+		`(?:fn|def|class|struct|enum|trait|interface|type|function|impl|module|object|func)\s+([A-Za-z_]\w*)`)
+	cargoNameRegexp = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
+)
+
+// ParseMode 解析 --context 的取值，空串为 none
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "":
+		return ModeNone, nil
+	case ModeNone, ModeAuto, ModeFull:
+		return m, nil
+	}
+	return ModeNone, errors.New(i18n.T(i18n.MsgContextBadMode, s))
+}
+
+// DefaultMode 项目 .j.toml 中的 context.mode，其次为 config.yaml 的 setting.agent_context，未配置或无法识别时为 none
+func DefaultMode() Mode {
+	if pc, _ := project.Current(); pc != nil && pc.Context.Mode != "" {
+		return Mode(pc.Context.Mode)
+	}
+	m, err := ParseMode(config.Setting(SettingMode))
+	if err != nil {
+		return ModeNone
+	}
+	return m
+}
+
+// Context 收集到的项目上下文
+type Context struct {
+	Root   string
+	Files  []string // 附上了内容的文件（相对根目录）
+	Text   string
+	Tokens int
+}
+
+// Collect 在 dir 所在的项目中按 mode 收集与 prompt 相关的上下文，budget 为 token 预算
+// （0 为项目 .j.toml 中的 context.budget，其次为模式默认值），按 tokens 计数（通常是接收问题的 provider 的计数器），
+// .j.toml 中 context.exclude 匹配的文件不收集；mode 为 none 或 dir 不在项目中时返回 nil
+func Collect(dir, prompt string, mode Mode, budget int, tokens tokenizer.Counter) (*Context, error) {
+	if mode == ModeNone {
+		return nil, nil
+	}
+	pc, _ := project.Current()
+	if budget <= 0 && pc != nil {
+		budget = pc.Context.Budget
+	}
+	if budget <= 0 {
+		budget = AutoBudget
+		if mode == ModeFull {
+			budget = FullBudget
+		}
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	root := findRoot(abs)
+	if root == "" {
+		return nil, nil
+	}
+	files := listFiles(root)
+	if pc != nil && len(pc.Context.Exclude) > 0 {
+		files = excludeFiles(root, files, pc)
+	}
+
+	var b strings.Builder
+	b.WriteString("Context about the project the question is asked in (collected automatically, it may be incomplete):\n\n")
+	fmt.Fprintf(&b, "Project: %s", filepath.Base(root))
+	rel, _ := filepath.Rel(root, abs)
+	rel = filepath.ToSlash(rel)
+	if rel != "." {
+		fmt.Fprintf(&b, " (working directory: %s)", rel)
+	}
+	b.WriteString("\n")
+	if module, file := moduleInfo(abs, root); module != "" {
+		fmt.Fprintf(&b, "Module: %s (%s)\n", module, file)
+	}
+	// 目录概要最多占预算的四分之一，超出时从末尾逐行去掉
+	tree := treeSummary(files)
+	lines := strings.SplitAfter(tree, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for cost := tokens.Count(tree); cost > budget/4 && len(lines) > 1; lines = lines[:len(lines)-1] {
+		cost -= tokens.Count(lines[len(lines)-1])
+	}
+	tree = strings.Join(lines, "")
+	b.WriteString("\nDirectory tree (directories show their file count):\n\n```\n" + tree + "```\n")
+
+	c := &Context{Root: root}
+	remaining := budget - tokens.Count(b.String())
+	var excerpts []excerpt
+	if mode == ModeFull {
+		excerpts = wholeFiles(root, files)
+	} else {
+		excerpts = relevantExcerpts(root, rel, files, prompt)
+	}
+	included := map[string]bool{}
+	for _, e := range excerpts {
+		block := e.markdown()
+		cost := tokens.Count(block)
+		if cost > remaining {
+			continue
+		}
+		if len(included) == 0 {
+			if mode == ModeFull {
+				b.WriteString("\nProject files:\n")
+			} else {
+				b.WriteString("\nCode the question may refer to:\n")
+			}
+		}
+		b.WriteString(block)
+		remaining -= cost
+		if !included[e.path] {
+			included[e.path] = true
+			c.Files = append(c.Files, e.path)
+		}
+	}
+	c.Text = b.String()
+	c.Tokens = tokens.Count(c.Text)
+	return c, nil
+}
+
+// findRoot 由 dir 向上查找项目根目录：最近的 .git 所在目录，没有时取最远的其他标志文件所在目录
+func findRoot(dir string) string {
+	root := ""
+	for d := dir; ; d = filepath.Dir(d) {
+		for _, marker := range projectMarkers {
+			if _, err := os.Stat(filepath.Join(d, marker)); err == nil {
+				if marker == ".git" {
+					return d
+				}
+				root = d
+				break
+			}
+		}
+		if filepath.Dir(d) == d {
+			return root
+		}
+	}
+}
+
+// moduleInfo 由 dir 向上（不超出 root）找到的第一个模块清单中的模块名，及该清单相对 root 的路径
+func moduleInfo(dir, root string) (string, string) {
+	for d := dir; ; d = filepath.Dir(d) {
+		rel := func(name string) string {
+			r, _ := filepath.Rel(root, filepath.Join(d, name))
+			return filepath.ToSlash(r)
+		}
+		if data, err := os.ReadFile(filepath.Join(d, "go.mod")); err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					return strings.Trim(strings.TrimSpace(rest), `"`), rel("go.mod")
+				}
+			}
+		}
+		if data, err := os.ReadFile(filepath.Join(d, "Cargo.toml")); err == nil {
+			if m := cargoNameRegexp.FindSubmatch(data); m != nil {
+				return string(m[1]), rel("Cargo.toml")
+			}
+		}
+		if data, err := os.ReadFile(filepath.Join(d, "package.json")); err == nil {
+			var pkg struct {
+				Name string `json:"name"`
+			}
+			if json.Unmarshal(data, &pkg) == nil && pkg.Name != "" {
+				return pkg.Name, rel("package.json")
+			}
+		}
+		if d == root || filepath.Dir(d) == d {
+			return "", ""
+		}
+	}
+}
+
+// listFiles 项目中的文件（相对根目录，/ 分隔，已排序）：git 仓库用 git ls-files（含未跟踪但未忽略的文件），
+// 否则遍历目录并跳过隐藏目录与常见的依赖目录；最多 maxScanFiles 个
+func listFiles(root string) []string {
+	var files []string
+	if _, err := os.Stat(filepath.Join(root, ".git")); err == nil {
+		out, err := exec.Command("git", "-C", root, "ls-files", "--cached", "--others", "--exclude-standard").Output()
+		if err == nil {
+			for _, line := range strings.Split(string(out), "\n") {
+				if line != "" {
+					files = append(files, line)
+				}
+			}
+			sort.Strings(files)
+			files = compact(files)
+			if len(files) > maxScanFiles {
+				files = files[:maxScanFiles]
+			}
+			return files
+		}
+	}
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || skippedDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		files = append(files, filepath.ToSlash(rel))
+		if len(files) == maxScanFiles {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return files
+}
+
+// excludeFiles 去掉被 .j.toml 的 context.exclude 排除的文件；模式相对 .j.toml 所在的目录
+func excludeFiles(root string, files []string, pc *project.Config) []string {
+	base := filepath.Dir(pc.Path)
+	out := files[:0]
+	for _, f := range files {
+		rel, err := filepath.Rel(base, filepath.Join(root, f))
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || !pc.Context.Excluded(filepath.ToSlash(rel)) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// compact 去掉已排序切片中的重复项
+func compact(sorted []string) []string {
+	out := sorted[:0]
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// treeSummary 目录概要：根目录下的文件逐个列出，目录只列两层并给出其中（含子目录）的文件数
+func treeSummary(files []string) string {
+	counts := map[string]int{}
+	var entries []string
+	for _, f := range files {
+		parts := strings.Split(f, "/")
+		if len(parts) == 1 {
+			entries = append(entries, f)
+			continue
+		}
+		for depth := 1; depth <= 2 && depth < len(parts); depth++ {
+			dir := strings.Join(parts[:depth], "/") + "/"
+			if counts[dir] == 0 {
+				entries = append(entries, dir)
+			}
+			counts[dir]++
+		}
+	}
+	sort.Strings(entries)
+	var b strings.Builder
+	for i, e := range entries {
+		if i == maxTreeEntries {
+			fmt.Fprintf(&b, "... (%d more)\n", len(entries)-maxTreeEntries)
+			break
+		}
+		indent := ""
+		if strings.Count(strings.TrimSuffix(e, "/"), "/") > 0 {
+			indent = "  "
+		}
+		if n := counts[e]; n > 0 {
+			fmt.Fprintf(&b, "%s%s (%d)\n", indent, e, n)
+		} else {
+			b.WriteString(indent + e + "\n")
+		}
+	}
+	return b.String()
+}
+
+// excerpt 一段附上的源码
+type excerpt struct {
+	path       string
+	start, end int // 行号范围（从 1 开始，含两端），整个文件时为 0
+	text       string
+}
+
+func (e excerpt) markdown() string {
+	title := e.path
+	if e.start > 0 {
+		title = fmt.Sprintf("%s:%d-%d", e.path, e.start, e.end)
+	}
+	fence := "```"
+	for strings.Contains(e.text, fence) {
+		fence += "`"
+	}
+	return "\n" + title + "\n\n" + fence + languages[filepath.Ext(e.path)] + "\n" + strings.TrimRight(e.text, "\n") + "\n" + fence + "\n"
+}
+
+// readText 读取文本文件，过大或含 NUL 字节（二进制）时返回 false
+func readText(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Size() > maxFileBytes {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.IndexByte(data[:min(len(data), 8<<10)], 0) >= 0 {
+		return "", false
+	}
+	return string(data), true
+}
+
+// wholeFiles full 模式：源码文件在前、其他文本文件在后，各自按从小到大排列，在预算内容纳尽量多的文件；
+// 依赖锁文件不附上
+func wholeFiles(root string, files []string) []excerpt {
+	var excerpts []excerpt
+	for _, f := range files {
+		if lockFiles[filepath.Base(f)] {
+			continue
+		}
+		if text, ok := readText(filepath.Join(root, filepath.FromSlash(f))); ok && strings.TrimSpace(text) != "" {
+			excerpts = append(excerpts, excerpt{path: f, text: text})
+		}
+	}
+	sort.SliceStable(excerpts, func(i, j int) bool {
+		si, sj := languages[filepath.Ext(excerpts[i].path)] != "", languages[filepath.Ext(excerpts[j].path)] != ""
+		if si != sj {
+			return si
+		}
+		return len(excerpts[i].text) < len(excerpts[j].text)
+	})
+	return excerpts
+}
+
+// relevantExcerpts auto 模式：先是问题中提到的文件（相对根目录或工作目录 wd 的路径，或唯一的文件名），
+// 再是问题中出现的标识符在项目中的声明，按在问题中出现的顺序排列
+func relevantExcerpts(root, wd string, files []string, prompt string) []excerpt {
+	var excerpts []excerpt
+	byBase := map[string][]string{}
+	known := map[string]bool{}
+	for _, f := range files {
+		known[f] = true
+		byBase[filepath.Base(f)] = append(byBase[filepath.Base(f)], f)
+	}
+	mentioned := map[string]bool{}
+	for _, word := range strings.Fields(prompt) {
+		word = strings.Trim(word, "`'\"()[]{},;:!?")
+		word = strings.TrimPrefix(filepath.ToSlash(word), "./")
+		path := ""
+		if known[word] {
+			path = word
+		} else if joined := filepath.ToSlash(filepath.Join(wd, word)); known[joined] {
+			path = joined
+		} else if candidates := byBase[word]; len(candidates) == 1 && strings.Contains(word, ".") {
+			path = candidates[0]
+		}
+		if path == "" || mentioned[path] {
+			continue
+		}
+		mentioned[path] = true
+		if text, ok := readText(filepath.Join(root, filepath.FromSlash(path))); ok {
+			excerpts = append(excerpts, excerpt{path: path, text: text})
+		}
+	}
+
+	var symbols []string
+	seen := map[string]bool{}
+	for _, word := range identifierRegexp.FindAllString(prompt, -1) {
+		if !seen[word] && len(symbols) < maxSymbols {
+			seen[word] = true
+			symbols = append(symbols, word)
+		}
+	}
+	if len(symbols) == 0 {
+		return excerpts
+	}
+	decls := declarations(root, files, seen, mentioned)
+	for _, symbol := range symbols {
+		for i, d := range decls[symbol] {
+			if i == maxDeclsPerSymbol {
+				break
+			}
+			excerpts = append(excerpts, d)
+		}
+	}
+	return excerpts
+}
+
+// declarations 在源码文件中查找 wanted 中名字的声明：Go 文件按语法树取完整的声明（含文档注释），
+// 其他语言按声明行取到下一个同级声明为止（最多 maxExcerptLines 行）；跳过已整体附上的文件
+func declarations(root string, files []string, wanted, skip map[string]bool) map[string][]excerpt {
+	found := map[string][]excerpt{}
+	for _, f := range files {
+		ext := filepath.Ext(f)
+		if languages[ext] == "" || skip[f] {
+			continue
+		}
+		text, ok := readText(filepath.Join(root, filepath.FromSlash(f)))
+		if !ok {
+			continue
+		}
+		if ext == ".go" {
+			goDeclarations(f, text, wanted, found)
+			continue
+		}
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			m := declRegexp.FindStringSubmatch(line)
+			if m == nil || !wanted[m[1]] {
+				continue
+			}
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			end := i + 1
+			for ; end < len(lines) && end-i < maxExcerptLines; end++ {
+				next := lines[end]
+				if t := strings.TrimLeft(next, " \t"); t != "" && len(next)-len(t) <= indent && declRegexp.MatchString(next) {
+					break
+				}
+			}
+			for end > i+1 && strings.TrimSpace(lines[end-1]) == "" {
+				end--
+			}
+			found[m[1]] = append(found[m[1]], excerpt{path: f, start: i + 1, end: end, text: strings.Join(lines[i:end], "\n")})
+		}
+	}
+	return found
+}
+
+// goDeclarations Go 文件中名字在 wanted 里的顶层函数、方法、类型、变量与常量声明
+func goDeclarations(path, text string, wanted map[string]bool, found map[string][]excerpt) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, text, parser.ParseComments)
+	if err != nil {
+		return
+	}
+	add := func(name string, node ast.Node, doc *ast.CommentGroup) {
+		if !wanted[name] {
+			return
+		}
+		from := node.Pos()
+		if doc != nil {
+			from = doc.Pos()
+		}
+		start, end := fset.Position(from), fset.Position(node.End())
+		found[name] = append(found[name], excerpt{path: path, start: start.Line, end: end.Line, text: text[start.Offset:end.Offset]})
+	}
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			add(d.Name.Name, d, d.Doc)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Name.Name, declNode(d, s), declDoc(d, s.Doc))
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						add(name.Name, declNode(d, s), declDoc(d, s.Doc))
+					}
+				}
+			}
+		}
+	}
+}
+
+// declNode 只有一项的声明取整个 GenDecl（带上 type / var 关键字），分组声明只取该项
+func declNode(d *ast.GenDecl, spec ast.Spec) ast.Node {
+	if len(d.Specs) == 1 {
+		return d
+	}
+	return spec
+}
+
+func declDoc(d *ast.GenDecl, doc *ast.CommentGroup) *ast.CommentGroup {
+	if len(d.Specs) == 1 && d.Doc != nil {
+		return d.Doc
+	}
+	return doc
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+)
+
+const (
+	// settingMaxInputBytes config.yaml 中 setting 段从 stdin 读取的字节数上限（可写 512k、4m），-1 表示不限制
+	settingMaxInputBytes = "agent_max_input_bytes"
+	// defaultMaxInputBytes 默认的输入字节数上限
+	defaultMaxInputBytes = 1 << 20
+)
+
+// inputLimits --max-input 与 --max-context 的取值，0 表示按 config.yaml 的 setting
+var inputLimits struct {
+	bytes         int64
+	contextTokens int
+}
+
+// registerLimitFlags 在 FlagSet 上注册 --max-input 与 --max-context
+func registerLimitFlags(fs *flag.FlagSet) {
+	registerMaxInput(fs)
+	fs.IntVar(&inputLimits.contextTokens, "max-context", 0, "maximum estimated tokens sent to the model (default: setting agent_max_context_tokens, else 200000; -1 = no limit)")
+}
+
+// registerMaxInput 只注册 --max-input，用于不经对话接口发送的命令（如 embed）
+func registerMaxInput(fs *flag.FlagSet) {
+	fs.Func("max-input", "maximum bytes read from stdin, e.g. 512k or 4m (default: setting agent_max_input_bytes, else 1m; -1 = no limit)", func(v string) error {
+		n, err := parseByteSize(v)
+		inputLimits.bytes = n
+		return err
+	})
+}
+
+// maxInputBytes 生效的输入字节数上限，不大于 0 表示不限制
+func maxInputBytes() int64 {
+	if inputLimits.bytes != 0 {
+		return inputLimits.bytes
+	}
+	if v := strings.TrimSpace(config.Setting(settingMaxInputBytes)); v != "" {
+		if n, err := parseByteSize(v); err == nil {
+			return n
+		}
+	}
+	return defaultMaxInputBytes
+}
+
+// parseByteSize 解析字节数，可带 k / m / g 后缀（按 1024 进位）
+func parseByteSize(v string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(v))
+	s = strings.TrimSuffix(s, "b")
+	mult := int64(1)
+	switch {
+	case strings.HasSuffix(s, "k"):
+		mult, s = 1<<10, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "m"):
+		mult, s = 1<<20, strings.TrimSuffix(s, "m")
+	case strings.HasSuffix(s, "g"):
+		mult, s = 1<<30, strings.TrimSuffix(s, "g")
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errors.New(i18n.T(i18n.MsgLimitBadSize, v))
+	}
+	return n * mult, nil
+}
+
+// readLimited 读取 r 中至多 maxInputBytes 字节：超出的部分不再读入内存，在 stderr 提示截断的位置后照常返回已读的内容；
+// 截断处落在多字节字符中间时退回到该字符之前
+func readLimited(r io.Reader) (string, error) {
+	limit := maxInputBytes()
+	if limit <= 0 {
+		data, err := io.ReadAll(r)
+		return string(data), err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) <= limit {
+		return string(data), nil
+	}
+	data = data[:limit]
+	for i := 0; i < utf8.UTFMax-1 && len(data) > 0; i++ {
+		if r, size := utf8.DecodeLastRune(data); r != utf8.RuneError || size != 1 {
+			break
+		}
+		data = data[:len(data)-1]
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgLimitInputTruncated, limit))
+	return string(data), nil
+}
@@ -1,5 +1,151 @@
 package main
 
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/filelog"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/stats"
+	"wcp_agent/internal/trace"
+)
+
+// command 子命令：run 返回的错误统一由 main 输出到 stderr 并以非零码退出
+type command struct {
+	run     func(args []string) error
+	summary string // 用法说明中的一句话介绍（i18n key）
+}
+
+// commands 子命令注册表
+var commands = map[string]command{
+	"ask":        {runAsk, i18n.MsgAskSummary},
+	"audit":      {runAudit, i18n.MsgAuditSummary},
+	"budget":     {runBudget, i18n.MsgBudgetSummary},
+	"config":     {runConfig, i18n.MsgConfigSummary},
+	"cron":       {runCron, i18n.MsgCronSummary},
+	"daemon":     {runDaemon, i18n.MsgDaemonSummary},
+	"do":         {runDo, i18n.MsgDoSummary},
+	"editor":     {runEditor, i18n.MsgEditorSummary},
+	"embed":      {runEmbed, i18n.MsgEmbedSummary},
+	"explain":    {runExplain, i18n.MsgExplainSummary},
+	"flow":       {runFlow, i18n.MsgFlowSummary},
+	"fix":        {runFix, i18n.MsgFixSummary},
+	"auth":       {runAuth, i18n.MsgAuthSummary},
+	"git":        {runGit, i18n.MsgGitSummary},
+	"guard":      {runGuard, i18n.MsgGuardSummary},
+	"history":    {runHistory, i18n.MsgHistorySummary},
+	"mcp":        {runMCP, i18n.MsgMCPSummary},
+	"migrate":    {runMigrate, i18n.MsgMigrateSummary},
+	"mock":       {runMock, i18n.MsgMockSummary},
+	"not-found":  {runNotFound, i18n.MsgNotFoundSummary},
+	"serve":      {runServe, i18n.MsgServeSummary},
+	"session":    {runSession, i18n.MsgSessionSummary},
+	"setup":      {runSetup, i18n.MsgSetupSummary},
+	"stats":      {runStats, i18n.MsgStatsSummary},
+	"template":   {runTemplate, i18n.MsgTemplateSummary},
+	"tmux-popup": {runTmuxPopup, i18n.MsgTmuxSummary},
+	"tokens":     {runTokens, i18n.MsgTokensSummary},
+	"trace":      {runTrace, i18n.MsgTraceSummary},
+	"watch":      {runWatch, i18n.MsgWatchSummary},
+	"widget":     {runWidget, i18n.MsgWidgetSummary},
+}
+
 func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name, args := os.Args[1], os.Args[2:]
+	if name == CapabilitiesFlag && len(args) == 0 {
+		if err := printCapabilities(); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+	if name == HelpMarkdownFlag && len(args) <= 1 {
+		doc, err := helpMarkdown(strings.Join(args, ""))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+			os.Exit(2)
+		}
+		fmt.Print(doc)
+		return
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgUnknownCommand, name))
+		usage()
+		os.Exit(2)
+	}
+	if err := checkProfile(); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+		os.Exit(1)
+	}
+	if name != "migrate" {
+		migrateData()
+		recoverInterrupted()
+	}
+	if firstRun(name) {
+		runFirstSetup()
+	}
+	start := time.Now()
+	if name != "trace" { // 查看链路本身不记录
+		trace.StartProcess("agent "+name, trace.String("j.command", name), trace.Int("j.args", len(args)))
+	}
+	err := cmd.run(args)
+	closeRenderers()
+	stats.RecordCommand("agent "+name, time.Since(start))
+	trace.Shutdown(err)
+	var code exitCode
+	switch {
+	case errors.As(err, &code):
+		filelog.Dispatch("agent "+name, len(args), time.Since(start), int(code), "")
+		os.Exit(int(code))
+	case err != nil:
+		filelog.Dispatch("agent "+name, len(args), time.Since(start), 1, intercept.RedactError(err))
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+		os.Exit(1)
+	}
+	filelog.Dispatch("agent "+name, len(args), time.Since(start), 0, "")
+}
+
+// exitCode 以指定退出码结束进程且不再输出错误信息（提示已由子命令自行输出）
+type exitCode int
+
+func (c exitCode) Error() string {
+	return fmt.Sprintf("exit status %d", int(c))
+}
+
+// usage 输出命令列表到 stderr，说明按最长的命令名对齐
+func usage() {
+	names := make([]string, 0, len(commands))
+	width := 0
+	for name := range commands {
+		names = append(names, name)
+		width = max(width, len(name))
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgUsage))
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgUsageCommand, width, name, i18n.T(commands[name].summary)))
+	}
+}
 
+// checkProfile 与 j 主程序一致：profile 名称不合法或不存在时报错退出，而不是改用默认 profile 的数据
+func checkProfile() error {
+	_, err := config.Profile()
+	var pe *config.ProfileError
+	switch {
+	case errors.As(err, &pe) && pe.Missing:
+		return errors.New(i18n.T(i18n.MsgProfileMissing, pe.Name, pe.Source, pe.Name))
+	case errors.As(err, &pe):
+		return errors.New(i18n.T(i18n.MsgProfileInvalid, pe.Name, pe.Source))
+	}
+	return err
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/mcp"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/spinner"
+)
+
+// runMCP agent mcp [list] [server...]：连接配置的 MCP 服务器（默认全部）并列出它们提供的工具；
+// agent mcp serve 则反过来作为 MCP 服务器提供 j 的插件
+func runMCP(args []string) error {
+	if len(args) > 0 && args[0] == "serve" {
+		return mcpServe(args[1:])
+	}
+	if len(args) > 0 && args[0] == "list" {
+		args = args[1:]
+	}
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") {
+			return errors.New(i18n.T(i18n.MsgMCPUsage))
+		}
+	}
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	names := args
+	if len(names) == 0 {
+		names = mcp.Names(cfg)
+	}
+	if len(names) == 0 {
+		fmt.Println(i18n.T(i18n.MsgMCPNone))
+		return nil
+	}
+	ts, err := openMCP(context.Background(), cfg, names)
+	if err != nil {
+		return err
+	}
+	defer ts.Close()
+	for _, s := range ts.Servers() {
+		fmt.Println(i18n.T(i18n.MsgMCPServer, s.Name, len(s.Tools)))
+		for _, tool := range s.Tools {
+			desc, _, _ := strings.Cut(strings.TrimSpace(tool.Description), "\n")
+			fmt.Printf("  %-36s %s\n", mcp.ToolName(s.Name, tool.Name), desc)
+		}
+	}
+	return nil
+}
+
+// openMCP 连接 names 中的 MCP 服务器，连接期间显示转圈提示
+func openMCP(ctx context.Context, cfg config.AgentConfig, names []string) (*mcp.Toolset, error) {
+	spin := spinner.Start(i18n.T(i18n.MsgMCPConnecting))
+	ts, err := mcp.Open(ctx, cfg, names)
+	spin.Stop()
+	return ts, err
+}
+
+// mcpTools 把已连接的服务器作为对话可用的工具，每次调用工具时以拦截器事件提示（spinner 或 stderr）
+func mcpTools(cfg config.AgentConfig, ts *mcp.Toolset) *provider.Tools {
+	for _, s := range ts.Servers() {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgMCPConnected, s.Name, len(s.Tools)))
+	}
+	return ts.Tools(cfg.MaxToolRounds, func(ctx context.Context, name string) {
+		intercept.Notify(ctx, intercept.Event{Kind: intercept.EventToolCall, Tool: name})
+	})
+}
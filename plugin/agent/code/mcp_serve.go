@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/mcp"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/trace"
+)
+
+// 作为 MCP 服务器提供的工具
+const (
+	toolRender       = "render"
+	toolSnippets     = "snippets"
+	toolNotes        = "notes"
+	toolShellExplain = "shell_explain"
+)
+
+// defaultServeTools agent mcp serve 默认提供的工具
+var defaultServeTools = []string{toolRender, toolSnippets, toolNotes, toolShellExplain}
+
+// mcpServe agent mcp serve [--tools render,snippets,notes,shell_explain]：作为 stdio MCP 服务器，
+// 把 j 的插件提供给编辑器、桌面应用等其他 AI 客户端调用；找不到对应插件的工具不提供
+func mcpServe(args []string) error {
+	fs := flag.NewFlagSet("mcp serve", flag.ContinueOnError)
+	selected := fs.String("tools", strings.Join(defaultServeTools, ","), "comma-separated tools to expose: "+strings.Join(defaultServeTools, ", "))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var tools []mcp.ServerTool
+	for _, name := range strings.Split(*selected, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		tool, err := serveTool(name)
+		if err != nil {
+			return err
+		}
+		if tool.Run == nil {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgMCPServeMissing, name))
+			continue
+		}
+		tools = append(tools, tool)
+	}
+	names := make([]string, len(tools))
+	for i, t := range tools {
+		names[i] = t.Name
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgMCPServing, strings.Join(names, ", ")))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return mcp.Serve(ctx, os.Stdin, os.Stdout, "j", strconv.Itoa(ProtocolVersion), tools)
+}
+
+// serveTool 按名称构造工具；依赖的插件不存在时 Run 为 nil
+func serveTool(name string) (mcp.ServerTool, error) {
+	switch name {
+	case toolRender:
+		return mcp.ServerTool{
+			Tool: mcp.Tool{
+				Name:        toolRender,
+				Description: "Render Markdown to terminal text with the user's md_render setup (themes, code highlighting, tables). Colors are stripped unless color is true.",
+				InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+					`"markdown":{"type":"string","description":"Markdown source"},` +
+					`"width":{"type":"integer","description":"line width, 0 for the default"},` +
+					`"theme":{"type":"string","description":"md_render theme, e.g. dark or light"},` +
+					`"color":{"type":"boolean","description":"keep ANSI colors"}},"required":["markdown"]}`),
+			},
+			Run: ifPlugin(mdRenderPathOK, renderTool),
+		}, nil
+	case toolSnippets:
+		return mcp.ServerTool{
+			Tool: mcp.Tool{
+				Name:        toolSnippets,
+				Description: "Search the user's saved code snippets (snip). Give query to fuzzy-search names, tags, languages and code, id to print one snippet, or neither to list all.",
+				InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+					`"query":{"type":"string"},"id":{"type":"string","description":"snippet id or name"},` +
+					`"tag":{"type":"string","description":"only list snippets with this tag"}}}`),
+			},
+			Run: pluginTool("snip", func(a toolArgs) ([]string, string, error) {
+				switch {
+				case a.ID != "":
+					return []string{"show", a.ID}, "", nil
+				case a.Query != "":
+					return []string{"search", a.Query}, "", nil
+				case a.Tag != "":
+					return []string{"list", "-t", a.Tag}, "", nil
+				}
+				return []string{"list"}, "", nil
+			}),
+		}, nil
+	case toolNotes:
+		return mcp.ServerTool{
+			Tool: mcp.Tool{
+				Name:        toolNotes,
+				Description: "Read or add the user's notes (note). action is list (most recent first), search (line matches for query), view (one note by id) or add (save content as a new note).",
+				InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+					`"action":{"type":"string","enum":["list","search","view","add"]},` +
+					`"query":{"type":"string"},"id":{"type":"string"},"content":{"type":"string"},` +
+					`"limit":{"type":"integer","description":"notes to list, default 20"}},"required":["action"]}`),
+			},
+			Run: pluginTool("note", func(a toolArgs) ([]string, string, error) {
+				switch a.Action {
+				case "list":
+					limit := a.Limit
+					if limit <= 0 {
+						limit = 20
+					}
+					return []string{"list", "-n", strconv.Itoa(limit)}, "", nil
+				case "search":
+					return []string{"search", a.Query}, "", nil
+				case "view":
+					return []string{"view", a.ID}, "", nil
+				case "add":
+					// 内容经 stdin 传入，避免被当作参数解析
+					return []string{"add"}, a.Content, nil
+				}
+				return nil, "", errors.New(i18n.T(i18n.MsgMCPServeAction, a.Action))
+			}),
+		}, nil
+	case toolShellExplain:
+		return mcp.ServerTool{
+			Tool: mcp.Tool{
+				Name:        toolShellExplain,
+				Description: "Explain a shell command with the user's configured model: what each part does, side effects and risks. Returns Markdown.",
+				InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+					`"command":{"type":"string"},"shell":{"type":"string","description":"bash, zsh, fish, ... (default: inferred)"}},"required":["command"]}`),
+			},
+			Run: shellExplainTool,
+		}, nil
+	}
+	return mcp.ServerTool{}, errors.New(i18n.T(i18n.MsgMCPServeUnknown, name, strings.Join(defaultServeTools, ", ")))
+}
+
+// toolArgs 各工具参数的并集
+type toolArgs struct {
+	Markdown string `json:"markdown"`
+	Width    int    `json:"width"`
+	Theme    string `json:"theme"`
+	Color    bool   `json:"color"`
+	Query    string `json:"query"`
+	ID       string `json:"id"`
+	Tag      string `json:"tag"`
+	Action   string `json:"action"`
+	Content  string `json:"content"`
+	Limit    int    `json:"limit"`
+	Command  string `json:"command"`
+	Shell    string `json:"shell"`
+}
+
+func parseToolArgs(raw json.RawMessage) (toolArgs, error) {
+	var a toolArgs
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return a, errors.New(i18n.T(i18n.MsgServeBadRequest, err))
+	}
+	return a, nil
+}
+
+func mdRenderPathOK() bool {
+	_, err := config.MdRenderPath()
+	return err == nil
+}
+
+// ifPlugin 插件存在时返回 run，否则返回 nil
+func ifPlugin(available func() bool, run func(context.Context, json.RawMessage) (string, error)) func(context.Context, json.RawMessage) (string, error) {
+	if !available() {
+		return nil
+	}
+	return run
+}
+
+// ansi 终端控制序列：SGR 颜色与 OSC 8 超链接
+var ansi = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\]8;[^\x1b\a]*(\x1b\\|\a)`)
+
+func renderTool(ctx context.Context, raw json.RawMessage) (string, error) {
+	a, err := parseToolArgs(raw)
+	if err != nil {
+		return "", err
+	}
+	var args []string
+	if a.Theme != "" {
+		args = append(args, "--theme", a.Theme)
+	}
+	out, err := renderText(ctx, a.Markdown, max(a.Width, 0), args)
+	if err != nil {
+		return "", err
+	}
+	if !a.Color {
+		out = ansi.ReplaceAll(out, nil)
+	}
+	return string(out), nil
+}
+
+// pluginTool 以子进程调用插件：build 由参数得到命令行与 stdin，返回插件的输出（管道中为原文，不经渲染）
+func pluginTool(plugin string, build func(toolArgs) ([]string, string, error)) func(context.Context, json.RawMessage) (string, error) {
+	bin, err := config.PluginPath(plugin)
+	if err != nil {
+		return nil
+	}
+	return func(ctx context.Context, raw json.RawMessage) (string, error) {
+		a, err := parseToolArgs(raw)
+		if err != nil {
+			return "", err
+		}
+		args, stdin, err := build(a)
+		if err != nil {
+			return "", err
+		}
+		cmd := exec.CommandContext(ctx, bin, args...)
+		trace.Inject(ctx, cmd)
+		cmd.Stdin = strings.NewReader(stdin)
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", errors.New(msg)
+			}
+			return "", err
+		}
+		return string(out), nil
+	}
+}
+
+func shellExplainTool(ctx context.Context, raw json.RawMessage) (string, error) {
+	a, err := parseToolArgs(raw)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(a.Command) == "" {
+		return "", errors.New(i18n.T(i18n.MsgAskNoPrompt))
+	}
+	cfg, err := loadAgent()
+	if err != nil {
+		return "", err
+	}
+	p, err := selectProvider(cfg, "")
+	if err != nil {
+		return "", err
+	}
+	key, _ := auth.Resolve(p)
+	opts := provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(p)}
+	return chatOnce(quietly(ctx), cfg, p, opts, shellExplainPrompt(a.Command, a.Shell, false), i18n.T(i18n.MsgAskThinking))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/migrate"
+)
+
+// migrateData 在执行子命令之前把旧格式的数据升级到当前版本；失败时只提示，不影响本次命令
+func migrateData() {
+	res, err := migrate.Run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgMigrateFailed, err))
+		return
+	}
+	reportMigration(res)
+}
+
+// reportMigration 有文件被改动时在 stderr 提示一行
+func reportMigration(res migrate.Result) {
+	if len(res.Applied) > 0 {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgMigrateUpgraded, res.From, res.To, strings.Join(res.Applied, ", "), res.Backup))
+	}
+}
+
+// runMigrate agent migrate [status | run]：status（默认）列出格式版本与各迁移步骤，
+// run 立即执行尚未完成的迁移（平时在任何子命令之前自动执行）
+func runMigrate(args []string) error {
+	if len(args) > 1 {
+		return errors.New(i18n.T(i18n.MsgMigrateUsage))
+	}
+	op := "status"
+	if len(args) == 1 {
+		op = args[0]
+	}
+	switch op {
+	case "status":
+	case "run":
+		res, err := migrate.Run()
+		if err != nil {
+			return err
+		}
+		reportMigration(res)
+	default:
+		return errors.New(i18n.T(i18n.MsgMigrateUsage))
+	}
+	current, err := migrate.Current()
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.T(i18n.MsgMigrateVersion, current, migrate.Latest()))
+	for _, s := range migrate.Steps() {
+		state := i18n.T(i18n.MsgMigratePending)
+		if s.Version <= current {
+			state = i18n.T(i18n.MsgMigrateDone)
+		}
+		fmt.Printf("  v%-3d %-24s %s\n", s.Version, s.Name, state)
+	}
+	fmt.Println(i18n.T(i18n.MsgMigrateBackups, filepath.Join(config.AgentDataDir(), migrate.BackupDir)))
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+// runMock agent mock serve [--listen addr] [--script file]：
+// 启动 OpenAI 兼容的 mock 服务，供 chat TUI 与集成测试离线使用
+func runMock(args []string) error {
+	if len(args) == 0 || args[0] != "serve" {
+		return errors.New(i18n.T(i18n.MsgMockUsage))
+	}
+	fs := flag.NewFlagSet("mock serve", flag.ContinueOnError)
+	listen := fs.String("listen", "127.0.0.1:18080", "address to listen on")
+	scriptPath := fs.String("script", "", "JSON replay script (echo mode when empty)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	script, err := provider.LoadMockScript(*scriptPath)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgMockListening, *listen))
+	return http.ListenAndServe(*listen, provider.MockHandler(script))
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/i18n"
+)
+
+// notFoundTimeout 请模型给出建议的时限，超时就只报"找不到命令"，不让提示符久等
+const notFoundTimeout = 15 * time.Second
+
+// runNotFound agent not-found [--shell zsh] [--no-ai] -- <命令> [参数...]：供 command-not-found 钩子调用，
+// 先在 PATH 中找拼写相近的命令，找不到时请模型给出本意的命令或提供它的安装命令；
+// 建议（连同原有参数）写到 stdout，没有建议时以 127 退出
+func runNotFound(args []string) error {
+	fs := flag.NewFlagSet("not-found", flag.ContinueOnError)
+	shell := fs.String("shell", "", "shell the command was typed in (default: basename of $SHELL)")
+	noAI := fs.Bool("no-ai", false, "only suggest similarly spelled commands from PATH")
+	providerName := fs.String("provider", "", "provider name (defaults to the active one)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return exitCode(127)
+	}
+	name, rest := fs.Arg(0), fs.Args()[1:]
+	if match, ok := similarCommand(name); ok {
+		fmt.Println(strings.Join(append([]string{match}, quoteArgs(rest)...), " "))
+		return nil
+	}
+	if *noAI {
+		return exitCode(127)
+	}
+	if *shell == "" {
+		*shell = loginShell()
+	}
+	line := strings.Join(append([]string{name}, quoteArgs(rest)...), " ")
+	ctx, cancel := context.WithTimeout(context.Background(), notFoundTimeout)
+	defer cancel()
+	answer, err := shellChat(ctx, *providerName, notFoundPrompt(line, *shell), i18n.T(i18n.MsgNotFoundAsking, name))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+		return exitCode(127)
+	}
+	// 钩子会 eval 建议，只取一行
+	suggestion, _, _ := strings.Cut(extractCommand(answer), "\n")
+	suggestion = strings.TrimSpace(suggestion)
+	if suggestion == "" || strings.HasPrefix(suggestion, "#") {
+		if suggestion != "" {
+			fmt.Fprintln(os.Stderr, strings.TrimSpace(strings.TrimPrefix(suggestion, "#")))
+		}
+		return exitCode(127)
+	}
+	fmt.Println(suggestion)
+	return nil
+}
+
+// notFoundPrompt 请模型给出本意的命令或安装命令
+func notFoundPrompt(line, shell string) string {
+	return fmt.Sprintf(`In %s on %s the user typed the following, and the shell answered "command not found":
+
+%s
+
+Reply with exactly one command line and nothing else (no Markdown, no explanation):
+- if it looks like a typo or a wrong name for a common command, the corrected command line with the same arguments;
+- otherwise the command that installs the package providing it with this system's usual package manager.
+If you are not sure, reply with a single line starting with "# " saying so, in the language %q.
+`, shell, runtime.GOOS, line, string(i18n.Current()))
+}
+
+// similarCommand PATH 中与 name 拼写最相近的可执行文件（短名允许 1 处、5 个字符以上允许 2 处编辑）
+func similarCommand(name string) (string, bool) {
+	limit := 1
+	if len(name) >= 5 {
+		limit = 2
+	}
+	if len(name) < 2 {
+		return "", false
+	}
+	var best []string
+	bestDist := limit + 1
+	for _, c := range pathCommands() {
+		if c == name || abs(len(c)-len(name)) > limit {
+			continue
+		}
+		d := editDistance(name, c)
+		switch {
+		case d < bestDist:
+			best, bestDist = []string{c}, d
+		case d == bestDist:
+			best = append(best, c)
+		}
+	}
+	if len(best) == 0 {
+		return "", false
+	}
+	// 同样相近时取较短、再按字母序靠前的
+	sort.Slice(best, func(i, j int) bool {
+		if len(best[i]) != len(best[j]) {
+			return len(best[i]) < len(best[j])
+		}
+		return best[i] < best[j]
+	})
+	return best[0], true
+}
+
+// pathCommands PATH 各目录中的可执行文件名（去重）
+func pathCommands() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if seen[e.Name()] || e.IsDir() {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil || info.Mode()&0o111 == 0 && info.Mode()&os.ModeSymlink == 0 {
+				continue
+			}
+			seen[e.Name()] = true
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// editDistance 编辑距离，相邻字符对调算 1 处（git stauts → status）
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// quoteArgs 按 shell 单引号规则引用含特殊字符的参数，使建议的命令行可以原样 eval
+func quoteArgs(args []string) []string {
+	out := make([]string, len(args))
+	for i, a := range args {
+		if a != "" && !strings.ContainsAny(a, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+			out[i] = a
+			continue
+		}
+		out[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/mask"
+)
+
+var (
+	outputMaskOnce sync.Once
+	outputMasker   *mask.Masker
+)
+
+// outputMask 显示与导出回答时套用的脱敏规则（agent_config.json 的 output_redact），首次使用时读取；
+// 配置读取失败时不脱敏（命令本身会报告配置错误）
+func outputMask() *mask.Masker {
+	outputMaskOnce.Do(func() {
+		cfg, err := config.LoadAgent()
+		if err != nil {
+			return
+		}
+		var errs []error
+		outputMasker, errs = mask.New(cfg.OutputRedact)
+		for _, err := range errs {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgMaskBadRule, err))
+		}
+	})
+	return outputMasker
+}
+
+// masked 按 output_redact 规则脱敏后的文本
+func masked(text string) string {
+	return outputMask().Apply(text)
+}
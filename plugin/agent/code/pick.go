@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/picker"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/session"
+)
+
+// pickAction 选中之后的动作：默认输出，--copy 复制到剪贴板，--ask 接着提问（其余参数为追问，省略时在终端输入）
+type pickAction struct {
+	copy, ask *bool
+}
+
+func newPickAction(fs *flag.FlagSet) pickAction {
+	return pickAction{
+		copy: fs.Bool("copy", false, "copy the choice to the clipboard instead of printing it"),
+		ask:  fs.Bool("ask", false, "continue with a follow-up ask (remaining arguments, or typed at the prompt)"),
+	}
+}
+
+// historyPick agent history pick [-n N] [--copy | --ask [追问]]：模糊选择一条问答，输出（或复制）其回答，或以它为上文接着提问
+func historyPick(args []string) error {
+	fs := flag.NewFlagSet("history pick", flag.ContinueOnError)
+	limit := fs.Int("n", 500, "number of most recent exchanges to pick from (0 for all)")
+	action := newPickAction(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	list, err := history.Load()
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Println(i18n.T(i18n.MsgHistoryEmpty))
+		return nil
+	}
+	titles := history.Titles(list)
+	if *limit > 0 && len(list) > *limit {
+		list = list[len(list)-*limit:]
+	}
+	// 最近的在前，以对话标题标明各轮属于哪段对话
+	items := make([]picker.Item, len(list))
+	for i := range list {
+		e := list[len(list)-1-i]
+		items[i] = picker.Item{
+			Label:   fmt.Sprintf("%s  %s  %-12s %s", e.ID, e.Time.Local().Format("2006-01-02 15:04"), e.Provider, masked(exchangeLabel(e, titles, 0))),
+			Preview: masked("> " + strings.ReplaceAll(e.Prompt, "\n", "\n> ") + "\n\n" + e.Answer + "\n"),
+		}
+	}
+	i, err := pick(i18n.T(i18n.MsgPickHistory), items)
+	if err != nil {
+		return err
+	}
+	e := list[len(list)-1-i]
+	switch {
+	case *action.ask:
+		return followUp([]string{"--continue", e.ID}, fs.Args())
+	case *action.copy:
+		return copyAnswer(e.Answer)
+	}
+	return renderMarkdown(e.Answer)
+}
+
+// runSession agent session list | pick [--copy | --ask [追问]]：守护进程中保存的多轮会话
+func runSession(args []string) error {
+	if len(args) == 0 {
+		return errors.New(i18n.T(i18n.MsgSessionUsage))
+	}
+	switch args[0] {
+	case "list":
+		if len(args) > 1 {
+			return errors.New(i18n.T(i18n.MsgSessionUsage))
+		}
+		sessions, err := daemonSessions()
+		if err != nil {
+			return err
+		}
+		for _, s := range sessions {
+			fmt.Printf("%-20s %4d  %s\n", s.Name, s.Messages, s.Updated.Local().Format("2006-01-02 15:04"))
+		}
+		return nil
+	case "pick":
+		return sessionPick(args[1:])
+	}
+	return errors.New(i18n.T(i18n.MsgSessionUsage))
+}
+
+// sessionPick 选择一个会话：默认输出会话名（可用于 agent ask --session "$(agent session pick)"），
+// --copy 复制最后一条回答，--ask 在该会话中接着提问
+func sessionPick(args []string) error {
+	fs := flag.NewFlagSet("session pick", flag.ContinueOnError)
+	action := newPickAction(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	sessions, err := daemonSessions()
+	if err != nil {
+		return err
+	}
+	items := make([]picker.Item, len(sessions))
+	for i, s := range sessions {
+		items[i] = picker.Item{Label: fmt.Sprintf("%-20s %s  %s", s.Name, s.Updated.Local().Format("2006-01-02 15:04"), i18n.T(i18n.MsgSessionMessages, s.Messages))}
+		if messages, ok, err := daemon.SessionMessages(s.Name); err == nil && ok {
+			items[i].Preview = transcript(messages)
+		}
+	}
+	i, err := pick(i18n.T(i18n.MsgPickSession), items)
+	if err != nil {
+		return err
+	}
+	name := sessions[i].Name
+	switch {
+	case *action.ask:
+		return followUp([]string{"--session", name}, fs.Args())
+	case *action.copy:
+		messages, _, err := daemon.SessionMessages(name)
+		if err != nil {
+			return err
+		}
+		for j := len(messages) - 1; j >= 0; j-- {
+			if messages[j].Role == "assistant" {
+				return copyAnswer(messages[j].Content)
+			}
+		}
+		return errors.New(i18n.T(i18n.MsgSessionNoAnswer, name))
+	}
+	fmt.Println(name)
+	return nil
+}
+
+// daemonSessions 守护进程中的会话，最近更新的在前；没有会话时提示
+func daemonSessions() ([]session.Info, error) {
+	if !daemon.Available() {
+		return nil, errors.New(i18n.T(i18n.MsgAskSessionNoDaemon))
+	}
+	status, err := daemon.FetchStatus()
+	if err != nil {
+		return nil, err
+	}
+	sessions := status.Sessions
+	if len(sessions) == 0 {
+		return nil, errors.New(i18n.T(i18n.MsgSessionEmpty))
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Updated.After(sessions[j].Updated) })
+	return sessions, nil
+}
+
+// transcript 会话消息的预览：提问以 > 引用，system 消息略去
+func transcript(messages []provider.Message) string {
+	var b strings.Builder
+	for _, m := range messages {
+		switch m.Role {
+		case "user":
+			b.WriteString("> " + strings.ReplaceAll(m.Content, "\n", "\n> ") + "\n\n")
+		case "assistant":
+			b.WriteString(m.Content + "\n\n")
+		}
+	}
+	return b.String()
+}
+
+// pick 交互选择，取消时以 130 退出（与 Ctrl-C 一致）
+func pick(prompt string, items []picker.Item) (int, error) {
+	i, err := picker.Pick(prompt, items)
+	if errors.Is(err, picker.ErrCancelled) {
+		return -1, exitCode(130)
+	}
+	if errors.Is(err, picker.ErrNoTerminal) {
+		return -1, errors.New(i18n.T(i18n.MsgPickNoTerminal))
+	}
+	return i, err
+}
+
+// followUp 以 base（--continue id 或 --session name）接着 agent ask，question 可带 ask 的选项；
+// 没有给出追问时与 agent ask 一样读取：终端中逐行输入，否则读取整个 stdin，为空时报错
+func followUp(base, question []string) error {
+	if len(question) == 0 {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgPickFollowUp))
+		}
+		prompt, err := readPrompt(nil)
+		if err != nil {
+			return err
+		}
+		question = []string{"--", prompt}
+	}
+	return runAsk(append(base, question...))
+}
+
+// copyAnswer 把回答（按 output_redact 规则脱敏后）写入剪贴板
+func copyAnswer(text string) error {
+	if err := copyToClipboard(masked(text)); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgPickCopied))
+	return nil
+}
+
+// clipboardCommands 各平台的剪贴板写入命令，按顺序尝试
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// copyToClipboard 写入系统剪贴板
+func copyToClipboard(text string) error {
+	for _, argv := range clipboardCommands {
+		if argv[0] == "pbcopy" && runtime.GOOS != "darwin" {
+			continue
+		}
+		path, err := exec.LookPath(argv[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, argv[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return errors.New(i18n.T(i18n.MsgPickNoClipboard))
+}
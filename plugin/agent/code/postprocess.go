@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/filter"
+)
+
+// answerStages 输出回答前依次执行的过滤器：先是采样参数（预设）启用的后处理器（postprocess 为 false 时不用，
+// 如结构化输出的 JSON），再是 --through 给出的过滤器；都没有时返回 nil，不必与插件握手
+func answerStages(params config.Sampling, through []string, postprocess bool) ([]filter.Stage, error) {
+	if !postprocess {
+		params.Postprocess = nil
+	}
+	if len(params.Postprocess) == 0 && len(through) == 0 {
+		return nil, nil
+	}
+	plugins := daemon.Plugins()
+	stages, err := filter.Postprocessors(params.Postprocess, plugins)
+	if err != nil {
+		return nil, err
+	}
+	more, err := filter.Resolve(through, plugins)
+	if err != nil {
+		return nil, err
+	}
+	return append(stages, more...), nil
+}
+
+// applyStages 把回答依次交给各过滤器，返回最后一个的结果；初始 meta 为回答的 provider 与 model
+func applyStages(ctx context.Context, stages []filter.Stage, p config.Provider, answer string) (string, error) {
+	if len(stages) == 0 {
+		return answer, nil
+	}
+	out, err := filter.Run(ctx, stages, filter.Payload{Text: answer, Meta: map[string]string{"provider": p.Name, "model": p.Model}})
+	return out.Text, err
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+)
+
+// promptHistoryLimit 行编辑器中可用上下键翻出的历史问题条数
+const promptHistoryLimit = 200
+
+// readPrompt 优先使用命令行参数，其次读取管道输入（至多 maxInputBytes 字节）；stdin 为终端时打开多行的行编辑器
+func readPrompt(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return interactivePrompt()
+	}
+	data, err := readLimited(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	prompt := strings.TrimSpace(data)
+	if prompt == "" {
+		return "", errors.New(i18n.T(i18n.MsgAskNoPrompt))
+	}
+	return prompt, nil
+}
+
+// interactivePrompt 在终端中逐行输入问题：空行（或 Ctrl-D）发送，Ctrl-C 取消；
+// 支持常见的行编辑按键与上下键翻出以前的问题，粘贴的多行文本（bracketed paste）中的空行不会提前发送
+func interactivePrompt() (string, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(fd, state)
+
+	in := &interruptReader{r: os.Stdin}
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, os.Stderr}, "> ")
+	if width, height, err := term.GetSize(fd); err == nil && width > 0 {
+		t.SetSize(width, height)
+	}
+	t.History = loadPromptHistory()
+	t.SetBracketedPasteMode(true)
+	defer t.SetBracketedPasteMode(false)
+	fmt.Fprint(t, i18n.T(i18n.MsgPromptHint)+"\n")
+
+	var lines []string
+	for {
+		line, err := t.ReadLine()
+		pasted := errors.Is(err, term.ErrPasteIndicator)
+		switch {
+		case in.interrupted:
+			return "", exitCode(130)
+		case errors.Is(err, io.EOF):
+			return joinPrompt(lines)
+		case err != nil && !pasted:
+			return "", err
+		case line == "" && !pasted && len(lines) > 0:
+			return joinPrompt(lines)
+		case line == "" && !pasted:
+			continue
+		}
+		lines = append(lines, line)
+		t.SetPrompt(". ")
+	}
+}
+
+func joinPrompt(lines []string) (string, error) {
+	prompt := strings.TrimSpace(strings.Join(lines, "\n"))
+	if prompt == "" {
+		return "", errors.New(i18n.T(i18n.MsgAskNoPrompt))
+	}
+	return prompt, nil
+}
+
+// interruptReader 记下读到的 Ctrl-C：term.Terminal 对 Ctrl-C 与 Ctrl-D 都只返回 io.EOF
+type interruptReader struct {
+	r           io.Reader
+	interrupted bool
+}
+
+func (r *interruptReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if bytes.IndexByte(p[:n], 3) >= 0 {
+		r.interrupted = true
+	}
+	return n, err
+}
+
+// promptHistory 以前问过的单行问题，供行编辑器的上下键使用（新输入的也会加入，但不写回文件）
+type promptHistory []string
+
+func (h *promptHistory) Add(entry string) {
+	if entry != "" && (len(*h) == 0 || (*h)[0] != entry) {
+		*h = append([]string{entry}, *h...)
+	}
+}
+
+func (h *promptHistory) Len() int { return len(*h) }
+
+func (h *promptHistory) At(idx int) string { return (*h)[idx] }
+
+// loadPromptHistory 从问答历史中取最近的单行问题，最新的在前，重复的只保留一次
+func loadPromptHistory() *promptHistory {
+	h := promptHistory{}
+	list, _ := history.Load()
+	seen := map[string]bool{}
+	for i := len(list) - 1; i >= 0 && len(h) < promptHistoryLimit; i-- {
+		p := strings.TrimSpace(list[i].Prompt)
+		if p == "" || strings.Contains(p, "\n") || seen[p] {
+			continue
+		}
+		seen[p] = true
+		h = append(h, p)
+	}
+	return &h
+}
+
+// editorPrompt 用 $VISUAL / $EDITOR（默认 vi）编辑问题，initial 为初始内容；保存后的内容为空时视为没有问题
+func editorPrompt(initial string) (string, error) {
+	f, err := os.CreateTemp("", "agent-prompt-*.md")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if initial != "" {
+		initial += "\n"
+	}
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	// 编辑器需要终端：stdin 不是终端（例如经管道调用）时改用 /dev/tty
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stderr, os.Stderr
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		if tty, err := os.Open("/dev/tty"); err == nil {
+			defer tty.Close()
+			cmd.Stdin = tty
+		}
+	}
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w", editor, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	prompt := strings.TrimSpace(string(data))
+	if prompt == "" {
+		return "", errors.New(i18n.T(i18n.MsgPromptEmpty))
+	}
+	return prompt, nil
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/a11y"
+	"wcp_agent/internal/attach"
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/spinner"
+	"wcp_agent/internal/worddiff"
+)
+
+// 逐词对比的着色，与 md_render --diff 一致：新增为绿底，删除为红底加删除线
+const (
+	ansiInsert = "\x1b[48;5;22m"
+	ansiDelete = "\x1b[48;5;52;9m"
+	ansiReset  = "\x1b[0m"
+)
+
+// historyRegen agent history regen <id> [--model M] [--provider P]：用同一个问题（连同附件图片）重新提问，
+// 默认沿用原来的 provider，新的回答记入历史，并逐词对比新旧回答：终端中交给 md_render --diff 渲染，
+// 否则输出以 [-删除-]{+新增+} 标出增删的原文
+func historyRegen(args []string) error {
+	fs := flag.NewFlagSet("history regen", flag.ContinueOnError)
+	providerName := fs.String("provider", "", "provider to ask (defaults to the one that gave the original answer)")
+	model := fs.String("model", "", "model to ask: a provider name, a configured model, or any model of the selected provider")
+	var sampling samplingFlags
+	sampling.register(fs)
+	registerLimitFlags(fs)
+	registerSendFlags(fs)
+	id, rest := "", args
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		id, rest = rest[0], rest[1:]
+	}
+	if err := fs.Parse(rest); err != nil {
+		return err
+	}
+	if id == "" && fs.NArg() == 1 {
+		id = fs.Arg(0)
+	} else if id == "" || fs.NArg() > 0 {
+		return errors.New(i18n.T(i18n.MsgRegenUsage))
+	}
+	old, ok, err := history.Find(id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New(i18n.T(i18n.MsgHistoryNotFound, id))
+	}
+
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	p, err := regenProvider(cfg, old, *providerName, *model)
+	if err != nil {
+		return err
+	}
+	params, err := sampling.resolve(cfg, p)
+	if err != nil {
+		return err
+	}
+	if len(old.Attachments) > 0 && p.Vision != nil && !*p.Vision {
+		return fmt.Errorf("%s", i18n.T(i18n.MsgAttachNoVision, p.Name))
+	}
+	user := provider.Message{Role: "user", Content: old.Prompt}
+	for _, path := range old.Attachments {
+		img, err := attach.LoadImage(path)
+		if err != nil {
+			return err
+		}
+		user.Images = append(user.Images, img.DataURL())
+	}
+	var messages []provider.Message
+	if system := config.LoadSystemPrompt(cfg); system != "" {
+		messages = append(messages, provider.Message{Role: "system", Content: system})
+	}
+	// 原来那一轮接在某段对话之后时，重新提问带上同样的上文，新回答成为同一位置上的另一个分支
+	if old.Parent != "" {
+		thread, _, err := history.Thread(old.Parent)
+		if err != nil {
+			return err
+		}
+		for _, e := range thread {
+			messages = append(messages, provider.Message{Role: "user", Content: e.Prompt}, provider.Message{Role: "assistant", Content: e.Answer})
+		}
+	}
+	messages = append(messages, user)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	key, _ := auth.Resolve(p)
+	client, err := newClient(p, provider.Options{
+		Key:      key,
+		Timeouts: cfg.EffectiveTimeouts(p),
+		Sampling: params,
+		// 重新提问应得到新的回答，不取缓存
+		NoCache: true,
+	})
+	if err != nil {
+		return err
+	}
+	status := i18n.T(i18n.MsgRegenThinking, providerLabel(p.Name, p.Model))
+	spin := spinner.Start(status)
+	start := time.Now()
+	answer, err := client.Chat(withSpinner(ctx, spin, status), messages, nil)
+	spin.Stop()
+	elapsed := time.Since(start)
+
+	exchange := history.Exchange{
+		Provider:        p.Name,
+		Model:           p.Model,
+		Prompt:          old.Prompt,
+		Attachments:     old.Attachments,
+		Answer:          answer,
+		Status:          history.StatusOK,
+		DurationMs:      elapsed.Milliseconds(),
+		RegeneratedFrom: old.ID,
+		Parent:          old.Parent,
+	}
+	switch {
+	case ctx.Err() != nil:
+		exchange.Status = history.StatusCancelled
+	case errors.Is(err, provider.ErrTruncated):
+		exchange.Status = history.StatusTruncated
+	case err != nil:
+		exchange.Status = history.StatusError
+		exchange.Error = err.Error()
+	}
+	exchange.ID = history.NewID()
+	if herr := history.Append(exchange); herr != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, herr))
+	}
+	if err != nil && !errors.Is(err, provider.ErrTruncated) {
+		return interrupted(ctx, err)
+	}
+	if errors.Is(err, provider.ErrTruncated) {
+		warnTruncated()
+	}
+
+	fmt.Println(i18n.T(i18n.MsgRegenOld, old.ID, providerLabel(old.Provider, old.Model), old.Time.Local().Format("2006-01-02 15:04")))
+	fmt.Println(i18n.T(i18n.MsgRegenNew, exchange.ID, providerLabel(p.Name, p.Model), elapsed.Seconds()))
+	fmt.Println()
+	if strings.TrimSpace(old.Answer) == strings.TrimSpace(answer) {
+		fmt.Println(i18n.T(i18n.MsgRegenSame))
+		return nil
+	}
+	return renderAnswerDiff(old.Answer, answer)
+}
+
+// renderAnswerDiff 逐词对比两次回答：终端中由 md_render --diff 渲染合并后的 Markdown（增删词数在开头），
+// 未找到 md_render 或非终端输出时自行比较，输出标出增删的原文与增删词数；终端中仍然着色，
+// 无障碍模式下不只用颜色区分增删，改用 [-删除-]{+新增+} 标记
+func renderAnswerDiff(old, answer string) error {
+	old, answer = masked(old), masked(answer)
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	color := tty && !a11y.Enabled()
+	if bin, err := config.MdRenderPath(); err == nil && tty {
+		f, err := os.CreateTemp("", "agent-regen-*.md")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		_, err = f.WriteString(old)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		cmd := exec.Command(bin, "--diff", f.Name())
+		cmd.Stdin = strings.NewReader(answer)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+	ops := worddiff.Diff(strings.TrimRight(old, "\n"), strings.TrimRight(answer, "\n"))
+	fmt.Println(renderWordDiff(ops, color))
+	fmt.Println()
+	s := worddiff.Count(ops)
+	fmt.Println(i18n.T(i18n.MsgRegenStats, s.Deleted, s.Inserted))
+	return nil
+}
+
+// regenProvider 重新提问所用的 provider：--provider 指定的，否则是原来回答的那个；
+// 未给 --provider 时 --model 也可以是 provider 名称或其他 provider 配置的模型名，都不是时只替换模型
+func regenProvider(cfg config.AgentConfig, old history.Exchange, name, model string) (config.Provider, error) {
+	if name == "" && model != "" {
+		if p, err := lookupProvider(cfg, model); err == nil {
+			return p, nil
+		}
+	}
+	if name == "" {
+		name = old.Provider
+	}
+	p, err := selectProvider(cfg, name)
+	if err != nil {
+		return p, err
+	}
+	if model != "" {
+		p.Model = model
+	}
+	return p, nil
+}
+
+// providerLabel provider (model)，模型名与 provider 名称相同时省略
+func providerLabel(name, model string) string {
+	if model != "" && !strings.EqualFold(model, name) {
+		return name + " (" + model + ")"
+	}
+	return name
+}
+
+// renderWordDiff 逐词对比的文本：color 为 true 时用 ANSI 着色，否则用 [-删除-]{+新增+} 标记
+func renderWordDiff(ops []worddiff.Op, color bool) string {
+	var b strings.Builder
+	for _, op := range ops {
+		switch {
+		case op.Kind == worddiff.Equal:
+			b.WriteString(op.Text)
+		case color:
+			code := ansiInsert
+			if op.Kind == worddiff.Delete {
+				code = ansiDelete
+			}
+			// 逐行着色，换行处重置，终端滚动与分页时颜色不会串行
+			for i, line := range strings.Split(op.Text, "\n") {
+				if i > 0 {
+					b.WriteString("\n")
+				}
+				if line != "" {
+					b.WriteString(code + line + ansiReset)
+				}
+			}
+		case op.Kind == worddiff.Delete:
+			b.WriteString("[-" + op.Text + "-]")
+		default:
+			b.WriteString("{+" + op.Text + "+}")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/trace"
+)
+
+// renderMarkdown 终端中通过 md_render 渲染（支持时走 gRPC 传输，否则走 stdin / stdout）；
+// 非终端输出或未找到渲染引擎时原样输出；输出前按 output_redact 规则脱敏
+func renderMarkdown(content string) error {
+	content = masked(content)
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		if bin, err := config.MdRenderPath(); err == nil {
+			ctx, span := trace.Start(context.Background(), "render", trace.KindInternal, trace.Int("j.bytes", len(content)))
+			defer span.End()
+			if r := grpcRendererFor(ctx, bin); r != nil {
+				width, _, _ := term.GetSize(int(os.Stdout.Fd()))
+				if out, err := r.Render(ctx, content, width, nil); err == nil {
+					_, err = os.Stdout.Write(out)
+					return err
+				}
+			}
+			span.SetAttrs(trace.String("j.transport", "stdio"))
+			cmd := exec.Command(bin)
+			trace.Inject(ctx, cmd)
+			cmd.Stdin = strings.NewReader(content)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		}
+	}
+	_, err := os.Stdout.WriteString(content)
+	return err
+}
+
+// renderText 通过 md_render 把 Markdown 渲染为终端文本并返回：支持 gRPC 时按 width 排版（0 为默认宽度），
+// 否则经 stdin / stdout 调用（使用 md_render 的默认宽度）；args 为 md_render 的命令行参数，content 按 output_redact 规则脱敏
+func renderText(ctx context.Context, content string, width int, args []string) ([]byte, error) {
+	content = masked(content)
+	bin, err := config.MdRenderPath()
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := trace.Start(ctx, "render", trace.KindInternal, trace.Int("j.bytes", len(content)))
+	defer span.End()
+	if r := grpcRendererFor(ctx, bin); r != nil {
+		if out, err := r.Render(ctx, content, width, args); err == nil {
+			return out, nil
+		}
+	}
+	span.SetAttrs(trace.String("j.transport", "stdio"))
+	cmd := exec.CommandContext(ctx, bin, args...)
+	trace.Inject(ctx, cmd)
+	cmd.Stdin = strings.NewReader(content)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil && stderr.Len() > 0 {
+		return nil, errors.New(strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
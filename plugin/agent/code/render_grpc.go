@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"wcp_agent/internal/trace"
+)
+
+// md_render 的 gRPC 传输：握手声明 transport 为 grpc 时，通过 hashicorp/go-plugin 启动一个
+// md_render 进程并在本进程内复用，多次渲染（如 fix、测试生成的预览）不必各自启动进程。
+// 握手参数与服务名须与 md_render 的 grpc.go 保持一致；启动或调用失败时回退到 stdin / stdout
+const (
+	transportGRPC = "grpc"
+
+	grpcProtocolVersion = 1
+	grpcCookieKey       = "J_PLUGIN_COOKIE"
+	grpcCookieValue     = "md_render-renderer-v1"
+	grpcPluginName      = "render"
+	renderMethod        = "/j.md_render.Renderer/Render"
+)
+
+var grpcHandshake = plugin.HandshakeConfig{
+	ProtocolVersion:  grpcProtocolVersion,
+	MagicCookieKey:   grpcCookieKey,
+	MagicCookieValue: grpcCookieValue,
+}
+
+// rendererPlugin 只实现客户端
+type rendererPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+}
+
+func (rendererPlugin) GRPCServer(*plugin.GRPCBroker, *grpc.Server) error {
+	return errors.New("agent only consumes the renderer")
+}
+
+func (rendererPlugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (any, error) {
+	return &grpcRenderer{conn: conn}, nil
+}
+
+// grpcRenderer md_render Renderer 服务的客户端；md_render 的渲染选项是进程内的全局状态，请求逐个发送
+type grpcRenderer struct {
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+}
+
+// Render 按 width 渲染一篇文档（0 表示 md_render 的默认宽度），args 为 md_render 的命令行参数
+func (r *grpcRenderer) Render(ctx context.Context, content string, width int, args []string) ([]byte, error) {
+	list := make([]any, len(args))
+	for i, a := range args {
+		list[i] = a
+	}
+	req, err := structpb.NewStruct(map[string]any{"markdown": content, "width": width, "args": list})
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := trace.Start(ctx, "grpc "+renderMethod, trace.KindClient,
+		trace.String("rpc.system", "grpc"), trace.String("rpc.method", renderMethod))
+	defer span.End()
+	if tp := trace.Traceparent(ctx); tp != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "traceparent", tp)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	span.AddEvent("lock_acquired")
+	resp := new(wrapperspb.BytesValue)
+	if err := r.conn.Invoke(ctx, renderMethod, req, resp); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttrs(trace.Int("j.output_bytes", len(resp.GetValue())))
+	return resp.GetValue(), nil
+}
+
+var (
+	rendererOnce sync.Once
+	renderer     *grpcRenderer
+)
+
+// grpcRendererFor 返回复用的 gRPC 渲染客户端；md_render 未声明 grpc 或启动失败时返回 nil。
+// 首次调用时握手并启动 md_render，ctx 只用于把这两步记在调用方的 span 下
+func grpcRendererFor(ctx context.Context, bin string) *grpcRenderer {
+	rendererOnce.Do(func() {
+		if probeCapabilities(ctx, bin).Transport != transportGRPC {
+			return
+		}
+		_, span := trace.Start(ctx, "plugin start md_render", trace.KindClient, trace.String("rpc.system", "grpc"))
+		defer span.End()
+		client := plugin.NewClient(&plugin.ClientConfig{
+			HandshakeConfig:  grpcHandshake,
+			Plugins:          plugin.PluginSet{grpcPluginName: rendererPlugin{}},
+			Cmd:              exec.Command(bin),
+			AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			Logger:           hclog.NewNullLogger(),
+			Managed:          true,
+		})
+		rpc, err := client.Client()
+		if err != nil {
+			span.RecordError(err)
+			client.Kill()
+			return
+		}
+		raw, err := rpc.Dispense(grpcPluginName)
+		if err != nil {
+			span.RecordError(err)
+			client.Kill()
+			return
+		}
+		renderer = raw.(*grpcRenderer)
+	})
+	return renderer
+}
+
+// closeRenderers 结束 gRPC 方式启动的 md_render 进程
+func closeRenderers() {
+	plugin.CleanupClients()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+)
+
+// resumeLast 单独给出 --resume 时的取值：续写最近一轮未完成的回答
+const resumeLast = "last"
+
+// resumeFlag --resume[=id]，可以像布尔参数一样不带取值
+type resumeFlag struct{ id string }
+
+func (f *resumeFlag) String() string { return f.id }
+
+func (f *resumeFlag) Set(v string) error {
+	switch v {
+	case "true":
+		f.id = resumeLast
+	case "false":
+		f.id = ""
+	default:
+		f.id = v
+	}
+	return nil
+}
+
+func (f *resumeFlag) IsBoolFlag() bool { return true }
+
+// resumable 这些状态的回答没有完整结束，可以续写
+func resumable(status string) bool {
+	switch status {
+	case history.StatusInterrupted, history.StatusError, history.StatusCancelled, history.StatusTruncated:
+		return true
+	}
+	return false
+}
+
+// resumeTarget 找到要续写的问答：id 为 resumeLast 时取最近一轮未完成且尚未被续写过的
+func resumeTarget(id string) (history.Exchange, error) {
+	if id != resumeLast {
+		e, ok, err := history.Find(id)
+		if err != nil {
+			return history.Exchange{}, err
+		}
+		if !ok {
+			return history.Exchange{}, errors.New(i18n.T(i18n.MsgHistoryNotFound, id))
+		}
+		if !resumable(e.Status) {
+			return history.Exchange{}, errors.New(i18n.T(i18n.MsgResumeComplete, e.ID))
+		}
+		return e, nil
+	}
+	list, err := history.Load()
+	if err != nil {
+		return history.Exchange{}, err
+	}
+	continued := map[string]bool{}
+	for i := len(list) - 1; i >= 0; i-- {
+		e := list[i]
+		if e.ResumedFrom != "" {
+			continued[e.ResumedFrom] = true
+		}
+		if resumable(e.Status) && !continued[e.ID] {
+			return e, nil
+		}
+	}
+	return history.Exchange{}, errors.New(i18n.T(i18n.MsgResumeNothing))
+}
+
+// recoverInterrupted 把上次崩溃或被杀的进程留下的半截回答补记到问答历史，并提示可以续写；失败时只提示
+func recoverInterrupted() {
+	list, err := history.Recover()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+	}
+	for _, e := range list {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgResumeRecovered, e.ID, utf8.RuneCountInString(e.Answer)))
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strconv"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/project"
+	"wcp_agent/internal/provider"
+)
+
+// samplingFlags ask 的采样参数：--preset 与 --temperature / --top-p / --seed
+type samplingFlags struct {
+	preset string
+	values config.Sampling
+}
+
+// register 在 FlagSet 上注册采样参数
+func (f *samplingFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.preset, "preset", "", "sampling preset: precise, balanced, creative or one from sampling_presets")
+	fs.Func("temperature", "sampling temperature (0-2, 0-1 for Anthropic)", func(v string) error {
+		t, err := strconv.ParseFloat(v, 64)
+		f.values.Temperature = &t
+		return err
+	})
+	fs.Func("top-p", "nucleus sampling probability mass (0-1]", func(v string) error {
+		t, err := strconv.ParseFloat(v, 64)
+		f.values.TopP = &t
+		return err
+	})
+	fs.Func("seed", "seed for reproducible sampling (where supported)", func(v string) error {
+		t, err := strconv.ParseInt(v, 10, 64)
+		f.values.Seed = &t
+		return err
+	})
+}
+
+// resolve 合并 provider 默认值 → 预设 → 命令行参数，并按 provider 的取值范围校验；
+// 未给出 --preset 时使用项目 .j.toml 中的 preset
+func (f *samplingFlags) resolve(cfg config.AgentConfig, p config.Provider) (config.Sampling, error) {
+	var s config.Sampling
+	if p.Sampling != nil {
+		s = *p.Sampling
+	}
+	name := f.preset
+	if pc, _ := project.Current(); name == "" && pc != nil {
+		name = pc.Preset
+	}
+	if name != "" {
+		preset, ok := cfg.Preset(name)
+		if !ok {
+			return s, errors.New(i18n.T(i18n.MsgAskUnknownPreset, name, strings.Join(cfg.PresetNames(), ", ")))
+		}
+		s = s.Override(preset)
+	}
+	s = s.Override(f.values)
+	return s, provider.ValidateSampling(p, s)
+}
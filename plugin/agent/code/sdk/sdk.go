@@ -0,0 +1,50 @@
+// Package sdk 是 agent 插件对外开放的扩展接口，第三方可以通过它注册请求拦截器。
+// 拦截器包装下一层客户端，能在请求发出前改写消息、在拿到回答后做记录，或直接返回回答：
+//
+//	func init() {
+//		sdk.Register("audit", func(next sdk.Client) sdk.Client {
+//			return sdk.ClientFunc(func(ctx context.Context, messages []sdk.Message, onDelta func(string)) (string, error) {
+//				info, _ := sdk.RequestInfo(ctx)
+//				answer, err := next.Chat(ctx, messages, onDelta)
+//				log.Printf("%s/%s: %d messages", info.Provider, info.Model, len(messages))
+//				return answer, err
+//			})
+//		})
+//	}
+//
+// 插件是独立编译的二进制，注册拦截器的包需要在 agent 的 main 包中以空导入的方式引入后重新编译。
+// 第三方拦截器排在脱敏之后、缓存之前，看到的是脱敏后的消息，缓存命中时不会被调用。
+package sdk
+
+import (
+	"context"
+
+	"wcp_agent/internal/intercept"
+	"wcp_agent/internal/provider"
+)
+
+type (
+	// Message 对话消息
+	Message = provider.Message
+	// Client 对话客户端
+	Client = provider.Client
+	// ClientFunc 把函数当作 Client 使用
+	ClientFunc = provider.ClientFunc
+	// Middleware 拦截器
+	Middleware = provider.Middleware
+	// Info 当前请求的 provider、模型与是否流式
+	Info = intercept.Info
+)
+
+// ErrTruncated 回答因达到 token 上限被截断，与之一同返回的是有效的部分回答
+var ErrTruncated = provider.ErrTruncated
+
+// Register 注册拦截器，通常在 init 中调用；名称重复或拦截器为 nil 时 panic
+func Register(name string, mw Middleware) {
+	intercept.Register(name, mw)
+}
+
+// RequestInfo 在拦截器中读取当前请求的信息
+func RequestInfo(ctx context.Context) (Info, bool) {
+	return intercept.RequestInfo(ctx)
+}
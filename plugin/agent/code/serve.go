@@ -0,0 +1,593 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/compact"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/metrics"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/session"
+	"wcp_agent/internal/trace"
+	"wcp_agent/internal/webui"
+)
+
+const (
+	// defaultServeListen agent serve 的默认监听地址
+	defaultServeListen = "127.0.0.1:7878"
+	// serveTokenEnv 指定访问令牌的环境变量
+	serveTokenEnv = "J_SERVE_TOKEN"
+	// serveTokenSetting config.yaml setting 段中的访问令牌
+	serveTokenSetting = "agent_serve_token"
+	// serveTokenFile 未指定令牌时生成并保存令牌的文件（位于 agent 数据目录下）
+	serveTokenFile = "serve_token"
+	// maxRequestBytes 请求体的大小上限
+	maxRequestBytes = 8 << 20
+)
+
+// runServe agent serve [--listen addr] [--token t] [--metrics]：以 REST 接口提供 ask、render、history 与会话，
+// 供编辑器、脚本以及可信网络中的其他机器共用一个 j 实例；除 /v1/health 外都要求 Bearer 令牌。
+// --metrics 另在 /metrics 上以 Prometheus 文本格式提供请求统计（同样要求令牌）
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	listen := fs.String("listen", defaultServeListen, "address to listen on (plain HTTP: expose it on trusted networks only)")
+	tokenFlag := fs.String("token", "", "bearer token clients must send (default: $"+serveTokenEnv+", the "+serveTokenSetting+" setting, or a generated token saved in the data directory)")
+	withMetrics := fs.Bool("metrics", false, "serve request metrics in the Prometheus text format at /metrics (bearer token required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if _, err := loadAgent(); err != nil {
+		return err
+	}
+	token, source, err := serveToken(*tokenFlag)
+	if err != nil {
+		return err
+	}
+
+	api := &apiServer{token: token, sessions: session.NewStore(), metrics: *withMetrics}
+	srv := &http.Server{Addr: *listen, Handler: api.routes(), ReadHeaderTimeout: 10 * time.Second}
+	ln, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgServeListening, ln.Addr()))
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgServeWebUI, "http://"+ln.Addr().String()+"/"))
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgServeToken, source))
+	if api.metrics {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgServeMetrics, "http://"+ln.Addr().String()+"/metrics"))
+	}
+	if !loopback(ln.Addr()) {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgServeExposed))
+	}
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgServeStopped))
+	return nil
+}
+
+// serveToken 访问令牌：--token → 环境变量 → setting → 数据目录中保存的令牌（没有时生成），并返回来源说明
+func serveToken(flagValue string) (string, string, error) {
+	switch {
+	case flagValue != "":
+		return flagValue, "--token", nil
+	case os.Getenv(serveTokenEnv) != "":
+		return os.Getenv(serveTokenEnv), "$" + serveTokenEnv, nil
+	case config.Setting(serveTokenSetting) != "":
+		return config.Setting(serveTokenSetting), serveTokenSetting, nil
+	}
+	path := filepath.Join(config.AgentDataDir(), serveTokenFile)
+	if data, err := os.ReadFile(path); err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, path, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", "", err
+	}
+	var b [24]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b[:])
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", "", err
+	}
+	return token, path, nil
+}
+
+// loopback 监听地址是否只接受本机连接
+func loopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
+
+// apiServer HTTP API；守护进程未运行时会话保存在本进程内存中
+type apiServer struct {
+	token    string
+	sessions *session.Store
+	// metrics 是否提供 /metrics
+	metrics bool
+}
+
+func (s *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.Handle("GET /{$}", webui.Handler())
+	mux.Handle("GET "+webui.ScriptPath, webui.Script())
+	mux.Handle("GET /v1/providers", s.authorized(s.handleProviders))
+	mux.Handle("POST /v1/ask", s.authorized(s.handleAsk))
+	mux.Handle("POST /v1/render", s.authorized(s.handleRender))
+	mux.Handle("GET /v1/history", s.authorized(s.handleHistoryList))
+	mux.Handle("GET /v1/history/{id}", s.authorized(s.handleHistoryShow))
+	mux.Handle("GET /v1/sessions", s.authorized(s.handleSessionList))
+	mux.Handle("GET /v1/sessions/{name}", s.authorized(s.handleSessionShow))
+	mux.Handle("DELETE /v1/sessions/{name}", s.authorized(s.handleSessionDelete))
+	if s.metrics {
+		mux.Handle("GET /metrics", s.authorized(s.handleMetrics))
+	}
+	if trace.Enabled() {
+		return traced(mux)
+	}
+	return mux
+}
+
+// traced 每个请求单独成一条链路（请求带 traceparent 头时接在调用方之后），span 以路由命名
+func traced(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := trace.StartRemote(r.Context(), r.Header.Get("traceparent"), r.Method+" "+r.URL.Path, trace.KindServer,
+			trace.String("http.request.method", r.Method))
+		defer span.End()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		r = r.WithContext(ctx)
+		next.ServeHTTP(rec, r)
+		if r.Pattern != "" {
+			span.SetName(r.Pattern)
+		}
+		span.SetAttrs(trace.Int("http.response.status_code", rec.code))
+		if rec.code >= http.StatusInternalServerError {
+			span.RecordError(errors.New(http.StatusText(rec.code)))
+		}
+	})
+}
+
+// statusRecorder 记下响应状态码；保留 Flush 以免打断 SSE 流式输出
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// authorized 要求 Authorization: Bearer <令牌>
+func (s *apiServer) authorized(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(got)), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="j"`)
+			writeError(w, http.StatusUnauthorized, errors.New(i18n.T(i18n.MsgServeUnauthorized)))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+		next(w, r)
+	})
+}
+
+// handleMetrics 以 Prometheus 文本格式输出请求统计：守护进程在运行时 ask 经它发出，取它的统计，否则取本进程的
+func (s *apiServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	snap := metrics.Default.Snapshot()
+	if daemon.Available() {
+		if remote, err := daemon.FetchMetrics(); err == nil {
+			snap = remote
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = metrics.WritePrometheus(w, snap)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+// providerInfo GET /v1/providers 的一项（不含密钥）
+type providerInfo struct {
+	Name   string `json:"name"`
+	Model  string `json:"model"`
+	Active bool   `json:"active,omitempty"`
+}
+
+func (s *apiServer) handleProviders(w http.ResponseWriter, r *http.Request) {
+	cfg, err := loadAgent()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	active, _ := cfg.Active()
+	list := make([]providerInfo, len(cfg.Providers))
+	for i, p := range cfg.Providers {
+		list[i] = providerInfo{Name: p.Name, Model: p.Model, Active: p.Name == active.Name}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// askRequest POST /v1/ask 的请求体
+type askRequest struct {
+	Prompt      string   `json:"prompt"`
+	Provider    string   `json:"provider,omitempty"`
+	Session     string   `json:"session,omitempty"`
+	System      *string  `json:"system,omitempty"` // 覆盖配置中的 system prompt，空串表示不带
+	Stream      bool     `json:"stream,omitempty"` // 以 text/event-stream 逐段返回
+	Preset      string   `json:"preset,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	Seed        *int64   `json:"seed,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+}
+
+// askResponse POST /v1/ask 的回答；流式时作为最后一个事件发送，done 为 true
+type askResponse struct {
+	Done       bool   `json:"done,omitempty"`
+	ID         string `json:"id"`
+	Provider   string `json:"provider"`
+	Model      string `json:"model"`
+	Session    string `json:"session,omitempty"`
+	Answer     string `json:"answer"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+func (s *apiServer) handleAsk(w http.ResponseWriter, r *http.Request) {
+	var req askRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New(i18n.T(i18n.MsgServeBadRequest, err)))
+		return
+	}
+	if strings.TrimSpace(req.Prompt) == "" {
+		writeError(w, http.StatusBadRequest, errors.New(i18n.T(i18n.MsgAskNoPrompt)))
+		return
+	}
+	if len(req.Stop) > maxStops {
+		writeError(w, http.StatusBadRequest, errors.New(i18n.T(i18n.MsgAskTooManyStops, maxStops)))
+		return
+	}
+	cfg, err := loadAgent()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	p, err := selectProvider(cfg, req.Provider)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	flags := samplingFlags{preset: req.Preset, values: config.Sampling{Temperature: req.Temperature, TopP: req.TopP, Seed: req.Seed}}
+	params, err := flags.resolve(cfg, p)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// 守护进程在运行时请求经它发送，会话也交给它（与 agent ask --session 共用），否则使用本进程的会话
+	shared := daemon.Available()
+	key, _ := auth.Resolve(p)
+	client, err := newClient(p, provider.Options{
+		Key:       key,
+		Stream:    req.Stream,
+		Timeouts:  cfg.EffectiveTimeouts(p),
+		Sampling:  params,
+		MaxTokens: max(req.MaxTokens, 0),
+		Stop:      req.Stop,
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	system := config.LoadSystemPrompt(cfg)
+	if req.System != nil {
+		system = *req.System
+	}
+	var messages []provider.Message
+	if system != "" {
+		messages = append(messages, provider.Message{Role: "system", Content: system})
+	}
+	messages = append(messages, provider.Message{Role: "user", Content: req.Prompt})
+	turn := messages[len(messages)-1:]
+	ctx := quietly(r.Context())
+	switch {
+	case req.Session != "" && shared:
+		ctx = daemon.WithSession(ctx, req.Session)
+	case req.Session != "":
+		compact.Session(ctx, s.sessions, req.Session, messages, cfg, p, 0, func(sp config.Provider) (provider.Client, error) {
+			key, _ := auth.Resolve(sp)
+			return newClient(sp, provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(sp)})
+		})
+		messages, turn = s.sessions.Compose(req.Session, messages)
+	}
+
+	// 返回的回答按 output_redact 规则脱敏，问答历史与会话中保存原文
+	var onDelta func(string)
+	var events *sseWriter
+	display := outputMask().Stream()
+	if req.Stream {
+		events = newSSEWriter(w)
+		onDelta = func(delta string) {
+			if d := display.Write(delta); d != "" {
+				events.send(map[string]string{"delta": d})
+			}
+		}
+	}
+	start := time.Now()
+	answer, err := client.Chat(ctx, messages, onDelta)
+	if d := display.Flush(); d != "" && events != nil {
+		events.send(map[string]string{"delta": d})
+	}
+
+	exchange := history.Exchange{
+		ID:         history.NewID(),
+		Provider:   p.Name,
+		Model:      p.Model,
+		Prompt:     req.Prompt,
+		Answer:     answer,
+		Status:     history.StatusOK,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	switch {
+	case ctx.Err() != nil:
+		exchange.Status = history.StatusCancelled
+	case errors.Is(err, provider.ErrTruncated):
+		exchange.Status = history.StatusTruncated
+	case err != nil:
+		exchange.Status = history.StatusError
+		exchange.Error = err.Error()
+	}
+	if herr := history.Append(exchange); herr != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, herr))
+	}
+	if req.Session != "" && !shared && (exchange.Status == history.StatusOK || exchange.Status == history.StatusTruncated) {
+		s.sessions.Remember(req.Session, turn, answer)
+	}
+
+	resp := askResponse{
+		ID:         exchange.ID,
+		Provider:   p.Name,
+		Model:      p.Model,
+		Session:    req.Session,
+		Answer:     masked(answer),
+		Status:     exchange.Status,
+		Error:      exchange.Error,
+		DurationMs: exchange.DurationMs,
+	}
+	if events != nil {
+		resp.Done = true
+		events.send(resp)
+		return
+	}
+	code := http.StatusOK
+	if exchange.Status == history.StatusError {
+		code = http.StatusBadGateway
+	}
+	writeJSON(w, code, resp)
+}
+
+// sseWriter 以 text/event-stream 发送 JSON 事件
+type sseWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func newSSEWriter(w http.ResponseWriter) *sseWriter {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	return &sseWriter{w, flusher}
+}
+
+func (e *sseWriter) send(v any) {
+	data, _ := json.Marshal(v)
+	fmt.Fprintf(e.w, "data: %s\n\n", data)
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+}
+
+// renderRequest POST /v1/render 的请求体
+type renderRequest struct {
+	Markdown string   `json:"markdown"`
+	Width    int      `json:"width,omitempty"` // md_render 支持 gRPC 时生效，0 为默认宽度
+	Args     []string `json:"args,omitempty"`  // md_render 的命令行参数，如 ["--theme", "light", "--toc"]
+}
+
+// renderFlags API 允许的 md_render 参数，值表示它是否带参数；只有影响显示效果的参数，
+// --lint、--format（运行外部命令）与 --style、--diff、--save-files（读写本地文件）等一律拒绝
+var renderFlags = map[string]bool{
+	"theme": true, "toc": false, "toc-depth": true, "section": true, "highlight": true,
+	"no-emoji": false, "no-frontmatter": false, "accessible": false,
+}
+
+// checkRenderArgs 每个参数都须是 renderFlags 中的选项（或紧随其后的取值），位置参数同样拒绝
+func checkRenderArgs(args []string) error {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		name, _, inline := strings.Cut(strings.TrimLeft(a, "-"), "=")
+		takesValue, ok := renderFlags[name]
+		if !ok || !strings.HasPrefix(a, "-") {
+			return errors.New(i18n.T(i18n.MsgServeRenderArg, a))
+		}
+		if takesValue && !inline {
+			i++
+		}
+	}
+	return nil
+}
+
+func (s *apiServer) handleRender(w http.ResponseWriter, r *http.Request) {
+	var req renderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New(i18n.T(i18n.MsgServeBadRequest, err)))
+		return
+	}
+	if err := checkRenderArgs(req.Args); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	out, err := renderText(r.Context(), req.Markdown, req.Width, req.Args)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write(out)
+}
+
+func (s *apiServer) handleHistoryList(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if v := r.URL.Query().Get("n"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, errors.New(i18n.T(i18n.MsgServeBadRequest, "n="+v)))
+			return
+		}
+		limit = n
+	}
+	list, err := history.Load()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if limit > 0 && len(list) > limit {
+		list = list[len(list)-limit:]
+	}
+	out := make([]history.Exchange, len(list))
+	for i, e := range list {
+		out[i] = maskedExchange(e)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *apiServer) handleHistoryShow(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	e, ok, err := history.Find(id)
+	switch {
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	case !ok:
+		writeError(w, http.StatusNotFound, errors.New(i18n.T(i18n.MsgHistoryNotFound, id)))
+	default:
+		writeJSON(w, http.StatusOK, maskedExchange(e))
+	}
+}
+
+// maskedExchange 按 output_redact 规则脱敏问答中的问题、回答与标题，与 agent history show 的显示一致
+func maskedExchange(e history.Exchange) history.Exchange {
+	e.Prompt, e.Answer, e.Title = masked(e.Prompt), masked(e.Answer), masked(e.Title)
+	return e
+}
+
+func (s *apiServer) handleSessionList(w http.ResponseWriter, r *http.Request) {
+	if !daemon.Available() {
+		writeJSON(w, http.StatusOK, s.sessions.List())
+		return
+	}
+	st, err := daemon.FetchStatus()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, st.Sessions)
+}
+
+func (s *apiServer) handleSessionShow(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	messages, ok := s.sessions.Messages(name)
+	if daemon.Available() {
+		var err error
+		if messages, ok, err = daemon.SessionMessages(name); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New(i18n.T(i18n.MsgServeNoSession, name)))
+		return
+	}
+	out := make([]provider.Message, len(messages))
+	for i, m := range messages {
+		m.Content = masked(m.Content)
+		out[i] = m
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"name": name, "messages": out})
+}
+
+func (s *apiServer) handleSessionDelete(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	found := false
+	if daemon.Available() {
+		var err error
+		if found, err = daemon.ForgetSession(name); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	} else {
+		found = s.sessions.Delete(name)
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, errors.New(i18n.T(i18n.MsgServeNoSession, name)))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/picker"
+)
+
+// providerPreset 向导中可选的常见 provider
+type providerPreset struct {
+	label, name, typ, base, model string
+	local                         bool // 在本机运行，不需要 API Key
+}
+
+var providerPresets = []providerPreset{
+	{label: "OpenAI", name: "openai", base: "https://api.openai.com/v1", model: "gpt-4o-mini"},
+	{label: "OpenRouter", name: "openrouter", typ: config.ProviderOpenRouter, base: config.DefaultOpenRouterBase, model: "openai/gpt-4o-mini"},
+	{label: "DeepSeek", name: "deepseek", base: "https://api.deepseek.com/v1", model: "deepseek-chat"},
+	{label: "Ollama", name: "ollama", base: "http://localhost:11434/v1", model: "llama3.2", local: true},
+}
+
+// setupThemes md_render 的内置主题
+var setupThemes = []string{"dark", "light", "dracula", "gruvbox", "monokai", "nord"}
+
+// chatThemes j 对话界面同样提供的主题，选中时一并写入 agent_config.json 的 theme
+var chatThemes = map[string]bool{"dark": true, "light": true, "monokai": true, "nord": true}
+
+// settingTheme config.yaml 中 md_render 读取的主题配置项
+const settingTheme = "md_theme"
+
+// themeSample 主题预览中渲染的示例
+const themeSample = "# j agent\n\n## %s\n\nSome **bold**, *italic* and `inline code`, with a [link](https://example.com).\n\n" +
+	"> A quote\n\n- first item\n- second item\n\n| Theme | Colors |\n|---|---|\n| %s | %s |\n\n```go\nfmt.Println(\"hello\")\n```\n"
+
+// setupCommands 需要 provider 的命令：首次运行（还没有 agent_config.json）时先启动配置向导
+var setupCommands = map[string]bool{"ask": true, "do": true, "explain": true, "fix": true, "flow": true, "git": true, "watch": true}
+
+// terminalCaps 检测到的终端能力
+type terminalCaps struct {
+	width, height int
+	colors        string // truecolor、256 或 16
+	unicode       bool
+}
+
+// runSetup agent setup：配置向导——检测终端能力，选择 provider 并把 API Key 存入系统钥匙串，
+// 在各主题的预览中挑选渲染主题，最后写入 agent_config.json 与 config.yaml（主题带注释写入 setting 段）
+func runSetup(args []string) error {
+	fs := flag.NewFlagSet("setup", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New(i18n.T(i18n.MsgSetupUsage))
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return errors.New(i18n.T(i18n.MsgSetupNoTerminal))
+	}
+	caps := detectTerminal()
+	unicode := i18n.T(i18n.MsgSetupUnicode)
+	if !caps.unicode {
+		unicode = i18n.T(i18n.MsgSetupNoUnicode)
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupTerminal, caps.width, caps.height, caps.colors, unicode))
+	if caps.colors != "truecolor" {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupColorHint, caps.colors, envColorDepth))
+	}
+
+	// 会写回配置，不叠加项目的 .j.toml
+	cfg, err := loadAgentFile()
+	if err != nil {
+		return err
+	}
+	in := bufio.NewReader(os.Stdin)
+	fmt.Fprintln(os.Stderr, "\n"+i18n.T(i18n.MsgSetupStepProvider))
+	p, local, err := setupProvider(in)
+	if err != nil {
+		return err
+	}
+	if local {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupLocal, p.Name))
+	} else if err := setupKey(in, p); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "\n"+i18n.T(i18n.MsgSetupStepTheme))
+	theme, err := setupTheme(in, caps)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "\n"+i18n.T(i18n.MsgSetupStepSave))
+	if idx := cfg.FindProvider(p.Name); idx >= 0 {
+		old := cfg.Providers[idx]
+		old.APIBase, old.Model, old.Type = p.APIBase, p.Model, p.Type
+		cfg.Providers[idx], cfg.ActiveIndex = old, idx
+	} else {
+		cfg.Providers = append(cfg.Providers, p)
+		cfg.ActiveIndex = len(cfg.Providers) - 1
+	}
+	if chatThemes[theme] {
+		cfg.Theme = theme
+	}
+	if err := config.SaveAgent(cfg); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupSavedAgent, p.Name, p.Model, config.AgentConfigPath()))
+	if theme != "" {
+		comment := i18n.T(i18n.MsgSetupThemeComment, strings.Join(setupThemes, ", "))
+		if err := config.SetSetting(settingTheme, theme, comment); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupSavedTheme, theme, filepath.Join(config.DataDir(), config.MainConfigFile)))
+	}
+	fmt.Fprintln(os.Stderr, "\n"+i18n.T(i18n.MsgSetupDone))
+	return nil
+}
+
+// firstRun 是否应在执行 name 之前启动配置向导：需要 provider 的命令、还没有 agent_config.json，且 stdin 与 stderr 都是终端
+func firstRun(name string) bool {
+	if !setupCommands[name] {
+		return false
+	}
+	if _, err := os.Stat(config.AgentConfigPath()); !errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// runFirstSetup 首次运行时的配置向导；取消或失败时只提示，命令照常执行
+func runFirstSetup() {
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupFirstRun, config.AgentConfigPath()))
+	if err := runSetup(nil); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupSkipped, err))
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// setupProvider 从常见 provider 中选择一个（或填写其他 OpenAI 兼容服务）并确认模型名称；local 表示不需要 API Key
+func setupProvider(in *bufio.Reader) (p config.Provider, local bool, err error) {
+	items := make([]picker.Item, 0, len(providerPresets)+1)
+	for _, pp := range providerPresets {
+		items = append(items, picker.Item{Label: fmt.Sprintf("%-12s %s", pp.label, pp.base)})
+	}
+	items = append(items, picker.Item{Label: i18n.T(i18n.MsgSetupCustom)})
+	i, err := picker.Pick(i18n.T(i18n.MsgSetupProviderPrompt), items)
+	if errors.Is(err, picker.ErrCancelled) {
+		return p, false, errors.New(i18n.T(i18n.MsgSetupCancelled))
+	}
+	if err != nil {
+		return p, false, err
+	}
+	fmt.Fprintln(os.Stderr, "  "+items[i].Label)
+	var pp providerPreset
+	if i < len(providerPresets) {
+		pp = providerPresets[i]
+	} else {
+		if pp.name, err = askLine(in, i18n.T(i18n.MsgSetupAskName), ""); err != nil {
+			return p, false, err
+		}
+		if pp.base, err = askLine(in, i18n.T(i18n.MsgSetupAskBase), ""); err != nil {
+			return p, false, err
+		}
+	}
+	if pp.model, err = askLine(in, i18n.T(i18n.MsgSetupAskModel), pp.model); err != nil {
+		return p, false, err
+	}
+	return config.Provider{Name: pp.name, APIBase: pp.base, Model: pp.model, Type: pp.typ}, pp.local, nil
+}
+
+// setupKey 读取 API Key 并存入系统钥匙串；钥匙串中已有 Key 时可以继续使用。
+// 钥匙串不可用时只提示改用环境变量，向导照常继续
+func setupKey(in *bufio.Reader, p config.Provider) error {
+	if _, src := auth.Keys(p); src == auth.SourceKeyring && askYes(in, i18n.T(i18n.MsgSetupKeepKey, p.Name)) {
+		return nil
+	}
+	key, err := readKey(p.Name)
+	if err != nil {
+		return err
+	}
+	if err := auth.Store(p.Name, key); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupKeyringFailed, err, auth.EnvVar(p.Name)))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgAuthStored, p.Name))
+	return nil
+}
+
+// setupTheme 在选择器中挑选 md_render 主题：fzf 的预览窗随光标显示该主题下渲染的示例，
+// 选中后再在终端中显示一次预览并确认，不满意时重新选择。没有 md_render 时跳过，返回空串
+func setupTheme(in *bufio.Reader, caps terminalCaps) (string, error) {
+	if _, err := config.MdRenderPath(); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupNoRenderer))
+		return "", nil
+	}
+	current := config.Setting(settingTheme)
+	width := max(caps.width*55/100-4, 40) // fzf 预览窗占 55% 宽度
+	items := make([]picker.Item, len(setupThemes))
+	for i, name := range setupThemes {
+		items[i].Label = name
+		if name == current {
+			items[i].Label += i18n.T(i18n.MsgSetupThemeCurrent)
+		}
+		sample := fmt.Sprintf(themeSample, name, name, caps.colors)
+		if out, err := renderText(context.Background(), sample, width, []string{"--theme", name}); err == nil {
+			items[i].Preview = string(out)
+		}
+	}
+	for {
+		i, err := picker.Pick(i18n.T(i18n.MsgSetupThemePrompt), items)
+		if errors.Is(err, picker.ErrCancelled) {
+			return "", errors.New(i18n.T(i18n.MsgSetupCancelled))
+		}
+		if err != nil {
+			return "", err
+		}
+		name := setupThemes[i]
+		if items[i].Preview == "" {
+			return name, nil
+		}
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprint(os.Stderr, items[i].Preview)
+		if askYes(in, i18n.T(i18n.MsgSetupUseTheme, name)) {
+			return name, nil
+		}
+	}
+}
+
+// askLine 在 stderr 提问并读取一行，直接回车时取 def；def 为空时必须填写
+func askLine(in *bufio.Reader, label, def string) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(os.Stderr, "%s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s: ", label)
+		}
+		line, err := in.ReadString('\n')
+		if err != nil && line == "" {
+			return "", err
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			return line, nil
+		}
+		if def != "" {
+			return def, nil
+		}
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSetupRequired))
+	}
+}
+
+// askYes 提出 Y/n 问题，直接回车视为是
+func askYes(in *bufio.Reader, question string) bool {
+	fmt.Fprint(os.Stderr, question)
+	line, err := in.ReadString('\n')
+	if err != nil && line == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "", "y", "yes", "是":
+		return true
+	}
+	return false
+}
+
+// envColorDepth 强制指定终端颜色深度的环境变量，与 j 主程序及 md_render 一致
+const envColorDepth = "J_COLOR_DEPTH"
+
+// detectTerminal 检测 stderr 所在终端的尺寸、颜色深度与是否使用 UTF-8 locale
+func detectTerminal() terminalCaps {
+	caps := terminalCaps{width: 80, height: 24, colors: detectColors()}
+	if w, h, err := term.GetSize(int(os.Stderr.Fd())); err == nil {
+		caps.width, caps.height = w, h
+	}
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			v = strings.ToLower(v)
+			caps.unicode = strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+			break
+		}
+	}
+	return caps
+}
+
+// detectColors 依次查看 J_COLOR_DEPTH、COLORTERM 与 TERM（与 md_render 的检测顺序一致，不读取 terminfo）
+func detectColors() string {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(envColorDepth))) {
+	case "truecolor", "24bit", "16m":
+		return "truecolor"
+	case "256":
+		return "256"
+	case "16", "8":
+		return "16"
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return "truecolor"
+	}
+	t := os.Getenv("TERM")
+	switch {
+	case strings.HasSuffix(t, "-direct") || strings.Contains(t, "truecolor") || os.Getenv("WT_SESSION") != "":
+		return "truecolor"
+	case strings.Contains(t, "256color"):
+		return "256"
+	}
+	return "16"
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/widget"
+)
+
+// runDo agent do [--provider p] [--shell zsh] -- <描述>：按自然语言描述生成一条 shell 命令，只把命令本身写到 stdout，
+// 供 shell 小部件替换命令行或 $(agent do ...) 使用；无法完成时输出以 # 开头的说明
+func runDo(args []string) error {
+	fs := flag.NewFlagSet("do", flag.ContinueOnError)
+	providerName := fs.String("provider", "", "provider name (defaults to the active one)")
+	shell := fs.String("shell", "", "target shell (default: basename of $SHELL)")
+	registerLimitFlags(fs)
+	registerSendFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	description, err := readPrompt(fs.Args())
+	if err != nil {
+		return err
+	}
+	if *shell == "" {
+		*shell = loginShell()
+	}
+	answer, err := shellChat(context.Background(), *providerName, doPrompt(description, *shell), i18n.T(i18n.MsgShellAsking))
+	if err != nil {
+		return err
+	}
+	fmt.Println(extractCommand(answer))
+	return nil
+}
+
+// runExplain agent explain [--provider p] [--shell zsh] [--brief] <命令>：逐段解释 shell 命令及其风险；
+// --brief 只输出几行纯文本，供 shell 小部件显示在提示符旁
+func runExplain(args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	providerName := fs.String("provider", "", "provider name (defaults to the active one)")
+	shell := fs.String("shell", "", "shell the command is written for (default: inferred)")
+	brief := fs.Bool("brief", false, "a few plain-text lines instead of rendered Markdown")
+	registerLimitFlags(fs)
+	registerSendFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	command, err := readPrompt(fs.Args())
+	if err != nil {
+		return err
+	}
+	answer, err := shellChat(context.Background(), *providerName, shellExplainPrompt(command, *shell, *brief), i18n.T(i18n.MsgShellExplaining))
+	if err != nil {
+		return err
+	}
+	if *brief {
+		fmt.Println(strings.TrimSpace(answer))
+		return nil
+	}
+	return renderMarkdown(answer)
+}
+
+// runWidget agent widget [zsh|bash] [--key 快捷键] [--not-found [--no-ai]]：输出 shell 小部件脚本，在 ~/.zshrc 中 eval "$(agent widget zsh)" 加载；
+// --not-found 改为输出 command-not-found 钩子
+func runWidget(args []string) error {
+	shell := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		shell, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("widget", flag.ContinueOnError)
+	key := fs.String("key", "", `key binding in the shell's own syntax (default: zsh "^G", bash "\C-g")`)
+	notFound := fs.Bool("not-found", false, "print the command-not-found hook instead of the key binding widget")
+	noAI := fs.Bool("no-ai", false, "with --not-found: only suggest similarly spelled commands from PATH")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New(i18n.T(i18n.MsgWidgetUsage))
+	}
+	if shell == "" {
+		shell = loginShell()
+	}
+	agent, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	var script string
+	if *notFound {
+		script, err = widget.NotFoundScript(shell, agent, i18n.T(i18n.MsgNotFoundConfirm), *noAI)
+	} else {
+		script, err = widget.Script(shell, agent, *key, widget.Texts{
+			Asking:     i18n.T(i18n.MsgShellAsking),
+			Explaining: i18n.T(i18n.MsgShellExplaining),
+		})
+	}
+	if errors.Is(err, widget.ErrUnsupported) {
+		return errors.New(i18n.T(i18n.MsgWidgetUnsupported, shell, strings.Join(widget.Shells, ", ")))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// loginShell $SHELL 的文件名，未设置时为 zsh
+func loginShell() string {
+	if shell := filepath.Base(os.Getenv("SHELL")); shell != "." && shell != "/" {
+		return shell
+	}
+	return "zsh"
+}
+
+// shellChat 以活动（或指定的）provider 一次性提问，Ctrl-C 或 ctx 结束时取消
+func shellChat(ctx context.Context, providerName, prompt, status string) (string, error) {
+	cfg, err := loadAgent()
+	if err != nil {
+		return "", err
+	}
+	p, err := selectProvider(cfg, providerName)
+	if err != nil {
+		return "", err
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	key, _ := auth.Resolve(p)
+	opts := provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(p)}
+	return chatOnce(ctx, cfg, p, opts, prompt, status)
+}
+
+// doPrompt 请模型只回答一条命令
+func doPrompt(description, shell string) string {
+	cwd, _ := os.Getwd()
+	return fmt.Sprintf(`Write a single %s command for %s that does the following, run from the directory %s.
+Reply with the command only: no explanation, no Markdown, no code fence, no leading "$".
+Prefer one line; chain steps with && or pipes if needed.
+If it cannot be done safely with one command, reply with a single line starting with "# " explaining why, in the language %q.
+
+%s
+`, shell, runtime.GOOS, cwd, string(i18n.Current()), description)
+}
+
+// shellExplainPrompt 请模型解释 shell 命令；brief 时只要几行纯文本
+func shellExplainPrompt(command, shell string, brief bool) string {
+	if shell == "" {
+		shell = "inferred from the syntax"
+	}
+	format := `Go through it part by part in a Markdown list (commands, flags, pipes, redirections, expansions),
+then state what it changes on the system and any risks (data loss, privilege, network).`
+	if brief {
+		format = `Answer in at most 5 short lines of plain text (no Markdown): what it does,
+then any risk (data loss, privilege, network) if there is one.`
+	}
+	return fmt.Sprintf(`Explain this shell command (shell: %s) for a developer.
+%s
+Answer in the language %q.
+
+`+"```"+`
+%s
+`+"```"+`
+`, shell, format, string(i18n.Current()), command)
+}
+
+// extractCommand 去掉模型仍可能加上的代码块围栏、反引号与提示符 "$ "
+func extractCommand(answer string) string {
+	answer = strings.TrimSpace(answer)
+	if strings.HasPrefix(answer, "```") {
+		lines := strings.Split(answer, "\n")[1:]
+		for i, line := range lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "```") {
+				lines = lines[:i]
+				break
+			}
+		}
+		answer = strings.TrimSpace(strings.Join(lines, "\n"))
+	}
+	if len(answer) > 1 && strings.HasPrefix(answer, "`") && strings.HasSuffix(answer, "`") && !strings.Contains(answer, "\n") {
+		answer = strings.Trim(answer, "`")
+	}
+	return strings.TrimPrefix(answer, "$ ")
+}
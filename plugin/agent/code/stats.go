@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/width"
+
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/metrics"
+	"wcp_agent/internal/stats"
+)
+
+// runStats agent stats [reset | metrics [--json]]：查看或清空本地使用统计，或查看守护进程的运行时统计；
+// 守护进程在运行时，本地使用统计之后还会列出它的运行时统计
+func runStats(args []string) error {
+	if len(args) > 0 && args[0] == "metrics" {
+		return statsMetrics(args[1:])
+	}
+	if len(args) > 0 {
+		if args[0] != "reset" || len(args) > 1 {
+			return errors.New(i18n.T(i18n.MsgStatsUsage))
+		}
+		if err := stats.Reset(); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T(i18n.MsgStatsCleared))
+		return nil
+	}
+
+	if !stats.Enabled() {
+		fmt.Println(i18n.T(i18n.MsgStatsDisabled, stats.EnableEnv, stats.SettingStats))
+	}
+	st, err := stats.Load()
+	if err != nil {
+		return err
+	}
+	snap, running := daemonMetrics()
+	if len(st.Commands) == 0 && st.Render.Count == 0 && len(st.Usage) == 0 {
+		fmt.Println(i18n.T(i18n.MsgStatsEmpty))
+		if running {
+			fmt.Println()
+			printMetrics(snap)
+		}
+		return nil
+	}
+
+	fmt.Println(i18n.T(i18n.MsgStatsSince, st.Since.Format("2006-01-02 15:04"), stats.Path()))
+	fmt.Println()
+	cols := strings.Split(i18n.T(i18n.MsgStatsHeader), "|")
+	fmt.Printf("%s %s %s %s\n", padRight(cols[0], 16), padLeft(cols[1], 8), padLeft(cols[2], 12), padLeft(cols[3], 12))
+	names := make([]string, 0, len(st.Commands))
+	for name := range st.Commands {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return st.Commands[names[i]].Count > st.Commands[names[j]].Count
+	})
+	for _, name := range names {
+		c := st.Commands[name]
+		fmt.Printf("%-16s %8d %12d %12d\n", name, c.Count, c.AvgMs(), c.MaxMs)
+	}
+	if r := st.Render; r.Count > 0 {
+		fmt.Println()
+		fmt.Println(i18n.T(i18n.MsgStatsRender, r.Count, r.TotalBytes/r.Count, r.MaxBytes))
+	}
+	if len(st.Usage) > 0 {
+		printUsage(st.Usage)
+	}
+	if running {
+		fmt.Println()
+		printMetrics(snap)
+	}
+	return nil
+}
+
+// statsMetrics agent stats metrics [--json]：守护进程启动以来各 provider 的请求数、错误率、延迟分位数与 token 数
+func statsMetrics(args []string) error {
+	fs := flag.NewFlagSet("stats metrics", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the metrics as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New(i18n.T(i18n.MsgStatsUsage))
+	}
+	snap, err := daemon.FetchMetrics()
+	if err != nil {
+		return notRunning(err)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snap)
+	}
+	printMetrics(snap)
+	return nil
+}
+
+// daemonMetrics 守护进程在运行时读取它的运行时统计
+func daemonMetrics() (metrics.Snapshot, bool) {
+	if !daemon.Enabled() {
+		return metrics.Snapshot{}, false
+	}
+	snap, err := daemon.FetchMetrics()
+	return snap, err == nil
+}
+
+// printMetrics 按 provider 列出运行时统计（毫秒为最近请求的延迟分位数，token 为估算值）
+func printMetrics(snap metrics.Snapshot) {
+	fmt.Println(i18n.T(i18n.MsgStatsMetricsSince, snap.Since.Local().Format("2006-01-02 15:04")))
+	if len(snap.Providers) == 0 {
+		fmt.Println(i18n.T(i18n.MsgStatsMetricsEmpty))
+		return
+	}
+	cols := strings.Split(i18n.T(i18n.MsgStatsMetricsHdr), "|")
+	fmt.Printf("%s %s %s %s %s %s %s %s %s\n", padRight(cols[0], 16), padLeft(cols[1], 8), padLeft(cols[2], 8), padLeft(cols[3], 8),
+		padLeft(cols[4], 8), padLeft(cols[5], 8), padLeft(cols[6], 8), padLeft(cols[7], 12), padLeft(cols[8], 12))
+	for _, p := range snap.Providers {
+		fmt.Printf("%s %8d %8d %7.1f%% %8d %8d %8d %12d %12d\n", padRight(p.Provider, 16), p.Requests, p.Errors,
+			p.ErrorRate()*100, p.P50Ms, p.P90Ms, p.P99Ms, p.InputTokens, p.OutputTokens)
+	}
+}
+
+// printUsage 按 provider 列出模型请求的用量与估算费用（未配置 pricing 的 provider 费用列留空）
+func printUsage(usage map[string]*stats.Usage) {
+	fmt.Println()
+	cols := strings.Split(i18n.T(i18n.MsgStatsUsageHdr), "|")
+	fmt.Printf("%s %s %s %s %s\n", padRight(cols[0], 16), padLeft(cols[1], 8), padLeft(cols[2], 14), padLeft(cols[3], 14), padLeft(cols[4], 10))
+	names := make([]string, 0, len(usage))
+	for name := range usage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		u := usage[name]
+		cost := ""
+		if u.Cost > 0 {
+			cost = fmt.Sprintf("%.4f", u.Cost)
+		}
+		fmt.Printf("%s %8d %14d %14d %10s\n", padRight(name, 16), u.Requests, u.InputTokens, u.OutputTokens, cost)
+	}
+}
+
+// displayWidth 终端显示宽度（中日韩全角字符按 2 列计算）
+func displayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// runeWidth 全角与东亚宽字符占 2 列，其余占 1 列（制表符、框线等半宽符号不会被误算为 2 列）
+func runeWidth(r rune) int {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+func padRight(s string, width int) string {
+	if n := width - displayWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+func padLeft(s string, width int) string {
+	if n := width - displayWidth(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/schema"
+)
+
+// maxRepairRounds 回答不符合 schema 时最多请求模型修正的次数
+const maxRepairRounds = 2
+
+// schemaInstruction 附加的 system 消息：要求模型只输出符合 schema 的 JSON
+func schemaInstruction(sch *schema.Schema) provider.Message {
+	return provider.Message{
+		Role: "system",
+		Content: "Respond with a single JSON value that conforms to the following JSON Schema. " +
+			"Output only the JSON, with no explanation and no code fences.\n\n" + string(sch.Raw),
+	}
+}
+
+// structuredAnswer 从回答中提取 JSON 并按 schema 校验；不通过时把错误反馈给模型要求修正，
+// 返回格式化后的 JSON。修正次数用尽仍不通过时返回错误（错误明细已输出到 stderr）
+func structuredAnswer(ctx context.Context, client provider.Client, messages []provider.Message, answer string, sch *schema.Schema) (string, error) {
+	for round := 0; ; round++ {
+		raw, problems := validateAnswer(answer, sch)
+		if len(problems) == 0 {
+			var out bytes.Buffer
+			err := json.Indent(&out, []byte(raw), "", "  ")
+			return out.String(), err
+		}
+		if round == maxRepairRounds {
+			for _, p := range problems {
+				fmt.Fprintln(os.Stderr, "  "+p)
+			}
+			return "", fmt.Errorf("%s", i18n.T(i18n.MsgSchemaInvalid, maxRepairRounds))
+		}
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgSchemaRepairing, len(problems), round+1))
+		messages = append(messages,
+			provider.Message{Role: "assistant", Content: answer},
+			provider.Message{Role: "user", Content: "Your reply does not conform to the schema:\n- " +
+				strings.Join(problems, "\n- ") + "\nReply with only the corrected JSON."},
+		)
+		var err error
+		answer, err = client.Chat(ctx, messages, nil)
+		// 被截断的 JSON 交给下一轮校验，作为不合规的回答继续修正
+		if err != nil && !errors.Is(err, provider.ErrTruncated) {
+			return "", err
+		}
+	}
+}
+
+// validateAnswer 返回提取到的 JSON 原文与不符合 schema 的原因，提取不到 JSON 时也作为一条原因
+func validateAnswer(answer string, sch *schema.Schema) (string, []string) {
+	v, raw, err := schema.Extract(answer)
+	if err != nil {
+		return "", []string{err.Error()}
+	}
+	return raw, sch.Validate(v)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/templates"
+)
+
+// runTemplate agent template list | show <名称>：列出模板目录中的模板及其变量，或打印一个模板的原文
+func runTemplate(args []string) error {
+	switch {
+	case len(args) == 1 && args[0] == "list":
+		return templateList()
+	case len(args) == 2 && args[0] == "show":
+		t, err := templates.Load(args[1])
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(t.Path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, t.Path)
+		if len(t.Vars) > 0 {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTemplateVars, strings.Join(t.Vars, ", ")))
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	default:
+		return errors.New(i18n.T(i18n.MsgTemplateUsage))
+	}
+}
+
+// templateList 每行一个模板：名称与引用的变量
+func templateList() error {
+	list, err := templates.List()
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTemplateNone, templates.Dir()))
+		return nil
+	}
+	width := 0
+	for _, t := range list {
+		width = max(width, displayWidth(t.Name))
+	}
+	for _, t := range list {
+		vars := strings.Join(t.Vars, ", ")
+		switch {
+		case t.Vars == nil:
+			vars = i18n.T(i18n.MsgTemplateInvalid)
+		case vars == "":
+			vars = i18n.T(i18n.MsgTemplateNoVars)
+		}
+		fmt.Printf("%s  %s\n", padRight(t.Name, width), vars)
+	}
+	return nil
+}
+
+// templatePrompt 用 --var 与位置参数渲染 --template 指定的模板：
+// 模板引用 {{.Input}} 且未用 --var 给出时，位置参数（没有时为管道输入）作为 Input；
+// 模板不引用 Input 时，位置参数作为补充要求附在渲染结果之后
+func templatePrompt(name string, varList []string, args []string) (string, error) {
+	t, err := templates.Load(name)
+	if err != nil {
+		return "", err
+	}
+	vars, err := parseVars(varList)
+	if err != nil {
+		return "", err
+	}
+	extra := strings.Join(args, " ")
+	if _, ok := vars[templates.InputVar]; !ok && t.Uses(templates.InputVar) {
+		switch {
+		case extra != "":
+			vars[templates.InputVar], extra = extra, ""
+		case !stdinUsed(varList) && !term.IsTerminal(int(os.Stdin.Fd())):
+			data, err := readLimited(os.Stdin)
+			if err != nil {
+				return "", err
+			}
+			vars[templates.InputVar] = strings.TrimSpace(data)
+		}
+	}
+	prompt, err := t.Execute(vars)
+	if err != nil {
+		return "", err
+	}
+	prompt = strings.TrimSpace(prompt)
+	if extra != "" {
+		prompt += "\n\n" + extra
+	}
+	if prompt == "" {
+		return "", errors.New(i18n.T(i18n.MsgAskNoPrompt))
+	}
+	return prompt, nil
+}
+
+// parseVars 解析 --var key=value；value 以 @ 开头时从其他来源读取：
+// @- 为标准输入（只能用一次），@clip 为系统剪贴板，@路径 为文件内容，@@ 开头表示字面的 @
+func parseVars(list []string) (map[string]string, error) {
+	vars := map[string]string{}
+	stdin := false
+	for _, kv := range list {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, errors.New(i18n.T(i18n.MsgVarBad, kv))
+		}
+		if src, ok := strings.CutPrefix(v, "@"); ok && !strings.HasPrefix(src, "@") {
+			var err error
+			switch src {
+			case "-":
+				if stdin {
+					return nil, errors.New(i18n.T(i18n.MsgVarStdinTwice, k))
+				}
+				stdin = true
+				v, err = readLimited(os.Stdin)
+			case "clip":
+				v, err = readClipboard()
+			default:
+				var data []byte
+				data, err = os.ReadFile(src)
+				v = string(data)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("--var %s: %w", k, err)
+			}
+			v = strings.TrimRight(v, "\r\n")
+		} else if ok {
+			v = src
+		}
+		vars[k] = v
+	}
+	return vars, nil
+}
+
+// stdinUsed 是否有变量从标准输入读取（此时不再把管道输入当作 Input）
+func stdinUsed(list []string) bool {
+	for _, kv := range list {
+		if _, v, _ := strings.Cut(kv, "="); v == "@-" {
+			return true
+		}
+	}
+	return false
+}
+
+// pasteCommands 各平台的剪贴板读取命令，按顺序尝试（与 clipboardCommands 对应）
+var pasteCommands = [][]string{
+	{"pbpaste"},
+	{"wl-paste", "--no-newline"},
+	{"xclip", "-selection", "clipboard", "-o"},
+	{"xsel", "--clipboard", "--output"},
+	{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard"},
+}
+
+// readClipboard 读取系统剪贴板中的文本
+func readClipboard() (string, error) {
+	for _, argv := range pasteCommands {
+		if argv[0] == "pbpaste" && runtime.GOOS != "darwin" {
+			continue
+		}
+		path, err := exec.LookPath(argv[0])
+		if err != nil {
+			continue
+		}
+		var out bytes.Buffer
+		cmd := exec.Command(path, argv[1:]...)
+		cmd.Stdout = &out
+		if err := cmd.Run(); err != nil {
+			return "", err
+		}
+		return out.String(), nil
+	}
+	return "", errors.New(i18n.T(i18n.MsgVarNoClipboard))
+}
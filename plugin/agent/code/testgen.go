@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+const (
+	// maxPackageContextBytes 提示词中同包其他文件的声明总长度上限
+	maxPackageContextBytes = 48 << 10
+	// maxConventionBytes 作为写法参考的现有测试文件长度上限
+	maxConventionBytes = 12 << 10
+)
+
+// testTarget 需要生成测试的源文件及生成结果的写入位置
+type testTarget struct {
+	source   string // 源文件路径，如 pkg/foo/bar.go
+	dir      string
+	output   string // 写入的测试文件：bar_test.go，已存在时为 bar_gen_test.go
+	existing string // bar_test.go 已存在时为其路径
+}
+
+// runTestGen agent ask --tests file.go [补充要求]：把文件、同包声明与现有测试的写法交给模型生成测试，
+// 渲染预览并确认后写入 _test.go，再运行 go test 报告能否编译与通过
+func runTestGen(ctx context.Context, cfg config.AgentConfig, p config.Provider, opts provider.Options, path, extra string) error {
+	target, prompt, err := buildTestPrompt(path, extra)
+	if err != nil {
+		return err
+	}
+	answer, err := chatOnce(ctx, cfg, p, opts, prompt, i18n.T(i18n.MsgTestsGenerating, target.source))
+	if err != nil {
+		return err
+	}
+
+	code, ok := goCodeBlock(answer)
+	if !ok {
+		return errors.New(i18n.T(i18n.MsgTestsNoCode))
+	}
+	if formatted, ferr := format.Source([]byte(code)); ferr == nil {
+		code = string(formatted)
+	}
+	if err := renderMarkdown("### " + target.output + "\n\n```go\n" + code + "```\n"); err != nil {
+		return err
+	}
+	if !confirm(i18n.T(i18n.MsgTestsConfirm, target.output)) {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTestsNotWritten))
+		return nil
+	}
+	if err := os.WriteFile(target.output, []byte(code), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTestsWritten, target.output))
+	return runGoTest(ctx, target)
+}
+
+// buildTestPrompt 组装生成测试的提示词：目标文件全文、所在模块与导入路径、同包其他文件的声明
+// （函数只保留签名）、现有测试的写法，以及用户的补充要求
+func buildTestPrompt(path, extra string) (testTarget, string, error) {
+	if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+		return testTarget{}, "", errors.New(i18n.T(i18n.MsgTestsNotGo, path))
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return testTarget{}, "", err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, source, parser.PackageClauseOnly)
+	if err != nil {
+		return testTarget{}, "", err
+	}
+	pkg := file.Name.Name
+	dir := filepath.Dir(path)
+	base := strings.TrimSuffix(filepath.Base(path), ".go")
+	target := testTarget{source: path, dir: dir, output: filepath.Join(dir, base+"_test.go")}
+	if _, err := os.Stat(target.output); err == nil {
+		target.existing = target.output
+		target.output = filepath.Join(dir, base+"_gen_test.go")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Write Go unit tests for the file %s (package %s", filepath.Base(path), pkg)
+	if importPath := packageImportPath(dir); importPath != "" {
+		fmt.Fprintf(&b, ", import path %s", importPath)
+	}
+	b.WriteString(").\n\n")
+	b.WriteString("Requirements:\n" +
+		"- Reply with exactly one ```go code block containing the complete test file, ready to save as " + filepath.Base(target.output) + ".\n" +
+		"- Cover the exported behavior and the important edge cases and error paths; prefer table-driven tests.\n" +
+		"- Only use the standard library and modules the package already imports; do not call the network.\n" +
+		"- The tests must compile against the code shown: do not invent functions, fields or types.\n")
+	if target.existing != "" {
+		b.WriteString("- " + filepath.Base(target.existing) + " already exists (shown below); add new tests only and do not redeclare its functions or helpers.\n")
+	}
+	if extra = strings.TrimSpace(extra); extra != "" {
+		b.WriteString("- " + extra + "\n")
+	}
+	fmt.Fprintf(&b, "\n%s:\n\n```go\n%s\n```\n", filepath.Base(path), strings.TrimRight(string(source), "\n"))
+
+	if decls := packageDeclarations(dir, filepath.Clean(path), pkg); decls != "" {
+		b.WriteString("\nDeclarations from the other files of the package (function bodies omitted):\n\n```go\n" + strings.TrimRight(decls, "\n") + "\n```\n")
+	}
+	if name, example := testConvention(dir, target.existing); example != "" {
+		b.WriteString("\nAn existing test of this package, " + name + "; follow its conventions (package clause, assertion style, helpers, naming):\n\n```go\n" + example + "```\n")
+	} else {
+		b.WriteString("\nThe package has no tests yet: use the standard testing package and the same package clause as the file.\n")
+	}
+	return target, b.String(), nil
+}
+
+// packageImportPath 由最近的 go.mod 推出 dir 的导入路径，找不到模块时返回空串
+func packageImportPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		if data, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+			module := modulePath(data)
+			if module == "" {
+				return ""
+			}
+			rel, _ := filepath.Rel(root, abs)
+			if rel == "." {
+				return module
+			}
+			return module + "/" + filepath.ToSlash(rel)
+		}
+		if filepath.Dir(root) == root {
+			return ""
+		}
+	}
+}
+
+// modulePath 读取 go.mod 中的 module 指令
+func modulePath(gomod []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(gomod))
+	for scanner.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// packageDeclarations 同包其他非测试文件的声明，函数去掉函数体，总长度不超过 maxPackageContextBytes
+func packageDeclarations(dir, skip, pkg string) string {
+	names, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		if name == skip || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil || file.Name.Name != pkg {
+			continue
+		}
+		var decls bytes.Buffer
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+				continue
+			}
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				fn.Body = nil
+			}
+			if err := printer.Fprint(&decls, fset, decl); err != nil {
+				continue
+			}
+			decls.WriteString("\n")
+		}
+		chunk := "// " + filepath.Base(name) + "\n" + decls.String() + "\n"
+		if b.Len()+len(chunk) > maxPackageContextBytes {
+			break
+		}
+		b.WriteString(chunk)
+	}
+	return b.String()
+}
+
+// testConvention 选一个现有测试文件作为写法参考：优先与目标同名的测试，否则取最短的一个；
+// 超出 maxConventionBytes 的部分截掉
+func testConvention(dir, preferred string) (string, string) {
+	names, _ := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if len(names) == 0 {
+		return "", ""
+	}
+	pick := preferred
+	if pick == "" {
+		size := int64(-1)
+		for _, name := range names {
+			if info, err := os.Stat(name); err == nil && (size < 0 || info.Size() < size) {
+				pick, size = name, info.Size()
+			}
+		}
+	}
+	data, err := os.ReadFile(pick)
+	if err != nil {
+		return "", ""
+	}
+	example := string(data)
+	if len(example) > maxConventionBytes {
+		example = example[:maxConventionBytes]
+		example = example[:strings.LastIndexByte(example, '\n')+1] + "// ...\n"
+	}
+	return filepath.Base(pick), example
+}
+
+// goCodeBlock 取回答中第一个 go 代码块，没有时取第一个代码块
+func goCodeBlock(answer string) (string, bool) {
+	var first string
+	found := false
+	lines := strings.Split(answer, "\n")
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, "```") {
+			continue
+		}
+		lang := strings.ToLower(strings.TrimSpace(strings.TrimLeft(trimmed, "`")))
+		var code []string
+		for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+			code = append(code, lines[i])
+		}
+		block := strings.Join(code, "\n") + "\n"
+		if lang == "go" || lang == "golang" {
+			return block, true
+		}
+		if !found {
+			first, found = block, true
+		}
+	}
+	return first, found
+}
+
+// confirm 在终端中提出 question 并等待 y/N 回答；无法交互（没有终端）时视为否
+func confirm(question string) bool {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return false
+	}
+	defer tty.Close()
+	fmt.Fprint(os.Stderr, question)
+	line, _ := bufio.NewReader(tty).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes", "是":
+		return true
+	}
+	return false
+}
+
+// runGoTest 在包目录中运行 go test，输出实时转到 stderr，最后报告能否编译与通过
+func runGoTest(ctx context.Context, target testTarget) error {
+	fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTestsRunning, target.dir))
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "test", "-count=1", ".")
+	cmd.Dir = target.dir
+	cmd.Stdout = io.MultiWriter(os.Stderr, &output)
+	cmd.Stderr = cmd.Stdout
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTestsPassed, target.output))
+		return nil
+	case ctx.Err() != nil:
+		return interrupted(ctx, err)
+	case !errors.As(err, &exitErr):
+		return err
+	case strings.Contains(output.String(), "[build failed]") || strings.Contains(output.String(), "[setup failed]"):
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTestsBuildFailed, target.output))
+	default:
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTestsFailed, target.output))
+	}
+	return exitCode(1)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+)
+
+const (
+	// titleTimeout 请模型生成标题的时间上限，超时则在本地按问题生成
+	titleTimeout = 10 * time.Second
+	// titleInputRunes 发给模型的问题与回答各自至多保留的字符数
+	titleInputRunes = 2000
+)
+
+const titleInstruction = "Write a title of at most 6 words for the conversation that starts with the exchange below. " +
+	"Reply with the title only, in the language of the question, without quotes or a trailing period."
+
+// modelTitle 按 titles 配置请模型为新对话的第一轮概括标题；未配置或请求失败时返回空，由 history.Append 按问题生成
+func modelTitle(ctx context.Context, cfg config.AgentConfig, e history.Exchange) string {
+	if cfg.Titles == nil || cfg.Titles.Provider == "" {
+		return ""
+	}
+	i := cfg.FindProvider(cfg.Titles.Provider)
+	if i < 0 {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTitlesNoProvider, cfg.Titles.Provider))
+		return ""
+	}
+	p := cfg.Providers[i]
+	if cfg.Titles.Model != "" {
+		p.Model = cfg.Titles.Model
+	}
+	key, _ := auth.Resolve(p)
+	client, err := newClient(p, provider.Options{Key: key, Timeouts: cfg.EffectiveTimeouts(p), MaxTokens: 32})
+	if err != nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(quietly(ctx), titleTimeout)
+	defer cancel()
+	answer, err := client.Chat(ctx, []provider.Message{
+		{Role: "system", Content: titleInstruction},
+		{Role: "user", Content: "Question:\n" + truncateRunes(e.Prompt, titleInputRunes) + "\n\nAnswer:\n" + truncateRunes(e.Answer, titleInputRunes)},
+	}, nil)
+	if err != nil && !errors.Is(err, provider.ErrTruncated) {
+		return ""
+	}
+	return history.Title(strings.Trim(answer, "\"'“”「」*`# \n"))
+}
+
+// exchangeLabel 列表与选择器中一轮问答的说明：对话之后的各轮为 "标题 › 问题"；第一轮为标题，
+// 标题由模型概括（不只是问题的开头）时再附上问题；没有标题时只有问题。limit 大于 0 时问题压成一行并截断
+func exchangeLabel(e history.Exchange, titles map[string]string, limit int) string {
+	prompt := strings.Join(strings.Fields(e.Prompt), " ")
+	if limit > 0 {
+		prompt = oneLine(e.Prompt, limit)
+	}
+	title := titles[e.ID]
+	switch {
+	case title == "":
+		return prompt
+	case e.Parent != "":
+		return title + " › " + prompt
+	case title == history.Title(e.Prompt):
+		return title
+	}
+	return title + " · " + prompt
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/daemon"
+	"wcp_agent/internal/i18n"
+)
+
+// popupInside 在弹窗内运行时附加的参数（由外层调用传入，不必手动使用）
+const popupInside = "--inside"
+
+// runTmuxPopup agent tmux-popup [ask|chat] [--session 名称] [--width 80%] [--height 70%] [-- 问题]：
+// 在 tmux display-popup 中打开一次提问（ask，回答后按任意键关闭）或多轮对话（chat，空行或 Ctrl-D 关闭），
+// 弹窗的工作目录为当前窗格的目录；默认会话名为 tmux-<tmux 会话名>，经守护进程与 agent ask --session 共享。
+// --bindings 输出可加入 ~/.tmux.conf 的按键绑定
+func runTmuxPopup(args []string) error {
+	inside := len(args) > 0 && args[0] == popupInside
+	if inside {
+		args = args[1:]
+	}
+	mode := "ask"
+	if len(args) > 0 && (args[0] == "ask" || args[0] == "chat") {
+		mode, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("tmux-popup", flag.ContinueOnError)
+	session := fs.String("session", "", "daemon session to use (default: tmux-<tmux session name>)")
+	width := fs.String("width", "80%", "popup width (columns or a percentage)")
+	height := fs.String("height", "70%", "popup height (lines or a percentage)")
+	bindings := fs.Bool("bindings", false, "print key bindings for ~/.tmux.conf")
+	key := fs.String("key", "a", "with --bindings: key after the prefix for ask (the upper case key opens chat)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	agent, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if *bindings {
+		fmt.Print(tmuxBindings(agent, *key))
+		return nil
+	}
+	if inside {
+		return popupLoop(mode, *session, fs.Args())
+	}
+	if os.Getenv("TMUX") == "" {
+		return errors.New(i18n.T(i18n.MsgTmuxNotInside))
+	}
+	if *session == "" {
+		name, err := tmuxFormat("#S")
+		if err != nil {
+			return err
+		}
+		*session = "tmux-" + name
+	}
+	cwd, err := tmuxFormat("#{pane_current_path}")
+	if err != nil || cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+	inner := []string{agent, "tmux-popup", popupInside, mode, "--session", *session}
+	if fs.NArg() > 0 {
+		inner = append(append(inner, "--"), fs.Args()...)
+	}
+	cmd := exec.Command("tmux", "display-popup", "-E", "-w", *width, "-h", *height, "-d", cwd, strings.Join(quoteArgs(inner), " "))
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// tmuxFormat 以 tmux display-message 展开格式串（当前客户端的会话、窗格）
+func tmuxFormat(format string) (string, error) {
+	out, err := exec.Command("tmux", "display-message", "-p", format).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// tmuxBindings 前缀键 + key 打开提问、+ 大写 key 打开对话；run-shell -b 使绑定立即返回，弹窗由 agent 自己打开
+func tmuxBindings(agent, key string) string {
+	bin := quoteArgs([]string{agent})[0]
+	return fmt.Sprintf(`# agent tmux-popup：prefix %[2]s 提问，prefix %[3]s 多轮对话
+bind-key %[2]s run-shell -b "%[1]s tmux-popup ask"
+bind-key %[3]s run-shell -b "%[1]s tmux-popup chat"
+`, strings.ReplaceAll(bin, `"`, `\"`), key, strings.ToUpper(key))
+}
+
+// popupLoop 弹窗内的交互：ask 只问一次，回答后等待按键再关闭；chat 反复提问直到空行或 Ctrl-D。
+// 守护进程未运行时无法保存会话，提示后逐次独立提问
+func popupLoop(mode, session string, question []string) error {
+	if session != "" && !daemon.Available() {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTmuxNoDaemon))
+		session = ""
+	}
+	base := []string{}
+	if session != "" {
+		base = []string{"--session", session}
+	}
+	if len(question) > 0 {
+		question = append([]string{"--"}, question...)
+	}
+	in := bufio.NewReader(os.Stdin)
+	if mode == "chat" {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTmuxChatHint))
+	}
+	for {
+		if len(question) == 0 {
+			fmt.Fprint(os.Stderr, i18n.T(i18n.MsgTmuxPrompt))
+			line, err := in.ReadString('\n')
+			line = strings.TrimSpace(line)
+			if line == "" {
+				if err != nil && !errors.Is(err, io.EOF) {
+					return err
+				}
+				return nil
+			}
+			question = []string{"--", line}
+		}
+		err := runAsk(append(append([]string{}, base...), question...))
+		question = nil
+		var code exitCode
+		if err != nil && !errors.As(err, &code) {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+		}
+		if mode != "chat" {
+			waitKey()
+			return nil
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// waitKey 提示后等待任意键，使回答在弹窗关闭前可以读完
+func waitKey() {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return
+	}
+	fmt.Fprint(os.Stderr, "\n"+i18n.T(i18n.MsgTmuxClose))
+	if state, err := term.MakeRaw(fd); err == nil {
+		defer term.Restore(fd, state)
+	}
+	os.Stdin.Read(make([]byte, 1))
+}
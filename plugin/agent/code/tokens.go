@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/tokenizer"
+)
+
+// runTokens agent tokens [--provider P] [--model M] [文本] | download [编码...]：
+// 统计文本（不给出时读取 stdin）发给某个模型时的 token 数，stdout 上只有数字；
+// stderr 说明所用的编码与占模型上下文窗口的比例
+func runTokens(args []string) error {
+	if len(args) > 0 && args[0] == "download" {
+		return tokensDownload(args[1:])
+	}
+	fs := flag.NewFlagSet("tokens", flag.ContinueOnError)
+	providerName := fs.String("provider", "", "provider name (defaults to the active one)")
+	model := fs.String("model", "", "count for this model instead of the provider's")
+	registerMaxInput(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	text, err := readPrompt(fs.Args())
+	if err != nil {
+		return err
+	}
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	p, err := selectProvider(cfg, *providerName)
+	if err != nil {
+		return err
+	}
+	if *model != "" {
+		p.Model = *model
+	}
+	tokens := tokenizer.For(p)
+	n := tokens.Count(text)
+	fmt.Println(n)
+	if tokens.Local() {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTokensCounted, providerLabel(p.Name, p.Model), tokens.Encoding()))
+	} else {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTokensEstimated, providerLabel(p.Name, p.Model)))
+	}
+	if window := tokenizer.ContextWindow(p); window > 0 {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTokensWindow, float64(n)/float64(window)*100, window))
+	}
+	return nil
+}
+
+// tokensDownload 下载编码文件到 ~/.jdata/agent/tokenizers/，不指定时下载全部支持的编码
+func tokensDownload(names []string) error {
+	if len(names) == 0 {
+		names = tokenizer.Encodings
+	}
+	for _, name := range names {
+		if !tokenizer.Known(name) {
+			return errors.New(i18n.T(i18n.MsgTokensUnknown, name))
+		}
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTokensDownloading, name, tokenizer.URL(name)))
+		size, err := tokenizer.Download(ctx, http.DefaultClient, name)
+		if err != nil {
+			return interrupted(ctx, err)
+		}
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgTokensDownloaded, tokenizer.Path(name), float64(size)/(1<<20)))
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/trace"
+)
+
+// runTrace agent trace [show [id]] | list [-n N]：查看本地追踪文件中的链路，默认展示最近一条
+func runTrace(args []string) error {
+	sub := "show"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		sub, args = args[0], args[1:]
+	}
+	if sub != "show" && sub != "list" {
+		return errors.New(i18n.T(i18n.MsgTraceUsage))
+	}
+	flags := flag.NewFlagSet("trace "+sub, flag.ContinueOnError)
+	file := flags.String("file", defaultTraceFile(), "trace file written with J_TRACE")
+	limit := flags.Int("n", 20, "number of most recent traces to list (0 for all)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	// 允许 id 写在选项之前：show <id> --file ...
+	id := ""
+	if sub == "show" && flags.NArg() > 0 {
+		id = flags.Arg(0)
+		if err := flags.Parse(flags.Args()[1:]); err != nil {
+			return err
+		}
+	}
+	if flags.NArg() > 0 {
+		return errors.New(i18n.T(i18n.MsgTraceUsage))
+	}
+	traces, err := trace.Load(*file)
+	if errors.Is(err, fs.ErrNotExist) || err == nil && len(traces) == 0 {
+		fmt.Println(i18n.T(i18n.MsgTraceEmpty, *file))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if sub == "list" {
+		traceList(traces, *limit)
+		return nil
+	}
+	t, ok := findTrace(traces, id)
+	if !ok {
+		return errors.New(i18n.T(i18n.MsgTraceNotFound, id))
+	}
+	traceShow(t)
+	return nil
+}
+
+// defaultTraceFile J_TRACE 指定了追踪文件时用它，否则为 ~/.jdata/traces.jsonl
+func defaultTraceFile() string {
+	if t := trace.CurrentTarget(); t != nil && t.File != "" {
+		return t.File
+	}
+	return filepath.Join(config.DataDir(), trace.FileName)
+}
+
+// findTrace 按 id 前缀查找链路，id 为空时取最近一条
+func findTrace(traces []trace.Trace, id string) (trace.Trace, bool) {
+	for i := len(traces) - 1; i >= 0; i-- {
+		if strings.HasPrefix(traces[i].ID, id) {
+			return traces[i], true
+		}
+	}
+	return trace.Trace{}, false
+}
+
+func traceList(traces []trace.Trace, limit int) {
+	if limit > 0 && len(traces) > limit {
+		traces = traces[len(traces)-limit:]
+	}
+	for _, t := range traces {
+		mark := ""
+		if failedSpans(t) > 0 {
+			mark = " [" + i18n.T(i18n.MsgTraceFailed, failedSpans(t)) + "]"
+		}
+		fmt.Printf("%s  %s  %8s  %3d  %s%s\n", t.ID[:12], t.Start().Local().Format("2006-01-02 15:04:05"),
+			formatSpan(t.Duration()), len(t.Spans), t.Root().Name, mark)
+	}
+}
+
+func failedSpans(t trace.Trace) int {
+	n := 0
+	for _, s := range t.Spans {
+		if s.Failed {
+			n++
+		}
+	}
+	return n
+}
+
+// traceShow 按父子关系缩进输出各 span：相对链路开始的时间、耗时、名称与属性，失败原因与事件另起一行
+func traceShow(t trace.Trace) {
+	fmt.Println(i18n.T(i18n.MsgTraceHeader, t.ID, t.Start().Local().Format("2006-01-02 15:04:05"), formatSpan(t.Duration()), len(t.Spans)))
+	children := map[string][]trace.Record{}
+	ids := map[string]bool{}
+	for _, s := range t.Spans {
+		ids[s.SpanID] = true
+	}
+	var roots []trace.Record
+	for _, s := range t.Spans {
+		if ids[s.ParentID] {
+			children[s.ParentID] = append(children[s.ParentID], s)
+		} else {
+			roots = append(roots, s)
+		}
+	}
+	start := t.Start()
+	var walk func(s trace.Record, depth int)
+	walk = func(s trace.Record, depth int) {
+		indent := strings.Repeat("  ", depth)
+		attrs := make([]string, len(s.Attrs))
+		for i, a := range s.Attrs {
+			attrs[i] = a.String()
+		}
+		fmt.Printf("%9s %8s  %s%s  %s\n", "+"+formatSpan(s.Start.Sub(start)), formatSpan(s.Duration()), indent, s.Name, strings.Join(attrs, " "))
+		pad := strings.Repeat(" ", 20) + indent
+		if s.Failed {
+			fmt.Printf("%s  ✗ %s\n", pad, s.Message)
+		}
+		for _, e := range s.Events {
+			fmt.Printf("%s  · %s +%s", pad, e.Name, formatSpan(e.Time.Sub(s.Start)))
+			for _, a := range e.Attrs {
+				fmt.Printf(" %s", a)
+			}
+			fmt.Println()
+		}
+		for _, c := range children[s.SpanID] {
+			walk(c, depth+1)
+		}
+	}
+	for _, r := range roots {
+		walk(r, 0)
+	}
+}
+
+// formatSpan 不到 1 秒时以毫秒显示，否则以秒显示
+func formatSpan(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
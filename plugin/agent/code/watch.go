@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"wcp_agent/internal/a11y"
+	"wcp_agent/internal/auth"
+	"wcp_agent/internal/config"
+	"wcp_agent/internal/history"
+	"wcp_agent/internal/i18n"
+	"wcp_agent/internal/provider"
+	"wcp_agent/internal/spinner"
+)
+
+const (
+	// defaultWatchDebounce 文件停止变化这么久之后才重新提问（编辑器保存时常连续写几次）
+	defaultWatchDebounce = 500 * time.Millisecond
+	// watchPoll 检查文件变化的间隔
+	watchPoll = 250 * time.Millisecond
+	// clearScreen 清屏并把光标移到左上角
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// fileStamp 文件的修改时间与大小，任一变化即视为文件变了
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+// runWatch agent watch -f file [-f dir | -f 'glob']... [--provider P] [--debounce 500ms] [--clear] 问题：
+// 把文件内容附在问题之后提问，之后每当文件变化（停止变化 --debounce 之后）就重新提问，
+// 并逐词对比新旧回答（与 history regen 相同，终端中交给 md_render --diff 渲染）；Ctrl-C 结束
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	var patterns stringList
+	fs.Var(&patterns, "f", "file, directory or glob to watch and include in the prompt (repeatable)")
+	providerName := fs.String("provider", "", "provider name (defaults to the active one)")
+	debounce := fs.Duration("debounce", defaultWatchDebounce, "wait until the files have been quiet this long before asking again")
+	clear := fs.Bool("clear", false, "clear the screen before every new answer")
+	var sampling samplingFlags
+	sampling.register(fs)
+	registerLimitFlags(fs)
+	registerSendFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	prompt := strings.Join(fs.Args(), " ")
+	if len(patterns) == 0 || prompt == "" {
+		return errors.New(i18n.T(i18n.MsgWatchUsage))
+	}
+	stamps := snapshotFiles(patterns)
+	if len(stamps) == 0 {
+		return errors.New(i18n.T(i18n.MsgWatchNoFiles, strings.Join(patterns, ", ")))
+	}
+
+	cfg, err := loadAgent()
+	if err != nil {
+		return err
+	}
+	p, err := selectProvider(cfg, *providerName)
+	if err != nil {
+		return err
+	}
+	params, err := sampling.resolve(cfg, p)
+	if err != nil {
+		return err
+	}
+	stages, err := answerStages(params, nil, true)
+	if err != nil {
+		return err
+	}
+	key, _ := auth.Resolve(p)
+	client, err := newClient(p, provider.Options{
+		Key:      key,
+		Timeouts: cfg.EffectiveTimeouts(p),
+		Sampling: params,
+		// 文件没变时的重新保存也应得到新的回答
+		NoCache: true,
+	})
+	if err != nil {
+		return err
+	}
+	var system []provider.Message
+	if s := config.LoadSystemPrompt(cfg); s != "" {
+		system = append(system, provider.Message{Role: "system", Content: s})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	var previous string
+	var changed []string
+	for {
+		paths := sortedPaths(stamps)
+		// 无障碍模式下不清屏，读屏软件按时间顺序读到每一轮的回答
+		if *clear && tty && !a11y.Enabled() {
+			fmt.Print(clearScreen)
+		}
+		now := time.Now().Format("15:04:05")
+		if changed == nil {
+			fmt.Println(i18n.T(i18n.MsgWatchFirst, now, providerLabel(p.Name, p.Model), len(paths)))
+		} else {
+			fmt.Println(i18n.T(i18n.MsgWatchChanged, now, strings.Join(changed, ", ")))
+		}
+		fmt.Println()
+
+		answer, err := watchAsk(ctx, client, p, append(system, provider.Message{Role: "user", Content: filesPrompt(prompt, paths)}))
+		if err == nil {
+			answer, err = applyStages(ctx, stages, p, answer)
+		}
+		ok := err == nil
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+		case previous == "":
+			err = renderMarkdown(answer)
+		case strings.TrimSpace(previous) == strings.TrimSpace(answer):
+			fmt.Println(i18n.T(i18n.MsgWatchSame))
+		default:
+			err = renderAnswerDiff(previous, answer)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, err))
+		}
+		if ok && answer != "" {
+			previous = answer
+		}
+		fmt.Println()
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgWatchWaiting, len(paths)))
+
+		if changed, stamps = waitForChange(ctx, patterns, stamps, *debounce); ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// watchAsk 发送一轮并记入问答历史，被截断的回答照常返回
+func watchAsk(ctx context.Context, client provider.Client, p config.Provider, messages []provider.Message) (string, error) {
+	status := i18n.T(i18n.MsgAskThinking)
+	spin := spinner.Start(status)
+	start := time.Now()
+	answer, err := client.Chat(withSpinner(ctx, spin, status), messages, nil)
+	spin.Stop()
+
+	exchange := history.Exchange{
+		Provider:   p.Name,
+		Model:      p.Model,
+		Prompt:     messages[len(messages)-1].Content,
+		Answer:     answer,
+		Status:     history.StatusOK,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	truncated := errors.Is(err, provider.ErrTruncated)
+	exchange.ID = history.NewID()
+	switch {
+	case ctx.Err() != nil:
+		exchange.Status = history.StatusCancelled
+	case truncated:
+		exchange.Status = history.StatusTruncated
+	case err != nil:
+		exchange.Status = history.StatusError
+		exchange.Error = err.Error()
+	}
+	if herr := history.Append(exchange); herr != nil {
+		fmt.Fprintln(os.Stderr, i18n.T(i18n.MsgError, herr))
+	}
+	if truncated {
+		warnTruncated()
+		return answer, nil
+	}
+	return answer, err
+}
+
+// waitForChange 等到文件有变化且之后 debounce 内不再变化，返回变化的文件（新增、修改或删除）与新的快照；
+// ctx 结束时提前返回
+func waitForChange(ctx context.Context, patterns []string, stamps map[string]fileStamp, debounce time.Duration) ([]string, map[string]fileStamp) {
+	ticker := time.NewTicker(watchPoll)
+	defer ticker.Stop()
+	changed := map[string]bool{}
+	var last time.Time
+	current := stamps
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, stamps
+		case <-ticker.C:
+		}
+		next := snapshotFiles(patterns)
+		if diff := diffStamps(current, next); len(diff) > 0 {
+			for _, path := range diff {
+				changed[path] = true
+			}
+			current, last = next, time.Now()
+			continue
+		}
+		if len(changed) > 0 && time.Since(last) >= debounce {
+			// 改了又改回去的文件不算变化
+			var list []string
+			for path := range changed {
+				if stamps[path] != current[path] {
+					list = append(list, path)
+				}
+			}
+			if len(list) > 0 {
+				sort.Strings(list)
+				return list, current
+			}
+			changed = map[string]bool{}
+		}
+	}
+}
+
+// snapshotFiles 按模式展开出的全部文件及其时间戳：模式可以是文件、目录（递归，跳过以 . 开头的目录）或 glob
+func snapshotFiles(patterns []string) map[string]fileStamp {
+	stamps := map[string]fileStamp{}
+	add := func(path string, info fs.FileInfo) {
+		if info.Mode().IsRegular() {
+			stamps[filepath.Clean(path)] = fileStamp{info.ModTime(), info.Size()}
+		}
+	}
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			matches, _ = filepath.Glob(pattern)
+		}
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if !info.IsDir() {
+				add(path, info)
+				continue
+			}
+			_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return nil
+				}
+				if d.IsDir() {
+					if p != path && strings.HasPrefix(d.Name(), ".") {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if info, err := d.Info(); err == nil {
+					add(p, info)
+				}
+				return nil
+			})
+		}
+	}
+	return stamps
+}
+
+// diffStamps 两次快照之间新增、修改或删除的文件
+func diffStamps(old, cur map[string]fileStamp) []string {
+	var diff []string
+	for path, s := range cur {
+		if o, ok := old[path]; !ok || o != s {
+			diff = append(diff, path)
+		}
+	}
+	for path := range old {
+		if _, ok := cur[path]; !ok {
+			diff = append(diff, path)
+		}
+	}
+	return diff
+}
+
+func sortedPaths(stamps map[string]fileStamp) []string {
+	paths := make([]string, 0, len(stamps))
+	for path := range stamps {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,9 @@
+package main
+
+const (
+	// SettingNumberLocale setting 段中的数字格式（如 de、fr、en），未配置时读取 LC_NUMERIC / LANG
+	SettingNumberLocale = "number_locale"
+
+	// CalcDir 汇率缓存目录（位于数据目录下）
+	CalcDir = "calc"
+)
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+const (
+	// ratesURL 免 Key 的汇率接口（以 USD 为基准），可用 CALC_RATES_URL 覆盖
+	ratesURL = "https://open.er-api.com/v6/latest/USD"
+	// ratesURLEnv 覆盖汇率接口地址的环境变量
+	ratesURLEnv = "CALC_RATES_URL"
+	// ratesTTL 缓存有效期，过期后刷新，刷新失败时继续使用旧缓存并提示
+	ratesTTL = 12 * time.Hour
+)
+
+// Rates 以 USD 为基准的汇率缓存
+type Rates struct {
+	Fetched time.Time          `json:"fetched"` // 本地获取时间，用于判断缓存是否过期
+	Updated time.Time          `json:"updated"` // 接口公布的更新时间，用于展示
+	Rates   map[string]float64 `json:"rates"`
+}
+
+// currencyAliases 常见的货币符号与中文名称
+var currencyAliases = map[string]string{
+	"$": "USD", "美元": "USD", "¥": "CNY", "元": "CNY", "人民币": "CNY", "rmb": "CNY",
+	"€": "EUR", "欧元": "EUR", "£": "GBP", "英镑": "GBP", "日元": "JPY", "円": "JPY",
+	"港币": "HKD", "港元": "HKD", "韩元": "KRW", "₩": "KRW", "卢布": "RUB",
+}
+
+func ratesPath() (string, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, CalcDir, "rates.json"), nil
+}
+
+// currencyCode 归一化货币名称，非货币返回空串
+func currencyCode(name string, rates *Rates) string {
+	if code, ok := currencyAliases[strings.ToLower(name)]; ok {
+		return code
+	}
+	if code, ok := currencyAliases[name]; ok {
+		return code
+	}
+	code := strings.ToUpper(name)
+	if len(code) != 3 || rates == nil {
+		return ""
+	}
+	if _, ok := rates.Rates[code]; ok {
+		return code
+	}
+	return ""
+}
+
+// looksLikeCurrency 不联网的快速判断：已知别名或三个字母的货币代码；代码不区分大小写（usd 同 USD），
+// 但小写时与已知单位（如 min、day）同名的按单位处理
+func looksLikeCurrency(name string) bool {
+	if _, ok := currencyAliases[strings.ToLower(name)]; ok {
+		return true
+	}
+	if _, ok := currencyAliases[name]; ok {
+		return true
+	}
+	if len(name) != 3 {
+		return false
+	}
+	code := strings.ToUpper(name)
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	if code == name {
+		return true
+	}
+	_, isUnit := lookupUnit(name)
+	return !isUnit
+}
+
+// loadRates 读取缓存，过期时联网刷新
+func loadRates() (*Rates, error) {
+	path, err := ratesPath()
+	if err != nil {
+		return nil, err
+	}
+	var cached *Rates
+	if data, err := os.ReadFile(path); err == nil {
+		var r Rates
+		if json.Unmarshal(data, &r) == nil && len(r.Rates) > 0 {
+			cached = &r
+		}
+	}
+	if cached != nil && time.Since(cached.Fetched) < ratesTTL {
+		return cached, nil
+	}
+	fresh, err := fetchRates()
+	if err != nil {
+		if cached != nil {
+			fmt.Fprintln(os.Stderr, T(MsgRatesStale, cached.Updated.Format(time.DateTime), err))
+			return cached, nil
+		}
+		return nil, fmt.Errorf("%s", T(MsgRatesFailed, err))
+	}
+	if data, err := json.Marshal(fresh); err == nil {
+		if os.MkdirAll(filepath.Dir(path), 0o755) == nil {
+			_ = os.WriteFile(path, data, 0o644)
+		}
+	}
+	return fresh, nil
+}
+
+func fetchRates() (*Rates, error) {
+	url := ratesURL
+	if v := os.Getenv(ratesURLEnv); v != "" {
+		url = v
+	}
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	var body struct {
+		Result  string             `json:"result"`
+		Updated int64              `json:"time_last_update_unix"`
+		Rates   map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if len(body.Rates) == 0 {
+		return nil, fmt.Errorf("empty response (%s)", body.Result)
+	}
+	updated := time.Now()
+	if body.Updated > 0 {
+		updated = time.Unix(body.Updated, 0)
+	}
+	return &Rates{Fetched: time.Now(), Updated: updated, Rates: body.Rates}, nil
+}
+
+// convertCurrency 货币换算，返回结果和汇率更新时间
+func convertCurrency(value float64, from, to string) (float64, time.Time, error) {
+	rates, err := loadRates()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	f, t := currencyCode(from, rates), currencyCode(to, rates)
+	if f == "" {
+		return 0, time.Time{}, fmt.Errorf("%s", T(MsgUnknownCurrency, from))
+	}
+	if t == "" {
+		return 0, time.Time{}, fmt.Errorf("%s", T(MsgUnknownCurrency, to))
+	}
+	return value / rates.Rates[f] * rates.Rates[t], rates.Updated, nil
+}
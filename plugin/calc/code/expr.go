@@ -0,0 +1,349 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// constants 表达式中可用的常量
+var constants = map[string]float64{
+	"pi":  math.Pi,
+	"e":   math.E,
+	"tau": 2 * math.Pi,
+	"phi": math.Phi,
+}
+
+// functions 表达式中可用的函数及参数个数（-1 表示至少一个）
+var functions = map[string]struct {
+	arity int
+	fn    func(args []float64) float64
+}{
+	"sqrt":  {1, func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"cbrt":  {1, func(a []float64) float64 { return math.Cbrt(a[0]) }},
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"sin":   {1, func(a []float64) float64 { return math.Sin(a[0]) }},
+	"cos":   {1, func(a []float64) float64 { return math.Cos(a[0]) }},
+	"tan":   {1, func(a []float64) float64 { return math.Tan(a[0]) }},
+	"asin":  {1, func(a []float64) float64 { return math.Asin(a[0]) }},
+	"acos":  {1, func(a []float64) float64 { return math.Acos(a[0]) }},
+	"atan":  {1, func(a []float64) float64 { return math.Atan(a[0]) }},
+	"ln":    {1, func(a []float64) float64 { return math.Log(a[0]) }},
+	"log":   {1, func(a []float64) float64 { return math.Log10(a[0]) }},
+	"log2":  {1, func(a []float64) float64 { return math.Log2(a[0]) }},
+	"exp":   {1, func(a []float64) float64 { return math.Exp(a[0]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"min":   {-1, func(a []float64) float64 { return minOf(a) }},
+	"max":   {-1, func(a []float64) float64 { return -minOf(negate(a)) }},
+}
+
+func minOf(a []float64) float64 {
+	m := a[0]
+	for _, v := range a[1:] {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+func negate(a []float64) []float64 {
+	out := make([]float64, len(a))
+	for i, v := range a {
+		out[i] = -v
+	}
+	return out
+}
+
+// parser 递归下降求值：
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = unary { ("*" | "/" | "%" | 隐式乘法) unary }
+//	unary  = ("-" | "+") unary | power
+//	power  = postfix [ ("^" | "**") unary ]      右结合
+//	postfix= primary [ "!" ]
+//	primary= number [ "%" ] | name [ "(" args ")" ] | "(" expr ")"
+type parser struct {
+	src string
+	pos int
+}
+
+// evalExpr 计算数学表达式；数字中的下划线分隔符（1_000_000）会被忽略
+func evalExpr(src string) (float64, error) {
+	p := &parser{src: src}
+	v, err := p.expr()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return 0, p.errorHere()
+	}
+	// 结果不是实数（如负数的小数次幂）或超出 float64 范围时报错，而不是输出 NaN / +Inf
+	switch {
+	case math.IsNaN(v):
+		return 0, fmt.Errorf("%s", T(MsgUndefined))
+	case math.IsInf(v, 0):
+		return 0, fmt.Errorf("%s", T(MsgOverflow))
+	}
+	return v, nil
+}
+
+// errorHere 当前位置的语法错误；已到输入末尾（如括号未闭合的 "(1+2"）时报告输入意外结束
+func (p *parser) errorHere() error {
+	if p.pos >= len(p.src) {
+		return fmt.Errorf("%s", T(MsgUnexpectedEnd))
+	}
+	return fmt.Errorf("%s", T(MsgSyntax, p.src[p.pos:]))
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) expr() (float64, error) {
+	v, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '+':
+			p.pos++
+			r, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			v += r
+		case '-':
+			p.pos++
+			r, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			v -= r
+		default:
+			return v, nil
+		}
+	}
+}
+
+func (p *parser) term() (float64, error) {
+	v, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		c := p.peek()
+		switch {
+		case c == '*' && !strings.HasPrefix(p.src[p.pos:], "**"):
+			p.pos++
+		case c == '/' || c == '%':
+			p.pos++
+		case c == '(' || isNameStart(c) || isDigit(c):
+			// 隐式乘法：2pi、3(4+5)
+		default:
+			return v, nil
+		}
+		r, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case '/':
+			if r == 0 {
+				return 0, fmt.Errorf("%s", T(MsgDivZero))
+			}
+			v /= r
+		case '%':
+			if r == 0 {
+				return 0, fmt.Errorf("%s", T(MsgDivZero))
+			}
+			v = math.Mod(v, r)
+		default:
+			v *= r
+		}
+	}
+}
+
+func (p *parser) unary() (float64, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		v, err := p.unary()
+		return -v, err
+	case '+':
+		p.pos++
+		return p.unary()
+	}
+	return p.power()
+}
+
+func (p *parser) power() (float64, error) {
+	base, err := p.postfix()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case p.peek() == '^':
+		p.pos++
+	case strings.HasPrefix(p.src[p.pos:], "**"):
+		p.pos += 2
+	default:
+		return base, nil
+	}
+	exp, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(base, exp), nil
+}
+
+func (p *parser) postfix() (float64, error) {
+	v, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+	if p.peek() == '!' {
+		p.pos++
+		return math.Gamma(v + 1), nil
+	}
+	return v, nil
+}
+
+func (p *parser) primary() (float64, error) {
+	c := p.peek()
+	switch {
+	case c == '(':
+		p.pos++
+		v, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, p.errorHere()
+		}
+		p.pos++
+		return v, nil
+	case isDigit(c) || c == '.':
+		return p.number()
+	case isNameStart(c):
+		return p.name()
+	}
+	return 0, p.errorHere()
+}
+
+func (p *parser) number() (float64, error) {
+	start := p.pos
+	if strings.HasPrefix(p.src[p.pos:], "0x") || strings.HasPrefix(p.src[p.pos:], "0b") || strings.HasPrefix(p.src[p.pos:], "0o") {
+		p.pos += 2
+		for p.pos < len(p.src) && isHexDigit(p.src[p.pos]) {
+			p.pos++
+		}
+		n, err := strconv.ParseInt(p.src[start:p.pos], 0, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("%s", T(MsgNumberRange, p.src[start:p.pos]))
+		} else if err != nil {
+			p.pos = start
+			return 0, p.errorHere()
+		}
+		return float64(n), nil
+	}
+	for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.' || p.src[p.pos] == '_') {
+		p.pos++
+	}
+	// 科学计数法 1e6、2.5E-3
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		save := p.pos
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		if p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+				p.pos++
+			}
+		} else {
+			p.pos = save
+		}
+	}
+	text := strings.ReplaceAll(p.src[start:p.pos], "_", "")
+	v, err := strconv.ParseFloat(text, 64)
+	if errors.Is(err, strconv.ErrRange) && math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%s", T(MsgNumberRange, p.src[start:p.pos]))
+	} else if err != nil {
+		p.pos = start
+		return 0, p.errorHere()
+	}
+	// 百分数：50% 表示 0.5（后面紧跟操作数时视为取模）
+	if p.pos < len(p.src) && p.src[p.pos] == '%' {
+		rest := strings.TrimSpace(p.src[p.pos+1:])
+		if rest == "" || strings.ContainsRune("+-*/)^", rune(rest[0])) {
+			p.pos++
+			v /= 100
+		}
+	}
+	return v, nil
+}
+
+func (p *parser) name() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.src) && (isNameStart(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+		p.pos++
+	}
+	name := strings.ToLower(p.src[start:p.pos])
+	if f, ok := functions[name]; ok && p.peek() == '(' {
+		p.pos++
+		var args []float64
+		for p.peek() != ')' {
+			v, err := p.expr()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, v)
+			if p.peek() == ',' {
+				p.pos++
+			} else if p.peek() != ')' {
+				return 0, p.errorHere()
+			}
+		}
+		p.pos++
+		if f.arity < 0 && len(args) == 0 {
+			return 0, fmt.Errorf("%s", T(MsgArgCountMin, name))
+		}
+		if f.arity >= 0 && len(args) != f.arity {
+			return 0, fmt.Errorf("%s", T(MsgArgCount, name, f.arity))
+		}
+		v := f.fn(args)
+		if math.IsNaN(v) && !slices.ContainsFunc(args, math.IsNaN) {
+			// sqrt(-1)、ln(-1)、asin(2) 等：参数超出定义域
+			return 0, fmt.Errorf("%s", T(MsgDomain, name))
+		}
+		return v, nil
+	}
+	if v, ok := constants[name]; ok {
+		return v, nil
+	}
+	p.pos = start
+	return 0, fmt.Errorf("%s", T(MsgUnknownName, name))
+}
+
+func isDigit(c byte) bool     { return '0' <= c && c <= '9' }
+func isNameStart(c byte) bool { return c == '_' || unicode.IsLetter(rune(c)) }
+func isHexDigit(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
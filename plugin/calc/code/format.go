@@ -0,0 +1,109 @@
+package main
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"wcp_jdata"
+)
+
+// numberFormat 数字格式：小数点与千分位分隔符
+type numberFormat struct {
+	decimal, group string
+}
+
+// numberFormats 按语言区分的数字格式，未列出的语言使用英文格式
+var numberFormats = map[string]numberFormat{
+	"en": {".", ","},
+	"zh": {".", ","},
+	"ja": {".", ","},
+	"ko": {".", ","},
+	"de": {",", "."},
+	"es": {",", "."},
+	"it": {",", "."},
+	"nl": {",", "."},
+	"pt": {",", "."},
+	"id": {",", "."},
+	"tr": {",", "."},
+	"fr": {",", " "},
+	"ru": {",", " "},
+	"pl": {",", " "},
+	"sv": {",", " "},
+	"ch": {".", "'"},
+}
+
+// detectNumberFormat setting.number_locale > LC_ALL > LC_NUMERIC > LANG；de_CH 等瑞士区域使用撇号分组
+func detectNumberFormat() numberFormat {
+	raw := jdata.Setting(SettingNumberLocale)
+	for _, key := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if raw != "" {
+			break
+		}
+		if v := os.Getenv(key); v != "C" && v != "POSIX" {
+			raw = v
+		}
+	}
+	tag := strings.ToLower(raw)
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if strings.HasSuffix(tag, "_ch") || strings.HasSuffix(tag, "-ch") {
+		return numberFormats["ch"]
+	}
+	lang, _, _ := strings.Cut(strings.ReplaceAll(tag, "-", "_"), "_")
+	if f, ok := numberFormats[lang]; ok {
+		return f
+	}
+	return numberFormats["en"]
+}
+
+// format 保留 12 位有效数字（消除 0.1+0.2 这类浮点误差）并去掉末尾 0；极大 / 极小值使用科学计数法
+func (f numberFormat) format(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "∞"
+	case math.IsInf(v, -1):
+		return "-∞"
+	}
+	abs := math.Abs(v)
+	if abs != 0 && (abs >= 1e15 || abs < 1e-6) {
+		return strings.Replace(strconv.FormatFloat(v, 'g', 10, 64), ".", f.decimal, 1)
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 12, 64), 64)
+	s := strconv.FormatFloat(rounded, 'f', -1, 64)
+	if s == "-0" {
+		s = "0"
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	sign := ""
+	if strings.HasPrefix(intPart, "-") {
+		sign, intPart = "-", intPart[1:]
+	}
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(f.group)
+		}
+		b.WriteRune(c)
+	}
+	out := sign + b.String()
+	if frac != "" {
+		out += f.decimal + frac
+	}
+	return out
+}
+
+// formatMoney 货币保留两位小数（日元、韩元等无辅币单位的取整）
+func (f numberFormat) formatMoney(v float64, code string) string {
+	digits := 2
+	switch code {
+	case "JPY", "KRW", "VND", "IDR", "CLP", "ISK", "HUF":
+		digits = 0
+	}
+	p := math.Pow(10, float64(digits))
+	return f.format(math.Round(v*p)/p) + " " + code
+}
@@ -0,0 +1,80 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage           = "usage"
+	MsgError           = "error"
+	MsgSyntax          = "syntax"
+	MsgUnexpectedEnd   = "unexpected_end"
+	MsgUnknownName     = "unknown_name"
+	MsgDivZero         = "div_zero"
+	MsgArgCount        = "arg_count"
+	MsgArgCountMin     = "arg_count_min"
+	MsgDomain          = "domain"
+	MsgUndefined       = "undefined"
+	MsgOverflow        = "overflow"
+	MsgNumberRange     = "number_range"
+	MsgUnknownUnit     = "unknown_unit"
+	MsgIncompatible    = "incompatible"
+	MsgUnknownZone     = "unknown_zone"
+	MsgBadTime         = "bad_time"
+	MsgRatesFailed     = "rates_failed"
+	MsgRatesStale      = "rates_stale"
+	MsgUnknownCurrency = "unknown_currency"
+	MsgRatesFrom       = "rates_from"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:           "usage: calc <expression>   e.g. calc \"2^10 / 3\", calc 10 km to mi, calc 100 USD to CNY, calc 15:00 Asia/Shanghai to New_York",
+		MsgError:           "error: %v",
+		MsgSyntax:          "syntax error at %q",
+		MsgUnexpectedEnd:   "unexpected end of input",
+		MsgUnknownName:     "unknown name %q",
+		MsgDivZero:         "division by zero",
+		MsgArgCount:        "%s expects %d argument(s)",
+		MsgArgCountMin:     "%s expects at least one argument",
+		MsgDomain:          "%s: argument out of domain",
+		MsgUndefined:       "result is undefined (not a real number)",
+		MsgOverflow:        "result out of range (beyond ±1.8e308)",
+		MsgNumberRange:     "number %s is out of range",
+		MsgUnknownUnit:     "unknown unit %q",
+		MsgIncompatible:    "cannot convert %s (%s) to %s (%s)",
+		MsgUnknownZone:     "unknown time zone %q (use an IANA name like Asia/Tokyo)",
+		MsgBadTime:         "cannot parse time %q (use now, 15:04, or 2006-01-02 15:04)",
+		MsgRatesFailed:     "cannot fetch exchange rates: %v",
+		MsgRatesStale:      "using cached exchange rates from %s (refresh failed: %v)",
+		MsgUnknownCurrency: "unknown currency %q",
+		MsgRatesFrom:       "rates as of %s",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:           "用法: calc <表达式>   例如 calc \"2^10 / 3\"、calc 10 km to mi、calc 100 USD to CNY、calc 15:00 Asia/Shanghai to New_York",
+		MsgError:           "错误: %v",
+		MsgSyntax:          "语法错误（%q 处）",
+		MsgUnexpectedEnd:   "表达式不完整，输入意外结束",
+		MsgUnknownName:     "未知的名称 %q",
+		MsgDivZero:         "除数为零",
+		MsgArgCount:        "%s 需要 %d 个参数",
+		MsgArgCountMin:     "%s 至少需要 1 个参数",
+		MsgDomain:          "%s 的参数超出定义域",
+		MsgUndefined:       "结果无定义（不是实数）",
+		MsgOverflow:        "结果超出范围（绝对值大于 1.8e308）",
+		MsgNumberRange:     "数字 %s 超出范围",
+		MsgUnknownUnit:     "未知单位 %q",
+		MsgIncompatible:    "无法把 %s（%s）换算为 %s（%s）",
+		MsgUnknownZone:     "未知时区 %q（请使用 Asia/Tokyo 这类 IANA 名称）",
+		MsgBadTime:         "无法解析时间 %q（可用 now、15:04、2006-01-02 15:04）",
+		MsgRatesFailed:     "获取汇率失败: %v",
+		MsgRatesStale:      "使用 %s 缓存的汇率（刷新失败: %v）",
+		MsgUnknownCurrency: "未知货币 %q",
+		MsgRatesFrom:       "汇率更新于 %s",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help calc 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	args := os.Args[1:]
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprintln(os.Stderr, T(MsgUsage))
+		os.Exit(2)
+	}
+	out, err := calculate(strings.Join(args, " "), time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, T(MsgError, err))
+		os.Exit(1)
+	}
+	fmt.Println(out)
+}
+
+// conversionPattern "<左侧> to|in|as|=> <目标>"，取最后一个连接词切分
+var conversionPattern = regexp.MustCompile(`^(.+)\s+(?:to|in|as|into|=>|->|转|换成)\s+(\S+(?:\s\S+)?)$`)
+
+// quantityPattern "<表达式> <单位或货币>"，单位为最后一个字段
+var quantityPattern = regexp.MustCompile(`^(.*?)\s*([^\s\d().+*/^-][^\s]*)$`)
+
+// calculate 按 时区 → 货币 → 单位 → 纯数学 的顺序识别并计算
+func calculate(input string, now time.Time) (string, error) {
+	input = strings.TrimSpace(input)
+	f := detectNumberFormat()
+
+	if m := conversionPattern.FindStringSubmatch(input); m != nil {
+		left, right := strings.TrimSpace(m[1]), strings.TrimSpace(m[2])
+		if looksLikeTime(left) {
+			from, to, err := convertTime(left, right, now)
+			if err != nil {
+				return "", err
+			}
+			return formatZones(from, to), nil
+		}
+		if q := quantityPattern.FindStringSubmatch(left); q != nil && q[1] != "" {
+			value, err := evalExpr(q[1])
+			if err != nil {
+				return "", err
+			}
+			if looksLikeCurrency(q[2]) && looksLikeCurrency(right) {
+				result, updated, err := convertCurrency(value, q[2], right)
+				if err != nil {
+					return "", err
+				}
+				fmt.Fprintln(os.Stderr, T(MsgRatesFrom, updated.Format(time.DateTime)))
+				code := strings.ToUpper(right)
+				if alias, ok := currencyAliases[right]; ok {
+					code = alias
+				}
+				return f.formatMoney(result, code), nil
+			}
+			result, err := convertUnit(value, q[2], right)
+			if err != nil {
+				return "", err
+			}
+			return f.format(result) + " " + right, nil
+		}
+	}
+
+	v, err := evalExpr(input)
+	if err != nil {
+		return "", err
+	}
+	return f.format(v), nil
+}
+
+// formatZones "2026-10-14 15:00 CST (Asia/Shanghai) → 2026-10-14 03:00 EDT (America/New_York)"
+func formatZones(from, to time.Time) string {
+	format := func(t time.Time) string {
+		abbr, _ := t.Zone()
+		return fmt.Sprintf("%s %s (%s)", t.Format("2006-01-02 15:04 Mon"), abbr, t.Location())
+	}
+	return format(from) + " → " + format(to)
+}
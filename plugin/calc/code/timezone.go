@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// zoneAliases 常用城市 / 缩写到 IANA 时区的映射（缩写按最常见的含义，CST 视为中国标准时间）
+var zoneAliases = map[string]string{
+	"utc": "UTC", "gmt": "UTC", "z": "UTC",
+	"cst": "Asia/Shanghai", "beijing": "Asia/Shanghai", "shanghai": "Asia/Shanghai", "北京": "Asia/Shanghai", "上海": "Asia/Shanghai",
+	"hongkong": "Asia/Hong_Kong", "香港": "Asia/Hong_Kong", "taipei": "Asia/Taipei", "台北": "Asia/Taipei",
+	"jst": "Asia/Tokyo", "tokyo": "Asia/Tokyo", "东京": "Asia/Tokyo",
+	"kst": "Asia/Seoul", "seoul": "Asia/Seoul", "首尔": "Asia/Seoul",
+	"singapore": "Asia/Singapore", "新加坡": "Asia/Singapore",
+	"ist": "Asia/Kolkata", "india": "Asia/Kolkata",
+	"london": "Europe/London", "伦敦": "Europe/London", "bst": "Europe/London",
+	"paris": "Europe/Paris", "巴黎": "Europe/Paris", "berlin": "Europe/Berlin", "柏林": "Europe/Berlin", "cet": "Europe/Berlin",
+	"moscow": "Europe/Moscow", "莫斯科": "Europe/Moscow",
+	"est": "America/New_York", "edt": "America/New_York", "et": "America/New_York",
+	"nyc": "America/New_York", "newyork": "America/New_York", "纽约": "America/New_York",
+	"pst": "America/Los_Angeles", "pdt": "America/Los_Angeles", "pt": "America/Los_Angeles",
+	"sf": "America/Los_Angeles", "la": "America/Los_Angeles", "seattle": "America/Los_Angeles",
+	"旧金山": "America/Los_Angeles", "洛杉矶": "America/Los_Angeles",
+	"chicago": "America/Chicago", "芝加哥": "America/Chicago",
+	"sydney": "Australia/Sydney", "悉尼": "Australia/Sydney",
+	"local": "Local", "here": "Local", "本地": "Local",
+}
+
+// loadZone 解析时区：别名、IANA 名称，或仅给出城市部分（New_York → America/New_York）
+func loadZone(name string) (*time.Location, error) {
+	key := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name))
+	if alias, ok := zoneAliases[key]; ok {
+		name = alias
+	}
+	if strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc, nil
+	}
+	for _, region := range []string{"America", "Europe", "Asia", "Africa", "Australia", "Pacific", "Atlantic", "Indian"} {
+		if loc, err := time.LoadLocation(region + "/" + strings.ReplaceAll(name, " ", "_")); err == nil {
+			return loc, nil
+		}
+	}
+	return nil, fmt.Errorf("%s", T(MsgUnknownZone, name))
+}
+
+// timeLayouts 支持的时间写法
+var timeLayouts = []string{
+	"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02",
+	"15:04:05", "15:04", "3:04pm", "3pm", "3:04PM", "3PM",
+}
+
+// parseClock 在 loc 时区中解析时间；只给出时刻时取该时区的今天
+func parseClock(raw string, loc *time.Location, now time.Time) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.EqualFold(raw, "now") || raw == "现在" {
+		return now.In(loc), nil
+	}
+	for _, layout := range timeLayouts {
+		t, err := time.ParseInLocation(layout, raw, loc)
+		if err != nil {
+			continue
+		}
+		if !strings.HasPrefix(layout, "2006") {
+			today := now.In(loc)
+			t = time.Date(today.Year(), today.Month(), today.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%s", T(MsgBadTime, raw))
+}
+
+// looksLikeTime 左侧是否为时间表达式（now / 含冒号的时刻 / 日期 / 3pm）
+func looksLikeTime(s string) bool {
+	first := strings.ToLower(strings.Fields(s + " x")[0])
+	if first == "now" || first == "现在" {
+		return true
+	}
+	if len(first) >= 3 && (strings.HasSuffix(first, "am") || strings.HasSuffix(first, "pm")) && isDigit(first[0]) {
+		return true
+	}
+	if strings.Count(first, ":") >= 1 && isDigit(first[0]) {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", first)
+	return err == nil
+}
+
+// convertTime "15:00 Asia/Shanghai to New_York"：源时区可省略（默认本地）
+func convertTime(left, right string, now time.Time) (time.Time, time.Time, error) {
+	target, err := loadZone(strings.TrimSpace(right))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	fields := strings.Fields(left)
+	src := time.Local
+	clock := left
+	// 最后一个字段能解析为时区时视为源时区
+	if len(fields) > 1 || (len(fields) == 1 && !looksLikeTime(fields[0])) {
+		last := fields[len(fields)-1]
+		if loc, err := loadZone(last); err == nil && !looksLikeTime(last) {
+			src = loc
+			clock = strings.Join(fields[:len(fields)-1], " ")
+		}
+	}
+	t, err := parseClock(clock, src, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return t, t.In(target), nil
+}
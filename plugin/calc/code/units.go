@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Unit 计量单位：value × Factor + Offset 得到该维度的基准单位
+type Unit struct {
+	Dim    string // 维度：length、mass、time、data、area、volume、speed、temperature、energy、pressure、angle
+	Factor float64
+	Offset float64
+}
+
+// units 单位表（名称均为小写，查找时不区分大小写，数据单位区分 b / B 见 lookupUnit）
+var units = map[string]Unit{}
+
+func define(dim string, factor float64, names ...string) {
+	for _, n := range names {
+		units[n] = Unit{Dim: dim, Factor: factor}
+	}
+}
+
+func init() {
+	// 长度（米）
+	define("length", 1e-9, "nm")
+	define("length", 1e-6, "um", "μm")
+	define("length", 1e-3, "mm")
+	define("length", 1e-2, "cm")
+	define("length", 1, "m", "meter", "meters", "米")
+	define("length", 1e3, "km", "kilometer", "kilometers", "公里", "千米")
+	define("length", 0.0254, "in", "inch", "inches")
+	define("length", 0.3048, "ft", "foot", "feet")
+	define("length", 0.9144, "yd", "yard", "yards")
+	define("length", 1609.344, "mi", "mile", "miles", "英里")
+	define("length", 1852, "nmi", "海里")
+	define("length", 1.0/3, "尺")
+	define("length", 500, "里")
+	// 质量（千克）
+	define("mass", 1e-6, "mg")
+	define("mass", 1e-3, "g", "gram", "grams", "克")
+	define("mass", 1, "kg", "kilogram", "kilograms", "公斤", "千克")
+	define("mass", 1e3, "t", "tonne", "tonnes", "吨")
+	define("mass", 0.45359237, "lb", "lbs", "pound", "pounds", "磅")
+	define("mass", 0.028349523125, "oz", "ounce", "ounces", "盎司")
+	define("mass", 0.5, "斤")
+	define("mass", 0.05, "两")
+	// 时间（秒）
+	define("time", 1e-9, "ns")
+	define("time", 1e-6, "us", "μs")
+	define("time", 1e-3, "ms")
+	define("time", 1, "s", "sec", "secs", "second", "seconds", "秒")
+	define("time", 60, "min", "mins", "minute", "minutes", "分钟")
+	define("time", 3600, "h", "hr", "hrs", "hour", "hours", "小时")
+	define("time", 86400, "d", "day", "days", "天")
+	define("time", 7*86400, "wk", "week", "weeks", "周")
+	define("time", 365.2425*86400, "yr", "year", "years", "年")
+	// 数据（字节）：十进制 KB/MB/GB 与二进制 KiB/MiB/GiB 区分
+	define("data", 1.0/8, "bit", "bits")
+	define("data", 1, "byte", "bytes", "字节")
+	for i, prefix := range []string{"K", "M", "G", "T", "P"} {
+		dec, bin := 1.0, 1.0
+		for j := 0; j <= i; j++ {
+			dec *= 1000
+			bin *= 1024
+		}
+		lower := strings.ToLower(prefix)
+		// 小写的 kb / mb 习惯上指字节；区分大小写的 Kb / Mb 指比特
+		define("data", dec, prefix+"B", lower+"B", lower+"b")
+		define("data", bin, prefix+"iB", lower+"ib")
+		define("data", dec/8, prefix+"b", prefix+"bit", lower+"bit", prefix+"bps", lower+"bps")
+	}
+	// 面积（平方米）
+	define("area", 1e-4, "cm2")
+	define("area", 1, "m2", "㎡", "平方米")
+	define("area", 1e4, "ha", "hectare", "公顷")
+	define("area", 1e6, "km2")
+	define("area", 4046.8564224, "acre", "acres")
+	define("area", 0.09290304, "ft2", "sqft")
+	define("area", 10000.0/15, "亩")
+	// 体积（升）
+	define("volume", 1e-3, "ml", "毫升")
+	define("volume", 1, "l", "liter", "liters", "litre", "升")
+	define("volume", 1e3, "m3")
+	define("volume", 3.785411784, "gal", "gallon", "gallons")
+	define("volume", 0.946352946, "qt", "quart")
+	define("volume", 0.473176473, "pt", "pint")
+	define("volume", 0.2365882365, "cup", "cups")
+	define("volume", 0.0295735295625, "floz")
+	// 速度（米/秒）
+	define("speed", 1, "m/s", "mps")
+	define("speed", 1000.0/3600, "km/h", "kmh", "kph")
+	define("speed", 1609.344/3600, "mph")
+	define("speed", 1852.0/3600, "kn", "knot", "knots")
+	// 能量（焦耳）
+	define("energy", 1, "j", "joule", "joules")
+	define("energy", 1e3, "kj")
+	define("energy", 4.184, "cal")
+	define("energy", 4184, "kcal", "大卡")
+	define("energy", 3.6e6, "kwh", "度")
+	// 压强（帕）
+	define("pressure", 1, "pa")
+	define("pressure", 1e3, "kpa")
+	define("pressure", 1e5, "bar")
+	define("pressure", 101325, "atm")
+	define("pressure", 6894.757293168, "psi")
+	// 角度（弧度）
+	define("angle", 1, "rad", "radian", "radians")
+	define("angle", 3.141592653589793/180, "deg", "degree", "degrees", "°")
+	// 温度（开尔文）
+	units["k"] = Unit{Dim: "temperature", Factor: 1}
+	units["kelvin"] = units["k"]
+	units["c"] = Unit{Dim: "temperature", Factor: 1, Offset: 273.15}
+	units["celsius"] = units["c"]
+	units["°c"] = units["c"]
+	units["℃"] = units["c"]
+	units["摄氏度"] = units["c"]
+	units["f"] = Unit{Dim: "temperature", Factor: 5.0 / 9, Offset: 273.15 - 32*5.0/9}
+	units["fahrenheit"] = units["f"]
+	units["°f"] = units["f"]
+	units["℉"] = units["f"]
+}
+
+// lookupUnit 先按原样查找（区分 MB / Mb 这类数据单位），再按小写查找
+func lookupUnit(name string) (Unit, bool) {
+	if u, ok := units[name]; ok {
+		return u, true
+	}
+	u, ok := units[strings.ToLower(name)]
+	return u, ok
+}
+
+// convertUnit 单位换算
+func convertUnit(value float64, from, to string) (float64, error) {
+	f, ok := lookupUnit(from)
+	if !ok {
+		return 0, fmt.Errorf("%s", T(MsgUnknownUnit, from))
+	}
+	t, ok := lookupUnit(to)
+	if !ok {
+		return 0, fmt.Errorf("%s", T(MsgUnknownUnit, to))
+	}
+	if f.Dim != t.Dim {
+		return 0, fmt.Errorf("%s", T(MsgIncompatible, from, f.Dim, to, t.Dim))
+	}
+	base := value*f.Factor + f.Offset
+	return (base - t.Offset) / t.Factor, nil
+}
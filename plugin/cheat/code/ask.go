@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"wcp_jdata"
+)
+
+// askPrompt 按 tldr 页面格式生成速查表
+func askPrompt(command, platform, lang string) string {
+	return fmt.Sprintf(`Write a tldr-pages style cheatsheet for the command-line tool %q on %s.
+Use exactly this Markdown layout and nothing else:
+
+# %s
+
+> One-line description.
+
+- Description of an example:
+
+`+"`command --flag {{argument}}`"+`
+
+Give 6 to 8 of the most useful examples. Write the descriptions in language %q.
+If you do not know the tool, say so in the description line instead of inventing flags.
+`, command, platform, command, lang)
+}
+
+// generate 通过 agent ask 生成速查表并保存到 generated/ 目录
+func generate(root, name, platform, lang string) (string, error) {
+	bin, err := jdata.AgentPath()
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command(bin, "ask")
+	cmd.Stdin = strings.NewReader(askPrompt(strings.ReplaceAll(name, "-", " "), platform, lang))
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	content := strings.TrimSpace(string(out)) + "\n"
+	path := filepath.Join(root, generatedDir, name+".md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(os.Stderr, T(MsgGenerated, path))
+	return content, nil
+}
@@ -0,0 +1,6 @@
+package main
+
+const (
+	// CheatDir 速查表根目录（位于数据目录下）
+	CheatDir = "cheat"
+)
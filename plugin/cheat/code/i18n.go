@@ -0,0 +1,41 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage       = "usage"
+	MsgError       = "error"
+	MsgNeedCommand = "need_command"
+	MsgNotFound    = "not_found"
+	MsgStaleCache  = "stale_cache"
+	MsgGenerated   = "generated"
+	MsgNoSheets    = "no_sheets"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:       "usage: cheat [--ask] [--refresh] [--platform p] [--lang l] <command> | cheat --list",
+		MsgError:       "error: %v",
+		MsgNeedCommand: "missing command name",
+		MsgNotFound:    "no cheatsheet for %q (try --ask to generate one)",
+		MsgStaleCache:  "cannot refresh %s, showing cached copy: %v",
+		MsgGenerated:   "generated by the LLM and saved to %s — double-check before relying on it",
+		MsgNoSheets:    "no cheatsheets yet",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:       "用法: cheat [--ask] [--refresh] [--platform 平台] [--lang 语言] <命令> | cheat --list",
+		MsgError:       "错误: %v",
+		MsgNeedCommand: "缺少命令名",
+		MsgNotFound:    "没有 %q 的速查表（可加 --ask 让 LLM 生成）",
+		MsgStaleCache:  "无法更新 %s，显示缓存内容: %v",
+		MsgGenerated:   "由 LLM 生成并保存到 %s，使用前请核对",
+		MsgNoSheets:    "还没有任何速查表",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
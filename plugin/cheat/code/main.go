@@ -0,0 +1,110 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"wcp_jdata"
+)
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help cheat 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// run cheat [--ask] [--refresh] [--platform p] [--lang l] <command...>
+//
+// 查找顺序：sheets/（用户编写）→ tldr-pages（缓存 30 天）→ generated/（此前 --ask 生成）→ --ask 现场生成
+func run(args []string) error {
+	fs := flag.NewFlagSet("cheat", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, T(MsgUsage)); fs.PrintDefaults() }
+	ask := fs.Bool("ask", false, "generate a cheatsheet with the LLM when none exists")
+	refresh := fs.Bool("refresh", false, "re-download the tldr page even if cached")
+	platform := fs.String("platform", defaultPlatform(), "tldr platform: common, linux, osx, windows")
+	lang := fs.String("lang", "", "page language, e.g. en, zh (defaults to the UI language)")
+	list := fs.Bool("list", false, "list locally available cheatsheets")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	root, err := cheatRoot()
+	if err != nil {
+		return err
+	}
+	if *list {
+		names, err := listSheets(root)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Println(T(MsgNoSheets))
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	}
+	if fs.NArg() == 0 {
+		return errors.New(T(MsgNeedCommand))
+	}
+	name := sheetName(fs.Args())
+	pageLang := *lang
+	if pageLang == "" {
+		pageLang = "en"
+		if jdata.CurrentLocale() == jdata.LocaleZhCN {
+			pageLang = "zh"
+		}
+	}
+
+	if content, ok, err := readLocal(filepath.Join(root, sheetsDir, name+".md")); err != nil || ok {
+		return show(content, err)
+	}
+	content, ok, err := lookupTldr(root, name, *platform, pageLang, *refresh)
+	if ok {
+		return show(content, nil)
+	}
+	// 网络不可用时仍可回退到已生成的速查表
+	if generated, found, gerr := readLocal(filepath.Join(root, generatedDir, name+".md")); gerr == nil && found && !*refresh {
+		return show(generated, nil)
+	}
+	if *ask {
+		return show(generate(root, name, *platform, pageLang))
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%s", T(MsgNotFound, name))
+}
+
+func show(content string, err error) error {
+	if err != nil {
+		return err
+	}
+	return renderMarkdown(content)
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
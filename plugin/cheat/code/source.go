@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+const (
+	// tldrBase tldr-pages 原始文件地址，可用 TLDR_BASE_URL 指向镜像
+	tldrBase = "https://raw.githubusercontent.com/tldr-pages/tldr/main"
+	// tldrBaseEnv 覆盖 tldr-pages 地址的环境变量
+	tldrBaseEnv = "TLDR_BASE_URL"
+	// cacheTTL 缓存有效期，过期后联网刷新，刷新失败时继续使用旧缓存
+	cacheTTL = 30 * 24 * time.Hour
+)
+
+// 速查表目录（位于 ~/.jdata/cheat/ 下）
+const (
+	sheetsDir    = "sheets"    // 用户自己编写的速查表，优先级最高
+	generatedDir = "generated" // --ask 生成的速查表
+	cacheDir     = "cache"     // tldr-pages 缓存: cache/<lang>/<platform>/<command>.md
+)
+
+// cheatRoot 速查表根目录，其下为 sheets/、generated/ 与 cache/
+func cheatRoot() (string, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, CheatDir), nil
+}
+
+// sheetName 规范化命令名：tldr 以小写、短横线连接多级子命令（git checkout → git-checkout）
+func sheetName(args []string) string {
+	return strings.ToLower(strings.Join(args, "-"))
+}
+
+// defaultPlatform tldr 的平台目录名
+func defaultPlatform() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "osx"
+	case "windows":
+		return "windows"
+	default:
+		return "linux"
+	}
+}
+
+// pagesDir tldr 的语言目录：英文为 pages，其余为 pages.<lang>
+func pagesDir(lang string) string {
+	if lang == "" || lang == "en" {
+		return "pages"
+	}
+	return "pages." + lang
+}
+
+// readLocal 读取已存在的文件，不存在时返回 ok=false
+func readLocal(path string) (string, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+// tldrPage 一个候选 tldr 页面
+type tldrPage struct {
+	lang, platform string
+}
+
+func (p tldrPage) cachePath(root, name string) string {
+	return filepath.Join(root, cacheDir, p.lang, p.platform, name+".md")
+}
+
+// lookupTldr 候选页面依次为 <lang>/<platform>、<lang>/common，语言版本缺失时回退英文。
+// 先查未过期的缓存；再联网下载并写入缓存；网络不可用时退回任意旧缓存
+func lookupTldr(root, name, platform, lang string, refresh bool) (string, bool, error) {
+	langs := []string{lang}
+	if lang != "en" {
+		langs = append(langs, "en")
+	}
+	var pages []tldrPage
+	for _, l := range langs {
+		for _, p := range []string{platform, "common"} {
+			pages = append(pages, tldrPage{l, p})
+		}
+	}
+
+	if !refresh {
+		for _, p := range pages {
+			if info, err := os.Stat(p.cachePath(root, name)); err == nil && time.Since(info.ModTime()) < cacheTTL {
+				return readLocal(p.cachePath(root, name))
+			}
+		}
+	}
+
+	base := strings.TrimRight(firstNonEmpty(os.Getenv(tldrBaseEnv), tldrBase), "/")
+	var fetchErr error
+	for _, p := range pages {
+		content, found, err := fetch(fmt.Sprintf("%s/%s/%s/%s.md", base, pagesDir(p.lang), p.platform, name))
+		if err != nil {
+			if fetchErr == nil {
+				fetchErr = err
+			}
+			continue
+		}
+		if !found {
+			continue
+		}
+		path := p.cachePath(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return "", false, err
+		}
+		return content, true, os.WriteFile(path, []byte(content), 0o644)
+	}
+
+	if fetchErr != nil {
+		for _, p := range pages {
+			if content, ok, err := readLocal(p.cachePath(root, name)); err != nil || ok {
+				fmt.Fprintln(os.Stderr, T(MsgStaleCache, name, fetchErr))
+				return content, ok, err
+			}
+		}
+	}
+	return "", false, fetchErr
+}
+
+// fetch 下载页面，404 返回 found=false
+func fetch(url string) (string, bool, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	return string(data), true, err
+}
+
+// listSheets 列出本地可用的速查表名称（用户、生成、缓存），去重排序
+func listSheets(root string) ([]string, error) {
+	seen := map[string]bool{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == root {
+			return fs.SkipAll
+		}
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(path) == ".md" {
+			seen[strings.TrimSuffix(d.Name(), ".md")] = true
+		}
+		return nil
+	})
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, err
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"wcp_jdata"
+)
+
+// askAbout 把剪贴板记录作为上下文交给 agent ask，回答直接输出到 stdout
+func askAbout(entry, question string) error {
+	bin, err := jdata.AgentPath()
+	if err != nil {
+		return err
+	}
+	prompt := fmt.Sprintf("%s\n\n<clipboard>\n%s\n</clipboard>\n", question, entry)
+	return askAgent(bin, prompt, printRaw)
+}
+
+// printRaw 原样输出收齐的回答
+func printRaw(answer string) error {
+	_, err := os.Stdout.WriteString(answer)
+	return err
+}
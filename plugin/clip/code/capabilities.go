@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 插件握手：以唯一的参数 --capabilities 启动插件，插件在 stdout 输出一行 JSON
+// {"name": ..., "protocol": 1, "features": [...]}；不认识该参数的旧版插件按协议 0（不支持任何特性）处理
+const (
+	capabilitiesFlag    = "--capabilities"
+	capabilitiesTimeout = 2 * time.Second
+	featureStreaming    = "streaming"
+	featureCancellation = "cancellation"
+)
+
+// capabilities 插件的握手应答
+type capabilities struct {
+	Name     string   `json:"name"`
+	Protocol int      `json:"protocol"`
+	Features []string `json:"features"`
+}
+
+func (c capabilities) has(feature string) bool {
+	return slices.Contains(c.Features, feature)
+}
+
+// probe 与插件握手，失败（旧版插件、超时、输出无法解析）时返回零值
+func probe(bin string) capabilities {
+	ctx, cancel := context.WithTimeout(context.Background(), capabilitiesTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, bin, capabilitiesFlag).Output()
+	var c capabilities
+	if err != nil || json.Unmarshal(out, &c) != nil {
+		return capabilities{}
+	}
+	return c
+}
+
+// askAgent 以 prompt 调用 agent ask 并按握手结果调整：支持 streaming 时回答边生成边输出，
+// 否则收齐后交给 show 一次输出；支持 cancellation 时 Ctrl-C 交给 agent 处理
+// （输出已收到的部分后以 130 退出，本进程随之以 130 退出），否则直接结束 agent
+func askAgent(bin, prompt string, show func(string) error) error {
+	caps := probe(bin)
+	cmd := exec.Command(bin, "ask")
+	cmd.Stdin = strings.NewReader(prompt)
+	cmd.Stderr = os.Stderr
+	var buf bytes.Buffer
+	if caps.has(featureStreaming) {
+		cmd.Stdout = os.Stdout
+	} else {
+		cmd.Stdout = &buf
+	}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	var err error
+	select {
+	case err = <-done:
+	case <-interrupt:
+		if !caps.has(featureCancellation) {
+			_ = cmd.Process.Kill()
+		}
+		err = <-done
+		var exit *exec.ExitError
+		if errors.As(err, &exit) || err == nil {
+			// 中断提示已由 agent 输出
+			os.Exit(130)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if buf.Len() > 0 {
+		return show(buf.String())
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// tool 剪贴板读写命令
+type tool struct {
+	read, write []string
+}
+
+// tools 各平台的剪贴板工具，按顺序选用第一个可用的
+var tools = []tool{
+	{[]string{"pbpaste"}, []string{"pbcopy"}},
+	{[]string{"wl-paste", "--no-newline"}, []string{"wl-copy"}},
+	{[]string{"xclip", "-selection", "clipboard", "-o"}, []string{"xclip", "-selection", "clipboard"}},
+	{[]string{"xsel", "--clipboard", "--output"}, []string{"xsel", "--clipboard", "--input"}},
+	{[]string{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard"}, []string{"clip.exe"}},
+}
+
+// findTool 选择当前平台可用的剪贴板工具
+func findTool() (tool, error) {
+	for _, t := range tools {
+		if t.read[0] == "pbpaste" && runtime.GOOS != "darwin" {
+			continue
+		}
+		if _, err := exec.LookPath(t.read[0]); err == nil {
+			return t, nil
+		}
+	}
+	return tool{}, errors.New(T(MsgNoClipboard))
+}
+
+// readClipboard 读取当前剪贴板文本
+func readClipboard(t tool) (string, error) {
+	out, err := exec.Command(t.read[0], t.read[1:]...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// writeClipboard 写入剪贴板
+func writeClipboard(t tool, text string) error {
+	cmd := exec.Command(t.write[0], t.write[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
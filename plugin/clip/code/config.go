@@ -0,0 +1,8 @@
+package main
+
+const (
+	// ClipDir 剪贴板历史目录（位于数据目录下）
+	ClipDir = "clip"
+	// SettingClipMax setting 段中剪贴板历史条数上限
+	SettingClipMax = "clip_max"
+)
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxEntryBytes 超过该大小的剪贴板内容不记录（通常是误复制的大文件）
+const maxEntryBytes = 1 << 20
+
+// runDaemon clip daemon [--interval 1s]：轮询剪贴板，内容变化时记录。
+// 需要用户显式启动（可放进 launchd / systemd --user / 登录脚本）
+func runDaemon(args []string) error {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	interval := fs.Duration("interval", time.Second, "polling interval")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	t, err := findTool()
+	if err != nil {
+		return err
+	}
+	history, err := historyPath()
+	if err != nil {
+		return err
+	}
+	pid, err := pidPath()
+	if err != nil {
+		return err
+	}
+	if err := acquirePid(pid); err != nil {
+		return err
+	}
+	defer os.Remove(pid)
+
+	fmt.Fprintln(os.Stderr, T(MsgDaemonStarted, *interval, history))
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	// 启动时的剪贴板内容视为已知，不重复记录
+	last, _ := readClipboard(t)
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+		text, err := readClipboard(t)
+		if err != nil || text == last {
+			continue
+		}
+		last = text
+		if strings.TrimSpace(text) == "" || len(text) > maxEntryBytes {
+			continue
+		}
+		if err := record(text); err != nil {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+	}
+}
+
+// acquirePid 写入 pid 文件，已有存活的守护进程时报错
+func acquirePid(path string) error {
+	if data, err := os.ReadFile(path); err == nil {
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && alive(pid) {
+			return fmt.Errorf("%s", T(MsgDaemonRunning, pid))
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())), 0o600)
+}
+
+// alive 通过 0 号信号探测进程是否存在
+func alive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return p.Signal(syscall.Signal(0)) == nil
+}
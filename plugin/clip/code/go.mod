@@ -0,0 +1,9 @@
+module wcp_clip
+
+go 1.25.4
+
+require wcp_jdata v0.0.0
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+
+replace wcp_jdata => ../../jdata
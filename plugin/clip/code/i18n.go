@@ -0,0 +1,77 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage          = "usage"
+	MsgUsageCommand   = "usage_command"
+	MsgUnknownCommand = "unknown_command"
+	MsgError          = "error"
+	MsgDaemonSummary  = "daemon_summary"
+	MsgListSummary    = "list_summary"
+	MsgSearchSummary  = "search_summary"
+	MsgShowSummary    = "show_summary"
+	MsgCopySummary    = "copy_summary"
+	MsgAskSummary     = "ask_summary"
+	MsgClearSummary   = "clear_summary"
+	MsgNoClipboard    = "no_clipboard"
+	MsgDaemonStarted  = "daemon_started"
+	MsgDaemonRunning  = "daemon_running"
+	MsgNoEntries      = "no_entries"
+	MsgBadIndex       = "bad_index"
+	MsgCopied         = "copied"
+	MsgCleared        = "cleared"
+	MsgNeedQuestion   = "need_question"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:          "usage: clip <command> [args]",
+		MsgUsageCommand:   "  %-8s %s",
+		MsgUnknownCommand: "unknown command: %s",
+		MsgError:          "error: %v",
+		MsgDaemonSummary:  "watch the clipboard and record new entries (opt-in, runs in foreground)",
+		MsgListSummary:    "list recent entries",
+		MsgSearchSummary:  "search entries",
+		MsgShowSummary:    "print an entry",
+		MsgCopySummary:    "copy an entry back to the clipboard",
+		MsgAskSummary:     "ask about an entry: clip ask <n> \"question\"",
+		MsgClearSummary:   "delete all recorded entries",
+		MsgNoClipboard:    "no clipboard tool found (pbcopy/pbpaste, wl-copy/wl-paste, xclip, xsel, powershell)",
+		MsgDaemonStarted:  "recording clipboard every %v to %s (Ctrl-C to stop)",
+		MsgDaemonRunning:  "another clip daemon is already running (pid %d)",
+		MsgNoEntries:      "no clipboard entries recorded (start one with: clip daemon)",
+		MsgBadIndex:       "no entry #%s",
+		MsgCopied:         "copied entry #%d",
+		MsgCleared:        "cleared %d entries",
+		MsgNeedQuestion:   "missing question",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:          "用法: clip <命令> [参数]",
+		MsgUsageCommand:   "  %-8s %s",
+		MsgUnknownCommand: "未知命令: %s",
+		MsgError:          "错误: %v",
+		MsgDaemonSummary:  "监听剪贴板并记录新内容（需手动启动，前台运行）",
+		MsgListSummary:    "列出最近的记录",
+		MsgSearchSummary:  "搜索记录",
+		MsgShowSummary:    "输出一条记录",
+		MsgCopySummary:    "把记录重新复制到剪贴板",
+		MsgAskSummary:     "针对某条记录提问: clip ask <编号> \"问题\"",
+		MsgClearSummary:   "清空所有记录",
+		MsgNoClipboard:    "未找到剪贴板工具（pbcopy/pbpaste、wl-copy/wl-paste、xclip、xsel、powershell）",
+		MsgDaemonStarted:  "每 %v 记录一次剪贴板到 %s（Ctrl-C 停止）",
+		MsgDaemonRunning:  "已有 clip 守护进程在运行（pid %d）",
+		MsgNoEntries:      "还没有剪贴板记录（先运行 clip daemon）",
+		MsgBadIndex:       "没有编号为 %s 的记录",
+		MsgCopied:         "已复制记录 #%d",
+		MsgCleared:        "已清空 %d 条记录",
+		MsgNeedQuestion:   "缺少问题",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// command 子命令：run 返回的错误统一由 main 输出到 stderr 并以非零码退出
+type command struct {
+	run     func(args []string) error
+	summary string // 用法说明中的一句话介绍（i18n key）
+}
+
+// commands 子命令注册表
+var commands = map[string]command{
+	"ask":    {runAsk, MsgAskSummary},
+	"clear":  {runClear, MsgClearSummary},
+	"copy":   {runCopy, MsgCopySummary},
+	"daemon": {runDaemon, MsgDaemonSummary},
+	"list":   {runList, MsgListSummary},
+	"search": {runSearch, MsgSearchSummary},
+	"show":   {runShow, MsgShowSummary},
+}
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help clip 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	args := os.Args[1:]
+	name := "list"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintln(os.Stderr, T(MsgUnknownCommand, name))
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(args); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// usage 输出命令列表到 stderr
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, T(MsgUsage))
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, T(MsgUsageCommand, name, T(commands[name].summary)))
+	}
+}
+
+// newestFirst 读取记录并按新到旧排列，编号 1 为最新
+func newestFirst() ([]Entry, error) {
+	entries, err := loadEntries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New(T(MsgNoEntries))
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// pick 按编号取记录，未给出编号时取最新一条
+func pick(args []string) (int, Entry, error) {
+	entries, err := newestFirst()
+	if err != nil {
+		return 0, Entry{}, err
+	}
+	if len(args) == 0 {
+		return 1, entries[0], nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil || n < 1 || n > len(entries) {
+		return 0, Entry{}, fmt.Errorf("%s", T(MsgBadIndex, args[0]))
+	}
+	return n, entries[n-1], nil
+}
+
+// runList clip [list] [-n 20]
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	limit := fs.Int("n", 20, "number of entries to show (0 = all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	entries, err := newestFirst()
+	if err != nil {
+		return err
+	}
+	for i, e := range entries {
+		if *limit > 0 && i >= *limit {
+			break
+		}
+		printLine(i+1, e)
+	}
+	return nil
+}
+
+// runSearch clip search <关键字>：编号与 list 一致，可直接用于 copy / show
+func runSearch(args []string) error {
+	query := strings.ToLower(strings.Join(args, " "))
+	entries, err := newestFirst()
+	if err != nil {
+		return err
+	}
+	for i, e := range entries {
+		if strings.Contains(strings.ToLower(e.Text), query) {
+			printLine(i+1, e)
+		}
+	}
+	return nil
+}
+
+// runShow clip show [n]：输出完整内容，便于管道传给其他命令（如 clip show 3 | agent ask）
+func runShow(args []string) error {
+	_, e, err := pick(args)
+	if err != nil {
+		return err
+	}
+	fmt.Println(e.Text)
+	return nil
+}
+
+// runCopy clip copy [n]
+func runCopy(args []string) error {
+	n, e, err := pick(args)
+	if err != nil {
+		return err
+	}
+	t, err := findTool()
+	if err != nil {
+		return err
+	}
+	if err := writeClipboard(t, e.Text); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, T(MsgCopied, n))
+	return nil
+}
+
+// runAsk clip ask [n] "问题"：以记录内容为上下文提问
+func runAsk(args []string) error {
+	var idx []string
+	if len(args) > 0 {
+		if _, err := strconv.Atoi(strings.TrimPrefix(args[0], "#")); err == nil {
+			idx, args = args[:1], args[1:]
+		}
+	}
+	question := strings.TrimSpace(strings.Join(args, " "))
+	if question == "" {
+		return errors.New(T(MsgNeedQuestion))
+	}
+	_, e, err := pick(idx)
+	if err != nil {
+		return err
+	}
+	return askAbout(e.Text, question)
+}
+
+// runClear clip clear
+func runClear(args []string) error {
+	entries, err := loadEntries()
+	if err != nil {
+		return err
+	}
+	path, err := historyPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	fmt.Println(T(MsgCleared, len(entries)))
+	return nil
+}
+
+// printLine 单行预览：编号  时间  内容（换行折叠，最多 60 个字符）
+func printLine(n int, e Entry) {
+	preview := strings.Join(strings.Fields(e.Text), " ")
+	if r := []rune(preview); len(r) > 60 {
+		preview = string(r[:60]) + "…"
+	}
+	fmt.Printf("%3d  %s  %s\n", n, e.Time.Format(time.DateTime), preview)
+}
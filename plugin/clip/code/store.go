@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"wcp_jdata"
+)
+
+// DefaultMaxEntries 未配置 setting.clip_max 时保留的记录条数
+const DefaultMaxEntries = 500
+
+// Entry 一条剪贴板记录
+type Entry struct {
+	Time time.Time `json:"time"`
+	Hash string    `json:"hash"` // 内容摘要，用于去重
+	Text string    `json:"text"`
+}
+
+func historyPath() (string, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ClipDir, "history.jsonl"), nil
+}
+
+func pidPath() (string, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ClipDir, "daemon.pid"), nil
+}
+
+func maxEntries() int {
+	if n, err := strconv.Atoi(jdata.Setting(SettingClipMax)); err == nil && n > 0 {
+		return n
+	}
+	return DefaultMaxEntries
+}
+
+func hashOf(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:8])
+}
+
+// loadEntries 读取全部记录（由旧到新）
+func loadEntries() ([]Entry, error) {
+	path, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// saveEntries 整体写回（去重后截断到上限时使用），权限 0600：剪贴板可能含敏感内容
+func saveEntries(entries []Entry) error {
+	path, err := historyPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(line)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// record 记录新内容：已存在的相同内容移到最新位置，超出上限时丢弃最旧的
+func record(text string) error {
+	entries, err := loadEntries()
+	if err != nil {
+		return err
+	}
+	e := Entry{Time: time.Now(), Hash: hashOf(text), Text: text}
+	kept := entries[:0]
+	for _, old := range entries {
+		if old.Hash != e.Hash {
+			kept = append(kept, old)
+		}
+	}
+	kept = append(kept, e)
+	if max := maxEntries(); len(kept) > max {
+		kept = kept[len(kept)-max:]
+	}
+	return saveEntries(kept)
+}
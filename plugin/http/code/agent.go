@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"wcp_jdata"
+)
+
+// askAbout 把响应作为上下文交给 agent ask，回答直接输出到 stdout
+func askAbout(question string, resp *Response) error {
+	bin, err := jdata.AgentPath()
+	if err != nil {
+		return err
+	}
+	body := string(resp.Body)
+	if len(body) > maxAskBytes {
+		body = body[:maxAskBytes] + "\n…(truncated)"
+	}
+	prompt := fmt.Sprintf("%s\n\n<http_response status=%q content_type=%q>\n%s\n</http_response>\n",
+		question, resp.Status, resp.ContentType, body)
+	return askAgent(bin, prompt, renderMarkdown)
+}
+
+// maxAskBytes 交给 LLM 的响应体上限，避免超出上下文窗口
+const maxAskBytes = 100 * 1024
@@ -0,0 +1,6 @@
+package main
+
+const (
+	// HTTPDir 已保存请求的目录（位于数据目录下）
+	HTTPDir = "http"
+)
@@ -0,0 +1,15 @@
+module wcp_http
+
+go 1.26.0
+
+require (
+	golang.org/x/term v0.46.0
+	wcp_jdata v0.0.0
+)
+
+require (
+	golang.org/x/sys v0.48.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace wcp_jdata => ../../jdata
@@ -0,0 +1,47 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage        = "usage"
+	MsgError        = "error"
+	MsgNeedURL      = "need_url"
+	MsgBadHeader    = "bad_header"
+	MsgSaved        = "saved"
+	MsgRemoved      = "removed"
+	MsgNotFound     = "not_found"
+	MsgNoSaved      = "no_saved"
+	MsgStatusFailed = "status_failed"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:        "usage: http [flags] [METHOD] URL | http run <name> [flags] | http saved | http rm <name>",
+		MsgError:        "error: %v",
+		MsgNeedURL:      "missing URL",
+		MsgBadHeader:    "invalid header %q (expected \"Name: value\")",
+		MsgSaved:        "saved request %q",
+		MsgRemoved:      "removed request %q",
+		MsgNotFound:     "no saved request named %q",
+		MsgNoSaved:      "no saved requests",
+		MsgStatusFailed: "request failed with %s",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:        "用法: http [参数] [方法] URL | http run <名称> [参数] | http saved | http rm <名称>",
+		MsgError:        "错误: %v",
+		MsgNeedURL:      "缺少 URL",
+		MsgBadHeader:    "请求头格式错误 %q（应为 \"Name: value\"）",
+		MsgSaved:        "已保存请求 %q",
+		MsgRemoved:      "已删除请求 %q",
+		MsgNotFound:     "没有名为 %q 的已保存请求",
+		MsgNoSaved:      "还没有保存任何请求",
+		MsgStatusFailed: "请求失败: %s",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
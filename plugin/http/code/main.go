@@ -0,0 +1,126 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help http 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// run 分发: http saved | http rm <name> | http run <name> [flags] | http [flags] [METHOD] URL
+func run(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "saved":
+			return runSaved()
+		case "rm":
+			if len(args) < 2 {
+				return errors.New(T(MsgUsage))
+			}
+			return runRm(args[1])
+		case "run":
+			if len(args) < 2 {
+				return errors.New(T(MsgUsage))
+			}
+			req, err := findSaved(args[1])
+			if err != nil {
+				return err
+			}
+			return request(req, args[2:])
+		}
+	}
+	return request(Request{}, args)
+}
+
+// request 解析参数（覆盖 base 中的字段）后发送并输出
+func request(base Request, args []string) error {
+	fs := flag.NewFlagSet("http", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, T(MsgUsage)); fs.PrintDefaults() }
+	var headers headerList
+	fs.Var(&headers, "H", "request header \"Name: value\" (repeatable)")
+	data := fs.String("d", "", "request body; @file reads a file, @- reads stdin")
+	include := fs.Bool("i", false, "print status line and response headers to stderr")
+	raw := fs.Bool("raw", false, "print the body as-is without pretty-printing")
+	save := fs.String("save", "", "save this request under a name for `http run`")
+	ask := fs.String("ask", "", "hand the response to agent ask with this question")
+	timeout := fs.Duration("timeout", 30*time.Second, "request timeout")
+	rest, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+
+	req := base
+	if len(rest) > 0 && methods[strings.ToUpper(rest[0])] {
+		req.Method, rest = strings.ToUpper(rest[0]), rest[1:]
+	}
+	if len(rest) > 0 {
+		req.URL = rest[0]
+	}
+	if req.URL == "" {
+		return errors.New(T(MsgNeedURL))
+	}
+	req.Headers = append(req.Headers, headers...)
+	if *data != "" {
+		req.Body = *data
+	}
+	if *save != "" {
+		if err := saveRequest(*save, req); err != nil {
+			return err
+		}
+	}
+
+	resp, err := send(req, *timeout)
+	if err != nil {
+		return err
+	}
+	if *ask != "" {
+		if *include {
+			printHeaders(os.Stderr, resp)
+		}
+		return askAbout(*ask, resp)
+	}
+	if err := printResponse(resp, *include, *raw); err != nil {
+		return err
+	}
+	if resp.Code >= 400 {
+		return fmt.Errorf("%s", T(MsgStatusFailed, resp.Status))
+	}
+	return nil
+}
+
+// parseInterleaved 允许参数与位置参数交替出现（http POST url -d '{}'）
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// printResponse 状态行和响应头输出到 stderr（-i 时），响应体按类型美化后输出到 stdout；
+// 非终端或 --raw 时原样输出响应体，便于管道处理
+func printResponse(resp *Response, includeHeaders, raw bool) error {
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	if includeHeaders {
+		printHeaders(os.Stderr, resp)
+	}
+	if raw || !tty {
+		_, err := os.Stdout.Write(resp.Body)
+		return err
+	}
+	lang := bodyLang(resp)
+	body := resp.Body
+	if lang == "json" {
+		var buf bytes.Buffer
+		if json.Indent(&buf, body, "", "  ") == nil {
+			body = buf.Bytes()
+		}
+	}
+	if lang == "" {
+		_, err := os.Stdout.Write(body)
+		return err
+	}
+	return renderMarkdown("```" + lang + "\n" + strings.TrimRight(string(body), "\n") + "\n```\n")
+}
+
+// bodyLang 推断用于语法高亮的语言，无法识别时返回空串（原样输出）
+func bodyLang(resp *Response) string {
+	ct := resp.ContentType
+	switch {
+	case ct == "application/json" || strings.HasSuffix(ct, "+json"):
+		return "json"
+	case ct == "text/html":
+		return "html"
+	case ct == "application/xml" || ct == "text/xml" || strings.HasSuffix(ct, "+xml"):
+		return "xml"
+	case ct == "application/javascript" || ct == "text/javascript":
+		return "javascript"
+	case ct == "text/css":
+		return "css"
+	case ct == "application/yaml" || ct == "text/yaml":
+		return "yaml"
+	}
+	// 未声明类型但内容是 JSON 时同样美化
+	if trimmed := bytes.TrimSpace(resp.Body); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return "json"
+	}
+	return ""
+}
+
+// printHeaders 输出状态行、耗时和按名称排序的响应头
+func printHeaders(w io.Writer, resp *Response) {
+	fmt.Fprintf(w, "%s %s  (%d ms, %d bytes)\n", resp.Proto, resp.Status, resp.Elapsed.Milliseconds(), len(resp.Body))
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range resp.Header[name] {
+			fmt.Fprintf(w, "%s: %s\n", name, v)
+		}
+	}
+	fmt.Fprintln(w)
+}
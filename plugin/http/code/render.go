@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+	"wcp_jdata"
+)
+
+// renderMarkdown 终端中通过 md_render 渲染；非终端输出或未找到渲染引擎时原样输出
+func renderMarkdown(content string) error {
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		if bin, err := jdata.MdRenderPath(); err == nil {
+			cmd := exec.Command(bin)
+			cmd.Stdin = strings.NewReader(content)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		}
+	}
+	_, err := os.Stdout.WriteString(content)
+	return err
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// methods 可作为第一个位置参数的 HTTP 方法
+var methods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true,
+	"DELETE": true, "HEAD": true, "OPTIONS": true,
+}
+
+// Request 可保存 / 重放的请求
+type Request struct {
+	Method  string   `json:"method"`
+	URL     string   `json:"url"`
+	Headers []string `json:"headers,omitempty"` // "Name: value"
+	Body    string   `json:"body,omitempty"`
+}
+
+// Response 插件关心的响应字段
+type Response struct {
+	Status      string
+	Code        int
+	Proto       string
+	Header      http.Header
+	ContentType string // 不含参数的 MIME 类型
+	Body        []byte
+	Elapsed     time.Duration
+}
+
+// send 发送请求；Body 以 @ 开头时从文件读取（@- 为 stdin），有 body 且未指定方法时默认 POST
+func send(req Request, timeout time.Duration) (*Response, error) {
+	body, err := loadBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = http.MethodGet
+		if body != nil {
+			method = http.MethodPost
+		}
+	}
+	url := withScheme(req.URL)
+	httpReq, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil && json.Valid(body) {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("User-Agent", "j-cli-http")
+	for _, h := range req.Headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%s", T(MsgBadHeader, h))
+		}
+		httpReq.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	start := time.Now()
+	resp, err := (&http.Client{Timeout: timeout}).Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return &Response{
+		Status:      resp.Status,
+		Code:        resp.StatusCode,
+		Proto:       resp.Proto,
+		Header:      resp.Header,
+		ContentType: mediaType,
+		Body:        data,
+		Elapsed:     time.Since(start),
+	}, nil
+}
+
+// loadBody 解析 -d 参数
+func loadBody(raw string) ([]byte, error) {
+	switch {
+	case raw == "":
+		return nil, nil
+	case raw == "@-":
+		return io.ReadAll(os.Stdin)
+	case strings.HasPrefix(raw, "@"):
+		return os.ReadFile(raw[1:])
+	}
+	return []byte(raw), nil
+}
+
+// headerList 可重复的 -H 参数
+type headerList []string
+
+func (h *headerList) String() string { return strings.Join(*h, ", ") }
+
+func (h *headerList) Set(v string) error {
+	if !strings.Contains(v, ":") {
+		return errors.New(T(MsgBadHeader, v))
+	}
+	*h = append(*h, v)
+	return nil
+}
+
+// withScheme 省略协议时本机地址默认 http，其余默认 https；":8080/path" 视为 localhost
+func withScheme(url string) string {
+	if strings.Contains(url, "://") {
+		return url
+	}
+	if strings.HasPrefix(url, ":") {
+		url = "localhost" + url
+	}
+	for _, local := range []string{"localhost", "127.", "[::1]"} {
+		if strings.HasPrefix(url, local) {
+			return "http://" + url
+		}
+	}
+	return "https://" + url
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"wcp_jdata"
+)
+
+func savedPath() (string, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, HTTPDir, "requests.json"), nil
+}
+
+// loadSaved 读取已保存的请求（名称 → 请求）
+func loadSaved() (map[string]Request, error) {
+	path, err := savedPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]Request{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	saved := map[string]Request{}
+	return saved, json.Unmarshal(data, &saved)
+}
+
+// storeSaved 写回，权限 0600：请求头里可能带有 Token
+func storeSaved(saved map[string]Request) error {
+	path, err := savedPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func saveRequest(name string, req Request) error {
+	saved, err := loadSaved()
+	if err != nil {
+		return err
+	}
+	saved[name] = req
+	if err := storeSaved(saved); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, T(MsgSaved, name))
+	return nil
+}
+
+func findSaved(name string) (Request, error) {
+	saved, err := loadSaved()
+	if err != nil {
+		return Request{}, err
+	}
+	req, ok := saved[name]
+	if !ok {
+		return Request{}, fmt.Errorf("%s", T(MsgNotFound, name))
+	}
+	return req, nil
+}
+
+// runSaved http saved：列出已保存的请求
+func runSaved() error {
+	saved, err := loadSaved()
+	if err != nil {
+		return err
+	}
+	if len(saved) == 0 {
+		fmt.Println(T(MsgNoSaved))
+		return nil
+	}
+	names := make([]string, 0, len(saved))
+	for name := range saved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		req := saved[name]
+		method := req.Method
+		if method == "" {
+			method = "GET"
+		}
+		fmt.Printf("%-16s %-6s %s\n", name, method, req.URL)
+	}
+	return nil
+}
+
+// runRm http rm <name>
+func runRm(name string) error {
+	saved, err := loadSaved()
+	if err != nil {
+		return err
+	}
+	if _, ok := saved[name]; !ok {
+		return fmt.Errorf("%s", T(MsgNotFound, name))
+	}
+	delete(saved, name)
+	if err := storeSaved(saved); err != nil {
+		return err
+	}
+	fmt.Println(T(MsgRemoved, name))
+	return nil
+}
@@ -0,0 +1,43 @@
+package jdata
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const (
+	// BinDir 插件二进制目录（位于数据根目录下，各 profile 共用），与 md_render 的释放位置一致
+	BinDir = "bin"
+	// AgentBinary agent 插件的可执行文件名
+	AgentBinary = "agent"
+	// MdRenderBinary md_render 渲染引擎的可执行文件名
+	MdRenderBinary = "md_render"
+)
+
+// PluginPath 查找插件可执行文件：优先 ~/.jdata/bin/<name>（j 主程序释放的位置），其次 PATH
+func PluginPath(name string) (string, error) {
+	local := filepath.Join(RootDir(), BinDir, name)
+	if _, err := os.Stat(local); err == nil {
+		return local, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	return exec.LookPath(name)
+}
+
+// MdRenderPath 查找 md_render 渲染引擎（见 PluginPath）
+func MdRenderPath() (string, error) {
+	return PluginPath(MdRenderBinary)
+}
+
+// AgentPath 查找 agent 插件（见 PluginPath）；找不到时的错误按界面语言说明查找过的位置
+func AgentPath() (string, error) {
+	path, err := PluginPath(AgentBinary)
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", errors.New(messages.T(MsgAgentNotFound, filepath.Join(RootDir(), BinDir, AgentBinary)))
+	}
+	return path, err
+}
@@ -0,0 +1,5 @@
+module wcp_jdata
+
+go 1.25.1
+
+require gopkg.in/yaml.v3 v3.0.1
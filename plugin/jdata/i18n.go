@@ -0,0 +1,91 @@
+package jdata
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Locale 界面语言标识
+type Locale string
+
+const (
+	LocaleEN   Locale = "en"    // 英文
+	LocaleZhCN Locale = "zh-CN" // 简体中文
+
+	// DefaultLocale 未能识别任何语言设置时使用的默认语言
+	DefaultLocale = LocaleZhCN
+
+	// LangEnv 显式指定界面语言的环境变量（优先级高于配置文件和 LANG）
+	LangEnv = "J_LANG"
+	// SettingLang setting 段中的界面语言配置项
+	SettingLang = "lang"
+)
+
+// Bundles 各语言的消息模板，格式化参数遵循 fmt 语法；每个插件定义自己的一份，经 T 取当前语言的文案
+type Bundles map[Locale]map[string]string
+
+var (
+	locale     Locale
+	localeOnce sync.Once
+)
+
+// CurrentLocale 进程内生效的语言，第一次调用时由 DetectLocale 决定
+func CurrentLocale() Locale {
+	localeOnce.Do(func() { locale = DetectLocale() })
+	return locale
+}
+
+// SetLocale 指定进程内生效的语言（测试按固定语言比对输出时使用）
+func SetLocale(l Locale) {
+	localeOnce.Do(func() {})
+	locale = l
+}
+
+// DetectLocale 按优先级确定界面语言：
+// J_LANG > config.yaml 的 setting.lang > LC_ALL > LC_MESSAGES > LANG
+func DetectLocale() Locale {
+	if v := os.Getenv(LangEnv); v != "" {
+		return ParseLocale(v)
+	}
+	if v := Setting(SettingLang); v != "" {
+		return ParseLocale(v)
+	}
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); v != "" && v != "C" && v != "POSIX" {
+			return ParseLocale(v)
+		}
+	}
+	return DefaultLocale
+}
+
+// ParseLocale 将 "zh_CN.UTF-8"、"en-US" 这类写法归一化为已支持的语言
+func ParseLocale(raw string) Locale {
+	tag := strings.ToLower(raw)
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if strings.HasPrefix(tag, "zh") {
+		return LocaleZhCN
+	}
+	if strings.HasPrefix(tag, "en") {
+		return LocaleEN
+	}
+	return DefaultLocale
+}
+
+// T 获取当前语言下的文案，缺失时回退到默认语言，再缺失则原样返回 key
+func (b Bundles) T(key string, args ...any) string {
+	format, ok := b[CurrentLocale()][key]
+	if !ok {
+		format, ok = b[DefaultLocale][key]
+	}
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,133 @@
+// Package jdata 定位 j 的数据根目录与当前 profile 的数据目录、读取 config.yaml 的 setting 段并决定界面语言；
+// 规则与 Rust 主程序（src/config/profile.rs）保持一致，各插件共用这一份实现。
+package jdata
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DataPathEnv 数据目录环境变量，与 j 主程序保持一致
+	DataPathEnv = "J_DATA_PATH"
+	// DirName 默认数据目录名（位于用户主目录下）
+	DirName = ".jdata"
+	// ProfileEnv 选择 profile 的环境变量，与 j 主程序保持一致（j --profile 同样通过它传给插件）
+	ProfileEnv = "J_PROFILE"
+	// ProfilesDir 非默认 profile 的存放目录（位于数据根目录下）
+	ProfilesDir = "profiles"
+	// ProfileFile 记录当前 profile 的文件（位于数据根目录下）
+	ProfileFile = "profile"
+	// DefaultProfile 默认 profile，数据直接存放在数据根目录
+	DefaultProfile = "default"
+	// ConfigFile j 主程序的配置文件名
+	ConfigFile = "config.yaml"
+)
+
+// RootDir 获取数据根目录: 优先 J_DATA_PATH，否则 ~/.jdata/（插件二进制等各 profile 共用的内容放在这里）
+func RootDir() string {
+	if path := os.Getenv(DataPathEnv); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return DirName
+	}
+	return filepath.Join(home, DirName)
+}
+
+// ProfileError 当前 profile 不可用：名称不合法或 profile 不存在，与 j 主程序启动时的检查一致；
+// Error 按界面语言输出（错误在输出时才翻译，决定界面语言本身也会读取 profile）
+type ProfileError struct {
+	Name    string // 选择的 profile 名称
+	Source  string // 名称的来源：J_PROFILE 或 profile 文件的路径
+	Missing bool   // true 为名称合法但 profile 不存在，false 为名称不合法
+}
+
+func (e *ProfileError) Error() string {
+	if e.Missing {
+		return messages.T(MsgProfileMissing, e.Name, e.Source, e.Name)
+	}
+	return messages.T(MsgProfileInvalid, e.Name, e.Source)
+}
+
+// Profile 当前 profile 的名称：优先 J_PROFILE，否则数据根目录下 profile 文件记录的名称，都没有时为 default；
+// 名称不合法或 profile 不存在时返回 *ProfileError，名称不合法时返回的名称为 default，
+// 不会把不合法的名称拼进路径
+func Profile() (string, error) {
+	name := strings.TrimSpace(os.Getenv(ProfileEnv))
+	source := "--profile / " + ProfileEnv
+	if name == "" {
+		path := filepath.Join(RootDir(), ProfileFile)
+		data, _ := os.ReadFile(path)
+		name, source = strings.TrimSpace(string(data)), path
+	}
+	if name == "" || name == DefaultProfile {
+		return DefaultProfile, nil
+	}
+	if !ValidProfile(name) {
+		return DefaultProfile, &ProfileError{Name: name, Source: source}
+	}
+	if info, err := os.Stat(filepath.Join(RootDir(), ProfilesDir, name)); err != nil || !info.IsDir() {
+		return name, &ProfileError{Name: name, Source: source, Missing: true}
+	}
+	return name, nil
+}
+
+// ValidProfile profile 名称只能包含字母、数字、- 与 _，最长 64 个字符
+func ValidProfile(name string) bool {
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return len(name) <= 64
+}
+
+// ProfileDir profile 的数据目录：默认 profile 为数据根目录本身，其他 profile 为 profiles/<name>/
+func ProfileDir(name string) string {
+	if name == DefaultProfile {
+		return RootDir()
+	}
+	return filepath.Join(RootDir(), ProfilesDir, name)
+}
+
+// DataDir 获取当前 profile 的数据目录（见 ProfileDir）。名称不合法或 profile 不存在时返回 *ProfileError，
+// 不会改用默认 profile 的数据
+func DataDir() (string, error) {
+	name, err := Profile()
+	if err != nil {
+		return "", err
+	}
+	return ProfileDir(name), nil
+}
+
+// mainConfig 只解析插件关心的配置段，其余字段忽略
+type mainConfig struct {
+	Setting map[string]string `yaml:"setting"`
+}
+
+// Setting 读取 config.yaml 中 setting 段的某个配置项，读取失败时返回空串
+func Setting(key string) string {
+	return Settings()[key]
+}
+
+// Settings 读取当前 profile 的 config.yaml 中的 setting 段，profile 不可用或读取失败时返回 nil
+func Settings() map[string]string {
+	dir, err := DataDir()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ConfigFile))
+	if err != nil {
+		return nil
+	}
+	var cfg mainConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil
+	}
+	return cfg.Setting
+}
@@ -0,0 +1,21 @@
+package jdata
+
+// 本包自身输出的文案，各插件共用
+const (
+	MsgAgentNotFound  = "agent_not_found"
+	MsgProfileInvalid = "profile_invalid"
+	MsgProfileMissing = "profile_missing"
+)
+
+var messages = Bundles{
+	LocaleEN: {
+		MsgAgentNotFound:  "agent plugin not found (looked in %s and PATH)",
+		MsgProfileInvalid: "invalid profile name %q (from %s): only letters, digits, - and _ are allowed",
+		MsgProfileMissing: "profile %s does not exist (from %s), run j profile create %s first",
+	},
+	LocaleZhCN: {
+		MsgAgentNotFound:  "未找到 agent 插件（已查找 %s 与 PATH）",
+		MsgProfileInvalid: "profile 名称 %q 不合法（来自 %s），只能包含字母、数字、- 与 _",
+		MsgProfileMissing: "profile %s 不存在（来自 %s），先执行 j profile create %s",
+	},
+}
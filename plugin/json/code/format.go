@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ANSI 颜色，配色与 jq 默认保持接近
+const (
+	colorReset  = "\033[0m"
+	colorKey    = "\033[34;1m" // 键名：亮蓝
+	colorString = "\033[32m"   // 字符串：绿
+	colorNumber = "\033[36m"   // 数字：青
+	colorBool   = "\033[33m"   // 布尔：黄
+	colorNull   = "\033[90m"   // null：灰
+)
+
+// formatter JSON 输出选项
+type formatter struct {
+	indent string // 为空表示紧凑输出
+	color  bool
+}
+
+func (f formatter) paint(color, s string) string {
+	if !f.color {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// format 序列化一个值
+func (f formatter) format(v any) string {
+	var b strings.Builder
+	f.write(&b, v, 0)
+	return b.String()
+}
+
+func (f formatter) newline(b *strings.Builder, depth int) {
+	if f.indent == "" {
+		return
+	}
+	b.WriteByte('\n')
+	b.WriteString(strings.Repeat(f.indent, depth))
+}
+
+func (f formatter) write(b *strings.Builder, v any, depth int) {
+	switch t := v.(type) {
+	case nil:
+		b.WriteString(f.paint(colorNull, "null"))
+	case bool:
+		s := "false"
+		if t {
+			s = "true"
+		}
+		b.WriteString(f.paint(colorBool, s))
+	case json.Number:
+		b.WriteString(f.paint(colorNumber, t.String()))
+	case string:
+		b.WriteString(f.paint(colorString, quote(t)))
+	case []any:
+		if len(t) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteByte('[')
+		for i, item := range t {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			f.newline(b, depth+1)
+			f.write(b, item, depth+1)
+		}
+		f.newline(b, depth)
+		b.WriteByte(']')
+	case *Object:
+		if len(t.Keys) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteByte('{')
+		for i, k := range t.Keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			f.newline(b, depth+1)
+			b.WriteString(f.paint(colorKey, quote(k)))
+			b.WriteByte(':')
+			if f.indent != "" {
+				b.WriteByte(' ')
+			}
+			f.write(b, t.Values[k], depth+1)
+		}
+		f.newline(b, depth)
+		b.WriteByte('}')
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage        = "usage"
+	MsgError        = "error"
+	MsgParse        = "parse"
+	MsgQuerySyntax  = "query_syntax"
+	MsgNotIndexable = "not_indexable"
+	MsgNotIterable  = "not_iterable"
+	MsgUnknownFunc  = "unknown_func"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:        "usage: json [-r] [-c] [-C|-M] [query] [file...]   e.g. some-cmd | json '.items[0].name'",
+		MsgError:        "error: %v",
+		MsgParse:        "invalid JSON in %s: %v",
+		MsgQuerySyntax:  "invalid query at %q: %s",
+		MsgNotIndexable: "cannot index %s with %q",
+		MsgNotIterable:  "cannot iterate over %s",
+		MsgUnknownFunc:  "unknown function %q (supported: keys, length, type, values)",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:        "用法: json [-r] [-c] [-C|-M] [查询] [文件...]   例如 some-cmd | json '.items[0].name'",
+		MsgError:        "错误: %v",
+		MsgParse:        "%s 中的 JSON 无效: %v",
+		MsgQuerySyntax:  "查询语法错误（%q 处）: %s",
+		MsgNotIndexable: "无法对 %s 使用 %q 取值",
+		MsgNotIterable:  "无法遍历 %s",
+		MsgUnknownFunc:  "未知函数 %q（支持 keys、length、type、values）",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
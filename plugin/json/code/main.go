@@ -0,0 +1,134 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help json 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// run json [-r] [-c] [-C|-M] [--indent N] [query] [file...]
+//
+// 输入可以是单个 JSON 值，也可以是 JSON Lines（如 agent audit show --json 的输出），
+// 查询对每个值分别执行
+func run(args []string) error {
+	fs := flag.NewFlagSet("json", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, T(MsgUsage)); fs.PrintDefaults() }
+	raw := fs.Bool("r", false, "print strings without quotes")
+	compact := fs.Bool("c", false, "compact output, one value per line")
+	forceColor := fs.Bool("C", false, "always colorize")
+	noColor := fs.Bool("M", false, "never colorize")
+	indent := fs.Int("indent", 2, "spaces per indentation level")
+	files, err := parseInterleaved(fs, args)
+	if err != nil {
+		return err
+	}
+
+	query := "."
+	if len(files) > 0 && looksLikeQuery(files[0]) {
+		query, files = files[0], files[1:]
+	}
+	steps, err := compile(query)
+	if err != nil {
+		return err
+	}
+
+	f := formatter{indent: strings.Repeat(" ", *indent), color: term.IsTerminal(int(os.Stdout.Fd()))}
+	if *compact {
+		f.indent = ""
+	}
+	if *forceColor {
+		f.color = true
+	}
+	if *noColor || os.Getenv("NO_COLOR") != "" && !*forceColor {
+		f.color = false
+	}
+
+	if len(files) == 0 {
+		return process("stdin", os.Stdin, steps, f, *raw)
+	}
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = process(name, file, steps, f, *raw)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process 解析一个输入源并输出查询结果
+func process(name string, r io.Reader, steps []step, f formatter, raw bool) error {
+	values, err := decodeAll(r)
+	for _, v := range values {
+		out, qerr := evaluate(steps, v)
+		if qerr != nil {
+			return qerr
+		}
+		for _, o := range out {
+			if s, ok := o.(string); ok && raw {
+				fmt.Println(s)
+				continue
+			}
+			fmt.Println(f.format(o))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s", T(MsgParse, name, err))
+	}
+	return nil
+}
+
+// looksLikeQuery 第一个位置参数以 . 开头（且不是已存在的文件）或为内置函数时视为查询
+func looksLikeQuery(s string) bool {
+	if strings.HasPrefix(s, ".") {
+		_, err := os.Stat(s)
+		return err != nil || s == "."
+	}
+	name := strings.TrimSpace(strings.SplitN(s, "|", 2)[0])
+	_, err := function(name)
+	return err == nil
+}
+
+// parseInterleaved 允许参数与位置参数交替出现（json .a -r）
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// step 查询中的一步，对每个输入值产出零个或多个输出值
+type step func(v any) ([]any, error)
+
+// compile 解析 jq 风格的路径查询：
+//
+//	.            原样输出
+//	.a.b  .["a b"]  .a?   对象取值（? 在类型不符时静默跳过）
+//	.[0]  .[-1]  .[1:3]    数组下标 / 负下标 / 切片
+//	.[]                    遍历数组元素或对象的值
+//	a | keys               管道与内置函数 keys、values、length、type
+func compile(query string) ([]step, error) {
+	var steps []step
+	for _, stage := range splitPipes(query) {
+		stage = strings.TrimSpace(stage)
+		if stage == "" || stage == "." {
+			continue
+		}
+		if !strings.HasPrefix(stage, ".") {
+			fn, err := function(stage)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, fn)
+			continue
+		}
+		path, err := parsePath(stage)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, path...)
+	}
+	return steps, nil
+}
+
+// splitPipes 按不在引号内的 | 切分
+func splitPipes(query string) []string {
+	var parts []string
+	inQuote, start := false, 0
+	for i := 0; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case '"':
+			inQuote = !inQuote
+		case '|':
+			if !inQuote {
+				parts = append(parts, query[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, query[start:])
+}
+
+// parsePath 解析以 . 开头的路径
+func parsePath(s string) ([]step, error) {
+	var steps []step
+	i := 0
+	for i < len(s) {
+		switch {
+		case s[i] == '.':
+			i++
+			if i < len(s) && isIdentStart(s[i]) {
+				j := i
+				for j < len(s) && isIdent(s[j]) {
+					j++
+				}
+				key := s[i:j]
+				i = j
+				optional := i < len(s) && s[i] == '?'
+				if optional {
+					i++
+				}
+				steps = append(steps, field(key, optional))
+			}
+		case s[i] == '[':
+			end := closing(s, i)
+			if end < 0 {
+				return nil, fmt.Errorf("%s", T(MsgQuerySyntax, s[i:], "missing ]"))
+			}
+			st, err := bracket(s[i+1 : end])
+			if err != nil {
+				return nil, fmt.Errorf("%s", T(MsgQuerySyntax, s[i:end+1], err.Error()))
+			}
+			steps = append(steps, st)
+			i = end + 1
+		default:
+			return nil, fmt.Errorf("%s", T(MsgQuerySyntax, s[i:], "unexpected character"))
+		}
+	}
+	return steps, nil
+}
+
+// closing 查找与 s[open] 匹配的 ]，跳过引号中的内容
+func closing(s string, open int) int {
+	inQuote := false
+	for i := open + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			inQuote = !inQuote
+		case ']':
+			if !inQuote {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// bracket 解析 [] 中的内容："key"、下标、切片或空（遍历）
+func bracket(inner string) (step, error) {
+	inner = strings.TrimSpace(inner)
+	switch {
+	case inner == "":
+		return iterate, nil
+	case strings.HasPrefix(inner, `"`):
+		key, err := strconv.Unquote(inner)
+		if err != nil {
+			return nil, err
+		}
+		return field(key, false), nil
+	case strings.Contains(inner, ":"):
+		lo, hi, _ := strings.Cut(inner, ":")
+		return slice(strings.TrimSpace(lo), strings.TrimSpace(hi))
+	}
+	n, err := strconv.Atoi(inner)
+	if err != nil {
+		return nil, err
+	}
+	return index(n), nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || c >= 0x80
+}
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || ('0' <= c && c <= '9') || c == '-'
+}
+
+// field 对象取值；null 取值结果为 null（与 jq 一致）
+func field(key string, optional bool) step {
+	return func(v any) ([]any, error) {
+		switch t := v.(type) {
+		case nil:
+			return []any{nil}, nil
+		case *Object:
+			return []any{t.Values[key]}, nil
+		}
+		if optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s", T(MsgNotIndexable, typeName(v), key))
+	}
+}
+
+// index 数组下标，负数从末尾计数，越界为 null
+func index(n int) step {
+	return func(v any) ([]any, error) {
+		switch t := v.(type) {
+		case nil:
+			return []any{nil}, nil
+		case []any:
+			if n < 0 {
+				n += len(t)
+			}
+			if n < 0 || n >= len(t) {
+				return []any{nil}, nil
+			}
+			return []any{t[n]}, nil
+		}
+		return nil, fmt.Errorf("%s", T(MsgNotIndexable, typeName(v), strconv.Itoa(n)))
+	}
+}
+
+// slice 数组 / 字符串切片 [lo:hi]，边界可省略或为负
+func slice(loRaw, hiRaw string) (step, error) {
+	parse := func(s string, def int) (int, bool, error) {
+		if s == "" {
+			return def, false, nil
+		}
+		n, err := strconv.Atoi(s)
+		return n, true, err
+	}
+	lo, _, err := parse(loRaw, 0)
+	if err != nil {
+		return nil, err
+	}
+	hi, hiSet, err := parse(hiRaw, 0)
+	if err != nil {
+		return nil, err
+	}
+	bounds := func(length int) (int, int) {
+		l, h := lo, length
+		if hiSet {
+			h = hi
+		}
+		if l < 0 {
+			l += length
+		}
+		if h < 0 {
+			h += length
+		}
+		l = max(0, min(l, length))
+		h = max(l, min(h, length))
+		return l, h
+	}
+	return func(v any) ([]any, error) {
+		switch t := v.(type) {
+		case nil:
+			return []any{nil}, nil
+		case []any:
+			l, h := bounds(len(t))
+			return []any{t[l:h]}, nil
+		case string:
+			r := []rune(t)
+			l, h := bounds(len(r))
+			return []any{string(r[l:h])}, nil
+		}
+		return nil, fmt.Errorf("%s", T(MsgNotIndexable, typeName(v), loRaw+":"+hiRaw))
+	}, nil
+}
+
+// iterate .[] 展开数组元素或对象的值
+func iterate(v any) ([]any, error) {
+	switch t := v.(type) {
+	case []any:
+		return t, nil
+	case *Object:
+		out := make([]any, len(t.Keys))
+		for i, k := range t.Keys {
+			out[i] = t.Values[k]
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("%s", T(MsgNotIterable, typeName(v)))
+}
+
+// function 内置函数
+func function(name string) (step, error) {
+	switch name {
+	case "keys":
+		return func(v any) ([]any, error) {
+			obj, ok := v.(*Object)
+			if arr, isArr := v.([]any); isArr {
+				out := make([]any, len(arr))
+				for i := range arr {
+					out[i] = json.Number(strconv.Itoa(i))
+				}
+				return []any{out}, nil
+			}
+			if !ok {
+				return nil, fmt.Errorf("%s", T(MsgNotIterable, typeName(v)))
+			}
+			keys := append([]string(nil), obj.Keys...)
+			sort.Strings(keys)
+			out := make([]any, len(keys))
+			for i, k := range keys {
+				out[i] = k
+			}
+			return []any{out}, nil
+		}, nil
+	case "values":
+		return func(v any) ([]any, error) {
+			vals, err := iterate(v)
+			return []any{vals}, err
+		}, nil
+	case "length":
+		return func(v any) ([]any, error) {
+			n := 0
+			switch t := v.(type) {
+			case nil:
+			case string:
+				n = len([]rune(t))
+			case []any:
+				n = len(t)
+			case *Object:
+				n = len(t.Keys)
+			case json.Number:
+				return []any{t}, nil
+			default:
+				return nil, fmt.Errorf("%s", T(MsgNotIterable, typeName(v)))
+			}
+			return []any{json.Number(strconv.Itoa(n))}, nil
+		}, nil
+	case "type":
+		return func(v any) ([]any, error) { return []any{typeName(v)}, nil }, nil
+	}
+	return nil, fmt.Errorf("%s", T(MsgUnknownFunc, name))
+}
+
+// evaluate 依次执行每一步
+func evaluate(steps []step, input any) ([]any, error) {
+	values := []any{input}
+	for _, st := range steps {
+		var next []any
+		for _, v := range values {
+			out, err := st(v)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, out...)
+		}
+		values = next
+	}
+	return values, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Object 保持键顺序的 JSON 对象（标准库 map 会打乱顺序）
+type Object struct {
+	Keys   []string
+	Values map[string]any
+}
+
+// Value 的取值：nil、bool、json.Number、string、[]any、*Object
+
+// decodeValue 从 Decoder 读取一个完整的 JSON 值
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			obj := &Object{Values: map[string]any{}}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key := keyTok.(string)
+				v, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				if _, dup := obj.Values[key]; !dup {
+					obj.Keys = append(obj.Keys, key)
+				}
+				obj.Values[key] = v
+			}
+			_, err := dec.Token()
+			return obj, err
+		case '[':
+			arr := []any{}
+			for dec.More() {
+				v, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, v)
+			}
+			_, err := dec.Token()
+			return arr, err
+		}
+		return nil, fmt.Errorf("unexpected %v", t)
+	default:
+		return t, nil
+	}
+}
+
+// decodeAll 读取输入中的全部 JSON 值（支持 JSON Lines / 连续拼接的多个值）
+func decodeAll(r io.Reader) ([]any, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var values []any
+	for {
+		v, err := decodeValue(dec)
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+	}
+}
+
+// typeName jq 风格的类型名
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case *Object:
+		return "object"
+	}
+	return "unknown"
+}
+
+// quote 按 JSON 规则转义字符串（不转义 HTML 字符，保持终端可读）
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return string(bytes.TrimRight(buf.Bytes(), "\n"))
+}
@@ -0,0 +1,10 @@
+package main
+
+const (
+	// JumpDir 目录访问记录所在目录（位于数据目录下）
+	JumpDir = "jump"
+	// SettingJumpExclude setting 段中不记录的目录（逗号分隔的 glob，如 /tmp/*,~/Downloads）
+	SettingJumpExclude = "jump_exclude"
+	// SettingJumpMax setting 段中排名总和的上限
+	SettingJumpMax = "jump_max"
+)
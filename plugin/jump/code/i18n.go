@@ -0,0 +1,71 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage           = "usage"
+	MsgUsageCommand    = "usage_command"
+	MsgUnknownCommand  = "unknown_command"
+	MsgError           = "error"
+	MsgAddSummary      = "add_summary"
+	MsgQuerySummary    = "query_summary"
+	MsgListSummary     = "list_summary"
+	MsgRmSummary       = "rm_summary"
+	MsgInitSummary     = "init_summary"
+	MsgNeedKeywords    = "need_keywords"
+	MsgNeedDir         = "need_dir"
+	MsgNoMatch         = "no_match"
+	MsgNoEntries       = "no_entries"
+	MsgNotRecorded     = "not_recorded"
+	MsgRemoved         = "removed"
+	MsgInitUsage       = "init_usage"
+	MsgInitUnsupported = "init_unsupported"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:           "usage: jump <command> [args]",
+		MsgUsageCommand:    "  %-6s %s",
+		MsgUnknownCommand:  "unknown command: %s",
+		MsgError:           "error: %v",
+		MsgAddSummary:      "record a visit to a directory (called by the shell hook)",
+		MsgQuerySummary:    "print the highest-ranked directory matching the keywords (-l lists all matches)",
+		MsgListSummary:     "list recorded directories by frecency",
+		MsgRmSummary:       "forget a directory",
+		MsgInitSummary:     "print the opt-in shell hook and the j cd wrapper: eval \"$(jump init zsh)\"",
+		MsgNeedKeywords:    "missing keywords",
+		MsgNeedDir:         "missing directory",
+		MsgNoMatch:         "no recorded directory matches: %s",
+		MsgNoEntries:       "no directories recorded yet (enable the hook with: eval \"$(jump init zsh)\")",
+		MsgNotRecorded:     "not recorded: %s",
+		MsgRemoved:         "removed %s",
+		MsgInitUsage:       "usage: jump init [zsh|bash|fish] [--no-cmd]",
+		MsgInitUnsupported: "unsupported shell %q (supported: %s)",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:           "用法: jump <命令> [参数]",
+		MsgUsageCommand:    "  %-6s %s",
+		MsgUnknownCommand:  "未知命令: %s",
+		MsgError:           "错误: %v",
+		MsgAddSummary:      "记录一次目录访问（由 shell 钩子调用）",
+		MsgQuerySummary:    "输出与关键字匹配、排名最高的目录（-l 列出全部匹配）",
+		MsgListSummary:     "按访问频率与时间列出已记录的目录",
+		MsgRmSummary:       "删除一个目录的记录",
+		MsgInitSummary:     "输出需手动启用的 shell 钩子与 j cd 包装函数: eval \"$(jump init zsh)\"",
+		MsgNeedKeywords:    "缺少关键字",
+		MsgNeedDir:         "缺少目录",
+		MsgNoMatch:         "没有与 %s 匹配的目录记录",
+		MsgNoEntries:       "还没有目录记录（先启用钩子: eval \"$(jump init zsh)\"）",
+		MsgNotRecorded:     "没有该目录的记录: %s",
+		MsgRemoved:         "已删除 %s",
+		MsgInitUsage:       "用法: jump init [zsh|bash|fish] [--no-cmd]",
+		MsgInitUnsupported: "不支持的 shell %q（支持: %s）",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
@@ -0,0 +1,26 @@
+# jump: 记录访问过的目录（eval "$(jump init bash)"）
+__jump_add() {
+  [[ "${__jump_pwd:-}" == "$PWD" ]] && return
+  __jump_pwd=$PWD
+  (command {{jump}} add -- "$PWD" >/dev/null 2>&1 &)
+}
+if [[ ";${PROMPT_COMMAND:-};" != *";__jump_add;"* ]]; then
+  PROMPT_COMMAND="__jump_add${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+
+# --- j cd wrapper ---
+j() {
+  if [[ "$1" == cd ]]; then
+    shift
+    if (( $# == 0 )) || [[ "$1" == - ]] || { (( $# == 1 )) && [[ -d "$1" ]]; }; then
+      builtin cd "$@"
+      return
+    fi
+    local dir
+    dir="$(command {{jump}} query -- "$@")" || return
+    builtin cd -- "$dir"
+    return
+  fi
+  command j "$@"
+}
+# --- j cd wrapper ---
@@ -0,0 +1,21 @@
+# jump: 记录访问过的目录（jump init fish | source）
+function __jump_add --on-variable PWD
+    command {{jump}} add -- "$PWD" >/dev/null 2>&1 &
+    disown 2>/dev/null
+end
+
+# --- j cd wrapper ---
+function j
+    if test (count $argv) -ge 1; and test "$argv[1]" = cd
+        set -e argv[1]
+        if test (count $argv) -eq 0; or test "$argv[1]" = -; or begin; test (count $argv) -eq 1; and test -d "$argv[1]"; end
+            builtin cd $argv
+            return
+        end
+        set -l dir (command {{jump}} query -- $argv); or return
+        builtin cd "$dir"
+        return
+    end
+    command j $argv
+end
+# --- j cd wrapper ---
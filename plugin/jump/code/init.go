@@ -0,0 +1,77 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 各 shell 的初始化脚本：切换目录后在后台调用 jump add 的钩子，以及可选的 j 包装函数
+// （j cd <关键字> 跳转到匹配的目录，其余参数原样交给 j）
+var (
+	//go:embed init.zsh
+	zshInit string
+	//go:embed init.bash
+	bashInit string
+	//go:embed init.fish
+	fishInit string
+)
+
+// initShells 支持的 shell
+var initShells = []string{"zsh", "bash", "fish"}
+
+// wrapperMarker 脚本中包装函数所在段的起止标记，--no-cmd 时删去这一段
+const wrapperMarker = "# --- j cd wrapper ---\n"
+
+// runInit jump init [zsh|bash|fish] [--no-cmd]：输出初始化脚本，需要在 ~/.zshrc 中加入 eval "$(jump init zsh)" 才会启用；
+// --no-cmd 只记录访问，不定义 j 包装函数（可自行用 cd "$(jump query 关键字)"）
+func runInit(args []string) error {
+	shell := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		shell, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	noCmd := fs.Bool("no-cmd", false, "only track visited directories, do not define the j wrapper function")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New(T(MsgInitUsage))
+	}
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+	}
+	var script string
+	switch shell {
+	case "zsh":
+		script = zshInit
+	case "bash":
+		script = bashInit
+	case "fish":
+		script = fishInit
+	default:
+		return errors.New(T(MsgInitUnsupported, shell, strings.Join(initShells, ", ")))
+	}
+	if *noCmd {
+		if start := strings.Index(script, wrapperMarker); start >= 0 {
+			if end := strings.LastIndex(script, wrapperMarker); end > start {
+				script = script[:start] + script[end+len(wrapperMarker):]
+			}
+		}
+	}
+	bin, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	fmt.Print(strings.ReplaceAll(script, "{{jump}}", shellQuote(bin)))
+	return nil
+}
+
+// shellQuote 以单引号包裹，供 shell 原样使用
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// command 子命令：run 返回的错误统一由 main 输出到 stderr 并以非零码退出
+type command struct {
+	run     func(args []string) error
+	summary string // 用法说明中的一句话介绍（i18n key）
+}
+
+// commands 子命令注册表
+var commands = map[string]command{
+	"add":   {runAdd, MsgAddSummary},
+	"init":  {runInit, MsgInitSummary},
+	"list":  {runList, MsgListSummary},
+	"query": {runQuery, MsgQuerySummary},
+	"rm":    {runRm, MsgRmSummary},
+}
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help jump 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	args := os.Args[1:]
+	name := "list"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintln(os.Stderr, T(MsgUnknownCommand, name))
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(args); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// usage 输出命令列表到 stderr
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, T(MsgUsage))
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, T(MsgUsageCommand, name, T(commands[name].summary)))
+	}
+}
+
+// absDir 转为绝对路径并解析符号链接，使同一目录只记录一次
+func absDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = real
+	}
+	return abs, nil
+}
+
+// runAdd jump add [--] <目录>：钩子在每次切换目录后于后台调用；不存在的目录与排除的目录静默忽略
+func runAdd(args []string) error {
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) != 1 {
+		return errors.New(T(MsgNeedDir))
+	}
+	dir, err := absDir(args[0])
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() || excluded(dir) {
+		return nil
+	}
+	return visit(dir, time.Now())
+}
+
+// runQuery jump query [-l] [--] <关键字...>：输出得分最高的匹配目录（当前目录除外），
+// 已不存在的目录顺便从记录中删除；-l 按得分列出全部匹配
+func runQuery(args []string) error {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	all := fs.Bool("l", false, "list all matches with their scores")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	keywords := fs.Args()
+	if len(keywords) == 0 && !*all {
+		return errors.New(T(MsgNeedKeywords))
+	}
+	dirs, err := loadDirs()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	cwd, _ := os.Getwd()
+	if real, err := filepath.EvalSymlinks(cwd); err == nil {
+		cwd = real
+	}
+	var gone []string
+	found := false
+	for _, d := range ranked(dirs, now) {
+		if d.Path == cwd || !matches(d.Path, keywords) {
+			continue
+		}
+		if info, err := os.Stat(d.Path); err != nil || !info.IsDir() {
+			gone = append(gone, d.Path)
+			continue
+		}
+		found = true
+		if !*all {
+			fmt.Println(d.Path)
+			break
+		}
+		fmt.Printf("%8.1f  %s\n", d.Score(now), d.Path)
+	}
+	if len(gone) > 0 {
+		if err := forget(dirs, gone); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return errors.New(T(MsgNoMatch, strings.Join(keywords, " ")))
+	}
+	return nil
+}
+
+// runList jump [list] [-n 20]：按得分从高到低
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	limit := fs.Int("n", 20, "number of directories to show (0 = all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dirs, err := loadDirs()
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return errors.New(T(MsgNoEntries))
+	}
+	now := time.Now()
+	for i, d := range ranked(dirs, now) {
+		if *limit > 0 && i >= *limit {
+			break
+		}
+		fmt.Printf("%8.1f  %s  %s\n", d.Score(now), d.Last.Local().Format(time.DateTime), d.Path)
+	}
+	return nil
+}
+
+// runRm jump rm <目录...>
+func runRm(args []string) error {
+	if len(args) == 0 {
+		return errors.New(T(MsgNeedDir))
+	}
+	dirs, err := loadDirs()
+	if err != nil {
+		return err
+	}
+	var paths []string
+	for _, arg := range args {
+		dir, err := absDir(arg)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(dirs, func(d Dir) bool { return d.Path == dir }) {
+			return errors.New(T(MsgNotRecorded, arg))
+		}
+		paths = append(paths, dir)
+	}
+	if err := forget(dirs, paths); err != nil {
+		return err
+	}
+	for _, p := range paths {
+		fmt.Println(T(MsgRemoved, p))
+	}
+	return nil
+}
+
+// forget 从记录中删除 paths 并写回
+func forget(dirs []Dir, paths []string) error {
+	kept := dirs[:0]
+	for _, d := range dirs {
+		if !slices.Contains(paths, d.Path) {
+			kept = append(kept, d)
+		}
+	}
+	return saveDirs(kept)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+// DefaultMaxRank 未配置 setting.jump_max 时排名总和的上限：超出后所有目录按比例衰减，
+// 排名不足 1 的被遗忘，记录因此不会无限增长
+const DefaultMaxRank = 10000
+
+// Dir 一个访问过的目录：每次访问排名加 1，排序时再按上次访问距今的时间加权
+type Dir struct {
+	Path string    `json:"path"`
+	Rank float64   `json:"rank"`
+	Last time.Time `json:"last"`
+}
+
+// Score 综合访问次数与最近访问时间的"frecency"得分
+func (d Dir) Score(now time.Time) float64 {
+	age := now.Sub(d.Last)
+	switch {
+	case age < time.Hour:
+		return d.Rank * 4
+	case age < 24*time.Hour:
+		return d.Rank * 2
+	case age < 7*24*time.Hour:
+		return d.Rank / 2
+	}
+	return d.Rank / 4
+}
+
+func storePath() (string, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, JumpDir, "dirs.json"), nil
+}
+
+func maxRank() float64 {
+	if n, err := strconv.Atoi(jdata.Setting(SettingJumpMax)); err == nil && n > 0 {
+		return float64(n)
+	}
+	return DefaultMaxRank
+}
+
+// loadDirs 读取全部记录，文件不存在时返回空
+func loadDirs() ([]Dir, error) {
+	path, err := storePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var dirs []Dir
+	if err := json.Unmarshal(data, &dirs); err != nil {
+		return nil, err
+	}
+	return dirs, nil
+}
+
+// saveDirs 整体写回，权限 0600（目录路径也会透露项目信息）；钩子在后台并发调用时
+// 各自写入独立的临时文件再 rename，最坏情况下丢失一次访问，但文件不会损坏
+func saveDirs(dirs []Dir) error {
+	path, err := storePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(dirs, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "dirs-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// visit 记录一次访问：已有的目录排名加 1，新目录从 1 开始；排名总和超出上限时整体衰减
+func visit(path string, now time.Time) error {
+	dirs, err := loadDirs()
+	if err != nil {
+		return err
+	}
+	found := false
+	total := 0.0
+	for i := range dirs {
+		if dirs[i].Path == path {
+			dirs[i].Rank++
+			dirs[i].Last = now
+			found = true
+		}
+		total += dirs[i].Rank
+	}
+	if !found {
+		dirs = append(dirs, Dir{Path: path, Rank: 1, Last: now})
+		total++
+	}
+	if limit := maxRank(); total > limit {
+		dirs = age(dirs, 0.9*limit/total)
+	}
+	return saveDirs(dirs)
+}
+
+// age 所有排名乘以 factor，丢弃不足 1 的
+func age(dirs []Dir, factor float64) []Dir {
+	kept := dirs[:0]
+	for _, d := range dirs {
+		d.Rank *= factor
+		if d.Rank >= 1 {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// ranked 按得分从高到低排列
+func ranked(dirs []Dir, now time.Time) []Dir {
+	out := append([]Dir(nil), dirs...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score(now) > out[j].Score(now) })
+	return out
+}
+
+// matches 关键字（不区分大小写）须按顺序出现在路径中，且最后一个关键字须落在最后一级目录名里，
+// 这样 "j cd pro" 跳到 .../project 而不是 .../project/src；含 / 的关键字不受这条限制
+func matches(path string, keywords []string) bool {
+	p := strings.ToLower(path)
+	base := strings.LastIndexByte(p, '/') + 1
+	pos := 0
+	for i, kw := range keywords {
+		kw = strings.ToLower(kw)
+		if i == len(keywords)-1 && !strings.Contains(kw, "/") {
+			pos = max(pos, base)
+		}
+		idx := strings.Index(p[pos:], kw)
+		if idx < 0 {
+			return false
+		}
+		pos += idx + len(kw)
+	}
+	return true
+}
+
+// excluded 不记录的目录：主目录本身与 setting.jump_exclude 中的 glob（逗号分隔，支持 ~ 开头）
+func excluded(path string) bool {
+	home, _ := os.UserHomeDir()
+	if path == home || path == "/" {
+		return true
+	}
+	for _, pattern := range strings.Split(jdata.Setting(SettingJumpExclude), ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(pattern, "~"); ok && home != "" {
+			pattern = home + rest
+		}
+		if ok, _ := filepath.Match(pattern, path); ok {
+			return true
+		}
+		if strings.HasSuffix(pattern, "/*") && strings.HasPrefix(path, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/parser"
+)
+
+const (
+	// EnvAccessible 无障碍模式的环境变量（优先级高于配置文件），与 j 主程序、agent 一致
+	EnvAccessible = "J_ACCESSIBLE"
+	// SettingAccessible setting 段中的无障碍模式开关
+	SettingAccessible = "accessible"
+)
+
+// accessibleMode 是否开启无障碍模式：J_ACCESSIBLE > config.yaml 的 setting.accessible，默认关闭
+func accessibleMode() bool {
+	v := os.Getenv(EnvAccessible)
+	if v == "" {
+		return settingOn(SettingAccessible)
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "on", "yes":
+		return true
+	}
+	return false
+}
+
+// languageNames 代码块语言标注对应的读法
+var languageNames = map[string]string{
+	"go": "Go", "golang": "Go", "python": "Python", "py": "Python", "python3": "Python", "rust": "Rust", "rs": "Rust",
+	"js": "JavaScript", "javascript": "JavaScript", "jsx": "JavaScript", "ts": "TypeScript", "typescript": "TypeScript",
+	"tsx": "TypeScript", "sh": "Shell", "bash": "Shell", "zsh": "Shell", "shell": "Shell", "console": "Shell",
+	"json": "JSON", "yaml": "YAML", "yml": "YAML", "toml": "TOML", "html": "HTML", "xml": "XML", "css": "CSS",
+	"sql": "SQL", "c": "C", "cpp": "C++", "c++": "C++", "java": "Java", "kotlin": "Kotlin", "swift": "Swift",
+	"ruby": "Ruby", "rb": "Ruby", "php": "PHP", "diff": "Diff", "markdown": "Markdown", "md": "Markdown",
+	"dockerfile": "Dockerfile", "makefile": "Makefile", "lua": "Lua", "text": "", "txt": "", "plaintext": "",
+}
+
+// calloutNames GitHub 风格提示块的标记对应的文案
+var calloutNames = map[string]string{
+	"NOTE": MsgCalloutNote, "TIP": MsgCalloutTip, "IMPORTANT": MsgCalloutImportant,
+	"WARNING": MsgCalloutWarning, "CAUTION": MsgCalloutCaution,
+}
+
+var (
+	calloutRegexp = regexp.MustCompile(`^\[!([A-Za-z]+)\]\s*`)
+	htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+)
+
+// renderAccessible 无障碍模式的输出：不用颜色、边框、缩进与控制序列，不折行（交给终端与读屏软件），
+// 按阅读顺序输出纯文本，并用文字说明文档结构（"Heading level 2: …"、"Code block, Go, 14 lines"）；
+// --diff 的增删以 [added: …] / [removed: …] 标出
+func renderAccessible(content string, opts renderOptions) []byte {
+	p := parser.NewWithExtensions(markdown.Extensions())
+	doc := p.Parse([]byte(content))
+	w := &a11yWriter{numbers: opts.numbers}
+	if opts.toc {
+		w.toc(doc, opts.tocDepth)
+	}
+	for _, child := range doc.GetChildren() {
+		w.block(child)
+	}
+	out := strings.TrimRight(w.b.String(), "\n") + "\n"
+	if opts.diffOld != "" {
+		out = strings.NewReplacer(
+			string(diffInsOpen), "["+T(MsgA11yAdded)+": ", string(diffInsClose), "]",
+			string(diffDelOpen), "["+T(MsgA11yRemoved)+": ", string(diffDelClose), "]",
+		).Replace(out)
+	}
+	return []byte(out)
+}
+
+// a11yWriter 逐块写出无障碍模式的文本，块之间空一行
+type a11yWriter struct {
+	b       bytes.Buffer
+	numbers []int // 第一个标题的章节编号（--section），之后的标题不加编号
+}
+
+func (w *a11yWriter) line(s string) {
+	w.b.WriteString(s + "\n")
+}
+
+func (w *a11yWriter) gap() {
+	if w.b.Len() > 0 && !bytes.HasSuffix(w.b.Bytes(), []byte("\n\n")) {
+		w.b.WriteString("\n")
+	}
+}
+
+// tight 去掉块末尾的空行：列表条目、引用中的段落与代码块紧跟在前一行之后
+func (w *a11yWriter) tight() {
+	if bytes.HasSuffix(w.b.Bytes(), []byte("\n\n")) {
+		w.b.Truncate(w.b.Len() - 1)
+	}
+}
+
+// toc 在开头列出各级标题
+func (w *a11yWriter) toc(doc ast.Node, depth int) {
+	var titles []string
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		if h, ok := node.(*ast.Heading); ok && entering && h.Level <= depth {
+			titles = append(titles, T(MsgA11yHeading, h.Level, inlineText(h)))
+			return ast.SkipChildren
+		}
+		return ast.GoToNext
+	})
+	if len(titles) == 0 {
+		return
+	}
+	w.line(T(MsgA11yContents, T(MsgTOCTitle), Tn(len(titles), MsgA11yHeadingsOne, MsgA11yHeadings)))
+	for _, t := range titles {
+		w.line(t)
+	}
+	w.line(T(MsgA11yContentsEnd))
+	w.gap()
+}
+
+func (w *a11yWriter) block(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.Heading:
+		text := inlineText(n)
+		if w.numbers != nil {
+			parts := make([]string, len(w.numbers))
+			for i, v := range w.numbers {
+				parts[i] = strconv.Itoa(v)
+			}
+			text = strings.Join(parts, ".") + " " + text
+			w.numbers = nil
+		}
+		w.line(T(MsgA11yHeading, n.Level, text))
+	case *ast.Paragraph:
+		w.line(inlineText(n))
+	case *ast.CodeBlock:
+		w.codeBlock(n)
+	case *ast.List:
+		w.list(n)
+	case *ast.BlockQuote:
+		w.quote(n)
+	case *ast.Table:
+		w.table(n)
+	case *ast.HorizontalRule:
+		w.line(T(MsgA11ySeparator))
+	case *ast.HTMLBlock:
+		text := strings.TrimSpace(htmlTagRegexp.ReplaceAllString(string(n.Literal), ""))
+		if text == "" {
+			return
+		}
+		w.line(text)
+	case *ast.MathBlock:
+		w.line(T(MsgA11yMath, strings.TrimSpace(string(n.Literal))))
+	default:
+		for _, child := range node.GetChildren() {
+			w.block(child)
+		}
+		return
+	}
+	w.gap()
+}
+
+// codeBlock 先说明语言与行数，再原样输出代码
+func (w *a11yWriter) codeBlock(n *ast.CodeBlock) {
+	code := strings.TrimRight(string(n.Literal), "\n")
+	lines := strings.Count(code, "\n") + 1
+	if code == "" {
+		lines = 0
+	}
+	tag := ""
+	if fields := strings.Fields(string(n.Info)); len(fields) > 0 {
+		tag, _, _ = strings.Cut(fields[0], ":")
+	}
+	if tag == "" {
+		tag = markdown.DetectLanguage(code)
+	}
+	name, ok := languageNames[strings.ToLower(tag)]
+	if !ok {
+		name = tag
+	}
+	if name == "" {
+		w.line(T(MsgA11yCodePlain, Tn(lines, MsgA11yLinesOne, MsgA11yLines)))
+	} else {
+		w.line(T(MsgA11yCode, name, Tn(lines, MsgA11yLinesOne, MsgA11yLines)))
+	}
+	if code != "" {
+		w.line(code)
+	}
+	w.line(T(MsgA11yCodeEnd))
+}
+
+// list 先说明条目数；有序列表的条目带序号，任务列表说明是否完成，嵌套的列表同样说明开始与结束
+func (w *a11yWriter) list(n *ast.List) {
+	items := n.GetChildren()
+	ordered := n.ListFlags&ast.ListTypeOrdered != 0
+	switch {
+	case n.ListFlags&ast.ListTypeDefinition != 0:
+		w.line(T(MsgA11yDefinitions))
+	case ordered:
+		w.line(T(MsgA11yOrderedList, Tn(len(items), MsgA11yItemsOne, MsgA11yItems)))
+	default:
+		w.line(T(MsgA11yList, Tn(len(items), MsgA11yItemsOne, MsgA11yItems)))
+	}
+	number := max(n.Start, 1)
+	for _, item := range items {
+		li, ok := item.(*ast.ListItem)
+		if !ok {
+			continue
+		}
+		children := li.GetChildren()
+		text := ""
+		if len(children) > 0 {
+			if _, isPara := children[0].(*ast.Paragraph); isPara {
+				text, children = inlineText(children[0]), children[1:]
+			}
+		}
+		switch {
+		case strings.HasPrefix(text, "[ ] "):
+			text = T(MsgA11yTaskOpen) + ": " + text[4:]
+		case strings.HasPrefix(text, "[x] ") || strings.HasPrefix(text, "[X] "):
+			text = T(MsgA11yTaskDone) + ": " + text[4:]
+		}
+		switch {
+		case li.ListFlags&ast.ListTypeTerm != 0:
+			w.line(T(MsgA11yTerm, text))
+		case n.ListFlags&ast.ListTypeDefinition != 0:
+			w.line(text)
+		case ordered:
+			w.line(strconv.Itoa(number) + ". " + text)
+			number++
+		default:
+			w.line("- " + text)
+		}
+		for _, child := range children {
+			w.block(child)
+			w.tight()
+		}
+	}
+	w.line(T(MsgA11yListEnd))
+}
+
+// quote 引用与 GitHub 风格的提示块（> [!NOTE]）
+func (w *a11yWriter) quote(n *ast.BlockQuote) {
+	children := n.GetChildren()
+	label := T(MsgA11yQuote)
+	if len(children) > 0 {
+		if p, ok := children[0].(*ast.Paragraph); ok {
+			text := inlineText(p)
+			if m := calloutRegexp.FindStringSubmatch(text); m != nil {
+				if key, ok := calloutNames[strings.ToUpper(m[1])]; ok {
+					label = T(key)
+					if rest := strings.TrimSpace(text[len(m[0]):]); rest != "" {
+						w.line(label + ": " + rest)
+						label = ""
+					}
+					children = children[1:]
+				}
+			}
+		}
+	}
+	if label != "" {
+		w.line(label + ":")
+	}
+	for _, child := range children {
+		w.block(child)
+		w.tight()
+	}
+	w.line(T(MsgA11yQuoteEnd))
+}
+
+// table 先说明行列数与表头，每行以 "列名: 值" 读出
+func (w *a11yWriter) table(n *ast.Table) {
+	var header []string
+	var rows [][]string
+	ast.WalkFunc(n, func(node ast.Node, entering bool) ast.WalkStatus {
+		row, ok := node.(*ast.TableRow)
+		if !ok || !entering {
+			return ast.GoToNext
+		}
+		var cells []string
+		isHeader := false
+		for _, c := range row.GetChildren() {
+			if cell, ok := c.(*ast.TableCell); ok {
+				cells = append(cells, inlineText(cell))
+				isHeader = isHeader || cell.IsHeader
+			}
+		}
+		if isHeader && header == nil {
+			header = cells
+		} else {
+			rows = append(rows, cells)
+		}
+		return ast.SkipChildren
+	})
+	w.line(T(MsgA11yTable, Tn(len(header), MsgA11yColumnCountOne, MsgA11yColumnCount), Tn(len(rows), MsgA11yRowCountOne, MsgA11yRowCount)))
+	if len(header) > 0 {
+		w.line(T(MsgA11yColumns, strings.Join(header, ", ")))
+	}
+	for i, cells := range rows {
+		parts := make([]string, len(cells))
+		for j, c := range cells {
+			if j < len(header) && header[j] != "" {
+				c = header[j] + ": " + c
+			}
+			parts[j] = c
+		}
+		w.line(T(MsgA11yRow, i+1, strings.Join(parts, "; ")))
+	}
+	w.line(T(MsgA11yTableEnd))
+}
+
+// inlineText 节点内行内元素的纯文本：强调只保留文字，删除线、链接与图片用文字说明
+func inlineText(node ast.Node) string {
+	var b strings.Builder
+	for _, child := range node.GetChildren() {
+		writeInline(&b, child)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func writeInline(b *strings.Builder, node ast.Node) {
+	switch n := node.(type) {
+	case *ast.Text:
+		b.Write(n.Literal)
+	case *ast.Code:
+		b.Write(n.Literal)
+	case *ast.Softbreak:
+		b.WriteString(" ")
+	case *ast.Hardbreak:
+		b.WriteString("\n")
+	case *ast.NonBlockingSpace:
+		b.WriteString(" ")
+	case *ast.Math:
+		b.WriteString(T(MsgA11yMath, string(n.Literal)))
+	case *ast.HTMLSpan:
+		// 行内 HTML 标签不读出
+	case *ast.Del:
+		b.WriteString("[" + T(MsgA11yStruck) + ": " + inlineText(n) + "]")
+	case *ast.Image:
+		alt := inlineText(n)
+		if alt == "" {
+			alt = string(n.Destination)
+		}
+		b.WriteString(T(MsgA11yImage, alt))
+	case *ast.Link:
+		text := inlineText(n)
+		dest := string(n.Destination)
+		switch {
+		case text == "":
+			b.WriteString(dest)
+		case text == dest || strings.TrimPrefix(dest, "mailto:") == text || strings.HasPrefix(dest, "#"):
+			b.WriteString(text)
+		default:
+			b.WriteString(text + " (" + T(MsgA11yLink, dest) + ")")
+		}
+	default:
+		for _, child := range node.GetChildren() {
+			writeInline(b, child)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// 插件握手：调用方以唯一的参数 --capabilities 启动，md_render 在 stdout 输出一行 JSON 后以 0 退出，
+// 格式与 agent 插件相同。md_render 读完全部输入才开始渲染，因此不声明 streaming，
+// 调用方应先收集完整的 Markdown 再交给它。transport 为 grpc 时调用方也可以改用 go-plugin 启动，
+// 在一个进程内连续渲染多篇文档（见 grpc.go）；不支持 gRPC 的调用方照旧走 stdin / stdout
+const (
+	// CapabilitiesFlag 握手参数
+	CapabilitiesFlag = "--capabilities"
+	// ProtocolVersion 握手协议版本
+	ProtocolVersion = 1
+	// HelpMarkdownFlag 以该参数启动时输出内嵌的帮助文档（Markdown），j help md_render 借此显示帮助页
+	HelpMarkdownFlag = "--help-markdown"
+)
+
+// 握手中声明的特性
+const (
+	FeatureJSONOutput   = "json-output"   // --json 输出文档结构
+	FeatureCancellation = "cancellation"  // 读取输入时被中断会渲染已读到的部分并复位终端样式
+	FeatureFilter       = "filter"        // 以 --filter <名称> 作为过滤器处理结构化输入（见 filter.go）
+	FeatureHelp         = "help-markdown" // 以 --help-markdown 输出帮助文档
+)
+
+// capabilities 握手应答
+type capabilities struct {
+	Name     string   `json:"name"`
+	Protocol int      `json:"protocol"`
+	Features []string `json:"features"`
+	// Transport 插件使用的传输方式：stdio 或 grpc（声明 grpc 的插件同样接受 stdio 调用）
+	Transport string `json:"transport"`
+	// Filters 声明了 filter 特性时提供的过滤器
+	Filters []filterInfo `json:"filters,omitempty"`
+}
+
+// printCapabilities 输出握手应答
+func printCapabilities() error {
+	return json.NewEncoder(os.Stdout).Encode(capabilities{
+		Name:      "md_render",
+		Protocol:  ProtocolVersion,
+		Features:  []string{FeatureJSONOutput, FeatureCancellation, FeatureFilter, FeatureHelp},
+		Transport: TransportGRPC,
+		Filters:   filterInfos,
+	})
+}
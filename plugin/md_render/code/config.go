@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+
+	"wcp_jdata"
+)
+
+const (
+	// SettingEmoji setting 段中的 :shortcode: emoji 转换开关，终端字体缺少 emoji 时设为 off
+	SettingEmoji = "md_emoji"
+)
+
+// settingsWithPrefix setting 段中以 prefix 开头的配置项，键为去掉前缀后的小写名称（如 md_highlight_yaml 为 yaml）
+func settingsWithPrefix(prefix string) map[string]string {
+	found := map[string]string{}
+	for key, value := range jdata.Settings() {
+		if name, ok := strings.CutPrefix(key, prefix); ok && name != "" {
+			found[strings.ToLower(name)] = value
+		}
+	}
+	return found
+}
+
+// settingOff 配置项显式关闭（false/off/no/0）时返回 true，未配置视为开启
+func settingOff(key string) bool {
+	switch strings.ToLower(strings.TrimSpace(jdata.Setting(key))) {
+	case "0", "false", "off", "no":
+		return true
+	}
+	return false
+}
+
+// settingOn 配置项显式开启（true/on/yes/1）时返回 true，未配置视为关闭
+func settingOn(key string) bool {
+	switch strings.ToLower(strings.TrimSpace(jdata.Setting(key))) {
+	case "1", "true", "on", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+)
+
+// 差异标记：合并后的 Markdown 源码中用私用区字符包住增删的词，
+// 解析器把它们当作普通文字，渲染时再替换为颜色（见 diffFilter）
+const (
+	diffInsOpen  = '\uE000'
+	diffInsClose = '\uE001'
+	diffDelOpen  = '\uE002'
+	diffDelClose = '\uE003'
+
+	diffMarkers = "\uE000\uE001\uE002\uE003"
+
+	// maxDiffCells 单次 LCS 表的最大格数，超过时整段视为删除后插入
+	maxDiffCells = 4 << 20
+)
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffInsert
+	diffDelete
+)
+
+type diffEdit struct {
+	op   diffOp
+	text string
+}
+
+var (
+	// diffStructuralRegexp 代码块围栏、分隔线、setext 下划线与表格分隔行，这些行不能带标记
+	diffStructuralRegexp = regexp.MustCompile("^[ \t]*(```|~~~|([-*_][ \t]*){3,}$|=+[ \t]*$|\\|?[ \t]*:?-+:?[ \t]*(\\|[ \t]*:?-+:?[ \t]*)+\\|?[ \t]*$)")
+	// diffPrefixRegexp 行首被标记包住的列表符号、标题井号、引用符号与表格竖线，需要移到标记之外
+	diffPrefixRegexp = regexp.MustCompile(`^([ \t]*)([\x{E000}\x{E002}])((?:(?:[-*+>|]|\d+[.)]|#{1,6})[ \t]+)+)`)
+	// diffTrailingPipeRegexp 表格行末的竖线同样移到标记之外
+	diffTrailingPipeRegexp = regexp.MustCompile(`(\|[ \t]*)([\x{E001}\x{E003}])[ \t]*$`)
+)
+
+// diffMarkdown 逐词比较两份 Markdown，返回带差异标记的合并文档与增删的词数：
+// 先按行找出变化的段落，再在段落内逐词（中日韩文字逐字）比较
+func diffMarkdown(old, new string) (merged string, added, removed int) {
+	w := &diffWriter{}
+	var oldHunk, newHunk strings.Builder
+	flush := func() {
+		if oldHunk.Len() == 0 && newHunk.Len() == 0 {
+			return
+		}
+		for _, e := range diffSequences(diffWords(oldHunk.String()), diffWords(newHunk.String())) {
+			w.write(e)
+		}
+		oldHunk.Reset()
+		newHunk.Reset()
+	}
+	for _, e := range diffSequences(strings.SplitAfter(old, "\n"), strings.SplitAfter(new, "\n")) {
+		switch e.op {
+		case diffEqual:
+			flush()
+			w.write(e)
+		case diffDelete:
+			oldHunk.WriteString(e.text)
+		case diffInsert:
+			newHunk.WriteString(e.text)
+		}
+	}
+	flush()
+	w.flushRun()
+	return fixDiffLines(w.out.String()), w.added, w.removed
+}
+
+// diffWriter 把连续的同类编辑合并为一段后写出
+type diffWriter struct {
+	out            strings.Builder
+	run            strings.Builder
+	op             diffOp
+	added, removed int
+}
+
+func (w *diffWriter) write(e diffEdit) {
+	if e.op != w.op {
+		w.flushRun()
+		w.op = e.op
+	}
+	w.run.WriteString(e.text)
+	if strings.TrimSpace(e.text) == "" {
+		return
+	}
+	switch e.op {
+	case diffInsert:
+		w.added++
+	case diffDelete:
+		w.removed++
+	}
+}
+
+// flushRun 写出当前一段；增删的内容逐行包上标记，缩进与行尾空白留在标记之外
+func (w *diffWriter) flushRun() {
+	text := w.run.String()
+	w.run.Reset()
+	if w.op == diffEqual {
+		w.out.WriteString(text)
+		return
+	}
+	open, close := diffInsOpen, diffInsClose
+	if w.op == diffDelete {
+		open, close = diffDelOpen, diffDelClose
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			w.out.WriteByte('\n')
+		}
+		body := strings.TrimSpace(line)
+		if body == "" {
+			w.out.WriteString(line)
+			continue
+		}
+		start := strings.Index(line, body)
+		w.out.WriteString(line[:start])
+		w.out.WriteRune(open)
+		w.out.WriteString(body)
+		w.out.WriteRune(close)
+		w.out.WriteString(line[start+len(body):])
+	}
+}
+
+// fixDiffLines 保证带标记的行仍能被正确解析：围栏、分隔线等结构行只保留新版本且去掉标记，
+// 列表符号、标题井号等行首结构移到标记之外
+func fixDiffLines(merged string) string {
+	lines := strings.Split(merged, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if !strings.ContainsAny(line, diffMarkers) {
+			out = append(out, line)
+			continue
+		}
+		oldLine, newLine := diffSide(line, diffDelete), diffSide(line, diffInsert)
+		if diffStructuralRegexp.MatchString(oldLine) || diffStructuralRegexp.MatchString(newLine) {
+			if strings.TrimSpace(newLine) != "" {
+				out = append(out, newLine)
+			}
+			continue
+		}
+		line = diffPrefixRegexp.ReplaceAllString(line, "$1$3$2")
+		line = diffTrailingPipeRegexp.ReplaceAllString(line, "$2$1")
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// diffSide 取合并行中某一侧的内容：diffDelete 为旧版本，diffInsert 为新版本
+func diffSide(line string, side diffOp) string {
+	var b strings.Builder
+	skip := false
+	for _, r := range line {
+		switch r {
+		case diffInsOpen:
+			skip = side == diffDelete
+		case diffDelOpen:
+			skip = side == diffInsert
+		case diffInsClose, diffDelClose:
+			skip = false
+		default:
+			if !skip {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// diffWords 切分为词：连续的字母数字、单个中日韩字符、单个标点、连续空白与换行各为一个词
+func diffWords(s string) []string {
+	var tokens []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch r := runes[i]; {
+		case r == '\n':
+		case unicode.IsSpace(r):
+			for j < len(runes) && runes[j] != '\n' && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		case isDiffWordRune(r):
+			for j < len(runes) && isDiffWordRune(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+func isDiffWordRune(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return false
+	}
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// diffSequences 基于最长公共子序列的编辑序列，相同的首尾部分先行剥离
+func diffSequences(a, b []string) []diffEdit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	edits := make([]diffEdit, 0, len(a)+len(b))
+	for _, t := range a[:prefix] {
+		edits = append(edits, diffEdit{diffEqual, t})
+	}
+	edits = append(edits, lcsEdits(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, t := range a[len(a)-suffix:] {
+		edits = append(edits, diffEdit{diffEqual, t})
+	}
+	return edits
+}
+
+func lcsEdits(a, b []string) []diffEdit {
+	var edits []diffEdit
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 || (n+1)*(m+1) > maxDiffCells {
+		for _, t := range a {
+			edits = append(edits, diffEdit{diffDelete, t})
+		}
+		for _, t := range b {
+			edits = append(edits, diffEdit{diffInsert, t})
+		}
+		return edits
+	}
+	// lcs[i*(m+1)+j] 为 a[i:] 与 b[j:] 的最长公共子序列长度
+	lcs := make([]int32, (n+1)*(m+1))
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+			} else {
+				lcs[i*(m+1)+j] = max(lcs[(i+1)*(m+1)+j], lcs[i*(m+1)+j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, diffEdit{diffEqual, a[i]})
+			i++
+			j++
+		case lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+			edits = append(edits, diffEdit{diffDelete, a[i]})
+			i++
+		default:
+			edits = append(edits, diffEdit{diffInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		edits = append(edits, diffEdit{diffDelete, a[i]})
+	}
+	for ; j < m; j++ {
+		edits = append(edits, diffEdit{diffInsert, b[j]})
+	}
+	return edits
+}
+
+// diffMarkerSequence 标记对应的终端样式：新增为绿底，删除为红底加删除线；
+// 只改背景，保留标题等元素自身的前景色。不输出颜色时退化为 {+新增+} / [-删除-]
+func diffMarkerSequence(marker rune) string {
+	if color.NoColor {
+		return map[rune]string{diffInsOpen: "{+", diffInsClose: "+}", diffDelOpen: "[-", diffDelClose: "-]"}[marker]
+	}
+	return map[rune]string{
+		diffInsOpen:  "\033[48;5;22m",
+		diffInsClose: "\033[49m",
+		diffDelOpen:  "\033[48;5;52;9m",
+		diffDelClose: "\033[29;49m",
+	}[marker]
+}
+
+// diffFilter 渲染器的文字过滤：标记换成颜色；一段标记跨越多个文字节点（强调、表格单元格）时，
+// 每个节点单独开启与关闭颜色，避免颜色漏到边框与缩进上
+func diffFilter() func(string) string {
+	var open rune
+	closing := map[rune]rune{diffInsOpen: diffInsClose, diffDelOpen: diffDelClose}
+	return func(text string) string {
+		if open == 0 && !strings.ContainsAny(text, diffMarkers) {
+			return text
+		}
+		var b strings.Builder
+		if open != 0 {
+			b.WriteString(diffMarkerSequence(open))
+		}
+		for _, r := range text {
+			switch r {
+			case diffInsOpen, diffDelOpen:
+				open = r
+				b.WriteString(diffMarkerSequence(r))
+			case diffInsClose, diffDelClose:
+				open = 0
+				b.WriteString(diffMarkerSequence(r))
+			default:
+				b.WriteRune(r)
+			}
+		}
+		if open != 0 {
+			b.WriteString(diffMarkerSequence(closing[open]))
+		}
+		return b.String()
+	}
+}
+
+// replaceDiffMarkers 替换过滤器处理不到的标记（代码块、行内代码、链接地址）
+func replaceDiffMarkers(out []byte) []byte {
+	if !strings.ContainsAny(string(out), diffMarkers) {
+		return out
+	}
+	return []byte(strings.NewReplacer(
+		string(diffInsOpen), diffMarkerSequence(diffInsOpen),
+		string(diffInsClose), diffMarkerSequence(diffInsClose),
+		string(diffDelOpen), diffMarkerSequence(diffDelOpen),
+		string(diffDelClose), diffMarkerSequence(diffDelClose),
+	).Replace(string(out)))
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+)
+
+// 过滤器：调用方以 --filter <名称> 启动 md_render，在 stdin 写入 {"text": ..., "meta": {...}}，
+// md_render 在 stdout 输出同样格式的结果（meta 中只写有变化的键，空串表示删除）后以 0 退出，
+// 失败时输出 {"error": ...} 并以 1 退出。提供的过滤器在握手应答的 filters 中声明，
+// kind 为 postprocess 的输出仍是 Markdown，调用方可以在渲染前自动执行
+const (
+	// FilterFlag 以过滤器方式启动的参数
+	FilterFlag = "--filter"
+	// MetaLanguage meta 中代码语言的键：extract-code 写入，format 据此选择格式化命令
+	MetaLanguage = "language"
+	// kindPostprocess 后处理器：输入输出都是 Markdown
+	kindPostprocess = "postprocess"
+)
+
+// filterInfo 握手应答中声明的一个过滤器
+type filterInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Kind        string `json:"kind,omitempty"`
+}
+
+// filterPayload 过滤器的输入与输出
+type filterPayload struct {
+	Text  string            `json:"text"`
+	Meta  map[string]string `json:"meta,omitempty"`
+	Error string            `json:"error,omitempty"`
+}
+
+// filters 提供的过滤器
+var filters = map[string]func(filterPayload) (filterPayload, error){
+	"extract-code": extractCode,
+	"format":       formatFilter,
+	"strip-boilerplate": func(in filterPayload) (filterPayload, error) {
+		return filterPayload{Text: stripBoilerplate(in.Text)}, nil
+	},
+}
+
+var filterInfos = []filterInfo{
+	{Name: "extract-code", Description: "keep only the code of the code blocks (sets meta.language when they share one)"},
+	{Name: "format", Description: "format the code blocks, or the whole text when meta.language is set", Kind: kindPostprocess},
+	{Name: "strip-boilerplate", Description: "drop the pleasantries an answer opens or closes with and leading apologies", Kind: kindPostprocess},
+}
+
+// runFilter 执行名为 name 的过滤器，返回进程退出码
+func runFilter(name string) int {
+	enc := json.NewEncoder(os.Stdout)
+	fail := func(err error) int {
+		_ = enc.Encode(filterPayload{Error: err.Error()})
+		return 1
+	}
+	fn, ok := filters[name]
+	if !ok {
+		return fail(errors.New(T(MsgFilterUnknown, name)))
+	}
+	var in filterPayload
+	if err := json.NewDecoder(os.Stdin).Decode(&in); err != nil {
+		return fail(errors.New(T(MsgFilterBadInput, err)))
+	}
+	out, err := fn(in)
+	if err != nil {
+		return fail(err)
+	}
+	if err := enc.Encode(out); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// extractCode 只保留各代码块的代码，以空行分隔；各代码块语言相同时写入 meta.language，否则删除该键
+func extractCode(in filterPayload) (filterPayload, error) {
+	content := normalizeInput(in.Text)
+	if _, body, ok := splitFrontMatter(content); ok {
+		content = body
+	}
+	blocks := markdown.CodeBlocks(content)
+	if len(blocks) == 0 {
+		return in, errors.New(T(MsgFilterNoCode))
+	}
+	language := strings.ToLower(blocks[0].Language)
+	codes := make([]string, len(blocks))
+	for i, b := range blocks {
+		codes[i] = strings.TrimRight(b.Code, "\n")
+		if strings.ToLower(b.Language) != language {
+			language = ""
+		}
+	}
+	return filterPayload{
+		Text: strings.Join(codes, "\n\n") + "\n",
+		Meta: map[string]string{MetaLanguage: language},
+	}, nil
+}
+
+// formatFilter meta.language 给出时把整段文本当作该语言的代码格式化，否则格式化文档中的代码块；
+// 与 --format 相同，代码无法格式化（如有语法错误）时保留原样
+func formatFilter(in filterPayload) (filterPayload, error) {
+	language := in.Meta[MetaLanguage]
+	if language == "" {
+		return filterPayload{Text: formatCodeBlocks(normalizeInput(in.Text))}, nil
+	}
+	argv, ok := lookupFormatter(strings.ToLower(language))
+	if !ok {
+		return filterPayload{Text: in.Text}, nil
+	}
+	formatted, err := runFormatter(argv, in.Text)
+	if errors.Is(err, exec.ErrNotFound) {
+		return in, errors.New(T(MsgFormatMissing, argv[0], language))
+	}
+	if err != nil {
+		return filterPayload{Text: in.Text}, nil
+	}
+	return filterPayload{Text: formatted}, nil
+}
+
+// 回答开头的客套话、道歉与结尾的套话，按小写前缀匹配
+var (
+	boilerplateOpeners = []string{
+		"sure", "certainly", "of course", "absolutely", "great question", "good question", "happy to help",
+		"i apologize", "apologies", "sorry for", "my apologies",
+		"好的", "当然", "没问题", "这是一个好问题", "很高兴", "抱歉", "很抱歉", "对不起",
+	}
+	boilerplateClosers = []string{
+		"i hope this helps", "hope this helps", "let me know if", "feel free to", "if you have any other questions",
+		"if you have any further questions", "happy coding",
+		"希望对你有帮助", "希望这对你有帮助", "希望以上内容", "如有其他问题", "如果还有其他问题", "如果你还有", "如有疑问",
+	}
+)
+
+// maxBoilerplateRunes 超过该长度的段落不当作客套话
+const maxBoilerplateRunes = 160
+
+// stripBoilerplate 去掉回答开头与结尾的客套话段落（如 "Sure! Here's ..." / "希望对你有帮助"）；
+// 只看首尾的短段落，代码块、标题等不是普通文字的段落不动，去掉后没有剩余内容时原样返回
+func stripBoilerplate(content string) string {
+	paragraphs := strings.Split(strings.TrimSpace(normalizeInput(content)), "\n\n")
+	start, end := 0, len(paragraphs)
+	if end > 1 && isBoilerplate(paragraphs[0], boilerplateOpeners) {
+		start++
+	}
+	if end-start > 1 && isBoilerplate(paragraphs[end-1], boilerplateClosers) {
+		end--
+	}
+	if start == 0 && end == len(paragraphs) {
+		return content
+	}
+	return strings.Join(paragraphs[start:end], "\n\n") + "\n"
+}
+
+// isBoilerplate 段落是否为以 prefixes 之一开头的一段短文字
+func isBoilerplate(paragraph string, prefixes []string) bool {
+	text := strings.TrimSpace(paragraph)
+	if text == "" || strings.Contains(text, "\n") || utf8.RuneCountInString(text) > maxBoilerplateRunes ||
+		strings.ContainsAny(text[:1], "#>|`~-*+") {
+		return false
+	}
+	lower := strings.ToLower(text)
+	for _, p := range prefixes {
+		if strings.HasPrefix(lower, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+const (
+	// SettingFormat setting 段中的格式化开关，设为 on 时默认格式化代码块（同 --format）
+	SettingFormat = "md_format"
+	// SettingFormatPrefix setting 段中某种语言的格式化命令，如 md_format_python: "ruff format -"，
+	// 命令从 stdin 读入代码、向 stdout 输出结果；设为 off 时不格式化该语言
+	SettingFormatPrefix = "md_format_"
+
+	// FormatTimeout 单个代码块的格式化时限
+	FormatTimeout = 10 * time.Second
+)
+
+// formatCommands 各语言默认的格式化命令（stdin 读入，stdout 输出），键为代码块语言标注（或推测出的语言）
+var formatCommands = map[string][]string{
+	"go":         {"gofmt"},
+	"golang":     {"gofmt"},
+	"python":     {"black", "--quiet", "-"},
+	"python3":    {"black", "--quiet", "-"},
+	"py":         {"black", "--quiet", "-"},
+	"javascript": {"prettier", "--stdin-filepath", "snippet.js"},
+	"js":         {"prettier", "--stdin-filepath", "snippet.js"},
+	"typescript": {"prettier", "--stdin-filepath", "snippet.ts"},
+	"ts":         {"prettier", "--stdin-filepath", "snippet.ts"},
+	"json":       {"prettier", "--stdin-filepath", "snippet.json"},
+	"css":        {"prettier", "--stdin-filepath", "snippet.css"},
+	"html":       {"prettier", "--stdin-filepath", "snippet.html"},
+	"yaml":       {"prettier", "--stdin-filepath", "snippet.yaml"},
+}
+
+// formatCodeBlocks 用各语言的格式化命令重排文档中的代码块；格式化失败（如代码不完整、有语法错误）
+// 时保留原样，格式化工具未安装时在 stderr 提示一次并跳过该语言
+func formatCodeBlocks(content string) string {
+	lines := strings.Split(content, "\n")
+	missing := map[string]bool{}
+	var out []string
+	next := 0
+	for _, block := range fencedBlocks(lines) {
+		language := blockLanguage(block)
+		argv, ok := lookupFormatter(strings.ToLower(language))
+		if !ok || missing[argv[0]] || block.end <= block.start+1 {
+			continue
+		}
+		formatted, err := runFormatter(argv, block.code)
+		if errors.Is(err, exec.ErrNotFound) {
+			missing[argv[0]] = true
+			fmt.Fprintln(os.Stderr, T(MsgFormatMissing, argv[0], language))
+			continue
+		}
+		if err != nil || formatted == block.code {
+			continue
+		}
+		out = append(out, lines[next:block.start+1]...)
+		for _, line := range strings.Split(strings.TrimRight(formatted, "\n"), "\n") {
+			if line != "" {
+				line = block.indent + line
+			}
+			out = append(out, line)
+		}
+		next = block.end
+	}
+	if next == 0 {
+		return content
+	}
+	return strings.Join(append(out, lines[next:]...), "\n")
+}
+
+// lookupFormatter 某种语言的格式化命令：setting 段的 md_format_<语言> 优先于默认命令
+func lookupFormatter(language string) ([]string, bool) {
+	argv, ok := formatCommands[language]
+	if language == "" {
+		return nil, false
+	}
+	custom := strings.TrimSpace(jdata.Setting(SettingFormatPrefix + language))
+	if custom == "" {
+		return argv, ok
+	}
+	if settingOff(SettingFormatPrefix + language) {
+		return nil, false
+	}
+	return strings.Fields(custom), true
+}
+
+// runFormatter 把代码写入格式化命令的 stdin，返回其 stdout；命令失败或输出为空时返回错误
+func runFormatter(argv []string, code string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), FormatTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdin = strings.NewReader(code)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(stdout.String()) == "" {
+		return "", errors.New("empty output")
+	}
+	return stdout.String(), nil
+}
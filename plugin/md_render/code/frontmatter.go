@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// splitFrontMatter 拆出文档开头 --- 与 ---（或 ...）之间的 YAML 元数据；
+// 内容不是 YAML 映射时（例如文档以分隔线开头）视为没有元数据
+func splitFrontMatter(content string) (meta *yaml.Node, body string, ok bool) {
+	source := strings.TrimPrefix(content, "\uFEFF")
+	first, rest, found := strings.Cut(source, "\n")
+	if !found || strings.TrimRight(first, " \t\r") != "---" {
+		return nil, content, false
+	}
+	for offset := 0; offset <= len(rest); {
+		line, next, more := strings.Cut(rest[offset:], "\n")
+		if end := strings.TrimRight(line, " \t\r"); end == "---" || end == "..." {
+			var doc yaml.Node
+			if err := yaml.Unmarshal([]byte(rest[:offset]), &doc); err != nil {
+				return nil, content, false
+			}
+			if len(doc.Content) == 0 {
+				// 空的元数据块
+				return &yaml.Node{Kind: yaml.MappingNode}, next, true
+			}
+			if doc.Content[0].Kind != yaml.MappingNode {
+				return nil, content, false
+			}
+			return doc.Content[0], next, true
+		}
+		if !more {
+			break
+		}
+		offset += len(line) + 1
+	}
+	return nil, content, false
+}
+
+// renderFrontMatter 把元数据转换为“字段 / 值”两列表格放在正文之前，hide 时直接去掉
+func renderFrontMatter(content string, hide bool) string {
+	meta, body, ok := splitFrontMatter(content)
+	if !ok {
+		return content
+	}
+	if hide || len(meta.Content) == 0 {
+		return body
+	}
+	var b strings.Builder
+	b.WriteString("| " + T(MsgFrontMatterField) + " | " + T(MsgFrontMatterValue) + " |\n|---|---|\n")
+	for i := 0; i+1 < len(meta.Content); i += 2 {
+		b.WriteString("| " + tableCell(meta.Content[i].Value) + " | " + tableCell(frontMatterValue(meta.Content[i+1])) + " |\n")
+	}
+	b.WriteString("\n")
+	return b.String() + body
+}
+
+// frontMatterValue 标量原样显示，列表以逗号连接，映射显示为 key: value
+func frontMatterValue(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return frontMatterValue(node.Alias)
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			items = append(items, frontMatterValue(item))
+		}
+		return strings.Join(items, ", ")
+	case yaml.MappingNode:
+		pairs := make([]string, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, node.Content[i].Value+": "+frontMatterValue(node.Content[i+1]))
+		}
+		return strings.Join(pairs, "; ")
+	}
+	return node.Value
+}
+
+// tableCell 转为单行并转义竖线，使其可以放进表格单元格
+func tableCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"wcp_jdata"
+)
+
+// 重新生成金样文件: go test -run TestGolden -update
+var update = flag.Bool("update", false, "regenerate golden files under testdata/golden")
+
+// goldenWidths 覆盖最小宽度、默认宽度和宽屏三种排版
+var goldenWidths = []int{MinTerminalWidth, DefaultTerminalWidth, 120}
+
+// goldenPath 金样文件路径：默认主题为 <name>.w<width>.golden，其余主题为 <name>.<theme>.w<width>.golden
+func goldenPath(name, theme string, width int) string {
+	if theme != DefaultTheme {
+		name += "." + theme
+	}
+	return filepath.Join("testdata", "golden", fmt.Sprintf("%s.w%d.golden", name, width))
+}
+
+// TestGolden 将 testdata/*.md 在各主题、各宽度下的 ANSI 渲染结果与金样逐字节比对
+func TestGolden(t *testing.T) {
+	// 测试进程的 stdout 不是终端，需强制开启颜色才能覆盖 ANSI 输出；
+	// color.New 每次都会重新读取 NO_COLOR，环境里设置了它时金样会丢失颜色
+	t.Setenv("NO_COLOR", "")
+	color.NoColor = false
+	// 提示块标题等渲染内容随界面语言变化，金样固定使用英文
+	jdata.SetLocale(jdata.LocaleEN)
+	// 主题的 #rrggbb 颜色随终端颜色深度降级，金样固定按真彩色终端输出
+	depth := termColorDepth
+	termColorDepth = depthTrueColor
+	t.Cleanup(func() { termColorDepth = depth })
+
+	sources, err := filepath.Glob(filepath.Join("testdata", "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) == 0 {
+		t.Fatal("no fixtures found in testdata/")
+	}
+
+	for _, src := range sources {
+		input, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := strings.TrimSuffix(filepath.Base(src), ".md")
+		for _, theme := range themeNames() {
+			for _, width := range goldenWidths {
+				t.Run(fmt.Sprintf("%s/%s/w%d", name, theme, width), func(t *testing.T) {
+					opts := defaultRenderOptions()
+					opts.styles = themes[theme].resolve()
+					got := renderMarkdown(string(input), width, opts)
+					path := goldenPath(name, theme, width)
+					if *update {
+						if err := os.WriteFile(path, got, 0o644); err != nil {
+							t.Fatal(err)
+						}
+						return
+					}
+					want, err := os.ReadFile(path)
+					if err != nil {
+						t.Fatalf("missing golden file %s (run go test -update): %v", path, err)
+					}
+					if !bytes.Equal(got, want) {
+						t.Errorf("render mismatch for %s\n--- want\n%s\n--- got\n%s", path, want, got)
+					}
+				})
+			}
+		}
+	}
+}
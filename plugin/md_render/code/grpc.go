@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// gRPC 传输：调用方通过 hashicorp/go-plugin 启动 md_render 时（环境变量中带有下面的 magic cookie），
+// md_render 不读 stdin，而是在本地 socket 上提供 Renderer 服务，一个进程可以连续渲染任意多篇文档，
+// 省去每次启动进程、读取主题与样式表的开销。请求与回答使用 protobuf 内置类型，不需要生成代码。
+// 握手参数与服务定义须与调用方（agent 插件的 render.go）保持一致
+const (
+	// TransportGRPC 握手中声明的传输方式
+	TransportGRPC = "grpc"
+	// TransportStdio 默认的 stdin / stdout 传输
+	TransportStdio = "stdio"
+
+	grpcProtocolVersion = 1
+	grpcCookieKey       = "J_PLUGIN_COOKIE"
+	grpcCookieValue     = "md_render-renderer-v1"
+	// grpcPluginName go-plugin 中的插件名
+	grpcPluginName = "render"
+	// rendererService Render 方法所在的服务名
+	rendererService = "j.md_render.Renderer"
+)
+
+var grpcHandshake = plugin.HandshakeConfig{
+	ProtocolVersion:  grpcProtocolVersion,
+	MagicCookieKey:   grpcCookieKey,
+	MagicCookieValue: grpcCookieValue,
+}
+
+// launchedAsGRPCPlugin 是否由 go-plugin 启动
+func launchedAsGRPCPlugin() bool {
+	return os.Getenv(grpcCookieKey) == grpcCookieValue
+}
+
+// serveGRPC 提供 Renderer 服务，直到调用方关闭连接。
+// stdout 由 go-plugin 接管而不是终端，按终端输出的方式保留颜色（仍遵守 NO_COLOR）
+func serveGRPC() {
+	color.NoColor = os.Getenv("NO_COLOR") != ""
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: grpcHandshake,
+		Plugins:         plugin.PluginSet{grpcPluginName: &rendererPlugin{}},
+		GRPCServer:      plugin.DefaultGRPCServer,
+	})
+}
+
+// rendererPlugin 只实现服务端，客户端由调用方实现
+type rendererPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+}
+
+func (rendererPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	s.RegisterService(&rendererServiceDesc, grpcRenderer{})
+	return nil
+}
+
+func (rendererPlugin) GRPCClient(context.Context, *plugin.GRPCBroker, *grpc.ClientConn) (any, error) {
+	return nil, errors.New("md_render only serves the renderer")
+}
+
+// rendererServer Renderer 服务：Render 的请求为 {"markdown": 文档, "width": 宽度, "args": [命令行参数]}，
+// 回答为渲染结果；args 与命令行用法相同（--theme、--toc 等），不支持 --view、--json、--save-files、--diff
+type rendererServer interface {
+	Render(ctx context.Context, req *structpb.Struct) (*wrapperspb.BytesValue, error)
+}
+
+var rendererServiceDesc = grpc.ServiceDesc{
+	ServiceName: rendererService,
+	HandlerType: (*rendererServer)(nil),
+	Methods:     []grpc.MethodDesc{{MethodName: "Render", Handler: renderHandler}},
+}
+
+func renderHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	req := new(structpb.Struct)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(rendererServer).Render(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + rendererService + "/Render"}
+	return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(rendererServer).Render(ctx, req.(*structpb.Struct))
+	})
+}
+
+type grpcRenderer struct{}
+
+// Render 按请求中的参数渲染一篇文档
+func (grpcRenderer) Render(_ context.Context, req *structpb.Struct) (*wrapperspb.BytesValue, error) {
+	start := time.Now()
+	fields := req.GetFields()
+	var args []string
+	for _, v := range fields["args"].GetListValue().GetValues() {
+		args = append(args, v.GetStringValue())
+	}
+	opts, err := parseArgs(args)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if opts.view || opts.json || opts.saveDir != "" || opts.diffOld != "" {
+		return nil, status.Error(codes.InvalidArgument, T(MsgGRPCUnsupportedArgs))
+	}
+	content := normalizeInput(fields["markdown"].GetStringValue())
+	if opts.section != "" {
+		if content, opts.numbers, err = selectSection(content, opts.section); err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+	if opts.format {
+		content = formatCodeBlocks(content)
+	}
+	if opts.lint {
+		content = lintCodeBlocks(content)
+	}
+	width := int(fields["width"].GetNumberValue())
+	if width <= 0 {
+		width = DefaultTerminalWidth
+	}
+	width = min(max(width, MinTerminalWidth), MaxTerminalWidth)
+	result := renderMarkdown(content, width, opts)
+	recordRender(len(fields["markdown"].GetStringValue()), time.Since(start))
+	return wrapperspb.Bytes(result), nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+	"wcp_jdata"
+)
+
+const (
+	// SettingHighlight setting 段中代码块的高亮配色（chroma 样式名），覆盖主题的配色
+	SettingHighlight = "md_highlight"
+	// SettingHighlightPrefix setting 段中某种语言的高亮配色，如 md_highlight_yaml: hr_high_contrast，
+	// 取值为 none 时该语言不高亮
+	SettingHighlightPrefix = "md_highlight_"
+)
+
+// checkHighlight 校验高亮配色：chroma 样式名或 none（不高亮）
+func checkHighlight(name string) error {
+	if strings.EqualFold(name, markdown.NoHighlight) || markdown.HasHighlightStyle(name) {
+		return nil
+	}
+	return fmt.Errorf("%s", T(MsgUnknownHighlight, name, strings.Join(markdown.HighlightStyles(), ", ")))
+}
+
+// setHighlight 设置默认的高亮配色（language 为空）或某种语言的高亮配色
+func (set *styleSet) setHighlight(language, name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if err := checkHighlight(name); err != nil {
+		return err
+	}
+	if language == "" {
+		set.highlight = name
+		return nil
+	}
+	if set.highlightLanguages == nil {
+		set.highlightLanguages = map[string]string{}
+	}
+	set.highlightLanguages[strings.ToLower(language)] = name
+	return nil
+}
+
+// applyHighlightSettings 在主题之上叠加 setting 段的 md_highlight 与 md_highlight_<语言>
+func applyHighlightSettings(set *styleSet) error {
+	if name := jdata.Setting(SettingHighlight); strings.TrimSpace(name) != "" {
+		if err := set.setHighlight("", name); err != nil {
+			return err
+		}
+	}
+	for language, name := range settingsWithPrefix(SettingHighlightPrefix) {
+		if err := set.setHighlight(language, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// highlightOption 转换为渲染器选项；未设置任何配色时返回 nil，保持 pygments 配色与 8 色输出
+func (set styleSet) highlightOption() markdown.Options {
+	if set.highlight == "" && len(set.highlightLanguages) == 0 {
+		return nil
+	}
+	colors := 16
+	switch termColorDepth {
+	case depthTrueColor:
+		colors = 1 << 24
+	case depth256:
+		colors = 256
+	}
+	return markdown.WithHighlight(markdown.Highlight{Style: set.highlight, Languages: set.highlightLanguages, Colors: colors})
+}
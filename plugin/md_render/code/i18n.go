@@ -0,0 +1,311 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage                 = "usage"
+	MsgReadStdinFailed       = "read_stdin_failed"
+	MsgTerminalWidthFallback = "terminal_width_fallback"
+	MsgUnknownTheme          = "unknown_theme"
+	MsgUnknownHighlight      = "unknown_highlight"
+	MsgBadColor              = "bad_color"
+	MsgCalloutNote           = "callout_note"
+	MsgCalloutTip            = "callout_tip"
+	MsgCalloutImportant      = "callout_important"
+	MsgCalloutWarning        = "callout_warning"
+	MsgCalloutCaution        = "callout_caution"
+	MsgViewerStatus          = "viewer_status"
+	MsgViewerPrompt          = "viewer_prompt"
+	MsgViewerNoHeading       = "viewer_no_heading"
+	MsgTOCTitle              = "toc_title"
+	MsgStyleReadFailed       = "style_read_failed"
+	MsgStyleSyntax           = "style_syntax"
+	MsgStyleUnknownSelector  = "style_unknown_selector"
+	MsgStyleBadDeclaration   = "style_bad_declaration"
+	MsgSectionNotFound       = "section_not_found"
+	MsgReadFileFailed        = "read_file_failed"
+	MsgDiffSummary           = "diff_summary"
+	MsgFrontMatterField      = "front_matter_field"
+	MsgFrontMatterValue      = "front_matter_value"
+	MsgRunNoBlock            = "run_no_block"
+	MsgRunUnsupported        = "run_unsupported"
+	MsgRunConfirm            = "run_confirm"
+	MsgRunFailed             = "run_failed"
+	MsgRunRunning            = "run_running"
+	MsgRunExited             = "run_exited"
+	MsgRunStopped            = "run_stopped"
+	MsgSaveNoFiles           = "save_no_files"
+	MsgSaveOverwrite         = "save_overwrite"
+	MsgSaveWritten           = "save_written"
+	MsgSaveSkipped           = "save_skipped"
+	MsgSaveUnsafePath        = "save_unsafe_path"
+	MsgSaveSummary           = "save_summary"
+	MsgLintTitle             = "lint_title"
+	MsgLintMore              = "lint_more"
+	MsgLintMissing           = "lint_missing"
+	MsgLintTimeout           = "lint_timeout"
+	MsgFormatMissing         = "format_missing"
+	MsgApplyNoBlock          = "apply_no_block"
+	MsgApplyNoSuggestion     = "apply_no_suggestion"
+	MsgApplyOutOfRange       = "apply_out_of_range"
+	MsgApplyTitle            = "apply_title"
+	MsgApplyShifted          = "apply_shifted"
+	MsgApplyConfirm          = "apply_confirm"
+	MsgApplyChanged          = "apply_changed"
+	MsgApplyFailed           = "apply_failed"
+	MsgApplyDone             = "apply_done"
+	MsgApplyUndone           = "apply_undone"
+	MsgApplyNothingToUndo    = "apply_nothing_to_undo"
+	MsgGRPCUnsupportedArgs   = "grpc_unsupported_args"
+	MsgFilterUnknown         = "filter_unknown"
+	MsgFilterBadInput        = "filter_bad_input"
+	MsgFilterNoCode          = "filter_no_code"
+	MsgA11yHeading           = "a11y_heading"
+	MsgA11yContents          = "a11y_contents"
+	MsgA11yContentsEnd       = "a11y_contents_end"
+	MsgA11yCode              = "a11y_code"
+	MsgA11yCodePlain         = "a11y_code_plain"
+	MsgA11yCodeEnd           = "a11y_code_end"
+	MsgA11yList              = "a11y_list"
+	MsgA11yOrderedList       = "a11y_ordered_list"
+	MsgA11yDefinitions       = "a11y_definitions"
+	MsgA11yTerm              = "a11y_term"
+	MsgA11yListEnd           = "a11y_list_end"
+	MsgA11yTaskDone          = "a11y_task_done"
+	MsgA11yTaskOpen          = "a11y_task_open"
+	MsgA11yQuote             = "a11y_quote"
+	MsgA11yQuoteEnd          = "a11y_quote_end"
+	MsgA11yTable             = "a11y_table"
+	MsgA11yColumns           = "a11y_columns"
+	MsgA11yRow               = "a11y_row"
+	MsgA11yTableEnd          = "a11y_table_end"
+	MsgA11ySeparator         = "a11y_separator"
+	MsgA11yLink              = "a11y_link"
+	MsgA11yImage             = "a11y_image"
+	MsgA11yMath              = "a11y_math"
+	MsgA11yStruck            = "a11y_struck"
+	MsgA11yAdded             = "a11y_added"
+	MsgA11yRemoved           = "a11y_removed"
+
+	// 无障碍模式中的数量：One 为数量是 1 时的单数形式
+	MsgA11yLines          = "a11y_lines"
+	MsgA11yLinesOne       = "a11y_lines_one"
+	MsgA11yItems          = "a11y_items"
+	MsgA11yItemsOne       = "a11y_items_one"
+	MsgA11yColumnCount    = "a11y_column_count"
+	MsgA11yColumnCountOne = "a11y_column_count_one"
+	MsgA11yRowCount       = "a11y_row_count"
+	MsgA11yRowCountOne    = "a11y_row_count_one"
+	MsgA11yHeadings       = "a11y_headings"
+	MsgA11yHeadingsOne    = "a11y_headings_one"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:                 "usage: md_render [options] < file.md\n       md_render [options] --diff OLD.md [NEW.md]",
+		MsgReadStdinFailed:       "read from stdin failed: %v",
+		MsgTerminalWidthFallback: "cannot get terminal width, falling back to %d: %v",
+		MsgUnknownTheme:          "unknown theme %q (available: %s)",
+		MsgUnknownHighlight:      "unknown highlight style %q (available: none, %s)",
+		MsgBadColor:              "invalid color %q (use a name like green / hiblue or #rrggbb)",
+		MsgCalloutNote:           "Note",
+		MsgCalloutTip:            "Tip",
+		MsgCalloutImportant:      "Important",
+		MsgCalloutWarning:        "Warning",
+		MsgCalloutCaution:        "Caution",
+		MsgViewerStatus:          " %d/%d  j/k move · space/b page · [/] heading · : jump · n/N details · Enter expand · r run · a apply · q quit ",
+		MsgViewerPrompt:          "jump to (number or title): ",
+		MsgViewerNoHeading:       " no heading matches %q ",
+		MsgTOCTitle:              "Contents",
+		MsgStyleReadFailed:       "cannot read style sheet %s: %v",
+		MsgStyleSyntax:           "%s:%d: syntax error near %q",
+		MsgStyleUnknownSelector:  "%s:%d: unknown selector %q (use h1-h6, heading, code, code.<language>, link, table, th, quote)",
+		MsgStyleBadDeclaration:   "%s:%d: unknown property or invalid value in %q",
+		MsgSectionNotFound:       "no section matches %q",
+		MsgReadFileFailed:        "read %s failed: %v",
+		MsgDiffSummary:           "_Diff: %d words added, %d removed_",
+		MsgFrontMatterField:      "Field",
+		MsgFrontMatterValue:      "Value",
+		MsgRunNoBlock:            " move the cursor onto a code block to run it ",
+		MsgRunUnsupported:        "cannot run %q code blocks (supported: shell, python, go)",
+		MsgRunConfirm:            " run `%s` in %s ? [y/N] ",
+		MsgRunFailed:             "run failed: %v",
+		MsgRunRunning:            " running…  j/k scroll · Ctrl-C stop · q back ",
+		MsgRunExited:             " exited with status %d  j/k scroll · q back ",
+		MsgRunStopped:            " stopped (%v)  j/k scroll · q back ",
+		MsgSaveNoFiles:           "no code block names a file (use ```go title=main.go or a line like \"In file main.go:\" before the block)",
+		MsgSaveOverwrite:         "%s exists, overwrite? [y/N/a(ll)/q(uit)] ",
+		MsgSaveWritten:           "written  %s (%d lines)",
+		MsgSaveSkipped:           "skipped  %s (exists)",
+		MsgSaveUnsafePath:        "skipped  %s (outside the target directory)",
+		MsgSaveSummary:           "%d file(s) written, %d skipped",
+		MsgLintTitle:             "%s reported problems in this code",
+		MsgLintMore:              "… %d more",
+		MsgLintMissing:           "%s not found, %s code blocks are not checked",
+		MsgLintTimeout:           "check did not finish within %v",
+		MsgFormatMissing:         "%s not found, %s code blocks are not formatted",
+		MsgApplyNoBlock:          " move the cursor onto a code block to apply it ",
+		MsgApplyNoSuggestion:     " the text before this block names no file and line (like \"change line 12 of main.go to:\") ",
+		MsgApplyOutOfRange:       "%s has no lines %d-%d (%d lines)",
+		MsgApplyTitle:            "%s  lines %d-%d",
+		MsgApplyShifted:          "(the answer gave other line numbers; matched by content)",
+		MsgApplyConfirm:          " apply this change? [y/N]  j/k scroll ",
+		MsgApplyChanged:          "%s changed in the meantime",
+		MsgApplyFailed:           "cannot apply the change: %v",
+		MsgApplyDone:             " changed %s lines %d-%d · u undo ",
+		MsgApplyUndone:           " restored %s ",
+		MsgApplyNothingToUndo:    " nothing to undo ",
+		MsgGRPCUnsupportedArgs:   "--view, --json, --save-files and --diff are not available over gRPC",
+		MsgFilterUnknown:         "unknown filter %q (extract-code, format)",
+		MsgFilterBadInput:        "invalid filter input: %v",
+		MsgFilterNoCode:          "the text has no code blocks",
+		MsgA11yHeading:           "Heading level %d: %s",
+		MsgA11yContents:          "%s, %s:",
+		MsgA11yContentsEnd:       "End of contents.",
+		MsgA11yCode:              "Code block, %s, %s:",
+		MsgA11yCodePlain:         "Code block, %s:",
+		MsgA11yCodeEnd:           "End of code block.",
+		MsgA11yList:              "List, %s:",
+		MsgA11yOrderedList:       "Numbered list, %s:",
+		MsgA11yDefinitions:       "Definition list:",
+		MsgA11yTerm:              "Term: %s",
+		MsgA11yListEnd:           "End of list.",
+		MsgA11yTaskDone:          "done",
+		MsgA11yTaskOpen:          "not done",
+		MsgA11yQuote:             "Quote",
+		MsgA11yQuoteEnd:          "End of quote.",
+		MsgA11yTable:             "Table, %s, %s:",
+		MsgA11yColumns:           "Columns: %s",
+		MsgA11yRow:               "Row %d: %s",
+		MsgA11yTableEnd:          "End of table.",
+		MsgA11ySeparator:         "Separator.",
+		MsgA11yLink:              "link: %s",
+		MsgA11yImage:             "Image: %s",
+		MsgA11yMath:              "Formula: %s",
+		MsgA11yStruck:            "struck out",
+		MsgA11yAdded:             "added",
+		MsgA11yRemoved:           "removed",
+
+		MsgA11yLines:          "%d lines",
+		MsgA11yLinesOne:       "%d line",
+		MsgA11yItems:          "%d items",
+		MsgA11yItemsOne:       "%d item",
+		MsgA11yColumnCount:    "%d columns",
+		MsgA11yColumnCountOne: "%d column",
+		MsgA11yRowCount:       "%d rows",
+		MsgA11yRowCountOne:    "%d row",
+		MsgA11yHeadings:       "%d headings",
+		MsgA11yHeadingsOne:    "%d heading",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:                 "用法: md_render [选项] < file.md\n      md_render [选项] --diff OLD.md [NEW.md]",
+		MsgReadStdinFailed:       "读取标准输入失败: %v",
+		MsgTerminalWidthFallback: "无法获取终端宽度，使用默认值%d: %v",
+		MsgUnknownTheme:          "未知主题 %q（可选: %s）",
+		MsgUnknownHighlight:      "未知的高亮配色 %q（可选: none、%s）",
+		MsgBadColor:              "无效的颜色 %q（使用 green / hiblue 这类颜色名或 #rrggbb）",
+		MsgCalloutNote:           "注意",
+		MsgCalloutTip:            "提示",
+		MsgCalloutImportant:      "重要",
+		MsgCalloutWarning:        "警告",
+		MsgCalloutCaution:        "当心",
+		MsgViewerStatus:          " %d/%d  j/k 移动 · 空格/b 翻页 · [/] 标题 · : 跳转 · n/N 折叠块 · Enter 展开 · r 运行 · a 应用修改 · q 退出 ",
+		MsgViewerPrompt:          "跳转到（章节编号或标题）: ",
+		MsgViewerNoHeading:       " 没有匹配 %q 的标题 ",
+		MsgTOCTitle:              "目录",
+		MsgStyleReadFailed:       "无法读取样式表 %s: %v",
+		MsgStyleSyntax:           "%s:%d: 语法错误，位于 %q 附近",
+		MsgStyleUnknownSelector:  "%s:%d: 未知选择器 %q（可用 h1-h6、heading、code、code.<语言>、link、table、th、quote）",
+		MsgStyleBadDeclaration:   "%s:%d: %q 中的属性未知或取值无效",
+		MsgSectionNotFound:       "没有匹配 %q 的章节",
+		MsgReadFileFailed:        "读取 %s 失败: %v",
+		MsgDiffSummary:           "_差异：新增 %d 个词，删除 %d 个词_",
+		MsgFrontMatterField:      "字段",
+		MsgFrontMatterValue:      "值",
+		MsgRunNoBlock:            " 把光标移到代码块上再运行 ",
+		MsgRunUnsupported:        "无法运行 %q 代码块（支持 shell、python、go）",
+		MsgRunConfirm:            " 在 %[2]s 中执行 `%[1]s` ？[y/N] ",
+		MsgRunFailed:             "运行失败: %v",
+		MsgRunRunning:            " 运行中…  j/k 滚动 · Ctrl-C 停止 · q 返回 ",
+		MsgRunExited:             " 已退出，状态码 %d  j/k 滚动 · q 返回 ",
+		MsgRunStopped:            " 已停止（%v）  j/k 滚动 · q 返回 ",
+		MsgSaveNoFiles:           "没有标明文件名的代码块（使用 ```go title=main.go，或在代码块前写一行 \"In file main.go:\"）",
+		MsgSaveOverwrite:         "%s 已存在，是否覆盖？[y/N/a(全部)/q(停止)] ",
+		MsgSaveWritten:           "已写入  %s（%d 行）",
+		MsgSaveSkipped:           "已跳过  %s（文件已存在）",
+		MsgSaveUnsafePath:        "已跳过  %s（位于目标目录之外）",
+		MsgSaveSummary:           "写入 %d 个文件，跳过 %d 个",
+		MsgLintTitle:             "%s 发现这段代码有问题",
+		MsgLintMore:              "… 另有 %d 条",
+		MsgLintMissing:           "未找到 %s，不检查 %s 代码块",
+		MsgLintTimeout:           "检查未能在 %v 内完成",
+		MsgFormatMissing:         "未找到 %s，不格式化 %s 代码块",
+		MsgApplyNoBlock:          " 把光标移到代码块上再应用修改 ",
+		MsgApplyNoSuggestion:     " 代码块前的文字没有写明文件与行号（如 \"把 main.go 第 12 行改为：\"） ",
+		MsgApplyOutOfRange:       "%s 没有第 %d-%d 行（共 %d 行）",
+		MsgApplyTitle:            "%s  第 %d-%d 行",
+		MsgApplyShifted:          "（回答中的行号与文件不符，已按内容定位）",
+		MsgApplyConfirm:          " 应用这处修改？[y/N]  j/k 滚动 ",
+		MsgApplyChanged:          "%s 在此期间已被修改",
+		MsgApplyFailed:           "无法应用修改：%v",
+		MsgApplyDone:             " 已修改 %s 第 %d-%d 行 · u 撤销 ",
+		MsgApplyUndone:           " 已恢复 %s ",
+		MsgApplyNothingToUndo:    " 没有可撤销的修改 ",
+		MsgGRPCUnsupportedArgs:   "通过 gRPC 调用时不支持 --view、--json、--save-files 与 --diff",
+		MsgFilterUnknown:         "未知的过滤器 %q（extract-code、format）",
+		MsgFilterBadInput:        "过滤器输入无效: %v",
+		MsgFilterNoCode:          "文本中没有代码块",
+		MsgA11yHeading:           "%d 级标题：%s",
+		MsgA11yContents:          "%s，%s：",
+		MsgA11yContentsEnd:       "目录结束。",
+		MsgA11yCode:              "代码块，%s，%s：",
+		MsgA11yCodePlain:         "代码块，%s：",
+		MsgA11yCodeEnd:           "代码块结束。",
+		MsgA11yList:              "列表，%s：",
+		MsgA11yOrderedList:       "编号列表，%s：",
+		MsgA11yDefinitions:       "定义列表：",
+		MsgA11yTerm:              "术语：%s",
+		MsgA11yListEnd:           "列表结束。",
+		MsgA11yTaskDone:          "已完成",
+		MsgA11yTaskOpen:          "未完成",
+		MsgA11yQuote:             "引用",
+		MsgA11yQuoteEnd:          "引用结束。",
+		MsgA11yTable:             "表格，%s，%s：",
+		MsgA11yColumns:           "列：%s",
+		MsgA11yRow:               "第 %d 行：%s",
+		MsgA11yTableEnd:          "表格结束。",
+		MsgA11ySeparator:         "分隔线。",
+		MsgA11yLink:              "链接：%s",
+		MsgA11yImage:             "图片：%s",
+		MsgA11yMath:              "公式：%s",
+		MsgA11yStruck:            "删除线",
+		MsgA11yAdded:             "新增",
+		MsgA11yRemoved:           "删除",
+
+		MsgA11yLines:          "%d 行",
+		MsgA11yLinesOne:       "%d 行",
+		MsgA11yItems:          "%d 项",
+		MsgA11yItemsOne:       "%d 项",
+		MsgA11yColumnCount:    "%d 列",
+		MsgA11yColumnCountOne: "%d 列",
+		MsgA11yRowCount:       "%d 行",
+		MsgA11yRowCountOne:    "%d 行",
+		MsgA11yHeadings:       "共 %d 个标题",
+		MsgA11yHeadingsOne:    "共 %d 个标题",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
+
+// Tn 带数量的文案：n 为 1 时使用单数形式 one，否则使用 many，n 作为唯一的参数
+func Tn(n int, one, many string) string {
+	if n == 1 {
+		return T(one, n)
+	}
+	return T(many, n)
+}
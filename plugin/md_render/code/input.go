@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// ResetSequence 复位所有 ANSI 样式，避免中断后终端残留颜色
+const ResetSequence = "\033[0m"
+
+// InterruptExitCode 被 Ctrl-C 中断时的退出码（与 shell 对 SIGINT 的约定一致）
+const InterruptExitCode = 130
+
+// readInput 读取 stdin 全部内容。
+// 上游（如流式输出的 AI 回答）还没写完时用户按下 Ctrl-C，返回已读到的部分并置 interrupted，
+// 让调用方照常渲染出这部分内容，而不是直接丢弃。
+func readInput(interrupt <-chan os.Signal) (data []byte, interrupted bool, err error) {
+	var (
+		mu  sync.Mutex
+		buf bytes.Buffer
+	)
+	done := make(chan error, 1)
+	go func() {
+		chunk := make([]byte, 32<<10)
+		for {
+			n, rerr := os.Stdin.Read(chunk)
+			mu.Lock()
+			buf.Write(chunk[:n])
+			mu.Unlock()
+			if rerr == io.EOF {
+				done <- nil
+				return
+			}
+			if rerr != nil {
+				done <- rerr
+				return
+			}
+		}
+	}()
+
+	select {
+	case err = <-done:
+	case <-interrupt:
+		interrupted = true
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return bytes.Clone(buf.Bytes()), interrupted, err
+}
+
+// resetOnInterrupt 渲染输出阶段再收到 Ctrl-C 时复位样式后退出
+func resetOnInterrupt(interrupt <-chan os.Signal) {
+	go func() {
+		<-interrupt
+		os.Stdout.WriteString(ResetSequence + "\n")
+		os.Exit(InterruptExitCode)
+	}()
+}
+
+// notifyInterrupt 接管 SIGINT
+func notifyInterrupt() chan os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+	return ch
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+)
+
+// documentJSON --json 的输出结构，供提取代码块等功能使用
+type documentJSON struct {
+	CodeBlocks []codeBlockJSON `json:"code_blocks"`
+}
+
+type codeBlockJSON struct {
+	Index    int    `json:"index"` // 从 1 开始
+	Language string `json:"language,omitempty"`
+	Detected bool   `json:"detected,omitempty"` // 语言由内容推测，而非代码块标注
+	Filename string `json:"filename,omitempty"` // 围栏或前一段文字标明的文件名
+	Code     string `json:"code"`
+}
+
+// printJSON 输出文档中的代码块；没有语言标注的代码块给出推测的语言
+func printJSON(content string) error {
+	_, body, ok := splitFrontMatter(content)
+	if !ok {
+		body = content
+	}
+	doc := documentJSON{CodeBlocks: []codeBlockJSON{}}
+	for i, b := range markdown.CodeBlocks(body) {
+		doc.CodeBlocks = append(doc.CodeBlocks, codeBlockJSON{
+			Index:    i + 1,
+			Language: b.Language,
+			Detected: b.Detected,
+			Filename: b.Filename,
+			Code:     b.Code,
+		})
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+	"wcp_jdata"
+)
+
+const (
+	// SettingLint setting 段中的检查开关，设为 on 时默认检查代码块（同 --lint）
+	SettingLint = "md_lint"
+	// SettingLintPrefix setting 段中某种语言的检查命令，如 md_lint_python: "ruff check"，
+	// 代码文件名追加在命令末尾；设为 off 时不检查该语言
+	SettingLintPrefix = "md_lint_"
+
+	// LintTimeout 单个代码块的检查时限
+	LintTimeout = 20 * time.Second
+	// MaxLintLines 每个代码块最多附上的诊断行数
+	MaxLintLines = 10
+)
+
+// lintCommands 各语言默认的检查命令，键为代码块语言标注（或推测出的语言）
+var lintCommands = map[string]codeRunner{
+	"go":         {"main.go", []string{"go", "vet", "main.go"}},
+	"golang":     {"main.go", []string{"go", "vet", "main.go"}},
+	"python":     {"snippet.py", []string{"ruff", "check", "--output-format=concise", "--no-cache", "snippet.py"}},
+	"python3":    {"snippet.py", []string{"ruff", "check", "--output-format=concise", "--no-cache", "snippet.py"}},
+	"py":         {"snippet.py", []string{"ruff", "check", "--output-format=concise", "--no-cache", "snippet.py"}},
+	"typescript": {"snippet.ts", []string{"tsc", "--noEmit", "--pretty", "false", "snippet.ts"}},
+	"ts":         {"snippet.ts", []string{"tsc", "--noEmit", "--pretty", "false", "snippet.ts"}},
+	"javascript": {"snippet.js", []string{"node", "--check", "snippet.js"}},
+	"js":         {"snippet.js", []string{"node", "--check", "snippet.js"}},
+}
+
+var (
+	goPackageRegexp = regexp.MustCompile(`(?m)^package \w+`)
+	backtickRegexp  = regexp.MustCompile("`+")
+	// lintNoiseRegexp go vet 输出的包名标题行，以及 node 附带的调用栈与版本行
+	lintNoiseRegexp = regexp.MustCompile(`^(# |at |Node\.js v\d)`)
+)
+
+// fencedBlock 源码中的一个围栏代码块，start、end 为开始与结束围栏所在行
+type fencedBlock struct {
+	indent string
+	info   string
+	code   string
+	start  int
+	end    int
+}
+
+// fencedBlocks 扫描源码中的围栏代码块（``` 或 ~~~，结束围栏不短于开始围栏）；
+// 与渲染器一致，没有结束围栏的不算代码块
+func fencedBlocks(lines []string) []fencedBlock {
+	var blocks []fencedBlock
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if !strings.HasPrefix(trimmed, "```") && !strings.HasPrefix(trimmed, "~~~") {
+			continue
+		}
+		fence := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, trimmed[:1]))]
+		block := fencedBlock{
+			indent: lines[i][:len(lines[i])-len(trimmed)],
+			info:   strings.TrimSpace(trimmed[len(fence):]),
+			start:  i,
+		}
+		var code []string
+		for end := i + 1; end < len(lines); end++ {
+			if closing := strings.TrimSpace(lines[end]); strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+				block.end = end
+				break
+			}
+			code = append(code, strings.TrimPrefix(lines[end], block.indent))
+		}
+		if block.end == 0 {
+			continue
+		}
+		block.code = strings.Join(code, "\n") + "\n"
+		blocks = append(blocks, block)
+		i = block.end
+	}
+	return blocks
+}
+
+// blockLanguage 代码块的语言：围栏标注（去掉 :文件名），未标注时按内容推测
+func blockLanguage(block fencedBlock) string {
+	if fields := strings.Fields(block.info); len(fields) > 0 {
+		language, _, _ := strings.Cut(fields[0], ":")
+		return language
+	}
+	return markdown.DetectLanguage(block.code)
+}
+
+// lintCodeBlocks 用各语言的检查命令检查文档中的代码块，把诊断信息以 [!WARNING] 提示块
+// 插在出问题的代码块之后；检查工具未安装时在 stderr 提示一次并跳过该语言
+func lintCodeBlocks(content string) string {
+	lines := strings.Split(content, "\n")
+	blocks := fencedBlocks(lines)
+	missing := map[string]bool{}
+	var out []string
+	next := 0
+	for _, block := range blocks {
+		language := blockLanguage(block)
+		linter, ok := lookupLinter(strings.ToLower(language))
+		if !ok || missing[linter.argv[0]] {
+			continue
+		}
+		if linter.file == "main.go" && !goPackageRegexp.MatchString(block.code) {
+			// 没有 package 子句的 Go 片段无法单独检查
+			continue
+		}
+		diagnostics, err := runLinter(linter, block.code)
+		if errors.Is(err, exec.ErrNotFound) {
+			missing[linter.argv[0]] = true
+			fmt.Fprintln(os.Stderr, T(MsgLintMissing, linter.argv[0], language))
+			continue
+		}
+		if err != nil {
+			diagnostics = append(diagnostics, err.Error())
+		}
+		if len(diagnostics) == 0 {
+			continue
+		}
+		out = append(out, lines[next:block.end+1]...)
+		next = block.end + 1
+		out = append(out, lintNote(block.indent, strings.Join(linter.argv[:len(linter.argv)-1], " "), diagnostics)...)
+	}
+	if next == 0 {
+		return content
+	}
+	return strings.Join(append(out, lines[next:]...), "\n")
+}
+
+// lookupLinter 某种语言的检查命令：setting 段的 md_lint_<语言> 优先于默认命令
+func lookupLinter(language string) (codeRunner, bool) {
+	linter, ok := lintCommands[language]
+	if language == "" {
+		return linter, false
+	}
+	custom := strings.TrimSpace(jdata.Setting(SettingLintPrefix + language))
+	if custom == "" {
+		return linter, ok
+	}
+	if settingOff(SettingLintPrefix + language) {
+		return linter, false
+	}
+	file := linter.file
+	if file == "" {
+		file = "snippet." + language
+	}
+	return codeRunner{file, append(strings.Fields(custom), file)}, true
+}
+
+// runLinter 在临时目录中检查代码，命令失败时返回整理后的诊断行（去掉临时目录前缀与空行）
+func runLinter(linter codeRunner, code string) ([]string, error) {
+	dir, err := os.MkdirTemp("", RunDirPattern)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, linter.file), []byte(code), 0o600); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), LintTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, linter.argv[0], linter.argv[1:]...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return nil, nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, errors.New(T(MsgLintTimeout, LintTimeout))
+	case !errors.As(err, &exitErr):
+		return nil, err
+	}
+	var diagnostics []string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, dir+string(filepath.Separator), ""))
+		if line == "" || lintNoiseRegexp.MatchString(line) {
+			continue
+		}
+		diagnostics = append(diagnostics, line)
+	}
+	if len(diagnostics) == 0 {
+		diagnostics = append(diagnostics, err.Error())
+	}
+	return diagnostics, nil
+}
+
+// lintNote 诊断信息的 [!WARNING] 提示块，每行一个行内代码，超出 MaxLintLines 的部分只给出条数
+func lintNote(indent, command string, diagnostics []string) []string {
+	note := []string{"", indent + "> [!WARNING] " + T(MsgLintTitle, command)}
+	for i, line := range diagnostics {
+		if i == MaxLintLines {
+			note = append(note, indent+"> "+T(MsgLintMore, len(diagnostics)-MaxLintLines))
+			break
+		}
+		note = append(note, indent+"> "+codeSpan(line)+"  ")
+	}
+	return append(note, "")
+}
+
+// codeSpan 把文本包成行内代码，反引号分隔符比文本中最长的连续反引号多一个
+func codeSpan(s string) string {
+	longest := 0
+	for _, run := range backtickRegexp.FindAllString(s, -1) {
+		longest = max(longest, len(run))
+	}
+	fence := strings.Repeat("`", longest+1)
+	if longest > 0 {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
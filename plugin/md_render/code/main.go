@@ -1,13 +1,19 @@
 package main
 
 import (
+	_ "embed"
+	"errors"
+	"flag"
 	"fmt"
-	"io"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"time"
 
 	markdown "github.com/MichaelMure/go-term-markdown"
 	"golang.org/x/term"
+	"wcp_jdata"
 )
 
 const (
@@ -18,18 +24,233 @@ const (
 	IndentDivisor        = 20  // 缩进计算除数（宽度/20）
 	MinIndent            = 2   // 最小缩进
 	MaxIndent            = 8   // 最大缩进
+
+	// DefaultTOCDepth --toc 默认收录到三级标题
+	DefaultTOCDepth = 3
 )
 
+// errUsage 命令行参数有误，用法说明已输出
+var errUsage = errors.New("usage")
+
+// renderOptions 渲染开关，来自命令行参数与 config.yaml 的 setting 段（参数优先）
+type renderOptions struct {
+	emoji    bool                                             // 把 :shortcode: 转换为 emoji
+	styles   styleSet                                         // 主题与样式表决定的各元素样式
+	view     bool                                             // 全屏交互查看
+	toc      bool                                             // 在开头输出带章节编号的目录
+	tocDepth int                                              // 目录收录的最深标题级别
+	details  markdown.DetailsState                            // <details> 折叠状态，仅查看器使用
+	headings func(markdown.Heading)                           // 接收渲染出的标题位置，仅查看器使用
+	blocks   func(block markdown.CodeBlock, line, height int) // 接收渲染出的代码块位置，仅查看器使用
+	section  string                                           // 只渲染该章节：章节编号或标题关键字
+	numbers  []int                                            // 第一个标题的章节编号，截取章节时沿用原文档的编号
+	diffOld  string                                           // 与该文件逐词比较，输出标出增删的合并文档
+	diffNew  string                                           // 比较的新版本文件，为空时读取 stdin
+	noMeta   bool                                             // 去掉开头的 YAML 元数据，而不是渲染为表格
+	json     bool                                             // 不渲染，输出文档结构（代码块及其语言）的 JSON
+	saveDir  string                                           // 不渲染，把标明文件名的代码块写入该目录
+	lint     bool                                             // 检查代码块并在其后附上诊断信息
+	format   bool                                             // 渲染前用格式化工具重排代码块
+	a11y     bool                                             // 无障碍模式：输出供读屏软件朗读的线性纯文本
+}
+
+// defaultRenderOptions 不读取任何配置时的默认渲染开关（金样测试使用）
+func defaultRenderOptions() renderOptions {
+	return renderOptions{emoji: true, styles: themes[DefaultTheme].resolve(), tocDepth: DefaultTOCDepth}
+}
+
+// parseArgs md_render [--json | --save-files DIR] [--format] [--lint] [--no-emoji] [--no-frontmatter] [--theme NAME] [--style PATH|NAME] [--highlight STYLE] [--section QUERY] [--toc [--toc-depth N]] [--view] [--accessible] [--diff OLD [NEW]]
+func parseArgs(args []string) (renderOptions, error) {
+	opts := defaultRenderOptions()
+	opts.emoji = !settingOff(SettingEmoji)
+	opts.lint = settingOn(SettingLint)
+	opts.format = settingOn(SettingFormat)
+	opts.a11y = accessibleMode()
+
+	fs := flag.NewFlagSet("md_render", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, T(MsgUsage)); fs.PrintDefaults() }
+	noEmoji := fs.Bool("no-emoji", false, "keep :shortcode: text instead of converting it to emoji")
+	themeName := fs.String("theme", "", "color theme: "+strings.Join(themeNames(), ", "))
+	styleSheet := fs.String("style", "", "CSS-like style sheet applied on top of the theme: a file path, or NAME for "+StylesDir+"/NAME.css in the data directory")
+	highlight := fs.String("highlight", "", "chroma `STYLE` for syntax highlighting of code blocks (like monokai, github, solarized-dark), or none")
+	fs.StringVar(&opts.section, "section", "", "render only the section whose number (like 2.1) or title matches, with its subsections")
+	fs.StringVar(&opts.diffOld, "diff", "", "compare with the `OLD` markdown file word by word; the new version is the NEW argument or stdin")
+	fs.BoolVar(&opts.json, "json", false, "print the code blocks (with their language, guessed when untagged) as JSON instead of rendering")
+	fs.StringVar(&opts.saveDir, "save-files", "", "write the code blocks that name a file (go:main.go or title=main.go in the fence, or an \"In file main.go:\" line before the block) under `DIR` instead of rendering")
+	fs.BoolVar(&opts.format, "format", opts.format, "reformat code blocks with gofmt / black / prettier (or the md_format_<language> setting) before highlighting")
+	fs.BoolVar(&opts.lint, "lint", opts.lint, "check code blocks with go vet / ruff / tsc / node --check (or the md_lint_<language> setting) and show the problems under each block")
+	fs.BoolVar(&opts.noMeta, "no-frontmatter", false, "hide the leading YAML front matter instead of rendering it as a table")
+	fs.BoolVar(&opts.view, "view", false, "open an interactive full-screen viewer")
+	fs.BoolVar(&opts.a11y, "accessible", opts.a11y, "screen-reader friendly plain text: no color or box drawing, structure announced in words (also J_ACCESSIBLE=1)")
+	fs.BoolVar(&opts.toc, "toc", false, "prepend a numbered table of contents")
+	fs.IntVar(&opts.tocDepth, "toc-depth", DefaultTOCDepth, "deepest heading level listed in the table of contents")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return opts, err
+		}
+		// flag 包已输出错误与用法
+		return opts, errUsage
+	}
+	switch {
+	case fs.NArg() == 1 && opts.diffOld != "":
+		opts.diffNew = fs.Arg(0)
+	case fs.NArg() > 0:
+		fs.Usage()
+		return opts, errUsage
+	}
+	if *noEmoji {
+		opts.emoji = false
+	}
+	if *themeName == "" {
+		*themeName = jdata.Setting(SettingTheme)
+	}
+	if *themeName != "" {
+		t, err := lookupTheme(*themeName)
+		if err != nil {
+			return opts, err
+		}
+		opts.styles = t.resolve()
+	}
+	if err := applyHighlightSettings(&opts.styles); err != nil {
+		return opts, err
+	}
+	if *styleSheet == "" {
+		*styleSheet = jdata.Setting(SettingStyle)
+	}
+	if *styleSheet != "" {
+		if err := loadStyleSheet(&opts.styles, *styleSheet); err != nil {
+			return opts, err
+		}
+	}
+	if *highlight != "" {
+		if err := opts.styles.setHighlight("", *highlight); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help md_render 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
 func main() {
-	inputBytes, err := io.ReadAll(os.Stdin)
+	if len(os.Args) == 2 && os.Args[1] == HelpMarkdownFlag {
+		fmt.Print(helpDoc)
+		return
+	}
+	if len(os.Args) == 2 && os.Args[1] == CapabilitiesFlag {
+		if err := printCapabilities(); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) == 3 && os.Args[1] == FilterFlag {
+		os.Exit(runFilter(os.Args[2]))
+	}
+	if launchedAsGRPCPlugin() {
+		serveGRPC()
+		return
+	}
+	start := time.Now()
+	opts, err := parseArgs(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if !errors.Is(err, errUsage) {
+			log.Println(err)
+		}
+		os.Exit(2)
+	}
+	interrupt := notifyInterrupt()
+	inputBytes, interrupted, err := readDocument(opts, interrupt)
 	if err != nil {
-		log.Println("read from stdin failed, err:", err)
+		log.Println(err)
+		os.Exit(1)
+	}
+	content := normalizeInput(string(inputBytes))
+	if opts.diffOld != "" {
+		old, err := os.ReadFile(opts.diffOld)
+		if err != nil {
+			log.Println(T(MsgReadFileFailed, opts.diffOld, err))
+			os.Exit(1)
+		}
+		merged, added, removed := diffMarkdown(normalizeInput(string(old)), content)
+		content = T(MsgDiffSummary, added, removed) + "\n\n" + merged
+	}
+	if opts.section != "" {
+		if content, opts.numbers, err = selectSection(content, opts.section); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+	}
+	if opts.format && !interrupted {
+		content = formatCodeBlocks(content)
+	}
+
+	if opts.saveDir != "" {
+		if err := saveFiles(content, opts.saveDir); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if opts.json {
+		if err := printJSON(content); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
 		return
 	}
-	content := string(inputBytes)
 
-	width := getTerminalWidth()
+	if opts.lint && !interrupted {
+		content = lintCodeBlocks(content)
+	}
+
+	// 无障碍模式不进入全屏查看器（读屏软件无法跟随），直接输出线性文本
+	if opts.view && !opts.a11y && !interrupted {
+		signal.Stop(interrupt)
+		if err := runViewer(content, opts); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		recordRender(len(inputBytes), time.Since(start))
+		return
+	}
+
+	resetOnInterrupt(interrupt)
+	result := renderMarkdown(content, getTerminalWidth(), opts)
+	fmt.Print(string(result))
+	recordRender(len(inputBytes), time.Since(start))
+	if interrupted {
+		fmt.Print(ResetSequence)
+		os.Exit(InterruptExitCode)
+	}
+}
+
+// readDocument 读取要渲染的文档：--diff 给出新版本文件时读取该文件，否则读取 stdin
+func readDocument(opts renderOptions, interrupt <-chan os.Signal) ([]byte, bool, error) {
+	if opts.diffNew != "" {
+		data, err := os.ReadFile(opts.diffNew)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s", T(MsgReadFileFailed, opts.diffNew, err))
+		}
+		return data, false, nil
+	}
+	data, interrupted, err := readInput(interrupt)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s", T(MsgReadStdinFailed, err))
+	}
+	return data, interrupted, nil
+}
 
+// renderMarkdown 按给定终端宽度渲染 Markdown，左侧缩进随宽度自适应；无障碍模式输出不折行的纯文本
+func renderMarkdown(content string, width int, opts renderOptions) []byte {
+	if opts.a11y {
+		return renderAccessible(renderFrontMatter(expandTabs(content), opts.noMeta), opts)
+	}
 	indent := width / IndentDivisor
 	if indent < MinIndent {
 		indent = MinIndent
@@ -37,15 +258,31 @@ func main() {
 	if indent > MaxIndent {
 		indent = MaxIndent
 	}
-
-	result := markdown.Render(content, width, indent)
-	fmt.Print(string(result))
+	content = renderFrontMatter(expandTabs(content), opts.noMeta)
+	options := append(opts.styles.options(), markdown.WithEmoji(opts.emoji), markdown.WithDetails(opts.details))
+	if opts.toc {
+		options = append(options, markdown.WithTOC(T(MsgTOCTitle), opts.tocDepth))
+	}
+	if opts.numbers != nil {
+		options = append(options, markdown.WithNumberingStart(opts.numbers))
+	}
+	if opts.headings != nil {
+		options = append(options, markdown.WithHeadingHook(opts.headings))
+	}
+	if opts.blocks != nil {
+		options = append(options, markdown.WithCodeBlockHook(opts.blocks))
+	}
+	if opts.diffOld != "" {
+		options = append(options, markdown.WithTextFilter(diffFilter()))
+		return replaceDiffMarkers(markdown.Render(content, width, indent, options...))
+	}
+	return markdown.Render(content, width, indent, options...)
 }
 
 func getTerminalWidth() int {
 	width, _, err := term.GetSize(int(os.Stdout.Fd()))
 	if err != nil {
-		log.Printf("无法获取终端宽度，使用默认值%d: %v", DefaultTerminalWidth, err)
+		log.Println(T(MsgTerminalWidthFallback, DefaultTerminalWidth, err))
 		return DefaultTerminalWidth
 	}
 	if width < MinTerminalWidth {
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/unicode/norm"
+)
+
+// TabWidth 渲染时制表符展开到的制表位间隔（与 CommonMark 一致）
+const TabWidth = 4
+
+// bom 字节顺序标记（U+FEFF），从 Windows 记事本或网页复制的内容中常见
+const bom = "\ufeff"
+
+// normalizeInput 统一读入文档的格式：去掉 BOM、CRLF 与单独的 CR 换成 LF、按 Unicode NFC 合并组合字符，
+// 否则 Windows 或网页复制来的内容会在行尾多出 \r、组合字符被拆开计算宽度，折行错乱
+func normalizeInput(text string) string {
+	if strings.Contains(text, bom) {
+		text = strings.ReplaceAll(text, bom, "")
+	}
+	if strings.Contains(text, "\r") {
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+		text = strings.ReplaceAll(text, "\r", "\n")
+	}
+	return norm.NFC.String(text)
+}
+
+// expandTabs 按 TabWidth 列的制表位把制表符展开为空格（按显示宽度计列）：渲染器把制表符当作 1 列，
+// 终端却跳到下一个制表位，对齐与折行都会错位。只在渲染前展开，--save 与 --json 输出保留原文
+func expandTabs(text string) string {
+	if !strings.Contains(text, "\t") {
+		return text
+	}
+	var b strings.Builder
+	b.Grow(len(text))
+	col := 0
+	for _, r := range text {
+		switch r {
+		case '\t':
+			n := TabWidth - col%TabWidth
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		case '\n':
+			b.WriteRune(r)
+			col = 0
+		default:
+			b.WriteRune(r)
+			col += runewidth.RuneWidth(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+)
+
+// RunDirPattern 运行代码块时临时目录的名称模式
+const RunDirPattern = "md_render-run-"
+
+// codeRunner 一种语言的运行方式：代码写入临时目录中的 file 后执行 argv
+type codeRunner struct {
+	file string
+	argv []string
+}
+
+// codeRunners 可运行的语言，键为代码块语言标注（或推测出的语言）
+var codeRunners = map[string]codeRunner{
+	"sh":      {"snippet.sh", []string{"sh", "snippet.sh"}},
+	"bash":    {"snippet.sh", []string{"bash", "snippet.sh"}},
+	"shell":   {"snippet.sh", []string{"bash", "snippet.sh"}},
+	"console": {"snippet.sh", []string{"bash", "snippet.sh"}},
+	"zsh":     {"snippet.sh", []string{"zsh", "snippet.sh"}},
+	"python":  {"snippet.py", []string{"python3", "snippet.py"}},
+	"python3": {"snippet.py", []string{"python3", "snippet.py"}},
+	"py":      {"snippet.py", []string{"python3", "snippet.py"}},
+	"go":      {"main.go", []string{"go", "run", "main.go"}},
+	"golang":  {"main.go", []string{"go", "run", "main.go"}},
+}
+
+// codeRun 一次代码块运行：确认前已准备好临时目录，确认后执行并收集输出
+type codeRun struct {
+	command string // 展示给用户的完整命令
+	dir     string
+	argv    []string
+
+	cmd     *exec.Cmd
+	events  chan runEvent
+	output  []string
+	running bool
+	err     error // 退出状态，nil 表示成功
+	top     int   // 输出第一行显示的行号
+	follow  bool  // 自动滚动到最新输出
+}
+
+// runEvent 运行过程中的一行输出，或结束事件
+type runEvent struct {
+	line string
+	done bool
+	err  error
+}
+
+// prepareRun 把代码写入新的临时目录，返回待确认的运行；语言不支持时返回错误
+func prepareRun(block markdown.CodeBlock) (*codeRun, error) {
+	runner, ok := codeRunners[strings.ToLower(block.Language)]
+	if !ok {
+		return nil, errors.New(T(MsgRunUnsupported, block.Language))
+	}
+	code := block.Code
+	if runner.file == "snippet.sh" {
+		code = stripPrompts(code)
+	}
+	dir, err := os.MkdirTemp("", RunDirPattern)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, runner.file), []byte(code), 0o600); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &codeRun{
+		command: strings.Join(runner.argv, " "),
+		dir:     dir,
+		argv:    runner.argv,
+		follow:  true,
+	}, nil
+}
+
+// stripPrompts 去掉终端示例中的 "$ " 提示符；含提示符时其余行视为示例输出，不执行
+func stripPrompts(code string) string {
+	lines := strings.Split(code, "\n")
+	var commands []string
+	for _, line := range lines {
+		if cmd, ok := strings.CutPrefix(strings.TrimLeft(line, " "), "$ "); ok {
+			commands = append(commands, cmd)
+		}
+	}
+	if len(commands) == 0 {
+		return code
+	}
+	return strings.Join(commands, "\n") + "\n"
+}
+
+// start 在临时目录中执行，标准输出与标准错误合并后逐行发送到 events
+func (run *codeRun) start() error {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	run.cmd = exec.Command(run.argv[0], run.argv[1:]...)
+	run.cmd.Dir = run.dir
+	run.cmd.Stdout = writer
+	run.cmd.Stderr = writer
+	// 独立进程组，停止时连同子进程一起结束
+	run.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := run.cmd.Start(); err != nil {
+		reader.Close()
+		writer.Close()
+		return err
+	}
+	writer.Close()
+	run.running = true
+	events := make(chan runEvent)
+	run.events = events
+	go func(cmd *exec.Cmd) {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+		for scanner.Scan() {
+			events <- runEvent{line: strings.TrimRight(scanner.Text(), "\r")}
+		}
+		if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, os.ErrClosed) {
+			events <- runEvent{line: err.Error()}
+		}
+		reader.Close()
+		events <- runEvent{done: true, err: cmd.Wait()}
+		close(events)
+	}(run.cmd)
+	return nil
+}
+
+// handle 处理运行事件
+func (run *codeRun) handle(e runEvent) {
+	if e.done {
+		run.running = false
+		run.err = e.err
+		run.events = nil
+		return
+	}
+	run.output = append(run.output, e.line)
+}
+
+// stop 结束仍在运行的进程（不等待，结束事件照常送达）
+func (run *codeRun) stop() {
+	if run.running && run.cmd != nil && run.cmd.Process != nil {
+		_ = syscall.Kill(-run.cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// close 结束进程并删除临时目录；仍在运行时在后台读完剩余输出后再删除
+func (run *codeRun) close() {
+	if !run.running || run.events == nil {
+		os.RemoveAll(run.dir)
+		return
+	}
+	run.stop()
+	go func(events <-chan runEvent) {
+		for range events {
+		}
+		os.RemoveAll(run.dir)
+	}(run.events)
+}
+
+// exitCode 结束后的退出码，无法获知时为 -1
+func (run *codeRun) exitCode() int {
+	var exitErr *exec.ExitError
+	switch {
+	case run.err == nil:
+		return 0
+	case errors.As(run.err, &exitErr):
+		return exitErr.ExitCode()
+	}
+	return -1
+}
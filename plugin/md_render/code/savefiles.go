@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+)
+
+// saveFiles 把标明了文件名的代码块写入 dir 下对应的路径：已存在的文件逐个确认是否覆盖
+// （无法打开终端时跳过），同一文件名出现多次时以最后一个代码块为准，最后输出汇总
+func saveFiles(content, dir string) error {
+	if _, body, ok := splitFrontMatter(content); ok {
+		content = body
+	}
+	// 同一文件只保留最后一个代码块，按首次出现的顺序写入
+	var order []string
+	latest := map[string]markdown.CodeBlock{}
+	var rejected []string
+	for _, b := range markdown.CodeBlocks(content) {
+		if b.Filename == "" {
+			continue
+		}
+		name, ok := safeRelativePath(b.Filename)
+		if !ok {
+			rejected = append(rejected, b.Filename)
+			continue
+		}
+		if _, seen := latest[name]; !seen {
+			order = append(order, name)
+		}
+		latest[name] = b
+	}
+	if len(order) == 0 && len(rejected) == 0 {
+		return errors.New(T(MsgSaveNoFiles))
+	}
+
+	prompt := newOverwritePrompt()
+	defer prompt.close()
+	written := 0
+	for _, name := range order {
+		path := filepath.Join(dir, name)
+		code := latest[name].Code
+		if !strings.HasSuffix(code, "\n") {
+			code += "\n"
+		}
+		if _, err := os.Stat(path); err == nil {
+			overwrite, stop := prompt.ask(path)
+			if stop {
+				break
+			}
+			if !overwrite {
+				fmt.Println(T(MsgSaveSkipped, path))
+				continue
+			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			return err
+		}
+		fmt.Println(T(MsgSaveWritten, path, strings.Count(code, "\n")))
+		written++
+	}
+	for _, name := range rejected {
+		fmt.Println(T(MsgSaveUnsafePath, name))
+	}
+	fmt.Println(T(MsgSaveSummary, written, len(order)-written+len(rejected)))
+	return nil
+}
+
+// safeRelativePath 只接受目标目录之内的相对路径，拒绝绝对路径与 .. 越界
+func safeRelativePath(name string) (string, bool) {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "~") {
+		return "", false
+	}
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return clean, true
+}
+
+// overwritePrompt 通过 /dev/tty 询问是否覆盖（stdin 通常是管道输入的文档）
+type overwritePrompt struct {
+	tty    *os.File
+	reader *bufio.Reader
+	all    bool // 已选择全部覆盖
+}
+
+func newOverwritePrompt() *overwritePrompt {
+	p := &overwritePrompt{}
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		p.tty, p.reader = tty, bufio.NewReader(tty)
+	}
+	return p
+}
+
+// ask 询问是否覆盖 path：y 覆盖，a 本次全部覆盖，q 停止写入后续文件，其余（含无法询问）为跳过
+func (p *overwritePrompt) ask(path string) (overwrite, stop bool) {
+	if p.all {
+		return true, false
+	}
+	if p.tty == nil {
+		return false, false
+	}
+	fmt.Fprint(p.tty, T(MsgSaveOverwrite, path))
+	line, _ := p.reader.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, false
+	case "a", "all":
+		p.all = true
+		return true, false
+	case "q", "quit":
+		return false, true
+	}
+	return false, false
+}
+
+func (p *overwritePrompt) close() {
+	if p.tty != nil {
+		p.tty.Close()
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+)
+
+var (
+	atxHeadingRegexp      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextUnderlineRegexp = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+)
+
+// sourceHeadings 扫描 Markdown 源码中的标题（代码块之外的 # 标题与 ===/--- 下划线标题），
+// Line 为源码行号，Number 与渲染结果中的章节编号一致
+func sourceHeadings(lines []string) []markdown.Heading {
+	var headings []markdown.Heading
+	var counters [6]int
+	add := func(level, line int, title string) {
+		counters[level-1]++
+		for i := level; i < len(counters); i++ {
+			counters[i] = 0
+		}
+		parts := make([]string, level)
+		for i := range parts {
+			parts[i] = strconv.Itoa(counters[i])
+		}
+		headings = append(headings, markdown.Heading{
+			Level:  level,
+			Number: strings.Join(parts, "."),
+			Title:  strings.TrimSpace(title),
+			Line:   line,
+		})
+	}
+
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		if m := atxHeadingRegexp.FindStringSubmatch(line); m != nil {
+			add(len(m[1]), i, m[2])
+			continue
+		}
+		if i > 0 && setextUnderlineRegexp.MatchString(line) && isSetextTitle(lines[i-1]) {
+			// 标题从上一行开始
+			level := 1
+			if strings.Contains(line, "-") {
+				level = 2
+			}
+			add(level, i-1, lines[i-1])
+		}
+	}
+	return headings
+}
+
+// isSetextTitle 判断 ===/--- 上方的一行能否作为标题（排除空行、列表、引用、表格等）
+func isSetextTitle(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || len(line)-len(strings.TrimLeft(line, " ")) > 3 {
+		return false
+	}
+	return !strings.ContainsAny(trimmed[:1], "#>-*+|`~") && !atxHeadingRegexp.MatchString(line)
+}
+
+// selectSection 截取 query 匹配的标题及其下属内容（直到下一个同级或更高级标题）；
+// query 为章节编号（如 2.1）时精确匹配，否则按标题模糊匹配。
+// 返回截取的源码与该标题的章节编号，用于让渲染结果沿用原文档的编号
+func selectSection(content, query string) (string, []int, error) {
+	if _, body, ok := splitFrontMatter(content); ok {
+		content = body
+	}
+	lines := strings.Split(content, "\n")
+	headings := sourceHeadings(lines)
+	h, ok := findHeading(headings, query)
+	if !ok {
+		return "", nil, fmt.Errorf("%s", T(MsgSectionNotFound, query))
+	}
+	end := len(lines)
+	for _, next := range headings {
+		if next.Line > h.Line && next.Level <= h.Level {
+			end = next.Line
+			break
+		}
+	}
+	var number []int
+	for _, part := range strings.Split(h.Number, ".") {
+		n, _ := strconv.Atoi(part)
+		number = append(number, n)
+	}
+	return strings.Join(lines[h.Line:end], "\n"), number, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+const (
+	// StatsEnv 设置为 1/true/on 时开启本地使用统计（优先级高于配置文件）
+	StatsEnv = "J_STATS"
+	// SettingStats setting 段中的统计开关
+	SettingStats = "stats"
+	// StatsFile 统计文件名，与 agent 插件共享
+	StatsFile = "stats.json"
+	// StatsCommand 本插件在统计文件中的命令名
+	StatsCommand = "md_render"
+)
+
+// usageStats 统计文件结构，与 agent 插件的 internal/stats 保持一致
+type usageStats struct {
+	Since    time.Time                  `json:"since"`
+	Commands map[string]*commandCounter `json:"commands"`
+	Render   renderCounter              `json:"render"`
+	// Usage agent 按 provider 累计的请求用量，本插件只原样保留
+	Usage json.RawMessage `json:"usage,omitempty"`
+}
+
+type commandCounter struct {
+	Count   int64 `json:"count"`
+	TotalMs int64 `json:"total_ms"`
+	MaxMs   int64 `json:"max_ms"`
+}
+
+type renderCounter struct {
+	Count      int64 `json:"count"`
+	TotalBytes int64 `json:"total_bytes"`
+	MaxBytes   int64 `json:"max_bytes"`
+}
+
+// statsEnabled 统计默认关闭，只有用户显式开启才记录，数据仅保存在本机
+func statsEnabled() bool {
+	v := os.Getenv(StatsEnv)
+	if v == "" {
+		v = jdata.Setting(SettingStats)
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "on", "yes":
+		return true
+	}
+	return false
+}
+
+// recordRender 累加一次渲染的输入体积与耗时，任何失败都静默忽略
+func recordRender(inputBytes int, elapsed time.Duration) {
+	if !statsEnabled() {
+		return
+	}
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return
+	}
+	path := filepath.Join(dir, StatsFile)
+	st := usageStats{}
+	if data, err := os.ReadFile(path); err == nil {
+		if json.Unmarshal(data, &st) != nil {
+			return
+		}
+	}
+	if st.Since.IsZero() {
+		st.Since = time.Now()
+	}
+	if st.Commands == nil {
+		st.Commands = map[string]*commandCounter{}
+	}
+	c := st.Commands[StatsCommand]
+	if c == nil {
+		c = &commandCounter{}
+		st.Commands[StatsCommand] = c
+	}
+	ms := elapsed.Milliseconds()
+	c.Count++
+	c.TotalMs += ms
+	c.MaxMs = max(c.MaxMs, ms)
+
+	size := int64(inputBytes)
+	st.Render.Count++
+	st.Render.TotalBytes += size
+	st.Render.MaxBytes = max(st.Render.MaxBytes, size)
+
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.MkdirAll(filepath.Dir(path), 0o755)
+	_ = os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+	"github.com/fatih/color"
+)
+
+// textStyle 文字的颜色与属性
+type textStyle struct {
+	fg, bg    string // 颜色名（green、hiblue 等）或 #rrggbb，空串表示不设置
+	bold      bool
+	italic    bool
+	underline bool
+	strike    bool
+	dim       bool
+}
+
+// headingLook 单级标题的完整样式
+type headingLook struct {
+	text      textStyle
+	prefix    string // 标题前的符号，如 "§"、"##"
+	numbering bool   // 显示章节编号（1.2）
+	rule      string // 非空时在标题下方整行重复该字符
+}
+
+// styleSet 解析后的完整样式：内置主题解析为 styleSet，样式表再逐项覆盖
+type styleSet struct {
+	headings    [6]headingLook
+	gutter      string      // 引用块左侧的竖条
+	quote       []textStyle // 引用块竖条，按嵌套层级排列，更深的层级沿用最后一项
+	code        textStyle   // 行内代码
+	link        textStyle   // 链接地址
+	tableBorder textStyle   // 表格边框
+	tableHeader textStyle   // 表头文字
+
+	highlight          string            // 代码块的高亮配色（chroma 样式名），为空时使用 pygments
+	highlightLanguages map[string]string // 各语言的高亮配色，键为小写的语言标注或 chroma 语言名，none 为不高亮
+}
+
+// colorNames 支持的颜色名，hi 前缀为高亮色
+var colorNames = map[string]color.Attribute{
+	"black": color.FgBlack, "red": color.FgRed, "green": color.FgGreen, "yellow": color.FgYellow,
+	"blue": color.FgBlue, "magenta": color.FgMagenta, "cyan": color.FgCyan, "white": color.FgWhite,
+	"hiblack": color.FgHiBlack, "hired": color.FgHiRed, "higreen": color.FgHiGreen, "hiyellow": color.FgHiYellow,
+	"hiblue": color.FgHiBlue, "himagenta": color.FgHiMagenta, "hicyan": color.FgHiCyan, "hiwhite": color.FgHiWhite,
+}
+
+// parseStyle 解析以空格分隔的样式写法，如 "green bold"、"#ff79c6 underline"、"bg:blue italic"：
+// 颜色为前景色，bg: 前缀为背景色，其余为 bold / italic / underline / strike / dim
+func parseStyle(spec string) (textStyle, error) {
+	var s textStyle
+	for _, word := range strings.Fields(strings.ToLower(spec)) {
+		switch word {
+		case "bold":
+			s.bold = true
+		case "italic":
+			s.italic = true
+		case "underline":
+			s.underline = true
+		case "strike":
+			s.strike = true
+		case "dim":
+			s.dim = true
+		default:
+			if bg, ok := strings.CutPrefix(word, "bg:"); ok {
+				if err := checkColor(bg); err != nil {
+					return s, err
+				}
+				s.bg = bg
+				continue
+			}
+			if err := checkColor(word); err != nil {
+				return s, err
+			}
+			s.fg = word
+		}
+	}
+	return s, nil
+}
+
+// mustParseStyle 解析内置主题中的样式，写法有误属于编码错误
+func mustParseStyle(spec string) textStyle {
+	s, err := parseStyle(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// checkColor 校验颜色名或 #rrggbb
+func checkColor(spec string) error {
+	if _, ok := colorNames[spec]; ok {
+		return nil
+	}
+	if _, ok := parseHex(spec); ok {
+		return nil
+	}
+	return fmt.Errorf("%s", T(MsgBadColor, spec))
+}
+
+// parseHex 解析 #rrggbb
+func parseHex(spec string) ([3]int, bool) {
+	hex, ok := strings.CutPrefix(spec, "#")
+	if !ok || len(hex) != 6 {
+		return [3]int{}, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return [3]int{}, false
+	}
+	return [3]int{int(v >> 16), int(v >> 8 & 0xff), int(v & 0xff)}, true
+}
+
+// sprint 转换为格式化函数；参数顺序为前景色、背景色、文字属性，#rrggbb 按终端的颜色深度降级
+func (s textStyle) sprint() func(a ...interface{}) string {
+	c := color.New()
+	if attr, ok := colorNames[s.fg]; ok {
+		c.Add(attr)
+	} else if rgb, ok := parseHex(s.fg); ok {
+		addColor(c, rgb, false)
+	}
+	if attr, ok := colorNames[s.bg]; ok {
+		c.Add(attr + color.BgBlack - color.FgBlack)
+	} else if rgb, ok := parseHex(s.bg); ok {
+		addColor(c, rgb, true)
+	}
+	for _, a := range []struct {
+		on   bool
+		attr color.Attribute
+	}{{s.bold, color.Bold}, {s.dim, color.Faint}, {s.italic, color.Italic}, {s.underline, color.Underline}, {s.strike, color.CrossedOut}} {
+		if a.on {
+			c.Add(a.attr)
+		}
+	}
+	return c.SprintFunc()
+}
+
+// isZero 未设置任何颜色或属性
+func (s textStyle) isZero() bool {
+	return s == textStyle{}
+}
+
+// options 转换为渲染器选项
+func (set styleSet) options() []markdown.Options {
+	headings := make([]markdown.HeadingStyle, len(set.headings))
+	for i, h := range set.headings {
+		headings[i] = markdown.HeadingStyle{
+			Color:     h.text.sprint(),
+			Prefix:    h.prefix,
+			Numbering: h.numbering,
+			Rule:      h.rule,
+		}
+	}
+	quote := make([]func(a ...interface{}) string, len(set.quote))
+	for i, q := range set.quote {
+		quote[i] = q.sprint()
+	}
+	styles := markdown.Styles{Code: set.code.sprint(), Link: set.link.sprint()}
+	if !set.tableBorder.isZero() {
+		styles.TableBorder = set.tableBorder.sprint()
+	}
+	if !set.tableHeader.isZero() {
+		styles.TableHeader = set.tableHeader.sprint()
+	}
+	options := []markdown.Options{
+		markdown.WithHeadingStyles(headings),
+		markdown.WithBlockquoteGutter(set.gutter, quote),
+		markdown.WithCallouts(callouts()),
+		markdown.WithStyles(styles),
+	}
+	if highlight := set.highlightOption(); highlight != nil {
+		options = append(options, highlight)
+	}
+	return options
+}
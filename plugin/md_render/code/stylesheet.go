@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"wcp_jdata"
+)
+
+const (
+	// SettingStyle setting 段中的样式表配置项，写法同 --style
+	SettingStyle = "md_style"
+	// StylesDir 具名样式表所在目录（位于数据目录下），--style NAME 对应其中的 NAME.css
+	StylesDir = "md_render/styles"
+)
+
+// styleSheetPath --style 的取值为名称时解析为数据目录下的样式表，含路径分隔符或 .css 后缀时视为文件路径
+func styleSheetPath(spec string) (string, error) {
+	if strings.ContainsRune(spec, filepath.Separator) || strings.HasSuffix(spec, ".css") {
+		return spec, nil
+	}
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, StylesDir, spec+".css"), nil
+}
+
+// loadStyleSheet 读取样式表并叠加到 set 上
+func loadStyleSheet(set *styleSet, spec string) error {
+	path, err := styleSheetPath(spec)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s", T(MsgStyleReadFailed, path, err))
+	}
+	return applyStyleSheet(set, path, string(data))
+}
+
+// applyStyleSheet 解析类 CSS 样式表：
+//
+//	h1, h2 { color: #bd93f9; font-weight: bold; border-bottom: "═" }
+//	code   { color: yellow; background: none; highlight: monokai }
+//	code.yaml { highlight: hr_high_contrast }
+//	quote  { border-left: "▌"; color: hiblack }
+//
+// 选择器为 h1~h6、heading（全部标题）、code、code.<语言>（只支持 highlight）、link、table、th、quote，
+// 未出现的元素保持主题样式；name 用于错误信息中的 文件:行号
+func applyStyleSheet(set *styleSet, name, source string) error {
+	source = stripComments(source)
+	line := 1
+	for {
+		open := strings.IndexByte(source, '{')
+		if open < 0 {
+			if strings.TrimSpace(source) != "" {
+				return styleError(MsgStyleSyntax, name, line+leadingLines(source), strings.TrimSpace(source))
+			}
+			return nil
+		}
+		selectors := source[:open]
+		selectorLine := line + leadingLines(selectors)
+		body := source[open+1:]
+		end := strings.IndexByte(body, '}')
+		if end < 0 {
+			return styleError(MsgStyleSyntax, name, selectorLine, strings.TrimSpace(selectors)+" {")
+		}
+		bodyLine := line + strings.Count(selectors, "\n")
+		var targets []func(property, value string) bool
+		for _, sel := range strings.Split(selectors, ",") {
+			sel = strings.ToLower(strings.TrimSpace(sel))
+			target := set.selector(sel)
+			if target == nil {
+				return styleError(MsgStyleUnknownSelector, name, selectorLine, sel)
+			}
+			targets = append(targets, target)
+		}
+		for _, decl := range strings.Split(body[:end], ";") {
+			declLine := bodyLine + leadingLines(decl)
+			bodyLine += strings.Count(decl, "\n")
+			decl = strings.TrimSpace(decl)
+			if decl == "" {
+				continue
+			}
+			property, value, ok := strings.Cut(decl, ":")
+			if !ok {
+				return styleError(MsgStyleSyntax, name, declLine, decl)
+			}
+			property = strings.ToLower(strings.TrimSpace(property))
+			value = strings.TrimSpace(value)
+			for _, target := range targets {
+				if !target(property, value) {
+					return styleError(MsgStyleBadDeclaration, name, declLine, decl)
+				}
+			}
+		}
+		line += strings.Count(source[:open+1+end+1], "\n")
+		source = body[end+1:]
+	}
+}
+
+// selector 返回选择器对应的属性设置函数，未知选择器返回 nil；
+// 设置函数在属性未知或取值无效时返回 false
+func (set *styleSet) selector(sel string) func(property, value string) bool {
+	switch sel {
+	case "heading":
+		return func(property, value string) bool {
+			for level := range set.headings {
+				if !set.headings[level].set(property, value) {
+					return false
+				}
+			}
+			return true
+		}
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return set.headings[sel[1]-'1'].set
+	case "code":
+		return func(property, value string) bool {
+			if property == "highlight" {
+				return set.setHighlight("", value) == nil
+			}
+			return set.code.set(property, value)
+		}
+	case "link":
+		return set.link.set
+	case "th":
+		return set.tableHeader.set
+	case "table":
+		return func(property, value string) bool {
+			if property == "border-color" {
+				property = "color"
+			}
+			return set.tableBorder.set(property, value)
+		}
+	case "quote":
+		return func(property, value string) bool {
+			if property == "border-left" {
+				bar, ok := glyph(value)
+				if ok && bar != "" {
+					set.gutter = bar
+				}
+				return ok && bar != ""
+			}
+			// 样式表中的引用样式作用于所有嵌套层级
+			quote := textStyle{}
+			if len(set.quote) > 0 {
+				quote = set.quote[0]
+			}
+			if !quote.set(property, value) {
+				return false
+			}
+			set.quote = []textStyle{quote}
+			return true
+		}
+	}
+	// code.<语言> 设置该语言代码块的高亮配色
+	if language, ok := strings.CutPrefix(sel, "code."); ok && language != "" {
+		return func(property, value string) bool {
+			return property == "highlight" && set.setHighlight(language, value) == nil
+		}
+	}
+	return nil
+}
+
+// set 设置标题属性：文字样式之外还支持 prefix、numbering、border-bottom（标题下方的分隔线）
+func (h *headingLook) set(property, value string) bool {
+	switch property {
+	case "prefix":
+		prefix, ok := unquote(value)
+		h.prefix = prefix
+		return ok
+	case "numbering":
+		switch strings.ToLower(value) {
+		case "on", "true", "yes":
+			h.numbering = true
+		case "off", "false", "no", "none":
+			h.numbering = false
+		default:
+			return false
+		}
+		return true
+	case "border-bottom":
+		rule, ok := glyph(value)
+		h.rule = rule
+		return ok
+	}
+	return h.text.set(property, value)
+}
+
+// set 设置文字样式属性：color、background、font-weight、font-style、text-decoration、opacity，
+// 以及直接使用主题写法的 style（如 style: "#ff79c6 bold"，覆盖此前的全部设置）
+func (s *textStyle) set(property, value string) bool {
+	value = strings.ToLower(value)
+	switch property {
+	case "style":
+		spec, ok := unquote(value)
+		parsed, err := parseStyle(spec)
+		if !ok || err != nil {
+			return false
+		}
+		*s = parsed
+	case "color", "background":
+		spec := value
+		if spec == "none" || spec == "inherit" {
+			spec = ""
+		} else if checkColor(spec) != nil {
+			return false
+		}
+		if property == "color" {
+			s.fg = spec
+		} else {
+			s.bg = spec
+		}
+	case "font-weight":
+		switch value {
+		case "bold", "bolder", "700", "800", "900":
+			s.bold = true
+		case "normal", "400":
+			s.bold = false
+		default:
+			return false
+		}
+	case "font-style":
+		switch value {
+		case "italic", "oblique":
+			s.italic = true
+		case "normal":
+			s.italic = false
+		default:
+			return false
+		}
+	case "text-decoration":
+		s.underline, s.strike = false, false
+		for _, word := range strings.Fields(value) {
+			switch word {
+			case "underline":
+				s.underline = true
+			case "line-through":
+				s.strike = true
+			case "none":
+			default:
+				return false
+			}
+		}
+	case "opacity":
+		switch value {
+		case "dim", "0.5", "50%":
+			s.dim = true
+		case "1", "100%", "normal":
+			s.dim = false
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// glyph 解析边框字符："═" 或 none，none 表示不绘制
+func glyph(value string) (string, bool) {
+	if strings.EqualFold(value, "none") {
+		return "", true
+	}
+	s, ok := unquote(value)
+	if !ok || utf8.RuneCountInString(s) != 1 {
+		return "", false
+	}
+	return s, true
+}
+
+// unquote 去掉成对的单引号或双引号，未加引号的值原样返回
+func unquote(value string) (string, bool) {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if value[len(value)-1] != value[0] {
+			return "", false
+		}
+		return value[1 : len(value)-1], true
+	}
+	return value, true
+}
+
+// stripComments 去掉 /* */ 注释，保留其中的换行以便行号不变
+func stripComments(source string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(source, "/*")
+		if start < 0 {
+			b.WriteString(source)
+			return b.String()
+		}
+		b.WriteString(source[:start])
+		end := strings.Index(source[start+2:], "*/")
+		if end < 0 {
+			b.WriteString(strings.Repeat("\n", strings.Count(source[start:], "\n")))
+			return b.String()
+		}
+		b.WriteString(strings.Repeat("\n", strings.Count(source[start:start+2+end], "\n")))
+		source = source[start+2+end+2:]
+	}
+}
+
+// leadingLines 统计文本开头第一个非空白字符之前的换行数
+func leadingLines(s string) int {
+	return strings.Count(s[:len(s)-len(strings.TrimLeft(s, " \t\r\n"))], "\n")
+}
+
+func styleError(key, name string, line int, detail string) error {
+	return fmt.Errorf("%s", T(key, name, line, detail))
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+)
+
+const (
+	// MaxSuggestionShift 回答中的行号与文件对不上时，向前后查找替换位置的最大行数
+	MaxSuggestionShift = 15
+	// SuggestionContext 差异预览中改动前后保留的上下文行数
+	SuggestionContext = 3
+)
+
+var (
+	// suggestionLinesRegexp 代码块前一段文字中的行号：line 12、lines 10-14、第 12 行、第 10 至 14 行
+	suggestionLinesRegexp = regexp.MustCompile(`(?i)\blines?\s+(\d+)(?:\s*(?:-|–|to|through)\s*(\d+))?|第\s*(\d+)\s*(?:(?:-|–|~|至|到)\s*(?:第\s*)?(\d+)\s*)?行`)
+	// suggestionFileRegexp 代码块前一段文字中的文件名（带扩展名的路径，或 Makefile / Dockerfile）
+	suggestionFileRegexp = regexp.MustCompile(`(?:^|[\s(])([\w.][\w./-]*\.[A-Za-z]\w*|[\w./-]*(?:Makefile|Dockerfile))\b`)
+)
+
+// suggestion 回答中“把文件 Y 的第 X 行改为”式的修改建议，代码块为替换后的内容
+type suggestion struct {
+	path       string
+	start, end int // 建议中写明的行号范围（从 1 开始，含两端）
+	code       []string
+}
+
+// parseSuggestion 从代码块及其前一段文字中读出修改建议，文字中没有文件名或行号时返回 false
+func parseSuggestion(block markdown.CodeBlock) (suggestion, bool) {
+	m := suggestionLinesRegexp.FindStringSubmatch(block.Caption)
+	if m == nil {
+		return suggestion{}, false
+	}
+	path := block.Filename
+	if path == "" {
+		f := suggestionFileRegexp.FindStringSubmatch(block.Caption)
+		if f == nil {
+			return suggestion{}, false
+		}
+		path = strings.TrimRight(f[1], ".")
+	}
+	start, _ := strconv.Atoi(m[1] + m[3])
+	end := start
+	if last := m[2] + m[4]; last != "" {
+		end, _ = strconv.Atoi(last)
+	}
+	if start < 1 || end < start {
+		return suggestion{}, false
+	}
+	code := strings.Split(strings.TrimSuffix(block.Code, "\n"), "\n")
+	return suggestion{path: path, start: start, end: end, code: code}, true
+}
+
+// applyPlan 待确认的修改：目标文件、实际替换的行与展示用的差异
+type applyPlan struct {
+	path       string
+	original   []byte
+	lines      []string
+	start, end int // 实际替换的行（从 0 开始，左闭右开）
+	shifted    bool
+	code       []string
+	diff       []string // 带行号与颜色的差异行
+	top        int      // 差异第一行显示的行号
+}
+
+// appliedChange 已写入的修改，用于撤销
+type appliedChange struct {
+	path     string
+	original []byte
+	written  []byte
+}
+
+// planSuggestion 读取目标文件并定位替换位置：写明的行号与文件内容对不上时
+// （回答基于旧版本的文件），在前后 MaxSuggestionShift 行内找与替换内容最吻合的位置
+func planSuggestion(s suggestion) (*applyPlan, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, errors.New(T(MsgReadFileFailed, s.path, err))
+	}
+	lines := strings.Split(string(data), "\n")
+	total := len(lines)
+	if total > 0 && lines[total-1] == "" {
+		// 末尾换行之后不算一行
+		total--
+	}
+	if s.end > total {
+		return nil, errors.New(T(MsgApplyOutOfRange, s.path, s.start, s.end, total))
+	}
+	start, size := s.start-1, s.end-s.start+1
+	best, bestScore := 0, 0
+	for shift := 0; shift <= MaxSuggestionShift; shift++ {
+		for _, d := range []int{shift, -shift} {
+			from := start + d
+			if from < 0 || from+size > total {
+				continue
+			}
+			if score := sharedWords(lines[from:from+size], s.code); score > bestScore {
+				best, bestScore = d, score
+			}
+		}
+	}
+	plan := &applyPlan{
+		path:     s.path,
+		original: data,
+		lines:    lines,
+		start:    start + best,
+		end:      start + best + size,
+		shifted:  best != 0,
+		code:     s.code,
+	}
+	plan.diff = plan.annotatedDiff()
+	return plan, nil
+}
+
+// sharedWords 两段代码共有的词（字母数字串）个数，用于衡量替换位置是否吻合
+func sharedWords(old, new []string) int {
+	seen := map[string]int{}
+	for _, word := range diffWords(strings.Join(old, "\n")) {
+		if isDiffWordRune([]rune(word)[0]) {
+			seen[word]++
+		}
+	}
+	score := 0
+	for _, word := range diffWords(strings.Join(new, "\n")) {
+		if seen[word] > 0 {
+			seen[word]--
+			score++
+		}
+	}
+	return score
+}
+
+// annotatedDiff 替换位置前后带上下文的逐行差异，左侧为原文件与修改后的行号
+func (p *applyPlan) annotatedDiff() []string {
+	width := len(strconv.Itoa(max(p.end+SuggestionContext, p.start+len(p.code))))
+	row := func(mark, color string, number int, text string) string {
+		return fmt.Sprintf("%s%s %*d │ %s%s", color, mark, width, number, text, ResetSequence)
+	}
+	var diff []string
+	from := max(p.start-SuggestionContext, 0)
+	for i := from; i < p.start; i++ {
+		diff = append(diff, row(" ", "\033[2m", i+1, p.lines[i]))
+	}
+	oldLine, newLine := p.start, p.start
+	for _, e := range diffSequences(p.lines[p.start:p.end], p.code) {
+		switch e.op {
+		case diffEqual:
+			oldLine++
+			newLine++
+			diff = append(diff, row(" ", "", newLine, e.text))
+		case diffDelete:
+			oldLine++
+			diff = append(diff, row("-", "\033[31m", oldLine, e.text))
+		case diffInsert:
+			newLine++
+			diff = append(diff, row("+", "\033[32m", newLine, e.text))
+		}
+	}
+	// 修改后的行号
+	after := p.start + len(p.code)
+	for i := p.end; i < min(p.end+SuggestionContext, len(p.lines)); i++ {
+		if i == len(p.lines)-1 && p.lines[i] == "" {
+			break
+		}
+		after++
+		diff = append(diff, row(" ", "\033[2m", after, p.lines[i]))
+	}
+	return diff
+}
+
+// apply 写入修改，文件在预览之后被改动过时拒绝写入
+func (p *applyPlan) apply() (appliedChange, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return appliedChange{}, err
+	}
+	current, err := os.ReadFile(p.path)
+	if err != nil {
+		return appliedChange{}, err
+	}
+	if !bytes.Equal(current, p.original) {
+		return appliedChange{}, errors.New(T(MsgApplyChanged, p.path))
+	}
+	lines := append(append(append([]string{}, p.lines[:p.start]...), p.code...), p.lines[p.end:]...)
+	written := []byte(strings.Join(lines, "\n"))
+	if err := os.WriteFile(p.path, written, info.Mode().Perm()); err != nil {
+		return appliedChange{}, err
+	}
+	return appliedChange{path: p.path, original: p.original, written: written}, nil
+}
+
+// undo 恢复修改前的内容，文件在修改之后又被改动过时拒绝恢复
+func (c appliedChange) undo() error {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(current, c.written) {
+		return errors.New(T(MsgApplyChanged, c.path))
+	}
+	return os.WriteFile(c.path, c.original, info.Mode().Perm())
+}
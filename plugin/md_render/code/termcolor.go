@@ -0,0 +1,268 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// EnvColorDepth 强制指定终端的颜色深度（truecolor、256、16），与 j 主程序一致；不设置时自动检测
+const EnvColorDepth = "J_COLOR_DEPTH"
+
+// colorDepth 终端能显示的颜色数
+type colorDepth int
+
+const (
+	depth16        colorDepth = iota // 基本的 16 色
+	depth256                         // xterm 256 色
+	depthTrueColor                   // 24 位真彩色
+)
+
+// termColorDepth 当前终端的颜色深度；主题中的 #rrggbb 在不支持真彩色的终端中换成最接近的 256 色或 16 色
+var termColorDepth = detectColorDepth()
+
+// trueColorPrograms TERM_PROGRAM 为这些值的终端支持真彩色（即使没有设置 COLORTERM）
+var trueColorPrograms = map[string]bool{
+	"iTerm.app": true, "WezTerm": true, "vscode": true, "ghostty": true, "Hyper": true, "rio": true, "Tabby": true,
+}
+
+// detectColorDepth 依次查看 J_COLOR_DEPTH、COLORTERM、TERM、终端程序与 terminfo 中的 colors，都无法判断时按 16 色处理
+func detectColorDepth() colorDepth {
+	if depth, ok := parseColorDepth(os.Getenv(EnvColorDepth)); ok {
+		return depth
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return depthTrueColor
+	}
+	term := os.Getenv("TERM")
+	if term == "" || term == "dumb" {
+		return depth16
+	}
+	if strings.HasSuffix(term, "-direct") || strings.Contains(term, "truecolor") {
+		return depthTrueColor
+	}
+	if os.Getenv("WT_SESSION") != "" || trueColorPrograms[os.Getenv("TERM_PROGRAM")] {
+		return depthTrueColor
+	}
+	if colors, ok := terminfoColors(term); ok {
+		switch {
+		case colors >= 1<<24:
+			return depthTrueColor
+		case colors >= 256:
+			return depth256
+		}
+		return depth16
+	}
+	if strings.Contains(term, "256color") {
+		return depth256
+	}
+	return depth16
+}
+
+// parseColorDepth 解析 J_COLOR_DEPTH 的值
+func parseColorDepth(value string) (colorDepth, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "truecolor", "24bit", "16m":
+		return depthTrueColor, true
+	case "256":
+		return depth256, true
+	case "16", "8":
+		return depth16, true
+	}
+	return 0, false
+}
+
+// terminfoDirs terminfo 数据库的查找位置，顺序与 ncurses 一致
+func terminfoDirs() []string {
+	var dirs []string
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	for _, dir := range strings.Split(os.Getenv("TERMINFO_DIRS"), ":") {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return append(dirs, "/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo", "/usr/lib/terminfo", "/opt/homebrew/share/terminfo")
+}
+
+// terminfoColors 读取 TERM 对应的编译后 terminfo 条目中的 max_colors（数值能力的第 13 项）
+func terminfoColors(term string) (int, bool) {
+	if strings.ContainsAny(term, "/\\") {
+		return 0, false
+	}
+	for _, dir := range terminfoDirs() {
+		// Linux 按首字母分目录，macOS 按首字母的十六进制
+		for _, sub := range []string{term[:1], fmt.Sprintf("%02x", term[0])} {
+			data, err := os.ReadFile(filepath.Join(dir, sub, term))
+			if err != nil {
+				continue
+			}
+			return parseTerminfoColors(data)
+		}
+	}
+	return 0, false
+}
+
+// maxColorsIndex max_colors 在数值能力中的序号
+const maxColorsIndex = 13
+
+// parseTerminfoColors 解析编译后的 terminfo（term(5)）：12 字节的头之后依次为名称、布尔能力、
+// 对齐到偶数字节后的数值能力（旧格式 2 字节、扩展格式 4 字节，小端）
+func parseTerminfoColors(data []byte) (int, bool) {
+	if len(data) < 12 {
+		return 0, false
+	}
+	header := func(i int) int { return int(int16(binary.LittleEndian.Uint16(data[i*2:]))) }
+	width := 0
+	switch header(0) {
+	case 0o432:
+		width = 2
+	case 0o1036:
+		width = 4
+	default:
+		return 0, false
+	}
+	namesSize, boolCount, numCount := header(1), header(2), header(3)
+	if namesSize < 0 || boolCount < 0 || numCount <= maxColorsIndex {
+		return 0, false
+	}
+	offset := 12 + namesSize + boolCount
+	if offset%2 == 1 {
+		offset++
+	}
+	offset += maxColorsIndex * width
+	if offset+width > len(data) {
+		return 0, false
+	}
+	var colors int
+	if width == 2 {
+		colors = int(int16(binary.LittleEndian.Uint16(data[offset:])))
+	} else {
+		colors = int(int32(binary.LittleEndian.Uint32(data[offset:])))
+	}
+	if colors < 0 {
+		return 0, false
+	}
+	return colors, true
+}
+
+// addColor 按终端的颜色深度把 #rrggbb 加到样式中：真彩色原样输出，256 色取最接近的色号，16 色取最接近的基本色
+func addColor(c *color.Color, rgb [3]int, background bool) {
+	switch termColorDepth {
+	case depthTrueColor:
+		if background {
+			c.AddBgRGB(rgb[0], rgb[1], rgb[2])
+		} else {
+			c.AddRGB(rgb[0], rgb[1], rgb[2])
+		}
+	case depth256:
+		code := color.Attribute(38)
+		if background {
+			code = 48
+		}
+		c.Add(code, 5, color.Attribute(nearest256(rgb)))
+	default:
+		attr := ansi16Attr(nearest16(rgb))
+		if background {
+			attr += color.BgBlack - color.FgBlack
+		}
+		c.Add(attr)
+	}
+}
+
+// cubeLevels xterm 256 色中 6×6×6 色块每个分量的取值
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// nearest256 最接近的 xterm 256 色色号（16–255：色块或 24 级灰度）
+func nearest256(rgb [3]int) int {
+	var idx [3]int
+	var cube [3]int
+	for i, v := range rgb {
+		best := 0
+		for j, level := range cubeLevels {
+			if abs(level-v) < abs(cubeLevels[best]-v) {
+				best = j
+			}
+		}
+		idx[i], cube[i] = best, cubeLevels[best]
+	}
+	cubeCode := 16 + 36*idx[0] + 6*idx[1] + idx[2]
+	// 灰度 232–255 为 8, 18, ..., 238
+	gray := (rgb[0] + rgb[1] + rgb[2]) / 3
+	grayIdx := min(max((gray-3)/10, 0), 23)
+	grayLevel := 8 + 10*grayIdx
+	if colorDistance(rgb, [3]int{grayLevel, grayLevel, grayLevel}) < colorDistance(rgb, cube) {
+		return 232 + grayIdx
+	}
+	return cubeCode
+}
+
+// hueSectors 色相每 60° 一段对应的基本色序号：红、黄、绿、青、蓝、品红
+var hueSectors = [6]int{1, 3, 2, 6, 4, 5}
+
+// nearest16 对应的基本色序号（0–15）：按距离取最近的基本色会让浅色几乎都落到白色上，
+// 因此灰色按亮度取黑、暗灰、浅灰、白，其他颜色按色相取六种基本色之一，较亮的用高亮色
+func nearest16(rgb [3]int) int {
+	hi := max(rgb[0], rgb[1], rgb[2])
+	lo := min(rgb[0], rgb[1], rgb[2])
+	chroma := hi - lo
+	if chroma < 40 {
+		switch lightness := (hi + lo) / 2; {
+		case lightness < 50:
+			return 0
+		case lightness < 140:
+			return 8
+		case lightness < 215:
+			return 7
+		}
+		return 15
+	}
+	r, g, b := float64(rgb[0]), float64(rgb[1]), float64(rgb[2])
+	c := float64(chroma)
+	var hue float64
+	switch hi {
+	case rgb[0]:
+		hue = (g - b) / c
+	case rgb[1]:
+		hue = (b-r)/c + 2
+	default:
+		hue = (r-g)/c + 4
+	}
+	sector := (int(hue+6.5)) % 6 // 四舍五入到最近的 60° 段
+	base := hueSectors[sector]
+	if hi > 200 {
+		return base + 8
+	}
+	return base
+}
+
+// ansi16Attr 基本色序号对应的前景色
+func ansi16Attr(i int) color.Attribute {
+	if i < 8 {
+		return color.FgBlack + color.Attribute(i)
+	}
+	return color.FgHiBlack + color.Attribute(i-8)
+}
+
+// colorDistance 按人眼对红绿蓝的敏感度加权的距离平方（"redmean" 近似）
+func colorDistance(a, b [3]int) int {
+	rmean := (a[0] + b[0]) / 2
+	dr, dg, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return ((512+rmean)*dr*dr)>>8 + 4*dg*dg + ((767-rmean)*db*db)>>8
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+)
+
+const (
+	// SettingTheme setting 段中的渲染主题配置项
+	SettingTheme = "md_theme"
+	// DefaultTheme 默认主题，即 md_render 一直以来的绿色标题
+	DefaultTheme = "dark"
+)
+
+// headingStyle 主题中单级标题的样式
+type headingStyle struct {
+	style     string // 颜色与文字属性，写法见 parseStyle
+	prefix    string // 标题前的符号，如 "§"、"##"
+	numbering bool   // 显示章节编号（1.2）
+	rule      string // 非空时在标题下方整行重复该字符
+}
+
+// theme 渲染主题；headings 与 quote 按级别（引用为嵌套层级）排列，更深的级别沿用最后一项
+type theme struct {
+	headings []headingStyle
+	gutter   string   // 引用块左侧的竖条
+	quote    []string // 引用块竖条的样式，写法见 parseStyle
+
+	code        string // 行内代码，默认蓝底斜体
+	link        string // 链接地址，默认蓝色
+	tableBorder string // 表格边框，默认不着色
+	tableHeader string // 表头文字，默认不着色
+	highlight   string // 代码块的高亮配色（chroma 样式名），默认 pygments
+}
+
+// themes 内置主题，名称与 j 对话界面的主题一致
+var themes = map[string]theme{
+	"dark": {headings: []headingStyle{
+		{style: "green bold", numbering: true, rule: "─"},
+		{style: "green bold", numbering: true},
+		{style: "higreen", numbering: true},
+		{style: "green", numbering: true},
+	}, gutter: "┃", quote: []string{"green bold", "green bold", "higreen", "green"}},
+	"light": {headings: []headingStyle{
+		{style: "blue bold", numbering: true, rule: "─"},
+		{style: "blue bold", numbering: true},
+		{style: "magenta bold", numbering: true},
+		{style: "magenta", numbering: true},
+	}, gutter: "│", quote: []string{"blue", "magenta"}},
+	"dracula": {headings: []headingStyle{
+		{style: "#bd93f9 bold", numbering: true, rule: "═"},
+		{style: "#ff79c6 bold", prefix: "§", numbering: true},
+		{style: "#8be9fd", prefix: "§", numbering: true},
+		{style: "#50fa7b", numbering: true},
+	}, gutter: "▌", quote: []string{"#bd93f9", "#6272a4"},
+		code: "#f1fa8c", link: "#8be9fd underline", tableBorder: "#6272a4", tableHeader: "#ff79c6 bold", highlight: "dracula"},
+	"gruvbox": {headings: []headingStyle{
+		{style: "#fabd2f bold", numbering: true, rule: "━"},
+		{style: "#fe8019 bold", numbering: true},
+		{style: "#b8bb26", numbering: true},
+		{style: "#83a598", numbering: true},
+	}, gutter: "┃", quote: []string{"#928374", "#665c54"},
+		code: "#8ec07c", link: "#83a598 underline", tableBorder: "#665c54", tableHeader: "#fabd2f bold"},
+	"monokai": {headings: []headingStyle{
+		{style: "#f92672 bold", prefix: "#", rule: "─"},
+		{style: "#a6e22e bold", prefix: "##"},
+		{style: "#66d9ef", prefix: "###"},
+		{style: "#fd971f", prefix: "####"},
+		{style: "#fd971f", prefix: "#####"},
+		{style: "#fd971f", prefix: "######"},
+	}, gutter: "▎", quote: []string{"#75715e"},
+		code: "#e6db74", link: "#66d9ef underline", tableBorder: "#75715e", tableHeader: "#a6e22e bold", highlight: "monokai"},
+	"nord": {headings: []headingStyle{
+		{style: "#88c0d0 bold underline", prefix: "§", numbering: true},
+		{style: "#81a1c1 bold", prefix: "§", numbering: true},
+		{style: "#8fbcbb", numbering: true},
+		{style: "#5e81ac", numbering: true},
+	}, gutter: "│", quote: []string{"#4c566a", "#434c5e"},
+		code: "#a3be8c", link: "#88c0d0 underline", tableBorder: "#4c566a", tableHeader: "#81a1c1 bold", highlight: "nord"},
+}
+
+// themeNames 按字母序列出内置主题
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupTheme 按名称（不区分大小写）取内置主题
+func lookupTheme(name string) (theme, error) {
+	t, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return theme{}, fmt.Errorf("%s", T(MsgUnknownTheme, name, strings.Join(themeNames(), ", ")))
+	}
+	return t, nil
+}
+
+// resolve 解析主题中的样式写法，未设置的元素使用 dark 主题一致的默认样式
+func (t theme) resolve() styleSet {
+	var set styleSet
+	for level := range set.headings {
+		h := t.headings[min(level, len(t.headings)-1)]
+		set.headings[level] = headingLook{
+			text:      mustParseStyle(h.style),
+			prefix:    h.prefix,
+			numbering: h.numbering,
+			rule:      h.rule,
+		}
+	}
+	set.gutter = t.gutter
+	for _, spec := range t.quote {
+		set.quote = append(set.quote, mustParseStyle(spec))
+	}
+	set.code = mustParseStyle(cmp.Or(t.code, "bg:blue italic"))
+	set.link = mustParseStyle(cmp.Or(t.link, "blue"))
+	set.tableBorder = mustParseStyle(t.tableBorder)
+	set.tableHeader = mustParseStyle(t.tableHeader)
+	set.highlight = t.highlight
+	return set
+}
+
+// calloutTitles GitHub 提示块（> [!NOTE]）的默认标题
+var calloutTitles = map[string]string{
+	"NOTE":      MsgCalloutNote,
+	"TIP":       MsgCalloutTip,
+	"IMPORTANT": MsgCalloutImportant,
+	"WARNING":   MsgCalloutWarning,
+	"CAUTION":   MsgCalloutCaution,
+}
+
+// callouts 内置提示块样式，标题使用当前界面语言
+func callouts() map[string]markdown.CalloutStyle {
+	styles := markdown.DefaultCallouts()
+	for kind, style := range styles {
+		if key, ok := calloutTitles[kind]; ok {
+			style.Title = T(key)
+			styles[kind] = style
+		}
+	}
+	return styles
+}
@@ -0,0 +1,605 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	markdown "github.com/MichaelMure/go-term-markdown"
+	"golang.org/x/term"
+)
+
+// 查看器按键
+const (
+	keyUp = iota + 256
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+)
+
+// viewer 全屏交互查看器：内容从 stdin 读入，按键从 /dev/tty 读取，
+// 因此管道输入（agent ask ... | md_render --view）同样可用
+type viewer struct {
+	content string
+	opts    renderOptions
+	tty     *os.File
+	width   int
+	height  int
+
+	lines     []string
+	summaries map[int]int  // 输出行号 -> <details> 序号
+	collapsed map[int]bool // 本次渲染中各 <details> 是否折叠
+	expanded  map[int]bool // 用户切换过的 <details> 展开状态
+	headings  []markdown.Heading
+	top       int // 第一行显示的行号
+	cursor    int // 光标所在行号
+
+	prompting bool   // 正在输入跳转目标
+	query     []rune // 跳转输入：章节编号或标题关键字
+	notice    string // 状态栏上的一次性提示
+
+	blocks  []viewerBlock // 本次渲染中的代码块位置
+	pending *codeRun      // 等待确认的代码块运行
+	run     *codeRun      // 正在显示输出的运行
+
+	apply   *applyPlan      // 正在预览、等待确认的修改建议
+	applied []appliedChange // 本次查看中已写入的修改，u 依次撤销
+}
+
+// viewerBlock 代码块及其在输出中占据的行
+type viewerBlock struct {
+	block        markdown.CodeBlock
+	line, height int
+}
+
+// runViewer 进入全屏查看；stdout 不是终端或无法打开 /dev/tty 时退化为直接输出
+func runViewer(content string, opts renderOptions) error {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Print(string(renderMarkdown(content, getTerminalWidth(), opts)))
+		return nil
+	}
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		fmt.Print(string(renderMarkdown(content, getTerminalWidth(), opts)))
+		return nil
+	}
+	defer tty.Close()
+	state, err := term.MakeRaw(int(tty.Fd()))
+	if err != nil {
+		return err
+	}
+	defer term.Restore(int(tty.Fd()), state)
+
+	// 备用屏幕 + 隐藏光标，退出时恢复
+	fmt.Print("\033[?1049h\033[?25l")
+	defer fmt.Print(ResetSequence + "\033[?25h\033[?1049l")
+
+	v := &viewer{content: content, opts: opts, tty: tty, expanded: map[int]bool{}}
+	v.resize()
+	v.render()
+
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	defer signal.Stop(winch)
+	keys := make(chan int)
+	go readKeys(tty, keys)
+
+	for {
+		v.draw()
+		select {
+		case <-winch:
+			v.resize()
+			v.render()
+		case key, ok := <-keys:
+			if !ok || !v.handle(key) {
+				v.closeRun()
+				return nil
+			}
+		case e := <-v.runEvents():
+			v.run.handle(e)
+		}
+	}
+}
+
+// runEvents 当前运行的输出通道，没有运行时为 nil（select 中永不就绪）
+func (v *viewer) runEvents() <-chan runEvent {
+	if v.run == nil {
+		return nil
+	}
+	return v.run.events
+}
+
+// resize 读取终端尺寸，底部一行留给状态栏
+func (v *viewer) resize() {
+	w, h, err := term.GetSize(int(v.tty.Fd()))
+	if err != nil {
+		w, h = DefaultTerminalWidth, 24
+	}
+	v.width = min(max(w, MinTerminalWidth), MaxTerminalWidth)
+	v.height = max(h-1, 1)
+}
+
+// render 按当前折叠状态重新渲染，<details> 默认折叠（带 open 属性的除外）
+func (v *viewer) render() {
+	v.summaries, v.collapsed, v.headings, v.blocks = map[int]int{}, map[int]bool{}, nil, nil
+	opts := v.opts
+	opts.headings = func(h markdown.Heading) { v.headings = append(v.headings, h) }
+	opts.blocks = func(block markdown.CodeBlock, line, height int) {
+		v.blocks = append(v.blocks, viewerBlock{block, line, height})
+	}
+	opts.details = markdown.DetailsState{
+		Collapsed: func(index int, open bool) bool {
+			collapsed := !open
+			if expanded, ok := v.expanded[index]; ok {
+				collapsed = !expanded
+			}
+			v.collapsed[index] = collapsed
+			return collapsed
+		},
+		Summary: func(index, line int) { v.summaries[line] = index },
+	}
+	out := strings.TrimRight(string(renderMarkdown(v.content, v.width, opts)), "\n")
+	v.lines = strings.Split(out, "\n")
+	v.cursor = min(v.cursor, len(v.lines)-1)
+	v.scrollToCursor()
+}
+
+// handle 处理一个按键，返回 false 表示退出
+func (v *viewer) handle(key int) bool {
+	v.notice = ""
+	if v.prompting {
+		v.handlePrompt(key)
+		return true
+	}
+	if v.pending != nil {
+		v.confirmRun(key == 'y' || key == 'Y')
+		return true
+	}
+	if v.run != nil {
+		v.handleRun(key)
+		return true
+	}
+	if v.apply != nil {
+		v.handleApply(key)
+		return true
+	}
+	switch key {
+	case 'q', 'Q', 3, 27: // q / Ctrl-C / Esc
+		return false
+	case 'j', keyDown:
+		v.cursor++
+	case 'k', keyUp:
+		v.cursor--
+	case ' ', 'f', keyPageDown:
+		v.cursor += v.height
+		v.top += v.height
+	case 'b', keyPageUp:
+		v.cursor -= v.height
+		v.top -= v.height
+	case 'g', keyHome:
+		v.cursor = 0
+	case 'G', keyEnd:
+		v.cursor = len(v.lines) - 1
+	case 'n', '\t':
+		v.cursor = v.nextSummary(1)
+	case 'N':
+		v.cursor = v.nextSummary(-1)
+	case ']':
+		v.cursor = v.nextHeading(1)
+	case '[':
+		v.cursor = v.nextHeading(-1)
+	case ':', '/':
+		v.prompting, v.query = true, nil
+	case 'r':
+		v.requestRun()
+	case 'a':
+		v.requestApply()
+	case 'u':
+		v.undoApply()
+	case '\r', '\n':
+		if index, ok := v.summaries[v.cursor]; ok {
+			v.expanded[index] = v.collapsed[index]
+			v.render()
+		}
+	}
+	v.cursor = min(max(v.cursor, 0), len(v.lines)-1)
+	v.scrollToCursor()
+	return true
+}
+
+// handlePrompt 跳转输入：Enter 跳到匹配的标题，Esc 取消
+func (v *viewer) handlePrompt(key int) {
+	switch key {
+	case 27, 3:
+		v.prompting = false
+	case '\r', '\n':
+		v.prompting = false
+		if h, ok := findHeading(v.headings, string(v.query)); ok {
+			v.cursor = h.Line
+			v.top = h.Line // 标题置顶显示
+		} else {
+			v.notice = T(MsgViewerNoHeading, string(v.query))
+		}
+	case 127, 8:
+		if len(v.query) > 0 {
+			v.query = v.query[:len(v.query)-1]
+		}
+	default:
+		if key >= ' ' && key < 256 {
+			v.query = append(v.query, rune(key))
+		}
+	}
+	v.cursor = min(max(v.cursor, 0), len(v.lines)-1)
+	v.scrollToCursor()
+}
+
+// cursorBlock 光标所在的代码块
+func (v *viewer) cursorBlock() (markdown.CodeBlock, bool) {
+	for _, b := range v.blocks {
+		if v.cursor >= b.line && v.cursor < b.line+b.height {
+			return b.block, true
+		}
+	}
+	return markdown.CodeBlock{}, false
+}
+
+// requestRun 准备运行光标所在的代码块，执行前需要确认
+func (v *viewer) requestRun() {
+	block, ok := v.cursorBlock()
+	if !ok {
+		v.notice = T(MsgRunNoBlock)
+		return
+	}
+	run, err := prepareRun(block)
+	if err != nil {
+		v.notice = " " + err.Error() + " "
+		return
+	}
+	v.pending = run
+}
+
+// requestApply 预览光标所在代码块对应的修改建议，写入前需要确认
+func (v *viewer) requestApply() {
+	block, ok := v.cursorBlock()
+	if !ok {
+		v.notice = T(MsgApplyNoBlock)
+		return
+	}
+	s, ok := parseSuggestion(block)
+	if !ok {
+		v.notice = T(MsgApplyNoSuggestion)
+		return
+	}
+	plan, err := planSuggestion(s)
+	if err != nil {
+		v.notice = " " + err.Error() + " "
+		return
+	}
+	v.apply = plan
+}
+
+// handleApply 修改预览的按键：y 写入，j/k 滚动，其余键放弃
+func (v *viewer) handleApply(key int) {
+	plan := v.apply
+	switch key {
+	case 'y', 'Y':
+		v.apply = nil
+		change, err := plan.apply()
+		if err != nil {
+			v.notice = " " + T(MsgApplyFailed, err) + " "
+			return
+		}
+		v.applied = append(v.applied, change)
+		v.notice = T(MsgApplyDone, plan.path, plan.start+1, plan.start+len(plan.code))
+	case 'j', keyDown:
+		plan.top++
+	case 'k', keyUp:
+		plan.top--
+	case ' ', 'f', keyPageDown:
+		plan.top += v.height - 1
+	case 'b', keyPageUp:
+		plan.top -= v.height - 1
+	default:
+		v.apply = nil
+	}
+}
+
+// undoApply 撤销最近一次写入的修改
+func (v *viewer) undoApply() {
+	if len(v.applied) == 0 {
+		v.notice = T(MsgApplyNothingToUndo)
+		return
+	}
+	change := v.applied[len(v.applied)-1]
+	if err := change.undo(); err != nil {
+		v.notice = " " + T(MsgApplyFailed, err) + " "
+		return
+	}
+	v.applied = v.applied[:len(v.applied)-1]
+	v.notice = T(MsgApplyUndone, change.path)
+}
+
+// drawApply 修改预览：首行为目标文件与替换的行，其下为带行号的差异
+func (v *viewer) drawApply(b *strings.Builder) {
+	plan := v.apply
+	rows := v.height - 1
+	plan.top = min(max(plan.top, 0), max(len(plan.diff)-rows, 0))
+	b.WriteString("\033[2K\033[1m" + T(MsgApplyTitle, plan.path, plan.start+1, plan.end) + "\033[22m")
+	if plan.shifted {
+		b.WriteString("  \033[2m" + T(MsgApplyShifted) + "\033[22m")
+	}
+	b.WriteString("\r\n")
+	for row := 0; row < rows; row++ {
+		b.WriteString("\033[2K")
+		if line := plan.top + row; line < len(plan.diff) {
+			b.WriteString(plan.diff[line])
+		}
+		b.WriteString("\r\n")
+	}
+	b.WriteString("\033[2K\033[7m" + T(MsgApplyConfirm) + ResetSequence)
+}
+
+// confirmRun 确认后开始运行并切换到输出界面，否则丢弃准备好的临时目录
+func (v *viewer) confirmRun(ok bool) {
+	run := v.pending
+	v.pending = nil
+	if !ok {
+		run.close()
+		return
+	}
+	if err := run.start(); err != nil {
+		run.close()
+		v.notice = " " + T(MsgRunFailed, err) + " "
+		return
+	}
+	v.run = run
+}
+
+// handleRun 输出界面的按键：j/k 滚动，Ctrl-C 停止运行，q/Esc 返回文档
+func (v *viewer) handleRun(key int) {
+	run := v.run
+	switch key {
+	case 3:
+		if run.running {
+			run.stop()
+			return
+		}
+		v.closeRun()
+	case 'q', 'Q', 27:
+		v.closeRun()
+	case 'j', keyDown:
+		run.top++
+	case 'k', keyUp:
+		run.top--
+		run.follow = false
+	case ' ', 'f', keyPageDown:
+		run.top += v.height - 1
+	case 'b', keyPageUp:
+		run.top -= v.height - 1
+		run.follow = false
+	case 'g', keyHome:
+		run.top, run.follow = 0, false
+	case 'G', keyEnd:
+		run.follow = true
+	}
+}
+
+// closeRun 离开输出界面；进程仍在运行时将其结束
+func (v *viewer) closeRun() {
+	if v.pending != nil {
+		v.pending.close()
+		v.pending = nil
+	}
+	if v.run != nil {
+		v.run.close()
+		v.run = nil
+	}
+}
+
+// drawRun 输出界面：首行为执行的命令与目录，其下为输出，新输出到达时自动滚动到底部
+func (v *viewer) drawRun(b *strings.Builder) {
+	run := v.run
+	rows := v.height - 1
+	bottom := max(len(run.output)-rows, 0)
+	if run.follow {
+		run.top = bottom
+	}
+	run.top = min(max(run.top, 0), bottom)
+	if run.top == bottom {
+		run.follow = true
+	}
+	b.WriteString("\033[2K\033[1m$ " + run.command + "\033[22m  \033[2m(" + run.dir + ")\033[22m\r\n")
+	for row := 0; row < rows; row++ {
+		b.WriteString("\033[2K")
+		if line := run.top + row; line < len(run.output) {
+			b.WriteString(run.output[line])
+			b.WriteString(ResetSequence)
+		}
+		b.WriteString("\r\n")
+	}
+	b.WriteString("\033[2K\033[7m")
+	switch code := run.exitCode(); {
+	case run.running:
+		b.WriteString(T(MsgRunRunning))
+	case code < 0:
+		b.WriteString(T(MsgRunStopped, run.err))
+	default:
+		b.WriteString(T(MsgRunExited, code))
+	}
+	b.WriteString(ResetSequence)
+}
+
+// nextHeading 从光标向 dir 方向查找下一个标题行，找不到时不动
+func (v *viewer) nextHeading(dir int) int {
+	best := v.cursor
+	for _, h := range v.headings {
+		if dir > 0 && h.Line > v.cursor && (best == v.cursor || h.Line < best) {
+			best = h.Line
+		}
+		if dir < 0 && h.Line < v.cursor && (best == v.cursor || h.Line > best) {
+			best = h.Line
+		}
+	}
+	return best
+}
+
+// nextSummary 从光标向 dir 方向查找下一个 <details> 摘要行，找不到时不动
+func (v *viewer) nextSummary(dir int) int {
+	for line := v.cursor + dir; line >= 0 && line < len(v.lines); line += dir {
+		if _, ok := v.summaries[line]; ok {
+			return line
+		}
+	}
+	return v.cursor
+}
+
+// scrollToCursor 滚动使光标行可见
+func (v *viewer) scrollToCursor() {
+	if v.cursor < v.top {
+		v.top = v.cursor
+	}
+	if v.cursor >= v.top+v.height {
+		v.top = v.cursor - v.height + 1
+	}
+	v.top = min(max(v.top, 0), max(len(v.lines)-v.height, 0))
+}
+
+// draw 输出当前屏；光标行用行首的 ▸ 标记（渲染结果左侧总有缩进，不会遮住内容）
+func (v *viewer) draw() {
+	var b strings.Builder
+	b.WriteString("\033[H")
+	if v.run != nil {
+		v.drawRun(&b)
+		fmt.Print(b.String())
+		return
+	}
+	if v.apply != nil {
+		v.drawApply(&b)
+		fmt.Print(b.String())
+		return
+	}
+	for row := 0; row < v.height; row++ {
+		b.WriteString("\033[2K")
+		if line := v.top + row; line < len(v.lines) {
+			text := v.lines[line]
+			if line == v.cursor {
+				text = "\033[7m▸\033[27m" + strings.TrimPrefix(text, " ")
+			}
+			b.WriteString(text)
+			b.WriteString(ResetSequence)
+		}
+		b.WriteString("\r\n")
+	}
+	b.WriteString("\033[2K")
+	switch {
+	case v.prompting:
+		b.WriteString(T(MsgViewerPrompt) + string(v.query) + "\033[7m \033[27m")
+	case v.pending != nil:
+		b.WriteString("\033[7m" + T(MsgRunConfirm, v.pending.command, v.pending.dir))
+	case v.notice != "":
+		b.WriteString("\033[7m" + v.notice)
+	default:
+		b.WriteString("\033[7m" + T(MsgViewerStatus, v.cursor+1, len(v.lines)))
+	}
+	b.WriteString(ResetSequence)
+	fmt.Print(b.String())
+}
+
+// findHeading 按章节编号（如 2.1）精确匹配，否则按标题模糊匹配取得分最高的标题
+func findHeading(headings []markdown.Heading, query string) (markdown.Heading, bool) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return markdown.Heading{}, false
+	}
+	number := strings.TrimSuffix(query, ".")
+	for _, h := range headings {
+		if h.Number == number {
+			return h, true
+		}
+	}
+	best, bestScore := markdown.Heading{}, 0
+	for _, h := range headings {
+		if score := fuzzyScore(query, h.Title); score > bestScore {
+			best, bestScore = h, score
+		}
+	}
+	return best, bestScore > 0
+}
+
+// fuzzyScore 不区分大小写的模糊匹配得分，0 表示不匹配：
+// 包含完整子串时得分最高（越靠前越高），否则要求按顺序出现全部字符，字符越连续得分越高
+func fuzzyScore(query, title string) int {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(title))
+	if i := strings.Index(string(t), string(q)); i >= 0 {
+		return 1000 - len([]rune(string(t)[:i]))
+	}
+	score, last := 0, -1
+	for _, r := range q {
+		found := -1
+		for j := last + 1; j < len(t); j++ {
+			if t[j] == r {
+				found = j
+				break
+			}
+		}
+		if found < 0 {
+			return 0
+		}
+		if found == last+1 {
+			score += 10
+		} else {
+			score++
+		}
+		last = found
+	}
+	return score
+}
+
+// readKeys 从终端读取按键，方向键等转义序列转换为 key 常量；读取失败时关闭通道
+func readKeys(tty *os.File, keys chan<- int) {
+	defer close(keys)
+	r := bufio.NewReader(tty)
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		if c != 27 {
+			keys <- int(c)
+			continue
+		}
+		// 单独的 Esc 后面不会立即跟着其他字节
+		if r.Buffered() == 0 {
+			keys <- 27
+			continue
+		}
+		seq := make([]byte, 0, 4)
+		for r.Buffered() > 0 && len(seq) < 4 {
+			b, _ := r.ReadByte()
+			seq = append(seq, b)
+			if b >= 'A' && b <= 'Z' || b == '~' {
+				break
+			}
+		}
+		switch strings.TrimLeft(string(seq), "[O") {
+		case "A":
+			keys <- keyUp
+		case "B":
+			keys <- keyDown
+		case "5~":
+			keys <- keyPageUp
+		case "6~":
+			keys <- keyPageDown
+		case "H", "1~":
+			keys <- keyHome
+		case "F", "4~":
+			keys <- keyEnd
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wcp_jdata"
+)
+
+const (
+	// SettingNotesDir setting 段中的笔记目录配置项，支持 ~ 开头
+	SettingNotesDir = "notes_dir"
+	// NoteDir 未配置 notes_dir 时的笔记目录（位于数据目录下）
+	NoteDir = "note"
+)
+
+// notesDir 笔记目录：setting.notes_dir，未配置时为 ~/.jdata/note/
+func notesDir() (string, error) {
+	dir := jdata.Setting(SettingNotesDir)
+	if dir == "" {
+		data, err := jdata.DataDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(data, NoteDir), nil
+	}
+	if strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, dir[2:]), nil
+		}
+	}
+	return dir, nil
+}
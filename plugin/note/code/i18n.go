@@ -0,0 +1,71 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage         = "usage"
+	MsgUsageCommand  = "usage_command"
+	MsgError         = "error"
+	MsgAddSummary    = "add_summary"
+	MsgDailySummary  = "daily_summary"
+	MsgListSummary   = "list_summary"
+	MsgViewSummary   = "view_summary"
+	MsgEditSummary   = "edit_summary"
+	MsgSearchSummary = "search_summary"
+	MsgEmpty         = "empty"
+	MsgSaved         = "saved"
+	MsgAppended      = "appended"
+	MsgNotFound      = "not_found"
+	MsgAmbiguous     = "ambiguous"
+	MsgNeedID        = "need_id"
+	MsgNoNotes       = "no_notes"
+	MsgNoDaily       = "no_daily"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:         "usage: note \"text...\" | note <command> [args]",
+		MsgUsageCommand:  "  %-8s %s",
+		MsgError:         "error: %v",
+		MsgAddSummary:    "capture a note from arguments or stdin (same as note \"text\")",
+		MsgDailySummary:  "append to / view today's daily note",
+		MsgListSummary:   "list recent notes",
+		MsgViewSummary:   "render a note in the terminal",
+		MsgEditSummary:   "open a note in $EDITOR",
+		MsgSearchSummary: "search note contents",
+		MsgEmpty:         "note is empty",
+		MsgSaved:         "saved note %s",
+		MsgAppended:      "appended to %s",
+		MsgNotFound:      "no note matches %q",
+		MsgAmbiguous:     "%q matches several notes: %s",
+		MsgNeedID:        "missing note id",
+		MsgNoNotes:       "no notes yet",
+		MsgNoDaily:       "no daily note for %s yet",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:         "用法: note \"内容...\" | note <命令> [参数]",
+		MsgUsageCommand:  "  %-8s %s",
+		MsgError:         "错误: %v",
+		MsgAddSummary:    "从参数或管道记录一条笔记（等同 note \"内容\"）",
+		MsgDailySummary:  "追加 / 查看今天的日记",
+		MsgListSummary:   "列出最近的笔记",
+		MsgViewSummary:   "在终端中渲染笔记",
+		MsgEditSummary:   "用 $EDITOR 编辑笔记",
+		MsgSearchSummary: "搜索笔记内容",
+		MsgEmpty:         "笔记内容为空",
+		MsgSaved:         "已保存笔记 %s",
+		MsgAppended:      "已追加到 %s",
+		MsgNotFound:      "没有匹配 %q 的笔记",
+		MsgAmbiguous:     "%q 匹配到多个笔记: %s",
+		MsgNeedID:        "缺少笔记 ID",
+		MsgNoNotes:       "还没有任何笔记",
+		MsgNoDaily:       "%s 还没有日记",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// command 子命令：run 返回的错误统一由 main 输出到 stderr 并以非零码退出
+type command struct {
+	run     func(args []string) error
+	summary string // 用法说明中的一句话介绍（i18n key）
+}
+
+// commands 子命令注册表；第一个参数不是子命令时视为快速记录（note "内容"）
+var commands = map[string]command{
+	"add":    {runAdd, MsgAddSummary},
+	"daily":  {runDaily, MsgDailySummary},
+	"edit":   {runEdit, MsgEditSummary},
+	"list":   {runList, MsgListSummary},
+	"search": {runSearch, MsgSearchSummary},
+	"view":   {runView, MsgViewSummary},
+}
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help note 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	args := os.Args[1:]
+	if len(args) == 0 && term.IsTerminal(int(os.Stdin.Fd())) {
+		usage()
+		os.Exit(2)
+	}
+	run := runAdd
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			run, args = cmd.run, args[1:]
+		} else if args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+			usage()
+			return
+		}
+	}
+	if err := run(args); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// usage 输出命令列表到 stderr
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, T(MsgUsage))
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, T(MsgUsageCommand, name, T(commands[name].summary)))
+	}
+}
+
+// readContent 参数优先，否则读取管道输入
+func readContent(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errors.New(T(MsgEmpty))
+	}
+	data, err := io.ReadAll(os.Stdin)
+	return string(data), err
+}
+
+// runAdd note [add] "内容..."，新建一篇以时间命名的笔记
+func runAdd(args []string) error {
+	content, err := readContent(args)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(content) == "" {
+		return errors.New(T(MsgEmpty))
+	}
+	n, err := createNote(content)
+	if err != nil {
+		return err
+	}
+	fmt.Println(T(MsgSaved, n.ID))
+	return nil
+}
+
+// runDaily note daily ["内容"] [-d YYYY-MM-DD]：有内容时追加到当天日记，否则渲染日记
+func runDaily(args []string) error {
+	fs := flag.NewFlagSet("daily", flag.ContinueOnError)
+	date := fs.String("d", "", "date of the daily note to view (YYYY-MM-DD)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	day := time.Now()
+	if *date != "" {
+		parsed, err := time.ParseInLocation(dailyLayout, *date, time.Local)
+		if err != nil {
+			return err
+		}
+		day = parsed
+	}
+	var content string
+	if fs.NArg() > 0 || (*date == "" && !term.IsTerminal(int(os.Stdin.Fd()))) {
+		var err error
+		if content, err = readContent(fs.Args()); err != nil {
+			return err
+		}
+	}
+	// 管道输入为空时按查看处理
+	if strings.TrimSpace(content) != "" {
+		path, err := appendDaily(time.Now(), content)
+		if err != nil {
+			return err
+		}
+		fmt.Println(T(MsgAppended, path))
+		return nil
+	}
+	path, err := dailyPath(day)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("%s", T(MsgNoDaily, day.Format(dailyLayout)))
+	}
+	return renderFile(path)
+}
+
+// runList note list [-n N]
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	limit := fs.Int("n", 20, "number of notes to show (0 = all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	notes, err := listNotes()
+	if err != nil {
+		return err
+	}
+	if len(notes) == 0 {
+		fmt.Println(T(MsgNoNotes))
+		return nil
+	}
+	if *limit > 0 && len(notes) > *limit {
+		notes = notes[:*limit]
+	}
+	for _, n := range notes {
+		fmt.Printf("%s  %s  %s\n", n.ModTime.Format("2006-01-02 15:04"), n.ID, n.Title)
+	}
+	return nil
+}
+
+// runView note view <id>
+func runView(args []string) error {
+	n, err := findNote(firstArg(args))
+	if err != nil {
+		return err
+	}
+	return renderFile(n.Path)
+}
+
+// runEdit note edit <id>：使用 $VISUAL / $EDITOR（默认 vi）打开
+func runEdit(args []string) error {
+	n, err := findNote(firstArg(args))
+	if err != nil {
+		return err
+	}
+	editor := firstNonEmpty(os.Getenv("VISUAL"), os.Getenv("EDITOR"), "vi")
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], n.Path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// runSearch note search <关键字>：大小写不敏感，逐行输出命中位置
+func runSearch(args []string) error {
+	query := strings.ToLower(strings.Join(args, " "))
+	if query == "" {
+		return errors.New(T(MsgNeedID))
+	}
+	notes, err := listNotes()
+	if err != nil {
+		return err
+	}
+	for _, n := range notes {
+		f, err := os.Open(n.Path)
+		if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(f)
+		for line := 1; scanner.Scan(); line++ {
+			if strings.Contains(strings.ToLower(scanner.Text()), query) {
+				fmt.Printf("%s:%d: %s\n", n.ID, line, strings.TrimSpace(scanner.Text()))
+			}
+		}
+		f.Close()
+	}
+	return nil
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+
+	"golang.org/x/term"
+	"wcp_jdata"
+)
+
+// renderFile 终端中通过 md_render 渲染笔记；非终端输出或未找到渲染引擎时原样输出
+func renderFile(path string) error {
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		if bin, err := jdata.MdRenderPath(); err == nil {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			cmd := exec.Command(bin)
+			cmd.Stdin = f
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DailyDir 日记子目录，文件名为 YYYY-MM-DD.md
+	DailyDir = "daily"
+	// noteIDLayout 快速笔记文件名（即笔记 ID）的时间格式
+	noteIDLayout = "20060102-150405"
+	// dailyLayout 日记文件名的日期格式
+	dailyLayout = "2006-01-02"
+)
+
+// Note 一篇笔记，ID 为相对笔记目录、去掉 .md 的路径（如 20261014-093000、daily/2026-10-14）
+type Note struct {
+	ID      string
+	Path    string
+	Title   string
+	ModTime time.Time
+}
+
+// createNote 以当前时间为 ID 新建笔记，同一秒内重复创建时追加序号
+func createNote(content string) (Note, error) {
+	dir, err := notesDir()
+	if err != nil {
+		return Note{}, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Note{}, err
+	}
+	base := time.Now().Format(noteIDLayout)
+	id := base
+	for n := 2; ; n++ {
+		path := filepath.Join(dir, id+".md")
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			id = fmt.Sprintf("%s-%d", base, n)
+			continue
+		}
+		if err != nil {
+			return Note{}, err
+		}
+		_, err = f.WriteString(strings.TrimRight(content, "\n") + "\n")
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return Note{ID: id, Path: path, Title: titleOf(content)}, err
+	}
+}
+
+// dailyPath 指定日期的日记路径
+func dailyPath(day time.Time) (string, error) {
+	dir, err := notesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, DailyDir, day.Format(dailyLayout)+".md"), nil
+}
+
+// appendDaily 向当天日记追加一条 "- HH:MM 内容"，文件不存在时先写入日期标题
+func appendDaily(now time.Time, text string) (string, error) {
+	path, err := dailyPath(now)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	var header string
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		header = "# " + now.Format(dailyLayout) + "\n\n"
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	entry := "- " + now.Format("15:04") + " " + lines[0] + "\n"
+	for _, line := range lines[1:] {
+		entry += "  " + line + "\n"
+	}
+	_, err = f.WriteString(header + entry)
+	return path, err
+}
+
+// listNotes 列出笔记目录下全部 .md 文件，按修改时间倒序
+func listNotes() ([]Note, error) {
+	root, err := notesDir()
+	if err != nil {
+		return nil, err
+	}
+	var notes []Note
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == root {
+			return fs.SkipAll
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".md" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		notes = append(notes, Note{
+			ID:      filepath.ToSlash(strings.TrimSuffix(rel, ".md")),
+			Path:    path,
+			Title:   readTitle(path),
+			ModTime: info.ModTime(),
+		})
+		return nil
+	})
+	sort.Slice(notes, func(i, j int) bool { return notes[i].ModTime.After(notes[j].ModTime) })
+	return notes, err
+}
+
+// findNote 按完整 ID、文件名或唯一前缀定位笔记
+func findNote(query string) (Note, error) {
+	if query == "" {
+		return Note{}, errors.New(T(MsgNeedID))
+	}
+	query = strings.TrimSuffix(query, ".md")
+	notes, err := listNotes()
+	if err != nil {
+		return Note{}, err
+	}
+	var hits []Note
+	for _, n := range notes {
+		if n.ID == query || filepath.Base(n.ID) == query {
+			return n, nil
+		}
+		if strings.HasPrefix(n.ID, query) || strings.HasPrefix(filepath.Base(n.ID), query) {
+			hits = append(hits, n)
+		}
+	}
+	switch len(hits) {
+	case 0:
+		return Note{}, fmt.Errorf("%s", T(MsgNotFound, query))
+	case 1:
+		return hits[0], nil
+	}
+	ids := make([]string, len(hits))
+	for i, n := range hits {
+		ids[i] = n.ID
+	}
+	return Note{}, fmt.Errorf("%s", T(MsgAmbiguous, query, strings.Join(ids, ", ")))
+}
+
+// readTitle 读取笔记标题（第一行非空内容）
+func readTitle(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return titleOf(string(data))
+}
+
+// titleOf 取第一行非空内容并去掉标题符号，最多 60 个字符
+func titleOf(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line == "" {
+			continue
+		}
+		if r := []rune(line); len(r) > 60 {
+			return string(r[:60]) + "…"
+		}
+		return line
+	}
+	return ""
+}
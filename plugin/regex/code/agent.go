@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"wcp_jdata"
+)
+
+// explainPrompt 请 LLM 逐段解释正则（Go RE2 语法）
+func explainPrompt(pattern string) string {
+	return fmt.Sprintf(`Explain this regular expression (Go RE2 syntax) for a developer.
+Break it into its parts in a Markdown list, describe each capture group, give two strings that match
+and one that does not, and point out likely pitfalls (greediness, anchoring, escaping).
+Answer in the language %q.
+
+`+"```"+`
+%s
+`+"```"+`
+`, explainLanguage(), pattern)
+}
+
+// explainLanguage 回答使用界面语言
+func explainLanguage() string {
+	if jdata.CurrentLocale() == jdata.LocaleZhCN {
+		return "zh-CN"
+	}
+	return "en"
+}
+
+// explain 通过 agent ask 获取解释（Markdown），由调用方渲染
+func explain(pattern string) (string, error) {
+	bin, err := jdata.AgentPath()
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command(bin, "ask")
+	cmd.Stdin = strings.NewReader(explainPrompt(pattern))
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -0,0 +1,38 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage       = "usage"
+	MsgError       = "error"
+	MsgNeedPattern = "need_pattern"
+	MsgBadPattern  = "bad_pattern"
+	MsgNoMatch     = "no_match"
+	MsgSummary     = "summary"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:       "usage: regex [-i] [-m] [-s] [--explain] <pattern> [text...]   (reads stdin when no text is given)",
+		MsgError:       "error: %v",
+		MsgNeedPattern: "missing pattern",
+		MsgBadPattern:  "invalid pattern (Go RE2 syntax; lookaround and backreferences are not supported): %v",
+		MsgNoMatch:     "no match",
+		MsgSummary:     "%d match(es) on %d line(s), %d capture group(s)",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:       "用法: regex [-i] [-m] [-s] [--explain] <正则> [文本...]   （未给出文本时读取标准输入）",
+		MsgError:       "错误: %v",
+		MsgNeedPattern: "缺少正则表达式",
+		MsgBadPattern:  "正则无效（Go RE2 语法，不支持环视与反向引用）: %v",
+		MsgNoMatch:     "没有匹配",
+		MsgSummary:     "%d 处匹配，涉及 %d 行，%d 个捕获组",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// errNoMatch 没有匹配：以退出码 1 结束，提示已输出
+var errNoMatch = errors.New("no match")
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help regex 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) && !errors.Is(err, errNoMatch) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// run regex [-i] [-m] [-s] [--explain] <pattern> [text...]
+//
+// 退出码与 grep 一致：有匹配为 0，无匹配为 1，其他错误为 1 并输出原因
+func run(args []string) error {
+	fs := flag.NewFlagSet("regex", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, T(MsgUsage)); fs.PrintDefaults() }
+	ignoreCase := fs.Bool("i", false, "case-insensitive (?i)")
+	multiline := fs.Bool("m", false, "^ and $ match at line breaks (?m), implies -s")
+	single := fs.Bool("s", false, "match against the whole input instead of line by line")
+	explainFlag := fs.Bool("explain", false, "ask the LLM to explain the pattern")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New(T(MsgNeedPattern))
+	}
+	pattern := fs.Arg(0)
+	flags := ""
+	if *ignoreCase {
+		flags += "i"
+	}
+	if *multiline {
+		flags += "m"
+		*single = true
+	}
+	if *single {
+		flags += "s"
+	}
+	compiled := pattern
+	if flags != "" {
+		compiled = "(?" + flags + ")" + pattern
+	}
+	re, err := regexp.Compile(compiled)
+	if err != nil {
+		return fmt.Errorf("%s", T(MsgBadPattern, err))
+	}
+
+	if *explainFlag {
+		answer, err := explain(pattern)
+		if err != nil {
+			return err
+		}
+		if err := renderMarkdown(answer); err != nil {
+			return err
+		}
+		// 仅解释、没有样例输入时到此结束
+		if fs.NArg() == 1 && term.IsTerminal(int(os.Stdin.Fd())) {
+			return nil
+		}
+	}
+
+	input, err := readInput(fs.Args()[1:])
+	if err != nil {
+		return err
+	}
+	lines := []string{input}
+	if !*single {
+		lines = strings.Split(input, "\n")
+	}
+	matches := findAll(re, lines)
+	if len(matches) == 0 {
+		fmt.Fprintln(os.Stderr, T(MsgNoMatch))
+		return errNoMatch
+	}
+	printMatches(os.Stdout, re, lines, matches, term.IsTerminal(int(os.Stdout.Fd())))
+	return nil
+}
+
+// readInput 参数优先（多个参数视为多行），否则读取 stdin
+func readInput(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, "\n"), nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	return strings.TrimRight(string(data), "\n"), err
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ANSI 样式：整体匹配加下划线，捕获组按序号轮换颜色
+const (
+	ansiReset     = "\033[0m"
+	ansiUnderline = "\033[4m"
+	ansiDim       = "\033[2m"
+	ansiBold      = "\033[1m"
+)
+
+// groupColors 捕获组背景色（第 1、2、3… 组依次使用，超出后循环）
+var groupColors = []string{
+	"\033[30;43m", // 黄
+	"\033[30;46m", // 青
+	"\033[30;45m", // 品红
+	"\033[30;42m", // 绿
+	"\033[30;44m", // 蓝
+	"\033[30;41m", // 红
+}
+
+// Match 一处匹配
+type Match struct {
+	Line   int     // 行号（从 1 开始；-s 模式下整个输入视为第 1 行）
+	Groups [][]int // [0] 为整体匹配，其余为捕获组的 [start, end)，未参与匹配的组为 nil
+}
+
+// findAll 逐行（或整体）查找所有匹配
+func findAll(re *regexp.Regexp, lines []string) []Match {
+	var matches []Match
+	for i, line := range lines {
+		for _, loc := range re.FindAllStringSubmatchIndex(line, -1) {
+			m := Match{Line: i + 1}
+			for g := 0; g+1 < len(loc); g += 2 {
+				if loc[g] < 0 {
+					m.Groups = append(m.Groups, nil)
+				} else {
+					m.Groups = append(m.Groups, []int{loc[g], loc[g+1]})
+				}
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// highlight 为一行文本加上匹配样式：捕获组着色，组外的匹配部分加下划线
+func highlight(line string, matches []Match) string {
+	if len(matches) == 0 {
+		return line
+	}
+	// 逐字节计算样式：0 = 无，-1 = 整体匹配，>0 = 捕获组序号（内层组覆盖外层）
+	style := make([]int, len(line))
+	for _, m := range matches {
+		for i := m.Groups[0][0]; i < m.Groups[0][1]; i++ {
+			style[i] = -1
+		}
+		for g, span := range m.Groups[1:] {
+			if span == nil {
+				continue
+			}
+			for i := span[0]; i < span[1]; i++ {
+				style[i] = g + 1
+			}
+		}
+	}
+	var b strings.Builder
+	current := 0
+	for i := 0; i < len(line); i++ {
+		if style[i] != current {
+			b.WriteString(ansiReset)
+			b.WriteString(styleCode(style[i]))
+			current = style[i]
+		}
+		b.WriteByte(line[i])
+	}
+	if current != 0 {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
+
+func styleCode(s int) string {
+	switch {
+	case s == 0:
+		return ""
+	case s < 0:
+		return ansiUnderline + ansiBold
+	}
+	return groupColors[(s-1)%len(groupColors)]
+}
+
+// printMatches 输出高亮后的文本和匹配明细；color 为 false 时只输出明细（便于管道处理）
+func printMatches(w io.Writer, re *regexp.Regexp, lines []string, matches []Match, color bool) {
+	byLine := map[int][]Match{}
+	for _, m := range matches {
+		byLine[m.Line] = append(byLine[m.Line], m)
+	}
+	if color {
+		for i, line := range lines {
+			if ms := byLine[i+1]; len(ms) > 0 {
+				fmt.Fprintf(w, "%s%4d%s  %s\n", ansiDim, i+1, ansiReset, highlight(line, ms))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	names := re.SubexpNames()
+	for n, m := range matches {
+		text := lines[m.Line-1]
+		whole := m.Groups[0]
+		fmt.Fprintf(w, "#%d  %d:%d-%d  %q\n", n+1, m.Line, whole[0], whole[1], text[whole[0]:whole[1]])
+		for g, span := range m.Groups[1:] {
+			label := fmt.Sprintf("$%d", g+1)
+			if names[g+1] != "" {
+				label += " (" + names[g+1] + ")"
+			}
+			value := "<nil>"
+			if span != nil {
+				value = fmt.Sprintf("%q", text[span[0]:span[1]])
+				if color {
+					value = styleCode(g+1) + value + ansiReset
+				}
+			}
+			fmt.Fprintf(w, "    %s = %s\n", label, value)
+		}
+	}
+	lineCount := len(byLine)
+	fmt.Fprintln(w, T(MsgSummary, len(matches), lineCount, re.NumSubexp()))
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wcp_jdata"
+)
+
+// CodeBlock Markdown 中的一个围栏代码块
+type CodeBlock struct {
+	Lang string
+	Code string
+}
+
+// extractCodeBlocks 提取 ``` / ~~~ 围栏代码块，未闭合的末尾代码块同样保留
+func extractCodeBlocks(markdown string) []CodeBlock {
+	var (
+		blocks []CodeBlock
+		fence  string
+		lang   string
+		body   []string
+	)
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" {
+			for _, f := range []string{"```", "~~~"} {
+				if strings.HasPrefix(trimmed, f) {
+					fence = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, f[:1]))]
+					lang = ""
+					if info := strings.Fields(trimmed[len(fence):]); len(info) > 0 {
+						lang = info[0]
+					}
+					body = nil
+					break
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			blocks = append(blocks, CodeBlock{lang, strings.Join(body, "\n")})
+			fence = ""
+			continue
+		}
+		body = append(body, line)
+	}
+	if fence != "" && len(body) > 0 {
+		blocks = append(blocks, CodeBlock{lang, strings.Join(body, "\n")})
+	}
+	return blocks
+}
+
+// askExchange ask 历史中插件关心的字段
+type askExchange struct {
+	ID     string `json:"id"`
+	Answer string `json:"answer"`
+}
+
+// lastAnswer 读取最近一轮 ask 回答
+func lastAnswer() (askExchange, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return askExchange{}, err
+	}
+	f, err := os.Open(filepath.Join(dir, AskHistoryFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return askExchange{}, errors.New(T(MsgNoAnswer))
+	}
+	if err != nil {
+		return askExchange{}, err
+	}
+	defer f.Close()
+	var last askExchange
+	found := false
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e askExchange
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			last, found = e, true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return askExchange{}, err
+	}
+	if !found {
+		return askExchange{}, errors.New(T(MsgNoAnswer))
+	}
+	return last, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"wcp_jdata"
+)
+
+const (
+	// AccessibleEnv 无障碍模式的环境变量（优先级高于配置文件），与 j 主程序、agent 一致
+	AccessibleEnv = "J_ACCESSIBLE"
+	// SettingAccessible setting 段中的无障碍模式开关
+	SettingAccessible = "accessible"
+
+	// SnipDir 片段存储目录（位于数据目录下）
+	SnipDir = "snip"
+	// AskHistoryFile agent ask 的问答历史（位于数据目录下）
+	AskHistoryFile = "agent/data/ask_history.jsonl"
+)
+
+// accessibleMode 是否开启无障碍模式：J_ACCESSIBLE > config.yaml 的 setting.accessible，默认关闭
+func accessibleMode() bool {
+	v := os.Getenv(AccessibleEnv)
+	if v == "" {
+		v = jdata.Setting(SettingAccessible)
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "on", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// fuzzyScore 大小写不敏感的子序列匹配打分：不匹配返回 -1，
+// 连续命中、命中词首和更短的候选得分更高
+func fuzzyScore(candidate, query string) int {
+	c, q := strings.ToLower(candidate), strings.ToLower(query)
+	if q == "" {
+		return 0
+	}
+	if strings.Contains(c, q) {
+		// 子串命中直接给高分，越靠前越好
+		return 1000 - strings.Index(c, q) - utf8.RuneCountInString(c)/10
+	}
+	score, run := 0, 0
+	prev := ' '
+	qr := []rune(q)
+	qi := 0
+	for _, r := range c {
+		if qi < len(qr) && r == qr[qi] {
+			qi++
+			run++
+			score += 1 + run*2
+			if !isWordRune(prev) {
+				score += 5
+			}
+		} else {
+			run = 0
+		}
+		prev = r
+	}
+	if qi < len(qr) {
+		return -1
+	}
+	return score - utf8.RuneCountInString(c)/10
+}
+
+// isWordRune 字母数字视为词内字符，其余视为分隔
+func isWordRune(r rune) bool {
+	return r == '_' || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || r > 127
+}
+
+// search 在名称、标签、语言和代码中模糊搜索，名称 / 标签命中权重更高
+func search(snippets []Snippet, query string) []Snippet {
+	type scored struct {
+		s     Snippet
+		score int
+	}
+	var hits []scored
+	for _, s := range snippets {
+		best := -1
+		for _, field := range []struct {
+			text   string
+			weight int
+		}{
+			{s.Name, 3},
+			{strings.Join(s.Tags, " "), 2},
+			{s.Lang, 2},
+			{s.Code, 1},
+		} {
+			if score := fuzzyScore(field.text, query); score >= 0 && score*field.weight > best {
+				best = score * field.weight
+			}
+		}
+		if best >= 0 {
+			hits = append(hits, scored{s, best})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	result := make([]Snippet, len(hits))
+	for i, h := range hits {
+		result[i] = h.s
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage             = "usage"
+	MsgUsageCommand      = "usage_command"
+	MsgUnknownCommand    = "unknown_command"
+	MsgError             = "error"
+	MsgAddSummary        = "add_summary"
+	MsgFromAnswerSummary = "from_answer_summary"
+	MsgListSummary       = "list_summary"
+	MsgSearchSummary     = "search_summary"
+	MsgShowSummary       = "show_summary"
+	MsgCopySummary       = "copy_summary"
+	MsgRmSummary         = "rm_summary"
+	MsgEmptyCode         = "empty_code"
+	MsgNotFound          = "not_found"
+	MsgAmbiguous         = "ambiguous"
+	MsgNeedQuery         = "need_query"
+	MsgSaved             = "saved"
+	MsgRemoved           = "removed"
+	MsgCopied            = "copied"
+	MsgNoSnippets        = "no_snippets"
+	MsgNoAnswer          = "no_answer"
+	MsgNoCodeBlocks      = "no_code_blocks"
+	MsgBlockIndex        = "block_index"
+	MsgNoClipboard       = "no_clipboard"
+	MsgPickSummary       = "pick_summary"
+	MsgPickPrompt        = "pick_prompt"
+	MsgPickNoTerminal    = "pick_no_terminal"
+	MsgPickQuestion      = "pick_question"
+	MsgPickListed        = "pick_listed"
+	MsgPickMore          = "pick_more"
+	MsgPickNumber        = "pick_number"
+	MsgNeedQuestion      = "need_question"
+	MsgNoAgent           = "no_agent"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:             "usage: snip <command> [args]",
+		MsgUsageCommand:      "  %-12s %s",
+		MsgUnknownCommand:    "unknown command: %s",
+		MsgError:             "error: %v",
+		MsgAddSummary:        "save a snippet from a file or stdin",
+		MsgFromAnswerSummary: "save code blocks from the last ask answer",
+		MsgListSummary:       "list snippets (optionally by tag)",
+		MsgSearchSummary:     "fuzzy-search names, tags and code",
+		MsgShowSummary:       "print a snippet with syntax highlighting",
+		MsgCopySummary:       "copy a snippet to the clipboard",
+		MsgRmSummary:         "delete a snippet",
+		MsgEmptyCode:         "snippet is empty",
+		MsgNotFound:          "no snippet matches %q",
+		MsgAmbiguous:         "%q matches several snippets: %s",
+		MsgNeedQuery:         "missing snippet id or name",
+		MsgSaved:             "saved snippet %s (%s)",
+		MsgRemoved:           "removed snippet %s",
+		MsgCopied:            "copied snippet %s to the clipboard",
+		MsgNoSnippets:        "no snippets yet",
+		MsgNoAnswer:          "no ask history found",
+		MsgNoCodeBlocks:      "the last answer has no code blocks",
+		MsgBlockIndex:        "code block index %d out of range (1-%d)",
+		MsgNoClipboard:       "no clipboard tool found (pbcopy, wl-copy, xclip, xsel, clip.exe)",
+		MsgPickSummary:       "fuzzy-pick a snippet to print, copy or ask about",
+		MsgPickPrompt:        "snippet",
+		MsgPickNoTerminal:    "picking needs a terminal (or fzf)",
+		MsgPickQuestion:      "question: ",
+		MsgPickListed:        "%s, %d matches:",
+		MsgPickMore:          "and %d more; type some text to narrow the list",
+		MsgPickNumber:        "number to pick, text to filter, empty line to cancel: ",
+		MsgNeedQuestion:      "missing question",
+		MsgNoAgent:           "agent plugin not found (~/.jdata/bin/agent or PATH)",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:             "用法: snip <命令> [参数]",
+		MsgUsageCommand:      "  %-12s %s",
+		MsgUnknownCommand:    "未知命令: %s",
+		MsgError:             "错误: %v",
+		MsgAddSummary:        "从文件或管道保存片段",
+		MsgFromAnswerSummary: "从上一次 ask 回答中提取代码块保存",
+		MsgListSummary:       "列出片段（可按标签过滤）",
+		MsgSearchSummary:     "模糊搜索名称、标签和代码",
+		MsgShowSummary:       "带语法高亮输出片段",
+		MsgCopySummary:       "复制片段到剪贴板",
+		MsgRmSummary:         "删除片段",
+		MsgEmptyCode:         "片段内容为空",
+		MsgNotFound:          "没有匹配 %q 的片段",
+		MsgAmbiguous:         "%q 匹配到多个片段: %s",
+		MsgNeedQuery:         "缺少片段 ID 或名称",
+		MsgSaved:             "已保存片段 %s（%s）",
+		MsgRemoved:           "已删除片段 %s",
+		MsgCopied:            "已复制片段 %s 到剪贴板",
+		MsgNoSnippets:        "还没有保存任何片段",
+		MsgNoAnswer:          "没有找到 ask 历史",
+		MsgNoCodeBlocks:      "上一次回答中没有代码块",
+		MsgBlockIndex:        "代码块序号 %d 超出范围（1-%d）",
+		MsgNoClipboard:       "未找到剪贴板工具（pbcopy、wl-copy、xclip、xsel、clip.exe）",
+		MsgPickSummary:       "模糊选择片段后输出、复制或交给 agent 提问",
+		MsgPickPrompt:        "片段",
+		MsgPickNoTerminal:    "选择需要在终端中进行（或安装 fzf）",
+		MsgPickQuestion:      "问题: ",
+		MsgPickListed:        "%s，共 %d 项：",
+		MsgPickMore:          "另有 %d 项，输入文字可缩小范围",
+		MsgPickNumber:        "输入编号选中，输入文字筛选，空行取消: ",
+		MsgNeedQuestion:      "缺少问题",
+		MsgNoAgent:           "未找到 agent 插件（~/.jdata/bin/agent 或 PATH）",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
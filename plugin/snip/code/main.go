@@ -0,0 +1,409 @@
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+// command 子命令：run 返回的错误统一由 main 输出到 stderr 并以非零码退出
+type command struct {
+	run     func(args []string) error
+	summary string // 用法说明中的一句话介绍（i18n key）
+}
+
+// commands 子命令注册表
+var commands = map[string]command{
+	"add":         {runAdd, MsgAddSummary},
+	"copy":        {runCopy, MsgCopySummary},
+	"from-answer": {runFromAnswer, MsgFromAnswerSummary},
+	"list":        {runList, MsgListSummary},
+	"pick":        {runPick, MsgPickSummary},
+	"rm":          {runRm, MsgRmSummary},
+	"search":      {runSearch, MsgSearchSummary},
+	"show":        {runShow, MsgShowSummary},
+}
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help snip 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name, args := os.Args[1], os.Args[2:]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintln(os.Stderr, T(MsgUnknownCommand, name))
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(args); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// usage 输出命令列表到 stderr
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, T(MsgUsage))
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, T(MsgUsageCommand, name, T(commands[name].summary)))
+	}
+}
+
+// snippetFlags add / from-answer 共用的元数据参数
+type snippetFlags struct {
+	name, lang, tags *string
+}
+
+func newSnippetFlags(fs *flag.FlagSet) snippetFlags {
+	return snippetFlags{
+		name: fs.String("n", "", "snippet name"),
+		lang: fs.String("l", "", "language used for highlighting"),
+		tags: fs.String("t", "", "comma separated tags"),
+	}
+}
+
+// saveNew 补全 ID / 名称后追加保存
+func saveNew(s Snippet) error {
+	if strings.TrimSpace(s.Code) == "" {
+		return errors.New(T(MsgEmptyCode))
+	}
+	snippets, err := loadSnippets()
+	if err != nil {
+		return err
+	}
+	s.ID = newID()
+	if s.Name == "" {
+		s.Name = firstLine(s.Code)
+	}
+	s.Created = time.Now()
+	if err := saveSnippets(append(snippets, s)); err != nil {
+		return err
+	}
+	fmt.Println(T(MsgSaved, s.ID, s.Name))
+	return nil
+}
+
+// runAdd snip add [-n name] [-l lang] [-t tags] [file]，未给出文件时读取 stdin
+func runAdd(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	f := newSnippetFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var (
+		data   []byte
+		err    error
+		source = "stdin"
+		lang   = *f.lang
+	)
+	if fs.NArg() > 0 {
+		source = fs.Arg(0)
+		data, err = os.ReadFile(source)
+		if lang == "" {
+			lang = strings.TrimPrefix(filepath.Ext(source), ".")
+		}
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+	return saveNew(Snippet{
+		Name:   *f.name,
+		Lang:   lang,
+		Tags:   parseTags(*f.tags),
+		Code:   strings.TrimRight(string(data), "\n"),
+		Source: source,
+	})
+}
+
+// runFromAnswer snip from-answer [-i N] [-n name] [-t tags]：保存上一次 ask 回答中的代码块，
+// 默认保存全部，-i 指定第 N 个（从 1 开始）
+func runFromAnswer(args []string) error {
+	fs := flag.NewFlagSet("from-answer", flag.ContinueOnError)
+	f := newSnippetFlags(fs)
+	index := fs.Int("i", 0, "only save the N-th code block (1-based)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	answer, err := lastAnswer()
+	if err != nil {
+		return err
+	}
+	blocks := extractCodeBlocks(answer.Answer)
+	if len(blocks) == 0 {
+		return errors.New(T(MsgNoCodeBlocks))
+	}
+	if *index != 0 {
+		if *index < 1 || *index > len(blocks) {
+			return fmt.Errorf("%s", T(MsgBlockIndex, *index, len(blocks)))
+		}
+		blocks = blocks[*index-1 : *index]
+	}
+	for i, b := range blocks {
+		name := *f.name
+		if name != "" && len(blocks) > 1 {
+			name = fmt.Sprintf("%s-%d", name, i+1)
+		}
+		lang := b.Lang
+		if *f.lang != "" {
+			lang = *f.lang
+		}
+		err := saveNew(Snippet{
+			Name:   name,
+			Lang:   lang,
+			Tags:   parseTags(*f.tags),
+			Code:   b.Code,
+			Source: "ask:" + answer.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runList snip list [-t tag]
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	tag := fs.String("t", "", "only list snippets with this tag")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	snippets, err := loadSnippets()
+	if err != nil {
+		return err
+	}
+	var shown []Snippet
+	for _, s := range snippets {
+		if *tag == "" || s.hasTag(strings.ToLower(*tag)) {
+			shown = append(shown, s)
+		}
+	}
+	printList(shown)
+	return nil
+}
+
+// runSearch snip search <query...>
+func runSearch(args []string) error {
+	snippets, err := loadSnippets()
+	if err != nil {
+		return err
+	}
+	printList(search(snippets, strings.Join(args, " ")))
+	return nil
+}
+
+// runShow snip show [--copy] <id|name>
+func runShow(args []string) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	copyToo := fs.Bool("copy", false, "also copy the snippet to the clipboard")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	s, err := lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if err := renderCode(s); err != nil {
+		return err
+	}
+	if *copyToo {
+		return copySnippet(s)
+	}
+	return nil
+}
+
+// runCopy snip copy <id|name>
+func runCopy(args []string) error {
+	s, err := lookup(firstArg(args))
+	if err != nil {
+		return err
+	}
+	return copySnippet(s)
+}
+
+// runRm snip rm <id|name>
+func runRm(args []string) error {
+	snippets, err := loadSnippets()
+	if err != nil {
+		return err
+	}
+	i, err := find(snippets, firstArg(args))
+	if err != nil {
+		return err
+	}
+	removed := snippets[i]
+	if err := saveSnippets(append(snippets[:i], snippets[i+1:]...)); err != nil {
+		return err
+	}
+	fmt.Println(T(MsgRemoved, removed.ID))
+	return nil
+}
+
+// runPick snip pick [-t tag] [--copy | --ask [问题]]：模糊选择一个片段并输出，
+// --copy 复制到剪贴板，--ask 把片段连同问题交给 agent ask（问题省略时在终端输入）
+func runPick(args []string) error {
+	fs := flag.NewFlagSet("pick", flag.ContinueOnError)
+	tag := fs.String("t", "", "only pick from snippets with this tag")
+	copyIt := fs.Bool("copy", false, "copy the snippet to the clipboard instead of printing it")
+	ask := fs.Bool("ask", false, "ask about the snippet with agent ask (remaining arguments, or typed at the prompt)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	snippets, err := loadSnippets()
+	if err != nil {
+		return err
+	}
+	var shown []Snippet
+	for i := len(snippets) - 1; i >= 0; i-- {
+		if s := snippets[i]; *tag == "" || s.hasTag(strings.ToLower(*tag)) {
+			shown = append(shown, s)
+		}
+	}
+	if len(shown) == 0 {
+		fmt.Println(T(MsgNoSnippets))
+		return nil
+	}
+	items := make([]pickItem, len(shown))
+	for i, s := range shown {
+		label := s.ID + "  " + s.Name
+		if s.Lang != "" {
+			label += "  [" + s.Lang + "]"
+		}
+		for _, t := range s.Tags {
+			label += "  #" + t
+		}
+		items[i] = pickItem{Label: label, Preview: s.Code + "\n"}
+	}
+	i, err := pickOne(T(MsgPickPrompt), items)
+	if errors.Is(err, errPickCancelled) {
+		os.Exit(130)
+	}
+	if errors.Is(err, errNoTerminal) {
+		return errors.New(T(MsgPickNoTerminal))
+	}
+	if err != nil {
+		return err
+	}
+	s := shown[i]
+	switch {
+	case *ask:
+		return askAbout(s, fs.Args())
+	case *copyIt:
+		return copySnippet(s)
+	}
+	return renderCode(s)
+}
+
+// askAbout 把片段附在问题后交给 agent ask；question 为空时在终端输入一行
+func askAbout(s Snippet, question []string) error {
+	bin, err := jdata.AgentPath()
+	if err != nil {
+		return errors.New(T(MsgNoAgent))
+	}
+	q := strings.Join(question, " ")
+	if q == "" {
+		fmt.Fprint(os.Stderr, T(MsgPickQuestion))
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if q = strings.TrimSpace(line); q == "" {
+			return errors.New(T(MsgNeedQuestion))
+		}
+	}
+	cmd := exec.Command(bin, "ask", "--", q+"\n\n```"+s.Lang+"\n"+s.Code+"\n```")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
+
+func lookup(query string) (Snippet, error) {
+	snippets, err := loadSnippets()
+	if err != nil {
+		return Snippet{}, err
+	}
+	i, err := find(snippets, query)
+	if err != nil {
+		return Snippet{}, err
+	}
+	return snippets[i], nil
+}
+
+func copySnippet(s Snippet) error {
+	if err := copyToClipboard(s.Code); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, T(MsgCopied, s.ID))
+	return nil
+}
+
+// printList 每行一个片段: ID  名称  [语言]  #标签
+func printList(snippets []Snippet) {
+	if len(snippets) == 0 {
+		fmt.Println(T(MsgNoSnippets))
+		return
+	}
+	for _, s := range snippets {
+		line := s.ID + "  " + s.Name
+		if s.Lang != "" {
+			line += "  [" + s.Lang + "]"
+		}
+		for _, t := range s.Tags {
+			line += "  #" + t
+		}
+		fmt.Println(line)
+	}
+}
+
+// firstLine 取代码第一行非空内容作为默认名称（最多 40 个字符）
+func firstLine(code string) string {
+	for _, line := range strings.Split(code, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			if r := []rune(line); len(r) > 40 {
+				return string(r[:40]) + "…"
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+	"wcp_jdata"
+)
+
+// renderCode 通过 md_render 输出带语法高亮的代码块；
+// 非终端输出或未找到渲染引擎时原样输出，便于管道使用
+func renderCode(s Snippet) error {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		_, err := fmt.Println(s.Code)
+		return err
+	}
+	bin, err := jdata.MdRenderPath()
+	if err != nil {
+		_, err := fmt.Println(s.Code)
+		return err
+	}
+	cmd := exec.Command(bin)
+	cmd.Stdin = strings.NewReader("```" + s.Lang + "\n" + s.Code + "\n```\n")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// clipboardCommands 各平台的剪贴板写入命令，按顺序尝试
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// copyToClipboard 写入系统剪贴板
+func copyToClipboard(text string) error {
+	for _, argv := range clipboardCommands {
+		if argv[0] == "pbcopy" && runtime.GOOS != "darwin" {
+			continue
+		}
+		path, err := exec.LookPath(argv[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, argv[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return errors.New(T(MsgNoClipboard))
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+// PickerEnv 选择器：fzf、builtin，未设置时有 fzf 就用 fzf
+const PickerEnv = "J_PICKER"
+
+// maxRows 内置选择器最多显示的候选行数
+const maxRows = 12
+
+// maxListed 编号列表每次最多列出的候选数，更多时提示输入文字缩小范围
+const maxListed = 20
+
+// errPickCancelled 用户取消了选择
+var errPickCancelled = errors.New("cancelled")
+
+// errNoTerminal 没有可交互的终端
+var errNoTerminal = errors.New("no terminal to pick from")
+
+// pickItem 一个候选项：Label 单行显示并参与匹配，Preview 为 fzf 预览窗中的内容（可以为空）
+type pickItem struct {
+	Label   string
+	Preview string
+}
+
+// pickOne 让用户从 items 中选择一项，返回其下标：PATH 中有 fzf 时交给 fzf（带预览窗），否则使用内置的简易选择器；
+// 无障碍模式下（J_PICKER=fzf 除外）改为逐行输出的编号列表
+func pickOne(prompt string, items []pickItem) (int, error) {
+	if len(items) == 0 {
+		return -1, errPickCancelled
+	}
+	mode := os.Getenv(PickerEnv)
+	if accessibleMode() && mode != "fzf" {
+		return pickNumbered(prompt, items)
+	}
+	if mode != "builtin" {
+		if bin, err := exec.LookPath("fzf"); err == nil {
+			return pickFzf(bin, prompt, items)
+		} else if mode == "fzf" {
+			return -1, err
+		}
+	}
+	return pickBuiltin(prompt, items)
+}
+
+// pickFzf 每行 "下标\t标签" 交给 fzf，只显示标签；预览内容写入临时目录，以下标为文件名
+func pickFzf(bin, prompt string, items []pickItem) (int, error) {
+	dir, err := os.MkdirTemp("", "j-pick-")
+	if err != nil {
+		return -1, err
+	}
+	defer os.RemoveAll(dir)
+	var input strings.Builder
+	previews := false
+	for i, item := range items {
+		fmt.Fprintf(&input, "%d\t%s\n", i, pickLine(item.Label))
+		if item.Preview != "" {
+			previews = true
+			if err := os.WriteFile(filepath.Join(dir, strconv.Itoa(i)), []byte(item.Preview), 0o600); err != nil {
+				return -1, err
+			}
+		}
+	}
+	args := []string{"--delimiter", "\t", "--with-nth", "2..", "--no-multi", "--reverse", "--height", "60%", "--prompt", prompt + "> "}
+	if previews {
+		args = append(args, "--preview", "cat "+shellQuote(dir)+"/{1} 2>/dev/null", "--preview-window", "right,55%,wrap")
+	}
+	cmd := exec.Command(bin, args...)
+	cmd.Stdin = strings.NewReader(input.String())
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) && (exit.ExitCode() == 1 || exit.ExitCode() == 130) {
+		return -1, errPickCancelled
+	}
+	if err != nil {
+		return -1, err
+	}
+	idx, _, _ := strings.Cut(string(out), "\t")
+	i, err := strconv.Atoi(strings.TrimSpace(idx))
+	if err != nil || i < 0 || i >= len(items) {
+		return -1, errPickCancelled
+	}
+	return i, nil
+}
+
+// pickBuiltin 在 /dev/tty 上就地绘制：首行为输入，下面是按匹配度排序的候选
+func pickBuiltin(prompt string, items []pickItem) (int, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return -1, errNoTerminal
+	}
+	defer tty.Close()
+	state, err := term.MakeRaw(int(tty.Fd()))
+	if err != nil {
+		return -1, errNoTerminal
+	}
+	defer term.Restore(int(tty.Fd()), state)
+
+	cols, rows, err := term.GetSize(int(tty.Fd()))
+	if err != nil || cols <= 0 || rows <= 0 {
+		cols, rows = 80, 24
+	}
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = pickLine(item.Label)
+	}
+	p := &builtin{tty: tty, prompt: prompt, labels: labels, width: cols, rows: min(maxRows, max(rows-2, 1), len(items))}
+	defer p.clear()
+	r := bufio.NewReader(tty)
+	p.filter()
+	for {
+		p.draw()
+		c, _, err := r.ReadRune()
+		if err != nil {
+			return -1, errPickCancelled
+		}
+		switch c {
+		case '\r', '\n':
+			if len(p.matches) == 0 {
+				continue
+			}
+			return p.matches[p.cursor], nil
+		case 3, 7, 4: // Ctrl-C / Ctrl-G / Ctrl-D
+			return -1, errPickCancelled
+		case 27:
+			// 单独的 Esc 后面不会立即跟着其他字节；方向键为 Esc [ A / Esc [ B
+			if r.Buffered() == 0 {
+				return -1, errPickCancelled
+			}
+			seq := make([]byte, 0, 4)
+			for r.Buffered() > 0 && len(seq) < 4 {
+				b, _ := r.ReadByte()
+				seq = append(seq, b)
+				if b >= 'A' && b <= 'Z' || b == '~' {
+					break
+				}
+			}
+			switch strings.TrimLeft(string(seq), "[O") {
+			case "A":
+				p.move(-1)
+			case "B":
+				p.move(1)
+			}
+		case 16, 11: // Ctrl-P / Ctrl-K
+			p.move(-1)
+		case 14: // Ctrl-N
+			p.move(1)
+		case 127, 8:
+			if len(p.query) > 0 {
+				p.query = p.query[:len(p.query)-1]
+				p.filter()
+			}
+		case 21: // Ctrl-U
+			p.query = nil
+			p.filter()
+		default:
+			if unicode.IsPrint(c) {
+				p.query = append(p.query, c)
+				p.filter()
+			}
+		}
+	}
+}
+
+// pickNumbered 无障碍模式的选择：在 /dev/tty 上逐行列出带编号的候选，不用光标移动与反色高亮；
+// 输入编号选中，输入其他文字按模糊匹配缩小范围后重新列出，空行取消
+func pickNumbered(prompt string, items []pickItem) (int, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return -1, errNoTerminal
+	}
+	defer tty.Close()
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = pickLine(item.Label)
+	}
+	p := &builtin{labels: labels}
+	p.filter()
+	r := bufio.NewReader(tty)
+	for {
+		shown := min(len(p.matches), maxListed)
+		var b strings.Builder
+		b.WriteString(T(MsgPickListed, prompt, len(p.matches)) + "\n")
+		for i := 0; i < shown; i++ {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, p.labels[p.matches[i]])
+		}
+		if len(p.matches) > shown {
+			b.WriteString(T(MsgPickMore, len(p.matches)-shown) + "\n")
+		}
+		b.WriteString(T(MsgPickNumber))
+		tty.WriteString(b.String())
+		line, err := r.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return -1, errPickCancelled
+		}
+		if n, convErr := strconv.Atoi(line); convErr == nil && n >= 1 && n <= shown {
+			return p.matches[n-1], nil
+		}
+		if err != nil {
+			return -1, errPickCancelled
+		}
+		p.query = []rune(line)
+		p.filter()
+	}
+}
+
+type builtin struct {
+	tty     *os.File
+	prompt  string
+	labels  []string
+	width   int
+	rows    int
+	query   []rune
+	matches []int // 过滤后的下标，按匹配度排序
+	cursor  int   // matches 中选中的位置
+	top     int   // 第一行显示的位置
+	drawn   bool
+}
+
+func (p *builtin) filter() {
+	type scored struct{ index, score int }
+	var hits []scored
+	q := string(p.query)
+	for i, label := range p.labels {
+		if s := fuzzyScore(label, q); s >= 0 {
+			hits = append(hits, scored{i, s})
+		}
+	}
+	if q != "" {
+		sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	}
+	p.matches = p.matches[:0]
+	for _, h := range hits {
+		p.matches = append(p.matches, h.index)
+	}
+	p.cursor, p.top = 0, 0
+}
+
+func (p *builtin) move(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.cursor = (p.cursor + delta + len(p.matches)) % len(p.matches)
+	if p.cursor < p.top {
+		p.top = p.cursor
+	}
+	if p.cursor >= p.top+p.rows {
+		p.top = p.cursor - p.rows + 1
+	}
+}
+
+// draw 重绘输入行与 rows 行候选，光标回到输入行末尾
+func (p *builtin) draw() {
+	var b strings.Builder
+	if p.drawn {
+		b.WriteString("\r")
+	}
+	p.drawn = true
+	head := fmt.Sprintf("%s> %s", p.prompt, string(p.query))
+	count := fmt.Sprintf("  %d/%d", len(p.matches), len(p.labels))
+	b.WriteString("\x1b[K" + truncate(head, p.width-displayWidth(count)-1) + "\x1b[2m" + count + "\x1b[0m")
+	for row := 0; row < p.rows; row++ {
+		b.WriteString("\r\n\x1b[K")
+		i := p.top + row
+		if i >= len(p.matches) {
+			continue
+		}
+		line := truncate(p.labels[p.matches[i]], p.width-3)
+		if i == p.cursor {
+			b.WriteString("\x1b[7m> " + line + "\x1b[0m")
+		} else {
+			b.WriteString("  " + line)
+		}
+	}
+	fmt.Fprintf(&b, "\x1b[%dA\r\x1b[%dC", p.rows, min(displayWidth(head), p.width-1))
+	p.tty.WriteString(b.String())
+}
+
+// clear 擦除选择器占用的行
+func (p *builtin) clear() {
+	if p.drawn {
+		p.tty.WriteString("\r\x1b[J")
+	}
+}
+
+// pickLine 多行文本压成一行
+func pickLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncate 按显示宽度截断，超出时以 … 结尾
+func truncate(s string, limit int) string {
+	if displayWidth(s) <= limit {
+		return s
+	}
+	w := 0
+	for i, r := range s {
+		if w+runeWidth(r) > limit-1 {
+			return s[:i] + "…"
+		}
+		w += runeWidth(r)
+	}
+	return s
+}
+
+func displayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// runeWidth 中日韩文字、全角符号与谚文占 2 列，其余占 1 列
+func runeWidth(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115f, r >= 0x2e80 && r <= 0x303e, r >= 0x3041 && r <= 0x33ff,
+		r >= 0x3400 && r <= 0x4dbf, r >= 0x4e00 && r <= 0x9fff, r >= 0xa000 && r <= 0xa4cf,
+		r >= 0xac00 && r <= 0xd7a3, r >= 0xf900 && r <= 0xfaff, r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0xff00 && r <= 0xff60, r >= 0xffe0 && r <= 0xffe6, r >= 0x1f300 && r <= 0x1faff,
+		r >= 0x20000 && r <= 0x3fffd:
+		return 2
+	}
+	return 1
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+// Snippet 一条代码片段
+type Snippet struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Lang    string    `json:"lang,omitempty"`
+	Tags    []string  `json:"tags,omitempty"`
+	Code    string    `json:"code"`
+	Source  string    `json:"source,omitempty"` // 来源：文件路径、"stdin" 或 "ask:<问答 ID>"
+	Created time.Time `json:"created"`
+}
+
+// storePath 片段文件: ~/.jdata/snip/snippets.json
+func storePath() (string, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SnipDir, "snippets.json"), nil
+}
+
+// loadSnippets 读取全部片段，文件不存在时返回空列表
+func loadSnippets() ([]Snippet, error) {
+	path, err := storePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snippets []Snippet
+	if err := json.Unmarshal(data, &snippets); err != nil {
+		return nil, err
+	}
+	return snippets, nil
+}
+
+// saveSnippets 整体写回（先写临时文件再改名，避免写一半损坏）
+func saveSnippets(snippets []Snippet) error {
+	path, err := storePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snippets, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// newID 生成 6 位十六进制 ID
+func newID() string {
+	var b [3]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// find 按 ID、名称（大小写不敏感）或唯一 ID 前缀定位片段
+func find(snippets []Snippet, query string) (int, error) {
+	if query == "" {
+		return -1, errors.New(T(MsgNeedQuery))
+	}
+	for i, s := range snippets {
+		if s.ID == query || strings.EqualFold(s.Name, query) {
+			return i, nil
+		}
+	}
+	var hits []int
+	for i, s := range snippets {
+		if strings.HasPrefix(s.ID, query) {
+			hits = append(hits, i)
+		}
+	}
+	switch len(hits) {
+	case 0:
+		return -1, fmt.Errorf("%s", T(MsgNotFound, query))
+	case 1:
+		return hits[0], nil
+	}
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = snippets[h].ID
+	}
+	return -1, fmt.Errorf("%s", T(MsgAmbiguous, query, strings.Join(ids, ", ")))
+}
+
+// parseTags 解析 "a,b, c" 形式的标签，去重并排序
+func parseTags(raw string) []string {
+	seen := map[string]bool{}
+	var tags []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" && !seen[t] {
+			seen[t] = true
+			tags = append(tags, t)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// hasTag 片段是否带有指定标签
+func (s Snippet) hasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+// askPrompt 要求模型只输出 Markdown 任务行，便于直接解析
+func askPrompt(paragraph string, now time.Time) string {
+	return fmt.Sprintf(`Today is %s (%s).
+Split the text between the <text> tags into concrete, actionable tasks.
+Output ONLY Markdown task lines, one per task, in exactly this format:
+- [ ] (A|B|C) task description due:YYYY-MM-DD
+Priority and due date are optional: include a priority only when urgency is implied,
+and a due date only when the text mentions a time. Keep the language of the original text.
+<text>
+%s
+</text>
+`, now.Format(dueLayout), now.Weekday(), strings.TrimSpace(paragraph))
+}
+
+// askTasks 通过 agent ask 把自由描述拆成任务
+func askTasks(paragraph string, now time.Time) ([]Task, error) {
+	bin, err := jdata.AgentPath()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(bin, "ask")
+	cmd.Stdin = strings.NewReader(askPrompt(paragraph, now))
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	var tasks []Task
+	for _, line := range strings.Split(string(out), "\n") {
+		if t, ok := parseTask(strings.TrimSpace(line)); ok && strings.TrimSpace(t.Text) != "" {
+			t.Done = false
+			tasks = append(tasks, t)
+		}
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New(T(MsgAskNoTasks))
+	}
+	return tasks, nil
+}
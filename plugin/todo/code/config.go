@@ -0,0 +1,6 @@
+package main
+
+const (
+	// TodoFile Markdown 任务清单（位于数据目录下，与 j todo 的 todo.json 同目录）
+	TodoFile = "todo/tasks.md"
+)
@@ -0,0 +1,83 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage          = "usage"
+	MsgUsageCommand   = "usage_command"
+	MsgUnknownCommand = "unknown_command"
+	MsgError          = "error"
+	MsgAddSummary     = "add_summary"
+	MsgDoneSummary    = "done_summary"
+	MsgUndoSummary    = "undo_summary"
+	MsgListSummary    = "list_summary"
+	MsgDueSummary     = "due_summary"
+	MsgAskSummary     = "ask_summary"
+	MsgEmptyTask      = "empty_task"
+	MsgBadPriority    = "bad_priority"
+	MsgBadDue         = "bad_due"
+	MsgBadNumber      = "bad_number"
+	MsgNeedNumber     = "need_number"
+	MsgAdded          = "added"
+	MsgMarked         = "marked"
+	MsgNoTasks        = "no_tasks"
+	MsgOverdue        = "overdue"
+	MsgAskNoTasks     = "ask_no_tasks"
+	MsgAskPreview     = "ask_preview"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:          "usage: todo <command> [args]",
+		MsgUsageCommand:   "  %-6s %s",
+		MsgUnknownCommand: "unknown command: %s",
+		MsgError:          "error: %v",
+		MsgAddSummary:     "add a task: todo add [-p A|B|C] [-d due] text",
+		MsgDoneSummary:    "mark tasks done by number",
+		MsgUndoSummary:    "mark tasks not done again",
+		MsgListSummary:    "list open tasks (--all includes done)",
+		MsgDueSummary:     "list tasks due within N days (default 7)",
+		MsgAskSummary:     "turn a freeform paragraph into tasks via the LLM",
+		MsgEmptyTask:      "task text is empty",
+		MsgBadPriority:    "priority must be A, B or C",
+		MsgBadDue:         "cannot parse due date %q (use YYYY-MM-DD, today, tomorrow, +3d, mon..sun)",
+		MsgBadNumber:      "no task #%s",
+		MsgNeedNumber:     "missing task number",
+		MsgAdded:          "added #%d %s",
+		MsgMarked:         "#%d %s",
+		MsgNoTasks:        "no tasks",
+		MsgOverdue:        "overdue",
+		MsgAskNoTasks:     "the model did not return any tasks",
+		MsgAskPreview:     "tasks that would be added (dry run):",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:          "用法: todo <命令> [参数]",
+		MsgUsageCommand:   "  %-6s %s",
+		MsgUnknownCommand: "未知命令: %s",
+		MsgError:          "错误: %v",
+		MsgAddSummary:     "添加任务: todo add [-p A|B|C] [-d 截止] 内容",
+		MsgDoneSummary:    "按编号标记完成",
+		MsgUndoSummary:    "按编号恢复为未完成",
+		MsgListSummary:    "列出未完成任务（--all 包含已完成）",
+		MsgDueSummary:     "列出 N 天内到期的任务（默认 7 天）",
+		MsgAskSummary:     "用 LLM 把一段描述拆成任务",
+		MsgEmptyTask:      "任务内容为空",
+		MsgBadPriority:    "优先级只能是 A、B、C",
+		MsgBadDue:         "无法解析截止日期 %q（可用 YYYY-MM-DD、today、tomorrow、+3d、mon..sun）",
+		MsgBadNumber:      "没有编号为 %s 的任务",
+		MsgNeedNumber:     "缺少任务编号",
+		MsgAdded:          "已添加 #%d %s",
+		MsgMarked:         "#%d %s",
+		MsgNoTasks:        "没有任务",
+		MsgOverdue:        "已过期",
+		MsgAskNoTasks:     "模型没有返回任何任务",
+		MsgAskPreview:     "将要添加的任务（预览）:",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode"
+
+	"wcp_jdata"
+)
+
+// joinedLocales 中英文案被 | 拼接在同一条里的痕迹，如 "text|添加任务"
+var joinedLocales = regexp.MustCompile(`\p{Han}\||\|\p{Han}`)
+
+// TestBundles 每条文案都非空、括号成对，英文里没有中文，也没有另一种语言的文案拼在里面
+func TestBundles(t *testing.T) {
+	for locale, bundle := range bundles {
+		for key, value := range bundle {
+			if strings.TrimSpace(value) == "" {
+				t.Errorf("%s %s: empty", locale, key)
+				continue
+			}
+			if value != strings.TrimRight(value, " ") {
+				t.Errorf("%s %s: trailing space in %q", locale, key, value)
+			}
+			if strings.Count(value, "[") != strings.Count(value, "]") {
+				t.Errorf("%s %s: unbalanced brackets in %q", locale, key, value)
+			}
+			if joinedLocales.MatchString(value) {
+				t.Errorf("%s %s: another locale is joined into %q", locale, key, value)
+			}
+			if locale == jdata.LocaleEN && strings.ContainsFunc(value, func(r rune) bool { return unicode.Is(unicode.Han, r) }) {
+				t.Errorf("%s %s: Chinese text in %q", locale, key, value)
+			}
+		}
+	}
+	for key := range bundles[jdata.DefaultLocale] {
+		if _, ok := bundles[jdata.LocaleEN][key]; !ok {
+			t.Errorf("%s %s: missing", jdata.LocaleEN, key)
+		}
+	}
+}
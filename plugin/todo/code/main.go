@@ -0,0 +1,225 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// command 子命令：run 返回的错误统一由 main 输出到 stderr 并以非零码退出
+type command struct {
+	run     func(args []string) error
+	summary string // 用法说明中的一句话介绍（i18n key）
+}
+
+// commands 子命令注册表
+var commands = map[string]command{
+	"add":  {runAdd, MsgAddSummary},
+	"ask":  {runAsk, MsgAskSummary},
+	"done": {runDone, MsgDoneSummary},
+	"due":  {runDue, MsgDueSummary},
+	"list": {runList, MsgListSummary},
+	"undo": {runUndo, MsgUndoSummary},
+}
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help todo 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	args := os.Args[1:]
+	name := "list"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintln(os.Stderr, T(MsgUnknownCommand, name))
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(args); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// usage 输出命令列表到 stderr
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, T(MsgUsage))
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, T(MsgUsageCommand, name, T(commands[name].summary)))
+	}
+}
+
+// runAdd todo add [-p A|B|C] [-d due] 内容...
+func runAdd(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	priority := fs.String("p", "", "priority: A, B or C")
+	due := fs.String("d", "", "due date: YYYY-MM-DD, today, tomorrow, +3d, +1w, mon..sun")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	text := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if text == "" {
+		return errors.New(T(MsgEmptyTask))
+	}
+	t := Task{Text: text}
+	if *priority != "" {
+		p := strings.ToUpper(*priority)
+		if p != "A" && p != "B" && p != "C" {
+			return errors.New(T(MsgBadPriority))
+		}
+		t.Priority = p
+	}
+	if *due != "" {
+		d, err := parseDue(*due, time.Now())
+		if err != nil {
+			return err
+		}
+		t.Due = d
+	}
+	l, err := loadList()
+	if err != nil {
+		return err
+	}
+	l.Add(t)
+	if err := l.Save(); err != nil {
+		return err
+	}
+	fmt.Println(T(MsgAdded, len(l.Tasks), t))
+	return nil
+}
+
+func runDone(args []string) error { return setDone(args, true) }
+func runUndo(args []string) error { return setDone(args, false) }
+
+// setDone 按编号批量修改完成状态：todo done 1 3 5
+func setDone(args []string, done bool) error {
+	if len(args) == 0 {
+		return errors.New(T(MsgNeedNumber))
+	}
+	l, err := loadList()
+	if err != nil {
+		return err
+	}
+	var changed []int
+	for _, arg := range args {
+		n, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || n < 1 || n > len(l.Tasks) {
+			return fmt.Errorf("%s", T(MsgBadNumber, arg))
+		}
+		t := l.Tasks[n-1]
+		t.Done = done
+		l.Update(n, t)
+		changed = append(changed, n)
+	}
+	if err := l.Save(); err != nil {
+		return err
+	}
+	for _, n := range changed {
+		fmt.Println(T(MsgMarked, n, l.Tasks[n-1]))
+	}
+	return nil
+}
+
+// runList todo [list] [--all]
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	all := fs.Bool("all", false, "include done tasks")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return show(func(t Task) bool { return *all || !t.Done })
+}
+
+// runDue todo due [N]：N 天内（含已过期）到期的未完成任务
+func runDue(args []string) error {
+	days := 7
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 0 {
+			return fmt.Errorf("%s", T(MsgBadDue, args[0]))
+		}
+		days = n
+	}
+	limit, _ := parseDue("+"+strconv.Itoa(days)+"d", time.Now())
+	return show(func(t Task) bool { return !t.Done && !t.Due.IsZero() && !t.Due.After(limit) })
+}
+
+func show(keep func(Task) bool) error {
+	l, err := loadList()
+	if err != nil {
+		return err
+	}
+	var tasks []numbered
+	for i, t := range l.Tasks {
+		if keep(t) {
+			tasks = append(tasks, numbered{i + 1, t})
+		}
+	}
+	sortTasks(tasks)
+	printTasks(os.Stdout, tasks, time.Now())
+	return nil
+}
+
+// runAsk todo ask [--dry-run] "一段描述"：交给 LLM 拆成任务后加入清单
+func runAsk(args []string) error {
+	fs := flag.NewFlagSet("ask", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "print the tasks without saving them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	paragraph := strings.Join(fs.Args(), " ")
+	if paragraph == "" && !term.IsTerminal(int(os.Stdin.Fd())) {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		paragraph = string(data)
+	}
+	if strings.TrimSpace(paragraph) == "" {
+		return errors.New(T(MsgEmptyTask))
+	}
+	tasks, err := askTasks(paragraph, time.Now())
+	if err != nil {
+		return err
+	}
+	if *dryRun {
+		fmt.Println(T(MsgAskPreview))
+		for _, t := range tasks {
+			fmt.Println(t)
+		}
+		return nil
+	}
+	l, err := loadList()
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		l.Add(t)
+		fmt.Println(T(MsgAdded, len(l.Tasks), t))
+	}
+	return l.Save()
+}
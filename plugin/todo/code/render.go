@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ANSI 样式，仅在 stdout 为终端时使用
+const (
+	ansiReset  = "\033[0m"
+	ansiDim    = "\033[2m"
+	ansiStrike = "\033[9m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiBlue   = "\033[34m"
+)
+
+// priorityColors 优先级对应的颜色
+var priorityColors = map[string]string{"A": ansiRed, "B": ansiYellow, "C": ansiBlue}
+
+// numbered 带编号的任务（编号为在清单中的顺序，排序后保持不变）
+type numbered struct {
+	n int
+	Task
+}
+
+// sortTasks 未完成在前；其次按优先级（未设置排最后）、截止日期（无截止排最后）
+func sortTasks(tasks []numbered) {
+	rank := func(p string) int {
+		if p == "" {
+			return 3
+		}
+		return int(p[0] - 'A')
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if a.Done != b.Done {
+			return !a.Done
+		}
+		if rank(a.Priority) != rank(b.Priority) {
+			return rank(a.Priority) < rank(b.Priority)
+		}
+		if a.Due.IsZero() != b.Due.IsZero() {
+			return !a.Due.IsZero()
+		}
+		return a.Due.Before(b.Due)
+	})
+}
+
+// printTasks 输出任务：终端中以 ☐ / ☑ 复选框和颜色显示，管道中输出 Markdown 任务行
+func printTasks(w io.Writer, tasks []numbered, now time.Time) {
+	if len(tasks) == 0 {
+		fmt.Fprintln(w, T(MsgNoTasks))
+		return
+	}
+	color := term.IsTerminal(int(os.Stdout.Fd()))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	for _, t := range tasks {
+		if !color {
+			fmt.Fprintf(w, "%d. %s\n", t.n, t.Task)
+			continue
+		}
+		box, style := "☐", ""
+		if t.Done {
+			box, style = ansiGreen+"☑"+ansiReset, ansiDim+ansiStrike
+		}
+		line := fmt.Sprintf("%3d %s ", t.n, box)
+		if t.Priority != "" {
+			line += priorityColors[t.Priority] + "(" + t.Priority + ")" + ansiReset + " "
+		}
+		line += style + t.Text + ansiReset
+		if !t.Due.IsZero() {
+			due := " 📅 " + t.Due.Format(dueLayout)
+			if !t.Done && t.Due.Before(today) {
+				due = ansiRed + due + " " + T(MsgOverdue) + ansiReset
+			} else {
+				due = ansiDim + due + ansiReset
+			}
+			line += due
+		}
+		fmt.Fprintln(w, line)
+	}
+}
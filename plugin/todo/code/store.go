@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wcp_jdata"
+)
+
+// List 任务清单文件：保留所有非任务行（标题、说明等），只改写任务行
+type List struct {
+	lines []string
+	Tasks []Task
+}
+
+func todoPath() (string, error) {
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, TodoFile), nil
+}
+
+// loadList 读取任务清单，文件不存在时返回带标题的空清单
+func loadList() (*List, error) {
+	path, err := todoPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &List{lines: []string{"# TODO", ""}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	l := &List{lines: strings.Split(strings.TrimRight(string(data), "\n"), "\n")}
+	for i, line := range l.lines {
+		if t, ok := parseTask(line); ok {
+			t.Line = i
+			l.Tasks = append(l.Tasks, t)
+		}
+	}
+	return l, nil
+}
+
+// Add 追加任务到文件末尾
+func (l *List) Add(t Task) {
+	t.Line = len(l.lines)
+	l.lines = append(l.lines, t.String())
+	l.Tasks = append(l.Tasks, t)
+}
+
+// Update 将任务写回原行（编号为 1 起的任务序号）
+func (l *List) Update(n int, t Task) {
+	t.Line = l.Tasks[n-1].Line
+	l.Tasks[n-1] = t
+	l.lines[t.Line] = t.String()
+}
+
+// Save 写回文件（先写临时文件再改名）
+func (l *List) Save() error {
+	path, err := todoPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(l.lines, "\n")+"\n"), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
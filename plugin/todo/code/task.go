@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// dueLayout 截止日期格式
+const dueLayout = "2006-01-02"
+
+// Task 一条任务，在 Markdown 中写作: - [ ] (A) 内容 due:2026-10-20
+type Task struct {
+	Done     bool
+	Priority string // "A" | "B" | "C"，空表示未设置
+	Text     string
+	Due      time.Time // 零值表示无截止日期
+	Line     int       // 在文件中的行号（从 0 开始）
+}
+
+var (
+	taskPattern     = regexp.MustCompile(`^(\s*[-*+]) \[([ xX])\] (.*)$`)
+	priorityPattern = regexp.MustCompile(`^\(([A-Ca-c])\)\s+`)
+	duePattern      = regexp.MustCompile(`(?:^|\s)due:(\d{4}-\d{2}-\d{2})(?:\s|$)`)
+)
+
+// parseTask 解析一行任务，非任务行返回 false
+func parseTask(line string) (Task, bool) {
+	m := taskPattern.FindStringSubmatch(line)
+	if m == nil {
+		return Task{}, false
+	}
+	t := Task{Done: m[2] != " ", Text: m[3]}
+	if p := priorityPattern.FindStringSubmatch(t.Text); p != nil {
+		t.Priority = strings.ToUpper(p[1])
+		t.Text = t.Text[len(p[0]):]
+	}
+	if d := duePattern.FindStringSubmatch(t.Text); d != nil {
+		if due, err := time.ParseInLocation(dueLayout, d[1], time.Local); err == nil {
+			t.Due = due
+			t.Text = strings.TrimSpace(duePattern.ReplaceAllString(t.Text, " "))
+		}
+	}
+	return t, true
+}
+
+// String 序列化为 Markdown 任务行
+func (t Task) String() string {
+	var b strings.Builder
+	if t.Done {
+		b.WriteString("- [x] ")
+	} else {
+		b.WriteString("- [ ] ")
+	}
+	if t.Priority != "" {
+		fmt.Fprintf(&b, "(%s) ", t.Priority)
+	}
+	b.WriteString(t.Text)
+	if !t.Due.IsZero() {
+		b.WriteString(" due:" + t.Due.Format(dueLayout))
+	}
+	return b.String()
+}
+
+// weekdays 英文星期缩写，用于 "fri" 这类截止日期写法
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseDue 解析截止日期：YYYY-MM-DD、today、tomorrow、+Nd、+Nw、星期缩写（下一个该星期几）
+func parseDue(raw string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	s := strings.ToLower(strings.TrimSpace(raw))
+	switch s {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+	if wd, ok := weekdays[s]; ok {
+		days := (int(wd) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+	var n int
+	var unit string
+	if _, err := fmt.Sscanf(s, "+%d%s", &n, &unit); err == nil && n >= 0 {
+		switch unit {
+		case "d":
+			return today.AddDate(0, 0, n), nil
+		case "w":
+			return today.AddDate(0, 0, 7*n), nil
+		}
+	}
+	if due, err := time.ParseInLocation(dueLayout, s, time.Local); err == nil {
+		return due, nil
+	}
+	return time.Time{}, fmt.Errorf("%s", T(MsgBadDue, raw))
+}
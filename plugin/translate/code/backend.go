@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"wcp_jdata"
+)
+
+// Request 一次翻译请求
+type Request struct {
+	Text   string
+	From   string // 源语言，空表示自动检测
+	To     string // 目标语言
+	Terms  []Term // 生效的术语
+	Stderr io.Writer
+}
+
+// Backend 翻译后端
+type Backend func(req Request) (string, error)
+
+// backends 可选的翻译后端
+var backends = map[string]Backend{
+	"llm":   translateLLM,
+	"deepl": translateDeepL,
+}
+
+// ========== LLM（通过 agent 插件） ==========
+
+// translateLLM 组装翻译指令，交给 agent ask 使用当前激活的 provider 完成
+func translateLLM(req Request) (string, error) {
+	bin, err := jdata.AgentPath()
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command(bin, "ask")
+	cmd.Stdin = strings.NewReader(llmPrompt(req))
+	cmd.Stderr = req.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// llmPrompt 翻译指令：只输出译文，保留 Markdown / 代码格式，严格使用术语表译法
+func llmPrompt(req Request) string {
+	var b strings.Builder
+	b.WriteString("Translate the text between the <text> tags")
+	if req.From != "" {
+		fmt.Fprintf(&b, " from %s", req.From)
+	}
+	fmt.Fprintf(&b, " into %s.\n", req.To)
+	b.WriteString("Output only the translation, without explanations or the tags. ")
+	b.WriteString("Keep Markdown formatting, code, URLs and placeholders unchanged.\n")
+	if len(req.Terms) > 0 {
+		b.WriteString("Always use these preferred translations:\n")
+		for _, t := range req.Terms {
+			fmt.Fprintf(&b, "- %s => %s\n", t.Source, t.Target)
+		}
+	}
+	fmt.Fprintf(&b, "<text>\n%s\n</text>\n", req.Text)
+	return b.String()
+}
+
+// ========== DeepL ==========
+
+const (
+	// DeepLKeyEnv DeepL API Key 环境变量
+	DeepLKeyEnv = "DEEPL_API_KEY"
+	// deeplFreeHost / deeplProHost 免费版 Key 以 ":fx" 结尾，使用独立域名
+	deeplFreeHost = "https://api-free.deepl.com"
+	deeplProHost  = "https://api.deepl.com"
+	// deeplKeepTag 包裹术语的 XML 标签，DeepL 不会翻译其中内容
+	deeplKeepTag = "keep"
+)
+
+// translateDeepL 调用 DeepL 翻译 API；术语先替换成指定译法并用忽略标签包裹
+func translateDeepL(req Request) (string, error) {
+	key := os.Getenv(DeepLKeyEnv)
+	if key == "" {
+		return "", fmt.Errorf("%s", T(MsgDeepLNoKey, DeepLKeyEnv))
+	}
+	host := deeplProHost
+	if strings.HasSuffix(key, ":fx") {
+		host = deeplFreeHost
+	}
+
+	text := req.Text
+	form := url.Values{"target_lang": {deeplLang(req.To)}}
+	if req.From != "" {
+		form.Set("source_lang", deeplLang(req.From))
+	}
+	if len(req.Terms) > 0 {
+		text = protectTerms(text, req.Terms)
+		form.Set("tag_handling", "xml")
+		form.Set("ignore_tags", deeplKeepTag)
+	}
+	form.Set("text", text)
+
+	httpReq, err := http.NewRequest(http.MethodPost, host+"/v2/translate", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Authorization", "DeepL-Auth-Key "+key)
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := (&http.Client{Timeout: 60 * time.Second}).Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s", T(MsgDeepLFailed, resp.Status+" "+strings.TrimSpace(string(body))))
+	}
+	var parsed struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	for _, t := range parsed.Translations {
+		out.WriteString(t.Text)
+	}
+	result := out.String()
+	if len(req.Terms) > 0 {
+		result = unprotectTerms(result)
+	}
+	return result, nil
+}
+
+// deeplLang DeepL 语言代码为大写，英文与葡萄牙文目标语言需要区域变体
+func deeplLang(lang string) string {
+	switch code := strings.ToUpper(lang); code {
+	case "EN":
+		return "EN-US"
+	case "PT":
+		return "PT-BR"
+	default:
+		return code
+	}
+}
+
+// protectTerms 开启 XML 模式后先转义原文，再把术语替换为 <keep>译法</keep>
+func protectTerms(text string, terms []Term) string {
+	text = html.EscapeString(text)
+	for _, t := range terms {
+		text = strings.ReplaceAll(text, html.EscapeString(t.Source),
+			"<"+deeplKeepTag+">"+html.EscapeString(t.Target)+"</"+deeplKeepTag+">")
+	}
+	return text
+}
+
+// unprotectTerms 去掉忽略标签并还原转义字符
+func unprotectTerms(text string) string {
+	text = strings.ReplaceAll(text, "<"+deeplKeepTag+">", "")
+	text = strings.ReplaceAll(text, "</"+deeplKeepTag+">", "")
+	return html.UnescapeString(text)
+}
@@ -0,0 +1,8 @@
+package main
+
+const (
+	// SettingTranslateTo 未指定 --to 时的默认目标语言
+	SettingTranslateTo = "translate_to"
+	// SettingTranslateBackend 默认翻译后端（llm | deepl）
+	SettingTranslateBackend = "translate_backend"
+)
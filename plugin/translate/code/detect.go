@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// detectLanguage 按文字体系粗略判断源语言，返回 ISO 639-1 代码；无法判断时返回空串。
+// 只用于提示 LLM 和在 stderr 展示，最终以翻译后端的判断为准。
+func detectLanguage(text string) string {
+	counts := map[string]int{}
+	total := 0
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		default:
+			continue
+		}
+		total++
+	}
+	if total == 0 {
+		return ""
+	}
+	// 日文常混排汉字，出现假名即视为日文
+	if counts["ja"] > 0 {
+		return "ja"
+	}
+	best, bestN := "", 0
+	for lang, n := range counts {
+		if n > bestN || (n == bestN && lang < best) {
+			best, bestN = lang, n
+		}
+	}
+	if best == "latin" {
+		return detectLatin(text)
+	}
+	return best
+}
+
+// latinHints 常见拉丁字母语言的高频词，用于在拉丁文字之间区分
+var latinHints = map[string][]string{
+	"en": {"the", "and", "is", "of", "to", "with", "this", "that"},
+	"fr": {"le", "la", "les", "et", "est", "une", "des", "avec"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "ein"},
+	"es": {"el", "los", "las", "y", "es", "una", "con", "para"},
+}
+
+// detectLatin 统计高频词命中数，默认视为英文
+func detectLatin(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	seen := map[string]bool{}
+	for _, w := range words {
+		seen[w] = true
+	}
+	best, bestN := "en", 0
+	for _, lang := range []string{"en", "fr", "de", "es"} {
+		n := 0
+		for _, w := range latinHints[lang] {
+			if seen[w] {
+				n++
+			}
+		}
+		if n > bestN {
+			best, bestN = lang, n
+		}
+	}
+	return best
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"wcp_jdata"
+)
+
+// GlossaryFile 默认术语表位置（位于数据目录下）
+const GlossaryFile = "translate/glossary.yaml"
+
+// Glossary 术语表：目标语言 → (原文术语 → 指定译法)，"*" 对所有目标语言生效
+//
+//	"*":
+//	  j-cli: j-cli
+//	en:
+//	  日报: daily report
+type Glossary map[string]map[string]string
+
+// glossaryPath 未指定 --glossary 时使用 ~/.jdata/translate/glossary.yaml
+func glossaryPath(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	dir, err := jdata.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, GlossaryFile), nil
+}
+
+// loadGlossary 读取术语表；默认位置不存在时返回空表
+func loadGlossary(path string) (Glossary, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Glossary{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var g Glossary
+	if err := yaml.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("%s", T(MsgGlossaryInvalid, path, err))
+	}
+	return g, nil
+}
+
+// Term 一条术语
+type Term struct {
+	Source, Target string
+}
+
+// Terms 返回对目标语言生效的术语，且只保留原文中出现过的，按原文长度降序（长词优先替换）
+func (g Glossary) Terms(target, text string) []Term {
+	merged := map[string]string{}
+	for _, lang := range []string{"*", strings.ToLower(target)} {
+		for src, dst := range g[lang] {
+			merged[src] = dst
+		}
+	}
+	var terms []Term
+	for src, dst := range merged {
+		if src != "" && strings.Contains(text, src) {
+			terms = append(terms, Term{src, dst})
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if len(terms[i].Source) != len(terms[j].Source) {
+			return len(terms[i].Source) > len(terms[j].Source)
+		}
+		return terms[i].Source < terms[j].Source
+	})
+	return terms
+}
@@ -0,0 +1,13 @@
+module wcp_translate
+
+go 1.26.0
+
+require (
+	golang.org/x/term v0.46.0
+	gopkg.in/yaml.v3 v3.0.1
+	wcp_jdata v0.0.0
+)
+
+require golang.org/x/sys v0.48.0 // indirect
+
+replace wcp_jdata => ../../jdata
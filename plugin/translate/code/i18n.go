@@ -0,0 +1,44 @@
+package main
+
+import "wcp_jdata"
+
+// 消息 key 常量，所有面向用户的文案都必须通过这些 key 获取
+const (
+	MsgUsage           = "usage"
+	MsgError           = "error"
+	MsgNoText          = "no_text"
+	MsgUnknownBackend  = "unknown_backend"
+	MsgDeepLNoKey      = "deepl_no_key"
+	MsgDeepLFailed     = "deepl_failed"
+	MsgGlossaryInvalid = "glossary_invalid"
+	MsgDetected        = "detected"
+)
+
+// bundles 各语言的消息模板，格式化参数遵循 fmt 语法
+var bundles = jdata.Bundles{
+	jdata.LocaleEN: {
+		MsgUsage:           "usage: translate [--to lang] [--from lang] [--backend llm|deepl] [--glossary file] [text...]",
+		MsgError:           "error: %v",
+		MsgNoText:          "nothing to translate: pass text as arguments or pipe it in",
+		MsgUnknownBackend:  "unknown backend %q (expected llm or deepl)",
+		MsgDeepLNoKey:      "DeepL backend needs %s to be set",
+		MsgDeepLFailed:     "DeepL request failed: %s",
+		MsgGlossaryInvalid: "invalid glossary %s: %v",
+		MsgDetected:        "detected source language: %s",
+	},
+	jdata.LocaleZhCN: {
+		MsgUsage:           "用法: translate [--to 语言] [--from 语言] [--backend llm|deepl] [--glossary 文件] [文本...]",
+		MsgError:           "错误: %v",
+		MsgNoText:          "没有要翻译的内容：请以参数给出文本或通过管道输入",
+		MsgUnknownBackend:  "未知的翻译后端 %q（可选 llm 或 deepl）",
+		MsgDeepLNoKey:      "DeepL 后端需要设置 %s",
+		MsgDeepLFailed:     "DeepL 请求失败: %s",
+		MsgGlossaryInvalid: "术语表 %s 格式错误: %v",
+		MsgDetected:        "检测到源语言: %s",
+	},
+}
+
+// T 获取当前语言下的文案（见 jdata.Bundles.T）
+func T(key string, args ...any) string {
+	return bundles.T(key, args...)
+}
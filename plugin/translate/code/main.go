@@ -0,0 +1,118 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+	"wcp_jdata"
+)
+
+// DefaultTarget 未指定 --to 且未配置 setting.translate_to 时的目标语言
+const DefaultTarget = "en"
+
+// helpDoc 帮助文档：以唯一的参数 --help-markdown 启动时原样输出，由 j help translate 交给 md_render 渲染
+//
+//go:embed help.md
+var helpDoc string
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--help-markdown" {
+		fmt.Print(helpDoc)
+		return
+	}
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, T(MsgError, err))
+		}
+		os.Exit(1)
+	}
+}
+
+// run translate [--to lang] [--from lang] [--backend llm|deepl] [--glossary file] [text...]
+func run(args []string) error {
+	fs := flag.NewFlagSet("translate", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, T(MsgUsage)); fs.PrintDefaults() }
+	to := fs.String("to", "", "target language, e.g. en, zh, ja")
+	from := fs.String("from", "", "source language (auto-detected when empty)")
+	backendName := fs.String("backend", "", "llm (default) or deepl")
+	glossaryFlag := fs.String("glossary", "", "glossary yaml (defaults to ~/.jdata/translate/glossary.yaml)")
+	quiet := fs.Bool("q", false, "do not print the detected source language")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	text, err := readText(fs.Args())
+	if err != nil {
+		return err
+	}
+	target := firstNonEmpty(*to, jdata.Setting(SettingTranslateTo), DefaultTarget)
+	name := firstNonEmpty(*backendName, jdata.Setting(SettingTranslateBackend), "llm")
+	backend, ok := backends[name]
+	if !ok {
+		return fmt.Errorf("%s", T(MsgUnknownBackend, name))
+	}
+
+	source := *from
+	if source == "" {
+		source = detectLanguage(text)
+		if source != "" && !*quiet {
+			fmt.Fprintln(os.Stderr, T(MsgDetected, source))
+		}
+	}
+
+	path, err := glossaryPath(*glossaryFlag)
+	if err != nil {
+		return err
+	}
+	glossary, err := loadGlossary(path)
+	if err != nil {
+		return err
+	}
+	result, err := backend(Request{
+		Text:   text,
+		From:   source,
+		To:     target,
+		Terms:  glossary.Terms(target, text),
+		Stderr: os.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(result)
+	return nil
+}
+
+// readText 参数优先，否则读取管道输入（stdin 为终端时不等待输入）
+func readText(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errors.New(T(MsgNoText))
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return "", errors.New(T(MsgNoText))
+	}
+	return text, nil
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -8,6 +8,7 @@
 //! | 资源名称 | 类型 | 路径 | 用途 |
 //! |---------|------|------|------|
 //! | `HELP_TEXT` | 文本 | `assets/help.md` | 帮助命令输出 |
+//! | `HELP_TEXT_EN` | 文本 | `assets/help.en.md` | 帮助命令输出（英文） |
 //! | `VERSION_TEMPLATE` | 文本 | `assets/version.md` | 版本命令模板 |
 //! | `MD_RENDER_BINARY` | 二进制 | `plugin/md_render/bin/` | Markdown 渲染引擎 |
 
@@ -19,10 +20,16 @@
 /// 格式: Markdown
 pub const HELP_TEXT: &str = include_str!("../assets/help.md");
 
+/// 帮助文档的英文版，章节与 `HELP_TEXT` 一一对应
+///
+/// 用途: `J_LANG=en` 时的 `j help` 输出
+/// 格式: Markdown
+pub const HELP_TEXT_EN: &str = include_str!("../assets/help.en.md");
+
 /// 版本信息模板
 ///
 /// 用途: `j version` 命令输出
-/// 占位符: `{version}`, `{os}`, `{extra}`
+/// 占位符: `{version}`, `{os}`, `{arch}`，表头 `{property}`, `{value}` 按当前语言替换
 /// 格式: Markdown 表格
 pub const VERSION_TEMPLATE: &str = include_str!("../assets/version.md");
 
@@ -192,9 +192,12 @@ pub enum SubCmd {
     #[command(alias = "v")]
     Version,
 
-    /// 帮助信息
+    /// 帮助信息（给出插件名时渲染该插件的帮助页）
     #[command(alias = "h")]
-    Help,
+    Help {
+        /// 插件名（如 calc），或 agent 的命令名（如 history）
+        topic: Option<String>,
+    },
 
     /// 退出（交互模式）
     #[command(aliases = ["q", "quit"])]
@@ -215,9 +218,34 @@ pub enum SubCmd {
         model: Option<String>,
     },
 
+    // ========== profile ==========
+    /// 管理 profile（彼此独立的配置、Key 与历史，如 work / personal）
+    Profile {
+        /// 操作: list（默认）/ create / switch
+        action: Option<String>,
+        /// profile 名称（create / switch 时必填）
+        name: Option<String>,
+    },
+
     /// 生成 shell 补全脚本
     Completion {
         /// shell 类型: zsh, bash, fish
         shell: Option<String>,
     },
+
+    /// 生成文档：man（核心与已安装插件的 man page）
+    Gen {
+        /// 生成的内容（目前支持: man）
+        target: String,
+        /// 输出目录（默认 ~/.jdata/man/man1，打包时可指向 $PREFIX/share/man/man1）
+        #[arg(short = 'o', long = "out")]
+        out: Option<String>,
+    },
+
+    /// 使用统计与运行时统计（交给 agent stats）：reset 清空，metrics [--json] 查看守护进程的请求统计
+    Stats {
+        /// 传给 agent stats 的参数
+        #[arg(trailing_var_arg = true, allow_hyphen_values = true)]
+        args: Vec<String>,
+    },
 }
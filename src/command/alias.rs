@@ -2,7 +2,8 @@ use crate::command::all_command_keywords;
 use crate::config::YamlConfig;
 use crate::constants::section;
 use crate::constants::{MODIFY_SECTIONS, REMOVE_CLEANUP_SECTIONS, RENAME_SYNC_SECTIONS};
-use crate::{error, info, usage};
+use crate::util::i18n::msg;
+use crate::{error, info, t, usage};
 use url::Url;
 
 /// 处理 set 命令: j set <alias> <path...>
@@ -14,7 +15,7 @@ pub fn handle_set(alias: &str, path_parts: &[String], config: &mut YamlConfig) {
 
     // 检查别名是否与内置命令冲突
     if all_command_keywords().contains(&alias) {
-        error!("别名 `{}` 已经是预设命令，请换一个。 😢", alias);
+        error!("{}", t!(msg::ALIAS_RESERVED, alias));
         return;
     }
 
@@ -38,8 +39,8 @@ pub fn handle_remove(alias: &str, config: &mut YamlConfig) {
             let path = std::path::Path::new(&script_path);
             if path.exists() {
                 match std::fs::remove_file(path) {
-                    Ok(_) => info!("🗑️ 已删除脚本文件: {}", script_path),
-                    Err(e) => error!("⚠️ 删除脚本文件失败: {}", e),
+                    Ok(_) => info!("{}", t!(msg::ALIAS_SCRIPT_DELETED, script_path)),
+                    Err(e) => error!("{}", t!(msg::ALIAS_SCRIPT_DELETE_FAILED, e)),
                 }
             }
         }
@@ -48,15 +49,15 @@ pub fn handle_remove(alias: &str, config: &mut YamlConfig) {
         for s in REMOVE_CLEANUP_SECTIONS {
             config.remove_property(s, alias);
         }
-        info!("成功从 PATH 中移除别名 {} ✅", alias);
+        info!("{}", t!(msg::ALIAS_REMOVED_PATH, alias));
     } else if config.contains(section::INNER_URL, alias) {
         config.remove_property(section::INNER_URL, alias);
-        info!("成功从 INNER_URL 中移除别名 {} ✅", alias);
+        info!("{}", t!(msg::ALIAS_REMOVED_INNER_URL, alias));
     } else if config.contains(section::OUTER_URL, alias) {
         config.remove_property(section::OUTER_URL, alias);
-        info!("成功从 OUTER_URL 中移除别名 {} ✅", alias);
+        info!("{}", t!(msg::ALIAS_REMOVED_OUTER_URL, alias));
     } else {
-        error!("别名 {} 不存在 ❌", alias);
+        error!("{}", t!(msg::ALIAS_NOT_FOUND, alias));
     }
 }
 
@@ -76,10 +77,7 @@ pub fn handle_rename(alias: &str, new_alias: &str, config: &mut YamlConfig) {
             config.rename_property(s, alias, new_alias);
         }
         updated = true;
-        info!(
-            "✅ 重命名 {} -> {} 成功! Path: {} 🎉",
-            alias, new_alias, path
-        );
+        info!("{}", t!(msg::ALIAS_RENAMED_PATH, alias, new_alias, path));
     }
 
     // inner_url
@@ -91,8 +89,8 @@ pub fn handle_rename(alias: &str, new_alias: &str, config: &mut YamlConfig) {
         config.rename_property(section::INNER_URL, alias, new_alias);
         updated = true;
         info!(
-            "✅ 重命名 {} -> {} 成功! Inner URL: {} 🚀",
-            alias, new_alias, url
+            "{}",
+            t!(msg::ALIAS_RENAMED_INNER_URL, alias, new_alias, url)
         );
     }
 
@@ -105,13 +103,13 @@ pub fn handle_rename(alias: &str, new_alias: &str, config: &mut YamlConfig) {
         config.rename_property(section::OUTER_URL, alias, new_alias);
         updated = true;
         info!(
-            "✅ 重命名 {} -> {} 成功! Outer URL: {} 🌐",
-            alias, new_alias, url
+            "{}",
+            t!(msg::ALIAS_RENAMED_OUTER_URL, alias, new_alias, url)
         );
     }
 
     if !updated {
-        error!("❌ 别名 {} 不存在!", alias);
+        error!("{}", t!(msg::ALIAS_RENAME_NOT_FOUND, alias));
     }
 }
 
@@ -133,12 +131,12 @@ pub fn handle_modify(alias: &str, path_parts: &[String], config: &mut YamlConfig
         if config.contains(s, alias) {
             config.set_property(s, alias, &path);
             has_modified = true;
-            info!("修改 {} 在 {} 下的值为 {{{}}} 成功 ✅", alias, s, path);
+            info!("{}", t!(msg::ALIAS_MODIFIED, alias, s, path));
         }
     }
 
     if !has_modified {
-        error!("别名 {} 不存在，请先使用 set 命令添加。", alias);
+        error!("{}", t!(msg::ALIAS_MODIFY_NOT_FOUND, alias));
     }
 }
 
@@ -158,22 +156,25 @@ fn is_url(input: &str) -> bool {
 fn add_as_path(alias: &str, path: &str, config: &mut YamlConfig) {
     if config.contains(section::PATH, alias) {
         error!(
-            "别名 {} 的路径 {{{}}} 已存在。 😢 请使用 `mf` 命令修改",
-            alias,
-            config.get_property(section::PATH, alias).unwrap()
+            "{}",
+            t!(
+                msg::ALIAS_PATH_EXISTS,
+                alias,
+                config.get_property(section::PATH, alias).unwrap()
+            )
         );
     } else {
         config.set_property(section::PATH, alias, path);
-        info!("✅ 添加别名 {} -> {{{}}} 成功! 🎉", alias, path);
+        info!("{}", t!(msg::ALIAS_ADDED_PATH, alias, path));
     }
 }
 
 /// 添加为 URL 别名
 fn add_as_url(alias: &str, url: &str, config: &mut YamlConfig) {
     if config.contains(section::INNER_URL, alias) || config.contains(section::OUTER_URL, alias) {
-        error!("别名 {} 已存在。 😢 请使用 `mf` 命令修改", alias);
+        error!("{}", t!(msg::ALIAS_EXISTS, alias));
     } else {
         config.set_property(section::INNER_URL, alias, url);
-        info!("✅ 添加别名 {} -> {{{}}} 成功! 🚀", alias, url);
+        info!("{}", t!(msg::ALIAS_ADDED_URL, alias, url));
     }
 }
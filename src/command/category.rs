@@ -1,6 +1,7 @@
 use crate::config::YamlConfig;
 use crate::constants::{self, section};
-use crate::{error, info, usage};
+use crate::util::i18n::msg;
+use crate::{error, info, t, usage};
 
 /// 支持标记的分类列表（引用全局常量）
 const VALID_CATEGORIES: &[&str] = constants::NOTE_CATEGORIES;
@@ -13,15 +14,15 @@ pub fn handle_note(alias: &str, category: &str, config: &mut YamlConfig) {
         && !config.contains(section::INNER_URL, alias)
         && !config.contains(section::OUTER_URL, alias)
     {
-        error!("❌ 别名 {} 不存在", alias);
+        error!("{}", t!(msg::CATEGORY_ALIAS_NOT_FOUND, alias));
         return;
     }
 
     // 校验 category 是否合法
     if !VALID_CATEGORIES.contains(&category) {
         usage!(
-            "j note <alias> <category> (可选: {})",
-            VALID_CATEGORIES.join(", ")
+            "{}",
+            t!(msg::CATEGORY_NOTE_USAGE, VALID_CATEGORIES.join(", "))
         );
         return;
     }
@@ -32,9 +33,9 @@ pub fn handle_note(alias: &str, category: &str, config: &mut YamlConfig) {
             if let Some(url) = config.get_property(section::INNER_URL, alias).cloned() {
                 config.set_property(section::OUTER_URL, alias, &url);
                 config.remove_property(section::INNER_URL, alias);
-                info!("✅ 将别名 {} 标记为 OUTER_URL 成功", alias);
+                info!("{}", t!(msg::CATEGORY_MARKED_OUTER_URL, alias));
             } else {
-                error!("❌ 别名 {} 不在 INNER_URL 中，无法标记为 OUTER_URL", alias);
+                error!("{}", t!(msg::CATEGORY_NOT_INNER_URL, alias));
             }
         }
         _ => {
@@ -42,12 +43,11 @@ pub fn handle_note(alias: &str, category: &str, config: &mut YamlConfig) {
             if let Some(path) = config.get_property(section::PATH, alias).cloned() {
                 config.set_property(category, alias, &path);
                 info!(
-                    "✅ 将别名 {} 标记为 {} 成功",
-                    alias,
-                    category.to_uppercase()
+                    "{}",
+                    t!(msg::CATEGORY_MARKED, alias, category.to_uppercase())
                 );
             } else {
-                error!("❌ 别名 {} 不在 PATH 中，无法标记", alias);
+                error!("{}", t!(msg::CATEGORY_NOT_PATH, alias));
             }
         }
     }
@@ -59,21 +59,23 @@ pub fn handle_denote(alias: &str, category: &str, config: &mut YamlConfig) {
     // 校验 category 是否合法
     if !VALID_CATEGORIES.contains(&category) {
         usage!(
-            "j denote <alias> <category> (可选: {})",
-            VALID_CATEGORIES.join(", ")
+            "{}",
+            t!(msg::CATEGORY_DENOTE_USAGE, VALID_CATEGORIES.join(", "))
         );
         return;
     }
 
     if !config.contains(category, alias) {
-        error!("❌ 别名 {} 不在 {} 分类中", alias, category.to_uppercase());
+        error!(
+            "{}",
+            t!(msg::CATEGORY_NOT_MARKED, alias, category.to_uppercase())
+        );
         return;
     }
 
     config.remove_property(category, alias);
     info!(
-        "✅ 已将别名 {} 从 {} 中移除",
-        alias,
-        category.to_uppercase()
+        "{}",
+        t!(msg::CATEGORY_REMOVED, alias, category.to_uppercase())
     );
 }
@@ -1,4 +1,6 @@
 use super::model::{ChatMessage, ModelProvider};
+use crate::t;
+use crate::util::i18n::msg;
 use async_openai::{
     Client,
     config::OpenAIConfig,
@@ -101,7 +103,9 @@ pub fn build_request_with_tools(
     if !tools.is_empty() {
         builder.tools(tools);
     }
-    builder.build().map_err(|e| format!("构建请求失败: {}", e))
+    builder
+        .build()
+        .map_err(|e| t!(msg::CHAT_BUILD_REQUEST_FAILED, e))
 }
 
 /// 使用 async-openai 流式调用 API，通过回调逐步输出
@@ -131,13 +135,13 @@ pub async fn call_openai_stream_async(
         .model(&provider.model)
         .messages(openai_messages)
         .build()
-        .map_err(|e| format!("构建请求失败: {}", e))?;
+        .map_err(|e| t!(msg::CHAT_BUILD_REQUEST_FAILED, e))?;
 
     let mut stream = client
         .chat()
         .create_stream(request)
         .await
-        .map_err(|e| format!("API 请求失败: {}", e))?;
+        .map_err(|e| t!(msg::CHAT_API_FAILED, e))?;
 
     let mut full_content = String::new();
 
@@ -152,7 +156,7 @@ pub async fn call_openai_stream_async(
                 }
             }
             Err(e) => {
-                return Err(format!("流式响应错误: {}", e));
+                return Err(t!(msg::CHAT_STREAM_ERROR, e));
             }
         }
     }
@@ -167,7 +171,7 @@ pub fn call_openai_stream(
     system_prompt: Option<&str>,
     on_chunk: &mut dyn FnMut(&str),
 ) -> Result<String, String> {
-    let rt = tokio::runtime::Runtime::new().map_err(|e| format!("创建异步运行时失败: {}", e))?;
+    let rt = tokio::runtime::Runtime::new().map_err(|e| t!(msg::CHAT_RUNTIME_FAILED, e))?;
     rt.block_on(call_openai_stream_async(
         provider,
         messages,
@@ -6,8 +6,10 @@ use super::model::{
 };
 use super::skill::{self, Skill};
 use super::theme::Theme;
-use super::tools::ToolRegistry;
+use super::tools::{Risk, ToolRegistry};
 use crate::constants::{CONFIG_FIELDS, CONFIG_GLOBAL_FIELDS, TOAST_DURATION_SECS};
+use crate::t;
+use crate::util::i18n::{self, msg};
 use crate::util::log::{write_error_log, write_info_log};
 use async_openai::types::chat::ChatCompletionTools;
 use futures::StreamExt;
@@ -49,6 +51,8 @@ pub struct ToolCallStatus {
     pub tool_name: String,
     pub arguments: String,
     pub confirm_message: String,
+    /// 执行前的风险分析（仅需要确认的工具）
+    pub risk: Option<Risk>,
     pub status: ToolExecStatus,
 }
 
@@ -131,6 +135,8 @@ pub struct ChatApp {
     pub active_tool_calls: Vec<ToolCallStatus>,
     /// ToolConfirm 模式中当前待处理工具的索引
     pub pending_tool_idx: usize,
+    /// ToolConfirm 模式中为高风险命令输入的确认词
+    pub confirm_input: String,
     /// 配置界面：是否有待处理的 system_prompt 编辑（需弹出全屏编辑器）
     pub pending_system_prompt_edit: bool,
     /// 已加载的 skills（用于补全和高亮）
@@ -163,6 +169,8 @@ pub struct MsgLinesCache {
     pub browse_index: Option<usize>,
     /// 工具确认模式中待处理工具的索引（None 表示非确认模式）
     pub tool_confirm_idx: Option<usize>,
+    /// 确认词输入的长度（输入变化时重绘确认框）
+    pub confirm_input_len: usize,
     /// 缓存的渲染行
     pub lines: Vec<Line<'static>>,
     /// 每条消息（按 msg_index）的起始行号（用于浏览模式自动滚动）
@@ -212,8 +220,9 @@ pub fn config_total_fields() -> usize {
     CONFIG_FIELDS.len() + CONFIG_GLOBAL_FIELDS.len()
 }
 
-/// 默认系统提示词模板（编译时嵌入）
+/// 默认系统提示词模板（编译时嵌入），首次运行时按当前语言选用
 const DEFAULT_SYSTEM_PROMPT: &str = include_str!("../../../assets/system_prompt_default.md");
+const DEFAULT_SYSTEM_PROMPT_EN: &str = include_str!("../../../assets/system_prompt_default.en.md");
 
 impl ChatApp {
     pub fn new() -> Self {
@@ -226,8 +235,9 @@ impl ChatApp {
                 let _ = save_system_prompt(&config_prompt);
             } else {
                 // 首次运行：写入默认系统提示词
-                let _ = save_system_prompt(DEFAULT_SYSTEM_PROMPT);
-                agent_config.system_prompt = Some(DEFAULT_SYSTEM_PROMPT.to_string());
+                let prompt = i18n::pick(DEFAULT_SYSTEM_PROMPT_EN, DEFAULT_SYSTEM_PROMPT);
+                let _ = save_system_prompt(prompt);
+                agent_config.system_prompt = Some(prompt.to_string());
             }
         }
         // 加载 style
@@ -277,6 +287,7 @@ impl ChatApp {
             tool_registry,
             active_tool_calls: Vec::new(),
             pending_tool_idx: 0,
+            confirm_input: String::new(),
             pending_system_prompt_edit: false,
             loaded_skills,
             at_popup_active: false,
@@ -292,7 +303,11 @@ impl ChatApp {
         let template = self.agent_config.system_prompt.as_ref()?;
         let skills_summary = skill::build_skills_summary(&self.loaded_skills);
         let tools_summary = self.tool_registry.build_tools_summary();
-        let style_text = self.agent_config.style.as_deref().unwrap_or("（未设置）");
+        let style_text = self
+            .agent_config
+            .style
+            .as_deref()
+            .unwrap_or(i18n::text(&msg::CHAT_STYLE_UNSET));
 
         let resolved = template
             .replace("{{.skills}}", &skills_summary)
@@ -338,7 +353,7 @@ impl ChatApp {
     pub fn active_model_name(&self) -> String {
         self.active_provider()
             .map(|p| p.name.clone())
-            .unwrap_or_else(|| "未配置".to_string())
+            .unwrap_or_else(|| i18n::text(&msg::CHAT_NOT_CONFIGURED).to_string())
     }
 
     /// 构建发送给 API 的消息列表
@@ -374,7 +389,7 @@ impl ChatApp {
         let provider = match self.active_provider() {
             Some(p) => p.clone(),
             None => {
-                self.show_toast("未配置模型提供方，请先编辑配置文件", true);
+                self.show_toast(i18n::text(&msg::CHAT_NO_PROVIDER_EDIT), true);
                 return;
             }
         };
@@ -417,7 +432,7 @@ impl ChatApp {
             let rt = match tokio::runtime::Runtime::new() {
                 Ok(rt) => rt,
                 Err(e) => {
-                    let _ = stream_tx.send(StreamMsg::Error(format!("创建异步运行时失败: {}", e)));
+                    let _ = stream_tx.send(StreamMsg::Error(t!(msg::CHAT_RUNTIME_FAILED, e)));
                     return;
                 }
             };
@@ -463,23 +478,32 @@ impl ChatApp {
                         // 初始化工具调用状态
                         self.active_tool_calls.clear();
                         self.pending_tool_idx = 0;
+                        self.confirm_input.clear();
 
                         for tc in tool_calls {
                             let confirm_msg = if let Some(tool) = self.tool_registry.get(&tc.name) {
                                 tool.confirmation_message(&tc.arguments)
                             } else {
-                                format!("调用工具 {} 参数: {}", tc.name, tc.arguments)
+                                t!(msg::CHAT_TOOL_CALL, tc.name, tc.arguments)
                             };
                             let needs_confirm = self
                                 .tool_registry
                                 .get(&tc.name)
                                 .map(|t| t.requires_confirmation())
                                 .unwrap_or(false);
+                            let risk = if needs_confirm {
+                                self.tool_registry
+                                    .get(&tc.name)
+                                    .and_then(|t| t.risk(&tc.arguments))
+                            } else {
+                                None
+                            };
                             self.active_tool_calls.push(ToolCallStatus {
                                 tool_call_id: tc.id.clone(),
                                 tool_name: tc.name.clone(),
                                 arguments: tc.arguments.clone(),
                                 confirm_message: confirm_msg,
+                                risk,
                                 status: if needs_confirm {
                                     ToolExecStatus::PendingConfirm
                                 } else {
@@ -510,7 +534,7 @@ impl ChatApp {
                         break;
                     }
                     Ok(StreamMsg::Error(e)) => {
-                        self.show_toast(format!("请求失败: {}", e), true);
+                        self.show_toast(t!(msg::CHAT_REQUEST_FAILED, e), true);
                         had_error = true;
                         finished = true;
                         break;
@@ -537,7 +561,7 @@ impl ChatApp {
                     tool.execute(&tc_status.arguments)
                 } else {
                     super::tools::ToolResult {
-                        output: format!("未知工具: {}", tc_status.tool_name),
+                        output: t!(msg::CHAT_UNKNOWN_TOOL, tc_status.tool_name),
                         is_error: true,
                     }
                 };
@@ -593,7 +617,7 @@ impl ChatApp {
             tool.execute(&arguments)
         } else {
             super::tools::ToolResult {
-                output: format!("未知工具: {}", tool_name),
+                output: t!(msg::CHAT_UNKNOWN_TOOL, tool_name),
                 is_error: true,
             }
         };
@@ -641,10 +665,19 @@ impl ChatApp {
         let tool_call_id = self.active_tool_calls[idx].tool_call_id.clone();
         self.active_tool_calls[idx].status = ToolExecStatus::Rejected;
 
+        // 被拒绝的 shell 命令同样记入审计日志
+        if self.active_tool_calls[idx].tool_name == "run_shell" {
+            if let Some(cmd) =
+                super::tools::shell_command_of(&self.active_tool_calls[idx].arguments)
+            {
+                super::audit::record(&cmd, "rejected", None);
+            }
+        }
+
         if let Some(ref tx) = self.tool_result_tx {
             let _ = tx.send(ToolResultMsg {
                 tool_call_id,
-                result: "用户拒绝执行该工具".to_string(),
+                result: i18n::text(&msg::CHAT_TOOL_REJECTED).to_string(),
                 is_error: true,
             });
         }
@@ -652,8 +685,17 @@ impl ChatApp {
         self.advance_tool_confirm();
     }
 
+    /// 当前待确认的工具是否有高风险（需要输入确认词才执行）
+    pub fn pending_high_risk(&self) -> bool {
+        self.active_tool_calls
+            .get(self.pending_tool_idx)
+            .and_then(|tc| tc.risk.as_ref())
+            .is_some_and(|r| r.high)
+    }
+
     /// 推进到下一个待确认工具，或退出确认模式
     fn advance_tool_confirm(&mut self) {
+        self.confirm_input.clear();
         // 查找下一个 PendingConfirm 状态的工具
         let next = self
             .active_tool_calls
@@ -692,7 +734,7 @@ impl ChatApp {
                     .messages
                     .push(ChatMessage::text("assistant", content));
                 self.streaming_content.lock().unwrap().clear();
-                self.show_toast("回复完成 ✓", false);
+                self.show_toast(i18n::text(&msg::CHAT_REPLY_DONE), false);
             }
             if self.auto_scroll {
                 self.scroll_offset = u16::MAX;
@@ -710,7 +752,7 @@ impl ChatApp {
         self.scroll_offset = 0;
         self.msg_lines_cache = None; // 清除缓存
         let _ = save_chat_session(&self.session);
-        self.show_toast("对话已清空", false);
+        self.show_toast(i18n::text(&msg::CHAT_CLEARED), false);
     }
 
     /// 切换模型
@@ -719,7 +761,7 @@ impl ChatApp {
             self.agent_config.active_index = sel;
             let _ = save_agent_config(&self.agent_config);
             let name = self.active_model_name();
-            self.show_toast(format!("已切换到: {}", name), false);
+            self.show_toast(t!(msg::CHAT_SWITCHED_MODEL, name), false);
         }
         self.mode = ChatMode::Chat;
     }
@@ -767,7 +809,7 @@ impl ChatApp {
             Ok(_) => {
                 // 归档成功后清空当前会话
                 self.clear_session();
-                self.show_toast(format!("对话已归档: {}", name), false);
+                self.show_toast(t!(msg::CHAT_ARCHIVED, name), false);
             }
             Err(e) => {
                 self.show_toast(e, true);
@@ -790,7 +832,7 @@ impl ChatApp {
                     self.input.clear();
                     self.cursor_pos = 0;
                     let _ = save_chat_session(&self.session);
-                    self.show_toast(format!("已还原归档: {}", archive.name), false);
+                    self.show_toast(t!(msg::CHAT_RESTORED, archive.name), false);
                 }
                 Err(e) => {
                     self.show_toast(e, true);
@@ -807,7 +849,7 @@ impl ChatApp {
         if let Some(archive) = self.archives.get(self.archive_list_index) {
             match delete_archive(&archive.name) {
                 Ok(_) => {
-                    self.show_toast(format!("归档已删除: {}", archive.name), false);
+                    self.show_toast(t!(msg::CHAT_ARCHIVE_DELETED, archive.name), false);
                     // 刷新归档列表
                     self.archives = super::archive::list_archives();
                     if self.archive_list_index >= self.archives.len() && self.archive_list_index > 0
@@ -879,7 +921,7 @@ async fn run_agent_loop(
                     }
                 }
             }
-            write_info_log("Chat 请求", &log_content);
+            write_info_log(i18n::text(&msg::CHAT_LOG_REQUEST), &log_content);
         }
 
         let request = match build_request_with_tools(
@@ -890,7 +932,7 @@ async fn run_agent_loop(
         ) {
             Ok(req) => req,
             Err(e) => {
-                let _ = tx.send(StreamMsg::Error(format!("构建请求失败: {}", e)));
+                let _ = tx.send(StreamMsg::Error(t!(msg::CHAT_BUILD_REQUEST_FAILED, e)));
                 return;
             }
         };
@@ -900,8 +942,8 @@ async fn run_agent_loop(
             let mut stream = match client.chat().create_stream(request.clone()).await {
                 Ok(s) => s,
                 Err(e) => {
-                    let error_msg = format!("API 请求失败: {}", e);
-                    write_error_log("Chat API 流式请求创建", &error_msg);
+                    let error_msg = t!(msg::CHAT_API_FAILED, e);
+                    write_error_log(i18n::text(&msg::CHAT_LOG_STREAM_CREATE), &error_msg);
                     let _ = tx.send(StreamMsg::Error(error_msg));
                     return;
                 }
@@ -966,7 +1008,7 @@ async fn run_agent_loop(
                             stream_had_deserialize_error = true;
                             break 'stream;
                         }
-                        write_error_log("Chat API 流式响应", &error_str);
+                        write_error_log(i18n::text(&msg::CHAT_LOG_STREAM_RESPONSE), &error_str);
                         let _ = tx.send(StreamMsg::Error(error_str));
                         return;
                     }
@@ -975,7 +1017,7 @@ async fn run_agent_loop(
 
             // 记录流式回复日志
             if !assistant_text.is_empty() {
-                write_info_log("Chat 回复", &assistant_text);
+                write_info_log(i18n::text(&msg::CHAT_LOG_REPLY), &assistant_text);
             }
 
             // 如果流式遇到 tool_calls 反序列化错误，fallback 到非流式获取完整响应
@@ -1023,14 +1065,20 @@ async fn run_agent_loop(
                                                 item.name, item.arguments
                                             ));
                                         }
-                                        write_info_log("工具调用请求", &log_content);
+                                        write_info_log(
+                                            i18n::text(&msg::CHAT_LOG_TOOL_CALLS),
+                                            &log_content,
+                                        );
                                     }
 
                                     let assistant_text =
                                         choice.message.content.clone().unwrap_or_default();
                                     // 记录回复日志 (fallback)
                                     if !assistant_text.is_empty() {
-                                        write_info_log("Chat 回复", &assistant_text);
+                                        write_info_log(
+                                            i18n::text(&msg::CHAT_LOG_REPLY),
+                                            &assistant_text,
+                                        );
                                     }
 
                                     messages.push(ChatMessage {
@@ -1055,7 +1103,7 @@ async fn run_agent_loop(
                                             Ok(result) => tool_results.push(result),
                                             Err(_) => {
                                                 let _ = tx.send(StreamMsg::Error(
-                                                    "等待工具执行超时".to_string(),
+                                                    i18n::text(&msg::CHAT_TOOL_TIMEOUT).to_string(),
                                                 ));
                                                 return;
                                             }
@@ -1075,7 +1123,10 @@ async fn run_agent_loop(
                                                 result.tool_call_id, tool_name, result.result
                                             ));
                                         }
-                                        write_info_log("工具调用结果", &log_content);
+                                        write_info_log(
+                                            i18n::text(&msg::CHAT_LOG_TOOL_RESULTS),
+                                            &log_content,
+                                        );
                                     }
 
                                     for result in tool_results {
@@ -1091,7 +1142,7 @@ async fn run_agent_loop(
                             }
                             // 普通文本回复
                             if let Some(ref content) = choice.message.content {
-                                write_info_log("Chat 回复", content);
+                                write_info_log(i18n::text(&msg::CHAT_LOG_REPLY), content);
                                 let mut sc = streaming_content.lock().unwrap();
                                 sc.push_str(content);
                                 drop(sc);
@@ -1100,8 +1151,8 @@ async fn run_agent_loop(
                         }
                     }
                     Err(e) => {
-                        let error_msg = format!("API 请求失败(fallback): {}", e);
-                        write_error_log("Chat API fallback 非流式", &error_msg);
+                        let error_msg = t!(msg::CHAT_API_FALLBACK_FAILED, e);
+                        write_error_log(i18n::text(&msg::CHAT_LOG_FALLBACK), &error_msg);
                         let _ = tx.send(StreamMsg::Error(error_msg));
                         return;
                     }
@@ -1135,7 +1186,7 @@ async fn run_agent_loop(
                     for item in &tool_items {
                         log_content.push_str(&format!("- {}: {}\n", item.name, item.arguments));
                     }
-                    write_info_log("工具调用请求", &log_content);
+                    write_info_log(i18n::text(&msg::CHAT_LOG_TOOL_CALLS), &log_content);
                 }
 
                 messages.push(ChatMessage {
@@ -1157,7 +1208,9 @@ async fn run_agent_loop(
                     match tool_result_rx.recv_timeout(std::time::Duration::from_secs(60)) {
                         Ok(result) => tool_results.push(result),
                         Err(_) => {
-                            let _ = tx.send(StreamMsg::Error("等待工具执行超时".to_string()));
+                            let _ = tx.send(StreamMsg::Error(
+                                i18n::text(&msg::CHAT_TOOL_TIMEOUT).to_string(),
+                            ));
                             return;
                         }
                     }
@@ -1171,12 +1224,15 @@ async fn run_agent_loop(
                             .get(i)
                             .map(|t| (t.name.as_str(), t.arguments.as_str()))
                             .unwrap_or(("unknown", ""));
-                        log_content.push_str(&format!(
-                            "- [{}] {}({})\n结果: {}\n",
-                            result.tool_call_id, tool_name, tool_args, result.result
+                        log_content.push_str(&t!(
+                            msg::CHAT_LOG_TOOL_RESULT,
+                            result.tool_call_id,
+                            tool_name,
+                            tool_args,
+                            result.result
                         ));
                     }
-                    write_info_log("工具调用结果", &log_content);
+                    write_info_log(i18n::text(&msg::CHAT_LOG_TOOL_RESULTS), &log_content);
                 }
 
                 for result in tool_results {
@@ -1233,14 +1289,20 @@ async fn run_agent_loop(
                                             item.name, item.arguments
                                         ));
                                     }
-                                    write_info_log("工具调用请求", &log_content);
+                                    write_info_log(
+                                        i18n::text(&msg::CHAT_LOG_TOOL_CALLS),
+                                        &log_content,
+                                    );
                                 }
 
                                 let assistant_text =
                                     choice.message.content.clone().unwrap_or_default();
                                 // 记录回复日志 (非流式)
                                 if !assistant_text.is_empty() {
-                                    write_info_log("Chat 回复", &assistant_text);
+                                    write_info_log(
+                                        i18n::text(&msg::CHAT_LOG_REPLY),
+                                        &assistant_text,
+                                    );
                                 }
 
                                 messages.push(ChatMessage {
@@ -1265,7 +1327,7 @@ async fn run_agent_loop(
                                         Ok(result) => tool_results.push(result),
                                         Err(_) => {
                                             let _ = tx.send(StreamMsg::Error(
-                                                "等待工具执行超时".to_string(),
+                                                i18n::text(&msg::CHAT_TOOL_TIMEOUT).to_string(),
                                             ));
                                             return;
                                         }
@@ -1285,7 +1347,10 @@ async fn run_agent_loop(
                                             result.tool_call_id, tool_name, result.result
                                         ));
                                     }
-                                    write_info_log("工具调用结果", &log_content);
+                                    write_info_log(
+                                        i18n::text(&msg::CHAT_LOG_TOOL_RESULTS),
+                                        &log_content,
+                                    );
                                 }
 
                                 for result in tool_results {
@@ -1303,7 +1368,7 @@ async fn run_agent_loop(
 
                         // 正常文本回复
                         if let Some(ref content) = choice.message.content {
-                            write_info_log("Chat 回复", content);
+                            write_info_log(i18n::text(&msg::CHAT_LOG_REPLY), content);
                             let mut sc = streaming_content.lock().unwrap();
                             sc.push_str(content);
                             drop(sc);
@@ -1312,8 +1377,8 @@ async fn run_agent_loop(
                     }
                 }
                 Err(e) => {
-                    let error_msg = format!("API 请求失败: {}", e);
-                    write_error_log("Chat API 非流式请求", &error_msg);
+                    let error_msg = t!(msg::CHAT_API_FAILED, e);
+                    write_error_log(i18n::text(&msg::CHAT_LOG_NON_STREAM), &error_msg);
                     let _ = tx.send(StreamMsg::Error(error_msg));
                     return;
                 }
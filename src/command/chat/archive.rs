@@ -1,5 +1,6 @@
 use super::model::ChatMessage;
-use crate::error;
+use crate::util::i18n::{msg, text};
+use crate::{error, t};
 use chrono::{DateTime, Utc};
 use serde::{Deserialize, Serialize};
 use std::fs;
@@ -53,7 +54,10 @@ pub fn list_archives() -> Vec<ChatArchive> {
                     match serde_json::from_str::<ChatArchive>(&content) {
                         Ok(archive) => archives.push(archive),
                         Err(e) => {
-                            error!("[list_archives] 解析归档文件失败: {:?}, 错误: {}", path, e);
+                            error!(
+                                "{}",
+                                t!(msg::CHAT_ARCHIVE_PARSE_FAILED_AT, path.display(), e)
+                            );
                         }
                     }
                 }
@@ -73,7 +77,7 @@ pub fn create_archive(name: &str, messages: Vec<ChatMessage>) -> Result<ChatArch
 
     // 确保归档目录存在
     if let Err(e) = ensure_archives_dir() {
-        return Err(format!("创建归档目录失败: {}", e));
+        return Err(t!(msg::CHAT_ARCHIVE_MKDIR_FAILED, e));
     }
 
     let now: DateTime<Utc> = Utc::now();
@@ -84,10 +88,10 @@ pub fn create_archive(name: &str, messages: Vec<ChatMessage>) -> Result<ChatArch
     };
 
     let path = get_archive_path(name);
-    let json =
-        serde_json::to_string_pretty(&archive).map_err(|e| format!("序列化归档失败: {}", e))?;
+    let json = serde_json::to_string_pretty(&archive)
+        .map_err(|e| t!(msg::CHAT_ARCHIVE_SERIALIZE_FAILED, e))?;
 
-    fs::write(&path, json).map_err(|e| format!("写入归档文件失败: {}", e))?;
+    fs::write(&path, json).map_err(|e| t!(msg::CHAT_ARCHIVE_WRITE_FAILED, e))?;
 
     Ok(archive)
 }
@@ -97,13 +101,13 @@ pub fn restore_archive(name: &str) -> Result<Vec<ChatMessage>, String> {
     let path = get_archive_path(name);
 
     if !path.exists() {
-        return Err(format!("归档文件不存在: {}", name));
+        return Err(t!(msg::CHAT_ARCHIVE_MISSING, name));
     }
 
-    let content = fs::read_to_string(&path).map_err(|e| format!("读取归档文件失败: {}", e))?;
+    let content = fs::read_to_string(&path).map_err(|e| t!(msg::CHAT_ARCHIVE_READ_FAILED, e))?;
 
     let archive: ChatArchive =
-        serde_json::from_str(&content).map_err(|e| format!("解析归档文件失败: {}", e))?;
+        serde_json::from_str(&content).map_err(|e| t!(msg::CHAT_ARCHIVE_PARSE_FAILED, e))?;
 
     Ok(archive.messages)
 }
@@ -113,10 +117,10 @@ pub fn delete_archive(name: &str) -> Result<(), String> {
     let path = get_archive_path(name);
 
     if !path.exists() {
-        return Err(format!("归档文件不存在: {}", name));
+        return Err(t!(msg::CHAT_ARCHIVE_MISSING, name));
     }
 
-    fs::remove_file(&path).map_err(|e| format!("删除归档文件失败: {}", e))?;
+    fs::remove_file(&path).map_err(|e| t!(msg::CHAT_ARCHIVE_DELETE_FAILED, e))?;
 
     Ok(())
 }
@@ -125,17 +129,17 @@ pub fn delete_archive(name: &str) -> Result<(), String> {
 pub fn validate_archive_name(name: &str) -> Result<(), String> {
     // 检查名称长度
     if name.is_empty() {
-        return Err("归档名称不能为空".to_string());
+        return Err(text(&msg::CHAT_ARCHIVE_NAME_EMPTY).to_string());
     }
     if name.len() > 50 {
-        return Err("归档名称过长，最多 50 字符".to_string());
+        return Err(text(&msg::CHAT_ARCHIVE_NAME_TOO_LONG).to_string());
     }
 
     // 检查非法字符
     let invalid_chars = ['/', '\\', ':', '*', '?', '"', '<', '>', '|'];
     for c in invalid_chars {
         if name.contains(c) {
-            return Err(format!("归档名称包含非法字符: {}", c));
+            return Err(t!(msg::CHAT_ARCHIVE_NAME_INVALID, c));
         }
     }
 
@@ -0,0 +1,48 @@
+use crate::util::file_lock;
+use serde::Serialize;
+use std::path::PathBuf;
+
+// ========== 数据结构 ==========
+
+/// 审计日志条目（JSON Lines，每行一条，只追加不修改）
+///
+/// 格式与 agent 插件（`plugin/agent/code/internal/audit`）共享，
+/// 可通过 `agent audit show` 查看
+#[derive(Debug, Serialize)]
+pub struct AuditEntry<'a> {
+    /// 记录时间（RFC 3339）
+    pub time: String,
+    /// 来源（如 "chat/run_shell"）
+    pub source: &'a str,
+    /// 执行的 shell 命令
+    pub command: &'a str,
+    /// 确认结果: "confirmed" | "rejected" | "blocked"
+    pub decision: &'a str,
+    /// 进程退出码（未执行或被信号终止时为 None）
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub exit_code: Option<i32>,
+}
+
+// ========== 文件路径 ==========
+
+/// 获取审计日志路径: ~/.jdata/agent/data/audit.jsonl
+pub fn audit_log_path() -> PathBuf {
+    super::model::agent_data_dir().join("audit.jsonl")
+}
+
+// ========== 写入 ==========
+
+/// 追加一条审计记录，写入失败不影响命令本身
+pub fn record(command: &str, decision: &str, exit_code: Option<i32>) {
+    let entry = AuditEntry {
+        time: chrono::Local::now().to_rfc3339(),
+        source: "chat/run_shell",
+        command,
+        decision,
+        exit_code,
+    };
+    let Ok(line) = serde_json::to_string(&entry) else {
+        return;
+    };
+    let _ = file_lock::append_line(&audit_log_path(), &line);
+}
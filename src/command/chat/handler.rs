@@ -3,10 +3,12 @@ use super::model::{
 };
 use super::render::copy_to_clipboard;
 use super::theme::ThemeName;
+use super::tools::GUARD_CONFIRM_WORD;
 use super::ui::draw_chat_ui;
 use crate::command::chat::app::{ChatApp, ChatMode, config_total_fields};
 use crate::constants::{CONFIG_FIELDS, CONFIG_GLOBAL_FIELDS};
-use crate::{error, info};
+use crate::util::i18n::{msg, text};
+use crate::{error, info, t};
 use crossterm::{
     event::{self, Event, KeyCode, KeyEvent, KeyModifiers},
     execute,
@@ -19,7 +21,7 @@ pub fn run_chat_tui() {
     match run_chat_tui_internal() {
         Ok(_) => {}
         Err(e) => {
-            error!("❌ Chat TUI 启动失败: {}", e);
+            error!("{}", t!(msg::CHAT_TUI_FAILED, e));
         }
     }
 }
@@ -37,7 +39,7 @@ pub fn run_chat_tui_internal() -> io::Result<()> {
     if app.agent_config.providers.is_empty() {
         terminal::disable_raw_mode()?;
         execute!(terminal.backend_mut(), LeaveAlternateScreen)?;
-        info!("⚠️  尚未配置 LLM 模型提供方，请先运行 j chat 查看配置说明。");
+        info!("{}", t!(msg::CHAT_NO_PROVIDER));
         return Ok(());
     }
 
@@ -135,7 +137,7 @@ pub fn run_chat_tui_internal() -> io::Result<()> {
                 let current_prompt = app.agent_config.system_prompt.clone().unwrap_or_default();
                 match crate::tui::editor::open_editor_on_terminal(
                     &mut terminal,
-                    "编辑系统提示词 (System Prompt)",
+                    text(&msg::CHAT_EDIT_SYSTEM_PROMPT),
                     &current_prompt,
                 ) {
                     Ok(Some(new_text)) => {
@@ -146,16 +148,16 @@ pub fn run_chat_tui_internal() -> io::Result<()> {
                         }
                         let prompt_text = app.agent_config.system_prompt.as_deref().unwrap_or("");
                         if save_system_prompt(prompt_text) {
-                            app.show_toast("系统提示词已更新", false);
+                            app.show_toast(text(&msg::CHAT_SYSTEM_PROMPT_UPDATED), false);
                         } else {
-                            app.show_toast("系统提示词保存失败", true);
+                            app.show_toast(text(&msg::CHAT_SYSTEM_PROMPT_SAVE_FAILED), true);
                         }
                     }
                     Ok(None) => {
                         // 用户取消编辑
                     }
                     Err(e) => {
-                        app.show_toast(format!("编辑器错误: {}", e), true);
+                        app.show_toast(t!(msg::CHAT_EDITOR_ERROR, e), true);
                     }
                 }
                 needs_redraw = true;
@@ -167,7 +169,7 @@ pub fn run_chat_tui_internal() -> io::Result<()> {
                 let current_style = app.agent_config.style.clone().unwrap_or_default();
                 match crate::tui::editor::open_editor_on_terminal(
                     &mut terminal,
-                    "编辑回复风格 (Style)",
+                    text(&msg::CHAT_EDIT_STYLE),
                     &current_style,
                 ) {
                     Ok(Some(new_text)) => {
@@ -178,16 +180,16 @@ pub fn run_chat_tui_internal() -> io::Result<()> {
                         }
                         let style_text = app.agent_config.style.as_deref().unwrap_or("");
                         if save_style(style_text) {
-                            app.show_toast("回复风格已更新", false);
+                            app.show_toast(text(&msg::CHAT_STYLE_UPDATED), false);
                         } else {
-                            app.show_toast("回复风格保存失败", true);
+                            app.show_toast(text(&msg::CHAT_STYLE_SAVE_FAILED), true);
                         }
                     }
                     Ok(None) => {
                         // 用户取消编辑
                     }
                     Err(e) => {
-                        app.show_toast(format!("编辑器错误: {}", e), true);
+                        app.show_toast(t!(msg::CHAT_EDITOR_ERROR, e), true);
                     }
                 }
                 needs_redraw = true;
@@ -291,7 +293,7 @@ pub fn handle_chat_mode(app: &mut ChatApp, key: KeyEvent) -> bool {
     // Ctrl+L 归档对话
     if key.modifiers.contains(KeyModifiers::CONTROL) && key.code == KeyCode::Char('l') {
         if app.session.messages.is_empty() {
-            app.show_toast("当前对话为空，无法归档", true);
+            app.show_toast(text(&msg::CHAT_ARCHIVE_EMPTY), true);
         } else {
             app.start_archive_confirm();
         }
@@ -314,12 +316,12 @@ pub fn handle_chat_mode(app: &mut ChatApp, key: KeyEvent) -> bool {
             .find(|m| m.role == "assistant")
         {
             if copy_to_clipboard(&last_ai.content) {
-                app.show_toast("已复制最后一条 AI 回复", false);
+                app.show_toast(text(&msg::CHAT_COPIED_LAST), false);
             } else {
-                app.show_toast("复制到剪切板失败", true);
+                app.show_toast(text(&msg::CHAT_COPY_FAILED), true);
             }
         } else {
-            app.show_toast("暂无 AI 回复可复制", true);
+            app.show_toast(text(&msg::CHAT_NOTHING_TO_COPY), true);
         }
         return false;
     }
@@ -333,7 +335,7 @@ pub fn handle_chat_mode(app: &mut ChatApp, key: KeyEvent) -> bool {
             app.mode = ChatMode::Browse;
             app.msg_lines_cache = None; // 清除缓存以触发高亮重绘
         } else {
-            app.show_toast("暂无消息可浏览", true);
+            app.show_toast(text(&msg::CHAT_NOTHING_TO_BROWSE), true);
         }
         return false;
     }
@@ -357,11 +359,11 @@ pub fn handle_chat_mode(app: &mut ChatApp, key: KeyEvent) -> bool {
         app.agent_config.stream_mode = !app.agent_config.stream_mode;
         let _ = save_agent_config(&app.agent_config);
         let mode_str = if app.agent_config.stream_mode {
-            "流式输出"
+            text(&msg::CHAT_STREAM_MODE)
         } else {
-            "整体输出"
+            text(&msg::CHAT_WHOLE_MODE)
         };
-        app.show_toast(&format!("已切换为: {}", mode_str), false);
+        app.show_toast(&t!(msg::CHAT_SWITCHED_TO, mode_str), false);
         return false;
     }
 
@@ -525,17 +527,21 @@ pub fn handle_browse_mode(app: &mut ChatApp, key: KeyEvent) {
                 let role_label = if msg.role == "assistant" {
                     "AI"
                 } else if msg.role == "user" {
-                    "用户"
+                    text(&msg::CHAT_ROLE_USER)
                 } else {
-                    "系统"
+                    text(&msg::CHAT_ROLE_SYSTEM)
                 };
                 if copy_to_clipboard(&content) {
                     app.show_toast(
-                        &format!("已复制第 {} 条{}消息", app.browse_msg_index + 1, role_label),
+                        &t!(
+                            msg::CHAT_COPIED_MESSAGE,
+                            app.browse_msg_index + 1,
+                            role_label
+                        ),
                         false,
                     );
                 } else {
-                    app.show_toast("复制到剪切板失败", true);
+                    app.show_toast(text(&msg::CHAT_COPY_FAILED), true);
                 }
             }
         }
@@ -548,22 +554,22 @@ pub fn config_field_label(idx: usize) -> &'static str {
     let total_provider = CONFIG_FIELDS.len();
     if idx < total_provider {
         match CONFIG_FIELDS[idx] {
-            "name" => "显示名称",
+            "name" => text(&msg::CHAT_FIELD_NAME),
             "api_base" => "API Base",
             "api_key" => "API Key",
-            "model" => "模型名称",
+            "model" => text(&msg::CHAT_FIELD_MODEL),
             _ => CONFIG_FIELDS[idx],
         }
     } else {
         let gi = idx - total_provider;
         match CONFIG_GLOBAL_FIELDS[gi] {
-            "system_prompt" => "系统提示词",
-            "style" => "回复风格",
-            "stream_mode" => "流式输出",
-            "max_history_messages" => "历史消息数",
-            "theme" => "主题风格",
-            "tools_enabled" => "工具调用",
-            "max_tool_rounds" => "工具轮数上限",
+            "system_prompt" => text(&msg::CHAT_FIELD_SYSTEM_PROMPT),
+            "style" => text(&msg::CHAT_FIELD_STYLE),
+            "stream_mode" => text(&msg::CHAT_STREAM_MODE),
+            "max_history_messages" => text(&msg::CHAT_FIELD_MAX_HISTORY),
+            "theme" => text(&msg::CHAT_FIELD_THEME),
+            "tools_enabled" => text(&msg::CHAT_FIELD_TOOLS),
+            "max_tool_rounds" => text(&msg::CHAT_FIELD_MAX_TOOL_ROUNDS),
             _ => CONFIG_GLOBAL_FIELDS[gi],
         }
     }
@@ -602,18 +608,18 @@ pub fn config_field_value(app: &ChatApp, field_idx: usize) -> String {
             "style" => app.agent_config.style.clone().unwrap_or_default(),
             "stream_mode" => {
                 if app.agent_config.stream_mode {
-                    "开启".into()
+                    text(&msg::CHAT_ON).into()
                 } else {
-                    "关闭".into()
+                    text(&msg::CHAT_OFF).into()
                 }
             }
             "max_history_messages" => app.agent_config.max_history_messages.to_string(),
             "theme" => app.agent_config.theme.display_name().to_string(),
             "tools_enabled" => {
                 if app.agent_config.tools_enabled {
-                    "开启".into()
+                    text(&msg::CHAT_ON).into()
                 } else {
-                    "关闭".into()
+                    text(&msg::CHAT_OFF).into()
                 }
             }
             "max_tool_rounds" => app.agent_config.max_tool_rounds.to_string(),
@@ -799,13 +805,13 @@ pub fn handle_config_mode(app: &mut ChatApp, key: KeyEvent) {
             let style_saved = save_style(app.agent_config.style.as_deref().unwrap_or(""));
             let config_saved = save_agent_config(&app.agent_config);
             if prompt_saved && style_saved && config_saved {
-                app.show_toast("配置已保存 ✅", false);
+                app.show_toast(text(&msg::CHAT_CONFIG_SAVED), false);
             } else if !prompt_saved {
-                app.show_toast("系统提示词保存失败", true);
+                app.show_toast(text(&msg::CHAT_SYSTEM_PROMPT_SAVE_FAILED), true);
             } else if !style_saved {
-                app.show_toast("回复风格保存失败", true);
+                app.show_toast(text(&msg::CHAT_STYLE_SAVE_FAILED), true);
             } else {
-                app.show_toast("配置保存失败", true);
+                app.show_toast(text(&msg::CHAT_CONFIG_SAVE_FAILED), true);
             }
             app.mode = ChatMode::Chat;
         }
@@ -846,7 +852,7 @@ pub fn handle_config_mode(app: &mut ChatApp, key: KeyEvent) {
             // 进入编辑模式
             let total_provider = CONFIG_FIELDS.len();
             if app.config_field_idx < total_provider && app.agent_config.providers.is_empty() {
-                app.show_toast("还没有 Provider，按 a 新增", true);
+                app.show_toast(text(&msg::CHAT_NO_PROVIDER_ADD), true);
                 return;
             }
             // stream_mode 字段直接切换，不进入编辑模式
@@ -888,17 +894,18 @@ pub fn handle_config_mode(app: &mut ChatApp, key: KeyEvent) {
                 api_base: "https://api.openai.com/v1".to_string(),
                 api_key: String::new(),
                 model: String::new(),
+                extra: Default::default(),
             };
             app.agent_config.providers.push(new_provider);
             app.config_provider_idx = app.agent_config.providers.len() - 1;
             app.config_field_idx = 0; // 跳到 name 字段
-            app.show_toast("已新增 Provider，请填写配置", false);
+            app.show_toast(text(&msg::CHAT_PROVIDER_ADDED), false);
         }
         KeyCode::Char('d') => {
             // 删除当前 Provider
             let count = app.agent_config.providers.len();
             if count == 0 {
-                app.show_toast("没有可删除的 Provider", true);
+                app.show_toast(text(&msg::CHAT_NO_PROVIDER_DELETE), true);
             } else {
                 let removed_name = app.agent_config.providers[app.config_provider_idx]
                     .name
@@ -916,7 +923,7 @@ pub fn handle_config_mode(app: &mut ChatApp, key: KeyEvent) {
                 {
                     app.agent_config.active_index -= 1;
                 }
-                app.show_toast(format!("已删除 Provider: {}", removed_name), false);
+                app.show_toast(t!(msg::CHAT_PROVIDER_DELETED, removed_name), false);
             }
         }
         KeyCode::Char('s') => {
@@ -926,7 +933,7 @@ pub fn handle_config_mode(app: &mut ChatApp, key: KeyEvent) {
                 let name = app.agent_config.providers[app.config_provider_idx]
                     .name
                     .clone();
-                app.show_toast(format!("已设为活跃模型: {}", name), false);
+                app.show_toast(t!(msg::CHAT_PROVIDER_ACTIVATED, name), false);
             }
         }
         _ => {}
@@ -1116,8 +1123,27 @@ pub fn handle_archive_list_mode(app: &mut ChatApp, key: KeyEvent) {
     }
 }
 
-/// 工具确认模式按键处理：Y/Enter 执行，N/Esc 拒绝
+/// 工具确认模式按键处理：Y/Enter 执行，N/Esc 拒绝；
+/// 高风险命令需输入确认词后按 Enter 才执行，其余输入按 Enter 视为拒绝
 pub fn handle_tool_confirm_mode(app: &mut ChatApp, key: KeyEvent) {
+    if app.pending_high_risk() {
+        match key.code {
+            KeyCode::Enter => {
+                if app.confirm_input.trim() == GUARD_CONFIRM_WORD {
+                    app.execute_pending_tool();
+                } else {
+                    app.reject_pending_tool();
+                }
+            }
+            KeyCode::Esc => app.reject_pending_tool(),
+            KeyCode::Backspace => {
+                app.confirm_input.pop();
+            }
+            KeyCode::Char(c) => app.confirm_input.push(c),
+            _ => {}
+        }
+        return;
+    }
     match key.code {
         KeyCode::Char('y') | KeyCode::Char('Y') | KeyCode::Enter => {
             app.execute_pending_tool();
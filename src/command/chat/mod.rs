@@ -1,6 +1,7 @@
 pub mod api;
 pub mod app;
 pub mod archive;
+pub mod audit;
 pub mod handler;
 pub mod markdown;
 pub mod model;
@@ -12,7 +13,8 @@ pub mod ui;
 
 use crate::command::chat::theme::ThemeName;
 use crate::config::YamlConfig;
-use crate::{error, info};
+use crate::util::i18n::{msg, text};
+use crate::{error, info, t};
 use api::call_openai_stream;
 use handler::run_chat_tui;
 use model::{
@@ -30,15 +32,19 @@ pub fn handle_chat(content: &[String], _config: &YamlConfig) {
     }
 
     if agent_config.providers.is_empty() {
-        info!("⚠️  尚未配置 LLM 模型提供方。");
-        info!("📁 请编辑配置文件: {}", agent_config_path().display());
-        info!("📝 配置示例:");
+        info!("{}", t!(msg::CHAT_NO_PROVIDER_CLI));
+        info!(
+            "{}",
+            t!(msg::CHAT_EDIT_CONFIG_FILE, agent_config_path().display())
+        );
+        info!("{}", t!(msg::CHAT_CONFIG_EXAMPLE));
         let example = AgentConfig {
             providers: vec![ModelProvider {
                 name: "GPT-4o".to_string(),
                 api_base: "https://api.openai.com/v1".to_string(),
                 api_key: "sk-your-api-key".to_string(),
                 model: "gpt-4o".to_string(),
+                extra: Default::default(),
             }],
             active_index: 0,
             system_prompt: None,
@@ -48,19 +54,20 @@ pub fn handle_chat(content: &[String], _config: &YamlConfig) {
             tools_enabled: false,
             max_tool_rounds: 10,
             style: None,
+            extra: Default::default(),
         };
         if let Ok(json) = serde_json::to_string_pretty(&example) {
             println!("{}", json);
         }
-        let _ = save_system_prompt("你是一个有用的助手。");
+        let _ = save_system_prompt(text(&msg::CHAT_DEFAULT_SYSTEM_PROMPT));
         // 自动创建示例配置文件
         if !agent_config_path().exists() {
             let _ = save_agent_config(&example);
             info!(
-                "✅ 已自动创建示例配置文件: {}",
-                agent_config_path().display()
+                "{}",
+                t!(msg::CHAT_EXAMPLE_CREATED, agent_config_path().display())
             );
-            info!("📌 请修改其中的 api_key 和其他配置后重新运行 chat 命令");
+            info!("{}", t!(msg::CHAT_EXAMPLE_EDIT_HINT));
         }
         return;
     }
@@ -75,7 +82,7 @@ pub fn handle_chat(content: &[String], _config: &YamlConfig) {
     let message = content.join(" ");
     let message = message.trim().to_string();
     if message.is_empty() {
-        error!("⚠️ 消息内容为空");
+        error!("{}", t!(msg::CHAT_MESSAGE_EMPTY));
         return;
     }
 
@@ -84,7 +91,7 @@ pub fn handle_chat(content: &[String], _config: &YamlConfig) {
         .min(agent_config.providers.len() - 1);
     let provider = &agent_config.providers[idx];
 
-    info!("🤖 [{}] 思考中...", provider.name);
+    info!("{}", t!(msg::CHAT_CLI_THINKING, provider.name));
 
     let mut messages = Vec::new();
     messages.push(ChatMessage::text("user", message));
@@ -1,7 +1,10 @@
 use super::theme::ThemeName;
 use crate::config::YamlConfig;
-use crate::error;
+use crate::util::file_lock;
+use crate::util::i18n::msg;
+use crate::{error, t};
 use serde::{Deserialize, Serialize};
+use std::collections::BTreeMap;
 use std::fs;
 use std::path::PathBuf;
 
@@ -18,6 +21,9 @@ pub struct ModelProvider {
     pub api_key: String,
     /// 模型名称（如 "gpt-4o", "deepseek-chat"）
     pub model: String,
+    /// 捕获未知字段（如 agent 插件使用的 proxy / rate_limit），保证保存时不丢失
+    #[serde(flatten)]
+    pub extra: BTreeMap<String, serde_json::Value>,
 }
 
 /// Agent 配置
@@ -50,6 +56,9 @@ pub struct AgentConfig {
     /// 回复风格（可选）
     #[serde(default)]
     pub style: Option<String>,
+    /// 捕获未知字段（agent 插件的全局配置），保证保存时不丢失
+    #[serde(flatten)]
+    pub extra: BTreeMap<String, serde_json::Value>,
 }
 
 fn default_max_history_messages() -> usize {
@@ -146,11 +155,11 @@ pub fn load_agent_config() -> AgentConfig {
     }
     match fs::read_to_string(&path) {
         Ok(content) => serde_json::from_str(&content).unwrap_or_else(|e| {
-            error!("❌ 解析 agent_config.json 失败: {}", e);
+            error!("{}", t!(msg::CHAT_CONFIG_PARSE_FAILED, e));
             AgentConfig::default()
         }),
         Err(e) => {
-            error!("❌ 读取 agent_config.json 失败: {}", e);
+            error!("{}", t!(msg::CHAT_CONFIG_READ_FAILED, e));
             AgentConfig::default()
         }
     }
@@ -170,12 +179,12 @@ pub fn save_agent_config(config: &AgentConfig) -> bool {
         Ok(json) => match fs::write(&path, json) {
             Ok(_) => true,
             Err(e) => {
-                error!("❌ 保存 agent_config.json 失败: {}", e);
+                error!("{}", t!(msg::CHAT_CONFIG_WRITE_FAILED, e));
                 false
             }
         },
         Err(e) => {
-            error!("❌ 序列化 agent 配置失败: {}", e);
+            error!("{}", t!(msg::CHAT_CONFIG_SERIALIZE_FAILED, e));
             false
         }
     }
@@ -193,14 +202,14 @@ pub fn load_chat_session() -> ChatSession {
     }
 }
 
-/// 保存对话历史
+/// 保存对话历史（加锁并以改名替换写入，另一个进程同时保存或读取时不会得到写了一半的文件）
 pub fn save_chat_session(session: &ChatSession) -> bool {
     let path = chat_history_path();
     if let Some(parent) = path.parent() {
         let _ = fs::create_dir_all(parent);
     }
     match serde_json::to_string_pretty(session) {
-        Ok(json) => fs::write(&path, json).is_ok(),
+        Ok(json) => file_lock::write_atomic(&path, json.as_bytes()).is_ok(),
         Err(_) => false,
     }
 }
@@ -221,7 +230,7 @@ pub fn load_system_prompt() -> Option<String> {
             }
         }
         Err(e) => {
-            error!("❌ 读取 system_prompt.md 失败: {}", e);
+            error!("{}", t!(msg::CHAT_PROMPT_READ_FAILED, e));
             None
         }
     }
@@ -240,7 +249,7 @@ pub fn save_system_prompt(prompt: &str) -> bool {
             Ok(_) => true,
             Err(e) if e.kind() == std::io::ErrorKind::NotFound => true,
             Err(e) => {
-                error!("❌ 删除 system_prompt.md 失败: {}", e);
+                error!("{}", t!(msg::CHAT_PROMPT_DELETE_FAILED, e));
                 false
             }
         };
@@ -249,7 +258,7 @@ pub fn save_system_prompt(prompt: &str) -> bool {
     match fs::write(path, trimmed) {
         Ok(_) => true,
         Err(e) => {
-            error!("❌ 保存 system_prompt.md 失败: {}", e);
+            error!("{}", t!(msg::CHAT_PROMPT_WRITE_FAILED, e));
             false
         }
     }
@@ -271,7 +280,7 @@ pub fn load_style() -> Option<String> {
             }
         }
         Err(e) => {
-            error!("❌ 读取 style.md 失败: {}", e);
+            error!("{}", t!(msg::CHAT_STYLE_READ_FAILED, e));
             None
         }
     }
@@ -290,7 +299,7 @@ pub fn save_style(style: &str) -> bool {
             Ok(_) => true,
             Err(e) if e.kind() == std::io::ErrorKind::NotFound => true,
             Err(e) => {
-                error!("❌ 删除 style.md 失败: {}", e);
+                error!("{}", t!(msg::CHAT_STYLE_DELETE_FAILED, e));
                 false
             }
         };
@@ -299,7 +308,7 @@ pub fn save_style(style: &str) -> bool {
     match fs::write(path, trimmed) {
         Ok(_) => true,
         Err(e) => {
-            error!("❌ 保存 style.md 失败: {}", e);
+            error!("{}", t!(msg::CHAT_STYLE_WRITE_FAILED, e));
             false
         }
     }
@@ -1,6 +1,10 @@
 use super::app::{ChatApp, ChatMode, MsgLinesCache, PerMsgCache};
 use super::markdown::markdown_to_lines;
 use super::theme::Theme;
+use super::tools::GUARD_CONFIRM_WORD;
+use crate::t;
+use crate::util::color::degrade;
+use crate::util::i18n::{msg, text};
 use ratatui::{
     style::{Color, Modifier, Style},
     text::{Line, Span},
@@ -74,7 +78,7 @@ pub fn build_message_lines_incremental(
             role_label: m
                 .tool_call_id
                 .as_ref()
-                .map(|id| format!("工具 {}", &id[..id.len().min(8)])),
+                .map(|id| t!(msg::CHAT_TOOL_LABEL, &id[..id.len().min(8)])),
         })
         .collect();
 
@@ -191,7 +195,9 @@ pub fn build_message_lines_incremental(
             "tool" => {
                 render_tool_result_msg(
                     &msg.content,
-                    msg.role_label.as_deref().unwrap_or("工具结果"),
+                    msg.role_label
+                        .as_deref()
+                        .unwrap_or(text(&msg::CHAT_TOOL_RESULT)),
                     &mut lines,
                     t,
                 );
@@ -328,7 +334,7 @@ pub fn build_message_lines_incremental(
 
             // 标题行
             lines.push(Line::from(Span::styled(
-                "  🔧 工具调用确认",
+                text(&msg::CHAT_TOOL_CONFIRM_HEADING),
                 Style::default()
                     .fg(t.tool_confirm_title)
                     .add_modifier(Modifier::BOLD),
@@ -343,7 +349,7 @@ pub fn build_message_lines_incremental(
 
             // 工具名行
             {
-                let label = "工具: ";
+                let label = text(&msg::CHAT_TOOL_NAME_LABEL);
                 let name = &tc.tool_name;
                 let text_content = format!("{}{}", label, name);
                 let fill = content_w.saturating_sub(display_width(&text_content));
@@ -398,6 +404,25 @@ pub fn build_message_lines_incremental(
                 ]));
             }
 
+            // 风险分析行（agent guard 的结果，高风险用错误色）
+            let high_risk = tc.risk.as_ref().is_some_and(|r| r.high);
+            if let Some(risk) = &tc.risk {
+                for finding in &risk.findings {
+                    let fg = if risk.high {
+                        t.toast_error_text
+                    } else {
+                        t.tool_confirm_hint
+                    };
+                    lines.push(tool_confirm_text_line(
+                        finding,
+                        Style::default().fg(fg).bg(confirm_bg),
+                        border_color,
+                        confirm_bg,
+                        content_w,
+                    ));
+                }
+            }
+
             // 空行
             {
                 let fill = bubble_max_width.saturating_sub(4);
@@ -408,15 +433,38 @@ pub fn build_message_lines_incremental(
                 ]));
             }
 
+            // 高风险命令：输入确认词后按 Enter 执行
+            if high_risk {
+                let prompt = t!(
+                    msg::CHAT_TOOL_TYPE_TO_CONFIRM,
+                    GUARD_CONFIRM_WORD,
+                    app.confirm_input
+                );
+                lines.push(tool_confirm_text_line(
+                    &prompt,
+                    Style::default()
+                        .fg(t.toast_error_border)
+                        .bg(confirm_bg)
+                        .add_modifier(Modifier::BOLD),
+                    border_color,
+                    confirm_bg,
+                    content_w,
+                ));
+            }
+
             // 操作提示行
-            {
-                let hint_text_w = display_width("[Y/Enter] 执行  /  [N/Esc] 拒绝");
+            if !high_risk {
+                let hint_text_w = display_width(&format!(
+                    "{}  /  {}",
+                    text(&msg::CHAT_CONFIRM_RUN),
+                    text(&msg::CHAT_CONFIRM_REJECT)
+                ));
                 let fill = content_w.saturating_sub(hint_text_w + 2);
                 lines.push(Line::from(vec![
                     Span::styled("  │ ", Style::default().fg(border_color).bg(confirm_bg)),
                     Span::styled(" ".repeat(1), Style::default().bg(confirm_bg)),
                     Span::styled(
-                        "[Y/Enter] 执行",
+                        text(&msg::CHAT_CONFIRM_RUN),
                         Style::default()
                             .fg(t.toast_success_border)
                             .bg(confirm_bg)
@@ -427,7 +475,7 @@ pub fn build_message_lines_incremental(
                         Style::default().fg(t.tool_confirm_label).bg(confirm_bg),
                     ),
                     Span::styled(
-                        "[N/Esc] 拒绝",
+                        text(&msg::CHAT_CONFIRM_REJECT),
                         Style::default()
                             .fg(t.toast_error_border)
                             .bg(confirm_bg)
@@ -733,6 +781,42 @@ pub fn char_width(c: char) -> usize {
 }
 
 /// 渲染工具调用请求消息（AI 发起）：黄色标签 + 工具名和参数摘要
+/// 工具确认框中的一行文字，超出宽度时按显示宽度截断
+fn tool_confirm_text_line(
+    text: &str,
+    style: Style,
+    border_color: Color,
+    confirm_bg: Color,
+    content_w: usize,
+) -> Line<'static> {
+    let max_w = content_w.saturating_sub(2);
+    let mut shown = String::new();
+    if display_width(text) > max_w {
+        let mut w = 0;
+        for c in text.chars() {
+            if w + char_width(c) > max_w.saturating_sub(3) {
+                break;
+            }
+            w += char_width(c);
+            shown.push(c);
+        }
+        shown.push_str("...");
+    } else {
+        shown.push_str(text);
+    }
+    let fill = content_w.saturating_sub(display_width(&shown) + 2);
+    Line::from(vec![
+        Span::styled("  │ ", Style::default().fg(border_color).bg(confirm_bg)),
+        Span::styled(" ", Style::default().bg(confirm_bg)),
+        Span::styled(shown, style),
+        Span::styled(
+            " ".repeat(fill.saturating_sub(1).saturating_add(2)),
+            Style::default().bg(confirm_bg),
+        ),
+        Span::styled(" │", Style::default().fg(border_color).bg(confirm_bg)),
+    ])
+}
+
 pub fn render_tool_call_request_msg(
     tool_calls: &[super::model::ToolCallItem],
     bubble_max_width: usize,
@@ -741,12 +825,12 @@ pub fn render_tool_call_request_msg(
 ) {
     lines.push(Line::from(""));
     lines.push(Line::from(Span::styled(
-        "  🔧 AI 调用工具",
+        text(&msg::CHAT_TOOL_CALLS_HEADING),
         Style::default()
             .fg(Color::Yellow)
             .add_modifier(Modifier::BOLD),
     )));
-    let bubble_bg = Color::Rgb(40, 35, 10);
+    let bubble_bg = degrade(Color::Rgb(40, 35, 10));
     let pad = 3usize;
     let content_w = bubble_max_width.saturating_sub(pad * 2);
     lines.push(Line::from(vec![Span::styled(
@@ -793,7 +877,7 @@ pub fn render_tool_result_msg(
             .fg(Color::Green)
             .add_modifier(Modifier::BOLD),
     )));
-    let bubble_bg = Color::Rgb(10, 40, 15);
+    let bubble_bg = degrade(Color::Rgb(10, 40, 15));
     let pad = 3usize;
     let content_w = 60usize;
     let bubble_w = content_w + pad * 2;
@@ -821,7 +905,9 @@ pub fn render_tool_result_msg(
             Span::styled(" ".repeat(pad), Style::default().bg(bubble_bg)),
             Span::styled(
                 wl,
-                Style::default().fg(Color::Rgb(180, 255, 180)).bg(bubble_bg),
+                Style::default()
+                    .fg(degrade(Color::Rgb(180, 255, 180)))
+                    .bg(bubble_bg),
             ),
             Span::styled(" ".repeat(fill), Style::default().bg(bubble_bg)),
             Span::styled(" ".repeat(pad), Style::default().bg(bubble_bg)),
@@ -1,5 +1,7 @@
 use super::tools::{Tool, ToolResult};
 use crate::config::YamlConfig;
+use crate::t;
+use crate::util::i18n::{msg, text};
 use serde::Deserialize;
 use serde_json::{Value, json};
 use std::fs;
@@ -109,8 +111,7 @@ pub fn resolve_skill_content(skill: &Skill) -> String {
                 if path.is_file() {
                     if let Ok(content) = fs::read_to_string(&path) {
                         let filename = path.file_name().unwrap_or_default().to_string_lossy();
-                        result
-                            .push_str(&format!("\n\n--- 参考文件: {} ---\n{}", filename, content));
+                        result.push_str(&t!(msg::CHAT_SKILL_REFERENCE, filename, content));
                     }
                 }
                 if result.len() > MAX_BYTES {
@@ -127,7 +128,7 @@ pub fn resolve_skill_content(skill: &Skill) -> String {
             end -= 1;
         }
         result.truncate(end);
-        result.push_str("\n...(内容已截断)");
+        result.push_str(text(&msg::CHAT_SKILL_TRUNCATED));
     }
 
     result
@@ -138,7 +139,7 @@ pub fn resolve_skill_content(skill: &Skill) -> String {
 /// 构建 skills 摘要列表（name + description），用于系统提示词的 {{.skills}} 占位符
 pub fn build_skills_summary(skills: &[Skill]) -> String {
     if skills.is_empty() {
-        return "（暂无已安装的技能）".to_string();
+        return text(&msg::CHAT_NO_SKILLS).to_string();
     }
     let mut result = String::new();
     for skill in skills {
@@ -162,7 +163,7 @@ impl Tool for LoadSkillTool {
     }
 
     fn description(&self) -> &str {
-        "加载指定 skill 的完整内容到上下文以了解更多信息，帮助你进行更好地完成任务。"
+        text(&msg::CHAT_TOOL_SKILL_DESC)
     }
 
     fn parameters_schema(&self) -> Value {
@@ -171,11 +172,11 @@ impl Tool for LoadSkillTool {
             "properties": {
                 "name": {
                     "type": "string",
-                    "description": "要加载的技能名称"
+                    "description": text(&msg::CHAT_TOOL_SKILL_NAME)
                 },
                 "arguments": {
                     "type": "string",
-                    "description": "传递给技能的参数（可选）"
+                    "description": text(&msg::CHAT_TOOL_SKILL_ARGS)
                 }
             },
             "required": ["name"]
@@ -197,7 +198,7 @@ impl Tool for LoadSkillTool {
 
         if skill_name.is_empty() {
             return ToolResult {
-                output: "参数缺少 name 字段".to_string(),
+                output: text(&msg::CHAT_TOOL_NO_NAME).to_string(),
                 is_error: true,
             };
         }
@@ -222,11 +223,7 @@ impl Tool for LoadSkillTool {
                     .map(|s| s.frontmatter.name.as_str())
                     .collect();
                 ToolResult {
-                    output: format!(
-                        "未找到技能 '{}'。可用技能: {}",
-                        skill_name,
-                        available.join(", ")
-                    ),
+                    output: t!(msg::CHAT_SKILL_NOT_FOUND, skill_name, available.join(", ")),
                     is_error: true,
                 }
             }
@@ -1,3 +1,5 @@
+use crate::util::color::{self, ColorDepth};
+use crate::util::i18n::{msg, text};
 use ratatui::style::Color;
 use serde::{Deserialize, Serialize};
 
@@ -51,7 +53,7 @@ impl ThemeName {
         match self {
             ThemeName::Dark => "Dark",
             ThemeName::Light => "Light",
-            ThemeName::Midnight => "Midnight（默认）",
+            ThemeName::Midnight => text(&msg::CHAT_THEME_MIDNIGHT),
             ThemeName::Nord => "Nord",
             ThemeName::Monokai => "Monokai",
         }
@@ -332,14 +334,130 @@ pub struct Theme {
 }
 
 impl Theme {
-    /// 根据主题名称创建对应的主题
+    /// 根据主题名称创建对应的主题（按终端的颜色深度转换颜色）
     pub fn from_name(name: &ThemeName) -> Self {
-        match name {
+        let theme = match name {
             ThemeName::Dark => Self::dark(),
             ThemeName::Light => Self::light(),
             ThemeName::Midnight => Self::midnight(),
             ThemeName::Nord => Self::nord(),
             ThemeName::Monokai => Self::monokai(),
+        };
+        match color::color_depth() {
+            ColorDepth::TrueColor => theme,
+            depth => theme.degraded(depth),
+        }
+    }
+
+    /// 把所有 Rgb 颜色换成指定颜色深度下最接近的颜色，避免 256 色或 16 色终端把 24 位色序列显示成乱码
+    pub fn degraded(self, depth: ColorDepth) -> Self {
+        let d = |c: Color| color::degrade_to(c, depth);
+        Self {
+            bg_primary: d(self.bg_primary),
+            bg_title: d(self.bg_title),
+            bg_input: d(self.bg_input),
+            bg_panel: d(self.bg_panel),
+            border_title: d(self.border_title),
+            border_message: d(self.border_message),
+            border_input: d(self.border_input),
+            border_input_loading: d(self.border_input_loading),
+            border_config: d(self.border_config),
+            separator: d(self.separator),
+            bubble_ai: d(self.bubble_ai),
+            bubble_ai_selected: d(self.bubble_ai_selected),
+            bubble_user: d(self.bubble_user),
+            bubble_user_selected: d(self.bubble_user_selected),
+            label_ai: d(self.label_ai),
+            label_user: d(self.label_user),
+            label_selected: d(self.label_selected),
+            text_normal: d(self.text_normal),
+            text_bold: d(self.text_bold),
+            text_dim: d(self.text_dim),
+            text_very_dim: d(self.text_very_dim),
+            text_white: d(self.text_white),
+            text_system: d(self.text_system),
+            title_icon: d(self.title_icon),
+            title_separator: d(self.title_separator),
+            title_model: d(self.title_model),
+            title_count: d(self.title_count),
+            title_loading: d(self.title_loading),
+            input_prompt: d(self.input_prompt),
+            input_prompt_loading: d(self.input_prompt_loading),
+            cursor_fg: d(self.cursor_fg),
+            cursor_bg: d(self.cursor_bg),
+            hint_key_fg: d(self.hint_key_fg),
+            hint_key_bg: d(self.hint_key_bg),
+            hint_desc: d(self.hint_desc),
+            hint_separator: d(self.hint_separator),
+            toast_success_border: d(self.toast_success_border),
+            toast_success_bg: d(self.toast_success_bg),
+            toast_success_text: d(self.toast_success_text),
+            toast_error_border: d(self.toast_error_border),
+            toast_error_bg: d(self.toast_error_bg),
+            toast_error_text: d(self.toast_error_text),
+            tool_confirm_border: d(self.tool_confirm_border),
+            tool_confirm_bg: d(self.tool_confirm_bg),
+            tool_confirm_title: d(self.tool_confirm_title),
+            tool_confirm_name: d(self.tool_confirm_name),
+            tool_confirm_text: d(self.tool_confirm_text),
+            tool_confirm_label: d(self.tool_confirm_label),
+            tool_confirm_hint: d(self.tool_confirm_hint),
+            welcome_border: d(self.welcome_border),
+            welcome_text: d(self.welcome_text),
+            welcome_hint: d(self.welcome_hint),
+            model_sel_border: d(self.model_sel_border),
+            model_sel_title: d(self.model_sel_title),
+            model_sel_active: d(self.model_sel_active),
+            model_sel_inactive: d(self.model_sel_inactive),
+            model_sel_highlight_bg: d(self.model_sel_highlight_bg),
+            config_title: d(self.config_title),
+            config_section: d(self.config_section),
+            config_pointer: d(self.config_pointer),
+            config_label_selected: d(self.config_label_selected),
+            config_label: d(self.config_label),
+            config_value: d(self.config_value),
+            config_edit_bg: d(self.config_edit_bg),
+            config_tab_active_bg: d(self.config_tab_active_bg),
+            config_tab_active_fg: d(self.config_tab_active_fg),
+            config_tab_inactive: d(self.config_tab_inactive),
+            config_hint_key: d(self.config_hint_key),
+            config_hint_desc: d(self.config_hint_desc),
+            config_toggle_on: d(self.config_toggle_on),
+            config_toggle_off: d(self.config_toggle_off),
+            config_dim: d(self.config_dim),
+            config_api_key: d(self.config_api_key),
+            md_h1: d(self.md_h1),
+            md_h2: d(self.md_h2),
+            md_h3: d(self.md_h3),
+            md_h4: d(self.md_h4),
+            md_heading_sep: d(self.md_heading_sep),
+            md_inline_code_fg: d(self.md_inline_code_fg),
+            md_inline_code_bg: d(self.md_inline_code_bg),
+            md_list_bullet: d(self.md_list_bullet),
+            md_blockquote_bar: d(self.md_blockquote_bar),
+            md_blockquote_text: d(self.md_blockquote_text),
+            md_rule: d(self.md_rule),
+            code_border: d(self.code_border),
+            code_bg: d(self.code_bg),
+            code_default: d(self.code_default),
+            code_keyword: d(self.code_keyword),
+            code_string: d(self.code_string),
+            code_comment: d(self.code_comment),
+            code_number: d(self.code_number),
+            code_type: d(self.code_type),
+            code_primitive: d(self.code_primitive),
+            code_macro: d(self.code_macro),
+            code_attribute: d(self.code_attribute),
+            code_lifetime: d(self.code_lifetime),
+            code_shell_var: d(self.code_shell_var),
+            table_border: d(self.table_border),
+            table_header: d(self.table_header),
+            table_body: d(self.table_body),
+            help_title: d(self.help_title),
+            help_key: d(self.help_key),
+            help_desc: d(self.help_desc),
+            help_path: d(self.help_path),
+            help_bg: d(self.help_bg),
         }
     }
 
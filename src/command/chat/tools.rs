@@ -2,6 +2,10 @@ use async_openai::types::chat::{ChatCompletionTool, ChatCompletionTools, Functio
 use serde_json::{Value, json};
 
 use super::skill::Skill;
+use crate::config::profile;
+use crate::constants::BIN_DIR;
+use crate::t;
+use crate::util::i18n::{msg, text};
 
 /// 展开路径中的 ~ 为用户 home 目录
 fn expand_tilde(path: &str) -> String {
@@ -38,8 +42,20 @@ pub trait Tool: Send + Sync {
     }
     /// 生成确认提示文字（供 TUI 展示）
     fn confirmation_message(&self, arguments: &str) -> String {
-        format!("调用工具 {} 参数: {}", self.name(), arguments)
+        t!(msg::CHAT_TOOL_CALL, self.name(), arguments)
     }
+    /// 执行前的风险分析（供确认框展示），不涉及风险的工具返回 None
+    fn risk(&self, _arguments: &str) -> Option<Risk> {
+        None
+    }
+}
+
+/// 执行前的风险分析结果
+pub struct Risk {
+    /// 每处风险一行，高风险在前
+    pub findings: Vec<String>,
+    /// 是否有高风险：需要输入确认词才执行
+    pub high: bool,
 }
 
 // ========== run_shell ==========
@@ -47,29 +63,62 @@ pub trait Tool: Send + Sync {
 /// 执行 shell 命令的工具
 pub struct ShellTool;
 
-/// 简单的危险命令过滤
-fn is_dangerous_command(cmd: &str) -> bool {
-    let dangerous_patterns = [
-        "rm -rf /",
-        "rm -rf /*",
-        "mkfs",
-        "dd if=",
-        ":(){:|:&};:",
-        "chmod -R 777 /",
-        "chown -R",
-        "> /dev/sda",
-        "wget -O- | sh",
-        "curl | sh",
-        "alias",
-        "curl | bash",
-    ];
-    let cmd_lower = cmd.to_lowercase();
-    for pat in &dangerous_patterns {
-        if cmd_lower.contains(pat) {
-            return true;
+/// 执行高风险命令前需要输入的确认词，与 agent guard confirm 一致
+pub const GUARD_CONFIRM_WORD: &str = "yes";
+
+/// agent guard check --json 输出的一处风险
+#[derive(serde::Deserialize)]
+struct GuardFinding {
+    rule: String,
+    level: String,
+    reason: String,
+}
+
+/// 用 agent guard 分析命令（规则与 guard.json 和命令行钩子共用）；
+/// 找不到 agent 插件或分析失败时按高风险处理，宁可多确认一次
+fn guard_check(command: &str) -> Risk {
+    let bin = profile::root_dir().join(BIN_DIR).join("agent");
+    let bin = if bin.is_file() {
+        bin
+    } else {
+        std::path::PathBuf::from("agent")
+    };
+    let output = std::process::Command::new(&bin)
+        .args(["guard", "check", "--json", "--", command])
+        .stdin(std::process::Stdio::null())
+        .output();
+    // 退出码 0 / 1 / 2 分别表示无风险、仅提示、有高风险，都会输出 JSON
+    let findings = match output {
+        Ok(out) if matches!(out.status.code(), Some(0..=2)) => {
+            serde_json::from_slice::<Vec<GuardFinding>>(&out.stdout).map_err(|e| e.to_string())
+        }
+        Ok(out) => Err(String::from_utf8_lossy(&out.stderr).trim().to_string()),
+        Err(e) => Err(format!("{}: {}", bin.display(), e)),
+    };
+    let mut findings = match findings {
+        Ok(findings) => findings,
+        Err(e) => {
+            return Risk {
+                findings: vec![t!(msg::CHAT_TOOL_RISK_UNKNOWN, e)],
+                high: true,
+            };
         }
+    };
+    findings.sort_by_key(|f| f.level != "high");
+    Risk {
+        high: findings.iter().any(|f| f.level == "high"),
+        findings: findings
+            .iter()
+            .map(|f| {
+                let label = if f.level == "high" {
+                    text(&msg::CHAT_TOOL_RISK_HIGH)
+                } else {
+                    text(&msg::CHAT_TOOL_RISK_NOTICE)
+                };
+                format!("⚠ {}: {} [{}]", label, f.reason, f.rule)
+            })
+            .collect(),
     }
-    false
 }
 
 impl Tool for ShellTool {
@@ -78,7 +127,7 @@ impl Tool for ShellTool {
     }
 
     fn description(&self) -> &str {
-        "在当前系统上执行 shell 命令，返回命令的 stdout 和 stderr 输出；注意每次调用 run_shell 都会创建一个新的进程，状态是不延续的"
+        text(&msg::CHAT_TOOL_SHELL_DESC)
     }
 
     fn parameters_schema(&self) -> Value {
@@ -87,7 +136,7 @@ impl Tool for ShellTool {
             "properties": {
                 "command": {
                     "type": "string",
-                    "description": "要执行的 shell 命令（在 bash 中执行）"
+                    "description": text(&msg::CHAT_TOOL_SHELL_COMMAND)
                 }
             },
             "required": ["command"]
@@ -100,33 +149,26 @@ impl Tool for ShellTool {
                 Some(cmd) => cmd.to_string(),
                 None => {
                     return ToolResult {
-                        output: "参数缺少 command 字段".to_string(),
+                        output: text(&msg::CHAT_TOOL_NO_COMMAND).to_string(),
                         is_error: true,
                     };
                 }
             },
             Err(e) => {
                 return ToolResult {
-                    output: format!("参数解析失败: {}", e),
+                    output: t!(msg::CHAT_TOOL_BAD_ARGS, e),
                     is_error: true,
                 };
             }
         };
 
-        // 安全过滤
-        if is_dangerous_command(&command) {
-            return ToolResult {
-                output: "该命令被安全策略拒绝执行".to_string(),
-                is_error: true,
-            };
-        }
-
         match std::process::Command::new("bash")
             .arg("-c")
             .arg(&command)
             .output()
         {
             Ok(output) => {
+                super::audit::record(&command, "confirmed", output.status.code());
                 let mut result = String::new();
                 let stdout = String::from_utf8_lossy(&output.stdout);
                 let stderr = String::from_utf8_lossy(&output.stderr);
@@ -144,7 +186,7 @@ impl Tool for ShellTool {
                 }
 
                 if result.is_empty() {
-                    result = "(无输出)".to_string();
+                    result = text(&msg::CHAT_TOOL_NO_OUTPUT).to_string();
                 }
 
                 // 截断到 4000 字节
@@ -154,7 +196,7 @@ impl Tool for ShellTool {
                     while !result.is_char_boundary(end) {
                         end -= 1;
                     }
-                    format!("{}\n...(输出已截断)", &result[..end])
+                    t!(msg::CHAT_TOOL_OUTPUT_TRUNCATED, &result[..end])
                 } else {
                     result
                 };
@@ -165,10 +207,13 @@ impl Tool for ShellTool {
                     is_error,
                 }
             }
-            Err(e) => ToolResult {
-                output: format!("执行失败: {}", e),
-                is_error: true,
-            },
+            Err(e) => {
+                super::audit::record(&command, "confirmed", None);
+                ToolResult {
+                    output: t!(msg::CHAT_TOOL_RUN_FAILED, e),
+                    is_error: true,
+                }
+            }
         }
     }
 
@@ -178,18 +223,24 @@ impl Tool for ShellTool {
 
     fn confirmation_message(&self, arguments: &str) -> String {
         // 尝试解析 command 字段
-        let cmd = serde_json::from_str::<Value>(arguments)
-            .ok()
-            .and_then(|v| {
-                v.get("command")
-                    .and_then(|c| c.as_str())
-                    .map(|s| s.to_string())
-            })
-            .unwrap_or_else(|| arguments.to_string());
-        format!("即将执行: {}", cmd)
+        let cmd = shell_command_of(arguments).unwrap_or_else(|| arguments.to_string());
+        t!(msg::CHAT_TOOL_CONFIRM_SHELL, cmd)
+    }
+
+    fn risk(&self, arguments: &str) -> Option<Risk> {
+        shell_command_of(arguments).map(|cmd| guard_check(&cmd))
     }
 }
 
+/// 从 run_shell 的参数 JSON 中提取 command 字段
+pub fn shell_command_of(arguments: &str) -> Option<String> {
+    serde_json::from_str::<Value>(arguments).ok().and_then(|v| {
+        v.get("command")
+            .and_then(|c| c.as_str())
+            .map(|s| s.to_string())
+    })
+}
+
 // ========== read_file ==========
 
 /// 读取文件的工具
@@ -201,7 +252,7 @@ impl Tool for ReadFileTool {
     }
 
     fn description(&self) -> &str {
-        "读取本地文件内容并返回（带行号）。支持通过 offset 和 limit 参数按行范围读取。"
+        text(&msg::CHAT_TOOL_READ_DESC)
     }
 
     fn parameters_schema(&self) -> Value {
@@ -210,15 +261,15 @@ impl Tool for ReadFileTool {
             "properties": {
                 "path": {
                     "type": "string",
-                    "description": "要读取的文件路径（绝对路径或相对于当前工作目录）"
+                    "description": text(&msg::CHAT_TOOL_READ_PATH)
                 },
                 "offset": {
                     "type": "integer",
-                    "description": "从第几行开始读取（0-based，即 0 表示第 1 行），不传则从头开始"
+                    "description": text(&msg::CHAT_TOOL_READ_OFFSET)
                 },
                 "limit": {
                     "type": "integer",
-                    "description": "读取多少行，不传则读到文件末尾"
+                    "description": text(&msg::CHAT_TOOL_READ_LIMIT)
                 }
             },
             "required": ["path"]
@@ -230,7 +281,7 @@ impl Tool for ReadFileTool {
             Ok(v) => v,
             Err(e) => {
                 return ToolResult {
-                    output: format!("参数解析失败: {}", e),
+                    output: t!(msg::CHAT_TOOL_BAD_ARGS, e),
                     is_error: true,
                 };
             }
@@ -240,7 +291,7 @@ impl Tool for ReadFileTool {
             Some(p) => expand_tilde(p),
             None => {
                 return ToolResult {
-                    output: "参数缺少 path 字段".to_string(),
+                    output: text(&msg::CHAT_TOOL_NO_PATH).to_string(),
                     is_error: true,
                 };
             }
@@ -263,7 +314,7 @@ impl Tool for ReadFileTool {
                 let mut result = selected.join("\n");
 
                 if start + count < total {
-                    result.push_str(&format!("\n...(还有 {} 行未显示)", total - start - count));
+                    result.push_str(&t!(msg::CHAT_TOOL_MORE_LINES, total - start - count));
                 }
 
                 // 截断到 8000 字节
@@ -273,7 +324,7 @@ impl Tool for ReadFileTool {
                     while !result.is_char_boundary(end) {
                         end -= 1;
                     }
-                    format!("{}\n...(文件内容已截断)", &result[..end])
+                    t!(msg::CHAT_TOOL_FILE_TRUNCATED, &result[..end])
                 } else {
                     result
                 };
@@ -283,7 +334,7 @@ impl Tool for ReadFileTool {
                 }
             }
             Err(e) => ToolResult {
-                output: format!("读取文件失败: {}", e),
+                output: t!(msg::CHAT_TOOL_READ_FAILED, e),
                 is_error: true,
             },
         }
@@ -305,7 +356,7 @@ impl Tool for WriteFileTool {
     }
 
     fn description(&self) -> &str {
-        "将内容写入指定文件。如果文件已存在则覆盖，如果目录不存在会自动创建。"
+        text(&msg::CHAT_TOOL_WRITE_DESC)
     }
 
     fn parameters_schema(&self) -> Value {
@@ -314,11 +365,11 @@ impl Tool for WriteFileTool {
             "properties": {
                 "path": {
                     "type": "string",
-                    "description": "要写入的文件路径（绝对路径或相对于当前工作目录）"
+                    "description": text(&msg::CHAT_TOOL_WRITE_PATH)
                 },
                 "content": {
                     "type": "string",
-                    "description": "要写入的文件内容"
+                    "description": text(&msg::CHAT_TOOL_WRITE_CONTENT)
                 }
             },
             "required": ["path", "content"]
@@ -330,7 +381,7 @@ impl Tool for WriteFileTool {
             Ok(v) => v,
             Err(e) => {
                 return ToolResult {
-                    output: format!("参数解析失败: {}", e),
+                    output: t!(msg::CHAT_TOOL_BAD_ARGS, e),
                     is_error: true,
                 };
             }
@@ -340,7 +391,7 @@ impl Tool for WriteFileTool {
             Some(p) => expand_tilde(p),
             None => {
                 return ToolResult {
-                    output: "参数缺少 path 字段".to_string(),
+                    output: text(&msg::CHAT_TOOL_NO_PATH).to_string(),
                     is_error: true,
                 };
             }
@@ -350,7 +401,7 @@ impl Tool for WriteFileTool {
             Some(c) => c.to_string(),
             None => {
                 return ToolResult {
-                    output: "参数缺少 content 字段".to_string(),
+                    output: text(&msg::CHAT_TOOL_NO_CONTENT).to_string(),
                     is_error: true,
                 };
             }
@@ -362,7 +413,7 @@ impl Tool for WriteFileTool {
             if !parent.exists() {
                 if let Err(e) = std::fs::create_dir_all(parent) {
                     return ToolResult {
-                        output: format!("创建目录失败: {}", e),
+                        output: t!(msg::CHAT_TOOL_MKDIR_FAILED, e),
                         is_error: true,
                     };
                 }
@@ -371,11 +422,11 @@ impl Tool for WriteFileTool {
 
         match std::fs::write(&path, &content) {
             Ok(_) => ToolResult {
-                output: format!("已写入文件: {} ({} 字节)", path, content.len()),
+                output: t!(msg::CHAT_TOOL_WRITTEN, path, content.len()),
                 is_error: false,
             },
             Err(e) => ToolResult {
-                output: format!("写入文件失败: {}", e),
+                output: t!(msg::CHAT_TOOL_WRITE_FAILED, e),
                 is_error: true,
             },
         }
@@ -393,8 +444,8 @@ impl Tool for WriteFileTool {
                     .and_then(|c| c.as_str())
                     .map(|s| expand_tilde(s))
             })
-            .unwrap_or_else(|| "未知路径".to_string());
-        format!("即将写入文件: {}", path)
+            .unwrap_or_else(|| text(&msg::CHAT_TOOL_UNKNOWN_PATH).to_string());
+        t!(msg::CHAT_TOOL_CONFIRM_WRITE, path)
     }
 }
 
@@ -409,7 +460,7 @@ impl Tool for EditFileTool {
     }
 
     fn description(&self) -> &str {
-        "通过精确字符串匹配替换来编辑文件。old_string 必须在文件中唯一匹配，替换为 new_string。如果 new_string 为空字符串则表示删除匹配内容。"
+        text(&msg::CHAT_TOOL_EDIT_DESC)
     }
 
     fn parameters_schema(&self) -> Value {
@@ -418,15 +469,15 @@ impl Tool for EditFileTool {
             "properties": {
                 "path": {
                     "type": "string",
-                    "description": "要编辑的文件路径"
+                    "description": text(&msg::CHAT_TOOL_EDIT_PATH)
                 },
                 "old_string": {
                     "type": "string",
-                    "description": "要被替换的原始字符串（必须在文件中唯一存在）"
+                    "description": text(&msg::CHAT_TOOL_EDIT_OLD)
                 },
                 "new_string": {
                     "type": "string",
-                    "description": "替换后的新字符串，为空则表示删除"
+                    "description": text(&msg::CHAT_TOOL_EDIT_NEW)
                 }
             },
             "required": ["path", "old_string", "new_string"]
@@ -438,7 +489,7 @@ impl Tool for EditFileTool {
             Ok(v) => v,
             Err(e) => {
                 return ToolResult {
-                    output: format!("参数解析失败: {}", e),
+                    output: t!(msg::CHAT_TOOL_BAD_ARGS, e),
                     is_error: true,
                 };
             }
@@ -448,7 +499,7 @@ impl Tool for EditFileTool {
             Some(p) => expand_tilde(p),
             None => {
                 return ToolResult {
-                    output: "参数缺少 path 字段".to_string(),
+                    output: text(&msg::CHAT_TOOL_NO_PATH).to_string(),
                     is_error: true,
                 };
             }
@@ -458,7 +509,7 @@ impl Tool for EditFileTool {
             Some(s) => s.to_string(),
             None => {
                 return ToolResult {
-                    output: "参数缺少 old_string 字段".to_string(),
+                    output: text(&msg::CHAT_TOOL_NO_OLD).to_string(),
                     is_error: true,
                 };
             }
@@ -475,7 +526,7 @@ impl Tool for EditFileTool {
             Ok(c) => c,
             Err(e) => {
                 return ToolResult {
-                    output: format!("读取文件失败: {}", e),
+                    output: t!(msg::CHAT_TOOL_READ_FAILED, e),
                     is_error: true,
                 };
             }
@@ -485,16 +536,13 @@ impl Tool for EditFileTool {
         let count = content.matches(&old_string).count();
         if count == 0 {
             return ToolResult {
-                output: "未找到匹配的字符串".to_string(),
+                output: text(&msg::CHAT_TOOL_NO_MATCH).to_string(),
                 is_error: true,
             };
         }
         if count > 1 {
             return ToolResult {
-                output: format!(
-                    "old_string 在文件中匹配了 {} 次，必须唯一匹配。请提供更多上下文使其唯一",
-                    count
-                ),
+                output: t!(msg::CHAT_TOOL_MANY_MATCHES, count),
                 is_error: true,
             };
         }
@@ -503,11 +551,11 @@ impl Tool for EditFileTool {
         let new_content = content.replacen(&old_string, &new_string, 1);
         match std::fs::write(&path, &new_content) {
             Ok(_) => ToolResult {
-                output: format!("已编辑文件: {}", path),
+                output: t!(msg::CHAT_TOOL_EDITED, path),
                 is_error: false,
             },
             Err(e) => ToolResult {
-                output: format!("写入文件失败: {}", e),
+                output: t!(msg::CHAT_TOOL_WRITE_FAILED, e),
                 is_error: true,
             },
         }
@@ -526,7 +574,7 @@ impl Tool for EditFileTool {
                     .and_then(|c| c.as_str())
                     .map(|s| expand_tilde(s))
             })
-            .unwrap_or_else(|| "未知路径".to_string());
+            .unwrap_or_else(|| text(&msg::CHAT_TOOL_UNKNOWN_PATH).to_string());
         let old = v
             .as_ref()
             .and_then(|v| v.get("old_string").and_then(|c| c.as_str()))
@@ -538,7 +586,7 @@ impl Tool for EditFileTool {
         } else {
             first_line.to_string()
         };
-        format!("即将编辑文件 {} (替换: \"{}\")", path, preview)
+        t!(msg::CHAT_TOOL_CONFIRM_EDIT, path, preview)
     }
 }
 
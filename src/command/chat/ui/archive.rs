@@ -1,4 +1,6 @@
 use super::super::app::ChatApp;
+use crate::t;
+use crate::util::i18n::{msg, text};
 use ratatui::{
     layout::Rect,
     style::{Modifier, Style},
@@ -13,7 +15,7 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
 
     lines.push(Line::from(""));
     lines.push(Line::from(Span::styled(
-        "  📦 归档当前对话",
+        text(&msg::CHAT_ARCHIVE_HEADING),
         Style::default()
             .fg(t.help_title)
             .add_modifier(Modifier::BOLD),
@@ -25,14 +27,14 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
     )));
     lines.push(Line::from(""));
     lines.push(Line::from(Span::styled(
-        "  即将归档当前对话，归档后当前会话将被清空。",
+        text(&msg::CHAT_ARCHIVE_WARNING),
         Style::default().fg(t.text_dim),
     )));
     lines.push(Line::from(""));
 
     if app.archive_editing_name {
         lines.push(Line::from(Span::styled(
-            "  请输入归档名称：",
+            text(&msg::CHAT_ARCHIVE_NAME_PROMPT),
             Style::default().fg(t.text_white),
         )));
         lines.push(Line::from(""));
@@ -81,7 +83,7 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
         ));
         lines.push(Line::from(""));
         lines.push(Line::from(Span::styled(
-            "  提示：留空则使用默认名称（如 archive-2026-02-25）",
+            text(&msg::CHAT_ARCHIVE_NAME_HINT),
             Style::default().fg(t.text_dim),
         )));
         lines.push(Line::from(""));
@@ -96,7 +98,10 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "Enter",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("  确认归档", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_ARCHIVE_CONFIRM),
+                Style::default().fg(t.help_desc),
+            ),
         ]));
         lines.push(Line::from(vec![
             Span::styled("  ", Style::default()),
@@ -104,11 +109,17 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "Esc",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("    取消", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_ARCHIVE_CANCEL),
+                Style::default().fg(t.help_desc),
+            ),
         ]));
     } else {
         lines.push(Line::from(vec![
-            Span::styled("  默认名称：", Style::default().fg(t.text_dim)),
+            Span::styled(
+                text(&msg::CHAT_ARCHIVE_DEFAULT_NAME),
+                Style::default().fg(t.text_dim),
+            ),
             Span::styled(
                 &app.archive_default_name,
                 Style::default()
@@ -128,7 +139,10 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "Enter",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("  使用默认名称归档", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_ARCHIVE_USE_DEFAULT),
+                Style::default().fg(t.help_desc),
+            ),
         ]));
         lines.push(Line::from(vec![
             Span::styled("  ", Style::default()),
@@ -136,7 +150,10 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "n",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("      自定义名称", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_ARCHIVE_CUSTOM),
+                Style::default().fg(t.help_desc),
+            ),
         ]));
         lines.push(Line::from(vec![
             Span::styled("  ", Style::default()),
@@ -144,7 +161,10 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "d",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("      仅清空不归档", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_ARCHIVE_CLEAR_ONLY),
+                Style::default().fg(t.help_desc),
+            ),
         ]));
         lines.push(Line::from(vec![
             Span::styled("  ", Style::default()),
@@ -152,7 +172,10 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "Esc",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("    取消", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_ARCHIVE_CANCEL),
+                Style::default().fg(t.help_desc),
+            ),
         ]));
     }
 
@@ -160,7 +183,10 @@ pub fn draw_archive_confirm(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
         .borders(Borders::ALL)
         .border_type(ratatui::widgets::BorderType::Rounded)
         .border_style(Style::default().fg(t.border_title))
-        .title(Span::styled(" 归档确认 ", Style::default().fg(t.text_dim)))
+        .title(Span::styled(
+            text(&msg::CHAT_ARCHIVE_CONFIRM_TITLE),
+            Style::default().fg(t.text_dim),
+        ))
         .style(Style::default().bg(t.help_bg));
     let widget = Paragraph::new(lines).block(block);
     f.render_widget(widget, area);
@@ -174,14 +200,14 @@ pub fn draw_archive_list(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
         let mut lines: Vec<Line> = Vec::new();
         lines.push(Line::from(""));
         lines.push(Line::from(Span::styled(
-            "  ⚠️  确认还原",
+            text(&msg::CHAT_RESTORE_HEADING),
             Style::default()
                 .fg(t.toast_error_text)
                 .add_modifier(Modifier::BOLD),
         )));
         lines.push(Line::from(""));
         lines.push(Line::from(Span::styled(
-            "  当前对话未归档，还原将丢失当前对话内容！",
+            text(&msg::CHAT_RESTORE_WARNING),
             Style::default().fg(t.text_white),
         )));
         lines.push(Line::from(""));
@@ -192,7 +218,10 @@ pub fn draw_archive_list(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
         lines.push(Line::from(""));
         if let Some(archive) = app.archives.get(app.archive_list_index) {
             lines.push(Line::from(vec![
-                Span::styled("  将还原归档：", Style::default().fg(t.text_dim)),
+                Span::styled(
+                    text(&msg::CHAT_RESTORE_TARGET),
+                    Style::default().fg(t.text_dim),
+                ),
                 Span::styled(
                     &archive.name,
                     Style::default()
@@ -208,7 +237,10 @@ pub fn draw_archive_list(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "y/Enter",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("  确认还原", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_RESTORE_CONFIRM),
+                Style::default().fg(t.help_desc),
+            ),
         ]));
         lines.push(Line::from(vec![
             Span::styled("  ", Style::default()),
@@ -216,14 +248,20 @@ pub fn draw_archive_list(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "Esc",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("     取消", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_RESTORE_CANCEL),
+                Style::default().fg(t.help_desc),
+            ),
         ]));
 
         let block = Block::default()
             .borders(Borders::ALL)
             .border_type(ratatui::widgets::BorderType::Rounded)
             .border_style(Style::default().fg(t.toast_error_border))
-            .title(Span::styled(" 还原确认 ", Style::default().fg(t.text_dim)))
+            .title(Span::styled(
+                text(&msg::CHAT_RESTORE_CONFIRM_TITLE),
+                Style::default().fg(t.text_dim),
+            ))
             .style(Style::default().bg(t.help_bg));
         let widget = Paragraph::new(lines).block(block);
         f.render_widget(widget, area);
@@ -235,17 +273,17 @@ pub fn draw_archive_list(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
             Line::from(""),
             Line::from(""),
             Line::from(Span::styled(
-                "  📦 暂无归档对话",
+                text(&msg::CHAT_ARCHIVE_NONE),
                 Style::default().fg(t.text_dim).add_modifier(Modifier::BOLD),
             )),
             Line::from(""),
             Line::from(Span::styled(
-                "  按 Ctrl+L 归档当前对话",
+                text(&msg::CHAT_ARCHIVE_NONE_HINT),
                 Style::default().fg(t.text_dim),
             )),
             Line::from(""),
             Line::from(Span::styled(
-                "  按 Esc 返回聊天",
+                text(&msg::CHAT_ARCHIVE_BACK_HINT),
                 Style::default().fg(t.text_dim),
             )),
         ];
@@ -254,7 +292,10 @@ pub fn draw_archive_list(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
             .borders(Borders::ALL)
             .border_type(ratatui::widgets::BorderType::Rounded)
             .border_style(Style::default().fg(t.border_title))
-            .title(Span::styled(" 归档列表 ", Style::default().fg(t.text_dim)))
+            .title(Span::styled(
+                text(&msg::CHAT_ARCHIVE_LIST_TITLE),
+                Style::default().fg(t.text_dim),
+            ))
             .style(Style::default().bg(t.help_bg));
         let widget = Paragraph::new(lines).block(block);
         f.render_widget(widget, area);
@@ -281,9 +322,12 @@ pub fn draw_archive_list(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
             } else {
                 Style::default().fg(t.model_sel_inactive)
             };
-            let detail = format!(
-                "{}{}  📨 {} 条消息  📅 {}",
-                marker, archive.name, msg_count, created_at
+            let detail = t!(
+                msg::CHAT_ARCHIVE_ITEM,
+                marker,
+                archive.name,
+                msg_count,
+                created_at
             );
             ListItem::new(Line::from(Span::styled(detail, style)))
         })
@@ -296,7 +340,7 @@ pub fn draw_archive_list(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 .border_type(ratatui::widgets::BorderType::Rounded)
                 .border_style(Style::default().fg(t.model_sel_border))
                 .title(Span::styled(
-                    " 📦 归档列表 (Enter 还原, d 删除, Esc 返回) ",
+                    text(&msg::CHAT_ARCHIVE_LIST_KEYS),
                     Style::default()
                         .fg(t.model_sel_title)
                         .add_modifier(Modifier::BOLD),
@@ -4,6 +4,8 @@ use super::super::model::agent_config_path;
 use super::super::render::{build_message_lines_incremental, char_width, display_width, wrap_text};
 use super::archive::{draw_archive_confirm, draw_archive_list};
 use super::config::draw_config_screen;
+use crate::t;
+use crate::util::i18n::{msg, text};
 use ratatui::{
     layout::{Constraint, Direction, Layout, Rect},
     style::{Modifier, Style},
@@ -74,11 +76,11 @@ pub fn draw_title_bar(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
             .active_tool_calls
             .iter()
             .find(|tc| matches!(tc.status, ToolExecStatus::Executing))
-            .map(|tc| format!(" 🔧 执行 {}...", tc.tool_name));
+            .map(|tc| t!(msg::CHAT_RUNNING_TOOL, tc.tool_name));
         if let Some(info) = tool_info {
             info
         } else {
-            " ⏳ 思考中...".to_string()
+            text(&msg::CHAT_THINKING).to_string()
         }
     } else {
         String::new()
@@ -102,7 +104,7 @@ pub fn draw_title_bar(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
         ),
         Span::styled("  │  ", Style::default().fg(t.title_separator)),
         Span::styled(
-            format!("📨 {} 条消息", msg_count),
+            t!(msg::CHAT_MESSAGE_COUNT, msg_count),
             Style::default().fg(t.title_count),
         ),
         Span::styled(
@@ -131,7 +133,7 @@ pub fn draw_messages(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp) {
         .border_type(ratatui::widgets::BorderType::Rounded)
         .border_style(Style::default().fg(t.border_message))
         .title(Span::styled(
-            " 对话记录 ",
+            text(&msg::CHAT_MESSAGES_TITLE),
             Style::default().fg(t.text_dim).add_modifier(Modifier::BOLD),
         ))
         .title_alignment(ratatui::layout::Alignment::Left)
@@ -211,6 +213,7 @@ pub fn draw_messages(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp) {
             && cache.bubble_max_width == bubble_max_width
             && cache.browse_index == current_browse_index
             && cache.tool_confirm_idx == current_tool_confirm_idx
+            && cache.confirm_input_len == app.confirm_input.len()
     } else {
         false
     };
@@ -227,6 +230,7 @@ pub fn draw_messages(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp) {
             bubble_max_width,
             browse_index: current_browse_index,
             tool_confirm_idx: current_tool_confirm_idx,
+            confirm_input_len: app.confirm_input.len(),
             lines: new_lines,
             msg_start_lines: new_msg_start_lines,
             per_msg_lines: new_per_msg,
@@ -485,7 +489,10 @@ pub fn draw_input(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
             } else {
                 Style::default().fg(t.border_input)
             })
-            .title(Span::styled(" 输入消息 ", Style::default().fg(t.text_dim)))
+            .title(Span::styled(
+                text(&msg::CHAT_INPUT_TITLE),
+                Style::default().fg(t.text_dim),
+            ))
             .style(Style::default().bg(t.bg_input)),
     );
 
@@ -529,54 +536,77 @@ pub fn draw_hint_bar(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
     let t = &app.theme;
     let hints = match app.mode {
         ChatMode::Chat => vec![
-            ("Enter", "发送"),
-            ("↑↓", "滚动"),
-            ("@", "技能"),
-            ("Ctrl+T", "切换模型"),
-            ("Ctrl+L", "归档"),
-            ("Ctrl+R", "还原"),
-            ("Ctrl+Y", "复制"),
-            ("Ctrl+B", "浏览"),
-            ("Ctrl+S", "流式切换"),
-            ("Ctrl+E", "配置"),
-            ("?/F1", "帮助"),
-            ("Esc", "退出"),
+            ("Enter", text(&msg::CHAT_KEY_SEND)),
+            ("↑↓", text(&msg::CHAT_KEY_SCROLL)),
+            ("@", text(&msg::CHAT_KEY_SKILL)),
+            ("Ctrl+T", text(&msg::CHAT_KEY_SWITCH_MODEL)),
+            ("Ctrl+L", text(&msg::CHAT_KEY_ARCHIVE)),
+            ("Ctrl+R", text(&msg::CHAT_KEY_RESTORE)),
+            ("Ctrl+Y", text(&msg::CHAT_KEY_COPY)),
+            ("Ctrl+B", text(&msg::CHAT_KEY_BROWSE)),
+            ("Ctrl+S", text(&msg::CHAT_KEY_STREAM)),
+            ("Ctrl+E", text(&msg::CHAT_KEY_CONFIG)),
+            ("?/F1", text(&msg::CHAT_KEY_HELP)),
+            ("Esc", text(&msg::CHAT_KEY_QUIT)),
+        ],
+        ChatMode::SelectModel => vec![
+            ("↑↓/jk", text(&msg::CHAT_KEY_MOVE)),
+            ("Enter", text(&msg::CHAT_KEY_CONFIRM)),
+            ("Esc", text(&msg::CHAT_KEY_CANCEL)),
         ],
-        ChatMode::SelectModel => vec![("↑↓/jk", "移动"), ("Enter", "确认"), ("Esc", "取消")],
-        ChatMode::Browse => vec![("↑↓", "选择消息"), ("y/Enter", "复制"), ("Esc", "返回")],
-        ChatMode::Help => vec![("任意键", "返回")],
+        ChatMode::Browse => vec![
+            ("↑↓", text(&msg::CHAT_KEY_SELECT_MESSAGE)),
+            ("y/Enter", text(&msg::CHAT_KEY_COPY)),
+            ("Esc", text(&msg::CHAT_KEY_BACK)),
+        ],
+        ChatMode::Help => vec![(text(&msg::CHAT_KEY_ANY), text(&msg::CHAT_KEY_BACK))],
         ChatMode::Config => vec![
-            ("↑↓", "切换字段"),
-            ("Enter", "编辑"),
-            ("Tab", "切换 Provider"),
-            ("a", "新增"),
-            ("d", "删除"),
-            ("Esc", "保存返回"),
+            ("↑↓", text(&msg::CHAT_KEY_SWITCH_FIELD)),
+            ("Enter", text(&msg::CHAT_KEY_EDIT)),
+            ("Tab", text(&msg::CHAT_KEY_SWITCH_PROVIDER)),
+            ("a", text(&msg::CHAT_KEY_ADD)),
+            ("d", text(&msg::CHAT_KEY_DELETE)),
+            ("Esc", text(&msg::CHAT_KEY_SAVE_BACK)),
         ],
         ChatMode::ArchiveConfirm => {
             if app.archive_editing_name {
-                vec![("Enter", "确认"), ("Esc", "取消")]
+                vec![
+                    ("Enter", text(&msg::CHAT_KEY_CONFIRM)),
+                    ("Esc", text(&msg::CHAT_KEY_CANCEL)),
+                ]
             } else {
                 vec![
-                    ("Enter", "默认名称归档"),
-                    ("n", "自定义名称"),
-                    ("Esc", "取消"),
+                    ("Enter", text(&msg::CHAT_KEY_ARCHIVE_DEFAULT)),
+                    ("n", text(&msg::CHAT_KEY_CUSTOM_NAME)),
+                    ("Esc", text(&msg::CHAT_KEY_CANCEL)),
                 ]
             }
         }
         ChatMode::ArchiveList => {
             if app.restore_confirm_needed {
-                vec![("y/Enter", "确认还原"), ("Esc", "取消")]
+                vec![
+                    ("y/Enter", text(&msg::CHAT_KEY_CONFIRM_RESTORE)),
+                    ("Esc", text(&msg::CHAT_KEY_CANCEL)),
+                ]
             } else {
                 vec![
-                    ("↑↓/jk", "选择"),
-                    ("Enter", "还原"),
-                    ("d", "删除"),
-                    ("Esc", "返回"),
+                    ("↑↓/jk", text(&msg::CHAT_KEY_SELECT)),
+                    ("Enter", text(&msg::CHAT_KEY_RESTORE)),
+                    ("d", text(&msg::CHAT_KEY_DELETE)),
+                    ("Esc", text(&msg::CHAT_KEY_BACK)),
                 ]
             }
         }
-        ChatMode::ToolConfirm => vec![("Y", "执行工具"), ("N/Esc", "拒绝")],
+        ChatMode::ToolConfirm if app.pending_high_risk() => {
+            vec![
+                ("yes + Enter", text(&msg::CHAT_KEY_RUN_TOOL)),
+                ("Esc", text(&msg::CHAT_KEY_REJECT)),
+            ]
+        }
+        ChatMode::ToolConfirm => vec![
+            ("Y", text(&msg::CHAT_KEY_RUN_TOOL)),
+            ("N/Esc", text(&msg::CHAT_KEY_REJECT)),
+        ],
     };
 
     let mut spans: Vec<Span> = Vec::new();
@@ -676,7 +706,7 @@ pub fn draw_model_selector(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp
                 .border_type(ratatui::widgets::BorderType::Rounded)
                 .border_style(Style::default().fg(t.model_sel_border))
                 .title(Span::styled(
-                    " 🔄 选择模型 ",
+                    text(&msg::CHAT_SELECT_MODEL_TITLE),
                     Style::default()
                         .fg(t.model_sel_title)
                         .add_modifier(Modifier::BOLD),
@@ -705,7 +735,7 @@ pub fn draw_help(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
     let help_lines = vec![
         Line::from(""),
         Line::from(Span::styled(
-            "  📖 快捷键帮助",
+            text(&msg::CHAT_HELP_HEADING),
             Style::default()
                 .fg(t.help_title)
                 .add_modifier(Modifier::BOLD),
@@ -718,49 +748,64 @@ pub fn draw_help(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "  Enter        ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("发送消息", Style::default().fg(t.help_desc)),
+            Span::styled(text(&msg::CHAT_HELP_SEND), Style::default().fg(t.help_desc)),
         ]),
         Line::from(vec![
             Span::styled(
                 "  ↑ / ↓        ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("滚动对话记录", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_HELP_SCROLL),
+                Style::default().fg(t.help_desc),
+            ),
         ]),
         Line::from(vec![
             Span::styled(
                 "  ← / →        ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("移动输入光标", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_HELP_CURSOR),
+                Style::default().fg(t.help_desc),
+            ),
         ]),
         Line::from(vec![
             Span::styled(
                 "  Ctrl+T       ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("切换模型", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_KEY_SWITCH_MODEL),
+                Style::default().fg(t.help_desc),
+            ),
         ]),
         Line::from(vec![
             Span::styled(
                 "  Ctrl+L       ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("归档当前对话", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_HELP_ARCHIVE),
+                Style::default().fg(t.help_desc),
+            ),
         ]),
         Line::from(vec![
             Span::styled(
                 "  Ctrl+R       ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("还原归档对话", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_HELP_RESTORE),
+                Style::default().fg(t.help_desc),
+            ),
         ]),
         Line::from(vec![
             Span::styled(
                 "  Ctrl+Y       ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("复制最后一条 AI 回复", Style::default().fg(t.help_desc)),
+            Span::styled(text(&msg::CHAT_HELP_COPY), Style::default().fg(t.help_desc)),
         ]),
         Line::from(vec![
             Span::styled(
@@ -768,7 +813,7 @@ pub fn draw_help(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
             Span::styled(
-                "浏览消息 (↑↓选择, y/Enter复制)",
+                text(&msg::CHAT_HELP_BROWSE),
                 Style::default().fg(t.help_desc),
             ),
         ]),
@@ -777,34 +822,43 @@ pub fn draw_help(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
                 "  Ctrl+S       ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("切换流式/整体输出", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_HELP_STREAM),
+                Style::default().fg(t.help_desc),
+            ),
         ]),
         Line::from(vec![
             Span::styled(
                 "  Ctrl+E       ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("打开配置界面", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_HELP_CONFIG),
+                Style::default().fg(t.help_desc),
+            ),
         ]),
         Line::from(vec![
             Span::styled(
                 "  Esc / Ctrl+C ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("退出对话", Style::default().fg(t.help_desc)),
+            Span::styled(text(&msg::CHAT_HELP_QUIT), Style::default().fg(t.help_desc)),
         ]),
         Line::from(vec![
             Span::styled(
                 "  ? / F1       ",
                 Style::default().fg(t.help_key).add_modifier(Modifier::BOLD),
             ),
-            Span::styled("显示 / 关闭此帮助", Style::default().fg(t.help_desc)),
+            Span::styled(
+                text(&msg::CHAT_HELP_TOGGLE),
+                Style::default().fg(t.help_desc),
+            ),
         ]),
         Line::from(""),
         separator,
         Line::from(""),
         Line::from(Span::styled(
-            "  📁 配置文件:",
+            text(&msg::CHAT_HELP_CONFIG_FILE),
             Style::default()
                 .fg(t.help_title)
                 .add_modifier(Modifier::BOLD),
@@ -820,7 +874,7 @@ pub fn draw_help(f: &mut ratatui::Frame, area: Rect, app: &ChatApp) {
         .border_type(ratatui::widgets::BorderType::Rounded)
         .border_style(Style::default().fg(t.border_title))
         .title(Span::styled(
-            " 帮助 (按任意键返回) ",
+            text(&msg::CHAT_HELP_TITLE),
             Style::default().fg(t.text_dim),
         ))
         .style(Style::default().bg(t.help_bg));
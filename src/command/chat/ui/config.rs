@@ -1,6 +1,7 @@
 use super::super::handler::{config_field_label, config_field_value};
 use crate::command::chat::app::ChatApp;
 use crate::constants::{CONFIG_FIELDS, CONFIG_GLOBAL_FIELDS};
+use crate::util::i18n::{msg, text};
 use ratatui::{
     layout::Rect,
     style::{Modifier, Style},
@@ -17,7 +18,7 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
     lines.push(Line::from(""));
 
     lines.push(Line::from(vec![Span::styled(
-        "  ⚙️  模型配置",
+        text(&msg::CHAT_CONFIG_HEADING),
         Style::default()
             .fg(t.config_title)
             .add_modifier(Modifier::BOLD),
@@ -51,13 +52,13 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
             }
         }
         tab_spans.push(Span::styled(
-            "    (● = 活跃模型, Tab 切换, s 设为活跃)",
+            text(&msg::CHAT_CONFIG_PROVIDER_HINT),
             Style::default().fg(t.config_dim),
         ));
         lines.push(Line::from(tab_spans));
     } else {
         lines.push(Line::from(Span::styled(
-            "  (无 Provider，按 a 新增)",
+            text(&msg::CHAT_CONFIG_NO_PROVIDER),
             Style::default().fg(t.config_toggle_off),
         )));
     }
@@ -71,7 +72,7 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
 
     if provider_count > 0 {
         lines.push(Line::from(Span::styled(
-            "  📦 Provider 配置",
+            text(&msg::CHAT_CONFIG_PROVIDER_HEADING),
             Style::default()
                 .fg(t.config_section)
                 .add_modifier(Modifier::BOLD),
@@ -145,7 +146,7 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                     Span::styled("  ", Style::default()),
                     Span::styled(
                         if value.is_empty() {
-                            "(空)".to_string()
+                            text(&msg::CHAT_CONFIG_EMPTY).to_string()
                         } else {
                             value
                         },
@@ -159,7 +160,7 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
     lines.push(Line::from(""));
 
     lines.push(Line::from(Span::styled(
-        "  🌐 全局配置",
+        text(&msg::CHAT_CONFIG_GLOBAL_HEADING),
         Style::default()
             .fg(t.config_section)
             .add_modifier(Modifier::BOLD),
@@ -207,9 +208,9 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                 Style::default().fg(t.config_toggle_off)
             };
             let toggle_text = if toggle_on {
-                "● 开启"
+                text(&msg::CHAT_CONFIG_ON)
             } else {
-                "○ 关闭"
+                text(&msg::CHAT_CONFIG_OFF)
             };
             lines.push(Line::from(vec![
                 Span::styled(pointer, pointer_style),
@@ -217,7 +218,11 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                 Span::styled("  ", Style::default()),
                 Span::styled(toggle_text, toggle_style),
                 Span::styled(
-                    if is_selected { "  (Enter 切换)" } else { "" },
+                    if is_selected {
+                        text(&msg::CHAT_CONFIG_ENTER_TOGGLE)
+                    } else {
+                        ""
+                    },
                     Style::default().fg(t.config_dim),
                 ),
             ]));
@@ -234,7 +239,11 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                         .add_modifier(Modifier::BOLD),
                 ),
                 Span::styled(
-                    if is_selected { "  (Enter 切换)" } else { "" },
+                    if is_selected {
+                        text(&msg::CHAT_CONFIG_ENTER_TOGGLE)
+                    } else {
+                        ""
+                    },
                     Style::default().fg(t.config_dim),
                 ),
             ]));
@@ -248,9 +257,9 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                 Style::default().fg(t.config_toggle_off)
             };
             let toggle_text = if toggle_on {
-                "● 开启"
+                text(&msg::CHAT_CONFIG_ON)
             } else {
-                "○ 关闭"
+                text(&msg::CHAT_CONFIG_OFF)
             };
             lines.push(Line::from(vec![
                 Span::styled(pointer, pointer_style),
@@ -258,14 +267,18 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                 Span::styled("  ", Style::default()),
                 Span::styled(toggle_text, toggle_style),
                 Span::styled(
-                    if is_selected { "  (Enter 切换)" } else { "" },
+                    if is_selected {
+                        text(&msg::CHAT_CONFIG_ENTER_TOGGLE)
+                    } else {
+                        ""
+                    },
                     Style::default().fg(t.config_dim),
                 ),
             ]));
         } else if CONFIG_GLOBAL_FIELDS[i] == "system_prompt" {
             // system_prompt 特殊处理：截断显示 + Enter 弹出全屏编辑器
             let display_value = if value.is_empty() {
-                "(空)".to_string()
+                text(&msg::CHAT_CONFIG_EMPTY).to_string()
             } else {
                 // 截断到 40 个字符，替换换行为空格
                 let flat: String = value
@@ -285,14 +298,18 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                 Span::styled("  ", Style::default()),
                 Span::styled(display_value, value_style),
                 Span::styled(
-                    if is_selected { "  (Enter 编辑)" } else { "" },
+                    if is_selected {
+                        text(&msg::CHAT_CONFIG_ENTER_EDIT)
+                    } else {
+                        ""
+                    },
                     Style::default().fg(t.config_dim),
                 ),
             ]));
         } else if CONFIG_GLOBAL_FIELDS[i] == "style" {
             // style 特殊处理：同 system_prompt 模式
             let display_value = if value.is_empty() {
-                "(空)".to_string()
+                text(&msg::CHAT_CONFIG_EMPTY).to_string()
             } else {
                 let flat: String = value
                     .chars()
@@ -311,7 +328,11 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                 Span::styled("  ", Style::default()),
                 Span::styled(display_value, value_style),
                 Span::styled(
-                    if is_selected { "  (Enter 编辑)" } else { "" },
+                    if is_selected {
+                        text(&msg::CHAT_CONFIG_ENTER_EDIT)
+                    } else {
+                        ""
+                    },
                     Style::default().fg(t.config_dim),
                 ),
             ]));
@@ -351,7 +372,7 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                     Span::styled("  ", Style::default()),
                     Span::styled(
                         if value.is_empty() {
-                            "(空)".to_string()
+                            text(&msg::CHAT_CONFIG_EMPTY).to_string()
                         } else {
                             value
                         },
@@ -378,49 +399,70 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                 .fg(t.config_hint_key)
                 .add_modifier(Modifier::BOLD),
         ),
-        Span::styled(" 切换字段  ", Style::default().fg(t.config_hint_desc)),
+        Span::styled(
+            text(&msg::CHAT_CONFIG_KEY_FIELD),
+            Style::default().fg(t.config_hint_desc),
+        ),
         Span::styled(
             "Enter",
             Style::default()
                 .fg(t.config_hint_key)
                 .add_modifier(Modifier::BOLD),
         ),
-        Span::styled(" 编辑  ", Style::default().fg(t.config_hint_desc)),
+        Span::styled(
+            text(&msg::CHAT_CONFIG_KEY_EDIT),
+            Style::default().fg(t.config_hint_desc),
+        ),
         Span::styled(
             "Tab/←→",
             Style::default()
                 .fg(t.config_hint_key)
                 .add_modifier(Modifier::BOLD),
         ),
-        Span::styled(" 切换 Provider  ", Style::default().fg(t.config_hint_desc)),
+        Span::styled(
+            text(&msg::CHAT_CONFIG_KEY_PROVIDER),
+            Style::default().fg(t.config_hint_desc),
+        ),
         Span::styled(
             "a",
             Style::default()
                 .fg(t.config_hint_key)
                 .add_modifier(Modifier::BOLD),
         ),
-        Span::styled(" 新增  ", Style::default().fg(t.config_hint_desc)),
+        Span::styled(
+            text(&msg::CHAT_CONFIG_KEY_ADD),
+            Style::default().fg(t.config_hint_desc),
+        ),
         Span::styled(
             "d",
             Style::default()
                 .fg(t.config_hint_key)
                 .add_modifier(Modifier::BOLD),
         ),
-        Span::styled(" 删除  ", Style::default().fg(t.config_hint_desc)),
+        Span::styled(
+            text(&msg::CHAT_CONFIG_KEY_DELETE),
+            Style::default().fg(t.config_hint_desc),
+        ),
         Span::styled(
             "s",
             Style::default()
                 .fg(t.config_hint_key)
                 .add_modifier(Modifier::BOLD),
         ),
-        Span::styled(" 设为活跃  ", Style::default().fg(t.config_hint_desc)),
+        Span::styled(
+            text(&msg::CHAT_CONFIG_KEY_ACTIVATE),
+            Style::default().fg(t.config_hint_desc),
+        ),
         Span::styled(
             "Esc",
             Style::default()
                 .fg(t.config_hint_key)
                 .add_modifier(Modifier::BOLD),
         ),
-        Span::styled(" 保存返回", Style::default().fg(t.config_hint_desc)),
+        Span::styled(
+            text(&msg::CHAT_CONFIG_KEY_SAVE),
+            Style::default().fg(t.config_hint_desc),
+        ),
     ]));
 
     let content = Paragraph::new(lines)
@@ -430,7 +472,7 @@ pub fn draw_config_screen(f: &mut ratatui::Frame, area: Rect, app: &mut ChatApp)
                 .border_type(ratatui::widgets::BorderType::Rounded)
                 .border_style(Style::default().fg(t.border_config))
                 .title(Span::styled(
-                    " ⚙️  模型配置编辑 ",
+                    text(&msg::CHAT_CONFIG_TITLE),
                     Style::default()
                         .fg(t.config_label_selected)
                         .add_modifier(Modifier::BOLD),
@@ -118,8 +118,8 @@ command_handlers! {
     VersionCmd {} => |self, _config| {
         crate::command::system::handle_version();
     },
-    HelpCmd {} => |self, _config| {
-        crate::command::help::handle_help();
+    HelpCmd { topic: Option<String> } => |self, _config| {
+        crate::command::help::handle_help(self.topic.as_deref());
     },
     ExitCmd {} => |self, _config| {
         crate::command::system::handle_exit();
@@ -127,6 +127,17 @@ command_handlers! {
     CompletionCmd { shell: Option<String> } => |self, config| {
         crate::command::system::handle_completion(self.shell.as_deref(), config);
     },
+    GenCmd { target: String, out: Option<String> } => |self, _config| {
+        crate::command::man::handle_gen(&self.target, self.out.as_deref());
+    },
+    StatsCmd { args: Vec<String> } => |self, _config| {
+        crate::command::stats::handle_stats(&self.args);
+    },
+
+    // ========== profile ==========
+    ProfileCmd { action: Option<String>, name: Option<String> } => |self, _config| {
+        crate::command::profile::handle_profile(self.action.as_deref(), self.name.as_deref());
+    },
 
     // ========== 语音转文字 ==========
     VoiceCmd { action: String, copy: bool, model: Option<String> } => |self, config| {
@@ -181,9 +192,12 @@ impl SubCmd {
 
             // 系统信息
             SubCmd::Version => Box::new(VersionCmd {}),
-            SubCmd::Help => Box::new(HelpCmd {}),
+            SubCmd::Help { topic } => Box::new(HelpCmd { topic }),
             SubCmd::Exit => Box::new(ExitCmd {}),
             SubCmd::Completion { shell } => Box::new(CompletionCmd { shell }),
+            SubCmd::Gen { target, out } => Box::new(GenCmd { target, out }),
+            SubCmd::Stats { args } => Box::new(StatsCmd { args }),
+            SubCmd::Profile { action, name } => Box::new(ProfileCmd { action, name }),
 
             // 语音转文字
             SubCmd::Voice {
@@ -1,65 +1,77 @@
-use crate::assets::HELP_TEXT;
+use crate::assets::{HELP_TEXT, HELP_TEXT_EN};
 use crate::command::chat::markdown::markdown_to_lines;
 use crate::command::chat::theme::{Theme, ThemeName};
+use crate::util::i18n::{self, msg};
 use ratatui::text::Line;
 
-/// Tab 定义：名称 + 匹配的 ## 标题关键词列表
+/// Tab 定义：名称 + 匹配的 ## 标题关键词列表（按语言）
 struct TabDef {
-    name: &'static str,
-    heading_keywords: &'static [&'static str],
+    name: &'static i18n::Msg,
+    keywords_en: &'static [&'static str],
+    keywords_zh: &'static [&'static str],
 }
 
 const TAB_DEFS: &[TabDef] = &[
     TabDef {
-        name: "快速上手",
-        heading_keywords: &["快速上手"],
+        name: &msg::HELP_TAB_QUICKSTART,
+        keywords_en: &["Quick start"],
+        keywords_zh: &["快速上手"],
     },
     TabDef {
-        name: "数据目录",
-        heading_keywords: &["数据目录"],
+        name: &msg::HELP_TAB_DATA,
+        keywords_en: &["Data directory"],
+        keywords_zh: &["数据目录"],
     },
     TabDef {
-        name: "别名 & 打开",
-        heading_keywords: &["别名管理", "分类标记", "列表", "打开"],
+        name: &msg::HELP_TAB_ALIAS,
+        keywords_en: &["Alias management", "Categories", "Listing", "Opening"],
+        keywords_zh: &["别名管理", "分类标记", "列表", "打开"],
     },
     TabDef {
-        name: "日报",
-        heading_keywords: &["日报系统"],
+        name: &msg::HELP_TAB_REPORT,
+        keywords_en: &["Daily report"],
+        keywords_zh: &["日报系统"],
     },
     TabDef {
-        name: "待办",
-        heading_keywords: &["待办备忘录"],
+        name: &msg::HELP_TAB_TODO,
+        keywords_en: &["Todos"],
+        keywords_zh: &["待办备忘录"],
     },
     TabDef {
-        name: "脚本 & 计时",
-        heading_keywords: &["脚本"],
+        name: &msg::HELP_TAB_SCRIPT,
+        keywords_en: &["Scripts"],
+        keywords_zh: &["脚本"],
     },
     TabDef {
-        name: "系统 & 语音",
-        heading_keywords: &["系统设置", "语音转文字"],
+        name: &msg::HELP_TAB_SYSTEM,
+        keywords_en: &["System settings", "Speech to text"],
+        keywords_zh: &["系统设置", "语音转文字"],
     },
     TabDef {
-        name: "AI 对话",
-        heading_keywords: &["AI 对话"],
+        name: &msg::HELP_TAB_CHAT,
+        keywords_en: &["AI chat"],
+        keywords_zh: &["AI 对话"],
     },
     TabDef {
-        name: "安装 & 卸载",
-        heading_keywords: &["安装", "卸载"],
+        name: &msg::HELP_TAB_INSTALL,
+        keywords_en: &["Install", "Uninstall"],
+        keywords_zh: &["安装", "卸载"],
     },
     TabDef {
-        name: "使用技巧",
-        heading_keywords: &["使用技巧"],
+        name: &msg::HELP_TAB_TIPS,
+        keywords_en: &["Tips"],
+        keywords_zh: &["使用技巧"],
     },
 ];
 
-/// 按 `## ` 标题行将 HELP_TEXT 分割到各 Tab
+/// 按 `## ` 标题行将当前语言的帮助文档分割到各 Tab
 fn split_help_into_tabs() -> Vec<String> {
     // 先按 ## 标题切分所有 section
     let mut sections: Vec<(String, String)> = Vec::new(); // (标题行文本, 内容)
     let mut current_heading = String::new();
     let mut current_content = String::new();
 
-    for line in HELP_TEXT.lines() {
+    for line in i18n::pick(HELP_TEXT_EN, HELP_TEXT).lines() {
         if line.starts_with("## ") {
             // 保存上一个 section
             if !current_heading.is_empty() {
@@ -84,7 +96,7 @@ fn split_help_into_tabs() -> Vec<String> {
     for (heading, content) in &sections {
         let mut matched = false;
         for (tab_idx, tab_def) in TAB_DEFS.iter().enumerate() {
-            for kw in tab_def.heading_keywords {
+            for kw in i18n::pick(tab_def.keywords_en, tab_def.keywords_zh) {
                 if heading.contains(kw) {
                     if !tab_contents[tab_idx].is_empty() {
                         tab_contents[tab_idx].push_str("\n---\n\n");
@@ -126,7 +138,7 @@ impl HelpApp {
     pub fn new() -> Self {
         let tab_raw_contents = split_help_into_tabs();
         let count = TAB_DEFS.len();
-        let tab_names: Vec<&'static str> = TAB_DEFS.iter().map(|t| t.name).collect();
+        let tab_names: Vec<&'static str> = TAB_DEFS.iter().map(|t| i18n::text(t.name)).collect();
         Self {
             active_tab: 0,
             tab_count: count,
@@ -160,7 +172,7 @@ impl HelpApp {
         if need_rebuild {
             let md_text = &self.tab_raw_contents[idx];
             let lines = if md_text.trim().is_empty() {
-                vec![Line::from("  (暂无内容)")]
+                vec![Line::from(i18n::text(&msg::HELP_EMPTY_TAB))]
             } else {
                 markdown_to_lines(md_text, content_width, &self.theme)
             };
@@ -8,20 +8,58 @@ use crossterm::{
 };
 use ratatui::{Terminal, backend::CrosstermBackend};
 use std::io;
+use std::path::Path;
+use std::process::Command;
 
+use crate::t;
+use crate::util::i18n::msg;
 use app::HelpApp;
 use ui::draw_help_ui;
 
-/// 处理 help 命令：启动 TUI 帮助界面
-pub fn handle_help() {
+/// 插件输出内嵌帮助文档（Markdown）的参数
+const HELP_MARKDOWN_FLAG: &str = "--help-markdown";
+
+/// 处理 help 命令：不给 topic 时启动 TUI 帮助界面，否则渲染插件的帮助页
+pub fn handle_help(topic: Option<&str>) {
+    if let Some(topic) = topic {
+        match plugin_help(topic) {
+            Some(doc) => crate::util::md_render::render_md(&doc),
+            None => crate::error!("{}", t!(msg::HELP_PLUGIN_NOT_FOUND, topic)),
+        }
+        return;
+    }
     match run_help_tui() {
         Ok(_) => {}
         Err(e) => {
-            eprintln!("TUI 启动失败: {}", e);
+            crate::error!("{}", t!(msg::HELP_TUI_FAILED, e));
         }
     }
 }
 
+/// 插件的帮助文档：~/.jdata/bin/<topic> 输出的；没有该插件时交给 agent（topic 为其命令名），
+/// 都失败时返回 None
+fn plugin_help(topic: &str) -> Option<String> {
+    let bin_dir = crate::config::profile::root_dir().join(crate::constants::BIN_DIR);
+    let plugin = bin_dir.join(topic);
+    if plugin.is_file() && !topic.contains(['/', '\\']) {
+        plugin_markdown(&plugin, &[])
+    } else {
+        plugin_markdown(&bin_dir.join("agent"), &[topic])
+    }
+}
+
+/// 以 --help-markdown（其后跟 args）启动插件 bin，返回它输出的帮助文档（Markdown）；
+/// 插件不存在、失败或不认识该参数（旧版插件）时返回 None
+pub fn plugin_markdown(bin: &Path, args: &[&str]) -> Option<String> {
+    let output = Command::new(bin)
+        .arg(HELP_MARKDOWN_FLAG)
+        .args(args)
+        .output()
+        .ok()?;
+    let doc = String::from_utf8_lossy(&output.stdout).into_owned();
+    (output.status.success() && !doc.trim().is_empty()).then_some(doc)
+}
+
 fn run_help_tui() -> io::Result<()> {
     terminal::enable_raw_mode()?;
     let mut stdout = io::stdout();
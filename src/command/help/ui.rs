@@ -1,5 +1,6 @@
 use super::app::HelpApp;
 use crate::command::chat::render::display_width;
+use crate::util::i18n::{msg, text};
 use ratatui::{
     Frame,
     layout::{Constraint, Direction, Layout, Rect},
@@ -168,11 +169,11 @@ fn draw_content(
 /// 绘制底部提示栏
 fn draw_hint_bar(f: &mut Frame, area: Rect, theme: &crate::command::chat::theme::Theme) {
     let hints: &[(&str, &str)] = &[
-        ("←→", "切换"),
-        ("1-0", "跳转"),
-        ("↑↓", "滚动"),
-        ("PgUp/Dn", "翻页"),
-        ("q", "退出"),
+        ("←→", text(&msg::HELP_HINT_SWITCH)),
+        ("1-0", text(&msg::HELP_HINT_JUMP)),
+        ("↑↓", text(&msg::HELP_HINT_SCROLL)),
+        ("PgUp/Dn", text(&msg::HELP_HINT_PAGE)),
+        ("q", text(&msg::HELP_HINT_QUIT)),
     ];
 
     let mut spans: Vec<Span> = Vec::new();
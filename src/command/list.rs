@@ -1,7 +1,8 @@
 use crate::config::YamlConfig;
 use crate::constants::DEFAULT_DISPLAY_SECTIONS;
-use crate::md;
+use crate::util::i18n::msg;
 use crate::util::log::capitalize_first_letter;
+use crate::{md, t};
 
 /// 处理 list 命令: j ls [part]
 pub fn handle_list(part: Option<&str>, config: &YamlConfig) {
@@ -25,7 +26,7 @@ pub fn handle_list(part: Option<&str>, config: &YamlConfig) {
     }
 
     if md_text.is_empty() {
-        crate::info!("无可展示的内容");
+        crate::info!("{}", t!(msg::LIST_EMPTY));
     } else {
         md!("{}", md_text);
     }
@@ -55,6 +56,6 @@ fn build_section_md(section: &str, config: &YamlConfig, md_text: &mut String) {
         }
         md_text.push('\n');
     } else {
-        crate::error!("该 section 不存在: {}", section);
+        crate::error!("{}", t!(msg::LIST_NO_SECTION, section));
     }
 }
@@ -0,0 +1,445 @@
+//! j gen man：为核心命令与已安装的插件生成 man page（roff 格式，第 1 节）
+//!
+//! 核心的页面由 clap 的命令定义生成；插件的页面由它以 `--help-markdown` 输出的帮助文档转换而来，
+//! agent 另为每个命令生成一页 agent-<命令>.1。默认写入 ~/.jdata/man/man1，
+//! 打包时用 `--out` 指向 `$PREFIX/share/man/man1`。
+
+use crate::cli::Cli;
+use crate::command::help::plugin_markdown;
+use crate::config::profile;
+use crate::constants::{self, BIN_DIR, DATA_PATH_ENV, MAN_DIR};
+use crate::util::i18n::{self, msg, text};
+use crate::{error, info, t, usage};
+use chrono::Local;
+use clap::CommandFactory;
+use clap::builder::StyledStr;
+use pulldown_cmark::{Event, Options, Parser, Tag, TagEnd};
+use std::path::{Path, PathBuf};
+
+/// 处理 gen 命令: j gen man [-o <dir>]
+pub fn handle_gen(target: &str, out: Option<&str>) {
+    match target {
+        "man" => handle_man(out),
+        _ => usage!("j gen man [-o <dir>]"),
+    }
+}
+
+/// 生成全部 man page 并写入输出目录
+fn handle_man(out: Option<&str>) {
+    let dir = out
+        .map(PathBuf::from)
+        .unwrap_or_else(|| profile::root_dir().join(MAN_DIR).join("man1"));
+    if let Err(e) = std::fs::create_dir_all(&dir) {
+        error!("{}", t!(msg::MAN_MKDIR_FAILED, dir.display(), e));
+        return;
+    }
+    let date = Local::now().format("%Y-%m-%d").to_string();
+    let mut pages = plugin_pages(&date);
+    let see_also: Vec<String> = pages.iter().map(|(name, _)| name.clone()).collect();
+    pages.insert(0, ("j".to_string(), core_page(&date, &see_also)));
+    for (name, page) in &pages {
+        let path = dir.join(format!("{}.1", name));
+        if let Err(e) = std::fs::write(&path, page) {
+            error!("{}", t!(msg::MAN_WRITE_FAILED, path.display(), e));
+            return;
+        }
+    }
+    info!("{}", t!(msg::MAN_GENERATED, pages.len(), dir.display()));
+    if out.is_none() {
+        info!(
+            "{}",
+            t!(
+                msg::MAN_MANPATH_HINT,
+                profile::root_dir().join(MAN_DIR).display()
+            )
+        );
+    }
+}
+
+/// 页面开头：.TH 行
+fn title_header(name: &str, date: &str, manual: &str) -> String {
+    format!(
+        ".TH \"{}\" 1 \"{}\" \"j-cli {}\" \"{}\"\n",
+        name.to_uppercase(),
+        date,
+        constants::VERSION,
+        manual
+    )
+}
+
+/// 核心命令 j 的页面：由 clap 的子命令定义列出每个命令的用法、别名与参数，see_also 为插件的页面
+fn core_page(date: &str, see_also: &[String]) -> String {
+    let cli = Cli::command();
+    let mut roff = Roff::default();
+    roff.raw(&title_header("j", date, "User Commands"));
+    roff.request(".SH NAME");
+    roff.text(&format!("j - {}", text(&msg::MAN_ABOUT)));
+    roff.request(".SH SYNOPSIS");
+    roff.raw(concat!(
+        ".B j\n",
+        "[\\fB\\-\\-profile\\fR \\fIname\\fR] [\\fIcommand\\fR] [\\fIargs\\fR...]\n",
+        ".br\n",
+        ".B j\n",
+        "\\fIalias\\fR [\\fIargs\\fR...]\n",
+    ));
+    roff.request(".SH DESCRIPTION");
+    roff.text(text(&msg::MAN_DESCRIPTION));
+    roff.request(".SH COMMANDS");
+    for sub in cli.get_subcommands() {
+        roff.request(".TP");
+        roff.raw(&format!("\\fB{}\\fR", sub.get_name()));
+        for arg in sub.get_arguments() {
+            let id = arg.get_id().as_str();
+            if id == "help" || id == "version" {
+                continue;
+            }
+            let name = if arg.is_positional() {
+                format!("\\fI{}\\fR", id)
+            } else {
+                let flag = match (arg.get_short(), arg.get_long()) {
+                    (Some(s), Some(l)) => format!("\\-{} | \\-\\-{}", s, l),
+                    (Some(s), None) => format!("\\-{}", s),
+                    (None, Some(l)) => format!("\\-\\-{}", l),
+                    (None, None) => id.to_string(),
+                };
+                format!("\\fB{}\\fR", flag)
+            };
+            let repeat = if arg.is_trailing_var_arg_set() {
+                "..."
+            } else {
+                ""
+            };
+            if arg.is_required_set() {
+                roff.raw(&format!(" {}{}", name, repeat));
+            } else {
+                roff.raw(&format!(" [{}]{}", name, repeat));
+            }
+        }
+        roff.raw("\n");
+        roff.text(&help_text(sub.get_name(), None, sub.get_about()));
+        let aliases: Vec<&str> = sub.get_all_aliases().collect();
+        if !aliases.is_empty() {
+            roff.request(".br");
+            roff.text(&t!(msg::MAN_ALIASES, aliases.join(", ")));
+        }
+        let args: Vec<_> = sub
+            .get_arguments()
+            .filter(|a| a.get_help().is_some())
+            .collect();
+        if !args.is_empty() {
+            roff.request(".RS");
+            for arg in args {
+                roff.request(".TP");
+                let name = match (arg.get_short(), arg.get_long()) {
+                    (Some(s), Some(l)) => format!("\\fB\\-{}\\fR, \\fB\\-\\-{}\\fR", s, l),
+                    (None, Some(l)) => format!("\\fB\\-\\-{}\\fR", l),
+                    (Some(s), None) => format!("\\fB\\-{}\\fR", s),
+                    (None, None) => format!("\\fI{}\\fR", arg.get_id()),
+                };
+                roff.raw(&format!("{}\n", name));
+                roff.text(&help_text(
+                    sub.get_name(),
+                    Some(arg.get_id().as_str()),
+                    arg.get_help(),
+                ));
+            }
+            roff.request(".RE");
+        }
+    }
+    roff.request(".SH ENVIRONMENT");
+    roff.request(".TP");
+    roff.raw(&format!("\\fB{}\\fR\n", DATA_PATH_ENV));
+    roff.text(text(&msg::MAN_ENV_DATA_PATH));
+    roff.request(".TP");
+    roff.raw(&format!("\\fB{}\\fR\n", constants::profile::ENV));
+    roff.text(text(&msg::MAN_ENV_PROFILE));
+    roff.request(".TP");
+    roff.raw("\\fBJ_LANG\\fR\n");
+    roff.text(text(&msg::MAN_ENV_LANG));
+    roff.request(".SH FILES");
+    roff.request(".TP");
+    roff.raw(&format!(
+        "\\fI~/{}/{}\\fR\n",
+        constants::DATA_DIR,
+        constants::CONFIG_FILE
+    ));
+    roff.text(text(&msg::MAN_FILE_CONFIG));
+    roff.request(".TP");
+    roff.raw(&format!("\\fI~/{}/{}/\\fR\n", constants::DATA_DIR, BIN_DIR));
+    roff.text(text(&msg::MAN_FILE_BIN));
+    if !see_also.is_empty() {
+        roff.request(".SH SEE ALSO");
+        let refs: Vec<String> = see_also
+            .iter()
+            .map(|name| format!("\\fB{}\\fR(1)", escape(name)))
+            .collect();
+        roff.raw(&format!("{}\n", refs.join(", ")));
+    }
+    roff.finish()
+}
+
+/// clap 定义中子命令或参数（arg 为其 id）的说明；英文下换成 MAN_HELP_EN 中的译文，没有译文时保留原文
+fn help_text(sub: &str, arg: Option<&str>, help: Option<&StyledStr>) -> String {
+    let key = match arg {
+        Some(arg) => format!("{}.{}", sub, arg),
+        None => sub.to_string(),
+    };
+    let zh = help.map(|s| s.to_string()).unwrap_or_default();
+    match msg::MAN_HELP_EN.iter().find(|(k, _)| *k == key) {
+        Some((_, en)) => i18n::pick(en.to_string(), zh),
+        None => zh,
+    }
+}
+
+/// 已安装插件的页面：~/.jdata/bin 中能以 --help-markdown 输出帮助文档的插件各一页，
+/// agent 另按总览中的命令表为每个命令生成 agent-<命令>
+fn plugin_pages(date: &str) -> Vec<(String, String)> {
+    let bin_dir = profile::root_dir().join(BIN_DIR);
+    let mut names: Vec<String> = std::fs::read_dir(&bin_dir)
+        .map(|entries| {
+            entries
+                .flatten()
+                .filter(|e| is_executable(&e.path()))
+                .filter_map(|e| e.file_name().into_string().ok())
+                .collect()
+        })
+        .unwrap_or_default();
+    names.sort();
+    let mut pages = Vec::new();
+    for name in names {
+        let bin = bin_dir.join(&name);
+        let Some(doc) = plugin_markdown(&bin, &[]) else {
+            continue;
+        };
+        pages.push((name.clone(), markdown_page(&name, &doc, date)));
+        if name != "agent" {
+            continue;
+        }
+        for command in agent_commands(&doc) {
+            if let Some(doc) = plugin_markdown(&bin, &[&command]) {
+                let page = format!("agent-{}", command);
+                pages.push((page.clone(), markdown_page(&page, &doc, date)));
+            }
+        }
+    }
+    pages
+}
+
+/// 是否为可执行文件（非 unix 平台只要求是文件）
+fn is_executable(path: &Path) -> bool {
+    let Ok(meta) = path.metadata() else {
+        return false;
+    };
+    #[cfg(unix)]
+    {
+        use std::os::unix::fs::PermissionsExt;
+        meta.is_file() && meta.permissions().mode() & 0o111 != 0
+    }
+    #[cfg(not(unix))]
+    {
+        meta.is_file()
+    }
+}
+
+/// agent 总览中命令表（| `命令` | 说明 |）列出的命令
+fn agent_commands(doc: &str) -> Vec<String> {
+    doc.lines()
+        .filter_map(|line| line.strip_prefix("| `"))
+        .filter_map(|rest| rest.split_once('`'))
+        .map(|(name, _)| name.to_string())
+        .filter(|name| name != "help")
+        .collect()
+}
+
+/// 把插件的帮助文档转换为 man page：一级标题之后的第一段作为 NAME 中的一句话介绍，
+/// 二级标题为 .SH，更深的标题为 .SS，第一个二级标题之前的内容归入 DESCRIPTION
+fn markdown_page(name: &str, doc: &str, date: &str) -> String {
+    let (summary, body) = split_summary(doc);
+    let mut roff = Roff::default();
+    roff.raw(&title_header(name, date, "j plugins"));
+    roff.request(".SH NAME");
+    roff.text(&format!("{} - {}", name, summary));
+    markdown_to_roff(&mut roff, body);
+    roff.finish()
+}
+
+/// 去掉文档开头的一级标题，取其后第一段的第一句作为一句话介绍，返回介绍与余下的正文；
+/// 该段不止一句时整段留在正文中
+fn split_summary(doc: &str) -> (String, &str) {
+    let mut rest = doc.trim_start();
+    if rest.starts_with("# ") {
+        rest = rest.split_once('\n').map_or("", |(_, r)| r).trim_start();
+    }
+    if rest.starts_with('#') || rest.starts_with("```") {
+        return (String::new(), rest);
+    }
+    let (para, body) = rest.split_once("\n\n").unwrap_or((rest, ""));
+    let para = para.split_whitespace().collect::<Vec<_>>().join(" ");
+    let sentence = para
+        .split_once('。')
+        .or_else(|| para.split_once(". "))
+        .map_or(para.as_str(), |(first, _)| first);
+    let summary = strip_inline(sentence.trim_end_matches(['。', '.']));
+    if summary.len() + 3 < para.len() {
+        return (summary, rest);
+    }
+    (summary, body)
+}
+
+/// 去掉一句话介绍中的行内 Markdown 标记
+fn strip_inline(s: &str) -> String {
+    s.replace(['`', '*'], "")
+}
+
+/// 把 Markdown 正文逐个事件写成 roff：代码块不换行不填充，列表为 .IP，表格每行为一个 .TP
+/// （第一列作标签，其余非空的列以 " — " 连接），HTML 注释等丢弃
+fn markdown_to_roff(roff: &mut Roff, md: &str) {
+    let mut section = false;
+    let mut in_table_head = false;
+    let mut cell = 0;
+    // 表格中第三列起的分隔符，等到该列有内容时才写出
+    let mut sep = false;
+    let mut items: Vec<Option<u64>> = Vec::new();
+    let mut heading = String::new();
+    let mut in_heading = false;
+    for event in Parser::new_ext(md, Options::ENABLE_TABLES) {
+        if !section
+            && !in_heading
+            && matches!(event, Event::Start(ref tag) if !matches!(tag, Tag::Heading { .. }))
+        {
+            roff.request(".SH DESCRIPTION");
+            section = true;
+        }
+        match event {
+            Event::Start(Tag::Heading { .. }) => {
+                in_heading = true;
+                heading.clear();
+            }
+            Event::End(TagEnd::Heading(level)) => {
+                in_heading = false;
+                let macro_name = if level as usize <= 2 { ".SH" } else { ".SS" };
+                roff.request(&format!(
+                    "{} \"{}\"",
+                    macro_name,
+                    escape(&heading).replace('"', "\\(dq")
+                ));
+                section = true;
+            }
+            Event::Text(text) | Event::Code(text) if in_heading => heading.push_str(&text),
+            Event::Start(Tag::Paragraph) => {
+                if items.is_empty() {
+                    roff.request(".PP");
+                }
+            }
+            Event::End(TagEnd::Paragraph) => roff.newline(),
+            Event::Start(Tag::CodeBlock(_)) => {
+                roff.request(".PP");
+                roff.request(".RS 4");
+                roff.request(".nf");
+            }
+            Event::End(TagEnd::CodeBlock) => {
+                roff.request(".fi");
+                roff.request(".RE");
+            }
+            Event::Start(Tag::List(start)) => items.push(start),
+            Event::End(TagEnd::List(_)) => {
+                items.pop();
+                roff.request(".PP");
+            }
+            Event::Start(Tag::Item) => match items.last_mut() {
+                Some(Some(n)) => {
+                    roff.request(&format!(".IP {}. 4", n));
+                    *n += 1;
+                }
+                _ => roff.request(".IP \\(bu 2"),
+            },
+            Event::End(TagEnd::Item) => roff.newline(),
+            Event::Start(Tag::TableHead) => in_table_head = true,
+            Event::End(TagEnd::TableHead) => in_table_head = false,
+            Event::Start(Tag::TableRow) => {
+                cell = 0;
+                sep = false;
+            }
+            Event::End(TagEnd::TableRow) => roff.newline(),
+            Event::Start(Tag::TableCell) if !in_table_head => {
+                match cell {
+                    0 => roff.request(".TP"),
+                    1 => roff.newline(),
+                    _ => sep = true,
+                }
+                cell += 1;
+            }
+            Event::Start(Tag::Strong) => roff.raw("\\fB"),
+            Event::Start(Tag::Emphasis) => roff.raw("\\fI"),
+            Event::End(TagEnd::Strong) | Event::End(TagEnd::Emphasis) => roff.raw("\\fR"),
+            Event::Code(code) if !in_table_head => {
+                if std::mem::take(&mut sep) {
+                    roff.raw(" \\(em ");
+                }
+                roff.raw(&format!("\\fB{}\\fR", escape(&code)));
+            }
+            Event::Text(text) if !in_table_head => {
+                if std::mem::take(&mut sep) {
+                    roff.raw(" \\(em ");
+                }
+                roff.text(&text);
+            }
+            Event::SoftBreak => roff.raw(" "),
+            Event::HardBreak => roff.request(".br"),
+            Event::Rule => roff.request(".PP"),
+            _ => {}
+        }
+    }
+}
+
+/// roff 文本的转义：反斜杠与减号
+fn escape(s: &str) -> String {
+    s.replace('\\', "\\e").replace('-', "\\-")
+}
+
+/// roff 输出：请求（以 . 开头的行）总在行首，文本中行首的 . 与 ' 用 \& 保护，避免被当作请求
+#[derive(Default)]
+struct Roff {
+    out: String,
+}
+
+impl Roff {
+    /// 另起一行写入请求
+    fn request(&mut self, line: &str) {
+        self.newline();
+        self.out.push_str(line);
+        self.out.push('\n');
+    }
+
+    /// 写入已转义的 roff 片段
+    fn raw(&mut self, s: &str) {
+        self.out.push_str(s);
+    }
+
+    /// 写入普通文本（转义后），其中的换行保留（代码块）
+    fn text(&mut self, s: &str) {
+        for (i, line) in s.split('\n').enumerate() {
+            if i > 0 {
+                self.out.push('\n');
+            }
+            if (self.out.is_empty() || self.out.ends_with('\n'))
+                && (line.starts_with('.') || line.starts_with('\''))
+            {
+                self.out.push_str("\\&");
+            }
+            self.out.push_str(&escape(line));
+        }
+    }
+
+    /// 结束当前行（已在行首时不做任何事）
+    fn newline(&mut self) {
+        if !self.out.is_empty() && !self.out.ends_with('\n') {
+            self.out.push('\n');
+        }
+    }
+
+    fn finish(mut self) -> String {
+        self.newline();
+        self.out
+    }
+}
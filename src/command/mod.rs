@@ -4,9 +4,13 @@ pub mod chat;
 pub mod handler;
 pub mod help;
 pub mod list;
+pub mod man;
 pub mod open;
+pub mod profile;
 pub mod report;
 pub mod script;
+pub mod stats;
+pub mod suggest;
 pub mod system;
 pub mod time;
 pub mod todo;
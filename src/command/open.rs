@@ -1,6 +1,7 @@
 use crate::config::YamlConfig;
 use crate::constants::{DEFAULT_SEARCH_ENGINE, config_key, search_engine, section, shell};
-use crate::{error, info};
+use crate::util::i18n::msg;
+use crate::{error, info, t};
 use std::path::Path;
 use std::process::Command;
 
@@ -8,7 +9,7 @@ use std::process::Command;
 /// args[0] = alias, args[1..] = 额外参数
 pub fn handle_open(args: &[String], config: &YamlConfig) {
     if args.is_empty() {
-        error!("❌ 请指定要打开的别名");
+        error!("{}", t!(msg::OPEN_ALIAS_REQUIRED));
         return;
     }
 
@@ -16,10 +17,7 @@ pub fn handle_open(args: &[String], config: &YamlConfig) {
 
     // 检查别名是否存在
     if !config.alias_exists(alias) {
-        error!(
-            "❌ 无法找到别名对应的路径或网址 {{{}}}。请检查配置文件。",
-            alias
-        );
+        error!("{}", t!(msg::OPEN_ALIAS_UNKNOWN, alias));
         return;
     }
 
@@ -122,10 +120,10 @@ fn run_script(args: &[String], config: &YamlConfig) {
         let script_arg_refs: Vec<&str> = script_args.iter().map(|s| s.as_str()).collect();
 
         if new_window {
-            info!("⚙️ 即将在新窗口执行脚本，路径: {}", script_path);
+            info!("{}", t!(msg::OPEN_SCRIPT_NEW_WINDOW, script_path));
             run_script_in_new_window(&script_path, &script_arg_refs, config);
         } else {
-            info!("⚙️ 即将执行脚本，路径: {}", script_path);
+            info!("{}", t!(msg::OPEN_SCRIPT_RUNNING, script_path));
             run_script_in_current_terminal(&script_path, &script_arg_refs, config);
         }
     }
@@ -156,12 +154,14 @@ fn run_script_in_current_terminal(script_path: &str, script_args: &[&str], confi
     match result {
         Ok(status) => {
             if status.success() {
-                info!("✅ 脚本执行完成");
+                info!("{}", t!(msg::OPEN_SCRIPT_DONE));
             } else {
-                error!("❌ 脚本执行失败，退出码: {}", status);
+                // 沿用脚本的退出码，便于调用方区分失败原因
+                crate::util::log::set_exit_code(status.code().unwrap_or(1));
+                error!("{}", t!(msg::OPEN_SCRIPT_FAILED, status));
             }
         }
-        Err(e) => error!("💥 执行脚本失败: {}", e),
+        Err(e) => error!("{}", t!(msg::OPEN_SCRIPT_ERROR, e)),
     }
 }
 
@@ -211,12 +211,12 @@ fn run_script_in_new_window(script_path: &str, script_args: &[&str], config: &Ya
         match result {
             Ok(status) => {
                 if status.success() {
-                    info!("✅ 已在新终端窗口中启动脚本");
+                    info!("{}", t!(msg::OPEN_SCRIPT_STARTED));
                 } else {
-                    error!("❌ 启动新终端窗口失败，退出码: {}", status);
+                    error!("{}", t!(msg::OPEN_WINDOW_FAILED, status));
                 }
             }
-            Err(e) => error!("💥 调用 osascript 失败: {}", e),
+            Err(e) => error!("{}", t!(msg::OPEN_OSASCRIPT_ERROR, e)),
         }
     } else if os == shell::WINDOWS_OS {
         // Windows: 使用 start cmd /c 在新窗口执行
@@ -240,12 +240,12 @@ fn run_script_in_new_window(script_path: &str, script_args: &[&str], config: &Ya
         match result {
             Ok(status) => {
                 if status.success() {
-                    info!("✅ 已在新终端窗口中启动脚本");
+                    info!("{}", t!(msg::OPEN_SCRIPT_STARTED));
                 } else {
-                    error!("❌ 启动新终端窗口失败，退出码: {}", status);
+                    error!("{}", t!(msg::OPEN_WINDOW_FAILED, status));
                 }
             }
-            Err(e) => error!("💥 启动新窗口失败: {}", e),
+            Err(e) => error!("{}", t!(msg::OPEN_WINDOW_ERROR, e)),
         }
     } else {
         // Linux: 尝试常见的终端模拟器
@@ -272,14 +272,14 @@ fn run_script_in_new_window(script_path: &str, script_args: &[&str], config: &Ya
         for (term, term_args) in &terminals {
             if let Ok(status) = Command::new(term).args(term_args).status() {
                 if status.success() {
-                    info!("✅ 已在新终端窗口中启动脚本");
+                    info!("{}", t!(msg::OPEN_SCRIPT_STARTED));
                     return;
                 }
             }
         }
 
         // 所有终端都失败，降级到当前终端执行
-        info!("⚠️ 未找到可用的终端模拟器，降级到当前终端执行");
+        info!("{}", t!(msg::OPEN_NO_TERMINAL));
         run_script_in_current_terminal(script_path, script_args, config);
     }
 }
@@ -343,10 +343,11 @@ fn open_alias_with_args(alias: &str, extra_args: &[String], config: &YamlConfig)
             match result {
                 Ok(status) => {
                     if !status.success() {
-                        error!("❌ 执行 {{{}}} 失败，退出码: {}", alias, status);
+                        crate::util::log::set_exit_code(status.code().unwrap_or(1));
+                        error!("{}", t!(msg::OPEN_RUN_FAILED, alias, status));
                     }
                 }
-                Err(e) => error!("💥 执行 {{{}}} 失败: {}", alias, e),
+                Err(e) => error!("{}", t!(msg::OPEN_RUN_ERROR, alias, e)),
             }
         } else {
             // GUI 应用或普通文件：系统 open 命令打开
@@ -369,14 +370,14 @@ fn open_alias_with_args(alias: &str, extra_args: &[String], config: &YamlConfig)
                     Command::new("xdg-open").arg(&path).status()
                 };
                 if let Err(e) = result {
-                    error!("💥 启动 {{{}}} 失败: {}", alias, e);
+                    error!("{}", t!(msg::OPEN_LAUNCH_ERROR, alias, e));
                     return;
                 }
             }
-            info!("✅ 启动 {{{}}} : {{{}}}", alias, path);
+            info!("{}", t!(msg::OPEN_LAUNCHED, alias, path));
         }
     } else {
-        error!("❌ 未找到别名对应的路径或网址: {}。请检查配置文件。", alias);
+        error!("{}", t!(msg::OPEN_ALIAS_NO_TARGET, alias));
     }
 }
 
@@ -448,19 +449,19 @@ fn open_with_path(alias: &str, file_path: Option<&str>, config: &YamlConfig) {
                     .status(),
             }
         } else {
-            error!("💥 当前操作系统不支持此功能: {}", os);
+            error!("{}", t!(msg::OPEN_UNSUPPORTED_OS, os));
             return;
         };
 
         match result {
             Ok(_) => {
                 let target = file_path.unwrap_or("");
-                info!("✅ 启动 {{{}}} {} : {{{}}}", alias, target, app_path);
+                info!("{}", t!(msg::OPEN_LAUNCHED_WITH, alias, target, app_path));
             }
-            Err(e) => error!("💥 启动 {} 失败: {}", alias, e),
+            Err(e) => error!("{}", t!(msg::OPEN_START_ERROR, alias, e)),
         }
     } else {
-        error!("❌ 未找到别名对应的路径: {}。", alias);
+        error!("{}", t!(msg::OPEN_ALIAS_NO_PATH, alias));
     }
 }
 
@@ -479,7 +480,7 @@ fn do_open(path: &str) {
     };
 
     if let Err(e) = result {
-        crate::error!("💥 打开 {} 失败: {}", path, e);
+        crate::error!("{}", t!(msg::OPEN_FAILED, path, e));
     }
 }
 
@@ -525,10 +526,7 @@ fn get_search_url(query: &str, engine: &str) -> String {
         "bing" => search_engine::BING,
         "baidu" => search_engine::BAIDU,
         _ => {
-            info!(
-                "未指定搜索引擎，使用默认搜索引擎：{}",
-                DEFAULT_SEARCH_ENGINE
-            );
+            info!("{}", t!(msg::OPEN_DEFAULT_ENGINE, DEFAULT_SEARCH_ENGINE));
             search_engine::BING
         }
     };
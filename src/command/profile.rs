@@ -0,0 +1,79 @@
+use crate::config::profile;
+use crate::constants::profile as pf;
+use crate::util::i18n::msg;
+use crate::{error, info, t, usage};
+use colored::Colorize;
+
+/// 处理 profile 命令: j profile [list|create <name>|switch <name>]
+pub fn handle_profile(action: Option<&str>, name: Option<&str>) {
+    match (action.unwrap_or(pf::ACTION_LIST), name) {
+        (pf::ACTION_LIST, _) => handle_list(),
+        (pf::ACTION_CREATE, Some(name)) => handle_create(name),
+        (pf::ACTION_SWITCH, Some(name)) => handle_switch(name),
+        _ => usage!("j profile [list | create <name> | switch <name>]"),
+    }
+}
+
+/// 列出全部 profile，标出当前使用的一个
+fn handle_list() {
+    let (current, from_env) = profile::active();
+    for name in profile::list() {
+        let path = profile::dir(&name);
+        if name == current {
+            let note = if from_env {
+                format!(" (--profile / {})", pf::ENV)
+            } else {
+                String::new()
+            };
+            info!(
+                "{} {}{}  {}",
+                "*".green(),
+                name.green().bold(),
+                note,
+                path.display().to_string().dimmed()
+            );
+        } else {
+            info!("  {}  {}", name, path.display().to_string().dimmed());
+        }
+    }
+}
+
+/// 创建新的 profile：独立的配置、agent 设置与历史，首次使用时按默认值初始化
+fn handle_create(name: &str) {
+    if !profile::is_valid_name(name) {
+        error!("{}", t!(msg::PROFILE_INVALID, name));
+        return;
+    }
+    if profile::exists(name) {
+        error!(
+            "{}",
+            t!(msg::PROFILE_EXISTS, name, profile::dir(name).display())
+        );
+        return;
+    }
+    match profile::create(name) {
+        Ok(path) => {
+            info!("{}", t!(msg::PROFILE_CREATED, name.green(), path.display()));
+            info!("{}", t!(msg::PROFILE_CREATED_HINT, name, name));
+        }
+        Err(e) => error!("{}", t!(msg::PROFILE_CREATE_FAILED, e)),
+    }
+}
+
+/// 切换之后默认使用的 profile
+fn handle_switch(name: &str) {
+    if !profile::exists(name) {
+        error!("{}", t!(msg::PROFILE_MISSING, name, name));
+        return;
+    }
+    if let Err(e) = profile::switch(name) {
+        error!("{}", t!(msg::PROFILE_SWITCH_FAILED, e));
+        return;
+    }
+    info!("{}", t!(msg::PROFILE_SWITCHED, name.green()));
+    if let Ok(env) = std::env::var(pf::ENV) {
+        if !env.trim().is_empty() && env.trim() != name {
+            info!("{}", t!(msg::PROFILE_SWITCH_PENDING, env.trim(), pf::ENV));
+        }
+    }
+}
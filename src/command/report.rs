@@ -4,7 +4,8 @@ use crate::constants::{
     search_flag, section,
 };
 use crate::util::fuzzy;
-use crate::{error, info, usage};
+use crate::util::i18n::{msg, text};
+use crate::{error, info, t, usage};
 use chrono::{Local, NaiveDate};
 use colored::Colorize;
 use std::fs;
@@ -60,14 +61,17 @@ pub fn handle_report(sub: &str, content: &[String], config: &mut YamlConfig) {
             }
             _ => {
                 error!(
-                    "❌ 未知的元数据操作: {}，可选: {}, {}, {}, {}, {}, {}",
-                    first,
-                    rmeta_action::NEW,
-                    rmeta_action::SYNC,
-                    rmeta_action::PUSH,
-                    rmeta_action::PULL,
-                    rmeta_action::SET_URL,
-                    rmeta_action::OPEN
+                    "{}",
+                    t!(
+                        msg::REPORT_UNKNOWN_ACTION,
+                        first,
+                        rmeta_action::NEW,
+                        rmeta_action::SYNC,
+                        rmeta_action::PUSH,
+                        rmeta_action::PULL,
+                        rmeta_action::SET_URL,
+                        rmeta_action::OPEN
+                    )
                 );
             }
         }
@@ -79,7 +83,7 @@ pub fn handle_report(sub: &str, content: &[String], config: &mut YamlConfig) {
     let text = text.trim().trim_matches('"').to_string();
 
     if text.is_empty() {
-        error!("⚠️ 内容为空，无法写入");
+        error!("{}", t!(msg::REPORT_EMPTY));
         return;
     }
 
@@ -98,10 +102,10 @@ fn get_report_path(config: &YamlConfig) -> Option<String> {
     // 如果文件不存在则自动创建空文件
     if !report_path.exists() {
         if let Err(e) = fs::write(&report_path, "") {
-            error!("❌ 创建日报文件失败: {}", e);
+            error!("{}", t!(msg::REPORT_CREATE_FAILED, e));
             return None;
         }
-        info!("📄 已自动创建日报文件: {:?}", report_path);
+        info!("{}", t!(msg::REPORT_CREATED, report_path.display()));
     }
 
     Some(report_path.to_string_lossy().to_string())
@@ -167,8 +171,10 @@ fn handle_report_tui(config: &mut YamlConfig) {
     initial_lines.push(date_prefix);
 
     // 打开带初始内容的编辑器（NORMAL 模式）
-    match crate::tui::editor::open_multiline_editor_with_content("📝 编辑日报", &initial_lines)
-    {
+    match crate::tui::editor::open_multiline_editor_with_content(
+        text(&msg::REPORT_EDITOR_TITLE),
+        &initial_lines,
+    ) {
         Ok(Some(text)) => {
             // 用户提交了内容
             // 计算原始上下文有多少行（用于替换）
@@ -177,15 +183,15 @@ fn handle_report_tui(config: &mut YamlConfig) {
             // 从文件中去掉最后 N 行，再写入编辑器的全部内容
             replace_last_n_lines(report_file, original_context_count, &text);
 
-            info!("✅ 日报已写入：{}", report_path);
+            info!("{}", t!(msg::REPORT_WRITTEN, report_path));
         }
         Ok(None) => {
-            info!("已取消编辑");
+            info!("{}", t!(msg::REPORT_EDIT_CANCELLED));
             // 文件未做任何修改（新周标题也没有写入）
             // 配置文件中的 week_num/last_day 可能已更新，但下次进入时 now <= last_day 不会重复生成
         }
         Err(e) => {
-            error!("❌ 编辑器启动失败: {}", e);
+            error!("{}", t!(msg::REPORT_EDITOR_FAILED, e));
         }
     }
 }
@@ -195,7 +201,7 @@ fn replace_last_n_lines(path: &Path, n: usize, new_content: &str) {
     let content = match fs::read_to_string(path) {
         Ok(c) => c,
         Err(e) => {
-            error!("❌ 读取文件失败: {}", e);
+            error!("{}", t!(msg::REPORT_READ_FILE_FAILED, e));
             return;
         }
     };
@@ -226,7 +232,7 @@ fn replace_last_n_lines(path: &Path, n: usize, new_content: &str) {
     }
 
     if let Err(e) = fs::write(path, &result) {
-        error!("❌ 写入文件失败: {}", e);
+        error!("{}", t!(msg::REPORT_WRITE_FILE_FAILED, e));
     }
 }
 
@@ -347,7 +353,7 @@ fn handle_daily_report(content: &str, config: &mut YamlConfig) {
         None => return,
     };
 
-    info!("📂 日报文件路径：{}", report_path);
+    info!("{}", t!(msg::REPORT_PATH, report_path));
 
     let report_file = Path::new(&report_path);
     let config_path = get_settings_json_path(&report_path);
@@ -384,7 +390,7 @@ fn handle_daily_report(content: &str, config: &mut YamlConfig) {
             }
         }
         None => {
-            error!("❌ 无法解析 last_day 日期: {}", last_day_str);
+            error!("{}", t!(msg::REPORT_BAD_LAST_DAY, last_day_str));
             return;
         }
     }
@@ -392,7 +398,7 @@ fn handle_daily_report(content: &str, config: &mut YamlConfig) {
     let today_str = now.format(SIMPLE_DATE_FORMAT);
     let log_entry = format!("- 【{}】 {}\n", today_str, content);
     append_to_file(report_file, &log_entry);
-    info!("✅ 成功将内容写入：{}", report_path);
+    info!("{}", t!(msg::REPORT_APPENDED, report_path));
 }
 
 /// 处理 reportctl new 命令：开启新的一周
@@ -424,10 +430,7 @@ fn handle_week_update(date_str: Option<&str>, config: &mut YamlConfig) {
             update_config_files(week_num + 1, &next_last_day, &config_path, config);
         }
         None => {
-            error!(
-                "❌ 更新周数失败，请检查日期字符串是否有误: {}",
-                last_day_str
-            );
+            error!("{}", t!(msg::REPORT_WEEK_UPDATE_FAILED, last_day_str));
         }
     }
 }
@@ -462,10 +465,7 @@ fn handle_sync(date_str: Option<&str>, config: &mut YamlConfig) {
             update_config_files(week_num, &last_day, &config_path, config);
         }
         None => {
-            error!(
-                "❌ 更新周数失败，请检查日期字符串是否有误: {}",
-                last_day_str
-            );
+            error!("{}", t!(msg::REPORT_WEEK_UPDATE_FAILED, last_day_str));
         }
     }
 }
@@ -482,10 +482,7 @@ fn update_config_files(
     // 更新 YAML 配置
     config.set_property(section::REPORT, config_key::WEEK_NUM, &week_num.to_string());
     config.set_property(section::REPORT, config_key::LAST_DAY, &last_day_str);
-    info!(
-        "✅ 更新YAML配置文件成功：周数 = {}, 周结束日期 = {}",
-        week_num, last_day_str
-    );
+    info!("{}", t!(msg::REPORT_YAML_UPDATED, week_num, last_day_str));
 
     // 更新 JSON 配置
     if config_path.exists() {
@@ -494,11 +491,8 @@ fn update_config_files(
             "last_day": last_day_str
         });
         match fs::write(config_path, json.to_string()) {
-            Ok(_) => info!(
-                "✅ 更新JSON配置文件成功：周数 = {}, 周结束日期 = {}",
-                week_num, last_day_str
-            ),
-            Err(e) => error!("❌ 更新JSON配置文件时出错: {}", e),
+            Ok(_) => info!("{}", t!(msg::REPORT_JSON_UPDATED, week_num, last_day_str)),
+            Err(e) => error!("{}", t!(msg::REPORT_JSON_UPDATE_FAILED, e)),
         }
     }
 }
@@ -506,7 +500,10 @@ fn update_config_files(
 /// 从 JSON 配置文件读取并同步到 YAML
 fn load_config_from_json_and_sync(config_path: &Path, config: &mut YamlConfig) {
     if !config_path.exists() {
-        error!("❌ 日报配置文件不存在：{:?}", config_path);
+        error!(
+            "{}",
+            t!(msg::REPORT_SETTINGS_MISSING, config_path.display())
+        );
         return;
     }
 
@@ -516,19 +513,16 @@ fn load_config_from_json_and_sync(config_path: &Path, config: &mut YamlConfig) {
                 let last_day = json.get("last_day").and_then(|v| v.as_str()).unwrap_or("");
                 let week_num = json.get("week_num").and_then(|v| v.as_i64()).unwrap_or(1);
 
-                info!(
-                    "✅ 从日报配置文件中读取到：last_day = {}, week_num = {}",
-                    last_day, week_num
-                );
+                info!("{}", t!(msg::REPORT_SETTINGS_LOADED, last_day, week_num));
 
                 if let Some(last_day_date) = parse_date(last_day) {
                     update_config_files(week_num as i32, &last_day_date, config_path, config);
                 }
             } else {
-                error!("❌ 解析日报配置文件时出错");
+                error!("{}", t!(msg::REPORT_SETTINGS_PARSE_FAILED));
             }
         }
-        Err(e) => error!("❌ 读取日报配置文件失败: {}", e),
+        Err(e) => error!("{}", t!(msg::REPORT_SETTINGS_READ_FAILED, e)),
     }
 }
 
@@ -542,10 +536,10 @@ fn append_to_file(path: &Path, content: &str) {
     match OpenOptions::new().create(true).append(true).open(path) {
         Ok(mut f) => {
             if let Err(e) = f.write_all(content.as_bytes()) {
-                error!("❌ 写入文件失败: {}", e);
+                error!("{}", t!(msg::REPORT_WRITE_FILE_FAILED, e));
             }
         }
-        Err(e) => error!("❌ 打开文件失败: {}", e),
+        Err(e) => error!("{}", t!(msg::REPORT_OPEN_FILE_FAILED, e)),
     }
 }
 
@@ -560,7 +554,7 @@ fn handle_open_report(config: &YamlConfig) {
 
     let path = Path::new(&report_path);
     if !path.is_file() {
-        error!("❌ 日报文件不存在: {}", report_path);
+        error!("{}", t!(msg::REPORT_FILE_MISSING, report_path));
         return;
     }
 
@@ -568,7 +562,7 @@ fn handle_open_report(config: &YamlConfig) {
     let content = match fs::read_to_string(path) {
         Ok(c) => c,
         Err(e) => {
-            error!("❌ 读取日报文件失败: {}", e);
+            error!("{}", t!(msg::REPORT_FILE_READ_FAILED, e));
             return;
         }
     };
@@ -576,8 +570,10 @@ fn handle_open_report(config: &YamlConfig) {
     let lines: Vec<String> = content.lines().map(|l| l.to_string()).collect();
 
     // 用 TUI 编辑器打开全文（NORMAL 模式）
-    match crate::tui::editor::open_multiline_editor_with_content("📝 编辑日报文件", &lines)
-    {
+    match crate::tui::editor::open_multiline_editor_with_content(
+        text(&msg::REPORT_FILE_EDITOR_TITLE),
+        &lines,
+    ) {
         Ok(Some(text)) => {
             // 用户提交了内容，整体回写文件
             let mut result = text;
@@ -585,16 +581,16 @@ fn handle_open_report(config: &YamlConfig) {
                 result.push('\n');
             }
             if let Err(e) = fs::write(path, &result) {
-                error!("❌ 写入日报文件失败: {}", e);
+                error!("{}", t!(msg::REPORT_FILE_WRITE_FAILED, e));
                 return;
             }
-            info!("✅ 日报文件已保存：{}", report_path);
+            info!("{}", t!(msg::REPORT_FILE_SAVED, report_path));
         }
         Ok(None) => {
-            info!("已取消编辑，文件未修改");
+            info!("{}", t!(msg::REPORT_FILE_UNCHANGED));
         }
         Err(e) => {
-            error!("❌ 编辑器启动失败: {}", e);
+            error!("{}", t!(msg::REPORT_EDITOR_FAILED, e));
         }
     }
 }
@@ -620,10 +616,10 @@ fn handle_set_url(url: Option<&str>, config: &mut YamlConfig) {
 
             match old {
                 Some(old_url) if !old_url.is_empty() => {
-                    info!("✅ git 仓库地址已更新: {} → {}", old_url, u);
+                    info!("{}", t!(msg::REPORT_URL_UPDATED, old_url, u));
                 }
                 _ => {
-                    info!("✅ git 仓库地址已设置: {}", u);
+                    info!("{}", t!(msg::REPORT_URL_SET, u));
                 }
             }
         }
@@ -631,10 +627,10 @@ fn handle_set_url(url: Option<&str>, config: &mut YamlConfig) {
             // 无参数时显示当前配置
             match config.get_property(section::REPORT, config_key::GIT_REPO) {
                 Some(url) if !url.is_empty() => {
-                    info!("📦 当前 git 仓库地址: {}", url);
+                    info!("{}", t!(msg::REPORT_URL_CURRENT, url));
                 }
                 _ => {
-                    info!("📦 尚未配置 git 仓库地址");
+                    info!("{}", t!(msg::REPORT_URL_NONE));
                     usage!("reportctl set-url <repo_url>");
                 }
             }
@@ -657,7 +653,7 @@ fn run_git_in_report_dir(args: &[&str], config: &YamlConfig) -> Option<std::proc
     let dir = match get_report_dir(config) {
         Some(d) => d,
         None => {
-            error!("❌ 无法确定日报目录");
+            error!("{}", t!(msg::REPORT_DIR_UNKNOWN));
             return None;
         }
     };
@@ -667,7 +663,7 @@ fn run_git_in_report_dir(args: &[&str], config: &YamlConfig) -> Option<std::proc
     match result {
         Ok(status) => Some(status),
         Err(e) => {
-            error!("💥 执行 git 命令失败: {}", e);
+            error!("{}", t!(msg::REPORT_GIT_FAILED, e));
             None
         }
     }
@@ -678,7 +674,7 @@ fn ensure_git_repo(config: &YamlConfig) -> bool {
     let dir = match get_report_dir(config) {
         Some(d) => d,
         None => {
-            error!("❌ 无法确定日报目录");
+            error!("{}", t!(msg::REPORT_DIR_UNKNOWN));
             return false;
         }
     };
@@ -693,17 +689,17 @@ fn ensure_git_repo(config: &YamlConfig) -> bool {
     // 检查是否有配置 git_repo
     let git_repo = config.get_property(section::REPORT, config_key::GIT_REPO);
     if git_repo.is_none() || git_repo.unwrap().is_empty() {
-        error!("❌ 尚未配置 git 仓库地址，请先执行: j reportctl set-url <repo_url>");
+        error!("{}", t!(msg::REPORT_URL_REQUIRED));
         return false;
     }
     let repo_url = git_repo.unwrap().clone();
 
-    info!("📦 日报目录尚未初始化 git 仓库，正在初始化...");
+    info!("{}", t!(msg::REPORT_GIT_INITIALIZING));
 
     // git init -b main
     if let Some(status) = run_git_in_report_dir(&["init", "-b", "main"], config) {
         if !status.success() {
-            error!("❌ git init 失败");
+            error!("{}", t!(msg::REPORT_GIT_INIT_FAILED));
             return false;
         }
     } else {
@@ -713,14 +709,14 @@ fn ensure_git_repo(config: &YamlConfig) -> bool {
     // git remote add origin <repo_url>
     if let Some(status) = run_git_in_report_dir(&["remote", "add", "origin", &repo_url], config) {
         if !status.success() {
-            error!("❌ git remote add 失败");
+            error!("{}", t!(msg::REPORT_GIT_REMOTE_FAILED));
             return false;
         }
     } else {
         return false;
     }
 
-    info!("✅ git 仓库初始化完成，remote: {}", repo_url);
+    info!("{}", t!(msg::REPORT_GIT_INITIALIZED, repo_url));
     true
 }
 
@@ -748,7 +744,7 @@ fn sync_git_remote(config: &YamlConfig) {
             if url != git_repo {
                 // URL 不一致，更新 remote
                 let _ = run_git_in_report_dir(&["remote", "set-url", "origin", &git_repo], config);
-                info!("🔄 已同步 remote origin: {} → {}", url, git_repo);
+                info!("{}", t!(msg::REPORT_REMOTE_SYNCED, url, git_repo));
             }
         }
         _ => {
@@ -763,7 +759,7 @@ fn handle_push(commit_msg: Option<&str>, config: &YamlConfig) {
     // 检查 git_repo 配置
     let git_repo = config.get_property(section::REPORT, config_key::GIT_REPO);
     if git_repo.is_none() || git_repo.unwrap().is_empty() {
-        error!("❌ 尚未配置 git 仓库地址，请先执行: j reportctl set-url <repo_url>");
+        error!("{}", t!(msg::REPORT_URL_REQUIRED));
         return;
     }
 
@@ -775,12 +771,12 @@ fn handle_push(commit_msg: Option<&str>, config: &YamlConfig) {
     let default_msg = format!("update report {}", Local::now().format("%Y-%m-%d %H:%M"));
     let msg = commit_msg.unwrap_or(&default_msg);
 
-    info!("📤 正在推送周报到远程仓库...");
+    info!("{}", t!(msg::REPORT_PUSHING));
 
     // git add .
     if let Some(status) = run_git_in_report_dir(&["add", "."], config) {
         if !status.success() {
-            error!("❌ git add 失败");
+            error!("{}", t!(msg::REPORT_GIT_ADD_FAILED));
             return;
         }
     } else {
@@ -791,7 +787,7 @@ fn handle_push(commit_msg: Option<&str>, config: &YamlConfig) {
     if let Some(status) = run_git_in_report_dir(&["commit", "-m", msg], config) {
         if !status.success() {
             // commit 可能因为没有变更而失败，这不一定是错误
-            info!("ℹ️ git commit 返回非零退出码（可能没有新变更）");
+            info!("{}", t!(msg::REPORT_COMMIT_NOTHING));
         }
     } else {
         return;
@@ -800,9 +796,9 @@ fn handle_push(commit_msg: Option<&str>, config: &YamlConfig) {
     // git push origin main
     if let Some(status) = run_git_in_report_dir(&["push", "-u", "origin", "main"], config) {
         if status.success() {
-            info!("✅ 周报已成功推送到远程仓库");
+            info!("{}", t!(msg::REPORT_PUSHED));
         } else {
-            error!("❌ git push 失败，请检查网络连接和仓库权限");
+            error!("{}", t!(msg::REPORT_PUSH_FAILED));
         }
     }
 }
@@ -812,14 +808,14 @@ fn handle_pull(config: &YamlConfig) {
     // 检查 git_repo 配置
     let git_repo = config.get_property(section::REPORT, config_key::GIT_REPO);
     if git_repo.is_none() || git_repo.unwrap().is_empty() {
-        error!("❌ 尚未配置 git 仓库地址，请先执行: j reportctl set-url <repo_url>");
+        error!("{}", t!(msg::REPORT_URL_REQUIRED));
         return;
     }
 
     let dir = match get_report_dir(config) {
         Some(d) => d,
         None => {
-            error!("❌ 无法确定日报目录");
+            error!("{}", t!(msg::REPORT_DIR_UNKNOWN));
             return;
         }
     };
@@ -829,7 +825,7 @@ fn handle_pull(config: &YamlConfig) {
     if !git_dir.exists() {
         // 日报目录不是 git 仓库，尝试 clone
         let repo_url = git_repo.unwrap().clone();
-        info!("📥 日报目录尚未初始化，正在从远程仓库克隆...");
+        info!("{}", t!(msg::REPORT_CLONING));
 
         // 先备份已有文件（如果有的话）
         let report_path = config.report_file_path();
@@ -842,9 +838,9 @@ fn handle_pull(config: &YamlConfig) {
             // 备份现有文件
             let backup_path = report_path.with_extension("md.bak");
             if let Err(e) = fs::copy(&report_path, &backup_path) {
-                error!("⚠️ 备份现有日报文件失败: {}", e);
+                error!("{}", t!(msg::REPORT_BACKUP_FAILED, e));
             } else {
-                info!("📋 已备份现有日报到: {:?}", backup_path);
+                info!("{}", t!(msg::REPORT_BACKED_UP, backup_path.display()));
             }
         }
 
@@ -868,17 +864,20 @@ fn handle_pull(config: &YamlConfig) {
                 // 将 clone 出来的内容移到 report 目录
                 let _ = fs::remove_dir_all(&dir);
                 if let Err(e) = fs::rename(&temp_dir, &dir) {
-                    error!("❌ 移动克隆仓库失败: {}，临时目录: {:?}", e, temp_dir);
+                    error!(
+                        "{}",
+                        t!(msg::REPORT_CLONE_MOVE_FAILED, e, temp_dir.display())
+                    );
                     return;
                 }
-                info!("✅ 成功从远程仓库克隆周报");
+                info!("{}", t!(msg::REPORT_CLONED));
             }
             Ok(_) => {
-                error!("❌ git clone 失败，请检查仓库地址和网络连接");
+                error!("{}", t!(msg::REPORT_CLONE_FAILED));
                 let _ = fs::remove_dir_all(&temp_dir);
             }
             Err(e) => {
-                error!("💥 执行 git clone 失败: {}", e);
+                error!("{}", t!(msg::REPORT_CLONE_ERROR, e));
                 let _ = fs::remove_dir_all(&temp_dir);
             }
         }
@@ -896,7 +895,7 @@ fn handle_pull(config: &YamlConfig) {
 
         if !has_commits {
             // 空仓库（git init 后未 commit），通过 fetch + checkout 来拉取
-            info!("📥 本地仓库尚无提交，正在从远程仓库拉取...");
+            info!("{}", t!(msg::REPORT_FETCHING));
 
             // 备份本地已有的未跟踪文件
             let report_path = config.report_file_path();
@@ -907,13 +906,13 @@ fn handle_pull(config: &YamlConfig) {
             {
                 let backup_path = report_path.with_extension("md.bak");
                 let _ = fs::copy(&report_path, &backup_path);
-                info!("📋 已备份本地日报到: {:?}", backup_path);
+                info!("{}", t!(msg::REPORT_LOCAL_BACKED_UP, backup_path.display()));
             }
 
             // git fetch origin main
             if let Some(status) = run_git_in_report_dir(&["fetch", "origin", "main"], config) {
                 if !status.success() {
-                    error!("❌ git fetch 失败，请检查网络连接和仓库地址");
+                    error!("{}", t!(msg::REPORT_FETCH_FAILED));
                     return;
                 }
             } else {
@@ -924,14 +923,14 @@ fn handle_pull(config: &YamlConfig) {
             if let Some(status) = run_git_in_report_dir(&["reset", "--hard", "origin/main"], config)
             {
                 if status.success() {
-                    info!("✅ 成功从远程仓库拉取周报");
+                    info!("{}", t!(msg::REPORT_FETCHED));
                 } else {
-                    error!("❌ git reset 失败");
+                    error!("{}", t!(msg::REPORT_RESET_FAILED));
                 }
             }
         } else {
             // 正常仓库，先 stash 再 pull
-            info!("📥 正在从远程仓库拉取最新周报...");
+            info!("{}", t!(msg::REPORT_PULLING));
 
             // 先暂存本地未跟踪/修改的文件，防止 pull 时冲突
             let _ = run_git_in_report_dir(&["add", "-A"], config);
@@ -952,10 +951,10 @@ fn handle_pull(config: &YamlConfig) {
                 run_git_in_report_dir(&["pull", "origin", "main", "--rebase"], config)
             {
                 if status.success() {
-                    info!("✅ 周报已更新到最新版本");
+                    info!("{}", t!(msg::REPORT_PULLED));
                     true
                 } else {
-                    error!("❌ git pull 失败，请检查网络连接或手动解决冲突");
+                    error!("{}", t!(msg::REPORT_PULL_FAILED));
                     false
                 }
             } else {
@@ -966,7 +965,7 @@ fn handle_pull(config: &YamlConfig) {
             if has_stash {
                 if let Some(status) = run_git_in_report_dir(&["stash", "pop"], config) {
                     if !status.success() && pull_ok {
-                        info!("⚠️ stash pop 存在冲突，请手动合并本地修改（已保存在 git stash 中）");
+                        info!("{}", t!(msg::REPORT_STASH_CONFLICT));
                     }
                 }
             }
@@ -988,7 +987,7 @@ pub fn handle_check(line_count: Option<&str>, config: &YamlConfig) {
         Some(s) => match s.parse::<usize>() {
             Ok(n) if n > 0 => n,
             _ => {
-                error!("❌ 无效的行数参数: {}，请输入正整数或 open", s);
+                error!("{}", t!(msg::REPORT_BAD_CHECK_LINES, s));
                 return;
             }
         },
@@ -1000,16 +999,16 @@ pub fn handle_check(line_count: Option<&str>, config: &YamlConfig) {
         None => return,
     };
 
-    info!("📂 正在读取周报文件路径: {}", report_path);
+    info!("{}", t!(msg::REPORT_READING, report_path));
 
     let path = Path::new(&report_path);
     if !path.is_file() {
-        error!("❌ 文件不存在或不是有效文件: {}", report_path);
+        error!("{}", t!(msg::REPORT_NOT_A_FILE, report_path));
         return;
     }
 
     let lines = read_last_n_lines(path, num);
-    info!("📄 最近的 {} 行内容如下：", lines.len());
+    info!("{}", t!(msg::REPORT_LAST_LINES, lines.len()));
     // 周报本身就是 Markdown 格式，使用 termimad 渲染
     let md_content = lines.join("\n");
     crate::md!("{}", md_content);
@@ -1030,7 +1029,7 @@ pub fn handle_search(
         match line_count.parse::<usize>() {
             Ok(n) if n > 0 => n,
             _ => {
-                error!("❌ 无效的行数参数: {}，请输入正整数或 all", line_count);
+                error!("{}", t!(msg::REPORT_BAD_SEARCH_LINES, line_count));
                 return;
             }
         }
@@ -1041,22 +1040,22 @@ pub fn handle_search(
         None => return,
     };
 
-    info!("📂 正在读取周报文件路径: {}", report_path);
+    info!("{}", t!(msg::REPORT_READING, report_path));
 
     let path = Path::new(&report_path);
     if !path.is_file() {
-        error!("❌ 文件不存在或不是有效文件: {}", report_path);
+        error!("{}", t!(msg::REPORT_NOT_A_FILE, report_path));
         return;
     }
 
     let is_fuzzy =
         matches!(fuzzy_flag, Some(f) if f == search_flag::FUZZY_SHORT || f == search_flag::FUZZY);
     if is_fuzzy {
-        info!("启用模糊匹配...");
+        info!("{}", t!(msg::REPORT_FUZZY));
     }
 
     let lines = read_last_n_lines(path, num);
-    info!("🔍 搜索目标关键字: {}", target.green());
+    info!("{}", t!(msg::REPORT_SEARCHING, target.green()));
 
     let mut index = 0;
     for line in &lines {
@@ -1086,7 +1085,7 @@ fn read_last_n_lines(path: &Path, n: usize) -> Vec<String> {
     let mut file = match fs::File::open(path) {
         Ok(f) => f,
         Err(e) => {
-            error!("❌ 读取文件时发生错误: {}", e);
+            error!("{}", t!(msg::REPORT_READ_ERROR, e));
             return lines;
         }
     };
@@ -1,14 +1,15 @@
 use crate::config::YamlConfig;
 use crate::constants::{section, shell};
-use crate::{error, info};
+use crate::util::i18n::{msg, text};
+use crate::{error, info, t};
 use std::fs;
 
 /// 生成脚本末尾的「等待用户按键」模板内容
 fn wait_for_key_template() -> String {
     if std::env::consts::OS == shell::WINDOWS_OS {
-        "echo.\necho 脚本执行完毕，按任意键退出...\npause >nul".to_string()
+        text(&msg::SCRIPT_WAIT_KEY_WINDOWS).to_string()
     } else {
-        "echo ''\necho '\\033[32m✅ 脚本执行完毕，按回车键退出...\\033[0m'\nread _".to_string()
+        text(&msg::SCRIPT_WAIT_KEY).to_string()
     }
 }
 
@@ -25,7 +26,7 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
         {
             Some(p) => p.clone(),
             None => {
-                error!("❌ 别名 {{{}}} 已存在，但未找到对应的脚本路径", name);
+                error!("{}", t!(msg::SCRIPT_PATH_MISSING, name));
                 return;
             }
         };
@@ -34,7 +35,7 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
         let existing_content = match fs::read_to_string(&existing_path) {
             Ok(c) => c,
             Err(e) => {
-                error!("❌ 读取已有脚本文件失败: {} (路径: {})", e, existing_path);
+                error!("{}", t!(msg::SCRIPT_READ_FAILED, e, existing_path));
                 return;
             }
         };
@@ -42,25 +43,25 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
         // 打开 TUI 编辑器让用户修改
         let initial_lines: Vec<String> = existing_content.lines().map(|l| l.to_string()).collect();
         match crate::tui::editor::open_multiline_editor_with_content(
-            &format!("📝 编辑脚本: {}", name),
+            &t!(msg::SCRIPT_EDIT_TITLE, name),
             &initial_lines,
         ) {
             Ok(Some(new_content)) => {
                 if new_content.trim().is_empty() {
-                    error!("⚠️ 脚本内容为空，未保存修改");
+                    error!("{}", t!(msg::SCRIPT_EMPTY_UNSAVED));
                     return;
                 }
                 // 写回脚本文件
                 match fs::write(&existing_path, &new_content) {
-                    Ok(_) => info!("✅ 脚本 {{{}}} 已更新，路径: {}", name, existing_path),
-                    Err(e) => error!("💥 写入脚本文件失败: {}", e),
+                    Ok(_) => info!("{}", t!(msg::SCRIPT_UPDATED, name, existing_path)),
+                    Err(e) => error!("{}", t!(msg::SCRIPT_WRITE_FAILED, e)),
                 }
             }
             Ok(None) => {
-                info!("已取消编辑脚本");
+                info!("{}", t!(msg::SCRIPT_EDIT_CANCELLED));
             }
             Err(e) => {
-                error!("❌ 编辑器启动失败: {}", e);
+                error!("{}", t!(msg::SCRIPT_EDITOR_FAILED, e));
             }
         }
         return;
@@ -72,23 +73,23 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
         let initial_lines = vec![
             "#!/bin/bash".to_string(),
             "".to_string(),
-            "# 在此编写脚本内容...".to_string(),
+            text(&msg::SCRIPT_PLACEHOLDER).to_string(),
             "".to_string(),
-            "# --- 以下为等待按键模板（可删除） ---".to_string(),
+            text(&msg::SCRIPT_WAIT_KEY_HEADER).to_string(),
             wait_for_key_template(),
         ];
 
         match crate::tui::editor::open_multiline_editor_with_content(
-            &format!("📝 编写脚本: {}", name),
+            &t!(msg::SCRIPT_NEW_TITLE, name),
             &initial_lines,
         ) {
             Ok(Some(text)) => text,
             Ok(None) => {
-                info!("已取消创建脚本");
+                info!("{}", t!(msg::SCRIPT_CREATE_CANCELLED));
                 return;
             }
             Err(e) => {
-                error!("❌ 编辑器启动失败: {}", e);
+                error!("{}", t!(msg::SCRIPT_EDITOR_FAILED, e));
                 return;
             }
         }
@@ -102,7 +103,7 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
     };
 
     if script_content.trim().is_empty() {
-        error!("⚠️ 脚本内容为空，无法创建");
+        error!("{}", t!(msg::SCRIPT_EMPTY));
         return;
     }
 
@@ -121,7 +122,7 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
     // 确保目录存在（scripts_dir() 已保证，这里冗余保护）
     if let Some(parent) = script_path.parent() {
         if let Err(e) = fs::create_dir_all(parent) {
-            error!("❌ 创建目录失败: {}", e);
+            error!("{}", t!(msg::SCRIPT_MKDIR_FAILED, e));
             return;
         }
     }
@@ -129,10 +130,10 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
     // 写入脚本内容
     match fs::write(&script_path, &script_content) {
         Ok(_) => {
-            info!("🎉 文件创建成功: {}", script_path_str);
+            info!("{}", t!(msg::SCRIPT_FILE_CREATED, script_path_str));
         }
         Err(e) => {
-            error!("💥 写入脚本文件失败: {}", e);
+            error!("{}", t!(msg::SCRIPT_WRITE_FAILED, e));
             return;
         }
     }
@@ -145,9 +146,9 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
             let mut perms = metadata.permissions();
             perms.set_mode(perms.mode() | 0o111); // 添加执行权限
             if let Err(e) = fs::set_permissions(&script_path, perms) {
-                error!("❌ 设置执行权限失败: {}", e);
+                error!("{}", t!(msg::SCRIPT_CHMOD_FAILED, e));
             } else {
-                info!("🔧 已为脚本 {{{}}} 设置执行权限", name);
+                info!("{}", t!(msg::SCRIPT_CHMOD_DONE, name));
             }
         }
     }
@@ -156,5 +157,5 @@ pub fn handle_concat(name: &str, content: &[String], config: &mut YamlConfig) {
     config.set_property(section::PATH, name, &script_path_str);
     config.set_property(section::SCRIPT, name, &script_path_str);
 
-    info!("✅ 成功创建脚本 {{{}}}，路径: {}", name, script_path_str);
+    info!("{}", t!(msg::SCRIPT_CREATED, name, script_path_str));
 }
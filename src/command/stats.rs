@@ -0,0 +1,21 @@
+use crate::config::profile;
+use crate::constants::BIN_DIR;
+use crate::util::i18n::msg;
+use crate::util::log::{EXIT_FAILURE, set_exit_code};
+use crate::{error, t};
+use std::process::Command;
+
+/// j stats [reset | metrics [--json]]：交给 agent 插件的 stats 子命令，
+/// 查看本地使用统计与守护进程的运行时统计（请求数、错误率、延迟分位数与 token 数）
+pub fn handle_stats(args: &[String]) {
+    let bin = profile::root_dir().join(BIN_DIR).join("agent");
+    if !bin.is_file() {
+        error!("{}", t!(msg::STATS_AGENT_MISSING, bin.display()));
+        return;
+    }
+    match Command::new(&bin).arg("stats").args(args).status() {
+        Ok(status) if status.success() => {}
+        Ok(status) => set_exit_code(status.code().unwrap_or(EXIT_FAILURE)),
+        Err(e) => error!("{}", t!(msg::STATS_AGENT_FAILED, bin.display(), e)),
+    }
+}
@@ -0,0 +1,173 @@
+use crate::cli::Cli;
+use crate::config::{YamlConfig, profile};
+use crate::constants::{self, BIN_DIR, config_key, section};
+use crate::util::fuzzy;
+use crate::util::i18n::{msg, text};
+use crate::util::log::{EXIT_FAILURE, set_exit_code};
+use crate::{error, t};
+use clap::Parser;
+use colored::Colorize;
+use std::io::{self, BufRead, IsTerminal, Write};
+use std::path::PathBuf;
+
+/// 最多给出的候选数
+const MAX_SUGGESTIONS: usize = 3;
+
+/// 候选的来源
+#[derive(Clone, Copy, PartialEq)]
+enum Kind {
+    Command,
+    Plugin,
+    Alias,
+}
+
+impl Kind {
+    fn label(self) -> &'static str {
+        match self {
+            Kind::Command => text(&msg::SUGGEST_KIND_COMMAND),
+            Kind::Plugin => text(&msg::SUGGEST_KIND_PLUGIN),
+            Kind::Alias => text(&msg::SUGGEST_KIND_ALIAS),
+        }
+    }
+}
+
+/// 运行插件或打开别名：第一个参数正是 ~/.jdata/bin 中已安装的插件名时以其余参数运行该插件（如 `j translate hello`），
+/// 否则打开别名；既不是内置命令也不是插件或别名时，按编辑距离从内置命令、已安装的插件与别名中
+/// 给出最接近的几个（如 `j sett` → set）。交互终端中有唯一最接近的候选时询问是否改为执行它，
+/// `setting.autocorrect: off` 关闭询问，只给出候选；没有接近的候选时照旧报告找不到别名
+pub fn open_or_suggest(args: &[String], config: &mut YamlConfig, interactive: bool) {
+    let Some(name) = args.first() else {
+        crate::command::open::handle_open(args, config);
+        return;
+    };
+    if is_plugin(name) {
+        run_plugin(args);
+        return;
+    }
+    if name.chars().count() < 2
+        || config.alias_exists(name)
+        || constants::cmd::all_keywords().contains(&name.as_str())
+    {
+        crate::command::open::handle_open(args, config);
+        return;
+    }
+    let candidates = candidates(config);
+    let names: Vec<&str> = candidates.iter().map(|(n, _)| n.as_str()).collect();
+    let matches = fuzzy::closest(name, &names, MAX_SUGGESTIONS);
+    if matches.is_empty() {
+        crate::command::open::handle_open(args, config);
+        return;
+    }
+    let kind_of = |m: &str| {
+        candidates
+            .iter()
+            .find(|(n, _)| n == m)
+            .map_or(Kind::Alias, |(_, k)| *k)
+    };
+    eprintln!("{}{}", "[ERROR] ".red(), t!(msg::SUGGEST_UNKNOWN, name));
+    eprintln!("{}", text(&msg::SUGGEST_CLOSEST).green());
+    for &m in &matches {
+        eprintln!("    {}  {}", m.bold(), kind_of(m).label().dimmed());
+    }
+    let unique = matches.len() == 1
+        || fuzzy::edit_distance(name, matches[0]) < fuzzy::edit_distance(name, matches[1]);
+    if !unique || !should_prompt(config) {
+        set_exit_code(EXIT_FAILURE);
+        return;
+    }
+    let mut corrected = args.to_vec();
+    corrected[0] = matches[0].to_string();
+    if !confirm(&t!(msg::SUGGEST_CONFIRM, corrected.join(" "))) {
+        set_exit_code(EXIT_FAILURE);
+        return;
+    }
+    match kind_of(matches[0]) {
+        Kind::Alias => crate::command::open::handle_open(&corrected, config),
+        Kind::Plugin => run_plugin(&corrected),
+        Kind::Command if interactive => {
+            crate::interactive::parser::execute_interactive_command(&corrected, config)
+        }
+        Kind::Command => {
+            let argv = std::iter::once("j".to_string()).chain(corrected);
+            match Cli::try_parse_from(argv) {
+                Ok(Cli {
+                    command: Some(sub), ..
+                }) => crate::command::dispatch(sub, config),
+                Ok(_) => {}
+                Err(e) => {
+                    let _ = e.print();
+                    set_exit_code(e.exit_code());
+                }
+            }
+        }
+    }
+}
+
+/// 全部候选：内置命令（含简写，单个字母的除外）、~/.jdata/bin 中的插件与已配置的别名
+fn candidates(config: &YamlConfig) -> Vec<(String, Kind)> {
+    let mut all: Vec<(String, Kind)> = constants::cmd::all_keywords()
+        .into_iter()
+        .filter(|k| k.chars().count() > 1)
+        .map(|k| (k.to_string(), Kind::Command))
+        .collect();
+    if let Ok(entries) = std::fs::read_dir(plugin_dir()) {
+        for entry in entries.flatten() {
+            if entry.path().is_file() {
+                if let Ok(name) = entry.file_name().into_string() {
+                    all.push((name, Kind::Plugin));
+                }
+            }
+        }
+    }
+    for s in constants::ALIAS_EXISTS_SECTIONS {
+        if let Some(map) = config.get_section(s) {
+            for key in map.keys() {
+                if !all.iter().any(|(n, _)| n == key) {
+                    all.push((key.clone(), Kind::Alias));
+                }
+            }
+        }
+    }
+    all
+}
+
+/// name 是否为已安装的插件：~/.jdata/bin 下的同名文件（名称不能含路径分隔符）
+fn is_plugin(name: &str) -> bool {
+    !name.is_empty()
+        && !name.starts_with('.')
+        && !name.contains(['/', '\\'])
+        && plugin_dir().join(name).is_file()
+}
+
+fn plugin_dir() -> PathBuf {
+    profile::root_dir().join(BIN_DIR)
+}
+
+/// 是否询问自动纠正：stdin 与 stderr 都是终端，且 setting.autocorrect 没有关闭
+fn should_prompt(config: &YamlConfig) -> bool {
+    let enabled = config
+        .get_property(section::SETTING, config_key::AUTOCORRECT)
+        .is_none_or(|v| !matches!(v.as_str(), "off" | "false" | "no"));
+    enabled && io::stdin().is_terminal() && io::stderr().is_terminal()
+}
+
+/// 在 stderr 提问，读取一行回答：y / yes 为确认
+fn confirm(prompt: &str) -> bool {
+    eprint!("{}", prompt);
+    let _ = io::stderr().flush();
+    let mut line = String::new();
+    if io::stdin().lock().read_line(&mut line).is_err() {
+        return false;
+    }
+    matches!(line.trim().to_lowercase().as_str(), "y" | "yes")
+}
+
+/// 以其余参数运行 ~/.jdata/bin 中的插件，退出码非 0 时记下失败
+fn run_plugin(args: &[String]) {
+    let bin = plugin_dir().join(&args[0]);
+    match std::process::Command::new(&bin).args(&args[1..]).status() {
+        Ok(status) if status.success() => {}
+        Ok(status) => set_exit_code(status.code().unwrap_or(EXIT_FAILURE)),
+        Err(e) => error!("{}", t!(msg::SUGGEST_PLUGIN_FAILED, bin.display(), e)),
+    }
+}
@@ -1,7 +1,8 @@
 use crate::assets::VERSION_TEMPLATE;
 use crate::config::YamlConfig;
 use crate::constants::{self, CONTAIN_SEARCH_SECTIONS, config_key, section};
-use crate::{error, info, md, usage};
+use crate::util::i18n::{msg, text};
+use crate::{error, info, md, t, usage};
 use colored::Colorize;
 
 /// 处理 version 命令: j version
@@ -9,7 +10,9 @@ pub fn handle_version() {
     let text = VERSION_TEMPLATE
         .replace("{version}", constants::VERSION)
         .replace("{os}", std::env::consts::OS)
-        .replace("{arch}", std::env::consts::ARCH);
+        .replace("{arch}", std::env::consts::ARCH)
+        .replace("{property}", text(&msg::SYSTEM_VERSION_PROPERTY))
+        .replace("{value}", text(&msg::SYSTEM_VERSION_VALUE));
     md!("{}", text);
 }
 
@@ -28,7 +31,7 @@ pub fn handle_log(key: &str, value: &str, config: &mut YamlConfig) {
             config_key::CONCISE
         };
         config.set_property(section::LOG, config_key::MODE, mode);
-        info!("✅ 日志模式已切换为: {}", mode);
+        info!("{}", t!(msg::SYSTEM_LOG_MODE, mode));
     } else {
         usage!("j log mode <verbose|concise>");
     }
@@ -66,7 +69,7 @@ pub fn handle_contain(alias: &str, containers: Option<&str>, config: &YamlConfig
     if found.is_empty() {
         info!("nothing found 😢");
     } else {
-        info!("找到 {} 条结果 😊", found.len().to_string().green());
+        info!("{}", t!(msg::SYSTEM_FOUND, found.len().to_string().green()));
         for line in &found {
             info!("{}", line);
         }
@@ -77,7 +80,7 @@ pub fn handle_contain(alias: &str, containers: Option<&str>, config: &YamlConfig
 /// 直接修改配置文件中的某个字段（如果字段不存在则新增）
 pub fn handle_change(part: &str, field: &str, value: &str, config: &mut YamlConfig) {
     if config.get_section(part).is_none() {
-        error!("❌ 在配置文件中未找到该 section：{}", part);
+        error!("{}", t!(msg::SYSTEM_NO_SECTION, part));
         return;
     }
 
@@ -86,18 +89,13 @@ pub fn handle_change(part: &str, field: &str, value: &str, config: &mut YamlConf
 
     match old_value {
         Some(old) => {
-            info!(
-                "✅ 已修改 {}.{} 的值为 {}，旧值为 {}",
-                part, field, value, old
-            );
+            info!("{}", t!(msg::SYSTEM_CHANGED, part, field, value, old));
         }
         None => {
-            info!("✅ 已新增 {}.{} = {}", part, field, value);
+            info!("{}", t!(msg::SYSTEM_ADDED, part, field, value));
         }
     }
-    info!(
-        "🚧 此命令可能会导致配置文件属性错乱而使 Copilot 无法正常使用，请确保在您清楚在做什么的情况下使用"
-    );
+    info!("{}", t!(msg::SYSTEM_CHANGE_WARNING));
 }
 
 // ========== completion 命令 ==========
@@ -111,7 +109,7 @@ pub fn handle_completion(shell_type: Option<&str>, config: &YamlConfig) {
         "zsh" => generate_zsh_completion(config),
         "bash" => generate_bash_completion(config),
         _ => {
-            error!("❌ 不支持的 shell 类型: {}，可选: zsh, bash", shell);
+            error!("{}", t!(msg::SYSTEM_BAD_SHELL, shell));
             usage!("j completion [zsh|bash]");
         }
     }
@@ -172,10 +170,8 @@ fn generate_zsh_completion(config: &YamlConfig) {
     let mut script = String::new();
     script.push_str("#compdef j\n");
     script.push_str("# Zsh completion for j (work-copilot)\n");
-    script.push_str("# 生成方式: eval \"$(j completion zsh)\"\n");
-    script.push_str(
-        "# 或: j completion zsh > ~/.zsh/completions/_j && fpath=(~/.zsh/completions $fpath)\n\n",
-    );
+    script.push_str(text(&msg::SYSTEM_ZSH_HOWTO));
+    script.push_str(text(&msg::SYSTEM_ZSH_HOWTO_FILE));
     script.push_str("_j() {\n");
     script.push_str("    local curcontext=\"$curcontext\" state line\n");
     script.push_str("    typeset -A opt_args\n\n");
@@ -261,6 +257,14 @@ fn generate_zsh_completion(config: &YamlConfig) {
     script.push_str("                    _describe 'function' time_funcs\n");
     script.push_str("                    ;;\n");
 
+    // profile 命令
+    script.push_str("                profile)\n");
+    script.push_str("                    if (( CURRENT == 3 )); then\n");
+    script.push_str("                        local -a profile_actions=(list create switch)\n");
+    script.push_str("                        _describe 'action' profile_actions\n");
+    script.push_str("                    fi\n");
+    script.push_str("                    ;;\n");
+
     // completion 命令
     script.push_str("                completion)\n");
     script.push_str("                    local -a shells=(zsh bash)\n");
@@ -327,8 +331,8 @@ fn generate_bash_completion(config: &YamlConfig) {
 
     let mut script = String::new();
     script.push_str("# Bash completion for j (work-copilot)\n");
-    script.push_str("# 生成方式: eval \"$(j completion bash)\"\n");
-    script.push_str("# 或: j completion bash > /etc/bash_completion.d/j\n\n");
+    script.push_str(text(&msg::SYSTEM_BASH_HOWTO));
+    script.push_str(text(&msg::SYSTEM_BASH_HOWTO_FILE));
     script.push_str("_j_completion() {\n");
     script.push_str("    local cur prev words cword\n");
     script.push_str("    _init_completion || return\n\n");
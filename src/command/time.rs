@@ -1,5 +1,6 @@
 use crate::constants::time_function;
-use crate::{error, info, usage};
+use crate::util::i18n::msg;
+use crate::{error, info, t, usage};
 use indicatif::{ProgressBar, ProgressStyle};
 use std::io::{self, Write};
 
@@ -7,45 +8,48 @@ use std::io::{self, Write};
 /// duration 支持: 30s（秒）、5m（分钟）、1h（小时），不带单位默认为分钟
 pub fn handle_time(function: &str, arg: &str) {
     if function != time_function::COUNTDOWN {
-        error!("❌ 未知的功能: {}，目前仅支持 countdown", function);
+        error!("{}", t!(msg::TIME_UNKNOWN_FUNCTION, function));
         usage!("j time countdown <duration>");
-        info!("  duration 格式: 30s(秒), 5m(分钟), 1h(小时), 不带单位默认为分钟");
+        info!("{}", t!(msg::TIME_DURATION_FORMAT));
         return;
     }
 
     let duration_secs = parse_duration(arg);
     if duration_secs <= 0 {
-        error!("❌ 无效的时长: {}", arg);
+        error!("{}", t!(msg::TIME_BAD_DURATION, arg));
         return;
     }
 
     info!(
-        "⏳ 倒计时开始：{}",
-        format_duration_display(duration_secs as u64)
+        "{}",
+        t!(
+            msg::TIME_COUNTDOWN_START,
+            format_duration_display(duration_secs as u64)
+        )
     );
     run_countdown(duration_secs as u64);
 }
 
-/// 格式化时长为可读的中文显示
+/// 按当前语言把时长格式化为可读的文本
 fn format_duration_display(secs: u64) -> String {
     if secs >= 3600 {
         let h = secs / 3600;
         let m = (secs % 3600) / 60;
         if m > 0 {
-            format!("{}小时{}分钟", h, m)
+            t!(msg::TIME_HOURS_MINUTES, h, m)
         } else {
-            format!("{}小时", h)
+            t!(msg::TIME_HOURS, h)
         }
     } else if secs >= 60 {
         let m = secs / 60;
         let s = secs % 60;
         if s > 0 {
-            format!("{}分{}秒", m, s)
+            t!(msg::TIME_MINUTES_SECONDS, m, s)
         } else {
-            format!("{}分钟", m)
+            t!(msg::TIME_MINUTES, m)
         }
     } else {
-        format!("{}秒", secs)
+        t!(msg::TIME_SECONDS, secs)
     }
 }
 
@@ -84,8 +88,12 @@ fn format_remaining(secs: u64) -> String {
     }
 }
 
-/// 运行倒计时（带进度条和动画）
+/// 运行倒计时（带进度条和动画；无障碍模式下改为每分钟输出一行剩余时间）
 fn run_countdown(total_secs: u64) {
+    if crate::util::accessible::enabled() {
+        run_countdown_plain(total_secs);
+        return;
+    }
     let pb = ProgressBar::new(total_secs);
 
     // 设置进度条样式
@@ -115,13 +123,39 @@ fn run_countdown(total_secs: u64) {
 
     pb.finish_and_clear();
 
-    println!("  🎉 Time's up! 倒计时结束！");
+    println!("{}", t!(msg::TIME_UP_CELEBRATE));
     println!();
 
     // 结束动画
     display_celebration();
 }
 
+/// 无障碍模式的倒计时：不用进度条与就地刷新，整分钟时输出一行剩余时间
+fn run_countdown_plain(total_secs: u64) {
+    let start = std::time::Instant::now();
+    for elapsed in 1..=total_secs {
+        let next_tick = start + std::time::Duration::from_secs(elapsed);
+        let now = std::time::Instant::now();
+        if next_tick > now {
+            std::thread::sleep(next_tick - now);
+        }
+        let remaining = total_secs - elapsed;
+        if remaining > 0 && remaining % 60 == 0 {
+            println!(
+                "{}",
+                t!(msg::TIME_REMAINING, format_duration_display(remaining))
+            );
+        }
+    }
+    println!("{}", t!(msg::TIME_UP));
+    #[cfg(target_os = "macos")]
+    {
+        let _ = std::process::Command::new("afplay")
+            .arg("/System/Library/Sounds/Glass.aiff")
+            .spawn();
+    }
+}
+
 /// 结束庆祝动画
 fn display_celebration() {
     let frames = [
@@ -1,7 +1,8 @@
 use crate::command::report;
 use crate::config::YamlConfig;
 use crate::constants::todo_filter;
-use crate::error;
+use crate::util::i18n::msg;
+use crate::{error, t};
 use chrono::Local;
 use crossterm::event::{KeyCode, KeyEvent, KeyModifiers};
 use ratatui::widgets::ListState;
@@ -54,11 +55,11 @@ pub fn load_todo_list() -> TodoList {
     }
     match fs::read_to_string(&path) {
         Ok(content) => serde_json::from_str(&content).unwrap_or_else(|e| {
-            error!("❌ 解析 todo.json 失败: {}", e);
+            error!("{}", t!(msg::TODO_PARSE_FAILED, e));
             TodoList::default()
         }),
         Err(e) => {
-            error!("❌ 读取 todo.json 失败: {}", e);
+            error!("{}", t!(msg::TODO_READ_FAILED, e));
             TodoList::default()
         }
     }
@@ -74,12 +75,12 @@ pub fn save_todo_list(list: &TodoList) -> bool {
         Ok(json) => match fs::write(&path, json) {
             Ok(_) => true,
             Err(e) => {
-                error!("❌ 保存 todo.json 失败: {}", e);
+                error!("{}", t!(msg::TODO_SAVE_FAILED, e));
                 false
             }
         },
         Err(e) => {
-            error!("❌ 序列化 todo 列表失败: {}", e);
+            error!("{}", t!(msg::TODO_SERIALIZE_FAILED, e));
             false
         }
     }
@@ -236,7 +237,7 @@ impl TodoApp {
                 self.mode = AppMode::ConfirmReport;
             } else {
                 item.done_at = None;
-                self.message = Some("⬜ 已标记为未完成".to_string());
+                self.message = Some(t!(msg::TODO_MARKED_UNDONE));
             }
         }
     }
@@ -245,7 +246,7 @@ impl TodoApp {
     pub fn add_item(&mut self) {
         let text = self.input.trim().to_string();
         if text.is_empty() {
-            self.message = Some("⚠️ 内容为空，已取消".to_string());
+            self.message = Some(t!(msg::TODO_ADD_EMPTY));
             self.mode = AppMode::Normal;
             self.input.clear();
             return;
@@ -265,9 +266,9 @@ impl TodoApp {
         // 自动保存到文件
         if save_todo_list(&self.list) {
             self.snapshot = self.list.clone();
-            self.message = Some("✅ 已添加并保存".to_string());
+            self.message = Some(t!(msg::TODO_ADDED_SAVED));
         } else {
-            self.message = Some("✅ 已添加（保存失败）".to_string());
+            self.message = Some(t!(msg::TODO_ADDED_UNSAVED));
         }
     }
 
@@ -275,7 +276,7 @@ impl TodoApp {
     pub fn confirm_edit(&mut self) {
         let text = self.input.trim().to_string();
         if text.is_empty() {
-            self.message = Some("⚠️ 内容为空，已取消编辑".to_string());
+            self.message = Some(t!(msg::TODO_EDIT_EMPTY));
             self.mode = AppMode::Normal;
             self.input.clear();
             self.edit_index = None;
@@ -287,9 +288,9 @@ impl TodoApp {
                 // 自动保存到文件
                 if save_todo_list(&self.list) {
                     self.snapshot = self.list.clone();
-                    self.message = Some("✅ 已更新并保存".to_string());
+                    self.message = Some(t!(msg::TODO_UPDATED_SAVED));
                 } else {
-                    self.message = Some("✅ 已更新（保存失败）".to_string());
+                    self.message = Some(t!(msg::TODO_UPDATED_UNSAVED));
                 }
             }
         }
@@ -302,7 +303,7 @@ impl TodoApp {
     pub fn delete_selected(&mut self) {
         if let Some(real_idx) = self.selected_real_index() {
             let removed = self.list.items.remove(real_idx);
-            self.message = Some(format!("🗑️ 已删除: {}", removed.content));
+            self.message = Some(t!(msg::TODO_DELETED, removed.content));
             let count = self.filtered_indices().len();
             if count == 0 {
                 self.state.select(None);
@@ -345,7 +346,7 @@ impl TodoApp {
             self.state.select(None);
         }
         let label = todo_filter::label(self.filter);
-        self.message = Some(format!("🔍 过滤: {}", label));
+        self.message = Some(t!(msg::TODO_FILTER_SET, label));
     }
 
     /// 保存数据
@@ -353,10 +354,10 @@ impl TodoApp {
         if self.is_dirty() {
             if save_todo_list(&self.list) {
                 self.snapshot = self.list.clone();
-                self.message = Some("💾 已保存".to_string());
+                self.message = Some(t!(msg::TODO_SAVED));
             }
         } else {
-            self.message = Some("📋 无需保存，没有修改".to_string());
+            self.message = Some(t!(msg::TODO_NOTHING_TO_SAVE));
         }
     }
 }
@@ -372,9 +373,7 @@ pub fn handle_normal_mode(app: &mut TodoApp, key: KeyEvent) -> bool {
     match key.code {
         KeyCode::Char('q') => {
             if app.is_dirty() {
-                app.message = Some(
-                    "⚠️ 有未保存的修改！请先 s 保存，或输入 q! 强制退出（丢弃修改）".to_string(),
-                );
+                app.message = Some(t!(msg::TODO_UNSAVED_QUIT));
                 app.quit_input = "q".to_string();
                 return false;
             }
@@ -382,9 +381,7 @@ pub fn handle_normal_mode(app: &mut TodoApp, key: KeyEvent) -> bool {
         }
         KeyCode::Esc => {
             if app.is_dirty() {
-                app.message = Some(
-                    "⚠️ 有未保存的修改！请先 s 保存，或输入 q! 强制退出（丢弃修改）".to_string(),
-                );
+                app.message = Some(t!(msg::TODO_UNSAVED_QUIT));
                 return false;
             }
             return true;
@@ -417,9 +414,9 @@ pub fn handle_normal_mode(app: &mut TodoApp, key: KeyEvent) -> bool {
             if let Some(real_idx) = app.selected_real_index() {
                 let content = app.list.items[real_idx].content.clone();
                 if copy_to_clipboard(&content) {
-                    app.message = Some(format!("📋 已复制到剪切板: {}", content));
+                    app.message = Some(t!(msg::TODO_COPIED, content));
                 } else {
-                    app.message = Some("❌ 复制到剪切板失败".to_string());
+                    app.message = Some(t!(msg::TODO_COPY_FAILED));
                 }
             }
         }
@@ -479,17 +476,14 @@ pub fn handle_input_mode(app: &mut TodoApp, key: KeyEvent) {
             if has_changes {
                 // 有修改，进入确认模式，询问是否保存
                 app.mode = AppMode::ConfirmCancelInput;
-                app.message = Some(
-                    "⚠️ 有未保存的内容，是否保存？(Enter/y 保存 / n 放弃 / 其他键继续编辑)"
-                        .to_string(),
-                );
+                app.message = Some(t!(msg::TODO_UNSAVED_INPUT));
             } else {
                 // 无修改，直接取消
                 app.mode = AppMode::Normal;
                 app.input.clear();
                 app.cursor_pos = 0;
                 app.edit_index = None;
-                app.message = Some("已取消".to_string());
+                app.message = Some(t!(msg::TODO_CANCELLED));
             }
         }
         KeyCode::Left => {
@@ -565,7 +559,7 @@ pub fn handle_confirm_delete(app: &mut TodoApp, key: KeyEvent) {
         }
         KeyCode::Char('n') | KeyCode::Char('N') | KeyCode::Esc => {
             app.mode = AppMode::Normal;
-            app.message = Some("已取消删除".to_string());
+            app.message = Some(t!(msg::TODO_DELETE_CANCELLED));
         }
         _ => {}
     }
@@ -594,7 +588,7 @@ pub fn handle_confirm_cancel_input(app: &mut TodoApp, key: KeyEvent, prev_mode:
             app.input.clear();
             app.cursor_pos = 0;
             app.edit_index = None;
-            app.message = Some("已放弃".to_string());
+            app.message = Some(t!(msg::TODO_DISCARDED));
         }
         KeyCode::Esc => {
             // Esc 放弃修改
@@ -602,7 +596,7 @@ pub fn handle_confirm_cancel_input(app: &mut TodoApp, key: KeyEvent, prev_mode:
             app.input.clear();
             app.cursor_pos = 0;
             app.edit_index = None;
-            app.message = Some("已放弃".to_string());
+            app.message = Some(t!(msg::TODO_DISCARDED));
         }
         _ => {
             // 其他键继续编辑
@@ -626,9 +620,9 @@ pub fn handle_confirm_report(app: &mut TodoApp, key: KeyEvent, config: &mut Yaml
                 }
                 // 保存后再设置最终 message
                 if write_ok {
-                    app.message = Some("✅ 已标记为完成，已写入日报并保存".to_string());
+                    app.message = Some(t!(msg::TODO_DONE_REPORTED));
                 } else {
-                    app.message = Some("✅ 已标记为完成，但写入日报失败".to_string());
+                    app.message = Some(t!(msg::TODO_DONE_REPORT_FAILED));
                 }
             }
             app.mode = AppMode::Normal;
@@ -636,7 +630,7 @@ pub fn handle_confirm_report(app: &mut TodoApp, key: KeyEvent, config: &mut Yaml
         _ => {
             // 其他任意键跳过，不写入日报
             app.report_pending_content = None;
-            app.message = Some("✅ 已标记为完成".to_string());
+            app.message = Some(t!(msg::TODO_DONE));
             app.mode = AppMode::Normal;
         }
     }
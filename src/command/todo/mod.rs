@@ -2,7 +2,8 @@ pub mod app;
 pub mod ui;
 
 use crate::config::YamlConfig;
-use crate::{error, info, usage};
+use crate::util::i18n::msg;
+use crate::{error, info, t, usage};
 use app::{
     AppMode, TodoApp, TodoItem, handle_confirm_cancel_input, handle_confirm_delete,
     handle_confirm_report, handle_help_mode, handle_input_mode, handle_normal_mode, load_todo_list,
@@ -31,13 +32,13 @@ pub fn handle_todo(content: &[String], config: &mut YamlConfig) {
             let text = content[1..].join(" ");
             let text = text.trim().trim_matches('"').to_string();
             if text.is_empty() {
-                error!("⚠️ 内容为空，无法添加待办");
+                error!("{}", t!(msg::TODO_EMPTY));
                 return;
             }
             quick_add_todo(&text);
         }
         _ => {
-            usage!("j todo | j todo list | j todo add <内容>");
+            usage!("{}", t!(msg::TODO_USAGE));
         }
     }
 }
@@ -53,9 +54,9 @@ fn quick_add_todo(text: &str) {
     });
 
     if save_todo_list(&todo_list) {
-        info!("✅ 已添加待办: {}", text);
+        info!("{}", t!(msg::TODO_ADDED, text));
         let undone = todo_list.items.iter().filter(|i| !i.done).count();
-        info!("📋 当前未完成待办: {} 条", undone);
+        info!("{}", t!(msg::TODO_UNDONE_COUNT, undone));
     }
 }
 
@@ -64,7 +65,7 @@ fn handle_todo_list() {
     let todo_list = load_todo_list();
 
     if todo_list.items.is_empty() {
-        info!("📋 暂无待办");
+        info!("{}", t!(msg::TODO_NONE));
         return;
     }
 
@@ -72,10 +73,7 @@ fn handle_todo_list() {
     let done_count = todo_list.items.iter().filter(|i| i.done).count();
     let undone_count = total - done_count;
 
-    let mut md = format!(
-        "## 待办备忘录 — 共 {} 条 | ✅ {} | ⬜ {}\n\n",
-        total, done_count, undone_count
-    );
+    let mut md = t!(msg::TODO_LIST_HEADING, total, done_count, undone_count);
 
     for item in &todo_list.items {
         if item.done {
@@ -93,7 +91,7 @@ fn run_todo_tui(config: &mut YamlConfig) {
     match run_todo_tui_internal(config) {
         Ok(_) => {}
         Err(e) => {
-            error!("❌ TUI 启动失败: {}", e);
+            error!("{}", t!(msg::TODO_TUI_FAILED, e));
         }
     }
 }
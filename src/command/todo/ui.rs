@@ -3,6 +3,8 @@ use super::app::{
     split_input_at_cursor, truncate_to_width,
 };
 use crate::constants::todo_filter;
+use crate::t;
+use crate::util::i18n::{msg, text};
 use ratatui::{
     layout::{Constraint, Direction, Layout},
     style::{Color, Modifier, Style},
@@ -43,17 +45,14 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
 
     // ========== 标题栏 ==========
     let filter_label = match app.filter {
-        todo_filter::UNDONE => " [未完成]",
-        todo_filter::DONE => " [已完成]",
+        todo_filter::UNDONE => text(&msg::TODO_TITLE_UNDONE),
+        todo_filter::DONE => text(&msg::TODO_TITLE_DONE),
         _ => "",
     };
     let total = app.list.items.len();
     let done = app.list.items.iter().filter(|i| i.done).count();
     let undone = total - done;
-    let title = format!(
-        " 📋 待办备忘录{} — 共 {} 条 | ✅ {} | ⬜ {} ",
-        filter_label, total, done, undone
-    );
+    let title = t!(msg::TODO_TITLE, filter_label, total, done, undone);
     let title_block = Paragraph::new(Line::from(vec![Span::styled(
         title,
         Style::default()
@@ -71,7 +70,7 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
     if app.mode == AppMode::Help {
         let help_lines = vec![
             Line::from(Span::styled(
-                "  📖 快捷键帮助",
+                text(&msg::TODO_HELP_HEADING),
                 Style::default()
                     .fg(Color::Cyan)
                     .add_modifier(Modifier::BOLD),
@@ -79,78 +78,81 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
             Line::from(""),
             Line::from(vec![
                 Span::styled("  n / ↓ / j    ", Style::default().fg(Color::Yellow)),
-                Span::raw("向下移动"),
+                Span::raw(text(&msg::TODO_HELP_DOWN)),
             ]),
             Line::from(vec![
                 Span::styled("  N / ↑ / k    ", Style::default().fg(Color::Yellow)),
-                Span::raw("向上移动"),
+                Span::raw(text(&msg::TODO_HELP_UP)),
             ]),
             Line::from(vec![
-                Span::styled("  空格 / 回车   ", Style::default().fg(Color::Yellow)),
-                Span::raw("切换完成状态 [x] / [ ]"),
+                Span::styled(
+                    text(&msg::TODO_HELP_TOGGLE_KEY),
+                    Style::default().fg(Color::Yellow),
+                ),
+                Span::raw(text(&msg::TODO_HELP_TOGGLE)),
             ]),
             Line::from(vec![
                 Span::styled("  a            ", Style::default().fg(Color::Yellow)),
-                Span::raw("添加新待办"),
+                Span::raw(text(&msg::TODO_HELP_ADD)),
             ]),
             Line::from(vec![
                 Span::styled("  e            ", Style::default().fg(Color::Yellow)),
-                Span::raw("编辑选中待办"),
+                Span::raw(text(&msg::TODO_HELP_EDIT)),
             ]),
             Line::from(vec![
                 Span::styled("  d            ", Style::default().fg(Color::Yellow)),
-                Span::raw("删除待办（需确认）"),
+                Span::raw(text(&msg::TODO_HELP_DELETE)),
             ]),
             Line::from(vec![
                 Span::styled("  f            ", Style::default().fg(Color::Yellow)),
-                Span::raw("过滤切换（全部 / 未完成 / 已完成）"),
+                Span::raw(text(&msg::TODO_HELP_FILTER)),
             ]),
             Line::from(vec![
                 Span::styled("  J / K        ", Style::default().fg(Color::Yellow)),
-                Span::raw("调整待办顺序（下移 / 上移）"),
+                Span::raw(text(&msg::TODO_HELP_MOVE)),
             ]),
             Line::from(vec![
                 Span::styled("  s            ", Style::default().fg(Color::Yellow)),
-                Span::raw("手动保存"),
+                Span::raw(text(&msg::TODO_HELP_SAVE)),
             ]),
             Line::from(vec![
                 Span::styled("  y            ", Style::default().fg(Color::Yellow)),
-                Span::raw("复制选中待办到剪切板"),
+                Span::raw(text(&msg::TODO_HELP_COPY)),
             ]),
             Line::from(vec![
                 Span::styled("  q            ", Style::default().fg(Color::Yellow)),
-                Span::raw("退出（有未保存修改时需先保存或用 q! 强制退出）"),
+                Span::raw(text(&msg::TODO_HELP_QUIT)),
             ]),
             Line::from(vec![
                 Span::styled("  q!           ", Style::default().fg(Color::Yellow)),
-                Span::raw("强制退出（丢弃未保存的修改）"),
+                Span::raw(text(&msg::TODO_HELP_FORCE_QUIT)),
             ]),
             Line::from(vec![
                 Span::styled("  Esc          ", Style::default().fg(Color::Yellow)),
-                Span::raw("退出（同 q）"),
+                Span::raw(text(&msg::TODO_HELP_ESC)),
             ]),
             Line::from(vec![
                 Span::styled("  Ctrl+C       ", Style::default().fg(Color::Yellow)),
-                Span::raw("强制退出（不保存）"),
+                Span::raw(text(&msg::TODO_HELP_CTRL_C)),
             ]),
             Line::from(vec![
                 Span::styled("  ?            ", Style::default().fg(Color::Yellow)),
-                Span::raw("显示此帮助"),
+                Span::raw(text(&msg::TODO_HELP_HELP)),
             ]),
             Line::from(""),
             Line::from(Span::styled(
-                "  添加/编辑模式下：",
+                text(&msg::TODO_HELP_INPUT),
                 Style::default().fg(Color::Gray),
             )),
             Line::from(vec![
                 Span::styled("  Alt+↓/↑      ", Style::default().fg(Color::Yellow)),
-                Span::raw("预览区滚动（长文本输入时）"),
+                Span::raw(text(&msg::TODO_HELP_PREVIEW)),
             ]),
         ];
         let help_block = Block::default()
             .borders(Borders::ALL)
             .border_style(Style::default().fg(Color::Cyan))
-            .title(" 帮助 ");
+            .title(text(&msg::TODO_HELP_TITLE));
         let help_widget = Paragraph::new(help_lines).block(help_block);
         f.render_widget(help_widget, chunks[1]);
     } else {
@@ -206,11 +208,11 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
         let list_block = Block::default()
             .borders(Borders::ALL)
             .border_style(Style::default().fg(Color::White))
-            .title(" 待办列表 ");
+            .title(text(&msg::TODO_LIST_TITLE));
 
         if items.is_empty() {
             let empty_hint = List::new(vec![ListItem::new(Line::from(Span::styled(
-                "   (空) 按 a 添加新待办...",
+                text(&msg::TODO_LIST_EMPTY),
                 Style::default().fg(Color::DarkGray),
             )))])
             .block(list_block);
@@ -250,19 +252,19 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
         app.preview_scroll = clamped_scroll;
 
         let mode_label = match app.mode {
-            AppMode::Adding => "新待办",
-            AppMode::Editing => "编辑中",
-            _ => "预览",
+            AppMode::Adding => text(&msg::TODO_MODE_ADDING),
+            AppMode::Editing => text(&msg::TODO_MODE_EDITING),
+            _ => text(&msg::TODO_MODE_PREVIEW),
         };
         let title = if total_wrapped > preview_inner_h {
-            format!(
-                " 📖 {} 预览 [{}/{}行] Alt+↓/↑滚动 ",
+            t!(
+                msg::TODO_PREVIEW_SCROLL,
                 mode_label,
                 clamped_scroll + preview_inner_h,
                 total_wrapped
             )
         } else {
-            format!(" 📖 {} 预览 ", mode_label)
+            t!(msg::TODO_PREVIEW, mode_label)
         };
 
         let preview_block = Block::default()
@@ -300,7 +302,10 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
         AppMode::Adding => {
             let (before, cursor_ch, after) = split_input_at_cursor(&app.input, app.cursor_pos);
             let input_widget = Paragraph::new(Line::from(vec![
-                Span::styled(" 新待办: ", Style::default().fg(Color::Green)),
+                Span::styled(
+                    text(&msg::TODO_INPUT_NEW),
+                    Style::default().fg(Color::Green),
+                ),
                 Span::raw(before),
                 Span::styled(
                     cursor_ch,
@@ -312,14 +317,17 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
                 Block::default()
                     .borders(Borders::ALL)
                     .border_style(Style::default().fg(Color::Green))
-                    .title(" 添加模式 (Enter 确认 / Esc 取消 / ←→ 移动光标) "),
+                    .title(text(&msg::TODO_INPUT_ADD_TITLE)),
             );
             f.render_widget(input_widget, chunks[status_chunk_idx]);
         }
         AppMode::Editing => {
             let (before, cursor_ch, after) = split_input_at_cursor(&app.input, app.cursor_pos);
             let input_widget = Paragraph::new(Line::from(vec![
-                Span::styled(" 编辑: ", Style::default().fg(Color::Yellow)),
+                Span::styled(
+                    text(&msg::TODO_INPUT_EDIT),
+                    Style::default().fg(Color::Yellow),
+                ),
                 Span::raw(before),
                 Span::styled(
                     cursor_ch,
@@ -331,18 +339,15 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
                 Block::default()
                     .borders(Borders::ALL)
                     .border_style(Style::default().fg(Color::Yellow))
-                    .title(" 编辑模式 (Enter 确认 / Esc 取消 / ←→ 移动光标) "),
+                    .title(text(&msg::TODO_INPUT_EDIT_TITLE)),
             );
             f.render_widget(input_widget, chunks[status_chunk_idx]);
         }
         AppMode::ConfirmDelete => {
             let msg = if let Some(real_idx) = app.selected_real_index() {
-                format!(
-                    " 确认删除「{}」？(y 确认 / n 取消)",
-                    app.list.items[real_idx].content
-                )
+                t!(msg::TODO_CONFIRM_DELETE, app.list.items[real_idx].content)
             } else {
-                " 没有选中的项目".to_string()
+                t!(msg::TODO_NO_SELECTION)
             };
             let confirm_widget = Paragraph::new(Line::from(Span::styled(
                 msg,
@@ -352,7 +357,7 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
                 Block::default()
                     .borders(Borders::ALL)
                     .border_style(Style::default().fg(Color::Red))
-                    .title(" ⚠️ 确认删除 "),
+                    .title(text(&msg::TODO_CONFIRM_DELETE_TITLE)),
             );
             f.render_widget(confirm_widget, chunks[2]);
         }
@@ -360,15 +365,15 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
             let inner_width = chunks[status_chunk_idx].width.saturating_sub(2) as usize;
             let msg = if let Some(ref content) = app.report_pending_content {
                 // 预留前缀和后缀的显示宽度
-                let prefix = " 写入日报: \"";
-                let suffix = "\" ？ (Enter/y 写入, 其他跳过)";
+                let prefix = text(&msg::TODO_REPORT_PREFIX);
+                let suffix = text(&msg::TODO_REPORT_SUFFIX);
                 let prefix_w = display_width(prefix);
                 let suffix_w = display_width(suffix);
                 let budget = inner_width.saturating_sub(prefix_w + suffix_w);
                 let truncated = truncate_to_width(content, budget);
                 format!("{}{}{}", prefix, truncated, suffix)
             } else {
-                " 没有待写入的内容".to_string()
+                t!(msg::TODO_REPORT_NOTHING)
             };
             let confirm_widget = Paragraph::new(Line::from(Span::styled(
                 msg,
@@ -378,14 +383,14 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
                 Block::default()
                     .borders(Borders::ALL)
                     .border_style(Style::default().fg(Color::Cyan))
-                    .title(" 📝 写入日报 "),
+                    .title(text(&msg::TODO_REPORT_TITLE)),
             );
             f.render_widget(confirm_widget, chunks[status_chunk_idx]);
         }
         AppMode::ConfirmCancelInput => {
             let inner_width = chunks[status_chunk_idx].width.saturating_sub(2) as usize;
-            let prefix = " ⚠️ 是否保存？当前输入: \"";
-            let suffix = "\" (Enter/y 保存 / n/Esc 放弃 / 其他键继续编辑)";
+            let prefix = text(&msg::TODO_SAVE_PREFIX);
+            let suffix = text(&msg::TODO_SAVE_SUFFIX);
             let prefix_w = display_width(prefix);
             let suffix_w = display_width(suffix);
             let budget = inner_width.saturating_sub(prefix_w + suffix_w);
@@ -399,13 +404,20 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
                 Block::default()
                     .borders(Borders::ALL)
                     .border_style(Style::default().fg(Color::Yellow))
-                    .title(" ⚠️ 未保存的内容 "),
+                    .title(text(&msg::TODO_UNSAVED_TITLE)),
             );
             f.render_widget(confirm_widget, chunks[status_chunk_idx]);
         }
         AppMode::Normal | AppMode::Help => {
-            let msg = app.message.as_deref().unwrap_or("按 ? 查看完整帮助");
-            let dirty_indicator = if app.is_dirty() { " [未保存]" } else { "" };
+            let msg = app
+                .message
+                .as_deref()
+                .unwrap_or(text(&msg::TODO_STATUS_HINT));
+            let dirty_indicator = if app.is_dirty() {
+                text(&msg::TODO_DIRTY)
+            } else {
+                ""
+            };
             let status_widget = Paragraph::new(Line::from(vec![
                 Span::styled(msg, Style::default().fg(Color::Gray)),
                 Span::styled(
@@ -424,16 +436,12 @@ pub fn draw_ui(f: &mut ratatui::Frame, app: &mut TodoApp) {
 
     // ========== 帮助栏 ==========
     let help_text = match app.mode {
-        AppMode::Normal => {
-            " n/↓ 下移 | N/↑ 上移 | 空格/回车 切换完成 | a 添加 | e 编辑 | d 删除 | y 复制 | f 过滤 | s 保存 | ? 帮助 | q 退出"
-        }
-        AppMode::Adding | AppMode::Editing => {
-            " Enter 确认 | Esc 取消 | ←→ 移动光标 | Home/End 行首尾 | Alt+↓/↑ 预览滚动"
-        }
-        AppMode::ConfirmDelete => " y 确认删除 | n/Esc 取消",
-        AppMode::ConfirmReport => " Enter/y 写入日报并保存 | 其他键 跳过",
-        AppMode::ConfirmCancelInput => " Enter/y 保存 | n/Esc 放弃 | 其他键 继续编辑",
-        AppMode::Help => " 按任意键返回",
+        AppMode::Normal => text(&msg::TODO_KEYS_NORMAL),
+        AppMode::Adding | AppMode::Editing => text(&msg::TODO_KEYS_INPUT),
+        AppMode::ConfirmDelete => text(&msg::TODO_KEYS_CONFIRM_DELETE),
+        AppMode::ConfirmReport => text(&msg::TODO_KEYS_CONFIRM_REPORT),
+        AppMode::ConfirmCancelInput => text(&msg::TODO_KEYS_CONFIRM_CANCEL),
+        AppMode::Help => text(&msg::TODO_KEYS_HELP),
     };
     let help_widget = Paragraph::new(Line::from(Span::styled(
         help_text,
@@ -1,6 +1,7 @@
 use crate::config::YamlConfig;
 use crate::constants::voice as vc;
-use crate::{error, info};
+use crate::util::i18n::{msg, text};
+use crate::{error, info, t};
 use colored::Colorize;
 use std::io::Write;
 use std::path::PathBuf;
@@ -26,7 +27,7 @@ struct RawModeGuard;
 
 impl RawModeGuard {
     fn enter() -> Result<Self, String> {
-        crossterm::terminal::enable_raw_mode().map_err(|e| format!("启用 raw mode 失败: {}", e))?;
+        crossterm::terminal::enable_raw_mode().map_err(|e| t!(msg::VOICE_RAW_MODE_FAILED, e))?;
         Ok(Self)
     }
 }
@@ -48,11 +49,11 @@ fn start_recording_stream(
     let host = cpal::default_host();
     let device = host
         .default_input_device()
-        .ok_or_else(|| "未找到麦克风设备，请检查音频输入设备".to_string())?;
+        .ok_or_else(|| text(&msg::VOICE_NO_MICROPHONE).to_string())?;
 
     let supported_config = device
         .default_input_config()
-        .map_err(|e| format!("获取设备默认输入配置失败: {}", e))?;
+        .map_err(|e| t!(msg::VOICE_INPUT_CONFIG_FAILED, e))?;
 
     let sample_rate = supported_config.sample_rate();
     let channels = supported_config.channels();
@@ -74,13 +75,15 @@ fn start_recording_stream(
                 buf.extend_from_slice(data);
             },
             move |err| {
-                eprintln!("录音流错误: {}", err);
+                eprintln!("{}", t!(msg::VOICE_STREAM_ERROR, err));
             },
             None,
         )
-        .map_err(|e| format!("创建录音流失败: {}", e))?;
+        .map_err(|e| t!(msg::VOICE_STREAM_CREATE_FAILED, e))?;
 
-    stream.play().map_err(|e| format!("启动录音失败: {}", e))?;
+    stream
+        .play()
+        .map_err(|e| t!(msg::VOICE_RECORD_START_FAILED, e))?;
 
     Ok((stream, sample_rate, channels))
 }
@@ -111,7 +114,7 @@ fn transcribe_from_samples(model_path: &PathBuf, samples: &[f32]) -> Result<Stri
     use whisper_rs::{FullParams, SamplingStrategy, WhisperContext, WhisperContextParameters};
 
     if samples.is_empty() {
-        return Err("音频数据为空".to_string());
+        return Err(text(&msg::VOICE_AUDIO_EMPTY).to_string());
     }
 
     let _stderr_guard = suppress_stderr();
@@ -120,11 +123,11 @@ fn transcribe_from_samples(model_path: &PathBuf, samples: &[f32]) -> Result<Stri
         model_path.to_str().unwrap_or(""),
         WhisperContextParameters::default(),
     )
-    .map_err(|e| format!("加载 Whisper 模型失败: {}", e))?;
+    .map_err(|e| t!(msg::VOICE_MODEL_LOAD_FAILED, e))?;
 
     let mut state = ctx
         .create_state()
-        .map_err(|e| format!("创建 Whisper 状态失败: {}", e))?;
+        .map_err(|e| t!(msg::VOICE_STATE_FAILED, e))?;
 
     let mut params = FullParams::new(SamplingStrategy::Greedy { best_of: 1 });
     params.set_language(Some("zh"));
@@ -136,7 +139,7 @@ fn transcribe_from_samples(model_path: &PathBuf, samples: &[f32]) -> Result<Stri
 
     state
         .full(params, samples)
-        .map_err(|e| format!("Whisper 转写失败: {}", e))?;
+        .map_err(|e| t!(msg::VOICE_WHISPER_FAILED, e))?;
 
     let num_segments = state.full_n_segments();
     let mut result = String::new();
@@ -290,7 +293,7 @@ fn record_and_transcribe_streaming(model_path: &PathBuf) -> Result<String, Strin
     // 最终完整转写确保精度
     let raw_data = raw_samples.lock().unwrap();
     if raw_data.is_empty() {
-        return Err("未录到任何音频数据".to_string());
+        return Err(text(&msg::VOICE_NOTHING_RECORDED).to_string());
     }
 
     let processed = process_raw_audio(&raw_data, sample_rate, channels);
@@ -299,12 +302,17 @@ fn record_and_transcribe_streaming(model_path: &PathBuf) -> Result<String, Strin
     // 换行（之前流式输出可能没换行）
     println!();
     info!(
-        "📊 录音时长: {:.1}s (设备: {}Hz {}ch → 16kHz 单声道)",
-        duration_secs, sample_rate, channels
+        "{}",
+        t!(
+            msg::VOICE_DURATION_DEVICE,
+            format!("{:.1}", duration_secs),
+            sample_rate,
+            channels
+        )
     );
 
     if processed.is_empty() || duration_secs < vc::MIN_AUDIO_SECS as f64 {
-        return Err("录音时间过短".to_string());
+        return Err(text(&msg::VOICE_TOO_SHORT).to_string());
     }
 
     // 清除之前的流式输出，用最终结果替代
@@ -333,7 +341,7 @@ pub fn handle_voice(action: &str, copy: bool, model_size: Option<&str>, _config:
     let model = if let Some(m) = model_size {
         m.to_string()
     } else if let Some(best) = detect_best_model() {
-        info!("🔍 自动检测到模型: {}", best.cyan().bold());
+        info!("{}", t!(msg::VOICE_MODEL_DETECTED, best.cyan().bold()));
         best.to_string()
     } else {
         vc::DEFAULT_MODEL.to_string()
@@ -342,9 +350,8 @@ pub fn handle_voice(action: &str, copy: bool, model_size: Option<&str>, _config:
     // 验证模型大小
     if !vc::MODEL_SIZES.contains(&model.as_str()) {
         error!(
-            "不支持的模型大小: {}，可选: {}",
-            model,
-            vc::MODEL_SIZES.join(", ")
+            "{}",
+            t!(msg::VOICE_BAD_MODEL, model, vc::MODEL_SIZES.join(", "))
         );
         return;
     }
@@ -355,23 +362,29 @@ pub fn handle_voice(action: &str, copy: bool, model_size: Option<&str>, _config:
     }
 
     if !action.is_empty() {
-        error!("未知操作: {}，可用操作: download", action);
-        crate::usage!("voice [-c] [-m <model>] 或 voice download [-m <model>]");
+        error!("{}", t!(msg::VOICE_UNKNOWN_ACTION, action));
+        crate::usage!("{}", t!(msg::VOICE_USAGE));
         return;
     }
 
     // 检查模型是否存在
     let model_path = get_model_path(&model);
     if !model_path.exists() {
-        error!("模型文件不存在: {}", model_path.display());
+        error!("{}", t!(msg::VOICE_MODEL_MISSING, model_path.display()));
         info!(
-            "💡 请先下载模型: {} 或 {}",
-            format!("j voice download -m {}", model).cyan(),
-            format!("j voice download").cyan()
+            "{}",
+            t!(
+                msg::VOICE_DOWNLOAD_HINT_EITHER,
+                format!("j voice download -m {}", model).cyan(),
+                format!("j voice download").cyan()
+            )
         );
         info!(
-            "💡 也可以手动下载模型放到: {}",
-            model_path.display().to_string().cyan()
+            "{}",
+            t!(
+                msg::VOICE_MANUAL_HINT,
+                model_path.display().to_string().cyan()
+            )
         );
         return;
     }
@@ -383,24 +396,33 @@ pub fn handle_voice(action: &str, copy: bool, model_size: Option<&str>, _config:
     let min_size = expected_min_size_mb(&model);
     if file_size_mb < min_size {
         error!(
-            "模型文件不完整: {} ({} MB，期望至少 {} MB)",
-            model_path.display(),
-            file_size_mb,
-            min_size
+            "{}",
+            t!(
+                msg::VOICE_MODEL_INCOMPLETE,
+                model_path.display(),
+                file_size_mb,
+                min_size
+            )
         );
         info!(
-            "💡 请删除后重新下载: {} && {}",
-            format!("rm {}", model_path.display()).cyan(),
-            format!("j voice download -m {}", model).cyan()
+            "{}",
+            t!(
+                msg::VOICE_REDOWNLOAD_HINT,
+                format!("rm {}", model_path.display()).cyan(),
+                format!("j voice download -m {}", model).cyan()
+            )
         );
         return;
     }
 
     info!(
-        "🎙️  按 {} 开始录音，录音中按 {} 或 {} 结束",
-        "回车".green().bold(),
-        "回车".red().bold(),
-        "Ctrl+C".red().bold()
+        "{}",
+        t!(
+            msg::VOICE_PRESS_TO_START,
+            text(&msg::VOICE_KEY_ENTER).green().bold(),
+            text(&msg::VOICE_KEY_ENTER).red().bold(),
+            "Ctrl+C".red().bold()
+        )
     );
 
     // 等待用户按回车开始（使用 crossterm raw mode 避免与交互模式冲突）
@@ -433,19 +455,22 @@ pub fn handle_voice(action: &str, copy: bool, model_size: Option<&str>, _config:
 
     println!();
     info!(
-        "🔴 录音中... 按 {} 或 {} 结束录音",
-        "回车".red().bold(),
-        "Ctrl+C".red().bold()
+        "{}",
+        t!(
+            msg::VOICE_RECORDING_STOP,
+            text(&msg::VOICE_KEY_ENTER).red().bold(),
+            "Ctrl+C".red().bold()
+        )
     );
 
     match record_and_transcribe_streaming(&model_path) {
         Ok(text) => {
             let text = text.trim().to_string();
             if text.is_empty() {
-                info!("⚠️  未识别到语音内容");
+                info!("{}", t!(msg::VOICE_NO_SPEECH));
             } else {
                 println!();
-                info!("📝 转写结果:");
+                info!("{}", t!(msg::VOICE_RESULT));
                 println!("{}", text);
 
                 if copy {
@@ -465,7 +490,7 @@ pub fn handle_voice(action: &str, copy: bool, model_size: Option<&str>, _config:
 /// 返回转写文本（可能为空字符串）
 pub fn do_voice_record_for_interactive() -> String {
     let model = if let Some(best) = detect_best_model() {
-        info!("🔍 自动检测到模型: {}", best.cyan().bold());
+        info!("{}", t!(msg::VOICE_MODEL_DETECTED, best.cyan().bold()));
         best.to_string()
     } else {
         vc::DEFAULT_MODEL.to_string()
@@ -473,8 +498,11 @@ pub fn do_voice_record_for_interactive() -> String {
 
     let model_path = get_model_path(&model);
     if !model_path.exists() {
-        error!("模型文件不存在: {}", model_path.display());
-        info!("💡 请先下载模型: {}", format!("j voice download").cyan());
+        error!("{}", t!(msg::VOICE_MODEL_MISSING, model_path.display()));
+        info!(
+            "{}",
+            t!(msg::VOICE_DOWNLOAD_HINT, format!("j voice download").cyan())
+        );
         return String::new();
     }
 
@@ -482,14 +510,17 @@ pub fn do_voice_record_for_interactive() -> String {
         .map(|m| m.len() / 1024 / 1024)
         .unwrap_or(0);
     if file_size_mb < expected_min_size_mb(&model) {
-        error!("模型文件不完整，请重新下载");
+        error!("{}", t!(msg::VOICE_MODEL_INCOMPLETE_SHORT));
         return String::new();
     }
 
     info!(
-        "🔴 录音中... 按 {} 或 {} 结束",
-        "Ctrl+V".red().bold(),
-        "Ctrl+C".red().bold()
+        "{}",
+        t!(
+            msg::VOICE_RECORDING,
+            "Ctrl+V".red().bold(),
+            "Ctrl+C".red().bold()
+        )
     );
 
     let recording = Arc::new(AtomicBool::new(true));
@@ -571,7 +602,7 @@ pub fn do_voice_record_for_interactive() -> String {
     let raw_data = raw_samples.lock().unwrap();
     if raw_data.is_empty() {
         println!();
-        info!("⚠️  未录到音频数据");
+        info!("{}", t!(msg::VOICE_NO_AUDIO));
         return String::new();
     }
 
@@ -579,26 +610,29 @@ pub fn do_voice_record_for_interactive() -> String {
     let duration_secs = processed.len() as f64 / vc::SAMPLE_RATE as f64;
 
     println!();
-    info!("📊 录音时长: {:.1}s", duration_secs);
+    info!(
+        "{}",
+        t!(msg::VOICE_DURATION, format!("{:.1}", duration_secs))
+    );
 
     if processed.is_empty() || duration_secs < vc::MIN_AUDIO_SECS as f64 {
-        info!("⚠️  录音时间过短");
+        info!("{}", t!(msg::VOICE_TOO_SHORT_WARN));
         return String::new();
     }
 
-    info!("✅ 转写中...");
+    info!("{}", t!(msg::VOICE_TRANSCRIBING));
     match transcribe_from_samples(&model_path, &processed) {
         Ok(text) => {
             let text = text.trim().to_string();
             if text.is_empty() {
-                info!("⚠️  未识别到语音内容");
+                info!("{}", t!(msg::VOICE_NO_SPEECH));
             } else {
                 info!("📝 {}", &text);
             }
             text
         }
         Err(e) => {
-            error!("[voice] 转写失败: {}", e);
+            error!("{}", t!(msg::VOICE_TRANSCRIBE_FAILED, e));
             String::new()
         }
     }
@@ -606,10 +640,10 @@ pub fn do_voice_record_for_interactive() -> String {
 
 // ========== 辅助函数 ==========
 
-/// 获取模型文件路径: ~/.jdata/voice/model/ggml-<size>.bin
+/// 获取模型文件路径: ~/.jdata/voice/model/ggml-<size>.bin（位于数据根目录，各 profile 共用）
 fn get_model_path(model_size: &str) -> PathBuf {
     let model_file = vc::MODEL_FILE_TEMPLATE.replace("{}", model_size);
-    let voice_dir = YamlConfig::data_dir()
+    let voice_dir = crate::config::profile::root_dir()
         .join(vc::VOICE_DIR)
         .join(vc::MODEL_DIR);
     let _ = std::fs::create_dir_all(&voice_dir);
@@ -656,29 +690,41 @@ fn download_model(model_size: &str) {
 
         if file_size_mb < min_size {
             info!(
-                "⚠️  模型文件不完整: {} ({} MB，期望至少 {} MB)",
-                model_path.display(),
-                file_size_mb,
-                min_size
+                "{}",
+                t!(
+                    msg::VOICE_MODEL_INCOMPLETE_WARN,
+                    model_path.display(),
+                    file_size_mb,
+                    min_size
+                )
             );
-            info!("🔄 删除不完整文件，重新下载...");
+            info!("{}", t!(msg::VOICE_REDOWNLOADING));
             let _ = std::fs::remove_file(&model_path);
         } else {
             info!(
-                "✅ 模型已存在: {} ({:.1} MB)",
-                model_path.display(),
-                file_size as f64 / 1024.0 / 1024.0
+                "{}",
+                t!(
+                    msg::VOICE_MODEL_EXISTS,
+                    model_path.display(),
+                    format!("{:.1}", file_size as f64 / 1024.0 / 1024.0)
+                )
             );
-            info!("💡 如需重新下载，请先删除模型文件");
+            info!("{}", t!(msg::VOICE_DELETE_TO_REDOWNLOAD));
             return;
         }
     }
 
     let url = vc::MODEL_URL_TEMPLATE.replace("{}", model_size);
 
-    info!("📥 下载 Whisper {} 模型...", model_size.cyan().bold());
+    info!("{}", t!(msg::VOICE_DOWNLOADING, model_size.cyan().bold()));
     info!("   URL: {}", url.dimmed());
-    info!("   保存到: {}", model_path.display().to_string().dimmed());
+    info!(
+        "{}",
+        t!(
+            msg::VOICE_SAVE_TO,
+            model_path.display().to_string().dimmed()
+        )
+    );
     println!();
 
     let status = std::process::Command::new("curl")
@@ -701,36 +747,36 @@ fn download_model(model_size: &str) {
             let min_size = expected_min_size_mb(model_size);
             if file_size_mb < min_size {
                 error!(
-                    "下载的文件不完整 ({} MB，期望至少 {} MB)",
-                    file_size_mb, min_size
+                    "{}",
+                    t!(msg::VOICE_DOWNLOAD_INCOMPLETE, file_size_mb, min_size)
                 );
+                error!("{}", t!(msg::VOICE_DOWNLOAD_MANUAL, model_path.display()));
                 error!(
-                    "请检查网络连接，或手动下载模型文件到: {}",
-                    model_path.display()
-                );
-                error!(
-                    "手动下载链接: {}",
-                    vc::MODEL_URL_TEMPLATE.replace("{}", model_size)
+                    "{}",
+                    t!(
+                        msg::VOICE_DOWNLOAD_URL,
+                        vc::MODEL_URL_TEMPLATE.replace("{}", model_size)
+                    )
                 );
                 let _ = std::fs::remove_file(&model_path);
                 return;
             }
             println!();
             info!(
-                "✅ 模型下载完成: {} ({:.1} MB)",
-                model_size.green().bold(),
-                file_size as f64 / 1024.0 / 1024.0
+                "{}",
+                t!(
+                    msg::VOICE_DOWNLOADED,
+                    model_size.green().bold(),
+                    format!("{:.1}", file_size as f64 / 1024.0 / 1024.0)
+                )
             );
         }
         Ok(_) => {
-            error!("模型下载失败，请检查网络连接");
+            error!("{}", t!(msg::VOICE_DOWNLOAD_FAILED));
             let _ = std::fs::remove_file(&model_path);
         }
         Err(e) => {
-            error!(
-                "[download_model] 执行 curl 失败: {}，请确保系统安装了 curl",
-                e
-            );
+            error!("{}", t!(msg::VOICE_CURL_FAILED, e));
         }
     }
 }
@@ -743,7 +789,7 @@ fn copy_to_clipboard(text: &str) {
     {
         Ok(c) => c,
         Err(e) => {
-            error!("[copy_to_clipboard] 无法调用 pbcopy: {}", e);
+            error!("{}", t!(msg::VOICE_PBCOPY_ERROR, e));
             return;
         }
     };
@@ -753,8 +799,8 @@ fn copy_to_clipboard(text: &str) {
     }
 
     match child.wait() {
-        Ok(_) => info!("📋 已复制到剪贴板"),
-        Err(e) => error!("[copy_to_clipboard] pbcopy 执行失败: {}", e),
+        Ok(_) => info!("{}", t!(msg::VOICE_COPIED)),
+        Err(e) => error!("{}", t!(msg::VOICE_PBCOPY_FAILED, e)),
     }
 }
 
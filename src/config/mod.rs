@@ -1,3 +1,4 @@
+pub mod profile;
 pub mod yaml_config;
 
 pub use yaml_config::YamlConfig;
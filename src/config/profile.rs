@@ -0,0 +1,121 @@
+//! profile：彼此独立的数据目录，用于隔离不同身份（如 work / personal）的配置、API Key 与历史
+//!
+//! 默认 profile 的数据直接存放在数据根目录（`~/.jdata/`），其他 profile 存放在 `~/.jdata/profiles/<name>/`，
+//! 插件二进制（`bin/`）各 profile 共用。当前 profile 按 `--profile` / `J_PROFILE` → `~/.jdata/profile` 文件 → default 的顺序确定，
+//! Go 插件按同样的规则定位数据目录。
+
+use crate::constants::{self, profile};
+use crate::t;
+use crate::util::i18n::msg;
+use std::fs;
+use std::io;
+use std::path::PathBuf;
+
+/// 获取数据根目录: 优先 J_DATA_PATH，否则 ~/.jdata/（不区分 profile）
+pub fn root_dir() -> PathBuf {
+    if let Ok(path) = std::env::var(constants::DATA_PATH_ENV) {
+        return PathBuf::from(path);
+    }
+    dirs::home_dir()
+        .unwrap_or_else(|| PathBuf::from("."))
+        .join(constants::DATA_DIR)
+}
+
+/// profile 名称是否合法：只允许字母、数字、`-` 与 `_`
+pub fn is_valid_name(name: &str) -> bool {
+    !name.is_empty()
+        && name.len() <= 64
+        && name
+            .chars()
+            .all(|c| c.is_ascii_alphanumeric() || c == '-' || c == '_')
+}
+
+/// 当前 profile 的名称及来源（环境变量为 true）
+pub fn active() -> (String, bool) {
+    if let Ok(name) = std::env::var(profile::ENV) {
+        let name = name.trim();
+        if !name.is_empty() {
+            return (name.to_string(), true);
+        }
+    }
+    let saved = fs::read_to_string(root_dir().join(profile::ACTIVE_FILE)).unwrap_or_default();
+    let saved = saved.trim();
+    if saved.is_empty() {
+        (profile::DEFAULT.to_string(), false)
+    } else {
+        (saved.to_string(), false)
+    }
+}
+
+/// profile 的数据目录；名称不合法时退回默认 profile（由 check_active 在启动时报错）
+pub fn dir(name: &str) -> PathBuf {
+    let root = root_dir();
+    if name == profile::DEFAULT || !is_valid_name(name) {
+        root
+    } else {
+        root.join(profile::DIR).join(name)
+    }
+}
+
+/// profile 是否存在（默认 profile 总是存在）
+pub fn exists(name: &str) -> bool {
+    name == profile::DEFAULT || (is_valid_name(name) && dir(name).is_dir())
+}
+
+/// 全部 profile：default 在前，其余按名称排序
+pub fn list() -> Vec<String> {
+    let mut names: Vec<String> = fs::read_dir(root_dir().join(profile::DIR))
+        .map(|entries| {
+            entries
+                .flatten()
+                .filter(|e| e.path().is_dir())
+                .filter_map(|e| e.file_name().into_string().ok())
+                .filter(|n| is_valid_name(n) && n != profile::DEFAULT)
+                .collect()
+        })
+        .unwrap_or_default();
+    names.sort();
+    names.insert(0, profile::DEFAULT.to_string());
+    names
+}
+
+/// 校验当前 profile：名称不合法或尚未创建时返回错误说明，避免数据写进意料之外的目录
+pub fn check_active() -> Result<(), String> {
+    let (name, from_env) = active();
+    let source = if from_env {
+        format!("--profile / {}", profile::ENV)
+    } else {
+        root_dir().join(profile::ACTIVE_FILE).display().to_string()
+    };
+    if !is_valid_name(&name) {
+        return Err(t!(
+            msg::PROFILE_INVALID_SOURCE,
+            format!("{:?}", name),
+            source
+        ));
+    }
+    if !exists(&name) {
+        return Err(t!(msg::PROFILE_MISSING_SOURCE, name, source, name));
+    }
+    Ok(())
+}
+
+/// 创建 profile 的数据目录
+pub fn create(name: &str) -> io::Result<PathBuf> {
+    let path = dir(name);
+    fs::create_dir_all(&path)?;
+    Ok(path)
+}
+
+/// 把 name 记为之后默认使用的 profile
+pub fn switch(name: &str) -> io::Result<()> {
+    let path = root_dir().join(profile::ACTIVE_FILE);
+    if name == profile::DEFAULT {
+        return match fs::remove_file(&path) {
+            Err(e) if e.kind() != io::ErrorKind::NotFound => Err(e),
+            _ => Ok(()),
+        };
+    }
+    fs::create_dir_all(root_dir())?;
+    fs::write(&path, format!("{}\n", name))
+}
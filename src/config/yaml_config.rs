@@ -1,4 +1,6 @@
 use crate::constants::{self, config_key, section};
+use crate::t;
+use crate::util::i18n::msg;
 use serde::{Deserialize, Serialize};
 use std::collections::BTreeMap;
 use std::fs;
@@ -47,16 +49,9 @@ pub struct YamlConfig {
 }
 
 impl YamlConfig {
-    /// 获取数据根目录: ~/.jdata/
+    /// 获取数据目录: ~/.jdata/（J_DATA_PATH 可覆盖），使用非默认 profile 时为 ~/.jdata/profiles/<name>/
     pub fn data_dir() -> PathBuf {
-        // 优先使用环境变量指定的数据路径
-        if let Ok(path) = std::env::var(constants::DATA_PATH_ENV) {
-            return PathBuf::from(path);
-        }
-        // 默认路径: ~/.jdata/
-        dirs::home_dir()
-            .unwrap_or_else(|| PathBuf::from("."))
-            .join(constants::DATA_DIR)
+        super::profile::dir(&super::profile::active().0)
     }
 
     /// 获取配置文件路径: ~/.jdata/config.yaml
@@ -109,18 +104,18 @@ impl YamlConfig {
         if !path.exists() {
             // 配置文件不存在，创建默认配置
             let config = Self::default_config();
-            eprintln!("[INFO] 创建默认配置文件: {:?}", path);
+            eprintln!("{}", t!(msg::CONFIG_CREATED, path.display()));
             config.save();
             return config;
         }
 
         let content = fs::read_to_string(&path).unwrap_or_else(|e| {
-            eprintln!("[ERROR] 读取配置文件失败: {}, 路径: {:?}", e, path);
+            eprintln!("{}", t!(msg::CONFIG_READ_FAILED, e, path.display()));
             String::new()
         });
 
         serde_yaml::from_str(&content).unwrap_or_else(|e| {
-            eprintln!("[ERROR] 解析配置文件失败: {}, 路径: {:?}", e, path);
+            eprintln!("{}", t!(msg::CONFIG_PARSE_FAILED, e, path.display()));
             Self::default_config()
         })
     }
@@ -132,17 +127,17 @@ impl YamlConfig {
         // 确保目录存在
         if let Some(parent) = path.parent() {
             fs::create_dir_all(parent).unwrap_or_else(|e| {
-                eprintln!("[ERROR] 创建配置目录失败: {}", e);
+                crate::error!("{}", t!(msg::CONFIG_DIR_FAILED, e));
             });
         }
 
         let content = serde_yaml::to_string(self).unwrap_or_else(|e| {
-            eprintln!("[ERROR] 序列化配置失败: {}", e);
+            crate::error!("{}", t!(msg::CONFIG_SERIALIZE_FAILED, e));
             String::new()
         });
 
         fs::write(&path, content).unwrap_or_else(|e| {
-            eprintln!("[ERROR] 保存配置文件失败: {}, 路径: {:?}", e, path);
+            crate::error!("{}", t!(msg::CONFIG_SAVE_FAILED, e, path.display()));
         });
     }
 
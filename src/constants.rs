@@ -150,6 +150,20 @@ pub mod config_key {
     pub const WEEK_NUM: &str = "week_num";
     pub const LAST_DAY: &str = "last_day";
     pub const GIT_REPO: &str = "git_repo";
+    /// 输错命令时是否询问改为执行最接近的命令（默认开启，off 关闭）
+    pub const AUTOCORRECT: &str = "autocorrect";
+    /// 是否写入结构化文件日志（默认关闭，J_LOG_FILE 优先）
+    pub const LOG_FILE: &str = "log_file";
+    /// 单个日志文件的大小上限（MB）
+    pub const LOG_FILE_MAX_SIZE: &str = "log_file_max_size";
+    /// 轮换出的旧日志文件保留的天数
+    pub const LOG_FILE_MAX_AGE: &str = "log_file_max_age";
+    /// 最多保留的旧日志文件个数
+    pub const LOG_FILE_MAX_BACKUPS: &str = "log_file_max_backups";
+    /// 无障碍模式：不用颜色、进度条与动画，Markdown 以文字说明结构（默认关闭，J_ACCESSIBLE 优先）
+    pub const ACCESSIBLE: &str = "accessible";
+    /// 界面语言：zh-CN 或 en（J_LANG 优先，未设置时按 LC_ALL / LC_MESSAGES / LANG）
+    pub const LANG: &str = "lang";
 }
 
 // ========== 搜索引擎 ==========
@@ -222,12 +236,21 @@ pub mod cmd {
     // shell 补全
     pub const COMPLETION: &[&str] = &["completion"];
 
+    // 生成文档
+    pub const GEN: &[&str] = &["gen"];
+
+    // 使用与运行时统计（交给 agent 插件）
+    pub const STATS: &[&str] = &["stats"];
+
     // AI 对话
     pub const CHAT: &[&str] = &["chat", "ai"];
 
     // 语音转文字
     pub const VOICE: &[&str] = &["voice", "vc"];
 
+    // profile
+    pub const PROFILE: &[&str] = &["profile"];
+
     // agent（预留）
     pub const AGENT: &[&str] = &["agent"];
     pub const SYSTEM: &[&str] = &["system", "ps"];
@@ -237,7 +260,7 @@ pub mod cmd {
         let groups: &[&[&str]] = &[
             SET, REMOVE, RENAME, MODIFY, NOTE, DENOTE, LIST, CONTAIN, REPORT, REPORTCTL, CHECK,
             SEARCH, TODO, CHAT, CONCAT, TIME, LOG, CHANGE, CLEAR, VERSION, HELP, EXIT, COMPLETION,
-            VOICE, AGENT, SYSTEM,
+            GEN, STATS, VOICE, PROFILE, AGENT, SYSTEM,
         ];
         groups.iter().flat_map(|g| g.iter().copied()).collect()
     }
@@ -300,6 +323,9 @@ pub const AGENT_DIR: &str = "agent";
 /// agent 日志目录名
 pub const AGENT_LOG_DIR: &str = "logs";
 
+/// 结构化文件日志的目录名（位于数据目录下，与 agent 插件共用）
+pub const LOG_DIR: &str = "logs";
+
 /// 日报默认文件名
 pub const REPORT_DEFAULT_FILE: &str = "week_report.md";
 
@@ -309,6 +335,32 @@ pub const DATA_DIR: &str = ".jdata";
 /// 数据路径环境变量名
 pub const DATA_PATH_ENV: &str = "J_DATA_PATH";
 
+/// 插件二进制目录名（位于数据根目录下，各 profile 共用）
+pub const BIN_DIR: &str = "bin";
+
+/// man page 目录名（位于数据根目录下，j gen man 默认写入其中的 man1/）
+pub const MAN_DIR: &str = "man";
+
+/// profile 相关常量
+pub mod profile {
+    /// 选择 profile 的环境变量名（`j --profile <name>` 同样通过它传给插件）
+    pub const ENV: &str = "J_PROFILE";
+    /// 命令行全局参数
+    pub const FLAG: &str = "--profile";
+    /// 非默认 profile 的存放目录（位于数据根目录下）
+    pub const DIR: &str = "profiles";
+    /// 记录当前 profile 的文件（位于数据根目录下）
+    pub const ACTIVE_FILE: &str = "profile";
+    /// 默认 profile，数据直接存放在数据根目录
+    pub const DEFAULT: &str = "default";
+    /// 子命令: 列出
+    pub const ACTION_LIST: &str = "list";
+    /// 子命令: 创建
+    pub const ACTION_CREATE: &str = "create";
+    /// 子命令: 切换
+    pub const ACTION_SWITCH: &str = "switch";
+}
+
 // ========== Shell 命令 ==========
 
 // ========== 语音转文字 ==========
@@ -362,12 +414,13 @@ pub mod todo_filter {
     /// 过滤模式总数
     pub const COUNT: usize = 3;
 
-    /// 获取过滤模式标签
+    /// 获取过滤模式标签（当前界面语言）
     pub fn label(filter: usize) -> &'static str {
+        use crate::util::i18n::{msg, text};
         match filter {
-            UNDONE => "未完成",
-            DONE => "已完成",
-            _ => "全部",
+            UNDONE => text(&msg::TODO_FILTER_UNDONE),
+            DONE => text(&msg::TODO_FILTER_DONE),
+            _ => text(&msg::TODO_FILTER_ALL),
         }
     }
 
@@ -2,7 +2,7 @@ use crate::command;
 use crate::config::YamlConfig;
 use crate::constants::{
     self, ALIAS_PATH_SECTIONS, ALL_SECTIONS, LIST_ALL, NOTE_CATEGORIES, cmd, config_key,
-    rmeta_action, search_flag, time_function, voice as vc,
+    profile as pf, rmeta_action, search_flag, time_function, voice as vc,
 };
 use rustyline::completion::{Completer, Pair};
 use rustyline::highlight::CmdKind;
@@ -167,9 +167,31 @@ pub fn command_completion_rules() -> Vec<(&'static [&'static str], Vec<ArgHint>)
                 ArgHint::Placeholder("<duration>"),
             ],
         ),
+        (
+            cmd::PROFILE,
+            vec![
+                ArgHint::Fixed(vec![pf::ACTION_LIST, pf::ACTION_CREATE, pf::ACTION_SWITCH]),
+                ArgHint::Placeholder("<name>"),
+            ],
+        ),
         (cmd::COMPLETION, vec![ArgHint::Fixed(vec!["zsh", "bash"])]),
+        (
+            cmd::GEN,
+            vec![
+                ArgHint::Fixed(vec!["man"]),
+                ArgHint::Fixed(vec!["-o"]),
+                ArgHint::FilePath,
+            ],
+        ),
+        (
+            cmd::STATS,
+            vec![
+                ArgHint::Fixed(vec!["reset", "metrics"]),
+                ArgHint::Fixed(vec!["--json"]),
+            ],
+        ),
         (cmd::VERSION, vec![]),
-        (cmd::HELP, vec![]),
+        (cmd::HELP, vec![ArgHint::Placeholder("<plugin>")]),
         (cmd::CLEAR, vec![]),
         (cmd::EXIT, vec![]),
     ]
@@ -5,7 +5,8 @@ pub mod shell;
 use crate::command::voice::do_voice_record_for_interactive;
 use crate::config::YamlConfig;
 use crate::constants::{self, cmd};
-use crate::{error, info};
+use crate::util::i18n::{msg, text};
+use crate::{error, info, t};
 use colored::Colorize;
 use completer::CopilotHelper;
 use parser::execute_interactive_command;
@@ -83,7 +84,7 @@ pub fn run_interactive(config: &mut YamlConfig) {
     let helper = CopilotHelper::new(config);
 
     let mut rl: Editor<CopilotHelper, DefaultHistory> =
-        Editor::with_config(rl_config).expect("无法初始化编辑器");
+        Editor::with_config(rl_config).expect(text(&msg::INTERACTIVE_EDITOR_INIT_FAILED));
     rl.set_helper(Some(helper));
 
     rl.bind_sequence(
@@ -108,7 +109,16 @@ pub fn run_interactive(config: &mut YamlConfig) {
 
     inject_envs_to_process(config);
 
-    let prompt = format!("{} ", constants::INTERACTIVE_PROMPT.yellow());
+    // 使用非默认 profile 时在提示符中标出，如 j(work) >
+    let prompt = match crate::config::profile::active().0.as_str() {
+        constants::profile::DEFAULT => format!("{} ", constants::INTERACTIVE_PROMPT.yellow()),
+        name => format!(
+            "{} ",
+            constants::INTERACTIVE_PROMPT
+                .replacen(' ', &format!("({}) ", name), 1)
+                .yellow()
+        ),
+    };
 
     loop {
         // 每次循环重置 voice 状态
@@ -218,7 +228,10 @@ pub fn run_interactive(config: &mut YamlConfig) {
                                 break;
                             }
                             Err(err) => {
-                                error!("读取输入失败: {:?}", err);
+                                error!(
+                                    "{}",
+                                    t!(msg::INTERACTIVE_READ_FAILED, format!("{:?}", err))
+                                );
                                 break;
                             }
                         }
@@ -255,7 +268,10 @@ pub fn run_interactive(config: &mut YamlConfig) {
                                     break;
                                 }
                                 Err(err) => {
-                                    error!("读取输入失败: {:?}", err);
+                                    error!(
+                                        "{}",
+                                        t!(msg::INTERACTIVE_READ_FAILED, format!("{:?}", err))
+                                    );
                                     break;
                                 }
                             }
@@ -270,12 +286,14 @@ pub fn run_interactive(config: &mut YamlConfig) {
                 break;
             }
             Err(err) => {
-                error!("读取输入失败: {:?}", err);
+                error!("{}", t!(msg::INTERACTIVE_READ_FAILED, format!("{:?}", err)));
                 break;
             }
         }
     }
 
+    // 多个交互会话同时退出时依次写入，不会交错成损坏的历史文件
+    let _lock = crate::util::file_lock::lock(&history_path);
     let _ = rl.save_history(&history_path);
 }
 
@@ -32,7 +32,7 @@ pub fn execute_interactive_command(args: &[String], config: &mut YamlConfig) {
         }
         ParseResult::Handled => {}
         ParseResult::NotFound => {
-            command::open::handle_open(args, config);
+            command::suggest::open_or_suggest(args, config, true);
         }
     }
 }
@@ -197,11 +197,34 @@ pub fn parse_interactive_command(args: &[String]) -> ParseResult {
     } else if is(cmd::VERSION) {
         ParseResult::Matched(SubCmd::Version)
     } else if is(cmd::HELP) {
-        ParseResult::Matched(SubCmd::Help)
+        ParseResult::Matched(SubCmd::Help {
+            topic: rest.first().cloned(),
+        })
     } else if is(cmd::COMPLETION) {
         ParseResult::Matched(SubCmd::Completion {
             shell: rest.first().cloned(),
         })
+    } else if is(cmd::GEN) {
+        if rest.is_empty() {
+            crate::usage!("gen man [-o <dir>]");
+            return ParseResult::Handled;
+        }
+        ParseResult::Matched(SubCmd::Gen {
+            target: rest[0].clone(),
+            out: rest
+                .iter()
+                .position(|a| a == "-o" || a == "--out")
+                .and_then(|i| rest.get(i + 1).cloned()),
+        })
+    } else if is(cmd::STATS) {
+        ParseResult::Matched(SubCmd::Stats {
+            args: rest.to_vec(),
+        })
+    } else if is(cmd::PROFILE) {
+        ParseResult::Matched(SubCmd::Profile {
+            action: rest.first().cloned(),
+            name: rest.get(1).cloned(),
+        })
     } else if is(cmd::VOICE) {
         ParseResult::Matched(SubCmd::Voice {
             action: rest.first().cloned().unwrap_or_default(),
@@ -1,6 +1,7 @@
 use crate::config::YamlConfig;
 use crate::constants::shell;
-use crate::{error, info};
+use crate::util::i18n::{msg, text};
+use crate::{error, info, t};
 use colored::Colorize;
 
 /// 进入交互式 shell 子进程
@@ -13,7 +14,7 @@ pub fn enter_interactive_shell(config: &YamlConfig) {
         std::env::var("SHELL").unwrap_or_else(|_| shell::BASH_PATH.to_string())
     };
 
-    info!("进入 shell 模式 ({}), 输入 exit 返回 copilot", shell_path);
+    info!("{}", t!(msg::INTERACTIVE_SHELL_ENTER, shell_path));
 
     let mut command = std::process::Command::new(&shell_path);
 
@@ -51,7 +52,7 @@ pub fn enter_interactive_shell(config: &YamlConfig) {
 
             let zshrc_path = tmp_dir.join(".zshrc");
             if let Err(e) = std::fs::write(&zshrc_path, &zshrc_content) {
-                error!("创建临时 .zshrc 失败: {}", e);
+                error!("{}", t!(msg::INTERACTIVE_ZSHRC_FAILED, e));
                 command.env("PROMPT", "%F{green}shell%f (%F{cyan}%~%f) %F{green}>%f ");
             } else {
                 command.env("ZDOTDIR", tmp_dir.to_str().unwrap_or("/tmp"));
@@ -72,7 +73,7 @@ pub fn enter_interactive_shell(config: &YamlConfig) {
             );
 
             if let Err(e) = std::fs::write(&tmp_rc, &bashrc_content) {
-                error!("创建临时 bashrc 失败: {}", e);
+                error!("{}", t!(msg::INTERACTIVE_BASHRC_FAILED, e));
                 command.env("PS1", "\\[\\033[32m\\]shell\\[\\033[0m\\] (\\[\\033[36m\\]\\w\\[\\033[0m\\]) \\[\\033[32m\\]>\\[\\033[0m\\] ");
             } else {
                 command.arg("--rcfile");
@@ -100,12 +101,12 @@ pub fn enter_interactive_shell(config: &YamlConfig) {
         Ok(status) => {
             if !status.success() {
                 if let Some(code) = status.code() {
-                    error!("shell 退出码: {}", code);
+                    error!("{}", t!(msg::INTERACTIVE_SHELL_EXIT, code));
                 }
             }
         }
         Err(e) => {
-            error!("启动 shell 失败: {}", e);
+            error!("{}", t!(msg::INTERACTIVE_SHELL_FAILED, e));
         }
     }
 
@@ -117,7 +118,7 @@ pub fn enter_interactive_shell(config: &YamlConfig) {
         }
     }
 
-    info!("{}", "已返回 copilot 交互模式 🚀".green());
+    info!("{}", text(&msg::INTERACTIVE_BACK).green());
 }
 
 /// 执行 shell 命令（交互模式下 ! 前缀触发）
@@ -145,12 +146,12 @@ pub fn execute_shell_command(cmd: &str, config: &YamlConfig) {
         Ok(status) => {
             if !status.success() {
                 if let Some(code) = status.code() {
-                    error!("命令退出码: {}", code);
+                    error!("{}", t!(msg::INTERACTIVE_COMMAND_EXIT, code));
                 }
             }
         }
         Err(e) => {
-            error!("执行命令失败: {}", e);
+            error!("{}", t!(msg::INTERACTIVE_COMMAND_FAILED, e));
         }
     }
 }
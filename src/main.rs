@@ -12,21 +12,34 @@ use cli::Cli;
 use config::YamlConfig;
 
 fn main() {
+    // 取出开头的 --profile <name>，其余参数照常解析
+    let raw_args = take_profile_arg(std::env::args().collect());
+    if let Err(msg) = config::profile::check_active() {
+        // 当前 profile 不可用时只允许执行 profile 命令（用来创建或切换）
+        if !raw_args
+            .get(1)
+            .is_some_and(|a| constants::cmd::PROFILE.contains(&a.as_str()))
+        {
+            error!("{}", msg);
+            std::process::exit(util::log::EXIT_FAILURE);
+        }
+    }
+
     // 加载配置
     let mut config = YamlConfig::load();
+    util::i18n::init(&config);
+    util::filelog::init(&config);
+    util::accessible::init(&config);
 
-    let verbose = config.is_verbose();
-    let start = if verbose {
-        Some(std::time::Instant::now())
-    } else {
-        None
-    };
+    let start = std::time::Instant::now();
 
     // 检查是否有命令行参数
     // 如果 argv 只有一个元素（程序名），进入交互模式
-    let raw_args: Vec<String> = std::env::args().collect();
     if raw_args.len() <= 1 {
-        // 无参数：进入交互模式
+        // 无参数：进入交互模式。固定本次会话的 profile，会话中 profile switch 只影响之后启动的进程
+        let (profile, _) = config::profile::active();
+        // SAFETY: 此时尚未创建其他线程
+        unsafe { std::env::set_var(constants::profile::ENV, profile) };
         interactive::run_interactive(&mut config);
         return;
     }
@@ -34,7 +47,7 @@ fn main() {
     // 尝试用 clap 解析命令
     // 如果用户输入的是 `j <alias>` 这种非子命令形式，clap 会解析失败
     // 这时候我们 fallback 到别名打开逻辑
-    let cli = Cli::try_parse();
+    let cli = Cli::try_parse_from(&raw_args);
 
     match cli {
         Ok(cli) => {
@@ -47,8 +60,8 @@ fn main() {
                         // 不应该走到这里（已在上面处理了无参数情况）
                         interactive::run_interactive(&mut config);
                     } else {
-                        // 带参数但没匹配到子命令 → 别名打开
-                        command::open::handle_open(&cli.args, &config);
+                        // 带参数但没匹配到子命令 → 别名打开（找不到时给出最接近的命令）
+                        command::suggest::open_or_suggest(&cli.args, &mut config, false);
                     }
                 }
             }
@@ -58,12 +71,50 @@ fn main() {
             // 例如: j chrome, j vscode file.txt
             // 跳过 argv[0]（程序名），把剩余的作为别名参数
             let alias_args: Vec<String> = raw_args[1..].to_vec();
-            command::open::handle_open(&alias_args, &config);
+            command::suggest::open_or_suggest(&alias_args, &mut config, false);
         }
     }
 
-    if let Some(start) = start {
-        let elapsed = start.elapsed();
-        debug_log!(config, "duration: {} ms", elapsed.as_millis());
+    let elapsed = start.elapsed();
+    debug_log!(config, "duration: {} ms", elapsed.as_millis());
+
+    // 文件日志只记命令名：别名与插件名可能是用户自己的内容，记为 open
+    let command = match raw_args.get(1) {
+        Some(a) if constants::cmd::all_keywords().contains(&a.as_str()) => format!("j {}", a),
+        _ => "j open".to_string(),
+    };
+    let code = util::log::exit_code();
+    util::filelog::dispatch(&command, raw_args.len() - 1, elapsed, code);
+
+    // 执行中报告过错误时以非 0 退出
+    if code != 0 {
+        std::process::exit(code);
     }
 }
+
+/// 取出紧跟程序名的 `--profile <name>` / `--profile=<name>` 并写入 J_PROFILE，
+/// 本进程与它启动的插件（agent 等）都据此定位 profile 的数据目录
+fn take_profile_arg(mut args: Vec<String>) -> Vec<String> {
+    let name = match args.get(1).map(String::as_str) {
+        Some(constants::profile::FLAG) if args.len() > 2 => {
+            let name = args.remove(2);
+            args.remove(1);
+            name
+        }
+        Some(a) => match a
+            .strip_prefix(constants::profile::FLAG)
+            .and_then(|v| v.strip_prefix('='))
+        {
+            Some(v) => {
+                let name = v.to_string();
+                args.remove(1);
+                name
+            }
+            None => return args,
+        },
+        None => return args,
+    };
+    // SAFETY: 此时尚未创建其他线程
+    unsafe { std::env::set_var(constants::profile::ENV, name) };
+    args
+}
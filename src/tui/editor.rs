@@ -1,3 +1,5 @@
+use crate::t;
+use crate::util::i18n::{msg, text};
 use crossterm::{
     event::{self, Event},
     execute,
@@ -1004,14 +1006,14 @@ fn run_editor_loop(
                 let clamped_scroll = auto_scroll.min(max_scroll);
 
                 let scroll_hint = if total_wrapped > preview_inner_h {
-                    format!(
-                        " 📖 第 {} 行预览  [{}/{}行]  Alt+↓/↑滚动 ",
+                    t!(
+                        msg::EDITOR_PREVIEW_SCROLL,
                         cursor_row + 1,
                         clamped_scroll + preview_inner_h,
                         total_wrapped
                     )
                 } else {
-                    format!(" 📖 第 {} 行预览 ", cursor_row + 1)
+                    t!(msg::EDITOR_PREVIEW, cursor_row + 1)
                 };
 
                 let preview_block = Block::default()
@@ -1109,7 +1111,7 @@ fn run_editor_loop(
                         textarea.set_block(
                             Block::default()
                                 .borders(Borders::ALL)
-                                .title(" ⚠️ 有未保存的改动！使用 :q! 强制退出，或 :wq 保存退出 ")
+                                .title(text(&msg::EDITOR_UNSAVED))
                                 .border_style(Style::default().fg(Color::LightRed)),
                         );
                         *vim = Vim::new(Mode::Normal);
@@ -1221,12 +1223,12 @@ fn build_status_bar(mode: &Mode, line_count: usize, search: &SearchState) -> Par
                 " Ctrl+S ",
                 Style::default().fg(Color::Black).bg(Color::Green),
             ));
-            spans.push(Span::raw(" 提交  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_SUBMIT)));
             spans.push(Span::styled(
                 " Ctrl+Q ",
                 Style::default().fg(Color::Black).bg(Color::Red),
             ));
-            spans.push(Span::raw(" 取消  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_CANCEL)));
             spans.push(Span::styled(
                 " Esc ",
                 Style::default().fg(Color::Black).bg(Color::Yellow),
@@ -1238,46 +1240,46 @@ fn build_status_bar(mode: &Mode, line_count: usize, search: &SearchState) -> Par
                 " :wq ",
                 Style::default().fg(Color::Black).bg(Color::Green),
             ));
-            spans.push(Span::raw(" 提交  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_SUBMIT)));
             spans.push(Span::styled(
                 " / ",
                 Style::default().fg(Color::Black).bg(Color::Magenta),
             ));
-            spans.push(Span::raw(" 搜索  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_SEARCH)));
             spans.push(Span::styled(
                 " n/N ",
                 Style::default().fg(Color::Black).bg(Color::Cyan),
             ));
-            spans.push(Span::raw(" 下/上  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_NEXT_PREV)));
             spans.push(Span::styled(
                 " i ",
                 Style::default().fg(Color::Black).bg(Color::Cyan),
             ));
-            spans.push(Span::raw(" 编辑  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_EDIT)));
         }
         Mode::Visual => {
             spans.push(Span::styled(
                 " y ",
                 Style::default().fg(Color::Black).bg(Color::Green),
             ));
-            spans.push(Span::raw(" 复制  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_COPY)));
             spans.push(Span::styled(
                 " d ",
                 Style::default().fg(Color::Black).bg(Color::Red),
             ));
-            spans.push(Span::raw(" 删除  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_DELETE)));
             spans.push(Span::styled(
                 " Esc ",
                 Style::default().fg(Color::Black).bg(Color::Yellow),
             ));
-            spans.push(Span::raw(" 取消  "));
+            spans.push(Span::raw(text(&msg::EDITOR_KEY_CANCEL)));
         }
         _ => {}
     }
 
     // 行数
     spans.push(Span::styled(
-        format!(" {} 行 ", line_count),
+        t!(msg::EDITOR_LINES, line_count),
         Style::default().fg(Color::DarkGray),
     ));
 
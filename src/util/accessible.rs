@@ -0,0 +1,42 @@
+//! 无障碍模式：为读屏软件去掉颜色、进度条与动画、就地重绘等控制序列，改为逐行输出的纯文本。
+//!
+//! 默认关闭：`J_ACCESSIBLE` 或 `setting.accessible` 开启。开启后把 `J_ACCESSIBLE=1` 传给子进程，
+//! md_render 与 agent 插件读取同一开关（md_render 以文字说明标题、代码块、列表与表格的结构）。
+
+use crate::config::YamlConfig;
+use crate::constants::{config_key, section};
+use std::sync::OnceLock;
+
+/// 开关环境变量（优先级高于配置文件），与 md_render、agent 插件一致
+pub const ENV: &str = "J_ACCESSIBLE";
+
+/// 未调用 init 时视为关闭
+static ENABLED: OnceLock<bool> = OnceLock::new();
+
+/// 按 J_ACCESSIBLE 与配置决定是否开启，在 main 加载配置后、创建其他线程前调用一次
+pub fn init(config: &YamlConfig) {
+    let value = std::env::var(ENV)
+        .ok()
+        .filter(|v| !v.is_empty())
+        .or_else(|| {
+            config
+                .get_property(section::SETTING, config_key::ACCESSIBLE)
+                .cloned()
+        })
+        .unwrap_or_default();
+    let enabled = matches!(
+        value.trim().to_lowercase().as_str(),
+        "1" | "true" | "on" | "yes"
+    );
+    if enabled {
+        colored::control::set_override(false);
+        // SAFETY: 此时尚未创建其他线程
+        unsafe { std::env::set_var(ENV, "1") };
+    }
+    let _ = ENABLED.set(enabled);
+}
+
+/// 是否开启了无障碍模式
+pub fn enabled() -> bool {
+    ENABLED.get().copied().unwrap_or(false)
+}
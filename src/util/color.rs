@@ -0,0 +1,261 @@
+use ratatui::style::Color;
+use std::path::PathBuf;
+use std::sync::OnceLock;
+
+/// 强制指定终端的颜色深度（truecolor、256、16）；不设置时自动检测
+pub const ENV_COLOR_DEPTH: &str = "J_COLOR_DEPTH";
+
+/// 终端能显示的颜色数
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum ColorDepth {
+    /// 基本的 16 色
+    Ansi16,
+    /// xterm 256 色
+    Ansi256,
+    /// 24 位真彩色
+    TrueColor,
+}
+
+/// TERM_PROGRAM 为这些值的终端支持真彩色（即使没有设置 COLORTERM）
+const TRUE_COLOR_PROGRAMS: &[&str] = &[
+    "iTerm.app",
+    "WezTerm",
+    "vscode",
+    "ghostty",
+    "Hyper",
+    "rio",
+    "Tabby",
+];
+
+/// 当前终端的颜色深度（首次调用时检测）
+pub fn color_depth() -> ColorDepth {
+    static DEPTH: OnceLock<ColorDepth> = OnceLock::new();
+    *DEPTH.get_or_init(detect_color_depth)
+}
+
+/// 依次查看 J_COLOR_DEPTH、COLORTERM、TERM、终端程序与 terminfo 中的 colors，都无法判断时按 16 色处理
+fn detect_color_depth() -> ColorDepth {
+    let env = |key: &str| std::env::var(key).unwrap_or_default();
+    if let Some(depth) = parse_color_depth(&env(ENV_COLOR_DEPTH)) {
+        return depth;
+    }
+    if matches!(
+        env("COLORTERM").to_lowercase().as_str(),
+        "truecolor" | "24bit"
+    ) {
+        return ColorDepth::TrueColor;
+    }
+    let term = env("TERM");
+    if term.is_empty() || term == "dumb" {
+        return ColorDepth::Ansi16;
+    }
+    if term.ends_with("-direct") || term.contains("truecolor") {
+        return ColorDepth::TrueColor;
+    }
+    if !env("WT_SESSION").is_empty() || TRUE_COLOR_PROGRAMS.contains(&env("TERM_PROGRAM").as_str())
+    {
+        return ColorDepth::TrueColor;
+    }
+    if let Some(colors) = terminfo_colors(&term) {
+        return if colors >= 1 << 24 {
+            ColorDepth::TrueColor
+        } else if colors >= 256 {
+            ColorDepth::Ansi256
+        } else {
+            ColorDepth::Ansi16
+        };
+    }
+    if term.contains("256color") {
+        return ColorDepth::Ansi256;
+    }
+    ColorDepth::Ansi16
+}
+
+/// 解析 J_COLOR_DEPTH 的值
+fn parse_color_depth(value: &str) -> Option<ColorDepth> {
+    match value.trim().to_lowercase().as_str() {
+        "truecolor" | "24bit" | "16m" => Some(ColorDepth::TrueColor),
+        "256" => Some(ColorDepth::Ansi256),
+        "16" | "8" => Some(ColorDepth::Ansi16),
+        _ => None,
+    }
+}
+
+/// terminfo 数据库的查找位置，顺序与 ncurses 一致
+fn terminfo_dirs() -> Vec<PathBuf> {
+    let mut paths = Vec::new();
+    if let Ok(dir) = std::env::var("TERMINFO") {
+        if !dir.is_empty() {
+            paths.push(PathBuf::from(dir));
+        }
+    }
+    if let Some(home) = dirs::home_dir() {
+        paths.push(home.join(".terminfo"));
+    }
+    if let Ok(list) = std::env::var("TERMINFO_DIRS") {
+        paths.extend(list.split(':').filter(|d| !d.is_empty()).map(PathBuf::from));
+    }
+    for dir in [
+        "/etc/terminfo",
+        "/lib/terminfo",
+        "/usr/share/terminfo",
+        "/usr/lib/terminfo",
+        "/opt/homebrew/share/terminfo",
+    ] {
+        paths.push(PathBuf::from(dir));
+    }
+    paths
+}
+
+/// 读取 TERM 对应的编译后 terminfo 条目中的 max_colors
+fn terminfo_colors(term: &str) -> Option<i64> {
+    if term.contains('/') || term.contains('\\') {
+        return None;
+    }
+    let first = term.as_bytes()[0];
+    for dir in terminfo_dirs() {
+        // Linux 按首字母分目录，macOS 按首字母的十六进制
+        for sub in [(first as char).to_string(), format!("{:02x}", first)] {
+            if let Ok(data) = std::fs::read(dir.join(sub).join(term)) {
+                return parse_terminfo_colors(&data);
+            }
+        }
+    }
+    None
+}
+
+/// max_colors 在数值能力中的序号
+const MAX_COLORS_INDEX: usize = 13;
+
+/// 解析编译后的 terminfo（term(5)）：12 字节的头之后依次为名称、布尔能力、
+/// 对齐到偶数字节后的数值能力（旧格式 2 字节、扩展格式 4 字节，小端）
+fn parse_terminfo_colors(data: &[u8]) -> Option<i64> {
+    if data.len() < 12 {
+        return None;
+    }
+    let header = |i: usize| i16::from_le_bytes([data[i * 2], data[i * 2 + 1]]) as i64;
+    let width = match header(0) {
+        0o432 => 2,
+        0o1036 => 4,
+        _ => return None,
+    };
+    let (names_size, bool_count, num_count) = (header(1), header(2), header(3));
+    if names_size < 0 || bool_count < 0 || num_count <= MAX_COLORS_INDEX as i64 {
+        return None;
+    }
+    let mut offset = 12 + names_size as usize + bool_count as usize;
+    if offset % 2 == 1 {
+        offset += 1;
+    }
+    offset += MAX_COLORS_INDEX * width;
+    let bytes = data.get(offset..offset + width)?;
+    let colors = if width == 2 {
+        i16::from_le_bytes([bytes[0], bytes[1]]) as i64
+    } else {
+        i32::from_le_bytes([bytes[0], bytes[1], bytes[2], bytes[3]]) as i64
+    };
+    (colors >= 0).then_some(colors)
+}
+
+/// 按终端的颜色深度转换颜色：真彩色原样返回，256 色取最接近的色号，16 色取最接近的基本色；
+/// 非 Rgb 的颜色不变
+pub fn degrade(color: Color) -> Color {
+    degrade_to(color, color_depth())
+}
+
+/// 把颜色转换到指定的颜色深度
+pub fn degrade_to(color: Color, depth: ColorDepth) -> Color {
+    let Color::Rgb(r, g, b) = color else {
+        return color;
+    };
+    let rgb = [r as i32, g as i32, b as i32];
+    match depth {
+        ColorDepth::TrueColor => color,
+        ColorDepth::Ansi256 => Color::Indexed(nearest_256(rgb)),
+        ColorDepth::Ansi16 => ANSI16[nearest_16(rgb)],
+    }
+}
+
+/// 基本色序号（0–15）对应的颜色
+const ANSI16: [Color; 16] = [
+    Color::Black,
+    Color::Red,
+    Color::Green,
+    Color::Yellow,
+    Color::Blue,
+    Color::Magenta,
+    Color::Cyan,
+    Color::Gray,
+    Color::DarkGray,
+    Color::LightRed,
+    Color::LightGreen,
+    Color::LightYellow,
+    Color::LightBlue,
+    Color::LightMagenta,
+    Color::LightCyan,
+    Color::White,
+];
+
+/// xterm 256 色中 6×6×6 色块每个分量的取值
+const CUBE_LEVELS: [i32; 6] = [0, 95, 135, 175, 215, 255];
+
+/// 最接近的 xterm 256 色色号（16–255：色块或 24 级灰度）
+fn nearest_256(rgb: [i32; 3]) -> u8 {
+    let mut idx = [0usize; 3];
+    let mut cube = [0i32; 3];
+    for (i, v) in rgb.iter().enumerate() {
+        let best = (0..CUBE_LEVELS.len())
+            .min_by_key(|&j| (CUBE_LEVELS[j] - v).abs())
+            .unwrap_or(0);
+        idx[i] = best;
+        cube[i] = CUBE_LEVELS[best];
+    }
+    let cube_code = 16 + 36 * idx[0] + 6 * idx[1] + idx[2];
+    // 灰度 232–255 为 8, 18, ..., 238
+    let gray = (rgb[0] + rgb[1] + rgb[2]) / 3;
+    let gray_idx = ((gray - 3) / 10).clamp(0, 23);
+    let gray_level = 8 + 10 * gray_idx;
+    if color_distance(rgb, [gray_level; 3]) < color_distance(rgb, cube) {
+        return (232 + gray_idx) as u8;
+    }
+    cube_code as u8
+}
+
+/// 色相每 60° 一段对应的基本色序号：红、黄、绿、青、蓝、品红
+const HUE_SECTORS: [usize; 6] = [1, 3, 2, 6, 4, 5];
+
+/// 对应的基本色序号（0–15）：按距离取最近的基本色会让浅色几乎都落到白色上，
+/// 因此灰色按亮度取黑、暗灰、浅灰、白，其他颜色按色相取六种基本色之一，较亮的用高亮色
+fn nearest_16(rgb: [i32; 3]) -> usize {
+    let hi = rgb[0].max(rgb[1]).max(rgb[2]);
+    let lo = rgb[0].min(rgb[1]).min(rgb[2]);
+    let chroma = hi - lo;
+    if chroma < 40 {
+        return match (hi + lo) / 2 {
+            l if l < 50 => 0,
+            l if l < 140 => 8,
+            l if l < 215 => 7,
+            _ => 15,
+        };
+    }
+    let (r, g, b) = (rgb[0] as f64, rgb[1] as f64, rgb[2] as f64);
+    let c = chroma as f64;
+    let hue = if hi == rgb[0] {
+        (g - b) / c
+    } else if hi == rgb[1] {
+        (b - r) / c + 2.0
+    } else {
+        (r - g) / c + 4.0
+    };
+    // 四舍五入到最近的 60° 段
+    let sector = ((hue + 6.5) as usize) % 6;
+    let base = HUE_SECTORS[sector];
+    if hi > 200 { base + 8 } else { base }
+}
+
+/// 按人眼对红绿蓝的敏感度加权的距离平方（"redmean" 近似）
+fn color_distance(a: [i32; 3], b: [i32; 3]) -> i32 {
+    let rmean = (a[0] + b[0]) / 2;
+    let (dr, dg, db) = (a[0] - b[0], a[1] - b[1], a[2] - b[2]);
+    (((512 + rmean) * dr * dr) >> 8) + 4 * dg * dg + (((767 - rmean) * db * db) >> 8)
+}
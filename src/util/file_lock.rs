@@ -0,0 +1,102 @@
+//! 跨进程的文件建议锁（flock），串行化多个 j / agent 进程对同一数据文件的写入
+//!
+//! 锁加在旁边的 `<文件>.lock` 上而不是数据文件本身：整体重写的文件以改名替换，
+//! 加在旧文件上的锁会失效。约定与 agent 插件（`plugin/agent/code/internal/filelock`）一致，
+//! 两边写同一个文件时互相等待。进程退出时内核自动释放锁。
+
+use std::fs::{self, File, OpenOptions};
+use std::io::{self, Read, Seek, SeekFrom, Write};
+use std::os::unix::io::AsRawFd;
+use std::path::{Path, PathBuf};
+use std::time::{Duration, Instant};
+
+/// 等待锁的最长时间
+const LOCK_TIMEOUT: Duration = Duration::from_secs(5);
+
+/// 持有中的锁，drop 时释放
+pub struct FileLock {
+    file: File,
+}
+
+impl Drop for FileLock {
+    fn drop(&mut self) {
+        unsafe {
+            libc::flock(self.file.as_raw_fd(), libc::LOCK_UN);
+        }
+    }
+}
+
+/// 锁文件路径: <path>.lock
+fn lock_path(path: &Path) -> PathBuf {
+    let mut name = path.as_os_str().to_owned();
+    name.push(".lock");
+    PathBuf::from(name)
+}
+
+/// 以独占方式锁住 path（写入时使用），等待超过 5 秒返回 TimedOut
+pub fn lock(path: &Path) -> io::Result<FileLock> {
+    acquire(path, libc::LOCK_EX)
+}
+
+fn acquire(path: &Path, how: libc::c_int) -> io::Result<FileLock> {
+    let file = OpenOptions::new()
+        .create(true)
+        .truncate(false)
+        .read(true)
+        .write(true)
+        .open(lock_path(path))?;
+    let deadline = Instant::now() + LOCK_TIMEOUT;
+    loop {
+        if unsafe { libc::flock(file.as_raw_fd(), how | libc::LOCK_NB) } == 0 {
+            return Ok(FileLock { file });
+        }
+        let err = io::Error::last_os_error();
+        if err.kind() != io::ErrorKind::WouldBlock && err.kind() != io::ErrorKind::Interrupted {
+            return Err(err);
+        }
+        if Instant::now() >= deadline {
+            return Err(io::Error::new(
+                io::ErrorKind::TimedOut,
+                "timed out waiting for file lock",
+            ));
+        }
+        std::thread::sleep(Duration::from_millis(10));
+    }
+}
+
+/// 在独占锁内整体写入文件：先写临时文件再改名，读者要么看到旧内容要么看到新内容，不会读到写了一半的文件
+pub fn write_atomic(path: &Path, data: &[u8]) -> io::Result<()> {
+    let _lock = lock(path)?;
+    let mut tmp = path.as_os_str().to_owned();
+    tmp.push(format!(".{}.tmp", std::process::id()));
+    let tmp = PathBuf::from(tmp);
+    if let Err(e) = fs::write(&tmp, data) {
+        let _ = fs::remove_file(&tmp);
+        return Err(e);
+    }
+    fs::rename(&tmp, path).inspect_err(|_| {
+        let _ = fs::remove_file(&tmp);
+    })
+}
+
+/// 在独占锁内向 path 追加一行（line 不含换行符）；
+/// 文件末尾缺少换行（上次写入中途被杀）时先补上，半行不会连带损坏这一行
+pub fn append_line(path: &Path, line: &str) -> io::Result<()> {
+    let _lock = lock(path)?;
+    let mut file = OpenOptions::new()
+        .create(true)
+        .append(true)
+        .read(true)
+        .open(path)?;
+    let mut buf = Vec::with_capacity(line.len() + 2);
+    if file.metadata()?.len() > 0 {
+        let mut last = [0u8; 1];
+        file.seek(SeekFrom::End(-1))?;
+        if file.read_exact(&mut last).is_ok() && last[0] != b'\n' {
+            buf.push(b'\n');
+        }
+    }
+    buf.extend_from_slice(line.as_bytes());
+    buf.push(b'\n');
+    file.write_all(&buf)
+}
@@ -0,0 +1,187 @@
+//! 结构化的文件日志（JSON Lines）：命令分发与错误各记一行，偶发的问题可以拿日志里的真实记录来报告。
+//!
+//! 与 agent 插件（`plugin/agent/code/internal/filelog`）共用 `~/.jdata/logs/j.jsonl`、同样的格式与轮换规则，
+//! agent 另外记录 provider 调用。默认关闭：`J_LOG_FILE` 或 `setting.log_file` 开启后才记录。
+//! 超过大小上限或跨天时改名为 `j-<时间>.jsonl` 轮换，过期与超出个数的旧文件随之删除。
+
+use crate::config::YamlConfig;
+use crate::constants::{LOG_DIR, config_key, section};
+use crate::util::file_lock;
+use chrono::{DateTime, Local, SecondsFormat, Utc};
+use serde_json::{Value, json};
+use std::fs::{self, OpenOptions};
+use std::io::{BufRead, BufReader, Write};
+use std::path::{Path, PathBuf};
+use std::sync::OnceLock;
+use std::time::{Duration, SystemTime};
+
+/// 开关环境变量（优先级高于配置文件）
+pub const ENV: &str = "J_LOG_FILE";
+/// 当前日志文件名
+const FILE_NAME: &str = "j.jsonl";
+/// 本进程在日志中的来源名
+const SOURCE: &str = "j";
+
+const DEFAULT_MAX_SIZE_MB: u64 = 10;
+const DEFAULT_MAX_AGE_DAYS: u64 = 14;
+const DEFAULT_MAX_BACKUPS: u64 = 5;
+
+/// 开启时生效的轮换设置
+struct Settings {
+    path: PathBuf,
+    max_size: u64,
+    max_age: Duration,
+    max_backups: usize,
+}
+
+/// 未开启（或尚未调用 init）时为 None
+static SETTINGS: OnceLock<Option<Settings>> = OnceLock::new();
+
+/// 按 J_LOG_FILE 与配置决定是否记录，在 main 加载配置后调用一次；之前报告的错误不会记录
+pub fn init(config: &YamlConfig) {
+    let value = std::env::var(ENV)
+        .ok()
+        .filter(|v| !v.is_empty())
+        .or_else(|| {
+            config
+                .get_property(section::SETTING, config_key::LOG_FILE)
+                .cloned()
+        })
+        .unwrap_or_default();
+    let enabled = matches!(
+        value.trim().to_lowercase().as_str(),
+        "1" | "true" | "on" | "yes"
+    );
+    let number = |key: &str, default: u64| {
+        config
+            .get_property(section::SETTING, key)
+            .and_then(|v| v.trim().parse::<u64>().ok())
+            .filter(|n| *n > 0)
+            .unwrap_or(default)
+    };
+    let settings = enabled.then(|| Settings {
+        path: YamlConfig::data_dir().join(LOG_DIR).join(FILE_NAME),
+        max_size: number(config_key::LOG_FILE_MAX_SIZE, DEFAULT_MAX_SIZE_MB) << 20,
+        max_age: Duration::from_secs(
+            number(config_key::LOG_FILE_MAX_AGE, DEFAULT_MAX_AGE_DAYS) * 24 * 3600,
+        ),
+        max_backups: number(config_key::LOG_FILE_MAX_BACKUPS, DEFAULT_MAX_BACKUPS) as usize,
+    });
+    let _ = SETTINGS.set(settings);
+}
+
+/// 记录一条执行完毕的命令：只记命令名与参数个数，不记参数内容
+pub fn dispatch(command: &str, args: usize, duration: Duration, exit_code: i32) {
+    let level = if exit_code == 0 { "info" } else { "error" };
+    write(
+        level,
+        json!({
+            "event": "dispatch",
+            "command": command,
+            "args": args,
+            "duration_ms": duration.as_millis() as u64,
+            "exit_code": exit_code,
+        }),
+    );
+}
+
+/// 记录一条报告给用户的错误（error! 宏调用）
+pub fn error(message: &str) {
+    write("error", json!({ "event": "error", "error": message }));
+}
+
+/// 补上时间、级别、来源与进程号后追加一行；未开启时什么也不做，写入失败时静默忽略（日志不应影响命令本身）
+fn write(level: &str, fields: Value) {
+    let Some(Some(settings)) = SETTINGS.get() else {
+        return;
+    };
+    let mut entry = json!({
+        "time": Utc::now().to_rfc3339_opts(SecondsFormat::Nanos, true),
+        "level": level,
+        "source": SOURCE,
+        "pid": std::process::id(),
+    });
+    if let (Some(entry), Value::Object(fields)) = (entry.as_object_mut(), fields) {
+        entry.extend(fields);
+    }
+    let _ = append(settings, &entry.to_string());
+}
+
+/// 在独占锁内按需轮换，再追加一行
+fn append(settings: &Settings, line: &str) -> std::io::Result<()> {
+    let path = &settings.path;
+    if let Some(dir) = path.parent() {
+        fs::create_dir_all(dir)?;
+    }
+    let _lock = file_lock::lock(path)?;
+    if due(settings) {
+        rotate(settings);
+    }
+    let mut file = OpenOptions::new().create(true).append(true).open(path)?;
+    file.write_all(format!("{}\n", line).as_bytes())
+}
+
+/// 当前文件是否需要轮换：达到大小上限，或第一行记录于更早的一天
+fn due(settings: &Settings) -> bool {
+    let size = fs::metadata(&settings.path).map(|m| m.len()).unwrap_or(0);
+    if size == 0 {
+        return false;
+    }
+    if size >= settings.max_size {
+        return true;
+    }
+    first_time(&settings.path)
+        .is_some_and(|t| t.with_timezone(&Local).date_naive() != Local::now().date_naive())
+}
+
+/// 文件第一行的 time 字段
+fn first_time(path: &Path) -> Option<DateTime<Utc>> {
+    let mut line = String::new();
+    BufReader::new(fs::File::open(path).ok()?)
+        .read_line(&mut line)
+        .ok()?;
+    let head: Value = serde_json::from_str(&line).ok()?;
+    let time = DateTime::parse_from_rfc3339(head.get("time")?.as_str()?).ok()?;
+    Some(time.with_timezone(&Utc))
+}
+
+/// 把当前文件改名为 j-<时间>.jsonl，再删除过期与超出个数的旧文件
+fn rotate(settings: &Settings) {
+    let Some(dir) = settings.path.parent() else {
+        return;
+    };
+    let name = format!("j-{}", Local::now().format("%Y%m%d-%H%M%S"));
+    let mut target = dir.join(format!("{}.jsonl", name));
+    if target.exists() {
+        target = dir.join(format!("{}-{}.jsonl", name, std::process::id()));
+    }
+    if fs::rename(&settings.path, &target).is_err() {
+        return;
+    }
+    let mut backups: Vec<PathBuf> = fs::read_dir(dir)
+        .map(|entries| {
+            entries
+                .flatten()
+                .map(|e| e.path())
+                .filter(|p| {
+                    p.file_name()
+                        .and_then(|n| n.to_str())
+                        .is_some_and(|n| n.starts_with("j-") && n.ends_with(".jsonl"))
+                })
+                .collect()
+        })
+        .unwrap_or_default();
+    backups.sort();
+    let now = SystemTime::now();
+    let count = backups.len();
+    for (i, path) in backups.iter().enumerate() {
+        let expired = fs::metadata(path)
+            .and_then(|m| m.modified())
+            .ok()
+            .and_then(|t| now.duration_since(t).ok())
+            .is_some_and(|age| age > settings.max_age);
+        if count - i > settings.max_backups || expired {
+            let _ = fs::remove_file(path);
+        }
+    }
+}
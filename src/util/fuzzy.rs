@@ -103,3 +103,39 @@ pub fn highlight_matches(content: &str, target: &str, fuzzy: bool) -> String {
     result.push_str(&content[last_end..]);
     result
 }
+
+/// 两个字符串的编辑距离（大小写不敏感）：插入、删除、替换与相邻字符交换各算一步，
+/// 因此 `aks` 与 `ask` 的距离为 1
+pub fn edit_distance(a: &str, b: &str) -> usize {
+    let a: Vec<char> = a.to_lowercase().chars().collect();
+    let b: Vec<char> = b.to_lowercase().chars().collect();
+    // prev2 / prev / cur 分别为 a 的前 i-2、i-1、i 个字符与 b 的各个前缀的距离
+    let mut prev2: Vec<usize> = Vec::new();
+    let mut prev: Vec<usize> = (0..=b.len()).collect();
+    for i in 1..=a.len() {
+        let mut cur = vec![i; b.len() + 1];
+        for j in 1..=b.len() {
+            let cost = usize::from(a[i - 1] != b[j - 1]);
+            cur[j] = (prev[j] + 1).min(cur[j - 1] + 1).min(prev[j - 1] + cost);
+            if i > 1 && j > 1 && a[i - 1] == b[j - 2] && a[i - 2] == b[j - 1] {
+                cur[j] = cur[j].min(prev2[j - 2] + 1);
+            }
+        }
+        prev2 = std::mem::replace(&mut prev, cur);
+    }
+    prev[b.len()]
+}
+
+/// candidates 中与 input 最接近的至多 limit 个（按编辑距离、其次按名称排序，去重）；
+/// 距离超过 input 长度的三分之一（至少允许 1、至多 3）的不算接近
+pub fn closest<'a>(input: &str, candidates: &[&'a str], limit: usize) -> Vec<&'a str> {
+    let max = (input.chars().count() / 3).clamp(1, 3);
+    let mut scored: Vec<(usize, &str)> = candidates
+        .iter()
+        .map(|c| (edit_distance(input, c), *c))
+        .filter(|(d, c)| *d <= max && !c.eq_ignore_ascii_case(input))
+        .collect();
+    scored.sort();
+    scored.dedup_by(|a, b| a.1 == b.1);
+    scored.into_iter().take(limit).map(|(_, c)| c).collect()
+}
@@ -0,0 +1,120 @@
+//! 界面语言：主程序的中英文提示，选择规则与 Go 插件（md_render、agent 等）一致。
+//!
+//! 优先级为 `J_LANG` > `config.yaml` 的 `setting.lang` > `LC_ALL` > `LC_MESSAGES` > `LANG`，默认简体中文。
+//! 文案以 [`Msg`] 常量集中定义在 [`msg`] 中，用 `t!` 取当前语言的文字，`{}` 依次替换为参数；
+//! 需要 `&'static str` 的地方（表格标签、快捷键说明等）用 [`text`]，整篇的内嵌文档用 [`pick`]。
+
+use crate::config::YamlConfig;
+use crate::constants::{config_key, section};
+use std::fmt::Display;
+use std::sync::OnceLock;
+
+/// 显式指定界面语言的环境变量（优先级高于配置文件和 LANG），与插件一致
+pub const ENV: &str = "J_LANG";
+
+/// 界面语言
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum Locale {
+    /// 英文
+    En,
+    /// 简体中文
+    ZhCn,
+}
+
+/// 未能识别任何语言设置时使用的默认语言
+pub const DEFAULT: Locale = Locale::ZhCn;
+
+/// 由 init 决定的语言；未调用 init 时（加载配置之前）只按环境变量判断
+static LOCALE: OnceLock<Locale> = OnceLock::new();
+
+/// 一条中英文文案
+pub struct Msg {
+    pub en: &'static str,
+    pub zh: &'static str,
+}
+
+/// 按 J_LANG、配置与 LC_* / LANG 决定界面语言，在 main 加载配置后调用一次
+pub fn init(config: &YamlConfig) {
+    let setting = config.get_property(section::SETTING, config_key::LANG);
+    let _ = LOCALE.set(detect(setting.map(String::as_str)));
+}
+
+/// 当前生效的语言
+pub fn current() -> Locale {
+    LOCALE.get().copied().unwrap_or_else(|| detect(None))
+}
+
+/// 当前语言下不带参数的文案
+pub fn text(msg: &'static Msg) -> &'static str {
+    pick(msg.en, msg.zh)
+}
+
+/// 按当前语言在两个版本中选一个，用于内嵌的帮助文档等整篇内容
+pub fn pick<T>(en: T, zh: T) -> T {
+    match current() {
+        Locale::En => en,
+        Locale::ZhCn => zh,
+    }
+}
+
+/// 当前语言下的文案，`{}` 依次替换为 args
+pub fn format(msg: &Msg, args: &[&dyn Display]) -> String {
+    let template = pick(msg.en, msg.zh);
+    let mut out = String::with_capacity(template.len());
+    let mut args = args.iter();
+    let mut rest = template;
+    while let Some(i) = rest.find("{}") {
+        out.push_str(&rest[..i]);
+        if let Some(arg) = args.next() {
+            out.push_str(&arg.to_string());
+        }
+        rest = &rest[i + 2..];
+    }
+    out.push_str(rest);
+    out
+}
+
+/// J_LANG > setting.lang > LC_ALL > LC_MESSAGES > LANG
+fn detect(setting: Option<&str>) -> Locale {
+    if let Some(v) = std::env::var(ENV).ok().filter(|v| !v.is_empty()) {
+        return parse(&v);
+    }
+    if let Some(v) = setting.filter(|v| !v.trim().is_empty()) {
+        return parse(v);
+    }
+    for key in ["LC_ALL", "LC_MESSAGES", "LANG"] {
+        if let Ok(v) = std::env::var(key) {
+            if !v.is_empty() && v != "C" && v != "POSIX" {
+                return parse(&v);
+            }
+        }
+    }
+    DEFAULT
+}
+
+/// 将 "zh_CN.UTF-8"、"en-US" 这类写法归一化为已支持的语言
+fn parse(raw: &str) -> Locale {
+    let tag = raw.trim().to_lowercase();
+    let tag = tag.split(['.', '@']).next().unwrap_or_default();
+    if tag.starts_with("zh") {
+        Locale::ZhCn
+    } else if tag.starts_with("en") {
+        Locale::En
+    } else {
+        DEFAULT
+    }
+}
+
+/// 取当前语言的文案：`t!(msg::X)` 或 `t!(msg::X, a, b)`
+#[macro_export]
+macro_rules! t {
+    ($msg:expr) => {
+        $crate::util::i18n::format(&$msg, &[])
+    };
+    ($msg:expr, $($arg:expr),+ $(,)?) => {
+        $crate::util::i18n::format(&$msg, &[$(&$arg),+])
+    };
+}
+
+/// 主程序的文案，按命令分文件定义
+pub mod msg;
@@ -0,0 +1,45 @@
+//! 主程序各命令的文案，常量名以所属命令为前缀
+
+use super::Msg;
+
+mod alias;
+mod category;
+mod chat;
+mod config;
+mod editor;
+mod help;
+mod interactive;
+mod list;
+mod log;
+mod man;
+mod open;
+mod profile;
+mod report;
+mod script;
+mod stats;
+mod suggest;
+mod system;
+mod time;
+mod todo;
+mod voice;
+
+pub use alias::*;
+pub use category::*;
+pub use chat::*;
+pub use config::*;
+pub use editor::*;
+pub use help::*;
+pub use interactive::*;
+pub use list::*;
+pub use log::*;
+pub use man::*;
+pub use open::*;
+pub use profile::*;
+pub use report::*;
+pub use script::*;
+pub use stats::*;
+pub use suggest::*;
+pub use system::*;
+pub use time::*;
+pub use todo::*;
+pub use voice::*;
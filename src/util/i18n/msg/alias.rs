@@ -0,0 +1,72 @@
+//! alias 命令的文案
+
+use super::Msg;
+
+pub const ALIAS_RESERVED: Msg = Msg {
+    en: "alias `{}` is a built-in command, choose another one. 😢",
+    zh: "别名 `{}` 已经是预设命令，请换一个。 😢",
+};
+pub const ALIAS_SCRIPT_DELETED: Msg = Msg {
+    en: "🗑️ deleted the script file: {}",
+    zh: "🗑️ 已删除脚本文件: {}",
+};
+pub const ALIAS_SCRIPT_DELETE_FAILED: Msg = Msg {
+    en: "⚠️ failed to delete the script file: {}",
+    zh: "⚠️ 删除脚本文件失败: {}",
+};
+pub const ALIAS_REMOVED_PATH: Msg = Msg {
+    en: "removed alias {} from PATH ✅",
+    zh: "成功从 PATH 中移除别名 {} ✅",
+};
+pub const ALIAS_REMOVED_INNER_URL: Msg = Msg {
+    en: "removed alias {} from INNER_URL ✅",
+    zh: "成功从 INNER_URL 中移除别名 {} ✅",
+};
+pub const ALIAS_REMOVED_OUTER_URL: Msg = Msg {
+    en: "removed alias {} from OUTER_URL ✅",
+    zh: "成功从 OUTER_URL 中移除别名 {} ✅",
+};
+pub const ALIAS_NOT_FOUND: Msg = Msg {
+    en: "alias {} does not exist ❌",
+    zh: "别名 {} 不存在 ❌",
+};
+pub const ALIAS_RENAMED_PATH: Msg = Msg {
+    en: "✅ renamed {} -> {}! Path: {} 🎉",
+    zh: "✅ 重命名 {} -> {} 成功! Path: {} 🎉",
+};
+pub const ALIAS_RENAMED_INNER_URL: Msg = Msg {
+    en: "✅ renamed {} -> {}! Inner URL: {} 🚀",
+    zh: "✅ 重命名 {} -> {} 成功! Inner URL: {} 🚀",
+};
+pub const ALIAS_RENAMED_OUTER_URL: Msg = Msg {
+    en: "✅ renamed {} -> {}! Outer URL: {} 🌐",
+    zh: "✅ 重命名 {} -> {} 成功! Outer URL: {} 🌐",
+};
+pub const ALIAS_RENAME_NOT_FOUND: Msg = Msg {
+    en: "❌ alias {} does not exist!",
+    zh: "❌ 别名 {} 不存在!",
+};
+pub const ALIAS_MODIFIED: Msg = Msg {
+    en: "set {} in {} to {{}} ✅",
+    zh: "修改 {} 在 {} 下的值为 {{}} 成功 ✅",
+};
+pub const ALIAS_MODIFY_NOT_FOUND: Msg = Msg {
+    en: "alias {} does not exist, add it with the set command first.",
+    zh: "别名 {} 不存在，请先使用 set 命令添加。",
+};
+pub const ALIAS_PATH_EXISTS: Msg = Msg {
+    en: "alias {} already points to {{}}. 😢 use the `mf` command to change it",
+    zh: "别名 {} 的路径 {{}} 已存在。 😢 请使用 `mf` 命令修改",
+};
+pub const ALIAS_ADDED_PATH: Msg = Msg {
+    en: "✅ added alias {} -> {{}}! 🎉",
+    zh: "✅ 添加别名 {} -> {{}} 成功! 🎉",
+};
+pub const ALIAS_EXISTS: Msg = Msg {
+    en: "alias {} already exists. 😢 use the `mf` command to change it",
+    zh: "别名 {} 已存在。 😢 请使用 `mf` 命令修改",
+};
+pub const ALIAS_ADDED_URL: Msg = Msg {
+    en: "✅ added alias {} -> {{}}! 🚀",
+    zh: "✅ 添加别名 {} -> {{}} 成功! 🚀",
+};
@@ -0,0 +1,40 @@
+//! category 命令的文案
+
+use super::Msg;
+
+pub const CATEGORY_ALIAS_NOT_FOUND: Msg = Msg {
+    en: "❌ alias {} does not exist",
+    zh: "❌ 别名 {} 不存在",
+};
+pub const CATEGORY_NOTE_USAGE: Msg = Msg {
+    en: "j note <alias> <category> (one of: {})",
+    zh: "j note <alias> <category> (可选: {})",
+};
+pub const CATEGORY_MARKED_OUTER_URL: Msg = Msg {
+    en: "✅ marked alias {} as OUTER_URL",
+    zh: "✅ 将别名 {} 标记为 OUTER_URL 成功",
+};
+pub const CATEGORY_NOT_INNER_URL: Msg = Msg {
+    en: "❌ alias {} is not in INNER_URL, cannot mark it as OUTER_URL",
+    zh: "❌ 别名 {} 不在 INNER_URL 中，无法标记为 OUTER_URL",
+};
+pub const CATEGORY_MARKED: Msg = Msg {
+    en: "✅ marked alias {} as {}",
+    zh: "✅ 将别名 {} 标记为 {} 成功",
+};
+pub const CATEGORY_NOT_PATH: Msg = Msg {
+    en: "❌ alias {} is not in PATH, cannot mark it",
+    zh: "❌ 别名 {} 不在 PATH 中，无法标记",
+};
+pub const CATEGORY_DENOTE_USAGE: Msg = Msg {
+    en: "j denote <alias> <category> (one of: {})",
+    zh: "j denote <alias> <category> (可选: {})",
+};
+pub const CATEGORY_NOT_MARKED: Msg = Msg {
+    en: "❌ alias {} is not in category {}",
+    zh: "❌ 别名 {} 不在 {} 分类中",
+};
+pub const CATEGORY_REMOVED: Msg = Msg {
+    en: "✅ removed alias {} from {}",
+    zh: "✅ 已将别名 {} 从 {} 中移除",
+};
@@ -0,0 +1,960 @@
+//! chat 命令（AI 对话）的文案
+
+use super::Msg;
+
+pub const CHAT_TOOL_CALL: Msg = Msg {
+    en: "call tool {} with arguments: {}",
+    zh: "调用工具 {} 参数: {}",
+};
+pub const CHAT_TOOL_RISK_UNKNOWN: Msg = Msg {
+    en: "⚠ cannot analyze the command, treating it as high risk: {}",
+    zh: "⚠ 无法分析命令的风险，按高风险处理: {}",
+};
+pub const CHAT_TOOL_RISK_HIGH: Msg = Msg {
+    en: "high risk",
+    zh: "高风险",
+};
+pub const CHAT_TOOL_RISK_NOTICE: Msg = Msg {
+    en: "notice",
+    zh: "提示",
+};
+pub const CHAT_TOOL_SHELL_DESC: Msg = Msg {
+    en: "Run a shell command on this system and return its stdout and stderr; every run_shell call starts a new process, so no state carries over between calls",
+    zh: "在当前系统上执行 shell 命令，返回命令的 stdout 和 stderr 输出；注意每次调用 run_shell 都会创建一个新的进程，状态是不延续的",
+};
+pub const CHAT_TOOL_SHELL_COMMAND: Msg = Msg {
+    en: "the shell command to run (run with bash)",
+    zh: "要执行的 shell 命令（在 bash 中执行）",
+};
+pub const CHAT_TOOL_NO_COMMAND: Msg = Msg {
+    en: "the arguments have no command field",
+    zh: "参数缺少 command 字段",
+};
+pub const CHAT_TOOL_BAD_ARGS: Msg = Msg {
+    en: "failed to parse the arguments: {}",
+    zh: "参数解析失败: {}",
+};
+pub const CHAT_TOOL_NO_OUTPUT: Msg = Msg {
+    en: "(no output)",
+    zh: "(无输出)",
+};
+pub const CHAT_TOOL_OUTPUT_TRUNCATED: Msg = Msg {
+    en: "{}\n...(output truncated)",
+    zh: "{}\n...(输出已截断)",
+};
+pub const CHAT_TOOL_RUN_FAILED: Msg = Msg {
+    en: "failed to run: {}",
+    zh: "执行失败: {}",
+};
+pub const CHAT_TOOL_CONFIRM_SHELL: Msg = Msg {
+    en: "about to run: {}",
+    zh: "即将执行: {}",
+};
+pub const CHAT_TOOL_READ_DESC: Msg = Msg {
+    en: "Read a local file and return its content with line numbers. Use offset and limit to read a range of lines.",
+    zh: "读取本地文件内容并返回（带行号）。支持通过 offset 和 limit 参数按行范围读取。",
+};
+pub const CHAT_TOOL_READ_PATH: Msg = Msg {
+    en: "the file to read (absolute, or relative to the working directory)",
+    zh: "要读取的文件路径（绝对路径或相对于当前工作目录）",
+};
+pub const CHAT_TOOL_READ_OFFSET: Msg = Msg {
+    en: "the line to start reading at (0-based, 0 is the first line); reads from the start when omitted",
+    zh: "从第几行开始读取（0-based，即 0 表示第 1 行），不传则从头开始",
+};
+pub const CHAT_TOOL_READ_LIMIT: Msg = Msg {
+    en: "how many lines to read; reads to the end of the file when omitted",
+    zh: "读取多少行，不传则读到文件末尾",
+};
+pub const CHAT_TOOL_NO_PATH: Msg = Msg {
+    en: "the arguments have no path field",
+    zh: "参数缺少 path 字段",
+};
+pub const CHAT_TOOL_MORE_LINES: Msg = Msg {
+    en: "\n...({} more lines not shown)",
+    zh: "\n...(还有 {} 行未显示)",
+};
+pub const CHAT_TOOL_FILE_TRUNCATED: Msg = Msg {
+    en: "{}\n...(file content truncated)",
+    zh: "{}\n...(文件内容已截断)",
+};
+pub const CHAT_TOOL_READ_FAILED: Msg = Msg {
+    en: "failed to read the file: {}",
+    zh: "读取文件失败: {}",
+};
+pub const CHAT_TOOL_WRITE_DESC: Msg = Msg {
+    en: "Write content to a file. An existing file is overwritten and missing directories are created.",
+    zh: "将内容写入指定文件。如果文件已存在则覆盖，如果目录不存在会自动创建。",
+};
+pub const CHAT_TOOL_WRITE_PATH: Msg = Msg {
+    en: "the file to write (absolute, or relative to the working directory)",
+    zh: "要写入的文件路径（绝对路径或相对于当前工作目录）",
+};
+pub const CHAT_TOOL_WRITE_CONTENT: Msg = Msg {
+    en: "the content to write",
+    zh: "要写入的文件内容",
+};
+pub const CHAT_TOOL_NO_CONTENT: Msg = Msg {
+    en: "the arguments have no content field",
+    zh: "参数缺少 content 字段",
+};
+pub const CHAT_TOOL_MKDIR_FAILED: Msg = Msg {
+    en: "failed to create the directory: {}",
+    zh: "创建目录失败: {}",
+};
+pub const CHAT_TOOL_WRITTEN: Msg = Msg {
+    en: "wrote file: {} ({} bytes)",
+    zh: "已写入文件: {} ({} 字节)",
+};
+pub const CHAT_TOOL_WRITE_FAILED: Msg = Msg {
+    en: "failed to write the file: {}",
+    zh: "写入文件失败: {}",
+};
+pub const CHAT_TOOL_UNKNOWN_PATH: Msg = Msg {
+    en: "unknown path",
+    zh: "未知路径",
+};
+pub const CHAT_TOOL_CONFIRM_WRITE: Msg = Msg {
+    en: "about to write file: {}",
+    zh: "即将写入文件: {}",
+};
+pub const CHAT_TOOL_EDIT_DESC: Msg = Msg {
+    en: "Edit a file by exact string replacement. old_string must match exactly once in the file and is replaced with new_string; an empty new_string deletes the match.",
+    zh: "通过精确字符串匹配替换来编辑文件。old_string 必须在文件中唯一匹配，替换为 new_string。如果 new_string 为空字符串则表示删除匹配内容。",
+};
+pub const CHAT_TOOL_EDIT_PATH: Msg = Msg {
+    en: "the file to edit",
+    zh: "要编辑的文件路径",
+};
+pub const CHAT_TOOL_EDIT_OLD: Msg = Msg {
+    en: "the original string to replace (must occur exactly once in the file)",
+    zh: "要被替换的原始字符串（必须在文件中唯一存在）",
+};
+pub const CHAT_TOOL_EDIT_NEW: Msg = Msg {
+    en: "the replacement string; empty deletes the match",
+    zh: "替换后的新字符串，为空则表示删除",
+};
+pub const CHAT_TOOL_NO_OLD: Msg = Msg {
+    en: "the arguments have no old_string field",
+    zh: "参数缺少 old_string 字段",
+};
+pub const CHAT_TOOL_NO_MATCH: Msg = Msg {
+    en: "the string was not found",
+    zh: "未找到匹配的字符串",
+};
+pub const CHAT_TOOL_MANY_MATCHES: Msg = Msg {
+    en: "old_string matched {} times in the file but must match exactly once, include more context to make it unique",
+    zh: "old_string 在文件中匹配了 {} 次，必须唯一匹配。请提供更多上下文使其唯一",
+};
+pub const CHAT_TOOL_EDITED: Msg = Msg {
+    en: "edited file: {}",
+    zh: "已编辑文件: {}",
+};
+pub const CHAT_TOOL_CONFIRM_EDIT: Msg = Msg {
+    en: "about to edit file {} (replacing: \"{}\")",
+    zh: "即将编辑文件 {} (替换: \"{}\")",
+};
+pub const CHAT_TUI_FAILED: Msg = Msg {
+    en: "❌ failed to start the chat TUI: {}",
+    zh: "❌ Chat TUI 启动失败: {}",
+};
+pub const CHAT_NO_PROVIDER: Msg = Msg {
+    en: "⚠️  no LLM provider configured yet, run j chat to see how to set one up.",
+    zh: "⚠️  尚未配置 LLM 模型提供方，请先运行 j chat 查看配置说明。",
+};
+pub const CHAT_EDIT_SYSTEM_PROMPT: Msg = Msg {
+    en: "Edit system prompt",
+    zh: "编辑系统提示词 (System Prompt)",
+};
+pub const CHAT_SYSTEM_PROMPT_UPDATED: Msg = Msg {
+    en: "system prompt updated",
+    zh: "系统提示词已更新",
+};
+pub const CHAT_SYSTEM_PROMPT_SAVE_FAILED: Msg = Msg {
+    en: "failed to save the system prompt",
+    zh: "系统提示词保存失败",
+};
+pub const CHAT_EDITOR_ERROR: Msg = Msg {
+    en: "editor error: {}",
+    zh: "编辑器错误: {}",
+};
+pub const CHAT_EDIT_STYLE: Msg = Msg {
+    en: "Edit reply style",
+    zh: "编辑回复风格 (Style)",
+};
+pub const CHAT_STYLE_UPDATED: Msg = Msg {
+    en: "reply style updated",
+    zh: "回复风格已更新",
+};
+pub const CHAT_STYLE_SAVE_FAILED: Msg = Msg {
+    en: "failed to save the reply style",
+    zh: "回复风格保存失败",
+};
+pub const CHAT_ARCHIVE_EMPTY: Msg = Msg {
+    en: "the conversation is empty, nothing to archive",
+    zh: "当前对话为空，无法归档",
+};
+pub const CHAT_COPIED_LAST: Msg = Msg {
+    en: "copied the last AI reply",
+    zh: "已复制最后一条 AI 回复",
+};
+pub const CHAT_COPY_FAILED: Msg = Msg {
+    en: "failed to copy to the clipboard",
+    zh: "复制到剪切板失败",
+};
+pub const CHAT_NOTHING_TO_COPY: Msg = Msg {
+    en: "no AI reply to copy yet",
+    zh: "暂无 AI 回复可复制",
+};
+pub const CHAT_NOTHING_TO_BROWSE: Msg = Msg {
+    en: "no messages to browse yet",
+    zh: "暂无消息可浏览",
+};
+pub const CHAT_STREAM_MODE: Msg = Msg {
+    en: "Streaming",
+    zh: "流式输出",
+};
+pub const CHAT_WHOLE_MODE: Msg = Msg {
+    en: "Whole replies",
+    zh: "整体输出",
+};
+pub const CHAT_SWITCHED_TO: Msg = Msg {
+    en: "switched to: {}",
+    zh: "已切换为: {}",
+};
+pub const CHAT_ROLE_USER: Msg = Msg {
+    en: "user",
+    zh: "用户",
+};
+pub const CHAT_ROLE_SYSTEM: Msg = Msg {
+    en: "system",
+    zh: "系统",
+};
+pub const CHAT_COPIED_MESSAGE: Msg = Msg {
+    en: "copied message {} ({})",
+    zh: "已复制第 {} 条{}消息",
+};
+pub const CHAT_FIELD_NAME: Msg = Msg {
+    en: "Display name",
+    zh: "显示名称",
+};
+pub const CHAT_FIELD_MODEL: Msg = Msg {
+    en: "Model",
+    zh: "模型名称",
+};
+pub const CHAT_FIELD_SYSTEM_PROMPT: Msg = Msg {
+    en: "System prompt",
+    zh: "系统提示词",
+};
+pub const CHAT_FIELD_STYLE: Msg = Msg {
+    en: "Reply style",
+    zh: "回复风格",
+};
+pub const CHAT_FIELD_MAX_HISTORY: Msg = Msg {
+    en: "History messages",
+    zh: "历史消息数",
+};
+pub const CHAT_FIELD_THEME: Msg = Msg {
+    en: "Theme",
+    zh: "主题风格",
+};
+pub const CHAT_FIELD_TOOLS: Msg = Msg {
+    en: "Tool calls",
+    zh: "工具调用",
+};
+pub const CHAT_FIELD_MAX_TOOL_ROUNDS: Msg = Msg {
+    en: "Max tool rounds",
+    zh: "工具轮数上限",
+};
+pub const CHAT_ON: Msg = Msg {
+    en: "on",
+    zh: "开启",
+};
+pub const CHAT_OFF: Msg = Msg {
+    en: "off",
+    zh: "关闭",
+};
+pub const CHAT_CONFIG_SAVED: Msg = Msg {
+    en: "config saved ✅",
+    zh: "配置已保存 ✅",
+};
+pub const CHAT_CONFIG_SAVE_FAILED: Msg = Msg {
+    en: "failed to save the config",
+    zh: "配置保存失败",
+};
+pub const CHAT_NO_PROVIDER_ADD: Msg = Msg {
+    en: "no providers yet, press a to add one",
+    zh: "还没有 Provider，按 a 新增",
+};
+pub const CHAT_PROVIDER_ADDED: Msg = Msg {
+    en: "provider added, fill in its settings",
+    zh: "已新增 Provider，请填写配置",
+};
+pub const CHAT_NO_PROVIDER_DELETE: Msg = Msg {
+    en: "no provider to delete",
+    zh: "没有可删除的 Provider",
+};
+pub const CHAT_PROVIDER_DELETED: Msg = Msg {
+    en: "deleted provider: {}",
+    zh: "已删除 Provider: {}",
+};
+pub const CHAT_PROVIDER_ACTIVATED: Msg = Msg {
+    en: "active model set to: {}",
+    zh: "已设为活跃模型: {}",
+};
+pub const CHAT_STYLE_UNSET: Msg = Msg {
+    en: "(not set)",
+    zh: "（未设置）",
+};
+pub const CHAT_NOT_CONFIGURED: Msg = Msg {
+    en: "not configured",
+    zh: "未配置",
+};
+pub const CHAT_NO_PROVIDER_EDIT: Msg = Msg {
+    en: "no model provider configured, edit the config file first",
+    zh: "未配置模型提供方，请先编辑配置文件",
+};
+pub const CHAT_RUNTIME_FAILED: Msg = Msg {
+    en: "failed to create the async runtime: {}",
+    zh: "创建异步运行时失败: {}",
+};
+pub const CHAT_REQUEST_FAILED: Msg = Msg {
+    en: "request failed: {}",
+    zh: "请求失败: {}",
+};
+pub const CHAT_UNKNOWN_TOOL: Msg = Msg {
+    en: "unknown tool: {}",
+    zh: "未知工具: {}",
+};
+pub const CHAT_TOOL_REJECTED: Msg = Msg {
+    en: "the user declined to run this tool",
+    zh: "用户拒绝执行该工具",
+};
+pub const CHAT_REPLY_DONE: Msg = Msg {
+    en: "reply finished ✓",
+    zh: "回复完成 ✓",
+};
+pub const CHAT_CLEARED: Msg = Msg {
+    en: "conversation cleared",
+    zh: "对话已清空",
+};
+pub const CHAT_SWITCHED_MODEL: Msg = Msg {
+    en: "switched to: {}",
+    zh: "已切换到: {}",
+};
+pub const CHAT_ARCHIVED: Msg = Msg {
+    en: "conversation archived: {}",
+    zh: "对话已归档: {}",
+};
+pub const CHAT_RESTORED: Msg = Msg {
+    en: "archive restored: {}",
+    zh: "已还原归档: {}",
+};
+pub const CHAT_ARCHIVE_DELETED: Msg = Msg {
+    en: "archive deleted: {}",
+    zh: "归档已删除: {}",
+};
+pub const CHAT_LOG_REQUEST: Msg = Msg {
+    en: "Chat request",
+    zh: "Chat 请求",
+};
+pub const CHAT_BUILD_REQUEST_FAILED: Msg = Msg {
+    en: "failed to build the request: {}",
+    zh: "构建请求失败: {}",
+};
+pub const CHAT_API_FAILED: Msg = Msg {
+    en: "API request failed: {}",
+    zh: "API 请求失败: {}",
+};
+pub const CHAT_LOG_STREAM_CREATE: Msg = Msg {
+    en: "Chat API stream request",
+    zh: "Chat API 流式请求创建",
+};
+pub const CHAT_LOG_STREAM_RESPONSE: Msg = Msg {
+    en: "Chat API stream response",
+    zh: "Chat API 流式响应",
+};
+pub const CHAT_LOG_REPLY: Msg = Msg {
+    en: "Chat reply",
+    zh: "Chat 回复",
+};
+pub const CHAT_LOG_TOOL_CALLS: Msg = Msg {
+    en: "Tool call request",
+    zh: "工具调用请求",
+};
+pub const CHAT_TOOL_TIMEOUT: Msg = Msg {
+    en: "timed out waiting for the tool to run",
+    zh: "等待工具执行超时",
+};
+pub const CHAT_LOG_TOOL_RESULTS: Msg = Msg {
+    en: "Tool call results",
+    zh: "工具调用结果",
+};
+pub const CHAT_API_FALLBACK_FAILED: Msg = Msg {
+    en: "API request failed (fallback): {}",
+    zh: "API 请求失败(fallback): {}",
+};
+pub const CHAT_LOG_FALLBACK: Msg = Msg {
+    en: "Chat API non-streaming fallback",
+    zh: "Chat API fallback 非流式",
+};
+pub const CHAT_LOG_TOOL_RESULT: Msg = Msg {
+    en: "- [{}] {}({})\nresult: {}\n",
+    zh: "- [{}] {}({})\n结果: {}\n",
+};
+pub const CHAT_LOG_NON_STREAM: Msg = Msg {
+    en: "Chat API non-streaming request",
+    zh: "Chat API 非流式请求",
+};
+pub const CHAT_RUNNING_TOOL: Msg = Msg {
+    en: " 🔧 running {}...",
+    zh: " 🔧 执行 {}...",
+};
+pub const CHAT_THINKING: Msg = Msg {
+    en: " ⏳ thinking...",
+    zh: " ⏳ 思考中...",
+};
+pub const CHAT_MESSAGE_COUNT: Msg = Msg {
+    en: "📨 {} messages",
+    zh: "📨 {} 条消息",
+};
+pub const CHAT_MESSAGES_TITLE: Msg = Msg {
+    en: " Conversation ",
+    zh: " 对话记录 ",
+};
+pub const CHAT_INPUT_TITLE: Msg = Msg {
+    en: " Message ",
+    zh: " 输入消息 ",
+};
+pub const CHAT_KEY_SEND: Msg = Msg {
+    en: "send",
+    zh: "发送",
+};
+pub const CHAT_KEY_SCROLL: Msg = Msg {
+    en: "scroll",
+    zh: "滚动",
+};
+pub const CHAT_KEY_SKILL: Msg = Msg {
+    en: "skills",
+    zh: "技能",
+};
+pub const CHAT_KEY_SWITCH_MODEL: Msg = Msg {
+    en: "switch model",
+    zh: "切换模型",
+};
+pub const CHAT_KEY_ARCHIVE: Msg = Msg {
+    en: "archive",
+    zh: "归档",
+};
+pub const CHAT_KEY_RESTORE: Msg = Msg {
+    en: "restore",
+    zh: "还原",
+};
+pub const CHAT_KEY_COPY: Msg = Msg {
+    en: "copy",
+    zh: "复制",
+};
+pub const CHAT_KEY_BROWSE: Msg = Msg {
+    en: "browse",
+    zh: "浏览",
+};
+pub const CHAT_KEY_STREAM: Msg = Msg {
+    en: "streaming",
+    zh: "流式切换",
+};
+pub const CHAT_KEY_CONFIG: Msg = Msg {
+    en: "config",
+    zh: "配置",
+};
+pub const CHAT_KEY_HELP: Msg = Msg {
+    en: "help",
+    zh: "帮助",
+};
+pub const CHAT_KEY_QUIT: Msg = Msg {
+    en: "quit",
+    zh: "退出",
+};
+pub const CHAT_KEY_MOVE: Msg = Msg {
+    en: "move",
+    zh: "移动",
+};
+pub const CHAT_KEY_CONFIRM: Msg = Msg {
+    en: "confirm",
+    zh: "确认",
+};
+pub const CHAT_KEY_CANCEL: Msg = Msg {
+    en: "cancel",
+    zh: "取消",
+};
+pub const CHAT_KEY_SELECT_MESSAGE: Msg = Msg {
+    en: "select message",
+    zh: "选择消息",
+};
+pub const CHAT_KEY_BACK: Msg = Msg {
+    en: "back",
+    zh: "返回",
+};
+pub const CHAT_KEY_ANY: Msg = Msg {
+    en: "any key",
+    zh: "任意键",
+};
+pub const CHAT_KEY_SWITCH_FIELD: Msg = Msg {
+    en: "switch field",
+    zh: "切换字段",
+};
+pub const CHAT_KEY_EDIT: Msg = Msg {
+    en: "edit",
+    zh: "编辑",
+};
+pub const CHAT_KEY_SWITCH_PROVIDER: Msg = Msg {
+    en: "switch provider",
+    zh: "切换 Provider",
+};
+pub const CHAT_KEY_ADD: Msg = Msg {
+    en: "add",
+    zh: "新增",
+};
+pub const CHAT_KEY_DELETE: Msg = Msg {
+    en: "delete",
+    zh: "删除",
+};
+pub const CHAT_KEY_SAVE_BACK: Msg = Msg {
+    en: "save and back",
+    zh: "保存返回",
+};
+pub const CHAT_KEY_ARCHIVE_DEFAULT: Msg = Msg {
+    en: "archive with the default name",
+    zh: "默认名称归档",
+};
+pub const CHAT_KEY_CUSTOM_NAME: Msg = Msg {
+    en: "custom name",
+    zh: "自定义名称",
+};
+pub const CHAT_KEY_CONFIRM_RESTORE: Msg = Msg {
+    en: "restore",
+    zh: "确认还原",
+};
+pub const CHAT_KEY_SELECT: Msg = Msg {
+    en: "select",
+    zh: "选择",
+};
+pub const CHAT_KEY_RUN_TOOL: Msg = Msg {
+    en: "run tool",
+    zh: "执行工具",
+};
+pub const CHAT_KEY_REJECT: Msg = Msg {
+    en: "reject",
+    zh: "拒绝",
+};
+pub const CHAT_SELECT_MODEL_TITLE: Msg = Msg {
+    en: " 🔄 Select model ",
+    zh: " 🔄 选择模型 ",
+};
+pub const CHAT_HELP_HEADING: Msg = Msg {
+    en: "  📖 Keyboard shortcuts",
+    zh: "  📖 快捷键帮助",
+};
+pub const CHAT_HELP_SEND: Msg = Msg {
+    en: "send the message",
+    zh: "发送消息",
+};
+pub const CHAT_HELP_SCROLL: Msg = Msg {
+    en: "scroll the conversation",
+    zh: "滚动对话记录",
+};
+pub const CHAT_HELP_CURSOR: Msg = Msg {
+    en: "move the input cursor",
+    zh: "移动输入光标",
+};
+pub const CHAT_HELP_ARCHIVE: Msg = Msg {
+    en: "archive the conversation",
+    zh: "归档当前对话",
+};
+pub const CHAT_HELP_RESTORE: Msg = Msg {
+    en: "restore an archived conversation",
+    zh: "还原归档对话",
+};
+pub const CHAT_HELP_COPY: Msg = Msg {
+    en: "copy the last AI reply",
+    zh: "复制最后一条 AI 回复",
+};
+pub const CHAT_HELP_BROWSE: Msg = Msg {
+    en: "browse messages (↑↓ select, y/Enter copy)",
+    zh: "浏览消息 (↑↓选择, y/Enter复制)",
+};
+pub const CHAT_HELP_STREAM: Msg = Msg {
+    en: "toggle streaming / whole replies",
+    zh: "切换流式/整体输出",
+};
+pub const CHAT_HELP_CONFIG: Msg = Msg {
+    en: "open the config screen",
+    zh: "打开配置界面",
+};
+pub const CHAT_HELP_QUIT: Msg = Msg {
+    en: "leave the chat",
+    zh: "退出对话",
+};
+pub const CHAT_HELP_TOGGLE: Msg = Msg {
+    en: "show / hide this help",
+    zh: "显示 / 关闭此帮助",
+};
+pub const CHAT_HELP_CONFIG_FILE: Msg = Msg {
+    en: "  📁 Config file:",
+    zh: "  📁 配置文件:",
+};
+pub const CHAT_HELP_TITLE: Msg = Msg {
+    en: " Help (press any key to go back) ",
+    zh: " 帮助 (按任意键返回) ",
+};
+pub const CHAT_CONFIG_HEADING: Msg = Msg {
+    en: "  ⚙️  Model settings",
+    zh: "  ⚙️  模型配置",
+};
+pub const CHAT_CONFIG_PROVIDER_HINT: Msg = Msg {
+    en: "    (● = active model, Tab to switch, s to make active)",
+    zh: "    (● = 活跃模型, Tab 切换, s 设为活跃)",
+};
+pub const CHAT_CONFIG_NO_PROVIDER: Msg = Msg {
+    en: "  (no providers, press a to add one)",
+    zh: "  (无 Provider，按 a 新增)",
+};
+pub const CHAT_CONFIG_PROVIDER_HEADING: Msg = Msg {
+    en: "  📦 Provider settings",
+    zh: "  📦 Provider 配置",
+};
+pub const CHAT_CONFIG_EMPTY: Msg = Msg {
+    en: "(empty)",
+    zh: "(空)",
+};
+pub const CHAT_CONFIG_GLOBAL_HEADING: Msg = Msg {
+    en: "  🌐 Global settings",
+    zh: "  🌐 全局配置",
+};
+pub const CHAT_CONFIG_ON: Msg = Msg {
+    en: "● on",
+    zh: "● 开启",
+};
+pub const CHAT_CONFIG_OFF: Msg = Msg {
+    en: "○ off",
+    zh: "○ 关闭",
+};
+pub const CHAT_CONFIG_ENTER_TOGGLE: Msg = Msg {
+    en: "  (Enter to toggle)",
+    zh: "  (Enter 切换)",
+};
+pub const CHAT_CONFIG_ENTER_EDIT: Msg = Msg {
+    en: "  (Enter to edit)",
+    zh: "  (Enter 编辑)",
+};
+pub const CHAT_CONFIG_KEY_FIELD: Msg = Msg {
+    en: " switch field  ",
+    zh: " 切换字段  ",
+};
+pub const CHAT_CONFIG_KEY_EDIT: Msg = Msg {
+    en: " edit  ",
+    zh: " 编辑  ",
+};
+pub const CHAT_CONFIG_KEY_PROVIDER: Msg = Msg {
+    en: " switch provider  ",
+    zh: " 切换 Provider  ",
+};
+pub const CHAT_CONFIG_KEY_ADD: Msg = Msg {
+    en: " add  ",
+    zh: " 新增  ",
+};
+pub const CHAT_CONFIG_KEY_DELETE: Msg = Msg {
+    en: " delete  ",
+    zh: " 删除  ",
+};
+pub const CHAT_CONFIG_KEY_ACTIVATE: Msg = Msg {
+    en: " make active  ",
+    zh: " 设为活跃  ",
+};
+pub const CHAT_CONFIG_KEY_SAVE: Msg = Msg {
+    en: " save and back",
+    zh: " 保存返回",
+};
+pub const CHAT_CONFIG_TITLE: Msg = Msg {
+    en: " ⚙️  Edit model settings ",
+    zh: " ⚙️  模型配置编辑 ",
+};
+pub const CHAT_ARCHIVE_HEADING: Msg = Msg {
+    en: "  📦 Archive the conversation",
+    zh: "  📦 归档当前对话",
+};
+pub const CHAT_ARCHIVE_WARNING: Msg = Msg {
+    en: "  The conversation will be archived and then cleared.",
+    zh: "  即将归档当前对话，归档后当前会话将被清空。",
+};
+pub const CHAT_ARCHIVE_NAME_PROMPT: Msg = Msg {
+    en: "  Archive name:",
+    zh: "  请输入归档名称：",
+};
+pub const CHAT_ARCHIVE_NAME_HINT: Msg = Msg {
+    en: "  Tip: leave it empty for the default name (e.g. archive-2026-02-25)",
+    zh: "  提示：留空则使用默认名称（如 archive-2026-02-25）",
+};
+pub const CHAT_ARCHIVE_CONFIRM: Msg = Msg {
+    en: "  archive",
+    zh: "  确认归档",
+};
+pub const CHAT_ARCHIVE_CANCEL: Msg = Msg {
+    en: "    cancel",
+    zh: "    取消",
+};
+pub const CHAT_ARCHIVE_DEFAULT_NAME: Msg = Msg {
+    en: "  Default name: ",
+    zh: "  默认名称：",
+};
+pub const CHAT_ARCHIVE_USE_DEFAULT: Msg = Msg {
+    en: "  archive with the default name",
+    zh: "  使用默认名称归档",
+};
+pub const CHAT_ARCHIVE_CUSTOM: Msg = Msg {
+    en: "      custom name",
+    zh: "      自定义名称",
+};
+pub const CHAT_ARCHIVE_CLEAR_ONLY: Msg = Msg {
+    en: "      clear without archiving",
+    zh: "      仅清空不归档",
+};
+pub const CHAT_ARCHIVE_CONFIRM_TITLE: Msg = Msg {
+    en: " Confirm archive ",
+    zh: " 归档确认 ",
+};
+pub const CHAT_RESTORE_HEADING: Msg = Msg {
+    en: "  ⚠️  Confirm restore",
+    zh: "  ⚠️  确认还原",
+};
+pub const CHAT_RESTORE_WARNING: Msg = Msg {
+    en: "  The conversation is not archived, restoring will discard it!",
+    zh: "  当前对话未归档，还原将丢失当前对话内容！",
+};
+pub const CHAT_RESTORE_TARGET: Msg = Msg {
+    en: "  Restoring: ",
+    zh: "  将还原归档：",
+};
+pub const CHAT_RESTORE_CONFIRM: Msg = Msg {
+    en: "  restore",
+    zh: "  确认还原",
+};
+pub const CHAT_RESTORE_CANCEL: Msg = Msg {
+    en: "     cancel",
+    zh: "     取消",
+};
+pub const CHAT_RESTORE_CONFIRM_TITLE: Msg = Msg {
+    en: " Confirm restore ",
+    zh: " 还原确认 ",
+};
+pub const CHAT_ARCHIVE_NONE: Msg = Msg {
+    en: "  📦 No archived conversations",
+    zh: "  📦 暂无归档对话",
+};
+pub const CHAT_ARCHIVE_NONE_HINT: Msg = Msg {
+    en: "  Press Ctrl+L to archive the conversation",
+    zh: "  按 Ctrl+L 归档当前对话",
+};
+pub const CHAT_ARCHIVE_BACK_HINT: Msg = Msg {
+    en: "  Press Esc to go back to the chat",
+    zh: "  按 Esc 返回聊天",
+};
+pub const CHAT_ARCHIVE_LIST_TITLE: Msg = Msg {
+    en: " Archives ",
+    zh: " 归档列表 ",
+};
+pub const CHAT_ARCHIVE_ITEM: Msg = Msg {
+    en: "{}{}  📨 {} messages  📅 {}",
+    zh: "{}{}  📨 {} 条消息  📅 {}",
+};
+pub const CHAT_ARCHIVE_LIST_KEYS: Msg = Msg {
+    en: " 📦 Archives (Enter restore, d delete, Esc back) ",
+    zh: " 📦 归档列表 (Enter 还原, d 删除, Esc 返回) ",
+};
+pub const CHAT_TOOL_LABEL: Msg = Msg {
+    en: "tool {}",
+    zh: "工具 {}",
+};
+pub const CHAT_TOOL_RESULT: Msg = Msg {
+    en: "tool result",
+    zh: "工具结果",
+};
+pub const CHAT_TOOL_CONFIRM_HEADING: Msg = Msg {
+    en: "  🔧 Confirm tool call",
+    zh: "  🔧 工具调用确认",
+};
+pub const CHAT_TOOL_NAME_LABEL: Msg = Msg {
+    en: "tool: ",
+    zh: "工具: ",
+};
+pub const CHAT_TOOL_TYPE_TO_CONFIRM: Msg = Msg {
+    en: "type {} and press Enter to run, Esc or anything else rejects: {}█",
+    zh: "输入 {} 后按 Enter 执行，Esc 或其他输入拒绝: {}█",
+};
+pub const CHAT_CONFIRM_RUN: Msg = Msg {
+    en: "[Y/Enter] run",
+    zh: "[Y/Enter] 执行",
+};
+pub const CHAT_CONFIRM_REJECT: Msg = Msg {
+    en: "[N/Esc] reject",
+    zh: "[N/Esc] 拒绝",
+};
+pub const CHAT_TOOL_CALLS_HEADING: Msg = Msg {
+    en: "  🔧 AI tool calls",
+    zh: "  🔧 AI 调用工具",
+};
+pub const CHAT_THEME_MIDNIGHT: Msg = Msg {
+    en: "Midnight (default)",
+    zh: "Midnight（默认）",
+};
+pub const CHAT_ARCHIVE_PARSE_FAILED_AT: Msg = Msg {
+    en: "[list_archives] failed to parse the archive {}: {}",
+    zh: "[list_archives] 解析归档文件失败: {}, 错误: {}",
+};
+pub const CHAT_ARCHIVE_MKDIR_FAILED: Msg = Msg {
+    en: "failed to create the archive directory: {}",
+    zh: "创建归档目录失败: {}",
+};
+pub const CHAT_ARCHIVE_SERIALIZE_FAILED: Msg = Msg {
+    en: "failed to serialize the archive: {}",
+    zh: "序列化归档失败: {}",
+};
+pub const CHAT_ARCHIVE_WRITE_FAILED: Msg = Msg {
+    en: "failed to write the archive file: {}",
+    zh: "写入归档文件失败: {}",
+};
+pub const CHAT_ARCHIVE_MISSING: Msg = Msg {
+    en: "archive does not exist: {}",
+    zh: "归档文件不存在: {}",
+};
+pub const CHAT_ARCHIVE_READ_FAILED: Msg = Msg {
+    en: "failed to read the archive file: {}",
+    zh: "读取归档文件失败: {}",
+};
+pub const CHAT_ARCHIVE_PARSE_FAILED: Msg = Msg {
+    en: "failed to parse the archive file: {}",
+    zh: "解析归档文件失败: {}",
+};
+pub const CHAT_ARCHIVE_DELETE_FAILED: Msg = Msg {
+    en: "failed to delete the archive file: {}",
+    zh: "删除归档文件失败: {}",
+};
+pub const CHAT_ARCHIVE_NAME_EMPTY: Msg = Msg {
+    en: "the archive name cannot be empty",
+    zh: "归档名称不能为空",
+};
+pub const CHAT_ARCHIVE_NAME_TOO_LONG: Msg = Msg {
+    en: "the archive name is too long, at most 50 characters",
+    zh: "归档名称过长，最多 50 字符",
+};
+pub const CHAT_ARCHIVE_NAME_INVALID: Msg = Msg {
+    en: "the archive name contains an invalid character: {}",
+    zh: "归档名称包含非法字符: {}",
+};
+pub const CHAT_CONFIG_PARSE_FAILED: Msg = Msg {
+    en: "❌ failed to parse agent_config.json: {}",
+    zh: "❌ 解析 agent_config.json 失败: {}",
+};
+pub const CHAT_CONFIG_READ_FAILED: Msg = Msg {
+    en: "❌ failed to read agent_config.json: {}",
+    zh: "❌ 读取 agent_config.json 失败: {}",
+};
+pub const CHAT_CONFIG_WRITE_FAILED: Msg = Msg {
+    en: "❌ failed to save agent_config.json: {}",
+    zh: "❌ 保存 agent_config.json 失败: {}",
+};
+pub const CHAT_CONFIG_SERIALIZE_FAILED: Msg = Msg {
+    en: "❌ failed to serialize the agent config: {}",
+    zh: "❌ 序列化 agent 配置失败: {}",
+};
+pub const CHAT_PROMPT_READ_FAILED: Msg = Msg {
+    en: "❌ failed to read system_prompt.md: {}",
+    zh: "❌ 读取 system_prompt.md 失败: {}",
+};
+pub const CHAT_PROMPT_DELETE_FAILED: Msg = Msg {
+    en: "❌ failed to delete system_prompt.md: {}",
+    zh: "❌ 删除 system_prompt.md 失败: {}",
+};
+pub const CHAT_PROMPT_WRITE_FAILED: Msg = Msg {
+    en: "❌ failed to save system_prompt.md: {}",
+    zh: "❌ 保存 system_prompt.md 失败: {}",
+};
+pub const CHAT_STYLE_READ_FAILED: Msg = Msg {
+    en: "❌ failed to read style.md: {}",
+    zh: "❌ 读取 style.md 失败: {}",
+};
+pub const CHAT_STYLE_DELETE_FAILED: Msg = Msg {
+    en: "❌ failed to delete style.md: {}",
+    zh: "❌ 删除 style.md 失败: {}",
+};
+pub const CHAT_STYLE_WRITE_FAILED: Msg = Msg {
+    en: "❌ failed to save style.md: {}",
+    zh: "❌ 保存 style.md 失败: {}",
+};
+pub const CHAT_NO_PROVIDER_CLI: Msg = Msg {
+    en: "⚠️  no LLM provider configured yet.",
+    zh: "⚠️  尚未配置 LLM 模型提供方。",
+};
+pub const CHAT_EDIT_CONFIG_FILE: Msg = Msg {
+    en: "📁 edit the config file: {}",
+    zh: "📁 请编辑配置文件: {}",
+};
+pub const CHAT_CONFIG_EXAMPLE: Msg = Msg {
+    en: "📝 example config:",
+    zh: "📝 配置示例:",
+};
+pub const CHAT_DEFAULT_SYSTEM_PROMPT: Msg = Msg {
+    en: "You are a helpful assistant.",
+    zh: "你是一个有用的助手。",
+};
+pub const CHAT_EXAMPLE_CREATED: Msg = Msg {
+    en: "✅ created an example config file: {}",
+    zh: "✅ 已自动创建示例配置文件: {}",
+};
+pub const CHAT_EXAMPLE_EDIT_HINT: Msg = Msg {
+    en: "📌 set api_key and the other settings in it, then run the chat command again",
+    zh: "📌 请修改其中的 api_key 和其他配置后重新运行 chat 命令",
+};
+pub const CHAT_MESSAGE_EMPTY: Msg = Msg {
+    en: "⚠️ the message is empty",
+    zh: "⚠️ 消息内容为空",
+};
+pub const CHAT_CLI_THINKING: Msg = Msg {
+    en: "🤖 [{}] thinking...",
+    zh: "🤖 [{}] 思考中...",
+};
+pub const CHAT_SKILL_REFERENCE: Msg = Msg {
+    en: "\n\n--- reference file: {} ---\n{}",
+    zh: "\n\n--- 参考文件: {} ---\n{}",
+};
+pub const CHAT_SKILL_TRUNCATED: Msg = Msg {
+    en: "\n...(content truncated)",
+    zh: "\n...(内容已截断)",
+};
+pub const CHAT_NO_SKILLS: Msg = Msg {
+    en: "(no skills installed)",
+    zh: "（暂无已安装的技能）",
+};
+pub const CHAT_TOOL_SKILL_DESC: Msg = Msg {
+    en: "Load the full content of a skill into the context to learn more about it and complete the task better.",
+    zh: "加载指定 skill 的完整内容到上下文以了解更多信息，帮助你进行更好地完成任务。",
+};
+pub const CHAT_TOOL_SKILL_NAME: Msg = Msg {
+    en: "the name of the skill to load",
+    zh: "要加载的技能名称",
+};
+pub const CHAT_TOOL_SKILL_ARGS: Msg = Msg {
+    en: "arguments for the skill (optional)",
+    zh: "传递给技能的参数（可选）",
+};
+pub const CHAT_TOOL_NO_NAME: Msg = Msg {
+    en: "the arguments have no name field",
+    zh: "参数缺少 name 字段",
+};
+pub const CHAT_SKILL_NOT_FOUND: Msg = Msg {
+    en: "skill '{}' not found. Available skills: {}",
+    zh: "未找到技能 '{}'。可用技能: {}",
+};
+pub const CHAT_STREAM_ERROR: Msg = Msg {
+    en: "stream response error: {}",
+    zh: "流式响应错误: {}",
+};
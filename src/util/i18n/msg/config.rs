@@ -0,0 +1,28 @@
+//! 配置文件读写的文案
+
+use super::Msg;
+
+pub const CONFIG_CREATED: Msg = Msg {
+    en: "[INFO] Created default config file: {}",
+    zh: "[INFO] 创建默认配置文件: {}",
+};
+pub const CONFIG_READ_FAILED: Msg = Msg {
+    en: "[ERROR] Failed to read config file: {}, path: {}",
+    zh: "[ERROR] 读取配置文件失败: {}, 路径: {}",
+};
+pub const CONFIG_PARSE_FAILED: Msg = Msg {
+    en: "[ERROR] Failed to parse config file: {}, path: {}",
+    zh: "[ERROR] 解析配置文件失败: {}, 路径: {}",
+};
+pub const CONFIG_DIR_FAILED: Msg = Msg {
+    en: "failed to create config directory: {}",
+    zh: "创建配置目录失败: {}",
+};
+pub const CONFIG_SERIALIZE_FAILED: Msg = Msg {
+    en: "failed to serialize config: {}",
+    zh: "序列化配置失败: {}",
+};
+pub const CONFIG_SAVE_FAILED: Msg = Msg {
+    en: "failed to save config file: {}, path: {}",
+    zh: "保存配置文件失败: {}, 路径: {}",
+};
@@ -0,0 +1,48 @@
+//! 内置 TUI 编辑器的文案
+
+use super::Msg;
+
+pub const EDITOR_PREVIEW_SCROLL: Msg = Msg {
+    en: " 📖 line {} preview  [{}/{} lines]  Alt+↓/↑ scroll ",
+    zh: " 📖 第 {} 行预览  [{}/{}行]  Alt+↓/↑滚动 ",
+};
+pub const EDITOR_PREVIEW: Msg = Msg {
+    en: " 📖 line {} preview ",
+    zh: " 📖 第 {} 行预览 ",
+};
+pub const EDITOR_UNSAVED: Msg = Msg {
+    en: " ⚠️ unsaved changes! :q! quits anyway, :wq saves and quits ",
+    zh: " ⚠️ 有未保存的改动！使用 :q! 强制退出，或 :wq 保存退出 ",
+};
+pub const EDITOR_KEY_SUBMIT: Msg = Msg {
+    en: " submit  ",
+    zh: " 提交  ",
+};
+pub const EDITOR_KEY_CANCEL: Msg = Msg {
+    en: " cancel  ",
+    zh: " 取消  ",
+};
+pub const EDITOR_KEY_SEARCH: Msg = Msg {
+    en: " search  ",
+    zh: " 搜索  ",
+};
+pub const EDITOR_KEY_NEXT_PREV: Msg = Msg {
+    en: " next/prev  ",
+    zh: " 下/上  ",
+};
+pub const EDITOR_KEY_EDIT: Msg = Msg {
+    en: " edit  ",
+    zh: " 编辑  ",
+};
+pub const EDITOR_KEY_COPY: Msg = Msg {
+    en: " copy  ",
+    zh: " 复制  ",
+};
+pub const EDITOR_KEY_DELETE: Msg = Msg {
+    en: " delete  ",
+    zh: " 删除  ",
+};
+pub const EDITOR_LINES: Msg = Msg {
+    en: " {} lines ",
+    zh: " {} 行 ",
+};
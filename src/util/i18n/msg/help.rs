@@ -0,0 +1,78 @@
+//! help 命令的文案
+
+use super::Msg;
+
+pub const HELP_PLUGIN_NOT_FOUND: Msg = Msg {
+    en: "no plugin or agent command named {}",
+    zh: "没有找到插件或 agent 命令: {}",
+};
+pub const HELP_TUI_FAILED: Msg = Msg {
+    en: "failed to start the TUI: {}",
+    zh: "TUI 启动失败: {}",
+};
+pub const HELP_EMPTY_TAB: Msg = Msg {
+    en: "  (nothing here yet)",
+    zh: "  (暂无内容)",
+};
+
+pub const HELP_TAB_QUICKSTART: Msg = Msg {
+    en: "Quick start",
+    zh: "快速上手",
+};
+pub const HELP_TAB_DATA: Msg = Msg {
+    en: "Data directory",
+    zh: "数据目录",
+};
+pub const HELP_TAB_ALIAS: Msg = Msg {
+    en: "Aliases & opening",
+    zh: "别名 & 打开",
+};
+pub const HELP_TAB_REPORT: Msg = Msg {
+    en: "Report",
+    zh: "日报",
+};
+pub const HELP_TAB_TODO: Msg = Msg {
+    en: "Todos",
+    zh: "待办",
+};
+pub const HELP_TAB_SCRIPT: Msg = Msg {
+    en: "Scripts & timer",
+    zh: "脚本 & 计时",
+};
+pub const HELP_TAB_SYSTEM: Msg = Msg {
+    en: "System & voice",
+    zh: "系统 & 语音",
+};
+pub const HELP_TAB_CHAT: Msg = Msg {
+    en: "AI chat",
+    zh: "AI 对话",
+};
+pub const HELP_TAB_INSTALL: Msg = Msg {
+    en: "Install & uninstall",
+    zh: "安装 & 卸载",
+};
+pub const HELP_TAB_TIPS: Msg = Msg {
+    en: "Tips",
+    zh: "使用技巧",
+};
+
+pub const HELP_HINT_SWITCH: Msg = Msg {
+    en: "switch",
+    zh: "切换",
+};
+pub const HELP_HINT_JUMP: Msg = Msg {
+    en: "jump",
+    zh: "跳转",
+};
+pub const HELP_HINT_SCROLL: Msg = Msg {
+    en: "scroll",
+    zh: "滚动",
+};
+pub const HELP_HINT_PAGE: Msg = Msg {
+    en: "page",
+    zh: "翻页",
+};
+pub const HELP_HINT_QUIT: Msg = Msg {
+    en: "quit",
+    zh: "退出",
+};
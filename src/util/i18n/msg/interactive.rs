@@ -0,0 +1,44 @@
+//! 交互模式的文案
+
+use super::Msg;
+
+pub const INTERACTIVE_EDITOR_INIT_FAILED: Msg = Msg {
+    en: "cannot initialize the line editor",
+    zh: "无法初始化编辑器",
+};
+pub const INTERACTIVE_READ_FAILED: Msg = Msg {
+    en: "failed to read input: {}",
+    zh: "读取输入失败: {}",
+};
+pub const INTERACTIVE_SHELL_ENTER: Msg = Msg {
+    en: "entering shell mode ({}), type exit to return to copilot",
+    zh: "进入 shell 模式 ({}), 输入 exit 返回 copilot",
+};
+pub const INTERACTIVE_ZSHRC_FAILED: Msg = Msg {
+    en: "failed to create the temporary .zshrc: {}",
+    zh: "创建临时 .zshrc 失败: {}",
+};
+pub const INTERACTIVE_BASHRC_FAILED: Msg = Msg {
+    en: "failed to create the temporary bashrc: {}",
+    zh: "创建临时 bashrc 失败: {}",
+};
+pub const INTERACTIVE_SHELL_EXIT: Msg = Msg {
+    en: "shell exit code: {}",
+    zh: "shell 退出码: {}",
+};
+pub const INTERACTIVE_SHELL_FAILED: Msg = Msg {
+    en: "failed to start the shell: {}",
+    zh: "启动 shell 失败: {}",
+};
+pub const INTERACTIVE_BACK: Msg = Msg {
+    en: "back in copilot interactive mode 🚀",
+    zh: "已返回 copilot 交互模式 🚀",
+};
+pub const INTERACTIVE_COMMAND_EXIT: Msg = Msg {
+    en: "command exit code: {}",
+    zh: "命令退出码: {}",
+};
+pub const INTERACTIVE_COMMAND_FAILED: Msg = Msg {
+    en: "failed to run the command: {}",
+    zh: "执行命令失败: {}",
+};
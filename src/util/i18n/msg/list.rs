@@ -0,0 +1,12 @@
+//! list 命令的文案
+
+use super::Msg;
+
+pub const LIST_EMPTY: Msg = Msg {
+    en: "nothing to list",
+    zh: "无可展示的内容",
+};
+pub const LIST_NO_SECTION: Msg = Msg {
+    en: "no such section: {}",
+    zh: "该 section 不存在: {}",
+};
@@ -0,0 +1,28 @@
+//! 日志文件的文案
+
+use super::Msg;
+
+pub const LOG_MKDIR_FAILED: Msg = Msg {
+    en: "cannot create the log directory: {}",
+    zh: "无法创建日志目录: {}",
+};
+pub const LOG_INFO_WRITE_FAILED: Msg = Msg {
+    en: "failed to write the info log: {}",
+    zh: "写入信息日志失败: {}",
+};
+pub const LOG_INFO_OPEN_FAILED: Msg = Msg {
+    en: "cannot open the info log file: {}",
+    zh: "无法打开信息日志文件: {}",
+};
+pub const LOG_ERROR_ENTRY: Msg = Msg {
+    en: "\n========================================\n[{}] {}\ndetails:\n{}\n",
+    zh: "\n========================================\n[{}] {}\n错误详情:\n{}\n",
+};
+pub const LOG_ERROR_WRITE_FAILED: Msg = Msg {
+    en: "failed to write the error log: {}",
+    zh: "写入错误日志失败: {}",
+};
+pub const LOG_ERROR_OPEN_FAILED: Msg = Msg {
+    en: "cannot open the error log file: {}",
+    zh: "无法打开错误日志文件: {}",
+};
@@ -0,0 +1,186 @@
+//! j gen man 命令的文案
+
+use super::Msg;
+
+pub const MAN_MKDIR_FAILED: Msg = Msg {
+    en: "cannot create directory {}: {}",
+    zh: "无法创建目录 {}: {}",
+};
+pub const MAN_WRITE_FAILED: Msg = Msg {
+    en: "cannot write {}: {}",
+    zh: "无法写入 {}: {}",
+};
+pub const MAN_GENERATED: Msg = Msg {
+    en: "generated {} man pages: {}",
+    zh: "已生成 {} 个 man page: {}",
+};
+pub const MAN_MANPATH_HINT: Msg = Msg {
+    en: "add it to MANPATH to use man j: export MANPATH=\"{}:$MANPATH\"",
+    zh: "加入 MANPATH 后即可使用 man j: export MANPATH=\"{}:$MANPATH\"",
+};
+pub const MAN_ABOUT: Msg = Msg {
+    en: "a quick command-line launcher",
+    zh: "快捷命令行工具",
+};
+pub const MAN_DESCRIPTION: Msg = Msg {
+    en: "Without arguments j enters interactive mode; when the first argument is not a built-in command it is opened as an alias (a path, URL or application) and the remaining arguments are passed on unchanged.",
+    zh: "不带参数时进入交互模式；第一个参数不是内置命令时按别名打开对应的路径、URL 或应用，其余参数原样传给它。",
+};
+pub const MAN_ALIASES: Msg = Msg {
+    en: "Aliases: {}",
+    zh: "别名: {}",
+};
+pub const MAN_ENV_DATA_PATH: Msg = Msg {
+    en: "data root (~/.jdata by default) holding the config, data and plugins",
+    zh: "数据根目录（默认 ~/.jdata），配置、数据与插件都在其中",
+};
+pub const MAN_ENV_PROFILE: Msg = Msg {
+    en: "profile for this run, same as --profile",
+    zh: "本次使用的 profile，与 --profile 相同",
+};
+pub const MAN_ENV_LANG: Msg = Msg {
+    en: "interface language (zh / en)",
+    zh: "界面语言（zh / en）",
+};
+pub const MAN_FILE_CONFIG: Msg = Msg {
+    en: "aliases, categories and settings",
+    zh: "别名、分类与设置",
+};
+pub const MAN_FILE_BIN: Msg = Msg {
+    en: "plugins (agent, md_render, ...)",
+    zh: "插件（agent、md_render 等）",
+};
+
+/// clap 定义（src/cli.rs）里中文说明的英文版，键为子命令名或 "子命令名.参数 id"；
+/// 只在英文下生成 man page 时使用，新增子命令或参数时一并补上
+pub const MAN_HELP_EN: &[(&str, &str)] = &[
+    ("set", "Set an alias (path or URL)"),
+    ("set.alias", "alias"),
+    (
+        "set.path",
+        "path or URL (spaces allowed, several arguments are joined)",
+    ),
+    ("remove", "Remove an alias"),
+    ("remove.alias", "alias to remove"),
+    ("rename", "Rename an alias"),
+    ("rename.alias", "current alias"),
+    ("rename.new_alias", "new alias"),
+    ("modify", "Change the path an alias points to"),
+    ("modify.alias", "alias"),
+    ("modify.path", "new path or URL"),
+    (
+        "note",
+        "Mark an alias with a category (browser/editor/vpn/outer_url/script)",
+    ),
+    ("note.alias", "alias"),
+    (
+        "note.category",
+        "category: browser, editor, vpn, outer_url, script",
+    ),
+    ("denote", "Remove a category mark from an alias"),
+    ("denote.alias", "alias"),
+    (
+        "denote.category",
+        "category: browser, editor, vpn, outer_url, script",
+    ),
+    ("list", "List aliases"),
+    ("list.part", "section (optional, e.g. path/inner_url/all)"),
+    ("contain", "Find an alias in categories"),
+    ("contain.alias", "alias to find"),
+    (
+        "contain.containers",
+        "optional categories (comma separated, e.g. path,browser,vpn)",
+    ),
+    ("report", "Write to the daily report"),
+    (
+        "report.content",
+        "report content (several arguments are joined)",
+    ),
+    ("reportctl", "Report metadata actions (new/sync/push/pull)"),
+    ("reportctl.action", "action: new / sync / push / pull"),
+    (
+        "reportctl.arg",
+        "optional argument (a date for new/sync, the commit message for push)",
+    ),
+    ("check", "Show the last N lines of the report"),
+    ("check.line_count", "number of lines (default 5)"),
+    ("search", "Search the report for a keyword"),
+    ("search.line_count", "number of lines or \"all\""),
+    ("search.target", "keyword"),
+    ("search.fuzzy", "optional: -f or -fuzzy for fuzzy matching"),
+    ("todo", "Todos (opens the TUI without arguments)"),
+    (
+        "todo.content",
+        "subcommand: list (print todos) / add <content> (quick add), the TUI without arguments",
+    ),
+    (
+        "chat",
+        "AI chat (opens the TUI without arguments, asks directly with arguments)",
+    ),
+    ("chat.content", "message (several arguments are joined)"),
+    ("concat", "Create a script"),
+    ("concat.name", "script name"),
+    (
+        "concat.content",
+        "script content (optional, opens the TUI editor when omitted)",
+    ),
+    ("time", "Countdown timer"),
+    ("time.function", "function (currently: countdown)"),
+    ("time.arg", "argument (a duration such as 30s, 5m, 1h)"),
+    ("log", "Log mode settings"),
+    ("log.key", "setting (e.g. mode)"),
+    ("log.value", "value (e.g. verbose/concise)"),
+    ("change", "Change a config field directly"),
+    ("change.part", "section"),
+    ("change.field", "field"),
+    ("change.value", "new value"),
+    ("clear", "Clear the screen"),
+    ("version", "Version information"),
+    (
+        "help",
+        "Help (renders a plugin's help page when given its name)",
+    ),
+    (
+        "help.topic",
+        "plugin name (e.g. calc) or agent command name (e.g. history)",
+    ),
+    ("exit", "Quit (interactive mode)"),
+    (
+        "voice",
+        "Speech to text (record → offline Whisper transcription)",
+    ),
+    (
+        "voice.action",
+        "action: record and transcribe by default, download fetches a model",
+    ),
+    ("voice.copy", "copy the transcript to the clipboard"),
+    (
+        "voice.model",
+        "model size: tiny, base, small, medium, large",
+    ),
+    (
+        "profile",
+        "Manage profiles (separate config, keys and history, e.g. work / personal)",
+    ),
+    ("profile.action", "action: list (default) / create / switch"),
+    (
+        "profile.name",
+        "profile name (required for create / switch)",
+    ),
+    ("completion", "Generate a shell completion script"),
+    ("completion.shell", "shell: zsh, bash, fish"),
+    (
+        "gen",
+        "Generate documentation: man (man pages for the core and installed plugins)",
+    ),
+    ("gen.target", "what to generate (currently: man)"),
+    (
+        "gen.out",
+        "output directory (~/.jdata/man/man1 by default, point it at $PREFIX/share/man/man1 when packaging)",
+    ),
+    (
+        "stats",
+        "Usage and runtime statistics (handled by agent stats): reset clears them, metrics [--json] shows the daemon's request statistics",
+    ),
+    ("stats.args", "arguments passed to agent stats"),
+];
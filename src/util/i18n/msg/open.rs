@@ -0,0 +1,96 @@
+//! open 命令的文案
+
+use super::Msg;
+
+pub const OPEN_ALIAS_REQUIRED: Msg = Msg {
+    en: "❌ specify an alias to open",
+    zh: "❌ 请指定要打开的别名",
+};
+pub const OPEN_ALIAS_UNKNOWN: Msg = Msg {
+    en: "❌ no path or URL for alias {{}}, check the config file",
+    zh: "❌ 无法找到别名对应的路径或网址 {{}}。请检查配置文件。",
+};
+pub const OPEN_SCRIPT_NEW_WINDOW: Msg = Msg {
+    en: "⚙️ running the script in a new window: {}",
+    zh: "⚙️ 即将在新窗口执行脚本，路径: {}",
+};
+pub const OPEN_SCRIPT_RUNNING: Msg = Msg {
+    en: "⚙️ running the script: {}",
+    zh: "⚙️ 即将执行脚本，路径: {}",
+};
+pub const OPEN_SCRIPT_DONE: Msg = Msg {
+    en: "✅ script finished",
+    zh: "✅ 脚本执行完成",
+};
+pub const OPEN_SCRIPT_FAILED: Msg = Msg {
+    en: "❌ script failed, exit status: {}",
+    zh: "❌ 脚本执行失败，退出码: {}",
+};
+pub const OPEN_SCRIPT_ERROR: Msg = Msg {
+    en: "💥 failed to run the script: {}",
+    zh: "💥 执行脚本失败: {}",
+};
+pub const OPEN_SCRIPT_STARTED: Msg = Msg {
+    en: "✅ started the script in a new terminal window",
+    zh: "✅ 已在新终端窗口中启动脚本",
+};
+pub const OPEN_WINDOW_FAILED: Msg = Msg {
+    en: "❌ failed to open a new terminal window, exit status: {}",
+    zh: "❌ 启动新终端窗口失败，退出码: {}",
+};
+pub const OPEN_OSASCRIPT_ERROR: Msg = Msg {
+    en: "💥 failed to run osascript: {}",
+    zh: "💥 调用 osascript 失败: {}",
+};
+pub const OPEN_WINDOW_ERROR: Msg = Msg {
+    en: "💥 failed to open a new window: {}",
+    zh: "💥 启动新窗口失败: {}",
+};
+pub const OPEN_NO_TERMINAL: Msg = Msg {
+    en: "⚠️ no terminal emulator found, running in the current terminal",
+    zh: "⚠️ 未找到可用的终端模拟器，降级到当前终端执行",
+};
+pub const OPEN_RUN_FAILED: Msg = Msg {
+    en: "❌ {{}} failed, exit status: {}",
+    zh: "❌ 执行 {{}} 失败，退出码: {}",
+};
+pub const OPEN_RUN_ERROR: Msg = Msg {
+    en: "💥 failed to run {{}}: {}",
+    zh: "💥 执行 {{}} 失败: {}",
+};
+pub const OPEN_LAUNCH_ERROR: Msg = Msg {
+    en: "💥 failed to launch {{}}: {}",
+    zh: "💥 启动 {{}} 失败: {}",
+};
+pub const OPEN_LAUNCHED: Msg = Msg {
+    en: "✅ launched {{}} : {{}}",
+    zh: "✅ 启动 {{}} : {{}}",
+};
+pub const OPEN_ALIAS_NO_TARGET: Msg = Msg {
+    en: "❌ no path or URL for alias {}, check the config file",
+    zh: "❌ 未找到别名对应的路径或网址: {}。请检查配置文件。",
+};
+pub const OPEN_UNSUPPORTED_OS: Msg = Msg {
+    en: "💥 not supported on this operating system: {}",
+    zh: "💥 当前操作系统不支持此功能: {}",
+};
+pub const OPEN_LAUNCHED_WITH: Msg = Msg {
+    en: "✅ launched {{}} {} : {{}}",
+    zh: "✅ 启动 {{}} {} : {{}}",
+};
+pub const OPEN_START_ERROR: Msg = Msg {
+    en: "💥 failed to launch {}: {}",
+    zh: "💥 启动 {} 失败: {}",
+};
+pub const OPEN_ALIAS_NO_PATH: Msg = Msg {
+    en: "❌ no path for alias {}",
+    zh: "❌ 未找到别名对应的路径: {}。",
+};
+pub const OPEN_FAILED: Msg = Msg {
+    en: "💥 failed to open {}: {}",
+    zh: "💥 打开 {} 失败: {}",
+};
+pub const OPEN_DEFAULT_ENGINE: Msg = Msg {
+    en: "no search engine configured, using the default: {}",
+    zh: "未指定搜索引擎，使用默认搜索引擎：{}",
+};
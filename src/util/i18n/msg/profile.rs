@@ -0,0 +1,48 @@
+//! profile 相关的文案
+
+use super::Msg;
+
+pub const PROFILE_INVALID_SOURCE: Msg = Msg {
+    en: "invalid profile name {} (from {}): only letters, digits, - and _ are allowed",
+    zh: "profile 名称 {} 不合法（来自 {}），只能包含字母、数字、- 与 _",
+};
+pub const PROFILE_MISSING_SOURCE: Msg = Msg {
+    en: "profile {} does not exist (from {}), run j profile create {} first",
+    zh: "profile {} 不存在（来自 {}），先执行 j profile create {}",
+};
+pub const PROFILE_INVALID: Msg = Msg {
+    en: "profile names may only contain letters, digits, - and _: {}",
+    zh: "profile 名称只能包含字母、数字、- 与 _: {}",
+};
+pub const PROFILE_EXISTS: Msg = Msg {
+    en: "profile {} already exists: {}",
+    zh: "profile {} 已存在: {}",
+};
+pub const PROFILE_CREATED: Msg = Msg {
+    en: "✅ Created profile {}: {}",
+    zh: "✅ 已创建 profile {}: {}",
+};
+pub const PROFILE_CREATED_HINT: Msg = Msg {
+    en: "💡 Run j profile switch {} to switch to it, or j --profile {} <command> to use it once",
+    zh: "💡 j profile switch {} 切换到该 profile，或用 j --profile {} <命令> 临时使用",
+};
+pub const PROFILE_CREATE_FAILED: Msg = Msg {
+    en: "failed to create profile: {}",
+    zh: "创建 profile 失败: {}",
+};
+pub const PROFILE_MISSING: Msg = Msg {
+    en: "profile {} does not exist, run j profile create {} first",
+    zh: "profile {} 不存在，先执行 j profile create {}",
+};
+pub const PROFILE_SWITCH_FAILED: Msg = Msg {
+    en: "failed to switch profile: {}",
+    zh: "切换 profile 失败: {}",
+};
+pub const PROFILE_SWITCHED: Msg = Msg {
+    en: "✅ Switched to profile {}",
+    zh: "✅ 已切换到 profile {}",
+};
+pub const PROFILE_SWITCH_PENDING: Msg = Msg {
+    en: "🚧 This process uses {} through --profile / {}; the switch applies once restarted without it",
+    zh: "🚧 当前进程使用 {}（来自 --profile / {}），不带该设置重新启动后生效",
+};
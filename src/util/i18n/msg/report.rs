@@ -0,0 +1,280 @@
+//! report 命令的文案
+
+use super::Msg;
+
+pub const REPORT_UNKNOWN_ACTION: Msg = Msg {
+    en: "❌ unknown reportctl action: {}, expected one of: {}, {}, {}, {}, {}, {}",
+    zh: "❌ 未知的元数据操作: {}，可选: {}, {}, {}, {}, {}, {}",
+};
+pub const REPORT_EMPTY: Msg = Msg {
+    en: "⚠️ nothing to write, the content is empty",
+    zh: "⚠️ 内容为空，无法写入",
+};
+pub const REPORT_CREATE_FAILED: Msg = Msg {
+    en: "❌ failed to create the report file: {}",
+    zh: "❌ 创建日报文件失败: {}",
+};
+pub const REPORT_CREATED: Msg = Msg {
+    en: "📄 created the report file: {}",
+    zh: "📄 已自动创建日报文件: {}",
+};
+pub const REPORT_EDITOR_TITLE: Msg = Msg {
+    en: "📝 Edit report",
+    zh: "📝 编辑日报",
+};
+pub const REPORT_WRITTEN: Msg = Msg {
+    en: "✅ report written: {}",
+    zh: "✅ 日报已写入：{}",
+};
+pub const REPORT_EDIT_CANCELLED: Msg = Msg {
+    en: "edit cancelled",
+    zh: "已取消编辑",
+};
+pub const REPORT_EDITOR_FAILED: Msg = Msg {
+    en: "❌ failed to start the editor: {}",
+    zh: "❌ 编辑器启动失败: {}",
+};
+pub const REPORT_READ_FILE_FAILED: Msg = Msg {
+    en: "❌ failed to read the file: {}",
+    zh: "❌ 读取文件失败: {}",
+};
+pub const REPORT_WRITE_FILE_FAILED: Msg = Msg {
+    en: "❌ failed to write the file: {}",
+    zh: "❌ 写入文件失败: {}",
+};
+pub const REPORT_PATH: Msg = Msg {
+    en: "📂 report file: {}",
+    zh: "📂 日报文件路径：{}",
+};
+pub const REPORT_BAD_LAST_DAY: Msg = Msg {
+    en: "❌ cannot parse the last_day date: {}",
+    zh: "❌ 无法解析 last_day 日期: {}",
+};
+pub const REPORT_APPENDED: Msg = Msg {
+    en: "✅ written to {}",
+    zh: "✅ 成功将内容写入：{}",
+};
+pub const REPORT_WEEK_UPDATE_FAILED: Msg = Msg {
+    en: "❌ failed to update the week number, check the date: {}",
+    zh: "❌ 更新周数失败，请检查日期字符串是否有误: {}",
+};
+pub const REPORT_YAML_UPDATED: Msg = Msg {
+    en: "✅ updated the YAML config: week = {}, week ends on {}",
+    zh: "✅ 更新YAML配置文件成功：周数 = {}, 周结束日期 = {}",
+};
+pub const REPORT_JSON_UPDATED: Msg = Msg {
+    en: "✅ updated the JSON config: week = {}, week ends on {}",
+    zh: "✅ 更新JSON配置文件成功：周数 = {}, 周结束日期 = {}",
+};
+pub const REPORT_JSON_UPDATE_FAILED: Msg = Msg {
+    en: "❌ failed to update the JSON config: {}",
+    zh: "❌ 更新JSON配置文件时出错: {}",
+};
+pub const REPORT_SETTINGS_MISSING: Msg = Msg {
+    en: "❌ report settings file does not exist: {}",
+    zh: "❌ 日报配置文件不存在：{}",
+};
+pub const REPORT_SETTINGS_LOADED: Msg = Msg {
+    en: "✅ read from the report settings: last_day = {}, week_num = {}",
+    zh: "✅ 从日报配置文件中读取到：last_day = {}, week_num = {}",
+};
+pub const REPORT_SETTINGS_PARSE_FAILED: Msg = Msg {
+    en: "❌ failed to parse the report settings file",
+    zh: "❌ 解析日报配置文件时出错",
+};
+pub const REPORT_SETTINGS_READ_FAILED: Msg = Msg {
+    en: "❌ failed to read the report settings file: {}",
+    zh: "❌ 读取日报配置文件失败: {}",
+};
+pub const REPORT_OPEN_FILE_FAILED: Msg = Msg {
+    en: "❌ failed to open the file: {}",
+    zh: "❌ 打开文件失败: {}",
+};
+pub const REPORT_FILE_MISSING: Msg = Msg {
+    en: "❌ report file does not exist: {}",
+    zh: "❌ 日报文件不存在: {}",
+};
+pub const REPORT_FILE_READ_FAILED: Msg = Msg {
+    en: "❌ failed to read the report file: {}",
+    zh: "❌ 读取日报文件失败: {}",
+};
+pub const REPORT_FILE_EDITOR_TITLE: Msg = Msg {
+    en: "📝 Edit report file",
+    zh: "📝 编辑日报文件",
+};
+pub const REPORT_FILE_WRITE_FAILED: Msg = Msg {
+    en: "❌ failed to write the report file: {}",
+    zh: "❌ 写入日报文件失败: {}",
+};
+pub const REPORT_FILE_SAVED: Msg = Msg {
+    en: "✅ report file saved: {}",
+    zh: "✅ 日报文件已保存：{}",
+};
+pub const REPORT_FILE_UNCHANGED: Msg = Msg {
+    en: "edit cancelled, the file is unchanged",
+    zh: "已取消编辑，文件未修改",
+};
+pub const REPORT_URL_UPDATED: Msg = Msg {
+    en: "✅ git repository URL updated: {} → {}",
+    zh: "✅ git 仓库地址已更新: {} → {}",
+};
+pub const REPORT_URL_SET: Msg = Msg {
+    en: "✅ git repository URL set: {}",
+    zh: "✅ git 仓库地址已设置: {}",
+};
+pub const REPORT_URL_CURRENT: Msg = Msg {
+    en: "📦 current git repository URL: {}",
+    zh: "📦 当前 git 仓库地址: {}",
+};
+pub const REPORT_URL_NONE: Msg = Msg {
+    en: "📦 no git repository URL configured",
+    zh: "📦 尚未配置 git 仓库地址",
+};
+pub const REPORT_DIR_UNKNOWN: Msg = Msg {
+    en: "❌ cannot determine the report directory",
+    zh: "❌ 无法确定日报目录",
+};
+pub const REPORT_GIT_FAILED: Msg = Msg {
+    en: "💥 failed to run git: {}",
+    zh: "💥 执行 git 命令失败: {}",
+};
+pub const REPORT_URL_REQUIRED: Msg = Msg {
+    en: "❌ no git repository URL configured, run j reportctl set-url <repo_url> first",
+    zh: "❌ 尚未配置 git 仓库地址，请先执行: j reportctl set-url <repo_url>",
+};
+pub const REPORT_GIT_INITIALIZING: Msg = Msg {
+    en: "📦 the report directory is not a git repository yet, initializing...",
+    zh: "📦 日报目录尚未初始化 git 仓库，正在初始化...",
+};
+pub const REPORT_GIT_INIT_FAILED: Msg = Msg {
+    en: "❌ git init failed",
+    zh: "❌ git init 失败",
+};
+pub const REPORT_GIT_REMOTE_FAILED: Msg = Msg {
+    en: "❌ git remote add failed",
+    zh: "❌ git remote add 失败",
+};
+pub const REPORT_GIT_INITIALIZED: Msg = Msg {
+    en: "✅ git repository initialized, remote: {}",
+    zh: "✅ git 仓库初始化完成，remote: {}",
+};
+pub const REPORT_REMOTE_SYNCED: Msg = Msg {
+    en: "🔄 synced remote origin: {} → {}",
+    zh: "🔄 已同步 remote origin: {} → {}",
+};
+pub const REPORT_PUSHING: Msg = Msg {
+    en: "📤 pushing the weekly report to the remote repository...",
+    zh: "📤 正在推送周报到远程仓库...",
+};
+pub const REPORT_GIT_ADD_FAILED: Msg = Msg {
+    en: "❌ git add failed",
+    zh: "❌ git add 失败",
+};
+pub const REPORT_COMMIT_NOTHING: Msg = Msg {
+    en: "ℹ️ git commit exited non-zero (probably nothing new to commit)",
+    zh: "ℹ️ git commit 返回非零退出码（可能没有新变更）",
+};
+pub const REPORT_PUSHED: Msg = Msg {
+    en: "✅ weekly report pushed to the remote repository",
+    zh: "✅ 周报已成功推送到远程仓库",
+};
+pub const REPORT_PUSH_FAILED: Msg = Msg {
+    en: "❌ git push failed, check the network and repository permissions",
+    zh: "❌ git push 失败，请检查网络连接和仓库权限",
+};
+pub const REPORT_CLONING: Msg = Msg {
+    en: "📥 the report directory is not initialized, cloning from the remote repository...",
+    zh: "📥 日报目录尚未初始化，正在从远程仓库克隆...",
+};
+pub const REPORT_BACKUP_FAILED: Msg = Msg {
+    en: "⚠️ failed to back up the existing report file: {}",
+    zh: "⚠️ 备份现有日报文件失败: {}",
+};
+pub const REPORT_BACKED_UP: Msg = Msg {
+    en: "📋 backed up the existing report to {}",
+    zh: "📋 已备份现有日报到: {}",
+};
+pub const REPORT_CLONE_MOVE_FAILED: Msg = Msg {
+    en: "❌ failed to move the cloned repository: {}, temporary directory: {}",
+    zh: "❌ 移动克隆仓库失败: {}，临时目录: {}",
+};
+pub const REPORT_CLONED: Msg = Msg {
+    en: "✅ cloned the weekly report from the remote repository",
+    zh: "✅ 成功从远程仓库克隆周报",
+};
+pub const REPORT_CLONE_FAILED: Msg = Msg {
+    en: "❌ git clone failed, check the repository URL and the network",
+    zh: "❌ git clone 失败，请检查仓库地址和网络连接",
+};
+pub const REPORT_CLONE_ERROR: Msg = Msg {
+    en: "💥 failed to run git clone: {}",
+    zh: "💥 执行 git clone 失败: {}",
+};
+pub const REPORT_FETCHING: Msg = Msg {
+    en: "📥 the local repository has no commits yet, fetching from the remote repository...",
+    zh: "📥 本地仓库尚无提交，正在从远程仓库拉取...",
+};
+pub const REPORT_LOCAL_BACKED_UP: Msg = Msg {
+    en: "📋 backed up the local report to {}",
+    zh: "📋 已备份本地日报到: {}",
+};
+pub const REPORT_FETCH_FAILED: Msg = Msg {
+    en: "❌ git fetch failed, check the network and the repository URL",
+    zh: "❌ git fetch 失败，请检查网络连接和仓库地址",
+};
+pub const REPORT_FETCHED: Msg = Msg {
+    en: "✅ fetched the weekly report from the remote repository",
+    zh: "✅ 成功从远程仓库拉取周报",
+};
+pub const REPORT_RESET_FAILED: Msg = Msg {
+    en: "❌ git reset failed",
+    zh: "❌ git reset 失败",
+};
+pub const REPORT_PULLING: Msg = Msg {
+    en: "📥 pulling the latest weekly report from the remote repository...",
+    zh: "📥 正在从远程仓库拉取最新周报...",
+};
+pub const REPORT_PULLED: Msg = Msg {
+    en: "✅ weekly report is up to date",
+    zh: "✅ 周报已更新到最新版本",
+};
+pub const REPORT_PULL_FAILED: Msg = Msg {
+    en: "❌ git pull failed, check the network or resolve conflicts by hand",
+    zh: "❌ git pull 失败，请检查网络连接或手动解决冲突",
+};
+pub const REPORT_STASH_CONFLICT: Msg = Msg {
+    en: "⚠️ stash pop has conflicts, merge your local changes by hand (they are kept in git stash)",
+    zh: "⚠️ stash pop 存在冲突，请手动合并本地修改（已保存在 git stash 中）",
+};
+pub const REPORT_BAD_CHECK_LINES: Msg = Msg {
+    en: "❌ invalid line count: {}, expected a positive integer or open",
+    zh: "❌ 无效的行数参数: {}，请输入正整数或 open",
+};
+pub const REPORT_READING: Msg = Msg {
+    en: "📂 reading the weekly report: {}",
+    zh: "📂 正在读取周报文件路径: {}",
+};
+pub const REPORT_NOT_A_FILE: Msg = Msg {
+    en: "❌ not an existing file: {}",
+    zh: "❌ 文件不存在或不是有效文件: {}",
+};
+pub const REPORT_LAST_LINES: Msg = Msg {
+    en: "📄 last {} lines:",
+    zh: "📄 最近的 {} 行内容如下：",
+};
+pub const REPORT_BAD_SEARCH_LINES: Msg = Msg {
+    en: "❌ invalid line count: {}, expected a positive integer or all",
+    zh: "❌ 无效的行数参数: {}，请输入正整数或 all",
+};
+pub const REPORT_FUZZY: Msg = Msg {
+    en: "fuzzy matching enabled...",
+    zh: "启用模糊匹配...",
+};
+pub const REPORT_SEARCHING: Msg = Msg {
+    en: "🔍 searching for: {}",
+    zh: "🔍 搜索目标关键字: {}",
+};
+pub const REPORT_READ_ERROR: Msg = Msg {
+    en: "❌ error while reading the file: {}",
+    zh: "❌ 读取文件时发生错误: {}",
+};
@@ -0,0 +1,84 @@
+//! script 命令的文案
+
+use super::Msg;
+
+pub const SCRIPT_WAIT_KEY_WINDOWS: Msg = Msg {
+    en: "echo.\necho Script finished, press any key to exit...\npause >nul",
+    zh: "echo.\necho 脚本执行完毕，按任意键退出...\npause >nul",
+};
+pub const SCRIPT_WAIT_KEY: Msg = Msg {
+    en: "echo ''\necho '\\033[32m✅ Script finished, press Enter to exit...\\033[0m'\nread _",
+    zh: "echo ''\necho '\\033[32m✅ 脚本执行完毕，按回车键退出...\\033[0m'\nread _",
+};
+pub const SCRIPT_PATH_MISSING: Msg = Msg {
+    en: "❌ alias {{}} exists but has no script path",
+    zh: "❌ 别名 {{}} 已存在，但未找到对应的脚本路径",
+};
+pub const SCRIPT_READ_FAILED: Msg = Msg {
+    en: "❌ failed to read the existing script: {} (path: {})",
+    zh: "❌ 读取已有脚本文件失败: {} (路径: {})",
+};
+pub const SCRIPT_EDIT_TITLE: Msg = Msg {
+    en: "📝 Edit script: {}",
+    zh: "📝 编辑脚本: {}",
+};
+pub const SCRIPT_EMPTY_UNSAVED: Msg = Msg {
+    en: "⚠️ the script is empty, changes not saved",
+    zh: "⚠️ 脚本内容为空，未保存修改",
+};
+pub const SCRIPT_UPDATED: Msg = Msg {
+    en: "✅ script {{}} updated: {}",
+    zh: "✅ 脚本 {{}} 已更新，路径: {}",
+};
+pub const SCRIPT_WRITE_FAILED: Msg = Msg {
+    en: "💥 failed to write the script file: {}",
+    zh: "💥 写入脚本文件失败: {}",
+};
+pub const SCRIPT_EDIT_CANCELLED: Msg = Msg {
+    en: "script edit cancelled",
+    zh: "已取消编辑脚本",
+};
+pub const SCRIPT_EDITOR_FAILED: Msg = Msg {
+    en: "❌ failed to start the editor: {}",
+    zh: "❌ 编辑器启动失败: {}",
+};
+pub const SCRIPT_PLACEHOLDER: Msg = Msg {
+    en: "# write the script here...",
+    zh: "# 在此编写脚本内容...",
+};
+pub const SCRIPT_WAIT_KEY_HEADER: Msg = Msg {
+    en: "# --- wait-for-key template (can be removed) ---",
+    zh: "# --- 以下为等待按键模板（可删除） ---",
+};
+pub const SCRIPT_NEW_TITLE: Msg = Msg {
+    en: "📝 New script: {}",
+    zh: "📝 编写脚本: {}",
+};
+pub const SCRIPT_CREATE_CANCELLED: Msg = Msg {
+    en: "script creation cancelled",
+    zh: "已取消创建脚本",
+};
+pub const SCRIPT_EMPTY: Msg = Msg {
+    en: "⚠️ the script is empty, nothing to create",
+    zh: "⚠️ 脚本内容为空，无法创建",
+};
+pub const SCRIPT_MKDIR_FAILED: Msg = Msg {
+    en: "❌ failed to create the directory: {}",
+    zh: "❌ 创建目录失败: {}",
+};
+pub const SCRIPT_FILE_CREATED: Msg = Msg {
+    en: "🎉 file created: {}",
+    zh: "🎉 文件创建成功: {}",
+};
+pub const SCRIPT_CHMOD_FAILED: Msg = Msg {
+    en: "❌ failed to make the script executable: {}",
+    zh: "❌ 设置执行权限失败: {}",
+};
+pub const SCRIPT_CHMOD_DONE: Msg = Msg {
+    en: "🔧 made script {{}} executable",
+    zh: "🔧 已为脚本 {{}} 设置执行权限",
+};
+pub const SCRIPT_CREATED: Msg = Msg {
+    en: "✅ created script {{}}: {}",
+    zh: "✅ 成功创建脚本 {{}}，路径: {}",
+};
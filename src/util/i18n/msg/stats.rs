@@ -0,0 +1,12 @@
+//! stats 命令的文案
+
+use super::Msg;
+
+pub const STATS_AGENT_MISSING: Msg = Msg {
+    en: "❌ agent plugin not found: {}",
+    zh: "❌ 没有找到 agent 插件: {}",
+};
+pub const STATS_AGENT_FAILED: Msg = Msg {
+    en: "❌ Failed to run the agent plugin {}: {}",
+    zh: "❌ 无法运行 agent 插件 {}: {}",
+};
@@ -0,0 +1,32 @@
+//! 未知命令的纠错提示的文案
+
+use super::Msg;
+
+pub const SUGGEST_UNKNOWN: Msg = Msg {
+    en: "❌ Unknown command or alias: {}",
+    zh: "❌ 未知的命令或别名: {}",
+};
+pub const SUGGEST_CLOSEST: Msg = Msg {
+    en: "💡 The closest matches are:",
+    zh: "💡 最接近的是:",
+};
+pub const SUGGEST_CONFIRM: Msg = Msg {
+    en: "Run j {} instead? [y/N] ",
+    zh: "改为执行 j {}？[y/N] ",
+};
+pub const SUGGEST_KIND_COMMAND: Msg = Msg {
+    en: "command",
+    zh: "命令",
+};
+pub const SUGGEST_KIND_PLUGIN: Msg = Msg {
+    en: "plugin",
+    zh: "插件",
+};
+pub const SUGGEST_KIND_ALIAS: Msg = Msg {
+    en: "alias",
+    zh: "别名",
+};
+pub const SUGGEST_PLUGIN_FAILED: Msg = Msg {
+    en: "❌ Failed to run plugin {}: {}",
+    zh: "❌ 无法运行插件 {}: {}",
+};
@@ -0,0 +1,56 @@
+//! system 命令的文案
+
+use super::Msg;
+
+pub const SYSTEM_LOG_MODE: Msg = Msg {
+    en: "✅ log mode set to: {}",
+    zh: "✅ 日志模式已切换为: {}",
+};
+pub const SYSTEM_FOUND: Msg = Msg {
+    en: "found {} results 😊",
+    zh: "找到 {} 条结果 😊",
+};
+pub const SYSTEM_NO_SECTION: Msg = Msg {
+    en: "❌ no such section in the config file: {}",
+    zh: "❌ 在配置文件中未找到该 section：{}",
+};
+pub const SYSTEM_CHANGED: Msg = Msg {
+    en: "✅ set {}.{} to {}, it was {}",
+    zh: "✅ 已修改 {}.{} 的值为 {}，旧值为 {}",
+};
+pub const SYSTEM_ADDED: Msg = Msg {
+    en: "✅ added {}.{} = {}",
+    zh: "✅ 已新增 {}.{} = {}",
+};
+pub const SYSTEM_CHANGE_WARNING: Msg = Msg {
+    en: "🚧 this command can corrupt the config file and break Copilot, only use it if you know what you are doing",
+    zh: "🚧 此命令可能会导致配置文件属性错乱而使 Copilot 无法正常使用，请确保在您清楚在做什么的情况下使用",
+};
+pub const SYSTEM_BAD_SHELL: Msg = Msg {
+    en: "❌ unsupported shell: {}, expected zsh or bash",
+    zh: "❌ 不支持的 shell 类型: {}，可选: zsh, bash",
+};
+pub const SYSTEM_ZSH_HOWTO: Msg = Msg {
+    en: "# generate with: eval \"$(j completion zsh)\"\n",
+    zh: "# 生成方式: eval \"$(j completion zsh)\"\n",
+};
+pub const SYSTEM_ZSH_HOWTO_FILE: Msg = Msg {
+    en: "# or: j completion zsh > ~/.zsh/completions/_j && fpath=(~/.zsh/completions $fpath)\n\n",
+    zh: "# 或: j completion zsh > ~/.zsh/completions/_j && fpath=(~/.zsh/completions $fpath)\n\n",
+};
+pub const SYSTEM_BASH_HOWTO: Msg = Msg {
+    en: "# generate with: eval \"$(j completion bash)\"\n",
+    zh: "# 生成方式: eval \"$(j completion bash)\"\n",
+};
+pub const SYSTEM_BASH_HOWTO_FILE: Msg = Msg {
+    en: "# or: j completion bash > /etc/bash_completion.d/j\n\n",
+    zh: "# 或: j completion bash > /etc/bash_completion.d/j\n\n",
+};
+pub const SYSTEM_VERSION_PROPERTY: Msg = Msg {
+    en: "Property",
+    zh: "属性",
+};
+pub const SYSTEM_VERSION_VALUE: Msg = Msg {
+    en: "Value",
+    zh: "当前值",
+};
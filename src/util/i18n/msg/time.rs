@@ -0,0 +1,52 @@
+//! time 命令的文案
+
+use super::Msg;
+
+pub const TIME_UNKNOWN_FUNCTION: Msg = Msg {
+    en: "❌ unknown function: {}, only countdown is supported",
+    zh: "❌ 未知的功能: {}，目前仅支持 countdown",
+};
+pub const TIME_DURATION_FORMAT: Msg = Msg {
+    en: "  duration format: 30s (seconds), 5m (minutes), 1h (hours), minutes when no unit is given",
+    zh: "  duration 格式: 30s(秒), 5m(分钟), 1h(小时), 不带单位默认为分钟",
+};
+pub const TIME_BAD_DURATION: Msg = Msg {
+    en: "❌ invalid duration: {}",
+    zh: "❌ 无效的时长: {}",
+};
+pub const TIME_COUNTDOWN_START: Msg = Msg {
+    en: "⏳ countdown started: {}",
+    zh: "⏳ 倒计时开始：{}",
+};
+pub const TIME_HOURS_MINUTES: Msg = Msg {
+    en: "{}h {}m",
+    zh: "{}小时{}分钟",
+};
+pub const TIME_HOURS: Msg = Msg {
+    en: "{}h",
+    zh: "{}小时",
+};
+pub const TIME_MINUTES_SECONDS: Msg = Msg {
+    en: "{}m {}s",
+    zh: "{}分{}秒",
+};
+pub const TIME_MINUTES: Msg = Msg {
+    en: "{}m",
+    zh: "{}分钟",
+};
+pub const TIME_SECONDS: Msg = Msg {
+    en: "{}s",
+    zh: "{}秒",
+};
+pub const TIME_UP_CELEBRATE: Msg = Msg {
+    en: "  🎉 Time's up!",
+    zh: "  🎉 Time's up! 倒计时结束！",
+};
+pub const TIME_REMAINING: Msg = Msg {
+    en: "  {} left",
+    zh: "  剩余 {}",
+};
+pub const TIME_UP: Msg = Msg {
+    en: "  Time's up!",
+    zh: "  Time's up! 倒计时结束！",
+};
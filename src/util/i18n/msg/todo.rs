@@ -0,0 +1,366 @@
+//! 待办备忘录（j todo）的文案
+
+use super::Msg;
+
+// ========== 命令行 ==========
+
+pub const TODO_EMPTY: Msg = Msg {
+    en: "⚠️ The content is empty, nothing to add",
+    zh: "⚠️ 内容为空，无法添加待办",
+};
+pub const TODO_USAGE: Msg = Msg {
+    en: "j todo | j todo list | j todo add <content>",
+    zh: "j todo | j todo list | j todo add <内容>",
+};
+pub const TODO_ADDED: Msg = Msg {
+    en: "✅ Added todo: {}",
+    zh: "✅ 已添加待办: {}",
+};
+pub const TODO_UNDONE_COUNT: Msg = Msg {
+    en: "📋 Open todos: {}",
+    zh: "📋 当前未完成待办: {} 条",
+};
+pub const TODO_NONE: Msg = Msg {
+    en: "📋 No todos yet",
+    zh: "📋 暂无待办",
+};
+pub const TODO_LIST_HEADING: Msg = Msg {
+    en: "## Todos — {} in total | ✅ {} | ⬜ {}\n\n",
+    zh: "## 待办备忘录 — 共 {} 条 | ✅ {} | ⬜ {}\n\n",
+};
+pub const TODO_TUI_FAILED: Msg = Msg {
+    en: "❌ Failed to start the TUI: {}",
+    zh: "❌ TUI 启动失败: {}",
+};
+
+// ========== 数据文件 ==========
+
+pub const TODO_PARSE_FAILED: Msg = Msg {
+    en: "❌ Failed to parse todo.json: {}",
+    zh: "❌ 解析 todo.json 失败: {}",
+};
+pub const TODO_READ_FAILED: Msg = Msg {
+    en: "❌ Failed to read todo.json: {}",
+    zh: "❌ 读取 todo.json 失败: {}",
+};
+pub const TODO_SAVE_FAILED: Msg = Msg {
+    en: "❌ Failed to save todo.json: {}",
+    zh: "❌ 保存 todo.json 失败: {}",
+};
+pub const TODO_SERIALIZE_FAILED: Msg = Msg {
+    en: "❌ Failed to serialize the todo list: {}",
+    zh: "❌ 序列化 todo 列表失败: {}",
+};
+
+// ========== 过滤 ==========
+
+pub const TODO_FILTER_ALL: Msg = Msg {
+    en: "all",
+    zh: "全部",
+};
+pub const TODO_FILTER_UNDONE: Msg = Msg {
+    en: "open",
+    zh: "未完成",
+};
+pub const TODO_FILTER_DONE: Msg = Msg {
+    en: "done",
+    zh: "已完成",
+};
+
+// ========== TUI 状态消息 ==========
+
+pub const TODO_MARKED_UNDONE: Msg = Msg {
+    en: "⬜ Marked as not done",
+    zh: "⬜ 已标记为未完成",
+};
+pub const TODO_ADD_EMPTY: Msg = Msg {
+    en: "⚠️ The content is empty, cancelled",
+    zh: "⚠️ 内容为空，已取消",
+};
+pub const TODO_ADDED_SAVED: Msg = Msg {
+    en: "✅ Added and saved",
+    zh: "✅ 已添加并保存",
+};
+pub const TODO_ADDED_UNSAVED: Msg = Msg {
+    en: "✅ Added (saving failed)",
+    zh: "✅ 已添加（保存失败）",
+};
+pub const TODO_EDIT_EMPTY: Msg = Msg {
+    en: "⚠️ The content is empty, edit cancelled",
+    zh: "⚠️ 内容为空，已取消编辑",
+};
+pub const TODO_UPDATED_SAVED: Msg = Msg {
+    en: "✅ Updated and saved",
+    zh: "✅ 已更新并保存",
+};
+pub const TODO_UPDATED_UNSAVED: Msg = Msg {
+    en: "✅ Updated (saving failed)",
+    zh: "✅ 已更新（保存失败）",
+};
+pub const TODO_DELETED: Msg = Msg {
+    en: "🗑️ Deleted: {}",
+    zh: "🗑️ 已删除: {}",
+};
+pub const TODO_FILTER_SET: Msg = Msg {
+    en: "🔍 Filter: {}",
+    zh: "🔍 过滤: {}",
+};
+pub const TODO_SAVED: Msg = Msg {
+    en: "💾 Saved",
+    zh: "💾 已保存",
+};
+pub const TODO_NOTHING_TO_SAVE: Msg = Msg {
+    en: "📋 Nothing to save, no changes",
+    zh: "📋 无需保存，没有修改",
+};
+pub const TODO_UNSAVED_QUIT: Msg = Msg {
+    en: "⚠️ There are unsaved changes! Press s to save, or type q! to quit and discard them",
+    zh: "⚠️ 有未保存的修改！请先 s 保存，或输入 q! 强制退出（丢弃修改）",
+};
+pub const TODO_COPIED: Msg = Msg {
+    en: "📋 Copied to the clipboard: {}",
+    zh: "📋 已复制到剪切板: {}",
+};
+pub const TODO_COPY_FAILED: Msg = Msg {
+    en: "❌ Failed to copy to the clipboard",
+    zh: "❌ 复制到剪切板失败",
+};
+pub const TODO_UNSAVED_INPUT: Msg = Msg {
+    en: "⚠️ The input is not saved. Save it? (Enter/y save / n discard / any other key keeps editing)",
+    zh: "⚠️ 有未保存的内容，是否保存？(Enter/y 保存 / n 放弃 / 其他键继续编辑)",
+};
+pub const TODO_CANCELLED: Msg = Msg {
+    en: "Cancelled",
+    zh: "已取消",
+};
+pub const TODO_DELETE_CANCELLED: Msg = Msg {
+    en: "Deletion cancelled",
+    zh: "已取消删除",
+};
+pub const TODO_DISCARDED: Msg = Msg {
+    en: "Discarded",
+    zh: "已放弃",
+};
+pub const TODO_DONE_REPORTED: Msg = Msg {
+    en: "✅ Marked as done, written to the report and saved",
+    zh: "✅ 已标记为完成，已写入日报并保存",
+};
+pub const TODO_DONE_REPORT_FAILED: Msg = Msg {
+    en: "✅ Marked as done, but writing the report failed",
+    zh: "✅ 已标记为完成，但写入日报失败",
+};
+pub const TODO_DONE: Msg = Msg {
+    en: "✅ Marked as done",
+    zh: "✅ 已标记为完成",
+};
+
+// ========== TUI 界面 ==========
+
+pub const TODO_TITLE: Msg = Msg {
+    en: " 📋 Todos{} — {} in total | ✅ {} | ⬜ {} ",
+    zh: " 📋 待办备忘录{} — 共 {} 条 | ✅ {} | ⬜ {} ",
+};
+pub const TODO_TITLE_UNDONE: Msg = Msg {
+    en: " [open]",
+    zh: " [未完成]",
+};
+pub const TODO_TITLE_DONE: Msg = Msg {
+    en: " [done]",
+    zh: " [已完成]",
+};
+pub const TODO_HELP_HEADING: Msg = Msg {
+    en: "  📖 Key bindings",
+    zh: "  📖 快捷键帮助",
+};
+pub const TODO_HELP_DOWN: Msg = Msg {
+    en: "Move down",
+    zh: "向下移动",
+};
+pub const TODO_HELP_UP: Msg = Msg {
+    en: "Move up",
+    zh: "向上移动",
+};
+pub const TODO_HELP_TOGGLE_KEY: Msg = Msg {
+    en: "  Space/Enter  ",
+    zh: "  空格 / 回车   ",
+};
+pub const TODO_HELP_TOGGLE: Msg = Msg {
+    en: "Toggle done [x] / [ ]",
+    zh: "切换完成状态 [x] / [ ]",
+};
+pub const TODO_HELP_ADD: Msg = Msg {
+    en: "Add a todo",
+    zh: "添加新待办",
+};
+pub const TODO_HELP_EDIT: Msg = Msg {
+    en: "Edit the selected todo",
+    zh: "编辑选中待办",
+};
+pub const TODO_HELP_DELETE: Msg = Msg {
+    en: "Delete a todo (asks first)",
+    zh: "删除待办（需确认）",
+};
+pub const TODO_HELP_FILTER: Msg = Msg {
+    en: "Cycle the filter (all / open / done)",
+    zh: "过滤切换（全部 / 未完成 / 已完成）",
+};
+pub const TODO_HELP_MOVE: Msg = Msg {
+    en: "Reorder todos (down / up)",
+    zh: "调整待办顺序（下移 / 上移）",
+};
+pub const TODO_HELP_SAVE: Msg = Msg {
+    en: "Save",
+    zh: "手动保存",
+};
+pub const TODO_HELP_COPY: Msg = Msg {
+    en: "Copy the selected todo to the clipboard",
+    zh: "复制选中待办到剪切板",
+};
+pub const TODO_HELP_QUIT: Msg = Msg {
+    en: "Quit (save first, or use q! to force quit, when there are unsaved changes)",
+    zh: "退出（有未保存修改时需先保存或用 q! 强制退出）",
+};
+pub const TODO_HELP_FORCE_QUIT: Msg = Msg {
+    en: "Force quit (discard unsaved changes)",
+    zh: "强制退出（丢弃未保存的修改）",
+};
+pub const TODO_HELP_ESC: Msg = Msg {
+    en: "Quit (same as q)",
+    zh: "退出（同 q）",
+};
+pub const TODO_HELP_CTRL_C: Msg = Msg {
+    en: "Force quit (without saving)",
+    zh: "强制退出（不保存）",
+};
+pub const TODO_HELP_HELP: Msg = Msg {
+    en: "Show this help",
+    zh: "显示此帮助",
+};
+pub const TODO_HELP_INPUT: Msg = Msg {
+    en: "  While adding or editing:",
+    zh: "  添加/编辑模式下：",
+};
+pub const TODO_HELP_PREVIEW: Msg = Msg {
+    en: "Scroll the preview (for long input)",
+    zh: "预览区滚动（长文本输入时）",
+};
+pub const TODO_HELP_TITLE: Msg = Msg {
+    en: " Help ",
+    zh: " 帮助 ",
+};
+pub const TODO_LIST_TITLE: Msg = Msg {
+    en: " Todos ",
+    zh: " 待办列表 ",
+};
+pub const TODO_LIST_EMPTY: Msg = Msg {
+    en: "   (empty) press a to add a todo...",
+    zh: "   (空) 按 a 添加新待办...",
+};
+pub const TODO_MODE_ADDING: Msg = Msg {
+    en: "New todo",
+    zh: "新待办",
+};
+pub const TODO_MODE_EDITING: Msg = Msg {
+    en: "Editing",
+    zh: "编辑中",
+};
+pub const TODO_MODE_PREVIEW: Msg = Msg {
+    en: "Preview",
+    zh: "预览",
+};
+pub const TODO_PREVIEW_SCROLL: Msg = Msg {
+    en: " 📖 {} preview [{}/{} lines] Alt+↓/↑ to scroll ",
+    zh: " 📖 {} 预览 [{}/{}行] Alt+↓/↑滚动 ",
+};
+pub const TODO_PREVIEW: Msg = Msg {
+    en: " 📖 {} preview ",
+    zh: " 📖 {} 预览 ",
+};
+pub const TODO_INPUT_NEW: Msg = Msg {
+    en: " New todo: ",
+    zh: " 新待办: ",
+};
+pub const TODO_INPUT_ADD_TITLE: Msg = Msg {
+    en: " Add (Enter confirm / Esc cancel / ←→ move the cursor) ",
+    zh: " 添加模式 (Enter 确认 / Esc 取消 / ←→ 移动光标) ",
+};
+pub const TODO_INPUT_EDIT: Msg = Msg {
+    en: " Edit: ",
+    zh: " 编辑: ",
+};
+pub const TODO_INPUT_EDIT_TITLE: Msg = Msg {
+    en: " Edit (Enter confirm / Esc cancel / ←→ move the cursor) ",
+    zh: " 编辑模式 (Enter 确认 / Esc 取消 / ←→ 移动光标) ",
+};
+pub const TODO_CONFIRM_DELETE: Msg = Msg {
+    en: " Delete \"{}\"? (y confirm / n cancel)",
+    zh: " 确认删除「{}」？(y 确认 / n 取消)",
+};
+pub const TODO_NO_SELECTION: Msg = Msg {
+    en: " Nothing is selected",
+    zh: " 没有选中的项目",
+};
+pub const TODO_CONFIRM_DELETE_TITLE: Msg = Msg {
+    en: " ⚠️ Confirm deletion ",
+    zh: " ⚠️ 确认删除 ",
+};
+pub const TODO_REPORT_PREFIX: Msg = Msg {
+    en: " Write to the report: \"",
+    zh: " 写入日报: \"",
+};
+pub const TODO_REPORT_SUFFIX: Msg = Msg {
+    en: "\"? (Enter/y write, anything else skips)",
+    zh: "\" ？ (Enter/y 写入, 其他跳过)",
+};
+pub const TODO_REPORT_NOTHING: Msg = Msg {
+    en: " Nothing to write",
+    zh: " 没有待写入的内容",
+};
+pub const TODO_REPORT_TITLE: Msg = Msg {
+    en: " 📝 Write to the report ",
+    zh: " 📝 写入日报 ",
+};
+pub const TODO_SAVE_PREFIX: Msg = Msg {
+    en: " ⚠️ Save? Current input: \"",
+    zh: " ⚠️ 是否保存？当前输入: \"",
+};
+pub const TODO_SAVE_SUFFIX: Msg = Msg {
+    en: "\" (Enter/y save / n/Esc discard / any other key keeps editing)",
+    zh: "\" (Enter/y 保存 / n/Esc 放弃 / 其他键继续编辑)",
+};
+pub const TODO_UNSAVED_TITLE: Msg = Msg {
+    en: " ⚠️ Unsaved input ",
+    zh: " ⚠️ 未保存的内容 ",
+};
+pub const TODO_STATUS_HINT: Msg = Msg {
+    en: "Press ? for the full help",
+    zh: "按 ? 查看完整帮助",
+};
+pub const TODO_DIRTY: Msg = Msg {
+    en: " [unsaved]",
+    zh: " [未保存]",
+};
+pub const TODO_KEYS_NORMAL: Msg = Msg {
+    en: " n/↓ down | N/↑ up | Space/Enter toggle | a add | e edit | d delete | y copy | f filter | s save | ? help | q quit",
+    zh: " n/↓ 下移 | N/↑ 上移 | 空格/回车 切换完成 | a 添加 | e 编辑 | d 删除 | y 复制 | f 过滤 | s 保存 | ? 帮助 | q 退出",
+};
+pub const TODO_KEYS_INPUT: Msg = Msg {
+    en: " Enter confirm | Esc cancel | ←→ move the cursor | Home/End line start/end | Alt+↓/↑ scroll the preview",
+    zh: " Enter 确认 | Esc 取消 | ←→ 移动光标 | Home/End 行首尾 | Alt+↓/↑ 预览滚动",
+};
+pub const TODO_KEYS_CONFIRM_DELETE: Msg = Msg {
+    en: " y delete | n/Esc cancel",
+    zh: " y 确认删除 | n/Esc 取消",
+};
+pub const TODO_KEYS_CONFIRM_REPORT: Msg = Msg {
+    en: " Enter/y write to the report and save | any other key skips",
+    zh: " Enter/y 写入日报并保存 | 其他键 跳过",
+};
+pub const TODO_KEYS_CONFIRM_CANCEL: Msg = Msg {
+    en: " Enter/y save | n/Esc discard | any other key keeps editing",
+    zh: " Enter/y 保存 | n/Esc 放弃 | 其他键 继续编辑",
+};
+pub const TODO_KEYS_HELP: Msg = Msg {
+    en: " Press any key to go back",
+    zh: " 按任意键返回",
+};
@@ -0,0 +1,204 @@
+//! voice 命令的文案
+
+use super::Msg;
+
+pub const VOICE_RAW_MODE_FAILED: Msg = Msg {
+    en: "failed to enable raw mode: {}",
+    zh: "启用 raw mode 失败: {}",
+};
+pub const VOICE_NO_MICROPHONE: Msg = Msg {
+    en: "no microphone found, check the audio input device",
+    zh: "未找到麦克风设备，请检查音频输入设备",
+};
+pub const VOICE_INPUT_CONFIG_FAILED: Msg = Msg {
+    en: "failed to get the default input config of the device: {}",
+    zh: "获取设备默认输入配置失败: {}",
+};
+pub const VOICE_STREAM_ERROR: Msg = Msg {
+    en: "recording stream error: {}",
+    zh: "录音流错误: {}",
+};
+pub const VOICE_STREAM_CREATE_FAILED: Msg = Msg {
+    en: "failed to create the recording stream: {}",
+    zh: "创建录音流失败: {}",
+};
+pub const VOICE_RECORD_START_FAILED: Msg = Msg {
+    en: "failed to start recording: {}",
+    zh: "启动录音失败: {}",
+};
+pub const VOICE_AUDIO_EMPTY: Msg = Msg {
+    en: "the audio data is empty",
+    zh: "音频数据为空",
+};
+pub const VOICE_MODEL_LOAD_FAILED: Msg = Msg {
+    en: "failed to load the Whisper model: {}",
+    zh: "加载 Whisper 模型失败: {}",
+};
+pub const VOICE_STATE_FAILED: Msg = Msg {
+    en: "failed to create the Whisper state: {}",
+    zh: "创建 Whisper 状态失败: {}",
+};
+pub const VOICE_WHISPER_FAILED: Msg = Msg {
+    en: "Whisper transcription failed: {}",
+    zh: "Whisper 转写失败: {}",
+};
+pub const VOICE_NOTHING_RECORDED: Msg = Msg {
+    en: "no audio was recorded",
+    zh: "未录到任何音频数据",
+};
+pub const VOICE_DURATION_DEVICE: Msg = Msg {
+    en: "📊 recorded {}s (device: {}Hz {}ch → 16kHz mono)",
+    zh: "📊 录音时长: {}s (设备: {}Hz {}ch → 16kHz 单声道)",
+};
+pub const VOICE_TOO_SHORT: Msg = Msg {
+    en: "the recording is too short",
+    zh: "录音时间过短",
+};
+pub const VOICE_MODEL_DETECTED: Msg = Msg {
+    en: "🔍 detected model: {}",
+    zh: "🔍 自动检测到模型: {}",
+};
+pub const VOICE_BAD_MODEL: Msg = Msg {
+    en: "unsupported model size: {}, expected one of: {}",
+    zh: "不支持的模型大小: {}，可选: {}",
+};
+pub const VOICE_UNKNOWN_ACTION: Msg = Msg {
+    en: "unknown action: {}, available actions: download",
+    zh: "未知操作: {}，可用操作: download",
+};
+pub const VOICE_USAGE: Msg = Msg {
+    en: "voice [-c] [-m <model>] | voice download [-m <model>]",
+    zh: "voice [-c] [-m <model>] 或 voice download [-m <model>]",
+};
+pub const VOICE_MODEL_MISSING: Msg = Msg {
+    en: "model file does not exist: {}",
+    zh: "模型文件不存在: {}",
+};
+pub const VOICE_DOWNLOAD_HINT_EITHER: Msg = Msg {
+    en: "💡 download a model first: {} or {}",
+    zh: "💡 请先下载模型: {} 或 {}",
+};
+pub const VOICE_MANUAL_HINT: Msg = Msg {
+    en: "💡 or download a model by hand to {}",
+    zh: "💡 也可以手动下载模型放到: {}",
+};
+pub const VOICE_MODEL_INCOMPLETE: Msg = Msg {
+    en: "model file is incomplete: {} ({} MB, expected at least {} MB)",
+    zh: "模型文件不完整: {} ({} MB，期望至少 {} MB)",
+};
+pub const VOICE_REDOWNLOAD_HINT: Msg = Msg {
+    en: "💡 delete it and download again: {} && {}",
+    zh: "💡 请删除后重新下载: {} && {}",
+};
+pub const VOICE_PRESS_TO_START: Msg = Msg {
+    en: "🎙️  press {} to start recording, {} or {} to stop",
+    zh: "🎙️  按 {} 开始录音，录音中按 {} 或 {} 结束",
+};
+pub const VOICE_KEY_ENTER: Msg = Msg {
+    en: "Enter",
+    zh: "回车",
+};
+pub const VOICE_RECORDING_STOP: Msg = Msg {
+    en: "🔴 recording... press {} or {} to stop recording",
+    zh: "🔴 录音中... 按 {} 或 {} 结束录音",
+};
+pub const VOICE_NO_SPEECH: Msg = Msg {
+    en: "⚠️  no speech recognized",
+    zh: "⚠️  未识别到语音内容",
+};
+pub const VOICE_RESULT: Msg = Msg {
+    en: "📝 transcript:",
+    zh: "📝 转写结果:",
+};
+pub const VOICE_DOWNLOAD_HINT: Msg = Msg {
+    en: "💡 download a model first: {}",
+    zh: "💡 请先下载模型: {}",
+};
+pub const VOICE_MODEL_INCOMPLETE_SHORT: Msg = Msg {
+    en: "the model file is incomplete, download it again",
+    zh: "模型文件不完整，请重新下载",
+};
+pub const VOICE_RECORDING: Msg = Msg {
+    en: "🔴 recording... press {} or {} to stop",
+    zh: "🔴 录音中... 按 {} 或 {} 结束",
+};
+pub const VOICE_NO_AUDIO: Msg = Msg {
+    en: "⚠️  no audio recorded",
+    zh: "⚠️  未录到音频数据",
+};
+pub const VOICE_DURATION: Msg = Msg {
+    en: "📊 recorded {}s",
+    zh: "📊 录音时长: {}s",
+};
+pub const VOICE_TOO_SHORT_WARN: Msg = Msg {
+    en: "⚠️  the recording is too short",
+    zh: "⚠️  录音时间过短",
+};
+pub const VOICE_TRANSCRIBING: Msg = Msg {
+    en: "✅ transcribing...",
+    zh: "✅ 转写中...",
+};
+pub const VOICE_TRANSCRIBE_FAILED: Msg = Msg {
+    en: "[voice] transcription failed: {}",
+    zh: "[voice] 转写失败: {}",
+};
+pub const VOICE_MODEL_INCOMPLETE_WARN: Msg = Msg {
+    en: "⚠️  model file is incomplete: {} ({} MB, expected at least {} MB)",
+    zh: "⚠️  模型文件不完整: {} ({} MB，期望至少 {} MB)",
+};
+pub const VOICE_REDOWNLOADING: Msg = Msg {
+    en: "🔄 deleting the incomplete file and downloading again...",
+    zh: "🔄 删除不完整文件，重新下载...",
+};
+pub const VOICE_MODEL_EXISTS: Msg = Msg {
+    en: "✅ model already exists: {} ({} MB)",
+    zh: "✅ 模型已存在: {} ({} MB)",
+};
+pub const VOICE_DELETE_TO_REDOWNLOAD: Msg = Msg {
+    en: "💡 delete the model file first to download it again",
+    zh: "💡 如需重新下载，请先删除模型文件",
+};
+pub const VOICE_DOWNLOADING: Msg = Msg {
+    en: "📥 downloading the Whisper {} model...",
+    zh: "📥 下载 Whisper {} 模型...",
+};
+pub const VOICE_SAVE_TO: Msg = Msg {
+    en: "   saving to: {}",
+    zh: "   保存到: {}",
+};
+pub const VOICE_DOWNLOAD_INCOMPLETE: Msg = Msg {
+    en: "the downloaded file is incomplete ({} MB, expected at least {} MB)",
+    zh: "下载的文件不完整 ({} MB，期望至少 {} MB)",
+};
+pub const VOICE_DOWNLOAD_MANUAL: Msg = Msg {
+    en: "check the network, or download the model file by hand to {}",
+    zh: "请检查网络连接，或手动下载模型文件到: {}",
+};
+pub const VOICE_DOWNLOAD_URL: Msg = Msg {
+    en: "download link: {}",
+    zh: "手动下载链接: {}",
+};
+pub const VOICE_DOWNLOADED: Msg = Msg {
+    en: "✅ model downloaded: {} ({} MB)",
+    zh: "✅ 模型下载完成: {} ({} MB)",
+};
+pub const VOICE_DOWNLOAD_FAILED: Msg = Msg {
+    en: "model download failed, check the network",
+    zh: "模型下载失败，请检查网络连接",
+};
+pub const VOICE_CURL_FAILED: Msg = Msg {
+    en: "[download_model] failed to run curl: {}, make sure curl is installed",
+    zh: "[download_model] 执行 curl 失败: {}，请确保系统安装了 curl",
+};
+pub const VOICE_PBCOPY_ERROR: Msg = Msg {
+    en: "[copy_to_clipboard] cannot run pbcopy: {}",
+    zh: "[copy_to_clipboard] 无法调用 pbcopy: {}",
+};
+pub const VOICE_COPIED: Msg = Msg {
+    en: "📋 copied to the clipboard",
+    zh: "📋 已复制到剪贴板",
+};
+pub const VOICE_PBCOPY_FAILED: Msg = Msg {
+    en: "[copy_to_clipboard] pbcopy failed: {}",
+    zh: "[copy_to_clipboard] pbcopy 执行失败: {}",
+};
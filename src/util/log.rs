@@ -1,8 +1,10 @@
-use crate::constants::{AGENT_DIR, AGENT_LOG_DIR, DATA_DIR};
+use crate::constants::{AGENT_DIR, AGENT_LOG_DIR};
+use crate::t;
+use crate::util::i18n::msg;
 use chrono::Local;
 use std::fs::{self, OpenOptions};
 use std::io::Write;
-use std::path::PathBuf;
+use std::sync::atomic::{AtomicI32, Ordering};
 
 /// 打印普通信息
 #[macro_export]
@@ -12,23 +14,27 @@ macro_rules! info {
     }};
 }
 
-/// 打印错误信息
+/// 打印错误信息（输出到 stderr），并让进程最终以 1 退出；开启文件日志时同时记入日志
 #[macro_export]
 macro_rules! error {
     ($($arg:tt)*) => {{
         use colored::Colorize;
+        $crate::util::log::set_exit_code($crate::util::log::EXIT_FAILURE);
+        let message = format!($($arg)*);
+        $crate::util::filelog::error(&message);
         eprint!("{}", "[ERROR] ".red());
-        eprintln!($($arg)*)
+        eprintln!("{}", message)
     }};
 }
 
-/// 打印 usage 提示
+/// 打印 usage 提示（参数有误，输出到 stderr），并让进程最终以 2 退出
 #[macro_export]
 macro_rules! usage {
     ($($arg:tt)*) => {{
         use colored::Colorize;
-        print!("{}", "💡 Usage: ".green());
-        println!($($arg)*)
+        $crate::util::log::set_exit_code($crate::util::log::EXIT_USAGE);
+        eprint!("{}", "💡 Usage: ".green());
+        eprintln!($($arg)*)
     }};
 }
 
@@ -42,6 +48,24 @@ macro_rules! debug_log {
     }};
 }
 
+/// 执行失败时的退出码
+pub const EXIT_FAILURE: i32 = 1;
+/// 参数有误时的退出码
+pub const EXIT_USAGE: i32 = 2;
+
+/// 命令执行过程中记下的退出码，多次失败时取最大值
+static EXIT_CODE: AtomicI32 = AtomicI32::new(0);
+
+/// 记下失败：命令行模式下 main 结束时以记下的退出码退出，便于脚本判断是否成功
+pub fn set_exit_code(code: i32) {
+    EXIT_CODE.fetch_max(code, Ordering::Relaxed);
+}
+
+/// 目前记下的退出码（没有失败时为 0）
+pub fn exit_code() -> i32 {
+    EXIT_CODE.load(Ordering::Relaxed)
+}
+
 /// 打印分隔线
 #[allow(dead_code)]
 pub fn print_line() {
@@ -60,14 +84,12 @@ pub fn capitalize_first_letter(s: &str) -> String {
 /// 写入信息日志到文件
 /// 日志文件位置：~/.jdata/agent/logs/info.log
 pub fn write_info_log(context: &str, content: &str) {
-    let log_dir = dirs::home_dir()
-        .unwrap_or_else(|| PathBuf::from("."))
-        .join(DATA_DIR)
+    let log_dir = crate::config::YamlConfig::data_dir()
         .join(AGENT_DIR)
         .join(AGENT_LOG_DIR);
 
     if let Err(e) = fs::create_dir_all(&log_dir) {
-        eprintln!("无法创建日志目录: {}", e);
+        eprintln!("{}", t!(msg::LOG_MKDIR_FAILED, e));
         return;
     }
 
@@ -81,11 +103,11 @@ pub fn write_info_log(context: &str, content: &str) {
                 timestamp, context, content
             );
             if let Err(e) = file.write_all(log_entry.as_bytes()) {
-                eprintln!("写入信息日志失败: {}", e);
+                eprintln!("{}", t!(msg::LOG_INFO_WRITE_FAILED, e));
             }
         }
         Err(e) => {
-            eprintln!("无法打开信息日志文件: {}", e);
+            eprintln!("{}", t!(msg::LOG_INFO_OPEN_FAILED, e));
         }
     }
 }
@@ -93,15 +115,13 @@ pub fn write_info_log(context: &str, content: &str) {
 /// 写入错误日志到文件
 /// 日志文件位置：~/.jdata/agent/logs/error.log
 pub fn write_error_log(context: &str, error: &str) {
-    let log_dir = dirs::home_dir()
-        .unwrap_or_else(|| PathBuf::from("."))
-        .join(DATA_DIR)
+    let log_dir = crate::config::YamlConfig::data_dir()
         .join(AGENT_DIR)
         .join(AGENT_LOG_DIR);
 
     // 创建日志目录
     if let Err(e) = fs::create_dir_all(&log_dir) {
-        eprintln!("无法创建日志目录: {}", e);
+        eprintln!("{}", t!(msg::LOG_MKDIR_FAILED, e));
         return;
     }
 
@@ -111,16 +131,13 @@ pub fn write_error_log(context: &str, error: &str) {
     match OpenOptions::new().create(true).append(true).open(&log_file) {
         Ok(mut file) => {
             let timestamp = Local::now().format("%Y-%m-%d %H:%M:%S");
-            let log_entry = format!(
-                "\n========================================\n[{}] {}\n错误详情:\n{}\n",
-                timestamp, context, error
-            );
+            let log_entry = t!(msg::LOG_ERROR_ENTRY, timestamp, context, error);
             if let Err(e) = file.write_all(log_entry.as_bytes()) {
-                eprintln!("写入错误日志失败: {}", e);
+                eprintln!("{}", t!(msg::LOG_ERROR_WRITE_FAILED, e));
             }
         }
         Err(e) => {
-            eprintln!("无法打开错误日志文件: {}", e);
+            eprintln!("{}", t!(msg::LOG_ERROR_OPEN_FAILED, e));
         }
     }
 }
@@ -34,8 +34,8 @@ fn md_render_path() -> Option<std::path::PathBuf> {
         // 从统一资源模块获取二进制
         let binary_data = crate::assets::MD_RENDER_BINARY;
 
-        let data_dir = crate::config::YamlConfig::data_dir();
-        let bin_dir = data_dir.join("bin");
+        // 插件二进制放在数据根目录下，各 profile 共用
+        let bin_dir = crate::config::profile::root_dir().join(crate::constants::BIN_DIR);
         let ask_path = bin_dir.join("md_render");
 
         if ask_path.exists() {
@@ -96,6 +96,12 @@ pub fn render_md(text: &str) {
         }
     }
 
+    // 无障碍模式下不用 termimad 的颜色与框线，原样输出
+    if crate::util::accessible::enabled() {
+        println!("{}", text.trim_end());
+        return;
+    }
+
     // fallback 到 termimad
     termimad::print_text(text);
 }
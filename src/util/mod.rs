@@ -1,4 +1,9 @@
+pub mod accessible;
+pub mod color;
+pub mod file_lock;
+pub mod filelog;
 pub mod fuzzy;
+pub mod i18n;
 pub mod log;
 pub mod md_render;
 